@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:categories=nginx-gateway-fabric
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Backend is a backend for HTTPRoutes and GRPCRoutes that is defined by a static list of endpoints
+// instead of a Kubernetes Service. It is meant for edge deployments where some upstreams
+// run outside of the cluster.
+type Backend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the Backend.
+	Spec BackendSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackendList contains a list of Backends.
+type BackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Backend `json:"items"`
+}
+
+// BackendSpec defines the desired state of the Backend.
+type BackendSpec struct {
+	// Endpoints is the list of endpoints of the Backend. Requests are load balanced across the endpoints.
+	// Endpoints with a DNS name are resolved by NGINX, so they require a DNS resolver to be configured
+	// in the NginxProxy of the Gateway.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	Endpoints []BackendEndpoint `json:"endpoints"`
+}
+
+// BackendEndpoint is an endpoint of a Backend.
+type BackendEndpoint struct {
+	// Address is the IP address or the DNS name of the endpoint.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[0-9a-zA-Z.:\-]+$`
+	Address string `json:"address"`
+
+	// Port is the port of the endpoint.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
@@ -37,6 +37,23 @@ type ClientSettingsPolicyList struct {
 
 // ClientSettingsPolicySpec defines the desired state of ClientSettingsPolicy.
 type ClientSettingsPolicySpec struct {
+	// Access restricts the access to the targeted Route by time windows and client attributes, for example,
+	// to lock an internal tool outside business hours. A request is allowed only if it matches all the
+	// specified conditions.
+	// Access can only be set when the policy targets an HTTPRoute or a GRPCRoute.
+	//
+	// +optional
+	Access *ClientAccess `json:"access,omitempty"`
+
+	// Authentication requires the clients of the targeted Route to authenticate, for example, with the token
+	// of a Kubernetes ServiceAccount, so that only the workloads in the cluster can call an internal Route
+	// without a separate authentication proxy. The requests that don't authenticate are rejected with
+	// 401 (Unauthorized), and the requests of the clients that aren't allowed are rejected with 403 (Forbidden).
+	// Authentication can only be set when the policy targets an HTTPRoute or a GRPCRoute.
+	//
+	// +optional
+	Authentication *ClientAuthentication `json:"authentication,omitempty"`
+
 	// Body defines the client request body settings.
 	//
 	// +optional
@@ -47,6 +64,34 @@ type ClientSettingsPolicySpec struct {
 	// +optional
 	KeepAlive *ClientKeepAlive `json:"keepAlive,omitempty"`
 
+	// Compression defines how the compressed responses of the backends are served to the clients.
+	//
+	// +optional
+	Compression *ClientCompression `json:"compression,omitempty"`
+
+	// ErrorResponses defines the responses to the client requests that are rejected because the request body
+	// or the request headers are too large, instead of the HTML error pages of NGINX.
+	// ErrorResponses can only be set when the policy targets a Gateway.
+	//
+	// +optional
+	ErrorResponses *ClientErrorResponses `json:"errorResponses,omitempty"`
+
+	// RequestLimits limits the concurrent requests and the request rate of the targeted Route, for example,
+	// to protect a login endpoint against brute-force attacks. The requests are counted separately from the other
+	// Routes and from any limits of the Gateway.
+	// RequestLimits can only be set when the policy targets an HTTPRoute or a GRPCRoute.
+	//
+	// +optional
+	RequestLimits *ClientRequestLimits `json:"requestLimits,omitempty"`
+
+	// Tarpit responds to the requests for decoy paths, for example, /wp-admin, with a deliberate delay, to slow
+	// down the clients that scan the Gateway for vulnerable applications. NGINX logs the IP address of every
+	// such client, so that the client can be blocked downstream.
+	// Tarpit can only be set when the policy targets a Gateway.
+	//
+	// +optional
+	Tarpit *ClientTarpit `json:"tarpit,omitempty"`
+
 	// TargetRef identifies an API object to apply the policy to.
 	// Object must be in the same namespace as the policy.
 	// Support: Gateway, HTTPRoute, GRPCRoute.
@@ -57,6 +102,150 @@ type ClientSettingsPolicySpec struct {
 	TargetRef gatewayv1.LocalPolicyTargetReference `json:"targetRef"`
 }
 
+// ClientAccess defines the conditions of the requests that are allowed to the targeted Route.
+// The requests that don't match the conditions are rejected with the DenyCode.
+//
+// +kubebuilder:validation:XValidation:message="windows, cidrs, or header must be specified",rule="has(self.windows) || has(self.cidrs) || has(self.header)"
+//
+//nolint:lll
+type ClientAccess struct {
+	// Windows are the time windows when the requests are allowed. A request is allowed if it is in any
+	// of the windows. The times are in UTC.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Windows []AccessWindow `json:"windows,omitempty"`
+
+	// CIDRs are the IP address ranges of the clients that are allowed, for example, 10.0.0.0/8.
+	// The client IP address is the address that NGINX sees, after any RewriteClientIP settings of the NginxProxy.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=set
+	CIDRs []string `json:"cidrs,omitempty"`
+
+	// Header is the request header that the allowed requests must have.
+	//
+	// +optional
+	Header *AccessHeader `json:"header,omitempty"`
+
+	// DenyCode is the status code of the responses to the requests that are not allowed.
+	// Default: 403.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=400
+	// +kubebuilder:validation:Maximum=599
+	DenyCode *int32 `json:"denyCode,omitempty"`
+}
+
+// AccessWindow is a time window when the requests are allowed.
+//
+// +kubebuilder:validation:XValidation:message="end must be after start",rule="self.end > self.start"
+type AccessWindow struct {
+	// Days are the days of the week of the window. If not specified, the window applies to every day.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=7
+	// +listType=set
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start is the start of the window, in the HH:MM format. Start is included in the window.
+	Start AccessTime `json:"start"`
+
+	// End is the end of the window, in the HH:MM format. End is not included in the window.
+	// Use 24:00 for the end of the day.
+	End AccessTime `json:"end"`
+}
+
+// AccessHeader defines a request header and its allowed values.
+type AccessHeader struct {
+	// Name is the name of the request header, for example, `X-Team`.
+	// The name is case-insensitive.
+	//
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-]+$`
+	Name string `json:"name"`
+
+	// Values are the allowed values of the header. The values are matched exactly and are case-sensitive.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=set
+	Values []AccessHeaderValue `json:"values"`
+}
+
+// ClientAuthentication defines how the clients of a Route authenticate.
+//
+// +kubebuilder:validation:XValidation:message="remote must be specified if and only if mode is Remote",rule="(self.mode == 'Remote') == has(self.remote)"
+// +kubebuilder:validation:XValidation:message="tokenReview can only be specified if mode is TokenReview",rule="!(has(self.tokenReview) && self.mode != 'TokenReview')"
+//
+//nolint:lll
+type ClientAuthentication struct {
+	// Mode is the mode of the authentication.
+	Mode ClientAuthenticationMode `json:"mode"`
+
+	// TokenReview configures the TokenReview mode.
+	//
+	// +optional
+	TokenReview *TokenReviewAuthentication `json:"tokenReview,omitempty"`
+
+	// Remote configures the Remote mode.
+	//
+	// +optional
+	Remote *RemoteAuthentication `json:"remote,omitempty"`
+}
+
+// RemoteAuthentication defines the remote endpoint that authenticates the requests in the Remote mode.
+// NGINX sends a GET request with the headers of the client request, without the body, to the endpoint, with the
+// X-Original-URI and X-Original-Method headers set to the URI and the method of the client request.
+// A 2xx response allows the request, a 401 or 403 response rejects it with the same status code, and any other
+// response or an error rejects it with 500 (Internal Server Error).
+//
+// NGINX resolves the hostname of the endpoint with the dnsResolver of the NginxProxy every time the cached address
+// expires, so that the endpoint can move, for example, behind a cloud load balancer. The policy is not accepted
+// for a Gateway whose NginxProxy has no dnsResolver.
+type RemoteAuthentication struct {
+	// URL is the URL of the endpoint, for example, https://auth.example.com/verify.
+	// The certificate of an HTTPS endpoint is verified with the system CA certificates of NGINX.
+	//
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://[^\s"'$;{}\\]+$`
+	URL string `json:"url"`
+
+	// Timeout is the timeout of the connection to the endpoint and of the response of the endpoint.
+	// Default: 5s.
+	//
+	// +optional
+	Timeout *Duration `json:"timeout,omitempty"`
+
+	// CacheDuration is the time for which NGINX reuses a 2xx response of the endpoint for the requests with the
+	// same Authorization header, instead of sending them to the endpoint.
+	// If not specified, the responses are not cached.
+	//
+	// +optional
+	CacheDuration *Duration `json:"cacheDuration,omitempty"`
+}
+
+// TokenReviewAuthentication defines the tokens that are accepted in the TokenReview mode.
+type TokenReviewAuthentication struct {
+	// Audiences are the audiences of the tokens, for example, the audience of the projected ServiceAccount tokens
+	// of the clients. A token is accepted if it is valid for any of the audiences.
+	// If not specified, the tokens are reviewed for the audiences of the Kubernetes API server.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=set
+	Audiences []string `json:"audiences,omitempty"`
+
+	// ServiceAccounts are the ServiceAccounts of the clients that are allowed, in the <namespace>/<name> format,
+	// for example, default/reporting. If not specified, the clients of any ServiceAccount are allowed.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=set
+	ServiceAccounts []ServiceAccountName `json:"serviceAccounts,omitempty"`
+}
+
 // ClientBody contains the settings for the client request body.
 type ClientBody struct {
 	// MaxSize sets the maximum allowed size of the client request body.
@@ -78,6 +267,154 @@ type ClientBody struct {
 	Timeout *Duration `json:"timeout,omitempty"`
 }
 
+// ClientCompression defines how the compressed responses of the backends are served to the clients.
+type ClientCompression struct {
+	// Precompressed enables serving the precompressed variants of the responses of the backends, for example,
+	// the static assets that a backend stores compressed with gzip or Brotli. NGINX passes the Accept-Encoding
+	// header of the request to the backend, so that the backend selects the variant that the client accepts.
+	// If the backend returns a gzip variant to a client that doesn't accept gzip, NGINX decompresses it.
+	// The responses include the "Vary: Accept-Encoding" header, so that caches store the variants separately.
+	// Default: https://nginx.org/en/docs/http/ngx_http_gunzip_module.html#gunzip.
+	//
+	// +optional
+	Precompressed bool `json:"precompressed,omitempty"`
+}
+
+// ClientErrorResponses defines the responses to the client requests that NGINX rejects because they are too large:
+// 413 (Content Too Large) when the request body exceeds the maximum size, and
+// 431 (Request Header Fields Too Large) when the request headers don't fit in the buffers of NGINX.
+// Every response includes the ID of the request in the X-Request-ID header, so that the response can be
+// correlated with the logs.
+type ClientErrorResponses struct {
+	// ContentType is the content type of the error responses.
+	// Default: application/json.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=128
+	ContentType *string `json:"contentType,omitempty"`
+
+	// Template is the body of the error responses. The following variables can be used in the template:
+	// $status is the status code, $reason is the reason phrase of the status code,
+	// and $request_id is the ID of the request. Other variables are not allowed.
+	// Default: {"status":$status,"error":"$reason","requestId":"$request_id"}.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	Template *string `json:"template,omitempty"`
+}
+
+// ClientRequestLimits defines the limits of the requests to a Route.
+// The requests are counted in the shared memory zones of the policy, named
+// csp_conn_<namespace>_<name> and csp_req_<namespace>_<name>. If the policy targets several Routes, the Routes
+// share the limits. NGINX Plus reports the counters of the zones in its API.
+// The requests over a limit are rejected with the RejectCode and, if specified, the RejectResponse.
+//
+// +kubebuilder:validation:XValidation:message="maxConcurrentRequests or rate must be specified",rule="has(self.maxConcurrentRequests) || has(self.rate)"
+// +kubebuilder:validation:XValidation:message="burst can only be specified if rate is specified",rule="!(has(self.burst) && !has(self.rate))"
+//
+//nolint:lll
+type ClientRequestLimits struct {
+	// MaxConcurrentRequests is the maximum number of requests that are processed at the same time.
+	// Directive: https://nginx.org/en/docs/http/ngx_http_limit_conn_module.html#limit_conn.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
+
+	// Rate is the maximum rate of the requests, in requests per second (r/s) or requests per minute (r/m).
+	// Directive: https://nginx.org/en/docs/http/ngx_http_limit_req_module.html#limit_req_zone.
+	//
+	// +optional
+	Rate *Rate `json:"rate,omitempty"`
+
+	// Burst is the number of the requests over the Rate that are delayed instead of rejected.
+	// Default: 0.
+	// Directive: https://nginx.org/en/docs/http/ngx_http_limit_req_module.html#limit_req.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Burst *int32 `json:"burst,omitempty"`
+
+	// RejectCode is the status code of the responses to the rejected requests.
+	// Default: 429.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=400
+	// +kubebuilder:validation:Maximum=599
+	RejectCode *int32 `json:"rejectCode,omitempty"`
+
+	// RejectResponse is the response to the rejected requests, instead of the HTML error page of NGINX.
+	//
+	// +optional
+	RejectResponse *ClientRejectResponse `json:"rejectResponse,omitempty"`
+
+	// PerClient counts the requests of every client IP address separately, so that the limits apply to every
+	// client instead of all the clients of the Route together.
+	//
+	// +optional
+	PerClient bool `json:"perClient,omitempty"`
+}
+
+// ClientRejectResponse defines the response to the requests that are rejected because they are over a limit.
+// The response has the RejectCode of the limits.
+// NGINX uses the RejectResponse instead of the error pages of the Gateway for the Route, so any ErrorResponses
+// of a policy that targets the Gateway don't apply to the Route.
+//
+// +kubebuilder:validation:XValidation:message="retryAfter or body must be specified",rule="has(self.retryAfter) || has(self.body)"
+// +kubebuilder:validation:XValidation:message="contentType can only be specified if body is specified",rule="!(has(self.contentType) && !has(self.body))"
+//
+//nolint:lll
+type ClientRejectResponse struct {
+	// RetryAfter is the value of the Retry-After header of the response, the time after which the client can retry
+	// the request. The time is rounded up to whole seconds. If not specified, the response has no Retry-After header.
+	//
+	// +optional
+	RetryAfter *Duration `json:"retryAfter,omitempty"`
+
+	// ContentType is the content type of the Body.
+	// Default: text/plain.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=128
+	ContentType *string `json:"contentType,omitempty"`
+
+	// Body is the body of the response. The body can't contain the '$' character.
+	// If not specified, the response has the HTML error page of NGINX.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=4096
+	Body *string `json:"body,omitempty"`
+}
+
+// ClientTarpit defines the decoy paths of a Gateway and the responses to the requests for them.
+// NGINX logs every request for a decoy path in the error log at the warn level, with the "tarpit:" prefix
+// and the IP address of the client, for example, for fail2ban.
+// A Route that matches a decoy path exactly still receives the requests for the path.
+type ClientTarpit struct {
+	// Paths are the decoy paths. A decoy path matches the requests for the path and for the paths under it.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=set
+	Paths []TarpitPath `json:"paths"`
+
+	// Delay is the time that NGINX waits before it responds to a request for a decoy path.
+	// The maximum is 10m.
+	// Default: 30s.
+	//
+	// +optional
+	Delay *Duration `json:"delay,omitempty"`
+
+	// StatusCode is the status code of the responses to the requests for the decoy paths.
+	// Default: 403.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	StatusCode *int32 `json:"statusCode,omitempty"`
+}
+
 // ClientKeepAlive defines the keep-alive settings for clients.
 type ClientKeepAlive struct {
 	// Requests sets the maximum number of requests that can be served through one keep-alive connection.
@@ -128,3 +465,79 @@ type ClientKeepAliveTimeout struct {
 //
 // +kubebuilder:validation:Pattern=`^\d{1,4}(k|m|g)?$`
 type Size string
+
+// Rate is a string value representing a rate of requests. Rate can be specified in requests per second (r/s)
+// or requests per minute (r/m).
+// Examples: 10r/s, 30r/m.
+//
+// +kubebuilder:validation:Pattern=`^\d{1,6}r/[sm]$`
+type Rate string
+
+// TarpitPath is a decoy path. TarpitPath must start with a slash and can contain only letters, digits,
+// and the following characters: '.', '_', '~', '/', '-'. TarpitPath can't be the root path.
+// Examples: /wp-admin, /.env.
+//
+// +kubebuilder:validation:MaxLength=256
+// +kubebuilder:validation:Pattern=`^/[A-Za-z0-9._~-][A-Za-z0-9._~/-]*$`
+type TarpitPath string
+
+// ClientAuthenticationMode is the mode of the authentication of the clients.
+//
+// +kubebuilder:validation:Enum=TokenReview;Remote
+type ClientAuthenticationMode string
+
+const (
+	// ClientAuthenticationModeTokenReview authenticates the clients with the bearer token in the Authorization
+	// header of the requests. NGINX sends the token to the verifier of the control plane, which reviews it with
+	// the Kubernetes TokenReview API. Only the tokens of ServiceAccounts are accepted.
+	// The control plane runs the verifier if its --token-review-port flag is set; otherwise, the requests
+	// are rejected with 500 (Internal Server Error).
+	ClientAuthenticationModeTokenReview ClientAuthenticationMode = "TokenReview"
+
+	// ClientAuthenticationModeRemote authenticates the clients with a remote endpoint, for example, an external
+	// authentication service, which receives the headers of every request.
+	ClientAuthenticationModeRemote ClientAuthenticationMode = "Remote"
+)
+
+// ServiceAccountName is the name of a ServiceAccount in the <namespace>/<name> format.
+// Examples: default/reporting, monitoring/prometheus.
+//
+// +kubebuilder:validation:MaxLength=317
+// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?/[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+type ServiceAccountName string
+
+// Weekday is a day of the week.
+//
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+const (
+	// Monday is the first day of the week.
+	Monday Weekday = "Monday"
+	// Tuesday is the second day of the week.
+	Tuesday Weekday = "Tuesday"
+	// Wednesday is the third day of the week.
+	Wednesday Weekday = "Wednesday"
+	// Thursday is the fourth day of the week.
+	Thursday Weekday = "Thursday"
+	// Friday is the fifth day of the week.
+	Friday Weekday = "Friday"
+	// Saturday is the sixth day of the week.
+	Saturday Weekday = "Saturday"
+	// Sunday is the seventh day of the week.
+	Sunday Weekday = "Sunday"
+)
+
+// AccessTime is a time of the day in the HH:MM format. 24:00 is the end of the day.
+// Examples: 09:00, 17:30, 24:00.
+//
+// +kubebuilder:validation:Pattern=`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`
+type AccessTime string
+
+// AccessHeaderValue is a value of a request header. AccessHeaderValue can contain only printable ASCII characters,
+// except '"' and '\'.
+//
+// +kubebuilder:validation:MinLength=1
+// +kubebuilder:validation:MaxLength=256
+// +kubebuilder:validation:Pattern=`^[ -!#-\[\]-~]+$`
+type AccessHeaderValue string
@@ -0,0 +1,276 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=nginx-gateway-fabric
+// +kubebuilder:printcolumn:name="Gateway",type=string,JSONPath=`.spec.gatewayName`
+// +kubebuilder:printcolumn:name="Passing",type=string,JSONPath=`.status.conditions[?(@.type=="Passing")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GatewayTest declares the expected behaviors of the routing of a Gateway. NGINX Gateway Fabric periodically sends
+// the requests of the assertions through the data plane of the Gateway, and reports whether the responses match
+// the expectations in the Passing condition, so that the routing assumptions become monitored invariants.
+type GatewayTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the GatewayTest.
+	Spec GatewayTestSpec `json:"spec"`
+
+	// Status defines the state of the GatewayTest.
+	Status GatewayTestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GatewayTestList contains a list of GatewayTests.
+type GatewayTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GatewayTest `json:"items"`
+}
+
+// GatewayTestSpec defines the desired state of the GatewayTest.
+type GatewayTestSpec struct {
+	// Interval is the interval between the verifications of the assertions.
+	// Default: 60s.
+	//
+	// +optional
+	Interval *Duration `json:"interval,omitempty"`
+
+	// GatewayName is the name of the Gateway in the namespace of the GatewayTest to which the requests are sent.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	GatewayName string `json:"gatewayName"`
+
+	// Assertions are the expected behaviors of the routing of the Gateway.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	Assertions []GatewayTestAssertion `json:"assertions"`
+}
+
+// GatewayTestAssertion is an expected behavior of the routing of a Gateway: the request is expected to result in
+// the response.
+type GatewayTestAssertion struct {
+	// Name is the name of the assertion, which is unique within the GatewayTest.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Request is the request that is sent to the Gateway.
+	Request GatewayTestRequest `json:"request"`
+
+	// Expect is the expected response to the request.
+	Expect GatewayTestExpectation `json:"expect"`
+}
+
+// GatewayTestRequest is a request that is sent to a Gateway.
+type GatewayTestRequest struct {
+	// Method is the method of the request.
+	// Default: GET.
+	//
+	// +optional
+	Method *GatewayTestMethod `json:"method,omitempty"`
+
+	// Path is the path of the request, including the query.
+	// Default: "/".
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^/[^\s]*$`
+	Path *string `json:"path,omitempty"`
+
+	// Port is the port of the listener of the Gateway to which the request is sent.
+	// Default: 80.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+
+	// Scheme is the scheme of the request. The certificate of an HTTPS listener is not verified, because
+	// the GatewayTest verifies the routing of the request.
+	// Default: HTTP.
+	//
+	// +optional
+	Scheme *GatewayTestScheme `json:"scheme,omitempty"`
+
+	// Host is the host of the request, which is sent in the Host header and as the SNI of an HTTPS request.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Host string `json:"host"`
+
+	// Headers are the additional headers of the request.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	Headers []GatewayTestHeader `json:"headers,omitempty"`
+}
+
+// GatewayTestMethod is the method of the request of a GatewayTest assertion.
+//
+// +kubebuilder:validation:Enum=GET;HEAD;POST;PUT;PATCH;DELETE;OPTIONS
+type GatewayTestMethod string
+
+// GatewayTestScheme is the scheme of the request of a GatewayTest assertion.
+//
+// +kubebuilder:validation:Enum=HTTP;HTTPS
+type GatewayTestScheme string
+
+const (
+	// GatewayTestSchemeHTTP sends the request over HTTP.
+	GatewayTestSchemeHTTP GatewayTestScheme = "HTTP"
+
+	// GatewayTestSchemeHTTPS sends the request over HTTPS.
+	GatewayTestSchemeHTTPS GatewayTestScheme = "HTTPS"
+)
+
+// GatewayTestHeader is a header of the request of a GatewayTest assertion.
+type GatewayTestHeader struct {
+	// Name is the name of the header.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+\-.^_\x60|~]+$`
+	Name string `json:"name"`
+
+	// Value is the value of the header.
+	//
+	// +kubebuilder:validation:MaxLength=4096
+	Value string `json:"value"`
+}
+
+// GatewayTestExpectation is the expected response to the request of a GatewayTest assertion.
+type GatewayTestExpectation struct {
+	// Backend is the backend that is expected to serve the request. Only the backends of HTTPRoutes are verified.
+	// If not set, the backend is not verified.
+	//
+	// +optional
+	Backend *GatewayTestBackend `json:"backend,omitempty"`
+
+	// StatusCode is the expected status code of the response. Redirects are not followed.
+	//
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	StatusCode int32 `json:"statusCode"`
+}
+
+// GatewayTestBackend is the backend that is expected to serve the request of a GatewayTest assertion.
+type GatewayTestBackend struct {
+	// Kind is the kind of the backend.
+	// Default: Service.
+	//
+	// +optional
+	Kind *GatewayTestBackendKind `json:"kind,omitempty"`
+
+	// Namespace is the namespace of the backend.
+	// Default: the namespace of the GatewayTest.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Port is the port of the Service. If not set, any port of the Service matches.
+	// The port of a Backend is not verified.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+
+	// Name is the name of the backend.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+}
+
+// GatewayTestBackendKind is the kind of the expected backend of a GatewayTest assertion.
+//
+// +kubebuilder:validation:Enum=Service;Backend
+type GatewayTestBackendKind string
+
+const (
+	// GatewayTestBackendKindService is a Kubernetes Service.
+	GatewayTestBackendKindService GatewayTestBackendKind = "Service"
+
+	// GatewayTestBackendKindBackend is a Backend with static endpoints.
+	GatewayTestBackendKindBackend GatewayTestBackendKind = "Backend"
+)
+
+// GatewayTestStatus defines the state of the GatewayTest.
+type GatewayTestStatus struct {
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Assertions are the results of the latest verification of the assertions.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Assertions []GatewayTestAssertionStatus `json:"assertions,omitempty"`
+}
+
+// GatewayTestAssertionStatus is the result of the latest verification of a GatewayTest assertion.
+type GatewayTestAssertionStatus struct {
+	// Name is the name of the assertion.
+	Name string `json:"name"`
+
+	// Message describes the response, and how it differs from the expected response if the assertion failed.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Passed is true if the response matched the expected response.
+	Passed bool `json:"passed"`
+}
+
+// GatewayTestConditionType is a type of condition associated with a GatewayTest.
+// This type should be used with the GatewayTestStatus.Conditions field.
+type GatewayTestConditionType string
+
+// GatewayTestConditionReason defines the set of reasons that explain why a particular GatewayTest condition type
+// has been raised.
+type GatewayTestConditionReason string
+
+const (
+	// GatewayTestConditionPassing is a condition that is true when the responses to the requests of all
+	// the assertions of the GatewayTest match their expected responses.
+	GatewayTestConditionPassing GatewayTestConditionType = "Passing"
+
+	// GatewayTestReasonPassed is a reason that is used with the "Passing" condition when the condition is True.
+	GatewayTestReasonPassed GatewayTestConditionReason = "AssertionsPassed"
+
+	// GatewayTestReasonFailed is a reason that is used with the "Passing" condition when the response to
+	// the request of an assertion doesn't match its expected response.
+	GatewayTestReasonFailed GatewayTestConditionReason = "AssertionsFailed"
+
+	// GatewayTestReasonGatewayNotReady is a reason that is used with the "Passing" condition when the requests
+	// can't be sent, because the Gateway doesn't exist or its NGINX Service doesn't have an address.
+	GatewayTestReasonGatewayNotReady GatewayTestConditionReason = "GatewayNotReady"
+
+	// GatewayTestReasonGatewayNotAccepted is a reason that is used with the "Passing" condition when the Gateway
+	// belongs to a GatewayClass that is not managed by this NGINX Gateway Fabric.
+	GatewayTestReasonGatewayNotAccepted GatewayTestConditionReason = "GatewayNotAccepted"
+)
@@ -32,10 +32,14 @@ var (
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Backend{},
+		&BackendList{},
 		&NginxGateway{},
 		&NginxGatewayList{},
 		&ClientSettingsPolicy{},
 		&ClientSettingsPolicyList{},
+		&GatewayTest{},
+		&GatewayTestList{},
 		&SnippetsFilter{},
 		&SnippetsFilterList{},
 		&UpstreamSettingsPolicy{},
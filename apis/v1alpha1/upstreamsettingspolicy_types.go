@@ -36,7 +36,8 @@ type UpstreamSettingsPolicyList struct {
 }
 
 // UpstreamSettingsPolicySpec defines the desired state of the UpstreamSettingsPolicy.
-// +kubebuilder:validation:XValidation:rule="!(has(self.loadBalancingMethod) && (self.loadBalancingMethod == 'hash' || self.loadBalancingMethod == 'hash consistent')) || has(self.hashMethodKey)",message="hashMethodKey is required when loadBalancingMethod is 'hash' or 'hash consistent'"
+// +kubebuilder:validation:XValidation:rule="!(has(self.loadBalancingMethod) && (self.loadBalancingMethod == 'hash' || self.loadBalancingMethod == 'hash consistent')) || has(self.hashMethodKey) || has(self.hashKey)",message="hashMethodKey or hashKey is required when loadBalancingMethod is 'hash' or 'hash consistent'"
+// +kubebuilder:validation:XValidation:rule="!(has(self.hashMethodKey) && has(self.hashKey))",message="hashMethodKey and hashKey cannot be set together"
 //
 //nolint:lll
 type UpstreamSettingsPolicySpec struct {
@@ -67,6 +68,13 @@ type UpstreamSettingsPolicySpec struct {
 	// +optional
 	HashMethodKey *HashMethodKey `json:"hashMethodKey,omitempty"`
 
+	// HashKey defines the request header or cookie used as the key for hash-based load balancing methods,
+	// so that the requests with the same value of the header or cookie are sent to the same upstream server.
+	// It is an alternative to `HashMethodKey` and cannot be set together with it.
+	//
+	// +optional
+	HashKey *HashKey `json:"hashKey,omitempty"`
+
 	// TargetRefs identifies API object(s) to apply the policy to.
 	// Objects must be in the same namespace as the policy.
 	// Support: Service
@@ -83,7 +91,20 @@ type UpstreamSettingsPolicySpec struct {
 }
 
 // UpstreamKeepAlive defines the keep-alive settings for upstreams.
+// +kubebuilder:validation:XValidation:rule="!(has(self.disable) && self.disable) || !(has(self.connections) || has(self.requests) || has(self.time) || has(self.timeout))",message="connections, requests, time and timeout cannot be set when keep-alive is disabled"
+//
+//nolint:lll
 type UpstreamKeepAlive struct {
+	// Disable disables the keep-alive connections to the upstream servers for the backends that mishandle them:
+	// NGINX proxies the requests using HTTP/1.0 and sends the "Connection: close" header.
+	// The other upstreams are not affected. If a route rule splits traffic between this upstream and other
+	// upstreams, the keep-alive connections are disabled for all upstreams of the rule.
+	// WebSocket connections can't be proxied to the upstream when the keep-alive connections are disabled.
+	// Directive: https://nginx.org/en/docs/http/ngx_http_proxy_module.html#proxy_http_version
+	//
+	// +optional
+	Disable *bool `json:"disable,omitempty"`
+
 	// Connections sets the maximum number of idle keep-alive connections to upstream servers that are preserved
 	// in the cache of each nginx worker process. When this number is exceeded, the least recently used
 	// connections are closed.
@@ -210,3 +231,26 @@ const (
 //
 // +kubebuilder:validation:Pattern=`^\$[a-z_]+$`
 type HashMethodKey string
+
+// HashKey defines the request header or cookie used as the key for hash-based load balancing methods.
+// Exactly one of Header or Cookie must be set.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.header) != has(self.cookie)",message="exactly one of header or cookie must be set"
+//
+//nolint:lll
+type HashKey struct {
+	// Header is the name of the request header used as the key, for example, `X-User-ID`.
+	// The name is case-insensitive.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-]+$`
+	Header *string `json:"header,omitempty"`
+
+	// Cookie is the name of the cookie used as the key, for example, `session_id`.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_]+$`
+	Cookie *string `json:"cookie,omitempty"`
+}
@@ -10,6 +10,201 @@ import (
 	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessHeader) DeepCopyInto(out *AccessHeader) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]AccessHeaderValue, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessHeader.
+func (in *AccessHeader) DeepCopy() *AccessHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessWindow) DeepCopyInto(out *AccessWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessWindow.
+func (in *AccessWindow) DeepCopy() *AccessWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Backend) DeepCopyInto(out *Backend) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backend.
+func (in *Backend) DeepCopy() *Backend {
+	if in == nil {
+		return nil
+	}
+	out := new(Backend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Backend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendEndpoint) DeepCopyInto(out *BackendEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendEndpoint.
+func (in *BackendEndpoint) DeepCopy() *BackendEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendList) DeepCopyInto(out *BackendList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Backend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendList.
+func (in *BackendList) DeepCopy() *BackendList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackendList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]BackendEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
+func (in *BackendSpec) DeepCopy() *BackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientAccess) DeepCopyInto(out *ClientAccess) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]AccessWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(AccessHeader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DenyCode != nil {
+		in, out := &in.DenyCode, &out.DenyCode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientAccess.
+func (in *ClientAccess) DeepCopy() *ClientAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientAuthentication) DeepCopyInto(out *ClientAuthentication) {
+	*out = *in
+	if in.TokenReview != nil {
+		in, out := &in.TokenReview, &out.TokenReview
+		*out = new(TokenReviewAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientAuthentication.
+func (in *ClientAuthentication) DeepCopy() *ClientAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientBody) DeepCopyInto(out *ClientBody) {
 	*out = *in
@@ -35,6 +230,46 @@ func (in *ClientBody) DeepCopy() *ClientBody {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCompression) DeepCopyInto(out *ClientCompression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCompression.
+func (in *ClientCompression) DeepCopy() *ClientCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientErrorResponses) DeepCopyInto(out *ClientErrorResponses) {
+	*out = *in
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientErrorResponses.
+func (in *ClientErrorResponses) DeepCopy() *ClientErrorResponses {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientErrorResponses)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientKeepAlive) DeepCopyInto(out *ClientKeepAlive) {
 	*out = *in
@@ -90,6 +325,76 @@ func (in *ClientKeepAliveTimeout) DeepCopy() *ClientKeepAliveTimeout {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientRejectResponse) DeepCopyInto(out *ClientRejectResponse) {
+	*out = *in
+	if in.RetryAfter != nil {
+		in, out := &in.RetryAfter, &out.RetryAfter
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRejectResponse.
+func (in *ClientRejectResponse) DeepCopy() *ClientRejectResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientRejectResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientRequestLimits) DeepCopyInto(out *ClientRequestLimits) {
+	*out = *in
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Rate != nil {
+		in, out := &in.Rate, &out.Rate
+		*out = new(Rate)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RejectCode != nil {
+		in, out := &in.RejectCode, &out.RejectCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RejectResponse != nil {
+		in, out := &in.RejectResponse, &out.RejectResponse
+		*out = new(ClientRejectResponse)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRequestLimits.
+func (in *ClientRequestLimits) DeepCopy() *ClientRequestLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientRequestLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientSettingsPolicy) DeepCopyInto(out *ClientSettingsPolicy) {
 	*out = *in
@@ -152,6 +457,16 @@ func (in *ClientSettingsPolicyList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientSettingsPolicySpec) DeepCopyInto(out *ClientSettingsPolicySpec) {
 	*out = *in
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = new(ClientAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(ClientAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Body != nil {
 		in, out := &in.Body, &out.Body
 		*out = new(ClientBody)
@@ -162,6 +477,26 @@ func (in *ClientSettingsPolicySpec) DeepCopyInto(out *ClientSettingsPolicySpec)
 		*out = new(ClientKeepAlive)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(ClientCompression)
+		**out = **in
+	}
+	if in.ErrorResponses != nil {
+		in, out := &in.ErrorResponses, &out.ErrorResponses
+		*out = new(ClientErrorResponses)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestLimits != nil {
+		in, out := &in.RequestLimits, &out.RequestLimits
+		*out = new(ClientRequestLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tarpit != nil {
+		in, out := &in.Tarpit, &out.Tarpit
+		*out = new(ClientTarpit)
+		(*in).DeepCopyInto(*out)
+	}
 	out.TargetRef = in.TargetRef
 }
 
@@ -175,6 +510,36 @@ func (in *ClientSettingsPolicySpec) DeepCopy() *ClientSettingsPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientTarpit) DeepCopyInto(out *ClientTarpit) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]TarpitPath, len(*in))
+		copy(*out, *in)
+	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientTarpit.
+func (in *ClientTarpit) DeepCopy() *ClientTarpit {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientTarpit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerStatus) DeepCopyInto(out *ControllerStatus) {
 	*out = *in
@@ -197,6 +562,281 @@ func (in *ControllerStatus) DeepCopy() *ControllerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTest) DeepCopyInto(out *GatewayTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTest.
+func (in *GatewayTest) DeepCopy() *GatewayTest {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GatewayTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTestAssertion) DeepCopyInto(out *GatewayTestAssertion) {
+	*out = *in
+	in.Request.DeepCopyInto(&out.Request)
+	in.Expect.DeepCopyInto(&out.Expect)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTestAssertion.
+func (in *GatewayTestAssertion) DeepCopy() *GatewayTestAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTestAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTestAssertionStatus) DeepCopyInto(out *GatewayTestAssertionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTestAssertionStatus.
+func (in *GatewayTestAssertionStatus) DeepCopy() *GatewayTestAssertionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTestAssertionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTestBackend) DeepCopyInto(out *GatewayTestBackend) {
+	*out = *in
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(GatewayTestBackendKind)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTestBackend.
+func (in *GatewayTestBackend) DeepCopy() *GatewayTestBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTestBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTestExpectation) DeepCopyInto(out *GatewayTestExpectation) {
+	*out = *in
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(GatewayTestBackend)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTestExpectation.
+func (in *GatewayTestExpectation) DeepCopy() *GatewayTestExpectation {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTestExpectation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTestHeader) DeepCopyInto(out *GatewayTestHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTestHeader.
+func (in *GatewayTestHeader) DeepCopy() *GatewayTestHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTestHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTestList) DeepCopyInto(out *GatewayTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GatewayTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTestList.
+func (in *GatewayTestList) DeepCopy() *GatewayTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GatewayTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTestRequest) DeepCopyInto(out *GatewayTestRequest) {
+	*out = *in
+	if in.Method != nil {
+		in, out := &in.Method, &out.Method
+		*out = new(GatewayTestMethod)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Scheme != nil {
+		in, out := &in.Scheme, &out.Scheme
+		*out = new(GatewayTestScheme)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]GatewayTestHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTestRequest.
+func (in *GatewayTestRequest) DeepCopy() *GatewayTestRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTestRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTestSpec) DeepCopyInto(out *GatewayTestSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]GatewayTestAssertion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTestSpec.
+func (in *GatewayTestSpec) DeepCopy() *GatewayTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTestStatus) DeepCopyInto(out *GatewayTestStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]GatewayTestAssertionStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTestStatus.
+func (in *GatewayTestStatus) DeepCopy() *GatewayTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HashKey) DeepCopyInto(out *HashKey) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(string)
+		**out = **in
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HashKey.
+func (in *HashKey) DeepCopy() *HashKey {
+	if in == nil {
+		return nil
+	}
+	out := new(HashKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Logging) DeepCopyInto(out *Logging) {
 	*out = *in
@@ -318,6 +958,31 @@ func (in *NginxGatewayStatus) DeepCopy() *NginxGatewayStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAuthentication) DeepCopyInto(out *RemoteAuthentication) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.CacheDuration != nil {
+		in, out := &in.CacheDuration, &out.CacheDuration
+		*out = new(Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAuthentication.
+func (in *RemoteAuthentication) DeepCopy() *RemoteAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Snippet) DeepCopyInto(out *Snippet) {
 	*out = *in
@@ -449,9 +1114,39 @@ func (in *SpanAttribute) DeepCopy() *SpanAttribute {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenReviewAuthentication) DeepCopyInto(out *TokenReviewAuthentication) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]ServiceAccountName, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenReviewAuthentication.
+func (in *TokenReviewAuthentication) DeepCopy() *TokenReviewAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenReviewAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpstreamKeepAlive) DeepCopyInto(out *UpstreamKeepAlive) {
 	*out = *in
+	if in.Disable != nil {
+		in, out := &in.Disable, &out.Disable
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Connections != nil {
 		in, out := &in.Connections, &out.Connections
 		*out = new(int32)
@@ -566,6 +1261,11 @@ func (in *UpstreamSettingsPolicySpec) DeepCopyInto(out *UpstreamSettingsPolicySp
 		*out = new(HashMethodKey)
 		**out = **in
 	}
+	if in.HashKey != nil {
+		in, out := &in.HashKey, &out.HashKey
+		*out = new(HashKey)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TargetRefs != nil {
 		in, out := &in.TargetRefs, &out.TargetRefs
 		*out = make([]apisv1.LocalPolicyTargetReference, len(*in))
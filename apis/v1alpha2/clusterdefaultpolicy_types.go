@@ -0,0 +1,158 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=nginx-gateway-fabric,scope=Cluster,shortName=cdpolicy
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterDefaultPolicy defines the default client, observability, and upstream settings of the Routes and
+// Services in the Namespaces selected by a label selector. It allows cluster administrators to set defaults
+// for the Namespaces of the application teams, which apply to every new Route and Service automatically.
+//
+// The defaults apply to a Route or a Service only if no ClientSettingsPolicy, ObservabilityPolicy, or
+// UpstreamSettingsPolicy, respectively, in its Namespace targets it. This way, the owners of a Namespace can
+// override the defaults of a kind of policy by attaching a policy of that kind to their Route or Service.
+// If several ClusterDefaultPolicies that define the same settings select a Namespace, the oldest one wins.
+type ClusterDefaultPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the ClusterDefaultPolicy.
+	Spec ClusterDefaultPolicySpec `json:"spec"`
+
+	// Status defines the state of the ClusterDefaultPolicy.
+	Status ClusterDefaultPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterDefaultPolicyList contains a list of ClusterDefaultPolicies.
+type ClusterDefaultPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDefaultPolicy `json:"items"`
+}
+
+// ClusterDefaultPolicySpec defines the desired state of the ClusterDefaultPolicy.
+//
+// +kubebuilder:validation:XValidation:message="at least one of clientSettings, observability, or upstreamSettings must be set",rule="has(self.clientSettings) || has(self.observability) || has(self.upstreamSettings)"
+//
+//nolint:lll
+type ClusterDefaultPolicySpec struct {
+	// NamespaceSelector selects the Namespaces whose Routes and Services get the defaults.
+	// An empty selector selects all Namespaces.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// ClientSettings are the default client settings of the HTTPRoutes and GRPCRoutes in the selected Namespaces.
+	// They are overridden by a ClientSettingsPolicy that targets the Route.
+	//
+	// +optional
+	ClientSettings *DefaultClientSettings `json:"clientSettings,omitempty"`
+
+	// Observability are the default observability settings of the HTTPRoutes and GRPCRoutes in the selected
+	// Namespaces. They are overridden by an ObservabilityPolicy that targets the Route.
+	//
+	// +optional
+	Observability *DefaultObservability `json:"observability,omitempty"`
+
+	// UpstreamSettings are the default upstream settings of the Services in the selected Namespaces.
+	// They are overridden by an UpstreamSettingsPolicy that targets the Service.
+	//
+	// +optional
+	UpstreamSettings *DefaultUpstreamSettings `json:"upstreamSettings,omitempty"`
+}
+
+// DefaultClientSettings defines the default client settings of the Routes.
+type DefaultClientSettings struct {
+	// Body defines the client request body settings.
+	//
+	// +optional
+	Body *ngfAPIv1alpha1.ClientBody `json:"body,omitempty"`
+
+	// KeepAlive defines the keep-alive settings.
+	//
+	// +optional
+	KeepAlive *ngfAPIv1alpha1.ClientKeepAlive `json:"keepAlive,omitempty"`
+}
+
+// DefaultObservability defines the default observability settings of the Routes.
+type DefaultObservability struct {
+	// Tracing allows for enabling and configuring tracing.
+	// Tracing requires telemetry to be enabled in the NginxProxy of the Gateway of a Route.
+	//
+	// +optional
+	Tracing *Tracing `json:"tracing,omitempty"`
+}
+
+// DefaultUpstreamSettings defines the default upstream settings of the Services.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.loadBalancingMethod) && (self.loadBalancingMethod == 'hash' || self.loadBalancingMethod == 'hash consistent')) || has(self.hashMethodKey)",message="hashMethodKey is required when loadBalancingMethod is 'hash' or 'hash consistent'"
+//
+//nolint:lll
+type DefaultUpstreamSettings struct {
+	// ZoneSize is the size of the shared memory zone used by the upstream.
+	//
+	// +optional
+	ZoneSize *ngfAPIv1alpha1.Size `json:"zoneSize,omitempty"`
+
+	// KeepAlive defines the keep-alive settings.
+	//
+	// +optional
+	KeepAlive *ngfAPIv1alpha1.UpstreamKeepAlive `json:"keepAlive,omitempty"`
+
+	// LoadBalancingMethod specifies the load balancing algorithm to be used for the upstream.
+	//
+	// +optional
+	LoadBalancingMethod *ngfAPIv1alpha1.LoadBalancingType `json:"loadBalancingMethod,omitempty"`
+
+	// HashMethodKey defines the key used for hash-based load balancing methods.
+	// This field is required when `LoadBalancingMethod` is set to `hash` or `hash consistent`.
+	//
+	// +optional
+	HashMethodKey *ngfAPIv1alpha1.HashMethodKey `json:"hashMethodKey,omitempty"`
+}
+
+// ClusterDefaultPolicyStatus defines the state of the ClusterDefaultPolicy.
+type ClusterDefaultPolicyStatus struct {
+	// Controllers is a list of Gateway API controllers that processed the ClusterDefaultPolicy
+	// and the status of the ClusterDefaultPolicy with respect to each controller.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	Controllers []ngfAPIv1alpha1.ControllerStatus `json:"controllers,omitempty"`
+}
+
+// ClusterDefaultPolicyConditionType is a type of condition associated with ClusterDefaultPolicy.
+type ClusterDefaultPolicyConditionType string
+
+// ClusterDefaultPolicyConditionReason is a reason for a ClusterDefaultPolicy condition type.
+type ClusterDefaultPolicyConditionReason string
+
+const (
+	// ClusterDefaultPolicyConditionTypeAccepted indicates that the ClusterDefaultPolicy is accepted.
+	//
+	// Possible reasons for this condition to be True:
+	//
+	// * Accepted
+	//
+	// Possible reasons for this condition to be False:
+	//
+	// * Invalid.
+	ClusterDefaultPolicyConditionTypeAccepted ClusterDefaultPolicyConditionType = "Accepted"
+
+	// ClusterDefaultPolicyConditionReasonAccepted is used with the Accepted condition type when
+	// the condition is true.
+	ClusterDefaultPolicyConditionReasonAccepted ClusterDefaultPolicyConditionReason = "Accepted"
+
+	// ClusterDefaultPolicyConditionReasonInvalid is used with the Accepted condition type when
+	// the ClusterDefaultPolicy is invalid.
+	ClusterDefaultPolicyConditionReasonInvalid ClusterDefaultPolicyConditionReason = "Invalid"
+)
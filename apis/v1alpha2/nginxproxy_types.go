@@ -4,6 +4,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
@@ -94,11 +95,169 @@ type NginxProxySpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
 	WorkerConnections *int32 `json:"workerConnections,omitempty"`
+	// ConnectionCloseTimeout is the time after which NGINX closes the connections that are still open when
+	// its configuration changes, for example, the long-lived WebSocket and gRPC connections of a route or
+	// a listener that was removed, so that they don't persist against the deleted backends indefinitely.
+	// NGINX applies a configuration change by gracefully replacing its worker processes, so the timeout applies
+	// to all connections that are open when the configuration changes.
+	// By default, NGINX waits for the clients or the backends to close these connections.
+	//
+	// +optional
+	ConnectionCloseTimeout *v1alpha1.Duration `json:"connectionCloseTimeout,omitempty"`
 	// DNSResolver specifies the DNS resolver configuration for external name resolution.
 	// This enables support for routing to ExternalName Services.
 	//
 	// +optional
 	DNSResolver *DNSResolver `json:"dnsResolver,omitempty"`
+	// DefaultResponseHeaders are the headers that are set on the responses of the routes of the Gateway,
+	// for example, the security headers required by a corporate policy. A default header replaces the header
+	// of the same name in the response of the backend.
+	// A route overrides or removes a default header with a ResponseHeaderModifier filter that sets, adds,
+	// or removes the header of the same name.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	DefaultResponseHeaders []HTTPHeader `json:"defaultResponseHeaders,omitempty"`
+	// TenantAttribution attributes every request of the Gateway to a tenant, so that the requests and bytes
+	// of every tenant can be counted, for example, to bill the internal tenants for the usage of the Gateway.
+	//
+	// +optional
+	TenantAttribution *TenantAttribution `json:"tenantAttribution,omitempty"`
+	// TempFiles configures the temporary files to which NGINX writes the request bodies and the responses
+	// of the backends that don't fit in its memory buffers. Writing to the temporary files adds latency,
+	// especially on small or slow ephemeral volumes.
+	//
+	// +optional
+	TempFiles *TempFiles `json:"tempFiles,omitempty"`
+	// UnknownExtensionRefFilters specifies how the ExtensionRef filters of Routes that NGINX Gateway Fabric
+	// doesn't recognize are handled, for example, the filters of other controllers that the Routes are
+	// also attached to. The field takes effect only in the NginxProxy referenced by the GatewayClass.
+	//
+	// +optional
+	// +kubebuilder:default:=Reject
+	UnknownExtensionRefFilters *UnknownExtensionRefFilterPolicy `json:"unknownExtensionRefFilters,omitempty"`
+	// Features toggles the configuration generation behaviors that change how NGINX handles the traffic, so that
+	// they can be rolled out Gateway by Gateway. The features set in the NginxProxy referenced by a Gateway
+	// override the features set in the NginxProxy referenced by the GatewayClass.
+	//
+	// +optional
+	Features *Features `json:"features,omitempty"`
+}
+
+// Features are the configuration generation behaviors that can be toggled per Gateway.
+// All features are disabled by default.
+type Features struct {
+	// UpstreamHTTP2 enables HTTP/2 for the connections to the backends of HTTPRoutes instead of HTTP/1.1.
+	// The backends must support HTTP/2, and the NGINX version must support HTTP/2 to the backends.
+	// The connections to the backends that have keep-alive disabled keep using HTTP/1.0.
+	// The backends of GRPCRoutes always use HTTP/2.
+	//
+	// +optional
+	UpstreamHTTP2 *bool `json:"upstreamHTTP2,omitempty"`
+
+	// StrictSNI rejects the TLS handshakes with a server name that doesn't match the hostname of a Route attached
+	// to an HTTPS listener. Without it, an HTTPS listener that matches any hostname, or that has a Route attached
+	// without hostnames, completes the TLS handshakes for any server name with its certificate.
+	//
+	// +optional
+	StrictSNI *bool `json:"strictSNI,omitempty"`
+}
+
+// UnknownExtensionRefFilterPolicy specifies how the ExtensionRef filters that NGINX Gateway Fabric
+// doesn't recognize are handled.
+//
+// +kubebuilder:validation:Enum=Reject;Warn;Ignore
+type UnknownExtensionRefFilterPolicy string
+
+const (
+	// UnknownExtensionRefFilterReject marks the rule of the Route with the filter as invalid.
+	UnknownExtensionRefFilterReject UnknownExtensionRefFilterPolicy = "Reject"
+	// UnknownExtensionRefFilterWarn ignores the filter and reports it in the Accepted condition of the Route.
+	UnknownExtensionRefFilterWarn UnknownExtensionRefFilterPolicy = "Warn"
+	// UnknownExtensionRefFilterIgnore ignores the filter.
+	UnknownExtensionRefFilterIgnore UnknownExtensionRefFilterPolicy = "Ignore"
+)
+
+// TenantAttribution specifies how the requests are attributed to tenants.
+// The tenant of a request is the value of the Header of the request, if configured and set to one of the Tenants,
+// otherwise, the namespace of the route that handles the request.
+// NGINX exposes the tenant in the $ngf_tenant variable, which can be used in the access log format.
+// If the control plane has the tenant attribution enabled, NGINX also reports every request to the control plane,
+// which exposes the requests and bytes of every tenant as Prometheus metrics. NGINX doesn't report the requests
+// if the access log is disabled.
+//
+// +kubebuilder:validation:XValidation:message="tenants must be set if header is set",rule="!has(self.header) || (has(self.tenants) && size(self.tenants) > 0)"
+//
+//nolint:lll
+type TenantAttribution struct {
+	// Header is the name of the request header that identifies the tenant of the request.
+	// The clients set the header, so only the values listed in Tenants are trusted. If not set, or if the request
+	// doesn't include the header or the header is not one of the Tenants, the tenant is the namespace of the route.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	Header *string `json:"header,omitempty"`
+
+	// Tenants are the tenants that the Header can identify. A request whose Header is not one of the Tenants
+	// is attributed to the namespace of its route, so that the clients can't attribute their requests
+	// to arbitrary tenants. Required if Header is set.
+	//
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=63
+	// +kubebuilder:validation:items:Pattern=`^[A-Za-z0-9._-]+$`
+	Tenants []string `json:"tenants,omitempty"`
+
+	// Enable enables the attribution of the requests to tenants.
+	Enable bool `json:"enable"`
+}
+
+// TempFiles configures the temporary files of NGINX.
+// NGINX exposes whether the body of a request was written to a temporary file in the $ngf_request_body_in_file
+// variable, which is 1 if it was and 0 otherwise, and can be used in the access log format.
+type TempFiles struct {
+	// MaxResponseFileSize is the maximum size of the temporary file to which NGINX writes a response of a backend.
+	// Once the temporary file reaches the size, the rest of the response is passed to the client synchronously.
+	// Setting it to 0 disables writing the responses to temporary files.
+	// If not specified, NGINX uses its default of 1024m.
+	// See https://nginx.org/en/docs/http/ngx_http_proxy_module.html#proxy_max_temp_file_size
+	//
+	// +optional
+	MaxResponseFileSize *v1alpha1.Size `json:"maxResponseFileSize,omitempty"`
+
+	// VolumeSizeLimit is the size limit of the volume of the temporary files of NGINX.
+	// If not specified, the volume is not limited. Changing this value results in a re-roll of the NGINX deployment.
+	//
+	// +optional
+	VolumeSizeLimit *resource.Quantity `json:"volumeSizeLimit,omitempty"`
+
+	// ReportWrites reports the writes to the temporary files to the control plane, which exposes them
+	// as Prometheus metrics. The control plane must have the temporary file metrics enabled.
+	//
+	// +optional
+	ReportWrites *bool `json:"reportWrites,omitempty"`
+}
+
+// HTTPHeader is an HTTP header.
+type HTTPHeader struct {
+	// Name is the name of the header. Header names are case-insensitive.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+\-.^_\x60|~]+$`
+	Name string `json:"name"`
+
+	// Value is the value of the header.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	Value string `json:"value"`
 }
 
 // Telemetry specifies the OpenTelemetry configuration.
@@ -372,6 +531,16 @@ type NginxAccessLog struct {
 	//
 	// +optional
 	Format *string `json:"format,omitempty"`
+
+	// SeparateClientAborts logs the requests that the client closed before NGINX responded (status 499)
+	// separately from the other requests, in a distinct format that starts with "client_abort" and includes
+	// the time the request spent in NGINX and the upstream timings, including the time the request spent in
+	// the upstream queue. The other requests are logged in the configured format.
+	// This helps diagnose cascades of client-side timeouts.
+	// See https://nginx.org/en/docs/http/ngx_http_log_module.html#access_log
+	//
+	// +optional
+	SeparateClientAborts *bool `json:"separateClientAborts,omitempty"`
 }
 
 // NginxPlus specifies NGINX Plus additional settings. These will only be applied if NGINX Plus is being used.
@@ -464,6 +633,11 @@ const (
 //
 //nolint:lll
 type KubernetesSpec struct {
+	// Agentless is the configuration for running the NGINX Pods without the NGINX agent.
+	//
+	// +optional
+	Agentless *AgentlessSpec `json:"agentless,omitempty"`
+
 	// Deployment is the configuration for the NGINX Deployment.
 	// This is the default deployment option.
 	//
@@ -475,10 +649,57 @@ type KubernetesSpec struct {
 	// +optional
 	DaemonSet *DaemonSetSpec `json:"daemonSet,omitempty"`
 
+	// NetworkPolicy is the configuration for the NetworkPolicy of the NGINX Pods.
+	//
+	// +optional
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+
 	// Service is the configuration for the NGINX Service.
 	//
 	// +optional
 	Service *ServiceSpec `json:"service,omitempty"`
+
+	// ServiceAccount is the configuration for the NGINX ServiceAccount.
+	// Each Gateway has a dedicated ServiceAccount that is not bound to any Kubernetes API permissions.
+	//
+	// +optional
+	ServiceAccount *ServiceAccountSpec `json:"serviceAccount,omitempty"`
+}
+
+// ServiceAccountSpec is the configuration for the NGINX ServiceAccount.
+type ServiceAccountSpec struct {
+	// Annotations are added to the ServiceAccount. They can be used to bind the ServiceAccount
+	// of the Gateway to a cloud identity, for example, with IAM Roles for Service Accounts (IRSA)
+	// or Workload Identity, so that cloud credentials are scoped to a single Gateway.
+	// These annotations take precedence over the annotations from the Gateway infrastructure.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// AgentlessSpec is the configuration for running the NGINX Pods without the NGINX agent.
+type AgentlessSpec struct {
+	// Enable runs the NGINX Pods without the NGINX agent, for environments that can't run it.
+	// The control plane writes the NGINX configuration to the ConfigMap <gateway-name>-nginx-config, and the files
+	// with secrets to the Secret with the same name. A config-reloader sidecar container copies the files
+	// to NGINX and reloads NGINX with a SIGHUP signal. The process namespace of the Pods is shared
+	// so that the sidecar can signal NGINX.
+	// Because the configuration is delivered through a ConfigMap, updates take as long as the kubelet takes
+	// to refresh the mounted volumes, and the status of the Gateway doesn't reflect errors when reloading NGINX.
+	Enable bool `json:"enable"`
+}
+
+// NetworkPolicySpec is the configuration for the NetworkPolicy of the NGINX Pods.
+type NetworkPolicySpec struct {
+	// Enable generates a NetworkPolicy that restricts the traffic of the NGINX Pods.
+	// Ingress traffic is only allowed to the listener and metrics ports. Egress traffic is only allowed
+	// to DNS, the enabled listeners of the control plane, the Services referenced by the Routes attached
+	// to the Gateway, the OpenTelemetry exporter, the NGINX Plus usage reporting endpoint,
+	// and the NGINX One Console. The NetworkPolicy is kept up to date as Routes change.
+	// Egress traffic to destinations whose Pods can't be selected, such as ExternalName Services,
+	// Services without a selector, and endpoints with hostnames, is allowed to any address on their ports.
+	// The Gateway reports such Services in its NetworkPolicyRestricted condition.
+	Enable bool `json:"enable"`
 }
 
 // Patch defines a patch to apply to a Kubernetes object.
@@ -671,6 +892,13 @@ type ContainerSpec struct {
 	// +optional
 	Lifecycle *corev1.Lifecycle `json:"lifecycle,omitempty"`
 
+	// PreStopDrain drains the traffic of the NGINX container before NGINX shuts down, so that the external
+	// load balancers deregister the Pod before NGINX stops accepting connections.
+	// It can't be combined with a PreStop handler in the Lifecycle.
+	//
+	// +optional
+	PreStopDrain *PreStopDrain `json:"preStopDrain,omitempty"`
+
 	// ReadinessProbe defines the readiness probe for the NGINX container.
 	//
 	// +optional
@@ -687,6 +915,21 @@ type ContainerSpec struct {
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
 }
 
+// PreStopDrain configures how the NGINX container drains its traffic before NGINX shuts down.
+// When the Pod starts terminating, a PreStop handler makes the readiness endpoint of NGINX, and the health check
+// endpoint of the cloud load balancer if enabled, respond with 503, so that the load balancers that health check
+// the Pod deregister it. NGINX keeps serving the traffic for the delay, and then starts its graceful shutdown.
+// If the termination grace period of the Pod is not set, it defaults to the delay plus 30 seconds.
+type PreStopDrain struct {
+	// DelaySeconds is how long NGINX keeps serving the traffic after the Pod starts terminating.
+	// Set it longer than the time the load balancer takes to deregister the Pod, for example the deregistration
+	// delay of an AWS Network Load Balancer plus the time its health checks take to fail.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	DelaySeconds int32 `json:"delaySeconds"`
+}
+
 // ReadinessProbeSpec defines the configuration for the NGINX readiness probe.
 type ReadinessProbeSpec struct {
 	// Port is the port on which the readiness endpoint is exposed.
@@ -780,12 +1023,137 @@ type ServiceSpec struct {
 	// +optional
 	NodePorts []NodePort `json:"nodePorts,omitempty"`
 
+	// NodeAddresses configures the addresses of the Nodes that are reported in the status of the Gateway
+	// when the Service is of type NodePort or the NGINX Pods use the host network. If no Node address is found,
+	// the cluster IP of the Service is reported.
+	//
+	// +optional
+	NodeAddresses *NodeAddresses `json:"nodeAddresses,omitempty"`
+
+	// ExternalDNS configures the annotations of the NGINX Service for external-dns, so that it creates
+	// the DNS records of the hostnames of the Gateway listeners, pointing at the address of the Service.
+	//
+	// +optional
+	ExternalDNS *ExternalDNS `json:"externalDNS,omitempty"`
+
+	// LoadBalancerHealthCheck configures a dedicated health check endpoint of NGINX for the cloud load balancer
+	// of the NGINX Service, so that the load balancer doesn't health check the routes of the Gateway.
+	//
+	// +optional
+	LoadBalancerHealthCheck *LoadBalancerHealthCheck `json:"loadBalancerHealthCheck,omitempty"`
+
 	// Patches are custom patches to apply to the NGINX Service.
 	//
 	// +optional
 	Patches []Patch `json:"patches,omitempty"`
 }
 
+// ExternalDNS configures the annotations of the NGINX Service for external-dns
+// (https://github.com/kubernetes-sigs/external-dns). The hostnames of the Gateway listeners are set in the
+// external-dns.alpha.kubernetes.io/hostname annotation of the Service, so external-dns creates their DNS records
+// pointing at the address of the Service, and updates them when the address or the hostnames change.
+// The listeners without a hostname are ignored.
+type ExternalDNS struct {
+	// TTL is the TTL of the DNS records in seconds. If not set, the default TTL of external-dns is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TTL *int32 `json:"ttl,omitempty"`
+
+	// Enable enables the external-dns annotations of the NGINX Service.
+	Enable bool `json:"enable"`
+}
+
+// LoadBalancerHealthCheck configures a dedicated health check endpoint of NGINX for the cloud load balancer
+// of the NGINX Service. NGINX responds with 200 to the requests of the path on the port, and the port is added
+// to the Service. The Service is annotated so that the load balancers of AWS and Azure health check
+// the endpoint instead of the ports of the listeners:
+//
+// - AWS: service.beta.kubernetes.io/aws-load-balancer-healthcheck-{protocol,path,port}. The port is the port
+// of the NGINX Pods, so the load balancer must target the Pods by IP.
+//
+// - Azure: service.beta.kubernetes.io/azure-load-balancer-health-probe-{protocol,request-path} and
+// service.beta.kubernetes.io/port_<listener port>_health-probe_port.
+//
+// The health checks of the L4 load balancers of GCP can't be configured with Service annotations. They check
+// the nodes through the health check node port of the Service when the external traffic policy is Local.
+type LoadBalancerHealthCheck struct {
+	// Path is the path of the health check endpoint.
+	// If not specified, the default path is /healthz.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^/[A-Za-z0-9._~/-]*$`
+	Path *string `json:"path,omitempty"`
+
+	// Port is the port on which the health check endpoint is exposed. It must not be the port of a listener
+	// of the Gateway or the port of the readiness probe.
+	// If not specified, the default port is 8082.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+
+	// Enable enables the health check endpoint and the annotations of the NGINX Service.
+	Enable bool `json:"enable"`
+}
+
+// NodeAddresses configures the addresses of the Nodes that are reported in the status of the Gateway, so that
+// tools that automate DNS from the Gateway addresses work on bare metal.
+type NodeAddresses struct {
+	// Types are the types of the Node addresses, in order of preference. For every selected Node, the address
+	// of the first type that the Node has is reported.
+	// If not specified, the default is [ExternalIP, InternalIP].
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=5
+	// +listType=set
+	Types []NodeAddressType `json:"types,omitempty"`
+
+	// Selection selects the Nodes whose addresses are reported.
+	// If not specified, the default is PodNodes.
+	//
+	// +optional
+	Selection *NodeSelection `json:"selection,omitempty"`
+}
+
+// NodeAddressType is the type of a Node address.
+// +kubebuilder:validation:Enum=ExternalIP;InternalIP;ExternalDNS;InternalDNS;Hostname
+type NodeAddressType corev1.NodeAddressType
+
+const (
+	// NodeAddressTypeExternalIP is an IP address of the Node that is routable outside the cluster.
+	NodeAddressTypeExternalIP NodeAddressType = NodeAddressType(corev1.NodeExternalIP)
+
+	// NodeAddressTypeInternalIP is an IP address of the Node that is routable within the cluster.
+	NodeAddressTypeInternalIP NodeAddressType = NodeAddressType(corev1.NodeInternalIP)
+
+	// NodeAddressTypeExternalDNS is a DNS name of the Node that resolves to an external IP address.
+	NodeAddressTypeExternalDNS NodeAddressType = NodeAddressType(corev1.NodeExternalDNS)
+
+	// NodeAddressTypeInternalDNS is a DNS name of the Node that resolves to an internal IP address.
+	NodeAddressTypeInternalDNS NodeAddressType = NodeAddressType(corev1.NodeInternalDNS)
+
+	// NodeAddressTypeHostname is the hostname of the Node.
+	NodeAddressTypeHostname NodeAddressType = NodeAddressType(corev1.NodeHostName)
+)
+
+// NodeSelection selects the Nodes whose addresses are reported in the status of the Gateway.
+// +kubebuilder:validation:Enum=PodNodes;AllNodes
+type NodeSelection string
+
+const (
+	// NodeSelectionPodNodes selects the Nodes that run the ready NGINX Pods. Use it with the host network, or with
+	// the Local external traffic policy, where only these Nodes accept the traffic of the Gateway.
+	NodeSelectionPodNodes NodeSelection = "PodNodes"
+
+	// NodeSelectionAllNodes selects all the ready Nodes. Use it with a NodePort Service and the Cluster external
+	// traffic policy, where every Node accepts the traffic of the Gateway.
+	NodeSelectionAllNodes NodeSelection = "AllNodes"
+)
+
 // ServiceType describes ingress method for the Service.
 // +kubebuilder:validation:Enum=ClusterIP;LoadBalancer;NodePort
 type ServiceType corev1.ServiceType
@@ -47,18 +47,25 @@ type ObservabilityPolicySpec struct {
 
 	// TargetRefs identifies the API object(s) to apply the policy to.
 	// Objects must be in the same namespace as the policy.
-	// Support: HTTPRoute, GRPCRoute.
+	// Support: Gateway, HTTPRoute, GRPCRoute.
 	//
-	// TargetRefs must be _distinct_. This means that the multi-part key defined by `kind` and `name` must
-	// be unique across all targetRef entries in the ObservabilityPolicy.
+	// A Gateway target applies the policy to all Routes attached to the Gateway. With a sectionName, it applies the
+	// policy only to the Routes attached to the named listener of the Gateway, for example, to trace the requests
+	// of an admin listener while keeping the public listeners quiet. The sectionName is only supported for Gateways.
+	// The settings of a policy that targets a Route override the same settings of a policy that targets
+	// the Gateway or the listener of the Route.
+	//
+	// TargetRefs must be _distinct_. This means that the multi-part key defined by `kind`, `name`, and
+	// `sectionName` must be unique across all targetRef entries in the ObservabilityPolicy.
 	//
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=16
-	// +kubebuilder:validation:XValidation:message="TargetRef Kind must be: HTTPRoute or GRPCRoute",rule="(self.exists(t, t.kind=='HTTPRoute') || self.exists(t, t.kind=='GRPCRoute'))"
+	// +kubebuilder:validation:XValidation:message="TargetRef Kind must be: Gateway, HTTPRoute, or GRPCRoute",rule="(self.exists(t, t.kind=='Gateway') || self.exists(t, t.kind=='HTTPRoute') || self.exists(t, t.kind=='GRPCRoute'))"
 	// +kubebuilder:validation:XValidation:message="TargetRef Group must be gateway.networking.k8s.io",rule="self.all(t, t.group=='gateway.networking.k8s.io')"
-	// +kubebuilder:validation:XValidation:message="TargetRef Kind and Name combination must be unique",rule="self.all(p1, self.exists_one(p2, (p1.name == p2.name) && (p1.kind == p2.kind)))"
+	// +kubebuilder:validation:XValidation:message="TargetRef SectionName is only supported for Gateway",rule="self.all(t, !has(t.sectionName) || t.kind=='Gateway')"
+	// +kubebuilder:validation:XValidation:message="TargetRef Kind, Name, and SectionName combination must be unique",rule="self.all(p1, self.exists_one(p2, (p1.name == p2.name) && (p1.kind == p2.kind) && (has(p1.sectionName) ? (has(p2.sectionName) && p1.sectionName == p2.sectionName) : !has(p2.sectionName))))"
 	//nolint:lll
-	TargetRefs []gatewayv1.LocalPolicyTargetReference `json:"targetRefs"`
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
 }
 
 // Tracing allows for enabling and configuring OpenTelemetry tracing.
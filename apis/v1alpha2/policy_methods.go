@@ -9,6 +9,17 @@ import (
 // These methods implement the policies.Policy interface which extends client.Object to add the following methods.
 
 func (p *ObservabilityPolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReference {
+	refs := make([]gatewayv1.LocalPolicyTargetReference, 0, len(p.Spec.TargetRefs))
+	for _, ref := range p.Spec.TargetRefs {
+		refs = append(refs, ref.LocalPolicyTargetReference)
+	}
+
+	return refs
+}
+
+// GetTargetRefsWithSectionName returns the targetRefs of the policy with their sectionNames.
+// It implements the policies.SectionNamePolicy interface.
+func (p *ObservabilityPolicy) GetTargetRefsWithSectionName() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
 	return p.Spec.TargetRefs
 }
 
@@ -32,6 +32,8 @@ var (
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&ClusterDefaultPolicy{},
+		&ClusterDefaultPolicyList{},
 		&NginxProxy{},
 		&NginxProxyList{},
 		&ObservabilityPolicy{},
@@ -13,6 +13,21 @@ import (
 	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentlessSpec) DeepCopyInto(out *AgentlessSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentlessSpec.
+func (in *AgentlessSpec) DeepCopy() *AgentlessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentlessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
 	*out = *in
@@ -55,6 +70,118 @@ func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDefaultPolicy) DeepCopyInto(out *ClusterDefaultPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDefaultPolicy.
+func (in *ClusterDefaultPolicy) DeepCopy() *ClusterDefaultPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDefaultPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDefaultPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDefaultPolicyList) DeepCopyInto(out *ClusterDefaultPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDefaultPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDefaultPolicyList.
+func (in *ClusterDefaultPolicyList) DeepCopy() *ClusterDefaultPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDefaultPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDefaultPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDefaultPolicySpec) DeepCopyInto(out *ClusterDefaultPolicySpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.ClientSettings != nil {
+		in, out := &in.ClientSettings, &out.ClientSettings
+		*out = new(DefaultClientSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Observability != nil {
+		in, out := &in.Observability, &out.Observability
+		*out = new(DefaultObservability)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpstreamSettings != nil {
+		in, out := &in.UpstreamSettings, &out.UpstreamSettings
+		*out = new(DefaultUpstreamSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDefaultPolicySpec.
+func (in *ClusterDefaultPolicySpec) DeepCopy() *ClusterDefaultPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDefaultPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDefaultPolicyStatus) DeepCopyInto(out *ClusterDefaultPolicyStatus) {
+	*out = *in
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]v1alpha1.ControllerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDefaultPolicyStatus.
+func (in *ClusterDefaultPolicyStatus) DeepCopy() *ClusterDefaultPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDefaultPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerSpec) DeepCopyInto(out *ContainerSpec) {
 	*out = *in
@@ -78,6 +205,11 @@ func (in *ContainerSpec) DeepCopyInto(out *ContainerSpec) {
 		*out = new(corev1.Lifecycle)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PreStopDrain != nil {
+		in, out := &in.PreStopDrain, &out.PreStopDrain
+		*out = new(PreStopDrain)
+		**out = **in
+	}
 	if in.ReadinessProbe != nil {
 		in, out := &in.ReadinessProbe, &out.ReadinessProbe
 		*out = new(ReadinessProbeSpec)
@@ -181,6 +313,86 @@ func (in *DaemonSetSpec) DeepCopy() *DaemonSetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultClientSettings) DeepCopyInto(out *DefaultClientSettings) {
+	*out = *in
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(v1alpha1.ClientBody)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeepAlive != nil {
+		in, out := &in.KeepAlive, &out.KeepAlive
+		*out = new(v1alpha1.ClientKeepAlive)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultClientSettings.
+func (in *DefaultClientSettings) DeepCopy() *DefaultClientSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultClientSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultObservability) DeepCopyInto(out *DefaultObservability) {
+	*out = *in
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(Tracing)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultObservability.
+func (in *DefaultObservability) DeepCopy() *DefaultObservability {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultObservability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultUpstreamSettings) DeepCopyInto(out *DefaultUpstreamSettings) {
+	*out = *in
+	if in.ZoneSize != nil {
+		in, out := &in.ZoneSize, &out.ZoneSize
+		*out = new(v1alpha1.Size)
+		**out = **in
+	}
+	if in.KeepAlive != nil {
+		in, out := &in.KeepAlive, &out.KeepAlive
+		*out = new(v1alpha1.UpstreamKeepAlive)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadBalancingMethod != nil {
+		in, out := &in.LoadBalancingMethod, &out.LoadBalancingMethod
+		*out = new(v1alpha1.LoadBalancingType)
+		**out = **in
+	}
+	if in.HashMethodKey != nil {
+		in, out := &in.HashMethodKey, &out.HashMethodKey
+		*out = new(v1alpha1.HashMethodKey)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultUpstreamSettings.
+func (in *DefaultUpstreamSettings) DeepCopy() *DefaultUpstreamSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultUpstreamSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
 	*out = *in
@@ -215,6 +427,66 @@ func (in *DeploymentSpec) DeepCopy() *DeploymentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNS) DeepCopyInto(out *ExternalDNS) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNS.
+func (in *ExternalDNS) DeepCopy() *ExternalDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Features) DeepCopyInto(out *Features) {
+	*out = *in
+	if in.UpstreamHTTP2 != nil {
+		in, out := &in.UpstreamHTTP2, &out.UpstreamHTTP2
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StrictSNI != nil {
+		in, out := &in.StrictSNI, &out.StrictSNI
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Features.
+func (in *Features) DeepCopy() *Features {
+	if in == nil {
+		return nil
+	}
+	out := new(Features)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostPort) DeepCopyInto(out *HostPort) {
 	*out = *in
@@ -263,6 +535,11 @@ func (in *Image) DeepCopy() *Image {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
 	*out = *in
+	if in.Agentless != nil {
+		in, out := &in.Agentless, &out.Agentless
+		*out = new(AgentlessSpec)
+		**out = **in
+	}
 	if in.Deployment != nil {
 		in, out := &in.Deployment, &out.Deployment
 		*out = new(DeploymentSpec)
@@ -273,11 +550,21 @@ func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
 		*out = new(DaemonSetSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicySpec)
+		**out = **in
+	}
 	if in.Service != nil {
 		in, out := &in.Service, &out.Service
 		*out = new(ServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
@@ -290,6 +577,31 @@ func (in *KubernetesSpec) DeepCopy() *KubernetesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerHealthCheck) DeepCopyInto(out *LoadBalancerHealthCheck) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerHealthCheck.
+func (in *LoadBalancerHealthCheck) DeepCopy() *LoadBalancerHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metrics) DeepCopyInto(out *Metrics) {
 	*out = *in
@@ -315,6 +627,21 @@ func (in *Metrics) DeepCopy() *Metrics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NginxAccessLog) DeepCopyInto(out *NginxAccessLog) {
 	*out = *in
@@ -328,6 +655,11 @@ func (in *NginxAccessLog) DeepCopyInto(out *NginxAccessLog) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SeparateClientAborts != nil {
+		in, out := &in.SeparateClientAborts, &out.SeparateClientAborts
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxAccessLog.
@@ -516,11 +848,41 @@ func (in *NginxProxySpec) DeepCopyInto(out *NginxProxySpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ConnectionCloseTimeout != nil {
+		in, out := &in.ConnectionCloseTimeout, &out.ConnectionCloseTimeout
+		*out = new(v1alpha1.Duration)
+		**out = **in
+	}
 	if in.DNSResolver != nil {
 		in, out := &in.DNSResolver, &out.DNSResolver
 		*out = new(DNSResolver)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DefaultResponseHeaders != nil {
+		in, out := &in.DefaultResponseHeaders, &out.DefaultResponseHeaders
+		*out = make([]HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.TenantAttribution != nil {
+		in, out := &in.TenantAttribution, &out.TenantAttribution
+		*out = new(TenantAttribution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TempFiles != nil {
+		in, out := &in.TempFiles, &out.TempFiles
+		*out = new(TempFiles)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnknownExtensionRefFilters != nil {
+		in, out := &in.UnknownExtensionRefFilters, &out.UnknownExtensionRefFilters
+		*out = new(UnknownExtensionRefFilterPolicy)
+		**out = **in
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = new(Features)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxProxySpec.
@@ -533,6 +895,31 @@ func (in *NginxProxySpec) DeepCopy() *NginxProxySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAddresses) DeepCopyInto(out *NodeAddresses) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]NodeAddressType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selection != nil {
+		in, out := &in.Selection, &out.Selection
+		*out = new(NodeSelection)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddresses.
+func (in *NodeAddresses) DeepCopy() *NodeAddresses {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAddresses)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePort) DeepCopyInto(out *NodePort) {
 	*out = *in
@@ -617,8 +1004,10 @@ func (in *ObservabilityPolicySpec) DeepCopyInto(out *ObservabilityPolicySpec) {
 	}
 	if in.TargetRefs != nil {
 		in, out := &in.TargetRefs, &out.TargetRefs
-		*out = make([]apisv1.LocalPolicyTargetReference, len(*in))
-		copy(*out, *in)
+		*out = make([]apisv1.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -710,6 +1099,21 @@ func (in *PodSpec) DeepCopy() *PodSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreStopDrain) DeepCopyInto(out *PreStopDrain) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreStopDrain.
+func (in *PreStopDrain) DeepCopy() *PreStopDrain {
+	if in == nil {
+		return nil
+	}
+	out := new(PreStopDrain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReadinessProbeSpec) DeepCopyInto(out *ReadinessProbeSpec) {
 	*out = *in
@@ -780,6 +1184,28 @@ func (in *RewriteClientIPAddress) DeepCopy() *RewriteClientIPAddress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountSpec) DeepCopyInto(out *ServiceAccountSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountSpec.
+func (in *ServiceAccountSpec) DeepCopy() *ServiceAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 	*out = *in
@@ -813,6 +1239,21 @@ func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 		*out = make([]NodePort, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeAddresses != nil {
+		in, out := &in.NodeAddresses, &out.NodeAddresses
+		*out = new(NodeAddresses)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(ExternalDNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadBalancerHealthCheck != nil {
+		in, out := &in.LoadBalancerHealthCheck, &out.LoadBalancerHealthCheck
+		*out = new(LoadBalancerHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Patches != nil {
 		in, out := &in.Patches, &out.Patches
 		*out = make([]Patch, len(*in))
@@ -867,6 +1308,61 @@ func (in *Telemetry) DeepCopy() *Telemetry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempFiles) DeepCopyInto(out *TempFiles) {
+	*out = *in
+	if in.MaxResponseFileSize != nil {
+		in, out := &in.MaxResponseFileSize, &out.MaxResponseFileSize
+		*out = new(v1alpha1.Size)
+		**out = **in
+	}
+	if in.VolumeSizeLimit != nil {
+		in, out := &in.VolumeSizeLimit, &out.VolumeSizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ReportWrites != nil {
+		in, out := &in.ReportWrites, &out.ReportWrites
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempFiles.
+func (in *TempFiles) DeepCopy() *TempFiles {
+	if in == nil {
+		return nil
+	}
+	out := new(TempFiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantAttribution) DeepCopyInto(out *TenantAttribution) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantAttribution.
+func (in *TenantAttribution) DeepCopy() *TenantAttribution {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantAttribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TelemetryExporter) DeepCopyInto(out *TelemetryExporter) {
 	*out = *in
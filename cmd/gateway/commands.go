@@ -1,17 +1,24 @@
 package main
 
 import (
+	"crypto/fips140"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"runtime/debug"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8sConfig "sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -19,9 +26,18 @@ import (
 	ctlrZap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/apicatalog"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/config"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/ingress"
 	ngxConfig "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/outlier"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	fwcontroller "github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/redact"
 )
 
 // These flags are shared by multiple commands.
@@ -78,6 +94,7 @@ func createControllerCommand() *cobra.Command {
 		nginxOneTLSSkipVerifyFlag           = "nginx-one-tls-skip-verify"
 		metricsDisableFlag                  = "metrics-disable"
 		metricsSecureFlag                   = "metrics-secure-serving"
+		debugEndpointsFlag                  = "debug-endpoints"
 		metricsPortFlag                     = "metrics-port"
 		healthDisableFlag                   = "health-disable"
 		healthPortFlag                      = "health-port"
@@ -95,7 +112,43 @@ func createControllerCommand() *cobra.Command {
 		usageReportCASecretFlag             = "usage-report-ca-secret"         //nolint:gosec // not credentials
 		usageReportEnforceInitialReportFlag = "usage-report-enforce-initial-report"
 		snippetsFiltersFlag                 = "snippets-filters"
+		gatewayTestsFlag                    = "gateway-tests"
+		clusterDefaultPoliciesFlag          = "cluster-default-policies"
 		nginxSCCFlag                        = "nginx-scc"
+		fipsFlag                            = "fips"
+		moduleLogLevelsFlag                 = "module-log-levels"
+		logLevelsConfigMapFlag              = "log-levels-configmap"
+		configExportDirFlag                 = "config-export-dir"
+		configExportConfigMapFlag           = "config-export-configmap"
+		upstreamMapConfigMapFlag            = "upstream-map-configmap"
+		configHistorySizeFlag               = "config-history-size"
+		canaryPrometheusAddressFlag         = "canary-analysis-prometheus-address"
+		canaryAnalysisIntervalFlag          = "canary-analysis-interval"
+		canaryErrorRateQueryFlag            = "canary-analysis-error-rate-query"
+		canaryLatencyQueryFlag              = "canary-analysis-latency-query"
+		apiCatalogPathFlag                  = "api-catalog-path"
+		apiCatalogIntervalFlag              = "api-catalog-interval"
+		outlierPrometheusAddressFlag        = "outlier-hook-prometheus-address"
+		outlierHookIntervalFlag             = "outlier-hook-interval"
+		outlierErrorRateQueryFlag           = "outlier-hook-error-rate-query"
+		wasmHookModuleFlag                  = "wasm-hook-module"
+		wasmHookTimeoutFlag                 = "wasm-hook-timeout"
+		wasmHookMaxMemoryFlag               = "wasm-hook-max-memory-mib"
+		ipamMetalLBAddressPoolFlag          = "ipam-metallb-address-pool"
+		ipamEndpointFlag                    = "ipam-endpoint"
+		tenantAttributionPortFlag           = "tenant-attribution-port"
+		usageSummaryIntervalFlag            = "usage-summary-interval"
+		tempFileMetricsPortFlag             = "temp-file-metrics-port"
+		dataPlaneFailurePortFlag            = "data-plane-failure-port"
+		tokenReviewPortFlag                 = "token-review-port"
+		nginxReloadMinIntervalFlag          = "nginx-reload-min-interval"
+		webhookPortFlag                     = "webhook-port"
+		webhookConfigurationNameFlag        = "webhook-configuration-name"
+		webhookMaxRoutesPerGatewayFlag      = "webhook-max-routes-per-gateway"
+		manageCRDsFlag                      = "manage-crds"
+		ingressClassFlag                    = "ingress-class"
+		ingressGatewayFlag                  = "ingress-gateway"
+		clusterDomainFlag                   = "cluster-domain"
 	)
 
 	// flag values
@@ -135,6 +188,7 @@ func createControllerCommand() *cobra.Command {
 		}
 		disableMetrics    bool
 		metricsSecure     bool
+		debugEndpoints    bool
 		metricsListenPort = intValidatingValue{
 			validator: validatePort,
 			value:     9113,
@@ -158,6 +212,127 @@ func createControllerCommand() *cobra.Command {
 
 		snippetsFilters bool
 
+		gatewayTests bool
+
+		clusterDefaultPolicies bool
+
+		manageCRDs bool
+
+		fips bool
+
+		moduleLogLevels = stringValidatingValue{
+			validator: validateModuleLogLevels,
+		}
+		logLevelsConfigMapName = stringValidatingValue{
+			validator: validateResourceName,
+		}
+
+		configExportDir = stringValidatingValue{
+			validator: validateAbsolutePath,
+		}
+		configExportConfigMap bool
+
+		upstreamMapConfigMap bool
+
+		configHistorySize = intValidatingValue{
+			validator: validateConfigHistorySize,
+			value:     10,
+		}
+
+		canaryPrometheusAddress = stringValidatingValue{
+			validator: validateHTTPURL,
+		}
+		canaryAnalysisInterval = stringValidatingValue{
+			validator: validateCanaryAnalysisInterval,
+			value:     "1m",
+		}
+		canaryErrorRateQuery = stringValidatingValue{
+			validator: validateCanaryAnalysisQuery,
+			value:     canary.DefaultErrorRateQuery,
+		}
+		canaryLatencyQuery = stringValidatingValue{
+			validator: validateCanaryAnalysisQuery,
+			value:     canary.DefaultLatencyQuery,
+		}
+
+		apiCatalogPath = stringValidatingValue{
+			validator: validateAPICatalogPath,
+		}
+		apiCatalogInterval = stringValidatingValue{
+			validator: validateAPICatalogInterval,
+			value:     "1m",
+		}
+
+		outlierPrometheusAddress = stringValidatingValue{
+			validator: validateHTTPURL,
+		}
+		outlierHookInterval = stringValidatingValue{
+			validator: validateOutlierHookInterval,
+			value:     "30s",
+		}
+		outlierErrorRateQuery = stringValidatingValue{
+			validator: validateOutlierHookQuery,
+			value:     canary.DefaultErrorRateQuery,
+		}
+
+		wasmHookModule = stringValidatingValue{
+			validator: validateAbsolutePath,
+		}
+		wasmHookTimeout = stringValidatingValue{
+			validator: validateWASMHookTimeout,
+			value:     "1s",
+		}
+		wasmHookMaxMemory = intValidatingValue{
+			validator: validateWASMHookMaxMemory,
+			value:     64,
+		}
+
+		ipamMetalLBAddressPool = stringValidatingValue{
+			validator: validateResourceName,
+		}
+		ipamEndpoint = stringValidatingValue{
+			validator: validateHTTPURL,
+		}
+		ingressClass = stringValidatingValue{
+			validator: validateResourceName,
+		}
+		ingressGateway = stringValidatingValue{
+			validator: validateNamespacedName,
+		}
+		clusterDomain = stringValidatingValue{
+			validator: validateQualifiedName,
+			value:     defaultDomain,
+		}
+
+		tenantAttributionPort = intValidatingValue{
+			validator: validatePort,
+		}
+		usageSummaryInterval = stringValidatingValue{
+			validator: validateUsageSummaryInterval,
+		}
+		tempFileMetricsPort = intValidatingValue{
+			validator: validatePort,
+		}
+		dataPlaneFailurePort = intValidatingValue{
+			validator: validatePort,
+		}
+		tokenReviewPort = intValidatingValue{
+			validator: validatePort,
+		}
+		nginxReloadMinInterval = stringValidatingValue{
+			validator: validateNginxReloadMinInterval,
+		}
+
+		webhookPort = intValidatingValue{
+			validator: validatePort,
+		}
+		webhookConfigurationName = stringValidatingValue{
+			validator: validateResourceName,
+		}
+		webhookMaxRoutesPerGateway = intValidatingValue{
+			validator: validateWebhookMaxRoutesPerGateway,
+		}
+
 		plus               bool
 		nginxDockerSecrets = stringSliceValidatingValue{
 			validator: validateResourceName,
@@ -191,8 +366,14 @@ func createControllerCommand() *cobra.Command {
 		Short: "Run the NGINX Gateway Fabric control plane",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			atom := zap.NewAtomicLevel()
-
-			logger := ctlrZap.New(ctlrZap.Level(atom))
+			moduleLevels := logging.NewModuleLevels(atom)
+
+			logger := logging.WithLayers(
+				ctlrZap.New(ctlrZap.Level(moduleLevels)),
+				errcodes.Layer{},
+				redact.Layer{},
+				moduleLevels.Layer(),
+			).WithValues(logging.KeySchemaVersion, logging.SchemaVersion)
 			klog.SetLogger(logger)
 
 			commit, date, dirty := getBuildInfo()
@@ -209,6 +390,19 @@ func createControllerCommand() *cobra.Command {
 				return fmt.Errorf("error validating ports: %w", err)
 			}
 
+			// the value was validated by the flag, so the error can be ignored
+			defaultModuleLogLevels, _ := logging.ParseModuleLevels(moduleLogLevels.value)
+			if err := moduleLevels.SetModuleLevels(defaultModuleLogLevels); err != nil {
+				return fmt.Errorf("error setting module log levels: %w", err)
+			}
+
+			if fips && !fips140.Enabled() {
+				return errors.New(
+					"FIPS mode requires a FIPS 140-3 enabled binary; " +
+						"build with GOFIPS140 set or run with GODEBUG=fips140=on",
+				)
+			}
+
 			imageSource := os.Getenv("BUILD_AGENT")
 			if imageSource != "gha" && imageSource != "local" {
 				imageSource = "unknown"
@@ -230,6 +424,39 @@ func createControllerCommand() *cobra.Command {
 				return fmt.Errorf("error parsing telemetry endpoint insecure: %w", err)
 			}
 
+			// the value was validated by the flag, so the error can be ignored
+			canaryInterval, _ := time.ParseDuration(canaryAnalysisInterval.value)
+			// the value was validated by the flag, so the error can be ignored
+			catalogInterval, _ := time.ParseDuration(apiCatalogInterval.value)
+			// the value was validated by the flag, so the error can be ignored
+			outlierInterval, _ := time.ParseDuration(outlierHookInterval.value)
+			// the value was validated by the flag, so the error can be ignored
+			wasmTimeout, _ := time.ParseDuration(wasmHookTimeout.value)
+
+			var summaryInterval time.Duration
+			if usageSummaryInterval.value != "" {
+				if tenantAttributionPort.value == 0 {
+					return fmt.Errorf("%s requires %s", usageSummaryIntervalFlag, tenantAttributionPortFlag)
+				}
+				// the value was validated by the flag, so the error can be ignored
+				summaryInterval, _ = time.ParseDuration(usageSummaryInterval.value)
+			}
+
+			var reloadMinInterval time.Duration
+			if nginxReloadMinInterval.value != "" {
+				// the value was validated by the flag, so the error can be ignored
+				reloadMinInterval, _ = time.ParseDuration(nginxReloadMinInterval.value)
+			}
+
+			if debugEndpoints {
+				if disableMetrics {
+					return fmt.Errorf("%s cannot be used with %s", debugEndpointsFlag, metricsDisableFlag)
+				}
+				if !metricsSecure {
+					return fmt.Errorf("%s requires %s", debugEndpointsFlag, metricsSecureFlag)
+				}
+			}
+
 			var usageReportConfig config.UsageReportConfig
 			if plus {
 				usageReportConfig, err = buildUsageReportConfig(usageReportParams)
@@ -250,16 +477,19 @@ func createControllerCommand() *cobra.Command {
 				ConfigName:       configName.String(),
 				Logger:           logger,
 				AtomicLevel:      atom,
+				ModuleLogLevels:  moduleLevels,
 				GatewayClassName: gatewayClassName.value,
+				ClusterDomain:    clusterDomain.value,
 				GatewayPodConfig: podConfig,
 				HealthConfig: config.HealthConfig{
 					Enabled: !disableHealth,
 					Port:    healthListenPort.value,
 				},
 				MetricsConfig: config.MetricsConfig{
-					Enabled: !disableMetrics,
-					Port:    metricsListenPort.value,
-					Secure:  metricsSecure,
+					Enabled:        !disableMetrics,
+					Port:           metricsListenPort.value,
+					Secure:         metricsSecure,
+					DebugEndpoints: debugEndpoints,
 				},
 				LeaderElection: config.LeaderElectionConfig{
 					Enabled:  !disableLeaderElection,
@@ -282,6 +512,8 @@ func createControllerCommand() *cobra.Command {
 					Values: flagValues,
 				},
 				SnippetsFilters:        snippetsFilters,
+				GatewayTests:           gatewayTests,
+				ClusterDefaultPolicies: clusterDefaultPolicies,
 				NginxDockerSecretNames: nginxDockerSecrets.values,
 				AgentTLSSecretName:     agentTLSSecretName.value,
 				NGINXSCCName:           nginxSCCName.value,
@@ -293,9 +525,66 @@ func createControllerCommand() *cobra.Command {
 				},
 				EndpointPickerDisableTLS:    endpointPickerDisableTLS,
 				EndpointPickerTLSSkipVerify: endpointPickerTLSSkipVerify,
+				FIPS:                        fips,
+				DefaultModuleLogLevels:      defaultModuleLogLevels,
+				LogLevelsConfigMapName:      logLevelsConfigMapName.value,
+				ConfigExport: config.ConfigExportConfig{
+					Dir:       configExportDir.value,
+					ConfigMap: configExportConfigMap,
+				},
+				UpstreamMapConfigMap: upstreamMapConfigMap,
+				ConfigHistorySize:    configHistorySize.value,
+				CanaryAnalysis: config.CanaryAnalysisConfig{
+					PrometheusAddress: canaryPrometheusAddress.value,
+					ErrorRateQuery:    canaryErrorRateQuery.value,
+					LatencyQuery:      canaryLatencyQuery.value,
+					Interval:          canaryInterval,
+				},
+				APICatalog: config.APICatalogConfig{
+					Path:     apiCatalogPath.value,
+					Interval: catalogInterval,
+				},
+				OutlierHook: config.OutlierHookConfig{
+					PrometheusAddress: outlierPrometheusAddress.value,
+					ErrorRateQuery:    outlierErrorRateQuery.value,
+					Interval:          outlierInterval,
+				},
+				WASMHook: config.WASMHookConfig{
+					ModulePath:     wasmHookModule.value,
+					Timeout:        wasmTimeout,
+					MaxMemoryBytes: int64(wasmHookMaxMemory.value) * 1024 * 1024,
+				},
+				IPAM: config.IPAMConfig{
+					MetalLBAddressPool: ipamMetalLBAddressPool.value,
+					Endpoint:           ipamEndpoint.value,
+				},
+				TenantAttributionPort:  tenantAttributionPort.value,
+				UsageSummaryInterval:   summaryInterval,
+				TempFileMetricsPort:    tempFileMetricsPort.value,
+				DataPlaneFailurePort:   dataPlaneFailurePort.value,
+				TokenReviewPort:        tokenReviewPort.value,
+				NginxReloadMinInterval: reloadMinInterval,
+				Webhook: config.WebhookConfig{
+					ConfigurationName:   webhookConfigurationName.value,
+					Port:                webhookPort.value,
+					MaxRoutesPerGateway: webhookMaxRoutesPerGateway.value,
+				},
+				CRDManagement: config.CRDManagementConfig{
+					Enabled: manageCRDs,
+				},
 			}
 
-			if err := controller.StartManager(conf); err != nil {
+			var customRouteKinds []graph.CustomRouteKind
+			if ingressClass.value != "" {
+				gwNamespace, gwName, _ := strings.Cut(ingressGateway.value, "/")
+				customRouteKinds = append(customRouteKinds, ingress.NewRouteKind(ingress.Config{
+					Logger:    logger.WithName("ingress"),
+					ClassName: ingressClass.value,
+					Gateway:   types.NamespacedName{Namespace: gwNamespace, Name: gwName},
+				}))
+			}
+
+			if err := controller.StartManager(conf, customRouteKinds...); err != nil {
 				return fmt.Errorf("failed to start control loop: %w", err)
 			}
 
@@ -384,7 +673,18 @@ func createControllerCommand() *cobra.Command {
 		metricsSecureFlag,
 		false,
 		"Enable serving metrics via https. By default metrics are served via http."+
-			" Please note that this endpoint will be secured with a self-signed certificate.",
+			" Please note that this endpoint will be secured with a self-signed certificate."+
+			" Requests are authenticated and authorized, so clients need get access to /metrics"+
+			" (and /debug/* for the debug endpoints).",
+	)
+
+	cmd.Flags().BoolVar(
+		&debugEndpoints,
+		debugEndpointsFlag,
+		false,
+		"Serve the debug endpoints /debug/graph, /debug/config, /debug/config/history and /debug/route-model "+
+			"on the metrics server. The endpoints expose the internal state of the control plane, so they "+
+			"require metrics-secure-serving.",
 	)
 
 	cmd.Flags().BoolVar(
@@ -512,6 +812,23 @@ func createControllerCommand() *cobra.Command {
 			"generated NGINX config for HTTPRoute and GRPCRoute resources.",
 	)
 
+	cmd.Flags().BoolVar(
+		&gatewayTests,
+		gatewayTestsFlag,
+		false,
+		"Enable GatewayTests feature. GatewayTests declare the expected behaviors of the routing of a Gateway, "+
+			"which are continuously verified by sending requests through the data plane of the Gateway.",
+	)
+
+	cmd.Flags().BoolVar(
+		&clusterDefaultPolicies,
+		clusterDefaultPoliciesFlag,
+		false,
+		"Enable ClusterDefaultPolicies feature. ClusterDefaultPolicies define the default client, observability, "+
+			"and upstream settings of the Routes and Services in the Namespaces selected by a label selector, "+
+			"which the owners of a Namespace can override with their own policies.",
+	)
+
 	cmd.Flags().Var(
 		&nginxSCCName,
 		nginxSCCFlag,
@@ -519,6 +836,284 @@ func createControllerCommand() *cobra.Command {
 			` Only applicable in OpenShift.`,
 	)
 
+	cmd.Flags().BoolVar(
+		&fips,
+		fipsFlag,
+		false,
+		"Enable FIPS mode. Restricts the TLS protocols, ciphers, and curves used by NGINX and the control plane "+
+			"to FIPS-approved values, and rejects certificates that are not FIPS compliant. "+
+			"Requires a FIPS 140-3 enabled binary.",
+	)
+
+	cmd.Flags().Var(
+		&moduleLogLevels,
+		moduleLogLevelsFlag,
+		"The logging levels of individual control plane modules, in the format 'module1=level1,module2=level2'. "+
+			"A module is the dot-separated name of a logger, for example 'eventHandler' or 'provisioner'. "+
+			"Supported levels are info, debug, and error. Modules without a level use the global logging level.",
+	)
+
+	cmd.Flags().Var(
+		&logLevelsConfigMapName,
+		logLevelsConfigMapFlag,
+		"The name of the ConfigMap, in the same namespace as the control plane Pod, that overrides the logging "+
+			"levels of control plane modules at runtime. Each key is a module name, and each value is a level. "+
+			"The reserved key 'nginx' overrides the NGINX error log level of all Gateways.",
+	)
+
+	cmd.Flags().Var(
+		&configExportDir,
+		configExportDirFlag,
+		"The absolute path of a directory to export the complete NGINX configuration of every Gateway to, "+
+			"for use by NGINX instances that are not managed by the control plane. The configuration of a Gateway "+
+			"is written to the <namespace>/<name> subdirectory.",
+	)
+
+	cmd.Flags().BoolVar(
+		&configExportConfigMap,
+		configExportConfigMapFlag,
+		false,
+		"Export the complete NGINX configuration of every Gateway to the ConfigMap <gateway-name>-nginx-config, "+
+			"and the files with secrets to the Secret with the same name, in the namespace of the Gateway, "+
+			"for use by NGINX instances that are not managed by the control plane.",
+	)
+
+	cmd.Flags().BoolVar(
+		&upstreamMapConfigMap,
+		upstreamMapConfigMapFlag,
+		false,
+		"Publish the mapping of the Routes of every Gateway to the generated NGINX upstreams and their zones, "+
+			"and of the upstreams to the Services and their endpoints, in the JSON format to the ConfigMap "+
+			"<gateway-name>-upstream-map in the namespace of the Gateway, for use by external tooling.",
+	)
+
+	cmd.Flags().Var(
+		&configHistorySize,
+		configHistorySizeFlag,
+		"The number of the latest versions of the NGINX configuration of every Gateway that are retained, "+
+			"with the time they were generated and the resources that triggered them. The redacted versions are "+
+			"served on the metrics server at /debug/config/history when debug-endpoints is set. "+
+			"Set to 0 to disable the history.",
+	)
+
+	cmd.Flags().Var(
+		&canaryPrometheusAddress,
+		canaryPrometheusAddressFlag,
+		"The address of the Prometheus server, for example http://prometheus.monitoring:9090, that the metrics of "+
+			"the canaries of the Routes are queried from. When set, the Routes with the "+
+			canary.BackendAnnotation+" annotation are analyzed, and their weighted rollout is paused or reverted "+
+			"once the canary breaches its error rate or latency objective.",
+	)
+
+	cmd.Flags().Var(
+		&canaryAnalysisInterval,
+		canaryAnalysisIntervalFlag,
+		"The interval between the analyses of the canaries of the Routes. Must be at least 1s.",
+	)
+
+	cmd.Flags().Var(
+		&canaryErrorRateQuery,
+		canaryErrorRateQueryFlag,
+		"The Prometheus query of the error rate, a ratio between 0 and 1, of a canary. "+
+			canary.UpstreamPlaceholder+" is replaced with the name of the NGINX upstream of the canary.",
+	)
+
+	cmd.Flags().Var(
+		&canaryLatencyQuery,
+		canaryLatencyQueryFlag,
+		"The Prometheus query of the latency, in seconds, of a canary. "+
+			canary.UpstreamPlaceholder+" is replaced with the name of the NGINX upstream of the canary.",
+	)
+
+	cmd.Flags().Var(
+		&apiCatalogPath,
+		apiCatalogPathFlag,
+		"The path, for example /.well-known/api-catalog, at which NGINX serves the catalog of the OpenAPI specs of "+
+			"the Routes of a Gateway on every server of the Gateway. When set, the specs of the HTTPRoutes with the "+
+			apicatalog.SpecAnnotation+" annotation are periodically fetched and aggregated per Gateway.",
+	)
+
+	cmd.Flags().Var(
+		&apiCatalogInterval,
+		apiCatalogIntervalFlag,
+		"The interval between the aggregations of the OpenAPI specs of the Routes. Must be at least 10s.",
+	)
+
+	cmd.Flags().Var(
+		&outlierPrometheusAddress,
+		outlierPrometheusAddressFlag,
+		"The address of the Prometheus server, for example http://prometheus.monitoring:9090, that the error rates "+
+			"of the Routes are queried from. When set, the Routes with the "+outlier.MaxErrorRateAnnotation+
+			" annotation trigger a diagnostic action on the NGINX Pods of their Gateways, such as debug logging, "+
+			"for a bounded duration once their error rate crosses the threshold.",
+	)
+
+	cmd.Flags().Var(
+		&outlierHookInterval,
+		outlierHookIntervalFlag,
+		"The interval between the evaluations of the error rates of the Routes of the outlier hook. Must be at least 1s.",
+	)
+
+	cmd.Flags().Var(
+		&outlierErrorRateQuery,
+		outlierErrorRateQueryFlag,
+		"The Prometheus query of the error rate, a ratio between 0 and 1, of an upstream of a Route. "+
+			canary.UpstreamPlaceholder+" is replaced with the name of the NGINX upstream.",
+	)
+
+	cmd.Flags().Var(
+		&wasmHookModule,
+		wasmHookModuleFlag,
+		"Experimental: the absolute path to a WASM module, a WASI command, that mutates the routing state of every "+
+			"Gateway. The module reads the state as JSON in the v1alpha1 format from its standard input and writes "+
+			"the mutated state to its standard output. The module runs in a WASI runtime embedded in the control plane, "+
+			"in the background, and the configuration of a Gateway is applied once the module completed for it. "+
+			"If the module fails, the state is applied without its changes.",
+	)
+
+	cmd.Flags().Var(
+		&wasmHookTimeout,
+		wasmHookTimeoutFlag,
+		"The maximum execution time of the WASM module set by --"+wasmHookModuleFlag+" for a Gateway. "+
+			"Must be at most 10s.",
+	)
+
+	cmd.Flags().Var(
+		&wasmHookMaxMemory,
+		wasmHookMaxMemoryFlag,
+		"The maximum memory, in MiB, of the WASM module set by --"+wasmHookModuleFlag+".",
+	)
+
+	cmd.Flags().Var(
+		&ipamMetalLBAddressPool,
+		ipamMetalLBAddressPoolFlag,
+		"The MetalLB address pool that the addresses of the nginx Services of the Gateways are allocated from. "+
+			"The allocated addresses are reported in the status of the Gateways.",
+	)
+
+	cmd.Flags().Var(
+		&ipamEndpoint,
+		ipamEndpointFlag,
+		"The URL of the IPAM service, for example http://ipam.example.com/api, that the addresses of the nginx "+
+			"Services of the Gateways are allocated from. The address of a Gateway is allocated with "+
+			"PUT <url>/gateways/<namespace>/<name> and released with DELETE on the same path. "+
+			"The allocated addresses are reported in the status of the Gateways.",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(ipamMetalLBAddressPoolFlag, ipamEndpointFlag)
+
+	cmd.Flags().Var(
+		&ingressClass,
+		ingressClassFlag,
+		"The class of the Ingresses that are translated to HTTPRoutes attached to the Gateway set by --"+
+			ingressGatewayFlag+". The class is read from spec.ingressClassName or the "+
+			"kubernetes.io/ingress.class annotation. Used to migrate from the Ingress API to the Gateway API.",
+	)
+
+	cmd.Flags().Var(
+		&ingressGateway,
+		ingressGatewayFlag,
+		"The Gateway, in the format <namespace>/<name>, that the Ingresses of the class set by --"+
+			ingressClassFlag+" are attached to. The listeners of the Gateway must allow the kind "+
+			"{group: networking.k8s.io, kind: Ingress} in allowedRoutes.kinds.",
+	)
+
+	cmd.Flags().Var(
+		&clusterDomain,
+		clusterDomainFlag,
+		"The DNS domain of the Kubernetes cluster, which is used to build the hostnames of the Services "+
+			"that are bound to a Gateway as their waypoint.",
+	)
+
+	cmd.MarkFlagsRequiredTogether(ingressClassFlag, ingressGatewayFlag)
+
+	cmd.Flags().Var(
+		&tenantAttributionPort,
+		tenantAttributionPortFlag,
+		"The UDP port on which the requests that NGINX attributes to tenants are received. The requests and bytes "+
+			"of every tenant are exposed as metrics. The control plane Service must expose the port. "+
+			"If not set, the requests are not received. Format: [1024 - 65535]",
+	)
+
+	cmd.Flags().Var(
+		&usageSummaryInterval,
+		usageSummaryIntervalFlag,
+		"The window of the usage summaries of the Gateways, for example 1h. At the end of every window, "+
+			"the requests, bytes, error rate, and top routes of every Gateway are published to the ConfigMap "+
+			"<gateway-name>-usage-summary in the namespace of the Gateway. Requires tenant-attribution-port. "+
+			"Must be at least 1m. If not set, the usage of the Gateways is not summarized.",
+	)
+
+	cmd.Flags().Var(
+		&tempFileMetricsPort,
+		tempFileMetricsPortFlag,
+		"The UDP port on which the writes of NGINX to its temporary files are received. The writes of the request "+
+			"bodies and the responses of every Gateway are exposed as metrics. The Gateways opt into the reporting "+
+			"of the writes with the tempFiles.reportWrites field of their NginxProxy. The control plane Service "+
+			"must expose the port. If not set, the writes are not received. Format: [1024 - 65535]",
+	)
+
+	cmd.Flags().Var(
+		&dataPlaneFailurePort,
+		dataPlaneFailurePortFlag,
+		"The UDP port on which the crashes of the NGINX worker processes are received. The crashes are reported "+
+			"in the DataPlaneHealthy condition of the Gateways and exposed as metrics, together with the OOM kills "+
+			"of the NGINX containers and the failures to apply the NGINX configuration. The control plane Service "+
+			"must expose the port. If not set, the crashes of the worker processes are not received. "+
+			"Format: [1024 - 65535]",
+	)
+
+	cmd.Flags().Var(
+		&tokenReviewPort,
+		tokenReviewPortFlag,
+		"The TCP port on which the bearer tokens of the requests are verified with the Kubernetes TokenReview API. "+
+			"NGINX verifies the tokens of the requests to the Routes whose ClientSettingsPolicy sets "+
+			"the TokenReview authentication mode. The control plane Service must expose the port. If not set, "+
+			"the tokens are not verified, and such requests are rejected. Format: [1024 - 65535]",
+	)
+
+	cmd.Flags().Var(
+		&nginxReloadMinInterval,
+		nginxReloadMinIntervalFlag,
+		"The minimum interval between the reloads of NGINX, for example 5s. The changes of the certificates and "+
+			"the routes are applied at most once per interval, and the changes of only the endpoints of "+
+			"the upstreams at most once per four intervals, unless they are applied through the NGINX Plus API "+
+			"without a reload. Must be greater than 0 and at most 1m. If not set, the reloads are not rate-limited.",
+	)
+
+	cmd.Flags().Var(
+		&webhookPort,
+		webhookPortFlag,
+		"The port of the validating admission webhook for HTTPRoutes, GRPCRoutes, and SnippetsFilters. "+
+			"The webhook rejects the resources that NGINX Gateway Fabric would mark as invalid or unsupported. "+
+			"The webhook uses the TLS certificate of the control plane. "+
+			"If not set, the webhook is disabled. Format: [1024 - 65535]",
+	)
+
+	cmd.Flags().Var(
+		&webhookConfigurationName,
+		webhookConfigurationNameFlag,
+		"The name of the ValidatingWebhookConfiguration of the webhook set by --"+webhookPortFlag+". "+
+			"The CA certificate of the control plane is injected into it. "+
+			"If not set, the CA certificate must be set in the ValidatingWebhookConfiguration by other means.",
+	)
+
+	cmd.Flags().Var(
+		&webhookMaxRoutesPerGateway,
+		webhookMaxRoutesPerGatewayFlag,
+		"The maximum number of Routes that can be attached to a Gateway. The webhook set by --"+webhookPortFlag+
+			" rejects the Routes that exceed it. If set to 0, the number of Routes is not limited.",
+	)
+
+	cmd.Flags().BoolVar(
+		&manageCRDs,
+		manageCRDsFlag,
+		false,
+		"Install and upgrade the NGINX Gateway Fabric CRDs on start, and migrate the stored resources to the "+
+			"storage versions of the CRDs. The CRDs installed by a newer version are not downgraded. "+
+			"The Gateway API CRDs are not managed.",
+	)
+
 	return cmd
 }
 
@@ -670,7 +1265,7 @@ func createInitializeCommand() *cobra.Command {
 				return fmt.Errorf("could not get cluster UID: %w", err)
 			}
 
-			logger := ctlrZap.New()
+			logger := logging.WithLayers(ctlrZap.New(), redact.Layer{})
 			klog.SetLogger(logger)
 			logger.Info(
 				"Starting init container",
@@ -691,7 +1286,7 @@ func createInitializeCommand() *cobra.Command {
 
 			return initialize(initializeConfig{
 				fileManager:   file.NewStdLibOSFileManager(),
-				fileGenerator: ngxConfig.NewGeneratorImpl(plus, nil, logger.WithName("generator")),
+				fileGenerator: ngxConfig.NewGeneratorImpl(plus, false, nil, logger.WithName("generator")),
 				logger:        logger,
 				podUID:        podUID,
 				clusterUID:    clusterUID,
@@ -779,6 +1374,342 @@ func createEndpointPickerCommand() *cobra.Command {
 	return cmd
 }
 
+func createConfigReloaderCommand() *cobra.Command {
+	// flag names
+	const (
+		configDirFlag  = "config-dir"
+		secretsDirFlag = "secrets-dir"
+		pidFileFlag    = "pid-file"
+		intervalFlag   = "interval"
+	)
+
+	// flag values
+	var (
+		configDir  string
+		secretsDir string
+		pidFile    string
+		interval   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "config-reloader",
+		Short: "Copy the exported NGINX configuration to NGINX and reload NGINX when the configuration changes",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("%s must be positive", intervalFlag)
+			}
+
+			logger := ctlrZap.New().WithName("config-reloader")
+			logger.Info(
+				"Starting config reloader",
+				"config directory", configDir,
+				"secrets directory", secretsDir,
+				"pid file", pidFile,
+			)
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGTERM, os.Interrupt)
+			defer stop()
+
+			runConfigReloader(ctx, configReloaderConfig{
+				fileManager: file.NewStdLibOSFileManager(),
+				logger:      logger,
+				signalNginx: sighup,
+				pidFile:     pidFile,
+				sources: []exportSource{
+					{dir: configDir, fileType: file.TypeRegular},
+					{dir: secretsDir, fileType: file.TypeSecret},
+				},
+				nginxDir: "/etc/nginx",
+				interval: interval,
+			})
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&configDir,
+		configDirFlag,
+		"/var/run/nginx-export/config",
+		"The directory of the mounted ConfigMap with the exported NGINX configuration",
+	)
+
+	cmd.Flags().StringVar(
+		&secretsDir,
+		secretsDirFlag,
+		"/var/run/nginx-export/secrets",
+		"The directory of the mounted Secret with the exported NGINX configuration files that contain secrets",
+	)
+
+	cmd.Flags().StringVar(
+		&pidFile,
+		pidFileFlag,
+		"/var/run/nginx/nginx.pid",
+		"The pid file of the NGINX master process",
+	)
+
+	cmd.Flags().DurationVar(
+		&interval,
+		intervalFlag,
+		2*time.Second,
+		"The interval of checking the exported NGINX configuration for changes. "+
+			"Must be parsable by https://pkg.go.dev/time#ParseDuration",
+	)
+
+	return cmd
+}
+
+func createSupportBundleCommand() *cobra.Command {
+	// flag names
+	const (
+		namespaceFlag     = "namespace"
+		selectorFlag      = "selector"
+		outputFlag        = "output"
+		metricsPortFlag   = "metrics-port"
+		metricsSecureFlag = "metrics-secure"
+		logTailLinesFlag  = "log-tail-lines"
+	)
+
+	// flag values
+	var (
+		namespace = stringValidatingValue{
+			validator: validateResourceName,
+			value:     "nginx-gateway",
+		}
+		selector    string
+		output      string
+		metricsPort = intValidatingValue{
+			validator: validatePort,
+			value:     int(config.DefaultNginxMetricsPort),
+		}
+		metricsSecure bool
+		logTailLines  int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect a support bundle with the logs, versions, configuration, and resource statuses",
+		Long: "Collect the control plane and data plane logs, versions, the redacted generated NGINX configuration, " +
+			"a dump of the internal graph, the statuses of the Gateway API and NGINX Gateway Fabric resources, and a " +
+			"snapshot of the control plane metrics into a single archive for support cases. Private keys, JWTs, and " +
+			"htpasswd credentials are redacted from all collected data.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := labels.Parse(selector); err != nil {
+				return fmt.Errorf("error parsing selector: %w", err)
+			}
+
+			restConfig, err := k8sConfig.GetConfig()
+			if err != nil {
+				return fmt.Errorf("error getting kubeconfig: %w", err)
+			}
+
+			k8sClient, err := client.New(restConfig, client.Options{Scheme: newSupportBundleScheme()})
+			if err != nil {
+				return fmt.Errorf("error creating k8s client: %w", err)
+			}
+
+			clientset, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("error creating k8s clientset: %w", err)
+			}
+
+			if output == "" {
+				output = fmt.Sprintf("nginx-gateway-fabric-support-bundle-%d.tar.gz", time.Now().Unix())
+			}
+
+			out, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("error creating support bundle file: %w", err)
+			}
+			defer out.Close()
+
+			var metrics metricsConnector = &proxyMetricsConnector{clientset: clientset, port: metricsPort.value}
+			if metricsSecure {
+				metrics = &secureMetricsConnector{
+					clientset: clientset,
+					forward:   newPortForwarder(restConfig, clientset),
+					port:      metricsPort.value,
+				}
+			}
+
+			if err := collectSupportBundle(
+				cmd.Context(),
+				supportBundleConfig{
+					k8sClient:    k8sClient,
+					clientset:    clientset,
+					namespace:    namespace.value,
+					selector:     selector,
+					metrics:      metrics,
+					logTailLines: logTailLines,
+				},
+				out,
+			); err != nil {
+				return fmt.Errorf("error collecting support bundle: %w", err)
+			}
+
+			cmd.Printf("Support bundle written to %s\n", output)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Var(
+		&namespace,
+		namespaceFlag,
+		"The namespace of the NGINX Gateway Fabric control plane.",
+	)
+
+	cmd.Flags().StringVar(
+		&selector,
+		selectorFlag,
+		fwcontroller.AppNameLabel+"=nginx-gateway-fabric",
+		"The label selector of the NGINX Gateway Fabric control plane Pods.",
+	)
+
+	cmd.Flags().StringVar(
+		&output,
+		outputFlag,
+		"",
+		"The path of the support bundle archive. Defaults to "+
+			"nginx-gateway-fabric-support-bundle-<timestamp>.tar.gz in the current directory.",
+	)
+
+	cmd.Flags().Var(
+		&metricsPort,
+		metricsPortFlag,
+		"The port of the NGINX Gateway Fabric control plane metrics server.",
+	)
+
+	cmd.Flags().BoolVar(
+		&metricsSecure,
+		metricsSecureFlag,
+		true,
+		"Set if the NGINX Gateway Fabric control plane metrics server is served over HTTPS, which the debug "+
+			"endpoints require. The endpoints are then collected through a port-forward to the Pods, authenticated "+
+			"with a short-lived token of the ServiceAccount of the Pods. Unset to collect the metrics served over HTTP "+
+			"through the Kubernetes API server proxy.",
+	)
+
+	cmd.Flags().Int64Var(
+		&logTailLines,
+		logTailLinesFlag,
+		1000,
+		"The number of lines of logs to collect from each container.",
+	)
+
+	return cmd
+}
+
+func createSimulateCommand() *cobra.Command {
+	// flag names
+	const (
+		manifestsFlag         = "manifests"
+		outputDirFlag         = "output-dir"
+		gwAPIExperimentalFlag = "gateway-api-experimental-features"
+		failOnProblemsFlag    = "fail-on-problems"
+	)
+
+	// flag values
+	var (
+		gatewayCtlrName = stringValidatingValue{
+			validator: validateGatewayControllerName,
+			value:     domain + "/nginx-gateway-controller",
+		}
+		gatewayClassName = stringValidatingValue{
+			validator: validateResourceName,
+			value:     "nginx",
+		}
+		manifestsDir         string
+		outputDir            string
+		plus                 bool
+		experimentalFeatures bool
+		failOnProblems       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate the control plane with a directory of manifests, without a cluster",
+		Long: "Load the Kubernetes manifests from a directory, build the NGINX configuration and the statuses of " +
+			"the resources the same way the control plane would do it, and print them. No cluster is needed, so " +
+			"CI pipelines can validate changes to routes before they are merged. The NGINX configuration is not " +
+			"validated by NGINX, and the Gateway addresses are not part of the statuses.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// logs go to stderr, so that the output can be processed by other tools
+			logger := ctlrZap.New().WithName("simulation")
+
+			return runSimulation(
+				cmd.Context(),
+				simulationConfig{
+					manifestsDir:   manifestsDir,
+					outputDir:      outputDir,
+					failOnProblems: failOnProblems,
+					simulation: controller.SimulationConfig{
+						Logger:               logger,
+						GatewayCtlrName:      gatewayCtlrName.value,
+						GatewayClassName:     gatewayClassName.value,
+						Plus:                 plus,
+						ExperimentalFeatures: experimentalFeatures,
+						FIPS:                 fips140.Enabled(),
+					},
+				},
+				cmd.OutOrStdout(),
+			)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&manifestsDir,
+		manifestsFlag,
+		"",
+		"The directory with the YAML and JSON manifests of the resources. Subdirectories are included. "+
+			"The resources without a namespace are put in the default namespace.",
+	)
+	utilruntime.Must(cmd.MarkFlagRequired(manifestsFlag))
+
+	cmd.Flags().Var(
+		&gatewayCtlrName,
+		gatewayCtlrNameFlag,
+		fmt.Sprintf(gatewayCtlrNameUsageFmt, domain),
+	)
+
+	cmd.Flags().Var(
+		&gatewayClassName,
+		gatewayClassFlag,
+		gatewayClassNameUsage+" If the manifests don't contain the GatewayClass, the simulation adds it.",
+	)
+
+	cmd.Flags().BoolVar(
+		&plus,
+		plusFlag,
+		false,
+		"Generate the configuration for NGINX Plus.",
+	)
+
+	cmd.Flags().BoolVar(
+		&experimentalFeatures,
+		gwAPIExperimentalFlag,
+		false,
+		"Enable the experimental features of Gateway API.",
+	)
+
+	cmd.Flags().StringVar(
+		&outputDir,
+		outputDirFlag,
+		"",
+		"The directory to write the NGINX configuration files of each Gateway to, "+
+			"in the subdirectory <namespace>/<name>.",
+	)
+
+	cmd.Flags().BoolVar(
+		&failOnProblems,
+		failOnProblemsFlag,
+		false,
+		"Exit with an error if a resource is not accepted, programmed, or has unresolved references.",
+	)
+
+	return cmd
+}
+
 func addEPPConnectionFlags(cmd *cobra.Command, disableTLS, tlsSkipVerify *bool) {
 	cmd.Flags().BoolVar(
 		disableTLS,
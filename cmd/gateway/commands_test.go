@@ -142,6 +142,7 @@ func TestControllerCmdFlagValidation(t *testing.T) {
 				"--metrics-port=9114",
 				"--metrics-disable",
 				"--metrics-secure-serving",
+				"--debug-endpoints",
 				"--health-port=8081",
 				"--health-disable",
 				"--leader-election-lock-name=my-lock",
@@ -156,6 +157,8 @@ func TestControllerCmdFlagValidation(t *testing.T) {
 				"--usage-report-client-ssl-secret=client-secret",
 				"--usage-report-enforce-initial-report",
 				"--snippets-filters",
+				"--gateway-tests",
+				"--cluster-default-policies",
 				"--nginx-scc=nginx-sscc-name",
 				"--nginx-one-dataplane-key-secret=dataplane-key-secret",
 				"--nginx-one-telemetry-endpoint-host=telemetry-endpoint-host",
@@ -163,6 +166,39 @@ func TestControllerCmdFlagValidation(t *testing.T) {
 				"--nginx-one-tls-skip-verify",
 				"--endpoint-picker-disable-tls",
 				"--endpoint-picker-tls-skip-verify",
+				"--fips",
+				"--module-log-levels=eventHandler=debug,provisioner=error",
+				"--log-levels-configmap=ngf-log-levels",
+				"--config-export-dir=/var/lib/nginx-export",
+				"--config-export-configmap",
+				"--upstream-map-configmap",
+				"--config-history-size=20",
+				"--canary-analysis-prometheus-address=http://prometheus.monitoring:9090",
+				"--canary-analysis-interval=30s",
+				`--canary-analysis-error-rate-query=errors{upstream="$upstream"}`,
+				`--canary-analysis-latency-query=latency{upstream="$upstream"}`,
+				"--api-catalog-path=/.well-known/api-catalog",
+				"--api-catalog-interval=5m",
+				"--outlier-hook-prometheus-address=http://prometheus.monitoring:9090",
+				"--outlier-hook-interval=1m",
+				`--outlier-hook-error-rate-query=errors{upstream="$upstream"}`,
+				"--wasm-hook-module=/etc/nginx-gateway/hook.wasm",
+				"--wasm-hook-timeout=500ms",
+				"--wasm-hook-max-memory-mib=128",
+				"--ipam-metallb-address-pool=gateways",
+				"--ingress-class=nginx",
+				"--ingress-gateway=default/gateway",
+				"--cluster-domain=example.local",
+				"--tenant-attribution-port=5140",
+				"--usage-summary-interval=1h",
+				"--temp-file-metrics-port=5141",
+				"--data-plane-failure-port=5142",
+				"--token-review-port=9444",
+				"--nginx-reload-min-interval=5s",
+				"--webhook-port=9443",
+				"--webhook-configuration-name=ngf-webhook",
+				"--webhook-max-routes-per-gateway=100",
+				"--manage-crds",
 			},
 			wantErr: false,
 		},
@@ -240,6 +276,238 @@ func TestControllerCmdFlagValidation(t *testing.T) {
 			expectedErrPrefix: `invalid argument "999" for "--metrics-port" flag:` +
 				` port outside of valid port range [1024 - 65535]: 999`,
 		},
+		{
+			name: "config-history-size is outside of range",
+			args: []string{
+				"--config-history-size=101",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "101" for "--config-history-size" flag:` +
+				` config history size outside of valid range [0 - 100]: 101`,
+		},
+		{
+			name: "api-catalog-path is not an absolute path",
+			args: []string{
+				"--api-catalog-path=api-catalog",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "api-catalog" for "--api-catalog-path" flag:` +
+				` "api-catalog" must be an absolute path`,
+		},
+		{
+			name: "api-catalog-interval is too short",
+			args: []string{
+				"--api-catalog-interval=5s",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "5s" for "--api-catalog-interval" flag: "5s" must be at least 10s`,
+		},
+		{
+			name: "canary-analysis-prometheus-address is not an http URL",
+			args: []string{
+				"--canary-analysis-prometheus-address=prometheus:9090",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "prometheus:9090" for "--canary-analysis-prometheus-address" flag:` +
+				` "prometheus:9090" must use the http or https scheme`,
+		},
+		{
+			name: "canary-analysis-interval is too short",
+			args: []string{
+				"--canary-analysis-interval=100ms",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "100ms" for "--canary-analysis-interval" flag: "100ms" must be at least 1s`,
+		},
+		{
+			name: "canary-analysis-error-rate-query doesn't reference the upstream",
+			args: []string{
+				"--canary-analysis-error-rate-query=errors",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "errors" for "--canary-analysis-error-rate-query" flag:` +
+				` "errors" must reference the upstream of the canary with $upstream`,
+		},
+		{
+			name: "outlier-hook-interval is too short",
+			args: []string{
+				"--outlier-hook-interval=100ms",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "100ms" for "--outlier-hook-interval" flag: "100ms" must be at least 1s`,
+		},
+		{
+			name: "outlier-hook-error-rate-query doesn't reference the upstream",
+			args: []string{
+				"--outlier-hook-error-rate-query=errors",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "errors" for "--outlier-hook-error-rate-query" flag:` +
+				` "errors" must reference the upstream with $upstream`,
+		},
+		{
+			name: "wasm-hook-module is not an absolute path",
+			args: []string{
+				"--wasm-hook-module=hook.wasm",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "hook.wasm" for "--wasm-hook-module" flag:` +
+				` "hook.wasm" must be an absolute path`,
+		},
+		{
+			name: "wasm-hook-timeout is too long",
+			args: []string{
+				"--wasm-hook-timeout=1m",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "1m" for "--wasm-hook-timeout" flag:` +
+				` "1m" must be greater than 0 and at most 10s`,
+		},
+		{
+			name: "wasm-hook-max-memory-mib is out of range",
+			args: []string{
+				"--wasm-hook-max-memory-mib=0",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "0" for "--wasm-hook-max-memory-mib" flag:` +
+				` WASM hook max memory outside of valid range [1 - 4096]: 0`,
+		},
+		{
+			name: "webhook-port is out of range",
+			args: []string{
+				"--webhook-port=443",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "443" for "--webhook-port" flag:` +
+				` port outside of valid port range [1024 - 65535]: 443`,
+		},
+		{
+			name: "webhook-configuration-name is invalid",
+			args: []string{
+				"--webhook-configuration-name=$invalid*(#)",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "$invalid*(#)" for "--webhook-configuration-name" flag: invalid format`,
+		},
+		{
+			name: "webhook-max-routes-per-gateway is negative",
+			args: []string{
+				"--webhook-max-routes-per-gateway=-1",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "-1" for "--webhook-max-routes-per-gateway" flag:` +
+				` max routes per Gateway must not be negative: -1`,
+		},
+		{
+			name: "manage-crds is not a bool",
+			args: []string{
+				"--manage-crds=999",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "999" for "--manage-crds" flag: strconv.ParseBool`,
+		},
+		{
+			name: "ipam-endpoint is not an http URL",
+			args: []string{
+				"--ipam-endpoint=ipam.example.com",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "ipam.example.com" for "--ipam-endpoint" flag:` +
+				` "ipam.example.com" must use the http or https scheme`,
+		},
+		{
+			name: "ipam-metallb-address-pool and ipam-endpoint are both set",
+			args: []string{
+				"--gateway-ctlr-name=gateway.nginx.org/nginx-gateway",
+				"--gatewayclass=nginx",
+				"--ipam-metallb-address-pool=gateways",
+				"--ipam-endpoint=http://ipam.example.com",
+			},
+			wantErr: true,
+			expectedErrPrefix: "if any flags in the group [ipam-metallb-address-pool ipam-endpoint] are set none of " +
+				"the others can be",
+		},
+		{
+			name: "ingress-gateway is not a namespaced name",
+			args: []string{
+				"--ingress-gateway=gateway",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "gateway" for "--ingress-gateway" flag: invalid format:` +
+				` "gateway" must be in the format <namespace>/<name>`,
+		},
+		{
+			name: "cluster-domain is invalid",
+			args: []string{
+				"--cluster-domain=$invalid*",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "$invalid*" for "--cluster-domain" flag:`,
+		},
+		{
+			name: "ingress-class is set without ingress-gateway",
+			args: []string{
+				"--gateway-ctlr-name=gateway.nginx.org/nginx-gateway",
+				"--gatewayclass=nginx",
+				"--ingress-class=nginx",
+			},
+			wantErr:           true,
+			expectedErrPrefix: "if any flags in the group [ingress-class ingress-gateway] are set they must all be set",
+		},
+		{
+			name: "tenant-attribution-port is outside of the valid range",
+			args: []string{
+				"--tenant-attribution-port=514",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "514" for "--tenant-attribution-port" flag:` +
+				` port outside of valid port range [1024 - 65535]: 514`,
+		},
+		{
+			name: "temp-file-metrics-port is outside of the valid range",
+			args: []string{
+				"--temp-file-metrics-port=80",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "80" for "--temp-file-metrics-port" flag:` +
+				` port outside of valid port range [1024 - 65535]: 80`,
+		},
+		{
+			name: "token-review-port is outside of the valid range",
+			args: []string{
+				"--token-review-port=80",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "80" for "--token-review-port" flag:` +
+				` port outside of valid port range [1024 - 65535]: 80`,
+		},
+		{
+			name: "data-plane-failure-port is outside of the valid range",
+			args: []string{
+				"--data-plane-failure-port=80",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "80" for "--data-plane-failure-port" flag:` +
+				` port outside of valid port range [1024 - 65535]: 80`,
+		},
+		{
+			name: "nginx-reload-min-interval is too long",
+			args: []string{
+				"--nginx-reload-min-interval=5m",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "5m" for "--nginx-reload-min-interval" flag:` +
+				` "5m" must be greater than 0 and at most 1m0s`,
+		},
+		{
+			name: "usage-summary-interval is too short",
+			args: []string{
+				"--usage-summary-interval=10s",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "10s" for "--usage-summary-interval" flag:` +
+				` "10s" must be at least 1m0s`,
+		},
 		{
 			name: "metrics-disable is not a bool",
 			args: []string{
@@ -258,6 +526,15 @@ func TestControllerCmdFlagValidation(t *testing.T) {
 			expectedErrPrefix: `invalid argument "999" for "--metrics-secure-serving" flag: strconv.ParseBool:` +
 				` parsing "999": invalid syntax`,
 		},
+		{
+			name: "debug-endpoints is not a bool",
+			args: []string{
+				"--debug-endpoints=999", // not a bool
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "999" for "--debug-endpoints" flag: strconv.ParseBool:` +
+				` parsing "999": invalid syntax`,
+		},
 		{
 			name: "health-port is invalid type",
 			args: []string{
@@ -408,6 +685,49 @@ func TestControllerCmdFlagValidation(t *testing.T) {
 			wantErr:           true,
 			expectedErrPrefix: `invalid argument "!@#$" for "--usage-report-client-ssl-secret" flag: invalid format: `,
 		},
+		{
+			name: "module-log-levels has an unsupported level",
+			expectedErrPrefix: `invalid argument "eventHandler=trace" for "--module-log-levels" flag: ` +
+				`invalid level for module "eventHandler"`,
+			args: []string{
+				"--module-log-levels=eventHandler=trace",
+			},
+			wantErr: true,
+		},
+		{
+			name: "module-log-levels is malformed",
+			expectedErrPrefix: `invalid argument "eventHandler" for "--module-log-levels" flag: ` +
+				`invalid module level "eventHandler"`,
+			args: []string{
+				"--module-log-levels=eventHandler",
+			},
+			wantErr: true,
+		},
+		{
+			name:              "log-levels-configmap is invalid",
+			expectedErrPrefix: `invalid argument "!@#$" for "--log-levels-configmap" flag: invalid format`,
+			args: []string{
+				"--log-levels-configmap=!@#$",
+			},
+			wantErr: true,
+		},
+		{
+			name:              "config-export-dir is not an absolute path",
+			expectedErrPrefix: `invalid argument "export" for "--config-export-dir" flag: "export" must be an absolute path`,
+			args: []string{
+				"--config-export-dir=export",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fips is not a bool",
+			expectedErrPrefix: `invalid argument "not-a-bool" for "--fips" flag: strconv.ParseBool:` +
+				` parsing "not-a-bool": invalid syntax`,
+			args: []string{
+				"--fips=not-a-bool",
+			},
+			wantErr: true,
+		},
 		{
 			name: "snippets-filters is not a bool",
 			expectedErrPrefix: `invalid argument "not-a-bool" for "--snippets-filters" flag: strconv.ParseBool:` +
@@ -689,6 +1009,161 @@ func TestSleepCmdFlagValidation(t *testing.T) {
 	}
 }
 
+func TestConfigReloaderCmdFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []flagTestCase{
+		{
+			name: "valid flags",
+			args: []string{
+				"--config-dir=/config",
+				"--secrets-dir=/secrets",
+				"--pid-file=/var/run/nginx.pid",
+				"--interval=5s",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "omitted flags",
+			args:    nil,
+			wantErr: false,
+		},
+		{
+			name: "interval is invalid",
+			args: []string{
+				"--interval=invalid",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "invalid" for "--interval" flag: time: invalid duration "invalid"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := createConfigReloaderCommand()
+			testFlag(t, cmd, test)
+		})
+	}
+}
+
+func TestSupportBundleCmdFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []flagTestCase{
+		{
+			name: "valid flags",
+			args: []string{
+				"--namespace=nginx-gateway",
+				"--selector=app.kubernetes.io/name=nginx-gateway-fabric",
+				"--output=bundle.tar.gz",
+				"--metrics-port=9113",
+				"--metrics-secure",
+				"--log-tail-lines=100",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "omitted flags",
+			args:    nil,
+			wantErr: false,
+		},
+		{
+			name: "namespace is invalid",
+			args: []string{
+				"--namespace=!@#$",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "!@#$" for "--namespace" flag: invalid format`,
+		},
+		{
+			name: "metrics-port is invalid",
+			args: []string{
+				"--metrics-port=999999",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "999999" for "--metrics-port" flag: port outside of valid port range`,
+		},
+		{
+			name: "log-tail-lines is not an int",
+			args: []string{
+				"--log-tail-lines=many",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "many" for "--log-tail-lines" flag: strconv.ParseInt`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := createSupportBundleCommand()
+			testFlag(t, cmd, test)
+		})
+	}
+}
+
+func TestSimulateCmdFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []flagTestCase{
+		{
+			name: "valid flags",
+			args: []string{
+				"--manifests=manifests",
+				"--gateway-ctlr-name=gateway.nginx.org/nginx-gateway",
+				"--gatewayclass=nginx",
+				"--nginx-plus",
+				"--gateway-api-experimental-features",
+				"--output-dir=config",
+				"--fail-on-problems",
+			},
+			wantErr: false,
+		},
+		{
+			name: "manifests is omitted",
+			args: []string{
+				"--gatewayclass=nginx",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `required flag(s) "manifests" not set`,
+		},
+		{
+			name: "gateway-ctlr-name is invalid",
+			args: []string{
+				"--manifests=manifests",
+				"--gateway-ctlr-name=nginx-gateway",
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "nginx-gateway" for "--gateway-ctlr-name" flag: invalid format; ` +
+				"must be DOMAIN/PATH",
+		},
+		{
+			name: "gatewayclass is invalid",
+			args: []string{
+				"--manifests=manifests",
+				"--gatewayclass=@",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "@" for "--gatewayclass" flag: invalid format`,
+		},
+		{
+			name: "fail-on-problems is not a bool",
+			args: []string{
+				"--manifests=manifests",
+				"--fail-on-problems=yes",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "yes" for "--fail-on-problems" flag: strconv.ParseBool`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := createSimulateCommand()
+			testFlag(t, cmd, test)
+		})
+	}
+}
+
 func TestParseFlags(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/export"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
+)
+
+// managedNginxDirs are the subdirectories of the nginx directory that contain only files from the exported
+// configuration, so the files that are no longer part of the configuration are removed from them.
+var managedNginxDirs = []string{"conf.d", "stream-conf.d", "secrets", "includes"}
+
+// exportSource is a directory with a mounted ConfigMap or Secret that contains the exported configuration.
+type exportSource struct {
+	dir      string
+	fileType file.Type
+}
+
+type configReloaderConfig struct {
+	fileManager file.OSFileManager
+	logger      logr.Logger
+	// signalNginx sends the SIGHUP signal to the NGINX master process.
+	signalNginx func(pid int) error
+	// nginxDir is the directory of the nginx configuration.
+	nginxDir string
+	pidFile  string
+	sources  []exportSource
+	interval time.Duration
+}
+
+func sighup(pid int) error {
+	return syscall.Kill(pid, syscall.SIGHUP)
+}
+
+// runConfigReloader copies the exported configuration to NGINX and reloads NGINX every time the configuration
+// changes, until the context is canceled.
+func runConfigReloader(ctx context.Context, cfg configReloaderConfig) {
+	ticker := time.NewTicker(cfg.interval)
+	defer ticker.Stop()
+
+	var appliedChecksum string
+
+	for {
+		checksum, err := reloadIfChanged(cfg, appliedChecksum)
+		if err != nil {
+			cfg.logger.Error(err, "Failed to apply configuration")
+		} else {
+			appliedChecksum = checksum
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reloadIfChanged applies the exported configuration if its checksum is different from the checksum
+// of the applied configuration. It returns the checksum of the applied configuration.
+func reloadIfChanged(cfg configReloaderConfig, appliedChecksum string) (string, error) {
+	files, err := readExportedFiles(cfg.sources, cfg.nginxDir)
+	if err != nil {
+		return appliedChecksum, err
+	}
+
+	// the configuration is not exported yet
+	if len(files) == 0 {
+		return appliedChecksum, nil
+	}
+
+	checksum := filesChecksum(files)
+	if checksum == appliedChecksum {
+		return appliedChecksum, nil
+	}
+
+	managedDirs := make([]string, 0, len(managedNginxDirs))
+	for _, dir := range managedNginxDirs {
+		managedDirs = append(managedDirs, filepath.Join(cfg.nginxDir, dir))
+	}
+
+	if err := applyFiles(cfg.fileManager, files, managedDirs); err != nil {
+		return appliedChecksum, err
+	}
+
+	pid, err := readPID(cfg.pidFile)
+	if err != nil {
+		return appliedChecksum, err
+	}
+
+	if err := cfg.signalNginx(pid); err != nil {
+		return appliedChecksum, fmt.Errorf("failed to reload nginx with pid %d: %w", pid, err)
+	}
+
+	cfg.logger.Info("Reloaded nginx", "files", len(files), "checksum", checksum)
+
+	return checksum, nil
+}
+
+// readExportedFiles reads the files of the exported configuration from the sources. A source without
+// the paths file is skipped, because the ConfigMap or the Secret doesn't exist yet.
+func readExportedFiles(sources []exportSource, nginxDir string) ([]file.File, error) {
+	var files []file.File
+
+	for _, source := range sources {
+		pathsJSON, err := os.ReadFile(filepath.Join(source.dir, export.PathsKey))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read file paths: %w", err)
+		}
+
+		var paths map[string]string
+		if err := json.Unmarshal(pathsJSON, &paths); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal file paths from %q: %w", source.dir, err)
+		}
+
+		for key, path := range paths {
+			path = filepath.Clean(path)
+
+			// the files in the root of the nginx directory are the same as the files of the nginx image
+			if filepath.Dir(path) == nginxDir {
+				continue
+			}
+
+			if !strings.HasPrefix(path, nginxDir+"/") {
+				return nil, fmt.Errorf("file path %q is outside of %s", path, nginxDir)
+			}
+
+			content, err := os.ReadFile(filepath.Join(source.dir, key))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read file %q: %w", key, err)
+			}
+
+			files = append(files, file.File{
+				Path:    path,
+				Content: content,
+				Type:    source.fileType,
+			})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	return files, nil
+}
+
+func filesChecksum(files []file.File) string {
+	h := sha256.New()
+
+	for _, f := range files {
+		h.Write([]byte(f.Path))
+		h.Write([]byte{0})
+		h.Write(f.Content)
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// applyFiles writes the files, and removes the files in the managed directories that are not part of the files.
+func applyFiles(fileManager file.OSFileManager, files []file.File, managedDirs []string) error {
+	paths := make(map[string]struct{}, len(files))
+
+	for _, f := range files {
+		paths[f.Path] = struct{}{}
+
+		if err := file.Write(fileManager, f); err != nil {
+			return err
+		}
+	}
+
+	for _, dir := range managedDirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return fmt.Errorf("failed to read directory %q: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if _, exists := paths[path]; exists || !entry.Type().IsRegular() {
+				continue
+			}
+
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove file %q: %w", path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func readPID(pidFile string) (int, error) {
+	content, err := os.ReadFile(pidFile)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read nginx pid file: %w", err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid nginx pid file %q: %w", pidFile, err)
+	}
+
+	return pid, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/export"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
+)
+
+func writeExportSource(g *WithT, dir string, files map[string]string, paths map[string]string) {
+	g.Expect(os.MkdirAll(dir, 0o750)).To(Succeed())
+
+	for key, content := range files {
+		g.Expect(os.WriteFile(filepath.Join(dir, key), []byte(content), 0o600)).To(Succeed())
+	}
+
+	pathsJSON, err := json.Marshal(paths)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(os.WriteFile(filepath.Join(dir, export.PathsKey), pathsJSON, 0o600)).To(Succeed())
+}
+
+func TestReloadIfChanged(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tmpDir := t.TempDir()
+	nginxDir := filepath.Join(tmpDir, "nginx")
+	configDir := filepath.Join(tmpDir, "config")
+	secretsDir := filepath.Join(tmpDir, "secrets")
+	pidFile := filepath.Join(tmpDir, "nginx.pid")
+
+	for _, dir := range managedNginxDirs {
+		g.Expect(os.MkdirAll(filepath.Join(nginxDir, dir), 0o750)).To(Succeed())
+	}
+
+	var signaledPIDs []int
+
+	cfg := configReloaderConfig{
+		fileManager: file.NewStdLibOSFileManager(),
+		logger:      logr.Discard(),
+		signalNginx: func(pid int) error {
+			signaledPIDs = append(signaledPIDs, pid)
+			return nil
+		},
+		nginxDir: nginxDir,
+		pidFile:  pidFile,
+		sources: []exportSource{
+			{dir: configDir, fileType: file.TypeRegular},
+			{dir: secretsDir, fileType: file.TypeSecret},
+		},
+	}
+
+	// nothing is exported yet
+	checksum, err := reloadIfChanged(cfg, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(checksum).To(BeEmpty())
+	g.Expect(signaledPIDs).To(BeEmpty())
+
+	writeExportSource(
+		g,
+		configDir,
+		map[string]string{
+			"nginx.conf":       "base",
+			"conf.d_http.conf": "http",
+		},
+		map[string]string{
+			"nginx.conf":       filepath.Join(nginxDir, "nginx.conf"),
+			"conf.d_http.conf": filepath.Join(nginxDir, "conf.d", "http.conf"),
+		},
+	)
+	writeExportSource(
+		g,
+		secretsDir,
+		map[string]string{"secrets_cert.pem": "cert"},
+		map[string]string{"secrets_cert.pem": filepath.Join(nginxDir, "secrets", "cert.pem")},
+	)
+
+	staleFile := filepath.Join(nginxDir, "conf.d", "stale.conf")
+	g.Expect(os.WriteFile(staleFile, []byte("stale"), 0o600)).To(Succeed())
+
+	// nginx is not running yet
+	checksum, err = reloadIfChanged(cfg, "")
+	g.Expect(err).To(MatchError(ContainSubstring("failed to read nginx pid file")))
+	g.Expect(checksum).To(BeEmpty())
+
+	g.Expect(os.WriteFile(pidFile, []byte("123\n"), 0o600)).To(Succeed())
+
+	checksum, err = reloadIfChanged(cfg, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(checksum).ToNot(BeEmpty())
+	g.Expect(signaledPIDs).To(Equal([]int{123}))
+
+	g.Expect(os.ReadFile(filepath.Join(nginxDir, "conf.d", "http.conf"))).To(Equal([]byte("http")))
+	g.Expect(os.ReadFile(filepath.Join(nginxDir, "secrets", "cert.pem"))).To(Equal([]byte("cert")))
+	g.Expect(filepath.Join(nginxDir, "nginx.conf")).ToNot(BeAnExistingFile())
+	g.Expect(staleFile).ToNot(BeAnExistingFile())
+
+	secretInfo, err := os.Stat(filepath.Join(nginxDir, "secrets", "cert.pem"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(secretInfo.Mode().Perm()).To(Equal(os.FileMode(0o640)))
+
+	// the configuration didn't change
+	newChecksum, err := reloadIfChanged(cfg, checksum)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(newChecksum).To(Equal(checksum))
+	g.Expect(signaledPIDs).To(HaveLen(1))
+
+	// reloading fails
+	cfg.signalNginx = func(int) error {
+		return errors.New("signal error")
+	}
+	g.Expect(os.WriteFile(filepath.Join(configDir, "conf.d_http.conf"), []byte("changed"), 0o600)).To(Succeed())
+
+	newChecksum, err = reloadIfChanged(cfg, checksum)
+	g.Expect(err).To(MatchError(ContainSubstring("signal error")))
+	g.Expect(newChecksum).To(Equal(checksum))
+}
+
+func TestReadExportedFilesOutsideOfNginxDir(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "config")
+
+	writeExportSource(
+		g,
+		configDir,
+		map[string]string{"file": "content"},
+		map[string]string{"file": "/etc/nginx/../passwd"},
+	)
+
+	files, err := readExportedFiles([]exportSource{{dir: configDir}}, "/etc/nginx")
+	g.Expect(err).To(MatchError(ContainSubstring(`file path "/etc/passwd" is outside of /etc/nginx`)))
+	g.Expect(files).To(BeNil())
+}
+
+func TestReadPID(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	pidFile := filepath.Join(t.TempDir(), "nginx.pid")
+
+	g.Expect(os.WriteFile(pidFile, []byte("not-a-pid"), 0o600)).To(Succeed())
+
+	_, err := readPID(pidFile)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid nginx pid file")))
+}
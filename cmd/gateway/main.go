@@ -26,6 +26,9 @@ func main() {
 		createInitializeCommand(),
 		createSleepCommand(),
 		createEndpointPickerCommand(),
+		createConfigReloaderCommand(),
+		createSupportBundleCommand(),
+		createSimulateCommand(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
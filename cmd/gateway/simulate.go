@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/export"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
+)
+
+// errSimulationProblems is returned when the simulation finds problems and the simulation must fail on them.
+var errSimulationProblems = errors.New("the statuses of the resources report problems")
+
+// simulationConfig holds the configuration for running a simulation.
+type simulationConfig struct {
+	// manifestsDir is the directory with the manifests.
+	manifestsDir string
+	// outputDir is the directory to export the NGINX configuration to. If empty, the configuration is not exported.
+	outputDir string
+	// simulation is the configuration of the simulation.
+	simulation controller.SimulationConfig
+	// failOnProblems indicates if the simulation fails when the statuses report problems.
+	failOnProblems bool
+}
+
+// runSimulation simulates the control plane with the resources in the manifests and prints the statuses
+// and the generated NGINX configuration to out.
+func runSimulation(ctx context.Context, cfg simulationConfig, out io.Writer) error {
+	objects, err := controller.LoadManifests(cfg.manifestsDir)
+	if err != nil {
+		return fmt.Errorf("error loading manifests: %w", err)
+	}
+
+	result, err := controller.Simulate(ctx, cfg.simulation, objects)
+	if err != nil {
+		return fmt.Errorf("error running simulation: %w", err)
+	}
+
+	if err := printStatuses(out, result); err != nil {
+		return err
+	}
+
+	gateways := slices.SortedFunc(maps.Keys(result.Configs), func(a, b types.NamespacedName) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	for _, nsname := range gateways {
+		printConfig(out, nsname, result.Configs[nsname])
+
+		if cfg.outputDir == "" {
+			continue
+		}
+
+		exporter := export.NewDirExporter(cfg.outputDir, cfg.simulation.Plus)
+		if err := exporter.Export(ctx, result.Gateways[nsname], result.Configs[nsname]); err != nil {
+			return fmt.Errorf("error exporting configuration of Gateway %s: %w", nsname, err)
+		}
+	}
+
+	if len(result.Skipped) > 0 {
+		fmt.Fprintln(out, "# Skipped resources")
+		for _, skipped := range result.Skipped {
+			fmt.Fprintf(out, "#   %s\n", skipped)
+		}
+	}
+
+	problems, err := result.Problems()
+	if err != nil {
+		return fmt.Errorf("error finding problems: %w", err)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out, "# Problems")
+	for _, problem := range problems {
+		fmt.Fprintf(out, "#   %s\n", problem)
+	}
+
+	if cfg.failOnProblems {
+		return errSimulationProblems
+	}
+
+	return nil
+}
+
+func printStatuses(out io.Writer, result controller.SimulationResult) error {
+	for _, obj := range result.Statuses {
+		content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return fmt.Errorf("error converting status of %s: %w", obj.GetName(), err)
+		}
+
+		metadata := map[string]any{"name": obj.GetName()}
+		if obj.GetNamespace() != "" {
+			metadata["namespace"] = obj.GetNamespace()
+		}
+
+		statusOnly := map[string]any{
+			"apiVersion": content["apiVersion"],
+			"kind":       content["kind"],
+			"metadata":   metadata,
+			"status":     content["status"],
+		}
+
+		doc, err := yaml.Marshal(statusOnly)
+		if err != nil {
+			return fmt.Errorf("error marshaling status of %s: %w", obj.GetName(), err)
+		}
+
+		fmt.Fprintf(out, "---\n%s", doc)
+	}
+
+	return nil
+}
+
+// printConfig prints the configuration files of the Gateway, sorted by path.
+// The contents of the secret files are omitted.
+func printConfig(out io.Writer, nsname types.NamespacedName, files []agent.File) {
+	fmt.Fprintf(out, "# NGINX configuration of Gateway %s\n", nsname)
+
+	sorted := slices.Clone(files)
+	slices.SortFunc(sorted, func(a, b agent.File) int {
+		return strings.Compare(a.Meta.GetName(), b.Meta.GetName())
+	})
+
+	for _, f := range sorted {
+		// nginx writes the unmanaged files itself
+		if f.Unmanaged {
+			continue
+		}
+
+		converted := file.Convert(f)
+
+		fmt.Fprintf(out, "# File %s\n", converted.Path)
+		if converted.Type == file.TypeSecret {
+			fmt.Fprintln(out, "# <contents omitted>")
+			continue
+		}
+
+		fmt.Fprintf(out, "%s\n", converted.Content)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller"
+)
+
+const simulateManifests = `apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: gateway
+spec:
+  gatewayClassName: nginx
+  listeners:
+  - name: http
+    port: 80
+    protocol: HTTP
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: coffee
+spec:
+  parentRefs:
+  - name: gateway
+  rules:
+  - backendRefs:
+    - name: coffee
+      port: 80
+---
+apiVersion: example.com/v1
+kind: Barista
+metadata:
+  name: coffee
+`
+
+func TestRunSimulation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expErr         error
+		name           string
+		failOnProblems bool
+	}{
+		{
+			name: "problems are reported",
+		},
+		{
+			name:           "fail on problems",
+			failOnProblems: true,
+			expErr:         errSimulationProblems,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			manifestsDir := t.TempDir()
+			err := os.WriteFile(filepath.Join(manifestsDir, "cafe.yaml"), []byte(simulateManifests), 0o600)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			outputDir := t.TempDir()
+
+			cfg := simulationConfig{
+				manifestsDir:   manifestsDir,
+				outputDir:      outputDir,
+				failOnProblems: test.failOnProblems,
+				simulation: controller.SimulationConfig{
+					Logger:           logr.Discard(),
+					GatewayCtlrName:  "gateway.nginx.org/nginx-gateway-controller",
+					GatewayClassName: "nginx",
+				},
+			}
+
+			var out bytes.Buffer
+			err = runSimulation(t.Context(), cfg, &out)
+			if test.expErr != nil {
+				g.Expect(err).To(MatchError(test.expErr))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			output := out.String()
+			g.Expect(output).To(ContainSubstring("kind: GatewayClass\nmetadata:\n  name: nginx\nstatus:"))
+			g.Expect(output).To(ContainSubstring("kind: HTTPRoute\nmetadata:\n  name: coffee\n  namespace: default\n"))
+			g.Expect(output).To(ContainSubstring("# NGINX configuration of Gateway default/gateway\n"))
+			g.Expect(output).To(ContainSubstring("# File /etc/nginx/conf.d/http.conf\n"))
+			g.Expect(output).To(ContainSubstring("# Skipped resources\n#   Barista default/coffee\n"))
+			g.Expect(output).To(ContainSubstring(
+				"# Problems\n#   HTTPRoute default/coffee: ResolvedRefs BackendNotFound: ",
+			))
+
+			g.Expect(filepath.Join(outputDir, "default", "gateway", "etc", "nginx", "conf.d", "http.conf")).
+				To(BeARegularFile())
+		})
+	}
+}
+
+func TestRunSimulationInvalidManifests(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	cfg := simulationConfig{
+		manifestsDir: filepath.Join(t.TempDir(), "missing"),
+	}
+
+	err := runSimulation(t.Context(), cfg, &bytes.Buffer{})
+	g.Expect(err).To(MatchError(ContainSubstring("error loading manifests")))
+}
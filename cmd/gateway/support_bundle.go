@@ -0,0 +1,344 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"runtime"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller"
+	fwcontroller "github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/redact"
+)
+
+// supportBundleConfig holds the configuration for collecting a support bundle.
+type supportBundleConfig struct {
+	// k8sClient is used to list the Gateway API and NGINX Gateway Fabric resources.
+	k8sClient client.Reader
+	// clientset is used to get the cluster version, Pod logs, and proxy requests to the control plane.
+	clientset kubernetes.Interface
+	// namespace is the namespace of the control plane.
+	namespace string
+	// selector is the label selector of the control plane Pods.
+	selector string
+	// metrics connects to the control plane metrics server.
+	metrics metricsConnector
+	// logTailLines is the number of lines of logs to collect from each container.
+	logTailLines int64
+}
+
+// supportBundleResource is a resource list that is included in the support bundle.
+type supportBundleResource struct {
+	list client.ObjectList
+	name string
+}
+
+func newSupportBundleScheme() *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+
+	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(gatewayv1beta1.Install(scheme))
+	utilruntime.Must(gatewayv1alpha2.Install(scheme))
+	utilruntime.Must(ngfAPIv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(ngfAPIv1alpha2.AddToScheme(scheme))
+
+	return scheme
+}
+
+// supportBundleResources returns the lists of resources, including their statuses, that are collected.
+// Secrets are never collected.
+func supportBundleResources() []supportBundleResource {
+	return []supportBundleResource{
+		{name: "gatewayclasses", list: &gatewayv1.GatewayClassList{}},
+		{name: "gateways", list: &gatewayv1.GatewayList{}},
+		{name: "httproutes", list: &gatewayv1.HTTPRouteList{}},
+		{name: "grpcroutes", list: &gatewayv1.GRPCRouteList{}},
+		{name: "backendtlspolicies", list: &gatewayv1.BackendTLSPolicyList{}},
+		{name: "referencegrants", list: &gatewayv1beta1.ReferenceGrantList{}},
+		{name: "tlsroutes", list: &gatewayv1alpha2.TLSRouteList{}},
+		{name: "nginxgateways", list: &ngfAPIv1alpha1.NginxGatewayList{}},
+		{name: "nginxproxies", list: &ngfAPIv1alpha2.NginxProxyList{}},
+		{name: "clientsettingspolicies", list: &ngfAPIv1alpha1.ClientSettingsPolicyList{}},
+		{name: "observabilitypolicies", list: &ngfAPIv1alpha2.ObservabilityPolicyList{}},
+		{name: "upstreamsettingspolicies", list: &ngfAPIv1alpha1.UpstreamSettingsPolicyList{}},
+		{name: "snippetsfilters", list: &ngfAPIv1alpha1.SnippetsFilterList{}},
+	}
+}
+
+// bundleWriter writes redacted files to a gzipped tar archive.
+type bundleWriter struct {
+	modTime time.Time
+	tw      *tar.Writer
+	errs    []string
+}
+
+// add redacts and writes the file to the archive.
+func (w *bundleWriter) add(name string, data []byte) error {
+	data = redact.File(name, data)
+
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: w.modTime,
+	}
+
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("error writing header for %s: %w", name, err)
+	}
+
+	if _, err := w.tw.Write(data); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// addJSON marshals the object as JSON and writes it to the archive.
+func (w *bundleWriter) addJSON(name string, obj any) error {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %w", name, err)
+	}
+
+	return w.add(name, data)
+}
+
+// recordError records an error that occurred while collecting part of the bundle. Collection continues so that
+// as much information as possible is included.
+func (w *bundleWriter) recordError(err error) {
+	w.errs = append(w.errs, err.Error())
+}
+
+// collectSupportBundle collects the support bundle and writes it as a gzipped tar archive to out.
+// Errors collecting individual items are recorded in errors.txt within the archive instead of being returned.
+func collectSupportBundle(ctx context.Context, cfg supportBundleConfig, out io.Writer) error {
+	gw := gzip.NewWriter(out)
+	w := &bundleWriter{
+		tw:      tar.NewWriter(gw),
+		modTime: time.Now(),
+	}
+
+	collectors := []func(context.Context, supportBundleConfig, *bundleWriter) error{
+		collectVersions,
+		collectPods,
+		collectResources,
+	}
+
+	for _, collect := range collectors {
+		if err := collect(ctx, cfg, w); err != nil {
+			return err
+		}
+	}
+
+	if len(w.errs) > 0 {
+		if err := w.add("errors.txt", []byte(strings.Join(w.errs, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+
+	if err := w.tw.Close(); err != nil {
+		return fmt.Errorf("error closing archive: %w", err)
+	}
+
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("error closing archive: %w", err)
+	}
+
+	return nil
+}
+
+func collectVersions(_ context.Context, cfg supportBundleConfig, w *bundleWriter) error {
+	commit, date, dirty := getBuildInfo()
+
+	versions := map[string]string{
+		"version":   version,
+		"commit":    commit,
+		"date":      date,
+		"dirty":     dirty,
+		"goVersion": runtime.Version(),
+	}
+
+	serverVersion, err := cfg.clientset.Discovery().ServerVersion()
+	if err != nil {
+		w.recordError(fmt.Errorf("error getting kubernetes version: %w", err))
+	} else {
+		versions["kubernetesVersion"] = serverVersion.GitVersion
+		versions["kubernetesPlatform"] = serverVersion.Platform
+	}
+
+	return w.addJSON("version.json", versions)
+}
+
+// collectPods collects the Pod specs and logs of the control plane and data plane Pods, and the metrics,
+// graph, and generated configuration of the control plane Pods.
+func collectPods(ctx context.Context, cfg supportBundleConfig, w *bundleWriter) error {
+	controlPlanePods, err := cfg.clientset.CoreV1().Pods(cfg.namespace).List(
+		ctx,
+		metav1.ListOptions{LabelSelector: cfg.selector},
+	)
+	if err != nil {
+		w.recordError(fmt.Errorf("error listing control plane pods: %w", err))
+		return nil
+	}
+
+	instances := make(map[string]struct{})
+	for _, pod := range controlPlanePods.Items {
+		dir := path.Join("controlplane", pod.Name)
+		if err := collectPod(ctx, cfg, w, dir, &pod); err != nil {
+			return err
+		}
+
+		if err := collectControlPlaneEndpoints(ctx, cfg, w, dir, &pod); err != nil {
+			return err
+		}
+
+		if instance, ok := pod.Labels[fwcontroller.AppInstanceLabel]; ok {
+			instances[instance] = struct{}{}
+		}
+	}
+
+	for instance := range instances {
+		selector := fmt.Sprintf("%s=%s,%s", fwcontroller.AppInstanceLabel, instance, fwcontroller.AppManagedByLabel)
+
+		dataPlanePods, err := cfg.clientset.CoreV1().Pods(metav1.NamespaceAll).List(
+			ctx,
+			metav1.ListOptions{LabelSelector: selector},
+		)
+		if err != nil {
+			w.recordError(fmt.Errorf("error listing data plane pods: %w", err))
+			continue
+		}
+
+		for _, pod := range dataPlanePods.Items {
+			if err := collectPod(ctx, cfg, w, path.Join("dataplane", pod.Namespace, pod.Name), &pod); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func collectPod(ctx context.Context, cfg supportBundleConfig, w *bundleWriter, dir string, pod *corev1.Pod) error {
+	pod.ManagedFields = nil
+	if err := w.addJSON(path.Join(dir, "pod.json"), pod); err != nil {
+		return err
+	}
+
+	containers := make([]string, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	for _, c := range pod.Spec.InitContainers {
+		containers = append(containers, c.Name)
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, c.Name)
+	}
+
+	for _, container := range containers {
+		logs, err := cfg.clientset.CoreV1().Pods(pod.Namespace).GetLogs(
+			pod.Name,
+			&corev1.PodLogOptions{Container: container, TailLines: &cfg.logTailLines},
+		).DoRaw(ctx)
+		if err != nil {
+			w.recordError(fmt.Errorf(
+				"error getting logs for pod %s/%s container %s: %w",
+				pod.Namespace,
+				pod.Name,
+				container,
+				err,
+			))
+			continue
+		}
+
+		if err := w.add(path.Join(dir, "logs", container+".log"), logs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectControlPlaneEndpoints collects the metrics snapshot, graph dump, and generated configuration from the
+// control plane metrics server.
+func collectControlPlaneEndpoints(
+	ctx context.Context,
+	cfg supportBundleConfig,
+	w *bundleWriter,
+	dir string,
+	pod *corev1.Pod,
+) error {
+	endpoints := []struct {
+		path string
+		file string
+	}{
+		{path: "/metrics", file: "metrics.txt"},
+		{path: controller.DebugGraphPath, file: "graph.json"},
+		{path: controller.DebugConfigPath, file: "config.json"},
+	}
+
+	get, closeConn, err := cfg.metrics.connect(ctx, pod)
+	if err != nil {
+		w.recordError(fmt.Errorf("error connecting to the metrics server of pod %s: %w", pod.Name, err))
+		return nil
+	}
+	defer closeConn()
+
+	for _, endpoint := range endpoints {
+		data, err := get(ctx, endpoint.path)
+		if err != nil {
+			w.recordError(fmt.Errorf("error getting %s from pod %s: %w", endpoint.path, pod.Name, err))
+			continue
+		}
+
+		if err := w.add(path.Join(dir, endpoint.file), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func collectResources(ctx context.Context, cfg supportBundleConfig, w *bundleWriter) error {
+	for _, resource := range supportBundleResources() {
+		if err := cfg.k8sClient.List(ctx, resource.list); err != nil {
+			w.recordError(fmt.Errorf("error listing %s: %w", resource.name, err))
+			continue
+		}
+
+		items, err := meta.ExtractList(resource.list)
+		if err != nil {
+			return fmt.Errorf("error extracting %s: %w", resource.name, err)
+		}
+
+		for _, item := range items {
+			if obj, ok := item.(client.Object); ok {
+				obj.SetManagedFields(nil)
+			}
+		}
+
+		if err := w.addJSON(path.Join("resources", resource.name+".json"), resource.list); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+const (
+	// metricsTokenExpiration is the expiration of the token that authenticates the requests to the secure
+	// metrics server. The API server enforces a minimum of 10 minutes.
+	metricsTokenExpiration = 10 * time.Minute
+	// metricsRequestTimeout is the timeout of a request to the metrics server.
+	metricsRequestTimeout = 30 * time.Second
+)
+
+// metricsGetter gets the response of a path of the metrics server of a control plane Pod.
+type metricsGetter func(ctx context.Context, path string) ([]byte, error)
+
+// metricsConnector connects to the metrics server of the control plane Pods.
+type metricsConnector interface {
+	// connect connects to the metrics server of the Pod. The returned function closes the connection.
+	connect(ctx context.Context, pod *corev1.Pod) (metricsGetter, func(), error)
+}
+
+// proxyMetricsConnector connects to the metrics server served over HTTP through the Kubernetes API server proxy.
+// The proxy doesn't forward the credentials of the user, so it can't be used for the secure metrics server,
+// which authenticates the requests.
+type proxyMetricsConnector struct {
+	clientset kubernetes.Interface
+	port      int
+}
+
+func (c *proxyMetricsConnector) connect(_ context.Context, pod *corev1.Pod) (metricsGetter, func(), error) {
+	get := func(ctx context.Context, path string) ([]byte, error) {
+		return c.clientset.CoreV1().Pods(pod.Namespace).ProxyGet(
+			"http",
+			pod.Name,
+			strconv.Itoa(c.port),
+			path,
+			nil,
+		).DoRaw(ctx)
+	}
+
+	return get, func() {}, nil
+}
+
+// portForwardFunc forwards a local port to the port of the Pod. It returns the local address and a function that
+// stops the forwarding.
+type portForwardFunc func(ctx context.Context, pod *corev1.Pod, port int) (string, func(), error)
+
+// secureMetricsConnector connects to the metrics server served over HTTPS through a port-forward to the Pod.
+// The requests are authenticated with a short-lived token of the ServiceAccount of the Pod, which is authorized
+// to get the metrics and debug endpoints.
+type secureMetricsConnector struct {
+	clientset kubernetes.Interface
+	forward   portForwardFunc
+	port      int
+}
+
+func (c *secureMetricsConnector) connect(ctx context.Context, pod *corev1.Pod) (metricsGetter, func(), error) {
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: helpers.GetPointer(int64(metricsTokenExpiration.Seconds())),
+			BoundObjectRef: &authenticationv1.BoundObjectReference{
+				Kind:       "Pod",
+				APIVersion: "v1",
+				Name:       pod.Name,
+				UID:        pod.UID,
+			},
+		},
+	}
+
+	token, err := c.clientset.CoreV1().ServiceAccounts(pod.Namespace).CreateToken(
+		ctx,
+		pod.Spec.ServiceAccountName,
+		tokenRequest,
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error creating token of service account %s: %w",
+			pod.Spec.ServiceAccountName,
+			err,
+		)
+	}
+
+	addr, stop, err := c.forward(ctx, pod, c.port)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error forwarding port %d: %w", c.port, err)
+	}
+
+	httpClient := &http.Client{
+		Timeout: metricsRequestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				// The metrics server uses a self-signed certificate. The connection is tunneled through
+				// the port-forward, which the API server authenticates and authorizes.
+				InsecureSkipVerify: true, //nolint:gosec // see above
+			},
+		},
+	}
+
+	get := func(ctx context.Context, path string) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+addr+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.Status.Token)
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, data)
+		}
+
+		return data, nil
+	}
+
+	return get, stop, nil
+}
+
+// newPortForwarder returns a portForwardFunc that forwards the ports through the Kubernetes API server.
+func newPortForwarder(restConfig *rest.Config, clientset kubernetes.Interface) portForwardFunc {
+	return func(ctx context.Context, pod *corev1.Pod, port int) (string, func(), error) {
+		transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+		if err != nil {
+			return "", nil, err
+		}
+
+		req := clientset.CoreV1().RESTClient().Post().
+			Resource("pods").
+			Namespace(pod.Namespace).
+			Name(pod.Name).
+			SubResource("portforward")
+
+		dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+		stopCh := make(chan struct{})
+		readyCh := make(chan struct{})
+
+		forwarder, err := portforward.NewOnAddresses(
+			dialer,
+			[]string{"127.0.0.1"},
+			[]string{fmt.Sprintf("0:%d", port)},
+			stopCh,
+			readyCh,
+			io.Discard,
+			io.Discard,
+		)
+		if err != nil {
+			return "", nil, err
+		}
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- forwarder.ForwardPorts()
+		}()
+
+		select {
+		case <-readyCh:
+		case err := <-errCh:
+			return "", nil, err
+		case <-ctx.Done():
+			close(stopCh)
+			return "", nil, ctx.Err()
+		}
+
+		ports, err := forwarder.GetPorts()
+		if err != nil {
+			close(stopCh)
+			return "", nil, err
+		}
+
+		return net.JoinHostPort("127.0.0.1", strconv.Itoa(int(ports[0].Local))), func() { close(stopCh) }, nil
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSecureMetricsConnector(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "ngf", Namespace: "nginx-gateway", UID: "pod-uid"},
+		Spec:       corev1.PodSpec{ServiceAccountName: "ngf-sa"},
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("forbidden"))
+			return
+		}
+
+		_, _ = w.Write([]byte("nginx_gateway_fabric_nginx_reloads_total 1"))
+	}))
+	t.Cleanup(server.Close)
+
+	newClientset := func(tokenErr error) *fake.Clientset {
+		clientset := fake.NewClientset()
+		clientset.PrependReactor(
+			"create",
+			"serviceaccounts",
+			func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "token" {
+					return false, nil, nil
+				}
+				if tokenErr != nil {
+					return true, nil, tokenErr
+				}
+
+				createAction, ok := action.(k8stesting.CreateActionImpl)
+				if !ok {
+					return false, nil, nil
+				}
+
+				tokenRequest, ok := createAction.GetObject().(*authenticationv1.TokenRequest)
+				if !ok {
+					return false, nil, nil
+				}
+
+				if tokenRequest.Spec.BoundObjectRef == nil || tokenRequest.Spec.BoundObjectRef.UID != pod.UID {
+					return true, nil, errors.New("token is not bound to the pod")
+				}
+
+				tokenRequest.Status.Token = "token"
+
+				return true, tokenRequest, nil
+			},
+		)
+
+		return clientset
+	}
+
+	tests := []struct {
+		tokenErr     error
+		forwardErr   error
+		name         string
+		path         string
+		expData      string
+		expErr       string
+		expConnErr   string
+		expForwarded bool
+	}{
+		{
+			name:         "gets the endpoint",
+			path:         "/metrics",
+			expData:      "nginx_gateway_fabric_nginx_reloads_total 1",
+			expForwarded: true,
+		},
+		{
+			name:         "returns the error of an unexpected status code",
+			path:         "/debug/graph",
+			expErr:       "unexpected status code 403: forbidden",
+			expForwarded: true,
+		},
+		{
+			name:       "returns the error of the token request",
+			tokenErr:   errors.New("forbidden"),
+			expConnErr: "error creating token of service account ngf-sa: forbidden",
+		},
+		{
+			name:         "returns the error of the port-forward",
+			forwardErr:   errors.New("connection refused"),
+			expConnErr:   "error forwarding port 9113: connection refused",
+			expForwarded: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			var forwarded, stopped bool
+			connector := &secureMetricsConnector{
+				clientset: newClientset(test.tokenErr),
+				port:      9113,
+				forward: func(_ context.Context, p *corev1.Pod, port int) (string, func(), error) {
+					forwarded = true
+					g.Expect(p).To(Equal(pod))
+					g.Expect(port).To(Equal(9113))
+
+					if test.forwardErr != nil {
+						return "", nil, test.forwardErr
+					}
+
+					return strings.TrimPrefix(server.URL, "https://"), func() { stopped = true }, nil
+				},
+			}
+
+			get, closeConn, err := connector.connect(t.Context(), pod)
+			g.Expect(forwarded).To(Equal(test.expForwarded))
+			if test.expConnErr != "" {
+				g.Expect(err).To(MatchError(test.expConnErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			data, err := get(t.Context(), test.path)
+			if test.expErr != "" {
+				g.Expect(err).To(MatchError(test.expErr))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(string(data)).To(Equal(test.expData))
+			}
+
+			closeConn()
+			g.Expect(stopped).To(BeTrue())
+		})
+	}
+}
@@ -4,16 +4,53 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
 )
 
 const (
 	// Regex from: https://github.com/kubernetes-sigs/gateway-api/blob/v1.4.1/apis/v1/shared_types.go#L675
 	controllerNameRegex = `^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*\/[A-Za-z0-9\/\-._~%!$&'()*+,;=:]+$` //nolint:lll
+
+	// apiCatalogPathRegex matches the paths at which the API catalogs can be served. The path is used in an exact
+	// NGINX location, so it must not contain characters that NGINX interprets, such as spaces, braces or semicolons.
+	apiCatalogPathRegex = `^/[A-Za-z0-9._~\-/]*$`
+
+	// maxConfigHistorySize is the maximum number of the retained versions of the nginx configuration of a Gateway.
+	maxConfigHistorySize = 100
+
+	// minCanaryAnalysisInterval is the minimum interval between the analyses of the canaries of the Routes.
+	minCanaryAnalysisInterval = time.Second
+
+	// minOutlierHookInterval is the minimum interval between the evaluations of the error rates of the Routes.
+	minOutlierHookInterval = time.Second
+
+	// minAPICatalogInterval is the minimum interval between the aggregations of the OpenAPI specs of the Routes.
+	// Every aggregation fetches the specs of all Routes, so a short interval overloads their backends.
+	minAPICatalogInterval = 10 * time.Second
+
+	// minUsageSummaryInterval is the minimum window of the usage summaries of the Gateways.
+	minUsageSummaryInterval = time.Minute
+
+	// maxNginxReloadMinInterval is the maximum of the minimum interval between the reloads of nginx. The endpoint
+	// changes are deferred for a multiple of the interval, so a long interval keeps stale endpoints for long.
+	maxNginxReloadMinInterval = time.Minute
+
+	// maxWASMHookTimeout is the maximum execution time of the WASM hook for a Gateway. The hook runs every time
+	// the configuration of a Gateway changes, so a long execution time delays the configuration of the Gateway.
+	maxWASMHookTimeout = 10 * time.Second
+
+	// maxWASMHookMemoryMiB is the maximum memory of the WASM hook, in MiB.
+	maxWASMHookMemoryMiB = 4096
 )
 
 func validateGatewayControllerName(value string) error {
@@ -54,6 +91,28 @@ func validateResourceName(value string) error {
 	return nil
 }
 
+// validateNamespacedName makes sure the value is a namespaced name in the format <namespace>/<name>.
+func validateNamespacedName(value string) error {
+	if len(value) == 0 {
+		return errors.New("must be set")
+	}
+
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok {
+		return fmt.Errorf("invalid format: %q must be in the format <namespace>/<name>", value)
+	}
+
+	if messages := validation.IsDNS1123Label(namespace); len(messages) > 0 {
+		return fmt.Errorf("invalid namespace: %s", strings.Join(messages, "; "))
+	}
+
+	if err := validateResourceName(name); err != nil {
+		return fmt.Errorf("invalid name: %w", err)
+	}
+
+	return nil
+}
+
 func validateQualifiedName(name string) error {
 	if len(name) == 0 {
 		return errors.New("must be set")
@@ -166,6 +225,184 @@ func validateAnyPort(port int) error {
 	return nil
 }
 
+// validateConfigHistorySize makes sure the number of the retained versions of the nginx configuration
+// is inside the supported range.
+func validateConfigHistorySize(size int) error {
+	if size < 0 || size > maxConfigHistorySize {
+		return fmt.Errorf("config history size outside of valid range [0 - %d]: %v", maxConfigHistorySize, size)
+	}
+	return nil
+}
+
+// validateWebhookMaxRoutesPerGateway makes sure the maximum number of the Routes of a Gateway is not negative.
+func validateWebhookMaxRoutesPerGateway(routes int) error {
+	if routes < 0 {
+		return fmt.Errorf("max routes per Gateway must not be negative: %v", routes)
+	}
+	return nil
+}
+
+// validateWASMHookTimeout makes sure the execution time of the WASM hook is a positive duration
+// that doesn't exceed maxWASMHookTimeout.
+func validateWASMHookTimeout(value string) error {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%q must be a valid duration: %w", value, err)
+	}
+
+	if timeout <= 0 || timeout > maxWASMHookTimeout {
+		return fmt.Errorf("%q must be greater than 0 and at most %s", value, maxWASMHookTimeout)
+	}
+
+	return nil
+}
+
+func validateWASMHookMaxMemory(size int) error {
+	if size < 1 || size > maxWASMHookMemoryMiB {
+		return fmt.Errorf("WASM hook max memory outside of valid range [1 - %d]: %v", maxWASMHookMemoryMiB, size)
+	}
+	return nil
+}
+
+// validateHTTPURL makes sure a given value is an absolute http or https URL.
+func validateHTTPURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("%q must be a valid URL: %w", value, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must use the http or https scheme", value)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("%q must include a host", value)
+	}
+
+	return nil
+}
+
+// validateCanaryAnalysisInterval makes sure the interval between the analyses of the canaries is a duration
+// of at least one second.
+func validateCanaryAnalysisInterval(value string) error {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%q must be a valid duration: %w", value, err)
+	}
+
+	if interval < minCanaryAnalysisInterval {
+		return fmt.Errorf("%q must be at least %s", value, minCanaryAnalysisInterval)
+	}
+
+	return nil
+}
+
+// validateOutlierHookInterval makes sure the interval between the evaluations of the outlier hook is a duration
+// of at least one second.
+func validateOutlierHookInterval(value string) error {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%q must be a valid duration: %w", value, err)
+	}
+
+	if interval < minOutlierHookInterval {
+		return fmt.Errorf("%q must be at least %s", value, minOutlierHookInterval)
+	}
+
+	return nil
+}
+
+// validateAPICatalogPath makes sure the path of the API catalogs is an absolute URI path that can be used
+// in an exact NGINX location.
+func validateAPICatalogPath(value string) error {
+	re := regexp.MustCompile(apiCatalogPathRegex)
+	if !re.MatchString(value) {
+		return fmt.Errorf("%q must be an absolute path that matches the regex %s", value, apiCatalogPathRegex)
+	}
+
+	return nil
+}
+
+// validateAPICatalogInterval makes sure the interval between the aggregations of the OpenAPI specs is a duration
+// of at least minAPICatalogInterval.
+func validateAPICatalogInterval(value string) error {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%q must be a valid duration: %w", value, err)
+	}
+
+	if interval < minAPICatalogInterval {
+		return fmt.Errorf("%q must be at least %s", value, minAPICatalogInterval)
+	}
+
+	return nil
+}
+
+// validateUsageSummaryInterval makes sure the window of the usage summaries is a valid duration
+// that is long enough to not overload the API server with the updates of the summaries.
+func validateUsageSummaryInterval(value string) error {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%q must be a valid duration: %w", value, err)
+	}
+
+	if interval < minUsageSummaryInterval {
+		return fmt.Errorf("%q must be at least %s", value, minUsageSummaryInterval)
+	}
+
+	return nil
+}
+
+// validateNginxReloadMinInterval makes sure the minimum interval between the reloads of nginx is a positive
+// duration that doesn't exceed maxNginxReloadMinInterval.
+func validateNginxReloadMinInterval(value string) error {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%q must be a valid duration: %w", value, err)
+	}
+
+	if interval <= 0 || interval > maxNginxReloadMinInterval {
+		return fmt.Errorf("%q must be greater than 0 and at most %s", value, maxNginxReloadMinInterval)
+	}
+
+	return nil
+}
+
+// validateCanaryAnalysisQuery makes sure a Prometheus query of the canary analysis references the upstream
+// of the canary, so that the query returns the metrics of the analyzed canary only.
+func validateCanaryAnalysisQuery(value string) error {
+	if !strings.Contains(value, canary.UpstreamPlaceholder) {
+		return fmt.Errorf("%q must reference the upstream of the canary with %s", value, canary.UpstreamPlaceholder)
+	}
+
+	return nil
+}
+
+// validateOutlierHookQuery makes sure the Prometheus query of the outlier hook references the upstream,
+// so that the query returns the error rate of the evaluated upstream only.
+func validateOutlierHookQuery(value string) error {
+	if !strings.Contains(value, canary.UpstreamPlaceholder) {
+		return fmt.Errorf("%q must reference the upstream with %s", value, canary.UpstreamPlaceholder)
+	}
+
+	return nil
+}
+
+// validateModuleLogLevels makes sure the module logging levels are in the format "module1=level1,module2=level2"
+// and only use supported levels.
+func validateModuleLogLevels(value string) error {
+	_, err := logging.ParseModuleLevels(value)
+	return err
+}
+
+// validateAbsolutePath makes sure a given path is an absolute path.
+func validateAbsolutePath(path string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("%q must be an absolute path", path)
+	}
+	return nil
+}
+
 // ensureNoPortCollisions checks if the same port has been defined multiple times.
 func ensureNoPortCollisions(ports ...int) error {
 	seen := make(map[int]struct{})
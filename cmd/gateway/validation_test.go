@@ -131,6 +131,20 @@ func TestValidateResourceName(t *testing.T) {
 	}
 }
 
+func TestValidateNamespacedName(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateNamespacedName("default/gateway")).To(Succeed())
+	g.Expect(validateNamespacedName("nginx-gateway/my.gateway")).To(Succeed())
+	g.Expect(validateNamespacedName("")).ToNot(Succeed())
+	g.Expect(validateNamespacedName("gateway")).ToNot(Succeed())
+	g.Expect(validateNamespacedName("/gateway")).ToNot(Succeed())
+	g.Expect(validateNamespacedName("default/")).ToNot(Succeed())
+	g.Expect(validateNamespacedName("my.namespace/gateway")).ToNot(Succeed())
+	g.Expect(validateNamespacedName("default/my/gateway")).ToNot(Succeed())
+}
+
 func TestValidateQualifiedName(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -414,6 +428,180 @@ func TestValidatePort(t *testing.T) {
 	}
 }
 
+func TestValidateAbsolutePath(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateAbsolutePath("/var/lib/nginx-export")).To(Succeed())
+	g.Expect(validateAbsolutePath("var/lib/nginx-export")).ToNot(Succeed())
+	g.Expect(validateAbsolutePath("")).ToNot(Succeed())
+}
+
+func TestValidateHTTPURL(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateHTTPURL("http://prometheus.monitoring:9090")).To(Succeed())
+	g.Expect(validateHTTPURL("https://prometheus.example.com/api/prom")).To(Succeed())
+	g.Expect(validateHTTPURL("prometheus.monitoring:9090")).ToNot(Succeed())
+	g.Expect(validateHTTPURL("ftp://prometheus.monitoring")).ToNot(Succeed())
+	g.Expect(validateHTTPURL("http://")).ToNot(Succeed())
+	g.Expect(validateHTTPURL("http://%zz")).ToNot(Succeed())
+}
+
+func TestValidateCanaryAnalysisInterval(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateCanaryAnalysisInterval("1s")).To(Succeed())
+	g.Expect(validateCanaryAnalysisInterval("5m")).To(Succeed())
+	g.Expect(validateCanaryAnalysisInterval("500ms")).ToNot(Succeed())
+	g.Expect(validateCanaryAnalysisInterval("1 minute")).ToNot(Succeed())
+}
+
+func TestValidateAPICatalogPath(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateAPICatalogPath("/.well-known/api-catalog")).To(Succeed())
+	g.Expect(validateAPICatalogPath("/apis")).To(Succeed())
+	g.Expect(validateAPICatalogPath("apis")).ToNot(Succeed())
+	g.Expect(validateAPICatalogPath("/apis;")).ToNot(Succeed())
+	g.Expect(validateAPICatalogPath("/api catalog")).ToNot(Succeed())
+	g.Expect(validateAPICatalogPath("")).ToNot(Succeed())
+}
+
+func TestValidateAPICatalogInterval(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateAPICatalogInterval("10s")).To(Succeed())
+	g.Expect(validateAPICatalogInterval("5m")).To(Succeed())
+	g.Expect(validateAPICatalogInterval("5s")).ToNot(Succeed())
+	g.Expect(validateAPICatalogInterval("1 minute")).ToNot(Succeed())
+}
+
+func TestValidateUsageSummaryInterval(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateUsageSummaryInterval("1m")).To(Succeed())
+	g.Expect(validateUsageSummaryInterval("1h")).To(Succeed())
+	g.Expect(validateUsageSummaryInterval("30s")).ToNot(Succeed())
+	g.Expect(validateUsageSummaryInterval("1 hour")).ToNot(Succeed())
+}
+
+func TestValidateNginxReloadMinInterval(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateNginxReloadMinInterval("500ms")).To(Succeed())
+	g.Expect(validateNginxReloadMinInterval("1m")).To(Succeed())
+	g.Expect(validateNginxReloadMinInterval("0s")).ToNot(Succeed())
+	g.Expect(validateNginxReloadMinInterval("2m")).ToNot(Succeed())
+	g.Expect(validateNginxReloadMinInterval("5 seconds")).ToNot(Succeed())
+}
+
+func TestValidateWebhookMaxRoutesPerGateway(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateWebhookMaxRoutesPerGateway(0)).To(Succeed())
+	g.Expect(validateWebhookMaxRoutesPerGateway(100)).To(Succeed())
+	g.Expect(validateWebhookMaxRoutesPerGateway(-1)).ToNot(Succeed())
+}
+
+func TestValidateWASMHookTimeout(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateWASMHookTimeout("100ms")).To(Succeed())
+	g.Expect(validateWASMHookTimeout("10s")).To(Succeed())
+	g.Expect(validateWASMHookTimeout("0s")).ToNot(Succeed())
+	g.Expect(validateWASMHookTimeout("11s")).ToNot(Succeed())
+	g.Expect(validateWASMHookTimeout("1 second")).ToNot(Succeed())
+}
+
+func TestValidateWASMHookMaxMemory(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateWASMHookMaxMemory(1)).To(Succeed())
+	g.Expect(validateWASMHookMaxMemory(4096)).To(Succeed())
+	g.Expect(validateWASMHookMaxMemory(0)).ToNot(Succeed())
+	g.Expect(validateWASMHookMaxMemory(4097)).ToNot(Succeed())
+}
+
+func TestValidateCanaryAnalysisQuery(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateCanaryAnalysisQuery(`errors{upstream="$upstream"}`)).To(Succeed())
+	g.Expect(validateCanaryAnalysisQuery("errors")).ToNot(Succeed())
+}
+
+func TestValidateOutlierHookInterval(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateOutlierHookInterval("1s")).To(Succeed())
+	g.Expect(validateOutlierHookInterval("1m")).To(Succeed())
+	g.Expect(validateOutlierHookInterval("100ms")).ToNot(Succeed())
+	g.Expect(validateOutlierHookInterval("one minute")).ToNot(Succeed())
+}
+
+func TestValidateOutlierHookQuery(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateOutlierHookQuery(`errors{upstream="$upstream"}`)).To(Succeed())
+	g.Expect(validateOutlierHookQuery("errors")).ToNot(Succeed())
+}
+
+func TestValidateModuleLogLevels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		value  string
+		expErr bool
+	}{
+		{
+			name:   "empty",
+			value:  "",
+			expErr: false,
+		},
+		{
+			name:   "valid",
+			value:  "eventHandler=debug,provisioner=error",
+			expErr: false,
+		},
+		{
+			name:   "unsupported level",
+			value:  "eventHandler=warn",
+			expErr: true,
+		},
+		{
+			name:   "missing level",
+			value:  "eventHandler",
+			expErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			err := validateModuleLogLevels(tc.value)
+			if tc.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestProtocolPort(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
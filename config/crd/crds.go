@@ -0,0 +1,30 @@
+// Package crd embeds the CustomResourceDefinitions of NGINX Gateway Fabric, so that the control plane can install
+// and upgrade them.
+package crd
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed bases/*.yaml
+var bases embed.FS
+
+// Files returns the contents of the CustomResourceDefinition files, keyed by the file name.
+func Files() (map[string][]byte, error) {
+	entries, err := fs.ReadDir(bases, "bases")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		content, err := fs.ReadFile(bases, "bases/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		files[entry.Name()] = content
+	}
+
+	return files, nil
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/apicatalog"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/runnables"
+)
+
+// apiCatalogJitterFactor spreads the aggregations of the API catalogs of the replicas of the control plane.
+const apiCatalogJitterFactor = 0.1
+
+// apiCatalogEvent makes the event handler regenerate the configuration from the latest graph, because the API
+// catalog of a Gateway changed.
+type apiCatalogEvent struct{}
+
+// newAPICatalogJob creates a job that periodically aggregates the OpenAPI specs of the Routes of the latest graph
+// into the API catalogs of the Gateways, and sends an apiCatalogEvent to the event loop when a catalog changes.
+// Every replica of the control plane aggregates the specs, so that they all generate the same configuration.
+func newAPICatalogJob(
+	logger logr.Logger,
+	aggregator *apicatalog.Aggregator,
+	getLatestGraph func() *graph.Graph,
+	eventCh chan<- interface{},
+	readyCh <-chan struct{},
+	period time.Duration,
+) *runnables.LeaderOrNonLeader {
+	worker := func(ctx context.Context) {
+		if !aggregator.Aggregate(ctx, getLatestGraph()) {
+			return
+		}
+
+		select {
+		case eventCh <- &apiCatalogEvent{}:
+		case <-ctx.Done():
+		}
+	}
+
+	return &runnables.LeaderOrNonLeader{
+		Runnable: runnables.NewCronJob(
+			runnables.CronJobConfig{
+				Worker:       worker,
+				Logger:       logger,
+				Period:       period,
+				JitterFactor: apiCatalogJitterFactor,
+				ReadyCh:      readyCh,
+			},
+		),
+	}
+}
+
+// apiCatalogChanged returns whether the API catalog of a Gateway changed since the last call, and resets it.
+func (h *eventHandlerImpl) apiCatalogChanged() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	changed := h.catalogChanged
+	h.catalogChanged = false
+
+	return changed
+}
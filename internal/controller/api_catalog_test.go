@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/apicatalog"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/apicatalog/apicatalogfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver/resolverfakes"
+)
+
+func TestAPICatalogJob(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	route := &graph.L7Route{
+		Source: &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "test",
+				Name:        "route",
+				Annotations: map[string]string{apicatalog.SpecAnnotation: "/openapi.json"},
+			},
+		},
+		RouteType: graph.RouteTypeHTTP,
+		Valid:     true,
+		Spec: graph.L7RouteSpec{
+			Rules: []graph.RouteRule{
+				{
+					BackendRefs: []graph.BackendRef{
+						{
+							SvcNsName:   types.NamespacedName{Namespace: "test", Name: "backend"},
+							ServicePort: v1.ServicePort{Port: 80},
+							Valid:       true,
+						},
+					},
+				},
+			},
+		},
+		ParentRefs: []graph.ParentRef{
+			{
+				Gateway:    &graph.ParentRefGateway{NamespacedName: gwNsName},
+				Attachment: &graph.ParentRefAttachmentStatus{Attached: true},
+			},
+		},
+	}
+	gr := &graph.Graph{
+		Gateways: map[types.NamespacedName]*graph.Gateway{gwNsName: {}},
+		Routes:   map[graph.RouteKey]*graph.L7Route{graph.CreateRouteKey(route.Source): route},
+	}
+
+	fetcher := &apicatalogfakes.FakeFetcher{}
+	fetcher.FetchReturns([]byte(`{"openapi": "3.0.0"}`), nil)
+
+	serviceResolver := &resolverfakes.FakeServiceResolver{}
+	serviceResolver.ResolveReturns([]resolver.Endpoint{{Address: "10.0.0.1", Port: 8080}}, nil)
+
+	aggregator := apicatalog.NewAggregator(logr.Discard(), fetcher, serviceResolver)
+
+	eventCh := make(chan interface{})
+	readyCh := make(chan struct{})
+
+	job := newAPICatalogJob(
+		logr.Discard(),
+		aggregator,
+		func() *graph.Graph { return gr },
+		eventCh,
+		readyCh,
+		10*time.Millisecond,
+	)
+	g.Expect(job.NeedLeaderElection()).To(BeFalse())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- job.Start(ctx)
+	}()
+
+	// the aggregation doesn't start until the control plane is ready
+	g.Consistently(eventCh).ShouldNot(Receive())
+
+	close(readyCh)
+
+	var event interface{}
+	g.Eventually(eventCh).Should(Receive(&event))
+	g.Expect(event).To(BeAssignableToTypeOf(&apiCatalogEvent{}))
+	g.Expect(string(aggregator.Catalog(gwNsName))).To(Equal(
+		`{"apis":[{"route":"test/route","spec":{"openapi":"3.0.0"}}]}`,
+	))
+
+	// the catalog holds, so no more events are sent
+	g.Consistently(eventCh).ShouldNot(Receive())
+
+	cancel()
+	g.Eventually(errCh).Should(Receive(BeNil()))
+}
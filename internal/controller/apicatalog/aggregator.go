@@ -0,0 +1,251 @@
+package apicatalog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+)
+
+// SpecAnnotation is the annotation of the HTTPRoutes whose backends provide an OpenAPI spec. The value is
+// the path of the spec on the first Service backend of the Route.
+const SpecAnnotation = "gateway.nginx.org/openapi-spec"
+
+// Catalog is the catalog of the APIs of the Routes of a Gateway.
+type Catalog struct {
+	// APIs are the APIs of the Routes, sorted by Route.
+	APIs []API `json:"apis"`
+}
+
+// API is the API of a Route.
+type API struct {
+	// Route is the namespace and name of the Route.
+	Route string `json:"route"`
+	// Hostnames are the hostnames of the Route.
+	Hostnames []string `json:"hostnames,omitempty"`
+	// Spec is the OpenAPI spec of the backend of the Route.
+	Spec json.RawMessage `json:"spec"`
+}
+
+// Aggregator aggregates the OpenAPI specs of the backends of the Routes into a Catalog per Gateway.
+type Aggregator struct {
+	fetcher  Fetcher
+	resolver resolver.ServiceResolver
+	// specs are the latest fetched specs of the Routes.
+	specs map[types.NamespacedName]json.RawMessage
+	// catalogs are the marshaled Catalogs of the Gateways.
+	catalogs map[types.NamespacedName][]byte
+	logger   logr.Logger
+	lock     sync.RWMutex
+}
+
+// NewAggregator creates a new Aggregator. The specs are fetched from the endpoints of the backends of the Routes
+// that the resolver resolves.
+func NewAggregator(logger logr.Logger, fetcher Fetcher, serviceResolver resolver.ServiceResolver) *Aggregator {
+	return &Aggregator{
+		fetcher:  fetcher,
+		resolver: serviceResolver,
+		specs:    make(map[types.NamespacedName]json.RawMessage),
+		catalogs: make(map[types.NamespacedName][]byte),
+		logger:   logger,
+	}
+}
+
+// Aggregate fetches the specs of the Routes of the graph, and builds the Catalogs of the Gateways of the graph.
+// It returns whether the Catalog of a Gateway changed.
+func (a *Aggregator) Aggregate(ctx context.Context, gr *graph.Graph) bool {
+	if gr == nil {
+		return false
+	}
+
+	specs := make(map[types.NamespacedName]json.RawMessage)
+	apis := make(map[types.NamespacedName][]API, len(gr.Gateways))
+	for gwNsName := range gr.Gateways {
+		apis[gwNsName] = []API{}
+	}
+
+	for _, route := range gr.Routes {
+		value, ok := route.Source.GetAnnotations()[SpecAnnotation]
+		if !ok || !route.Valid || route.RouteType != graph.RouteTypeHTTP {
+			continue
+		}
+
+		routeNsName := client.ObjectKeyFromObject(route.Source)
+
+		spec, err := a.fetchSpec(ctx, route, value)
+		if err != nil {
+			a.logger.Error(err, "error fetching OpenAPI spec", "route", routeNsName)
+
+			if spec, ok = a.latestSpec(routeNsName); !ok {
+				continue
+			}
+		}
+		specs[routeNsName] = spec
+
+		api := API{
+			Route:     routeNsName.String(),
+			Hostnames: hostnames(route),
+			Spec:      spec,
+		}
+
+		for _, gwNsName := range attachedGateways(route) {
+			if _, exists := apis[gwNsName]; exists {
+				apis[gwNsName] = append(apis[gwNsName], api)
+			}
+		}
+	}
+
+	catalogs := make(map[types.NamespacedName][]byte, len(apis))
+	for gwNsName, gwAPIs := range apis {
+		slices.SortFunc(gwAPIs, func(a, b API) int {
+			return strings.Compare(a.Route, b.Route)
+		})
+
+		catalog, err := json.Marshal(Catalog{APIs: gwAPIs})
+		if err != nil {
+			// the specs are valid JSON, so the Catalog is always marshaled
+			panic(fmt.Errorf("could not marshal API catalog: %w", err))
+		}
+		catalogs[gwNsName] = catalog
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	changed := !maps.EqualFunc(a.catalogs, catalogs, bytes.Equal)
+	a.catalogs = catalogs
+	a.specs = specs
+
+	return changed
+}
+
+// Catalog returns the marshaled Catalog of the Gateway, or nil if the Catalog of the Gateway wasn't built yet.
+func (a *Aggregator) Catalog(gateway types.NamespacedName) []byte {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.catalogs[gateway]
+}
+
+func (a *Aggregator) latestSpec(route types.NamespacedName) (json.RawMessage, bool) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	spec, ok := a.specs[route]
+	return spec, ok
+}
+
+func (a *Aggregator) fetchSpec(ctx context.Context, route *graph.L7Route, annotation string) (json.RawMessage, error) {
+	specURL, err := a.buildSpecURL(ctx, route, annotation)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := a.fetcher.Fetch(ctx, specURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// the specs are compacted, so that a change of the formatting of a spec doesn't change the Catalog
+	var spec bytes.Buffer
+	if err := json.Compact(&spec, data); err != nil {
+		return nil, fmt.Errorf("OpenAPI spec at %s is not valid JSON: %w", specURL, err)
+	}
+
+	return spec.Bytes(), nil
+}
+
+// buildSpecURL builds the URL of the spec of the Route from the value of its SpecAnnotation. The spec is fetched from
+// an endpoint of the first Service backend of the Route, so that a Route can't make the control plane fetch
+// an arbitrary URL.
+func (a *Aggregator) buildSpecURL(ctx context.Context, route *graph.L7Route, annotation string) (string, error) {
+	specPath, err := url.Parse(annotation)
+	if err != nil || specPath.Scheme != "" || specPath.Host != "" || !strings.HasPrefix(specPath.Path, "/") {
+		return "", fmt.Errorf("invalid %s annotation %q: must be an absolute path", SpecAnnotation, annotation)
+	}
+
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			if !ref.Valid || ref.IsStaticBackend() {
+				continue
+			}
+
+			endpoints, err := a.resolver.Resolve(
+				ctx,
+				a.logger,
+				ref.SvcNsName,
+				ref.ServicePort,
+				[]discoveryV1.AddressType{discoveryV1.AddressTypeIPv4, discoveryV1.AddressTypeIPv6},
+			)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve the endpoints of Service %s: %w", ref.SvcNsName, err)
+			}
+
+			for _, ep := range endpoints {
+				// the addresses of ExternalName Services are DNS names that can point anywhere
+				if ep.Resolve {
+					continue
+				}
+
+				specURL := url.URL{
+					Scheme:   "http",
+					Host:     net.JoinHostPort(ep.Address, strconv.Itoa(int(ep.Port))),
+					Path:     specPath.Path,
+					RawQuery: specPath.RawQuery,
+				}
+
+				return specURL.String(), nil
+			}
+
+			return "", fmt.Errorf("Service %s has no ready endpoint that provides the OpenAPI spec", ref.SvcNsName)
+		}
+	}
+
+	return "", errors.New("the Route has no valid Service backend that provides the OpenAPI spec")
+}
+
+func hostnames(route *graph.L7Route) []string {
+	if len(route.Spec.Hostnames) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(route.Spec.Hostnames))
+	for _, h := range route.Spec.Hostnames {
+		names = append(names, string(h))
+	}
+
+	return names
+}
+
+// attachedGateways returns the Gateways that the Route is attached to, without duplicates.
+func attachedGateways(route *graph.L7Route) []types.NamespacedName {
+	var gateways []types.NamespacedName
+
+	for _, ref := range route.ParentRefs {
+		if ref.Gateway == nil || ref.Attachment == nil || !ref.Attachment.Attached {
+			continue
+		}
+
+		if !slices.Contains(gateways, ref.Gateway.NamespacedName) {
+			gateways = append(gateways, ref.Gateway.NamespacedName)
+		}
+	}
+
+	return gateways
+}
@@ -0,0 +1,272 @@
+package apicatalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver/resolverfakes"
+)
+
+// newResolver returns a ServiceResolver that resolves every Service to the endpoint 10.0.0.<n>:8080, where n is
+// the length of the name of the Service.
+func newResolver() *resolverfakes.FakeServiceResolver {
+	fakeResolver := &resolverfakes.FakeServiceResolver{}
+	fakeResolver.ResolveStub = func(
+		_ context.Context,
+		_ logr.Logger,
+		svcNsName types.NamespacedName,
+		_ v1.ServicePort,
+		_ []discoveryV1.AddressType,
+	) ([]resolver.Endpoint, error) {
+		return []resolver.Endpoint{{Address: fmt.Sprintf("10.0.0.%d", len(svcNsName.Name)), Port: 8080}}, nil
+	}
+
+	return fakeResolver
+}
+
+type fakeFetcher struct {
+	fetch func(url string) ([]byte, error)
+	calls int
+}
+
+func (f *fakeFetcher) Fetch(_ context.Context, url string) ([]byte, error) {
+	f.calls++
+	return f.fetch(url)
+}
+
+func newRoute(name, annotation string, gateways ...types.NamespacedName) *graph.L7Route {
+	route := &graph.L7Route{
+		Source: &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "test",
+				Name:        name,
+				Annotations: map[string]string{SpecAnnotation: annotation},
+			},
+		},
+		RouteType: graph.RouteTypeHTTP,
+		Valid:     true,
+		Spec: graph.L7RouteSpec{
+			Hostnames: []gatewayv1.Hostname{"foo.example.com"},
+			Rules: []graph.RouteRule{
+				{
+					BackendRefs: []graph.BackendRef{
+						{
+							SvcNsName:   types.NamespacedName{Namespace: "test", Name: name},
+							ServicePort: v1.ServicePort{Port: 8080},
+							Valid:       true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, gw := range gateways {
+		route.ParentRefs = append(route.ParentRefs, graph.ParentRef{
+			Gateway:    &graph.ParentRefGateway{NamespacedName: gw},
+			Attachment: &graph.ParentRefAttachmentStatus{Attached: true},
+		})
+	}
+
+	return route
+}
+
+func TestAggregator_Aggregate(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gw1 := types.NamespacedName{Namespace: "test", Name: "gateway-1"}
+	gw2 := types.NamespacedName{Namespace: "test", Name: "gateway-2"}
+
+	bar := newRoute("bar", "/openapi.json", gw1)
+	foo := newRoute("foo", "/foo.json", gw1, gw2)
+	unannotated := newRoute("unannotated", "", gw1)
+	delete(unannotated.Source.GetAnnotations(), SpecAnnotation)
+
+	gr := &graph.Graph{
+		Gateways: map[types.NamespacedName]*graph.Gateway{
+			gw1: {},
+			gw2: {},
+		},
+		Routes: map[graph.RouteKey]*graph.L7Route{
+			graph.CreateRouteKey(bar.Source):         bar,
+			graph.CreateRouteKey(foo.Source):         foo,
+			graph.CreateRouteKey(unannotated.Source): unannotated,
+		},
+	}
+
+	fetcher := &fakeFetcher{}
+	fetcher.fetch = func(url string) ([]byte, error) {
+		switch url {
+		case "http://10.0.0.3:8080/openapi.json":
+			return []byte(`{"openapi": "3.0.0", "info": {"title": "bar"}}`), nil
+		case "http://10.0.0.3:8080/foo.json":
+			return []byte(`{"openapi": "3.0.0", "info": {"title": "foo"}}`), nil
+		default:
+			return nil, errors.New("not found")
+		}
+	}
+
+	aggregator := NewAggregator(logr.Discard(), fetcher, newResolver())
+	g.Expect(aggregator.Catalog(gw1)).To(BeNil())
+
+	g.Expect(aggregator.Aggregate(context.Background(), gr)).To(BeTrue())
+	g.Expect(fetcher.calls).To(Equal(2))
+
+	g.Expect(string(aggregator.Catalog(gw1))).To(Equal(
+		`{"apis":[` +
+			`{"route":"test/bar","hostnames":["foo.example.com"],"spec":{"openapi":"3.0.0","info":{"title":"bar"}}},` +
+			`{"route":"test/foo","hostnames":["foo.example.com"],"spec":{"openapi":"3.0.0","info":{"title":"foo"}}}` +
+			`]}`,
+	))
+	g.Expect(string(aggregator.Catalog(gw2))).To(Equal(
+		`{"apis":[` +
+			`{"route":"test/foo","hostnames":["foo.example.com"],"spec":{"openapi":"3.0.0","info":{"title":"foo"}}}` +
+			`]}`,
+	))
+
+	// the same specs don't change the catalogs
+	g.Expect(aggregator.Aggregate(context.Background(), gr)).To(BeFalse())
+
+	// the latest spec is kept while it can't be fetched
+	fetcher.fetch = func(string) ([]byte, error) { return nil, errors.New("unavailable") }
+	g.Expect(aggregator.Aggregate(context.Background(), gr)).To(BeFalse())
+	g.Expect(string(aggregator.Catalog(gw2))).To(ContainSubstring(`"route":"test/foo"`))
+
+	// the APIs of the deleted Routes are removed
+	delete(gr.Routes, graph.CreateRouteKey(foo.Source))
+	g.Expect(aggregator.Aggregate(context.Background(), gr)).To(BeTrue())
+	g.Expect(string(aggregator.Catalog(gw2))).To(Equal(`{"apis":[]}`))
+}
+
+func TestAggregator_AggregateInvalidSpec(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gw := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	route := newRoute("foo", "/openapi.yaml", gw)
+
+	gr := &graph.Graph{
+		Gateways: map[types.NamespacedName]*graph.Gateway{gw: {}},
+		Routes:   map[graph.RouteKey]*graph.L7Route{graph.CreateRouteKey(route.Source): route},
+	}
+
+	fetcher := &fakeFetcher{
+		fetch: func(string) ([]byte, error) { return []byte("openapi: 3.0.0"), nil },
+	}
+
+	aggregator := NewAggregator(logr.Discard(), fetcher, newResolver())
+	g.Expect(aggregator.Aggregate(context.Background(), gr)).To(BeTrue())
+	g.Expect(string(aggregator.Catalog(gw))).To(Equal(`{"apis":[]}`))
+}
+
+func TestBuildSpecURL(t *testing.T) {
+	t.Parallel()
+
+	staticBackend := newRoute("foo", "")
+	staticBackend.Spec.Rules[0].BackendRefs[0].StaticEndpoints = []ngfAPI.BackendEndpoint{
+		{Address: "10.0.0.1", Port: 8080},
+	}
+
+	tests := []struct {
+		route      *graph.L7Route
+		endpoints  []resolver.Endpoint
+		name       string
+		annotation string
+		expURL     string
+		expErr     bool
+	}{
+		{
+			name:       "path on an endpoint of the first backend",
+			route:      newRoute("foo", ""),
+			endpoints:  []resolver.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			annotation: "/openapi.json?format=json",
+			expURL:     "http://10.0.0.1:8080/openapi.json?format=json",
+		},
+		{
+			name:       "IPv6 endpoint",
+			route:      newRoute("foo", ""),
+			endpoints:  []resolver.Endpoint{{Address: "fd00::1", Port: 8080, IPv6: true}},
+			annotation: "/openapi.json",
+			expURL:     "http://[fd00::1]:8080/openapi.json",
+		},
+		{
+			name:  "ExternalName endpoints are skipped",
+			route: newRoute("foo", ""),
+			endpoints: []resolver.Endpoint{
+				{Address: "metadata.example.com", Port: 80, Resolve: true},
+				{Address: "10.0.0.2", Port: 8080},
+			},
+			annotation: "/openapi.json",
+			expURL:     "http://10.0.0.2:8080/openapi.json",
+		},
+		{
+			name:       "only ExternalName endpoints",
+			route:      newRoute("foo", ""),
+			endpoints:  []resolver.Endpoint{{Address: "metadata.example.com", Port: 80, Resolve: true}},
+			annotation: "/openapi.json",
+			expErr:     true,
+		},
+		{
+			name:       "absolute URL",
+			route:      newRoute("foo", ""),
+			endpoints:  []resolver.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			annotation: "http://169.254.169.254/latest/meta-data",
+			expErr:     true,
+		},
+		{
+			name:       "scheme-relative URL",
+			route:      newRoute("foo", ""),
+			endpoints:  []resolver.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			annotation: "//169.254.169.254/latest/meta-data",
+			expErr:     true,
+		},
+		{
+			name:       "relative path",
+			route:      newRoute("foo", ""),
+			endpoints:  []resolver.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			annotation: "openapi.json",
+			expErr:     true,
+		},
+		{
+			name:       "no Service backend",
+			route:      staticBackend,
+			annotation: "/openapi.json",
+			expErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			fakeResolver := &resolverfakes.FakeServiceResolver{}
+			fakeResolver.ResolveReturns(test.endpoints, nil)
+
+			aggregator := NewAggregator(logr.Discard(), &fakeFetcher{}, fakeResolver)
+
+			specURL, err := aggregator.buildSpecURL(context.Background(), test.route, test.annotation)
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(specURL).To(Equal(test.expURL))
+		})
+	}
+}
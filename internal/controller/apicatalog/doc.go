@@ -0,0 +1,11 @@
+/*
+Package apicatalog aggregates the OpenAPI specs of the backends of HTTPRoutes into a catalog per Gateway.
+
+An HTTPRoute opts into the catalog with the gateway.nginx.org/openapi-spec annotation, which is the path of the spec
+on the first Service backend of the Route. The Aggregator periodically fetches the specs of the Routes from
+the endpoints of their backends, without following redirects, and combines the specs of the Routes attached to
+a Gateway into the catalog of the Gateway, which NGINX serves on every server of the Gateway at a configurable path.
+The latest spec of a Route is kept while it can't be fetched, so that an unavailable backend doesn't remove its API
+from the catalog.
+*/
+package apicatalog
@@ -0,0 +1,76 @@
+package apicatalog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+//go:generate go tool counterfeiter -generate
+
+const (
+	// DefaultFetchTimeout is the default timeout of the requests for the specs.
+	DefaultFetchTimeout = 10 * time.Second
+
+	// maxSpecSize is the maximum size of a spec. The specs are served by NGINX from a file of the configuration
+	// of every Gateway, so large specs increase the size of the configuration.
+	maxSpecSize = 4 << 20
+)
+
+//counterfeiter:generate . Fetcher
+
+// Fetcher fetches the OpenAPI specs.
+type Fetcher interface {
+	// Fetch fetches the document at the URL.
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// HTTPFetcher fetches the OpenAPI specs over HTTP.
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher creates a new HTTPFetcher. Redirects are not followed, so that a backend can't redirect
+// the control plane to an arbitrary URL.
+func NewHTTPFetcher(timeout time.Duration) *HTTPFetcher {
+	return &HTTPFetcher{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Fetch fetches the document at the URL.
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: unexpected status code %d", url, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSpecSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+
+	if len(data) > maxSpecSize {
+		return nil, fmt.Errorf("document at %s exceeds the maximum size of %d bytes", url, maxSpecSize)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,46 @@
+package apicatalog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHTTPFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openapi.json":
+			_, _ = w.Write([]byte(`{"openapi":"3.0.0"}`))
+		case "/redirect.json":
+			http.Redirect(w, r, "/openapi.json", http.StatusFound)
+		case "/large.json":
+			_, _ = w.Write([]byte(strings.Repeat(" ", maxSpecSize+1)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPFetcher(DefaultFetchTimeout)
+
+	data, err := fetcher.Fetch(context.Background(), server.URL+"/openapi.json")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal(`{"openapi":"3.0.0"}`))
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/missing.json")
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 404")))
+
+	// redirects are not followed
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/redirect.json")
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 302")))
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/large.json")
+	g.Expect(err).To(MatchError(ContainSubstring("exceeds the maximum size")))
+}
@@ -0,0 +1,11 @@
+/*
+Package apicompat detects which Gateway API resources are served by the cluster, so that NGF can run with an older
+Gateway API CRD bundle.
+
+NGF requires the GatewayClass, Gateway and HTTPRoute resources. The other Gateway API resources are optional:
+if the installed CRD bundle doesn't include them, or doesn't include the version NGF uses, NGF doesn't watch them
+and reports the Gateway API features that depend on them as inactive, instead of failing to start.
+The fields that an older CRD bundle doesn't define don't need special handling: NGF reads them as unset, and the
+API server prunes them from the statuses that NGF writes.
+*/
+package apicompat
@@ -0,0 +1,144 @@
+package apicompat
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/pkg/features"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+// Resource is an optional Gateway API resource. NGF can run without it, but the features that depend on it
+// are inactive.
+type Resource struct {
+	// GVK is the GroupVersionKind that NGF uses for the resource.
+	GVK schema.GroupVersionKind
+	// Features are the Gateway API features that depend on the resource.
+	Features []features.FeatureName
+}
+
+var (
+	// BackendTLSPolicy is the BackendTLSPolicy resource.
+	BackendTLSPolicy = Resource{
+		GVK:      gatewayv1.SchemeGroupVersion.WithKind(kinds.BackendTLSPolicy),
+		Features: []features.FeatureName{features.SupportBackendTLSPolicy},
+	}
+	// GRPCRoute is the GRPCRoute resource.
+	GRPCRoute = Resource{
+		GVK:      gatewayv1.SchemeGroupVersion.WithKind(kinds.GRPCRoute),
+		Features: []features.FeatureName{features.SupportGRPCRoute},
+	}
+	// ReferenceGrant is the ReferenceGrant resource.
+	ReferenceGrant = Resource{
+		GVK:      gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant"),
+		Features: []features.FeatureName{features.SupportReferenceGrant},
+	}
+	// TLSRoute is the TLSRoute resource. It is part of the experimental channel.
+	TLSRoute = Resource{
+		GVK:      gatewayv1alpha2.SchemeGroupVersion.WithKind(kinds.TLSRoute),
+		Features: []features.FeatureName{features.SupportTLSRoute},
+	}
+)
+
+// OptionalResources returns the optional Gateway API resources that NGF watches.
+// If experimental is true, the resources of the experimental channel are included.
+func OptionalResources(experimental bool) []Resource {
+	resources := []Resource{BackendTLSPolicy, GRPCRoute, ReferenceGrant}
+
+	if experimental {
+		resources = append(resources, TLSRoute)
+	}
+
+	return resources
+}
+
+// Availability holds the optional Gateway API resources that are not served by the cluster.
+// The zero value means that all resources are served.
+type Availability struct {
+	missing []Resource
+}
+
+// NewAvailability returns the Availability with the given missing resources.
+func NewAvailability(missing ...Resource) Availability {
+	return Availability{missing: missing}
+}
+
+// Served returns true if the resource of the GroupVersionKind is served by the cluster.
+// The resources that are not optional are always considered served.
+func (a Availability) Served(gvk schema.GroupVersionKind) bool {
+	return !slices.ContainsFunc(a.missing, func(r Resource) bool {
+		return r.GVK == gvk
+	})
+}
+
+// Missing returns the optional resources that are not served by the cluster.
+func (a Availability) Missing() []Resource {
+	return a.missing
+}
+
+// InactiveFeatures returns the Gateway API features that are inactive because the resources they depend on are
+// not served by the cluster. The features are sorted by name.
+func (a Availability) InactiveFeatures() []features.FeatureName {
+	var inactive []features.FeatureName
+	for _, r := range a.missing {
+		inactive = append(inactive, r.Features...)
+	}
+
+	slices.Sort(inactive)
+
+	return slices.Compact(inactive)
+}
+
+// String returns the missing resources in a human-readable form, for example, for logging.
+func (a Availability) String() string {
+	gvks := make([]string, 0, len(a.missing))
+	for _, r := range a.missing {
+		gvks = append(gvks, r.GVK.GroupVersion().String()+"/"+r.GVK.Kind)
+	}
+
+	return strings.Join(gvks, ", ")
+}
+
+// Detect queries the API server for the given resources and returns the ones that are not served.
+// A resource is not served if its CRD is not installed or the installed CRD doesn't serve the version that NGF uses.
+func Detect(client discovery.ServerResourcesInterface, resources []Resource) (Availability, error) {
+	served := make(map[schema.GroupVersionKind]struct{})
+	checked := make(map[schema.GroupVersion]struct{})
+
+	for _, r := range resources {
+		gv := r.GVK.GroupVersion()
+		if _, ok := checked[gv]; ok {
+			continue
+		}
+		checked[gv] = struct{}{}
+
+		list, err := client.ServerResourcesForGroupVersion(gv.String())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return Availability{}, fmt.Errorf("error getting the resources of %s: %w", gv.String(), err)
+		}
+
+		for _, apiResource := range list.APIResources {
+			served[gv.WithKind(apiResource.Kind)] = struct{}{}
+		}
+	}
+
+	var missing []Resource
+	for _, r := range resources {
+		if _, ok := served[r.GVK]; !ok {
+			missing = append(missing, r)
+		}
+	}
+
+	return NewAvailability(missing...), nil
+}
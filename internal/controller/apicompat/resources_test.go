@@ -0,0 +1,143 @@
+package apicompat
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/pkg/features"
+)
+
+func TestOptionalResources(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(OptionalResources(false)).To(Equal([]Resource{BackendTLSPolicy, GRPCRoute, ReferenceGrant}))
+	g.Expect(OptionalResources(true)).To(Equal([]Resource{BackendTLSPolicy, GRPCRoute, ReferenceGrant, TLSRoute}))
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	gatewayV1Resources := &metav1.APIResourceList{
+		GroupVersion: "gateway.networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Kind: "GatewayClass"},
+			{Kind: "Gateway"},
+			{Kind: "HTTPRoute"},
+			{Kind: "GRPCRoute"},
+			{Kind: "BackendTLSPolicy"},
+		},
+	}
+
+	// Gateway API v1.3 served BackendTLSPolicy only in v1alpha3.
+	olderGatewayV1Resources := &metav1.APIResourceList{
+		GroupVersion: "gateway.networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Kind: "GatewayClass"},
+			{Kind: "Gateway"},
+			{Kind: "HTTPRoute"},
+			{Kind: "GRPCRoute"},
+		},
+	}
+
+	gatewayV1beta1Resources := &metav1.APIResourceList{
+		GroupVersion: "gateway.networking.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{
+			{Kind: "ReferenceGrant"},
+		},
+	}
+
+	gatewayV1alpha2Resources := &metav1.APIResourceList{
+		GroupVersion: "gateway.networking.k8s.io/v1alpha2",
+		APIResources: []metav1.APIResource{
+			{Kind: "TLSRoute"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		served       []*metav1.APIResourceList
+		resources    []Resource
+		expMissing   []Resource
+		expFeatures  []features.FeatureName
+		expString    string
+		discoveryErr error
+		expErr       bool
+	}{
+		{
+			name:      "all resources are served",
+			served:    []*metav1.APIResourceList{gatewayV1Resources, gatewayV1beta1Resources, gatewayV1alpha2Resources},
+			resources: OptionalResources(true),
+		},
+		{
+			name:        "resource version is not served",
+			served:      []*metav1.APIResourceList{olderGatewayV1Resources, gatewayV1beta1Resources},
+			resources:   OptionalResources(false),
+			expMissing:  []Resource{BackendTLSPolicy},
+			expFeatures: []features.FeatureName{features.SupportBackendTLSPolicy},
+			expString:   "gateway.networking.k8s.io/v1/BackendTLSPolicy",
+		},
+		{
+			name:       "group version is not served",
+			served:     []*metav1.APIResourceList{gatewayV1Resources},
+			resources:  OptionalResources(true),
+			expMissing: []Resource{ReferenceGrant, TLSRoute},
+			expFeatures: []features.FeatureName{
+				features.SupportReferenceGrant,
+				features.SupportTLSRoute,
+			},
+			expString: "gateway.networking.k8s.io/v1beta1/ReferenceGrant, gateway.networking.k8s.io/v1alpha2/TLSRoute",
+		},
+		{
+			name:         "discovery error",
+			resources:    OptionalResources(false),
+			discoveryErr: errors.New("connection refused"),
+			expErr:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			client := &discoveryfake.FakeDiscovery{Fake: &k8stesting.Fake{Resources: test.served}}
+			if test.discoveryErr != nil {
+				client.PrependReactor("*", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, test.discoveryErr
+				})
+			}
+
+			availability, err := Detect(client, test.resources)
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(availability.Missing()).To(Equal(test.expMissing))
+			g.Expect(availability.InactiveFeatures()).To(Equal(test.expFeatures))
+			g.Expect(availability.String()).To(Equal(test.expString))
+		})
+	}
+}
+
+func TestAvailabilityServed(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var allServed Availability
+	g.Expect(allServed.Served(BackendTLSPolicy.GVK)).To(BeTrue())
+	g.Expect(allServed.InactiveFeatures()).To(BeEmpty())
+
+	availability := NewAvailability(BackendTLSPolicy)
+	g.Expect(availability.Served(BackendTLSPolicy.GVK)).To(BeFalse())
+	g.Expect(availability.Served(GRPCRoute.GVK)).To(BeTrue())
+	g.Expect(availability.Served(gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"))).To(BeTrue())
+}
@@ -0,0 +1,319 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+)
+
+// State is the state of the canary analysis of a Route.
+type State string
+
+const (
+	// StateHealthy means that the canary meets its objectives, or that its analysis was restarted.
+	StateHealthy State = "Healthy"
+	// StatePaused means that the canary breached an objective and the rollout is paused.
+	StatePaused State = "Paused"
+	// StateReverted means that the canary breached an objective and the rollout is reverted.
+	StateReverted State = "Reverted"
+)
+
+// Change is a change of the State of the canary analysis of a Route.
+type Change struct {
+	// Route is the Route.
+	Route client.Object
+	// State is the new State.
+	State State
+	// Message describes the change.
+	Message string
+}
+
+// verdict is the verdict of a failed canary analysis of a Route.
+type verdict struct {
+	// route is the Route.
+	route client.Object
+	// weights are the weights that the backends of the Route are held at.
+	weights dataplane.BackendWeights
+	// fingerprint is the fingerprint of the canary annotations of the Route at the time of the verdict.
+	fingerprint string
+}
+
+// Analyzer analyzes the canaries of the Routes. Once the canary of a Route breaches an objective,
+// the Analyzer holds the verdict until the canary annotations of the Route change, so that the rollout
+// doesn't resume while the canary is not analyzed, for example because it receives no traffic.
+type Analyzer struct {
+	metrics MetricsProvider
+	// healthyWeights are the weights of the backends of the Routes at their latest healthy analysis.
+	healthyWeights map[types.NamespacedName]dataplane.BackendWeights
+	// verdicts are the verdicts of the Routes whose canary breached an objective.
+	verdicts map[types.NamespacedName]verdict
+	logger   logr.Logger
+	lock     sync.RWMutex
+}
+
+// NewAnalyzer creates a new Analyzer.
+func NewAnalyzer(logger logr.Logger, metrics MetricsProvider) *Analyzer {
+	return &Analyzer{
+		metrics:        metrics,
+		healthyWeights: make(map[types.NamespacedName]dataplane.BackendWeights),
+		verdicts:       make(map[types.NamespacedName]verdict),
+		logger:         logger,
+	}
+}
+
+// Analyze analyzes the canaries of the Routes of the graph and returns the changes of the States of their analyses.
+func (a *Analyzer) Analyze(ctx context.Context, gr *graph.Graph) []Change {
+	if gr == nil {
+		return nil
+	}
+
+	var changes []Change
+	analyzed := make(map[types.NamespacedName]struct{})
+
+	for _, route := range gr.Routes {
+		if route.Source == nil || !route.Valid {
+			continue
+		}
+
+		nsName := client.ObjectKeyFromObject(route.Source)
+		annotations := route.Source.GetAnnotations()
+
+		spec, exists, err := ParseSpec(annotations)
+		if !exists {
+			continue
+		}
+
+		analyzed[nsName] = struct{}{}
+
+		if err != nil {
+			a.logger.Error(err, "Invalid canary analysis annotations", "route", nsName.String())
+			continue
+		}
+
+		if change, changed := a.analyzeRoute(ctx, route, spec, fingerprint(annotations)); changed {
+			changes = append(changes, change)
+		}
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for nsName := range a.healthyWeights {
+		if _, exists := analyzed[nsName]; !exists {
+			delete(a.healthyWeights, nsName)
+		}
+	}
+
+	for nsName, v := range a.verdicts {
+		if _, exists := analyzed[nsName]; exists {
+			continue
+		}
+
+		delete(a.verdicts, nsName)
+		changes = append(changes, Change{
+			Route:   v.route,
+			State:   StateHealthy,
+			Message: "Canary analysis is no longer configured; the rollout is resumed",
+		})
+	}
+
+	slices.SortFunc(changes, func(c1, c2 Change) int {
+		return strings.Compare(
+			client.ObjectKeyFromObject(c1.Route).String(),
+			client.ObjectKeyFromObject(c2.Route).String(),
+		)
+	})
+
+	return changes
+}
+
+// WeightOverrides returns the weights that the backends of the Routes, whose canary breached an objective,
+// are held at, keyed by the NamespacedName of the Route.
+func (a *Analyzer) WeightOverrides() map[types.NamespacedName]dataplane.BackendWeights {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	overrides := make(map[types.NamespacedName]dataplane.BackendWeights, len(a.verdicts))
+	for nsName, v := range a.verdicts {
+		overrides[nsName] = maps.Clone(v.weights)
+	}
+
+	return overrides
+}
+
+func (a *Analyzer) analyzeRoute(
+	ctx context.Context,
+	route *graph.L7Route,
+	spec Spec,
+	fp string,
+) (Change, bool) {
+	nsName := client.ObjectKeyFromObject(route.Source)
+
+	a.lock.RLock()
+	v, hasVerdict := a.verdicts[nsName]
+	a.lock.RUnlock()
+
+	if hasVerdict {
+		if v.fingerprint == fp {
+			return Change{}, false
+		}
+
+		a.lock.Lock()
+		delete(a.verdicts, nsName)
+		delete(a.healthyWeights, nsName)
+		a.lock.Unlock()
+
+		return Change{
+			Route:   route.Source,
+			State:   StateHealthy,
+			Message: "Canary annotations changed; the canary analysis is restarted and the rollout is resumed",
+		}, true
+	}
+
+	canarySvc := types.NamespacedName{Namespace: nsName.Namespace, Name: spec.Backend}
+	weights, canaryUpstreams := routeWeights(route, canarySvc)
+
+	reason, complete, err := a.evaluate(ctx, spec, canaryUpstreams)
+	if err != nil {
+		a.logger.Error(err, "Failed to analyze the canary", "route", nsName.String())
+		return Change{}, false
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if reason == "" {
+		// the weights are healthy only if the canary was analyzed against all objectives
+		if complete {
+			a.healthyWeights[nsName] = weights
+		}
+		return Change{}, false
+	}
+
+	held, exists := a.healthyWeights[nsName]
+	if !exists {
+		held = weights
+	}
+
+	state := StatePaused
+	message := fmt.Sprintf(
+		"Canary analysis failed: %s; the rollout is paused at the weights of the latest healthy analysis",
+		reason,
+	)
+
+	if spec.Action == ActionRevert {
+		held = maps.Clone(held)
+		for key := range weights {
+			if _, isCanary := canaryUpstreams[key.UpstreamName]; isCanary {
+				held[key] = 0
+			}
+		}
+
+		state = StateReverted
+		message = fmt.Sprintf(
+			"Canary analysis failed: %s; the rollout is reverted and the canary receives no traffic",
+			reason,
+		)
+	}
+
+	a.verdicts[nsName] = verdict{
+		route:       route.Source,
+		weights:     held,
+		fingerprint: fp,
+	}
+
+	a.logger.Info(message, "route", nsName.String())
+
+	return Change{
+		Route:   route.Source,
+		State:   state,
+		Message: message,
+	}, true
+}
+
+// evaluate evaluates the canary upstreams against the objectives of the Spec. It returns the reason
+// why the canary breached an objective, or an empty string if the canary meets its objectives, and whether
+// there was data for every objective of every upstream. Objectives without data are not evaluated.
+func (a *Analyzer) evaluate(
+	ctx context.Context,
+	spec Spec,
+	canaryUpstreams map[string]struct{},
+) (string, bool, error) {
+	complete := true
+
+	for _, upstream := range slices.Sorted(maps.Keys(canaryUpstreams)) {
+		if spec.MaxErrorRate != nil {
+			rate, err := a.metrics.ErrorRate(ctx, upstream)
+			switch {
+			case errors.Is(err, ErrNoData):
+				complete = false
+			case err != nil:
+				return "", false, fmt.Errorf("failed to get the error rate of upstream %s: %w", upstream, err)
+			case rate > *spec.MaxErrorRate:
+				return fmt.Sprintf(
+					"error rate %.4f of upstream %s exceeds the maximum %.4f",
+					rate,
+					upstream,
+					*spec.MaxErrorRate,
+				), false, nil
+			}
+		}
+
+		if spec.MaxLatency != nil {
+			latency, err := a.metrics.Latency(ctx, upstream)
+			switch {
+			case errors.Is(err, ErrNoData):
+				complete = false
+			case err != nil:
+				return "", false, fmt.Errorf("failed to get the latency of upstream %s: %w", upstream, err)
+			case latency > *spec.MaxLatency:
+				return fmt.Sprintf(
+					"latency %s of upstream %s exceeds the maximum %s",
+					latency,
+					upstream,
+					*spec.MaxLatency,
+				), false, nil
+			}
+		}
+	}
+
+	return "", complete, nil
+}
+
+// routeWeights returns the weights of the valid backends of the Route, and the names of the upstreams
+// of the canary Service that receive traffic.
+func routeWeights(
+	route *graph.L7Route,
+	canarySvc types.NamespacedName,
+) (dataplane.BackendWeights, map[string]struct{}) {
+	weights := make(dataplane.BackendWeights)
+	canaryUpstreams := make(map[string]struct{})
+
+	for idx, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			upstream := ref.ServicePortReference()
+			if upstream == "" || ref.IsMirrorBackend {
+				continue
+			}
+
+			weights[dataplane.BackendWeightKey{UpstreamName: upstream, RuleIdx: idx}] = ref.Weight
+
+			if ref.SvcNsName == canarySvc && !ref.IsStaticBackend() && ref.Weight > 0 {
+				canaryUpstreams[upstream] = struct{}{}
+			}
+		}
+	}
+
+	return weights, canaryUpstreams
+}
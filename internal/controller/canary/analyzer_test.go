@@ -0,0 +1,189 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+)
+
+// fakeMetrics is a MetricsProvider that returns fixed metrics. The counterfeiter fake can't be used
+// in the tests of this package because it imports this package.
+type fakeMetrics struct {
+	errorRateErr   error
+	latencyErr     error
+	upstreams      []string
+	errorRate      float64
+	latency        time.Duration
+	errorRateCalls int
+	latencyCalls   int
+}
+
+func (f *fakeMetrics) ErrorRate(_ context.Context, upstream string) (float64, error) {
+	f.errorRateCalls++
+	f.upstreams = append(f.upstreams, upstream)
+	return f.errorRate, f.errorRateErr
+}
+
+func (f *fakeMetrics) Latency(_ context.Context, upstream string) (time.Duration, error) {
+	f.latencyCalls++
+	f.upstreams = append(f.upstreams, upstream)
+	return f.latency, f.latencyErr
+}
+
+func createGraph(annotations map[string]string, stableWeight, canaryWeight int32) *graph.Graph {
+	backendRef := func(name string, weight int32) graph.BackendRef {
+		return graph.BackendRef{
+			SvcNsName:   types.NamespacedName{Namespace: "test", Name: name},
+			ServicePort: v1.ServicePort{Port: 80},
+			Weight:      weight,
+			Valid:       true,
+		}
+	}
+
+	route := &graph.L7Route{
+		Source: &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route", Annotations: annotations},
+		},
+		RouteType: graph.RouteTypeHTTP,
+		Valid:     true,
+		Spec: graph.L7RouteSpec{
+			Rules: []graph.RouteRule{
+				{BackendRefs: []graph.BackendRef{backendRef("stable", stableWeight), backendRef("canary", canaryWeight)}},
+			},
+		},
+	}
+
+	return &graph.Graph{
+		Routes: map[graph.RouteKey]*graph.L7Route{
+			graph.CreateRouteKey(route.Source): route,
+		},
+	}
+}
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	routeNsName := types.NamespacedName{Namespace: "test", Name: "route"}
+	stableKey := dataplane.BackendWeightKey{UpstreamName: "test_stable_80", RuleIdx: 0}
+	canaryKey := dataplane.BackendWeightKey{UpstreamName: "test_canary_80", RuleIdx: 0}
+
+	pauseAnnotations := map[string]string{
+		BackendAnnotation:      "canary",
+		MaxErrorRateAnnotation: "0.1",
+		MaxLatencyAnnotation:   "1s",
+	}
+	revertAnnotations := map[string]string{
+		BackendAnnotation:       "canary",
+		MaxErrorRateAnnotation:  "0.1",
+		FailureActionAnnotation: "Revert",
+	}
+
+	metrics := &fakeMetrics{}
+	metrics.latency = 100 * time.Millisecond
+	analyzer := NewAnalyzer(logr.Discard(), metrics)
+
+	// the canary is healthy
+	metrics.errorRate = 0.01
+	g.Expect(analyzer.Analyze(context.Background(), createGraph(pauseAnnotations, 90, 10))).To(BeEmpty())
+	g.Expect(analyzer.WeightOverrides()).To(BeEmpty())
+
+	g.Expect(metrics.upstreams).To(Equal([]string{"test_canary_80", "test_canary_80"}))
+
+	// the canary has no data or the metrics can't be queried, so it's not analyzed
+	metrics.errorRateErr = ErrNoData
+	g.Expect(analyzer.Analyze(context.Background(), createGraph(pauseAnnotations, 50, 50))).To(BeEmpty())
+	metrics.errorRateErr = errors.New("connection refused")
+	g.Expect(analyzer.Analyze(context.Background(), createGraph(pauseAnnotations, 50, 50))).To(BeEmpty())
+	g.Expect(analyzer.WeightOverrides()).To(BeEmpty())
+
+	// the canary breaches the error rate after the weights changed, so the rollout is paused at the healthy weights
+	metrics.errorRate, metrics.errorRateErr = 0.2, nil
+	changes := analyzer.Analyze(context.Background(), createGraph(pauseAnnotations, 50, 50))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StatePaused))
+	g.Expect(changes[0].Message).To(ContainSubstring("error rate 0.2000 of upstream test_canary_80 exceeds the maximum"))
+	g.Expect(analyzer.WeightOverrides()).To(Equal(map[types.NamespacedName]dataplane.BackendWeights{
+		routeNsName: {stableKey: 90, canaryKey: 10},
+	}))
+
+	// the verdict holds, even if the weights change, without querying the metrics
+	calls := metrics.errorRateCalls
+	g.Expect(analyzer.Analyze(context.Background(), createGraph(pauseAnnotations, 0, 100))).To(BeEmpty())
+	g.Expect(metrics.errorRateCalls).To(Equal(calls))
+	g.Expect(analyzer.WeightOverrides()).To(HaveKey(routeNsName))
+
+	// the canary annotations change, so the analysis restarts
+	changes = analyzer.Analyze(context.Background(), createGraph(revertAnnotations, 50, 50))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StateHealthy))
+	g.Expect(analyzer.WeightOverrides()).To(BeEmpty())
+
+	// the canary still breaches the error rate, so the rollout is reverted
+	changes = analyzer.Analyze(context.Background(), createGraph(revertAnnotations, 50, 50))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StateReverted))
+	g.Expect(analyzer.WeightOverrides()).To(Equal(map[types.NamespacedName]dataplane.BackendWeights{
+		routeNsName: {stableKey: 50, canaryKey: 0},
+	}))
+
+	// the Route no longer has the canary annotations, so the rollout is resumed
+	changes = analyzer.Analyze(context.Background(), createGraph(nil, 50, 50))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StateHealthy))
+	g.Expect(changes[0].Message).To(ContainSubstring("no longer configured"))
+	g.Expect(analyzer.WeightOverrides()).To(BeEmpty())
+}
+
+func TestAnalyzer_Latency(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	annotations := map[string]string{
+		BackendAnnotation:    "canary",
+		MaxLatencyAnnotation: "500ms",
+	}
+
+	metrics := &fakeMetrics{}
+	metrics.latency = 2 * time.Second
+	analyzer := NewAnalyzer(logr.Discard(), metrics)
+
+	// without a healthy analysis, the rollout is paused at the current weights
+	changes := analyzer.Analyze(context.Background(), createGraph(annotations, 80, 20))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StatePaused))
+	g.Expect(changes[0].Message).To(ContainSubstring("latency 2s of upstream test_canary_80 exceeds the maximum 500ms"))
+	g.Expect(metrics.errorRateCalls).To(BeZero())
+	g.Expect(analyzer.WeightOverrides()).To(Equal(map[types.NamespacedName]dataplane.BackendWeights{
+		{Namespace: "test", Name: "route"}: {
+			{UpstreamName: "test_stable_80", RuleIdx: 0}: 80,
+			{UpstreamName: "test_canary_80", RuleIdx: 0}: 20,
+		},
+	}))
+}
+
+func TestAnalyzer_InvalidAnnotations(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	metrics := &fakeMetrics{}
+	analyzer := NewAnalyzer(logr.Discard(), metrics)
+
+	gr := createGraph(map[string]string{BackendAnnotation: "canary"}, 50, 50)
+
+	g.Expect(analyzer.Analyze(context.Background(), gr)).To(BeEmpty())
+	g.Expect(analyzer.Analyze(context.Background(), nil)).To(BeEmpty())
+	g.Expect(metrics.errorRateCalls).To(BeZero())
+	g.Expect(metrics.latencyCalls).To(BeZero())
+}
@@ -0,0 +1,197 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package canaryfakes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
+)
+
+type FakeMetricsProvider struct {
+	ErrorRateStub        func(context.Context, string) (float64, error)
+	errorRateMutex       sync.RWMutex
+	errorRateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	errorRateReturns struct {
+		result1 float64
+		result2 error
+	}
+	errorRateReturnsOnCall map[int]struct {
+		result1 float64
+		result2 error
+	}
+	LatencyStub        func(context.Context, string) (time.Duration, error)
+	latencyMutex       sync.RWMutex
+	latencyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	latencyReturns struct {
+		result1 time.Duration
+		result2 error
+	}
+	latencyReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMetricsProvider) ErrorRate(arg1 context.Context, arg2 string) (float64, error) {
+	fake.errorRateMutex.Lock()
+	ret, specificReturn := fake.errorRateReturnsOnCall[len(fake.errorRateArgsForCall)]
+	fake.errorRateArgsForCall = append(fake.errorRateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ErrorRateStub
+	fakeReturns := fake.errorRateReturns
+	fake.recordInvocation("ErrorRate", []interface{}{arg1, arg2})
+	fake.errorRateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMetricsProvider) ErrorRateCallCount() int {
+	fake.errorRateMutex.RLock()
+	defer fake.errorRateMutex.RUnlock()
+	return len(fake.errorRateArgsForCall)
+}
+
+func (fake *FakeMetricsProvider) ErrorRateCalls(stub func(context.Context, string) (float64, error)) {
+	fake.errorRateMutex.Lock()
+	defer fake.errorRateMutex.Unlock()
+	fake.ErrorRateStub = stub
+}
+
+func (fake *FakeMetricsProvider) ErrorRateArgsForCall(i int) (context.Context, string) {
+	fake.errorRateMutex.RLock()
+	defer fake.errorRateMutex.RUnlock()
+	argsForCall := fake.errorRateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMetricsProvider) ErrorRateReturns(result1 float64, result2 error) {
+	fake.errorRateMutex.Lock()
+	defer fake.errorRateMutex.Unlock()
+	fake.ErrorRateStub = nil
+	fake.errorRateReturns = struct {
+		result1 float64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMetricsProvider) ErrorRateReturnsOnCall(i int, result1 float64, result2 error) {
+	fake.errorRateMutex.Lock()
+	defer fake.errorRateMutex.Unlock()
+	fake.ErrorRateStub = nil
+	if fake.errorRateReturnsOnCall == nil {
+		fake.errorRateReturnsOnCall = make(map[int]struct {
+			result1 float64
+			result2 error
+		})
+	}
+	fake.errorRateReturnsOnCall[i] = struct {
+		result1 float64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMetricsProvider) Latency(arg1 context.Context, arg2 string) (time.Duration, error) {
+	fake.latencyMutex.Lock()
+	ret, specificReturn := fake.latencyReturnsOnCall[len(fake.latencyArgsForCall)]
+	fake.latencyArgsForCall = append(fake.latencyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.LatencyStub
+	fakeReturns := fake.latencyReturns
+	fake.recordInvocation("Latency", []interface{}{arg1, arg2})
+	fake.latencyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMetricsProvider) LatencyCallCount() int {
+	fake.latencyMutex.RLock()
+	defer fake.latencyMutex.RUnlock()
+	return len(fake.latencyArgsForCall)
+}
+
+func (fake *FakeMetricsProvider) LatencyCalls(stub func(context.Context, string) (time.Duration, error)) {
+	fake.latencyMutex.Lock()
+	defer fake.latencyMutex.Unlock()
+	fake.LatencyStub = stub
+}
+
+func (fake *FakeMetricsProvider) LatencyArgsForCall(i int) (context.Context, string) {
+	fake.latencyMutex.RLock()
+	defer fake.latencyMutex.RUnlock()
+	argsForCall := fake.latencyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMetricsProvider) LatencyReturns(result1 time.Duration, result2 error) {
+	fake.latencyMutex.Lock()
+	defer fake.latencyMutex.Unlock()
+	fake.LatencyStub = nil
+	fake.latencyReturns = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMetricsProvider) LatencyReturnsOnCall(i int, result1 time.Duration, result2 error) {
+	fake.latencyMutex.Lock()
+	defer fake.latencyMutex.Unlock()
+	fake.LatencyStub = nil
+	if fake.latencyReturnsOnCall == nil {
+		fake.latencyReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 error
+		})
+	}
+	fake.latencyReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMetricsProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMetricsProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ canary.MetricsProvider = new(FakeMetricsProvider)
@@ -0,0 +1,12 @@
+/*
+Package canary analyzes the canary backends of the weighted rollouts of HTTPRoutes and GRPCRoutes against
+service level objectives.
+
+A Route opts into the analysis with the gateway.nginx.org/canary-backend annotation, which names the Service
+that receives the canary traffic, and the annotations with the maximum error rate and latency of the canary.
+The Analyzer periodically queries a metrics provider, such as Prometheus, for the error rate and latency
+of the NGINX upstreams of the canary. When the canary breaches an objective, the rollout is either paused,
+which holds the weights of the backends of the Route at the weights of the latest healthy analysis, or reverted,
+which sends no traffic to the canary. The verdict holds until the canary annotations of the Route change.
+*/
+package canary
@@ -0,0 +1,120 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+//go:generate go tool counterfeiter -generate
+
+// UpstreamPlaceholder is replaced with the name of the NGINX upstream of the canary in the Prometheus queries.
+const UpstreamPlaceholder = "$upstream"
+
+const (
+	// DefaultErrorRateQuery is the default Prometheus query of the error rate of an NGINX upstream. It uses
+	// the upstream metrics that the NGINX agent exports.
+	DefaultErrorRateQuery = `sum(rate(nginx_http_upstream_peer_responses_total{nginx_upstream_name="$upstream",` +
+		`nginx_status_range="5xx"}[1m])) / sum(rate(nginx_http_upstream_peer_responses_total{` +
+		`nginx_upstream_name="$upstream"}[1m]))`
+	// DefaultLatencyQuery is the default Prometheus query of the response time, in seconds, of an NGINX upstream.
+	// It uses the upstream metrics that the NGINX agent exports.
+	DefaultLatencyQuery = `max(nginx_http_upstream_peer_response_time{nginx_upstream_name="$upstream"}) / 1000`
+)
+
+// ErrNoData is returned by the MetricsProvider when there is no data for the upstream, for example
+// because the upstream didn't receive any traffic.
+var ErrNoData = errors.New("no data")
+
+//counterfeiter:generate . MetricsProvider
+
+// MetricsProvider provides the metrics of the NGINX upstreams.
+type MetricsProvider interface {
+	// ErrorRate returns the ratio of the responses of the upstream with a 5xx status code to all responses
+	// of the upstream.
+	ErrorRate(ctx context.Context, upstream string) (float64, error)
+	// Latency returns the response time of the upstream.
+	Latency(ctx context.Context, upstream string) (time.Duration, error)
+}
+
+// PrometheusConfig is the configuration of the PrometheusProvider.
+type PrometheusConfig struct {
+	// Address is the address of the Prometheus server, for example http://prometheus.monitoring:9090.
+	Address string
+	// ErrorRateQuery is the query of the error rate of an upstream. The UpstreamPlaceholder is replaced with
+	// the name of the upstream.
+	ErrorRateQuery string
+	// LatencyQuery is the query of the response time, in seconds, of an upstream. The UpstreamPlaceholder
+	// is replaced with the name of the upstream.
+	LatencyQuery string
+}
+
+// PrometheusProvider provides the metrics of the NGINX upstreams from Prometheus.
+type PrometheusProvider struct {
+	api promv1.API
+	cfg PrometheusConfig
+}
+
+// NewPrometheusProvider creates a new PrometheusProvider.
+func NewPrometheusProvider(cfg PrometheusConfig) (*PrometheusProvider, error) {
+	client, err := api.NewClient(api.Config{Address: cfg.Address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Prometheus client: %w", err)
+	}
+
+	return &PrometheusProvider{
+		api: promv1.NewAPI(client),
+		cfg: cfg,
+	}, nil
+}
+
+// ErrorRate returns the error rate of the upstream.
+func (p *PrometheusProvider) ErrorRate(ctx context.Context, upstream string) (float64, error) {
+	return p.query(ctx, p.cfg.ErrorRateQuery, upstream)
+}
+
+// Latency returns the response time of the upstream.
+func (p *PrometheusProvider) Latency(ctx context.Context, upstream string) (time.Duration, error) {
+	seconds, err := p.query(ctx, p.cfg.LatencyQuery, upstream)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func (p *PrometheusProvider) query(ctx context.Context, query, upstream string) (float64, error) {
+	query = strings.ReplaceAll(query, UpstreamPlaceholder, upstream)
+
+	result, _, err := p.api.Query(ctx, query, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to query Prometheus: %w", err)
+	}
+
+	var value model.SampleValue
+	switch r := result.(type) {
+	case model.Vector:
+		if len(r) == 0 {
+			return 0, ErrNoData
+		}
+		value = r[0].Value
+	case *model.Scalar:
+		value = r.Value
+	default:
+		return 0, fmt.Errorf("unexpected type %s of the result of the Prometheus query", result.Type())
+	}
+
+	// the rate of an upstream without responses is NaN
+	if math.IsNaN(float64(value)) {
+		return 0, ErrNoData
+	}
+
+	return float64(value), nil
+}
@@ -0,0 +1,92 @@
+package canary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPrometheusProvider(t *testing.T) {
+	t.Parallel()
+
+	results := map[string]string{
+		`errors{upstream="test_canary_80"}`:  `{"resultType":"vector","result":[{"metric":{},"value":[1,"0.25"]}]}`,
+		`latency{upstream="test_canary_80"}`: `{"resultType":"vector","result":[{"metric":{},"value":[1,"0.5"]}]}`,
+		`errors{upstream="test_idle_80"}`:    `{"resultType":"vector","result":[{"metric":{},"value":[1,"NaN"]}]}`,
+		`latency{upstream="test_idle_80"}`:   `{"resultType":"vector","result":[]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		result, exists := results[r.Form.Get("query")]
+		if !exists {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"unknown query"}`)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"status":"success","data":%s}`, result)
+	}))
+	t.Cleanup(server.Close)
+
+	newProvider := func(errorRateQuery string) *PrometheusProvider {
+		provider, err := NewPrometheusProvider(PrometheusConfig{
+			Address:        server.URL,
+			ErrorRateQuery: errorRateQuery,
+			LatencyQuery:   `latency{upstream="$upstream"}`,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return provider
+	}
+
+	t.Run("upstream with data", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		provider := newProvider(`errors{upstream="$upstream"}`)
+
+		rate, err := provider.ErrorRate(context.Background(), "test_canary_80")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rate).To(Equal(0.25))
+
+		latency, err := provider.Latency(context.Background(), "test_canary_80")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(latency).To(Equal(500 * time.Millisecond))
+	})
+
+	t.Run("upstream without data", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		provider := newProvider(`errors{upstream="$upstream"}`)
+
+		_, err := provider.ErrorRate(context.Background(), "test_idle_80")
+		g.Expect(err).To(MatchError(ErrNoData))
+
+		_, err = provider.Latency(context.Background(), "test_idle_80")
+		g.Expect(err).To(MatchError(ErrNoData))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		provider := newProvider(`unknown{upstream="$upstream"}`)
+
+		_, err := provider.ErrorRate(context.Background(), "test_canary_80")
+		g.Expect(err).To(MatchError(ContainSubstring("failed to query Prometheus")))
+	})
+}
@@ -0,0 +1,121 @@
+package canary
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// BackendAnnotation is the annotation of a Route that names the Service, in the namespace of the Route,
+	// that receives the canary traffic of the weighted rollout. The canary of a Route is analyzed only if the Route
+	// has this annotation.
+	BackendAnnotation = "gateway.nginx.org/canary-backend"
+	// MaxErrorRateAnnotation is the annotation of a Route with the maximum ratio, between 0 and 1, of the responses
+	// of the canary with a 5xx status code to all responses of the canary.
+	MaxErrorRateAnnotation = "gateway.nginx.org/canary-max-error-rate"
+	// MaxLatencyAnnotation is the annotation of a Route with the maximum response time of the canary,
+	// for example 500ms.
+	MaxLatencyAnnotation = "gateway.nginx.org/canary-max-latency"
+	// FailureActionAnnotation is the annotation of a Route with the Action that is taken when the canary breaches
+	// an objective. Defaults to Pause.
+	FailureActionAnnotation = "gateway.nginx.org/canary-failure-action"
+)
+
+// Action is the action that is taken when the canary breaches an objective.
+type Action string
+
+const (
+	// ActionPause holds the weights of the backends of the Route at the weights of the latest healthy analysis.
+	ActionPause Action = "Pause"
+	// ActionRevert sends no traffic to the canary.
+	ActionRevert Action = "Revert"
+)
+
+// Spec is the specification of the canary analysis of a Route.
+type Spec struct {
+	// MaxErrorRate is the maximum error rate of the canary. If nil, the error rate is not analyzed.
+	MaxErrorRate *float64
+	// MaxLatency is the maximum latency of the canary. If nil, the latency is not analyzed.
+	MaxLatency *time.Duration
+	// Backend is the name of the Service of the canary.
+	Backend string
+	// Action is the action that is taken when the canary breaches an objective.
+	Action Action
+}
+
+// ParseSpec parses the Spec of the canary analysis from the annotations of a Route. It returns false
+// if the Route doesn't have the BackendAnnotation.
+func ParseSpec(annotations map[string]string) (Spec, bool, error) {
+	backend, exists := annotations[BackendAnnotation]
+	if !exists {
+		return Spec{}, false, nil
+	}
+
+	var errs []error
+
+	spec := Spec{
+		Backend: strings.TrimSpace(backend),
+		Action:  ActionPause,
+	}
+
+	if spec.Backend == "" {
+		errs = append(errs, fmt.Errorf("%s must not be empty", BackendAnnotation))
+	}
+
+	if value, exists := annotations[MaxErrorRateAnnotation]; exists {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			errs = append(errs, fmt.Errorf("%s must be a number between 0 and 1, got %q", MaxErrorRateAnnotation, value))
+		} else {
+			spec.MaxErrorRate = &rate
+		}
+	}
+
+	if value, exists := annotations[MaxLatencyAnnotation]; exists {
+		latency, err := time.ParseDuration(value)
+		if err != nil || latency <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive duration, got %q", MaxLatencyAnnotation, value))
+		} else {
+			spec.MaxLatency = &latency
+		}
+	}
+
+	if value, exists := annotations[FailureActionAnnotation]; exists {
+		switch action := Action(value); action {
+		case ActionPause, ActionRevert:
+			spec.Action = action
+		default:
+			errs = append(
+				errs,
+				fmt.Errorf("%s must be %s or %s, got %q", FailureActionAnnotation, ActionPause, ActionRevert, value),
+			)
+		}
+	}
+
+	if spec.MaxErrorRate == nil && spec.MaxLatency == nil && len(errs) == 0 {
+		errs = append(errs, fmt.Errorf("%s or %s must be set", MaxErrorRateAnnotation, MaxLatencyAnnotation))
+	}
+
+	if len(errs) > 0 {
+		return Spec{}, true, errors.Join(errs...)
+	}
+
+	return spec, true, nil
+}
+
+// fingerprint returns the values of the canary annotations. The verdict of the analysis of a Route holds
+// until the fingerprint changes.
+func fingerprint(annotations map[string]string) string {
+	return strings.Join(
+		[]string{
+			annotations[BackendAnnotation],
+			annotations[MaxErrorRateAnnotation],
+			annotations[MaxLatencyAnnotation],
+			annotations[FailureActionAnnotation],
+		},
+		"\x00",
+	)
+}
@@ -0,0 +1,94 @@
+package canary
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+func TestParseSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		annotations map[string]string
+		expSpec     Spec
+		name        string
+		expErr      string
+		expExists   bool
+	}{
+		{
+			name:        "no canary backend",
+			annotations: map[string]string{MaxErrorRateAnnotation: "0.1"},
+		},
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				BackendAnnotation:       "canary",
+				MaxErrorRateAnnotation:  "0.05",
+				MaxLatencyAnnotation:    "500ms",
+				FailureActionAnnotation: "Revert",
+			},
+			expSpec: Spec{
+				Backend:      "canary",
+				MaxErrorRate: helpers.GetPointer(0.05),
+				MaxLatency:   helpers.GetPointer(500 * time.Millisecond),
+				Action:       ActionRevert,
+			},
+			expExists: true,
+		},
+		{
+			name: "default action",
+			annotations: map[string]string{
+				BackendAnnotation:    "canary",
+				MaxLatencyAnnotation: "1s",
+			},
+			expSpec: Spec{
+				Backend:    "canary",
+				MaxLatency: helpers.GetPointer(time.Second),
+				Action:     ActionPause,
+			},
+			expExists: true,
+		},
+		{
+			name:        "no objectives",
+			annotations: map[string]string{BackendAnnotation: "canary"},
+			expErr:      "gateway.nginx.org/canary-max-error-rate or gateway.nginx.org/canary-max-latency must be set",
+			expExists:   true,
+		},
+		{
+			name: "invalid annotations",
+			annotations: map[string]string{
+				BackendAnnotation:       " ",
+				MaxErrorRateAnnotation:  "1.5",
+				MaxLatencyAnnotation:    "-1s",
+				FailureActionAnnotation: "Rollback",
+			},
+			expErr: "gateway.nginx.org/canary-backend must not be empty\n" +
+				"gateway.nginx.org/canary-max-error-rate must be a number between 0 and 1, got \"1.5\"\n" +
+				"gateway.nginx.org/canary-max-latency must be a positive duration, got \"-1s\"\n" +
+				"gateway.nginx.org/canary-failure-action must be Pause or Revert, got \"Rollback\"",
+			expExists: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			spec, exists, err := ParseSpec(test.annotations)
+			g.Expect(exists).To(Equal(test.expExists))
+
+			if test.expErr != "" {
+				g.Expect(err).To(MatchError(test.expErr))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(spec).To(Equal(test.expSpec))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/runnables"
+)
+
+// canaryAnalysisJitterFactor spreads the canary analyses of the replicas of the control plane.
+const canaryAnalysisJitterFactor = 0.1
+
+// canaryAnalysisEvent makes the event handler regenerate the configuration from the latest graph, because
+// the canary analysis of Routes changed the weights that the backends of the Routes are held at.
+type canaryAnalysisEvent struct {
+	changes []canary.Change
+}
+
+// newCanaryAnalysisJob creates a job that periodically analyzes the canaries of the Routes of the latest graph,
+// and sends a canaryAnalysisEvent to the event loop when the analysis of a Route changes.
+// Every replica of the control plane analyzes the canaries, so that they all generate the same configuration.
+func newCanaryAnalysisJob(
+	logger logr.Logger,
+	analyzer *canary.Analyzer,
+	getLatestGraph func() *graph.Graph,
+	eventCh chan<- interface{},
+	readyCh <-chan struct{},
+	period time.Duration,
+) *runnables.LeaderOrNonLeader {
+	worker := func(ctx context.Context) {
+		changes := analyzer.Analyze(ctx, getLatestGraph())
+		if len(changes) == 0 {
+			return
+		}
+
+		select {
+		case eventCh <- &canaryAnalysisEvent{changes: changes}:
+		case <-ctx.Done():
+		}
+	}
+
+	return &runnables.LeaderOrNonLeader{
+		Runnable: runnables.NewCronJob(
+			runnables.CronJobConfig{
+				Worker:       worker,
+				Logger:       logger,
+				Period:       period,
+				JitterFactor: canaryAnalysisJitterFactor,
+				ReadyCh:      readyCh,
+			},
+		),
+	}
+}
+
+// canaryAnalysisChanged returns whether the canary analysis of a Route changed since the last call, and resets it.
+func (h *eventHandlerImpl) canaryAnalysisChanged() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	changed := h.canaryChanged
+	h.canaryChanged = false
+
+	return changed
+}
+
+// recordCanaryAnalysisChanges records the changes of the canary analysis as events of the Routes.
+// Only the leader records the events, so that they are not duplicated by every replica.
+func (h *eventHandlerImpl) recordCanaryAnalysisChanges(changes []canary.Change) {
+	if !h.isLeader() {
+		return
+	}
+
+	for _, change := range changes {
+		eventType := v1.EventTypeWarning
+		if change.State == canary.StateHealthy {
+			eventType = v1.EventTypeNormal
+		}
+
+		h.cfg.eventRecorder.Event(change.Route, eventType, "CanaryAnalysis"+string(change.State), change.Message)
+	}
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary/canaryfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+)
+
+func TestCanaryAnalysisJob(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	route := &graph.L7Route{
+		Source: &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "route",
+				Annotations: map[string]string{
+					canary.BackendAnnotation:      "canary",
+					canary.MaxErrorRateAnnotation: "0.1",
+				},
+			},
+		},
+		RouteType: graph.RouteTypeHTTP,
+		Valid:     true,
+		Spec: graph.L7RouteSpec{
+			Rules: []graph.RouteRule{
+				{
+					BackendRefs: []graph.BackendRef{
+						{
+							SvcNsName:   types.NamespacedName{Namespace: "test", Name: "canary"},
+							ServicePort: v1.ServicePort{Port: 80},
+							Weight:      10,
+							Valid:       true,
+						},
+					},
+				},
+			},
+		},
+	}
+	gr := &graph.Graph{
+		Routes: map[graph.RouteKey]*graph.L7Route{graph.CreateRouteKey(route.Source): route},
+	}
+
+	metrics := &canaryfakes.FakeMetricsProvider{}
+	metrics.ErrorRateReturns(0.5, nil)
+
+	eventCh := make(chan interface{})
+	readyCh := make(chan struct{})
+
+	job := newCanaryAnalysisJob(
+		logr.Discard(),
+		canary.NewAnalyzer(logr.Discard(), metrics),
+		func() *graph.Graph { return gr },
+		eventCh,
+		readyCh,
+		10*time.Millisecond,
+	)
+	g.Expect(job.NeedLeaderElection()).To(BeFalse())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- job.Start(ctx)
+	}()
+
+	// the analysis doesn't start until the control plane is ready
+	g.Consistently(eventCh).ShouldNot(Receive())
+
+	close(readyCh)
+
+	var event interface{}
+	g.Eventually(eventCh).Should(Receive(&event))
+	g.Expect(event).To(BeAssignableToTypeOf(&canaryAnalysisEvent{}))
+
+	changes := event.(*canaryAnalysisEvent).changes
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(canary.StatePaused))
+
+	// the verdict holds, so no more events are sent
+	g.Consistently(eventCh).ShouldNot(Receive())
+
+	cancel()
+	g.Eventually(errCh).Should(Receive(BeNil()))
+}
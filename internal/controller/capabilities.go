@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"slices"
+
+	agentgrpc "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+)
+
+// withholdUnsupportedFeatures removes the features that depend on the unsupported capabilities from
+// the configuration, so that a data plane running an older version can still apply it.
+// It returns the capabilities whose features were actually removed.
+func withholdUnsupportedFeatures(
+	cfg *dataplane.Configuration,
+	unsupported []agentgrpc.Capability,
+) []agentgrpc.Capability {
+	var withheld []agentgrpc.Capability
+
+	if slices.Contains(unsupported, agentgrpc.CapabilityUpstreamResolve) {
+		// the upstream servers with hostnames are skipped. If an upstream ends up without servers,
+		// NGINX returns an error for its requests, like for any other upstream without endpoints.
+		removedHTTP := removeResolveEndpoints(cfg.Upstreams)
+		removedStream := removeResolveEndpoints(cfg.StreamUpstreams)
+
+		if removedHTTP || removedStream {
+			withheld = append(withheld, agentgrpc.CapabilityUpstreamResolve)
+		}
+	}
+
+	return withheld
+}
+
+// removeResolveEndpoints removes the endpoints that require DNS resolution from the upstreams.
+// It returns true if any endpoint was removed.
+func removeResolveEndpoints(upstreams []dataplane.Upstream) bool {
+	var removed bool
+
+	for i, upstream := range upstreams {
+		endpoints := make([]resolver.Endpoint, 0, len(upstream.Endpoints))
+		for _, endpoint := range upstream.Endpoints {
+			if endpoint.Resolve {
+				continue
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+
+		if len(endpoints) != len(upstream.Endpoints) {
+			upstreams[i].Endpoints = endpoints
+			removed = true
+		}
+	}
+
+	return removed
+}
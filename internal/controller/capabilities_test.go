@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	agentgrpc "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+)
+
+func TestWithholdUnsupportedFeatures(t *testing.T) {
+	t.Parallel()
+
+	ipEndpoint := resolver.Endpoint{Address: "10.0.0.1", Port: 80}
+	hostnameEndpoint := resolver.Endpoint{Address: "example.com", Port: 80, Resolve: true}
+
+	createConfig := func() dataplane.Configuration {
+		return dataplane.Configuration{
+			Upstreams: []dataplane.Upstream{
+				{Name: "ip", Endpoints: []resolver.Endpoint{ipEndpoint}},
+				{Name: "mixed", Endpoints: []resolver.Endpoint{ipEndpoint, hostnameEndpoint}},
+			},
+			StreamUpstreams: []dataplane.Upstream{
+				{Name: "hostname", Endpoints: []resolver.Endpoint{hostnameEndpoint}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		cfg         dataplane.Configuration
+		expCfg      dataplane.Configuration
+		unsupported []agentgrpc.Capability
+		expWithheld []agentgrpc.Capability
+	}{
+		{
+			name:   "all capabilities are supported",
+			cfg:    createConfig(),
+			expCfg: createConfig(),
+		},
+		{
+			name:        "upstream resolve is not supported",
+			cfg:         createConfig(),
+			unsupported: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+			expCfg: dataplane.Configuration{
+				Upstreams: []dataplane.Upstream{
+					{Name: "ip", Endpoints: []resolver.Endpoint{ipEndpoint}},
+					{Name: "mixed", Endpoints: []resolver.Endpoint{ipEndpoint}},
+				},
+				StreamUpstreams: []dataplane.Upstream{
+					{Name: "hostname", Endpoints: []resolver.Endpoint{}},
+				},
+			},
+			expWithheld: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+		},
+		{
+			name: "upstream resolve is not supported but not used",
+			cfg: dataplane.Configuration{
+				Upstreams: []dataplane.Upstream{
+					{Name: "ip", Endpoints: []resolver.Endpoint{ipEndpoint}},
+				},
+			},
+			unsupported: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+			expCfg: dataplane.Configuration{
+				Upstreams: []dataplane.Upstream{
+					{Name: "ip", Endpoints: []resolver.Endpoint{ipEndpoint}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			withheld := withholdUnsupportedFeatures(&test.cfg, test.unsupported)
+			g.Expect(withheld).To(Equal(test.expWithheld))
+			g.Expect(test.cfg).To(Equal(test.expCfg))
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/go-logr/logr"
 	"go.uber.org/zap"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
 )
 
 const DefaultNginxMetricsPort = int32(9113)
@@ -14,6 +16,10 @@ type Config struct {
 	AtomicLevel zap.AtomicLevel
 	// GatewayPodConfig contains information about this Pod.
 	GatewayPodConfig GatewayPodConfig
+	// ModuleLogLevels controls the logging levels of the control plane modules.
+	ModuleLogLevels *logging.ModuleLevels
+	// DefaultModuleLogLevels are the logging levels of the control plane modules, keyed by module name, set at startup.
+	DefaultModuleLogLevels map[string]string
 	// Logger is the Zap Logger used by all components.
 	Logger logr.Logger
 	// NGINXSCCName is the name of the SecurityContextConstraints for the NGINX Pods. Only applicable in OpenShift.
@@ -24,10 +30,15 @@ type Config struct {
 	AgentTLSSecretName string
 	// GatewayClassName is the name of the GatewayClass resource that the Gateway will use.
 	GatewayClassName string
+	// ClusterDomain is the DNS domain of the cluster.
+	ClusterDomain string
 	// ImageSource is the source of the NGINX Gateway image.
 	ImageSource string
 	// GatewayCtlrName is the name of this controller.
 	GatewayCtlrName string
+	// LogLevelsConfigMapName is the name of the ConfigMap, in the namespace of this Pod, that contains the logging
+	// levels of the control plane modules. If empty, the module logging levels can't be changed at runtime.
+	LogLevelsConfigMapName string
 	// UsageReportConfig specifies the NGINX Plus usage reporting configuration.
 	UsageReportConfig UsageReportConfig
 	// Flags contains the NGF command-line flag names and values.
@@ -44,6 +55,22 @@ type Config struct {
 	HealthConfig HealthConfig
 	// MetricsConfig specifies the metrics config.
 	MetricsConfig MetricsConfig
+	// ConfigExport specifies where the NGINX configuration is exported to.
+	ConfigExport ConfigExportConfig
+	// CanaryAnalysis specifies the analysis of the canaries of the weighted rollouts of Routes.
+	CanaryAnalysis CanaryAnalysisConfig
+	// OutlierHook specifies the diagnostic actions of the Routes whose error rate crosses a threshold.
+	OutlierHook OutlierHookConfig
+	// APICatalog specifies the catalogs of the OpenAPI specs of the Routes of the Gateways.
+	APICatalog APICatalogConfig
+	// WASMHook specifies the experimental WASM extension hook.
+	WASMHook WASMHookConfig
+	// Webhook specifies the validating admission webhook.
+	Webhook WebhookConfig
+	// CRDManagement specifies how NGF manages its CRDs.
+	CRDManagement CRDManagementConfig
+	// IPAM specifies how the addresses of the Gateways are allocated.
+	IPAM IPAMConfig
 	// Plus indicates whether NGINX Plus is being used.
 	Plus bool
 	// ExperimentalFeatures indicates if experimental features are enabled.
@@ -52,10 +79,47 @@ type Config struct {
 	InferenceExtension bool
 	// SnippetsFilters indicates if SnippetsFilters are enabled.
 	SnippetsFilters bool
+	// GatewayTests indicates if GatewayTests are enabled. The assertions of the GatewayTests are verified by sending
+	// their requests through the data plane of the Gateways.
+	GatewayTests bool
+	// ClusterDefaultPolicies indicates if ClusterDefaultPolicies are enabled. The ClusterDefaultPolicies define
+	// the default policies of the Routes and Services in the Namespaces that they select.
+	ClusterDefaultPolicies bool
 	// EndpointPickerDisableTLS indicates if TLS is disabled for EndpointPicker communication.
 	EndpointPickerDisableTLS bool
 	// EndpointPickerTLSSkipVerify indicates if secure verification is skipped for EndpointPicker communication.
 	EndpointPickerTLSSkipVerify bool
+	// ConfigHistorySize is the number of the latest versions of the NGINX configuration of every Gateway
+	// that are retained for inspection. If zero, the history is disabled.
+	ConfigHistorySize int
+	// TenantAttributionPort is the UDP port on which the control plane receives the requests that NGINX attributes
+	// to tenants, and exposes the requests and bytes of every tenant as metrics. If zero, the requests are not
+	// received.
+	TenantAttributionPort int
+	// UsageSummaryInterval is the window of the usage summaries of the Gateways, which are published to a ConfigMap
+	// per Gateway at the end of every window. The summaries are built from the requests that are received
+	// on the TenantAttributionPort. If zero, the usage of the Gateways is not summarized.
+	UsageSummaryInterval time.Duration
+	// TempFileMetricsPort is the UDP port on which the control plane receives the writes that NGINX reports
+	// to its temporary files, and exposes them as metrics. If zero, the writes are not received.
+	TempFileMetricsPort int
+	// DataPlaneFailurePort is the UDP port on which the control plane receives the exits of the worker processes
+	// that NGINX reports. If zero, the exits are not received, but the OOM kills of the nginx containers and
+	// the failures to apply the nginx configuration are still reported.
+	DataPlaneFailurePort int
+	// TokenReviewPort is the TCP port on which the control plane verifies the bearer tokens of the requests
+	// to the Routes whose ClientSettingsPolicy requires the tokens. If zero, the tokens are not verified,
+	// and such requests are rejected.
+	TokenReviewPort int
+	// NginxReloadMinInterval is the minimum interval between the reloads of every nginx Deployment. The changes
+	// of only the endpoints of the upstreams are deferred for a multiple of the interval, unless NGINX Plus
+	// updates them through its API. If zero, the reloads are not rate-limited.
+	NginxReloadMinInterval time.Duration
+	// FIPS indicates if FIPS mode is enabled. In FIPS mode, only FIPS-approved TLS parameters are used.
+	FIPS bool
+	// UpstreamMapConfigMap indicates whether the mapping of the Routes of every Gateway to the NGINX upstreams
+	// is published to a ConfigMap per Gateway.
+	UpstreamMapConfigMap bool
 }
 
 // GatewayPodConfig contains information about this Pod.
@@ -85,6 +149,88 @@ type MetricsConfig struct {
 	Enabled bool
 	// Secure is the flag for toggling the metrics endpoint to https.
 	Secure bool
+	// DebugEndpoints is the flag for serving the debug endpoints on the metrics server.
+	// It requires Enabled and Secure.
+	DebugEndpoints bool
+}
+
+// ConfigExportConfig specifies where the NGINX configuration of the Gateways is exported to, for use by NGINX
+// instances that are not managed by the control plane.
+type ConfigExportConfig struct {
+	// Dir is the directory to export the configuration to. If empty, the configuration is not exported
+	// to a directory.
+	Dir string
+	// ConfigMap indicates whether the configuration is exported to a ConfigMap and a Secret per Gateway.
+	ConfigMap bool
+}
+
+// CanaryAnalysisConfig specifies the analysis of the canaries of the weighted rollouts of Routes.
+type CanaryAnalysisConfig struct {
+	// PrometheusAddress is the address of the Prometheus server that the metrics of the canaries are queried from.
+	// If empty, the canaries are not analyzed.
+	PrometheusAddress string
+	// ErrorRateQuery is the Prometheus query of the error rate of an NGINX upstream.
+	ErrorRateQuery string
+	// LatencyQuery is the Prometheus query of the response time, in seconds, of an NGINX upstream.
+	LatencyQuery string
+	// Interval is the interval between the analyses.
+	Interval time.Duration
+}
+
+// APICatalogConfig specifies the catalogs of the OpenAPI specs of the Routes of the Gateways.
+type APICatalogConfig struct {
+	// Path is the path at which NGINX serves the catalog of a Gateway on every server of the Gateway.
+	// If empty, the catalogs are not built.
+	Path string
+	// Interval is the interval between the aggregations of the specs.
+	Interval time.Duration
+}
+
+// OutlierHookConfig specifies the diagnostic actions of the Routes whose error rate crosses a threshold.
+type OutlierHookConfig struct {
+	// PrometheusAddress is the address of the Prometheus server that the error rates of the Routes are queried from.
+	// If empty, the error rates of the Routes are not evaluated.
+	PrometheusAddress string
+	// ErrorRateQuery is the Prometheus query of the error rate of an NGINX upstream.
+	ErrorRateQuery string
+	// Interval is the interval between the evaluations.
+	Interval time.Duration
+}
+
+// WASMHookConfig specifies the experimental WASM extension hook that mutates the routing state of every Gateway.
+type WASMHookConfig struct {
+	// ModulePath is the path to the WASM module. If empty, the hook is disabled.
+	ModulePath string
+	// Timeout is the maximum execution time of the module for a Gateway.
+	Timeout time.Duration
+	// MaxMemoryBytes is the maximum memory of the module, in bytes.
+	MaxMemoryBytes int64
+}
+
+// WebhookConfig specifies the validating admission webhook.
+type WebhookConfig struct {
+	// ConfigurationName is the name of the ValidatingWebhookConfiguration that the CA certificate of the webhook
+	// server is injected into. If empty, the CA certificate is not injected.
+	ConfigurationName string
+	// Port is the port of the webhook server. If 0, the webhook is disabled.
+	Port int
+	// MaxRoutesPerGateway is the maximum number of the Routes attached to a Gateway. If 0, it is not limited.
+	MaxRoutesPerGateway int
+}
+
+// CRDManagementConfig specifies how NGF manages its CRDs.
+type CRDManagementConfig struct {
+	// Enabled indicates if NGF installs and upgrades its CRDs on start.
+	Enabled bool
+}
+
+// IPAMConfig specifies how the addresses of the nginx Services of the Gateways are allocated.
+// At most one of the fields is set. If none is set, the addresses are assigned by Kubernetes.
+type IPAMConfig struct {
+	// MetalLBAddressPool is the MetalLB address pool that the addresses are allocated from.
+	MetalLBAddressPool string
+	// Endpoint is the URL of the IPAM service that the addresses are allocated from.
+	Endpoint string
 }
 
 // HealthConfig specifies the health probe config.
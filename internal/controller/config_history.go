@@ -0,0 +1,297 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	filesHelper "github.com/nginx/agent/v3/pkg/files"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/events"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/redact"
+)
+
+// maxConfigTriggers is the maximum number of triggers that are recorded for a version of the nginx configuration.
+// The first event batch after startup includes every resource, so the triggers are truncated.
+const maxConfigTriggers = 20
+
+// configVersion is a version of the nginx configuration of a Gateway.
+type configVersion struct {
+	// Time is the time when the version was generated.
+	Time time.Time `json:"time"`
+	// Version identifies the files of the version.
+	Version string `json:"version"`
+	// Error is the error that occurred when the version was applied, if any.
+	Error string `json:"error,omitempty"`
+	// Triggers describe the changes that triggered the version.
+	Triggers []string `json:"triggers,omitempty"`
+	// Files are the redacted files of the version.
+	Files []fileDump `json:"files"`
+}
+
+// configHistory retains the latest versions of the nginx configuration of every Gateway, so that operators
+// can inspect what the configuration looked like at a point in time. The files are redacted before they are
+// retained, so the history never holds sensitive contents.
+type configHistory struct {
+	versions map[types.NamespacedName][]configVersion
+	now      func() time.Time
+	size     int
+	lock     sync.RWMutex
+}
+
+// newConfigHistory returns a new configHistory that retains up to size versions for every Gateway.
+func newConfigHistory(size int) *configHistory {
+	return &configHistory{
+		versions: make(map[types.NamespacedName][]configVersion),
+		now:      time.Now,
+		size:     size,
+	}
+}
+
+// record records the files as the latest version of the configuration of the Gateway. If the files are the same
+// as the ones of the latest version, only the error of the latest version is updated.
+// The oldest version is dropped once the history of the Gateway is full.
+func (c *configHistory) record(gateway types.NamespacedName, files []agent.File, triggers []string, err error) {
+	overviews := make([]*pb.File, 0, len(files))
+	for _, file := range files {
+		overviews = append(overviews, &pb.File{FileMeta: file.Meta})
+	}
+	version := filesHelper.GenerateConfigVersion(overviews)
+
+	var errMsg string
+	if err != nil {
+		errMsg = redact.String(err.Error())
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	versions := c.versions[gateway]
+	if len(versions) > 0 && versions[len(versions)-1].Version == version {
+		versions[len(versions)-1].Error = errMsg
+		return
+	}
+
+	fileDumps := make([]fileDump, 0, len(files))
+	for _, file := range files {
+		name := file.Meta.GetName()
+		fileDumps = append(fileDumps, fileDump{
+			Name:        name,
+			Permissions: file.Meta.GetPermissions(),
+			Contents:    string(redact.File(name, file.Contents)),
+		})
+	}
+
+	slices.SortFunc(fileDumps, func(a, b fileDump) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	versions = append(versions, configVersion{
+		Time:     c.now(),
+		Version:  version,
+		Error:    errMsg,
+		Triggers: triggers,
+		Files:    fileDumps,
+	})
+
+	if len(versions) > c.size {
+		versions = slices.Delete(versions, 0, len(versions)-c.size)
+	}
+
+	c.versions[gateway] = versions
+}
+
+// remove removes the history of the Gateway.
+func (c *configHistory) remove(gateway types.NamespacedName) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.versions, gateway)
+}
+
+// list returns the retained versions of the Gateways, from the oldest to the latest. If the time is not zero,
+// only the version that was in effect at that time is returned for every Gateway.
+func (c *configHistory) list(at time.Time) map[types.NamespacedName][]configVersion {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	list := make(map[types.NamespacedName][]configVersion, len(c.versions))
+	for gateway, versions := range c.versions {
+		if at.IsZero() {
+			list[gateway] = slices.Clone(versions)
+			continue
+		}
+
+		// the versions are ordered by time, so the version in effect is the last one generated before the time
+		idx, found := slices.BinarySearchFunc(versions, at, func(v configVersion, t time.Time) int {
+			return v.Time.Compare(t)
+		})
+		if found {
+			idx++
+		}
+
+		if idx > 0 {
+			list[gateway] = []configVersion{versions[idx-1]}
+		}
+	}
+
+	return list
+}
+
+// newConfigHistoryDebugHandler returns a handler that serves the history of the nginx configuration of every
+// Gateway as JSON. The optional query parameters are:
+// - gateway: the <namespace>/<name> of the Gateway to serve the history of.
+// - at: the time, in the RFC 3339 format, to serve the version of the configuration that was in effect at.
+func newConfigHistoryDebugHandler(history *configHistory) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var at time.Time
+		if param := r.URL.Query().Get("at"); param != "" {
+			var err error
+			if at, err = time.Parse(time.RFC3339, param); err != nil {
+				http.Error(w, fmt.Sprintf("invalid time %q: must be in the RFC 3339 format", param), http.StatusBadRequest)
+				return
+			}
+		}
+
+		gateway := r.URL.Query().Get("gateway")
+
+		dump := make(map[string][]configVersion)
+		for nsName, versions := range history.list(at) {
+			if gateway != "" && nsName.String() != gateway {
+				continue
+			}
+
+			dump[nsName.String()] = versions
+		}
+
+		writeJSON(w, dump)
+	})
+}
+
+type configTriggersKey struct{}
+
+// contextWithConfigTriggers returns a copy of the context that holds the changes that trigger the generation
+// of the nginx configuration.
+func contextWithConfigTriggers(ctx context.Context, triggers []string) context.Context {
+	return context.WithValue(ctx, configTriggersKey{}, triggers)
+}
+
+// configTriggers returns the changes that trigger the generation of the nginx configuration.
+func configTriggers(ctx context.Context) []string {
+	triggers, _ := ctx.Value(configTriggersKey{}).([]string)
+	return triggers
+}
+
+// describeEventBatch describes the changes of the event batch, sorted. At most maxConfigTriggers changes are
+// described.
+func describeEventBatch(batch events.EventBatch) []string {
+	descriptions := make([]string, 0, len(batch))
+	for _, event := range batch {
+		switch e := event.(type) {
+		case *events.UpsertEvent:
+			descriptions = append(
+				descriptions,
+				fmt.Sprintf("%s %s upserted", objectKind(e.Resource), formatNsName(client.ObjectKeyFromObject(e.Resource))),
+			)
+		case *events.DeleteEvent:
+			descriptions = append(
+				descriptions,
+				fmt.Sprintf("%s %s deleted", objectKind(e.Type), formatNsName(e.NamespacedName)),
+			)
+		case *agent.CapabilitiesChangedEvent:
+			descriptions = append(
+				descriptions,
+				fmt.Sprintf("capabilities of nginx Deployment %s changed", e.Deployment),
+			)
+		case *consistencySweepEvent:
+			descriptions = append(descriptions, "consistency sweep")
+		case *canaryAnalysisEvent:
+			for _, change := range e.changes {
+				descriptions = append(
+					descriptions,
+					fmt.Sprintf(
+						"canary analysis of %s %s changed to %s",
+						objectKind(change.Route),
+						formatNsName(client.ObjectKeyFromObject(change.Route)),
+						change.State,
+					),
+				)
+			}
+		case *rampUpEvent:
+			for _, change := range e.changes {
+				descriptions = append(
+					descriptions,
+					fmt.Sprintf(
+						"ramp-up of %s %s: %s",
+						objectKind(change.Route),
+						formatNsName(client.ObjectKeyFromObject(change.Route)),
+						change.Reason,
+					),
+				)
+			}
+		case *outlierHookEvent:
+			for _, change := range e.changes {
+				descriptions = append(
+					descriptions,
+					fmt.Sprintf(
+						"outlier hook of %s %s changed to %s",
+						objectKind(change.Route),
+						formatNsName(client.ObjectKeyFromObject(change.Route)),
+						change.State,
+					),
+				)
+			}
+		case *reloadGovernorEvent:
+			descriptions = append(
+				descriptions,
+				fmt.Sprintf("deferred reload of nginx Deployment %s", e.deployment),
+			)
+		case *apiCatalogEvent:
+			descriptions = append(descriptions, "API catalogs changed")
+		case *wasmHookEvent:
+			descriptions = append(descriptions, fmt.Sprintf("WASM hook of Gateway %s completed", formatNsName(e.gateway)))
+		}
+	}
+
+	slices.Sort(descriptions)
+	descriptions = slices.Compact(descriptions)
+
+	if len(descriptions) > maxConfigTriggers {
+		more := len(descriptions) - maxConfigTriggers
+		descriptions = append(descriptions[:maxConfigTriggers], fmt.Sprintf("%d more changes", more))
+	}
+
+	return descriptions
+}
+
+// objectKind returns the kind of the object. The typed objects usually don't have their kind set,
+// so the kind is derived from the type of the object.
+func objectKind(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Name()
+}
+
+func formatNsName(nsName types.NamespacedName) string {
+	if nsName.Namespace == "" {
+		return nsName.Name
+	}
+
+	return nsName.String()
+}
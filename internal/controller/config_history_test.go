@@ -0,0 +1,312 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/outlier"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/rampup"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/events"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/redact"
+)
+
+func newTestConfigHistory(size int, start time.Time) *configHistory {
+	history := newConfigHistory(size)
+
+	current := start
+	history.now = func() time.Time {
+		now := current
+		current = current.Add(time.Minute)
+		return now
+	}
+
+	return history
+}
+
+func testConfigFiles(contents string) []agent.File {
+	return []agent.File{
+		{
+			Meta: &pb.FileMeta{
+				Name:        "/etc/nginx/conf.d/http.conf",
+				Hash:        contents,
+				Permissions: "0644",
+			},
+			Contents: []byte(contents),
+		},
+		{
+			Meta: &pb.FileMeta{
+				Name:        "/etc/nginx/secrets/license.jwt",
+				Hash:        "token",
+				Permissions: "0640",
+			},
+			Contents: []byte("token"),
+		},
+	}
+}
+
+func TestConfigHistory(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	start := time.Date(2025, 1, 1, 14, 30, 0, 0, time.UTC)
+	history := newTestConfigHistory(2, start)
+
+	gateway := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	otherGateway := types.NamespacedName{Namespace: "test", Name: "other-gateway"}
+
+	history.record(gateway, testConfigFiles("server 1"), []string{"HTTPRoute test/hr upserted"}, nil)
+	// the same files don't make a new version, only the error is updated
+	history.record(gateway, testConfigFiles("server 1"), nil, errors.New("apply error"))
+	history.record(gateway, testConfigFiles("server 2"), nil, nil)
+	history.record(gateway, testConfigFiles("server 3"), nil, nil)
+	history.record(otherGateway, testConfigFiles("server 1"), nil, nil)
+
+	versions := history.list(time.Time{})
+	g.Expect(versions).To(HaveLen(2))
+
+	// the oldest version was dropped
+	g.Expect(versions[gateway]).To(HaveLen(2))
+	g.Expect(versions[gateway][0].Time).To(Equal(start.Add(time.Minute)))
+	g.Expect(versions[gateway][0].Files[0].Contents).To(Equal("server 2"))
+	g.Expect(versions[gateway][0].Files[1].Contents).To(Equal(redact.Placeholder))
+	g.Expect(versions[gateway][1].Time).To(Equal(start.Add(2 * time.Minute)))
+	g.Expect(versions[gateway][1].Files[0].Contents).To(Equal("server 3"))
+	g.Expect(versions[gateway][0].Version).ToNot(Equal(versions[gateway][1].Version))
+
+	history.remove(otherGateway)
+	g.Expect(history.list(time.Time{})).ToNot(HaveKey(otherGateway))
+}
+
+func TestConfigHistory_ErrorAndTriggers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	start := time.Date(2025, 1, 1, 14, 30, 0, 0, time.UTC)
+	history := newTestConfigHistory(5, start)
+
+	gateway := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	history.record(gateway, testConfigFiles("server 1"), []string{"HTTPRoute test/hr upserted"}, nil)
+	history.record(gateway, testConfigFiles("server 1"), []string{"Service test/svc upserted"}, errors.New("apply error"))
+
+	versions := history.list(time.Time{})[gateway]
+	g.Expect(versions).To(HaveLen(1))
+	g.Expect(versions[0].Triggers).To(Equal([]string{"HTTPRoute test/hr upserted"}))
+	g.Expect(versions[0].Error).To(Equal("apply error"))
+}
+
+func TestConfigHistory_ListAt(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 14, 30, 0, 0, time.UTC)
+	history := newTestConfigHistory(5, start)
+
+	gateway := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	history.record(gateway, testConfigFiles("server 1"), nil, nil) // 14:30
+	history.record(gateway, testConfigFiles("server 2"), nil, nil) // 14:31
+	history.record(gateway, testConfigFiles("server 3"), nil, nil) // 14:32
+
+	tests := []struct {
+		at          time.Time
+		name        string
+		expContents string
+	}{
+		{
+			name: "before the first version",
+			at:   start.Add(-time.Second),
+		},
+		{
+			name:        "at the time of a version",
+			at:          start.Add(time.Minute),
+			expContents: "server 2",
+		},
+		{
+			name:        "between versions",
+			at:          start.Add(time.Minute + 30*time.Second),
+			expContents: "server 2",
+		},
+		{
+			name:        "after the latest version",
+			at:          start.Add(time.Hour),
+			expContents: "server 3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			versions := history.list(test.at)
+			if test.expContents == "" {
+				g.Expect(versions).To(BeEmpty())
+				return
+			}
+
+			g.Expect(versions[gateway]).To(HaveLen(1))
+			g.Expect(versions[gateway][0].Files[0].Contents).To(Equal(test.expContents))
+		})
+	}
+}
+
+func TestConfigHistoryDebugHandler(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 14, 30, 0, 0, time.UTC)
+	history := newTestConfigHistory(5, start)
+
+	gateway := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	otherGateway := types.NamespacedName{Namespace: "test", Name: "other-gateway"}
+
+	history.record(gateway, testConfigFiles("server 1"), []string{"HTTPRoute test/hr upserted"}, nil)
+	history.record(gateway, testConfigFiles("server 2"), nil, nil)
+	history.record(otherGateway, testConfigFiles("server 1"), nil, nil)
+
+	tests := []struct {
+		expVersions map[string]int
+		name        string
+		query       string
+		expCode     int
+	}{
+		{
+			name:        "all gateways",
+			expCode:     http.StatusOK,
+			expVersions: map[string]int{"test/gateway": 2, "test/other-gateway": 1},
+		},
+		{
+			name:        "one gateway",
+			query:       "?gateway=test/gateway",
+			expCode:     http.StatusOK,
+			expVersions: map[string]int{"test/gateway": 2},
+		},
+		{
+			name:        "at a time",
+			query:       "?gateway=test/gateway&at=2025-01-01T14:30:30Z",
+			expCode:     http.StatusOK,
+			expVersions: map[string]int{"test/gateway": 1},
+		},
+		{
+			name:    "invalid time",
+			query:   "?at=14:32",
+			expCode: http.StatusBadRequest,
+		},
+	}
+
+	handler := newConfigHistoryDebugHandler(history)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+test.query, nil))
+
+			g.Expect(rec.Code).To(Equal(test.expCode))
+			if test.expCode != http.StatusOK {
+				return
+			}
+
+			var dump map[string][]configVersion
+			g.Expect(json.Unmarshal(rec.Body.Bytes(), &dump)).To(Succeed())
+			g.Expect(dump).To(HaveLen(len(test.expVersions)))
+			for gw, count := range test.expVersions {
+				g.Expect(dump[gw]).To(HaveLen(count))
+			}
+		})
+	}
+}
+
+func TestDescribeEventBatch(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	batch := events.EventBatch{
+		&events.UpsertEvent{
+			Resource: &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"}},
+		},
+		&events.UpsertEvent{
+			Resource: &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"}},
+		},
+		&events.DeleteEvent{
+			Type:           &gatewayv1.GatewayClass{},
+			NamespacedName: types.NamespacedName{Name: "nginx"},
+		},
+		&agent.CapabilitiesChangedEvent{Deployment: types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}},
+		&consistencySweepEvent{},
+		&canaryAnalysisEvent{
+			changes: []canary.Change{
+				{
+					Route: &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"}},
+					State: canary.StateReverted,
+				},
+			},
+		},
+		&rampUpEvent{
+			changes: []rampup.Change{
+				{
+					Route:  &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"}},
+					Reason: rampup.ReasonStarted,
+				},
+			},
+		},
+		&outlierHookEvent{
+			changes: []outlier.Change{
+				{
+					Route: &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"}},
+					State: outlier.StateTriggered,
+				},
+			},
+		},
+		&reloadGovernorEvent{deployment: types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}},
+		&apiCatalogEvent{},
+	}
+
+	g.Expect(describeEventBatch(batch)).To(Equal([]string{
+		"API catalogs changed",
+		"GatewayClass nginx deleted",
+		"HTTPRoute test/hr upserted",
+		"canary analysis of HTTPRoute test/hr changed to Reverted",
+		"capabilities of nginx Deployment test/gateway-nginx changed",
+		"consistency sweep",
+		"deferred reload of nginx Deployment test/gateway-nginx",
+		"outlier hook of HTTPRoute test/hr changed to Triggered",
+		"ramp-up of HTTPRoute test/hr: RampUpStarted",
+	}))
+
+	var largeBatch events.EventBatch
+	for i := range maxConfigTriggers + 5 {
+		largeBatch = append(largeBatch, &events.UpsertEvent{
+			Resource: &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: fmt.Sprintf("hr-%02d", i)}},
+		})
+	}
+
+	descriptions := describeEventBatch(largeBatch)
+	g.Expect(descriptions).To(HaveLen(maxConfigTriggers + 1))
+	g.Expect(descriptions[maxConfigTriggers]).To(Equal("5 more changes"))
+}
+
+func TestConfigTriggers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(configTriggers(context.Background())).To(BeNil())
+
+	ctx := contextWithConfigTriggers(context.Background(), []string{"consistency sweep"})
+	g.Expect(configTriggers(ctx)).To(Equal([]string{"consistency sweep"}))
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/runnables"
+)
+
+const (
+	// consistencySweepPeriod is the period of the consistency sweep.
+	consistencySweepPeriod = 5 * time.Minute
+	// consistencySweepJitterFactor spreads the consistency sweeps of the replicas of the control plane.
+	consistencySweepJitterFactor = 0.1
+)
+
+// consistencySweepEvent makes the event handler repair the state that was left behind by deletions of Gateways
+// and Routes that were missed or raced with the processing of another event batch: it removes the nginx Deployments
+// and the configurations of the Gateways that no longer exist, resends the configuration of the remaining Gateways,
+// and clears the statuses of the Routes that are no longer handled.
+type consistencySweepEvent struct{}
+
+// newConsistencySweepJob creates a job that periodically sends a consistencySweepEvent to the event loop.
+// The first sweep happens once the readyCh is closed, which repairs the deletions missed while the control plane
+// was not running.
+func newConsistencySweepJob(
+	logger logr.Logger,
+	eventCh chan<- interface{},
+	readyCh <-chan struct{},
+) *runnables.LeaderOrNonLeader {
+	worker := func(ctx context.Context) {
+		select {
+		case eventCh <- &consistencySweepEvent{}:
+		case <-ctx.Done():
+		}
+	}
+
+	return &runnables.LeaderOrNonLeader{
+		Runnable: runnables.NewCronJob(
+			runnables.CronJobConfig{
+				Worker:       worker,
+				Logger:       logger,
+				Period:       consistencySweepPeriod,
+				JitterFactor: consistencySweepJitterFactor,
+				ReadyCh:      readyCh,
+			},
+		),
+	}
+}
+
+// consistencySweepRequested returns whether a consistency sweep was requested since the last call, and resets it.
+func (h *eventHandlerImpl) consistencySweepRequested() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	requested := h.sweepRequested
+	h.sweepRequested = false
+
+	return requested
+}
+
+// removeStaleGateways removes the latest configurations, the configuration histories and the nginx Deployments
+// of the Gateways that are not in the graph. A Gateway can be deleted while the handler is processing a graph
+// that still contains it, in which case its nginx Deployment is stored again after the provisioner removed it.
+// Removing is idempotent, so it is safe to call this for every graph.
+func (h *eventHandlerImpl) removeStaleGateways(logger logr.Logger, gr *graph.Graph) {
+	h.lock.Lock()
+	for gwNsName := range h.latestConfigurations {
+		if _, exists := gr.Gateways[gwNsName]; !exists {
+			delete(h.latestConfigurations, gwNsName)
+			delete(h.appliedTrafficRoutingSteps, gwNsName)
+
+			if h.cfg.configHistory != nil {
+				h.cfg.configHistory.remove(gwNsName)
+			}
+		}
+	}
+	h.lock.Unlock()
+
+	if h.cfg.wasmHook != nil {
+		h.cfg.wasmHook.Retain(func(gwNsName types.NamespacedName) bool {
+			_, exists := gr.Gateways[gwNsName]
+			return exists
+		})
+	}
+
+	deploymentNames := make(map[types.NamespacedName]struct{}, len(gr.Gateways))
+	for _, gw := range gr.Gateways {
+		deploymentNames[gw.DeploymentName] = struct{}{}
+	}
+
+	for deploymentName := range h.cfg.nginxDeployments.List() {
+		if _, exists := deploymentNames[deploymentName]; exists {
+			continue
+		}
+
+		logger.Info(
+			"Removing nginx Deployment of a Gateway that no longer exists",
+			"namespace", deploymentName.Namespace,
+			"name", deploymentName.Name,
+		)
+		h.cfg.nginxDeployments.Remove(deploymentName)
+
+		if h.cfg.reloadGovernor != nil {
+			h.cfg.reloadGovernor.Forget(deploymentName)
+		}
+	}
+}
+
+// prepareStaleRouteRequests prepares the status UpdateRequests that clear the statuses of the Routes
+// that are no longer handled by any Gateway in the graph.
+func (h *eventHandlerImpl) prepareStaleRouteRequests(ctx context.Context, gr *graph.Graph) []status.UpdateRequest {
+	routeLists := []client.ObjectList{
+		&gatewayv1.HTTPRouteList{},
+		&gatewayv1.GRPCRouteList{},
+	}
+	if h.cfg.experimentalFeatures {
+		routeLists = append(routeLists, &gatewayv1alpha2.TLSRouteList{})
+	}
+
+	var routes []client.Object
+	for _, list := range routeLists {
+		// the Routes are listed from the cache, so it doesn't make any API calls
+		if err := h.cfg.k8sClient.List(ctx, list); err != nil {
+			h.cfg.logger.Error(err, "error listing Routes to clear stale statuses")
+			continue
+		}
+
+		if err := meta.EachListItem(list, func(obj runtime.Object) error {
+			if route, ok := obj.(client.Object); ok {
+				routes = append(routes, route)
+			}
+			return nil
+		}); err != nil {
+			h.cfg.logger.Error(err, "error reading Routes to clear stale statuses")
+		}
+	}
+
+	return status.PrepareStaleRouteRequests(routes, gr.L4Routes, gr.Routes, h.cfg.gatewayCtlrName)
+}
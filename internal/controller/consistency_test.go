@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+)
+
+func TestConsistencySweepJob(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	eventCh := make(chan interface{})
+	readyCh := make(chan struct{})
+
+	job := newConsistencySweepJob(logr.Discard(), eventCh, readyCh)
+	g.Expect(job.NeedLeaderElection()).To(BeFalse())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- job.Start(ctx)
+	}()
+
+	// the sweep doesn't start until the control plane is ready
+	g.Consistently(eventCh).ShouldNot(Receive())
+
+	close(readyCh)
+
+	g.Eventually(eventCh).Should(Receive(Equal(&consistencySweepEvent{})))
+
+	cancel()
+	g.Eventually(errCh).Should(Receive(BeNil()))
+}
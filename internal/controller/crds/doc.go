@@ -0,0 +1,8 @@
+/*
+Package crds installs and upgrades the CustomResourceDefinitions of NGINX Gateway Fabric.
+
+When the CRD management is enabled, the control plane applies its CRDs on start, so that the operators upgrading NGF
+don't need to apply the CRDs in a separate step. The Gateway API CRDs are not managed, because they are shared with
+the other Gateway API implementations in the cluster.
+*/
+package crds
@@ -0,0 +1,216 @@
+package crds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/go-logr/logr"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// VersionAnnotation is the annotation of the CRDs that holds the version of NGF that installed them.
+const VersionAnnotation = "gateway.nginx.org/ngf-version"
+
+// InstallerConfig is the configuration for the Installer.
+type InstallerConfig struct {
+	// Client is the Kubernetes client. It must not be backed by a cache, because the Installer runs before
+	// the caches are started.
+	Client client.Client
+	// Files are the contents of the CRD files.
+	Files map[string][]byte
+	// Logger is the logger.
+	Logger logr.Logger
+	// Version is the version of NGF.
+	Version string
+}
+
+// Installer installs and upgrades the CRDs of NGF.
+//
+// A CRD is not downgraded: if the CRD in the cluster was installed by a newer NGF version, it is left unchanged,
+// so that the replicas of an older version don't revert the CRDs during a rolling upgrade.
+// After a CRD is upgraded, the Installer migrates the stored resources to the storage version of the CRD and removes
+// the older versions from the stored versions of the CRD, so that they can be dropped by the next upgrade.
+type Installer struct {
+	cfg InstallerConfig
+}
+
+// NewInstaller creates a new Installer.
+func NewInstaller(cfg InstallerConfig) *Installer {
+	return &Installer{cfg: cfg}
+}
+
+// Install installs or upgrades the CRDs and migrates their stored versions.
+func (i *Installer) Install(ctx context.Context) error {
+	crds, err := decodeCRDs(i.cfg.Files)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, crd := range crds {
+		if err := i.install(ctx, crd); err != nil {
+			errs = append(errs, fmt.Errorf("error installing CRD %s: %w", crd.Name, err))
+			continue
+		}
+
+		if err := i.migrateStoredVersions(ctx, crd); err != nil {
+			errs = append(errs, fmt.Errorf("error migrating stored versions of CRD %s: %w", crd.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (i *Installer) install(ctx context.Context, crd *apiext.CustomResourceDefinition) error {
+	if crd.Annotations == nil {
+		crd.Annotations = make(map[string]string)
+	}
+	crd.Annotations[VersionAnnotation] = i.cfg.Version
+
+	var existing apiext.CustomResourceDefinition
+	if err := i.cfg.Client.Get(ctx, client.ObjectKeyFromObject(crd), &existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		i.cfg.Logger.Info("Installing CRD", "name", crd.Name)
+
+		return i.cfg.Client.Create(ctx, crd)
+	}
+
+	if isNewer(existing.Annotations[VersionAnnotation], i.cfg.Version) {
+		i.cfg.Logger.Info(
+			"Skipping upgrade of CRD installed by a newer version",
+			"name", crd.Name,
+			"installedBy", existing.Annotations[VersionAnnotation],
+		)
+		return nil
+	}
+
+	i.cfg.Logger.Info("Upgrading CRD", "name", crd.Name)
+
+	existing.Labels = crd.Labels
+	existing.Annotations = mergeAnnotations(existing.Annotations, crd.Annotations)
+	existing.Spec = crd.Spec
+
+	return i.cfg.Client.Update(ctx, &existing)
+}
+
+// migrateStoredVersions rewrites the resources of the CRD, so that they are stored in the storage version,
+// and then sets the stored versions of the CRD to the storage version.
+func (i *Installer) migrateStoredVersions(ctx context.Context, crd *apiext.CustomResourceDefinition) error {
+	var current apiext.CustomResourceDefinition
+	if err := i.cfg.Client.Get(ctx, client.ObjectKeyFromObject(crd), &current); err != nil {
+		return err
+	}
+
+	storageVersion := getStorageVersion(&current)
+	if storageVersion == "" {
+		return nil
+	}
+
+	if len(current.Status.StoredVersions) == 0 ||
+		slices.Equal(current.Status.StoredVersions, []string{storageVersion}) {
+		return nil
+	}
+
+	i.cfg.Logger.Info(
+		"Migrating stored versions of CRD",
+		"name", current.Name,
+		"storedVersions", current.Status.StoredVersions,
+		"storageVersion", storageVersion,
+	)
+
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion(current.Spec.Group + "/" + storageVersion)
+	list.SetKind(current.Spec.Names.ListKind)
+
+	if err := i.cfg.Client.List(ctx, list); err != nil {
+		return fmt.Errorf("error listing resources: %w", err)
+	}
+
+	for idx := range list.Items {
+		// An update without changes makes the API server store the resource in the storage version.
+		if err := i.cfg.Client.Update(ctx, &list.Items[idx]); err != nil {
+			if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+				// the resource was deleted or updated, so it is already stored in the storage version
+				continue
+			}
+			return fmt.Errorf("error migrating %s/%s: %w", list.Items[idx].GetNamespace(), list.Items[idx].GetName(), err)
+		}
+	}
+
+	current.Status.StoredVersions = []string{storageVersion}
+
+	return i.cfg.Client.Status().Update(ctx, &current)
+}
+
+func decodeCRDs(files map[string][]byte) ([]*apiext.CustomResourceDefinition, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	crds := make([]*apiext.CustomResourceDefinition, 0, len(files))
+	for _, name := range names {
+		var crd apiext.CustomResourceDefinition
+		if err := yaml.Unmarshal(files[name], &crd); err != nil {
+			return nil, fmt.Errorf("error decoding CRD file %s: %w", name, err)
+		}
+
+		if crd.Name == "" {
+			return nil, fmt.Errorf("CRD file %s doesn't contain a CRD", name)
+		}
+
+		crds = append(crds, &crd)
+	}
+
+	return crds, nil
+}
+
+func getStorageVersion(crd *apiext.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+
+	return ""
+}
+
+// isNewer returns true if the installed version is a newer semantic version than the running version.
+// Versions that are not semantic versions, like edge, are never considered newer.
+func isNewer(installed, running string) bool {
+	installedVersion, err := version.ParseSemantic(installed)
+	if err != nil {
+		return false
+	}
+
+	runningVersion, err := version.ParseSemantic(running)
+	if err != nil {
+		return false
+	}
+
+	return runningVersion.LessThan(installedVersion)
+}
+
+func mergeAnnotations(existing, desired map[string]string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(desired))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range desired {
+		merged[k] = v
+	}
+
+	return merged
+}
@@ -0,0 +1,260 @@
+package crds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ngfcrd "github.com/nginx/nginx-gateway-fabric/v2/config/crd"
+)
+
+const widgetsCRD = `---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    listKind: WidgetList
+    plural: widgets
+    singular: widget
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: false
+    schema:
+      openAPIV3Schema:
+        type: object
+  - name: v1alpha2
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+`
+
+func createScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	NewWithT(t).Expect(apiext.AddToScheme(scheme)).To(Succeed())
+
+	return scheme
+}
+
+func createFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	return fake.NewClientBuilder().
+		WithScheme(createScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&apiext.CustomResourceDefinition{}).
+		Build()
+}
+
+func getCRD(g *WithT, k8sClient client.Client, name string) *apiext.CustomResourceDefinition {
+	var crd apiext.CustomResourceDefinition
+	g.Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: name}, &crd)).To(Succeed())
+
+	return &crd
+}
+
+func TestInstallCreatesCRDs(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	files, err := ngfcrd.Files()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(files).ToNot(BeEmpty())
+
+	k8sClient := createFakeClient(t)
+
+	installer := NewInstaller(InstallerConfig{
+		Client:  k8sClient,
+		Files:   files,
+		Logger:  logr.Discard(),
+		Version: "2.3.0",
+	})
+	g.Expect(installer.Install(t.Context())).To(Succeed())
+
+	var crds apiext.CustomResourceDefinitionList
+	g.Expect(k8sClient.List(t.Context(), &crds)).To(Succeed())
+	g.Expect(crds.Items).To(HaveLen(len(files)))
+
+	for _, crd := range crds.Items {
+		g.Expect(crd.Spec.Group).To(Equal("gateway.nginx.org"))
+		g.Expect(crd.Annotations).To(HaveKeyWithValue(VersionAnnotation, "2.3.0"))
+		g.Expect(crd.Spec.Conversion).To(BeNil())
+	}
+}
+
+func TestInstallUpgradesCRD(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		installedVersion string
+		runningVersion   string
+		expUpgraded      bool
+	}{
+		{
+			name:             "older version is upgraded",
+			installedVersion: "2.2.0",
+			runningVersion:   "2.3.0",
+			expUpgraded:      true,
+		},
+		{
+			name:             "same version is reapplied",
+			installedVersion: "2.3.0",
+			runningVersion:   "2.3.0",
+			expUpgraded:      true,
+		},
+		{
+			name:             "CRD without version is upgraded",
+			installedVersion: "",
+			runningVersion:   "2.3.0",
+			expUpgraded:      true,
+		},
+		{
+			name:             "edge version always upgrades",
+			installedVersion: "2.3.0",
+			runningVersion:   "edge",
+			expUpgraded:      true,
+		},
+		{
+			name:             "newer version is not downgraded",
+			installedVersion: "2.4.0",
+			runningVersion:   "2.3.0",
+			expUpgraded:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			existing := &apiext.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "widgets.example.com",
+					Annotations: map[string]string{
+						VersionAnnotation: test.installedVersion,
+						"user-annotation": "kept",
+					},
+				},
+				Spec: apiext.CustomResourceDefinitionSpec{
+					Group: "example.com",
+					Names: apiext.CustomResourceDefinitionNames{
+						Kind:     "Widget",
+						ListKind: "WidgetList",
+						Plural:   "widgets",
+					},
+					Scope: apiext.NamespaceScoped,
+					Versions: []apiext.CustomResourceDefinitionVersion{
+						{Name: "v1alpha1", Served: true, Storage: true},
+					},
+				},
+			}
+
+			k8sClient := createFakeClient(t, existing)
+
+			installer := NewInstaller(InstallerConfig{
+				Client:  k8sClient,
+				Files:   map[string][]byte{"widgets.yaml": []byte(widgetsCRD)},
+				Logger:  logr.Discard(),
+				Version: test.runningVersion,
+			})
+			g.Expect(installer.Install(t.Context())).To(Succeed())
+
+			crd := getCRD(g, k8sClient, "widgets.example.com")
+			g.Expect(crd.Annotations).To(HaveKeyWithValue("user-annotation", "kept"))
+
+			if test.expUpgraded {
+				g.Expect(crd.Spec.Versions).To(HaveLen(2))
+				g.Expect(crd.Annotations).To(HaveKeyWithValue(VersionAnnotation, test.runningVersion))
+			} else {
+				g.Expect(crd.Spec.Versions).To(HaveLen(1))
+				g.Expect(crd.Annotations).To(HaveKeyWithValue(VersionAnnotation, test.installedVersion))
+			}
+		})
+	}
+}
+
+func TestInstallMigratesStoredVersions(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	existing := &apiext.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "widgets.example.com",
+		},
+		Spec: apiext.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiext.CustomResourceDefinitionNames{
+				Kind:     "Widget",
+				ListKind: "WidgetList",
+				Plural:   "widgets",
+			},
+			Scope: apiext.NamespaceScoped,
+			Versions: []apiext.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true, Storage: true},
+			},
+		},
+		Status: apiext.CustomResourceDefinitionStatus{
+			StoredVersions: []string{"v1alpha1"},
+		},
+	}
+
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1alpha2")
+	widget.SetKind("Widget")
+	widget.SetNamespace("test")
+	widget.SetName("widget")
+
+	k8sClient := createFakeClient(t, existing, widget)
+	widgetKey := types.NamespacedName{Namespace: "test", Name: "widget"}
+	g.Expect(k8sClient.Get(t.Context(), widgetKey, widget)).To(Succeed())
+
+	installer := NewInstaller(InstallerConfig{
+		Client:  k8sClient,
+		Files:   map[string][]byte{"widgets.yaml": []byte(widgetsCRD)},
+		Logger:  logr.Discard(),
+		Version: "2.3.0",
+	})
+	g.Expect(installer.Install(t.Context())).To(Succeed())
+
+	crd := getCRD(g, k8sClient, "widgets.example.com")
+	g.Expect(crd.Status.StoredVersions).To(Equal([]string{"v1alpha2"}))
+
+	// the widget was rewritten
+	migrated := &unstructured.Unstructured{}
+	migrated.SetAPIVersion("example.com/v1alpha2")
+	migrated.SetKind("Widget")
+	g.Expect(k8sClient.Get(t.Context(), widgetKey, migrated)).To(Succeed())
+	g.Expect(migrated.GetResourceVersion()).ToNot(Equal(widget.GetResourceVersion()))
+}
+
+func TestInstallInvalidFile(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	installer := NewInstaller(InstallerConfig{
+		Client:  createFakeClient(t),
+		Files:   map[string][]byte{"invalid.yaml": []byte("kind: ConfigMap")},
+		Logger:  logr.Discard(),
+		Version: "2.3.0",
+	})
+	g.Expect(installer.Install(t.Context())).To(MatchError(ContainSubstring("doesn't contain a CRD")))
+}
@@ -0,0 +1,351 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/redact"
+	"github.com/nginx/nginx-gateway-fabric/v2/pkg/dataplane/v1alpha1"
+)
+
+const (
+	// DebugGraphPath is the path on the metrics server that serves a dump of the latest Graph.
+	DebugGraphPath = "/debug/graph"
+	// DebugConfigPath is the path on the metrics server that serves the redacted generated NGINX configuration.
+	DebugConfigPath = "/debug/config"
+	// DebugConfigHistoryPath is the path on the metrics server that serves the history of the redacted generated
+	// NGINX configuration.
+	DebugConfigHistoryPath = "/debug/config/history"
+	// DebugRouteModelPath is the path on the metrics server that serves the latest route and upstream model of every
+	// Gateway in the public v1alpha1 format.
+	DebugRouteModelPath = "/debug/route-model"
+)
+
+// graphGetter gets the latest Graph.
+type graphGetter interface {
+	GetLatestGraph() *graph.Graph
+}
+
+// deploymentLister lists the nginx Deployments known to the control plane.
+type deploymentLister interface {
+	List() map[types.NamespacedName]*agent.Deployment
+}
+
+// configurationsGetter gets the latest dataplane Configuration of every Gateway.
+type configurationsGetter interface {
+	GetLatestConfigurationsByGateway() map[types.NamespacedName]*dataplane.Configuration
+}
+
+// graphDump is a JSON-friendly summary of the Graph. It only includes the validity and conditions of the
+// resources, so it never includes Secret data.
+type graphDump struct {
+	GatewayClass       *resourceDump  `json:"gatewayClass,omitempty"`
+	Gateways           []gatewayDump  `json:"gateways"`
+	Routes             []routeDump    `json:"routes"`
+	BackendTLSPolicies []resourceDump `json:"backendTLSPolicies"`
+	Policies           []resourceDump `json:"policies"`
+}
+
+type resourceDump struct {
+	Kind       string          `json:"kind"`
+	Name       string          `json:"name"`
+	Conditions []conditionDump `json:"conditions,omitempty"`
+	Valid      bool            `json:"valid"`
+}
+
+type gatewayDump struct {
+	DeploymentName string         `json:"deploymentName"`
+	Listeners      []listenerDump `json:"listeners"`
+	resourceDump
+}
+
+type listenerDump struct {
+	Name       string          `json:"name"`
+	Protocol   string          `json:"protocol"`
+	Routes     []string        `json:"routes,omitempty"`
+	Conditions []conditionDump `json:"conditions,omitempty"`
+	Port       int32           `json:"port"`
+	Valid      bool            `json:"valid"`
+	Attachable bool            `json:"attachable"`
+}
+
+type routeDump struct {
+	ParentRefs []parentRefDump `json:"parentRefs"`
+	resourceDump
+	Attachable bool `json:"attachable"`
+}
+
+type parentRefDump struct {
+	Gateway          string          `json:"gateway"`
+	SectionName      string          `json:"sectionName,omitempty"`
+	FailedConditions []conditionDump `json:"failedConditions,omitempty"`
+	Attached         bool            `json:"attached"`
+}
+
+type conditionDump struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// fileDump is a generated nginx file with any sensitive contents redacted.
+type fileDump struct {
+	Name        string `json:"name"`
+	Permissions string `json:"permissions"`
+	Contents    string `json:"contents"`
+}
+
+// newGraphDebugHandler returns a handler that serves a dump of the latest Graph as JSON.
+func newGraphDebugHandler(getter graphGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		g := getter.GetLatestGraph()
+		if g == nil {
+			http.Error(w, "graph has not been built yet", http.StatusServiceUnavailable)
+			return
+		}
+
+		writeJSON(w, buildGraphDump(g))
+	})
+}
+
+// newConfigDebugHandler returns a handler that serves the redacted nginx files of every Deployment as JSON.
+func newConfigDebugHandler(lister deploymentLister) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		deployments := lister.List()
+
+		dump := make(map[string][]fileDump, len(deployments))
+		for nsName, deployment := range deployments {
+			files := deployment.GetFiles()
+
+			fileDumps := make([]fileDump, 0, len(files))
+			for _, file := range files {
+				name := file.Meta.GetName()
+				fileDumps = append(fileDumps, fileDump{
+					Name:        name,
+					Permissions: file.Meta.GetPermissions(),
+					Contents:    string(redact.File(name, file.Contents)),
+				})
+			}
+
+			slices.SortFunc(fileDumps, func(a, b fileDump) int {
+				return strings.Compare(a.Name, b.Name)
+			})
+
+			dump[nsName.String()] = fileDumps
+		}
+
+		writeJSON(w, dump)
+	})
+}
+
+// newRouteModelDebugHandler returns a handler that serves the route and upstream model of every Gateway as JSON.
+// The model doesn't include Secrets.
+func newRouteModelDebugHandler(getter configurationsGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		configs := getter.GetLatestConfigurationsByGateway()
+
+		dump := make(map[string]v1alpha1.Configuration, len(configs))
+		for nsName, cfg := range configs {
+			if cfg == nil {
+				continue
+			}
+
+			dump[nsName.String()] = dataplane.ToV1alpha1(*cfg)
+		}
+
+		writeJSON(w, dump)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to marshal response: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func buildGraphDump(g *graph.Graph) graphDump {
+	dump := graphDump{
+		Gateways:           make([]gatewayDump, 0, len(g.Gateways)),
+		Routes:             make([]routeDump, 0, len(g.Routes)+len(g.L4Routes)),
+		BackendTLSPolicies: make([]resourceDump, 0, len(g.BackendTLSPolicies)),
+		Policies:           make([]resourceDump, 0, len(g.NGFPolicies)),
+	}
+
+	if g.GatewayClass != nil && g.GatewayClass.Source != nil {
+		dump.GatewayClass = &resourceDump{
+			Kind:       "GatewayClass",
+			Name:       g.GatewayClass.Source.Name,
+			Conditions: dumpConditions(g.GatewayClass.Conditions),
+			Valid:      g.GatewayClass.Valid,
+		}
+	}
+
+	for nsName, gw := range g.Gateways {
+		dump.Gateways = append(dump.Gateways, buildGatewayDump(nsName, gw))
+	}
+
+	for key, route := range g.Routes {
+		dump.Routes = append(dump.Routes, buildRouteDump(
+			string(key.RouteType),
+			key.NamespacedName,
+			route.ParentRefs,
+			route.Conditions,
+			route.Valid,
+			route.Attachable,
+		))
+	}
+
+	for key, route := range g.L4Routes {
+		dump.Routes = append(dump.Routes, buildRouteDump(
+			string(graph.RouteTypeTLS),
+			key.NamespacedName,
+			route.ParentRefs,
+			route.Conditions,
+			route.Valid,
+			route.Attachable,
+		))
+	}
+
+	for nsName, policy := range g.BackendTLSPolicies {
+		dump.BackendTLSPolicies = append(dump.BackendTLSPolicies, resourceDump{
+			Kind:       "BackendTLSPolicy",
+			Name:       nsName.String(),
+			Conditions: dumpConditions(policy.Conditions),
+			Valid:      policy.Valid,
+		})
+	}
+
+	for key, policy := range g.NGFPolicies {
+		dump.Policies = append(dump.Policies, resourceDump{
+			Kind:       key.GVK.Kind,
+			Name:       key.NsName.String(),
+			Conditions: dumpConditions(policy.Conditions),
+			Valid:      policy.Valid,
+		})
+	}
+
+	slices.SortFunc(dump.Gateways, func(a, b gatewayDump) int {
+		return compareResourceDumps(a.resourceDump, b.resourceDump)
+	})
+	slices.SortFunc(dump.Routes, func(a, b routeDump) int {
+		return compareResourceDumps(a.resourceDump, b.resourceDump)
+	})
+	slices.SortFunc(dump.BackendTLSPolicies, compareResourceDumps)
+	slices.SortFunc(dump.Policies, compareResourceDumps)
+
+	return dump
+}
+
+func buildGatewayDump(nsName types.NamespacedName, gw *graph.Gateway) gatewayDump {
+	listeners := make([]listenerDump, 0, len(gw.Listeners))
+	for _, l := range gw.Listeners {
+		routes := make([]string, 0, len(l.Routes)+len(l.L4Routes))
+		for key := range l.Routes {
+			routes = append(routes, fmt.Sprintf("%s/%s", key.RouteType, key.NamespacedName))
+		}
+		for key := range l.L4Routes {
+			routes = append(routes, fmt.Sprintf("%s/%s", graph.RouteTypeTLS, key.NamespacedName))
+		}
+		slices.Sort(routes)
+
+		listeners = append(listeners, listenerDump{
+			Name:       l.Name,
+			Protocol:   string(l.Source.Protocol),
+			Routes:     routes,
+			Conditions: dumpConditions(l.Conditions),
+			Port:       int32(l.Source.Port),
+			Valid:      l.Valid,
+			Attachable: l.Attachable,
+		})
+	}
+
+	return gatewayDump{
+		DeploymentName: gw.DeploymentName.String(),
+		Listeners:      listeners,
+		resourceDump: resourceDump{
+			Kind:       "Gateway",
+			Name:       nsName.String(),
+			Conditions: dumpConditions(gw.Conditions),
+			Valid:      gw.Valid,
+		},
+	}
+}
+
+func buildRouteDump(
+	kind string,
+	nsName types.NamespacedName,
+	parentRefs []graph.ParentRef,
+	conds []conditions.Condition,
+	valid bool,
+	attachable bool,
+) routeDump {
+	refs := make([]parentRefDump, 0, len(parentRefs))
+	for _, ref := range parentRefs {
+		var refDump parentRefDump
+
+		if ref.Gateway != nil {
+			refDump.Gateway = ref.Gateway.NamespacedName.String()
+		}
+		if ref.SectionName != nil {
+			refDump.SectionName = string(*ref.SectionName)
+		}
+		if ref.Attachment != nil {
+			refDump.Attached = ref.Attachment.Attached
+			refDump.FailedConditions = dumpConditions(ref.Attachment.FailedConditions)
+		}
+
+		refs = append(refs, refDump)
+	}
+
+	return routeDump{
+		ParentRefs: refs,
+		resourceDump: resourceDump{
+			Kind:       kind,
+			Name:       nsName.String(),
+			Conditions: dumpConditions(conds),
+			Valid:      valid,
+		},
+		Attachable: attachable,
+	}
+}
+
+func dumpConditions(conds []conditions.Condition) []conditionDump {
+	if len(conds) == 0 {
+		return nil
+	}
+
+	dumps := make([]conditionDump, 0, len(conds))
+	for _, cond := range conds {
+		dumps = append(dumps, conditionDump{
+			Type:    cond.Type,
+			Status:  string(cond.Status),
+			Reason:  cond.Reason,
+			Message: redact.String(cond.Message),
+		})
+	}
+
+	return dumps
+}
+
+func compareResourceDumps(a, b resourceDump) int {
+	if c := strings.Compare(a.Kind, b.Kind); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.Name, b.Name)
+}
@@ -0,0 +1,20 @@
+/*
+Package failure tracks the failures of the NGINX data plane of the Gateways, so that the operators see when the workers
+of NGINX crash, when NGINX runs out of memory, and when NGINX fails to apply its configuration, even though the Pods
+of NGINX stay ready.
+
+The Tracker records the failures of every Gateway. The failures come from three sources:
+
+  - NGINX logs the exits of its worker processes on a signal as alerts in its error log, and sends the alerts
+    to the syslog Receiver of the control plane, with a syslog tag that identifies the Gateway. A worker that was
+    killed with SIGKILL is reported as an OOM kill, because the kernel OOM killer kills the processes with SIGKILL.
+  - The agent of NGINX reports the failures to apply the configuration in its responses to the control plane.
+  - When the agent of NGINX connects to the control plane, the control plane checks whether the previous nginx
+    container of the Pod was OOM killed.
+
+The Tracker passes every failure to a MetricsCollector, which exposes the counters of every Gateway as Prometheus
+metrics, and queues an update of the status of the Gateway, which reports the recent failures with the
+DataPlaneHealthy condition. A failure is recent for a window after it occurred; when the window passes,
+the condition is removed.
+*/
+package failure
@@ -0,0 +1,76 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package failurefakes
+
+import (
+	"sync"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/failure"
+)
+
+type FakeMetricsCollector struct {
+	ObserveDataPlaneFailureStub        func(string, string)
+	observeDataPlaneFailureMutex       sync.RWMutex
+	observeDataPlaneFailureArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMetricsCollector) ObserveDataPlaneFailure(arg1 string, arg2 string) {
+	fake.observeDataPlaneFailureMutex.Lock()
+	fake.observeDataPlaneFailureArgsForCall = append(fake.observeDataPlaneFailureArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ObserveDataPlaneFailureStub
+	fake.recordInvocation("ObserveDataPlaneFailure", []interface{}{arg1, arg2})
+	fake.observeDataPlaneFailureMutex.Unlock()
+	if stub != nil {
+		fake.ObserveDataPlaneFailureStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeMetricsCollector) ObserveDataPlaneFailureCallCount() int {
+	fake.observeDataPlaneFailureMutex.RLock()
+	defer fake.observeDataPlaneFailureMutex.RUnlock()
+	return len(fake.observeDataPlaneFailureArgsForCall)
+}
+
+func (fake *FakeMetricsCollector) ObserveDataPlaneFailureCalls(stub func(string, string)) {
+	fake.observeDataPlaneFailureMutex.Lock()
+	defer fake.observeDataPlaneFailureMutex.Unlock()
+	fake.ObserveDataPlaneFailureStub = stub
+}
+
+func (fake *FakeMetricsCollector) ObserveDataPlaneFailureArgsForCall(i int) (string, string) {
+	fake.observeDataPlaneFailureMutex.RLock()
+	defer fake.observeDataPlaneFailureMutex.RUnlock()
+	argsForCall := fake.observeDataPlaneFailureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMetricsCollector) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMetricsCollector) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ failure.MetricsCollector = new(FakeMetricsCollector)
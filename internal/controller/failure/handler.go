@@ -0,0 +1,111 @@
+package failure
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/syslog"
+)
+
+const (
+	// SyslogTagPrefix is the prefix of the syslog tags of the messages in which NGINX reports the exits
+	// of its worker processes. The prefix is followed by the hash of the nginx Deployment, because a syslog tag
+	// is limited to 32 characters.
+	SyslogTagPrefix = "ngf_fail_"
+
+	// sigkill is the signal with which the kernel OOM killer kills the processes.
+	sigkill = "9"
+)
+
+// exitOnSignal matches the alert that NGINX logs when one of its processes exited on a signal,
+// for example, "worker process 21 exited on signal 11 (core dumped)".
+var exitOnSignal = regexp.MustCompile(`[a-z ]*process \d+ exited on signal (\d+)( \(core dumped\))?`)
+
+// Recorder records the failures of the nginx Deployments.
+type Recorder interface {
+	// Record records a failure of the kind of the nginx Deployment that occurred now.
+	Record(deployment types.NamespacedName, kind graph.DataPlaneFailureKind, message string)
+}
+
+// Handler handles the syslog messages in which NGINX reports the exits of its worker processes,
+// and records them as failures.
+type Handler struct {
+	recorder Recorder
+	// deployments maps the syslog tags to the nginx Deployments.
+	deployments *syslog.Tags[types.NamespacedName]
+	logger      logr.Logger
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(logger logr.Logger, recorder Recorder) *Handler {
+	return &Handler{
+		recorder:    recorder,
+		deployments: syslog.NewTags[types.NamespacedName](SyslogTagPrefix),
+		logger:      logger,
+	}
+}
+
+// Register registers the nginx Deployment and returns the syslog tag with which its NGINX reports the exits
+// of its worker processes.
+func (h *Handler) Register(deployment types.NamespacedName) string {
+	return h.deployments.Register(deployment.String(), deployment)
+}
+
+// SyslogTag returns the syslog tag of the nginx Deployment.
+func SyslogTag(deployment types.NamespacedName) string {
+	return syslog.HashedTag(SyslogTagPrefix, deployment.String())
+}
+
+// Handle records the exit of a worker process that NGINX reports in the syslog message. The message is ignored
+// if it doesn't come from a Pod of the nginx Deployment of its tag.
+func (h *Handler) Handle(source syslog.Source, msg []byte) {
+	tag, kind, message, err := ParseMessage(msg)
+	if err != nil {
+		h.logger.V(1).Info("Ignoring the syslog message", "error", err.Error())
+		return
+	}
+
+	deployment, exists := h.deployments.Lookup(tag)
+	if !exists {
+		h.logger.V(1).Info("Ignoring the syslog message of an unknown nginx Deployment", "tag", tag)
+		return
+	}
+
+	if deployment != source.Deployment {
+		h.logger.V(1).Info(
+			"Ignoring the syslog message of another nginx Deployment",
+			"deployment", deployment.String(),
+			"source", source.Deployment.String(),
+		)
+		return
+	}
+
+	h.recorder.Record(deployment, kind, message)
+}
+
+// ParseMessage parses a syslog message in which NGINX reports the exit of a process on a signal, and returns
+// the syslog tag, the kind of the failure, and the description of the exit. A process that was killed
+// with SIGKILL is reported as an OOM kill.
+func ParseMessage(msg []byte) (tag string, kind graph.DataPlaneFailureKind, message string, err error) {
+	tag, payload, err := syslog.CutTag(msg, SyslogTagPrefix)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	match := exitOnSignal.FindSubmatch(payload)
+	if match == nil {
+		return "", "", "", errors.New("message is not an exit of a process on a signal")
+	}
+
+	kind = graph.DataPlaneFailureWorkerCrash
+	if string(match[1]) == sigkill {
+		kind = graph.DataPlaneFailureOOMKill
+	}
+
+	return tag, kind, string(bytes.TrimSpace(match[0])), nil
+}
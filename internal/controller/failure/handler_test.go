@@ -0,0 +1,152 @@
+package failure
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/syslog"
+)
+
+type recordedFailure struct {
+	deployment types.NamespacedName
+	kind       graph.DataPlaneFailureKind
+	message    string
+}
+
+type fakeRecorder struct {
+	failures []recordedFailure
+}
+
+func (f *fakeRecorder) Record(deployment types.NamespacedName, kind graph.DataPlaneFailureKind, message string) {
+	f.failures = append(f.failures, recordedFailure{deployment: deployment, kind: kind, message: message})
+}
+
+func TestSyslogTag(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}
+
+	tag := SyslogTag(deployment)
+	g.Expect(tag).To(HavePrefix(SyslogTagPrefix))
+	g.Expect(tag).To(MatchRegexp(`^[a-z0-9_]+$`))
+	// NGINX limits the syslog tags to 32 characters
+	g.Expect(len(tag)).To(BeNumerically("<=", 32))
+
+	g.Expect(SyslogTag(deployment)).To(Equal(tag))
+	g.Expect(SyslogTag(types.NamespacedName{Namespace: "test", Name: "other-nginx"})).ToNot(Equal(tag))
+}
+
+func TestParseMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		msg        string
+		expTag     string
+		expKind    graph.DataPlaneFailureKind
+		expMessage string
+		expErr     bool
+	}{
+		{
+			name: "worker crash",
+			msg: "<161>Oct 17 10:00:00 ngf_fail_6c62272e07bb0142: 2026/10/17 10:00:00 [alert] 1#1: " +
+				"worker process 21 exited on signal 11 (core dumped)",
+			expTag:     "ngf_fail_6c62272e07bb0142",
+			expKind:    graph.DataPlaneFailureWorkerCrash,
+			expMessage: "worker process 21 exited on signal 11 (core dumped)",
+		},
+		{
+			name: "worker killed",
+			msg: "<161>Oct 17 10:00:00 ngf_fail_6c62272e07bb0142: 2026/10/17 10:00:00 [alert] 1#1: " +
+				"worker process 22 exited on signal 9\n",
+			expTag:     "ngf_fail_6c62272e07bb0142",
+			expKind:    graph.DataPlaneFailureOOMKill,
+			expMessage: "worker process 22 exited on signal 9",
+		},
+		{
+			name: "cache manager crash",
+			msg: "<161>Oct 17 10:00:00 ngf_fail_6c62272e07bb0142: 2026/10/17 10:00:00 [alert] 1#1: " +
+				"cache manager process 23 exited on signal 6",
+			expTag:     "ngf_fail_6c62272e07bb0142",
+			expKind:    graph.DataPlaneFailureWorkerCrash,
+			expMessage: "cache manager process 23 exited on signal 6",
+		},
+		{
+			name: "other alert",
+			msg: "<161>Oct 17 10:00:00 ngf_fail_6c62272e07bb0142: 2026/10/17 10:00:00 [alert] 1#1: " +
+				"could not open error log file",
+			expErr: true,
+		},
+		{
+			name:   "no tag",
+			msg:    "<161>Oct 17 10:00:00 nginx: worker process 21 exited on signal 11",
+			expErr: true,
+		},
+		{
+			name:   "no payload",
+			msg:    "<161>Oct 17 10:00:00 ngf_fail_6c62272e07bb0142",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			tag, kind, message, err := ParseMessage([]byte(test.msg))
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(tag).To(Equal(test.expTag))
+			g.Expect(kind).To(Equal(test.expKind))
+			g.Expect(message).To(Equal(test.expMessage))
+		})
+	}
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	recorder := &fakeRecorder{}
+	handler := NewHandler(logr.Discard(), recorder)
+
+	deployment := types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}
+	tag := handler.Register(deployment)
+	g.Expect(tag).To(Equal(SyslogTag(deployment)))
+
+	otherDeployment := types.NamespacedName{Namespace: "test", Name: "other-nginx"}
+	handler.Register(otherDeployment)
+
+	source := syslog.Source{Deployment: deployment}
+	otherSource := syslog.Source{Deployment: otherDeployment}
+
+	unknownTag := SyslogTag(types.NamespacedName{Namespace: "test", Name: "unknown-nginx"})
+	handler.Handle(source, []byte("<161>Oct 17 10:00:00 "+unknownTag+": 2026/10/17 10:00:00 [alert] 1#1: "+
+		"worker process 21 exited on signal 11"))
+	handler.Handle(source, []byte("<161>Oct 17 10:00:00 "+tag+": 2026/10/17 10:00:00 [alert] 1#1: "+
+		"could not open error log file"))
+	handler.Handle(otherSource, []byte("<161>Oct 17 10:00:00 "+tag+": 2026/10/17 10:00:00 [alert] 1#1: "+
+		"worker process 21 exited on signal 9"))
+	handler.Handle(source, []byte("<161>Oct 17 10:00:00 "+tag+": 2026/10/17 10:00:00 [alert] 1#1: "+
+		"worker process 21 exited on signal 11"))
+
+	// the failures of the unknown nginx Deployments, the failures reported by the Pods of other nginx Deployments,
+	// and the other alerts are ignored
+	g.Expect(recorder.failures).To(Equal([]recordedFailure{
+		{
+			deployment: deployment,
+			kind:       graph.DataPlaneFailureWorkerCrash,
+			message:    "worker process 21 exited on signal 11",
+		},
+	}))
+}
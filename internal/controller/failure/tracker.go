@@ -0,0 +1,247 @@
+package failure
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
+)
+
+//go:generate go tool counterfeiter -generate
+
+// DefaultWindow is the default duration for which a failure is recent.
+const DefaultWindow = 15 * time.Minute
+
+//counterfeiter:generate . MetricsCollector
+
+// MetricsCollector is an interface for the metrics of the failures of the NGINX data plane.
+type MetricsCollector interface {
+	// ObserveDataPlaneFailure records a failure of the kind of the NGINX data plane of the Gateway.
+	ObserveDataPlaneFailure(gateway, kind string)
+}
+
+// failures are the recent failures of one kind of an nginx Deployment.
+type failures struct {
+	latest  time.Time
+	message string
+	count   int
+}
+
+// Tracker records the failures of the NGINX data plane of the Gateways.
+type Tracker struct {
+	collector   MetricsCollector
+	statusQueue *status.Queue
+	// failures holds the recent failures of every nginx Deployment by kind.
+	failures map[types.NamespacedName]map[graph.DataPlaneFailureKind]*failures
+	// seen holds the IDs of the failures that were recorded with RecordOnce, and the times at which they occurred.
+	seen map[string]time.Time
+	// timers hold the timers that update the status of the Gateway of every nginx Deployment when
+	// the failures are no longer recent.
+	timers           map[types.NamespacedName]*time.Timer
+	now              func() time.Time
+	gatewayClassName string
+	window           time.Duration
+	lock             sync.Mutex
+}
+
+// NewTracker creates a new Tracker. A failure is recent for the window after it occurred.
+// The nginx Deployments are named after their Gateway and the GatewayClass.
+func NewTracker(
+	collector MetricsCollector,
+	statusQueue *status.Queue,
+	gatewayClassName string,
+	window time.Duration,
+) *Tracker {
+	return &Tracker{
+		collector:        collector,
+		statusQueue:      statusQueue,
+		failures:         make(map[types.NamespacedName]map[graph.DataPlaneFailureKind]*failures),
+		seen:             make(map[string]time.Time),
+		timers:           make(map[types.NamespacedName]*time.Timer),
+		now:              time.Now,
+		gatewayClassName: gatewayClassName,
+		window:           window,
+	}
+}
+
+// Record records a failure of the kind of the nginx Deployment that occurred now.
+func (t *Tracker) Record(deployment types.NamespacedName, kind graph.DataPlaneFailureKind, message string) {
+	t.record(deployment, kind, message, t.now())
+}
+
+// RecordOnce records a failure of the kind of the nginx Deployment that is identified by the id and occurred
+// at the time. The failure is ignored if it was already recorded, or if it is no longer recent.
+func (t *Tracker) RecordOnce(
+	deployment types.NamespacedName,
+	kind graph.DataPlaneFailureKind,
+	id string,
+	message string,
+	occurred time.Time,
+) {
+	now := t.now()
+	if now.Sub(occurred) >= t.window {
+		return
+	}
+
+	t.lock.Lock()
+	// the failures that are no longer recent are ignored anyway, so their IDs are forgotten
+	for seenID, seenOccurred := range t.seen {
+		if now.Sub(seenOccurred) >= t.window {
+			delete(t.seen, seenID)
+		}
+	}
+
+	if _, exists := t.seen[id]; exists {
+		t.lock.Unlock()
+		return
+	}
+	t.seen[id] = occurred
+	t.lock.Unlock()
+
+	t.record(deployment, kind, message, occurred)
+}
+
+func (t *Tracker) record(
+	deployment types.NamespacedName,
+	kind graph.DataPlaneFailureKind,
+	message string,
+	occurred time.Time,
+) {
+	t.lock.Lock()
+
+	byKind, exists := t.failures[deployment]
+	if !exists {
+		byKind = make(map[graph.DataPlaneFailureKind]*failures)
+		t.failures[deployment] = byKind
+	}
+
+	// the count starts again once the failures of the kind are no longer recent
+	f, exists := byKind[kind]
+	if !exists || t.now().Sub(f.latest) >= t.window {
+		f = &failures{}
+		byKind[kind] = f
+	}
+
+	f.count++
+	if !occurred.Before(f.latest) {
+		f.latest = occurred
+		f.message = message
+	}
+
+	t.scheduleExpiry(deployment)
+
+	t.lock.Unlock()
+
+	t.collector.ObserveDataPlaneFailure(t.gatewayName(deployment), string(kind))
+	t.enqueueStatusUpdate(deployment)
+}
+
+// Recent returns the recent failures of the nginx Deployment, the latest first.
+func (t *Tracker) Recent(deployment types.NamespacedName) []graph.DataPlaneFailure {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+
+	type recent struct {
+		latest  time.Time
+		failure graph.DataPlaneFailure
+	}
+
+	var recents []recent
+	for kind, f := range t.failures[deployment] {
+		if now.Sub(f.latest) >= t.window {
+			continue
+		}
+
+		recents = append(recents, recent{
+			latest: f.latest,
+			failure: graph.DataPlaneFailure{
+				Kind:    kind,
+				Message: f.message,
+				Count:   f.count,
+			},
+		})
+	}
+
+	slices.SortFunc(recents, func(a, b recent) int {
+		if c := b.latest.Compare(a.latest); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.failure.Kind), string(b.failure.Kind))
+	})
+
+	result := make([]graph.DataPlaneFailure, 0, len(recents))
+	for _, r := range recents {
+		result = append(result, r.failure)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// expire removes the failures of the nginx Deployment that are no longer recent, and updates the status
+// of its Gateway.
+func (t *Tracker) expire(deployment types.NamespacedName) {
+	t.lock.Lock()
+
+	now := t.now()
+	for kind, f := range t.failures[deployment] {
+		if now.Sub(f.latest) >= t.window {
+			delete(t.failures[deployment], kind)
+		}
+	}
+
+	if len(t.failures[deployment]) == 0 {
+		delete(t.failures, deployment)
+		delete(t.timers, deployment)
+	} else {
+		t.scheduleExpiry(deployment)
+	}
+
+	t.lock.Unlock()
+
+	t.enqueueStatusUpdate(deployment)
+}
+
+// scheduleExpiry schedules the update of the status of the Gateway of the nginx Deployment for the time when
+// the latest failure is no longer recent, so that the condition is removed. Must be called with the lock held.
+func (t *Tracker) scheduleExpiry(deployment types.NamespacedName) {
+	var latest time.Time
+	for _, f := range t.failures[deployment] {
+		if f.latest.After(latest) {
+			latest = f.latest
+		}
+	}
+
+	expiry := t.window - t.now().Sub(latest)
+	if timer, exists := t.timers[deployment]; exists {
+		timer.Reset(expiry)
+		return
+	}
+
+	t.timers[deployment] = time.AfterFunc(expiry, func() { t.expire(deployment) })
+}
+
+func (t *Tracker) enqueueStatusUpdate(deployment types.NamespacedName) {
+	t.statusQueue.Enqueue(&status.QueueObject{
+		UpdateType: status.UpdateGateway,
+		Deployment: deployment,
+	})
+}
+
+// gatewayName returns the namespaced name of the Gateway of the nginx Deployment.
+func (t *Tracker) gatewayName(deployment types.NamespacedName) string {
+	return types.NamespacedName{
+		Namespace: deployment.Namespace,
+		Name:      strings.TrimSuffix(deployment.Name, "-"+t.gatewayClassName),
+	}.String()
+}
@@ -0,0 +1,144 @@
+package failure
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
+)
+
+type observation struct {
+	gateway string
+	kind    string
+}
+
+type fakeCollector struct {
+	observations []observation
+	lock         sync.Mutex
+}
+
+func (f *fakeCollector) ObserveDataPlaneFailure(gateway, kind string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.observations = append(f.observations, observation{gateway: gateway, kind: kind})
+}
+
+func (f *fakeCollector) getObservations() []observation {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.observations
+}
+
+func TestTracker_Record(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}
+	collector := &fakeCollector{}
+	queue := status.NewQueue()
+
+	now := time.Now()
+	tracker := NewTracker(collector, queue, "nginx", time.Hour)
+	tracker.now = func() time.Time { return now }
+
+	tracker.Record(deployment, graph.DataPlaneFailureWorkerCrash, "worker process 20 exited on signal 11")
+	now = now.Add(time.Minute)
+	tracker.Record(deployment, graph.DataPlaneFailureWorkerCrash, "worker process 21 exited on signal 6")
+	now = now.Add(time.Minute)
+	tracker.Record(deployment, graph.DataPlaneFailureReloadFailure, "failed to reload")
+
+	g.Expect(tracker.Recent(deployment)).To(Equal([]graph.DataPlaneFailure{
+		{
+			Kind:    graph.DataPlaneFailureReloadFailure,
+			Message: "failed to reload",
+			Count:   1,
+		},
+		{
+			Kind:    graph.DataPlaneFailureWorkerCrash,
+			Message: "worker process 21 exited on signal 6",
+			Count:   2,
+		},
+	}))
+	g.Expect(tracker.Recent(types.NamespacedName{Namespace: "test", Name: "other-nginx"})).To(BeNil())
+
+	g.Expect(collector.getObservations()).To(Equal([]observation{
+		{gateway: "test/gateway", kind: "worker_crash"},
+		{gateway: "test/gateway", kind: "worker_crash"},
+		{gateway: "test/gateway", kind: "reload_failure"},
+	}))
+
+	// every failure updates the status of the Gateway
+	for range 3 {
+		item := queue.Dequeue(t.Context())
+		g.Expect(item.UpdateType).To(BeEquivalentTo(status.UpdateGateway))
+		g.Expect(item.Deployment).To(Equal(deployment))
+	}
+
+	// the worker crashes are no longer recent, and their count starts again
+	now = now.Add(time.Hour - time.Minute)
+	g.Expect(tracker.Recent(deployment)).To(Equal([]graph.DataPlaneFailure{
+		{
+			Kind:    graph.DataPlaneFailureReloadFailure,
+			Message: "failed to reload",
+			Count:   1,
+		},
+	}))
+
+	tracker.Record(deployment, graph.DataPlaneFailureWorkerCrash, "worker process 22 exited on signal 11")
+	g.Expect(tracker.Recent(deployment)[0]).To(Equal(graph.DataPlaneFailure{
+		Kind:    graph.DataPlaneFailureWorkerCrash,
+		Message: "worker process 22 exited on signal 11",
+		Count:   1,
+	}))
+}
+
+func TestTracker_RecordOnce(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}
+	collector := &fakeCollector{}
+
+	now := time.Now()
+	tracker := NewTracker(collector, status.NewQueue(), "nginx", time.Hour)
+	tracker.now = func() time.Time { return now }
+
+	tracker.RecordOnce(deployment, graph.DataPlaneFailureOOMKill, "pod-1/1", "OOMKilled", now.Add(-time.Minute))
+	tracker.RecordOnce(deployment, graph.DataPlaneFailureOOMKill, "pod-1/1", "OOMKilled", now.Add(-time.Minute))
+	// the failures that are no longer recent are ignored
+	tracker.RecordOnce(deployment, graph.DataPlaneFailureOOMKill, "pod-2/1", "OOMKilled", now.Add(-2*time.Hour))
+
+	g.Expect(collector.getObservations()).To(HaveLen(1))
+	g.Expect(tracker.Recent(deployment)).To(Equal([]graph.DataPlaneFailure{
+		{
+			Kind:    graph.DataPlaneFailureOOMKill,
+			Message: "OOMKilled",
+			Count:   1,
+		},
+	}))
+}
+
+func TestTracker_Expire(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}
+	queue := status.NewQueue()
+	tracker := NewTracker(&fakeCollector{}, queue, "nginx", 100*time.Millisecond)
+
+	tracker.Record(deployment, graph.DataPlaneFailureWorkerCrash, "worker process 20 exited on signal 11")
+	g.Expect(queue.Dequeue(t.Context()).Deployment).To(Equal(deployment))
+
+	// the status of the Gateway is updated again when the failure is no longer recent
+	item := queue.Dequeue(t.Context())
+	g.Expect(item.UpdateType).To(BeEquivalentTo(status.UpdateGateway))
+	g.Expect(item.Deployment).To(Equal(deployment))
+	g.Expect(tracker.Recent(deployment)).To(BeNil())
+}
@@ -0,0 +1,14 @@
+/*
+Package gatewaytest verifies the assertions of GatewayTests by sending their requests through the data plane
+of their Gateways.
+
+The Verifier sends the request of every assertion to the NGINX Service of the Gateway, and compares the response
+with the expected response of the assertion. The requests carry the token of the Gateway in the X-NGF-Gateway-Test
+header. NGINX responds to such requests with the name of the upstream that served the request in
+the X-NGF-Gateway-Test-Upstream header, from which the Verifier determines the backend that served the request.
+The token keeps the names of the upstreams from the clients of the Gateway. The header is added only to the responses
+to the requests with the token, so the other responses keep the headers that the locations inherit from the server.
+Only the Gateways of the GatewayClass of this NGINX Gateway Fabric are verified; the GatewayTests of the other
+Gateways are reported as not accepted.
+*/
+package gatewaytest
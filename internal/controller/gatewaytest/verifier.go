@@ -0,0 +1,226 @@
+package gatewaytest
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+)
+
+const (
+	// TokenHeader is the request header that carries the token of the Gateway.
+	TokenHeader = "X-NGF-Gateway-Test"
+	// UpstreamHeader is the response header in which NGINX reports the upstream that served the request.
+	UpstreamHeader = "X-NGF-Gateway-Test-Upstream"
+
+	// DefaultTimeout is the default timeout of the requests of the assertions.
+	DefaultTimeout = 5 * time.Second
+
+	defaultPath = "/"
+	defaultPort = 80
+
+	tokenPrefix = "ngf-gateway-test/"
+	// staticBackendSuffix is the suffix of the upstreams of Backends with static endpoints.
+	staticBackendSuffix = "backend"
+)
+
+// ErrGatewayNotAccepted is the error when the Gateway of a GatewayTest belongs to a GatewayClass that is not
+// managed by this NGINX Gateway Fabric.
+var ErrGatewayNotAccepted = errors.New("unsupported GatewayClass")
+
+// Token returns the token of the Gateway, which the requests of the assertions of the GatewayTests carry.
+// The token is derived from the UID of the Gateway, so it changes when the Gateway is recreated.
+func Token(gateway client.Object) string {
+	sum := sha256.Sum256([]byte(tokenPrefix + string(gateway.GetUID())))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Verifier sends the requests of the assertions of GatewayTests through the data plane of their Gateways.
+type Verifier struct {
+	// Timeout is the timeout of every request. If zero, DefaultTimeout is used.
+	Timeout time.Duration
+}
+
+// Verify sends the requests of the assertions of the GatewayTest to the address of the NGINX Service of its Gateway,
+// and returns the results of the assertions in their order. The messages of the results don't change between
+// the verifications of the same responses, so that the status of the GatewayTest is not updated needlessly.
+func (v Verifier) Verify(
+	ctx context.Context,
+	address string,
+	token string,
+	test *ngfAPI.GatewayTest,
+) []ngfAPI.GatewayTestAssertionStatus {
+	results := make([]ngfAPI.GatewayTestAssertionStatus, 0, len(test.Spec.Assertions))
+
+	for _, assertion := range test.Spec.Assertions {
+		results = append(results, v.verifyAssertion(ctx, address, token, test.GetNamespace(), assertion))
+	}
+
+	return results
+}
+
+func (v Verifier) verifyAssertion(
+	ctx context.Context,
+	address string,
+	token string,
+	namespace string,
+	assertion ngfAPI.GatewayTestAssertion,
+) ngfAPI.GatewayTestAssertionStatus {
+	result := ngfAPI.GatewayTestAssertionStatus{Name: assertion.Name}
+
+	resp, err := v.send(ctx, address, token, assertion.Request)
+	if err != nil {
+		result.Message = fmt.Sprintf("request failed: %v", err)
+		return result
+	}
+	resp.Body.Close()
+
+	upstream := resp.Header.Get(UpstreamHeader)
+	response := describeResponse(resp.StatusCode, upstream)
+
+	expect := assertion.Expect
+	if int32(resp.StatusCode) != expect.StatusCode { //nolint:gosec // status codes fit in int32
+		result.Message = fmt.Sprintf("expected status code %d, got %s", expect.StatusCode, response)
+		return result
+	}
+
+	if expect.Backend != nil {
+		backendNamespace := namespace
+		if expect.Backend.Namespace != nil {
+			backendNamespace = *expect.Backend.Namespace
+		}
+
+		if !backendMatches(*expect.Backend, backendNamespace, upstream) {
+			result.Message = fmt.Sprintf(
+				"expected backend %s, got %s",
+				describeBackend(*expect.Backend, backendNamespace),
+				response,
+			)
+			return result
+		}
+	}
+
+	result.Passed = true
+	result.Message = response
+
+	return result
+}
+
+func (v Verifier) send(
+	ctx context.Context,
+	address string,
+	token string,
+	req ngfAPI.GatewayTestRequest,
+) (*http.Response, error) {
+	method := http.MethodGet
+	if req.Method != nil {
+		method = string(*req.Method)
+	}
+
+	path := defaultPath
+	if req.Path != nil {
+		path = *req.Path
+	}
+
+	port := int32(defaultPort)
+	if req.Port != nil {
+		port = *req.Port
+	}
+
+	scheme := "http"
+	if req.Scheme != nil && *req.Scheme == ngfAPI.GatewayTestSchemeHTTPS {
+		scheme = "https"
+	}
+
+	timeout := v.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	// the requests are sent to the NGINX Service of the Gateway regardless of the host of the request
+	target := net.JoinHostPort(address, strconv.Itoa(int(port)))
+	dialer := &net.Dialer{}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, target)
+			},
+			TLSClientConfig: &tls.Config{
+				ServerName: req.Host,
+				MinVersion: tls.VersionTLS12,
+				// the GatewayTest verifies the routing of the request, not the certificate of the listener
+				InsecureSkipVerify: true, //nolint:gosec // see above
+			},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Timeout: timeout,
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s://%s%s", scheme, req.Host, path), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range req.Headers {
+		httpReq.Header.Set(h.Name, h.Value)
+	}
+	httpReq.Header.Set(TokenHeader, token)
+
+	return httpClient.Do(httpReq)
+}
+
+// backendMatches returns whether the upstream is an upstream of the backend. The upstreams are named
+// <namespace>_<name>_<port> for Services and <namespace>_<name>_backend for Backends, optionally followed by
+// the index of the session persistence. The names of Kubernetes resources can't contain underscores, so the parts
+// of the names of the upstreams are unambiguous.
+func backendMatches(backend ngfAPI.GatewayTestBackend, namespace, upstream string) bool {
+	parts := strings.Split(upstream, "_")
+	if len(parts) < 3 || parts[0] != namespace || parts[1] != backend.Name {
+		return false
+	}
+
+	if backend.Kind != nil && *backend.Kind == ngfAPI.GatewayTestBackendKindBackend {
+		return parts[2] == staticBackendSuffix
+	}
+
+	if parts[2] == staticBackendSuffix {
+		return false
+	}
+
+	return backend.Port == nil || parts[2] == strconv.Itoa(int(*backend.Port))
+}
+
+func describeResponse(statusCode int, upstream string) string {
+	if upstream == "" {
+		return fmt.Sprintf("status code %d", statusCode)
+	}
+
+	return fmt.Sprintf("status code %d from upstream %s", statusCode, upstream)
+}
+
+func describeBackend(backend ngfAPI.GatewayTestBackend, namespace string) string {
+	if backend.Kind != nil && *backend.Kind == ngfAPI.GatewayTestBackendKindBackend {
+		return fmt.Sprintf("Backend %s/%s", namespace, backend.Name)
+	}
+
+	if backend.Port == nil {
+		return fmt.Sprintf("Service %s/%s", namespace, backend.Name)
+	}
+
+	return fmt.Sprintf("Service %s/%s port %d", namespace, backend.Name, *backend.Port)
+}
@@ -0,0 +1,233 @@
+package gatewaytest
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+const testToken = "0123456789abcdef"
+
+// newGateway returns a server that simulates the data plane of a Gateway, which routes the requests
+// for foo.example.com to the upstreams of the paths.
+func newGateway(t *testing.T, tls bool) (address string, port int32) {
+	t.Helper()
+
+	upstreams := map[string]string{
+		"/":        "test_foo_80",
+		"/backend": "test_static_backend",
+		"/sticky":  "test_foo_80_0",
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "foo.example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+
+		upstream, ok := upstreams[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Header.Get(TokenHeader) == testToken {
+			w.Header().Set(UpstreamHeader, upstream)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	var server *httptest.Server
+	if tls {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	t.Cleanup(server.Close)
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return host, int32(p) //nolint:gosec // ports fit in int32
+}
+
+func TestToken(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gw := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{UID: "uid-1"}}
+	recreated := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{UID: "uid-2"}}
+
+	g.Expect(Token(gw)).To(HaveLen(32))
+	g.Expect(Token(gw)).To(Equal(Token(gw)))
+	g.Expect(Token(gw)).ToNot(Equal(Token(recreated)))
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	address, port := newGateway(t, false)
+	// both servers listen on the loopback address
+	_, tlsPort := newGateway(t, true)
+
+	request := func(path string) ngfAPI.GatewayTestRequest {
+		return ngfAPI.GatewayTestRequest{
+			Host: "foo.example.com",
+			Path: helpers.GetPointer(path),
+			Port: helpers.GetPointer(port),
+		}
+	}
+
+	serviceBackend := func(name string, port *int32) *ngfAPI.GatewayTestBackend {
+		return &ngfAPI.GatewayTestBackend{Name: name, Port: port}
+	}
+
+	test := &ngfAPI.GatewayTest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway-test"},
+		Spec: ngfAPI.GatewayTestSpec{
+			GatewayName: "gateway",
+			Assertions: []ngfAPI.GatewayTestAssertion{
+				{
+					Name:    "status-code",
+					Request: request("/"),
+					Expect:  ngfAPI.GatewayTestExpectation{StatusCode: http.StatusOK},
+				},
+				{
+					Name:    "service",
+					Request: request("/"),
+					Expect: ngfAPI.GatewayTestExpectation{
+						StatusCode: http.StatusOK,
+						Backend:    serviceBackend("foo", helpers.GetPointer[int32](80)),
+					},
+				},
+				{
+					Name:    "service-any-port",
+					Request: request("/sticky"),
+					Expect: ngfAPI.GatewayTestExpectation{
+						StatusCode: http.StatusOK,
+						Backend:    serviceBackend("foo", nil),
+					},
+				},
+				{
+					Name:    "static-backend",
+					Request: request("/backend"),
+					Expect: ngfAPI.GatewayTestExpectation{
+						StatusCode: http.StatusOK,
+						Backend: &ngfAPI.GatewayTestBackend{
+							Kind:      helpers.GetPointer(ngfAPI.GatewayTestBackendKindBackend),
+							Namespace: helpers.GetPointer("test"),
+							Name:      "static",
+						},
+					},
+				},
+				{
+					Name:    "redirect",
+					Request: request("/redirect"),
+					Expect:  ngfAPI.GatewayTestExpectation{StatusCode: http.StatusFound},
+				},
+				{
+					Name: "https",
+					Request: ngfAPI.GatewayTestRequest{
+						Host:   "foo.example.com",
+						Port:   helpers.GetPointer(tlsPort),
+						Scheme: helpers.GetPointer(ngfAPI.GatewayTestSchemeHTTPS),
+					},
+					Expect: ngfAPI.GatewayTestExpectation{
+						StatusCode: http.StatusOK,
+						Backend:    serviceBackend("foo", nil),
+					},
+				},
+				{
+					Name:    "wrong-status-code",
+					Request: request("/missing"),
+					Expect:  ngfAPI.GatewayTestExpectation{StatusCode: http.StatusOK},
+				},
+				{
+					Name:    "wrong-port",
+					Request: request("/"),
+					Expect: ngfAPI.GatewayTestExpectation{
+						StatusCode: http.StatusOK,
+						Backend:    serviceBackend("foo", helpers.GetPointer[int32](8080)),
+					},
+				},
+				{
+					Name:    "wrong-kind",
+					Request: request("/backend"),
+					Expect: ngfAPI.GatewayTestExpectation{
+						StatusCode: http.StatusOK,
+						Backend:    serviceBackend("static", nil),
+					},
+				},
+			},
+		},
+	}
+
+	results := Verifier{}.Verify(t.Context(), address, testToken, test)
+	g.Expect(results).To(Equal([]ngfAPI.GatewayTestAssertionStatus{
+		{Name: "status-code", Passed: true, Message: "status code 200 from upstream test_foo_80"},
+		{Name: "service", Passed: true, Message: "status code 200 from upstream test_foo_80"},
+		{Name: "service-any-port", Passed: true, Message: "status code 200 from upstream test_foo_80_0"},
+		{Name: "static-backend", Passed: true, Message: "status code 200 from upstream test_static_backend"},
+		{Name: "redirect", Passed: true, Message: "status code 302"},
+		{Name: "https", Passed: true, Message: "status code 200 from upstream test_foo_80"},
+		{Name: "wrong-status-code", Message: "expected status code 200, got status code 404"},
+		{
+			Name:    "wrong-port",
+			Message: "expected backend Service test/foo port 8080, got status code 200 from upstream test_foo_80",
+		},
+		{
+			Name:    "wrong-kind",
+			Message: "expected backend Service test/static, got status code 200 from upstream test_static_backend",
+		},
+	}))
+}
+
+func TestVerifier_VerifyRequestFailed(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	address, port := newGateway(t, false)
+
+	test := &ngfAPI.GatewayTest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway-test"},
+		Spec: ngfAPI.GatewayTestSpec{
+			Assertions: []ngfAPI.GatewayTestAssertion{
+				{
+					Name: "https-to-http-listener",
+					Request: ngfAPI.GatewayTestRequest{
+						Host:   "foo.example.com",
+						Port:   helpers.GetPointer(port),
+						Scheme: helpers.GetPointer(ngfAPI.GatewayTestSchemeHTTPS),
+					},
+					Expect: ngfAPI.GatewayTestExpectation{StatusCode: http.StatusOK},
+				},
+			},
+		},
+	}
+
+	results := Verifier{}.Verify(t.Context(), address, testToken, test)
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Passed).To(BeFalse())
+	g.Expect(results[0].Message).To(HavePrefix("request failed: "))
+}
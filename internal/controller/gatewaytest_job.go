@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/gatewaytest"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/runnables"
+)
+
+const (
+	// gatewayTestPeriod is the period of the job that verifies the GatewayTests. Every GatewayTest is verified
+	// at its own interval, so the period is the precision of the intervals.
+	gatewayTestPeriod = 10 * time.Second
+	// defaultGatewayTestInterval is the interval of a GatewayTest that doesn't set it.
+	defaultGatewayTestInterval = 60 * time.Second
+)
+
+// gatewayTestRun is the latest verification of a GatewayTest.
+type gatewayTestRun struct {
+	time       time.Time
+	generation int64
+}
+
+// gatewayTestRunner verifies the GatewayTests that are due, and updates their statuses.
+type gatewayTestRunner struct {
+	k8sClient        client.Reader
+	statusUpdater    *status.Updater
+	now              func() time.Time
+	runs             map[types.UID]gatewayTestRun
+	logger           logr.Logger
+	gatewayClassName string
+	verifier         gatewaytest.Verifier
+}
+
+// newGatewayTestJob creates a job that periodically verifies the assertions of the GatewayTests through the data
+// plane of their Gateways, and reports the results in the statuses of the GatewayTests.
+// Only the leader verifies the GatewayTests, so that the statuses are not updated by every replica.
+func newGatewayTestJob(
+	logger logr.Logger,
+	k8sClient client.Reader,
+	statusUpdater *status.Updater,
+	gatewayClassName string,
+	readyCh <-chan struct{},
+	period time.Duration,
+) *runnables.Leader {
+	runner := &gatewayTestRunner{
+		k8sClient:        k8sClient,
+		statusUpdater:    statusUpdater,
+		now:              time.Now,
+		runs:             make(map[types.UID]gatewayTestRun),
+		logger:           logger,
+		gatewayClassName: gatewayClassName,
+	}
+
+	return &runnables.Leader{
+		Runnable: runnables.NewCronJob(
+			runnables.CronJobConfig{
+				Worker:  runner.run,
+				Logger:  logger,
+				Period:  period,
+				ReadyCh: readyCh,
+			},
+		),
+	}
+}
+
+func (r *gatewayTestRunner) run(ctx context.Context) {
+	var gatewayTests ngfAPI.GatewayTestList
+	if err := r.k8sClient.List(ctx, &gatewayTests); err != nil {
+		r.logger.Error(err, "error listing GatewayTests")
+		return
+	}
+
+	now := r.now()
+	current := make(map[types.UID]struct{}, len(gatewayTests.Items))
+	reqs := make([]status.UpdateRequest, 0, len(gatewayTests.Items))
+
+	for i := range gatewayTests.Items {
+		gatewayTest := &gatewayTests.Items[i]
+		current[gatewayTest.GetUID()] = struct{}{}
+
+		if !r.due(gatewayTest, now) {
+			continue
+		}
+
+		r.runs[gatewayTest.GetUID()] = gatewayTestRun{time: now, generation: gatewayTest.GetGeneration()}
+
+		var results []ngfAPI.GatewayTestAssertionStatus
+		address, token, err := r.getGateway(ctx, gatewayTest)
+		if err == nil {
+			results = r.verifier.Verify(ctx, address, token, gatewayTest)
+		}
+
+		reqs = append(reqs, status.PrepareGatewayTestStatus(gatewayTest, metav1.NewTime(now), results, err))
+	}
+
+	for uid := range r.runs {
+		if _, exists := current[uid]; !exists {
+			delete(r.runs, uid)
+		}
+	}
+
+	r.statusUpdater.Update(ctx, reqs...)
+}
+
+// due returns whether the GatewayTest is due for a verification: it was never verified, its spec changed, or its
+// interval passed since its latest verification.
+func (r *gatewayTestRunner) due(gatewayTest *ngfAPI.GatewayTest, now time.Time) bool {
+	run, exists := r.runs[gatewayTest.GetUID()]
+	if !exists || run.generation != gatewayTest.GetGeneration() {
+		return true
+	}
+
+	interval := defaultGatewayTestInterval
+	if gatewayTest.Spec.Interval != nil {
+		if d, err := time.ParseDuration(string(*gatewayTest.Spec.Interval)); err == nil && d > 0 {
+			interval = d
+		}
+	}
+
+	return now.Sub(run.time) >= interval
+}
+
+// getGateway returns the address of the NGINX Service of the Gateway of the GatewayTest, and the token that
+// the requests to the Gateway carry. The requests are not sent to the Gateways of the other GatewayClasses,
+// because their Services are not the data planes of this NGINX Gateway Fabric.
+func (r *gatewayTestRunner) getGateway(
+	ctx context.Context,
+	gatewayTest *ngfAPI.GatewayTest,
+) (string, string, error) {
+	gwKey := types.NamespacedName{Namespace: gatewayTest.GetNamespace(), Name: gatewayTest.Spec.GatewayName}
+
+	var gw gatewayv1.Gateway
+	if err := r.k8sClient.Get(ctx, gwKey, &gw); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", "", fmt.Errorf("cannot find Gateway %s", gwKey)
+		}
+		return "", "", fmt.Errorf("error getting Gateway %s: %w", gwKey, err)
+	}
+
+	if string(gw.Spec.GatewayClassName) != r.gatewayClassName {
+		return "", "", fmt.Errorf(
+			"%w: Gateway %s belongs to GatewayClass %q, which is not managed by this controller",
+			gatewaytest.ErrGatewayNotAccepted,
+			gwKey,
+			gw.Spec.GatewayClassName,
+		)
+	}
+
+	svcKey := types.NamespacedName{
+		Namespace: gw.GetNamespace(),
+		Name:      controller.CreateNginxResourceName(gw.GetName(), string(gw.Spec.GatewayClassName)),
+	}
+
+	var svc v1.Service
+	if err := r.k8sClient.Get(ctx, svcKey, &svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", "", fmt.Errorf("cannot find NGINX Service %s of Gateway %s", svcKey, gwKey)
+		}
+		return "", "", fmt.Errorf("error getting NGINX Service %s of Gateway %s: %w", svcKey, gwKey, err)
+	}
+
+	if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == v1.ClusterIPNone {
+		return "", "", fmt.Errorf("NGINX Service %s of Gateway %s doesn't have a cluster IP", svcKey, gwKey)
+	}
+
+	return svc.Spec.ClusterIP, gatewaytest.Token(&gw), nil
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/gatewaytest"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+func TestGatewayTestJob(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway", UID: "gateway-uid"},
+		Spec:       gatewayv1.GatewaySpec{GatewayClassName: "nginx"},
+	}
+
+	// the server simulates the data plane of the Gateway
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(gatewaytest.TokenHeader) == gatewaytest.Token(gw) {
+			w.Header().Set(gatewaytest.UpstreamHeader, "test_foo_80")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	address, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	g.Expect(err).ToNot(HaveOccurred())
+	port, err := strconv.Atoi(portStr)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway-nginx"},
+		Spec:       v1.ServiceSpec{ClusterIP: address},
+	}
+
+	gatewayTest := &ngfAPI.GatewayTest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway-test", UID: "test-uid", Generation: 1},
+		Spec: ngfAPI.GatewayTestSpec{
+			Interval:    helpers.GetPointer[ngfAPI.Duration]("1m"),
+			GatewayName: "gateway",
+			Assertions: []ngfAPI.GatewayTestAssertion{
+				{
+					Name: "foo",
+					Request: ngfAPI.GatewayTestRequest{
+						Host: "foo.example.com",
+						Port: helpers.GetPointer(int32(port)), //nolint:gosec // ports fit in int32
+					},
+					Expect: ngfAPI.GatewayTestExpectation{
+						StatusCode: http.StatusOK,
+						Backend:    &ngfAPI.GatewayTestBackend{Name: "foo"},
+					},
+				},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gw, svc, gatewayTest).
+		WithStatusSubresource(&ngfAPI.GatewayTest{}).
+		Build()
+
+	now := time.Now()
+	runner := &gatewayTestRunner{
+		k8sClient:        k8sClient,
+		statusUpdater:    status.NewUpdater(k8sClient, logr.Discard(), nil),
+		now:              func() time.Time { return now },
+		runs:             make(map[types.UID]gatewayTestRun),
+		logger:           logr.Discard(),
+		gatewayClassName: "nginx",
+	}
+
+	getStatus := func() ngfAPI.GatewayTestStatus {
+		var gt ngfAPI.GatewayTest
+		g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(gatewayTest), &gt)).To(Succeed())
+
+		return gt.Status
+	}
+
+	getReason := func() string {
+		conds := getStatus().Conditions
+		g.Expect(conds).To(HaveLen(1))
+
+		return conds[0].Reason
+	}
+
+	// updateSpec simulates a change of the spec of the GatewayTest, so that it is verified again
+	updateSpec := func() {
+		var gt ngfAPI.GatewayTest
+		g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(gatewayTest), &gt)).To(Succeed())
+		gt.Generation++
+		g.Expect(k8sClient.Update(context.Background(), &gt)).To(Succeed())
+	}
+
+	// the GatewayTest is verified through the data plane of the Gateway
+	runner.run(context.Background())
+	g.Expect(getReason()).To(Equal(string(ngfAPI.GatewayTestReasonPassed)))
+	g.Expect(getStatus().Assertions).To(Equal([]ngfAPI.GatewayTestAssertionStatus{
+		{Name: "foo", Passed: true, Message: "status code 200 from upstream test_foo_80"},
+	}))
+
+	// the Gateways of the other GatewayClasses are not verified
+	runner.gatewayClassName = "other"
+	updateSpec()
+
+	runner.run(context.Background())
+	g.Expect(getReason()).To(Equal(string(ngfAPI.GatewayTestReasonGatewayNotAccepted)))
+	g.Expect(getStatus().Conditions[0].Message).To(Equal(
+		`unsupported GatewayClass: Gateway test/gateway belongs to GatewayClass "nginx", ` +
+			"which is not managed by this controller",
+	))
+	g.Expect(getStatus().Assertions).To(BeEmpty())
+
+	runner.gatewayClassName = "nginx"
+	updateSpec()
+
+	runner.run(context.Background())
+	g.Expect(getReason()).To(Equal(string(ngfAPI.GatewayTestReasonPassed)))
+
+	// the GatewayTest is not verified again before its interval passed
+	g.Expect(k8sClient.Delete(context.Background(), gw)).To(Succeed())
+
+	now = now.Add(30 * time.Second)
+	runner.run(context.Background())
+	g.Expect(getReason()).To(Equal(string(ngfAPI.GatewayTestReasonPassed)))
+
+	now = now.Add(30 * time.Second)
+	runner.run(context.Background())
+	g.Expect(getReason()).To(Equal(string(ngfAPI.GatewayTestReasonGatewayNotReady)))
+	g.Expect(getStatus().Conditions[0].Message).To(Equal("cannot find Gateway test/gateway"))
+	g.Expect(getStatus().Assertions).To(BeEmpty())
+
+	// the runs of the deleted GatewayTests are forgotten
+	g.Expect(k8sClient.Delete(context.Background(), gatewayTest)).To(Succeed())
+
+	runner.run(context.Background())
+	g.Expect(runner.runs).To(BeEmpty())
+}
+
+func TestGatewayTestJob_Due(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	now := time.Now()
+	runner := &gatewayTestRunner{
+		runs: map[types.UID]gatewayTestRun{
+			"test-uid": {time: now, generation: 1},
+		},
+	}
+
+	gatewayTest := &ngfAPI.GatewayTest{
+		ObjectMeta: metav1.ObjectMeta{UID: "test-uid", Generation: 1},
+	}
+
+	g.Expect(runner.due(gatewayTest, now.Add(defaultGatewayTestInterval-time.Second))).To(BeFalse())
+	g.Expect(runner.due(gatewayTest, now.Add(defaultGatewayTestInterval))).To(BeTrue())
+
+	gatewayTest.Spec.Interval = helpers.GetPointer[ngfAPI.Duration]("10s")
+	g.Expect(runner.due(gatewayTest, now.Add(10*time.Second))).To(BeTrue())
+
+	// a changed spec is verified immediately
+	gatewayTest.Generation = 2
+	g.Expect(runner.due(gatewayTest, now)).To(BeTrue())
+
+	gatewayTest.UID = "new-uid"
+	gatewayTest.Generation = 1
+	g.Expect(runner.due(gatewayTest, now)).To(BeTrue())
+}
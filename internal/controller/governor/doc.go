@@ -0,0 +1,20 @@
+/*
+Package governor rate-limits the reloads of NGINX, so that the churn of the endpoints of the backends doesn't make
+NGINX reload continuously, which drains the connections of the old worker processes and increases the memory usage
+of the nginx Pods.
+
+The Governor enforces a minimum interval between the reloads of every nginx Deployment. A configuration that arrives
+before the interval passed is deferred, and the deferred configurations are coalesced: when the deferred reload is
+due, the latest configuration is applied. The changes of the configuration are classified by comparing them with the
+last applied configuration:
+
+  - The changes of the certificates, the routes, or any other part of the configuration except the endpoints
+    of the upstreams are priority changes. They are applied once the minimum interval passed since the last reload.
+  - The changes of only the endpoints of the upstreams wait for a multiple of the minimum interval, so that the reloads
+    under endpoint churn leave room for the priority changes. A priority change that arrives while an endpoint
+    change is deferred makes the deferred reload due at the minimum interval. With NGINX Plus, the endpoints are
+    updated through the NGINX Plus API without a reload, so the endpoint changes are never deferred.
+
+The Governor passes every deferral and the age of every deferred reload when it is applied to a MetricsCollector.
+*/
+package governor
@@ -0,0 +1,217 @@
+package governor
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+)
+
+//go:generate go tool counterfeiter -generate
+
+// EndpointIntervalMultiplier is the multiple of the minimum interval between the reloads for which the changes
+// of only the endpoints of the upstreams are deferred.
+const EndpointIntervalMultiplier = 4
+
+// Change is the kind of a change of the nginx configuration.
+type Change string
+
+const (
+	// ChangeNone is the kind of a configuration that is the same as the last applied configuration.
+	ChangeNone Change = "none"
+	// ChangePriority is the kind of a change of the certificates, the routes, or any other part of
+	// the configuration except the endpoints of the upstreams.
+	ChangePriority Change = "priority"
+	// ChangeEndpoints is the kind of a change of only the endpoints of the upstreams.
+	ChangeEndpoints Change = "endpoints"
+)
+
+//counterfeiter:generate . MetricsCollector
+
+// MetricsCollector is an interface for the metrics of the Governor.
+type MetricsCollector interface {
+	// ObserveReloadDeferred records a reload of the kind of change that was deferred.
+	ObserveReloadDeferred(change string)
+	// ObserveDeferredReloadAge records the time for which a reload of the kind of change was deferred
+	// before it was applied.
+	ObserveDeferredReloadAge(change string, age time.Duration)
+}
+
+// Decision is the decision of the Governor about a configuration of an nginx Deployment.
+type Decision struct {
+	// Change is the kind of the change of the configuration.
+	Change Change
+	// Apply is true if the configuration is applied now, and false if it is deferred.
+	Apply bool
+}
+
+// Config is the configuration of the Governor.
+type Config struct {
+	// Collector collects the metrics of the deferred reloads.
+	Collector MetricsCollector
+	// Notify is called when the deferred reload of an nginx Deployment is due. It must not block.
+	Notify func(deployment types.NamespacedName)
+	// MinInterval is the minimum interval between the reloads of an nginx Deployment.
+	MinInterval time.Duration
+	// Plus indicates whether the endpoints are updated through the NGINX Plus API.
+	Plus bool
+}
+
+// deferral is a deferred reload of an nginx Deployment.
+type deferral struct {
+	// since is the time at which the reload was deferred first.
+	since time.Time
+}
+
+// Governor rate-limits the reloads of the nginx Deployments.
+type Governor struct {
+	cfg Config
+	// applied holds the last applied configuration of every nginx Deployment.
+	applied map[types.NamespacedName]*dataplane.Configuration
+	// lastReloads hold the times of the last reloads of the nginx Deployments.
+	lastReloads map[types.NamespacedName]time.Time
+	// deferrals hold the deferred reloads of the nginx Deployments.
+	deferrals map[types.NamespacedName]*deferral
+	// timers hold the timers that notify when the deferred reloads are due.
+	timers map[types.NamespacedName]*time.Timer
+	now    func() time.Time
+	lock   sync.Mutex
+}
+
+// New creates a new Governor.
+func New(cfg Config) *Governor {
+	return &Governor{
+		cfg:         cfg,
+		applied:     make(map[types.NamespacedName]*dataplane.Configuration),
+		lastReloads: make(map[types.NamespacedName]time.Time),
+		deferrals:   make(map[types.NamespacedName]*deferral),
+		timers:      make(map[types.NamespacedName]*time.Timer),
+		now:         time.Now,
+	}
+}
+
+// Admit decides whether the configuration of the nginx Deployment is applied now or deferred. When a deferred
+// reload is due, the Notify function of the Config is called, and the latest configuration must be admitted again.
+func (g *Governor) Admit(deployment types.NamespacedName, conf dataplane.Configuration) Decision {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	now := g.now()
+	change := Classify(g.applied[deployment], conf)
+	d := g.deferrals[deployment]
+
+	// the endpoints are updated through the NGINX Plus API, and a configuration that didn't change doesn't
+	// need a reload, so neither is deferred
+	if change == ChangeNone || (change == ChangeEndpoints && g.cfg.Plus) {
+		if change == ChangeNone {
+			g.cancelDeferral(deployment)
+		}
+		return Decision{Change: change, Apply: true}
+	}
+
+	interval := g.cfg.MinInterval
+	if change == ChangeEndpoints {
+		interval *= EndpointIntervalMultiplier
+	}
+
+	lastReload, reloaded := g.lastReloads[deployment]
+	if !reloaded || now.Sub(lastReload) >= interval {
+		if d != nil {
+			g.cfg.Collector.ObserveDeferredReloadAge(string(change), now.Sub(d.since))
+			g.cancelDeferral(deployment)
+		}
+		return Decision{Change: change, Apply: true}
+	}
+
+	if d == nil {
+		d = &deferral{since: now}
+		g.deferrals[deployment] = d
+		g.cfg.Collector.ObserveReloadDeferred(string(change))
+	}
+
+	due := lastReload.Add(interval).Sub(now)
+	if timer, exists := g.timers[deployment]; exists {
+		timer.Reset(due)
+	} else {
+		g.timers[deployment] = time.AfterFunc(due, func() { g.cfg.Notify(deployment) })
+	}
+
+	return Decision{Change: change, Apply: false}
+}
+
+// Applied records the configuration that was applied to the nginx Deployment, and whether applying it
+// reloaded NGINX.
+func (g *Governor) Applied(deployment types.NamespacedName, conf dataplane.Configuration, reloaded bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.applied[deployment] = &conf
+	if reloaded {
+		g.lastReloads[deployment] = g.now()
+	}
+}
+
+// Forget removes the state of the nginx Deployment, for example, when its Gateway was deleted.
+func (g *Governor) Forget(deployment types.NamespacedName) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.cancelDeferral(deployment)
+	delete(g.applied, deployment)
+	delete(g.lastReloads, deployment)
+}
+
+// cancelDeferral removes the deferred reload of the nginx Deployment. Must be called with the lock held.
+func (g *Governor) cancelDeferral(deployment types.NamespacedName) {
+	delete(g.deferrals, deployment)
+
+	if timer, exists := g.timers[deployment]; exists {
+		timer.Stop()
+		delete(g.timers, deployment)
+	}
+}
+
+// Classify returns the kind of the change of the configuration compared with the previous configuration.
+// If the previous configuration is nil, the change is a priority change.
+func Classify(prev *dataplane.Configuration, conf dataplane.Configuration) Change {
+	if prev == nil {
+		return ChangePriority
+	}
+
+	if reflect.DeepEqual(*prev, conf) {
+		return ChangeNone
+	}
+
+	if reflect.DeepEqual(withoutEndpoints(*prev), withoutEndpoints(conf)) {
+		return ChangeEndpoints
+	}
+
+	return ChangePriority
+}
+
+// withoutEndpoints returns a copy of the configuration without the endpoints of its upstreams. The error messages
+// of the upstreams are removed too, because an upstream without endpoints has an error message.
+func withoutEndpoints(conf dataplane.Configuration) dataplane.Configuration {
+	strip := func(upstreams []dataplane.Upstream) []dataplane.Upstream {
+		if upstreams == nil {
+			return nil
+		}
+
+		stripped := make([]dataplane.Upstream, 0, len(upstreams))
+		for _, u := range upstreams {
+			u.Endpoints = nil
+			u.ErrorMsg = ""
+			stripped = append(stripped, u)
+		}
+
+		return stripped
+	}
+
+	conf.Upstreams = strip(conf.Upstreams)
+	conf.StreamUpstreams = strip(conf.StreamUpstreams)
+
+	return conf
+}
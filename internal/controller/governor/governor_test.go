@@ -0,0 +1,245 @@
+package governor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+)
+
+type age struct {
+	change string
+	age    time.Duration
+}
+
+type fakeCollector struct {
+	deferred []string
+	ages     []age
+	lock     sync.Mutex
+}
+
+func (f *fakeCollector) ObserveReloadDeferred(change string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.deferred = append(f.deferred, change)
+}
+
+func (f *fakeCollector) ObserveDeferredReloadAge(change string, a time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.ages = append(f.ages, age{change: change, age: a})
+}
+
+func (f *fakeCollector) getDeferred() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.deferred
+}
+
+func (f *fakeCollector) getAges() []age {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.ages
+}
+
+func newConfiguration(endpointAddress string) dataplane.Configuration {
+	return dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{Hostname: "foo.example.com", Port: 80},
+		},
+		Upstreams: []dataplane.Upstream{
+			{
+				Name:      "test_foo_80",
+				Endpoints: []resolver.Endpoint{{Address: endpointAddress, Port: 8080}},
+			},
+		},
+	}
+}
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	conf := newConfiguration("10.0.0.1")
+
+	routeChanged := newConfiguration("10.0.0.1")
+	routeChanged.HTTPServers[0].Hostname = "bar.example.com"
+
+	noEndpoints := newConfiguration("10.0.0.1")
+	noEndpoints.Upstreams[0].Endpoints = nil
+	noEndpoints.Upstreams[0].ErrorMsg = "no endpoints"
+
+	tests := []struct {
+		prev      *dataplane.Configuration
+		name      string
+		conf      dataplane.Configuration
+		expChange Change
+	}{
+		{
+			name:      "no previous configuration",
+			conf:      conf,
+			expChange: ChangePriority,
+		},
+		{
+			name:      "same configuration",
+			prev:      &conf,
+			conf:      newConfiguration("10.0.0.1"),
+			expChange: ChangeNone,
+		},
+		{
+			name:      "endpoints changed",
+			prev:      &conf,
+			conf:      newConfiguration("10.0.0.2"),
+			expChange: ChangeEndpoints,
+		},
+		{
+			name:      "all endpoints removed",
+			prev:      &conf,
+			conf:      noEndpoints,
+			expChange: ChangeEndpoints,
+		},
+		{
+			name:      "route changed",
+			prev:      &conf,
+			conf:      routeChanged,
+			expChange: ChangePriority,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(Classify(test.prev, test.conf)).To(Equal(test.expChange))
+		})
+	}
+}
+
+func TestGovernor_Admit(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}
+	collector := &fakeCollector{}
+
+	gov := New(Config{
+		Collector:   collector,
+		Notify:      func(types.NamespacedName) {},
+		MinInterval: time.Hour,
+	})
+
+	now := time.Now()
+	gov.now = func() time.Time { return now }
+
+	// the first configuration is applied
+	conf := newConfiguration("10.0.0.1")
+	g.Expect(gov.Admit(deployment, conf)).To(Equal(Decision{Change: ChangePriority, Apply: true}))
+	gov.Applied(deployment, conf, true)
+
+	// the same configuration doesn't need a reload
+	g.Expect(gov.Admit(deployment, conf)).To(Equal(Decision{Change: ChangeNone, Apply: true}))
+
+	// the endpoint changes are deferred for a multiple of the interval
+	now = now.Add(time.Hour)
+	endpointsChanged := newConfiguration("10.0.0.2")
+	g.Expect(gov.Admit(deployment, endpointsChanged)).To(Equal(Decision{Change: ChangeEndpoints, Apply: false}))
+
+	now = now.Add(time.Hour)
+	g.Expect(gov.Admit(deployment, endpointsChanged)).To(Equal(Decision{Change: ChangeEndpoints, Apply: false}))
+	g.Expect(collector.getDeferred()).To(Equal([]string{"endpoints"}))
+
+	// a priority change is applied once the interval passed, together with the deferred endpoint changes
+	routeChanged := newConfiguration("10.0.0.2")
+	routeChanged.HTTPServers[0].Hostname = "bar.example.com"
+	g.Expect(gov.Admit(deployment, routeChanged)).To(Equal(Decision{Change: ChangePriority, Apply: true}))
+	g.Expect(collector.getAges()).To(Equal([]age{{change: "priority", age: time.Hour}}))
+	gov.Applied(deployment, routeChanged, true)
+
+	// a priority change is deferred within the interval
+	now = now.Add(time.Minute)
+	routeChangedAgain := newConfiguration("10.0.0.2")
+	routeChangedAgain.HTTPServers[0].Hostname = "baz.example.com"
+	g.Expect(gov.Admit(deployment, routeChangedAgain)).To(Equal(Decision{Change: ChangePriority, Apply: false}))
+	g.Expect(collector.getDeferred()).To(Equal([]string{"endpoints", "priority"}))
+
+	// reverting to the applied configuration cancels the deferred reload
+	g.Expect(gov.Admit(deployment, routeChanged)).To(Equal(Decision{Change: ChangeNone, Apply: true}))
+	g.Expect(gov.deferrals).To(BeEmpty())
+	g.Expect(gov.timers).To(BeEmpty())
+
+	// the endpoint changes are applied once the multiple of the interval passed
+	now = now.Add(EndpointIntervalMultiplier * time.Hour)
+	endpointsChangedAgain := newConfiguration("10.0.0.3")
+	endpointsChangedAgain.HTTPServers[0].Hostname = "bar.example.com"
+	g.Expect(gov.Admit(deployment, endpointsChangedAgain)).To(Equal(Decision{
+		Change: ChangeEndpoints,
+		Apply:  true,
+	}))
+
+	gov.Forget(deployment)
+	g.Expect(gov.applied).To(BeEmpty())
+	g.Expect(gov.lastReloads).To(BeEmpty())
+}
+
+func TestGovernor_AdmitPlus(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}
+	collector := &fakeCollector{}
+
+	gov := New(Config{
+		Collector:   collector,
+		Notify:      func(types.NamespacedName) {},
+		MinInterval: time.Hour,
+		Plus:        true,
+	})
+
+	conf := newConfiguration("10.0.0.1")
+	g.Expect(gov.Admit(deployment, conf).Apply).To(BeTrue())
+	gov.Applied(deployment, conf, true)
+
+	// the endpoints are updated through the NGINX Plus API without a reload
+	endpointsChanged := newConfiguration("10.0.0.2")
+	g.Expect(gov.Admit(deployment, endpointsChanged)).To(Equal(Decision{Change: ChangeEndpoints, Apply: true}))
+	gov.Applied(deployment, endpointsChanged, false)
+
+	routeChanged := newConfiguration("10.0.0.2")
+	routeChanged.HTTPServers[0].Hostname = "bar.example.com"
+	g.Expect(gov.Admit(deployment, routeChanged)).To(Equal(Decision{Change: ChangePriority, Apply: false}))
+	g.Expect(collector.getDeferred()).To(Equal([]string{"priority"}))
+}
+
+func TestGovernor_Notify(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := types.NamespacedName{Namespace: "test", Name: "gateway-nginx"}
+	notified := make(chan types.NamespacedName, 1)
+
+	gov := New(Config{
+		Collector:   &fakeCollector{},
+		Notify:      func(d types.NamespacedName) { notified <- d },
+		MinInterval: 100 * time.Millisecond,
+	})
+
+	conf := newConfiguration("10.0.0.1")
+	g.Expect(gov.Admit(deployment, conf).Apply).To(BeTrue())
+	gov.Applied(deployment, conf, true)
+
+	routeChanged := newConfiguration("10.0.0.1")
+	routeChanged.HTTPServers[0].Hostname = "bar.example.com"
+	g.Expect(gov.Admit(deployment, routeChanged).Apply).To(BeFalse())
+
+	g.Eventually(notified).WithTimeout(5 * time.Second).Should(Receive(Equal(deployment)))
+	g.Expect(gov.Admit(deployment, routeChanged)).To(Equal(Decision{Change: ChangePriority, Apply: true}))
+}
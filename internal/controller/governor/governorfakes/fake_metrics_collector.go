@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package governorfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/governor"
+)
+
+type FakeMetricsCollector struct {
+	ObserveDeferredReloadAgeStub        func(string, time.Duration)
+	observeDeferredReloadAgeMutex       sync.RWMutex
+	observeDeferredReloadAgeArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	ObserveReloadDeferredStub        func(string)
+	observeReloadDeferredMutex       sync.RWMutex
+	observeReloadDeferredArgsForCall []struct {
+		arg1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMetricsCollector) ObserveDeferredReloadAge(arg1 string, arg2 time.Duration) {
+	fake.observeDeferredReloadAgeMutex.Lock()
+	fake.observeDeferredReloadAgeArgsForCall = append(fake.observeDeferredReloadAgeArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.ObserveDeferredReloadAgeStub
+	fake.recordInvocation("ObserveDeferredReloadAge", []interface{}{arg1, arg2})
+	fake.observeDeferredReloadAgeMutex.Unlock()
+	if stub != nil {
+		fake.ObserveDeferredReloadAgeStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeMetricsCollector) ObserveDeferredReloadAgeCallCount() int {
+	fake.observeDeferredReloadAgeMutex.RLock()
+	defer fake.observeDeferredReloadAgeMutex.RUnlock()
+	return len(fake.observeDeferredReloadAgeArgsForCall)
+}
+
+func (fake *FakeMetricsCollector) ObserveDeferredReloadAgeCalls(stub func(string, time.Duration)) {
+	fake.observeDeferredReloadAgeMutex.Lock()
+	defer fake.observeDeferredReloadAgeMutex.Unlock()
+	fake.ObserveDeferredReloadAgeStub = stub
+}
+
+func (fake *FakeMetricsCollector) ObserveDeferredReloadAgeArgsForCall(i int) (string, time.Duration) {
+	fake.observeDeferredReloadAgeMutex.RLock()
+	defer fake.observeDeferredReloadAgeMutex.RUnlock()
+	argsForCall := fake.observeDeferredReloadAgeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMetricsCollector) ObserveReloadDeferred(arg1 string) {
+	fake.observeReloadDeferredMutex.Lock()
+	fake.observeReloadDeferredArgsForCall = append(fake.observeReloadDeferredArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ObserveReloadDeferredStub
+	fake.recordInvocation("ObserveReloadDeferred", []interface{}{arg1})
+	fake.observeReloadDeferredMutex.Unlock()
+	if stub != nil {
+		fake.ObserveReloadDeferredStub(arg1)
+	}
+}
+
+func (fake *FakeMetricsCollector) ObserveReloadDeferredCallCount() int {
+	fake.observeReloadDeferredMutex.RLock()
+	defer fake.observeReloadDeferredMutex.RUnlock()
+	return len(fake.observeReloadDeferredArgsForCall)
+}
+
+func (fake *FakeMetricsCollector) ObserveReloadDeferredCalls(stub func(string)) {
+	fake.observeReloadDeferredMutex.Lock()
+	defer fake.observeReloadDeferredMutex.Unlock()
+	fake.ObserveReloadDeferredStub = stub
+}
+
+func (fake *FakeMetricsCollector) ObserveReloadDeferredArgsForCall(i int) string {
+	fake.observeReloadDeferredMutex.RLock()
+	defer fake.observeReloadDeferredMutex.RUnlock()
+	argsForCall := fake.observeReloadDeferredArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMetricsCollector) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMetricsCollector) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ governor.MetricsCollector = new(FakeMetricsCollector)
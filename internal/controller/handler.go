@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
 	"strings"
 	"sync"
@@ -20,25 +21,54 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/pkg/features"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/apicatalog"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
 	ngfConfig "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/config"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/failure"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/gatewaytest"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/governor"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/health"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/licensing"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
 	ngxConfig "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/export"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/outlier"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/rampup"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tempfile"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/trafficrouting"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/upstreammap"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/wasmhook"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/events"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
+	"github.com/nginx/nginx-gateway-fabric/v2/pkg/dataplane/v1alpha1"
 )
 
+// queueDepthObserver observes the number of events that the handler processes at once.
+type queueDepthObserver interface {
+	ObserveQueueDepth(depth int)
+}
+
+// wasmHookRunner runs the WASM hook on the routing state of the Gateways in the background.
+type wasmHookRunner interface {
+	Result(ctx context.Context, gateway types.NamespacedName, conf v1alpha1.Configuration) (wasmhook.Result, bool)
+	Retain(keep func(gateway types.NamespacedName) bool)
+}
+
 type handlerMetricsCollector interface {
 	ObserveLastEventBatchProcessTime(time.Duration)
+	ObserveConfigApplyLatency(gateway types.NamespacedName, duration time.Duration)
 }
 
 // eventHandlerConfig holds configuration parameters for eventHandlerImpl.
@@ -50,6 +80,9 @@ type eventHandlerConfig struct {
 	nginxProvisioner provisioner.Provisioner
 	// metricsCollector collects metrics for this controller.
 	metricsCollector handlerMetricsCollector
+	// queueDepthObserver defers low-priority API server requests when the handler falls behind on events.
+	// If nil, the queue depth is not observed.
+	queueDepthObserver queueDepthObserver
 	// statusUpdater updates statuses on Kubernetes resources.
 	statusUpdater status.GroupUpdater
 	// processor is the state ChangeProcessor.
@@ -58,18 +91,62 @@ type eventHandlerConfig struct {
 	serviceResolver resolver.ServiceResolver
 	// generator is the nginx config generator.
 	generator ngxConfig.Generator
+	// configExporters export the nginx config for NGINX instances that are not managed by the control plane.
+	configExporters []export.Exporter
+	// agentlessExporter delivers the nginx config to the nginx Pods that run without the NGINX agent.
+	agentlessExporter export.Exporter
+	// upstreamMapPublisher publishes the mapping of the Routes to the nginx upstreams for external tooling.
+	// If nil, the mapping is not published.
+	upstreamMapPublisher upstreammap.Publisher
+	// configHistory retains the latest versions of the nginx configuration of every Gateway.
+	// If nil, the versions are not retained.
+	configHistory *configHistory
+	// canaryAnalyzer holds the weights of the backends of the Routes whose canary breached an objective.
+	// If nil, the canaries are not analyzed.
+	canaryAnalyzer *canary.Analyzer
+	// apiCatalog holds the API catalogs of the Gateways, which NGINX serves at apiCatalogPath.
+	// If nil, the catalogs are not served.
+	apiCatalog *apicatalog.Aggregator
+	// apiCatalogPath is the path at which NGINX serves the API catalogs of the Gateways.
+	apiCatalogPath string
+	// tempFileHandler handles the writes to the temporary files that NGINX reports to the tempFileServer.
+	tempFileHandler *tempfile.Handler
+	// failureTracker records the failures of the nginx data plane of the Gateways.
+	// If nil, the failures are not reported in the statuses of the Gateways.
+	failureTracker *failure.Tracker
+	// failureHandler handles the exits of the worker processes that NGINX reports to the failureServer.
+	// If nil, the exits are not reported.
+	failureHandler *failure.Handler
+	// rampUp holds the weights of the new backends of the Routes at the steps of their ramp-up.
+	// If nil, the new backends are not ramped up.
+	rampUp *rampup.Ramper
+	// outlierHook triggers the diagnostic actions of the Routes whose error rate crosses the threshold.
+	// If nil, the error rates of the Routes are not evaluated.
+	outlierHook *outlier.Hook
+	// reloadGovernor enforces the minimum interval between the reloads of nginx.
+	// If nil, the reloads are not rate-limited.
+	reloadGovernor *governor.Governor
+	// wasmHook is the experimental WASM extension hook that mutates the routing state of every Gateway.
+	// If nil, the routing state is not mutated.
+	wasmHook wasmHookRunner
 	// k8sClient is a Kubernetes API client.
 	k8sClient client.Client
 	// k8sReader is a Kubernets API reader.
 	k8sReader client.Reader
 	// logLevelSetter is used to update the logging level.
 	logLevelSetter logLevelSetter
+	// moduleLogLevelSetter is used to update the logging levels of the control plane modules.
+	moduleLogLevelSetter moduleLogLevelSetter
+	// defaultModuleLogLevels are the logging levels of the control plane modules set at startup.
+	defaultModuleLogLevels map[string]string
 	// eventRecorder records events for Kubernetes resources.
 	eventRecorder record.EventRecorder
 	// deployCtxCollector collects the deployment context for N+ licensing
 	deployCtxCollector licensing.Collector
 	// graphBuiltHealthChecker sets the health of the Pod to Ready once we've built our initial graph.
 	graphBuiltHealthChecker *graphBuiltHealthChecker
+	// componentHealth tracks the health of the components of the control plane.
+	componentHealth *health.Tracker
 	// statusQueue contains updates when the handler should write statuses.
 	statusQueue *status.Queue
 	// nginxDeployments contains a map of all nginx Deployments, and data about them.
@@ -80,6 +157,24 @@ type eventHandlerConfig struct {
 	gatewayPodConfig ngfConfig.GatewayPodConfig
 	// controlConfigNSName is the NamespacedName of the NginxGateway config for this controller.
 	controlConfigNSName types.NamespacedName
+	// tenantAttributionServer is the address of the syslog server of the control plane, to which NGINX reports
+	// the requests that it attributes to tenants. If empty, NGINX doesn't report the requests.
+	tenantAttributionServer string
+	// gatewayTests indicates if GatewayTests are enabled. If so, NGINX reports the upstreams of the requests
+	// of the GatewayTests.
+	gatewayTests bool
+	// tempFileServer is the address of the syslog server of the control plane, to which NGINX reports the writes
+	// to its temporary files. If empty, NGINX doesn't report the writes.
+	tempFileServer string
+	// failureServer is the address of the syslog server of the control plane, to which NGINX reports the exits
+	// of its worker processes. If empty, NGINX doesn't report the exits.
+	failureServer string
+	// tokenReviewServer is the address of the verifier of the control plane, which reviews the bearer tokens
+	// of the requests for NGINX. If empty, NGINX rejects the requests that require a token.
+	tokenReviewServer string
+	// logLevelsConfigMapNSName is the NamespacedName of the ConfigMap with the logging levels of the control plane
+	// modules. If the name is empty, the ConfigMap is not used.
+	logLevelsConfigMapNSName types.NamespacedName
 	// gatewayCtlrName is the name of the NGF controller.
 	gatewayCtlrName string
 	// gatewayInstanceName is the name of the NGINX Gateway instance.
@@ -90,6 +185,10 @@ type eventHandlerConfig struct {
 	plus bool
 	// InferenceExtension indicates if Gateway API Inference Extension support is enabled.
 	inferenceExtension bool
+	// experimentalFeatures indicates if the experimental features of the Gateway API are enabled.
+	experimentalFeatures bool
+	// inactiveFeatures are the Gateway API features whose resources are not served by the cluster.
+	inactiveFeatures []features.FeatureName
 }
 
 const (
@@ -120,20 +219,48 @@ type eventHandlerImpl struct {
 	// latestConfigurations are the latest Configuration generation for each Gateway tree.
 	latestConfigurations map[types.NamespacedName]*dataplane.Configuration
 
+	// appliedTrafficRoutingSteps are the traffic routing steps of the Routes that are applied to the data plane
+	// of each Gateway.
+	appliedTrafficRoutingSteps map[types.NamespacedName]trafficrouting.Steps
+
 	// objectFilters contains all created objectFilters, with the key being a filterKey
 	objectFilters map[filterKey]objectFilter
 
+	// nginxErrorLevelOverride is the NGINX error log level set in the log levels ConfigMap. If set, it overrides
+	// the error log level of the NginxProxy for all Gateways.
+	nginxErrorLevelOverride string
+
 	cfg        eventHandlerConfig
 	lock       sync.RWMutex
 	leaderLock sync.RWMutex
 	leader     bool
+	// nginxErrorLevelChanged is true if the NGINX error log level override changed since the last event batch.
+	nginxErrorLevelChanged bool
+	// capabilitiesChanged is true if the capabilities of an nginx Deployment changed since the last event batch.
+	capabilitiesChanged bool
+	// sweepRequested is true if a consistency sweep was requested since the last event batch.
+	sweepRequested bool
+	// canaryChanged is true if the canary analysis of a Route changed since the last event batch.
+	canaryChanged bool
+	// rampUpWeightsChanged is true if the weights of the ramping backends changed since the last event batch.
+	rampUpWeightsChanged bool
+	// outlierChanged is true if an action of the outlier hook changed since the last event batch.
+	outlierChanged bool
+	// deferredReloadDue is true if a reload deferred by the reload governor became due since the last event batch.
+	deferredReloadDue bool
+	// catalogChanged is true if the API catalog of a Gateway changed since the last event batch.
+	catalogChanged bool
+	// wasmHookCompleted is true if the WASM hook completed for the routing state of a Gateway
+	// since the last event batch.
+	wasmHookCompleted bool
 }
 
 // newEventHandlerImpl creates a new eventHandlerImpl.
 func newEventHandlerImpl(cfg eventHandlerConfig) *eventHandlerImpl {
 	handler := &eventHandlerImpl{
-		cfg:                  cfg,
-		latestConfigurations: make(map[types.NamespacedName]*dataplane.Configuration),
+		cfg:                        cfg,
+		latestConfigurations:       make(map[types.NamespacedName]*dataplane.Configuration),
+		appliedTrafficRoutingSteps: make(map[types.NamespacedName]trafficrouting.Steps),
 	}
 
 	handler.objectFilters = map[filterKey]objectFilter{
@@ -144,6 +271,15 @@ func newEventHandlerImpl(cfg eventHandlerConfig) *eventHandlerImpl {
 		},
 	}
 
+	if cfg.logLevelsConfigMapNSName.Name != "" {
+		handler.objectFilters[objectFilterKey(&v1.ConfigMap{}, cfg.logLevelsConfigMapNSName)] = objectFilter{
+			upsert: handler.logLevelsConfigMapUpsert,
+			delete: handler.logLevelsConfigMapDelete,
+			// the ConfigMap might also be referenced by other resources, so the graph needs to know about it.
+			captureChangeInGraph: true,
+		}
+	}
+
 	go handler.waitForStatusUpdates(cfg.ctx)
 
 	return handler
@@ -157,23 +293,50 @@ func (h *eventHandlerImpl) HandleEventBatch(ctx context.Context, logger logr.Log
 		duration := time.Since(start)
 		logger.V(1).Info(
 			"Finished processing event batch",
-			"duration", duration.String(),
+			logging.ResultValues(duration, logging.OutcomeSuccess)...,
 		)
 		h.cfg.metricsCollector.ObserveLastEventBatchProcessTime(duration)
 	}()
 
+	if h.cfg.queueDepthObserver != nil {
+		h.cfg.queueDepthObserver.ObserveQueueDepth(len(batch))
+	}
+
 	for _, event := range batch {
 		h.parseAndCaptureEvent(ctx, logger, event)
 	}
 
 	gr := h.cfg.processor.Process()
 
+	// The NGINX error log level override and the data plane capabilities are not part of the graph,
+	// so the configuration must be regenerated from the latest graph when only they changed.
+	// The consistency sweep also regenerates the configuration and the statuses from the latest graph,
+	// and so do the changes of the canary analysis and the ramp-ups, which override the weights of the backends,
+	// and the changes of the outlier hook, which override the error log level. The reloads deferred by
+	// the reload governor are applied from the latest graph once they are due, and so are the changes
+	// of the API catalogs and the results of the WASM hook.
+	errorLevelChanged := h.nginxErrorLevelOverrideChanged()
+	capabilitiesChanged := h.dataPlaneCapabilitiesChanged()
+	sweepRequested := h.consistencySweepRequested()
+	canaryChanged := h.canaryAnalysisChanged()
+	rampUpChanged := h.rampUpChanged()
+	outlierChanged := h.outlierHookChanged()
+	reloadDue := h.reloadDue()
+	catalogChanged := h.apiCatalogChanged()
+	hookCompleted := h.wasmHookChanged()
+	regenerate := errorLevelChanged || capabilitiesChanged || sweepRequested || canaryChanged || rampUpChanged ||
+		outlierChanged || reloadDue || catalogChanged || hookCompleted
+	if regenerate && gr == nil {
+		gr = h.cfg.processor.GetLatestGraph()
+	}
+
 	// Once we've processed resources on startup and built our first graph, mark the Pod as ready.
 	if !h.cfg.graphBuiltHealthChecker.ready {
 		h.cfg.graphBuiltHealthChecker.setAsReady()
+		h.cfg.componentHealth.SetHealthy(health.GraphBuilder)
 	}
 
-	h.sendNginxConfig(ctx, logger, gr)
+	h.sendNginxConfig(contextWithConfigTriggers(ctx, describeEventBatch(batch)), logger, gr)
 }
 
 // enable is called when the pod becomes leader to ensure the provisioner has
@@ -191,6 +354,13 @@ func (h *eventHandlerImpl) sendNginxConfig(ctx context.Context, logger logr.Logg
 		return
 	}
 
+	h.removeStaleGateways(logger, gr)
+
+	// the new backends of the Routes are held at weight 0 before the configuration is generated
+	if h.cfg.rampUp != nil {
+		h.cfg.rampUp.Observe(gr)
+	}
+
 	if len(gr.Gateways) == 0 {
 		// still need to update GatewayClass status
 		obj := &status.QueueObject{
@@ -233,14 +403,94 @@ func (h *eventHandlerImpl) sendNginxConfig(ctx context.Context, logger logr.Logg
 		deployment.SetImageVersion(nginxImage)
 
 		cfg := dataplane.BuildConfiguration(ctx, logger, gr, gw, h.cfg.serviceResolver, h.cfg.plus)
+
+		// the weights held by the canary analysis take precedence over the weights of the ramp-ups
+		var rampUpWeights, canaryWeights map[types.NamespacedName]dataplane.BackendWeights
+		if h.cfg.rampUp != nil {
+			rampUpWeights = h.cfg.rampUp.WeightOverrides()
+		}
+		if h.cfg.canaryAnalyzer != nil {
+			canaryWeights = h.cfg.canaryAnalyzer.WeightOverrides()
+		}
+
+		weightOverrides := dataplane.MergeBackendWeights(rampUpWeights, canaryWeights)
+		dataplane.OverrideBackendWeights(&cfg, weightOverrides)
+
+		// the hook receives every upstream, including the upstreams of the Backends without weight, because the hook
+		// can give them weight. The upstreams are removed once the hook made the weights of the Backends final.
+		// The upstreams that the hook adds are kept, because the hook may reference them in ways
+		// the configuration doesn't know about.
+		upstreams := cfg.Upstreams
+		// the configuration of the Gateway is applied once the hook completed for its routing state
+		if !h.runWASMHook(ctx, logger, gw, &cfg) {
+			continue
+		}
+		dataplane.RemoveUnreferencedUpstreams(&cfg, addedUpstreams(upstreams, cfg.Upstreams)...)
+
 		depCtx, getErr := h.getDeploymentContext(ctx)
 		if getErr != nil {
 			logger.Error(getErr, "error getting deployment context for usage reporting")
 		}
 		cfg.DeploymentContext = depCtx
 
+		if cfg.BaseHTTPConfig.TenantAttribution != nil {
+			cfg.BaseHTTPConfig.TenantAttribution.Server = h.cfg.tenantAttributionServer
+		}
+
+		if h.cfg.apiCatalog != nil {
+			if catalog := h.cfg.apiCatalog.Catalog(client.ObjectKeyFromObject(gw.Source)); catalog != nil {
+				cfg.APICatalog = &dataplane.APICatalog{Path: h.cfg.apiCatalogPath, Content: catalog}
+			}
+		}
+
+		if h.cfg.gatewayTests {
+			cfg.BaseHTTPConfig.GatewayTestToken = gatewaytest.Token(gw.Source)
+		}
+
+		if tf := cfg.BaseHTTPConfig.TempFiles; tf != nil && tf.ReportWrites && h.cfg.tempFileHandler != nil {
+			tf.Server = h.cfg.tempFileServer
+			tf.SyslogTag = h.cfg.tempFileHandler.Register(client.ObjectKeyFromObject(gw.Source).String())
+		}
+
+		if cfg.TokenReview != nil {
+			cfg.TokenReview.Server = h.cfg.tokenReviewServer
+		}
+
+		if level := h.nginxErrorLevel(); level != "" {
+			cfg.Logging.ErrorLevel = level
+		}
+
+		if h.cfg.failureHandler != nil {
+			cfg.Logging.FailureServer = h.cfg.failureServer
+			cfg.Logging.FailureSyslogTag = h.cfg.failureHandler.Register(gw.DeploymentName)
+		}
+
+		if h.outlierDebugLogging(client.ObjectKeyFromObject(gw.Source)) {
+			cfg.Logging.ErrorLevel = outlierDebugErrorLevel
+		}
+
 		h.setLatestConfiguration(gw, &cfg)
 
+		if graph.AgentlessEnabledForNginxProxy(gw.EffectiveNginxProxy) {
+			files := h.cfg.generator.Generate(cfg)
+			h.exportNginxConf(ctx, logger, gw.Source, files)
+			h.publishUpstreamMap(ctx, logger, gr, gw, cfg)
+
+			deliverErr := h.deliverAgentlessNginxConf(ctx, gw.Source, files)
+			h.recordConfigVersion(ctx, gw, files, deliverErr)
+			if deliverErr == nil {
+				h.setAppliedTrafficRoutingSteps(gr, gw, weightOverrides)
+			}
+
+			obj := &status.QueueObject{
+				UpdateType: status.UpdateAll,
+				Error:      deliverErr,
+				Deployment: gw.DeploymentName,
+			}
+			h.cfg.statusQueue.Enqueue(obj)
+			continue
+		}
+
 		vm := []v1.VolumeMount{}
 		if gw.EffectiveNginxProxy != nil &&
 			gw.EffectiveNginxProxy.Kubernetes != nil {
@@ -253,18 +503,57 @@ func (h *eventHandlerImpl) sendNginxConfig(ctx context.Context, logger logr.Logg
 			}
 		}
 
+		if rollbackRequested(gw.Source) {
+			h.rollbackNginxConf(ctx, logger, gw, deployment, vm)
+			continue
+		}
+
+		withheld := withholdUnsupportedFeatures(&cfg, deployment.GetUnsupportedCapabilities())
+		if len(withheld) > 0 {
+			logger.Info(
+				"Withholding features that are not supported by all nginx Pods",
+				"gateway", gw.Source.GetName(),
+				"capabilities", withheld,
+			)
+		}
+		deployment.SetWithheldCapabilities(withheld)
+
+		// the statuses are updated once the deferred reload is applied
+		if !h.admitReload(logger, gw, cfg) {
+			continue
+		}
+
 		deployment.FileLock.Lock()
-		h.updateNginxConf(deployment, cfg, vm)
+		releaseRollback(logger, gw, deployment)
+		files := h.updateGovernedNginxConf(gw, deployment, cfg, vm)
 		deployment.FileLock.Unlock()
 
-		configErr := deployment.GetLatestConfigError()
-		upstreamErr := deployment.GetLatestUpstreamError()
+		h.exportNginxConf(ctx, logger, gw.Source, files)
+		h.publishUpstreamMap(ctx, logger, gr, gw, cfg)
+
+		configErr := errcodes.Wrap(errcodes.NginxConfigApplyFailed, deployment.GetLatestConfigError())
+		upstreamErr := errcodes.Wrap(errcodes.NginxUpstreamUpdateFailed, deployment.GetLatestUpstreamError())
 		err := errors.Join(configErr, upstreamErr)
 
+		h.recordConfigVersion(ctx, gw, files, err)
+		if err == nil {
+			h.setAppliedTrafficRoutingSteps(gr, gw, weightOverrides)
+		}
+
+		// The configuration is live in NGINX once the agents applied it, so the latency is measured
+		// from the time the resource changes that triggered this update were received.
+		if receivedTime, ok := events.BatchReceivedTime(ctx); ok && err == nil && h.isLeader() {
+			h.cfg.metricsCollector.ObserveConfigApplyLatency(
+				client.ObjectKeyFromObject(gw.Source),
+				time.Since(receivedTime),
+			)
+		}
+
 		obj := &status.QueueObject{
-			UpdateType: status.UpdateAll,
-			Error:      err,
-			Deployment: gw.DeploymentName,
+			UpdateType:       status.UpdateAll,
+			Error:            err,
+			WithheldFeatures: deployment.GetWithheldFeatures(),
+			Deployment:       gw.DeploymentName,
 		}
 		h.cfg.statusQueue.Enqueue(obj)
 	}
@@ -282,7 +571,6 @@ func (h *eventHandlerImpl) waitForStatusUpdates(ctx context.Context) {
 			continue
 		}
 
-		var nginxReloadRes graph.NginxReloadResult
 		var gw *graph.Gateway
 		if item.Deployment.Name != "" {
 			gwNSName := types.NamespacedName{
@@ -293,15 +581,26 @@ func (h *eventHandlerImpl) waitForStatusUpdates(ctx context.Context) {
 			gw = gr.Gateways[gwNSName]
 		}
 
-		switch {
-		case item.Error != nil:
-			h.cfg.logger.Error(item.Error, "Failed to update NGINX configuration")
-			nginxReloadRes.Error = item.Error
-		case gw != nil:
-			h.cfg.logger.Info("NGINX configuration was successfully updated")
+		if gw != nil && h.cfg.failureTracker != nil {
+			gw.DataPlaneFailures = h.cfg.failureTracker.Recent(gw.DeploymentName)
 		}
-		if gw != nil {
-			gw.LatestReloadResult = nginxReloadRes
+
+		// Only the updates of all statuses carry the result of an NGINX configuration update. The updates of
+		// the Gateway status are triggered by the changes of the NGINX Service and Pods of the Gateway.
+		if item.UpdateType == status.UpdateAll {
+			var nginxReloadRes graph.NginxReloadResult
+			switch {
+			case item.Error != nil:
+				h.cfg.logger.Error(item.Error, "Failed to update NGINX configuration")
+				nginxReloadRes.Error = item.Error
+			case gw != nil:
+				h.cfg.logger.Info("NGINX configuration was successfully updated")
+			}
+			nginxReloadRes.WithheldFeatures = item.WithheldFeatures
+			nginxReloadRes.RolledBack = item.RolledBack
+			if gw != nil {
+				gw.LatestReloadResult = nginxReloadRes
+			}
 		}
 
 		switch item.UpdateType {
@@ -315,6 +614,7 @@ func (h *eventHandlerImpl) waitForStatusUpdates(ctx context.Context) {
 			gwAddresses, err := getGatewayAddresses(
 				ctx,
 				h.cfg.k8sClient,
+				h.cfg.k8sReader,
 				item.GatewayService,
 				gw,
 				h.cfg.gatewayClassName,
@@ -322,8 +622,15 @@ func (h *eventHandlerImpl) waitForStatusUpdates(ctx context.Context) {
 			if err != nil {
 				msg := "error getting Gateway Service IP address"
 				h.cfg.logger.Error(err, msg)
+
+				// the updates triggered by the NGINX Pods don't have the Service
+				var eventObj client.Object = gw.Source
+				if item.GatewayService != nil {
+					eventObj = item.GatewayService
+				}
+
 				h.cfg.eventRecorder.Eventf(
-					item.GatewayService,
+					eventObj,
 					v1.EventTypeWarning,
 					"GetServiceIPFailed",
 					msg+": %s",
@@ -349,14 +656,27 @@ func (h *eventHandlerImpl) waitForStatusUpdates(ctx context.Context) {
 
 func (h *eventHandlerImpl) updateStatuses(ctx context.Context, gr *graph.Graph, gw *graph.Gateway) {
 	transitionTime := metav1.Now()
-	gcReqs := status.PrepareGatewayClassRequests(gr.GatewayClass, gr.IgnoredGatewayClasses, transitionTime)
+	gcReqs := status.PrepareGatewayClassRequests(
+		gr.GatewayClass,
+		gr.IgnoredGatewayClasses,
+		transitionTime,
+		h.cfg.inactiveFeatures,
+	)
+
+	// The Routes that are no longer handled keep their statuses unless they are cleared,
+	// for example, when their Gateway was deleted.
+	staleRouteReqs := h.prepareStaleRouteRequests(ctx, gr)
 
 	if gw == nil {
-		h.cfg.statusUpdater.UpdateGroup(ctx, groupAllExceptGateways, gcReqs...)
+		reqs := make([]status.UpdateRequest, 0, len(gcReqs)+len(staleRouteReqs))
+		reqs = append(reqs, gcReqs...)
+		reqs = append(reqs, staleRouteReqs...)
+
+		h.cfg.statusUpdater.UpdateGroup(ctx, groupAllExceptGateways, reqs...)
 		return
 	}
 
-	gwAddresses, err := getGatewayAddresses(ctx, h.cfg.k8sClient, nil, gw, h.cfg.gatewayClassName)
+	gwAddresses, err := getGatewayAddresses(ctx, h.cfg.k8sClient, h.cfg.k8sReader, nil, gw, h.cfg.gatewayClassName)
 	if err != nil {
 		msg := "error getting Gateway Service IP address"
 		h.cfg.logger.Error(err, msg)
@@ -374,6 +694,7 @@ func (h *eventHandlerImpl) updateStatuses(ctx context.Context, gr *graph.Graph,
 		gr.Routes,
 		transitionTime,
 		h.cfg.gatewayCtlrName,
+		h.getAppliedTrafficRoutingSteps(),
 	)
 
 	polReqs := status.PrepareBackendTLSPolicyRequests(gr.BackendTLSPolicies, transitionTime, h.cfg.gatewayCtlrName)
@@ -383,6 +704,11 @@ func (h *eventHandlerImpl) updateStatuses(ctx context.Context, gr *graph.Graph,
 		transitionTime,
 		h.cfg.gatewayCtlrName,
 	)
+	clusterDefaultPolicyReqs := status.PrepareClusterDefaultPolicyRequests(
+		gr.ClusterDefaultPolicies,
+		transitionTime,
+		h.cfg.gatewayCtlrName,
+	)
 
 	// unfortunately, status is not on clusterState stored by the change processor, so we need to make a k8sAPI call here
 	ipList := &inference.InferencePoolList{}
@@ -406,18 +732,22 @@ func (h *eventHandlerImpl) updateStatuses(ctx context.Context, gr *graph.Graph,
 		ipList,
 		gr.Gateways,
 		transitionTime,
+		h.cfg.gatewayCtlrName,
 	)
 
 	reqs := make(
 		[]status.UpdateRequest,
 		0,
-		len(gcReqs)+len(routeReqs)+len(polReqs)+len(ngfPolReqs)+len(snippetsFilterReqs)+len(inferencePoolReqs),
+		len(gcReqs)+len(routeReqs)+len(staleRouteReqs)+len(polReqs)+len(ngfPolReqs)+
+			len(snippetsFilterReqs)+len(clusterDefaultPolicyReqs)+len(inferencePoolReqs),
 	)
 	reqs = append(reqs, gcReqs...)
 	reqs = append(reqs, routeReqs...)
+	reqs = append(reqs, staleRouteReqs...)
 	reqs = append(reqs, polReqs...)
 	reqs = append(reqs, ngfPolReqs...)
 	reqs = append(reqs, snippetsFilterReqs...)
+	reqs = append(reqs, clusterDefaultPolicyReqs...)
 	reqs = append(reqs, inferencePoolReqs...)
 
 	h.cfg.statusUpdater.UpdateGroup(ctx, groupAllExceptGateways, reqs...)
@@ -457,17 +787,71 @@ func (h *eventHandlerImpl) parseAndCaptureEvent(ctx context.Context, logger logr
 		}
 
 		h.cfg.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *agent.CapabilitiesChangedEvent:
+		logger.Info("Capabilities of the nginx data plane changed", "deployment", e.Deployment.String())
+
+		h.lock.Lock()
+		h.capabilitiesChanged = true
+		h.lock.Unlock()
+	case *consistencySweepEvent:
+		logger.V(1).Info("Starting consistency sweep")
+
+		h.lock.Lock()
+		h.sweepRequested = true
+		h.lock.Unlock()
+	case *canaryAnalysisEvent:
+		logger.Info("Canary analysis of Routes changed")
+
+		h.recordCanaryAnalysisChanges(e.changes)
+
+		h.lock.Lock()
+		h.canaryChanged = true
+		h.lock.Unlock()
+	case *rampUpEvent:
+		logger.V(1).Info("Weights of the ramping backends of Routes changed")
+
+		h.recordRampUpChanges(e.changes)
+
+		h.lock.Lock()
+		h.rampUpWeightsChanged = true
+		h.lock.Unlock()
+	case *outlierHookEvent:
+		logger.Info("Outlier hook actions of Routes changed")
+
+		h.applyOutlierHookChanges(ctx, logger, e.changes)
+
+		h.lock.Lock()
+		h.outlierChanged = true
+		h.lock.Unlock()
+	case *reloadGovernorEvent:
+		logger.V(1).Info("Deferred reload of nginx is due", "deployment", e.deployment.String())
+
+		h.lock.Lock()
+		h.deferredReloadDue = true
+		h.lock.Unlock()
+	case *apiCatalogEvent:
+		logger.V(1).Info("API catalogs of Gateways changed")
+
+		h.lock.Lock()
+		h.catalogChanged = true
+		h.lock.Unlock()
+	case *wasmHookEvent:
+		logger.V(1).Info("WASM hook completed", "gateway", e.gateway.String())
+
+		h.lock.Lock()
+		h.wasmHookCompleted = true
+		h.lock.Unlock()
 	default:
 		panic(fmt.Errorf("unknown event type %T", e))
 	}
 }
 
-// updateNginxConf updates nginx conf files and reloads nginx.
+// updateNginxConf updates nginx conf files and reloads nginx. It returns the generated files.
 func (h *eventHandlerImpl) updateNginxConf(
 	deployment *agent.Deployment,
 	conf dataplane.Configuration,
 	volumeMounts []v1.VolumeMount,
-) {
+) []agent.File {
 	files := h.cfg.generator.Generate(conf)
 	h.cfg.nginxUpdater.UpdateConfig(deployment, files, volumeMounts)
 
@@ -475,6 +859,143 @@ func (h *eventHandlerImpl) updateNginxConf(
 	if h.cfg.plus {
 		h.cfg.nginxUpdater.UpdateUpstreamServers(deployment, conf)
 	}
+
+	return files
+}
+
+// deliverAgentlessNginxConf delivers the nginx conf files to the nginx Pods of a Gateway that run without the agent,
+// by exporting the files to the ConfigMap and the Secret that the Pods mount. Only the leader exports the files.
+func (h *eventHandlerImpl) deliverAgentlessNginxConf(
+	ctx context.Context,
+	gateway *gatewayv1.Gateway,
+	files []agent.File,
+) error {
+	if !h.isLeader() {
+		return nil
+	}
+
+	if err := h.cfg.agentlessExporter.Export(ctx, gateway, files); err != nil {
+		return errcodes.Wrap(
+			errcodes.NginxConfigDeliveryFailed,
+			fmt.Errorf("failed to deliver nginx configuration: %w", err),
+		)
+	}
+
+	return nil
+}
+
+// exportNginxConf exports the nginx conf files of the Gateway using the configured exporters.
+// Only the leader exports the files, so that multiple replicas don't compete over the exported configuration.
+func (h *eventHandlerImpl) exportNginxConf(
+	ctx context.Context,
+	logger logr.Logger,
+	gateway *gatewayv1.Gateway,
+	files []agent.File,
+) {
+	if len(h.cfg.configExporters) == 0 || !h.isLeader() {
+		return
+	}
+
+	for _, exporter := range h.cfg.configExporters {
+		if err := exporter.Export(ctx, gateway, files); err != nil {
+			logger.Error(
+				err,
+				"error exporting nginx configuration",
+				"namespace", gateway.GetNamespace(),
+				"name", gateway.GetName(),
+			)
+		}
+	}
+}
+
+// publishUpstreamMap publishes the mapping of the Routes of the Gateway to the nginx upstreams.
+// Only the leader publishes the mapping.
+func (h *eventHandlerImpl) publishUpstreamMap(
+	ctx context.Context,
+	logger logr.Logger,
+	gr *graph.Graph,
+	gw *graph.Gateway,
+	cfg dataplane.Configuration,
+) {
+	if h.cfg.upstreamMapPublisher == nil || !h.isLeader() {
+		return
+	}
+
+	if err := h.cfg.upstreamMapPublisher.Publish(ctx, gw.Source, upstreammap.Build(gr, gw, cfg)); err != nil {
+		logger.Error(
+			err,
+			"error publishing upstream mapping",
+			"namespace", gw.Source.GetNamespace(),
+			"name", gw.Source.GetName(),
+		)
+	}
+}
+
+// runWASMHook mutates the routing state of the configuration of the Gateway with the result of the WASM hook.
+// If the hook fails, the configuration is not changed. It returns false if the hook is still running for
+// the routing state, in which case the configuration must not be applied yet.
+func (h *eventHandlerImpl) runWASMHook(
+	ctx context.Context,
+	logger logr.Logger,
+	gw *graph.Gateway,
+	cfg *dataplane.Configuration,
+) bool {
+	if h.cfg.wasmHook == nil {
+		return true
+	}
+
+	gwNsName := client.ObjectKeyFromObject(gw.Source)
+
+	result, available := h.cfg.wasmHook.Result(ctx, gwNsName, dataplane.ToV1alpha1(*cfg))
+	if !available {
+		logger.V(1).Info("WASM hook is running, the configuration of the Gateway is deferred", "gateway", gwNsName)
+		return false
+	}
+
+	err := result.Err
+	if err == nil {
+		err = dataplane.ApplyV1alpha1(cfg, result.Configuration)
+	}
+
+	if err != nil {
+		msg := "WASM hook failed, the configuration is applied without its changes"
+		logger.Error(err, msg, "namespace", gw.Source.GetNamespace(), "name", gw.Source.GetName())
+		h.cfg.eventRecorder.Eventf(gw.Source, v1.EventTypeWarning, "WASMHookFailed", msg+": %s", err.Error())
+	}
+
+	return true
+}
+
+// addedUpstreams returns the names of the upstreams that are in the mutated upstreams but not in the original ones.
+func addedUpstreams(original, mutated []dataplane.Upstream) []string {
+	originalNames := make(map[string]struct{}, len(original))
+	for _, u := range original {
+		originalNames[u.Name] = struct{}{}
+	}
+
+	var added []string
+	for _, u := range mutated {
+		if _, exists := originalNames[u.Name]; !exists {
+			added = append(added, u.Name)
+		}
+	}
+
+	return added
+}
+
+// recordConfigVersion records the files as the latest version of the nginx configuration of the Gateway
+// in the configuration history.
+func (h *eventHandlerImpl) recordConfigVersion(
+	ctx context.Context,
+	gw *graph.Gateway,
+	files []agent.File,
+	err error,
+) {
+	if h.cfg.configHistory == nil {
+		return
+	}
+
+	h.cfg.configHistory.record(client.ObjectKeyFromObject(gw.Source), files, configTriggers(ctx), err)
 }
 
 // updateControlPlaneAndSetStatus updates the control plane configuration and then sets the status
@@ -493,6 +1014,7 @@ func (h *eventHandlerImpl) updateControlPlaneAndSetStatus(
 		h.cfg.controlConfigNSName,
 		h.cfg.logLevelSetter,
 	); err != nil {
+		err = errcodes.Wrap(errcodes.ControlPlaneUpdateFailed, err)
 		msg := "Failed to update control plane configuration"
 		logger.Error(err, msg)
 		h.cfg.eventRecorder.Eventf(
@@ -521,6 +1043,7 @@ func (h *eventHandlerImpl) updateControlPlaneAndSetStatus(
 func getGatewayAddresses(
 	ctx context.Context,
 	k8sClient client.Client,
+	k8sReader client.Reader,
 	svc *v1.Service,
 	gateway *graph.Gateway,
 	gatewayClassName string,
@@ -566,7 +1089,20 @@ func getGatewayAddresses(
 			}
 		}
 	default:
-		addresses = append(addresses, gwSvc.Spec.ClusterIP)
+		nodeAddresses, nodeHostnames, err := getNodeAddresses(ctx, k8sClient, k8sReader, &gwSvc, gateway)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(nodeAddresses) > 0 || len(nodeHostnames) > 0 {
+			addresses = append(addresses, nodeAddresses...)
+			hostnames = append(hostnames, nodeHostnames...)
+		} else {
+			addresses = append(addresses, gwSvc.Spec.ClusterIP)
+		}
+
+		// the external IPs include the address allocated to the Service by the IPAM, if configured
+		addresses = append(addresses, gwSvc.Spec.ExternalIPs...)
 	}
 
 	for _, address := range gateway.Source.Spec.Addresses {
@@ -619,6 +1155,14 @@ func (h *eventHandlerImpl) GetLatestConfiguration() []*dataplane.Configuration {
 	return configs
 }
 
+// GetLatestConfigurationsByGateway gets the latest configuration of every Gateway.
+func (h *eventHandlerImpl) GetLatestConfigurationsByGateway() map[types.NamespacedName]*dataplane.Configuration {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return maps.Clone(h.latestConfigurations)
+}
+
 // setLatestConfiguration sets the latest configuration.
 func (h *eventHandlerImpl) setLatestConfiguration(gateway *graph.Gateway, cfg *dataplane.Configuration) {
 	if gateway == nil || gateway.Source == nil {
@@ -758,6 +1302,108 @@ func (h *eventHandlerImpl) nginxGatewayCRDUpsert(ctx context.Context, logger log
 	h.updateControlPlaneAndSetStatus(ctx, logger, cfg)
 }
 
+func (h *eventHandlerImpl) logLevelsConfigMapUpsert(_ context.Context, logger logr.Logger, obj client.Object) {
+	cm, ok := obj.(*v1.ConfigMap)
+	if !ok {
+		panic(fmt.Errorf("obj type mismatch: got %T, expected %T", obj, &v1.ConfigMap{}))
+	}
+
+	levels := maps.Clone(h.cfg.defaultModuleLogLevels)
+	if levels == nil {
+		levels = make(map[string]string, len(cm.Data))
+	}
+	maps.Copy(levels, cm.Data)
+
+	// the NGINX error log level is not a control plane module
+	nginxErrorLevel := levels[nginxErrorLevelKey]
+	delete(levels, nginxErrorLevelKey)
+
+	err := validateNginxErrorLevel(nginxErrorLevel)
+	if err == nil {
+		err = h.cfg.moduleLogLevelSetter.SetModuleLevels(levels)
+	}
+
+	if err != nil {
+		msg := "Failed to update module log levels"
+		logger.Error(err, msg, logging.ResourceValues("ConfigMap", client.ObjectKeyFromObject(cm))...)
+		h.cfg.eventRecorder.Eventf(
+			cm,
+			v1.EventTypeWarning,
+			"UpdateFailed",
+			msg+": %s",
+			err.Error(),
+		)
+
+		return
+	}
+
+	logger.Info("Updated module log levels", "levels", levels)
+	h.setNginxErrorLevelOverride(logger, nginxErrorLevel)
+}
+
+func (h *eventHandlerImpl) logLevelsConfigMapDelete(_ context.Context, logger logr.Logger, _ types.NamespacedName) {
+	h.setNginxErrorLevelOverride(logger, "")
+
+	if err := h.cfg.moduleLogLevelSetter.SetModuleLevels(h.cfg.defaultModuleLogLevels); err != nil {
+		logger.Error(err, "Failed to reset module log levels")
+		return
+	}
+
+	logger.Info("Module log levels ConfigMap was deleted; using defaults", "levels", h.cfg.defaultModuleLogLevels)
+}
+
+// setNginxErrorLevelOverride sets the NGINX error log level that overrides the level of the NginxProxy for all
+// Gateways. An empty level removes the override. If the override changed, the NGINX configuration is
+// regenerated at the end of the current event batch.
+func (h *eventHandlerImpl) setNginxErrorLevelOverride(logger logr.Logger, level string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.nginxErrorLevelOverride == level {
+		return
+	}
+
+	if level == "" {
+		logger.Info("Removed NGINX error log level override")
+	} else {
+		logger.Info("Overriding NGINX error log level", "level", level)
+	}
+
+	h.nginxErrorLevelOverride = level
+	h.nginxErrorLevelChanged = true
+}
+
+// dataPlaneCapabilitiesChanged returns true if the capabilities of an nginx Deployment changed
+// since the last call, and resets it.
+func (h *eventHandlerImpl) dataPlaneCapabilitiesChanged() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	changed := h.capabilitiesChanged
+	h.capabilitiesChanged = false
+
+	return changed
+}
+
+// nginxErrorLevel returns the NGINX error log level override.
+func (h *eventHandlerImpl) nginxErrorLevel() string {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.nginxErrorLevelOverride
+}
+
+// nginxErrorLevelOverrideChanged returns whether the NGINX error log level override changed since the last call.
+func (h *eventHandlerImpl) nginxErrorLevelOverrideChanged() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	changed := h.nginxErrorLevelChanged
+	h.nginxErrorLevelChanged = false
+
+	return changed
+}
+
 func (h *eventHandlerImpl) nginxGatewayCRDDelete(
 	ctx context.Context,
 	logger logr.Logger,
@@ -23,41 +23,55 @@ import (
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
 	"github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary/canaryfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/config"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/governor"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/licensing/licensingfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics/collectors"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/agentfakes"
 	agentgrpcfakes "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc/grpcfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/configfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/export"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/outlier"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/provisionerfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/rampup"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver/resolverfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/statefakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status/statusfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tempfile"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/trafficrouting"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/upstreammap"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/wasmhook"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/events"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	dataplanev1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/pkg/dataplane/v1alpha1"
 )
 
 var _ = Describe("eventHandler", func() {
 	var (
-		baseGraph         *graph.Graph
-		handler           *eventHandlerImpl
-		fakeProcessor     *statefakes.FakeChangeProcessor
-		fakeGenerator     *configfakes.FakeGenerator
-		fakeNginxUpdater  *agentfakes.FakeNginxUpdater
-		fakeProvisioner   *provisionerfakes.FakeProvisioner
-		fakeStatusUpdater *statusfakes.FakeGroupUpdater
-		fakeEventRecorder *record.FakeRecorder
-		fakeK8sClient     client.WithWatch
-		queue             *status.Queue
-		namespace         = "nginx-gateway"
-		configName        = "nginx-gateway-config"
-		zapLogLevelSetter zapLogLevelSetter
-		ctx               context.Context
-		cancel            context.CancelFunc
+		baseGraph              *graph.Graph
+		handler                *eventHandlerImpl
+		fakeProcessor          *statefakes.FakeChangeProcessor
+		fakeGenerator          *configfakes.FakeGenerator
+		fakeNginxUpdater       *agentfakes.FakeNginxUpdater
+		fakeProvisioner        *provisionerfakes.FakeProvisioner
+		fakeStatusUpdater      *statusfakes.FakeGroupUpdater
+		fakeEventRecorder      *record.FakeRecorder
+		fakeK8sClient          client.WithWatch
+		queue                  *status.Queue
+		namespace              = "nginx-gateway"
+		configName             = "nginx-gateway-config"
+		logLevelsConfigMapName = "nginx-gateway-log-levels"
+		zapLogLevelSetter      zapLogLevelSetter
+		moduleLevels           *fakeModuleLogLevelSetter
+		ctx                    context.Context
+		cancel                 context.CancelFunc
 	)
 
 	expectReconfig := func(expectedConf dataplane.Configuration, expectedFiles []agent.File) {
@@ -116,6 +130,7 @@ var _ = Describe("eventHandler", func() {
 		fakeStatusUpdater = &statusfakes.FakeGroupUpdater{}
 		fakeEventRecorder = record.NewFakeRecorder(1)
 		zapLogLevelSetter = newZapLogLevelSetter(zap.NewAtomicLevel())
+		moduleLevels = &fakeModuleLogLevelSetter{}
 		queue = status.NewQueue()
 
 		gatewaySvc := &v1.Service{
@@ -130,11 +145,17 @@ var _ = Describe("eventHandler", func() {
 		fakeK8sClient = fake.NewFakeClient(gatewaySvc)
 
 		handler = newEventHandlerImpl(eventHandlerConfig{
-			ctx:                     ctx,
-			k8sClient:               fakeK8sClient,
-			processor:               fakeProcessor,
-			generator:               fakeGenerator,
-			logLevelSetter:          zapLogLevelSetter,
+			ctx:                    ctx,
+			k8sClient:              fakeK8sClient,
+			processor:              fakeProcessor,
+			generator:              fakeGenerator,
+			logLevelSetter:         zapLogLevelSetter,
+			moduleLogLevelSetter:   moduleLevels,
+			defaultModuleLogLevels: map[string]string{"provisioner": "error"},
+			logLevelsConfigMapNSName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      logLevelsConfigMapName,
+			},
 			nginxUpdater:            fakeNginxUpdater,
 			nginxProvisioner:        fakeProvisioner,
 			statusUpdater:           fakeStatusUpdater,
@@ -222,6 +243,54 @@ var _ = Describe("eventHandler", func() {
 				Expect(helpers.Diff(config[0], &dcfg)).To(BeEmpty())
 			})
 
+			It("should set the address of the tenant attribution server", func() {
+				gw := baseGraph.Gateways[types.NamespacedName{Namespace: "test", Name: "gateway"}]
+				gw.EffectiveNginxProxy = &graph.EffectiveNginxProxy{
+					TenantAttribution: &v1alpha2.TenantAttribution{Enable: true},
+				}
+				baseGraph.GatewayClass = &graph.GatewayClass{
+					Source: &gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}},
+					Valid:  true,
+				}
+				handler.cfg.tenantAttributionServer = "nginx-gateway.nginx-gateway.svc:5140"
+
+				e := &events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}
+				handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{e})
+
+				config := handler.GetLatestConfiguration()
+				Expect(config).To(HaveLen(1))
+				Expect(config[0].BaseHTTPConfig.TenantAttribution).To(Equal(&dataplane.TenantAttribution{
+					Server: "nginx-gateway.nginx-gateway.svc:5140",
+				}))
+			})
+
+			It("should set the address of the temporary file server and the syslog tag of the Gateway", func() {
+				gw := baseGraph.Gateways[types.NamespacedName{Namespace: "test", Name: "gateway"}]
+				gw.EffectiveNginxProxy = &graph.EffectiveNginxProxy{
+					TempFiles: &v1alpha2.TempFiles{ReportWrites: helpers.GetPointer(true)},
+				}
+				baseGraph.GatewayClass = &graph.GatewayClass{
+					Source: &gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}},
+					Valid:  true,
+				}
+				handler.cfg.tempFileServer = "nginx-gateway.nginx-gateway.svc:5141"
+				handler.cfg.tempFileHandler = tempfile.NewHandler(
+					logr.Discard(),
+					collectors.NewTempFileNoopCollector(),
+				)
+
+				e := &events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}
+				handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{e})
+
+				config := handler.GetLatestConfiguration()
+				Expect(config).To(HaveLen(1))
+				Expect(config[0].BaseHTTPConfig.TempFiles).To(Equal(&dataplane.TempFiles{
+					Server:       "nginx-gateway.nginx-gateway.svc:5141",
+					SyslogTag:    tempfile.SyslogTag("test/gateway"),
+					ReportWrites: true,
+				}))
+			})
+
 			It("should not build anything if Gateway isn't set", func() {
 				fakeProcessor.ProcessReturns(&graph.Graph{})
 
@@ -265,190 +334,1217 @@ var _ = Describe("eventHandler", func() {
 					},
 				})
 
-				e := &events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}
-				batch := []interface{}{e}
+				e := &events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}
+				batch := []interface{}{e}
+
+				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+				checkUpsertEventExpectations(e)
+				// status update should still occur for GatewayClasses
+				Eventually(
+					func() int {
+						return fakeStatusUpdater.UpdateGroupCallCount()
+					}).Should(Equal(1))
+			})
+		})
+
+		When("a batch has multiple events", func() {
+			It("should process events", func() {
+				upsertEvent := &events.UpsertEvent{Resource: &gatewayv1.Gateway{}}
+				deleteEvent := &events.DeleteEvent{
+					Type:           &gatewayv1.HTTPRoute{},
+					NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"},
+				}
+				batch := []interface{}{upsertEvent, deleteEvent}
+
+				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+				checkUpsertEventExpectations(upsertEvent)
+				checkDeleteEventExpectations(deleteEvent)
+
+				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+				dcfg := dataplane.GetDefaultConfiguration(&graph.Graph{}, &graph.Gateway{})
+
+				config := handler.GetLatestConfiguration()
+				Expect(config).To(HaveLen(1))
+				Expect(helpers.Diff(config[0], &dcfg)).To(BeEmpty())
+			})
+		})
+	})
+
+	When("receiving control plane configuration updates", func() {
+		cfg := func(level ngfAPI.ControllerLogLevel) *ngfAPI.NginxGateway {
+			return &ngfAPI.NginxGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      configName,
+				},
+				Spec: ngfAPI.NginxGatewaySpec{
+					Logging: &ngfAPI.Logging{
+						Level: helpers.GetPointer(level),
+					},
+				},
+			}
+		}
+
+		It("handles a valid config", func() {
+			batch := []interface{}{&events.UpsertEvent{Resource: cfg(ngfAPI.ControllerLogLevelError)}}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(handler.GetLatestConfiguration()).To(BeEmpty())
+
+			Eventually(
+				func() int {
+					return fakeStatusUpdater.UpdateGroupCallCount()
+				}).Should(BeNumerically(">", 1))
+
+			_, name, reqs := fakeStatusUpdater.UpdateGroupArgsForCall(0)
+			Expect(name).To(Equal(groupControlPlane))
+			Expect(reqs).To(HaveLen(1))
+
+			Expect(zapLogLevelSetter.Enabled(zap.DebugLevel)).To(BeFalse())
+			Expect(zapLogLevelSetter.Enabled(zap.ErrorLevel)).To(BeTrue())
+		})
+
+		It("handles an invalid config", func() {
+			batch := []interface{}{&events.UpsertEvent{Resource: cfg(ngfAPI.ControllerLogLevel("invalid"))}}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(handler.GetLatestConfiguration()).To(BeEmpty())
+
+			Eventually(
+				func() int {
+					return fakeStatusUpdater.UpdateGroupCallCount()
+				}).Should(BeNumerically(">", 1))
+
+			_, name, reqs := fakeStatusUpdater.UpdateGroupArgsForCall(0)
+			Expect(name).To(Equal(groupControlPlane))
+			Expect(reqs).To(HaveLen(1))
+
+			Expect(fakeEventRecorder.Events).To(HaveLen(1))
+			event := <-fakeEventRecorder.Events
+			Expect(event).To(Equal(
+				"Warning UpdateFailed Failed to update control plane configuration: " +
+					"[NGF2101 ControlPlaneUpdateFailed] logging.level: Unsupported value: " +
+					"\"invalid\": supported values: \"info\", \"debug\", \"error\"",
+			))
+			Expect(zapLogLevelSetter.Enabled(zap.InfoLevel)).To(BeTrue())
+		})
+
+		It("handles a deleted config", func() {
+			batch := []interface{}{
+				&events.DeleteEvent{
+					Type: &ngfAPI.NginxGateway{},
+					NamespacedName: types.NamespacedName{
+						Namespace: namespace,
+						Name:      configName,
+					},
+				},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(handler.GetLatestConfiguration()).To(BeEmpty())
+
+			Eventually(
+				func() int {
+					return fakeStatusUpdater.UpdateGroupCallCount()
+				}).Should(BeNumerically(">", 1))
+
+			_, name, reqs := fakeStatusUpdater.UpdateGroupArgsForCall(0)
+			Expect(name).To(Equal(groupControlPlane))
+			Expect(reqs).To(BeEmpty())
+
+			Expect(fakeEventRecorder.Events).To(HaveLen(1))
+			event := <-fakeEventRecorder.Events
+			Expect(event).To(Equal("Warning ResourceDeleted NginxGateway configuration was deleted; using defaults"))
+			Expect(zapLogLevelSetter.Enabled(zap.InfoLevel)).To(BeTrue())
+		})
+	})
+
+	When("receiving module log level updates", func() {
+		cm := func(data map[string]string) *v1.ConfigMap {
+			return &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      logLevelsConfigMapName,
+				},
+				Data: data,
+			}
+		}
+
+		It("merges the ConfigMap levels with the defaults", func() {
+			batch := []interface{}{
+				&events.UpsertEvent{Resource: cm(map[string]string{"eventHandler": "debug"})},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(moduleLevels.levels).To(Equal(map[string]string{
+				"eventHandler": "debug",
+				"provisioner":  "error",
+			}))
+			Expect(fakeProcessor.CaptureUpsertChangeCallCount()).To(Equal(1))
+			Expect(fakeEventRecorder.Events).To(BeEmpty())
+		})
+
+		It("emits an event if the levels are invalid", func() {
+			moduleLevels.err = errors.New("unsupported level")
+
+			batch := []interface{}{
+				&events.UpsertEvent{Resource: cm(map[string]string{"eventHandler": "trace"})},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeEventRecorder.Events).To(HaveLen(1))
+			event := <-fakeEventRecorder.Events
+			Expect(event).To(Equal("Warning UpdateFailed Failed to update module log levels: unsupported level"))
+		})
+
+		It("overrides the NGINX error log level of all Gateways", func() {
+			fakeProcessor.ProcessReturns(nil)
+
+			batch := []interface{}{
+				&events.UpsertEvent{Resource: cm(map[string]string{"eventHandler": "debug", "nginx": "debug"})},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(moduleLevels.levels).To(Equal(map[string]string{
+				"eventHandler": "debug",
+				"provisioner":  "error",
+			}))
+
+			configs := handler.GetLatestConfiguration()
+			Expect(configs).To(HaveLen(1))
+			Expect(configs[0].Logging.ErrorLevel).To(Equal("debug"))
+
+			deleteBatch := []interface{}{
+				&events.DeleteEvent{
+					Type:           &v1.ConfigMap{},
+					NamespacedName: types.NamespacedName{Namespace: namespace, Name: logLevelsConfigMapName},
+				},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), deleteBatch)
+
+			configs = handler.GetLatestConfiguration()
+			Expect(configs).To(HaveLen(1))
+			Expect(configs[0].Logging.ErrorLevel).To(Equal("info"))
+		})
+
+		It("does not regenerate the NGINX configuration if the override did not change", func() {
+			fakeProcessor.ProcessReturns(nil)
+
+			batch := []interface{}{
+				&events.UpsertEvent{Resource: cm(map[string]string{"eventHandler": "debug"})},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(handler.GetLatestConfiguration()).To(BeEmpty())
+			Expect(fakeGenerator.GenerateCallCount()).To(BeZero())
+		})
+
+		It("emits an event if the NGINX error log level is invalid", func() {
+			batch := []interface{}{
+				&events.UpsertEvent{Resource: cm(map[string]string{"nginx": "trace"})},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(moduleLevels.levels).To(BeNil())
+			Expect(fakeEventRecorder.Events).To(HaveLen(1))
+			event := <-fakeEventRecorder.Events
+			Expect(event).To(Equal(
+				"Warning UpdateFailed Failed to update module log levels: " +
+					"unsupported NGINX error log level \"trace\" for key \"nginx\"",
+			))
+		})
+
+		It("resets the levels to the defaults when the ConfigMap is deleted", func() {
+			batch := []interface{}{
+				&events.DeleteEvent{
+					Type:           &v1.ConfigMap{},
+					NamespacedName: types.NamespacedName{Namespace: namespace, Name: logLevelsConfigMapName},
+				},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(moduleLevels.levels).To(Equal(map[string]string{"provisioner": "error"}))
+			Expect(fakeProcessor.CaptureDeleteChangeCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("NGINX Plus API calls", func() {
+		e := &events.UpsertEvent{Resource: &discoveryV1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-gateway",
+				Namespace: "nginx-gateway",
+			},
+		}}
+		batch := []interface{}{e}
+
+		BeforeEach(func() {
+			fakeProcessor.ProcessReturns(&graph.Graph{
+				Gateways: map[types.NamespacedName]*graph.Gateway{
+					{}: {
+						Source: &gatewayv1.Gateway{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "test",
+								Name:      "gateway",
+							},
+						},
+						Valid: true,
+					},
+				},
+			})
+		})
+
+		When("running NGINX Plus", func() {
+			It("should call the NGINX Plus API", func() {
+				handler.cfg.plus = true
+
+				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+				dcfg := dataplane.GetDefaultConfiguration(&graph.Graph{}, &graph.Gateway{})
+				dcfg.NginxPlus = dataplane.NginxPlus{AllowedAddresses: []string{"127.0.0.1"}}
+
+				config := handler.GetLatestConfiguration()
+				Expect(config).To(HaveLen(1))
+				Expect(helpers.Diff(config[0], &dcfg)).To(BeEmpty())
+
+				Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+				Expect(fakeNginxUpdater.UpdateUpstreamServersCallCount()).To(Equal(1))
+			})
+		})
+
+		When("not running NGINX Plus", func() {
+			It("should not call the NGINX Plus API", func() {
+				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+				dcfg := dataplane.GetDefaultConfiguration(&graph.Graph{}, &graph.Gateway{})
+
+				config := handler.GetLatestConfiguration()
+				Expect(config).To(HaveLen(1))
+				Expect(helpers.Diff(config[0], &dcfg)).To(BeEmpty())
+
+				Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+				Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+				Expect(fakeNginxUpdater.UpdateUpstreamServersCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("exporting NGINX configuration", func() {
+		var exporter *fakeExporter
+
+		gw := &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "gateway",
+			},
+		}
+		cfgFiles := []agent.File{
+			{
+				Meta: &pb.FileMeta{
+					Name: "test.conf",
+				},
+			},
+		}
+		batch := []interface{}{&events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}}
+
+		BeforeEach(func() {
+			exporter = &fakeExporter{err: errors.New("export error")}
+			handler.cfg.configExporters = []export.Exporter{exporter, exporter}
+
+			fakeProcessor.ProcessReturns(&graph.Graph{
+				Gateways: map[types.NamespacedName]*graph.Gateway{
+					{}: {
+						Source: gw,
+						Valid:  true,
+					},
+				},
+			})
+			fakeGenerator.GenerateReturns(cfgFiles)
+		})
+
+		It("should export the configuration with every exporter when leader", func() {
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(exporter.gateways).To(Equal([]*gatewayv1.Gateway{gw, gw}))
+			Expect(exporter.files).To(Equal([][]agent.File{cfgFiles, cfgFiles}))
+		})
+
+		It("should not export the configuration when not leader", func() {
+			handler.leader = false
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+			Expect(exporter.gateways).To(BeEmpty())
+		})
+	})
+
+	Context("publishing the upstream mapping", func() {
+		var publisher *fakeUpstreamMapPublisher
+
+		gw := &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "gateway",
+			},
+		}
+		batch := []interface{}{&events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}}
+
+		BeforeEach(func() {
+			publisher = &fakeUpstreamMapPublisher{err: errors.New("publish error")}
+			handler.cfg.upstreamMapPublisher = publisher
+
+			fakeProcessor.ProcessReturns(&graph.Graph{
+				Gateways: map[types.NamespacedName]*graph.Gateway{
+					{}: {
+						Source: gw,
+						Valid:  true,
+					},
+				},
+			})
+		})
+
+		It("should publish the mapping when leader", func() {
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(publisher.gateways).To(Equal([]*gatewayv1.Gateway{gw}))
+			Expect(publisher.mappings).To(Equal([]upstreammap.Mapping{
+				{
+					Gateway:   upstreammap.ObjectRef{Namespace: "test", Name: "gateway"},
+					Routes:    []upstreammap.Route{},
+					Upstreams: []upstreammap.Upstream{},
+				},
+			}))
+		})
+
+		It("should not publish the mapping when not leader", func() {
+			handler.leader = false
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+			Expect(publisher.gateways).To(BeEmpty())
+		})
+	})
+
+	Context("WASM hook", func() {
+		var hook *fakeWASMHookRunner
+
+		batch := []interface{}{&events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}}
+
+		BeforeEach(func() {
+			hook = &fakeWASMHookRunner{}
+			handler.cfg.wasmHook = hook
+
+			fakeProcessor.ProcessReturns(baseGraph)
+		})
+
+		It("should apply the routing state mutated by the hook", func() {
+			hook.mutate = func(conf dataplanev1alpha1.Configuration) dataplanev1alpha1.Configuration {
+				conf.Upstreams = append(conf.Upstreams, dataplanev1alpha1.Upstream{Name: "hook_upstream"})
+				return conf
+			}
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(hook.calls).To(Equal(1))
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeGenerator.GenerateArgsForCall(0).Upstreams).To(ContainElement(
+				dataplane.Upstream{Name: "hook_upstream"},
+			))
+			Expect(fakeEventRecorder.Events).To(BeEmpty())
+		})
+
+		It("should defer the configuration until the hook completes", func() {
+			hook.pending = true
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(hook.calls).To(Equal(1))
+			Expect(fakeGenerator.GenerateCallCount()).To(BeZero())
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(BeZero())
+
+			hook.pending = false
+			fakeProcessor.ProcessReturns(nil)
+			fakeProcessor.GetLatestGraphReturns(baseGraph)
+
+			gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{
+				&wasmHookEvent{gateway: gwNsName},
+			})
+
+			Expect(hook.calls).To(Equal(2))
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+		})
+
+		It("should apply the configuration without the changes of the hook when the hook fails", func() {
+			hook.err = errors.New("module trapped")
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(hook.calls).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+			Expect(fakeGenerator.GenerateArgsForCall(0).Upstreams).To(BeEmpty())
+			Expect(fakeEventRecorder.Events).To(Receive(ContainSubstring("WASMHookFailed")))
+		})
+
+		It("should give the hook the upstreams of the Backends without weight", func() {
+			gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+			listenerKey := graph.CreateGatewayListenerKey(gwNsName, "http")
+
+			route := &graph.L7Route{
+				Source: &gatewayv1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"},
+				},
+				RouteType: graph.RouteTypeHTTP,
+				Valid:     true,
+				ParentRefs: []graph.ParentRef{
+					{
+						Gateway: &graph.ParentRefGateway{NamespacedName: gwNsName},
+						Attachment: &graph.ParentRefAttachmentStatus{
+							Attached:          true,
+							AcceptedHostnames: map[string][]string{listenerKey: {"foo.example.com"}},
+						},
+					},
+				},
+				Spec: graph.L7RouteSpec{
+					Rules: []graph.RouteRule{
+						{
+							ValidMatches: true,
+							Filters:      graph.RouteRuleFilters{Valid: true},
+							Matches: []gatewayv1.HTTPRouteMatch{
+								{
+									Path: &gatewayv1.HTTPPathMatch{
+										Type:  helpers.GetPointer(gatewayv1.PathMatchPathPrefix),
+										Value: helpers.GetPointer("/"),
+									},
+								},
+							},
+							BackendRefs: []graph.BackendRef{
+								{
+									SvcNsName:   types.NamespacedName{Namespace: "test", Name: "stable"},
+									ServicePort: v1.ServicePort{Port: 80},
+									Weight:      1,
+									Valid:       true,
+								},
+								{
+									SvcNsName:   types.NamespacedName{Namespace: "test", Name: "canary"},
+									ServicePort: v1.ServicePort{Port: 80},
+									Weight:      0,
+									Valid:       true,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			gw := *baseGraph.Gateways[gwNsName]
+			gw.Listeners = []*graph.Listener{
+				{
+					Name:        "http",
+					GatewayName: gwNsName,
+					Source:      gatewayv1.Listener{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+					Routes:      map[graph.RouteKey]*graph.L7Route{graph.CreateRouteKey(route.Source): route},
+					Valid:       true,
+					Attachable:  true,
+				},
+			}
+
+			fakeProcessor.ProcessReturns(&graph.Graph{
+				GatewayClass: &graph.GatewayClass{Source: &gatewayv1.GatewayClass{}, Valid: true},
+				Gateways:     map[types.NamespacedName]*graph.Gateway{gwNsName: &gw},
+				Routes:       map[graph.RouteKey]*graph.L7Route{graph.CreateRouteKey(route.Source): route},
+			})
+			handler.cfg.serviceResolver = &resolverfakes.FakeServiceResolver{}
+
+			var hookUpstreams []string
+			hook.mutate = func(conf dataplanev1alpha1.Configuration) dataplanev1alpha1.Configuration {
+				for _, u := range conf.Upstreams {
+					hookUpstreams = append(hookUpstreams, u.Name)
+				}
+				return conf
+			}
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(hook.calls).To(Equal(1))
+			Expect(hookUpstreams).To(ConsistOf("test_canary_80", "test_stable_80"))
+			Expect(fakeEventRecorder.Events).To(BeEmpty())
+
+			// the upstream of the Backend without weight is removed after the hook
+			upstreams := fakeGenerator.GenerateArgsForCall(0).Upstreams
+			Expect(upstreams).To(HaveLen(1))
+			Expect(upstreams[0].Name).To(Equal("test_stable_80"))
+		})
+	})
+
+	Context("config history", func() {
+		It("should record the version of the configuration with the triggering resources", func() {
+			history := newConfigHistory(5)
+			handler.cfg.configHistory = history
+
+			fakeProcessor.ProcessReturns(baseGraph)
+			fakeGenerator.GenerateReturns([]agent.File{
+				{
+					Meta:     &pb.FileMeta{Name: "test.conf", Hash: "12345"},
+					Contents: []byte("test content"),
+				},
+			})
+
+			batch := []interface{}{
+				&events.UpsertEvent{
+					Resource: &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"}},
+				},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			versions := history.list(time.Time{})[types.NamespacedName{Namespace: "test", Name: "gateway"}]
+			Expect(versions).To(HaveLen(1))
+			Expect(versions[0].Triggers).To(Equal([]string{"HTTPRoute test/hr upserted"}))
+		})
+	})
+
+	Context("config rollback", func() {
+		batch := []interface{}{&events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}}
+
+		processGateway := func(annotations map[string]string) {
+			fakeProcessor.ProcessReturns(&graph.Graph{
+				Gateways: map[types.NamespacedName]*graph.Gateway{
+					{}: {
+						Source: &gatewayv1.Gateway{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace:   "test",
+								Name:        "gateway",
+								Annotations: annotations,
+							},
+						},
+						Valid: true,
+					},
+				},
+			})
+		}
+
+		It("should roll back and hold the configuration when the rollback is requested", func() {
+			processGateway(map[string]string{configRollbackAnnotation: "true"})
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeNginxUpdater.RollbackConfigCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(BeZero())
+			Expect(fakeNginxUpdater.UpdateUpstreamServersCallCount()).To(BeZero())
+		})
+
+		It("should hold the configuration when the rollback fails", func() {
+			processGateway(map[string]string{configRollbackAnnotation: "true"})
+			fakeNginxUpdater.RollbackConfigReturns(agent.ErrNoPreviousConfig)
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeNginxUpdater.RollbackConfigCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(BeZero())
+		})
+
+		It("should update the configuration when the rollback is not requested", func() {
+			processGateway(map[string]string{configRollbackAnnotation: "false"})
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeNginxUpdater.RollbackConfigCallCount()).To(BeZero())
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("agentless Gateways", func() {
+		var exporter *fakeExporter
+
+		gw := &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "gateway",
+			},
+		}
+		cfgFiles := []agent.File{
+			{
+				Meta: &pb.FileMeta{
+					Name: "test.conf",
+				},
+			},
+		}
+		batch := []interface{}{&events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}}
+
+		BeforeEach(func() {
+			exporter = &fakeExporter{}
+			handler.cfg.agentlessExporter = exporter
+
+			fakeProcessor.ProcessReturns(&graph.Graph{
+				Gateways: map[types.NamespacedName]*graph.Gateway{
+					{}: {
+						Source: gw,
+						Valid:  true,
+						EffectiveNginxProxy: &graph.EffectiveNginxProxy{
+							Kubernetes: &v1alpha2.KubernetesSpec{
+								Agentless: &v1alpha2.AgentlessSpec{Enable: true},
+							},
+						},
+					},
+				},
+			})
+			fakeGenerator.GenerateReturns(cfgFiles)
+		})
+
+		It("should deliver the configuration through the exporter instead of the agent", func() {
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(0))
+			Expect(exporter.gateways).To(Equal([]*gatewayv1.Gateway{gw}))
+			Expect(exporter.files).To(Equal([][]agent.File{cfgFiles}))
+		})
+
+		It("should not deliver the configuration when not leader", func() {
+			handler.leader = false
+
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(0))
+			Expect(exporter.gateways).To(BeEmpty())
+		})
+	})
+
+	Context("config apply latency", func() {
+		var collector *fakeMetricsCollector
+
+		gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+		batch := []interface{}{&events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}}
+
+		BeforeEach(func() {
+			collector = &fakeMetricsCollector{}
+			handler.cfg.metricsCollector = collector
+
+			fakeProcessor.ProcessReturns(baseGraph)
+		})
+
+		It("should observe the latency from receiving the batch to applying the configuration", func() {
+			receivedTime := time.Now().Add(-time.Second)
+			ctx := events.ContextWithBatchReceivedTime(context.Background(), receivedTime)
+
+			handler.HandleEventBatch(ctx, logr.Discard(), batch)
+
+			Expect(collector.configApplyLatencies).To(HaveKey(gwNsName))
+			Expect(collector.configApplyLatencies[gwNsName]).To(HaveLen(1))
+			Expect(collector.configApplyLatencies[gwNsName][0]).To(BeNumerically(">=", time.Second))
+		})
+
+		It("should not observe the latency if the time when the batch was received is unknown", func() {
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(collector.configApplyLatencies).To(BeEmpty())
+		})
+
+		It("should not observe the latency if applying the configuration failed", func() {
+			deployment := handler.cfg.nginxDeployments.GetOrStore(
+				context.Background(),
+				baseGraph.Gateways[gwNsName].DeploymentName,
+				nil,
+			)
+			deployment.SetLatestConfigError(errors.New("apply error"))
+
+			ctx := events.ContextWithBatchReceivedTime(context.Background(), time.Now())
+			handler.HandleEventBatch(ctx, logr.Discard(), batch)
+
+			Expect(collector.configApplyLatencies).To(BeEmpty())
+		})
+
+		It("should not observe the latency when not leader", func() {
+			handler.leader = false
+
+			ctx := events.ContextWithBatchReceivedTime(context.Background(), time.Now())
+			handler.HandleEventBatch(ctx, logr.Discard(), batch)
+
+			Expect(collector.configApplyLatencies).To(BeEmpty())
+		})
+	})
+
+	It("should observe the queue depth of the batch", func() {
+		observer := &fakeQueueDepthObserver{}
+		handler.cfg.queueDepthObserver = observer
+
+		fakeProcessor.ProcessReturns(nil)
+
+		batch := []interface{}{
+			&events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}},
+			&events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}},
+		}
+		handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+		Expect(observer.depths).To(Equal([]int{2}))
+	})
+
+	Context("data plane capabilities", func() {
+		It("should regenerate the configuration from the latest graph when the capabilities changed", func() {
+			fakeProcessor.ProcessReturns(nil)
+
+			batch := []interface{}{
+				&agent.CapabilitiesChangedEvent{Deployment: baseGraph.Gateways[types.NamespacedName{
+					Namespace: "test",
+					Name:      "gateway",
+				}].DeploymentName},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+
+			// the change is only handled once
+			handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{})
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+		})
+
+		It("should set the withheld features when receiving a queue event", func() {
+			obj := &status.QueueObject{
+				UpdateType: status.UpdateAll,
+				Deployment: types.NamespacedName{
+					Namespace: "test",
+					Name:      controller.CreateNginxResourceName("gateway", "nginx"),
+				},
+				WithheldFeatures: []string{"UpstreamResolve"},
+			}
+			queue.Enqueue(obj)
+
+			Eventually(
+				func() int {
+					return fakeStatusUpdater.UpdateGroupCallCount()
+				}).Should(Equal(2))
+
+			gr := handler.cfg.processor.GetLatestGraph()
+			gw := gr.Gateways[types.NamespacedName{Namespace: "test", Name: "gateway"}]
+			Expect(gw.LatestReloadResult.WithheldFeatures).To(Equal([]string{"UpstreamResolve"}))
+		})
+	})
+
+	Context("canary analysis", func() {
+		It("should regenerate the configuration from the latest graph when the canary analysis changed", func() {
+			fakeProcessor.ProcessReturns(nil)
+
+			route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"}}
+			batch := []interface{}{
+				&canaryAnalysisEvent{
+					changes: []canary.Change{
+						{Route: route, State: canary.StatePaused, Message: "Canary analysis failed"},
+					},
+				},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+			Expect(fakeEventRecorder.Events).To(Receive(Equal("Warning CanaryAnalysisPaused Canary analysis failed")))
+
+			// the change is only handled once
+			handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{})
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+		})
+
+		It("should not record the changes of the canary analysis when not leader", func() {
+			handler.leader = false
+			fakeProcessor.ProcessReturns(nil)
+
+			route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"}}
+			batch := []interface{}{
+				&canaryAnalysisEvent{
+					changes: []canary.Change{{Route: route, State: canary.StateHealthy, Message: "resumed"}},
+				},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeEventRecorder.Events).ToNot(Receive())
+		})
+	})
+
+	Context("ramp-up", func() {
+		It("should regenerate the configuration from the latest graph when the weights of the ramp-ups changed", func() {
+			fakeProcessor.ProcessReturns(nil)
+
+			route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"}}
+			batch := []interface{}{
+				&rampUpEvent{
+					changes: []rampup.Change{
+						{Route: route, Reason: rampup.ReasonStarted, Message: "Ramp-up started"},
+					},
+				},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+			Expect(fakeEventRecorder.Events).To(Receive(Equal("Normal RampUpStarted Ramp-up started")))
 
-				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+			// the change is only handled once
+			handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{})
 
-				checkUpsertEventExpectations(e)
-				// status update should still occur for GatewayClasses
-				Eventually(
-					func() int {
-						return fakeStatusUpdater.UpdateGroupCallCount()
-					}).Should(Equal(1))
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+		})
+
+		It("should not record the changes of the ramp-ups when not leader", func() {
+			handler.leader = false
+			fakeProcessor.ProcessReturns(nil)
+
+			route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"}}
+			batch := []interface{}{
+				&rampUpEvent{
+					changes: []rampup.Change{{Route: route, Reason: rampup.ReasonCompleted, Message: "completed"}},
+				},
+			}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeEventRecorder.Events).ToNot(Receive())
+		})
+	})
+
+	Context("reload governor", func() {
+		BeforeEach(func() {
+			handler.cfg.reloadGovernor = governor.New(governor.Config{
+				Collector:   collectors.NewReloadGovernorNoopCollector(),
+				Notify:      func(types.NamespacedName) {},
+				MinInterval: time.Hour,
 			})
+
+			// the configuration version of the Deployment changes like in a reload
+			fakeNginxUpdater.UpdateConfigStub = func(
+				deployment *agent.Deployment,
+				files []agent.File,
+				volumeMounts []v1.VolumeMount,
+			) {
+				deployment.SetFiles(files, volumeMounts)
+			}
 		})
 
-		When("a batch has multiple events", func() {
-			It("should process events", func() {
-				upsertEvent := &events.UpsertEvent{Resource: &gatewayv1.Gateway{}}
-				deleteEvent := &events.DeleteEvent{
-					Type:           &gatewayv1.HTTPRoute{},
-					NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"},
-				}
-				batch := []interface{}{upsertEvent, deleteEvent}
+		It("should defer the reload within the minimum interval", func() {
+			fakeProcessor.ProcessReturns(baseGraph)
 
-				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+			handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{})
 
-				checkUpsertEventExpectations(upsertEvent)
-				checkDeleteEventExpectations(deleteEvent)
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
 
-				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+			handler.nginxErrorLevelOverride = "debug"
+			handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{})
 
-				dcfg := dataplane.GetDefaultConfiguration(&graph.Graph{}, &graph.Gateway{})
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+		})
 
-				config := handler.GetLatestConfiguration()
-				Expect(config).To(HaveLen(1))
-				Expect(helpers.Diff(config[0], &dcfg)).To(BeEmpty())
-			})
+		It("should regenerate the configuration from the latest graph when a deferred reload is due", func() {
+			fakeProcessor.ProcessReturns(nil)
+
+			deployment := baseGraph.Gateways[types.NamespacedName{Namespace: "test", Name: "gateway"}].DeploymentName
+			batch := []interface{}{&reloadGovernorEvent{deployment: deployment}}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+
+			// the reload is only regenerated once
+			handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{})
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
 		})
 	})
 
-	When("receiving control plane configuration updates", func() {
-		cfg := func(level ngfAPI.ControllerLogLevel) *ngfAPI.NginxGateway {
-			return &ngfAPI.NginxGateway{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: namespace,
-					Name:      configName,
+	Context("outlier hook", func() {
+		gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+		evaluate := func(action outlier.Action) []outlier.Change {
+			route := &graph.L7Route{
+				Source: &gatewayv1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "route",
+						Annotations: map[string]string{
+							outlier.MaxErrorRateAnnotation: "0.1",
+							outlier.ActionAnnotation:       string(action),
+						},
+					},
 				},
-				Spec: ngfAPI.NginxGatewaySpec{
-					Logging: &ngfAPI.Logging{
-						Level: helpers.GetPointer(level),
+				RouteType: graph.RouteTypeHTTP,
+				Valid:     true,
+				ParentRefs: []graph.ParentRef{
+					{
+						Gateway:    &graph.ParentRefGateway{NamespacedName: gwNsName},
+						Attachment: &graph.ParentRefAttachmentStatus{Attached: true},
+					},
+				},
+				Spec: graph.L7RouteSpec{
+					Rules: []graph.RouteRule{
+						{
+							BackendRefs: []graph.BackendRef{
+								{
+									SvcNsName:   types.NamespacedName{Namespace: "test", Name: "backend"},
+									ServicePort: v1.ServicePort{Port: 80},
+									Weight:      1,
+									Valid:       true,
+								},
+							},
+						},
 					},
 				},
 			}
-		}
 
-		It("handles a valid config", func() {
-			batch := []interface{}{&events.UpsertEvent{Resource: cfg(ngfAPI.ControllerLogLevelError)}}
-			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+			metrics := &canaryfakes.FakeMetricsProvider{}
+			metrics.ErrorRateReturns(0.5, nil)
 
-			Expect(handler.GetLatestConfiguration()).To(BeEmpty())
+			handler.cfg.outlierHook = outlier.NewHook(logr.Discard(), metrics)
 
-			Eventually(
-				func() int {
-					return fakeStatusUpdater.UpdateGroupCallCount()
-				}).Should(BeNumerically(">", 1))
+			gr := &graph.Graph{
+				Gateways: baseGraph.Gateways,
+				Routes:   map[graph.RouteKey]*graph.L7Route{graph.CreateRouteKey(route.Source): route},
+			}
 
-			_, name, reqs := fakeStatusUpdater.UpdateGroupArgsForCall(0)
-			Expect(name).To(Equal(groupControlPlane))
-			Expect(reqs).To(HaveLen(1))
+			changes := handler.cfg.outlierHook.Evaluate(context.Background(), gr)
+			Expect(changes).To(HaveLen(1))
 
-			Expect(zapLogLevelSetter.Enabled(zap.DebugLevel)).To(BeFalse())
-			Expect(zapLogLevelSetter.Enabled(zap.ErrorLevel)).To(BeTrue())
-		})
+			return changes
+		}
 
-		It("handles an invalid config", func() {
-			batch := []interface{}{&events.UpsertEvent{Resource: cfg(ngfAPI.ControllerLogLevel("invalid"))}}
-			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+		It("should raise the NGINX error log level of the Gateways of a triggered DebugLogging action", func() {
+			fakeProcessor.ProcessReturns(nil)
 
-			Expect(handler.GetLatestConfiguration()).To(BeEmpty())
+			changes := evaluate(outlier.ActionDebugLogging)
+			handler.HandleEventBatch(
+				context.Background(),
+				logr.Discard(),
+				[]interface{}{&outlierHookEvent{changes: changes}},
+			)
 
-			Eventually(
-				func() int {
-					return fakeStatusUpdater.UpdateGroupCallCount()
-				}).Should(BeNumerically(">", 1))
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeEventRecorder.Events).To(Receive(HavePrefix("Warning OutlierHookTriggered Outlier hook triggered")))
 
-			_, name, reqs := fakeStatusUpdater.UpdateGroupArgsForCall(0)
-			Expect(name).To(Equal(groupControlPlane))
-			Expect(reqs).To(HaveLen(1))
+			configs := handler.GetLatestConfiguration()
+			Expect(configs).To(HaveLen(1))
+			Expect(configs[0].Logging.ErrorLevel).To(Equal("debug"))
+		})
 
-			Expect(fakeEventRecorder.Events).To(HaveLen(1))
-			event := <-fakeEventRecorder.Events
-			Expect(event).To(Equal(
-				"Warning UpdateFailed Failed to update control plane configuration: logging.level: Unsupported value: " +
-					"\"invalid\": supported values: \"info\", \"debug\", \"error\"",
+		It("should annotate the nginx Pods of the Gateways of an AnnotatePods action", func() {
+			fakeProcessor.ProcessReturns(nil)
+
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "gateway-nginx-1",
+					Labels: map[string]string{
+						controller.AppNameLabel: controller.CreateNginxResourceName("gateway", "nginx"),
+					},
+				},
+			}
+			Expect(fakeK8sClient.Create(context.Background(), pod)).To(Succeed())
+
+			changes := evaluate(outlier.ActionAnnotatePods)
+			handler.HandleEventBatch(
+				context.Background(),
+				logr.Discard(),
+				[]interface{}{&outlierHookEvent{changes: changes}},
+			)
+
+			Expect(fakeK8sClient.Get(context.Background(), client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+			Expect(pod.Annotations).To(HaveKeyWithValue(
+				outlier.CaptureUntilAnnotation,
+				changes[0].Until.UTC().Format(time.RFC3339),
 			))
-			Expect(zapLogLevelSetter.Enabled(zap.InfoLevel)).To(BeTrue())
+			Expect(fakeEventRecorder.Events).To(Receive(HavePrefix("Warning OutlierHookTriggered")))
+
+			configs := handler.GetLatestConfiguration()
+			Expect(configs).To(HaveLen(1))
+			Expect(configs[0].Logging.ErrorLevel).To(Equal("info"))
+
+			expired := changes[0]
+			expired.State = outlier.StateExpired
+			handler.HandleEventBatch(
+				context.Background(),
+				logr.Discard(),
+				[]interface{}{&outlierHookEvent{changes: []outlier.Change{expired}}},
+			)
+
+			Expect(fakeK8sClient.Get(context.Background(), client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+			Expect(pod.Annotations).ToNot(HaveKey(outlier.CaptureUntilAnnotation))
+			Expect(fakeEventRecorder.Events).To(Receive(HavePrefix("Normal OutlierHookExpired")))
 		})
+	})
 
-		It("handles a deleted config", func() {
-			batch := []interface{}{
-				&events.DeleteEvent{
-					Type: &ngfAPI.NginxGateway{},
-					NamespacedName: types.NamespacedName{
-						Namespace: namespace,
-						Name:      configName,
+	Context("traffic routing steps", func() {
+		gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+		routeNsName := types.NamespacedName{Namespace: "test", Name: "route"}
+
+		processRoute := func(annotations map[string]string) *graph.Graph {
+			route := &graph.L7Route{
+				Source: &gatewayv1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   routeNsName.Namespace,
+						Name:        routeNsName.Name,
+						Annotations: annotations,
+					},
+				},
+				RouteType: graph.RouteTypeHTTP,
+				Valid:     true,
+				ParentRefs: []graph.ParentRef{
+					{
+						Gateway:    &graph.ParentRefGateway{NamespacedName: gwNsName},
+						Attachment: &graph.ParentRefAttachmentStatus{Attached: true},
 					},
 				},
+				Spec: graph.L7RouteSpec{
+					Rules: []graph.RouteRule{
+						{
+							BackendRefs: []graph.BackendRef{
+								{
+									SvcNsName:   types.NamespacedName{Namespace: "test", Name: "canary"},
+									ServicePort: v1.ServicePort{Port: 80},
+									Weight:      20,
+									Valid:       true,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			gr := &graph.Graph{
+				Gateways: baseGraph.Gateways,
+				Routes:   map[graph.RouteKey]*graph.L7Route{graph.CreateRouteKey(route.Source): route},
 			}
+			fakeProcessor.ProcessReturns(gr)
+
+			return gr
+		}
+
+		batch := []interface{}{&events.UpsertEvent{Resource: &gatewayv1.HTTPRoute{}}}
+
+		It("should record the steps of the Routes once the configuration is applied", func() {
+			processRoute(map[string]string{trafficrouting.StepAnnotation: "canary-weight-20"})
+
 			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
 
-			Expect(handler.GetLatestConfiguration()).To(BeEmpty())
+			Expect(handler.getAppliedTrafficRoutingSteps()).To(Equal(map[types.NamespacedName]trafficrouting.Steps{
+				gwNsName: {routeNsName: "canary-weight-20"},
+			}))
+		})
 
-			Eventually(
-				func() int {
-					return fakeStatusUpdater.UpdateGroupCallCount()
-				}).Should(BeNumerically(">", 1))
+		It("should not record the steps of the Routes whose weights are held by the canary analysis", func() {
+			gr := processRoute(map[string]string{
+				trafficrouting.StepAnnotation: "canary-weight-20",
+				canary.BackendAnnotation:      "canary",
+				canary.MaxErrorRateAnnotation: "0.1",
+			})
 
-			_, name, reqs := fakeStatusUpdater.UpdateGroupArgsForCall(0)
-			Expect(name).To(Equal(groupControlPlane))
-			Expect(reqs).To(BeEmpty())
+			metrics := &canaryfakes.FakeMetricsProvider{}
+			metrics.ErrorRateReturns(0.5, nil)
+			handler.cfg.canaryAnalyzer = canary.NewAnalyzer(logr.Discard(), metrics)
+			Expect(handler.cfg.canaryAnalyzer.Analyze(context.Background(), gr)).To(HaveLen(1))
 
-			Expect(fakeEventRecorder.Events).To(HaveLen(1))
-			event := <-fakeEventRecorder.Events
-			Expect(event).To(Equal("Warning ResourceDeleted NginxGateway configuration was deleted; using defaults"))
-			Expect(zapLogLevelSetter.Enabled(zap.InfoLevel)).To(BeTrue())
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(handler.getAppliedTrafficRoutingSteps()).To(Equal(map[types.NamespacedName]trafficrouting.Steps{
+				gwNsName: {},
+			}))
 		})
 	})
 
-	Context("NGINX Plus API calls", func() {
-		e := &events.UpsertEvent{Resource: &discoveryV1.EndpointSlice{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "nginx-gateway",
-				Namespace: "nginx-gateway",
-			},
-		}}
-		batch := []interface{}{e}
+	Context("stale resources", func() {
+		gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+		staleDeploymentName := types.NamespacedName{
+			Namespace: "test",
+			Name:      controller.CreateNginxResourceName("deleted-gateway", "nginx"),
+		}
 
-		BeforeEach(func() {
-			fakeProcessor.ProcessReturns(&graph.Graph{
-				Gateways: map[types.NamespacedName]*graph.Gateway{
-					{}: {
-						Source: &gatewayv1.Gateway{
-							ObjectMeta: metav1.ObjectMeta{
-								Namespace: "test",
-								Name:      "gateway",
+		It("should remove the nginx Deployments and configurations of the Gateways that no longer exist", func() {
+			fakeProcessor.ProcessReturns(baseGraph)
+
+			handler.cfg.nginxDeployments.GetOrStore(context.Background(), staleDeploymentName, nil)
+			handler.latestConfigurations[types.NamespacedName{Namespace: "test", Name: "deleted-gateway"}] =
+				&dataplane.Configuration{}
+
+			batch := []interface{}{&events.DeleteEvent{
+				Type:           &gatewayv1.Gateway{},
+				NamespacedName: types.NamespacedName{Namespace: "test", Name: "deleted-gateway"},
+			}}
+			handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+
+			Expect(handler.cfg.nginxDeployments.List()).To(HaveLen(1))
+			Expect(handler.cfg.nginxDeployments.Get(staleDeploymentName)).To(BeNil())
+			Expect(handler.cfg.nginxDeployments.Get(baseGraph.Gateways[gwNsName].DeploymentName)).ToNot(BeNil())
+
+			Expect(handler.latestConfigurations).To(HaveLen(1))
+			Expect(handler.latestConfigurations).To(HaveKey(gwNsName))
+		})
+
+		It("should clear the statuses of the Routes that are no longer handled by any Gateway", func() {
+			const gatewayCtlrName = "gateway.nginx.org/nginx-gateway-controller"
+
+			staleRoute := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "stale-route"},
+				Status: gatewayv1.HTTPRouteStatus{
+					RouteStatus: gatewayv1.RouteStatus{
+						Parents: []gatewayv1.RouteParentStatus{
+							{
+								ParentRef:      gatewayv1.ParentReference{Name: "deleted-gateway"},
+								ControllerName: gatewayCtlrName,
 							},
 						},
-						Valid: true,
 					},
 				},
-			})
-		})
-
-		When("running NGINX Plus", func() {
-			It("should call the NGINX Plus API", func() {
-				handler.cfg.plus = true
+			}
 
-				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+			handler.cfg.gatewayCtlrName = gatewayCtlrName
+			handler.cfg.k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(
+					staleRoute,
+					&v1.Service{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway-nginx"},
+						Spec:       v1.ServiceSpec{ClusterIP: "1.2.3.4"},
+					},
+				).
+				Build()
 
-				dcfg := dataplane.GetDefaultConfiguration(&graph.Graph{}, &graph.Gateway{})
-				dcfg.NginxPlus = dataplane.NginxPlus{AllowedAddresses: []string{"127.0.0.1"}}
+			obj := &status.QueueObject{
+				UpdateType: status.UpdateAll,
+				Deployment: baseGraph.Gateways[gwNsName].DeploymentName,
+			}
+			queue.Enqueue(obj)
 
-				config := handler.GetLatestConfiguration()
-				Expect(config).To(HaveLen(1))
-				Expect(helpers.Diff(config[0], &dcfg)).To(BeEmpty())
+			Eventually(
+				func() int {
+					return fakeStatusUpdater.UpdateGroupCallCount()
+				}).Should(Equal(2))
 
-				Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
-				Expect(fakeNginxUpdater.UpdateUpstreamServersCallCount()).To(Equal(1))
-			})
+			_, name, reqs := fakeStatusUpdater.UpdateGroupArgsForCall(0)
+			Expect(name).To(Equal(groupAllExceptGateways))
+			Expect(reqs).To(HaveLen(1))
+			Expect(reqs[0].NsName).To(Equal(client.ObjectKeyFromObject(staleRoute)))
 		})
 
-		When("not running NGINX Plus", func() {
-			It("should not call the NGINX Plus API", func() {
-				handler.HandleEventBatch(context.Background(), logr.Discard(), batch)
+		It("should regenerate the configuration from the latest graph on a consistency sweep", func() {
+			fakeProcessor.ProcessReturns(nil)
 
-				dcfg := dataplane.GetDefaultConfiguration(&graph.Graph{}, &graph.Gateway{})
+			handler.cfg.nginxDeployments.GetOrStore(context.Background(), staleDeploymentName, nil)
 
-				config := handler.GetLatestConfiguration()
-				Expect(config).To(HaveLen(1))
-				Expect(helpers.Diff(config[0], &dcfg)).To(BeEmpty())
+			handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{&consistencySweepEvent{}})
 
-				Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
-				Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
-				Expect(fakeNginxUpdater.UpdateUpstreamServersCallCount()).To(Equal(0))
-			})
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeNginxUpdater.UpdateConfigCallCount()).To(Equal(1))
+			Expect(handler.cfg.nginxDeployments.Get(staleDeploymentName)).To(BeNil())
+
+			// the sweep is only done once
+			handler.HandleEventBatch(context.Background(), logr.Discard(), []interface{}{})
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
 		})
 	})
 
@@ -772,7 +1868,7 @@ var _ = Describe("getGatewayAddresses", func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 		defer cancel()
 
-		addrs, err := getGatewayAddresses(ctx, fakeClient, nil, gateway, "nginx")
+		addrs, err := getGatewayAddresses(ctx, fakeClient, fakeClient, nil, gateway, "nginx")
 		Expect(err).To(HaveOccurred())
 		Expect(addrs).To(BeNil())
 
@@ -801,7 +1897,7 @@ var _ = Describe("getGatewayAddresses", func() {
 
 		Expect(fakeClient.Create(context.Background(), &svc)).To(Succeed())
 
-		addrs, err = getGatewayAddresses(context.Background(), fakeClient, &svc, gateway, "nginx")
+		addrs, err = getGatewayAddresses(context.Background(), fakeClient, fakeClient, &svc, gateway, "nginx")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(addrs).To(HaveLen(4))
 		Expect(addrs[0].Value).To(Equal("34.35.36.37"))
@@ -824,12 +1920,23 @@ var _ = Describe("getGatewayAddresses", func() {
 
 		Expect(fakeClient.Create(context.Background(), &svc)).To(Succeed())
 
-		addrs, err = getGatewayAddresses(context.Background(), fakeClient, &svc, gateway, "nginx")
+		addrs, err = getGatewayAddresses(context.Background(), fakeClient, fakeClient, &svc, gateway, "nginx")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(addrs).To(HaveLen(3))
 		Expect(addrs[0].Value).To(Equal("12.13.14.15"))
 		Expect(addrs[1].Value).To(Equal("192.0.2.1"))
 		Expect(addrs[2].Value).To(Equal("192.0.2.3"))
+
+		// Add the address allocated by the IPAM
+		svc.Spec.ExternalIPs = []string{"198.51.100.10"}
+
+		addrs, err = getGatewayAddresses(context.Background(), fakeClient, fakeClient, &svc, gateway, "nginx")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(addrs).To(HaveLen(4))
+		Expect(addrs[0].Value).To(Equal("12.13.14.15"))
+		Expect(addrs[1].Value).To(Equal("198.51.100.10"))
+		Expect(addrs[2].Value).To(Equal("192.0.2.1"))
+		Expect(addrs[3].Value).To(Equal("192.0.2.3"))
 	})
 })
 
@@ -1052,3 +2159,101 @@ func (*badFakeClient) Create(context.Context, client.Object, ...client.CreateOpt
 func (*badFakeClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
 	return errors.New("update error")
 }
+
+type fakeExporter struct {
+	err      error
+	gateways []*gatewayv1.Gateway
+	files    [][]agent.File
+}
+
+func (f *fakeExporter) Export(_ context.Context, gateway *gatewayv1.Gateway, files []agent.File) error {
+	f.gateways = append(f.gateways, gateway)
+	f.files = append(f.files, files)
+
+	return f.err
+}
+
+type fakeWASMHookRunner struct {
+	err     error
+	mutate  func(dataplanev1alpha1.Configuration) dataplanev1alpha1.Configuration
+	calls   int
+	pending bool
+}
+
+func (f *fakeWASMHookRunner) Result(
+	_ context.Context,
+	_ types.NamespacedName,
+	conf dataplanev1alpha1.Configuration,
+) (wasmhook.Result, bool) {
+	f.calls++
+
+	if f.pending {
+		return wasmhook.Result{}, false
+	}
+
+	if f.err != nil {
+		return wasmhook.Result{Err: f.err}, true
+	}
+
+	if f.mutate != nil {
+		conf = f.mutate(conf)
+	}
+
+	return wasmhook.Result{Configuration: conf}, true
+}
+
+func (f *fakeWASMHookRunner) Retain(func(types.NamespacedName) bool) {}
+
+type fakeUpstreamMapPublisher struct {
+	err      error
+	gateways []*gatewayv1.Gateway
+	mappings []upstreammap.Mapping
+}
+
+func (f *fakeUpstreamMapPublisher) Publish(
+	_ context.Context,
+	gateway *gatewayv1.Gateway,
+	mapping upstreammap.Mapping,
+) error {
+	f.gateways = append(f.gateways, gateway)
+	f.mappings = append(f.mappings, mapping)
+
+	return f.err
+}
+
+type fakeMetricsCollector struct {
+	configApplyLatencies map[types.NamespacedName][]time.Duration
+}
+
+func (f *fakeMetricsCollector) ObserveLastEventBatchProcessTime(time.Duration) {}
+
+func (f *fakeMetricsCollector) ObserveConfigApplyLatency(gateway types.NamespacedName, duration time.Duration) {
+	if f.configApplyLatencies == nil {
+		f.configApplyLatencies = make(map[types.NamespacedName][]time.Duration)
+	}
+
+	f.configApplyLatencies[gateway] = append(f.configApplyLatencies[gateway], duration)
+}
+
+type fakeQueueDepthObserver struct {
+	depths []int
+}
+
+func (f *fakeQueueDepthObserver) ObserveQueueDepth(depth int) {
+	f.depths = append(f.depths, depth)
+}
+
+type fakeModuleLogLevelSetter struct {
+	err    error
+	levels map[string]string
+}
+
+func (f *fakeModuleLogLevelSetter) SetModuleLevels(levels map[string]string) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.levels = levels
+
+	return nil
+}
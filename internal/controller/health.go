@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"sync"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/health"
 )
 
 // newGraphBuiltHealthChecker creates a new graphBuiltHealthChecker.
@@ -47,3 +50,36 @@ func (h *graphBuiltHealthChecker) setAsReady() {
 func (h *graphBuiltHealthChecker) getReadyCh() <-chan struct{} {
 	return h.readyCh
 }
+
+// cacheSyncer waits for the informer caches to sync.
+type cacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// newCacheSyncHealthReporter creates a new cacheSyncHealthReporter.
+func newCacheSyncHealthReporter(cache cacheSyncer, tracker *health.Tracker) *cacheSyncHealthReporter {
+	return &cacheSyncHealthReporter{
+		cache:   cache,
+		tracker: tracker,
+	}
+}
+
+// cacheSyncHealthReporter is a runnable that marks the cache-sync component as healthy once the informer caches
+// are synced.
+type cacheSyncHealthReporter struct {
+	cache   cacheSyncer
+	tracker *health.Tracker
+}
+
+// Start waits for the informer caches to sync and reports the health of the cache-sync component.
+// It satisfies the controller-runtime Runnable interface.
+func (r *cacheSyncHealthReporter) Start(ctx context.Context) error {
+	if !r.cache.WaitForCacheSync(ctx) {
+		r.tracker.SetUnhealthy(health.CacheSync, errors.New("informer caches did not sync"))
+		return nil
+	}
+
+	r.tracker.SetHealthy(health.CacheSync)
+
+	return nil
+}
@@ -0,0 +1,16 @@
+/*
+Package health tracks the health of the components of the control plane.
+
+The readiness of the control plane is a single check, which passes once the first graph is built. A component that
+fails after that, like the status updater when the API server rejects the status updates, doesn't fail the readiness
+check, so the failure is masked by the Pod being ready. The Tracker holds the health of every component separately:
+
+  - cache-sync is healthy once the informer caches are synced.
+  - graph-builder is healthy once the first graph is built.
+  - agent-connectivity is healthy while the gRPC server for the nginx agents is serving.
+  - status-updater is unhealthy while the last status updates failed.
+
+Every component is exposed as a healthz check of the manager, at /healthz/<component> and in the verbose output of
+/healthz, and as a gauge through the MetricsCollector.
+*/
+package health
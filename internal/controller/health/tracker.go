@@ -0,0 +1,109 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// Component is a component of the control plane with its own health.
+type Component string
+
+const (
+	// CacheSync is the component for the sync of the informer caches.
+	CacheSync Component = "cache-sync"
+	// GraphBuilder is the component for building the graph of the resources.
+	GraphBuilder Component = "graph-builder"
+	// AgentConnectivity is the component for the gRPC server that the nginx agents connect to.
+	AgentConnectivity Component = "agent-connectivity"
+	// StatusUpdater is the component for updating the statuses of the resources.
+	StatusUpdater Component = "status-updater"
+)
+
+// Components are all components of the control plane.
+var Components = []Component{CacheSync, GraphBuilder, AgentConnectivity, StatusUpdater}
+
+// MetricsCollector is an interface for the metrics of the health of the components of the control plane.
+type MetricsCollector interface {
+	// SetComponentHealth records whether the component is healthy.
+	SetComponentHealth(component string, healthy bool)
+}
+
+// Tracker tracks the health of the components of the control plane.
+// SetHealthy and SetUnhealthy of a nil Tracker do nothing, so that the components don't need to check whether
+// the health is tracked.
+type Tracker struct {
+	collector MetricsCollector
+	// errs holds the reason why every unhealthy component is unhealthy. Healthy components have a nil error.
+	errs map[Component]error
+	lock sync.RWMutex
+}
+
+// NewTracker creates a new Tracker for the components. Every component starts unhealthy, until it reports
+// that it is healthy.
+func NewTracker(collector MetricsCollector, components ...Component) *Tracker {
+	t := &Tracker{
+		collector: collector,
+		errs:      make(map[Component]error, len(components)),
+	}
+
+	for _, c := range components {
+		t.errs[c] = fmt.Errorf("%s has not reported its health yet", c)
+		collector.SetComponentHealth(string(c), false)
+	}
+
+	return t
+}
+
+// SetHealthy marks the component as healthy.
+func (t *Tracker) SetHealthy(component Component) {
+	t.set(component, nil)
+}
+
+// SetUnhealthy marks the component as unhealthy because of the error.
+func (t *Tracker) SetUnhealthy(component Component, err error) {
+	if err == nil {
+		err = errors.New("unknown error")
+	}
+
+	t.set(component, err)
+}
+
+func (t *Tracker) set(component Component, err error) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.errs[component] = err
+	t.collector.SetComponentHealth(string(component), err == nil)
+}
+
+// Check returns the error that made the component unhealthy, or nil if the component is healthy.
+func (t *Tracker) Check(component Component) error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	err, exists := t.errs[component]
+	if !exists {
+		return fmt.Errorf("%s is not tracked", component)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s is unhealthy: %w", component, err)
+	}
+
+	return nil
+}
+
+// Checker returns the healthz.Checker of the component.
+func (t *Tracker) Checker(component Component) healthz.Checker {
+	return func(_ *http.Request) error {
+		return t.Check(component)
+	}
+}
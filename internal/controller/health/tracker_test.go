@@ -0,0 +1,63 @@
+package health
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type healthRecord struct {
+	component string
+	healthy   bool
+}
+
+type fakeCollector struct {
+	records []healthRecord
+}
+
+func (f *fakeCollector) SetComponentHealth(component string, healthy bool) {
+	f.records = append(f.records, healthRecord{component: component, healthy: healthy})
+}
+
+func TestTracker(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	collector := &fakeCollector{}
+	tracker := NewTracker(collector, CacheSync, StatusUpdater)
+
+	g.Expect(collector.records).To(Equal([]healthRecord{
+		{component: "cache-sync", healthy: false},
+		{component: "status-updater", healthy: false},
+	}))
+	g.Expect(tracker.Check(CacheSync)).To(MatchError(ContainSubstring("has not reported its health yet")))
+	g.Expect(tracker.Check(GraphBuilder)).To(MatchError("graph-builder is not tracked"))
+
+	tracker.SetHealthy(CacheSync)
+	g.Expect(tracker.Check(CacheSync)).To(Succeed())
+	g.Expect(tracker.Checker(CacheSync)(nil)).To(Succeed())
+
+	g.Expect(collector.records[2]).To(Equal(healthRecord{component: "cache-sync", healthy: true}))
+
+	tracker.SetUnhealthy(StatusUpdater, errors.New("update failed"))
+	g.Expect(tracker.Checker(StatusUpdater)(nil)).To(MatchError("status-updater is unhealthy: update failed"))
+	g.Expect(tracker.Check(CacheSync)).To(Succeed())
+
+	g.Expect(collector.records[3]).To(Equal(healthRecord{component: "status-updater", healthy: false}))
+
+	tracker.SetUnhealthy(StatusUpdater, nil)
+	g.Expect(tracker.Check(StatusUpdater)).To(MatchError("status-updater is unhealthy: unknown error"))
+}
+
+func TestNilTracker(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var tracker *Tracker
+
+	g.Expect(func() {
+		tracker.SetHealthy(CacheSync)
+		tracker.SetUnhealthy(CacheSync, errors.New("error"))
+	}).ToNot(Panic())
+}
@@ -1,9 +1,13 @@
 package controller
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/health"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics/collectors"
 )
 
 func TestReadyCheck(t *testing.T) {
@@ -15,3 +19,26 @@ func TestReadyCheck(t *testing.T) {
 	healthChecker.ready = true
 	g.Expect(healthChecker.readyCheck(nil)).To(Succeed())
 }
+
+type fakeCacheSyncer struct {
+	synced bool
+}
+
+func (f fakeCacheSyncer) WaitForCacheSync(_ context.Context) bool {
+	return f.synced
+}
+
+func TestCacheSyncHealthReporter(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tracker := health.NewTracker(collectors.NewComponentHealthNoopCollector(), health.CacheSync)
+
+	reporter := newCacheSyncHealthReporter(fakeCacheSyncer{synced: false}, tracker)
+	g.Expect(reporter.Start(context.Background())).To(Succeed())
+	g.Expect(tracker.Check(health.CacheSync)).To(MatchError(ContainSubstring("informer caches did not sync")))
+
+	reporter = newCacheSyncHealthReporter(fakeCacheSyncer{synced: true}, tracker)
+	g.Expect(reporter.Start(context.Background())).To(Succeed())
+	g.Expect(tracker.Check(health.CacheSync)).To(Succeed())
+}
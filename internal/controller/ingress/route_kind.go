@@ -0,0 +1,389 @@
+package ingress
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+const (
+	// ClassAnnotation is the legacy annotation with the IngressClass of an Ingress, which is used if
+	// spec.ingressClassName is not set.
+	ClassAnnotation = "kubernetes.io/ingress.class"
+
+	// RewriteTargetAnnotation replaces the matched path of the requests with its value, for example, /.
+	RewriteTargetAnnotation = "nginx.ingress.kubernetes.io/rewrite-target"
+	// PermanentRedirectAnnotation redirects the requests to its value, which is a URL, with the 301 status code.
+	PermanentRedirectAnnotation = "nginx.ingress.kubernetes.io/permanent-redirect"
+	// UpstreamVhostAnnotation sets the Host header of the requests to the backends to its value, which is
+	// a hostname.
+	UpstreamVhostAnnotation = "nginx.ingress.kubernetes.io/upstream-vhost"
+)
+
+// Config is the configuration of the Ingresses that are translated.
+type Config struct {
+	// Logger logs the Ingresses that can't be translated.
+	Logger logr.Logger
+	// ClassName is the IngressClass of the translated Ingresses. The Ingresses of other classes are ignored.
+	ClassName string
+	// Gateway is the Gateway that the translated Ingresses attach to.
+	Gateway types.NamespacedName
+}
+
+// RouteKind is the graph.CustomRouteKind of the networking.k8s.io/v1 Ingresses. It translates the Ingresses of
+// an IngressClass into HTTPRoutes attached to a Gateway, so that the Ingresses and the Gateway API routes can be
+// served by the same data plane during a migration from Ingress.
+//
+// The listeners of the Gateway must allow the Ingress kind in allowedRoutes.kinds. TLS is terminated by the
+// listeners of the Gateway, so spec.tls of the Ingresses is ignored. The status of the Ingresses is not written;
+// the Ingresses that can't be translated are logged.
+type RouteKind struct {
+	// reported holds the generations of the Ingresses whose translation errors were logged.
+	reported map[types.NamespacedName]int64
+	cfg      Config
+	lock     sync.Mutex
+}
+
+// NewRouteKind returns a new RouteKind.
+func NewRouteKind(cfg Config) *RouteKind {
+	return &RouteKind{
+		cfg:      cfg,
+		reported: make(map[types.NamespacedName]int64),
+	}
+}
+
+// GroupVersionKind returns the GroupVersionKind of the Ingresses.
+func (k *RouteKind) GroupVersionKind() schema.GroupVersionKind {
+	return networkingv1.SchemeGroupVersion.WithKind(kinds.Ingress)
+}
+
+// HasRouteStatus returns false, because the status of the Ingresses doesn't have status.parents.
+func (k *RouteKind) HasRouteStatus() bool {
+	return false
+}
+
+// Translate translates the spec of an Ingress into the spec of an HTTPRoute. If the Ingress doesn't belong to
+// the IngressClass, Translate returns an empty spec, so that the Ingress isn't attached to any Gateway.
+func (k *RouteKind) Translate(route *unstructured.Unstructured) (gatewayv1.HTTPRouteSpec, error) {
+	var ing networkingv1.Ingress
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(route.Object, &ing); err != nil {
+		return gatewayv1.HTTPRouteSpec{}, fmt.Errorf("cannot convert Ingress: %w", err)
+	}
+
+	if ingressClass(&ing) != k.cfg.ClassName {
+		return gatewayv1.HTTPRouteSpec{}, nil
+	}
+
+	spec, err := k.translate(&ing)
+	if err != nil {
+		k.logError(&ing, err)
+	}
+
+	return spec, err
+}
+
+// logError logs the translation error of an Ingress once per generation of the Ingress, because the Ingresses
+// are translated every time the cluster state changes.
+func (k *RouteKind) logError(ing *networkingv1.Ingress, err error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	nsname := types.NamespacedName{Namespace: ing.Namespace, Name: ing.Name}
+	if gen, exists := k.reported[nsname]; exists && gen == ing.Generation {
+		return
+	}
+
+	k.reported[nsname] = ing.Generation
+	k.cfg.Logger.Error(err, "Ingress cannot be translated", "namespace", ing.Namespace, "name", ing.Name)
+}
+
+func (k *RouteKind) translate(ing *networkingv1.Ingress) (gatewayv1.HTTPRouteSpec, error) {
+	// the parentRefs are returned even if the rest of the spec is invalid, see graph.CustomRouteKind
+	spec := gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: []gatewayv1.ParentReference{
+				{
+					Group:     helpers.GetPointer[gatewayv1.Group](gatewayv1.GroupName),
+					Kind:      helpers.GetPointer[gatewayv1.Kind](kinds.Gateway),
+					Namespace: helpers.GetPointer(gatewayv1.Namespace(k.cfg.Gateway.Namespace)),
+					Name:      gatewayv1.ObjectName(k.cfg.Gateway.Name),
+				},
+			},
+		},
+	}
+
+	hostname, err := ingressHostname(ing)
+	if err != nil {
+		return spec, err
+	}
+
+	if hostname != "" {
+		spec.Hostnames = []gatewayv1.Hostname{gatewayv1.Hostname(hostname)}
+	}
+
+	filters, err := annotationFilters(ing.Annotations)
+	if err != nil {
+		return spec, err
+	}
+
+	var rules []gatewayv1.HTTPRouteRule
+
+	for i, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for j, path := range rule.HTTP.Paths {
+			r, err := translatePath(path, filters)
+			if err != nil {
+				return spec, fmt.Errorf("spec.rules[%d].http.paths[%d]: %w", i, j, err)
+			}
+
+			rules = append(rules, r)
+		}
+	}
+
+	if ing.Spec.DefaultBackend != nil {
+		path := networkingv1.HTTPIngressPath{
+			Path:     "/",
+			PathType: helpers.GetPointer(networkingv1.PathTypePrefix),
+			Backend:  *ing.Spec.DefaultBackend,
+		}
+
+		r, err := translatePath(path, filters)
+		if err != nil {
+			return spec, fmt.Errorf("spec.defaultBackend: %w", err)
+		}
+
+		rules = append(rules, r)
+	}
+
+	if len(rules) == 0 {
+		return spec, errors.New("the Ingress must have at least one path or a default backend")
+	}
+
+	spec.Rules = rules
+
+	return spec, nil
+}
+
+// ingressClass returns the IngressClass of the Ingress.
+func ingressClass(ing *networkingv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+
+	return ing.Annotations[ClassAnnotation]
+}
+
+// ingressHostname returns the host of the rules of the Ingress. An HTTPRoute has the same hostnames for all its
+// rules, so all the rules of the Ingress must have the same host.
+func ingressHostname(ing *networkingv1.Ingress) (string, error) {
+	var hosts []string
+	for _, rule := range ing.Spec.Rules {
+		if !slices.Contains(hosts, rule.Host) {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+
+	switch len(hosts) {
+	case 0:
+		return "", nil
+	case 1:
+		return hosts[0], nil
+	default:
+		return "", fmt.Errorf(
+			"the rules have different hosts %s; create an Ingress for every host",
+			strings.Join(hosts, ", "),
+		)
+	}
+}
+
+// routeFilters are the filters of the rules, which are set by the annotations of the Ingress.
+type routeFilters struct {
+	redirect      *gatewayv1.HTTPRequestRedirectFilter
+	rewriteTarget string
+	upstreamVhost string
+}
+
+func annotationFilters(annotations map[string]string) (routeFilters, error) {
+	var filters routeFilters
+
+	if target, ok := annotations[RewriteTargetAnnotation]; ok {
+		if !strings.HasPrefix(target, "/") || strings.Contains(target, "$") {
+			return filters, fmt.Errorf(
+				"annotation %s must be a path without capture groups, for example, /",
+				RewriteTargetAnnotation,
+			)
+		}
+
+		filters.rewriteTarget = target
+	}
+
+	if location, ok := annotations[PermanentRedirectAnnotation]; ok {
+		redirect, err := redirectFilter(location)
+		if err != nil {
+			return filters, fmt.Errorf("annotation %s: %w", PermanentRedirectAnnotation, err)
+		}
+
+		filters.redirect = redirect
+	}
+
+	if vhost, ok := annotations[UpstreamVhostAnnotation]; ok {
+		if vhost == "" {
+			return filters, fmt.Errorf("annotation %s must not be empty", UpstreamVhostAnnotation)
+		}
+
+		filters.upstreamVhost = vhost
+	}
+
+	if filters.redirect != nil && filters.rewriteTarget != "" {
+		return filters, fmt.Errorf(
+			"annotations %s and %s cannot be used together",
+			PermanentRedirectAnnotation,
+			RewriteTargetAnnotation,
+		)
+	}
+
+	return filters, nil
+}
+
+func redirectFilter(location string) (*gatewayv1.HTTPRequestRedirectFilter, error) {
+	u, err := url.Parse(location)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, errors.New("must be an absolute http or https URL")
+	}
+
+	redirect := &gatewayv1.HTTPRequestRedirectFilter{
+		Scheme:     helpers.GetPointer(u.Scheme),
+		Hostname:   helpers.GetPointer(gatewayv1.PreciseHostname(u.Hostname())),
+		StatusCode: helpers.GetPointer(301),
+	}
+
+	if port := u.Port(); port != "" {
+		p, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", port)
+		}
+
+		redirect.Port = helpers.GetPointer(gatewayv1.PortNumber(p))
+	}
+
+	if u.Path != "" {
+		redirect.Path = &gatewayv1.HTTPPathModifier{
+			Type:            gatewayv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: helpers.GetPointer(u.Path),
+		}
+	}
+
+	return redirect, nil
+}
+
+func translatePath(path networkingv1.HTTPIngressPath, filters routeFilters) (gatewayv1.HTTPRouteRule, error) {
+	value := path.Path
+	if value == "" {
+		value = "/"
+	}
+
+	// ImplementationSpecific paths are matched as prefixes, like the Prefix paths
+	matchType := gatewayv1.PathMatchPathPrefix
+	if path.PathType != nil && *path.PathType == networkingv1.PathTypeExact {
+		matchType = gatewayv1.PathMatchExact
+	}
+
+	rule := gatewayv1.HTTPRouteRule{
+		Matches: []gatewayv1.HTTPRouteMatch{
+			{
+				Path: &gatewayv1.HTTPPathMatch{
+					Type:  helpers.GetPointer(matchType),
+					Value: helpers.GetPointer(value),
+				},
+			},
+		},
+	}
+
+	if filters.redirect != nil {
+		rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
+			Type:            gatewayv1.HTTPRouteFilterRequestRedirect,
+			RequestRedirect: filters.redirect,
+		})
+
+		// the redirected requests are not sent to the backend
+		return rule, nil
+	}
+
+	if filters.rewriteTarget != "" || filters.upstreamVhost != "" {
+		rewrite := &gatewayv1.HTTPURLRewriteFilter{}
+
+		if filters.upstreamVhost != "" {
+			rewrite.Hostname = helpers.GetPointer(gatewayv1.PreciseHostname(filters.upstreamVhost))
+		}
+
+		switch {
+		case filters.rewriteTarget == "":
+		case matchType == gatewayv1.PathMatchPathPrefix:
+			rewrite.Path = &gatewayv1.HTTPPathModifier{
+				Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: helpers.GetPointer(filters.rewriteTarget),
+			}
+		default:
+			rewrite.Path = &gatewayv1.HTTPPathModifier{
+				Type:            gatewayv1.FullPathHTTPPathModifier,
+				ReplaceFullPath: helpers.GetPointer(filters.rewriteTarget),
+			}
+		}
+
+		rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
+			Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+			URLRewrite: rewrite,
+		})
+	}
+
+	backendRef, err := translateBackend(path.Backend)
+	if err != nil {
+		return rule, err
+	}
+
+	rule.BackendRefs = []gatewayv1.HTTPBackendRef{{BackendRef: backendRef}}
+
+	return rule, nil
+}
+
+func translateBackend(backend networkingv1.IngressBackend) (gatewayv1.BackendRef, error) {
+	if backend.Resource != nil {
+		return gatewayv1.BackendRef{}, errors.New("resource backends are not supported")
+	}
+
+	if backend.Service == nil {
+		return gatewayv1.BackendRef{}, errors.New("the backend must have a service")
+	}
+
+	if backend.Service.Port.Name != "" {
+		return gatewayv1.BackendRef{}, fmt.Errorf(
+			"the port of service %s must be a number; port names are not supported",
+			backend.Service.Name,
+		)
+	}
+
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(backend.Service.Name),
+			Port: helpers.GetPointer(gatewayv1.PortNumber(backend.Service.Port.Number)),
+		},
+	}, nil
+}
@@ -0,0 +1,374 @@
+package ingress
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+func createIngress(modify func(ing *networkingv1.Ingress)) *unstructured.Unstructured {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "test",
+			Name:       "ingress",
+			Generation: 1,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: helpers.GetPointer("nginx"),
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "cafe.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/coffee",
+									PathType: helpers.GetPointer(networkingv1.PathTypePrefix),
+									Backend:  serviceBackend("coffee", 80),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if modify != nil {
+		modify(ing)
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ing)
+	if err != nil {
+		panic(err)
+	}
+
+	route := &unstructured.Unstructured{Object: obj}
+	route.SetGroupVersionKind(networkingv1.SchemeGroupVersion.WithKind("Ingress"))
+
+	return route
+}
+
+func serviceBackend(name string, port int32) networkingv1.IngressBackend {
+	return networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: name,
+			Port: networkingv1.ServiceBackendPort{Number: port},
+		},
+	}
+}
+
+func prefixRule(path, service string, filters ...gatewayv1.HTTPRouteFilter) gatewayv1.HTTPRouteRule {
+	return gatewayv1.HTTPRouteRule{
+		Matches: []gatewayv1.HTTPRouteMatch{
+			{
+				Path: &gatewayv1.HTTPPathMatch{
+					Type:  helpers.GetPointer(gatewayv1.PathMatchPathPrefix),
+					Value: helpers.GetPointer(path),
+				},
+			},
+		},
+		Filters: filters,
+		BackendRefs: []gatewayv1.HTTPBackendRef{
+			{
+				BackendRef: gatewayv1.BackendRef{
+					BackendObjectReference: gatewayv1.BackendObjectReference{
+						Name: gatewayv1.ObjectName(service),
+						Port: helpers.GetPointer[gatewayv1.PortNumber](80),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+
+	parentRefs := []gatewayv1.ParentReference{
+		{
+			Group:     helpers.GetPointer[gatewayv1.Group](gatewayv1.GroupName),
+			Kind:      helpers.GetPointer[gatewayv1.Kind]("Gateway"),
+			Namespace: helpers.GetPointer[gatewayv1.Namespace]("gateway-ns"),
+			Name:      "gateway",
+		},
+	}
+	hostnames := []gatewayv1.Hostname{"cafe.example.com"}
+
+	tests := []struct {
+		route  *unstructured.Unstructured
+		name   string
+		expErr string
+		expect gatewayv1.HTTPRouteSpec
+	}{
+		{
+			name:  "prefix path",
+			route: createIngress(nil),
+			expect: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+				Hostnames:       hostnames,
+				Rules:           []gatewayv1.HTTPRouteRule{prefixRule("/coffee", "coffee")},
+			},
+		},
+		{
+			name: "other class",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Spec.IngressClassName = helpers.GetPointer("other")
+			}),
+			expect: gatewayv1.HTTPRouteSpec{},
+		},
+		{
+			name: "legacy class annotation",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Spec.IngressClassName = nil
+				ing.Annotations = map[string]string{ClassAnnotation: "nginx"}
+			}),
+			expect: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+				Hostnames:       hostnames,
+				Rules:           []gatewayv1.HTTPRouteRule{prefixRule("/coffee", "coffee")},
+			},
+		},
+		{
+			name: "exact, implementation specific, and empty paths, and default backend",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Spec.Rules[0].Host = ""
+				ing.Spec.Rules[0].HTTP.Paths = []networkingv1.HTTPIngressPath{
+					{
+						Path:     "/tea",
+						PathType: helpers.GetPointer(networkingv1.PathTypeExact),
+						Backend:  serviceBackend("tea", 80),
+					},
+					{
+						Path:     "/coffee",
+						PathType: helpers.GetPointer(networkingv1.PathTypeImplementationSpecific),
+						Backend:  serviceBackend("coffee", 80),
+					},
+					{
+						Backend: serviceBackend("cafe", 80),
+					},
+				}
+				ing.Spec.DefaultBackend = helpers.GetPointer(serviceBackend("default", 80))
+			}),
+			expect: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+				Rules: []gatewayv1.HTTPRouteRule{
+					{
+						Matches: []gatewayv1.HTTPRouteMatch{
+							{
+								Path: &gatewayv1.HTTPPathMatch{
+									Type:  helpers.GetPointer(gatewayv1.PathMatchExact),
+									Value: helpers.GetPointer("/tea"),
+								},
+							},
+						},
+						BackendRefs: prefixRule("/tea", "tea").BackendRefs,
+					},
+					prefixRule("/coffee", "coffee"),
+					prefixRule("/", "cafe"),
+					prefixRule("/", "default"),
+				},
+			},
+		},
+		{
+			name: "rewrite target and upstream vhost",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Annotations = map[string]string{
+					RewriteTargetAnnotation: "/",
+					UpstreamVhostAnnotation: "coffee.internal",
+				}
+			}),
+			expect: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+				Hostnames:       hostnames,
+				Rules: []gatewayv1.HTTPRouteRule{
+					prefixRule("/coffee", "coffee", gatewayv1.HTTPRouteFilter{
+						Type: gatewayv1.HTTPRouteFilterURLRewrite,
+						URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+							Hostname: helpers.GetPointer[gatewayv1.PreciseHostname]("coffee.internal"),
+							Path: &gatewayv1.HTTPPathModifier{
+								Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+								ReplacePrefixMatch: helpers.GetPointer("/"),
+							},
+						},
+					}),
+				},
+			},
+		},
+		{
+			name: "permanent redirect",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Annotations = map[string]string{
+					PermanentRedirectAnnotation: "https://example.com:8443/menu",
+				}
+			}),
+			expect: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+				Hostnames:       hostnames,
+				Rules: []gatewayv1.HTTPRouteRule{
+					{
+						Matches: prefixRule("/coffee", "coffee").Matches,
+						Filters: []gatewayv1.HTTPRouteFilter{
+							{
+								Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+								RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+									Scheme:     helpers.GetPointer("https"),
+									Hostname:   helpers.GetPointer[gatewayv1.PreciseHostname]("example.com"),
+									Port:       helpers.GetPointer[gatewayv1.PortNumber](8443),
+									StatusCode: helpers.GetPointer(301),
+									Path: &gatewayv1.HTTPPathModifier{
+										Type:            gatewayv1.FullPathHTTPPathModifier,
+										ReplaceFullPath: helpers.GetPointer("/menu"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "different hosts",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				rule := *ing.Spec.Rules[0].DeepCopy()
+				rule.Host = "tea.example.com"
+				ing.Spec.Rules = append(ing.Spec.Rules, rule)
+			}),
+			expErr: "the rules have different hosts cafe.example.com, tea.example.com; create an Ingress for every host",
+		},
+		{
+			name: "named port",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port = networkingv1.ServiceBackendPort{Name: "http"}
+			}),
+			expErr: "spec.rules[0].http.paths[0]: the port of service coffee must be a number; " +
+				"port names are not supported",
+		},
+		{
+			name: "resource backend",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Spec.Rules[0].HTTP.Paths[0].Backend = networkingv1.IngressBackend{
+					Resource: &corev1.TypedLocalObjectReference{
+						APIGroup: helpers.GetPointer("example.com"),
+						Kind:     "Bucket",
+						Name:     "assets",
+					},
+				}
+			}),
+			expErr: "spec.rules[0].http.paths[0]: resource backends are not supported",
+		},
+		{
+			name: "rewrite target with capture groups",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Annotations = map[string]string{RewriteTargetAnnotation: "/$2"}
+			}),
+			expErr: "annotation nginx.ingress.kubernetes.io/rewrite-target must be a path without capture groups, " +
+				"for example, /",
+		},
+		{
+			name: "invalid permanent redirect",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Annotations = map[string]string{PermanentRedirectAnnotation: "/menu"}
+			}),
+			expErr: "annotation nginx.ingress.kubernetes.io/permanent-redirect: must be an absolute http or https URL",
+		},
+		{
+			name: "permanent redirect and rewrite target",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Annotations = map[string]string{
+					PermanentRedirectAnnotation: "https://example.com",
+					RewriteTargetAnnotation:     "/",
+				}
+			}),
+			expErr: "annotations nginx.ingress.kubernetes.io/permanent-redirect and " +
+				"nginx.ingress.kubernetes.io/rewrite-target cannot be used together",
+		},
+		{
+			name: "no paths",
+			route: createIngress(func(ing *networkingv1.Ingress) {
+				ing.Spec.Rules[0].HTTP = nil
+			}),
+			expErr: "the Ingress must have at least one path or a default backend",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			routeKind := NewRouteKind(Config{
+				Logger:    logr.Discard(),
+				ClassName: "nginx",
+				Gateway:   types.NamespacedName{Namespace: "gateway-ns", Name: "gateway"},
+			})
+
+			spec, err := routeKind.Translate(test.route)
+			if test.expErr != "" {
+				g.Expect(err).To(MatchError(test.expErr))
+				g.Expect(spec.ParentRefs).To(Equal(parentRefs))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(helpers.Diff(test.expect, spec)).To(BeEmpty())
+		})
+	}
+}
+
+func TestTranslate_LogsErrorOncePerGeneration(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var logs []string
+	logger := funcr.New(
+		func(prefix, args string) {
+			logs = append(logs, fmt.Sprintf("%s %s", prefix, args))
+		},
+		funcr.Options{},
+	)
+
+	routeKind := NewRouteKind(Config{
+		Logger:    logger,
+		ClassName: "nginx",
+		Gateway:   types.NamespacedName{Namespace: "gateway-ns", Name: "gateway"},
+	})
+
+	route := createIngress(func(ing *networkingv1.Ingress) {
+		ing.Spec.Rules[0].HTTP = nil
+	})
+
+	for range 2 {
+		_, err := routeKind.Translate(route)
+		g.Expect(err).To(HaveOccurred())
+	}
+	g.Expect(logs).To(HaveLen(1))
+	g.Expect(logs[0]).To(ContainSubstring("Ingress cannot be translated"))
+
+	route.SetGeneration(2)
+	_, err := routeKind.Translate(route)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(logs).To(HaveLen(2))
+}
+
+func TestRouteKind(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	routeKind := NewRouteKind(Config{})
+
+	g.Expect(routeKind.GroupVersionKind()).To(Equal(networkingv1.SchemeGroupVersion.WithKind("Ingress")))
+	g.Expect(routeKind.HasRouteStatus()).To(BeFalse())
+}
@@ -2,9 +2,13 @@ package controller
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 )
 
 //go:generate go tool counterfeiter -generate
@@ -16,6 +20,11 @@ type logLevelSetter interface {
 	SetLevel(string) error
 }
 
+// moduleLogLevelSetter defines an interface for setting the logging levels of the control plane modules.
+type moduleLogLevelSetter interface {
+	SetModuleLevels(map[string]string) error
+}
+
 // multiLogLevelSetter sets the log level for multiple logLevelSetters.
 type multiLogLevelSetter struct {
 	setters []logLevelSetter
@@ -63,3 +72,30 @@ func (z zapLogLevelSetter) SetLevel(level string) error {
 func (z zapLogLevelSetter) Enabled(level zapcore.Level) bool {
 	return z.atomicLevel.Enabled(level)
 }
+
+// nginxErrorLevelKey is the key in the log levels ConfigMap that overrides the NGINX error log level of all Gateways.
+const nginxErrorLevelKey = "nginx"
+
+// validateNginxErrorLevel validates the NGINX error log level. An empty level is valid and means no override.
+func validateNginxErrorLevel(level string) error {
+	if level == "" {
+		return nil
+	}
+
+	validLevels := []ngfAPIv1alpha2.NginxErrorLogLevel{
+		ngfAPIv1alpha2.NginxLogLevelDebug,
+		ngfAPIv1alpha2.NginxLogLevelInfo,
+		ngfAPIv1alpha2.NginxLogLevelNotice,
+		ngfAPIv1alpha2.NginxLogLevelWarn,
+		ngfAPIv1alpha2.NginxLogLevelError,
+		ngfAPIv1alpha2.NginxLogLevelCrit,
+		ngfAPIv1alpha2.NginxLogLevelAlert,
+		ngfAPIv1alpha2.NginxLogLevelEmerg,
+	}
+
+	if !slices.Contains(validLevels, ngfAPIv1alpha2.NginxErrorLogLevel(level)) {
+		return fmt.Errorf("unsupported NGINX error log level %q for key %q", level, nginxErrorLevelKey)
+	}
+
+	return nil
+}
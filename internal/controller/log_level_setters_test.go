@@ -58,3 +58,13 @@ func TestZapLogLevelSetter_SetLevel(t *testing.T) {
 
 	g.Expect(zapSetter.SetLevel("invalid")).ToNot(Succeed())
 }
+
+func TestValidateNginxErrorLevel(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(validateNginxErrorLevel("")).To(Succeed())
+	g.Expect(validateNginxErrorLevel("debug")).To(Succeed())
+	g.Expect(validateNginxErrorLevel("emerg")).To(Succeed())
+	g.Expect(validateNginxErrorLevel("trace")).To(MatchError(`unsupported NGINX error log level "trace" for key "nginx"`))
+}
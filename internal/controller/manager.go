@@ -3,6 +3,8 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -10,28 +12,37 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"google.golang.org/grpc"
+	admregv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	authv1 "k8s.io/api/authentication/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	apiv1 "k8s.io/api/core/v1"
 	discoveryV1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	ctlr "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	k8spredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
+	ctlrwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -40,7 +51,15 @@ import (
 
 	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
 	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/config/crd"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/apicatalog"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/apicompat"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/config"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/crds"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/failure"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/governor"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/health"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/licensing"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics/collectors"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
@@ -51,21 +70,36 @@ import (
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/observability"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/upstreamsettings"
 	ngxvalidation "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/validation"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/export"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/outlier"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/ipam"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/rampup"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/telemetry"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tempfile"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tenant"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tokenreview"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/upstreammap"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/usagesummary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/wasmhook"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/webhook"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller/filter"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller/index"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller/predicate"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/eventrecorder"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/events"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/loadshed"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/redact"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/runnables"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/syslog"
 	ngftypes "github.com/nginx/nginx-gateway-fabric/v2/internal/framework/types"
 )
 
@@ -78,6 +112,15 @@ const (
 	plusClientCertField = "tls.crt"
 	plusClientKeyField  = "tls.key"
 	grpcServerPort      = 8443
+	// eventAggregationInterval is the interval within which identical Kubernetes Events are only emitted once.
+	eventAggregationInterval = 10 * time.Minute
+)
+
+const (
+	// webhookCertDir is the directory with the certificate and key of the webhook server.
+	webhookCertDir = "/var/run/secrets/ngf"
+	// webhookCAPath is the path to the CA certificate of the webhook server.
+	webhookCAPath = webhookCertDir + "/ca.crt"
 )
 
 var scheme = runtime.NewScheme()
@@ -95,18 +138,63 @@ func init() {
 	utilruntime.Must(autoscalingv2.AddToScheme(scheme))
 	utilruntime.Must(authv1.AddToScheme(scheme))
 	utilruntime.Must(rbacv1.AddToScheme(scheme))
+	utilruntime.Must(networkingv1.AddToScheme(scheme))
 	utilruntime.Must(inference.Install(scheme))
+	utilruntime.Must(admregv1.AddToScheme(scheme))
 }
 
-func StartManager(cfg config.Config) error {
+// StartManager starts the manager of the control plane.
+// customRouteKinds register route kinds that are not part of the Gateway API. See graph.CustomRouteKind.
+// The control plane must be allowed to get, list, and watch the custom routes and to update their status.
+func StartManager(cfg config.Config, customRouteKinds ...graph.CustomRouteKind) error {
+	routeKinds, err := graph.NewCustomRouteKinds(customRouteKinds...)
+	if err != nil {
+		return fmt.Errorf("cannot register custom route kinds: %w", err)
+	}
+
+	var loadShedCollector loadshed.MetricsCollector = collectors.NewLoadShedNoopCollector()
+	if cfg.MetricsConfig.Enabled {
+		collector := collectors.NewLoadShedCollector(map[string]string{"class": cfg.GatewayClassName})
+		metrics.Registry.MustRegister(collector)
+		loadShedCollector = collector
+	}
+
+	apiServerLimiter := loadshed.NewLimiter(loadshed.LimiterConfig{MetricsCollector: loadShedCollector})
+
+	var componentHealthCollector health.MetricsCollector = collectors.NewComponentHealthNoopCollector()
+	if cfg.MetricsConfig.Enabled {
+		collector := collectors.NewComponentHealthCollector(map[string]string{"class": cfg.GatewayClassName})
+		metrics.Registry.MustRegister(collector)
+		componentHealthCollector = collector
+	}
+
+	componentHealth := health.NewTracker(componentHealthCollector, health.Components...)
+	// The status updater is healthy until an update of a status fails.
+	componentHealth.SetHealthy(health.StatusUpdater)
+
 	healthChecker := newGraphBuiltHealthChecker()
-	mgr, err := createManager(cfg, healthChecker)
+	mgr, err := createManager(cfg, healthChecker, componentHealth, apiServerLimiter)
 	if err != nil {
 		return fmt.Errorf("cannot build runtime manager: %w", err)
 	}
 
+	cacheSyncReporter := newCacheSyncHealthReporter(mgr.GetCache(), componentHealth)
+	if err = mgr.Add(&runnables.LeaderOrNonLeader{Runnable: cacheSyncReporter}); err != nil {
+		return fmt.Errorf("cannot register cache sync health reporter: %w", err)
+	}
+
+	if cfg.CRDManagement.Enabled {
+		if err := installCRDs(cfg, mgr); err != nil {
+			return fmt.Errorf("cannot install CRDs: %w", err)
+		}
+	}
+
+	apiAvailability := detectGatewayAPIResources(cfg, mgr.GetConfig())
+
 	recorderName := fmt.Sprintf("nginx-gateway-fabric-%s", cfg.GatewayClassName)
-	recorder := mgr.GetEventRecorderFor(recorderName)
+	recorder := redact.NewEventRecorder(
+		eventrecorder.NewAggregatingEventRecorder(mgr.GetEventRecorderFor(recorderName), eventAggregationInterval),
+	)
 
 	logLevelSetter := newMultiLogLevelSetter(newZapLogLevelSetter(cfg.AtomicLevel))
 
@@ -117,7 +205,17 @@ func StartManager(cfg config.Config) error {
 		Namespace: cfg.GatewayPodConfig.Namespace,
 		Name:      cfg.ConfigName,
 	}
-	if err := registerControllers(ctx, cfg, mgr, recorder, logLevelSetter, eventCh, controlConfigNSName); err != nil {
+	if err := registerControllers(
+		ctx,
+		cfg,
+		mgr,
+		recorder,
+		logLevelSetter,
+		eventCh,
+		controlConfigNSName,
+		routeKinds,
+		apiAvailability,
+	); err != nil {
 		return err
 	}
 
@@ -131,24 +229,35 @@ func StartManager(cfg config.Config) error {
 		return err
 	}
 
+	featureFlags := graph.FeatureFlags{
+		Plus:         cfg.Plus,
+		Experimental: cfg.ExperimentalFeatures,
+		FIPS:         cfg.FIPS,
+	}
+
 	processor := state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
 		GatewayCtlrName:  cfg.GatewayCtlrName,
 		GatewayClassName: cfg.GatewayClassName,
+		ClusterDomain:    cfg.ClusterDomain,
 		Logger:           cfg.Logger.WithName("changeProcessor"),
 		Validators: validation.Validators{
 			HTTPFieldsValidator: ngxvalidation.HTTPValidator{},
 			GenericValidator:    genericValidator,
 			PolicyValidator:     policyManager,
 		},
-		EventRecorder:  recorder,
-		MustExtractGVK: mustExtractGVK,
-		PlusSecrets:    plusSecrets,
-		FeatureFlags: graph.FeatureFlags{
-			Plus:         cfg.Plus,
-			Experimental: cfg.ExperimentalFeatures,
-		},
+		EventRecorder:    recorder,
+		MustExtractGVK:   mustExtractGVK,
+		PlusSecrets:      plusSecrets,
+		CustomRouteKinds: routeKinds,
+		FeatureFlags:     featureFlags,
 	})
 
+	if cfg.Webhook.Port != 0 {
+		if err := registerWebhook(cfg, mgr, processor, featureFlags); err != nil {
+			return err
+		}
+	}
+
 	var handlerCollector handlerMetricsCollector = collectors.NewControllerNoopCollector()
 
 	if cfg.MetricsConfig.Enabled {
@@ -166,6 +275,7 @@ func StartManager(cfg config.Config) error {
 	statusUpdater := status.NewUpdater(
 		mgr.GetClient(),
 		cfg.Logger.WithName("statusUpdater"),
+		componentHealth,
 	)
 
 	groupStatusUpdater := status.NewLeaderAwareGroupUpdater(statusUpdater)
@@ -176,21 +286,218 @@ func StartManager(cfg config.Config) error {
 	})
 
 	statusQueue := status.NewQueue()
+
+	var failureCollector failure.MetricsCollector = collectors.NewDataPlaneFailureNoopCollector()
+	if cfg.MetricsConfig.Enabled {
+		collector := collectors.NewDataPlaneFailureCollector(map[string]string{"class": cfg.GatewayClassName})
+		metrics.Registry.MustRegister(collector)
+		failureCollector = collector
+	}
+	failureTracker := failure.NewTracker(failureCollector, statusQueue, cfg.GatewayClassName, failure.DefaultWindow)
+
 	resetConnChan := make(chan struct{})
 	nginxUpdater := agent.NewNginxUpdater(
 		cfg.Logger.WithName("nginxUpdater"),
 		mgr.GetAPIReader(),
 		statusQueue,
 		resetConnChan,
+		eventCh,
+		failureTracker,
 		cfg.Plus,
 	)
 
+	var history *configHistory
+	if cfg.MetricsConfig.DebugEndpoints {
+		// The debug handlers are served by the metrics server alongside the metrics endpoint, so they share its
+		// port and TLS settings. They expose the internal state of the control plane, so they are only served
+		// when opted in, which requires the metrics to be served securely. They are used to collect support bundles.
+		if err := mgr.AddMetricsServerExtraHandler(DebugGraphPath, newGraphDebugHandler(processor)); err != nil {
+			return fmt.Errorf("cannot register graph debug handler: %w", err)
+		}
+
+		configHandler := newConfigDebugHandler(nginxUpdater.NginxDeployments)
+		if err := mgr.AddMetricsServerExtraHandler(DebugConfigPath, configHandler); err != nil {
+			return fmt.Errorf("cannot register config debug handler: %w", err)
+		}
+
+		// The history is only retained when it can be inspected.
+		if cfg.ConfigHistorySize > 0 {
+			history = newConfigHistory(cfg.ConfigHistorySize)
+			historyHandler := newConfigHistoryDebugHandler(history)
+			if err := mgr.AddMetricsServerExtraHandler(DebugConfigHistoryPath, historyHandler); err != nil {
+				return fmt.Errorf("cannot register config history debug handler: %w", err)
+			}
+		}
+	}
+
+	canaryAnalyzer, err := buildCanaryAnalyzer(cfg)
+	if err != nil {
+		return err
+	}
+
+	var catalogAggregator *apicatalog.Aggregator
+	if cfg.APICatalog.Path != "" {
+		catalogAggregator = apicatalog.NewAggregator(
+			cfg.Logger.WithName("apiCatalog"),
+			apicatalog.NewHTTPFetcher(apicatalog.DefaultFetchTimeout),
+			resolver.NewServiceResolverImpl(mgr.GetClient()),
+		)
+	}
+
+	ramper := rampup.NewRamper(
+		cfg.Logger.WithName("rampUp"),
+		resolver.NewServiceResolverImpl(mgr.GetClient()),
+		rampup.NewHTTPProber(rampUpProbeTimeout),
+	)
+
+	outlierHook, err := buildOutlierHook(cfg)
+	if err != nil {
+		return err
+	}
+
+	wasmHook, err := buildWASMHook(ctx, cfg, eventCh)
+	if err != nil {
+		return err
+	}
+
+	var reloadGovernor *governor.Governor
+	if cfg.NginxReloadMinInterval > 0 {
+		var governorCollector governor.MetricsCollector = collectors.NewReloadGovernorNoopCollector()
+		if cfg.MetricsConfig.Enabled {
+			collector := collectors.NewReloadGovernorCollector(map[string]string{"class": cfg.GatewayClassName})
+			metrics.Registry.MustRegister(collector)
+			governorCollector = collector
+		}
+
+		reloadGovernor = newReloadGovernor(
+			ctx,
+			governor.Config{
+				Collector:   governorCollector,
+				MinInterval: cfg.NginxReloadMinInterval,
+				Plus:        cfg.Plus,
+			},
+			eventCh,
+		)
+	}
+
 	tokenAudience := fmt.Sprintf(
 		"%s.%s.svc",
 		cfg.GatewayPodConfig.ServiceName,
 		cfg.GatewayPodConfig.Namespace,
 	)
 
+	// controlPlanePorts are the ports of the optional listeners of the control plane that the nginx Pods send
+	// traffic to, so that the NetworkPolicy of the nginx Pods allows the traffic.
+	var controlPlanePorts []int32
+
+	// syslogSources resolves the nginx Pods that send the syslog messages to the receivers of the control plane,
+	// so that the receivers drop the messages of the other Pods.
+	syslogSources := newPodSourceResolver(mgr.GetClient(), cfg.GatewayPodConfig.InstanceName, cfg.GatewayClassName)
+
+	var tenantAttributionServer string
+	if cfg.TenantAttributionPort != 0 {
+		var tenantCollector tenant.MetricsCollector = collectors.NewTenantNoopCollector()
+		if cfg.MetricsConfig.Enabled {
+			collector := collectors.NewTenantCollector(map[string]string{"class": cfg.GatewayClassName})
+			metrics.Registry.MustRegister(collector)
+			tenantCollector = collector
+		}
+
+		var usageRecorder tenant.UsageRecorder
+		if cfg.UsageSummaryInterval > 0 {
+			aggregator := usagesummary.NewAggregator(time.Now())
+			usageSummaryJob := newUsageSummaryJob(
+				cfg.Logger.WithName("usageSummaryJob"),
+				aggregator,
+				usagesummary.NewConfigMapPublisher(mgr.GetClient(), cfg.GatewayPodConfig.Name),
+				healthChecker.getReadyCh(),
+				cfg.UsageSummaryInterval,
+			)
+			if err = mgr.Add(usageSummaryJob); err != nil {
+				return fmt.Errorf("cannot register usage summary job: %w", err)
+			}
+			usageRecorder = aggregator
+		}
+
+		receiver := syslog.NewReceiver(
+			cfg.Logger.WithName("tenantReceiver"),
+			fmt.Sprintf(":%d", cfg.TenantAttributionPort),
+			syslogSources,
+			tenant.NewHandler(cfg.Logger.WithName("tenantHandler"), tenantCollector, usageRecorder),
+		)
+		if err = mgr.Add(&runnables.LeaderOrNonLeader{Runnable: receiver}); err != nil {
+			return fmt.Errorf("cannot register tenant receiver: %w", err)
+		}
+
+		tenantAttributionServer = fmt.Sprintf("%s:%d", tokenAudience, cfg.TenantAttributionPort)
+		controlPlanePorts = append(controlPlanePorts, int32(cfg.TenantAttributionPort))
+	}
+
+	var tempFileHandler *tempfile.Handler
+	var tempFileServer string
+	if cfg.TempFileMetricsPort != 0 {
+		var tempFileCollector tempfile.MetricsCollector = collectors.NewTempFileNoopCollector()
+		if cfg.MetricsConfig.Enabled {
+			collector := collectors.NewTempFileCollector(map[string]string{"class": cfg.GatewayClassName})
+			metrics.Registry.MustRegister(collector)
+			tempFileCollector = collector
+		}
+
+		tempFileHandler = tempfile.NewHandler(cfg.Logger.WithName("tempFileHandler"), tempFileCollector)
+		tempFileReceiver := syslog.NewReceiver(
+			cfg.Logger.WithName("tempFileReceiver"),
+			fmt.Sprintf(":%d", cfg.TempFileMetricsPort),
+			syslogSources,
+			tempFileHandler,
+		)
+		if err = mgr.Add(&runnables.LeaderOrNonLeader{Runnable: tempFileReceiver}); err != nil {
+			return fmt.Errorf("cannot register temporary file receiver: %w", err)
+		}
+
+		tempFileServer = fmt.Sprintf("%s:%d", tokenAudience, cfg.TempFileMetricsPort)
+		controlPlanePorts = append(controlPlanePorts, int32(cfg.TempFileMetricsPort))
+	}
+
+	var failureHandler *failure.Handler
+	var failureServer string
+	if cfg.DataPlaneFailurePort != 0 {
+		failureHandler = failure.NewHandler(cfg.Logger.WithName("failureHandler"), failureTracker)
+		failureReceiver := syslog.NewReceiver(
+			cfg.Logger.WithName("failureReceiver"),
+			fmt.Sprintf(":%d", cfg.DataPlaneFailurePort),
+			syslogSources,
+			failureHandler,
+		)
+		if err = mgr.Add(&runnables.LeaderOrNonLeader{Runnable: failureReceiver}); err != nil {
+			return fmt.Errorf("cannot register data plane failure receiver: %w", err)
+		}
+
+		failureServer = fmt.Sprintf("%s:%d", tokenAudience, cfg.DataPlaneFailurePort)
+		controlPlanePorts = append(controlPlanePorts, int32(cfg.DataPlaneFailurePort))
+	}
+
+	var tokenReviewServer string
+	if cfg.TokenReviewPort != 0 {
+		// the verifier serves with the server certificate of the control plane, and NGINX authenticates
+		// with the client certificate of the agent, which are both signed by the CA of the agent connections.
+		verifier := tokenreview.NewVerifier(
+			cfg.Logger.WithName("tokenReviewVerifier"),
+			fmt.Sprintf(":%d", cfg.TokenReviewPort),
+			tokenreview.TLSFiles{
+				CACert: webhookCAPath,
+				Cert:   webhookCertDir + "/tls.crt",
+				Key:    webhookCertDir + "/tls.key",
+			},
+			mgr.GetClient(),
+		)
+		if err = mgr.Add(&runnables.LeaderOrNonLeader{Runnable: verifier}); err != nil {
+			return fmt.Errorf("cannot register token review verifier: %w", err)
+		}
+
+		tokenReviewServer = fmt.Sprintf("%s:%d", tokenAudience, cfg.TokenReviewPort)
+		controlPlanePorts = append(controlPlanePorts, int32(cfg.TokenReviewPort))
+	}
+
 	grpcServer := agentgrpc.NewServer(
 		cfg.Logger.WithName("agentGRPCServer"),
 		grpcServerPort,
@@ -201,6 +508,8 @@ func StartManager(cfg config.Config) error {
 		mgr.GetClient(),
 		tokenAudience,
 		resetConnChan,
+		cfg.FIPS,
+		componentHealth,
 	)
 
 	if err = mgr.Add(&runnables.LeaderOrNonLeader{Runnable: grpcServer}); err != nil {
@@ -226,6 +535,8 @@ func StartManager(cfg config.Config) error {
 			InferenceExtension:             cfg.InferenceExtension,
 			EndpointPickerDisableTLS:       cfg.EndpointPickerDisableTLS,
 			EndpointPickerTLSSkipVerify:    cfg.EndpointPickerTLSSkipVerify,
+			AddressAllocator:               buildAddressAllocator(cfg.IPAM),
+			ControlPlanePorts:              controlPlanePorts,
 		},
 	)
 	if err != nil {
@@ -237,37 +548,74 @@ func StartManager(cfg config.Config) error {
 	}
 
 	eventHandler := newEventHandlerImpl(eventHandlerConfig{
-		ctx:              ctx,
-		nginxUpdater:     nginxUpdater,
-		nginxProvisioner: nginxProvisioner,
-		metricsCollector: handlerCollector,
-		statusUpdater:    groupStatusUpdater,
-		processor:        processor,
-		serviceResolver:  resolver.NewServiceResolverImpl(mgr.GetClient()),
+		ctx:                ctx,
+		nginxUpdater:       nginxUpdater,
+		nginxProvisioner:   nginxProvisioner,
+		metricsCollector:   handlerCollector,
+		queueDepthObserver: apiServerLimiter,
+		statusUpdater:      groupStatusUpdater,
+		processor:          processor,
+		serviceResolver:    resolver.NewServiceResolverImpl(mgr.GetClient()),
 		generator: ngxcfg.NewGeneratorImpl(
 			cfg.Plus,
+			cfg.FIPS,
 			&cfg.UsageReportConfig,
 			cfg.Logger.WithName("generator"),
 		),
+		configExporters:         buildConfigExporters(cfg, mgr.GetClient()),
+		agentlessExporter:       export.NewConfigMapExporter(mgr.GetClient(), cfg.Plus),
+		upstreamMapPublisher:    buildUpstreamMapPublisher(cfg, mgr.GetClient()),
+		configHistory:           history,
+		tenantAttributionServer: tenantAttributionServer,
+		gatewayTests:            cfg.GatewayTests,
+		tempFileServer:          tempFileServer,
+		tempFileHandler:         tempFileHandler,
+		failureServer:           failureServer,
+		tokenReviewServer:       tokenReviewServer,
+		failureHandler:          failureHandler,
+		failureTracker:          failureTracker,
+		canaryAnalyzer:          canaryAnalyzer,
+		apiCatalog:              catalogAggregator,
+		apiCatalogPath:          cfg.APICatalog.Path,
+		rampUp:                  ramper,
+		outlierHook:             outlierHook,
+		reloadGovernor:          reloadGovernor,
+		wasmHook:                wasmHook,
 		k8sClient:               mgr.GetClient(),
 		k8sReader:               mgr.GetAPIReader(),
 		logger:                  cfg.Logger.WithName("eventHandler"),
 		logLevelSetter:          logLevelSetter,
+		moduleLogLevelSetter:    cfg.ModuleLogLevels,
+		defaultModuleLogLevels:  cfg.DefaultModuleLogLevels,
 		eventRecorder:           recorder,
 		deployCtxCollector:      deployCtxCollector,
 		graphBuiltHealthChecker: healthChecker,
+		componentHealth:         componentHealth,
 		gatewayPodConfig:        cfg.GatewayPodConfig,
 		controlConfigNSName:     controlConfigNSName,
-		gatewayCtlrName:         cfg.GatewayCtlrName,
-		gatewayInstanceName:     cfg.GatewayPodConfig.InstanceName,
-		gatewayClassName:        cfg.GatewayClassName,
-		plus:                    cfg.Plus,
-		statusQueue:             statusQueue,
-		nginxDeployments:        nginxUpdater.NginxDeployments,
-		inferenceExtension:      cfg.InferenceExtension,
+		logLevelsConfigMapNSName: types.NamespacedName{
+			Namespace: cfg.GatewayPodConfig.Namespace,
+			Name:      cfg.LogLevelsConfigMapName,
+		},
+		gatewayCtlrName:      cfg.GatewayCtlrName,
+		gatewayInstanceName:  cfg.GatewayPodConfig.InstanceName,
+		gatewayClassName:     cfg.GatewayClassName,
+		plus:                 cfg.Plus,
+		statusQueue:          statusQueue,
+		nginxDeployments:     nginxUpdater.NginxDeployments,
+		inferenceExtension:   cfg.InferenceExtension,
+		experimentalFeatures: cfg.ExperimentalFeatures,
+		inactiveFeatures:     apiAvailability.InactiveFeatures(),
 	})
 
-	objects, objectLists := prepareFirstEventBatchPreparerArgs(cfg)
+	if cfg.MetricsConfig.DebugEndpoints {
+		routeModelHandler := newRouteModelDebugHandler(eventHandler)
+		if err := mgr.AddMetricsServerExtraHandler(DebugRouteModelPath, routeModelHandler); err != nil {
+			return fmt.Errorf("cannot register route model debug handler: %w", err)
+		}
+	}
+
+	objects, objectLists := prepareFirstEventBatchPreparerArgs(cfg, routeKinds, apiAvailability)
 
 	firstBatchPreparer := events.NewFirstEventBatchPreparerImpl(mgr.GetCache(), objects, objectLists)
 	eventLoop := events.NewEventLoop(
@@ -289,6 +637,83 @@ func StartManager(cfg config.Config) error {
 		return fmt.Errorf("cannot register functions that get called after Pod becomes leader: %w", err)
 	}
 
+	consistencySweepJob := newConsistencySweepJob(
+		cfg.Logger.WithName("consistencySweepJob"),
+		eventCh,
+		healthChecker.getReadyCh(),
+	)
+	if err = mgr.Add(consistencySweepJob); err != nil {
+		return fmt.Errorf("cannot register consistency sweep job: %w", err)
+	}
+
+	rampUpJob := newRampUpJob(
+		cfg.Logger.WithName("rampUpJob"),
+		ramper,
+		processor.GetLatestGraph,
+		eventCh,
+		healthChecker.getReadyCh(),
+		rampUpPeriod,
+	)
+	if err = mgr.Add(rampUpJob); err != nil {
+		return fmt.Errorf("cannot register ramp-up job: %w", err)
+	}
+
+	if canaryAnalyzer != nil {
+		canaryAnalysisJob := newCanaryAnalysisJob(
+			cfg.Logger.WithName("canaryAnalysisJob"),
+			canaryAnalyzer,
+			processor.GetLatestGraph,
+			eventCh,
+			healthChecker.getReadyCh(),
+			cfg.CanaryAnalysis.Interval,
+		)
+		if err = mgr.Add(canaryAnalysisJob); err != nil {
+			return fmt.Errorf("cannot register canary analysis job: %w", err)
+		}
+	}
+
+	if catalogAggregator != nil {
+		apiCatalogJob := newAPICatalogJob(
+			cfg.Logger.WithName("apiCatalogJob"),
+			catalogAggregator,
+			processor.GetLatestGraph,
+			eventCh,
+			healthChecker.getReadyCh(),
+			cfg.APICatalog.Interval,
+		)
+		if err = mgr.Add(apiCatalogJob); err != nil {
+			return fmt.Errorf("cannot register API catalog job: %w", err)
+		}
+	}
+
+	if outlierHook != nil {
+		outlierHookJob := newOutlierHookJob(
+			cfg.Logger.WithName("outlierHookJob"),
+			outlierHook,
+			processor.GetLatestGraph,
+			eventCh,
+			healthChecker.getReadyCh(),
+			cfg.OutlierHook.Interval,
+		)
+		if err = mgr.Add(outlierHookJob); err != nil {
+			return fmt.Errorf("cannot register outlier hook job: %w", err)
+		}
+	}
+
+	if cfg.GatewayTests {
+		gatewayTestJob := newGatewayTestJob(
+			cfg.Logger.WithName("gatewayTestJob"),
+			mgr.GetClient(),
+			statusUpdater,
+			cfg.GatewayClassName,
+			healthChecker.getReadyCh(),
+			gatewayTestPeriod,
+		)
+		if err = mgr.Add(gatewayTestJob); err != nil {
+			return fmt.Errorf("cannot register gateway test job: %w", err)
+		}
+	}
+
 	if cfg.ProductTelemetryConfig.Enabled {
 		dataCollector := telemetry.NewDataCollectorImpl(telemetry.DataCollectorConfig{
 			K8sClientReader:     mgr.GetAPIReader(),
@@ -346,7 +771,159 @@ func createPolicyManager(
 	return policies.NewManager(mustExtractGVK, cfgs...)
 }
 
-func createManager(cfg config.Config, healthChecker *graphBuiltHealthChecker) (manager.Manager, error) {
+// buildConfigExporters builds the exporters of the NGINX configuration that are enabled in the config.
+func buildConfigExporters(cfg config.Config, k8sClient client.Client) []export.Exporter {
+	var exporters []export.Exporter
+
+	if cfg.ConfigExport.Dir != "" {
+		exporters = append(exporters, export.NewDirExporter(cfg.ConfigExport.Dir, cfg.Plus))
+	}
+
+	if cfg.ConfigExport.ConfigMap {
+		exporters = append(exporters, export.NewConfigMapExporter(k8sClient, cfg.Plus))
+	}
+
+	return exporters
+}
+
+// buildUpstreamMapPublisher builds the publisher of the mapping of the Routes to the NGINX upstreams,
+// or returns nil if publishing the mapping is disabled in the config.
+func buildUpstreamMapPublisher(cfg config.Config, k8sClient client.Client) upstreammap.Publisher {
+	if !cfg.UpstreamMapConfigMap {
+		return nil
+	}
+
+	return upstreammap.NewConfigMapPublisher(k8sClient)
+}
+
+func buildAddressAllocator(cfg config.IPAMConfig) ipam.AddressAllocator {
+	switch {
+	case cfg.MetalLBAddressPool != "":
+		return ipam.NewMetalLBAllocator(cfg.MetalLBAddressPool)
+	case cfg.Endpoint != "":
+		return ipam.NewHTTPAllocator(cfg.Endpoint)
+	default:
+		return nil
+	}
+}
+
+func buildCanaryAnalyzer(cfg config.Config) (*canary.Analyzer, error) {
+	if cfg.CanaryAnalysis.PrometheusAddress == "" {
+		return nil, nil //nolint:nilnil // the canary analysis is disabled
+	}
+
+	provider, err := canary.NewPrometheusProvider(canary.PrometheusConfig{
+		Address:        cfg.CanaryAnalysis.PrometheusAddress,
+		ErrorRateQuery: cfg.CanaryAnalysis.ErrorRateQuery,
+		LatencyQuery:   cfg.CanaryAnalysis.LatencyQuery,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create canary analysis metrics provider: %w", err)
+	}
+
+	return canary.NewAnalyzer(cfg.Logger.WithName("canaryAnalyzer"), provider), nil
+}
+
+func buildOutlierHook(cfg config.Config) (*outlier.Hook, error) {
+	if cfg.OutlierHook.PrometheusAddress == "" {
+		return nil, nil //nolint:nilnil // the outlier hook is disabled
+	}
+
+	provider, err := canary.NewPrometheusProvider(canary.PrometheusConfig{
+		Address:        cfg.OutlierHook.PrometheusAddress,
+		ErrorRateQuery: cfg.OutlierHook.ErrorRateQuery,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create outlier hook metrics provider: %w", err)
+	}
+
+	return outlier.NewHook(cfg.Logger.WithName("outlierHook"), provider), nil
+}
+
+func buildWASMHook(ctx context.Context, cfg config.Config, eventCh chan<- interface{}) (wasmHookRunner, error) {
+	if cfg.WASMHook.ModulePath == "" {
+		return nil, nil //nolint:nilnil // the WASM hook is disabled
+	}
+
+	hook, err := wasmhook.New(ctx, wasmhook.Config{
+		ModulePath:     cfg.WASMHook.ModulePath,
+		Timeout:        cfg.WASMHook.Timeout,
+		MaxMemoryBytes: cfg.WASMHook.MaxMemoryBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create WASM hook: %w", err)
+	}
+
+	return newWASMHookRunner(hook, eventCh), nil
+}
+
+// registerWebhook registers the validating admission webhook on the webhook server of the manager.
+func registerWebhook(
+	cfg config.Config,
+	mgr manager.Manager,
+	processor state.ChangeProcessor,
+	featureFlags graph.FeatureFlags,
+) error {
+	validator := webhook.NewValidator(scheme, webhook.Config{
+		GraphGetter:         processor,
+		Validator:           ngxvalidation.HTTPValidator{},
+		FeatureFlags:        featureFlags,
+		MaxRoutesPerGateway: cfg.Webhook.MaxRoutesPerGateway,
+		SnippetsFilters:     cfg.SnippetsFilters,
+	})
+	mgr.GetWebhookServer().Register(webhook.Path, &ctlrwebhook.Admission{Handler: validator})
+
+	if cfg.Webhook.ConfigurationName == "" {
+		return nil
+	}
+
+	injector := webhook.NewCABundleInjector(
+		mgr.GetClient(),
+		mgr.GetAPIReader(),
+		cfg.Logger.WithName("webhookCABundleInjector"),
+		cfg.Webhook.ConfigurationName,
+		webhookCAPath,
+	)
+	if err := mgr.Add(injector); err != nil {
+		return fmt.Errorf("cannot register webhook CA bundle injector: %w", err)
+	}
+
+	return nil
+}
+
+// installCRDs installs and upgrades the NGF CRDs.
+// The CRDs are installed before the controllers are registered, so that the controllers can watch them.
+func installCRDs(cfg config.Config, mgr manager.Manager) error {
+	files, err := crd.Files()
+	if err != nil {
+		return err
+	}
+
+	// The cache of the manager is not started yet, so we use a client that reads from the API server.
+	k8sClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("cannot create client: %w", err)
+	}
+
+	installer := crds.NewInstaller(crds.InstallerConfig{
+		Client:  k8sClient,
+		Files:   files,
+		Logger:  cfg.Logger.WithName("crdInstaller"),
+		Version: cfg.GatewayPodConfig.Version,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	return installer.Install(ctx)
+}
+
+func createManager(
+	cfg config.Config,
+	healthChecker *graphBuiltHealthChecker,
+	componentHealth *health.Tracker,
+	apiServerLimiter *loadshed.Limiter,
+) (manager.Manager, error) {
 	options := manager.Options{
 		Scheme:  scheme,
 		Logger:  cfg.Logger.V(1),
@@ -368,6 +945,15 @@ func createManager(cfg config.Config, healthChecker *graphBuiltHealthChecker) (m
 		},
 	}
 
+	if cfg.Webhook.Port != 0 {
+		// The webhook server uses the same certificate as the agent gRPC server, which is issued for the
+		// control plane Service.
+		options.WebhookServer = ctlrwebhook.NewServer(ctlrwebhook.Options{
+			Port:    cfg.Webhook.Port,
+			CertDir: webhookCertDir,
+		})
+	}
+
 	if cfg.HealthConfig.Enabled {
 		options.HealthProbeBindAddress = fmt.Sprintf(":%d", cfg.HealthConfig.Port)
 	}
@@ -375,6 +961,16 @@ func createManager(cfg config.Config, healthChecker *graphBuiltHealthChecker) (m
 	clusterCfg := ctlr.GetConfigOrDie()
 	clusterCfg.Timeout = clusterTimeout
 
+	// The limiter replaces the default client-side rate limiter, so it rate limits the requests the same way
+	// if client-side rate limiting is enabled.
+	var baseLimiter flowcontrol.RateLimiter
+	if clusterCfg.QPS > 0 {
+		baseLimiter = flowcontrol.NewTokenBucketRateLimiter(clusterCfg.QPS, clusterCfg.Burst)
+	}
+	apiServerLimiter.SetBase(baseLimiter)
+	clusterCfg.RateLimiter = apiServerLimiter
+	clusterCfg.Wrap(apiServerLimiter.WrapTransport)
+
 	mgr, err := manager.New(clusterCfg, options)
 	if err != nil {
 		return nil, err
@@ -384,6 +980,13 @@ func createManager(cfg config.Config, healthChecker *graphBuiltHealthChecker) (m
 		if err := mgr.AddReadyzCheck("readyz", healthChecker.readyCheck); err != nil {
 			return nil, fmt.Errorf("error adding ready check: %w", err)
 		}
+
+		// Every component is a separate health check, so that /healthz reports which components are unhealthy.
+		for _, component := range health.Components {
+			if err := mgr.AddHealthzCheck(string(component), componentHealth.Checker(component)); err != nil {
+				return nil, fmt.Errorf("error adding health check for %s: %w", component, err)
+			}
+		}
 	}
 
 	// Add an indexer to get pods by their IP address. This is used when validating that an agent
@@ -393,7 +996,7 @@ func createManager(cfg config.Config, healthChecker *graphBuiltHealthChecker) (m
 		context.Background(),
 		mgr.GetFieldIndexer(),
 		&apiv1.Pod{},
-		"status.podIP",
+		index.PodIPIndexField,
 		podIPIndexFunc,
 	); err != nil {
 		return nil, fmt.Errorf("error adding pod IP indexer: %w", err)
@@ -410,6 +1013,8 @@ func registerControllers(
 	logLevelSetter logLevelSetter,
 	eventCh chan interface{},
 	controlConfigNSName types.NamespacedName,
+	customRouteKinds graph.CustomRouteKinds,
+	apiAvailability apicompat.Availability,
 ) error {
 	type ctlrCfg struct {
 		name       string
@@ -440,7 +1045,12 @@ func registerControllers(
 			objectType: &gatewayv1.Gateway{},
 			options: func() []controller.Option {
 				options := []controller.Option{
-					controller.WithK8sPredicate(k8spredicate.GenerationChangedPredicate{}),
+					controller.WithK8sPredicate(
+						k8spredicate.Or(
+							k8spredicate.GenerationChangedPredicate{},
+							predicate.AnnotationPredicate{Annotation: configRollbackAnnotation},
+						),
+					),
 				}
 				return options
 			}(),
@@ -533,6 +1143,12 @@ func registerControllers(
 				controller.WithK8sPredicate(k8spredicate.GenerationChangedPredicate{}),
 			},
 		},
+		{
+			objectType: &ngfAPIv1alpha1.Backend{},
+			options: []controller.Option{
+				controller.WithK8sPredicate(k8spredicate.GenerationChangedPredicate{}),
+			},
+		},
 	}
 
 	if cfg.ExperimentalFeatures {
@@ -590,6 +1206,37 @@ func registerControllers(
 		)
 	}
 
+	if cfg.ClusterDefaultPolicies {
+		controllerRegCfgs = append(controllerRegCfgs,
+			ctlrCfg{
+				objectType: &ngfAPIv1alpha2.ClusterDefaultPolicy{},
+				options: []controller.Option{
+					controller.WithK8sPredicate(k8spredicate.GenerationChangedPredicate{}),
+				},
+			},
+		)
+	}
+
+	for _, routeKind := range customRouteKinds {
+		// custom routes are watched as unstructured objects, because their types are not part of the scheme
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(routeKind.GroupVersionKind())
+
+		controllerRegCfgs = append(controllerRegCfgs, ctlrCfg{
+			name:       routeKind.GroupVersionKind().GroupKind().String(),
+			objectType: route,
+			options: []controller.Option{
+				controller.WithK8sPredicate(k8spredicate.GenerationChangedPredicate{}),
+			},
+		})
+	}
+
+	// the Gateway API resources that are not served by the cluster can't be watched
+	controllerRegCfgs = slices.DeleteFunc(controllerRegCfgs, func(regCfg ctlrCfg) bool {
+		gvk, err := apiutil.GVKForObject(regCfg.objectType, scheme)
+		return err == nil && !apiAvailability.Served(gvk)
+	})
+
 	for _, regCfg := range controllerRegCfgs {
 		name := regCfg.objectType.GetObjectKind().GroupVersionKind().Kind
 		if regCfg.name != "" {
@@ -746,7 +1393,11 @@ func createTelemetryJob(
 	}, nil
 }
 
-func prepareFirstEventBatchPreparerArgs(cfg config.Config) ([]client.Object, []client.ObjectList) {
+func prepareFirstEventBatchPreparerArgs(
+	cfg config.Config,
+	customRouteKinds graph.CustomRouteKinds,
+	apiAvailability apicompat.Availability,
+) ([]client.Object, []client.ObjectList) {
 	objects := []client.Object{
 		&gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: cfg.GatewayClassName}},
 	}
@@ -774,6 +1425,7 @@ func prepareFirstEventBatchPreparerArgs(cfg config.Config) ([]client.Object, []c
 		&ngfAPIv1alpha1.ClientSettingsPolicyList{},
 		&ngfAPIv1alpha2.ObservabilityPolicyList{},
 		&ngfAPIv1alpha1.UpstreamSettingsPolicyList{},
+		&ngfAPIv1alpha1.BackendList{},
 		partialObjectMetadataList,
 	}
 
@@ -795,11 +1447,65 @@ func prepareFirstEventBatchPreparerArgs(cfg config.Config) ([]client.Object, []c
 		)
 	}
 
+	if cfg.ClusterDefaultPolicies {
+		objectLists = append(
+			objectLists,
+			&ngfAPIv1alpha2.ClusterDefaultPolicyList{},
+		)
+	}
+
+	for _, routeKind := range customRouteKinds {
+		routeList := &unstructured.UnstructuredList{}
+		routeList.SetGroupVersionKind(routeKind.GroupVersionKind().GroupVersion().WithKind(
+			routeKind.GroupVersionKind().Kind + "List",
+		))
+		objectLists = append(objectLists, routeList)
+	}
+
 	objectLists = append(objectLists, &gatewayv1.GatewayList{})
 
+	// the Gateway API resources that are not served by the cluster are not watched, so they can't be listed
+	objectLists = slices.DeleteFunc(objectLists, func(list client.ObjectList) bool {
+		gvk, err := apiutil.GVKForObject(list, scheme)
+		if err != nil {
+			return false
+		}
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+
+		return !apiAvailability.Served(gvk)
+	})
+
 	return objects, objectLists
 }
 
+// detectGatewayAPIResources detects the optional Gateway API resources that are not served by the cluster, for
+// example, because it runs an older Gateway API CRD bundle. The features that depend on them are inactive.
+// If the detection fails, all resources are assumed to be served.
+func detectGatewayAPIResources(cfg config.Config, restConfig *rest.Config) apicompat.Availability {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		cfg.Logger.Error(err, "Cannot create discovery client; assuming all Gateway API resources are served")
+		return apicompat.Availability{}
+	}
+
+	availability, err := apicompat.Detect(discoveryClient, apicompat.OptionalResources(cfg.ExperimentalFeatures))
+	if err != nil {
+		cfg.Logger.Error(err, "Cannot detect the served Gateway API resources; assuming all resources are served")
+		return apicompat.Availability{}
+	}
+
+	if len(availability.Missing()) > 0 {
+		cfg.Logger.Info(
+			"Some Gateway API resources are not served by the cluster and will not be watched. "+
+				"Install a newer Gateway API CRD bundle and restart NGINX Gateway Fabric to activate their features",
+			"resources", availability.String(),
+			"inactiveFeatures", availability.InactiveFeatures(),
+		)
+	}
+
+	return availability
+}
+
 func setInitialConfig(
 	reader client.Reader,
 	logger logr.Logger,
@@ -838,8 +1544,11 @@ func getMetricsOptions(cfg config.MetricsConfig) metricsserver.Options {
 	metricsOptions := metricsserver.Options{BindAddress: "0"}
 
 	if cfg.Enabled {
+		// the secure metrics server authenticates and authorizes the requests with the Kubernetes API,
+		// because it also serves the debug endpoints
 		if cfg.Secure {
 			metricsOptions.SecureServing = true
+			metricsOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 		}
 		metricsOptions.BindAddress = fmt.Sprintf(":%v", cfg.Port)
 	}
@@ -8,6 +8,7 @@ import (
 	discoveryV1 "k8s.io/api/discovery/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -21,10 +22,24 @@ import (
 
 	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
 	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/apicompat"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/config"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
 )
 
+var customRouteGVK = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "CustomRoute"}
+
+// testCustomRouteKind is a graph.CustomRouteKind that doesn't translate anything.
+type testCustomRouteKind struct{}
+
+func (testCustomRouteKind) GroupVersionKind() schema.GroupVersionKind {
+	return customRouteGVK
+}
+
+func (testCustomRouteKind) Translate(*unstructured.Unstructured) (gatewayv1.HTTPRouteSpec, error) {
+	return gatewayv1.HTTPRouteSpec{}, nil
+}
+
 func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
 	t.Parallel()
 	const gcName = "nginx"
@@ -38,12 +53,76 @@ func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
 		},
 	)
 
+	customRouteList := &unstructured.UnstructuredList{}
+	customRouteList.SetGroupVersionKind(customRouteGVK.GroupVersion().WithKind("CustomRouteList"))
+
 	tests := []struct {
 		expectedObjects     []client.Object
 		expectedObjectLists []client.ObjectList
+		customRouteKinds    graph.CustomRouteKinds
 		name                string
+		apiAvailability     apicompat.Availability
 		cfg                 config.Config
 	}{
+		{
+			name: "Gateway API resources not served by the cluster",
+			cfg: config.Config{
+				GatewayClassName:     gcName,
+				ExperimentalFeatures: true,
+			},
+			apiAvailability: apicompat.NewAvailability(apicompat.BackendTLSPolicy, apicompat.TLSRoute),
+			expectedObjects: []client.Object{
+				&gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}},
+			},
+			expectedObjectLists: []client.ObjectList{
+				&apiv1.ServiceList{},
+				&apiv1.SecretList{},
+				&apiv1.NamespaceList{},
+				&discoveryV1.EndpointSliceList{},
+				&gatewayv1.HTTPRouteList{},
+				&apiv1.ConfigMapList{},
+				&gatewayv1beta1.ReferenceGrantList{},
+				&ngfAPIv1alpha2.NginxProxyList{},
+				&gatewayv1.GRPCRouteList{},
+				&ngfAPIv1alpha1.ClientSettingsPolicyList{},
+				&ngfAPIv1alpha2.ObservabilityPolicyList{},
+				&ngfAPIv1alpha1.UpstreamSettingsPolicyList{},
+				&ngfAPIv1alpha1.BackendList{},
+				partialObjectMetadataList,
+				&gatewayv1.GatewayList{},
+			},
+		},
+		{
+			name: "custom route kinds registered",
+			cfg: config.Config{
+				GatewayClassName: gcName,
+			},
+			customRouteKinds: graph.CustomRouteKinds{
+				customRouteGVK.GroupKind(): testCustomRouteKind{},
+			},
+			expectedObjects: []client.Object{
+				&gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}},
+			},
+			expectedObjectLists: []client.ObjectList{
+				&apiv1.ServiceList{},
+				&apiv1.SecretList{},
+				&apiv1.NamespaceList{},
+				&discoveryV1.EndpointSliceList{},
+				&gatewayv1.HTTPRouteList{},
+				&gatewayv1.BackendTLSPolicyList{},
+				&apiv1.ConfigMapList{},
+				&gatewayv1.GatewayList{},
+				&gatewayv1beta1.ReferenceGrantList{},
+				&ngfAPIv1alpha2.NginxProxyList{},
+				&gatewayv1.GRPCRouteList{},
+				partialObjectMetadataList,
+				&ngfAPIv1alpha1.ClientSettingsPolicyList{},
+				&ngfAPIv1alpha2.ObservabilityPolicyList{},
+				&ngfAPIv1alpha1.UpstreamSettingsPolicyList{},
+				&ngfAPIv1alpha1.BackendList{},
+				customRouteList,
+			},
+		},
 		{
 			name: "base case",
 			cfg: config.Config{
@@ -68,6 +147,7 @@ func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
 				&ngfAPIv1alpha1.ClientSettingsPolicyList{},
 				&ngfAPIv1alpha2.ObservabilityPolicyList{},
 				&ngfAPIv1alpha1.UpstreamSettingsPolicyList{},
+				&ngfAPIv1alpha1.BackendList{},
 			},
 		},
 		{
@@ -96,6 +176,7 @@ func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
 				&ngfAPIv1alpha1.ClientSettingsPolicyList{},
 				&ngfAPIv1alpha2.ObservabilityPolicyList{},
 				&ngfAPIv1alpha1.UpstreamSettingsPolicyList{},
+				&ngfAPIv1alpha1.BackendList{},
 			},
 		},
 		{
@@ -121,6 +202,7 @@ func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
 				&ngfAPIv1alpha1.ClientSettingsPolicyList{},
 				&ngfAPIv1alpha2.ObservabilityPolicyList{},
 				&ngfAPIv1alpha1.UpstreamSettingsPolicyList{},
+				&ngfAPIv1alpha1.BackendList{},
 				partialObjectMetadataList,
 				&inference.InferencePoolList{},
 				&gatewayv1.GatewayList{},
@@ -152,6 +234,7 @@ func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
 				&ngfAPIv1alpha2.ObservabilityPolicyList{},
 				&ngfAPIv1alpha1.SnippetsFilterList{},
 				&ngfAPIv1alpha1.UpstreamSettingsPolicyList{},
+				&ngfAPIv1alpha1.BackendList{},
 			},
 		},
 		{
@@ -184,6 +267,7 @@ func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
 				&ngfAPIv1alpha2.ObservabilityPolicyList{},
 				&ngfAPIv1alpha1.SnippetsFilterList{},
 				&ngfAPIv1alpha1.UpstreamSettingsPolicyList{},
+				&ngfAPIv1alpha1.BackendList{},
 			},
 		},
 	}
@@ -193,7 +277,11 @@ func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
 
-			objects, objectLists := prepareFirstEventBatchPreparerArgs(test.cfg)
+			objects, objectLists := prepareFirstEventBatchPreparerArgs(
+				test.cfg,
+				test.customRouteKinds,
+				test.apiAvailability,
+			)
 
 			g.Expect(objects).To(ConsistOf(test.expectedObjects))
 			g.Expect(objectLists).To(ConsistOf(test.expectedObjectLists))
@@ -207,6 +295,7 @@ func TestGetMetricsOptions(t *testing.T) {
 		name            string
 		expectedOptions metricsserver.Options
 		metricsConfig   config.MetricsConfig
+		expectedFilter  bool
 	}{
 		{
 			name:            "Metrics disabled",
@@ -236,6 +325,7 @@ func TestGetMetricsOptions(t *testing.T) {
 				SecureServing: true,
 				BindAddress:   ":9113",
 			},
+			expectedFilter: true,
 		},
 	}
 
@@ -246,6 +336,10 @@ func TestGetMetricsOptions(t *testing.T) {
 
 			metricsServerOptions := getMetricsOptions(test.metricsConfig)
 
+			// functions can't be compared, so only the presence of the filter is checked
+			g.Expect(metricsServerOptions.FilterProvider != nil).To(Equal(test.expectedFilter))
+			metricsServerOptions.FilterProvider = nil
+
 			g.Expect(metricsServerOptions).To(Equal(test.expectedOptions))
 		})
 	}
@@ -0,0 +1,60 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics"
+)
+
+// ComponentHealthCollector collects metrics about the health of the components of the control plane.
+// Implements the prometheus.Collector interface.
+type ComponentHealthCollector struct {
+	// Metrics
+	componentHealthy *prometheus.GaugeVec
+}
+
+// NewComponentHealthCollector creates a new ComponentHealthCollector.
+func NewComponentHealthCollector(constLabels map[string]string) *ComponentHealthCollector {
+	return &ComponentHealthCollector{
+		componentHealthy: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "component_healthy",
+				Namespace:   metrics.Namespace,
+				Help:        "Whether the component of the control plane is healthy (1) or not (0)",
+				ConstLabels: constLabels,
+			},
+			[]string{"component"},
+		),
+	}
+}
+
+// SetComponentHealth records whether the component is healthy.
+func (c *ComponentHealthCollector) SetComponentHealth(component string, healthy bool) {
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+
+	c.componentHealthy.WithLabelValues(component).Set(value)
+}
+
+// Describe implements prometheus.Collector interface Describe method.
+func (c *ComponentHealthCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.componentHealthy.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface Collect method.
+func (c *ComponentHealthCollector) Collect(ch chan<- prometheus.Metric) {
+	c.componentHealthy.Collect(ch)
+}
+
+// ComponentHealthNoopCollector used to initialize the ComponentHealthCollector when metrics are disabled
+// to avoid nil pointer errors.
+type ComponentHealthNoopCollector struct{}
+
+// NewComponentHealthNoopCollector returns an instance of the ComponentHealthNoopCollector.
+func NewComponentHealthNoopCollector() *ComponentHealthNoopCollector {
+	return &ComponentHealthNoopCollector{}
+}
+
+func (c *ComponentHealthNoopCollector) SetComponentHealth(_ string, _ bool) {}
@@ -0,0 +1,24 @@
+package collectors
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestComponentHealthCollector(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	c := NewComponentHealthCollector(map[string]string{"class": "nginx"})
+
+	c.SetComponentHealth("cache-sync", false)
+	c.SetComponentHealth("status-updater", false)
+	c.SetComponentHealth("cache-sync", true)
+
+	g.Expect(testutil.ToFloat64(c.componentHealthy.WithLabelValues("cache-sync"))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(c.componentHealthy.WithLabelValues("status-updater"))).To(Equal(0.0))
+
+	g.Expect(testutil.CollectAndCount(c)).To(Equal(2))
+}
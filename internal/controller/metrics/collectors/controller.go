@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics"
 )
@@ -13,6 +14,7 @@ import (
 type ControllerCollector struct {
 	// Metrics
 	eventBatchProcessDuration prometheus.Histogram
+	configApplyLatency        *prometheus.HistogramVec
 }
 
 // NewControllerCollector creates a new ControllerCollector.
@@ -27,6 +29,17 @@ func NewControllerCollector(constLabels map[string]string) *ControllerCollector
 				Buckets:     []float64{500, 1000, 5000, 10000, 30000},
 			},
 		),
+		configApplyLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:      "config_apply_latency_milliseconds",
+				Namespace: metrics.Namespace,
+				Help: "Duration in milliseconds from receiving a resource change to the NGINX configuration " +
+					"being applied (including the reload) for a Gateway",
+				ConstLabels: constLabels,
+				Buckets:     []float64{100, 500, 1000, 2500, 5000, 10000, 30000, 60000},
+			},
+			[]string{"gateway"},
+		),
 	}
 	return nc
 }
@@ -36,14 +49,22 @@ func (c *ControllerCollector) ObserveLastEventBatchProcessTime(duration time.Dur
 	c.eventBatchProcessDuration.Observe(float64(duration / time.Millisecond))
 }
 
+// ObserveConfigApplyLatency adds the duration from receiving a resource change to the NGINX configuration
+// being applied to the histogram of the Gateway.
+func (c *ControllerCollector) ObserveConfigApplyLatency(gateway types.NamespacedName, duration time.Duration) {
+	c.configApplyLatency.WithLabelValues(gateway.String()).Observe(float64(duration / time.Millisecond))
+}
+
 // Describe implements prometheus.Collector interface Describe method.
 func (c *ControllerCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.eventBatchProcessDuration.Describe(ch)
+	c.configApplyLatency.Describe(ch)
 }
 
 // Collect implements the prometheus.Collector interface Collect method.
 func (c *ControllerCollector) Collect(ch chan<- prometheus.Metric) {
 	c.eventBatchProcessDuration.Collect(ch)
+	c.configApplyLatency.Collect(ch)
 }
 
 // ControllerNoopCollector used to initialize the ControllerCollector when metrics are disabled to avoid nil pointer
@@ -56,3 +77,6 @@ func NewControllerNoopCollector() *ControllerNoopCollector {
 }
 
 func (c *ControllerNoopCollector) ObserveLastEventBatchProcessTime(_ time.Duration) {}
+
+func (c *ControllerNoopCollector) ObserveConfigApplyLatency(_ types.NamespacedName, _ time.Duration) {
+}
@@ -0,0 +1,56 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics"
+)
+
+// DataPlaneFailureCollector collects metrics about the failures of the NGINX data plane of the Gateways.
+// Implements the prometheus.Collector interface.
+type DataPlaneFailureCollector struct {
+	// Metrics
+	failures *prometheus.CounterVec
+}
+
+// NewDataPlaneFailureCollector creates a new DataPlaneFailureCollector.
+func NewDataPlaneFailureCollector(constLabels map[string]string) *DataPlaneFailureCollector {
+	return &DataPlaneFailureCollector{
+		failures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:      "data_plane_failures_total",
+				Namespace: metrics.Namespace,
+				Help: "Number of worker process crashes, OOM kills, and configuration reload failures " +
+					"of the NGINX of the Gateway",
+				ConstLabels: constLabels,
+			},
+			[]string{"gateway", "kind"},
+		),
+	}
+}
+
+// ObserveDataPlaneFailure records a failure of the kind of the NGINX data plane of the Gateway.
+func (c *DataPlaneFailureCollector) ObserveDataPlaneFailure(gateway, kind string) {
+	c.failures.WithLabelValues(gateway, kind).Inc()
+}
+
+// Describe implements prometheus.Collector interface Describe method.
+func (c *DataPlaneFailureCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.failures.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface Collect method.
+func (c *DataPlaneFailureCollector) Collect(ch chan<- prometheus.Metric) {
+	c.failures.Collect(ch)
+}
+
+// DataPlaneFailureNoopCollector used to initialize the DataPlaneFailureCollector when metrics are disabled
+// to avoid nil pointer errors.
+type DataPlaneFailureNoopCollector struct{}
+
+// NewDataPlaneFailureNoopCollector returns an instance of the DataPlaneFailureNoopCollector.
+func NewDataPlaneFailureNoopCollector() *DataPlaneFailureNoopCollector {
+	return &DataPlaneFailureNoopCollector{}
+}
+
+func (c *DataPlaneFailureNoopCollector) ObserveDataPlaneFailure(_, _ string) {}
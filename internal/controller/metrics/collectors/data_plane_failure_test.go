@@ -0,0 +1,26 @@
+package collectors
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestDataPlaneFailureCollector_ObserveDataPlaneFailure(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	c := NewDataPlaneFailureCollector(map[string]string{"class": "nginx"})
+
+	c.ObserveDataPlaneFailure("test/gateway", "worker_crash")
+	c.ObserveDataPlaneFailure("test/gateway", "worker_crash")
+	c.ObserveDataPlaneFailure("test/gateway", "oom_kill")
+	c.ObserveDataPlaneFailure("test/other-gateway", "reload_failure")
+
+	g.Expect(testutil.ToFloat64(c.failures.WithLabelValues("test/gateway", "worker_crash"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(c.failures.WithLabelValues("test/gateway", "oom_kill"))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(c.failures.WithLabelValues("test/other-gateway", "reload_failure"))).To(Equal(1.0))
+
+	g.Expect(testutil.CollectAndCount(c)).To(Equal(3))
+}
@@ -0,0 +1,96 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics"
+)
+
+// LoadShedCollector collects metrics about the requests to the Kubernetes API server that were deferred or shed
+// because of the pressure on the API server.
+// Implements the prometheus.Collector interface.
+type LoadShedCollector struct {
+	// Metrics
+	throttledResponses prometheus.Counter
+	deferredRequests   prometheus.Histogram
+	shedRequests       prometheus.Counter
+}
+
+// NewLoadShedCollector creates a new LoadShedCollector.
+func NewLoadShedCollector(constLabels map[string]string) *LoadShedCollector {
+	return &LoadShedCollector{
+		throttledResponses: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name:        "api_server_throttled_responses_total",
+				Namespace:   metrics.Namespace,
+				Help:        "Number of 429 Too Many Requests responses from the Kubernetes API server",
+				ConstLabels: constLabels,
+			},
+		),
+		deferredRequests: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:      "api_server_deferred_requests_milliseconds",
+				Namespace: metrics.Namespace,
+				Help: "Duration in milliseconds for which low-priority requests to the Kubernetes API server " +
+					"were deferred because of the pressure on the API server",
+				ConstLabels: constLabels,
+				Buckets:     []float64{100, 500, 1000, 5000, 10000, 15000},
+			},
+		),
+		shedRequests: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name:      "api_server_shed_requests_total",
+				Namespace: metrics.Namespace,
+				Help: "Number of low-priority requests to the Kubernetes API server that were shed " +
+					"because of the pressure on the API server",
+				ConstLabels: constLabels,
+			},
+		),
+	}
+}
+
+// IncThrottledResponses increments the number of throttled responses.
+func (c *LoadShedCollector) IncThrottledResponses() {
+	c.throttledResponses.Inc()
+}
+
+// ObserveDeferred adds the duration for which a request was deferred to the histogram.
+func (c *LoadShedCollector) ObserveDeferred(duration time.Duration) {
+	c.deferredRequests.Observe(float64(duration / time.Millisecond))
+}
+
+// IncShed increments the number of shed requests.
+func (c *LoadShedCollector) IncShed() {
+	c.shedRequests.Inc()
+}
+
+// Describe implements prometheus.Collector interface Describe method.
+func (c *LoadShedCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.throttledResponses.Describe(ch)
+	c.deferredRequests.Describe(ch)
+	c.shedRequests.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface Collect method.
+func (c *LoadShedCollector) Collect(ch chan<- prometheus.Metric) {
+	c.throttledResponses.Collect(ch)
+	c.deferredRequests.Collect(ch)
+	c.shedRequests.Collect(ch)
+}
+
+// LoadShedNoopCollector used to initialize the LoadShedCollector when metrics are disabled to avoid nil pointer
+// errors.
+type LoadShedNoopCollector struct{}
+
+// NewLoadShedNoopCollector returns an instance of the LoadShedNoopCollector.
+func NewLoadShedNoopCollector() *LoadShedNoopCollector {
+	return &LoadShedNoopCollector{}
+}
+
+func (c *LoadShedNoopCollector) IncThrottledResponses() {}
+
+func (c *LoadShedNoopCollector) ObserveDeferred(_ time.Duration) {}
+
+func (c *LoadShedNoopCollector) IncShed() {}
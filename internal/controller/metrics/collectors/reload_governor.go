@@ -0,0 +1,79 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics"
+)
+
+// ReloadGovernorCollector collects metrics about the NGINX reloads that the reload governor deferred.
+// Implements the prometheus.Collector interface.
+type ReloadGovernorCollector struct {
+	// Metrics
+	deferredReloads   *prometheus.CounterVec
+	deferredReloadAge *prometheus.HistogramVec
+}
+
+// NewReloadGovernorCollector creates a new ReloadGovernorCollector.
+func NewReloadGovernorCollector(constLabels map[string]string) *ReloadGovernorCollector {
+	return &ReloadGovernorCollector{
+		deferredReloads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "nginx_reloads_deferred_total",
+				Namespace:   metrics.Namespace,
+				Help:        "Number of NGINX reloads that were deferred to enforce the minimum interval between reloads",
+				ConstLabels: constLabels,
+			},
+			[]string{"change"},
+		),
+		deferredReloadAge: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:      "nginx_deferred_reload_age_milliseconds",
+				Namespace: metrics.Namespace,
+				Help: "Duration in milliseconds for which an NGINX reload was deferred before the configuration " +
+					"was applied",
+				ConstLabels: constLabels,
+				Buckets:     []float64{500, 1000, 5000, 10000, 30000, 60000, 300000},
+			},
+			[]string{"change"},
+		),
+	}
+}
+
+// ObserveReloadDeferred records a reload of the kind of change that was deferred.
+func (c *ReloadGovernorCollector) ObserveReloadDeferred(change string) {
+	c.deferredReloads.WithLabelValues(change).Inc()
+}
+
+// ObserveDeferredReloadAge records the time for which a reload of the kind of change was deferred
+// before it was applied.
+func (c *ReloadGovernorCollector) ObserveDeferredReloadAge(change string, age time.Duration) {
+	c.deferredReloadAge.WithLabelValues(change).Observe(float64(age.Milliseconds()))
+}
+
+// Describe implements prometheus.Collector interface Describe method.
+func (c *ReloadGovernorCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.deferredReloads.Describe(ch)
+	c.deferredReloadAge.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface Collect method.
+func (c *ReloadGovernorCollector) Collect(ch chan<- prometheus.Metric) {
+	c.deferredReloads.Collect(ch)
+	c.deferredReloadAge.Collect(ch)
+}
+
+// ReloadGovernorNoopCollector used to initialize the ReloadGovernorCollector when metrics are disabled
+// to avoid nil pointer errors.
+type ReloadGovernorNoopCollector struct{}
+
+// NewReloadGovernorNoopCollector returns an instance of the ReloadGovernorNoopCollector.
+func NewReloadGovernorNoopCollector() *ReloadGovernorNoopCollector {
+	return &ReloadGovernorNoopCollector{}
+}
+
+func (c *ReloadGovernorNoopCollector) ObserveReloadDeferred(_ string) {}
+
+func (c *ReloadGovernorNoopCollector) ObserveDeferredReloadAge(_ string, _ time.Duration) {}
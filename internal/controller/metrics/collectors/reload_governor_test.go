@@ -0,0 +1,26 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestReloadGovernorCollector(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	c := NewReloadGovernorCollector(map[string]string{"class": "nginx"})
+
+	c.ObserveReloadDeferred("endpoints")
+	c.ObserveReloadDeferred("endpoints")
+	c.ObserveReloadDeferred("priority")
+	c.ObserveDeferredReloadAge("priority", 2*time.Second)
+
+	g.Expect(testutil.ToFloat64(c.deferredReloads.WithLabelValues("endpoints"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(c.deferredReloads.WithLabelValues("priority"))).To(Equal(1.0))
+
+	g.Expect(testutil.CollectAndCount(c)).To(Equal(3))
+}
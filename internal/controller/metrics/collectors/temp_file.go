@@ -0,0 +1,55 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics"
+)
+
+// TempFileCollector collects metrics about the writes of NGINX to its temporary files.
+// Implements the prometheus.Collector interface.
+type TempFileCollector struct {
+	// Metrics
+	writes *prometheus.CounterVec
+}
+
+// NewTempFileCollector creates a new TempFileCollector.
+func NewTempFileCollector(constLabels map[string]string) *TempFileCollector {
+	return &TempFileCollector{
+		writes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "temp_file_writes_total",
+				Namespace:   metrics.Namespace,
+				Help:        "Number of request bodies and responses that the NGINX of the Gateway wrote to temporary files",
+				ConstLabels: constLabels,
+			},
+			[]string{"gateway", "kind"},
+		),
+	}
+}
+
+// ObserveTempFileWrite records a write of the kind to a temporary file of the NGINX of the Gateway.
+func (c *TempFileCollector) ObserveTempFileWrite(gateway, kind string) {
+	c.writes.WithLabelValues(gateway, kind).Inc()
+}
+
+// Describe implements prometheus.Collector interface Describe method.
+func (c *TempFileCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.writes.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface Collect method.
+func (c *TempFileCollector) Collect(ch chan<- prometheus.Metric) {
+	c.writes.Collect(ch)
+}
+
+// TempFileNoopCollector used to initialize the TempFileCollector when metrics are disabled
+// to avoid nil pointer errors.
+type TempFileNoopCollector struct{}
+
+// NewTempFileNoopCollector returns an instance of the TempFileNoopCollector.
+func NewTempFileNoopCollector() *TempFileNoopCollector {
+	return &TempFileNoopCollector{}
+}
+
+func (c *TempFileNoopCollector) ObserveTempFileWrite(_, _ string) {}
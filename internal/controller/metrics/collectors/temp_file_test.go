@@ -0,0 +1,26 @@
+package collectors
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTempFileCollector_ObserveTempFileWrite(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	c := NewTempFileCollector(map[string]string{"class": "nginx"})
+
+	c.ObserveTempFileWrite("test/gateway", "request_body")
+	c.ObserveTempFileWrite("test/gateway", "request_body")
+	c.ObserveTempFileWrite("test/gateway", "upstream_response")
+	c.ObserveTempFileWrite("test/other-gateway", "upstream_response")
+
+	g.Expect(testutil.ToFloat64(c.writes.WithLabelValues("test/gateway", "request_body"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(c.writes.WithLabelValues("test/gateway", "upstream_response"))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(c.writes.WithLabelValues("test/other-gateway", "upstream_response"))).To(Equal(1.0))
+
+	g.Expect(testutil.CollectAndCount(c)).To(Equal(3))
+}
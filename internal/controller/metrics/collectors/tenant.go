@@ -0,0 +1,129 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics"
+)
+
+const (
+	// maxTenantsPerGateway is the maximum number of the tenants of a Gateway that have their own metrics.
+	// The tenant of a request can be set by the client in a request header, so the number of the tenants
+	// is limited to keep the number of the metrics bounded.
+	maxTenantsPerGateway = 1000
+
+	// OtherTenant is the tenant that the requests of the tenants beyond the maximum number of the tenants
+	// of a Gateway are counted for.
+	OtherTenant = "other"
+)
+
+// TenantCollector collects metrics about the requests and bytes of the tenants of the Gateways.
+// Implements the prometheus.Collector interface.
+type TenantCollector struct {
+	// Metrics
+	requests      *prometheus.CounterVec
+	requestBytes  *prometheus.CounterVec
+	responseBytes *prometheus.CounterVec
+
+	tenants    map[string]map[string]struct{}
+	maxTenants int
+	lock       sync.Mutex
+}
+
+// NewTenantCollector creates a new TenantCollector.
+func NewTenantCollector(constLabels map[string]string) *TenantCollector {
+	labels := []string{"gateway", "tenant"}
+
+	return &TenantCollector{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "tenant_requests_total",
+				Namespace:   metrics.Namespace,
+				Help:        "Number of requests of the tenant of the Gateway",
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		requestBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "tenant_request_bytes_total",
+				Namespace:   metrics.Namespace,
+				Help:        "Number of bytes received in the requests of the tenant of the Gateway",
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		responseBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "tenant_response_bytes_total",
+				Namespace:   metrics.Namespace,
+				Help:        "Number of bytes sent in the responses to the tenant of the Gateway",
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		tenants:    make(map[string]map[string]struct{}),
+		maxTenants: maxTenantsPerGateway,
+	}
+}
+
+// ObserveRequest records a request of the tenant of the Gateway, with the size of the request
+// and the size of its response in bytes.
+func (c *TenantCollector) ObserveRequest(gateway, tenant string, requestBytes, responseBytes int64) {
+	tenant = c.admitTenant(gateway, tenant)
+
+	c.requests.WithLabelValues(gateway, tenant).Inc()
+	c.requestBytes.WithLabelValues(gateway, tenant).Add(float64(requestBytes))
+	c.responseBytes.WithLabelValues(gateway, tenant).Add(float64(responseBytes))
+}
+
+// admitTenant returns the tenant that the request is counted for, which is the OtherTenant
+// if the Gateway already has the maximum number of the tenants.
+func (c *TenantCollector) admitTenant(gateway, tenant string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	tenants, ok := c.tenants[gateway]
+	if !ok {
+		tenants = make(map[string]struct{})
+		c.tenants[gateway] = tenants
+	}
+
+	if _, exists := tenants[tenant]; exists {
+		return tenant
+	}
+
+	if len(tenants) >= c.maxTenants {
+		return OtherTenant
+	}
+
+	tenants[tenant] = struct{}{}
+
+	return tenant
+}
+
+// Describe implements prometheus.Collector interface Describe method.
+func (c *TenantCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.requestBytes.Describe(ch)
+	c.responseBytes.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface Collect method.
+func (c *TenantCollector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.requestBytes.Collect(ch)
+	c.responseBytes.Collect(ch)
+}
+
+// TenantNoopCollector used to initialize the TenantCollector when metrics are disabled to avoid nil pointer errors.
+type TenantNoopCollector struct{}
+
+// NewTenantNoopCollector returns an instance of the TenantNoopCollector.
+func NewTenantNoopCollector() *TenantNoopCollector {
+	return &TenantNoopCollector{}
+}
+
+func (c *TenantNoopCollector) ObserveRequest(_, _ string, _, _ int64) {}
@@ -0,0 +1,39 @@
+package collectors
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTenantCollector_ObserveRequest(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	c := NewTenantCollector(map[string]string{"class": "nginx"})
+	c.maxTenants = 2
+
+	c.ObserveRequest("test/gateway", "team-a", 100, 1000)
+	c.ObserveRequest("test/gateway", "team-a", 50, 500)
+	c.ObserveRequest("test/gateway", "team-b", 10, 20)
+	// beyond the maximum number of the tenants of the Gateway
+	c.ObserveRequest("test/gateway", "team-c", 1, 2)
+	c.ObserveRequest("test/gateway", "team-d", 3, 4)
+	// the tenants are limited per Gateway
+	c.ObserveRequest("test/other-gateway", "team-c", 5, 6)
+
+	g.Expect(testutil.ToFloat64(c.requests.WithLabelValues("test/gateway", "team-a"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(c.requestBytes.WithLabelValues("test/gateway", "team-a"))).To(Equal(150.0))
+	g.Expect(testutil.ToFloat64(c.responseBytes.WithLabelValues("test/gateway", "team-a"))).To(Equal(1500.0))
+
+	g.Expect(testutil.ToFloat64(c.requests.WithLabelValues("test/gateway", "team-b"))).To(Equal(1.0))
+
+	g.Expect(testutil.ToFloat64(c.requests.WithLabelValues("test/gateway", OtherTenant))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(c.requestBytes.WithLabelValues("test/gateway", OtherTenant))).To(Equal(4.0))
+	g.Expect(testutil.ToFloat64(c.responseBytes.WithLabelValues("test/gateway", OtherTenant))).To(Equal(6.0))
+
+	g.Expect(testutil.ToFloat64(c.requests.WithLabelValues("test/other-gateway", "team-c"))).To(Equal(1.0))
+
+	g.Expect(testutil.CollectAndCount(c)).To(Equal(12))
+}
@@ -32,6 +32,7 @@ const retryUpstreamTimeout = 5 * time.Second
 type NginxUpdater interface {
 	UpdateConfig(deployment *Deployment, files []File, volumeMounts []v1.VolumeMount)
 	UpdateUpstreamServers(deployment *Deployment, conf dataplane.Configuration)
+	RollbackConfig(deployment *Deployment, volumeMounts []v1.VolumeMount) error
 }
 
 // NginxUpdaterImpl implements the NginxUpdater interface.
@@ -50,6 +51,8 @@ func NewNginxUpdater(
 	reader client.Reader,
 	statusQueue *status.Queue,
 	resetConnChan <-chan struct{},
+	eventCh chan<- interface{},
+	failures FailureRecorder,
 	plus bool,
 ) *NginxUpdaterImpl {
 	connTracker := agentgrpc.NewConnectionsTracker()
@@ -62,6 +65,8 @@ func NewNginxUpdater(
 		connTracker,
 		statusQueue,
 		resetConnChan,
+		eventCh,
+		failures,
 	)
 	fileService := newFileService(logger.WithName("fileService"), nginxDeployments, connTracker)
 
@@ -104,6 +109,35 @@ func (n *NginxUpdaterImpl) UpdateConfig(
 	deployment.SetLatestConfigError(deployment.GetConfigurationStatus())
 }
 
+// RollbackConfig sends the previous nginx configuration of the deployment to the agent. The rolled back
+// configuration is held until the rollback of the deployment is released. If the configuration is already
+// rolled back, nothing is sent, so that the held configuration isn't rolled back again.
+// It returns ErrNoPreviousConfig if the deployment doesn't have a previous configuration.
+func (n *NginxUpdaterImpl) RollbackConfig(deployment *Deployment, volumeMounts []v1.VolumeMount) error {
+	if deployment.IsRolledBack() {
+		return nil
+	}
+
+	msg, err := deployment.RollBack(volumeMounts)
+	if err != nil {
+		return err
+	}
+
+	if msg == nil {
+		n.logger.V(1).Info("Previous nginx configuration is the same as the current one, not sending to agent")
+		return nil
+	}
+
+	applied := deployment.GetBroadcaster().Send(*msg)
+	if applied {
+		n.logger.Info("Sent previous nginx configuration to agent")
+	}
+
+	deployment.SetLatestConfigError(deployment.GetConfigurationStatus())
+
+	return nil
+}
+
 // UpdateUpstreamServers sends an APIRequest to the agent to update upstream servers using the NGINX Plus API.
 // Only applicable when using NGINX Plus.
 func (n *NginxUpdaterImpl) UpdateUpstreamServers(
@@ -45,7 +45,7 @@ func TestUpdateConfig(t *testing.T) {
 			fakeBroadcaster.SendReturns(true)
 
 			plus := false
-			updater := NewNginxUpdater(logr.Discard(), fake.NewFakeClient(), &status.Queue{}, nil, plus)
+			updater := NewNginxUpdater(logr.Discard(), fake.NewFakeClient(), &status.Queue{}, nil, nil, nil, plus)
 			deployment := &Deployment{
 				broadcaster: fakeBroadcaster,
 				podStatuses: make(map[string]error),
@@ -90,7 +90,7 @@ func TestUpdateConfig_NoChange(t *testing.T) {
 
 	fakeBroadcaster := &broadcastfakes.FakeBroadcaster{}
 
-	updater := NewNginxUpdater(logr.Discard(), fake.NewFakeClient(), &status.Queue{}, nil, false)
+	updater := NewNginxUpdater(logr.Discard(), fake.NewFakeClient(), &status.Queue{}, nil, nil, nil, false)
 
 	deployment := &Deployment{
 		broadcaster: fakeBroadcaster,
@@ -115,6 +115,57 @@ func TestUpdateConfig_NoChange(t *testing.T) {
 	g.Expect(fakeBroadcaster.SendCallCount()).To(Equal(0))
 }
 
+func TestRollbackConfig(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	fakeBroadcaster := &broadcastfakes.FakeBroadcaster{}
+	fakeBroadcaster.SendReturns(true)
+
+	updater := NewNginxUpdater(logr.Discard(), fake.NewFakeClient(), &status.Queue{}, nil, nil, nil, false)
+
+	deployment := &Deployment{
+		broadcaster: fakeBroadcaster,
+		podStatuses: make(map[string]error),
+	}
+
+	oldFile := File{
+		Meta: &pb.FileMeta{
+			Name: "test.conf",
+			Hash: "12345",
+		},
+		Contents: []byte("old content"),
+	}
+	newFile := File{
+		Meta: &pb.FileMeta{
+			Name: "test.conf",
+			Hash: "67890",
+		},
+		Contents: []byte("new content"),
+	}
+
+	g.Expect(updater.RollbackConfig(deployment, []v1.VolumeMount{})).To(MatchError(ErrNoPreviousConfig))
+	g.Expect(fakeBroadcaster.SendCallCount()).To(Equal(0))
+
+	updater.UpdateConfig(deployment, []File{oldFile}, []v1.VolumeMount{})
+	updater.UpdateConfig(deployment, []File{newFile}, []v1.VolumeMount{})
+	g.Expect(fakeBroadcaster.SendCallCount()).To(Equal(2))
+
+	g.Expect(updater.RollbackConfig(deployment, []v1.VolumeMount{})).To(Succeed())
+	g.Expect(fakeBroadcaster.SendCallCount()).To(Equal(3))
+	g.Expect(deployment.IsRolledBack()).To(BeTrue())
+
+	fileContents, _ := deployment.GetFile(oldFile.Meta.Name, oldFile.Meta.Hash)
+	g.Expect(fileContents).To(Equal(oldFile.Contents))
+
+	// the held configuration isn't rolled back again
+	g.Expect(updater.RollbackConfig(deployment, []v1.VolumeMount{})).To(Succeed())
+	g.Expect(fakeBroadcaster.SendCallCount()).To(Equal(3))
+
+	fileContents, _ = deployment.GetFile(oldFile.Meta.Name, oldFile.Meta.Hash)
+	g.Expect(fileContents).To(Equal(oldFile.Contents))
+}
+
 func TestUpdateUpstreamServers(t *testing.T) {
 	t.Parallel()
 
@@ -156,7 +207,7 @@ func TestUpdateUpstreamServers(t *testing.T) {
 
 			fakeBroadcaster := &broadcastfakes.FakeBroadcaster{}
 
-			updater := NewNginxUpdater(logr.Discard(), fake.NewFakeClient(), &status.Queue{}, nil, test.plus)
+			updater := NewNginxUpdater(logr.Discard(), fake.NewFakeClient(), &status.Queue{}, nil, nil, nil, test.plus)
 			updater.retryTimeout = 0
 
 			deployment := &Deployment{
@@ -283,7 +334,7 @@ func TestUpdateUpstreamServers_NoChange(t *testing.T) {
 
 	fakeBroadcaster := &broadcastfakes.FakeBroadcaster{}
 
-	updater := NewNginxUpdater(logr.Discard(), fake.NewFakeClient(), &status.Queue{}, nil, true)
+	updater := NewNginxUpdater(logr.Discard(), fake.NewFakeClient(), &status.Queue{}, nil, nil, nil, true)
 	updater.retryTimeout = 0
 
 	deployment := &Deployment{
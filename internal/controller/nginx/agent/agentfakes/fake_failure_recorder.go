@@ -0,0 +1,126 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package agentfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type FakeFailureRecorder struct {
+	RecordStub        func(types.NamespacedName, graph.DataPlaneFailureKind, string)
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		arg1 types.NamespacedName
+		arg2 graph.DataPlaneFailureKind
+		arg3 string
+	}
+	RecordOnceStub        func(types.NamespacedName, graph.DataPlaneFailureKind, string, string, time.Time)
+	recordOnceMutex       sync.RWMutex
+	recordOnceArgsForCall []struct {
+		arg1 types.NamespacedName
+		arg2 graph.DataPlaneFailureKind
+		arg3 string
+		arg4 string
+		arg5 time.Time
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeFailureRecorder) Record(arg1 types.NamespacedName, arg2 graph.DataPlaneFailureKind, arg3 string) {
+	fake.recordMutex.Lock()
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		arg1 types.NamespacedName
+		arg2 graph.DataPlaneFailureKind
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RecordStub
+	fake.recordInvocation("Record", []interface{}{arg1, arg2, arg3})
+	fake.recordMutex.Unlock()
+	if stub != nil {
+		fake.RecordStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeFailureRecorder) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeFailureRecorder) RecordCalls(stub func(types.NamespacedName, graph.DataPlaneFailureKind, string)) {
+	fake.recordMutex.Lock()
+	defer fake.recordMutex.Unlock()
+	fake.RecordStub = stub
+}
+
+func (fake *FakeFailureRecorder) RecordArgsForCall(i int) (types.NamespacedName, graph.DataPlaneFailureKind, string) {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	argsForCall := fake.recordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeFailureRecorder) RecordOnce(arg1 types.NamespacedName, arg2 graph.DataPlaneFailureKind, arg3 string, arg4 string, arg5 time.Time) {
+	fake.recordOnceMutex.Lock()
+	fake.recordOnceArgsForCall = append(fake.recordOnceArgsForCall, struct {
+		arg1 types.NamespacedName
+		arg2 graph.DataPlaneFailureKind
+		arg3 string
+		arg4 string
+		arg5 time.Time
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.RecordOnceStub
+	fake.recordInvocation("RecordOnce", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordOnceMutex.Unlock()
+	if stub != nil {
+		fake.RecordOnceStub(arg1, arg2, arg3, arg4, arg5)
+	}
+}
+
+func (fake *FakeFailureRecorder) RecordOnceCallCount() int {
+	fake.recordOnceMutex.RLock()
+	defer fake.recordOnceMutex.RUnlock()
+	return len(fake.recordOnceArgsForCall)
+}
+
+func (fake *FakeFailureRecorder) RecordOnceCalls(stub func(types.NamespacedName, graph.DataPlaneFailureKind, string, string, time.Time)) {
+	fake.recordOnceMutex.Lock()
+	defer fake.recordOnceMutex.Unlock()
+	fake.RecordOnceStub = stub
+}
+
+func (fake *FakeFailureRecorder) RecordOnceArgsForCall(i int) (types.NamespacedName, graph.DataPlaneFailureKind, string, string, time.Time) {
+	fake.recordOnceMutex.RLock()
+	defer fake.recordOnceMutex.RUnlock()
+	argsForCall := fake.recordOnceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeFailureRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeFailureRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ agent.FailureRecorder = new(FakeFailureRecorder)
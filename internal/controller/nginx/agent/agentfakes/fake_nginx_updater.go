@@ -10,6 +10,18 @@ import (
 )
 
 type FakeNginxUpdater struct {
+	RollbackConfigStub        func(*agent.Deployment, []v1.VolumeMount) error
+	rollbackConfigMutex       sync.RWMutex
+	rollbackConfigArgsForCall []struct {
+		arg1 *agent.Deployment
+		arg2 []v1.VolumeMount
+	}
+	rollbackConfigReturns struct {
+		result1 error
+	}
+	rollbackConfigReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateConfigStub        func(*agent.Deployment, []agent.File, []v1.VolumeMount)
 	updateConfigMutex       sync.RWMutex
 	updateConfigArgsForCall []struct {
@@ -27,6 +39,73 @@ type FakeNginxUpdater struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeNginxUpdater) RollbackConfig(arg1 *agent.Deployment, arg2 []v1.VolumeMount) error {
+	var arg2Copy []v1.VolumeMount
+	if arg2 != nil {
+		arg2Copy = make([]v1.VolumeMount, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.rollbackConfigMutex.Lock()
+	ret, specificReturn := fake.rollbackConfigReturnsOnCall[len(fake.rollbackConfigArgsForCall)]
+	fake.rollbackConfigArgsForCall = append(fake.rollbackConfigArgsForCall, struct {
+		arg1 *agent.Deployment
+		arg2 []v1.VolumeMount
+	}{arg1, arg2Copy})
+	stub := fake.RollbackConfigStub
+	fakeReturns := fake.rollbackConfigReturns
+	fake.recordInvocation("RollbackConfig", []interface{}{arg1, arg2Copy})
+	fake.rollbackConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNginxUpdater) RollbackConfigCallCount() int {
+	fake.rollbackConfigMutex.RLock()
+	defer fake.rollbackConfigMutex.RUnlock()
+	return len(fake.rollbackConfigArgsForCall)
+}
+
+func (fake *FakeNginxUpdater) RollbackConfigCalls(stub func(*agent.Deployment, []v1.VolumeMount) error) {
+	fake.rollbackConfigMutex.Lock()
+	defer fake.rollbackConfigMutex.Unlock()
+	fake.RollbackConfigStub = stub
+}
+
+func (fake *FakeNginxUpdater) RollbackConfigArgsForCall(i int) (*agent.Deployment, []v1.VolumeMount) {
+	fake.rollbackConfigMutex.RLock()
+	defer fake.rollbackConfigMutex.RUnlock()
+	argsForCall := fake.rollbackConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNginxUpdater) RollbackConfigReturns(result1 error) {
+	fake.rollbackConfigMutex.Lock()
+	defer fake.rollbackConfigMutex.Unlock()
+	fake.RollbackConfigStub = nil
+	fake.rollbackConfigReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNginxUpdater) RollbackConfigReturnsOnCall(i int, result1 error) {
+	fake.rollbackConfigMutex.Lock()
+	defer fake.rollbackConfigMutex.Unlock()
+	fake.RollbackConfigStub = nil
+	if fake.rollbackConfigReturnsOnCall == nil {
+		fake.rollbackConfigReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rollbackConfigReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNginxUpdater) UpdateConfig(arg1 *agent.Deployment, arg2 []agent.File, arg3 []v1.VolumeMount) {
 	var arg2Copy []agent.File
 	if arg2 != nil {
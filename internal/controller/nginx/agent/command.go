@@ -26,19 +26,51 @@ import (
 	grpcContext "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc/context"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc/messenger"
 	nginxTypes "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/types"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
 )
 
 const connectionWaitTimeout = 30 * time.Second
 
+// oomKilledReason is the reason of the termination of a container that was killed,
+// because it ran out of memory.
+const oomKilledReason = "OOMKilled"
+
+//counterfeiter:generate . FailureRecorder
+
+// FailureRecorder records the failures of the nginx data plane.
+type FailureRecorder interface {
+	// Record records a failure of the kind of the nginx Deployment that occurred now.
+	Record(deployment types.NamespacedName, kind graph.DataPlaneFailureKind, message string)
+	// RecordOnce records a failure of the kind of the nginx Deployment that is identified by the id and occurred
+	// at the time. The failure is ignored if it was already recorded.
+	RecordOnce(
+		deployment types.NamespacedName,
+		kind graph.DataPlaneFailureKind,
+		id string,
+		message string,
+		occurred time.Time,
+	)
+}
+
+// CapabilitiesChangedEvent is sent to the event loop when the capabilities that are unsupported by
+// at least one Pod of an nginx Deployment changed, so that the nginx configuration is regenerated
+// without (or again with) the features that depend on those capabilities.
+type CapabilitiesChangedEvent struct {
+	// Deployment is the nginx Deployment.
+	Deployment types.NamespacedName
+}
+
 // commandService handles the connection and subscription to the data plane agent.
 type commandService struct {
 	pb.CommandServiceServer
 	nginxDeployments  *DeploymentStore
 	statusQueue       *status.Queue
 	resetConnChan     <-chan struct{}
+	eventCh           chan<- interface{}
 	connTracker       agentgrpc.ConnectionsTracker
 	k8sReader         client.Reader
+	failures          FailureRecorder
 	logger            logr.Logger
 	connectionTimeout time.Duration
 }
@@ -50,8 +82,11 @@ func newCommandService(
 	connTracker agentgrpc.ConnectionsTracker,
 	statusQueue *status.Queue,
 	resetConnChan <-chan struct{},
+	eventCh chan<- interface{},
+	failures FailureRecorder,
 ) *commandService {
 	return &commandService{
+		failures:          failures,
 		connectionTimeout: connectionWaitTimeout,
 		k8sReader:         reader,
 		logger:            logger,
@@ -59,6 +94,7 @@ func newCommandService(
 		nginxDeployments:  depStore,
 		statusQueue:       statusQueue,
 		resetConnChan:     resetConnChan,
+		eventCh:           eventCh,
 	}
 }
 
@@ -103,13 +139,23 @@ func (cs *commandService) CreateConnection(
 		return response, grpcStatus.Errorf(codes.InvalidArgument, "error getting pod owner: %s", err.Error())
 	}
 
+	versions := agentgrpc.GetDataPlaneVersions(resource.GetInstances())
 	conn := agentgrpc.Connection{
-		ParentName: name,
-		ParentType: depType,
-		InstanceID: getNginxInstanceID(resource.GetInstances()),
+		ParentName:   name,
+		ParentType:   depType,
+		PodName:      podName,
+		InstanceID:   getNginxInstanceID(resource.GetInstances()),
+		Capabilities: agentgrpc.NegotiateCapabilities(versions),
 	}
 	cs.connTracker.Track(grpcInfo.UUID, conn)
 
+	cs.logger.V(1).Info(
+		"Negotiated capabilities with nginx agent",
+		"agentVersion", versions.AgentVersion,
+		"nginxVersion", versions.NginxVersion,
+		"capabilities", conn.Capabilities,
+	)
+
 	return &pb.CreateConnectionResponse{
 		Response: &pb.CommandResponse{
 			Status: pb.CommandResponse_COMMAND_STATUS_OK,
@@ -144,12 +190,25 @@ func (cs *commandService) Subscribe(in pb.CommandService_SubscribeServer) error
 	}
 	defer deployment.RemovePodStatus(grpcInfo.UUID)
 
+	// features that the agent doesn't support must be withheld from the configuration of the whole Deployment
+	if deployment.SetPodCapabilities(grpcInfo.UUID, conn.Capabilities) {
+		go cs.sendCapabilitiesChangedEvent(conn.ParentName)
+	}
+	defer func() {
+		if deployment.RemovePodCapabilities(grpcInfo.UUID) {
+			go cs.sendCapabilitiesChangedEvent(conn.ParentName)
+		}
+	}()
+
 	cs.logger.Info(
 		"Successfully connected to nginx agent",
 		conn.ParentType, conn.ParentName,
 		"uuid", grpcInfo.UUID,
 	)
 
+	// the agent connects again when its nginx container was restarted, for example, after an OOM kill
+	cs.recordOOMKill(ctx, conn)
+
 	msgr := messenger.New(in)
 	go msgr.Run(ctx)
 
@@ -229,6 +288,10 @@ func (cs *commandService) Subscribe(in pb.CommandService_SubscribeServer) error
 				}
 				err := fmt.Errorf("msg: %s; error: %s", res.GetMessage(), res.GetError())
 				deployment.SetPodErrorStatus(grpcInfo.UUID, err)
+
+				if pendingBroadcastRequest == nil || pendingBroadcastRequest.Type == broadcast.ConfigApplyRequest {
+					cs.recordFailure(conn.ParentName, graph.DataPlaneFailureReloadFailure, err.Error())
+				}
 			} else {
 				deployment.SetPodErrorStatus(grpcInfo.UUID, nil)
 			}
@@ -283,6 +346,26 @@ func (cs *commandService) waitForConnection(
 	}
 }
 
+// sendCapabilitiesChangedEvent notifies the event loop that the capabilities of a Deployment changed.
+func (cs *commandService) sendCapabilitiesChangedEvent(deployment types.NamespacedName) {
+	if cs.eventCh == nil {
+		return
+	}
+
+	timer := time.NewTimer(cs.connectionTimeout)
+	defer timer.Stop()
+
+	select {
+	case cs.eventCh <- &CapabilitiesChangedEvent{Deployment: deployment}:
+	case <-timer.C:
+		cs.logger.Error(
+			errors.New("timed out sending event"),
+			"error notifying about changed capabilities",
+			"deployment", deployment.String(),
+		)
+	}
+}
+
 // setInitialConfig gets the initial configuration for this connection and applies it.
 func (cs *commandService) setInitialConfig(
 	ctx context.Context,
@@ -321,6 +404,10 @@ func (cs *commandService) setInitialConfig(
 		return connErr
 	}
 
+	if applyErr != nil {
+		cs.recordFailure(conn.ParentName, graph.DataPlaneFailureReloadFailure, applyErr.Error())
+	}
+
 	errs := []error{applyErr}
 	for _, action := range deployment.GetNGINXPlusActions() {
 		// retry the API update request because sometimes nginx isn't quite ready after the config apply reload
@@ -423,9 +510,11 @@ func (cs *commandService) logAndSendErrorStatus(
 	deployment.SetPodErrorStatus(grpcInfo.UUID, err)
 
 	queueObj := &status.QueueObject{
-		Deployment: conn.ParentName,
-		Error:      deployment.GetConfigurationStatus(),
-		UpdateType: status.UpdateAll,
+		Deployment:       conn.ParentName,
+		Error:            deployment.GetConfigurationStatus(),
+		WithheldFeatures: deployment.GetWithheldFeatures(),
+		UpdateType:       status.UpdateAll,
+		RolledBack:       deployment.IsRolledBack(),
 	}
 	cs.statusQueue.Enqueue(queueObj)
 }
@@ -475,6 +564,50 @@ func buildPlusAPIRequest(action *pb.NGINXPlusAction, instanceID string) *pb.Mana
 	}
 }
 
+// recordFailure records a failure of the nginx Deployment, if the failures are recorded.
+func (cs *commandService) recordFailure(
+	deployment types.NamespacedName,
+	kind graph.DataPlaneFailureKind,
+	message string,
+) {
+	if cs.failures != nil {
+		cs.failures.Record(deployment, kind, message)
+	}
+}
+
+// recordOOMKill records a failure of the nginx Deployment if the previous nginx container of the Pod
+// of the agent was OOM killed. The failure is recorded once for every restart of the container.
+func (cs *commandService) recordOOMKill(ctx context.Context, conn *agentgrpc.Connection) {
+	if cs.failures == nil || conn.PodName == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	pod := &v1.Pod{}
+	podNsName := types.NamespacedName{Namespace: conn.ParentName.Namespace, Name: conn.PodName}
+	if err := cs.k8sReader.Get(ctx, podNsName, pod); err != nil {
+		cs.logger.V(1).Info("Failed to get the nginx Pod", "pod", podNsName.String(), "error", err.Error())
+		return
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		terminated := containerStatus.LastTerminationState.Terminated
+		if containerStatus.Name != "nginx" || terminated == nil || terminated.Reason != oomKilledReason {
+			continue
+		}
+
+		cs.failures.RecordOnce(
+			conn.ParentName,
+			graph.DataPlaneFailureOOMKill,
+			fmt.Sprintf("%s/%d", pod.UID, containerStatus.RestartCount),
+			fmt.Sprintf("the nginx container of Pod %s was OOM killed", pod.Name),
+			terminated.FinishedAt.Time,
+		)
+	}
+}
+
 // validatePodImageVersion checks if the pod's nginx container image version matches the expected version
 // from its deployment. Returns an error if versions don't match.
 func (cs *commandService) validatePodImageVersion(
@@ -552,6 +685,11 @@ func (cs *commandService) UpdateDataPlaneStatus(
 
 	cs.connTracker.SetInstanceID(grpcInfo.UUID, instanceID)
 
+	// the nginx instance might not have been discovered when the connection was created,
+	// so the capabilities are negotiated again with the reported nginx version
+	versions := agentgrpc.GetDataPlaneVersions(req.GetResource().GetInstances())
+	cs.connTracker.SetCapabilities(grpcInfo.UUID, agentgrpc.NegotiateCapabilities(versions))
+
 	return &pb.UpdateDataPlaneStatusResponse{}, nil
 }
 
@@ -27,6 +27,7 @@ import (
 	agentgrpcfakes "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc/grpcfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc/messenger/messengerfakes"
 	nginxTypes "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/types"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
 )
 
@@ -136,11 +137,13 @@ func TestCreateConnection(t *testing.T) {
 							InstanceMeta: &pb.InstanceMeta{
 								InstanceId:   "nginx-id",
 								InstanceType: pb.InstanceMeta_INSTANCE_TYPE_NGINX,
+								Version:      "1.27.2",
 							},
 						},
 						{
 							InstanceMeta: &pb.InstanceMeta{
 								InstanceType: pb.InstanceMeta_INSTANCE_TYPE_AGENT,
+								Version:      "v3.0.0",
 							},
 							InstanceConfig: &pb.InstanceConfig{
 								Config: &pb.InstanceConfig_AgentConfig{
@@ -231,6 +234,8 @@ func TestCreateConnection(t *testing.T) {
 				&connTracker,
 				status.NewQueue(),
 				nil,
+				nil,
+				nil,
 			)
 
 			resp, err := cs.CreateConnection(test.ctx, test.request)
@@ -246,10 +251,13 @@ func TestCreateConnection(t *testing.T) {
 			g.Expect(err).ToNot(HaveOccurred())
 			g.Expect(connTracker.TrackCallCount()).To(Equal(1))
 
+			// the nginx version doesn't support any capabilities
 			expConn := agentgrpc.Connection{
-				ParentName: types.NamespacedName{Namespace: "test", Name: "nginx-deployment"},
-				ParentType: nginxTypes.DeploymentType,
-				InstanceID: "nginx-id",
+				ParentName:   types.NamespacedName{Namespace: "test", Name: "nginx-deployment"},
+				ParentType:   nginxTypes.DeploymentType,
+				PodName:      "nginx-pod",
+				InstanceID:   "nginx-id",
+				Capabilities: []agentgrpc.Capability{},
 			}
 
 			key, conn := connTracker.TrackArgsForCall(0)
@@ -331,6 +339,8 @@ func TestSubscribe(t *testing.T) {
 		&connTracker,
 		status.NewQueue(),
 		nil,
+		nil,
+		nil,
 	)
 
 	broadcaster := &broadcastfakes.FakeBroadcaster{}
@@ -477,6 +487,8 @@ func TestSubscribe_Reset(t *testing.T) {
 		&connTracker,
 		status.NewQueue(),
 		resetChan,
+		nil,
+		nil,
 	)
 
 	broadcaster := &broadcastfakes.FakeBroadcaster{}
@@ -583,6 +595,8 @@ func TestSubscribe_Errors(t *testing.T) {
 				&connTracker,
 				status.NewQueue(),
 				nil,
+				nil,
+				nil,
 			)
 
 			if test.setup != nil {
@@ -709,6 +723,8 @@ func TestSetInitialConfig_Errors(t *testing.T) {
 				&connTracker,
 				status.NewQueue(),
 				nil,
+				nil,
+				nil,
 			)
 
 			conn := &agentgrpc.Connection{
@@ -732,16 +748,142 @@ func TestSetInitialConfig_Errors(t *testing.T) {
 	}
 }
 
+type recordedFailure struct {
+	occurred   time.Time
+	deployment types.NamespacedName
+	kind       graph.DataPlaneFailureKind
+	id         string
+	message    string
+}
+
+type fakeFailureRecorder struct {
+	failures []recordedFailure
+}
+
+func (f *fakeFailureRecorder) Record(deployment types.NamespacedName, kind graph.DataPlaneFailureKind, msg string) {
+	f.failures = append(f.failures, recordedFailure{deployment: deployment, kind: kind, message: msg})
+}
+
+func (f *fakeFailureRecorder) RecordOnce(
+	deployment types.NamespacedName,
+	kind graph.DataPlaneFailureKind,
+	id string,
+	message string,
+	occurred time.Time,
+) {
+	f.failures = append(f.failures, recordedFailure{
+		deployment: deployment,
+		kind:       kind,
+		id:         id,
+		message:    message,
+		occurred:   occurred,
+	})
+}
+
+func TestRecordOOMKill(t *testing.T) {
+	t.Parallel()
+
+	finishedAt := metav1.NewTime(time.Date(2026, 10, 17, 10, 0, 0, 0, time.Local))
+
+	createPod := func(reason string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-pod",
+				Namespace: "test",
+				UID:       "pod-uid",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						Name:         "nginx",
+						RestartCount: 2,
+						LastTerminationState: v1.ContainerState{
+							Terminated: &v1.ContainerStateTerminated{
+								Reason:     reason,
+								FinishedAt: finishedAt,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	deployment := types.NamespacedName{Namespace: "test", Name: "nginx-deployment"}
+
+	tests := []struct {
+		pod         *v1.Pod
+		name        string
+		expFailures []recordedFailure
+	}{
+		{
+			name: "nginx container was OOM killed",
+			pod:  createPod("OOMKilled"),
+			expFailures: []recordedFailure{
+				{
+					deployment: deployment,
+					kind:       graph.DataPlaneFailureOOMKill,
+					id:         "pod-uid/2",
+					message:    "the nginx container of Pod nginx-pod was OOM killed",
+					occurred:   finishedAt.Time,
+				},
+			},
+		},
+		{
+			name: "nginx container exited for another reason",
+			pod:  createPod("Error"),
+		},
+		{
+			name: "pod doesn't exist",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			var objs []runtime.Object
+			if test.pod != nil {
+				objs = append(objs, test.pod)
+			}
+			fakeClient, err := createFakeK8sClient(objs...)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			connTracker := agentgrpcfakes.FakeConnectionsTracker{}
+			recorder := &fakeFailureRecorder{}
+			cs := newCommandService(
+				logr.Discard(),
+				fakeClient,
+				NewDeploymentStore(&connTracker),
+				&connTracker,
+				status.NewQueue(),
+				nil,
+				nil,
+				recorder,
+			)
+
+			cs.recordOOMKill(t.Context(), &agentgrpc.Connection{
+				ParentName: deployment,
+				PodName:    "nginx-pod",
+			})
+
+			g.Expect(recorder.failures).To(Equal(test.expFailures))
+		})
+	}
+}
+
 func TestUpdateDataPlaneStatus(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		request   *pb.UpdateDataPlaneStatusRequest
-		response  *pb.UpdateDataPlaneStatusResponse
-		ctx       context.Context
-		errString string
-		expID     string
-		name      string
+		request         *pb.UpdateDataPlaneStatusRequest
+		response        *pb.UpdateDataPlaneStatusResponse
+		ctx             context.Context
+		errString       string
+		expID           string
+		name            string
+		expCapabilities []agentgrpc.Capability
 	}{
 		{
 			name: "successfully sets the status",
@@ -753,13 +895,15 @@ func TestUpdateDataPlaneStatus(t *testing.T) {
 							InstanceMeta: &pb.InstanceMeta{
 								InstanceId:   "nginx-id",
 								InstanceType: pb.InstanceMeta_INSTANCE_TYPE_NGINX,
+								Version:      "1.27.2",
 							},
 						},
 					},
 				},
 			},
-			expID:    "nginx-id",
-			response: &pb.UpdateDataPlaneStatusResponse{},
+			expID:           "nginx-id",
+			expCapabilities: []agentgrpc.Capability{},
+			response:        &pb.UpdateDataPlaneStatusResponse{},
 		},
 		{
 			name: "successfully sets the status using plus",
@@ -771,13 +915,15 @@ func TestUpdateDataPlaneStatus(t *testing.T) {
 							InstanceMeta: &pb.InstanceMeta{
 								InstanceId:   "nginx-plus-id",
 								InstanceType: pb.InstanceMeta_INSTANCE_TYPE_NGINX_PLUS,
+								Version:      "1.27.2",
 							},
 						},
 					},
 				},
 			},
-			expID:    "nginx-plus-id",
-			response: &pb.UpdateDataPlaneStatusResponse{},
+			expID:           "nginx-plus-id",
+			expCapabilities: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+			response:        &pb.UpdateDataPlaneStatusResponse{},
 		},
 		{
 			name:      "request is nil",
@@ -815,6 +961,8 @@ func TestUpdateDataPlaneStatus(t *testing.T) {
 				&connTracker,
 				status.NewQueue(),
 				nil,
+				nil,
+				nil,
 			)
 
 			resp, err := cs.UpdateDataPlaneStatus(test.ctx, test.request)
@@ -825,6 +973,7 @@ func TestUpdateDataPlaneStatus(t *testing.T) {
 				g.Expect(resp).To(BeNil())
 
 				g.Expect(connTracker.SetInstanceIDCallCount()).To(Equal(0))
+				g.Expect(connTracker.SetCapabilitiesCallCount()).To(Equal(0))
 
 				return
 			}
@@ -837,10 +986,48 @@ func TestUpdateDataPlaneStatus(t *testing.T) {
 			key, id := connTracker.SetInstanceIDArgsForCall(0)
 			g.Expect(key).To(Equal("1234567"))
 			g.Expect(id).To(Equal(test.expID))
+
+			g.Expect(connTracker.SetCapabilitiesCallCount()).To(Equal(1))
+
+			key, capabilities := connTracker.SetCapabilitiesArgsForCall(0)
+			g.Expect(key).To(Equal("1234567"))
+			g.Expect(capabilities).To(Equal(test.expCapabilities))
 		})
 	}
 }
 
+func TestSendCapabilitiesChangedEvent(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	connTracker := agentgrpcfakes.FakeConnectionsTracker{}
+	eventCh := make(chan interface{}, 1)
+
+	cs := newCommandService(
+		logr.Discard(),
+		fake.NewFakeClient(),
+		NewDeploymentStore(&connTracker),
+		&connTracker,
+		status.NewQueue(),
+		nil,
+		eventCh,
+		nil,
+	)
+
+	deploymentName := types.NamespacedName{Namespace: "test", Name: "nginx-deployment"}
+	cs.sendCapabilitiesChangedEvent(deploymentName)
+
+	g.Expect(eventCh).To(Receive(Equal(&CapabilitiesChangedEvent{Deployment: deploymentName})))
+
+	// the event is dropped if nobody receives it
+	cs.connectionTimeout = 10 * time.Millisecond
+	eventCh <- struct{}{}
+	cs.sendCapabilitiesChangedEvent(deploymentName)
+
+	g.Expect(eventCh).To(Receive(Equal(struct{}{})))
+	g.Expect(eventCh).ToNot(Receive())
+}
+
 func TestUpdateDataPlaneHealth(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -854,6 +1041,8 @@ func TestUpdateDataPlaneHealth(t *testing.T) {
 		&connTracker,
 		status.NewQueue(),
 		nil,
+		nil,
+		nil,
 	)
 
 	resp, err := cs.UpdateDataPlaneHealth(t.Context(), &pb.UpdateDataPlaneHealthRequest{})
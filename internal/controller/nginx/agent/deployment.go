@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -39,6 +40,8 @@ type Deployment struct {
 	// podStatuses is a map of all Pods for this Deployment and the most recent error
 	// (or nil if successful) that occurred on a config call to the nginx agent.
 	podStatuses map[string]error
+	// podCapabilities is a map of all Pods for this Deployment and the capabilities negotiated with their agents.
+	podCapabilities map[string][]agentgrpc.Capability
 
 	broadcaster broadcast.Broadcaster
 
@@ -59,18 +62,34 @@ type Deployment struct {
 	nginxPlusActions []*pb.NGINXPlusAction
 	fileOverviews    []*pb.File
 	files            []File
+	// previousFiles are the files of the configuration that preceded the current one.
+	// They are kept in memory, so they are lost when the control plane restarts.
+	previousFiles []File
 
 	latestFileNames []string
+	// withheldCapabilities are the capabilities that were withheld from the latest configuration,
+	// because at least one Pod doesn't support them.
+	withheldCapabilities []agentgrpc.Capability
 
-	FileLock sync.RWMutex
-	errLock  sync.RWMutex
+	FileLock         sync.RWMutex
+	errLock          sync.RWMutex
+	capabilitiesLock sync.RWMutex
+
+	// rolledBack is true if the configuration was rolled back to the previous files. The rolled back
+	// configuration is held until the rollback is released.
+	rolledBack bool
 }
 
+// ErrNoPreviousConfig is returned when the configuration of a deployment is rolled back,
+// but there is no previous configuration to roll back to.
+var ErrNoPreviousConfig = errors.New("no previous nginx configuration to roll back to")
+
 // newDeployment returns a new Deployment object.
 func newDeployment(broadcaster broadcast.Broadcaster) *Deployment {
 	return &Deployment{
-		broadcaster: broadcaster,
-		podStatuses: make(map[string]error),
+		broadcaster:     broadcaster,
+		podStatuses:     make(map[string]error),
+		podCapabilities: make(map[string][]agentgrpc.Capability),
 	}
 }
 
@@ -153,6 +172,79 @@ func (d *Deployment) GetConfigurationStatus() error {
 	return errors.Join(errs...)
 }
 
+// SetPodCapabilities sets the capabilities negotiated with the agent of a Pod. It returns true if
+// the capabilities that are unsupported by at least one Pod changed.
+func (d *Deployment) SetPodCapabilities(pod string, capabilities []agentgrpc.Capability) bool {
+	d.capabilitiesLock.Lock()
+	defer d.capabilitiesLock.Unlock()
+
+	previous := d.unsupportedCapabilities()
+	d.podCapabilities[pod] = capabilities
+
+	return !slices.Equal(previous, d.unsupportedCapabilities())
+}
+
+// RemovePodCapabilities deletes a pod from the pod capabilities map. It returns true if
+// the capabilities that are unsupported by at least one Pod changed.
+func (d *Deployment) RemovePodCapabilities(pod string) bool {
+	d.capabilitiesLock.Lock()
+	defer d.capabilitiesLock.Unlock()
+
+	previous := d.unsupportedCapabilities()
+	delete(d.podCapabilities, pod)
+
+	return !slices.Equal(previous, d.unsupportedCapabilities())
+}
+
+// GetUnsupportedCapabilities returns the capabilities that are not supported by at least one Pod
+// of this Deployment, in sorted order.
+func (d *Deployment) GetUnsupportedCapabilities() []agentgrpc.Capability {
+	d.capabilitiesLock.RLock()
+	defer d.capabilitiesLock.RUnlock()
+
+	return d.unsupportedCapabilities()
+}
+
+func (d *Deployment) unsupportedCapabilities() []agentgrpc.Capability {
+	var unsupported []agentgrpc.Capability
+
+	for _, capability := range agentgrpc.AllCapabilities() {
+		for _, capabilities := range d.podCapabilities {
+			if !slices.Contains(capabilities, capability) {
+				unsupported = append(unsupported, capability)
+				break
+			}
+		}
+	}
+
+	return unsupported
+}
+
+// SetWithheldCapabilities sets the capabilities that were withheld from the latest configuration.
+func (d *Deployment) SetWithheldCapabilities(capabilities []agentgrpc.Capability) {
+	d.capabilitiesLock.Lock()
+	defer d.capabilitiesLock.Unlock()
+
+	d.withheldCapabilities = capabilities
+}
+
+// GetWithheldFeatures returns the names of the capabilities that were withheld from the latest configuration.
+func (d *Deployment) GetWithheldFeatures() []string {
+	d.capabilitiesLock.RLock()
+	defer d.capabilitiesLock.RUnlock()
+
+	if len(d.withheldCapabilities) == 0 {
+		return nil
+	}
+
+	features := make([]string, 0, len(d.withheldCapabilities))
+	for _, capability := range d.withheldCapabilities {
+		features = append(features, string(capability))
+	}
+
+	return features
+}
+
 /*
 The following functions for the Deployment object are UNLOCKED, meaning that they are unsafe.
 Callers of these functions MUST ensure the FileLock is set before calling.
@@ -189,14 +281,30 @@ func (d *Deployment) GetFile(name, hash string) ([]byte, string) {
 	return nil, fileFoundHash
 }
 
+// GetFiles returns a copy of the nginx files for the deployment.
+func (d *Deployment) GetFiles() []File {
+	d.FileLock.RLock()
+	defer d.FileLock.RUnlock()
+
+	files := make([]File, len(d.files))
+	copy(files, d.files)
+
+	return files
+}
+
 // SetFiles updates the nginx files and fileOverviews for the deployment and returns the message to send.
 // The deployment FileLock MUST already be locked before calling this function.
 func (d *Deployment) SetFiles(files []File, volumeMounts []v1.VolumeMount) *broadcast.NginxAgentMessage {
+	currentFiles := d.files
+
+	if removed := d.removedUnmanagedFiles(files); len(removed) > 0 {
+		files = append(slices.Clip(files), removed...)
+	}
 	d.files = files
 
 	fileOverviews := make([]*pb.File, 0, len(files))
 	for _, file := range files {
-		fileOverviews = append(fileOverviews, &pb.File{FileMeta: file.Meta})
+		fileOverviews = append(fileOverviews, &pb.File{FileMeta: file.Meta, Unmanaged: file.Unmanaged})
 	}
 
 	// To avoid duplicates, use a set for volume ignore files
@@ -235,6 +343,7 @@ func (d *Deployment) SetFiles(files []File, volumeMounts []v1.VolumeMount) *broa
 
 	d.configVersion = newConfigVersion
 	d.fileOverviews = fileOverviews
+	d.previousFiles = currentFiles
 
 	return &broadcast.NginxAgentMessage{
 		Type:          broadcast.ConfigApplyRequest,
@@ -243,6 +352,69 @@ func (d *Deployment) SetFiles(files []File, volumeMounts []v1.VolumeMount) *broa
 	}
 }
 
+// removedUnmanagedFiles returns the unmanaged files of the current configuration that are not in the new files,
+// as empty managed files. The agent never deletes an unmanaged file, so a removed unmanaged file is sent
+// as a managed file once, which makes the agent truncate it and delete it with the next configuration.
+// The deployment FileLock MUST already be locked before calling this function.
+func (d *Deployment) removedUnmanagedFiles(files []File) []File {
+	newFileNames := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		newFileNames[file.Meta.GetName()] = struct{}{}
+	}
+
+	var removed []File
+	for _, file := range d.files {
+		if !file.Unmanaged {
+			continue
+		}
+
+		if _, exists := newFileNames[file.Meta.GetName()]; exists {
+			continue
+		}
+
+		removed = append(removed, File{
+			Meta: &pb.FileMeta{
+				Name:        file.Meta.GetName(),
+				Hash:        filesHelper.GenerateHash(nil),
+				Permissions: file.Meta.GetPermissions(),
+			},
+			Contents: []byte{},
+		})
+	}
+
+	return removed
+}
+
+// RollBack sets the files of the previous configuration as the files of the deployment and returns the message
+// to send. The rolled back configuration is held until ReleaseRollback is called.
+// The deployment FileLock MUST already be locked before calling this function.
+func (d *Deployment) RollBack(volumeMounts []v1.VolumeMount) (*broadcast.NginxAgentMessage, error) {
+	if d.previousFiles == nil {
+		return nil, ErrNoPreviousConfig
+	}
+
+	msg := d.SetFiles(d.previousFiles, volumeMounts)
+	d.rolledBack = true
+
+	return msg, nil
+}
+
+// ReleaseRollback releases the rolled back configuration, so that the next configuration replaces it.
+// It returns true if the configuration was rolled back.
+// The deployment FileLock MUST already be locked before calling this function.
+func (d *Deployment) ReleaseRollback() bool {
+	rolledBack := d.rolledBack
+	d.rolledBack = false
+
+	return rolledBack
+}
+
+// IsRolledBack returns true if the configuration of the deployment is rolled back and held.
+// The deployment FileLock MUST already be locked before calling this function.
+func (d *Deployment) IsRolledBack() bool {
+	return d.rolledBack
+}
+
 // SetNGINXPlusActions updates the deployment's latest NGINX Plus Actions to perform if using NGINX Plus.
 // Used by a Subscriber when it first connects.
 // The deployment FileLock MUST already be locked before calling this function.
@@ -316,6 +488,29 @@ func (d *DeploymentStore) StoreWithBroadcaster(
 	return deployment
 }
 
+// List returns all Deployments in the store.
+func (d *DeploymentStore) List() map[types.NamespacedName]*Deployment {
+	deployments := make(map[types.NamespacedName]*Deployment)
+
+	d.deployments.Range(func(key, val any) bool {
+		nsName, ok := key.(types.NamespacedName)
+		if !ok {
+			panic(fmt.Sprintf("expected NamespacedName, got type %T", key))
+		}
+
+		deployment, ok := val.(*Deployment)
+		if !ok {
+			panic(fmt.Sprintf("expected Deployment, got type %T", val))
+		}
+
+		deployments[nsName] = deployment
+
+		return true
+	})
+
+	return deployments
+}
+
 // Remove the deployment from the store.
 func (d *DeploymentStore) Remove(nsName types.NamespacedName) {
 	d.deployments.Delete(nsName)
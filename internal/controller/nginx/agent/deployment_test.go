@@ -5,12 +5,14 @@ import (
 	"testing"
 
 	pb "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	filesHelper "github.com/nginx/agent/v3/pkg/files"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/broadcast"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/broadcast/broadcastfakes"
+	agentgrpc "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc"
 	agentgrpcfakes "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc/grpcfakes"
 )
 
@@ -54,6 +56,7 @@ func TestSetAndGetFiles(t *testing.T) {
 
 	file, _ := deployment.GetFile("test.conf", "12345")
 	g.Expect(file).To(Equal([]byte("test content")))
+	g.Expect(deployment.GetFiles()).To(Equal(files))
 
 	invalidFile, _ := deployment.GetFile("invalid", "12345")
 	g.Expect(invalidFile).To(BeNil())
@@ -68,6 +71,106 @@ func TestSetAndGetFiles(t *testing.T) {
 	g.Expect(newFileOverviews).To(Equal(fileOverviews))
 }
 
+func TestSetFiles_UnmanagedFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := newDeployment(&broadcastfakes.FakeBroadcaster{})
+
+	stateFile := File{
+		Meta: &pb.FileMeta{
+			Name: "/var/lib/nginx/state/up.conf",
+		},
+		Unmanaged: true,
+	}
+	files := []File{
+		{
+			Meta: &pb.FileMeta{
+				Name: "test.conf",
+				Hash: "12345",
+			},
+			Contents: []byte("test content"),
+		},
+		stateFile,
+	}
+
+	msg := deployment.SetFiles(files, []v1.VolumeMount{})
+	g.Expect(msg).ToNot(BeNil())
+	g.Expect(msg.FileOverviews).To(HaveLen(10)) // 2 files + 8 ignored files
+	g.Expect(msg.FileOverviews[0].GetUnmanaged()).To(BeFalse())
+	g.Expect(msg.FileOverviews[1]).To(Equal(&pb.File{FileMeta: stateFile.Meta, Unmanaged: true}))
+
+	// the removed state file is sent once as an empty managed file, because the agent doesn't delete
+	// unmanaged files
+	msg = deployment.SetFiles(files[:1], []v1.VolumeMount{})
+	g.Expect(msg).ToNot(BeNil())
+	g.Expect(msg.FileOverviews).To(HaveLen(10))
+	g.Expect(msg.FileOverviews[1].GetUnmanaged()).To(BeFalse())
+	g.Expect(msg.FileOverviews[1].GetFileMeta().GetName()).To(Equal(stateFile.Meta.Name))
+	g.Expect(files).To(HaveLen(2))
+
+	contents, _ := deployment.GetFile(stateFile.Meta.Name, filesHelper.GenerateHash(nil))
+	g.Expect(contents).To(BeEmpty())
+	g.Expect(contents).ToNot(BeNil())
+
+	// then the agent deletes it with the next configuration
+	msg = deployment.SetFiles(files[:1], []v1.VolumeMount{})
+	g.Expect(msg).ToNot(BeNil())
+	g.Expect(msg.FileOverviews).To(HaveLen(9))
+	for _, overview := range msg.FileOverviews {
+		g.Expect(overview.GetFileMeta().GetName()).ToNot(Equal(stateFile.Meta.Name))
+	}
+}
+
+func TestRollBack(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := newDeployment(&broadcastfakes.FakeBroadcaster{})
+
+	oldFiles := []File{
+		{
+			Meta: &pb.FileMeta{
+				Name: "test.conf",
+				Hash: "12345",
+			},
+			Contents: []byte("old content"),
+		},
+	}
+	newFiles := []File{
+		{
+			Meta: &pb.FileMeta{
+				Name: "test.conf",
+				Hash: "67890",
+			},
+			Contents: []byte("new content"),
+		},
+	}
+
+	msg, err := deployment.RollBack([]v1.VolumeMount{})
+	g.Expect(err).To(MatchError(ErrNoPreviousConfig))
+	g.Expect(msg).To(BeNil())
+	g.Expect(deployment.IsRolledBack()).To(BeFalse())
+
+	oldMsg := deployment.SetFiles(oldFiles, []v1.VolumeMount{})
+	g.Expect(oldMsg).ToNot(BeNil())
+
+	// setting the same files doesn't replace the previous configuration
+	g.Expect(deployment.SetFiles(newFiles, []v1.VolumeMount{})).ToNot(BeNil())
+	g.Expect(deployment.SetFiles(newFiles, []v1.VolumeMount{})).To(BeNil())
+
+	msg, err = deployment.RollBack([]v1.VolumeMount{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(msg).ToNot(BeNil())
+	g.Expect(msg.ConfigVersion).To(Equal(oldMsg.ConfigVersion))
+	g.Expect(deployment.IsRolledBack()).To(BeTrue())
+	g.Expect(deployment.GetFiles()).To(Equal(oldFiles))
+
+	g.Expect(deployment.ReleaseRollback()).To(BeTrue())
+	g.Expect(deployment.IsRolledBack()).To(BeFalse())
+	g.Expect(deployment.ReleaseRollback()).To(BeFalse())
+}
+
 func TestSetAndGetFiles_VolumeIgnoreFiles(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -187,6 +290,44 @@ func TestSetPodErrorStatus(t *testing.T) {
 	g.Expect(deployment.podStatuses).ToNot(HaveKey("test-pod"))
 }
 
+func TestSetPodCapabilities(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := newDeployment(&broadcastfakes.FakeBroadcaster{})
+	g.Expect(deployment.GetUnsupportedCapabilities()).To(BeEmpty())
+
+	allCapabilities := []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve}
+
+	g.Expect(deployment.SetPodCapabilities("new-pod", allCapabilities)).To(BeFalse())
+	g.Expect(deployment.GetUnsupportedCapabilities()).To(BeEmpty())
+
+	// a Pod with an older version joins during a rolling upgrade
+	g.Expect(deployment.SetPodCapabilities("old-pod", []agentgrpc.Capability{})).To(BeTrue())
+	g.Expect(deployment.GetUnsupportedCapabilities()).To(Equal(allCapabilities))
+
+	g.Expect(deployment.SetPodCapabilities("new-pod2", allCapabilities)).To(BeFalse())
+	g.Expect(deployment.RemovePodCapabilities("new-pod")).To(BeFalse())
+
+	g.Expect(deployment.RemovePodCapabilities("old-pod")).To(BeTrue())
+	g.Expect(deployment.GetUnsupportedCapabilities()).To(BeEmpty())
+	g.Expect(deployment.podCapabilities).ToNot(HaveKey("old-pod"))
+}
+
+func TestSetWithheldCapabilities(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deployment := newDeployment(&broadcastfakes.FakeBroadcaster{})
+	g.Expect(deployment.GetWithheldFeatures()).To(BeNil())
+
+	deployment.SetWithheldCapabilities([]agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve})
+	g.Expect(deployment.GetWithheldFeatures()).To(Equal([]string{"UpstreamResolve"}))
+
+	deployment.SetWithheldCapabilities(nil)
+	g.Expect(deployment.GetWithheldFeatures()).To(BeNil())
+}
+
 func TestSetLatestConfigError(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -226,6 +367,9 @@ func TestDeploymentStore(t *testing.T) {
 	deployment = store.GetOrStore(t.Context(), nsName, nil)
 	g.Expect(fetchedDeployment).To(Equal(deployment))
 
+	g.Expect(store.List()).To(Equal(map[types.NamespacedName]*Deployment{nsName: deployment}))
+
 	store.Remove(nsName)
 	g.Expect(store.Get(nsName)).To(BeNil())
+	g.Expect(store.List()).To(BeEmpty())
 }
@@ -23,6 +23,10 @@ const defaultChunkSize uint32 = 2097152 // 2MB
 type File struct {
 	Meta     *pb.FileMeta
 	Contents []byte
+	// Unmanaged is true for a file that nginx writes itself, like the state file of an NGINX Plus upstream.
+	// The agent doesn't get or overwrite an unmanaged file, but deletes it once it is no longer in the
+	// configuration. An unmanaged file has no Contents.
+	Unmanaged bool
 }
 
 // fileService handles file management between the control plane and the agent.
@@ -148,7 +152,8 @@ func (fs *fileService) getFileContents(req *pb.GetFileRequest, connKey string) (
 
 	filename := req.GetFileMeta().GetName()
 	contents, fileFoundHash := deployment.GetFile(filename, req.GetFileMeta().GetHash())
-	if len(contents) == 0 {
+	// an empty file, like a removed unmanaged file, has empty but non-nil contents
+	if contents == nil {
 		fs.logger.V(1).Info(
 			"Error getting file for agent",
 			"file", filename,
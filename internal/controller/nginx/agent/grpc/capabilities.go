@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	pb "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+)
+
+// Capability is a data plane feature that is only supported by some versions of the nginx agent or NGINX.
+// The control plane negotiates the capabilities with every agent when it connects, so that a data plane
+// running an older version (for example, during a rolling upgrade) doesn't receive configuration it can't apply.
+type Capability string
+
+const (
+	// CapabilityUpstreamResolve is the support of the resolve parameter of the upstream servers,
+	// which is required for the upstream servers with hostnames.
+	CapabilityUpstreamResolve Capability = "UpstreamResolve"
+)
+
+// DataPlaneVersions are the versions that an nginx agent reports about itself and its NGINX instance.
+type DataPlaneVersions struct {
+	// AgentVersion is the version of the nginx agent.
+	AgentVersion string
+	// NginxVersion is the version of NGINX.
+	NginxVersion string
+	// Plus is true if the NGINX instance is NGINX Plus.
+	Plus bool
+}
+
+// capabilityRequirements contains the function that checks if a data plane supports the capability,
+// for every Capability.
+var capabilityRequirements = map[Capability]func(DataPlaneVersions) bool{
+	CapabilityUpstreamResolve: func(v DataPlaneVersions) bool {
+		return v.Plus || versionAtLeast(v.NginxVersion, []int{1, 27, 3})
+	},
+}
+
+// AllCapabilities returns all capabilities that the control plane negotiates, in sorted order.
+func AllCapabilities() []Capability {
+	capabilities := make([]Capability, 0, len(capabilityRequirements))
+	for capability := range capabilityRequirements {
+		capabilities = append(capabilities, capability)
+	}
+
+	slices.Sort(capabilities)
+
+	return capabilities
+}
+
+// NegotiateCapabilities returns the capabilities that the data plane supports, in sorted order.
+// An unknown version is assumed to support all capabilities.
+func NegotiateCapabilities(versions DataPlaneVersions) []Capability {
+	capabilities := make([]Capability, 0, len(capabilityRequirements))
+	for _, capability := range AllCapabilities() {
+		if capabilityRequirements[capability](versions) {
+			capabilities = append(capabilities, capability)
+		}
+	}
+
+	return capabilities
+}
+
+// GetDataPlaneVersions returns the versions of the nginx agent and NGINX from the instances reported by the agent.
+func GetDataPlaneVersions(instances []*pb.Instance) DataPlaneVersions {
+	var versions DataPlaneVersions
+
+	for _, instance := range instances {
+		meta := instance.GetInstanceMeta()
+
+		switch meta.GetInstanceType() {
+		case pb.InstanceMeta_INSTANCE_TYPE_AGENT:
+			versions.AgentVersion = meta.GetVersion()
+		case pb.InstanceMeta_INSTANCE_TYPE_NGINX:
+			versions.NginxVersion = meta.GetVersion()
+		case pb.InstanceMeta_INSTANCE_TYPE_NGINX_PLUS:
+			versions.NginxVersion = meta.GetVersion()
+			versions.Plus = true
+		default:
+		}
+	}
+
+	return versions
+}
+
+// versionAtLeast returns true if the version is equal to or greater than the minimum version.
+// A version that can't be parsed is treated as the latest version.
+func versionAtLeast(version string, minVersion []int) bool {
+	parts, ok := parseVersion(version)
+	if !ok {
+		return true
+	}
+
+	for i, minPart := range minVersion {
+		var part int
+		if i < len(parts) {
+			part = parts[i]
+		}
+
+		if part != minPart {
+			return part > minPart
+		}
+	}
+
+	return true
+}
+
+// parseVersion parses the numeric parts of a version like "1.27.3" or "v3.0.1".
+// Anything after the numeric parts, like a pre-release suffix, is ignored.
+func parseVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+
+	if end := strings.IndexFunc(version, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	}); end != -1 {
+		version = version[:end]
+	}
+
+	if version == "" {
+		return nil, false
+	}
+
+	fields := strings.Split(strings.TrimSuffix(version, "."), ".")
+	parts := make([]int, 0, len(fields))
+
+	for _, field := range fields {
+		part, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, part)
+	}
+
+	return parts, true
+}
@@ -0,0 +1,135 @@
+package grpc_test
+
+import (
+	"testing"
+
+	pb "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	. "github.com/onsi/gomega"
+
+	agentgrpc "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc"
+)
+
+func TestNegotiateCapabilities(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		versions agentgrpc.DataPlaneVersions
+		expected []agentgrpc.Capability
+	}{
+		{
+			name:     "current nginx version",
+			versions: agentgrpc.DataPlaneVersions{AgentVersion: "v3.2.1", NginxVersion: "1.29.0"},
+			expected: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+		},
+		{
+			name:     "minimum nginx version",
+			versions: agentgrpc.DataPlaneVersions{NginxVersion: "1.27.3"},
+			expected: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+		},
+		{
+			name:     "nginx version with a suffix",
+			versions: agentgrpc.DataPlaneVersions{NginxVersion: "1.28.0 (debug)"},
+			expected: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+		},
+		{
+			name:     "old nginx version",
+			versions: agentgrpc.DataPlaneVersions{NginxVersion: "1.27.2"},
+			expected: []agentgrpc.Capability{},
+		},
+		{
+			name:     "old nginx version with fewer parts",
+			versions: agentgrpc.DataPlaneVersions{NginxVersion: "1.26"},
+			expected: []agentgrpc.Capability{},
+		},
+		{
+			name:     "old nginx plus version",
+			versions: agentgrpc.DataPlaneVersions{NginxVersion: "1.25.5", Plus: true},
+			expected: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+		},
+		{
+			name:     "unknown nginx version",
+			versions: agentgrpc.DataPlaneVersions{},
+			expected: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+		},
+		{
+			name:     "invalid nginx version",
+			versions: agentgrpc.DataPlaneVersions{NginxVersion: "invalid"},
+			expected: []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(agentgrpc.NegotiateCapabilities(test.versions)).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestAllCapabilities(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(agentgrpc.AllCapabilities()).To(Equal([]agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve}))
+}
+
+func TestGetDataPlaneVersions(t *testing.T) {
+	t.Parallel()
+
+	agentInstance := &pb.Instance{
+		InstanceMeta: &pb.InstanceMeta{
+			InstanceType: pb.InstanceMeta_INSTANCE_TYPE_AGENT,
+			Version:      "v3.2.1",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		instances []*pb.Instance
+		expected  agentgrpc.DataPlaneVersions
+	}{
+		{
+			name: "nginx",
+			instances: []*pb.Instance{
+				agentInstance,
+				{
+					InstanceMeta: &pb.InstanceMeta{
+						InstanceType: pb.InstanceMeta_INSTANCE_TYPE_NGINX,
+						Version:      "1.29.0",
+					},
+				},
+			},
+			expected: agentgrpc.DataPlaneVersions{AgentVersion: "v3.2.1", NginxVersion: "1.29.0"},
+		},
+		{
+			name: "nginx plus",
+			instances: []*pb.Instance{
+				agentInstance,
+				{
+					InstanceMeta: &pb.InstanceMeta{
+						InstanceType: pb.InstanceMeta_INSTANCE_TYPE_NGINX_PLUS,
+						Version:      "1.27.4",
+					},
+				},
+			},
+			expected: agentgrpc.DataPlaneVersions{AgentVersion: "v3.2.1", NginxVersion: "1.27.4", Plus: true},
+		},
+		{
+			name:      "nginx is not discovered yet",
+			instances: []*pb.Instance{agentInstance},
+			expected:  agentgrpc.DataPlaneVersions{AgentVersion: "v3.2.1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(agentgrpc.GetDataPlaneVersions(test.instances)).To(Equal(test.expected))
+		})
+	}
+}
@@ -16,6 +16,7 @@ type ConnectionsTracker interface {
 	Track(key string, conn Connection)
 	GetConnection(key string) Connection
 	SetInstanceID(key, id string)
+	SetCapabilities(key string, capabilities []Capability)
 	RemoveConnection(key string)
 }
 
@@ -24,6 +25,10 @@ type Connection struct {
 	InstanceID string
 	ParentType string
 	ParentName types.NamespacedName
+	// PodName is the name of the nginx Pod of the agent.
+	PodName string
+	// Capabilities are the capabilities negotiated with the agent.
+	Capabilities []Capability
 }
 
 // Ready returns if the connection is ready to be used. In other words, agent
@@ -74,6 +79,17 @@ func (c *AgentConnectionsTracker) SetInstanceID(key, id string) {
 	}
 }
 
+// SetCapabilities sets the negotiated capabilities for a connection.
+func (c *AgentConnectionsTracker) SetCapabilities(key string, capabilities []Capability) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if conn, ok := c.connections[key]; ok {
+		conn.Capabilities = capabilities
+		c.connections[key] = conn
+	}
+}
+
 // RemoveConnection removes a connection from the tracking map.
 func (c *AgentConnectionsTracker) RemoveConnection(key string) {
 	c.lock.Lock()
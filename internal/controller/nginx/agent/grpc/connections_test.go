@@ -71,6 +71,24 @@ func TestSetInstanceID(t *testing.T) {
 	g.Expect(trackedConn.InstanceID).To(Equal("instance1"))
 }
 
+func TestSetCapabilities(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tracker := agentgrpc.NewConnectionsTracker()
+	tracker.Track("key1", agentgrpc.Connection{
+		ParentName: types.NamespacedName{Namespace: "default", Name: "parent1"},
+	})
+
+	capabilities := []agentgrpc.Capability{agentgrpc.CapabilityUpstreamResolve}
+	tracker.SetCapabilities("key1", capabilities)
+	g.Expect(tracker.GetConnection("key1").Capabilities).To(Equal(capabilities))
+
+	// unknown connections are ignored
+	tracker.SetCapabilities("key2", capabilities)
+	g.Expect(tracker.GetConnection("key2")).To(Equal(agentgrpc.Connection{}))
+}
+
 func TestRemoveConnection(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
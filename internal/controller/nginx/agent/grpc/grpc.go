@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/health"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc/filewatcher"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/grpc/interceptor"
 )
@@ -31,6 +32,9 @@ const (
 	tlsKeyPath       = "/var/run/secrets/ngf/tls.key"
 )
 
+// fipsCurvePreferences are the FIPS-approved elliptic curves used for key exchange in FIPS mode.
+var fipsCurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+
 var ErrStatusInvalidConnection = status.Error(codes.Unauthenticated, "invalid connection")
 
 // Interceptor provides hooks to intercept the execution of an RPC on the server.
@@ -43,6 +47,8 @@ type Interceptor interface {
 type Server struct {
 	// Interceptor provides hooks to intercept the execution of an RPC on the server.
 	interceptor Interceptor
+	// health tracks the connectivity of the nginx agents, which is healthy while the server is serving.
+	health *health.Tracker
 
 	logger logr.Logger
 
@@ -54,6 +60,8 @@ type Server struct {
 	// Port is the port that the server is listening on.
 	// Must be exposed in the control plane deployment/service.
 	port int
+	// fips restricts the TLS parameters of the server to FIPS-approved values.
+	fips bool
 }
 
 func NewServer(
@@ -63,25 +71,40 @@ func NewServer(
 	k8sClient client.Client,
 	tokenAudience string,
 	resetConnChan chan<- struct{},
+	fips bool,
+	healthTracker *health.Tracker,
 ) *Server {
 	return &Server{
+		health:           healthTracker,
 		logger:           logger,
 		port:             port,
 		registerServices: registerSvcs,
 		interceptor:      interceptor.NewContextSetter(k8sClient, tokenAudience),
 		resetConnChan:    resetConnChan,
+		fips:             fips,
 	}
 }
 
 // Start is a runnable that starts the gRPC server for communicating with the nginx agent.
 func (g *Server) Start(ctx context.Context) error {
+	if err := g.serve(ctx); err != nil {
+		g.health.SetUnhealthy(health.AgentConnectivity, fmt.Errorf("gRPC server failed: %w", err))
+		return err
+	}
+
+	g.health.SetUnhealthy(health.AgentConnectivity, errors.New("gRPC server stopped"))
+
+	return nil
+}
+
+func (g *Server) serve(ctx context.Context) error {
 	var lc net.ListenConfig
 	listener, err := lc.Listen(ctx, "tcp", fmt.Sprintf(":%d", g.port))
 	if err != nil {
 		return err
 	}
 
-	tlsCredentials, err := getTLSConfig()
+	tlsCredentials, err := getTLSConfig(g.fips)
 	if err != nil {
 		return err
 	}
@@ -123,10 +146,12 @@ func (g *Server) Start(ctx context.Context) error {
 		server.Stop()
 	}()
 
+	g.health.SetHealthy(health.AgentConnectivity)
+
 	return server.Serve(listener)
 }
 
-func getTLSConfig() (credentials.TransportCredentials, error) {
+func getTLSConfig(fips bool) (credentials.TransportCredentials, error) {
 	caPem, err := os.ReadFile(caCertPath)
 	if err != nil {
 		return nil, err
@@ -149,6 +174,11 @@ func getTLSConfig() (credentials.TransportCredentials, error) {
 		MinVersion:     tls.VersionTLS13,
 	}
 
+	if fips {
+		// X25519 is not a FIPS-approved curve, so only the NIST curves are allowed for key exchange.
+		tlsConfig.CurvePreferences = fipsCurvePreferences
+	}
+
 	return credentials.NewTLS(tlsConfig), nil
 }
 
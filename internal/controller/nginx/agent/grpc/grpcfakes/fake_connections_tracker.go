@@ -24,6 +24,12 @@ type FakeConnectionsTracker struct {
 	removeConnectionArgsForCall []struct {
 		arg1 string
 	}
+	SetCapabilitiesStub        func(string, []grpc.Capability)
+	setCapabilitiesMutex       sync.RWMutex
+	setCapabilitiesArgsForCall []struct {
+		arg1 string
+		arg2 []grpc.Capability
+	}
 	SetInstanceIDStub        func(string, string)
 	setInstanceIDMutex       sync.RWMutex
 	setInstanceIDArgsForCall []struct {
@@ -133,6 +139,44 @@ func (fake *FakeConnectionsTracker) RemoveConnectionArgsForCall(i int) string {
 	return argsForCall.arg1
 }
 
+func (fake *FakeConnectionsTracker) SetCapabilities(arg1 string, arg2 []grpc.Capability) {
+	var arg2Copy []grpc.Capability
+	if arg2 != nil {
+		arg2Copy = make([]grpc.Capability, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.setCapabilitiesMutex.Lock()
+	fake.setCapabilitiesArgsForCall = append(fake.setCapabilitiesArgsForCall, struct {
+		arg1 string
+		arg2 []grpc.Capability
+	}{arg1, arg2Copy})
+	stub := fake.SetCapabilitiesStub
+	fake.recordInvocation("SetCapabilities", []interface{}{arg1, arg2Copy})
+	fake.setCapabilitiesMutex.Unlock()
+	if stub != nil {
+		fake.SetCapabilitiesStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeConnectionsTracker) SetCapabilitiesCallCount() int {
+	fake.setCapabilitiesMutex.RLock()
+	defer fake.setCapabilitiesMutex.RUnlock()
+	return len(fake.setCapabilitiesArgsForCall)
+}
+
+func (fake *FakeConnectionsTracker) SetCapabilitiesCalls(stub func(string, []grpc.Capability)) {
+	fake.setCapabilitiesMutex.Lock()
+	defer fake.setCapabilitiesMutex.Unlock()
+	fake.SetCapabilitiesStub = stub
+}
+
+func (fake *FakeConnectionsTracker) SetCapabilitiesArgsForCall(i int) (string, []grpc.Capability) {
+	fake.setCapabilitiesMutex.RLock()
+	defer fake.setCapabilitiesMutex.RUnlock()
+	argsForCall := fake.setCapabilitiesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeConnectionsTracker) SetInstanceID(arg1 string, arg2 string) {
 	fake.setInstanceIDMutex.Lock()
 	fake.setInstanceIDArgsForCall = append(fake.setInstanceIDArgsForCall, struct {
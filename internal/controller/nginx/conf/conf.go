@@ -0,0 +1,27 @@
+/*
+Package conf contains the static NGINX configuration files that are part of the NGINX images
+and are not generated by the control plane.
+*/
+package conf
+
+import (
+	_ "embed"
+)
+
+var (
+	// NginxConf is the main NGINX configuration file of the NGINX OSS image.
+	//go:embed nginx.conf
+	NginxConf []byte
+
+	// NginxPlusConf is the main NGINX configuration file of the NGINX Plus image.
+	//go:embed nginx-plus.conf
+	NginxPlusConf []byte
+
+	// GRPCErrorLocations is the file with the locations that return gRPC errors.
+	//go:embed grpc-error-locations.conf
+	GRPCErrorLocations []byte
+
+	// GRPCErrorPages is the file with the error_page directives for gRPC errors.
+	//go:embed grpc-error-pages.conf
+	GRPCErrorPages []byte
+)
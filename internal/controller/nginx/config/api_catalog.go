@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+)
+
+func executeAPICatalog(conf dataplane.Configuration) []executeResult {
+	if conf.APICatalog == nil {
+		return nil
+	}
+
+	return []executeResult{
+		{
+			dest: apiCatalogFile,
+			data: conf.APICatalog.Content,
+		},
+	}
+}
+
+// getAPICatalogPath returns the path at which the server serves the API catalog. The catalog is not served
+// by the default servers, or by the servers with a Route for the same exact path, which takes precedence.
+func getAPICatalogPath(catalog *dataplane.APICatalog, server http.Server) string {
+	if catalog == nil || server.IsDefaultHTTP || server.IsDefaultSSL {
+		return ""
+	}
+
+	path := exactPath(catalog.Path)
+	for _, loc := range server.Locations {
+		if loc.Path == path {
+			return ""
+		}
+	}
+
+	return catalog.Path
+}
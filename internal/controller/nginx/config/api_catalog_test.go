@@ -0,0 +1,71 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/policiesfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+)
+
+func TestExecuteAPICatalog(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(executeAPICatalog(dataplane.Configuration{})).To(BeEmpty())
+
+	res := executeAPICatalog(dataplane.Configuration{
+		APICatalog: &dataplane.APICatalog{Path: "/apis", Content: []byte(`{"apis":[]}`)},
+	})
+	g.Expect(res).To(Equal([]executeResult{
+		{dest: apiCatalogFile, data: []byte(`{"apis":[]}`)},
+	}))
+}
+
+func TestExecuteServers_APICatalog(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	pathRule := func(path string) dataplane.PathRule {
+		return dataplane.PathRule{
+			Path:     path,
+			PathType: dataplane.PathTypeExact,
+			MatchRules: []dataplane.MatchRule{
+				{
+					BackendGroup: dataplane.BackendGroup{
+						Source: types.NamespacedName{Namespace: "test", Name: "route"},
+						Backends: []dataplane.Backend{
+							{UpstreamName: "test_foo_80", Valid: true, Weight: 1},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{IsDefault: true, Port: 8080},
+			{Hostname: "foo.example.com", Port: 8080, PathRules: []dataplane.PathRule{pathRule("/")}},
+			{Hostname: "bar.example.com", Port: 8080, PathRules: []dataplane.PathRule{pathRule("/apis")}},
+		},
+	}
+
+	const catalogLocation = "location = /apis {\n" +
+		"        default_type application/json;\n" +
+		"        alias /etc/nginx/conf.d/api-catalog.json;\n" +
+		"    }"
+
+	gen := GeneratorImpl{}
+	results := gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	g.Expect(string(results[0].data)).ToNot(ContainSubstring("api-catalog.json"))
+
+	conf.APICatalog = &dataplane.APICatalog{Path: "/apis", Content: []byte(`{"apis":[]}`)}
+	results = gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+
+	// the catalog is served by foo.example.com only: bar.example.com has a route for the same path
+	g.Expect(strings.Count(string(results[0].data), catalogLocation)).To(Equal(1))
+}
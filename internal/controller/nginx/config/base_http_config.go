@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"net"
+	"strings"
 	gotemplate "text/template"
 
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/shared"
@@ -12,16 +13,39 @@ import (
 
 var baseHTTPTemplate = gotemplate.Must(gotemplate.New("baseHttp").Parse(baseHTTPTemplateText))
 
+// clientAbortLogFormatName is the name of the log format of the requests that the client closed before
+// NGINX responded (status 499), when they are logged separately.
+const clientAbortLogFormatName = "ngf_client_abort_log_format"
+
 type AccessLog struct {
-	Format     string // User's format string
-	Path       string // Where to write logs (/dev/stdout)
-	FormatName string // Internal format name (ngf_user_defined_log_format)
-	Disable    bool   // User's disable flag
+	Format                string // User's format string
+	Path                  string // Where to write logs (/dev/stdout)
+	FormatName            string // Internal format name (ngf_user_defined_log_format)
+	ClientAbortFormatName string // Internal format name of the client aborts (ngf_client_abort_log_format)
+	Disable               bool   // User's disable flag
+	SeparateClientAborts  bool   // User's flag to log the client aborts separately
+}
+
+// tenantAttribution is the configuration of the attribution of the requests to tenants.
+type tenantAttribution struct {
+	// HeaderVariable is the NGINX variable of the request header that identifies the tenant, if any.
+	HeaderVariable string
+	// Server is the address of the syslog server of the control plane, if any.
+	Server string
+	// Tenants are the tenants that the header can identify.
+	Tenants []string
 }
+
 type httpConfig struct {
 	DNSResolver             *dataplane.DNSResolverConfig
 	AccessLog               *AccessLog
+	LoadBalancerHealthCheck *dataplane.LoadBalancerHealthCheck
+	TenantAttribution       *tenantAttribution
+	TempFiles               *dataplane.TempFiles
 	GatewaySecretID         dataplane.SSLKeyPairID
+	ErrorLevel              string
+	DrainFile               string
+	GatewayTestToken        string
 	Includes                []shared.Include
 	NginxReadinessProbePort int32
 	IPFamily                shared.IPFamily
@@ -39,6 +63,12 @@ func executeBaseHTTPConfig(conf dataplane.Configuration) []executeResult {
 		DNSResolver:             buildDNSResolver(conf.BaseHTTPConfig.DNSResolver),
 		AccessLog:               buildAccessLog(conf.Logging.AccessLog),
 		GatewaySecretID:         conf.BaseHTTPConfig.GatewaySecretID,
+		LoadBalancerHealthCheck: conf.BaseHTTPConfig.LoadBalancerHealthCheck,
+		DrainFile:               conf.BaseHTTPConfig.DrainFile,
+		GatewayTestToken:        conf.BaseHTTPConfig.GatewayTestToken,
+		TenantAttribution:       buildTenantAttribution(conf.BaseHTTPConfig.TenantAttribution),
+		TempFiles:               conf.BaseHTTPConfig.TempFiles,
+		ErrorLevel:              conf.Logging.ErrorLevel,
 	}
 
 	results := make([]executeResult, 0, len(includes)+1)
@@ -89,8 +119,31 @@ func buildAccessLog(accessLogConfig *dataplane.AccessLog) *AccessLog {
 			accessLog.Format = accessLogConfig.Format
 		}
 		accessLog.Disable = accessLogConfig.Disable
+		if accessLogConfig.SeparateClientAborts {
+			accessLog.SeparateClientAborts = true
+			accessLog.ClientAbortFormatName = clientAbortLogFormatName
+		}
 
 		return accessLog
 	}
 	return nil
 }
+
+func buildTenantAttribution(attribution *dataplane.TenantAttribution) *tenantAttribution {
+	if attribution == nil {
+		return nil
+	}
+
+	ta := &tenantAttribution{
+		Server:  attribution.Server,
+		Tenants: attribution.Tenants,
+	}
+
+	if attribution.Header != "" {
+		// NGINX exposes a request header in the $http_ variable with its name in lowercase
+		// and the dashes replaced by underscores.
+		ta.HeaderVariable = "$http_" + strings.ReplaceAll(strings.ToLower(attribution.Header), "-", "_")
+	}
+
+	return ta
+}
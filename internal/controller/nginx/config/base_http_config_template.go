@@ -44,10 +44,103 @@ server {
 
     location = /readyz {
         access_log off;
+        {{- if $.DrainFile }}
+        if (-f {{ $.DrainFile }}) {
+            return 503;
+        }
+        {{- end }}
         return 200;
     }
 }
 
+{{- if .LoadBalancerHealthCheck }}
+
+# Health check server block of the cloud load balancer.
+server {
+		{{- if $.IPFamily.IPv4 }}
+    listen {{ .LoadBalancerHealthCheck.Port }};
+		{{- end }}
+		{{- if $.IPFamily.IPv6 }}
+    listen [::]:{{ .LoadBalancerHealthCheck.Port }};
+		{{- end }}
+
+    location = {{ .LoadBalancerHealthCheck.Path }} {
+        access_log off;
+        {{- if $.DrainFile }}
+        if (-f {{ $.DrainFile }}) {
+            return 503;
+        }
+        {{- end }}
+        return 200;
+    }
+}
+{{- end }}
+
+{{- if .TenantAttribution }}
+
+# Attribute every request to a tenant. The locations of the routes set $ngf_route_namespace to the namespace of
+# their route, and $ngf_route to the namespace and name of their route.
+map $host $ngf_route_namespace {
+    default "";
+}
+
+map $host $ngf_route {
+    default "";
+}
+{{- if .TenantAttribution.HeaderVariable }}
+
+# The clients set the header, so only the listed tenants are trusted.
+map {{ .TenantAttribution.HeaderVariable }} $ngf_tenant {
+    default $ngf_route_namespace;
+    {{- range $tenant := .TenantAttribution.Tenants }}
+    "{{ $tenant }}" "{{ $tenant }}";
+    {{- end }}
+}
+{{- else }}
+
+map $ngf_route_namespace $ngf_tenant {
+    default $ngf_route_namespace;
+}
+{{- end }}
+{{- if .TenantAttribution.Server }}
+
+# Report every request to the control plane, which counts the requests and bytes of every tenant.
+log_format ngf_tenant_attribution escape=json '{"tenant":"$ngf_tenant","route":"$ngf_route","status":$status,"request_length":$request_length,"bytes_sent":$bytes_sent}';
+access_log syslog:server={{ .TenantAttribution.Server }},tag=ngf_tenant,nohostname ngf_tenant_attribution;
+{{- end }}
+{{- end }}
+
+{{- if .GatewayTestToken }}
+
+# Report the upstream of the requests of the GatewayTests, which carry the token of the Gateway, so that
+# the control plane can verify the backend that served a request. The header is added only to the responses
+# to such requests, so that the other responses keep inheriting the add_header directives of the server.
+map $http_x_ngf_gateway_test $ngf_gateway_test {
+    "{{ .GatewayTestToken }}" 1;
+    default "";
+}
+{{- end }}
+
+{{- if .TempFiles }}
+{{- if .TempFiles.MaxResponseFileSize }}
+
+proxy_max_temp_file_size {{ .TempFiles.MaxResponseFileSize }};
+{{- end }}
+
+# Set $ngf_request_body_in_file to 1 if the body of the request was written to a temporary file, otherwise, set it to 0.
+map $request_body_file $ngf_request_body_in_file {
+    '' 0;
+    default 1;
+}
+{{- if .TempFiles.Server }}
+
+# Report the writes to the temporary files, which NGINX logs as warnings, to the control plane, which counts them.
+# An error log of the http context replaces the error log of the main context, so the latter is repeated.
+error_log stderr {{ .ErrorLevel }};
+error_log syslog:server={{ .TempFiles.Server }},tag={{ .TempFiles.SyslogTag }},nohostname warn;
+{{- end }}
+{{- end }}
+
 {{- /* Define custom log format */ -}}
 {{- /* We use a fixed name for user-defined log format to avoid complexity of passing the name around. */ -}}
 {{- if .AccessLog }}
@@ -56,6 +149,25 @@ access_log off;
 {{- else }}
 {{- if .AccessLog.Format }}
 log_format {{ .AccessLog.FormatName }} '{{ .AccessLog.Format }}';
+{{- end }}
+{{- if .AccessLog.SeparateClientAborts }}
+
+# Log the requests that the client closed before NGINX responded (status 499) separately from the other requests,
+# including the time the request spent in NGINX and the upstream timings, to help diagnose client-side timeouts.
+map $status $ngf_client_abort {
+    499 1;
+    default 0;
+}
+
+map $status $ngf_not_client_abort {
+    499 0;
+    default 1;
+}
+
+log_format {{ .AccessLog.ClientAbortFormatName }} 'client_abort $remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent "$http_referer" "$http_user_agent" server_name=$server_name request_time=$request_time upstream_addr=$upstream_addr upstream_queue_time=$upstream_queue_time upstream_connect_time=$upstream_connect_time upstream_header_time=$upstream_header_time upstream_response_time=$upstream_response_time';
+access_log {{ .AccessLog.Path }} {{ if .AccessLog.Format }}{{ .AccessLog.FormatName }}{{ else }}combined{{ end }} if=$ngf_not_client_abort;
+access_log {{ .AccessLog.Path }} {{ .AccessLog.ClientAbortFormatName }} if=$ngf_client_abort;
+{{- else if .AccessLog.Format }}
 access_log {{ .AccessLog.Path }} {{ .AccessLog.FormatName }};
 {{- end }}
 {{- end }}
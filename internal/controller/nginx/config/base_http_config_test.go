@@ -48,6 +48,46 @@ func TestLoggingSettingsTemplate(t *testing.T) {
 				fmt.Sprintf("access_log off %s", dataplane.DefaultLogFormatName),
 			},
 		},
+		{
+			name:      "Client aborts logged separately with custom format",
+			accessLog: &dataplane.AccessLog{Format: logFormat, SeparateClientAborts: true},
+			expectedOutputs: []string{
+				fmt.Sprintf("log_format %s '%s'", dataplane.DefaultLogFormatName, logFormat),
+				"map $status $ngf_client_abort {",
+				"log_format ngf_client_abort_log_format 'client_abort $remote_addr",
+				"upstream_queue_time=$upstream_queue_time",
+				fmt.Sprintf(
+					"access_log %s %s if=$ngf_not_client_abort;",
+					dataplane.DefaultAccessLogPath,
+					dataplane.DefaultLogFormatName,
+				),
+				fmt.Sprintf("access_log %s ngf_client_abort_log_format if=$ngf_client_abort;", dataplane.DefaultAccessLogPath),
+			},
+			unexpectedOutputs: []string{
+				fmt.Sprintf("access_log %s %s;", dataplane.DefaultAccessLogPath, dataplane.DefaultLogFormatName),
+			},
+		},
+		{
+			name:      "Client aborts logged separately with default format",
+			accessLog: &dataplane.AccessLog{SeparateClientAborts: true},
+			expectedOutputs: []string{
+				fmt.Sprintf("access_log %s combined if=$ngf_not_client_abort;", dataplane.DefaultAccessLogPath),
+				fmt.Sprintf("access_log %s ngf_client_abort_log_format if=$ngf_client_abort;", dataplane.DefaultAccessLogPath),
+			},
+			unexpectedOutputs: []string{
+				fmt.Sprintf("log_format %s", dataplane.DefaultLogFormatName),
+			},
+		},
+		{
+			name:      "Access log off while client aborts logged separately",
+			accessLog: &dataplane.AccessLog{Disable: true, SeparateClientAborts: true},
+			expectedOutputs: []string{
+				`access_log off;`,
+			},
+			unexpectedOutputs: []string{
+				"ngf_client_abort",
+			},
+		},
 		{
 			name:      "Access log off",
 			accessLog: &dataplane.AccessLog{Disable: true},
@@ -283,6 +323,260 @@ func TestExecuteBaseHttp_NginxReadinessProbePort(t *testing.T) {
 	}
 }
 
+func TestExecuteBaseHttp_LoadBalancerHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		conf             dataplane.Configuration
+		expSubStrings    []string
+		notExpSubStrings []string
+	}{
+		{
+			name: "health check is disabled",
+			conf: dataplane.Configuration{
+				BaseHTTPConfig: dataplane.BaseHTTPConfig{
+					NginxReadinessProbePort: dataplane.DefaultNginxReadinessProbePort,
+				},
+			},
+			notExpSubStrings: []string{"# Health check server block of the cloud load balancer."},
+		},
+		{
+			name: "health check is enabled",
+			conf: dataplane.Configuration{
+				BaseHTTPConfig: dataplane.BaseHTTPConfig{
+					NginxReadinessProbePort: dataplane.DefaultNginxReadinessProbePort,
+					IPFamily:                dataplane.Dual,
+					LoadBalancerHealthCheck: &dataplane.LoadBalancerHealthCheck{
+						Path: "/healthz",
+						Port: 8082,
+					},
+				},
+			},
+			expSubStrings: []string{
+				"# Health check server block of the cloud load balancer.",
+				"listen 8082;",
+				"listen [::]:8082;",
+				"location = /healthz {",
+			},
+		},
+		{
+			name: "health check is enabled on ipv4",
+			conf: dataplane.Configuration{
+				BaseHTTPConfig: dataplane.BaseHTTPConfig{
+					NginxReadinessProbePort: dataplane.DefaultNginxReadinessProbePort,
+					IPFamily:                dataplane.IPv4,
+					LoadBalancerHealthCheck: &dataplane.LoadBalancerHealthCheck{
+						Path: "/lb-health",
+						Port: 9000,
+					},
+				},
+			},
+			expSubStrings: []string{
+				"listen 9000;",
+				"location = /lb-health {",
+			},
+			notExpSubStrings: []string{"listen [::]:9000;"},
+		},
+		{
+			name: "health check with the drain file",
+			conf: dataplane.Configuration{
+				BaseHTTPConfig: dataplane.BaseHTTPConfig{
+					NginxReadinessProbePort: dataplane.DefaultNginxReadinessProbePort,
+					IPFamily:                dataplane.IPv4,
+					LoadBalancerHealthCheck: &dataplane.LoadBalancerHealthCheck{
+						Path: "/healthz",
+						Port: 8082,
+					},
+					DrainFile: "/var/run/nginx/drain",
+				},
+			},
+			expSubStrings: []string{
+				"location = /healthz {\n        access_log off;\n        if (-f /var/run/nginx/drain) {\n" +
+					"            return 503;\n        }\n        return 200;",
+				"location = /readyz {\n        access_log off;\n        if (-f /var/run/nginx/drain) {\n" +
+					"            return 503;\n        }\n        return 200;",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			res := executeBaseHTTPConfig(test.conf)
+			g.Expect(res).To(HaveLen(1))
+
+			httpConfig := string(res[0].data)
+			for _, expSubStr := range test.expSubStrings {
+				g.Expect(httpConfig).To(ContainSubstring(expSubStr))
+			}
+			for _, notExpSubStr := range test.notExpSubStrings {
+				g.Expect(httpConfig).ToNot(ContainSubstring(notExpSubStr))
+			}
+		})
+	}
+}
+
+func TestExecuteBaseHttp_TenantAttribution(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		conf             dataplane.Configuration
+		expSubStrings    []string
+		notExpSubStrings []string
+	}{
+		{
+			name:             "tenant attribution is disabled",
+			conf:             dataplane.Configuration{},
+			notExpSubStrings: []string{"$ngf_route_namespace", "$ngf_tenant"},
+		},
+		{
+			name: "tenant is the namespace of the route",
+			conf: dataplane.Configuration{
+				BaseHTTPConfig: dataplane.BaseHTTPConfig{
+					TenantAttribution: &dataplane.TenantAttribution{},
+				},
+			},
+			expSubStrings: []string{
+				"map $host $ngf_route_namespace {",
+				"map $ngf_route_namespace $ngf_tenant {\n    default $ngf_route_namespace;\n}",
+			},
+			notExpSubStrings: []string{"log_format ngf_tenant_attribution", "syslog:"},
+		},
+		{
+			name: "tenant is identified by a header and the requests are reported",
+			conf: dataplane.Configuration{
+				BaseHTTPConfig: dataplane.BaseHTTPConfig{
+					TenantAttribution: &dataplane.TenantAttribution{
+						Header:  "X-Tenant-ID",
+						Server:  "ngf-nginx-gateway.nginx-gateway.svc:5140",
+						Tenants: []string{"team-a", "team-b"},
+					},
+				},
+			},
+			expSubStrings: []string{
+				"map $host $ngf_route {",
+				"map $http_x_tenant_id $ngf_tenant {\n    default $ngf_route_namespace;\n" +
+					`    "team-a" "team-a";` + "\n" + `    "team-b" "team-b";` + "\n}",
+				`log_format ngf_tenant_attribution escape=json '{"tenant":"$ngf_tenant",`,
+				`"route":"$ngf_route","status":$status,`,
+				"access_log syslog:server=ngf-nginx-gateway.nginx-gateway.svc:5140,tag=ngf_tenant,nohostname " +
+					"ngf_tenant_attribution;",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			res := executeBaseHTTPConfig(test.conf)
+			g.Expect(res).To(HaveLen(1))
+
+			httpConfig := string(res[0].data)
+			for _, expSubStr := range test.expSubStrings {
+				g.Expect(httpConfig).To(ContainSubstring(expSubStr))
+			}
+			for _, notExpSubStr := range test.notExpSubStrings {
+				g.Expect(httpConfig).ToNot(ContainSubstring(notExpSubStr))
+			}
+		})
+	}
+}
+
+func TestExecuteBaseHttp_TempFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		conf             dataplane.Configuration
+		expSubStrings    []string
+		notExpSubStrings []string
+	}{
+		{
+			name:             "temporary files are not configured",
+			conf:             dataplane.Configuration{},
+			notExpSubStrings: []string{"proxy_max_temp_file_size", "$ngf_request_body_in_file"},
+		},
+		{
+			name: "maximum size of the temporary files of the responses",
+			conf: dataplane.Configuration{
+				BaseHTTPConfig: dataplane.BaseHTTPConfig{
+					TempFiles: &dataplane.TempFiles{MaxResponseFileSize: "256m"},
+				},
+			},
+			expSubStrings: []string{
+				"proxy_max_temp_file_size 256m;",
+				"map $request_body_file $ngf_request_body_in_file {\n    '' 0;\n    default 1;\n}",
+			},
+			notExpSubStrings: []string{"error_log"},
+		},
+		{
+			name: "writes are reported",
+			conf: dataplane.Configuration{
+				BaseHTTPConfig: dataplane.BaseHTTPConfig{
+					TempFiles: &dataplane.TempFiles{
+						Server:       "ngf-nginx-gateway.nginx-gateway.svc:5141",
+						SyslogTag:    "ngf_temp_6c62272e07bb0142",
+						ReportWrites: true,
+					},
+				},
+				Logging: dataplane.Logging{ErrorLevel: "info"},
+			},
+			expSubStrings: []string{
+				"map $request_body_file $ngf_request_body_in_file {",
+				"error_log stderr info;\n" +
+					"error_log syslog:server=ngf-nginx-gateway.nginx-gateway.svc:5141,tag=ngf_temp_6c62272e07bb0142," +
+					"nohostname warn;",
+			},
+			notExpSubStrings: []string{"proxy_max_temp_file_size"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			res := executeBaseHTTPConfig(test.conf)
+			g.Expect(res).To(HaveLen(1))
+
+			httpConfig := string(res[0].data)
+			for _, expSubStr := range test.expSubStrings {
+				g.Expect(httpConfig).To(ContainSubstring(expSubStr))
+			}
+			for _, notExpSubStr := range test.notExpSubStrings {
+				g.Expect(httpConfig).ToNot(ContainSubstring(notExpSubStr))
+			}
+		})
+	}
+}
+
+func TestExecuteBaseHttp_GatewayTestToken(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	res := executeBaseHTTPConfig(dataplane.Configuration{})
+	g.Expect(res).To(HaveLen(1))
+	g.Expect(string(res[0].data)).ToNot(ContainSubstring("$ngf_gateway_test"))
+
+	conf := dataplane.Configuration{
+		BaseHTTPConfig: dataplane.BaseHTTPConfig{GatewayTestToken: "0123456789abcdef"},
+	}
+
+	res = executeBaseHTTPConfig(conf)
+	g.Expect(res).To(HaveLen(1))
+	g.Expect(string(res[0].data)).To(ContainSubstring(
+		"map $http_x_ngf_gateway_test $ngf_gateway_test {\n" +
+			"    \"0123456789abcdef\" 1;\n" +
+			"    default \"\";\n}",
+	))
+}
+
 func TestExecuteBaseHttp_DNSResolver(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,46 @@
+package config
+
+import (
+	gotemplate "text/template"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+var fipsTemplate = gotemplate.Must(gotemplate.New("fips").Parse(fipsTemplateText))
+
+const (
+	// fipsSSLProtocols are the TLS protocol versions approved for use in FIPS mode.
+	fipsSSLProtocols = "TLSv1.2 TLSv1.3"
+	// fipsSSLCiphers are the TLSv1.2 cipher suites approved for use in FIPS mode.
+	// TLSv1.3 cipher suites are restricted by the FIPS provider of the crypto library.
+	fipsSSLCiphers = "ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:" +
+		"ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256"
+	// fipsSSLECDHCurves are the elliptic curves approved for key exchange in FIPS mode.
+	fipsSSLECDHCurves = "prime256v1:secp384r1"
+)
+
+type fipsConfig struct {
+	Protocols string
+	Ciphers   string
+	Curves    string
+}
+
+func (g GeneratorImpl) executeFIPSConfig(_ dataplane.Configuration) []executeResult {
+	if !g.fips {
+		return nil
+	}
+
+	fc := fipsConfig{
+		Protocols: fipsSSLProtocols,
+		Ciphers:   fipsSSLCiphers,
+		Curves:    fipsSSLECDHCurves,
+	}
+
+	return []executeResult{
+		{
+			dest: fipsConfigFile,
+			data: helpers.MustExecuteTemplate(fipsTemplate, fc),
+		},
+	}
+}
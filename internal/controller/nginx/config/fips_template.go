@@ -0,0 +1,15 @@
+package config
+
+const fipsTemplateText = `
+# FIPS mode: restrict TLS parameters to FIPS 140 approved protocols, ciphers, and curves.
+ssl_protocols {{ .Protocols }};
+ssl_ciphers {{ .Ciphers }};
+ssl_prefer_server_ciphers on;
+ssl_ecdh_curve {{ .Curves }};
+
+proxy_ssl_protocols {{ .Protocols }};
+proxy_ssl_ciphers {{ .Ciphers }};
+
+grpc_ssl_protocols {{ .Protocols }};
+grpc_ssl_ciphers {{ .Ciphers }};
+`
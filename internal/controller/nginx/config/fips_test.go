@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+)
+
+func TestExecuteFIPSConfig(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gen := GeneratorImpl{fips: true}
+	res := gen.executeFIPSConfig(dataplane.Configuration{})
+	g.Expect(res).To(HaveLen(1))
+	g.Expect(res[0].dest).To(Equal(fipsConfigFile))
+
+	expSubStrings := map[string]int{
+		"\nssl_protocols TLSv1.2 TLSv1.3;":          1,
+		"\nssl_ciphers " + fipsSSLCiphers + ";":     1,
+		"ssl_prefer_server_ciphers on;":             1,
+		"ssl_ecdh_curve prime256v1:secp384r1;":      1,
+		"proxy_ssl_protocols TLSv1.2 TLSv1.3;":      1,
+		"proxy_ssl_ciphers " + fipsSSLCiphers + ";": 1,
+		"grpc_ssl_protocols TLSv1.2 TLSv1.3;":       1,
+		"grpc_ssl_ciphers " + fipsSSLCiphers + ";":  1,
+	}
+
+	for expSubStr, expCount := range expSubStrings {
+		g.Expect(strings.Count(string(res[0].data), expSubStr)).To(Equal(expCount), expSubStr)
+	}
+
+	g.Expect(string(res[0].data)).ToNot(ContainSubstring("CBC"))
+	g.Expect(string(res[0].data)).ToNot(ContainSubstring("X25519"))
+}
+
+func TestExecuteFIPSConfig_Disabled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gen := GeneratorImpl{}
+	g.Expect(gen.executeFIPSConfig(dataplane.Configuration{})).To(BeNil())
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/clientsettings"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/observability"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/upstreamsettings"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/shared"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
 )
@@ -67,6 +68,12 @@ const (
 
 	// nginxPlusConfigFile is the path to the file containing the NGINX Plus API config.
 	nginxPlusConfigFile = httpFolder + "/plus-api.conf"
+
+	// fipsConfigFile is the path to the file containing the FIPS TLS parameters.
+	fipsConfigFile = httpFolder + "/fips.conf"
+
+	// apiCatalogFile is the path to the file containing the API catalog of the Gateway.
+	apiCatalogFile = httpFolder + "/api-catalog.json"
 )
 
 // Generator generates NGINX configuration files.
@@ -88,16 +95,19 @@ type GeneratorImpl struct {
 	usageReportConfig *ngfConfig.UsageReportConfig
 	logger            logr.Logger
 	plus              bool
+	fips              bool
 }
 
 // NewGeneratorImpl creates a new GeneratorImpl.
 func NewGeneratorImpl(
 	plus bool,
+	fips bool,
 	usageReportConfig *ngfConfig.UsageReportConfig,
 	logger logr.Logger,
 ) GeneratorImpl {
 	return GeneratorImpl{
 		plus:              plus,
+		fips:              fips,
 		usageReportConfig: usageReportConfig,
 		logger:            logger,
 	}
@@ -166,7 +176,12 @@ func (g GeneratorImpl) executeConfigTemplates(
 	httpUpstreams := g.createUpstreams(conf.Upstreams, upstreamsettings.NewProcessor())
 	keepAliveCheck := newKeepAliveChecker(httpUpstreams)
 
-	for _, execute := range g.getExecuteFuncs(generator, httpUpstreams, keepAliveCheck) {
+	aliases := make(variableAliases)
+	splitClients := compactSplitClients(collectAllSplitClients(conf), aliases)
+	maps := compactMaps(collectAllMaps(conf), aliases)
+
+	executeFuncs := g.getExecuteFuncs(generator, httpUpstreams, splitClients, maps, aliases, keepAliveCheck)
+	for _, execute := range executeFuncs {
 		results := execute(conf)
 		for _, res := range results {
 			fileBytes[res.dest] = append(fileBytes[res.dest], res.data...)
@@ -192,27 +207,36 @@ func (g GeneratorImpl) executeConfigTemplates(
 	}
 	files = append(files, mgmtFiles...)
 
+	if g.plus {
+		files = append(files, generateStateFiles(httpUpstreams, g.createStreamUpstreams(conf.StreamUpstreams))...)
+	}
+
 	return files
 }
 
 func (g GeneratorImpl) getExecuteFuncs(
 	generator policies.Generator,
 	upstreams []http.Upstream,
+	splitClients []http.SplitClient,
+	maps []shared.Map,
+	aliases variableAliases,
 	keepAliveCheck keepAliveChecker,
 ) []executeFunc {
 	return []executeFunc{
 		executeMainConfig,
 		executeEventsConfig,
 		executeBaseHTTPConfig,
-		g.newExecuteServersFunc(generator, keepAliveCheck),
+		g.newExecuteServersFunc(generator, aliases, keepAliveCheck),
 		newExecuteUpstreamsFunc(upstreams),
-		executeSplitClients,
-		executeMaps,
+		newExecuteSplitClientsFunc(splitClients),
+		newExecuteMapsFunc(maps),
 		executeTelemetry,
 		g.executeStreamServers,
 		g.executeStreamUpstreams,
 		executeStreamMaps,
 		executePlusAPI,
+		g.executeFIPSConfig,
+		executeAPICatalog,
 	}
 }
 
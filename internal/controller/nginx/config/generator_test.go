@@ -141,13 +141,14 @@ func TestGenerate(t *testing.T) {
 	plus := true
 	generator := config.NewGeneratorImpl(
 		plus,
+		false,
 		&ngfConfig.UsageReportConfig{Endpoint: "test-endpoint"},
 		logr.Discard(),
 	)
 
 	files := generator.Generate(conf)
 
-	g.Expect(files).To(HaveLen(18))
+	g.Expect(files).To(HaveLen(20))
 	arrange := func(i, j int) bool {
 		return files[i].Meta.Name < files[j].Meta.Name
 	}
@@ -173,6 +174,8 @@ func TestGenerate(t *testing.T) {
 		/etc/nginx/secrets/test-certbundle.crt
 		/etc/nginx/secrets/test-keypair.pem
 		/etc/nginx/stream-conf.d/stream.conf
+		/var/lib/nginx/state/stream_up.conf
+		/var/lib/nginx/state/up.conf
 	*/
 
 	g.Expect(files[0].Meta.Permissions).To(Equal(file.RegularFileMode))
@@ -278,4 +281,15 @@ func TestGenerate(t *testing.T) {
 	g.Expect(streamCfg).To(ContainSubstring("listen 443"))
 	g.Expect(streamCfg).To(ContainSubstring("app.example.com unix:/var/run/nginx/app.example.com-443.sock"))
 	g.Expect(streamCfg).To(ContainSubstring("example.com unix:/var/run/nginx/https443.sock"))
+
+	// state files of the upstreams are unmanaged
+	g.Expect(files[18]).To(Equal(agent.File{
+		Meta: &pb.FileMeta{
+			Name:        "/var/lib/nginx/state/stream_up.conf",
+			Permissions: file.RegularFileMode,
+		},
+		Unmanaged: true,
+	}))
+	g.Expect(files[19].Meta.Name).To(Equal("/var/lib/nginx/state/up.conf"))
+	g.Expect(files[19].Unmanaged).To(BeTrue())
 }
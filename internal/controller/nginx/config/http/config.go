@@ -22,6 +22,11 @@ type Server struct {
 	IsDefaultSSL  bool
 	GRPC          bool
 	IsSocket      bool
+	// APICatalogPath is the path at which the server serves the API catalog of the Gateway.
+	// If empty, the server doesn't serve the catalog.
+	APICatalogPath string
+	// RejectHandshake rejects the TLS handshakes for the server. Only applicable to SSL servers.
+	RejectHandshake bool
 }
 
 type LocationType string
@@ -51,6 +56,8 @@ type Location struct {
 	Return *Return
 	// ProxySSLVerify controls SSL verification for upstreams when proxying requests.
 	ProxySSLVerify *ProxySSLVerify
+	// ProxyTimeouts holds the timeouts and the retries of the proxied requests.
+	ProxyTimeouts *ProxyTimeouts
 	// ProxyPass is the upstream backend (URL or name) to which requests are proxied.
 	ProxyPass string
 	// HTTPMatchKey is the key for associating HTTP match rules, used for routing and NJS module logic.
@@ -61,6 +68,15 @@ type Location struct {
 	EPPInternalPath string
 	// EPPHost is the host for the EndpointPicker, used for inference routing.
 	EPPHost string
+	// StatusZone is the NGINX Plus status zone that collects the metrics of the requests of the route,
+	// like the responses by status code, including the requests closed by the client (499).
+	StatusZone string
+	// RouteNamespace is the namespace of the route of the location, to which the requests are attributed
+	// if they don't identify their tenant.
+	RouteNamespace string
+	// RouteName is the name of the route of the location, which the requests are reported with
+	// for the usage summaries of the Gateway.
+	RouteName string
 	// Type indicates the type of location (external, internal, redirect, etc).
 	Type LocationType
 	// Path is the NGINX location path.
@@ -79,6 +95,21 @@ type Location struct {
 	EPPPort int
 	// GRPC indicates if this location proxies gRPC traffic.
 	GRPC bool
+	// KeepAliveDisabled indicates if the requests are proxied using HTTP/1.0, so that the connections to
+	// the upstream servers are closed after every request.
+	KeepAliveDisabled bool
+}
+
+// ProxyTimeouts holds the timeouts and the retries of the requests proxied by a location.
+type ProxyTimeouts struct {
+	// TryTimeout is the timeout of a single try of the request, used for the read and send timeouts.
+	TryTimeout string
+	// NextUpstreamTimeout limits the time within which the request can be passed to the next upstream server.
+	NextUpstreamTimeout string
+	// NextUpstream are the space-separated conditions in which the request is passed to the next upstream server.
+	NextUpstream string
+	// NextUpstreamTries is the number of tries of the request. Zero means the NGINX default.
+	NextUpstreamTries int
 }
 
 // Header defines an HTTP header to be passed to the proxied server.
@@ -144,6 +175,8 @@ type UpstreamKeepAlive struct {
 	Timeout     string
 	Connections int32
 	Requests    int32
+	// Disabled disables the keep-alive connections to the upstream servers.
+	Disabled bool
 }
 
 // UpstreamServer holds all configuration for an HTTP upstream server.
@@ -168,15 +201,41 @@ type SplitClientDistribution struct {
 type ProxySSLVerify struct {
 	TrustedCertificate string
 	Name               string
+	// SessionReuse is the value of the proxy_ssl_session_reuse directive. If empty, the directive is not set.
+	SessionReuse string
+	// Protocols is the value of the proxy_ssl_protocols directive. If empty, the directive is not set.
+	Protocols string
+	// Ciphers is the value of the proxy_ssl_ciphers directive. If empty, the directive is not set.
+	Ciphers string
 }
 
 // ServerConfig holds configuration for an HTTP server and IP family to be used by NGINX.
 type ServerConfig struct {
+	TokenReview              *TokenReview
 	Servers                  []Server
+	Includes                 []shared.Include
 	RewriteClientIP          shared.RewriteClientIPSettings
 	IPFamily                 shared.IPFamily
 	Plus                     bool
 	DisableSNIHostValidation bool
+	TenantAttribution        bool
+	UpstreamHTTP2            bool
+	GatewayTest              bool
+}
+
+// TokenReviewPath is the path prefix of the internal location that verifies the bearer tokens of the requests
+// with the verifier of the control plane. The prefix is followed by the namespace and the name of the
+// ClientSettingsPolicy that requires the tokens.
+const TokenReviewPath = "/_ngf-internal-token-review/"
+
+// TokenReview holds the configuration of the internal location that verifies the bearer tokens of the requests.
+type TokenReview struct {
+	// Path is the path prefix of the location, TokenReviewPath.
+	Path string
+	// Server is the address of the verifier of the control plane. If empty, the location rejects the requests.
+	Server string
+	// ServerName is the name in the certificate of the verifier, which NGINX verifies.
+	ServerName string
 }
 
 var (
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/shared"
@@ -39,6 +43,42 @@ func createIncludeExecuteResultsFromServers(servers []http.Server) []executeResu
 	return results
 }
 
+// createHTTPIncludesFromPolicies creates the includes for the http context from the policies of all the servers.
+// The includes are sorted, so that the configuration doesn't change between the generations.
+func createHTTPIncludesFromPolicies(conf dataplane.Configuration, generator policies.Generator) []shared.Include {
+	var pols []policies.Policy
+	seen := make(map[string]struct{})
+
+	add := func(serverPolicies []policies.Policy) {
+		for _, pol := range serverPolicies {
+			key := fmt.Sprintf("%T/%s/%s", pol, pol.GetNamespace(), pol.GetName())
+			if _, exists := seen[key]; exists {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			pols = append(pols, pol)
+		}
+	}
+
+	for _, servers := range [][]dataplane.VirtualServer{conf.HTTPServers, conf.SSLServers} {
+		for _, server := range servers {
+			add(server.Policies)
+
+			for _, rule := range server.PathRules {
+				add(rule.Policies)
+			}
+		}
+	}
+
+	includes := deduplicateIncludes(createIncludesFromPolicyGenerateResult(generator.GenerateForHTTP(pols)))
+	slices.SortFunc(includes, func(a, b shared.Include) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return includes
+}
+
 // createIncludesFromPolicyGenerateResult converts a list of policies.File into a list of includes.
 func createIncludesFromPolicyGenerateResult(resFiles []policies.File) []shared.Include {
 	if len(resFiles) == 0 {
@@ -4,9 +4,12 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/policiesfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/shared"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
 )
@@ -160,6 +163,52 @@ func TestCreateIncludesFromPolicyGenerateResult(t *testing.T) {
 	}
 }
 
+func TestCreateHTTPIncludesFromPolicies(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gatewayPolicy := &ngfAPI.ClientSettingsPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gw"}}
+	routePolicy := &ngfAPI.ClientSettingsPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"}}
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				Policies: []policies.Policy{gatewayPolicy},
+				PathRules: []dataplane.PathRule{
+					{Policies: []policies.Policy{routePolicy}},
+				},
+			},
+		},
+		SSLServers: []dataplane.VirtualServer{
+			{
+				Policies: []policies.Policy{gatewayPolicy},
+				PathRules: []dataplane.PathRule{
+					{Policies: []policies.Policy{routePolicy}},
+				},
+			},
+		},
+	}
+
+	generator := &policiesfakes.FakeGenerator{}
+	generator.GenerateForHTTPReturns(policies.GenerateResultFiles{
+		{Name: "two.conf", Content: []byte("two")},
+		{Name: "one.conf", Content: []byte("one")},
+	})
+
+	includes := createHTTPIncludesFromPolicies(conf, generator)
+	g.Expect(includes).To(Equal([]shared.Include{
+		{Name: includesFolder + "/one.conf", Content: []byte("one")},
+		{Name: includesFolder + "/two.conf", Content: []byte("two")},
+	}))
+
+	// every policy is passed once
+	g.Expect(generator.GenerateForHTTPCallCount()).To(Equal(1))
+	g.Expect(generator.GenerateForHTTPArgsForCall(0)).To(Equal([]policies.Policy{gatewayPolicy, routePolicy}))
+
+	generator.GenerateForHTTPReturns(nil)
+	g.Expect(createHTTPIncludesFromPolicies(dataplane.Configuration{}, generator)).To(BeEmpty())
+}
+
 func TestCreateIncludesFromLocationSnippetsFilter(t *testing.T) {
 	t.Parallel()
 
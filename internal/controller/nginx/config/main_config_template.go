@@ -6,6 +6,12 @@ load_module modules/ngx_otel_module.so;
 {{ end -}}
 
 error_log stderr {{ .Conf.Logging.ErrorLevel }};
+{{- if .Conf.Logging.FailureServer }}
+error_log syslog:server={{ .Conf.Logging.FailureServer }},tag={{ .Conf.Logging.FailureSyslogTag }},nohostname alert;
+{{- end }}
+{{- if .Conf.ConnectionCloseTimeout }}
+worker_shutdown_timeout {{ .Conf.ConnectionCloseTimeout }};
+{{- end }}
 
 
 {{ range $i := .Includes -}}
@@ -72,6 +72,30 @@ func TestExecuteMainConfig_Logging(t *testing.T) {
 	g.Expect(res[0].dest).To(Equal(mainIncludesConfigFile))
 
 	g.Expect(string(res[0].data)).To(ContainSubstring("error_log stderr info"))
+	g.Expect(string(res[0].data)).ToNot(ContainSubstring("syslog"))
+}
+
+func TestExecuteMainConfig_FailureReporting(t *testing.T) {
+	t.Parallel()
+
+	conf := dataplane.Configuration{
+		Logging: dataplane.Logging{
+			ErrorLevel:       "info",
+			FailureServer:    "ngf-nginx-gateway.nginx-gateway.svc:5142",
+			FailureSyslogTag: "ngf_fail_6c62272e07bb0142",
+		},
+	}
+
+	g := NewWithT(t)
+
+	res := executeMainConfig(conf)
+	g.Expect(res).To(HaveLen(1))
+
+	g.Expect(string(res[0].data)).To(ContainSubstring("error_log stderr info;"))
+	g.Expect(string(res[0].data)).To(ContainSubstring(
+		"error_log syslog:server=ngf-nginx-gateway.nginx-gateway.svc:5142,tag=ngf_fail_6c62272e07bb0142," +
+			"nohostname alert;",
+	))
 }
 
 func TestExecuteMainConfig_Snippets(t *testing.T) {
@@ -178,6 +202,43 @@ func TestExecuteMainConfig_WorkerConnections(t *testing.T) {
 	}
 }
 
+func TestExecuteMainConfig_ConnectionCloseTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		conf         dataplane.Configuration
+		expDirective bool
+	}{
+		{
+			name: "connection close timeout set",
+			conf: dataplane.Configuration{
+				ConnectionCloseTimeout: "30s",
+			},
+			expDirective: true,
+		},
+		{
+			name: "connection close timeout not set",
+			conf: dataplane.Configuration{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			res := executeMainConfig(test.conf)
+			g.Expect(res).To(HaveLen(1))
+			if test.expDirective {
+				g.Expect(string(res[0].data)).To(ContainSubstring("worker_shutdown_timeout 30s;"))
+			} else {
+				g.Expect(string(res[0].data)).ToNot(ContainSubstring("worker_shutdown_timeout"))
+			}
+		})
+	}
+}
+
 func TestExecuteEventsConfig_WorkerConnections(t *testing.T) {
 	t.Parallel()
 
@@ -28,10 +28,13 @@ const (
 	connectionClosedStreamServerSocket = "unix:/var/run/nginx/connection-closed-server.sock"
 )
 
-func executeMaps(conf dataplane.Configuration) []executeResult {
-	maps := buildAddHeaderMaps(append(conf.HTTPServers, conf.SSLServers...))
-	maps = append(maps, buildInferenceMaps(conf.BackendGroups)...)
+func newExecuteMapsFunc(maps []shared.Map) executeFunc {
+	return func(_ dataplane.Configuration) []executeResult {
+		return executeMaps(maps)
+	}
+}
 
+func executeMaps(maps []shared.Map) []executeResult {
 	result := executeResult{
 		dest: httpConfigFile,
 		data: helpers.MustExecuteTemplate(mapsTemplate, maps),
@@ -40,6 +43,13 @@ func executeMaps(conf dataplane.Configuration) []executeResult {
 	return []executeResult{result}
 }
 
+func collectAllMaps(conf dataplane.Configuration) []shared.Map {
+	maps := buildAddHeaderMaps(append(conf.HTTPServers, conf.SSLServers...))
+	maps = append(maps, buildInferenceMaps(conf.BackendGroups)...)
+
+	return maps
+}
+
 func executeStreamMaps(conf dataplane.Configuration) []executeResult {
 	maps := createStreamMaps(conf)
 
@@ -97,7 +97,7 @@ func TestExecuteMaps(t *testing.T) {
 		"invalid-backend-ref":                                                 1,
 	}
 
-	mapResult := executeMaps(conf)
+	mapResult := executeMaps(collectAllMaps(conf))
 	g.Expect(mapResult).To(HaveLen(1))
 	maps := string(mapResult[0].data)
 	g.Expect(mapResult[0].dest).To(Equal(httpConfigFile))
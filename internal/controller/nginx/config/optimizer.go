@@ -0,0 +1,138 @@
+package config
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/shared"
+)
+
+// variableRegexp matches the NGINX variables, like $group_test__route_rule0 or ${http_my_header}.
+var variableRegexp = regexp.MustCompile(`\$(\{[A-Za-z0-9_]+}|[A-Za-z0-9_]+)`)
+
+// variableAliases maps the variables of the split_clients and map blocks removed by the optimizer to the variables
+// of the equivalent blocks that are kept. The variables don't include the $ prefix.
+//
+// In clusters with many similar Routes, for example, thousands of Routes that split the traffic between the same
+// Services with the same weights, many generated blocks are identical except for their variables. Every block costs
+// NGINX memory and reload time, so the optimizer keeps only one block of each set of equivalent blocks.
+type variableAliases map[string]string
+
+// compactSplitClients removes the split_clients blocks that are equivalent to another block, and records their
+// variables in the aliases. The blocks are equivalent if they distribute the requests in the same way.
+// Of the equivalent blocks, the one with the lowest variable is kept, so that the result is deterministic.
+func compactSplitClients(splitClients []http.SplitClient, aliases variableAliases) []http.SplitClient {
+	sorted := slices.SortedStableFunc(slices.Values(splitClients), func(a, b http.SplitClient) int {
+		return cmp.Compare(a.VariableName, b.VariableName)
+	})
+
+	kept := make(map[string]string, len(sorted))
+	result := make([]http.SplitClient, 0, len(sorted))
+
+	for _, sc := range sorted {
+		var key strings.Builder
+		for _, d := range sc.Distributions {
+			key.WriteString(d.Percent + " " + d.Value + ";")
+		}
+
+		if variable, exists := kept[key.String()]; exists {
+			aliases[sc.VariableName] = variable
+			continue
+		}
+
+		kept[key.String()] = sc.VariableName
+		result = append(result, sc)
+	}
+
+	return result
+}
+
+// compactMaps removes the map blocks that are equivalent to another block, and records their variables in
+// the aliases. The blocks are equivalent if they map the same source to the same results.
+// Of the equivalent blocks, the one with the lowest variable is kept, so that the result is deterministic.
+func compactMaps(maps []shared.Map, aliases variableAliases) []shared.Map {
+	sorted := slices.SortedStableFunc(slices.Values(maps), func(a, b shared.Map) int {
+		return cmp.Compare(a.Variable, b.Variable)
+	})
+
+	kept := make(map[string]string, len(sorted))
+	result := make([]shared.Map, 0, len(sorted))
+
+	for _, m := range sorted {
+		var key strings.Builder
+		key.WriteString(m.Source)
+		if m.UseHostnames {
+			key.WriteString(" hostnames")
+		}
+		key.WriteString(";")
+		for _, p := range m.Parameters {
+			key.WriteString(p.Value + " " + p.Result + ";")
+		}
+
+		variable := strings.TrimPrefix(m.Variable, "$")
+
+		if keptVariable, exists := kept[key.String()]; exists {
+			aliases[variable] = keptVariable
+			continue
+		}
+
+		kept[key.String()] = variable
+		result = append(result, m)
+	}
+
+	return result
+}
+
+// replaceVariables replaces the variables of the removed blocks in the value of a directive with the variables of
+// the kept blocks.
+func (a variableAliases) replaceVariables(value string) string {
+	if len(a) == 0 || !strings.Contains(value, "$") {
+		return value
+	}
+
+	return variableRegexp.ReplaceAllStringFunc(value, func(variable string) string {
+		name := strings.Trim(variable, "${}")
+
+		alias, exists := a[name]
+		if !exists {
+			return variable
+		}
+
+		if strings.HasPrefix(variable, "${") {
+			return "${" + alias + "}"
+		}
+
+		return "$" + alias
+	})
+}
+
+// rewriteServers replaces the variables of the removed blocks in the locations of the servers with the variables
+// of the kept blocks.
+func (a variableAliases) rewriteServers(servers []http.Server) {
+	if len(a) == 0 {
+		return
+	}
+
+	for i := range servers {
+		for j := range servers[i].Locations {
+			loc := &servers[i].Locations[j]
+
+			loc.ProxyPass = a.replaceVariables(loc.ProxyPass)
+
+			for k := range loc.Rewrites {
+				loc.Rewrites[k] = a.replaceVariables(loc.Rewrites[k])
+			}
+
+			for k := range loc.ProxySetHeaders {
+				loc.ProxySetHeaders[k].Value = a.replaceVariables(loc.ProxySetHeaders[k].Value)
+			}
+
+			if alias, exists := a[loc.MirrorSplitClientsVariableName]; exists {
+				loc.MirrorSplitClientsVariableName = alias
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/shared"
+)
+
+func TestCompactSplitClients(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	distributions := []http.SplitClientDistribution{
+		{Percent: "80.00", Value: "test_stable_80"},
+		{Percent: "20.00", Value: "test_canary_80"},
+	}
+
+	splitClients := []http.SplitClient{
+		{VariableName: "group_test__route_c_rule0", Distributions: distributions},
+		{
+			VariableName: "group_test__route_b_rule0",
+			Distributions: []http.SplitClientDistribution{
+				{Percent: "50.00", Value: "test_stable_80"},
+				{Percent: "50.00", Value: "test_canary_80"},
+			},
+		},
+		{VariableName: "group_test__route_a_rule0", Distributions: distributions},
+		{VariableName: "group_test__route_d_rule0", Distributions: distributions},
+	}
+
+	aliases := make(variableAliases)
+	result := compactSplitClients(splitClients, aliases)
+
+	g.Expect(result).To(Equal([]http.SplitClient{
+		{VariableName: "group_test__route_a_rule0", Distributions: distributions},
+		splitClients[1],
+	}))
+	g.Expect(aliases).To(Equal(variableAliases{
+		"group_test__route_c_rule0": "group_test__route_a_rule0",
+		"group_test__route_d_rule0": "group_test__route_a_rule0",
+	}))
+
+	g.Expect(compactSplitClients(nil, aliases)).To(BeEmpty())
+}
+
+func TestCompactMaps(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	params := []shared.MapParameter{
+		{Value: "default", Result: "''"},
+		{Value: "~.*", Result: "${http_my_header},"},
+	}
+
+	maps := []shared.Map{
+		{Source: "${http_my_header}", Variable: "$my_header_b", Parameters: params},
+		{Source: "${http_my_header}", Variable: "$my_header_a", Parameters: params},
+		{Source: "${http_my_header}", Variable: "$my_header_hostnames", Parameters: params, UseHostnames: true},
+		{Source: "${http_other_header}", Variable: "$other_header", Parameters: params},
+	}
+
+	aliases := make(variableAliases)
+	result := compactMaps(maps, aliases)
+
+	g.Expect(result).To(Equal([]shared.Map{maps[1], maps[2], maps[3]}))
+	g.Expect(aliases).To(Equal(variableAliases{"my_header_b": "my_header_a"}))
+}
+
+func TestVariableAliasesReplaceVariables(t *testing.T) {
+	t.Parallel()
+
+	aliases := variableAliases{
+		"group_b":     "group_a",
+		"header_b":    "header_a",
+		"inference_b": "inference_a",
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "proxy pass",
+			value:    "http://$group_b$request_uri",
+			expected: "http://$group_a$request_uri",
+		},
+		{
+			name:     "variable with braces",
+			value:    "${header_b}value",
+			expected: "${header_a}value",
+		},
+		{
+			name:     "rewrite",
+			value:    "^ $inference_b last",
+			expected: "^ $inference_a last",
+		},
+		{
+			name:     "variable with a common prefix",
+			value:    "http://$group_bc$request_uri",
+			expected: "http://$group_bc$request_uri",
+		},
+		{
+			name:     "no variables",
+			value:    "http://test_foo_80",
+			expected: "http://test_foo_80",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(aliases.replaceVariables(test.value)).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestVariableAliasesRewriteServers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	servers := []http.Server{
+		{
+			Locations: []http.Location{
+				{
+					ProxyPass:                      "http://$group_b$request_uri",
+					Rewrites:                       []string{"^ $group_b last"},
+					ProxySetHeaders:                []http.Header{{Name: "My-Header", Value: "${header_b}value"}},
+					MirrorSplitClientsVariableName: "mirror_b",
+				},
+				{
+					ProxyPass: "http://test_foo_80$request_uri",
+				},
+			},
+		},
+	}
+
+	aliases := variableAliases{
+		"group_b":  "group_a",
+		"header_b": "header_a",
+		"mirror_b": "mirror_a",
+	}
+	aliases.rewriteServers(servers)
+
+	g.Expect(servers[0].Locations).To(Equal([]http.Location{
+		{
+			ProxyPass:                      "http://$group_a$request_uri",
+			Rewrites:                       []string{"^ $group_a last"},
+			ProxySetHeaders:                []http.Header{{Name: "My-Header", Value: "${header_a}value"}},
+			MirrorSplitClientsVariableName: "mirror_a",
+		},
+		{
+			ProxyPass: "http://test_foo_80$request_uri",
+		},
+	}))
+}
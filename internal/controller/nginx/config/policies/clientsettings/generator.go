@@ -2,7 +2,10 @@ package clientsettings
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"text/template"
+	"time"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
@@ -10,7 +13,62 @@ import (
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 )
 
-var tmpl = template.Must(template.New("client settings policy").Parse(clientSettingsTemplate))
+var (
+	tmpl               = template.Must(template.New("client settings policy").Parse(clientSettingsTemplate))
+	errorResponsesTmpl = template.Must(template.New("client error responses").Parse(errorResponsesTemplate))
+	limitZonesTmpl     = template.Must(template.New("client request limit zones").Parse(requestLimitZonesTemplate))
+	limitsTmpl         = template.Must(template.New("client request limits").Parse(requestLimitsTemplate))
+	rejectResponseTmpl = template.Must(template.New("client reject response").Parse(rejectResponseTemplate))
+	tarpitTmpl         = template.Must(template.New("client tarpit").Parse(tarpitTemplate))
+	accessMapsTmpl     = template.Must(template.New("client access maps").Parse(accessMapsTemplate))
+	accessTmpl         = template.Must(template.New("client access").Parse(accessTemplate))
+	authenticationTmpl = template.Must(template.New("client authentication").Parse(authenticationTemplate))
+	remoteAuthTmpl     = template.Must(template.New("client remote authentication").Parse(remoteAuthTemplate))
+	authCacheTmpl      = template.Must(template.New("client authentication cache").Parse(remoteAuthCacheTemplate))
+)
+
+const (
+	// DefaultErrorResponseContentType is the default content type of the error responses.
+	DefaultErrorResponseContentType = "application/json"
+	// DefaultErrorResponseTemplate is the default body of the error responses.
+	DefaultErrorResponseTemplate = `{"status":$status,"error":"$reason","requestId":"$request_id"}`
+	// DefaultRequestLimitRejectCode is the default status code of the responses to the requests over a limit.
+	DefaultRequestLimitRejectCode = 429
+	// DefaultRejectResponseContentType is the default content type of the responses to the requests over a limit.
+	DefaultRejectResponseContentType = "text/plain"
+	// DefaultTarpitDelay is the default delay of the responses to the requests for the decoy paths.
+	DefaultTarpitDelay = 30 * time.Second
+	// MaxTarpitDelay is the maximum delay of the responses to the requests for the decoy paths.
+	MaxTarpitDelay = 10 * time.Minute
+	// DefaultTarpitStatusCode is the default status code of the responses to the requests for the decoy paths.
+	DefaultTarpitStatusCode = 403
+	// DefaultAccessDenyCode is the default status code of the responses to the requests that are not allowed.
+	DefaultAccessDenyCode = 403
+	// DefaultRemoteAuthenticationTimeout is the default timeout of the remote authentication endpoint.
+	DefaultRemoteAuthenticationTimeout = "5s"
+)
+
+const (
+	// remoteAuthenticationPath is the path prefix of the internal locations that authenticate the requests with
+	// the remote endpoints. The prefix is followed by the namespace and the name of the ClientSettingsPolicy.
+	remoteAuthenticationPath = "/_ngf-internal-remote-auth/"
+	// remoteAuthenticationCachePath is the directory of the caches of the responses of the remote endpoints.
+	remoteAuthenticationCachePath = "/var/cache/nginx"
+	// systemRootCAPath is the bundle of the system CA certificates in the NGINX image.
+	systemRootCAPath = "/etc/ssl/cert.pem"
+)
+
+const (
+	// perClientLimitKey counts the requests of every client IP address separately.
+	perClientLimitKey = "$binary_remote_addr"
+	// sharedLimitKey counts all the requests together.
+	sharedLimitKey = "all"
+	// perClientLimitZoneSize is the size of the zones that count the requests of every client IP address,
+	// enough for about 160,000 addresses.
+	perClientLimitZoneSize = "10m"
+	// sharedLimitZoneSize is the size of the zones that count all the requests together.
+	sharedLimitZoneSize = "1m"
+)
 
 const clientSettingsTemplate = `
 {{- if .Body }}
@@ -21,6 +79,12 @@ client_max_body_size {{ .Body.MaxSize }};
 client_body_timeout {{ .Body.Timeout }};
 	{{- end }}
 {{- end }}
+{{- if .Compression }}
+	{{- if .Compression.Precompressed }}
+gunzip on;
+gzip_vary on;
+	{{- end }}
+{{- end }}
 {{- if .KeepAlive }}
 	{{- if .KeepAlive.Requests }}
 keepalive_requests {{ .KeepAlive.Requests }};
@@ -38,6 +102,230 @@ keepalive_timeout {{ .KeepAlive.Timeout.Server }};
 {{- end }}
 `
 
+// errorResponsesTemplate returns the error responses from named locations. The 494 status is the status
+// NGINX uses internally when the request headers are too large.
+const errorResponsesTemplate = `
+{{- range $r := .Responses }}
+error_page {{ $r.InternalCode }} {{ $r.Location }};
+{{- end }}
+{{- range $r := .Responses }}
+
+location {{ $r.Location }} {
+    default_type "{{ $.ContentType }}";
+    add_header X-Request-ID $request_id always;
+    return {{ $r.Code }} "{{ $r.Body }}";
+}
+{{- end }}
+`
+
+// requestLimitZonesTemplate defines the shared memory zones of the request limits in the http context.
+const requestLimitZonesTemplate = `
+{{- if .MaxConcurrentRequests }}
+limit_conn_zone {{ .Key }} zone={{ .ConnZone }}:{{ .ZoneSize }};
+{{- end }}
+{{- if .Rate }}
+limit_req_zone {{ .Key }} zone={{ .ReqZone }}:{{ .ZoneSize }} rate={{ .Rate }};
+{{- end }}
+`
+
+// requestLimitsTemplate applies the request limits to a location. The limits are not applied to the internal
+// locations, so that the requests redirected to them are not counted twice.
+const requestLimitsTemplate = `
+{{- if .MaxConcurrentRequests }}
+limit_conn {{ .ConnZone }} {{ .MaxConcurrentRequests }};
+limit_conn_status {{ .RejectCode }};
+{{- end }}
+{{- if .Rate }}
+limit_req zone={{ .ReqZone }}{{ if .Burst }} burst={{ .Burst }}{{ end }};
+limit_req_status {{ .RejectCode }};
+{{- end }}
+{{- if .RejectLocation }}
+error_page {{ .RejectCode }} {{ .RejectLocation }};
+{{- end }}
+`
+
+// rejectResponseTemplate returns the response to the requests over a limit from a named location. Without a body,
+// NGINX returns its error page, because the error pages are not recursive.
+const rejectResponseTemplate = `
+
+location {{ .Location }} {
+{{- if .RetryAfter }}
+    add_header Retry-After {{ .RetryAfter }} always;
+{{- end }}
+{{- if .Body }}
+    default_type "{{ .ContentType }}";
+    return {{ .Code }} "{{ .Body }}";
+{{- else }}
+    return {{ .Code }};
+{{- end }}
+}
+`
+
+// tarpitTemplate delays the responses to the requests for the decoy paths in the tarpit njs module, which
+// also logs the IP address of the client. The regular expression location takes precedence over the prefix
+// locations of the Routes.
+const tarpitTemplate = `
+
+location ~ "^(?:{{ .Paths }})(?:/|$)" {
+    set $ngf_tarpit_delay {{ .DelayMilliseconds }};
+    set $ngf_tarpit_status {{ .StatusCode }};
+    js_content tarpit.delay;
+}
+`
+
+// accessMapsTemplate evaluates the conditions of the access in the http context. Every condition sets its
+// variable to 1 if the request matches it. The time windows are matched against $date_gmt, which has the
+// "Saturday, 17-October-2026 14:05:03 GMT" format and is the only variable with the day of the week.
+const accessMapsTemplate = `
+{{ if .Windows -}}
+map $date_gmt ${{ .TimeVariable }} {
+    default 0;
+	{{- range $w := .Windows }}
+    "{{ $w }}" 1;
+	{{- end }}
+}
+
+{{ end -}}
+{{ if .CIDRs -}}
+geo ${{ .AddressVariable }} {
+    default 0;
+	{{- range $c := .CIDRs }}
+    {{ $c }} 1;
+	{{- end }}
+}
+
+{{ end -}}
+{{ if .HeaderValues -}}
+map $http_{{ .HeaderName }} ${{ .HeaderVariable }} {
+    default 0;
+    "{{ .HeaderValues }}" 1;
+}
+
+{{ end -}}
+map "{{ .Conditions }}" ${{ .DeniedVariable }} {
+    default 1;
+    "{{ .Allowed }}" 0;
+}
+`
+
+// accessTemplate rejects the requests that are not allowed. The access is also checked in the internal locations,
+// because an external location can be shared by the Routes of several policies.
+const accessTemplate = `
+if (${{ .DeniedVariable }}) {
+    return {{ .DenyCode }};
+}
+`
+
+// authenticationTemplate requires the requests to authenticate with the verifier of the control plane, which reviews
+// the bearer token of the request for the policy in the path of the subrequest, or with the remote endpoint of the
+// policy. The authentication is also checked
+// in the internal locations, because an external location can be shared by the Routes of several policies.
+const authenticationTemplate = `
+auth_request {{ .Path }};
+`
+
+// remoteAuthTemplate authenticates the requests with the remote endpoint in an internal location. The URL
+// of the endpoint is passed to proxy_pass in a variable, so that NGINX resolves the hostname with the resolver of the
+// http context when the cached address expires, rather than only when the configuration is loaded.
+const remoteAuthTemplate = `
+
+location = {{ .Path }} {
+    internal;
+    set $ngf_remote_auth_url "{{ .URL }}";
+    proxy_pass_request_body off;
+    proxy_set_header Content-Length "";
+    proxy_set_header X-Original-URI $request_uri;
+    proxy_set_header X-Original-Method $request_method;
+    proxy_connect_timeout {{ .Timeout }};
+    proxy_send_timeout {{ .Timeout }};
+    proxy_read_timeout {{ .Timeout }};
+{{- if .HTTPS }}
+    proxy_ssl_server_name on;
+    proxy_ssl_verify on;
+    proxy_ssl_trusted_certificate {{ .TrustedCertificate }};
+{{- end }}
+{{- if .CacheZone }}
+    proxy_cache {{ .CacheZone }};
+    proxy_cache_key $http_authorization;
+    proxy_cache_valid 200 204 {{ .CacheDuration }};
+    proxy_ignore_headers Cache-Control Expires Set-Cookie;
+{{- end }}
+    proxy_pass $ngf_remote_auth_url;
+}
+`
+
+// remoteAuthCacheTemplate defines the cache of the responses of the remote endpoint in the http context.
+const remoteAuthCacheTemplate = `
+proxy_cache_path {{ .CachePath }} keys_zone={{ .CacheZone }}:1m max_size=10m inactive={{ .CacheDuration }};
+`
+
+type authentication struct {
+	Path string
+}
+
+type remoteAuthentication struct {
+	Path               string
+	URL                string
+	Timeout            string
+	TrustedCertificate string
+	CachePath          string
+	CacheZone          string
+	CacheDuration      string
+	HTTPS              bool
+}
+
+type access struct {
+	TimeVariable    string
+	AddressVariable string
+	HeaderVariable  string
+	DeniedVariable  string
+	HeaderName      string
+	HeaderValues    string
+	Conditions      string
+	Allowed         string
+	Windows         []string
+	CIDRs           []string
+	DenyCode        int32
+}
+
+type tarpit struct {
+	Paths             string
+	DelayMilliseconds int64
+	StatusCode        int32
+}
+
+type rejectResponse struct {
+	Body        *string
+	Location    string
+	ContentType string
+	RetryAfter  int64
+	Code        int32
+}
+
+type requestLimits struct {
+	MaxConcurrentRequests *int32
+	Burst                 *int32
+	Key                   string
+	RejectLocation        string
+	ConnZone              string
+	ReqZone               string
+	ZoneSize              string
+	Rate                  ngfAPI.Rate
+	RejectCode            int32
+}
+
+type errorResponse struct {
+	Location     string
+	Body         string
+	Code         int
+	InternalCode int
+}
+
+type errorResponses struct {
+	ContentType string
+	Responses   []errorResponse
+}
+
 // Generator generates nginx configuration based on a clientsettings policy.
 type Generator struct{}
 
@@ -46,30 +334,188 @@ func NewGenerator() *Generator {
 	return &Generator{}
 }
 
+// GenerateForHTTP generates the shared memory zones of the request limits for the http context.
+func (g Generator) GenerateForHTTP(pols []policies.Policy) policies.GenerateResultFiles {
+	var files policies.GenerateResultFiles
+
+	for _, csp := range clientSettingsPolicies(pols) {
+		if csp.Spec.RequestLimits == nil {
+			continue
+		}
+
+		files = append(files, policies.File{
+			Name:    fmt.Sprintf("ClientSettingsPolicy_%s_%s_zones.conf", csp.Namespace, csp.Name),
+			Content: helpers.MustExecuteTemplate(limitZonesTmpl, createRequestLimits(csp)),
+		})
+	}
+
+	for _, csp := range clientSettingsPolicies(pols) {
+		if csp.Spec.Access == nil {
+			continue
+		}
+
+		files = append(files, policies.File{
+			Name:    fmt.Sprintf("ClientSettingsPolicy_%s_%s_access_maps.conf", csp.Namespace, csp.Name),
+			Content: helpers.MustExecuteTemplate(accessMapsTmpl, createAccess(csp)),
+		})
+	}
+
+	for _, csp := range clientSettingsPolicies(pols) {
+		remote := createRemoteAuthentication(csp)
+		if remote == nil || remote.CacheZone == "" {
+			continue
+		}
+
+		files = append(files, policies.File{
+			Name:    fmt.Sprintf("ClientSettingsPolicy_%s_%s_remote_auth_cache.conf", csp.Namespace, csp.Name),
+			Content: helpers.MustExecuteTemplate(authCacheTmpl, remote),
+		})
+	}
+
+	return files
+}
+
 // GenerateForServer generates policy configuration for the server block.
 func (g Generator) GenerateForServer(pols []policies.Policy, _ http.Server) policies.GenerateResultFiles {
-	return generate(pols)
+	files := generate(pols)
+
+	// the error responses are only generated for the server block, because they use named locations.
+	for i, pol := range clientSettingsPolicies(pols) {
+		if pol.Spec.ErrorResponses == nil {
+			continue
+		}
+
+		files[i].Content = append(
+			files[i].Content,
+			helpers.MustExecuteTemplate(errorResponsesTmpl, createErrorResponses(pol))...,
+		)
+	}
+
+	// the tarpit is only generated for the server block, because it defines a location.
+	for i, pol := range clientSettingsPolicies(pols) {
+		if pol.Spec.Tarpit == nil {
+			continue
+		}
+
+		files[i].Content = append(
+			files[i].Content,
+			helpers.MustExecuteTemplate(tarpitTmpl, createTarpit(pol))...,
+		)
+	}
+
+	return files
+}
+
+// GenerateForServerRoutes generates the named locations of the responses to the requests over the request limits
+// of the Routes and the internal locations of the remote authentication, because the locations can't be defined
+// in the locations of the Routes.
+func (g Generator) GenerateForServerRoutes(pols []policies.Policy, _ http.Server) policies.GenerateResultFiles {
+	var files policies.GenerateResultFiles
+
+	for _, csp := range clientSettingsPolicies(pols) {
+		if csp.Spec.RequestLimits == nil || csp.Spec.RequestLimits.RejectResponse == nil {
+			continue
+		}
+
+		files = append(files, policies.File{
+			Name:    fmt.Sprintf("ClientSettingsPolicy_%s_%s_reject.conf", csp.Namespace, csp.Name),
+			Content: helpers.MustExecuteTemplate(rejectResponseTmpl, createRejectResponse(csp)),
+		})
+	}
+
+	for _, csp := range clientSettingsPolicies(pols) {
+		remote := createRemoteAuthentication(csp)
+		if remote == nil {
+			continue
+		}
+
+		files = append(files, policies.File{
+			Name:    fmt.Sprintf("ClientSettingsPolicy_%s_%s_remote_auth.conf", csp.Namespace, csp.Name),
+			Content: helpers.MustExecuteTemplate(remoteAuthTmpl, remote),
+		})
+	}
+
+	return files
 }
 
 // GenerateForLocation generates policy configuration for a normal location block.
 func (g Generator) GenerateForLocation(pols []policies.Policy, _ http.Location) policies.GenerateResultFiles {
-	return generate(pols)
+	files := generate(pols)
+
+	// the request limits are in a separate file, because the file of the policy is shared with the internal
+	// locations, which don't apply the limits.
+	for _, csp := range clientSettingsPolicies(pols) {
+		if csp.Spec.RequestLimits == nil {
+			continue
+		}
+
+		files = append(files, policies.File{
+			Name:    fmt.Sprintf("ClientSettingsPolicy_%s_%s_limits.conf", csp.Namespace, csp.Name),
+			Content: helpers.MustExecuteTemplate(limitsTmpl, createRequestLimits(csp)),
+		})
+	}
+
+	files = append(files, generateAccess(pols)...)
+
+	return append(files, generateAuthentication(pols)...)
 }
 
 // GenerateForInternalLocation generates policy configuration for an internal location block.
 func (g Generator) GenerateForInternalLocation(pols []policies.Policy) policies.GenerateResultFiles {
-	return generate(pols)
+	files := append(generate(pols), generateAccess(pols)...)
+
+	return append(files, generateAuthentication(pols)...)
 }
 
-func generate(pols []policies.Policy) policies.GenerateResultFiles {
-	files := make(policies.GenerateResultFiles, 0, len(pols))
+// generateAccess generates the access checks for the locations. The checks are in a separate file, because
+// the file of the policy is shared with the server block, which doesn't check the access.
+func generateAccess(pols []policies.Policy) policies.GenerateResultFiles {
+	var files policies.GenerateResultFiles
 
-	for _, pol := range pols {
-		csp, ok := pol.(*ngfAPI.ClientSettingsPolicy)
-		if !ok {
+	for _, csp := range clientSettingsPolicies(pols) {
+		if csp.Spec.Access == nil {
 			continue
 		}
 
+		files = append(files, policies.File{
+			Name:    fmt.Sprintf("ClientSettingsPolicy_%s_%s_access.conf", csp.Namespace, csp.Name),
+			Content: helpers.MustExecuteTemplate(accessTmpl, createAccess(csp)),
+		})
+	}
+
+	return files
+}
+
+// generateAuthentication generates the authentication of the requests for the locations. The authentication is
+// in a separate file, because the file of the policy is shared with the server block, which doesn't authenticate
+// the requests.
+func generateAuthentication(pols []policies.Policy) policies.GenerateResultFiles {
+	var files policies.GenerateResultFiles
+
+	for _, csp := range clientSettingsPolicies(pols) {
+		if csp.Spec.Authentication == nil {
+			continue
+		}
+
+		path := http.TokenReviewPath + csp.Namespace + "/" + csp.Name
+		if csp.Spec.Authentication.Mode == ngfAPI.ClientAuthenticationModeRemote {
+			path = remoteAuthenticationPath + csp.Namespace + "/" + csp.Name
+		}
+
+		files = append(files, policies.File{
+			Name:    fmt.Sprintf("ClientSettingsPolicy_%s_%s_authentication.conf", csp.Namespace, csp.Name),
+			Content: helpers.MustExecuteTemplate(authenticationTmpl, authentication{Path: path}),
+		})
+	}
+
+	return files
+}
+
+func generate(pols []policies.Policy) policies.GenerateResultFiles {
+	csps := clientSettingsPolicies(pols)
+	files := make(policies.GenerateResultFiles, 0, len(csps))
+
+	for _, csp := range csps {
 		files = append(files, policies.File{
 			Name:    fmt.Sprintf("ClientSettingsPolicy_%s_%s.conf", csp.Namespace, csp.Name),
 			Content: helpers.MustExecuteTemplate(tmpl, csp.Spec),
@@ -78,3 +524,359 @@ func generate(pols []policies.Policy) policies.GenerateResultFiles {
 
 	return files
 }
+
+func clientSettingsPolicies(pols []policies.Policy) []*ngfAPI.ClientSettingsPolicy {
+	csps := make([]*ngfAPI.ClientSettingsPolicy, 0, len(pols))
+
+	for _, pol := range pols {
+		if csp, ok := pol.(*ngfAPI.ClientSettingsPolicy); ok {
+			csps = append(csps, csp)
+		}
+	}
+
+	return csps
+}
+
+func createErrorResponses(csp *ngfAPI.ClientSettingsPolicy) errorResponses {
+	contentType := DefaultErrorResponseContentType
+	if csp.Spec.ErrorResponses.ContentType != nil {
+		contentType = *csp.Spec.ErrorResponses.ContentType
+	}
+
+	body := DefaultErrorResponseTemplate
+	if csp.Spec.ErrorResponses.Template != nil {
+		body = *csp.Spec.ErrorResponses.Template
+	}
+
+	statuses := []struct {
+		reason       string
+		code         int
+		internalCode int
+	}{
+		{code: 413, internalCode: 413, reason: "Content Too Large"},
+		{code: 431, internalCode: 494, reason: "Request Header Fields Too Large"},
+	}
+
+	responses := make([]errorResponse, 0, len(statuses))
+	for _, status := range statuses {
+		replacer := strings.NewReplacer(
+			"$status", fmt.Sprint(status.code),
+			"$reason", status.reason,
+		)
+
+		responses = append(responses, errorResponse{
+			Location:     fmt.Sprintf("@client_settings_%s_%s_%d", csp.Namespace, csp.Name, status.code),
+			Body:         escapeQuotedString(replacer.Replace(body)),
+			Code:         status.code,
+			InternalCode: status.internalCode,
+		})
+	}
+
+	return errorResponses{
+		ContentType: contentType,
+		Responses:   responses,
+	}
+}
+
+func createRequestLimits(csp *ngfAPI.ClientSettingsPolicy) requestLimits {
+	limits := csp.Spec.RequestLimits
+
+	key, zoneSize := sharedLimitKey, sharedLimitZoneSize
+	if limits.PerClient {
+		key, zoneSize = perClientLimitKey, perClientLimitZoneSize
+	}
+
+	rejectCode := int32(DefaultRequestLimitRejectCode)
+	if limits.RejectCode != nil {
+		rejectCode = *limits.RejectCode
+	}
+
+	var rate ngfAPI.Rate
+	if limits.Rate != nil {
+		rate = *limits.Rate
+	}
+
+	var rejectLocation string
+	if limits.RejectResponse != nil {
+		rejectLocation = rejectResponseLocation(csp)
+	}
+
+	return requestLimits{
+		MaxConcurrentRequests: limits.MaxConcurrentRequests,
+		Burst:                 limits.Burst,
+		Key:                   key,
+		ConnZone:              fmt.Sprintf("csp_conn_%s_%s", csp.Namespace, csp.Name),
+		ReqZone:               fmt.Sprintf("csp_req_%s_%s", csp.Namespace, csp.Name),
+		ZoneSize:              zoneSize,
+		Rate:                  rate,
+		RejectCode:            rejectCode,
+		RejectLocation:        rejectLocation,
+	}
+}
+
+func createRejectResponse(csp *ngfAPI.ClientSettingsPolicy) rejectResponse {
+	limits := csp.Spec.RequestLimits
+	spec := limits.RejectResponse
+
+	code := int32(DefaultRequestLimitRejectCode)
+	if limits.RejectCode != nil {
+		code = *limits.RejectCode
+	}
+
+	var retryAfter int64
+	if spec.RetryAfter != nil {
+		// the duration is validated by the validator, so the error can't happen.
+		if d, err := parseDuration(*spec.RetryAfter); err == nil {
+			retryAfter = int64((d + time.Second - 1) / time.Second)
+		}
+	}
+
+	contentType := DefaultRejectResponseContentType
+	if spec.ContentType != nil {
+		contentType = *spec.ContentType
+	}
+
+	var body *string
+	if spec.Body != nil {
+		body = helpers.GetPointer(escapeQuotedString(*spec.Body))
+	}
+
+	return rejectResponse{
+		Body:        body,
+		Location:    rejectResponseLocation(csp),
+		ContentType: contentType,
+		RetryAfter:  retryAfter,
+		Code:        code,
+	}
+}
+
+// rejectResponseLocation returns the named location of the responses to the requests over the request limits.
+func rejectResponseLocation(csp *ngfAPI.ClientSettingsPolicy) string {
+	return fmt.Sprintf("@client_settings_%s_%s_rejected", csp.Namespace, csp.Name)
+}
+
+// createRemoteAuthentication returns the remote authentication of the policy, or nil if the policy doesn't
+// authenticate the requests with a remote endpoint.
+func createRemoteAuthentication(csp *ngfAPI.ClientSettingsPolicy) *remoteAuthentication {
+	auth := csp.Spec.Authentication
+	if auth == nil || auth.Mode != ngfAPI.ClientAuthenticationModeRemote || auth.Remote == nil {
+		return nil
+	}
+
+	remote := &remoteAuthentication{
+		Path:    remoteAuthenticationPath + csp.Namespace + "/" + csp.Name,
+		URL:     auth.Remote.URL,
+		Timeout: DefaultRemoteAuthenticationTimeout,
+		HTTPS:   strings.HasPrefix(auth.Remote.URL, "https://"),
+	}
+
+	if remote.HTTPS {
+		remote.TrustedCertificate = systemRootCAPath
+	}
+
+	if auth.Remote.Timeout != nil {
+		remote.Timeout = string(*auth.Remote.Timeout)
+	}
+
+	if auth.Remote.CacheDuration != nil {
+		zone := fmt.Sprintf("csp_auth_%s_%s", csp.Namespace, csp.Name)
+
+		remote.CachePath = remoteAuthenticationCachePath + "/" + zone
+		remote.CacheZone = zone
+		remote.CacheDuration = string(*auth.Remote.CacheDuration)
+	}
+
+	return remote
+}
+
+func createAccess(csp *ngfAPI.ClientSettingsPolicy) access {
+	spec := csp.Spec.Access
+	suffix := strings.NewReplacer("-", "_", ".", "_").Replace(csp.Namespace + "_" + csp.Name)
+
+	a := access{
+		TimeVariable:    "csp_access_time_" + suffix,
+		AddressVariable: "csp_access_addr_" + suffix,
+		HeaderVariable:  "csp_access_header_" + suffix,
+		DeniedVariable:  "csp_access_denied_" + suffix,
+		CIDRs:           spec.CIDRs,
+		DenyCode:        DefaultAccessDenyCode,
+	}
+
+	if spec.DenyCode != nil {
+		a.DenyCode = *spec.DenyCode
+	}
+
+	var conditions []string
+
+	if len(spec.Windows) > 0 {
+		a.Windows = make([]string, 0, len(spec.Windows))
+		for _, w := range spec.Windows {
+			a.Windows = append(a.Windows, windowRegexp(w))
+		}
+
+		conditions = append(conditions, "$"+a.TimeVariable)
+	}
+
+	if len(spec.CIDRs) > 0 {
+		conditions = append(conditions, "$"+a.AddressVariable)
+	}
+
+	if spec.Header != nil {
+		values := make([]string, 0, len(spec.Header.Values))
+		for _, v := range spec.Header.Values {
+			values = append(values, regexp.QuoteMeta(string(v)))
+		}
+
+		a.HeaderName = strings.ReplaceAll(strings.ToLower(spec.Header.Name), "-", "_")
+		a.HeaderValues = "~^(?:" + strings.Join(values, "|") + ")$"
+
+		conditions = append(conditions, "$"+a.HeaderVariable)
+	}
+
+	a.Conditions = strings.Join(conditions, "")
+	a.Allowed = strings.Repeat("1", len(conditions))
+
+	return a
+}
+
+// windowRegexp returns the regular expression that matches the $date_gmt values in the window.
+func windowRegexp(w ngfAPI.AccessWindow) string {
+	days := `[A-Za-z]+`
+	if len(w.Days) > 0 {
+		names := make([]string, 0, len(w.Days))
+		for _, d := range w.Days {
+			names = append(names, string(d))
+		}
+
+		days = "(?:" + strings.Join(names, "|") + ")"
+	}
+
+	return `~^` + days + `, \S+ ` + timeRangeRegexp(minuteOfDay(w.Start), minuteOfDay(w.End)-1)
+}
+
+// minuteOfDay returns the minute of the day of the HH:MM time.
+func minuteOfDay(t ngfAPI.AccessTime) int {
+	var hours, minutes int
+	// the time is validated by the validator, so the error can't happen.
+	_, _ = fmt.Sscanf(string(t), "%d:%d", &hours, &minutes)
+
+	return hours*60 + minutes
+}
+
+// timeRangeRegexp returns the regular expression that matches the HH:MM times from the first to the last minute
+// of the day, inclusive.
+func timeRangeRegexp(first, last int) string {
+	firstHour, firstMinute := first/60, first%60
+	lastHour, lastMinute := last/60, last%60
+
+	if firstHour == lastHour {
+		return fmt.Sprintf("(?:%02d:(?:%s))", firstHour, twoDigitRangeRegexp(firstMinute, lastMinute))
+	}
+
+	var parts []string
+
+	fullHoursStart, fullHoursEnd := firstHour, lastHour
+	if firstMinute != 0 {
+		parts = append(parts, fmt.Sprintf("%02d:(?:%s)", firstHour, twoDigitRangeRegexp(firstMinute, 59)))
+		fullHoursStart++
+	}
+
+	var lastPart string
+	if lastMinute != 59 {
+		lastPart = fmt.Sprintf("%02d:(?:%s)", lastHour, twoDigitRangeRegexp(0, lastMinute))
+		fullHoursEnd--
+	}
+
+	if fullHoursStart <= fullHoursEnd {
+		parts = append(parts, fmt.Sprintf("(?:%s):", twoDigitRangeRegexp(fullHoursStart, fullHoursEnd)))
+	}
+
+	if lastPart != "" {
+		parts = append(parts, lastPart)
+	}
+
+	return "(?:" + strings.Join(parts, "|") + ")"
+}
+
+// twoDigitRangeRegexp returns the regular expression that matches the two-digit numbers from first to last,
+// inclusive, like 05 or 42.
+func twoDigitRangeRegexp(first, last int) string {
+	digits := func(a, b int) string {
+		if a == b {
+			return fmt.Sprint(a)
+		}
+
+		return fmt.Sprintf("[%d-%d]", a, b)
+	}
+
+	if first/10 == last/10 {
+		return fmt.Sprint(first/10) + digits(first%10, last%10)
+	}
+
+	var parts []string
+
+	fullTensStart, fullTensEnd := first/10, last/10
+	if first%10 != 0 {
+		parts = append(parts, fmt.Sprint(first/10)+digits(first%10, 9))
+		fullTensStart++
+	}
+
+	var lastPart string
+	if last%10 != 9 {
+		lastPart = fmt.Sprint(last/10) + digits(0, last%10)
+		fullTensEnd--
+	}
+
+	if fullTensStart <= fullTensEnd {
+		parts = append(parts, digits(fullTensStart, fullTensEnd)+"[0-9]")
+	}
+
+	if lastPart != "" {
+		parts = append(parts, lastPart)
+	}
+
+	return strings.Join(parts, "|")
+}
+
+func createTarpit(csp *ngfAPI.ClientSettingsPolicy) tarpit {
+	spec := csp.Spec.Tarpit
+
+	delay := DefaultTarpitDelay
+	if spec.Delay != nil {
+		// the delay is validated by the validator, so the error can't happen.
+		if d, err := parseDuration(*spec.Delay); err == nil {
+			delay = d
+		}
+	}
+
+	statusCode := int32(DefaultTarpitStatusCode)
+	if spec.StatusCode != nil {
+		statusCode = *spec.StatusCode
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for _, path := range spec.Paths {
+		paths = append(paths, regexp.QuoteMeta(string(path)))
+	}
+
+	return tarpit{
+		Paths:             strings.Join(paths, "|"),
+		DelayMilliseconds: delay.Milliseconds(),
+		StatusCode:        statusCode,
+	}
+}
+
+// parseDuration parses an NGINX duration, like 30s or 500ms. A duration without a unit is in seconds.
+func parseDuration(d ngfAPI.Duration) (time.Duration, error) {
+	s := string(d)
+	if s != "" && s[len(s)-1] >= '0' && s[len(s)-1] <= '9' {
+		s += "s"
+	}
+
+	return time.ParseDuration(s)
+}
+
+// escapeQuotedString escapes the string to be used in a double-quoted NGINX parameter.
+func escapeQuotedString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -1,9 +1,11 @@
 package clientsettings_test
 
 import (
+	"regexp"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
 	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
@@ -53,6 +55,20 @@ func TestGenerate(t *testing.T) {
 				"client_body_timeout 600ms",
 			},
 		},
+		{
+			name: "precompressed enabled",
+			policy: &ngfAPIv1alpha1.ClientSettingsPolicy{
+				Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+					Compression: &ngfAPIv1alpha1.ClientCompression{
+						Precompressed: true,
+					},
+				},
+			},
+			expStrings: []string{
+				"gunzip on;",
+				"gzip_vary on;",
+			},
+		},
 		{
 			name: "keepalive requests populated",
 			policy: &ngfAPIv1alpha1.ClientSettingsPolicy{
@@ -131,6 +147,9 @@ func TestGenerate(t *testing.T) {
 						MaxSize: maxSize,
 						Timeout: bodyTimeout,
 					},
+					Compression: &ngfAPIv1alpha1.ClientCompression{
+						Precompressed: true,
+					},
 					KeepAlive: &ngfAPIv1alpha1.ClientKeepAlive{
 						Requests: keepaliveRequests,
 						Time:     keepaliveTime,
@@ -144,6 +163,8 @@ func TestGenerate(t *testing.T) {
 			expStrings: []string{
 				"client_max_body_size 10m;",
 				"client_body_timeout 600ms",
+				"gunzip on;",
+				"gzip_vary on;",
 				"keepalive_requests 900;",
 				"keepalive_time 50s;",
 				"keepalive_timeout 30s 60s;",
@@ -178,13 +199,718 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestGenerateErrorResponses(t *testing.T) {
+	t.Parallel()
+
+	policy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "csp",
+		},
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			Body: &ngfAPIv1alpha1.ClientBody{
+				MaxSize: helpers.GetPointer[ngfAPIv1alpha1.Size]("1m"),
+			},
+			ErrorResponses: &ngfAPIv1alpha1.ClientErrorResponses{},
+		},
+	}
+
+	customized := policy.DeepCopy()
+	customized.Spec.ErrorResponses = &ngfAPIv1alpha1.ClientErrorResponses{
+		ContentType: helpers.GetPointer("application/problem+json"),
+		Template:    helpers.GetPointer(`{"status":$status,"title":"$reason","detail":"C:\\temp","traceId":"$request_id"}`),
+	}
+
+	tests := []struct {
+		policy     policies.Policy
+		name       string
+		expStrings []string
+	}{
+		{
+			name:   "default error responses",
+			policy: policy,
+			expStrings: []string{
+				"client_max_body_size 1m;",
+				"error_page 413 @client_settings_test_csp_413;",
+				"error_page 494 @client_settings_test_csp_431;",
+				`location @client_settings_test_csp_413 {
+    default_type "application/json";
+    add_header X-Request-ID $request_id always;
+    return 413 "{\"status\":413,\"error\":\"Content Too Large\",\"requestId\":\"$request_id\"}";
+}`,
+				`location @client_settings_test_csp_431 {
+    default_type "application/json";
+    add_header X-Request-ID $request_id always;
+    return 431 "{\"status\":431,\"error\":\"Request Header Fields Too Large\",\"requestId\":\"$request_id\"}";
+}`,
+			},
+		},
+		{
+			name:   "customized error responses",
+			policy: customized,
+			expStrings: []string{
+				`default_type "application/problem+json";`,
+				`return 413 "{\"status\":413,\"title\":\"Content Too Large\",\"detail\":\"C:\\\\temp\",` +
+					`\"traceId\":\"$request_id\"}";`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			generator := clientsettings.NewGenerator()
+
+			resFiles := generator.GenerateForServer([]policies.Policy{test.policy}, http.Server{})
+			g.Expect(resFiles).To(HaveLen(1))
+			for _, str := range test.expStrings {
+				g.Expect(string(resFiles[0].Content)).To(ContainSubstring(str))
+			}
+
+			// named locations can't be defined in locations
+			resFiles = generator.GenerateForLocation([]policies.Policy{test.policy}, http.Location{})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(string(resFiles[0].Content)).ToNot(ContainSubstring("error_page"))
+
+			resFiles = generator.GenerateForInternalLocation([]policies.Policy{test.policy})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(string(resFiles[0].Content)).ToNot(ContainSubstring("error_page"))
+		})
+	}
+}
+
+func TestGenerateRequestLimits(t *testing.T) {
+	t.Parallel()
+
+	policy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "csp",
+		},
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			Body: &ngfAPIv1alpha1.ClientBody{
+				MaxSize: helpers.GetPointer[ngfAPIv1alpha1.Size]("1m"),
+			},
+			RequestLimits: &ngfAPIv1alpha1.ClientRequestLimits{
+				MaxConcurrentRequests: helpers.GetPointer[int32](10),
+			},
+		},
+	}
+
+	perClient := policy.DeepCopy()
+	perClient.Spec.RequestLimits = &ngfAPIv1alpha1.ClientRequestLimits{
+		Rate:       helpers.GetPointer[ngfAPIv1alpha1.Rate]("5r/m"),
+		Burst:      helpers.GetPointer[int32](3),
+		RejectCode: helpers.GetPointer[int32](503),
+		PerClient:  true,
+	}
+
+	rejectResponse := policy.DeepCopy()
+	rejectResponse.Spec.RequestLimits.RejectResponse = &ngfAPIv1alpha1.ClientRejectResponse{
+		RetryAfter:  helpers.GetPointer[ngfAPIv1alpha1.Duration]("1500ms"),
+		ContentType: helpers.GetPointer("application/json"),
+		Body:        helpers.GetPointer(`{"error":"too many requests"}`),
+	}
+
+	retryAfter := perClient.DeepCopy()
+	retryAfter.Spec.RequestLimits.RejectResponse = &ngfAPIv1alpha1.ClientRejectResponse{
+		RetryAfter: helpers.GetPointer[ngfAPIv1alpha1.Duration]("1m"),
+	}
+
+	tests := []struct {
+		policy    policies.Policy
+		name      string
+		expZones  string
+		expLimits string
+		expReject string
+	}{
+		{
+			name:      "concurrent requests limit",
+			policy:    policy,
+			expZones:  "\nlimit_conn_zone all zone=csp_conn_test_csp:1m;\n",
+			expLimits: "\nlimit_conn csp_conn_test_csp 10;\nlimit_conn_status 429;\n",
+		},
+		{
+			name:      "per client rate limit",
+			policy:    perClient,
+			expZones:  "\nlimit_req_zone $binary_remote_addr zone=csp_req_test_csp:10m rate=5r/m;\n",
+			expLimits: "\nlimit_req zone=csp_req_test_csp burst=3;\nlimit_req_status 503;\n",
+		},
+		{
+			name:     "reject response",
+			policy:   rejectResponse,
+			expZones: "\nlimit_conn_zone all zone=csp_conn_test_csp:1m;\n",
+			expLimits: "\nlimit_conn csp_conn_test_csp 10;\nlimit_conn_status 429;\n" +
+				"error_page 429 @client_settings_test_csp_rejected;\n",
+			expReject: `
+
+location @client_settings_test_csp_rejected {
+    add_header Retry-After 2 always;
+    default_type "application/json";
+    return 429 "{\"error\":\"too many requests\"}";
+}
+`,
+		},
+		{
+			name:     "reject response without body",
+			policy:   retryAfter,
+			expZones: "\nlimit_req_zone $binary_remote_addr zone=csp_req_test_csp:10m rate=5r/m;\n",
+			expLimits: "\nlimit_req zone=csp_req_test_csp burst=3;\nlimit_req_status 503;\n" +
+				"error_page 503 @client_settings_test_csp_rejected;\n",
+			expReject: `
+
+location @client_settings_test_csp_rejected {
+    add_header Retry-After 60 always;
+    return 503;
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			generator := clientsettings.NewGenerator()
+
+			resFiles := generator.GenerateForHTTP([]policies.Policy{test.policy})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(resFiles[0].Name).To(Equal("ClientSettingsPolicy_test_csp_zones.conf"))
+			g.Expect(string(resFiles[0].Content)).To(Equal(test.expZones))
+
+			resFiles = generator.GenerateForLocation([]policies.Policy{test.policy}, http.Location{})
+			g.Expect(resFiles).To(HaveLen(2))
+			g.Expect(resFiles[0].Name).To(Equal("ClientSettingsPolicy_test_csp.conf"))
+			g.Expect(string(resFiles[0].Content)).To(ContainSubstring("client_max_body_size 1m;"))
+			g.Expect(resFiles[1].Name).To(Equal("ClientSettingsPolicy_test_csp_limits.conf"))
+			g.Expect(string(resFiles[1].Content)).To(Equal(test.expLimits))
+
+			// the requests redirected to the internal locations are not counted twice
+			resFiles = generator.GenerateForInternalLocation([]policies.Policy{test.policy})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(string(resFiles[0].Content)).ToNot(ContainSubstring("limit_"))
+
+			resFiles = generator.GenerateForServer([]policies.Policy{test.policy}, http.Server{})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(string(resFiles[0].Content)).ToNot(ContainSubstring("limit_"))
+
+			resFiles = generator.GenerateForServerRoutes([]policies.Policy{test.policy}, http.Server{})
+			if test.expReject == "" {
+				g.Expect(resFiles).To(BeEmpty())
+				return
+			}
+
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(resFiles[0].Name).To(Equal("ClientSettingsPolicy_test_csp_reject.conf"))
+			g.Expect(string(resFiles[0].Content)).To(Equal(test.expReject))
+		})
+	}
+}
+
+func TestGenerateTarpit(t *testing.T) {
+	t.Parallel()
+
+	policy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "csp",
+		},
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			Body: &ngfAPIv1alpha1.ClientBody{
+				MaxSize: helpers.GetPointer[ngfAPIv1alpha1.Size]("1m"),
+			},
+			Tarpit: &ngfAPIv1alpha1.ClientTarpit{
+				Paths: []ngfAPIv1alpha1.TarpitPath{"/wp-admin"},
+			},
+		},
+	}
+
+	customized := policy.DeepCopy()
+	customized.Spec.Tarpit = &ngfAPIv1alpha1.ClientTarpit{
+		Paths:      []ngfAPIv1alpha1.TarpitPath{"/wp-admin", "/.env", "/phpmyadmin"},
+		Delay:      helpers.GetPointer[ngfAPIv1alpha1.Duration]("2m"),
+		StatusCode: helpers.GetPointer[int32](404),
+	}
+
+	unitless := policy.DeepCopy()
+	unitless.Spec.Tarpit.Delay = helpers.GetPointer[ngfAPIv1alpha1.Duration]("5")
+
+	tests := []struct {
+		policy    policies.Policy
+		name      string
+		expTarpit string
+	}{
+		{
+			name:   "default tarpit",
+			policy: policy,
+			expTarpit: `
+location ~ "^(?:/wp-admin)(?:/|$)" {
+    set $ngf_tarpit_delay 30000;
+    set $ngf_tarpit_status 403;
+    js_content tarpit.delay;
+}
+`,
+		},
+		{
+			name:   "customized tarpit",
+			policy: customized,
+			expTarpit: `
+location ~ "^(?:/wp-admin|/\.env|/phpmyadmin)(?:/|$)" {
+    set $ngf_tarpit_delay 120000;
+    set $ngf_tarpit_status 404;
+    js_content tarpit.delay;
+}
+`,
+		},
+		{
+			name:   "tarpit delay without a unit",
+			policy: unitless,
+			expTarpit: `
+    set $ngf_tarpit_delay 5000;
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			generator := clientsettings.NewGenerator()
+
+			resFiles := generator.GenerateForServer([]policies.Policy{test.policy}, http.Server{})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(string(resFiles[0].Content)).To(ContainSubstring("client_max_body_size 1m;"))
+			g.Expect(string(resFiles[0].Content)).To(ContainSubstring(test.expTarpit))
+
+			// locations can't be defined in the internal locations
+			resFiles = generator.GenerateForLocation([]policies.Policy{test.policy}, http.Location{})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(string(resFiles[0].Content)).ToNot(ContainSubstring("tarpit"))
+
+			resFiles = generator.GenerateForInternalLocation([]policies.Policy{test.policy})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(string(resFiles[0].Content)).ToNot(ContainSubstring("tarpit"))
+		})
+	}
+}
+
+func TestGenerateAccess(t *testing.T) {
+	t.Parallel()
+
+	policy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "internal-tools",
+		},
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			Access: &ngfAPIv1alpha1.ClientAccess{
+				Windows: []ngfAPIv1alpha1.AccessWindow{
+					{
+						Days:  []ngfAPIv1alpha1.Weekday{ngfAPIv1alpha1.Monday, ngfAPIv1alpha1.Friday},
+						Start: "09:30",
+						End:   "17:15",
+					},
+				},
+				CIDRs: []string{"10.0.0.0/8", "2001:db8::/32"},
+				Header: &ngfAPIv1alpha1.AccessHeader{
+					Name:   "X-Team",
+					Values: []ngfAPIv1alpha1.AccessHeaderValue{"ops", "on.call"},
+				},
+			},
+		},
+	}
+
+	cidrOnly := policy.DeepCopy()
+	cidrOnly.Spec.Access = &ngfAPIv1alpha1.ClientAccess{
+		CIDRs:    []string{"192.168.0.0/16"},
+		DenyCode: helpers.GetPointer[int32](404),
+	}
+
+	tests := []struct {
+		policy    policies.Policy
+		name      string
+		expMaps   string
+		expAccess string
+	}{
+		{
+			name:   "all conditions",
+			policy: policy,
+			expMaps: `
+map $date_gmt $csp_access_time_test_internal_tools {
+    default 0;
+    "~^(?:Monday|Friday), \S+ (?:09:(?:[3-5][0-9])|(?:1[0-6]):|17:(?:0[0-9]|1[0-4]))" 1;
+}
+
+geo $csp_access_addr_test_internal_tools {
+    default 0;
+    10.0.0.0/8 1;
+    2001:db8::/32 1;
+}
+
+map $http_x_team $csp_access_header_test_internal_tools {
+    default 0;
+    "~^(?:ops|on\.call)$" 1;
+}
+
+map "$csp_access_time_test_internal_tools$csp_access_addr_test_internal_tools` +
+				`$csp_access_header_test_internal_tools" $csp_access_denied_test_internal_tools {
+    default 1;
+    "111" 0;
+}
+`,
+			expAccess: `
+if ($csp_access_denied_test_internal_tools) {
+    return 403;
+}
+`,
+		},
+		{
+			name:   "cidrs only",
+			policy: cidrOnly,
+			expMaps: `
+geo $csp_access_addr_test_internal_tools {
+    default 0;
+    192.168.0.0/16 1;
+}
+
+map "$csp_access_addr_test_internal_tools" $csp_access_denied_test_internal_tools {
+    default 1;
+    "1" 0;
+}
+`,
+			expAccess: `
+if ($csp_access_denied_test_internal_tools) {
+    return 404;
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			generator := clientsettings.NewGenerator()
+
+			resFiles := generator.GenerateForHTTP([]policies.Policy{test.policy})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(resFiles[0].Name).To(Equal("ClientSettingsPolicy_test_internal-tools_access_maps.conf"))
+			g.Expect(string(resFiles[0].Content)).To(Equal(test.expMaps))
+
+			resFiles = generator.GenerateForLocation([]policies.Policy{test.policy}, http.Location{})
+			g.Expect(resFiles).To(HaveLen(2))
+			g.Expect(resFiles[1].Name).To(Equal("ClientSettingsPolicy_test_internal-tools_access.conf"))
+			g.Expect(string(resFiles[1].Content)).To(Equal(test.expAccess))
+
+			// an external location can be shared by the Routes of several policies
+			resFiles = generator.GenerateForInternalLocation([]policies.Policy{test.policy})
+			g.Expect(resFiles).To(HaveLen(2))
+			g.Expect(resFiles[1].Name).To(Equal("ClientSettingsPolicy_test_internal-tools_access.conf"))
+			g.Expect(string(resFiles[1].Content)).To(Equal(test.expAccess))
+
+			resFiles = generator.GenerateForServer([]policies.Policy{test.policy}, http.Server{})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(string(resFiles[0].Content)).ToNot(ContainSubstring("csp_access"))
+		})
+	}
+}
+
+func TestGenerateAccessWindows(t *testing.T) {
+	t.Parallel()
+
+	// windowKeyRegexp matches the regular expressions of the time windows in the map of $date_gmt.
+	windowKeyRegexp := regexp.MustCompile(`"~(.+)" 1;`)
+
+	tests := []struct {
+		window   ngfAPIv1alpha1.AccessWindow
+		name     string
+		allowed  []string
+		rejected []string
+	}{
+		{
+			name: "business hours",
+			window: ngfAPIv1alpha1.AccessWindow{
+				Days: []ngfAPIv1alpha1.Weekday{
+					ngfAPIv1alpha1.Monday,
+					ngfAPIv1alpha1.Tuesday,
+					ngfAPIv1alpha1.Wednesday,
+					ngfAPIv1alpha1.Thursday,
+					ngfAPIv1alpha1.Friday,
+				},
+				Start: "08:45",
+				End:   "17:30",
+			},
+			allowed: []string{
+				"Monday, 19-October-2026 08:45:00 GMT",
+				"Wednesday, 21-October-2026 12:00:00 GMT",
+				"Friday, 23-October-2026 17:29:59 GMT",
+			},
+			rejected: []string{
+				"Monday, 19-October-2026 08:44:59 GMT",
+				"Friday, 23-October-2026 17:30:00 GMT",
+				"Saturday, 24-October-2026 12:00:00 GMT",
+			},
+		},
+		{
+			name: "whole day",
+			window: ngfAPIv1alpha1.AccessWindow{
+				Start: "00:00",
+				End:   "24:00",
+			},
+			allowed: []string{
+				"Sunday, 18-October-2026 00:00:00 GMT",
+				"Sunday, 18-October-2026 19:59:59 GMT",
+				"Sunday, 18-October-2026 23:59:59 GMT",
+			},
+		},
+		{
+			name: "minutes of one hour",
+			window: ngfAPIv1alpha1.AccessWindow{
+				Days:  []ngfAPIv1alpha1.Weekday{ngfAPIv1alpha1.Sunday},
+				Start: "22:05",
+				End:   "22:08",
+			},
+			allowed: []string{
+				"Sunday, 18-October-2026 22:05:00 GMT",
+				"Sunday, 18-October-2026 22:07:59 GMT",
+			},
+			rejected: []string{
+				"Sunday, 18-October-2026 22:04:59 GMT",
+				"Sunday, 18-October-2026 22:08:00 GMT",
+				"Monday, 19-October-2026 22:06:00 GMT",
+			},
+		},
+		{
+			name: "end of the day",
+			window: ngfAPIv1alpha1.AccessWindow{
+				Start: "19:10",
+				End:   "24:00",
+			},
+			allowed: []string{
+				"Tuesday, 20-October-2026 19:10:00 GMT",
+				"Tuesday, 20-October-2026 23:59:59 GMT",
+			},
+			rejected: []string{
+				"Tuesday, 20-October-2026 09:10:00 GMT",
+				"Tuesday, 20-October-2026 19:09:59 GMT",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			policy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "csp",
+				},
+				Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+					Access: &ngfAPIv1alpha1.ClientAccess{
+						Windows: []ngfAPIv1alpha1.AccessWindow{test.window},
+					},
+				},
+			}
+
+			resFiles := clientsettings.NewGenerator().GenerateForHTTP([]policies.Policy{policy})
+			g.Expect(resFiles).To(HaveLen(1))
+
+			match := windowKeyRegexp.FindStringSubmatch(string(resFiles[0].Content))
+			g.Expect(match).To(HaveLen(2))
+
+			re, err := regexp.Compile(match[1])
+			g.Expect(err).ToNot(HaveOccurred())
+
+			for _, date := range test.allowed {
+				g.Expect(re.MatchString(date)).To(BeTrue(), date)
+			}
+
+			for _, date := range test.rejected {
+				g.Expect(re.MatchString(date)).To(BeFalse(), date)
+			}
+		})
+	}
+}
+
+func TestGenerateAuthentication(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	policy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "internal-api",
+		},
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			Authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: ngfAPIv1alpha1.ClientAuthenticationModeTokenReview,
+				TokenReview: &ngfAPIv1alpha1.TokenReviewAuthentication{
+					ServiceAccounts: []ngfAPIv1alpha1.ServiceAccountName{"default/reporting"},
+				},
+			},
+		},
+	}
+
+	expAuthentication := `
+auth_request /_ngf-internal-token-review/test/internal-api;
+`
+
+	generator := clientsettings.NewGenerator()
+
+	resFiles := generator.GenerateForLocation([]policies.Policy{policy}, http.Location{})
+	g.Expect(resFiles).To(HaveLen(2))
+	g.Expect(resFiles[1].Name).To(Equal("ClientSettingsPolicy_test_internal-api_authentication.conf"))
+	g.Expect(string(resFiles[1].Content)).To(Equal(expAuthentication))
+
+	// the external location can be shared by the Routes of several policies, so the internal location also
+	// authenticates the requests
+	resFiles = generator.GenerateForInternalLocation([]policies.Policy{policy})
+	g.Expect(resFiles).To(HaveLen(2))
+	g.Expect(resFiles[1].Name).To(Equal("ClientSettingsPolicy_test_internal-api_authentication.conf"))
+	g.Expect(string(resFiles[1].Content)).To(Equal(expAuthentication))
+
+	resFiles = generator.GenerateForServer([]policies.Policy{policy}, http.Server{})
+	g.Expect(resFiles).To(HaveLen(1))
+	g.Expect(string(resFiles[0].Content)).ToNot(ContainSubstring("auth_request"))
+
+	resFiles = generator.GenerateForHTTP([]policies.Policy{policy})
+	g.Expect(resFiles).To(BeEmpty())
+
+	// the verifier of the control plane is rendered by the servers template
+	resFiles = generator.GenerateForServerRoutes([]policies.Policy{policy}, http.Server{})
+	g.Expect(resFiles).To(BeEmpty())
+}
+
+func TestGenerateRemoteAuthentication(t *testing.T) {
+	t.Parallel()
+
+	policy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "internal-api",
+		},
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			Authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: ngfAPIv1alpha1.ClientAuthenticationModeRemote,
+				Remote: &ngfAPIv1alpha1.RemoteAuthentication{
+					URL: "http://auth.auth-system.svc.cluster.local:8080/verify",
+				},
+			},
+		},
+	}
+
+	customized := policy.DeepCopy()
+	customized.Spec.Authentication.Remote = &ngfAPIv1alpha1.RemoteAuthentication{
+		URL:           "https://auth.example.com/verify?realm=internal",
+		Timeout:       helpers.GetPointer[ngfAPIv1alpha1.Duration]("2s"),
+		CacheDuration: helpers.GetPointer[ngfAPIv1alpha1.Duration]("1m"),
+	}
+
+	tests := []struct {
+		policy        policies.Policy
+		name          string
+		expLocation   string
+		expCachePaths string
+	}{
+		{
+			name:   "default remote authentication",
+			policy: policy,
+			expLocation: `
+
+location = /_ngf-internal-remote-auth/test/internal-api {
+    internal;
+    set $ngf_remote_auth_url "http://auth.auth-system.svc.cluster.local:8080/verify";
+    proxy_pass_request_body off;
+    proxy_set_header Content-Length "";
+    proxy_set_header X-Original-URI $request_uri;
+    proxy_set_header X-Original-Method $request_method;
+    proxy_connect_timeout 5s;
+    proxy_send_timeout 5s;
+    proxy_read_timeout 5s;
+    proxy_pass $ngf_remote_auth_url;
+}
+`,
+		},
+		{
+			name:   "https remote authentication with a cache",
+			policy: customized,
+			expLocation: `
+
+location = /_ngf-internal-remote-auth/test/internal-api {
+    internal;
+    set $ngf_remote_auth_url "https://auth.example.com/verify?realm=internal";
+    proxy_pass_request_body off;
+    proxy_set_header Content-Length "";
+    proxy_set_header X-Original-URI $request_uri;
+    proxy_set_header X-Original-Method $request_method;
+    proxy_connect_timeout 2s;
+    proxy_send_timeout 2s;
+    proxy_read_timeout 2s;
+    proxy_ssl_server_name on;
+    proxy_ssl_verify on;
+    proxy_ssl_trusted_certificate /etc/ssl/cert.pem;
+    proxy_cache csp_auth_test_internal-api;
+    proxy_cache_key $http_authorization;
+    proxy_cache_valid 200 204 1m;
+    proxy_ignore_headers Cache-Control Expires Set-Cookie;
+    proxy_pass $ngf_remote_auth_url;
+}
+`,
+			expCachePaths: "\nproxy_cache_path /var/cache/nginx/csp_auth_test_internal-api " +
+				"keys_zone=csp_auth_test_internal-api:1m max_size=10m inactive=1m;\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			generator := clientsettings.NewGenerator()
+
+			resFiles := generator.GenerateForLocation([]policies.Policy{test.policy}, http.Location{})
+			g.Expect(resFiles).To(HaveLen(2))
+			g.Expect(resFiles[1].Name).To(Equal("ClientSettingsPolicy_test_internal-api_authentication.conf"))
+			g.Expect(string(resFiles[1].Content)).To(Equal("\nauth_request /_ngf-internal-remote-auth/test/internal-api;\n"))
+
+			resFiles = generator.GenerateForServerRoutes([]policies.Policy{test.policy}, http.Server{})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(resFiles[0].Name).To(Equal("ClientSettingsPolicy_test_internal-api_remote_auth.conf"))
+			g.Expect(string(resFiles[0].Content)).To(Equal(test.expLocation))
+
+			resFiles = generator.GenerateForHTTP([]policies.Policy{test.policy})
+			if test.expCachePaths == "" {
+				g.Expect(resFiles).To(BeEmpty())
+				return
+			}
+
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(resFiles[0].Name).To(Equal("ClientSettingsPolicy_test_internal-api_remote_auth_cache.conf"))
+			g.Expect(string(resFiles[0].Content)).To(Equal(test.expCachePaths))
+		})
+	}
+}
+
 func TestGenerateNoPolicies(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
 
 	generator := clientsettings.NewGenerator()
 
-	resFiles := generator.GenerateForServer([]policies.Policy{}, http.Server{})
+	resFiles := generator.GenerateForHTTP([]policies.Policy{})
+	g.Expect(resFiles).To(BeEmpty())
+
+	resFiles = generator.GenerateForHTTP([]policies.Policy{&ngfAPIv1alpha2.ObservabilityPolicy{}})
+	g.Expect(resFiles).To(BeEmpty())
+
+	resFiles = generator.GenerateForServer([]policies.Policy{}, http.Server{})
 	g.Expect(resFiles).To(BeEmpty())
 
 	resFiles = generator.GenerateForServer([]policies.Policy{&ngfAPIv1alpha2.ObservabilityPolicy{}}, http.Server{})
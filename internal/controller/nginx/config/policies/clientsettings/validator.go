@@ -1,6 +1,13 @@
 package clientsettings
 
 import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -12,6 +19,56 @@ import (
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
 )
 
+var (
+	// accessTimeRegexp matches a time of the day, like 09:00 or 24:00.
+	accessTimeRegexp = regexp.MustCompile(`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`)
+	// accessHeaderNameRegexp matches the name of a request header, like X-Team.
+	accessHeaderNameRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+	// accessHeaderValueRegexp matches the printable ASCII characters, except '"' and '\', so that the value
+	// can be used in a double-quoted NGINX parameter.
+	accessHeaderValueRegexp = regexp.MustCompile(`^[ -!#-\[\]-~]+$`)
+	// contentTypeRegexp matches a media type with optional parameters, like application/json; charset=utf-8.
+	contentTypeRegexp = regexp.MustCompile(
+		`^[A-Za-z0-9!#&^_.+-]+/[A-Za-z0-9!#&^_.+-]+(\s*;\s*[A-Za-z0-9!#&^_.+-]+=[A-Za-z0-9!#&^_.+-]+)*$`,
+	)
+	// serviceAccountNameRegexp matches the name of a ServiceAccount in the <namespace>/<name> format,
+	// like default/reporting.
+	serviceAccountNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?/[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+	// remoteURLRegexp matches an HTTP or HTTPS URL without the characters that could break out of a double-quoted
+	// NGINX parameter or start a variable.
+	remoteURLRegexp = regexp.MustCompile(`^https?://[^\s"'$;{}\\]+$`)
+	// rateRegexp matches a rate of requests, like 10r/s.
+	rateRegexp = regexp.MustCompile(`^\d{1,6}r/[sm]$`)
+	// tarpitPathRegexp matches a decoy path, like /wp-admin. The characters are restricted, because the paths
+	// are used in a regular expression location.
+	tarpitPathRegexp = regexp.MustCompile(`^/[A-Za-z0-9._~-][A-Za-z0-9._~/-]*$`)
+	// templateVariableRegexp matches the variables of an error response template and the variable-like strings
+	// that aren't allowed, like ${status}.
+	templateVariableRegexp = regexp.MustCompile(`\$(\{?\w*\}?)`)
+)
+
+// allowedTemplateVariables are the variables that can be used in the template of the error responses.
+var allowedTemplateVariables = map[string]struct{}{
+	"status":     {},
+	"reason":     {},
+	"request_id": {},
+}
+
+var authenticationModes = []ngfAPI.ClientAuthenticationMode{
+	ngfAPI.ClientAuthenticationModeTokenReview,
+	ngfAPI.ClientAuthenticationModeRemote,
+}
+
+var weekdays = []ngfAPI.Weekday{
+	ngfAPI.Monday,
+	ngfAPI.Tuesday,
+	ngfAPI.Wednesday,
+	ngfAPI.Thursday,
+	ngfAPI.Friday,
+	ngfAPI.Saturday,
+	ngfAPI.Sunday,
+}
+
 // Validator validates a ClientSettingsPolicy.
 // Implements policies.Validator interface.
 type Validator struct {
@@ -39,14 +96,62 @@ func (v *Validator) Validate(policy policies.Policy) []conditions.Condition {
 		return []conditions.Condition{conditions.NewPolicyInvalid(err.Error())}
 	}
 
+	if csp.Spec.ErrorResponses != nil && csp.Spec.TargetRef.Kind != kinds.Gateway {
+		path := field.NewPath("spec").Child("errorResponses")
+		err := field.Forbidden(path, "errorResponses can only be set when the policy targets a Gateway")
+
+		return []conditions.Condition{conditions.NewPolicyInvalid(err.Error())}
+	}
+
+	if csp.Spec.RequestLimits != nil && csp.Spec.TargetRef.Kind == kinds.Gateway {
+		path := field.NewPath("spec").Child("requestLimits")
+		err := field.Forbidden(path, "requestLimits can only be set when the policy targets an HTTPRoute or a GRPCRoute")
+
+		return []conditions.Condition{conditions.NewPolicyInvalid(err.Error())}
+	}
+
+	if csp.Spec.Access != nil && csp.Spec.TargetRef.Kind == kinds.Gateway {
+		path := field.NewPath("spec").Child("access")
+		err := field.Forbidden(path, "access can only be set when the policy targets an HTTPRoute or a GRPCRoute")
+
+		return []conditions.Condition{conditions.NewPolicyInvalid(err.Error())}
+	}
+
+	if csp.Spec.Authentication != nil && csp.Spec.TargetRef.Kind == kinds.Gateway {
+		path := field.NewPath("spec").Child("authentication")
+		err := field.Forbidden(path, "authentication can only be set when the policy targets an HTTPRoute or a GRPCRoute")
+
+		return []conditions.Condition{conditions.NewPolicyInvalid(err.Error())}
+	}
+
+	if csp.Spec.Tarpit != nil && csp.Spec.TargetRef.Kind != kinds.Gateway {
+		path := field.NewPath("spec").Child("tarpit")
+		err := field.Forbidden(path, "tarpit can only be set when the policy targets a Gateway")
+
+		return []conditions.Condition{conditions.NewPolicyInvalid(err.Error())}
+	}
+
 	return nil
 }
 
 // ValidateGlobalSettings validates a ClientSettingsPolicy with respect to the NginxProxy global settings.
+// The Remote authentication requires the DNS resolver of the NginxProxy to resolve the endpoint.
 func (v *Validator) ValidateGlobalSettings(
-	_ policies.Policy,
-	_ *policies.GlobalSettings,
+	policy policies.Policy,
+	globalSettings *policies.GlobalSettings,
 ) []conditions.Condition {
+	csp := helpers.MustCastObject[*ngfAPI.ClientSettingsPolicy](policy)
+
+	if csp.Spec.Authentication == nil || csp.Spec.Authentication.Mode != ngfAPI.ClientAuthenticationModeRemote {
+		return nil
+	}
+
+	if globalSettings == nil || !globalSettings.DNSResolverEnabled {
+		return []conditions.Condition{
+			conditions.NewPolicyNotAcceptedNginxProxyNotSet(conditions.PolicyMessageDNSResolverNotSet),
+		}
+	}
+
 	return nil
 }
 
@@ -69,6 +174,30 @@ func conflicts(a, b ngfAPI.ClientSettingsPolicySpec) bool {
 		}
 	}
 
+	if a.Compression != nil && b.Compression != nil {
+		return true
+	}
+
+	if a.ErrorResponses != nil && b.ErrorResponses != nil {
+		return true
+	}
+
+	if a.RequestLimits != nil && b.RequestLimits != nil {
+		return true
+	}
+
+	if a.Tarpit != nil && b.Tarpit != nil {
+		return true
+	}
+
+	if a.Access != nil && b.Access != nil {
+		return true
+	}
+
+	if a.Authentication != nil && b.Authentication != nil {
+		return true
+	}
+
 	if a.KeepAlive != nil && b.KeepAlive != nil {
 		if a.KeepAlive.Requests != nil && b.KeepAlive.Requests != nil {
 			return true
@@ -100,9 +229,322 @@ func (v *Validator) validateSettings(spec ngfAPI.ClientSettingsPolicySpec) error
 		allErrs = append(allErrs, v.validateClientKeepAlive(*spec.KeepAlive, fieldPath.Child("keepAlive"))...)
 	}
 
+	if spec.ErrorResponses != nil {
+		allErrs = append(allErrs, validateErrorResponses(*spec.ErrorResponses, fieldPath.Child("errorResponses"))...)
+	}
+
+	if spec.RequestLimits != nil {
+		allErrs = append(allErrs, v.validateRequestLimits(*spec.RequestLimits, fieldPath.Child("requestLimits"))...)
+	}
+
+	if spec.Tarpit != nil {
+		allErrs = append(allErrs, v.validateTarpit(*spec.Tarpit, fieldPath.Child("tarpit"))...)
+	}
+
+	if spec.Access != nil {
+		allErrs = append(allErrs, validateAccess(*spec.Access, fieldPath.Child("access"))...)
+	}
+
+	if spec.Authentication != nil {
+		allErrs = append(allErrs, v.validateAuthentication(*spec.Authentication, fieldPath.Child("authentication"))...)
+	}
+
 	return allErrs.ToAggregate()
 }
 
+func validateErrorResponses(responses ngfAPI.ClientErrorResponses, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if responses.ContentType != nil && !contentTypeRegexp.MatchString(*responses.ContentType) {
+		allErrs = append(allErrs, field.Invalid(
+			fieldPath.Child("contentType"),
+			*responses.ContentType,
+			"must be a media type, for example, application/json or application/problem+json; charset=utf-8",
+		))
+	}
+
+	if responses.Template != nil {
+		for _, match := range templateVariableRegexp.FindAllStringSubmatch(*responses.Template, -1) {
+			if _, ok := allowedTemplateVariables[match[1]]; !ok {
+				allErrs = append(allErrs, field.Invalid(
+					fieldPath.Child("template"),
+					*responses.Template,
+					fmt.Sprintf("variable %q is not allowed, only $status, $reason, and $request_id can be used", match[0]),
+				))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (v *Validator) validateRequestLimits(limits ngfAPI.ClientRequestLimits, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if limits.MaxConcurrentRequests == nil && limits.Rate == nil {
+		allErrs = append(allErrs, field.Required(fieldPath, "maxConcurrentRequests or rate must be specified"))
+	}
+
+	if limits.Rate != nil && !rateRegexp.MatchString(string(*limits.Rate)) {
+		allErrs = append(allErrs, field.Invalid(
+			fieldPath.Child("rate"),
+			*limits.Rate,
+			"must be a number of requests per second or per minute, for example, 10r/s or 30r/m",
+		))
+	}
+
+	if limits.Burst != nil && limits.Rate == nil {
+		allErrs = append(allErrs, field.Invalid(
+			fieldPath.Child("burst"),
+			*limits.Burst,
+			"burst can only be specified if rate is specified",
+		))
+	}
+
+	if limits.RejectResponse != nil {
+		allErrs = append(allErrs, v.validateRejectResponse(*limits.RejectResponse, fieldPath.Child("rejectResponse"))...)
+	}
+
+	return allErrs
+}
+
+func (v *Validator) validateRejectResponse(response ngfAPI.ClientRejectResponse, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if response.RetryAfter == nil && response.Body == nil {
+		allErrs = append(allErrs, field.Required(fieldPath, "retryAfter or body must be specified"))
+	}
+
+	if response.ContentType != nil && response.Body == nil {
+		allErrs = append(allErrs, field.Invalid(
+			fieldPath.Child("contentType"),
+			*response.ContentType,
+			"contentType can only be specified if body is specified",
+		))
+	}
+
+	if response.RetryAfter != nil {
+		if err := v.genericValidator.ValidateNginxDuration(string(*response.RetryAfter)); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("retryAfter"), *response.RetryAfter, err.Error()))
+		}
+	}
+
+	if response.ContentType != nil && !contentTypeRegexp.MatchString(*response.ContentType) {
+		allErrs = append(allErrs, field.Invalid(
+			fieldPath.Child("contentType"),
+			*response.ContentType,
+			"must be a media type, for example, text/plain or application/json; charset=utf-8",
+		))
+	}
+
+	// the body is returned in a double-quoted NGINX parameter, where '$' starts a variable and can't be escaped.
+	if response.Body != nil && strings.Contains(*response.Body, "$") {
+		allErrs = append(allErrs, field.Invalid(
+			fieldPath.Child("body"),
+			*response.Body,
+			"must not contain the '$' character",
+		))
+	}
+
+	return allErrs
+}
+
+func validateAccess(access ngfAPI.ClientAccess, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(access.Windows) == 0 && len(access.CIDRs) == 0 && access.Header == nil {
+		allErrs = append(allErrs, field.Required(fieldPath, "windows, cidrs, or header must be specified"))
+	}
+
+	for i, w := range access.Windows {
+		allErrs = append(allErrs, validateAccessWindow(w, fieldPath.Child("windows").Index(i))...)
+	}
+
+	for i, cidr := range access.CIDRs {
+		allErrs = append(allErrs, k8svalidation.IsValidCIDR(fieldPath.Child("cidrs").Index(i), cidr)...)
+	}
+
+	if access.Header != nil {
+		headerPath := fieldPath.Child("header")
+
+		if !accessHeaderNameRegexp.MatchString(access.Header.Name) {
+			allErrs = append(allErrs, field.Invalid(
+				headerPath.Child("name"),
+				access.Header.Name,
+				"must contain only letters, digits, or '-'",
+			))
+		}
+
+		if len(access.Header.Values) == 0 {
+			allErrs = append(allErrs, field.Required(headerPath.Child("values"), "at least one value must be specified"))
+		}
+
+		for i, value := range access.Header.Values {
+			if !accessHeaderValueRegexp.MatchString(string(value)) {
+				allErrs = append(allErrs, field.Invalid(
+					headerPath.Child("values").Index(i),
+					value,
+					`must contain only printable ASCII characters, except '"' and '\'`,
+				))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (v *Validator) validateAuthentication(
+	authentication ngfAPI.ClientAuthentication,
+	fieldPath *field.Path,
+) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !slices.Contains(authenticationModes, authentication.Mode) {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("mode"), authentication.Mode, authenticationModes))
+	}
+
+	remoteMode := authentication.Mode == ngfAPI.ClientAuthenticationModeRemote
+
+	if remoteMode && authentication.Remote == nil {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("remote"), "remote must be specified if mode is Remote"))
+	}
+
+	if !remoteMode && authentication.Remote != nil {
+		allErrs = append(allErrs, field.Forbidden(
+			fieldPath.Child("remote"),
+			"remote can only be specified if mode is Remote",
+		))
+	}
+
+	if authentication.TokenReview != nil && authentication.Mode != ngfAPI.ClientAuthenticationModeTokenReview {
+		allErrs = append(allErrs, field.Forbidden(
+			fieldPath.Child("tokenReview"),
+			"tokenReview can only be specified if mode is TokenReview",
+		))
+	}
+
+	if authentication.Remote != nil {
+		allErrs = append(allErrs, v.validateRemoteAuthentication(*authentication.Remote, fieldPath.Child("remote"))...)
+	}
+
+	if authentication.TokenReview == nil {
+		return allErrs
+	}
+
+	for i, sa := range authentication.TokenReview.ServiceAccounts {
+		if !serviceAccountNameRegexp.MatchString(string(sa)) {
+			allErrs = append(allErrs, field.Invalid(
+				fieldPath.Child("tokenReview", "serviceAccounts").Index(i),
+				sa,
+				"must be the name of a ServiceAccount in the <namespace>/<name> format, for example, default/reporting",
+			))
+		}
+	}
+
+	return allErrs
+}
+
+func (v *Validator) validateRemoteAuthentication(
+	remote ngfAPI.RemoteAuthentication,
+	fieldPath *field.Path,
+) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if u, err := url.Parse(remote.URL); err != nil || !remoteURLRegexp.MatchString(remote.URL) ||
+		u.Host == "" || u.User != nil || u.Fragment != "" {
+		allErrs = append(allErrs, field.Invalid(
+			fieldPath.Child("url"),
+			remote.URL,
+			"must be an HTTP or HTTPS URL with a host and without user information or a fragment, "+
+				"for example, https://auth.example.com/verify, and can't contain whitespace or the following "+
+				`characters: '"', "'", '$', ';', '{', '}', '\'`,
+		))
+	}
+
+	for _, d := range []struct {
+		value *ngfAPI.Duration
+		name  string
+	}{
+		{name: "timeout", value: remote.Timeout},
+		{name: "cacheDuration", value: remote.CacheDuration},
+	} {
+		if d.value == nil {
+			continue
+		}
+
+		if err := v.genericValidator.ValidateNginxDuration(string(*d.value)); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child(d.name), *d.value, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAccessWindow(w ngfAPI.AccessWindow, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, day := range w.Days {
+		if !slices.Contains(weekdays, day) {
+			allErrs = append(allErrs, field.NotSupported(fieldPath.Child("days").Index(i), day, weekdays))
+		}
+	}
+
+	validTimes := true
+	for _, t := range []struct {
+		name  string
+		value ngfAPI.AccessTime
+	}{
+		{name: "start", value: w.Start},
+		{name: "end", value: w.End},
+	} {
+		if !accessTimeRegexp.MatchString(string(t.value)) {
+			allErrs = append(allErrs, field.Invalid(
+				fieldPath.Child(t.name),
+				t.value,
+				"must be a time of the day in the HH:MM format, for example, 09:00 or 24:00",
+			))
+			validTimes = false
+		}
+	}
+
+	if validTimes && w.End <= w.Start {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("end"), w.End, "end must be after start"))
+	}
+
+	return allErrs
+}
+
+func (v *Validator) validateTarpit(tarpit ngfAPI.ClientTarpit, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(tarpit.Paths) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("paths"), "at least one decoy path must be specified"))
+	}
+
+	for i, path := range tarpit.Paths {
+		if !tarpitPathRegexp.MatchString(string(path)) {
+			allErrs = append(allErrs, field.Invalid(
+				fieldPath.Child("paths").Index(i),
+				path,
+				"must start with a slash, can't be the root path, and can contain only letters, digits, "+
+					"and the following characters: '.', '_', '~', '/', '-'",
+			))
+		}
+	}
+
+	if tarpit.Delay != nil {
+		path := fieldPath.Child("delay")
+
+		if err := v.genericValidator.ValidateNginxDuration(string(*tarpit.Delay)); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, *tarpit.Delay, err.Error()))
+		} else if d, err := parseDuration(*tarpit.Delay); err != nil || d > MaxTarpitDelay {
+			allErrs = append(allErrs, field.Invalid(path, *tarpit.Delay, "must not be longer than 10m"))
+		}
+	}
+
+	return allErrs
+}
+
 func (v *Validator) validateClientBody(body ngfAPI.ClientBody, fieldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	if body.Timeout != nil {
@@ -8,6 +8,7 @@ import (
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/clientsettings"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/policiesfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/validation"
@@ -33,6 +34,9 @@ func createValidPolicy() *ngfAPI.ClientSettingsPolicy {
 				MaxSize: helpers.GetPointer[ngfAPI.Size]("10m"),
 				Timeout: helpers.GetPointer[ngfAPI.Duration]("600ms"),
 			},
+			Compression: &ngfAPI.ClientCompression{
+				Precompressed: true,
+			},
 			KeepAlive: &ngfAPI.ClientKeepAlive{
 				Requests: helpers.GetPointer[int32](900),
 				Time:     helpers.GetPointer[ngfAPI.Duration]("50s"),
@@ -41,6 +45,15 @@ func createValidPolicy() *ngfAPI.ClientSettingsPolicy {
 					Header: helpers.GetPointer[ngfAPI.Duration]("60s"),
 				},
 			},
+			ErrorResponses: &ngfAPI.ClientErrorResponses{
+				ContentType: helpers.GetPointer("application/problem+json; charset=utf-8"),
+				Template:    helpers.GetPointer(`{"status":$status,"title":"$reason","traceId":"$request_id"}`),
+			},
+			Tarpit: &ngfAPI.ClientTarpit{
+				Paths:      []ngfAPI.TarpitPath{"/wp-admin", "/.env"},
+				Delay:      helpers.GetPointer[ngfAPI.Duration]("1m"),
+				StatusCode: helpers.GetPointer[int32](404),
+			},
 		},
 		Status: v1.PolicyStatus{},
 	}
@@ -86,7 +99,7 @@ func TestValidator_Validate(t *testing.T) {
 				return p
 			}),
 			expConditions: []conditions.Condition{
-				conditions.NewPolicyInvalid("spec.body.maxSize: Invalid value: \"invalid\": ^\\d{1,4}(k|m|g)?$ " +
+				conditions.NewPolicyInvalid("spec.body.maxSize: Invalid value: \"invalid\": [NGF1504 InvalidNginxSize] ^\\d{1,4}(k|m|g)?$ " +
 					"(e.g. '1024',  or '8k',  or '20m',  or '1g', regex used for validation is 'must contain a number. " +
 					"May be followed by 'k', 'm', or 'g', otherwise bytes are assumed')"),
 			},
@@ -102,16 +115,16 @@ func TestValidator_Validate(t *testing.T) {
 			}),
 			expConditions: []conditions.Condition{
 				conditions.NewPolicyInvalid(
-					"[spec.body.timeout: Invalid value: \"invalid\": ^[0-9]{1,4}(ms|s|m|h)? " +
+					"[spec.body.timeout: Invalid value: \"invalid\": [NGF1503 InvalidNginxDuration] ^[0-9]{1,4}(ms|s|m|h)? " +
 						"(e.g. '5ms',  or '10s',  or '500m',  or '1000h', regex used for validation is " +
 						"'must contain an, at most, four digit number followed by 'ms', 's', 'm', or 'h''), " +
-						"spec.keepAlive.time: Invalid value: \"invalid\": ^[0-9]{1,4}(ms|s|m|h)? " +
+						"spec.keepAlive.time: Invalid value: \"invalid\": [NGF1503 InvalidNginxDuration] ^[0-9]{1,4}(ms|s|m|h)? " +
 						"(e.g. '5ms',  or '10s',  or '500m',  or '1000h', regex used for validation is " +
 						"'must contain an, at most, four digit number followed by 'ms', 's', 'm', or 'h''), " +
-						"spec.keepAlive.timeout.server: Invalid value: \"invalid\": ^[0-9]{1,4}(ms|s|m|h)? " +
+						"spec.keepAlive.timeout.server: Invalid value: \"invalid\": [NGF1503 InvalidNginxDuration] ^[0-9]{1,4}(ms|s|m|h)? " +
 						"(e.g. '5ms',  or '10s',  or '500m',  or '1000h', regex used for validation is " +
 						"'must contain an, at most, four digit number followed by 'ms', 's', 'm', or 'h''), " +
-						"spec.keepAlive.timeout.header: Invalid value: \"invalid\": ^[0-9]{1,4}(ms|s|m|h)? " +
+						"spec.keepAlive.timeout.header: Invalid value: \"invalid\": [NGF1503 InvalidNginxDuration] ^[0-9]{1,4}(ms|s|m|h)? " +
 						"(e.g. '5ms',  or '10s',  or '500m',  or '1000h', regex used for validation is " +
 						"'must contain an, at most, four digit number followed by 'ms', 's', 'm', or 'h'')]"),
 			},
@@ -127,6 +140,418 @@ func TestValidator_Validate(t *testing.T) {
 					"server timeout must be set if header timeout is set"),
 			},
 		},
+		{
+			name: "invalid error responses content type",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.ErrorResponses.ContentType = helpers.GetPointer(`application/json"; return 200`)
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid(`spec.errorResponses.contentType: Invalid value: ` +
+					`"application/json\"; return 200": must be a media type, for example, application/json or ` +
+					`application/problem+json; charset=utf-8`),
+			},
+		},
+		{
+			name: "invalid error responses template variables",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.ErrorResponses.Template = helpers.GetPointer(`{"host":"$host","status":${status}}`)
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid(`[spec.errorResponses.template: Invalid value: ` +
+					`"{\"host\":\"$host\",\"status\":${status}}": variable "$host" is not allowed, ` +
+					`only $status, $reason, and $request_id can be used, spec.errorResponses.template: ` +
+					`Invalid value: "{\"host\":\"$host\",\"status\":${status}}": variable "${status}" ` +
+					`is not allowed, only $status, $reason, and $request_id can be used]`),
+			},
+		},
+		{
+			name: "error responses with a route target",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.errorResponses: Forbidden: " +
+					"errorResponses can only be set when the policy targets a Gateway"),
+			},
+		},
+		{
+			name: "invalid request limits",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.RequestLimits = &ngfAPI.ClientRequestLimits{
+					Rate: helpers.GetPointer[ngfAPI.Rate]("10r/s; return 200"),
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid(`spec.requestLimits.rate: Invalid value: "10r/s; return 200": ` +
+					`must be a number of requests per second or per minute, for example, 10r/s or 30r/m`),
+			},
+		},
+		{
+			name: "request limits without limits",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.RequestLimits = &ngfAPI.ClientRequestLimits{
+					Burst: helpers.GetPointer[int32](5),
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("[spec.requestLimits: Required value: " +
+					"maxConcurrentRequests or rate must be specified, spec.requestLimits.burst: Invalid value: 5: " +
+					"burst can only be specified if rate is specified]"),
+			},
+		},
+		{
+			name: "request limits with a gateway target",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.RequestLimits = &ngfAPI.ClientRequestLimits{
+					MaxConcurrentRequests: helpers.GetPointer[int32](10),
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.requestLimits: Forbidden: " +
+					"requestLimits can only be set when the policy targets an HTTPRoute or a GRPCRoute"),
+			},
+		},
+		{
+			name: "valid request limits",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.GRPCRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.RequestLimits = &ngfAPI.ClientRequestLimits{
+					MaxConcurrentRequests: helpers.GetPointer[int32](10),
+					Rate:                  helpers.GetPointer[ngfAPI.Rate]("30r/m"),
+					Burst:                 helpers.GetPointer[int32](5),
+					RejectCode:            helpers.GetPointer[int32](503),
+					RejectResponse: &ngfAPI.ClientRejectResponse{
+						RetryAfter:  helpers.GetPointer[ngfAPI.Duration]("1m"),
+						ContentType: helpers.GetPointer("application/json"),
+						Body:        helpers.GetPointer(`{"error":"too many requests"}`),
+					},
+					PerClient: true,
+				}
+				return p
+			}),
+			expConditions: nil,
+		},
+		{
+			name: "invalid reject response",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.RequestLimits = &ngfAPI.ClientRequestLimits{
+					Rate: helpers.GetPointer[ngfAPI.Rate]("10r/s"),
+					RejectResponse: &ngfAPI.ClientRejectResponse{
+						RetryAfter:  helpers.GetPointer[ngfAPI.Duration]("1x"),
+						ContentType: helpers.GetPointer(`text/plain"; return 200;`),
+						Body:        helpers.GetPointer("$request_uri is limited"),
+					},
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid(
+					"[spec.requestLimits.rejectResponse.retryAfter: Invalid value: \"1x\": [NGF1503 InvalidNginxDuration] " +
+						"^[0-9]{1,4}(ms|s|m|h)? (e.g. '5ms',  or '10s',  or '500m',  or '1000h', regex used for validation is " +
+						"'must contain an, at most, four digit number followed by 'ms', 's', 'm', or 'h''), " +
+						`spec.requestLimits.rejectResponse.contentType: Invalid value: "text/plain\"; return 200;": ` +
+						"must be a media type, for example, text/plain or application/json; charset=utf-8, " +
+						`spec.requestLimits.rejectResponse.body: Invalid value: "$request_uri is limited": ` +
+						"must not contain the '$' character]"),
+			},
+		},
+		{
+			name: "reject response without retry after or body",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.RequestLimits = &ngfAPI.ClientRequestLimits{
+					Rate: helpers.GetPointer[ngfAPI.Rate]("10r/s"),
+					RejectResponse: &ngfAPI.ClientRejectResponse{
+						ContentType: helpers.GetPointer("application/json"),
+					},
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("[spec.requestLimits.rejectResponse: Required value: " +
+					"retryAfter or body must be specified, " +
+					`spec.requestLimits.rejectResponse.contentType: Invalid value: "application/json": ` +
+					"contentType can only be specified if body is specified]"),
+			},
+		},
+		{
+			name: "invalid tarpit",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.Tarpit = &ngfAPI.ClientTarpit{
+					Paths: []ngfAPI.TarpitPath{"/wp-admin", `/" { return 200; } location ~ "/`, "/"},
+					Delay: helpers.GetPointer[ngfAPI.Duration]("11m"),
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid(`[spec.tarpit.paths[1]: Invalid value: ` +
+					`"/\" { return 200; } location ~ \"/": must start with a slash, can't be the root path, ` +
+					`and can contain only letters, digits, and the following characters: '.', '_', '~', '/', '-', ` +
+					`spec.tarpit.paths[2]: Invalid value: "/": must start with a slash, can't be the root path, ` +
+					`and can contain only letters, digits, and the following characters: '.', '_', '~', '/', '-', ` +
+					`spec.tarpit.delay: Invalid value: "11m": must not be longer than 10m]`),
+			},
+		},
+		{
+			name: "tarpit without paths",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.Tarpit.Paths = nil
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.tarpit.paths: Required value: at least one decoy path must be specified"),
+			},
+		},
+		{
+			name: "tarpit with a route target",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.tarpit: Forbidden: " +
+					"tarpit can only be set when the policy targets a Gateway"),
+			},
+		},
+		{
+			name: "invalid access",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.Access = &ngfAPI.ClientAccess{
+					Windows: []ngfAPI.AccessWindow{
+						{Days: []ngfAPI.Weekday{"Funday"}, Start: "9:00", End: "17:00"},
+						{Start: "17:00", End: "09:00"},
+					},
+					CIDRs: []string{"10.0.0.0/8", "10.0.0.1; allow all"},
+					Header: &ngfAPI.AccessHeader{
+						Name:   "X-Team; return 200",
+						Values: []ngfAPI.AccessHeaderValue{"ops", `ops" 0; default`},
+					},
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid(`[spec.access.windows[0].days[0]: Unsupported value: "Funday": ` +
+					`supported values: "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday", ` +
+					`spec.access.windows[0].start: Invalid value: "9:00": must be a time of the day in the HH:MM format, ` +
+					`for example, 09:00 or 24:00, ` +
+					`spec.access.windows[1].end: Invalid value: "09:00": end must be after start, ` +
+					`spec.access.cidrs[1]: Invalid value: "10.0.0.1; allow all": must be a valid CIDR value, ` +
+					`(e.g. 10.9.8.0/24 or 2001:db8::/64), ` +
+					`spec.access.header.name: Invalid value: "X-Team; return 200": ` +
+					`must contain only letters, digits, or '-', ` +
+					`spec.access.header.values[1]: Invalid value: "ops\" 0; default": ` +
+					`must contain only printable ASCII characters, except '"' and '\']`),
+			},
+		},
+		{
+			name: "access without conditions",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.Access = &ngfAPI.ClientAccess{
+					DenyCode: helpers.GetPointer[int32](404),
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.access: Required value: windows, cidrs, or header must be specified"),
+			},
+		},
+		{
+			name: "access with a gateway target",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.Access = &ngfAPI.ClientAccess{
+					CIDRs: []string{"10.0.0.0/8"},
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.access: Forbidden: " +
+					"access can only be set when the policy targets an HTTPRoute or a GRPCRoute"),
+			},
+		},
+		{
+			name: "valid access",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.GRPCRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.Access = &ngfAPI.ClientAccess{
+					Windows: []ngfAPI.AccessWindow{
+						{Days: []ngfAPI.Weekday{ngfAPI.Monday, ngfAPI.Friday}, Start: "09:00", End: "17:30"},
+						{Start: "22:00", End: "24:00"},
+					},
+					CIDRs: []string{"10.0.0.0/8", "2001:db8::/32"},
+					Header: &ngfAPI.AccessHeader{
+						Name:   "X-Team",
+						Values: []ngfAPI.AccessHeaderValue{"ops", "on-call (EMEA)"},
+					},
+					DenyCode: helpers.GetPointer[int32](404),
+				}
+				return p
+			}),
+			expConditions: nil,
+		},
+		{
+			name: "invalid authentication",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.Authentication = &ngfAPI.ClientAuthentication{
+					Mode: "Basic",
+					TokenReview: &ngfAPI.TokenReviewAuthentication{
+						ServiceAccounts: []ngfAPI.ServiceAccountName{"default/reporting", "reporting"},
+					},
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid(`[spec.authentication.mode: Unsupported value: "Basic": ` +
+					`supported values: "TokenReview", "Remote", ` +
+					"spec.authentication.tokenReview: Forbidden: tokenReview can only be specified if mode is TokenReview, " +
+					`spec.authentication.tokenReview.serviceAccounts[1]: Invalid value: "reporting": ` +
+					`must be the name of a ServiceAccount in the <namespace>/<name> format, for example, default/reporting]`),
+			},
+		},
+		{
+			name: "authentication with a gateway target",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.Authentication = &ngfAPI.ClientAuthentication{
+					Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.authentication: Forbidden: " +
+					"authentication can only be set when the policy targets an HTTPRoute or a GRPCRoute"),
+			},
+		},
+		{
+			name: "valid authentication",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.Authentication = &ngfAPI.ClientAuthentication{
+					Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+					TokenReview: &ngfAPI.TokenReviewAuthentication{
+						Audiences:       []string{"internal-api"},
+						ServiceAccounts: []ngfAPI.ServiceAccountName{"default/reporting", "monitoring/prometheus"},
+					},
+				}
+				return p
+			}),
+			expConditions: nil,
+		},
+		{
+			name: "invalid remote authentication",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.Authentication = &ngfAPI.ClientAuthentication{
+					Mode: ngfAPI.ClientAuthenticationModeRemote,
+					TokenReview: &ngfAPI.TokenReviewAuthentication{
+						Audiences: []string{"internal-api"},
+					},
+					Remote: &ngfAPI.RemoteAuthentication{
+						URL:           `https://auth.example.com/verify"; return 200; #`,
+						Timeout:       helpers.GetPointer[ngfAPI.Duration]("5x"),
+						CacheDuration: helpers.GetPointer[ngfAPI.Duration]("1m"),
+					},
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("[spec.authentication.tokenReview: Forbidden: " +
+					"tokenReview can only be specified if mode is TokenReview, " +
+					`spec.authentication.remote.url: Invalid value: "https://auth.example.com/verify\"; return 200; #": ` +
+					"must be an HTTP or HTTPS URL with a host and without user information or a fragment, " +
+					"for example, https://auth.example.com/verify, and can't contain whitespace or the following " +
+					`characters: '"', "'", '$', ';', '{', '}', '\', ` +
+					`spec.authentication.remote.timeout: Invalid value: "5x": [NGF1503 InvalidNginxDuration] ` +
+					"^[0-9]{1,4}(ms|s|m|h)? (e.g. '5ms',  or '10s',  or '500m',  or '1000h', regex used for validation is " +
+					"'must contain an, at most, four digit number followed by 'ms', 's', 'm', or 'h'')]"),
+			},
+		},
+		{
+			name: "remote authentication without remote",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.Authentication = &ngfAPI.ClientAuthentication{
+					Mode: ngfAPI.ClientAuthenticationModeRemote,
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.authentication.remote: Required value: " +
+					"remote must be specified if mode is Remote"),
+			},
+		},
+		{
+			name: "remote with token review authentication",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.Authentication = &ngfAPI.ClientAuthentication{
+					Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+					Remote: &ngfAPI.RemoteAuthentication{
+						URL: "https://auth.example.com/verify",
+					},
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.authentication.remote: Forbidden: " +
+					"remote can only be specified if mode is Remote"),
+			},
+		},
+		{
+			name: "valid remote authentication",
+			policy: createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+				p.Spec.TargetRef.Kind = kinds.HTTPRoute
+				p.Spec.ErrorResponses = nil
+				p.Spec.Tarpit = nil
+				p.Spec.Authentication = &ngfAPI.ClientAuthentication{
+					Mode: ngfAPI.ClientAuthenticationModeRemote,
+					Remote: &ngfAPI.RemoteAuthentication{
+						URL:           "https://auth.example.com:8443/verify?realm=internal",
+						Timeout:       helpers.GetPointer[ngfAPI.Duration]("2s"),
+						CacheDuration: helpers.GetPointer[ngfAPI.Duration]("1m"),
+					},
+				}
+				return p
+			}),
+			expConditions: nil,
+		},
 		{
 			name:          "valid",
 			policy:        createValidPolicy(),
@@ -162,11 +587,61 @@ func TestValidator_ValidatePanics(t *testing.T) {
 
 func TestValidator_ValidateGlobalSettings(t *testing.T) {
 	t.Parallel()
-	g := NewWithT(t)
+
+	remote := createModifiedPolicy(func(p *ngfAPI.ClientSettingsPolicy) *ngfAPI.ClientSettingsPolicy {
+		p.Spec.Authentication = &ngfAPI.ClientAuthentication{
+			Mode: ngfAPI.ClientAuthenticationModeRemote,
+			Remote: &ngfAPI.RemoteAuthentication{
+				URL: "https://auth.example.com/verify",
+			},
+		}
+		return p
+	})
+
+	dnsResolverNotSet := []conditions.Condition{
+		conditions.NewPolicyNotAcceptedNginxProxyNotSet(conditions.PolicyMessageDNSResolverNotSet),
+	}
+
+	tests := []struct {
+		policy         policies.Policy
+		globalSettings *policies.GlobalSettings
+		name           string
+		expConditions  []conditions.Condition
+	}{
+		{
+			name:          "without remote authentication",
+			policy:        createValidPolicy(),
+			expConditions: nil,
+		},
+		{
+			name:           "remote authentication with a dns resolver",
+			policy:         remote,
+			globalSettings: &policies.GlobalSettings{DNSResolverEnabled: true},
+			expConditions:  nil,
+		},
+		{
+			name:           "remote authentication without a dns resolver",
+			policy:         remote,
+			globalSettings: &policies.GlobalSettings{TelemetryEnabled: true},
+			expConditions:  dnsResolverNotSet,
+		},
+		{
+			name:          "remote authentication without global settings",
+			policy:        remote,
+			expConditions: dnsResolverNotSet,
+		},
+	}
 
 	v := clientsettings.NewValidator(validation.GenericValidator{})
 
-	g.Expect(v.ValidateGlobalSettings(nil, nil)).To(BeNil())
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(v.ValidateGlobalSettings(test.policy, test.globalSettings)).To(Equal(test.expConditions))
+		})
+	}
 }
 
 func TestValidator_Conflicts(t *testing.T) {
@@ -267,6 +742,95 @@ func TestValidator_Conflicts(t *testing.T) {
 			},
 			conflicts: true,
 		},
+		{
+			name: "compression conflicts",
+			polA: createValidPolicy(),
+			polB: &ngfAPI.ClientSettingsPolicy{
+				Spec: ngfAPI.ClientSettingsPolicySpec{
+					Compression: &ngfAPI.ClientCompression{},
+				},
+			},
+			conflicts: true,
+		},
+		{
+			name: "error responses conflicts",
+			polA: createValidPolicy(),
+			polB: &ngfAPI.ClientSettingsPolicy{
+				Spec: ngfAPI.ClientSettingsPolicySpec{
+					ErrorResponses: &ngfAPI.ClientErrorResponses{},
+				},
+			},
+			conflicts: true,
+		},
+		{
+			name: "request limits conflicts",
+			polA: &ngfAPI.ClientSettingsPolicy{
+				Spec: ngfAPI.ClientSettingsPolicySpec{
+					RequestLimits: &ngfAPI.ClientRequestLimits{
+						MaxConcurrentRequests: helpers.GetPointer[int32](10),
+					},
+				},
+			},
+			polB: &ngfAPI.ClientSettingsPolicy{
+				Spec: ngfAPI.ClientSettingsPolicySpec{
+					RequestLimits: &ngfAPI.ClientRequestLimits{
+						Rate: helpers.GetPointer[ngfAPI.Rate]("10r/s"),
+					},
+				},
+			},
+			conflicts: true,
+		},
+		{
+			name: "access conflicts",
+			polA: &ngfAPI.ClientSettingsPolicy{
+				Spec: ngfAPI.ClientSettingsPolicySpec{
+					Access: &ngfAPI.ClientAccess{
+						CIDRs: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+			polB: &ngfAPI.ClientSettingsPolicy{
+				Spec: ngfAPI.ClientSettingsPolicySpec{
+					Access: &ngfAPI.ClientAccess{
+						Windows: []ngfAPI.AccessWindow{{Start: "09:00", End: "17:00"}},
+					},
+				},
+			},
+			conflicts: true,
+		},
+		{
+			name: "authentication conflicts",
+			polA: &ngfAPI.ClientSettingsPolicy{
+				Spec: ngfAPI.ClientSettingsPolicySpec{
+					Authentication: &ngfAPI.ClientAuthentication{
+						Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+					},
+				},
+			},
+			polB: &ngfAPI.ClientSettingsPolicy{
+				Spec: ngfAPI.ClientSettingsPolicySpec{
+					Authentication: &ngfAPI.ClientAuthentication{
+						Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+						TokenReview: &ngfAPI.TokenReviewAuthentication{
+							Audiences: []string{"internal-api"},
+						},
+					},
+				},
+			},
+			conflicts: true,
+		},
+		{
+			name: "tarpit conflicts",
+			polA: createValidPolicy(),
+			polB: &ngfAPI.ClientSettingsPolicy{
+				Spec: ngfAPI.ClientSettingsPolicySpec{
+					Tarpit: &ngfAPI.ClientTarpit{
+						Paths: []ngfAPI.TarpitPath{"/.git"},
+					},
+				},
+			},
+			conflicts: true,
+		},
 	}
 
 	v := clientsettings.NewValidator(nil)
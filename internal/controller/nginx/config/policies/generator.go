@@ -8,8 +8,13 @@ import (
 //
 //counterfeiter:generate . Generator
 type Generator interface {
+	// GenerateForHTTP generates policy configuration for the http context.
+	GenerateForHTTP(policies []Policy) GenerateResultFiles
 	// GenerateForServer generates policy configuration for the server block.
 	GenerateForServer(policies []Policy, server http.Server) GenerateResultFiles
+	// GenerateForServerRoutes generates the configuration of the policies of the Routes of a server that must be
+	// in the server block, like named locations.
+	GenerateForServerRoutes(policies []Policy, server http.Server) GenerateResultFiles
 	// GenerateForLocation generates policy configuration for a normal location block.
 	GenerateForLocation(policies []Policy, location http.Location) GenerateResultFiles
 	// GenerateForInternalLocation generates policy configuration for an internal location block.
@@ -35,6 +40,17 @@ func NewCompositeGenerator(generators ...Generator) *CompositeGenerator {
 	return &CompositeGenerator{generators: generators}
 }
 
+// GenerateForHTTP calls all policy generators for the http context.
+func (g *CompositeGenerator) GenerateForHTTP(policies []Policy) GenerateResultFiles {
+	var compositeResult GenerateResultFiles
+
+	for _, generator := range g.generators {
+		compositeResult = append(compositeResult, generator.GenerateForHTTP(policies)...)
+	}
+
+	return compositeResult
+}
+
 // GenerateForServer calls all policy generators for the server block.
 func (g *CompositeGenerator) GenerateForServer(policies []Policy, server http.Server) GenerateResultFiles {
 	var compositeResult GenerateResultFiles
@@ -46,6 +62,17 @@ func (g *CompositeGenerator) GenerateForServer(policies []Policy, server http.Se
 	return compositeResult
 }
 
+// GenerateForServerRoutes calls all policy generators for the policies of the Routes of a server.
+func (g *CompositeGenerator) GenerateForServerRoutes(policies []Policy, server http.Server) GenerateResultFiles {
+	var compositeResult GenerateResultFiles
+
+	for _, generator := range g.generators {
+		compositeResult = append(compositeResult, generator.GenerateForServerRoutes(policies, server)...)
+	}
+
+	return compositeResult
+}
+
 // GenerateForLocation calls all policy generators for a normal location block.
 func (g *CompositeGenerator) GenerateForLocation(policies []Policy, location http.Location) GenerateResultFiles {
 	var compositeResult GenerateResultFiles
@@ -72,10 +99,18 @@ func (g *CompositeGenerator) GenerateForInternalLocation(policies []Policy) Gene
 // possible generations, in order to satisfy the Generator interface.
 type UnimplementedGenerator struct{}
 
+func (u UnimplementedGenerator) GenerateForHTTP(_ []Policy) GenerateResultFiles {
+	return nil
+}
+
 func (u UnimplementedGenerator) GenerateForServer(_ []Policy, _ http.Server) GenerateResultFiles {
 	return nil
 }
 
+func (u UnimplementedGenerator) GenerateForServerRoutes(_ []Policy, _ http.Server) GenerateResultFiles {
+	return nil
+}
+
 func (u UnimplementedGenerator) GenerateForLocation(_ []Policy, _ http.Location) GenerateResultFiles {
 	return nil
 }
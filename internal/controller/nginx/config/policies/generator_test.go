@@ -14,9 +14,15 @@ var _ = Describe("Policy Generator", func() {
 		fakeGen1 := &policiesfakes.FakeGenerator{}
 		fakeGen2 := &policiesfakes.FakeGenerator{}
 
+		fakeGen1.GenerateForHTTPReturns(policies.GenerateResultFiles{
+			{Name: "gen1HTTP", Content: []byte("gen1HTTP-content")},
+		})
 		fakeGen1.GenerateForServerReturns(policies.GenerateResultFiles{
 			{Name: "gen1Server", Content: []byte("gen1Server-content")},
 		})
+		fakeGen1.GenerateForServerRoutesReturns(policies.GenerateResultFiles{
+			{Name: "gen1ServerRoutes", Content: []byte("gen1ServerRoutes-content")},
+		})
 		fakeGen1.GenerateForLocationReturns(policies.GenerateResultFiles{
 			{Name: "gen1Location", Content: []byte("gen1Location-content")},
 		})
@@ -24,9 +30,15 @@ var _ = Describe("Policy Generator", func() {
 			{Name: "gen1IntLocation", Content: []byte("gen1IntLocation-content")},
 		})
 
+		fakeGen2.GenerateForHTTPReturns(policies.GenerateResultFiles{
+			{Name: "gen2HTTP", Content: []byte("gen2HTTP-content")},
+		})
 		fakeGen2.GenerateForServerReturns(policies.GenerateResultFiles{
 			{Name: "gen2Server", Content: []byte("gen2Server-content")},
 		})
+		fakeGen2.GenerateForServerRoutesReturns(policies.GenerateResultFiles{
+			{Name: "gen2ServerRoutes", Content: []byte("gen2ServerRoutes-content")},
+		})
 		fakeGen2.GenerateForLocationReturns(policies.GenerateResultFiles{
 			{Name: "gen2Location", Content: []byte("gen2Location-content")},
 		})
@@ -36,6 +48,15 @@ var _ = Describe("Policy Generator", func() {
 
 		generator := policies.NewCompositeGenerator(fakeGen1, fakeGen2)
 
+		It("returns proper http content", func() {
+			expFiles := policies.GenerateResultFiles{
+				{Name: "gen1HTTP", Content: []byte("gen1HTTP-content")},
+				{Name: "gen2HTTP", Content: []byte("gen2HTTP-content")},
+			}
+
+			Expect(generator.GenerateForHTTP(nil)).To(BeEquivalentTo(expFiles))
+		})
+
 		It("returns proper server content", func() {
 			expFiles := policies.GenerateResultFiles{
 				{Name: "gen1Server", Content: []byte("gen1Server-content")},
@@ -45,6 +66,15 @@ var _ = Describe("Policy Generator", func() {
 			Expect(generator.GenerateForServer(nil, http.Server{})).To(BeEquivalentTo(expFiles))
 		})
 
+		It("returns proper server routes content", func() {
+			expFiles := policies.GenerateResultFiles{
+				{Name: "gen1ServerRoutes", Content: []byte("gen1ServerRoutes-content")},
+				{Name: "gen2ServerRoutes", Content: []byte("gen2ServerRoutes-content")},
+			}
+
+			Expect(generator.GenerateForServerRoutes(nil, http.Server{})).To(BeEquivalentTo(expFiles))
+		})
+
 		It("returns proper location content", func() {
 			expFiles := policies.GenerateResultFiles{
 				{Name: "gen1Location", Content: []byte("gen1Location-content")},
@@ -67,10 +97,18 @@ var _ = Describe("Policy Generator", func() {
 	Context("Unimplemented Generator", func() {
 		generator := policies.UnimplementedGenerator{}
 
+		It("returns nil for GenerateForHTTP", func() {
+			Expect(generator.GenerateForHTTP(nil)).To(BeNil())
+		})
+
 		It("returns nil for GenerateForServer", func() {
 			Expect(generator.GenerateForServer(nil, http.Server{})).To(BeNil())
 		})
 
+		It("returns nil for GenerateForServerRoutes", func() {
+			Expect(generator.GenerateForServerRoutes(nil, http.Server{})).To(BeNil())
+		})
+
 		It("returns nil for GenerateForLocation", func() {
 			Expect(generator.GenerateForLocation(nil, http.Location{})).To(BeNil())
 		})
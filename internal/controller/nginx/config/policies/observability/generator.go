@@ -72,6 +72,33 @@ func NewGenerator(telemetry dataplane.Telemetry) *Generator {
 	return &Generator{telemetryConf: telemetry}
 }
 
+// GenerateForServer generates policy configuration for the server block of a Gateway or its listener.
+// All directives are applied, so that every location of the server inherits them, unless the location
+// sets them from a policy that targets its Route.
+func (g Generator) GenerateForServer(pols []policies.Policy, _ http.Server) policies.GenerateResultFiles {
+	for _, pol := range pols {
+		obs, ok := pol.(*ngfAPIv1alpha2.ObservabilityPolicy)
+		if !ok {
+			continue
+		}
+
+		fields := map[string]interface{}{
+			"Tracing":              obs.Spec.Tracing,
+			"Strategy":             getStrategy(obs),
+			"GlobalSpanAttributes": g.telemetryConf.SpanAttributes,
+		}
+
+		return policies.GenerateResultFiles{
+			{
+				Name:    fmt.Sprintf("ObservabilityPolicy_%s_%s_server.conf", obs.Namespace, obs.Name),
+				Content: helpers.MustExecuteTemplate(tmpl, fields),
+			},
+		}
+	}
+
+	return nil
+}
+
 // GenerateForLocation generates policy configuration for a normal location block.
 // For a normal location, all directives are applied.
 // When the configuration involves a normal location redirecting to an internal location,
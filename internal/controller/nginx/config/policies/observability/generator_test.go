@@ -278,6 +278,15 @@ func TestGenerate(t *testing.T) {
 					g.Expect(content).To(ContainSubstring(str))
 				}
 			}
+
+			// The server of a Gateway or a listener gets the same directives as an external location.
+			resFiles := generator.GenerateForServer([]policies.Policy{test.policy}, http.Server{})
+			g.Expect(resFiles).To(HaveLen(1))
+			g.Expect(resFiles[0].Name).To(HaveSuffix("_server.conf"))
+
+			for _, str := range test.expExternalStrings {
+				g.Expect(string(resFiles[0].Content)).To(ContainSubstring(str))
+			}
 		})
 	}
 }
@@ -299,4 +308,10 @@ func TestGenerateNoPolicies(t *testing.T) {
 
 	resFiles = generator.GenerateForInternalLocation([]policies.Policy{&ngfAPIv1alpha1.ClientSettingsPolicy{}})
 	g.Expect(resFiles).To(BeEmpty())
+
+	resFiles = generator.GenerateForServer([]policies.Policy{}, http.Server{})
+	g.Expect(resFiles).To(BeEmpty())
+
+	resFiles = generator.GenerateForServer([]policies.Policy{&ngfAPIv1alpha1.ClientSettingsPolicy{}}, http.Server{})
+	g.Expect(resFiles).To(BeEmpty())
 }
@@ -28,11 +28,17 @@ func (v *Validator) Validate(policy policies.Policy) []conditions.Condition {
 	obs := helpers.MustCastObject[*ngfAPIv1alpha2.ObservabilityPolicy](policy)
 
 	targetRefPath := field.NewPath("spec").Child("targetRefs")
-	supportedKinds := []gatewayv1.Kind{kinds.HTTPRoute, kinds.GRPCRoute}
+	supportedKinds := []gatewayv1.Kind{kinds.Gateway, kinds.HTTPRoute, kinds.GRPCRoute}
 	supportedGroups := []gatewayv1.Group{gatewayv1.GroupName}
 
 	for _, ref := range obs.Spec.TargetRefs {
-		if err := policies.ValidateTargetRef(ref, targetRefPath, supportedGroups, supportedKinds); err != nil {
+		err := policies.ValidateTargetRef(ref.LocalPolicyTargetReference, targetRefPath, supportedGroups, supportedKinds)
+		if err != nil {
+			return []conditions.Condition{conditions.NewPolicyInvalid(err.Error())}
+		}
+
+		if ref.SectionName != nil && ref.Kind != kinds.Gateway {
+			err := field.Forbidden(targetRefPath.Child("sectionName"), "sectionName is only supported for Gateway")
 			return []conditions.Condition{conditions.NewPolicyInvalid(err.Error())}
 		}
 	}
@@ -26,11 +26,13 @@ func createValidPolicy() *ngfAPIv1alpha2.ObservabilityPolicy {
 			Namespace: "default",
 		},
 		Spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-			TargetRefs: []v1.LocalPolicyTargetReference{
+			TargetRefs: []v1.LocalPolicyTargetReferenceWithSectionName{
 				{
-					Group: v1.GroupName,
-					Kind:  kinds.HTTPRoute,
-					Name:  "route",
+					LocalPolicyTargetReference: v1.LocalPolicyTargetReference{
+						Group: v1.GroupName,
+						Kind:  kinds.HTTPRoute,
+						Name:  "route",
+					},
 				},
 			},
 			Tracing: &ngfAPIv1alpha2.Tracing{
@@ -77,9 +79,28 @@ func TestValidator_Validate(t *testing.T) {
 			}),
 			expConditions: []conditions.Condition{
 				conditions.NewPolicyInvalid("spec.targetRefs.kind: Unsupported value: \"Unsupported\": " +
-					"supported values: \"HTTPRoute\", \"GRPCRoute\""),
+					"supported values: \"Gateway\", \"HTTPRoute\", \"GRPCRoute\""),
 			},
 		},
+		{
+			name: "invalid target ref; sectionName for non-Gateway kind",
+			policy: createModifiedPolicy(func(p *ngfAPIv1alpha2.ObservabilityPolicy) *ngfAPIv1alpha2.ObservabilityPolicy {
+				p.Spec.TargetRefs[0].SectionName = helpers.GetPointer[v1.SectionName]("listener")
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.targetRefs.sectionName: Forbidden: sectionName is only supported for Gateway"),
+			},
+		},
+		{
+			name: "valid target ref; Gateway listener",
+			policy: createModifiedPolicy(func(p *ngfAPIv1alpha2.ObservabilityPolicy) *ngfAPIv1alpha2.ObservabilityPolicy {
+				p.Spec.TargetRefs[0].Kind = kinds.Gateway
+				p.Spec.TargetRefs[0].SectionName = helpers.GetPointer[v1.SectionName]("listener")
+				return p
+			}),
+			expConditions: nil,
+		},
 		{
 			name: "invalid strategy",
 			policy: createModifiedPolicy(func(p *ngfAPIv1alpha2.ObservabilityPolicy) *ngfAPIv1alpha2.ObservabilityPolicy {
@@ -110,7 +131,7 @@ func TestValidator_Validate(t *testing.T) {
 			}),
 			expConditions: []conditions.Condition{
 				conditions.NewPolicyInvalid("spec.tracing.spanName: Invalid value: \"invalid$$$\": " +
-					"a valid value must have all '\"' escaped and must not contain any '$' or end with an " +
+					"[NGF1501 InvalidEscapedString] a valid value must have all '\"' escaped and must not contain any '$' or end with an " +
 					"unescaped '\\' (regex used for validation is '([^\"$\\\\]|\\\\[^$])*')"),
 			},
 		},
@@ -122,7 +143,7 @@ func TestValidator_Validate(t *testing.T) {
 			}),
 			expConditions: []conditions.Condition{
 				conditions.NewPolicyInvalid("spec.tracing.spanAttributes.key: Invalid value: \"invalid$$$\": " +
-					"a valid value must have all '\"' escaped and must not contain any '$' or end with an " +
+					"[NGF1501 InvalidEscapedString] a valid value must have all '\"' escaped and must not contain any '$' or end with an " +
 					"unescaped '\\' (regex used for validation is '([^\"$\\\\]|\\\\[^$])*')"),
 			},
 		},
@@ -134,7 +155,7 @@ func TestValidator_Validate(t *testing.T) {
 			}),
 			expConditions: []conditions.Condition{
 				conditions.NewPolicyInvalid("spec.tracing.spanAttributes.value: Invalid value: \"invalid$$$\": " +
-					"a valid value must have all '\"' escaped and must not contain any '$' or end with an " +
+					"[NGF1501 InvalidEscapedString] a valid value must have all '\"' escaped and must not contain any '$' or end with an " +
 					"unescaped '\\' (regex used for validation is '([^\"$\\\\]|\\\\[^$])*')"),
 			},
 		},
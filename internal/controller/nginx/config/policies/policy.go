@@ -20,11 +20,34 @@ type Policy interface {
 	client.Object
 }
 
+// SectionNamePolicy is a Policy that can target a section of a resource, like a listener of a Gateway.
+type SectionNamePolicy interface {
+	// GetTargetRefsWithSectionName returns the targetRefs of the Policy with their sectionNames.
+	GetTargetRefsWithSectionName() []gatewayv1.LocalPolicyTargetReferenceWithSectionName
+}
+
+// TargetRefsWithSectionName returns the targetRefs of the Policy with their sectionNames.
+// The targetRefs of a Policy that doesn't implement SectionNamePolicy have no sectionName.
+func TargetRefsWithSectionName(policy Policy) []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	if p, ok := policy.(SectionNamePolicy); ok {
+		return p.GetTargetRefsWithSectionName()
+	}
+
+	refs := make([]gatewayv1.LocalPolicyTargetReferenceWithSectionName, 0, len(policy.GetTargetRefs()))
+	for _, ref := range policy.GetTargetRefs() {
+		refs = append(refs, gatewayv1.LocalPolicyTargetReferenceWithSectionName{LocalPolicyTargetReference: ref})
+	}
+
+	return refs
+}
+
 // GlobalSettings contains global settings from the current state of the graph that may be
 // needed for policy validation or generation if certain policies rely on those global settings.
 type GlobalSettings struct {
 	// TelemetryEnabled is whether telemetry is enabled in the NginxProxy resource.
 	TelemetryEnabled bool
+	// DNSResolverEnabled is whether a DNS resolver is configured in the NginxProxy resource.
+	DNSResolverEnabled bool
 }
 
 // ValidateTargetRef validates a policy's targetRef for the proper group and kind.
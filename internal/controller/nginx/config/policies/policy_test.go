@@ -0,0 +1,44 @@
+package policies_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	policies "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
+	policiesfakes "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/policiesfakes"
+)
+
+var _ = Describe("TargetRefsWithSectionName", func() {
+	ref := gatewayv1.LocalPolicyTargetReference{
+		Group: gatewayv1.GroupName,
+		Kind:  "Gateway",
+		Name:  "gateway",
+	}
+
+	It("returns the targetRefs with their sectionNames for a SectionNamePolicy", func() {
+		sectionName := gatewayv1.SectionName("admin")
+		refs := []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+			{LocalPolicyTargetReference: ref, SectionName: &sectionName},
+		}
+
+		policy := &ngfAPIv1alpha2.ObservabilityPolicy{
+			Spec: ngfAPIv1alpha2.ObservabilityPolicySpec{TargetRefs: refs},
+		}
+
+		Expect(policies.TargetRefsWithSectionName(policy)).To(Equal(refs))
+	})
+
+	It("returns the targetRefs without sectionNames for other policies", func() {
+		policy := &policiesfakes.FakePolicy{
+			GetTargetRefsStub: func() []gatewayv1.LocalPolicyTargetReference {
+				return []gatewayv1.LocalPolicyTargetReference{ref}
+			},
+		}
+
+		Expect(policies.TargetRefsWithSectionName(policy)).To(Equal(
+			[]gatewayv1.LocalPolicyTargetReferenceWithSectionName{{LocalPolicyTargetReference: ref}},
+		))
+	})
+})
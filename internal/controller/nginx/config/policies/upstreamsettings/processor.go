@@ -1,6 +1,8 @@
 package upstreamsettings
 
 import (
+	"strings"
+
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
@@ -64,6 +66,10 @@ func processPolicies(pols []policies.Policy) UpstreamSettings {
 			if usp.Spec.KeepAlive.Timeout != nil {
 				upstreamSettings.KeepAlive.Timeout = string(*usp.Spec.KeepAlive.Timeout)
 			}
+
+			if usp.Spec.KeepAlive.Disable != nil {
+				upstreamSettings.KeepAlive.Disabled = *usp.Spec.KeepAlive.Disable
+			}
 		}
 
 		if usp.Spec.LoadBalancingMethod != nil {
@@ -73,7 +79,25 @@ func processPolicies(pols []policies.Policy) UpstreamSettings {
 		if usp.Spec.HashMethodKey != nil {
 			upstreamSettings.HashMethodKey = string(*usp.Spec.HashMethodKey)
 		}
+
+		if usp.Spec.HashKey != nil {
+			upstreamSettings.HashMethodKey = hashKeyVariable(*usp.Spec.HashKey)
+		}
 	}
 
 	return upstreamSettings
 }
+
+// hashKeyVariable returns the NGINX variable that holds the value of the header or the cookie of the HashKey.
+// The header name is converted to the form of the $http_ variables: lowercase, with dashes replaced by underscores.
+func hashKeyVariable(key ngfAPI.HashKey) string {
+	if key.Header != nil {
+		return "$http_" + strings.ReplaceAll(strings.ToLower(*key.Header), "-", "_")
+	}
+
+	if key.Cookie != nil {
+		return "$cookie_" + *key.Cookie
+	}
+
+	return ""
+}
@@ -90,6 +90,48 @@ func TestProcess(t *testing.T) {
 				HashMethodKey:       "$request_time",
 			},
 		},
+		{
+			name: "load balancing method set with header hash key",
+			policies: []policies.Policy{
+				&ngfAPIv1alpha1.UpstreamSettingsPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "usp",
+						Namespace: "test",
+					},
+					Spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+						LoadBalancingMethod: helpers.GetPointer(ngfAPIv1alpha1.LoadBalancingTypeHashConsistent),
+						HashKey: &ngfAPIv1alpha1.HashKey{
+							Header: helpers.GetPointer("X-Tenant-ID"),
+						},
+					},
+				},
+			},
+			expUpstreamSettings: UpstreamSettings{
+				LoadBalancingMethod: string(ngfAPIv1alpha1.LoadBalancingTypeHashConsistent),
+				HashMethodKey:       "$http_x_tenant_id",
+			},
+		},
+		{
+			name: "load balancing method set with cookie hash key",
+			policies: []policies.Policy{
+				&ngfAPIv1alpha1.UpstreamSettingsPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "usp",
+						Namespace: "test",
+					},
+					Spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+						LoadBalancingMethod: helpers.GetPointer(ngfAPIv1alpha1.LoadBalancingTypeHash),
+						HashKey: &ngfAPIv1alpha1.HashKey{
+							Cookie: helpers.GetPointer("SessionID"),
+						},
+					},
+				},
+			},
+			expUpstreamSettings: UpstreamSettings{
+				LoadBalancingMethod: string(ngfAPIv1alpha1.LoadBalancingTypeHash),
+				HashMethodKey:       "$cookie_SessionID",
+			},
+		},
 		{
 			name: "zone size set",
 			policies: []policies.Policy{
@@ -191,6 +233,27 @@ func TestProcess(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "keep alive disabled",
+			policies: []policies.Policy{
+				&ngfAPIv1alpha1.UpstreamSettingsPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "usp",
+						Namespace: "test",
+					},
+					Spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+						KeepAlive: helpers.GetPointer(ngfAPIv1alpha1.UpstreamKeepAlive{
+							Disable: helpers.GetPointer(true),
+						}),
+					},
+				},
+			},
+			expUpstreamSettings: UpstreamSettings{
+				KeepAlive: http.UpstreamKeepAlive{
+					Disabled: true,
+				},
+			},
+		},
 		{
 			name: "no fields populated",
 			policies: []policies.Policy{
@@ -89,6 +89,16 @@ func conflicts(a, b ngfAPI.UpstreamSettingsPolicySpec) bool {
 		if a.KeepAlive.Timeout != nil && b.KeepAlive.Timeout != nil {
 			return true
 		}
+
+		if a.KeepAlive.Disable != nil && b.KeepAlive.Disable != nil {
+			return true
+		}
+
+		// disabling the keep-alive connections conflicts with the keep-alive settings of another policy
+		if keepAliveDisabled(a.KeepAlive) && hasKeepAliveSettings(b.KeepAlive) ||
+			keepAliveDisabled(b.KeepAlive) && hasKeepAliveSettings(a.KeepAlive) {
+			return true
+		}
 	}
 
 	if checkConflictsForLoadBalancingFields(a, b) {
@@ -98,12 +108,23 @@ func conflicts(a, b ngfAPI.UpstreamSettingsPolicySpec) bool {
 	return false
 }
 
+func keepAliveDisabled(keepAlive *ngfAPI.UpstreamKeepAlive) bool {
+	return keepAlive.Disable != nil && *keepAlive.Disable
+}
+
+func hasKeepAliveSettings(keepAlive *ngfAPI.UpstreamKeepAlive) bool {
+	return keepAlive.Connections != nil ||
+		keepAlive.Requests != nil ||
+		keepAlive.Time != nil ||
+		keepAlive.Timeout != nil
+}
+
 func checkConflictsForLoadBalancingFields(a, b ngfAPI.UpstreamSettingsPolicySpec) bool {
 	if a.LoadBalancingMethod != nil && b.LoadBalancingMethod != nil {
 		return true
 	}
 
-	if a.HashMethodKey != nil && b.HashMethodKey != nil {
+	if hasHashKey(a) && hasHashKey(b) {
 		return true
 	}
 
@@ -154,6 +175,15 @@ func (v Validator) validateUpstreamKeepAlive(
 		}
 	}
 
+	if keepAliveDisabled(&keepAlive) && hasKeepAliveSettings(&keepAlive) {
+		path := fieldPath.Child("disable")
+
+		allErrs = append(
+			allErrs,
+			field.Forbidden(path, "connections, requests, time and timeout cannot be set when keep-alive is disabled"),
+		)
+	}
+
 	return allErrs
 }
 
@@ -194,9 +224,21 @@ func (v Validator) validateLoadBalancingMethod(spec ngfAPI.UpstreamSettingsPolic
 		}
 	}
 
+	if spec.HashKey != nil {
+		if err := v.genericValidator.ValidateNginxVariableName(hashKeyVariable(*spec.HashKey)); err != nil {
+			path := path.Child("hashKey")
+			allErrs = append(allErrs, field.Invalid(path, *spec.HashKey, err.Error()))
+		}
+	}
+
 	return allErrs
 }
 
+// hasHashKey returns true if the spec sets the key for hash-based load balancing methods.
+func hasHashKey(spec ngfAPI.UpstreamSettingsPolicySpec) bool {
+	return spec.HashMethodKey != nil || spec.HashKey != nil
+}
+
 func getLoadBalancingMethodList(lbMethods map[ngfAPI.LoadBalancingType]struct{}) string {
 	methods := make([]string, 0, len(lbMethods))
 	for method := range lbMethods {
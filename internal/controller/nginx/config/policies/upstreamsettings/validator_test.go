@@ -99,7 +99,7 @@ func TestValidator_Validate(t *testing.T) {
 				return p
 			}),
 			expConditions: []conditions.Condition{
-				conditions.NewPolicyInvalid("spec.zoneSize: Invalid value: \"invalid\": ^\\d{1,4}(k|m|g)?$ " +
+				conditions.NewPolicyInvalid("spec.zoneSize: Invalid value: \"invalid\": [NGF1504 InvalidNginxSize] ^\\d{1,4}(k|m|g)?$ " +
 					"(e.g. '1024',  or '8k',  or '20m',  or '1g', regex used for validation is 'must contain a number. " +
 					"May be followed by 'k', 'm', or 'g', otherwise bytes are assumed')"),
 			},
@@ -113,14 +113,63 @@ func TestValidator_Validate(t *testing.T) {
 			}),
 			expConditions: []conditions.Condition{
 				conditions.NewPolicyInvalid(
-					"[spec.keepAlive.time: Invalid value: \"invalid\": ^[0-9]{1,4}(ms|s|m|h)? " +
+					"[spec.keepAlive.time: Invalid value: \"invalid\": [NGF1503 InvalidNginxDuration] ^[0-9]{1,4}(ms|s|m|h)? " +
 						"(e.g. '5ms',  or '10s',  or '500m',  or '1000h', regex used for validation is " +
 						"'must contain an, at most, four digit number followed by 'ms', 's', 'm', or 'h''), " +
-						"spec.keepAlive.timeout: Invalid value: \"invalid\": ^[0-9]{1,4}(ms|s|m|h)? " +
+						"spec.keepAlive.timeout: Invalid value: \"invalid\": [NGF1503 InvalidNginxDuration] ^[0-9]{1,4}(ms|s|m|h)? " +
 						"(e.g. '5ms',  or '10s',  or '500m',  or '1000h', regex used for validation is " +
 						"'must contain an, at most, four digit number followed by 'ms', 's', 'm', or 'h'')]"),
 			},
 		},
+		{
+			name: "keepalive disabled with other settings",
+			policy: createModifiedPolicy(func(p *ngfAPI.UpstreamSettingsPolicy) *ngfAPI.UpstreamSettingsPolicy {
+				p.Spec.KeepAlive.Disable = helpers.GetPointer(true)
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid("spec.keepAlive.disable: Forbidden: connections, requests, time and " +
+					"timeout cannot be set when keep-alive is disabled"),
+			},
+		},
+		{
+			name: "valid keepalive disabled",
+			policy: createModifiedPolicy(func(p *ngfAPI.UpstreamSettingsPolicy) *ngfAPI.UpstreamSettingsPolicy {
+				p.Spec.KeepAlive = &ngfAPI.UpstreamKeepAlive{
+					Disable: helpers.GetPointer(true),
+				}
+				return p
+			}),
+			expConditions: nil,
+		},
+		{
+			name: "invalid hash key",
+			policy: createModifiedPolicy(func(p *ngfAPI.UpstreamSettingsPolicy) *ngfAPI.UpstreamSettingsPolicy {
+				p.Spec.HashMethodKey = nil
+				p.Spec.HashKey = &ngfAPI.HashKey{
+					Cookie: helpers.GetPointer("session-id"),
+				}
+				return p
+			}),
+			expConditions: []conditions.Condition{
+				conditions.NewPolicyInvalid(
+					"spec.hashKey: Invalid value: {\"cookie\":\"session-id\"}: " +
+						"[NGF1506 InvalidNginxVariableName] \\$[A-Za-z0-9_]+ " +
+						"(e.g. '$upstream_addr',  or '$remote_addr', regex used for validation is " +
+						"'must start with '$' followed by letters, digits and underscores only')"),
+			},
+		},
+		{
+			name: "valid header hash key",
+			policy: createModifiedPolicy(func(p *ngfAPI.UpstreamSettingsPolicy) *ngfAPI.UpstreamSettingsPolicy {
+				p.Spec.HashMethodKey = nil
+				p.Spec.HashKey = &ngfAPI.HashKey{
+					Header: helpers.GetPointer("X-B3-TraceId"),
+				}
+				return p
+			}),
+			expConditions: nil,
+		},
 		{
 			name:          "valid",
 			policy:        createValidPolicy(),
@@ -251,6 +300,42 @@ func TestValidator_Conflicts(t *testing.T) {
 			},
 			conflicts: true,
 		},
+		{
+			name: "keepalive disable conflicts",
+			polA: &ngfAPI.UpstreamSettingsPolicy{
+				Spec: ngfAPI.UpstreamSettingsPolicySpec{
+					KeepAlive: &ngfAPI.UpstreamKeepAlive{
+						Disable: helpers.GetPointer(true),
+					},
+				},
+			},
+			polB: &ngfAPI.UpstreamSettingsPolicy{
+				Spec: ngfAPI.UpstreamSettingsPolicySpec{
+					KeepAlive: &ngfAPI.UpstreamKeepAlive{
+						Disable: helpers.GetPointer(false),
+					},
+				},
+			},
+			conflicts: true,
+		},
+		{
+			name: "keepalive disable conflicts with keepalive settings",
+			polA: &ngfAPI.UpstreamSettingsPolicy{
+				Spec: ngfAPI.UpstreamSettingsPolicySpec{
+					KeepAlive: &ngfAPI.UpstreamKeepAlive{
+						Disable: helpers.GetPointer(true),
+					},
+				},
+			},
+			polB: &ngfAPI.UpstreamSettingsPolicy{
+				Spec: ngfAPI.UpstreamSettingsPolicySpec{
+					KeepAlive: &ngfAPI.UpstreamKeepAlive{
+						Connections: helpers.GetPointer[int32](50),
+					},
+				},
+			},
+			conflicts: true,
+		},
 		{
 			name: "load balancing method conflicts",
 			polA: createValidPolicy(),
@@ -271,6 +356,18 @@ func TestValidator_Conflicts(t *testing.T) {
 			},
 			conflicts: true,
 		},
+		{
+			name: "hash key conflicts with hash method key",
+			polA: createValidPolicy(),
+			polB: &ngfAPI.UpstreamSettingsPolicy{
+				Spec: ngfAPI.UpstreamSettingsPolicySpec{
+					HashKey: &ngfAPI.HashKey{
+						Header: helpers.GetPointer("X-Tenant-ID"),
+					},
+				},
+			},
+			conflicts: true,
+		},
 	}
 
 	v := upstreamsettings.NewValidator(nil, plusDisabled)
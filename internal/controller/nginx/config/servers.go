@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"net"
 	"slices"
 	"strconv"
 	"strings"
@@ -47,6 +48,11 @@ var unsetHTTPConnectionHeader = http.Header{
 	Value: "",
 }
 
+var closeHTTPConnectionHeader = http.Header{
+	Name:  "Connection",
+	Value: "close",
+}
+
 var httpUpgradeHeader = http.Header{
 	Name:  "Upgrade",
 	Value: "$http_upgrade",
@@ -54,26 +60,35 @@ var httpUpgradeHeader = http.Header{
 
 func (g GeneratorImpl) newExecuteServersFunc(
 	generator policies.Generator,
+	aliases variableAliases,
 	keepAliveCheck keepAliveChecker,
 ) executeFunc {
 	return func(configuration dataplane.Configuration) []executeResult {
-		return g.executeServers(configuration, generator, keepAliveCheck)
+		return g.executeServers(configuration, generator, aliases, keepAliveCheck)
 	}
 }
 
 func (g GeneratorImpl) executeServers(
 	conf dataplane.Configuration,
 	generator policies.Generator,
+	aliases variableAliases,
 	keepAliveCheck keepAliveChecker,
 ) []executeResult {
 	servers, httpMatchPairs := createServers(conf, generator, keepAliveCheck)
+	aliases.rewriteServers(servers)
+	httpIncludes := createHTTPIncludesFromPolicies(conf, generator)
 
 	serverConfig := http.ServerConfig{
 		Servers:                  servers,
+		Includes:                 httpIncludes,
 		IPFamily:                 getIPFamily(conf.BaseHTTPConfig),
 		Plus:                     g.plus,
 		RewriteClientIP:          getRewriteClientIPSettings(conf.BaseHTTPConfig.RewriteClientIPSettings),
 		DisableSNIHostValidation: conf.BaseHTTPConfig.DisableSNIHostValidation,
+		TenantAttribution:        conf.BaseHTTPConfig.TenantAttribution != nil,
+		UpstreamHTTP2:            conf.BaseHTTPConfig.UpstreamHTTP2,
+		GatewayTest:              conf.BaseHTTPConfig.GatewayTestToken != "",
+		TokenReview:              getTokenReview(conf.TokenReview),
 	}
 
 	serverResult := executeResult{
@@ -94,14 +109,35 @@ func (g GeneratorImpl) executeServers(
 	}
 
 	includeFileResults := createIncludeExecuteResultsFromServers(servers)
+	httpIncludeResults := createIncludeExecuteResults(httpIncludes)
 
-	allResults := make([]executeResult, 0, len(includeFileResults)+2)
+	allResults := make([]executeResult, 0, len(includeFileResults)+len(httpIncludeResults)+2)
 	allResults = append(allResults, includeFileResults...)
+	allResults = append(allResults, httpIncludeResults...)
 	allResults = append(allResults, serverResult, httpMatchResult)
 
 	return allResults
 }
 
+// getTokenReview returns the configuration of the internal location that verifies the bearer tokens of the requests.
+// The location is only added if a Route of the Gateway requires the tokens.
+func getTokenReview(tokenReview *dataplane.TokenReview) *http.TokenReview {
+	if tokenReview == nil {
+		return nil
+	}
+
+	serverName, _, err := net.SplitHostPort(tokenReview.Server)
+	if err != nil {
+		serverName = tokenReview.Server
+	}
+
+	return &http.TokenReview{
+		Path:       http.TokenReviewPath,
+		Server:     tokenReview.Server,
+		ServerName: serverName,
+	}
+}
+
 // getIPFamily returns whether the server should be configured for IPv4, IPv6, or both.
 func getIPFamily(baseHTTPConfig dataplane.BaseHTTPConfig) shared.IPFamily {
 	switch baseHTTPConfig.IPFamily {
@@ -130,6 +166,7 @@ func createServers(
 	for idx, s := range conf.HTTPServers {
 		serverID := fmt.Sprintf("%d", idx)
 		httpServer, matchPairs := createServer(s, serverID, generator, keepAliveCheck)
+		httpServer.APICatalogPath = getAPICatalogPath(conf.APICatalog, httpServer)
 		servers = append(servers, httpServer)
 		maps.Copy(finalMatchPairs, matchPairs)
 	}
@@ -138,6 +175,7 @@ func createServers(
 		serverID := fmt.Sprintf("SSL_%d", idx)
 
 		sslServer, matchPairs := createSSLServer(s, serverID, generator, keepAliveCheck)
+		sslServer.APICatalogPath = getAPICatalogPath(conf.APICatalog, sslServer)
 		if _, portInUse := sharedTLSPorts[s.Port]; portInUse {
 			sslServer.Listen = getSocketNameHTTPS(s.Port)
 			sslServer.IsSocket = true
@@ -171,13 +209,17 @@ func createSSLServer(
 			Certificate:    generatePEMFileName(virtualServer.SSL.KeyPairID),
 			CertificateKey: generatePEMFileName(virtualServer.SSL.KeyPairID),
 		},
-		Locations: locs,
-		GRPC:      grpc,
-		Listen:    listen,
+		Locations:       locs,
+		GRPC:            grpc,
+		Listen:          listen,
+		RejectHandshake: virtualServer.RejectHandshake,
 	}
 
 	policyIncludes := createIncludesFromPolicyGenerateResult(
-		generator.GenerateForServer(virtualServer.Policies, server),
+		slices.Concat(
+			generator.GenerateForServer(virtualServer.Policies, server),
+			generator.GenerateForServerRoutes(routePolicies(virtualServer), server),
+		),
 	)
 	snippetIncludes := createIncludesFromServerSnippetsFilters(virtualServer)
 
@@ -213,7 +255,10 @@ func createServer(
 	}
 
 	policyIncludes := createIncludesFromPolicyGenerateResult(
-		generator.GenerateForServer(virtualServer.Policies, server),
+		slices.Concat(
+			generator.GenerateForServer(virtualServer.Policies, server),
+			generator.GenerateForServerRoutes(routePolicies(virtualServer), server),
+		),
 	)
 	snippetIncludes := createIncludesFromServerSnippetsFilters(virtualServer)
 
@@ -224,6 +269,26 @@ func createServer(
 	return server, matchPairs
 }
 
+// routePolicies returns the policies of the path rules of the server. Every policy is returned once, even if it
+// applies to several path rules.
+func routePolicies(server dataplane.VirtualServer) []policies.Policy {
+	var pols []policies.Policy
+	seen := make(map[policies.Policy]struct{})
+
+	for _, rule := range server.PathRules {
+		for _, pol := range rule.Policies {
+			if _, ok := seen[pol]; ok {
+				continue
+			}
+
+			seen[pol] = struct{}{}
+			pols = append(pols, pol)
+		}
+	}
+
+	return pols
+}
+
 // rewriteConfig contains the configuration for a location to rewrite paths,
 // as specified in a URLRewrite filter.
 type rewriteConfig struct {
@@ -1174,11 +1239,66 @@ func updateLocationProxySettings(
 
 	location.ResponseHeaders = responseHeaders
 	location.ProxyPass = proxyPass
+	location.StatusZone = createRouteStatusZone(matchRule.BackendGroup.Source, grpc)
+	location.RouteNamespace = matchRule.BackendGroup.Source.Namespace
+	location.RouteName = matchRule.BackendGroup.Source.Name
 	location.GRPC = grpc
+	location.ProxyTimeouts = createProxyTimeouts(matchRule.Timeouts)
+	location.KeepAliveDisabled = !grpc && keepAliveDisabledForBackends(keepAliveCheck, matchRule.BackendGroup.Backends)
 
 	return location
 }
 
+// createProxyTimeouts returns the timeouts and the retries of the location of a routing rule.
+// A try uses the backend request timeout, or the request timeout if the backend request timeout is not set.
+// The request timeout limits the time of all tries, so the retries don't extend the request.
+func createProxyTimeouts(timeouts *dataplane.Timeouts) *http.ProxyTimeouts {
+	if timeouts == nil {
+		return nil
+	}
+
+	proxyTimeouts := &http.ProxyTimeouts{
+		TryTimeout:          timeouts.BackendRequest,
+		NextUpstreamTimeout: timeouts.Request,
+	}
+
+	if proxyTimeouts.TryTimeout == "" {
+		proxyTimeouts.TryTimeout = timeouts.Request
+	}
+
+	switch {
+	case timeouts.Tries == 1:
+		proxyTimeouts.NextUpstream = "off"
+	case timeouts.Tries > 1:
+		// connection errors and timeouts are always retried
+		conditions := make([]string, 0, len(timeouts.RetryCodes)+2)
+		conditions = append(conditions, "error", "timeout")
+		for _, code := range timeouts.RetryCodes {
+			conditions = append(conditions, fmt.Sprintf("http_%d", code))
+		}
+		proxyTimeouts.NextUpstream = strings.Join(conditions, " ")
+		proxyTimeouts.NextUpstreamTries = timeouts.Tries
+	}
+
+	return proxyTimeouts
+}
+
+// createRouteStatusZone returns the name of the NGINX Plus status zone of the route, so that NGINX Plus
+// collects the metrics of the requests of every route, like the responses by status code, including the requests
+// that the client closed before NGINX responded (499). All locations of a route share the zone.
+func createRouteStatusZone(route types.NamespacedName, grpc bool) string {
+	if route.Name == "" {
+		return ""
+	}
+
+	kind := "httproute"
+	if grpc {
+		kind = "grpcroute"
+	}
+
+	return fmt.Sprintf("%s_%s_%s", kind, route.Namespace, route.Name)
+}
+
 // updateLocations updates the existing locations with any relevant configurations, like proxy_pass,
 // filters, tls settings, etc.
 func updateLocations(
@@ -1244,10 +1364,21 @@ func createProxySSLVerify(v *dataplane.VerifyTLS) *http.ProxySSLVerify {
 	} else {
 		trustedCert = v.RootCAPath
 	}
-	return &http.ProxySSLVerify{
+	proxyVerify := &http.ProxySSLVerify{
 		TrustedCertificate: trustedCert,
 		Name:               v.Hostname,
+		Protocols:          strings.Join(v.Protocols, " "),
+		Ciphers:            v.Ciphers,
+	}
+
+	if v.SessionReuse != nil {
+		proxyVerify.SessionReuse = "off"
+		if *v.SessionReuse {
+			proxyVerify.SessionReuse = "on"
+		}
 	}
+
+	return proxyVerify
 }
 
 func createReturnAndRewriteConfigForRedirectFilter(
@@ -1681,8 +1812,12 @@ func createBaseProxySetHeaders(externalHostname string, extraHeaders ...http.Hea
 }
 
 func getConnectionHeader(keepAliveCheck keepAliveChecker, backends []dataplane.Backend) http.Header {
+	if keepAliveDisabledForBackends(keepAliveCheck, backends) {
+		return closeHTTPConnectionHeader
+	}
+
 	for _, backend := range backends {
-		if keepAliveCheck(backend.UpstreamName) {
+		if keepAliveCheck(backend.UpstreamName) == keepAliveEnabled {
 			// if keep-alive settings are enabled on any upstream, the connection header value
 			// must be empty for the location
 			return unsetHTTPConnectionHeader
@@ -1692,6 +1827,19 @@ func getConnectionHeader(keepAliveCheck keepAliveChecker, backends []dataplane.B
 	return httpConnectionHeader
 }
 
+// keepAliveDisabledForBackends returns true if the keep-alive connections are disabled for any upstream of
+// the backends. The HTTP version is set per location, so the keep-alive connections are disabled for
+// all upstreams of the location.
+func keepAliveDisabledForBackends(keepAliveCheck keepAliveChecker, backends []dataplane.Backend) bool {
+	for _, backend := range backends {
+		if keepAliveCheck(backend.UpstreamName) == keepAliveDisabled {
+			return true
+		}
+	}
+
+	return false
+}
+
 // deduplicateStrings removes duplicate strings from a slice while preserving order.
 func deduplicateStrings(content []string) []string {
 	seen := make(map[string]struct{})
@@ -2,6 +2,9 @@ package config
 
 const serversTemplateText = `
 js_preload_object matches from /etc/nginx/conf.d/matches.json;
+{{- range $i := .Includes }}
+include {{ $i.Name }};
+{{- end }}
 
 
 {{- range $s := .Servers -}}
@@ -55,6 +58,9 @@ server {
           {{- end }}
     ssl_certificate {{ $s.SSL.Certificate }};
     ssl_certificate_key {{ $s.SSL.CertificateKey }};
+          {{- if $s.RejectHandshake }}
+    ssl_reject_handshake on;
+          {{- end }}
 
           {{- if not $.DisableSNIHostValidation }}
     if ($ssl_server_name != $host) {
@@ -96,12 +102,27 @@ server {
         internal;
         {{ end }}
 
+        {{- if and $.Plus $l.StatusZone }}
+        status_zone {{ $l.StatusZone }};
+        {{- end }}
+
+        {{- if and $.TenantAttribution $l.RouteNamespace }}
+        set $ngf_route_namespace "{{ $l.RouteNamespace }}";
+        set $ngf_route "{{ $l.RouteNamespace }}/{{ $l.RouteName }}";
+        {{- end }}
+
         {{ if ne $l.MirrorSplitClientsVariableName "" -}}
         if (${{ $l.MirrorSplitClientsVariableName }} = "") {
             return 204;
         }
         {{- end }}
 
+        {{- if and $.GatewayTest $l.ProxyPass (not $l.GRPC) }}
+        if ($ngf_gateway_test) {
+            add_header X-NGF-Gateway-Test-Upstream $proxy_host always;
+        }
+        {{- end }}
+
         {{- range $i := $l.Includes }}
         include {{ $i.Name }};
         {{- end }}
@@ -137,12 +158,27 @@ server {
         include /etc/nginx/grpc-error-pages.conf;
         {{- end }}
 
-        proxy_http_version 1.1;
+        proxy_http_version {{ if $l.KeepAliveDisabled }}1.0{{ else if $.UpstreamHTTP2 }}2{{ else }}1.1{{ end }};
         {{- if $l.ProxyPass -}}
             {{ range $h := $l.ProxySetHeaders }}
         {{ $proxyOrGRPC }}_set_header {{ $h.Name }} "{{ $h.Value }}";
             {{- end }}
         {{ $proxyOrGRPC }}_pass {{ $l.ProxyPass }};
+            {{- with $l.ProxyTimeouts }}
+                {{- if .TryTimeout }}
+        {{ $proxyOrGRPC }}_read_timeout {{ .TryTimeout }};
+        {{ $proxyOrGRPC }}_send_timeout {{ .TryTimeout }};
+                {{- end }}
+                {{- if .NextUpstream }}
+        {{ $proxyOrGRPC }}_next_upstream {{ .NextUpstream }};
+                {{- end }}
+                {{- if .NextUpstreamTries }}
+        {{ $proxyOrGRPC }}_next_upstream_tries {{ .NextUpstreamTries }};
+                {{- end }}
+                {{- if .NextUpstreamTimeout }}
+        {{ $proxyOrGRPC }}_next_upstream_timeout {{ .NextUpstreamTimeout }};
+                {{- end }}
+            {{- end }}
             {{ range $h := $l.ResponseHeaders.Add }}
         add_header {{ $h.Name }} "{{ $h.Value }}" always;
             {{- end }}
@@ -158,11 +194,50 @@ server {
         {{ $proxyOrGRPC }}_ssl_verify on;
         {{ $proxyOrGRPC }}_ssl_name {{ $l.ProxySSLVerify.Name }};
         {{ $proxyOrGRPC }}_ssl_trusted_certificate {{ $l.ProxySSLVerify.TrustedCertificate }};
+                {{- if $l.ProxySSLVerify.SessionReuse }}
+        {{ $proxyOrGRPC }}_ssl_session_reuse {{ $l.ProxySSLVerify.SessionReuse }};
+                {{- end }}
+                {{- if $l.ProxySSLVerify.Protocols }}
+        {{ $proxyOrGRPC }}_ssl_protocols {{ $l.ProxySSLVerify.Protocols }};
+                {{- end }}
+                {{- if $l.ProxySSLVerify.Ciphers }}
+        {{ $proxyOrGRPC }}_ssl_ciphers {{ $l.ProxySSLVerify.Ciphers }};
+                {{- end }}
             {{- end }}
         {{- end }}
     }
         {{- end }}
 
+        {{- if $s.APICatalogPath }}
+
+    location = {{ $s.APICatalogPath }} {
+        default_type application/json;
+        alias /etc/nginx/conf.d/api-catalog.json;
+    }
+        {{- end }}
+
+        {{- with $.TokenReview }}
+
+    location ^~ {{ .Path }} {
+        internal;
+            {{- if .Server }}
+        proxy_pass_request_body off;
+        proxy_set_header Content-Length "";
+        proxy_connect_timeout 5s;
+        proxy_read_timeout 5s;
+        proxy_ssl_certificate /var/run/secrets/ngf/tls.crt;
+        proxy_ssl_certificate_key /var/run/secrets/ngf/tls.key;
+        proxy_ssl_trusted_certificate /var/run/secrets/ngf/ca.crt;
+        proxy_ssl_verify on;
+        proxy_ssl_name {{ .ServerName }};
+        proxy_ssl_protocols TLSv1.3;
+        proxy_pass https://{{ .Server }}/;
+            {{- else }}
+        return 500;
+            {{- end }}
+    }
+        {{- end }}
+
         {{- if $s.GRPC }}
         include /etc/nginx/grpc-error-locations.conf;
         {{- end }}
@@ -20,9 +20,9 @@ import (
 )
 
 var (
-	httpBaseHeaders             = createBaseProxySetHeaders("", httpUpgradeHeader, httpConnectionHeader)
-	grpcBaseHeaders             = createBaseProxySetHeaders("", grpcAuthorityHeader)
-	alwaysFalseKeepAliveChecker = func(_ string) bool { return false }
+	httpBaseHeaders         = createBaseProxySetHeaders("", httpUpgradeHeader, httpConnectionHeader)
+	grpcBaseHeaders         = createBaseProxySetHeaders("", grpcAuthorityHeader)
+	defaultKeepAliveChecker = func(_ string) keepAliveMode { return keepAliveDefault }
 )
 
 func TestExecuteServers(t *testing.T) {
@@ -177,6 +177,7 @@ func TestExecuteServers(t *testing.T) {
 		"mirror /_ngf-internal-mirror-my-backend-test/route1-0;":            1,
 		"if ($__ngf_internal_mirror_my_backend_test_route1_0_50_00 = \"\")": 1,
 		"return 204": 1,
+		"include /etc/nginx/includes/http-include.conf;": 1,
 	}
 
 	type assertion func(g *WithT, data string)
@@ -196,6 +197,9 @@ func TestExecuteServers(t *testing.T) {
 		includesFolder + "/include-2.conf": func(g *WithT, data string) {
 			g.Expect(data).To(Equal("include-2"))
 		},
+		includesFolder + "/http-include.conf": func(g *WithT, data string) {
+			g.Expect(data).To(Equal("http-include"))
+		},
 		includesFolder + "/location-snippet.conf": func(g *WithT, data string) {
 			g.Expect(data).To(Equal("location snippet contents"))
 		},
@@ -207,6 +211,14 @@ func TestExecuteServers(t *testing.T) {
 	g := NewWithT(t)
 
 	fakeGenerator := &policiesfakes.FakeGenerator{}
+	fakeGenerator.GenerateForHTTPReturns(
+		policies.GenerateResultFiles{
+			{
+				Name:    "http-include.conf",
+				Content: []byte("http-include"),
+			},
+		},
+	)
 	fakeGenerator.GenerateForServerReturns(
 		policies.GenerateResultFiles{
 			{
@@ -221,7 +233,7 @@ func TestExecuteServers(t *testing.T) {
 	)
 
 	gen := GeneratorImpl{}
-	results := gen.executeServers(conf, fakeGenerator, alwaysFalseKeepAliveChecker)
+	results := gen.executeServers(conf, fakeGenerator, nil, defaultKeepAliveChecker)
 	g.Expect(results).To(HaveLen(len(expectedResults)))
 
 	for _, res := range results {
@@ -360,7 +372,7 @@ func TestExecuteServers_IPFamily(t *testing.T) {
 			g := NewWithT(t)
 
 			gen := GeneratorImpl{}
-			results := gen.executeServers(test.config, &policiesfakes.FakeGenerator{}, alwaysFalseKeepAliveChecker)
+			results := gen.executeServers(test.config, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
 
 			g.Expect(results).To(HaveLen(2))
 			serverConf := string(results[0].data)
@@ -479,7 +491,7 @@ func TestExecuteServers_RewriteClientIP(t *testing.T) {
 			g := NewWithT(t)
 
 			gen := GeneratorImpl{}
-			results := gen.executeServers(test.config, &policiesfakes.FakeGenerator{}, alwaysFalseKeepAliveChecker)
+			results := gen.executeServers(test.config, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
 			g.Expect(results).To(HaveLen(2))
 			serverConf := string(results[0].data)
 			httpMatchConf := string(results[1].data)
@@ -501,6 +513,22 @@ func TestExecuteServers_Plus(t *testing.T) {
 			},
 			{
 				Hostname: "example2.com",
+				PathRules: []dataplane.PathRule{
+					{
+						Path:     "/",
+						PathType: dataplane.PathTypePrefix,
+						MatchRules: []dataplane.MatchRule{
+							{
+								BackendGroup: dataplane.BackendGroup{
+									Source: types.NamespacedName{Namespace: "test", Name: "route"},
+									Backends: []dataplane.Backend{
+										{UpstreamName: "test_foo_80", Valid: true, Weight: 1},
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 		SSLServers: []dataplane.VirtualServer{
@@ -514,14 +542,15 @@ func TestExecuteServers_Plus(t *testing.T) {
 	}
 
 	expectedHTTPConfig := map[string]int{
-		"status_zone example.com;":  2,
-		"status_zone example2.com;": 1,
+		"status_zone example.com;":          2,
+		"status_zone example2.com;":         1,
+		"status_zone httproute_test_route;": 1,
 	}
 
 	g := NewWithT(t)
 
 	gen := GeneratorImpl{plus: true}
-	results := gen.executeServers(config, &policiesfakes.FakeGenerator{}, alwaysFalseKeepAliveChecker)
+	results := gen.executeServers(config, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
 	g.Expect(results).To(HaveLen(2))
 
 	serverConf := string(results[0].data)
@@ -531,6 +560,47 @@ func TestExecuteServers_Plus(t *testing.T) {
 	}
 }
 
+func TestExecuteServers_TenantAttribution(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				Hostname: "example.com",
+				PathRules: []dataplane.PathRule{
+					{
+						Path:     "/",
+						PathType: dataplane.PathTypePrefix,
+						MatchRules: []dataplane.MatchRule{
+							{
+								BackendGroup: dataplane.BackendGroup{
+									Source: types.NamespacedName{Namespace: "tenant-a", Name: "route"},
+									Backends: []dataplane.Backend{
+										{UpstreamName: "tenant-a_foo_80", Valid: true, Weight: 1},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	gen := GeneratorImpl{}
+
+	results := gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	g.Expect(string(results[0].data)).ToNot(ContainSubstring("$ngf_route_namespace"))
+
+	conf.BaseHTTPConfig.TenantAttribution = &dataplane.TenantAttribution{}
+
+	results = gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	serverConf := string(results[0].data)
+	g.Expect(strings.Count(serverConf, `set $ngf_route_namespace "tenant-a";`)).To(Equal(1))
+	g.Expect(strings.Count(serverConf, `set $ngf_route "tenant-a/route";`)).To(Equal(1))
+}
+
 func TestExecuteForDefaultServers(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -605,7 +675,7 @@ func TestExecuteForDefaultServers(t *testing.T) {
 			g := NewWithT(t)
 
 			gen := GeneratorImpl{}
-			serverResults := gen.executeServers(tc.conf, &policiesfakes.FakeGenerator{}, alwaysFalseKeepAliveChecker)
+			serverResults := gen.executeServers(tc.conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
 			g.Expect(serverResults).To(HaveLen(2))
 			serverConf := string(serverResults[0].data)
 			httpMatchConf := string(serverResults[1].data)
@@ -1407,6 +1477,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "/_ngf-internal-rule0-route0",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.InternalLocationType,
 				Includes:        internalIncludes,
@@ -1414,6 +1487,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "/_ngf-internal-rule0-route1",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.InternalLocationType,
 				Includes:        internalIncludes,
@@ -1421,6 +1497,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "/_ngf-internal-rule0-route2",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.InternalLocationType,
 				Includes:        internalIncludes,
@@ -1434,6 +1513,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "/_ngf-internal-rule1-route0",
 				ProxyPass:       "http://$group_test__route1_rule1_pathRule0$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.InternalLocationType,
 				Includes:        internalIncludes,
@@ -1441,6 +1523,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "^~ /path-only/",
 				ProxyPass:       "http://invalid-backend-ref$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.ExternalLocationType,
 				Includes:        externalIncludes,
@@ -1448,6 +1533,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /path-only",
 				ProxyPass:       "http://invalid-backend-ref$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.ExternalLocationType,
 				Includes:        externalIncludes,
@@ -1455,6 +1543,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "^~ /backend-tls-policy/",
 				ProxyPass:       "https://test_btp_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				ProxySSLVerify: &http.ProxySSLVerify{
 					Name:               "test-btp.example.com",
@@ -1466,6 +1557,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /backend-tls-policy",
 				ProxyPass:       "https://test_btp_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				ProxySSLVerify: &http.ProxySSLVerify{
 					Name:               "test-btp.example.com",
@@ -1535,6 +1629,9 @@ func TestCreateServers(t *testing.T) {
 				Path:            "^~ /rewrite/",
 				Rewrites:        []string{"^ /replacement break"},
 				ProxyPass:       "http://test_foo_80",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: rewriteProxySetHeaders,
 				Type:            http.ExternalLocationType,
 				Includes:        externalIncludes,
@@ -1543,6 +1640,9 @@ func TestCreateServers(t *testing.T) {
 				Path:            "= /rewrite",
 				Rewrites:        []string{"^ /replacement break"},
 				ProxyPass:       "http://test_foo_80",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: rewriteProxySetHeaders,
 				Type:            http.ExternalLocationType,
 				Includes:        externalIncludes,
@@ -1563,6 +1663,9 @@ func TestCreateServers(t *testing.T) {
 				Path:            "/_ngf-internal-rule8-route0",
 				Rewrites:        []string{"^ $request_uri", "^/rewrite-with-headers([^?]*)? /prefix-replacement$1?$args? break"},
 				ProxyPass:       "http://test_foo_80",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: rewriteProxySetHeaders,
 				Type:            http.InternalLocationType,
 				Includes:        internalIncludes,
@@ -1570,6 +1673,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "^~ /mirror/",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				MirrorPaths:     []string{"/_ngf-internal-mirror-my-backend-test/route1-0"},
 				Type:            http.ExternalLocationType,
@@ -1578,6 +1684,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /mirror",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				MirrorPaths:     []string{"/_ngf-internal-mirror-my-backend-test/route1-0"},
 				Type:            http.ExternalLocationType,
@@ -1586,6 +1695,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /_ngf-internal-mirror-my-backend-test/route1-0",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.InternalLocationType,
 				Includes:        externalIncludes,
@@ -1593,6 +1705,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /mirror-filter-percentage-defined",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				MirrorPaths:     []string{"/_ngf-internal-mirror-my-backend-test/route1-1"},
 				Type:            http.ExternalLocationType,
@@ -1601,6 +1716,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:                           "= /_ngf-internal-mirror-my-backend-test/route1-1",
 				ProxyPass:                      "http://test_foo_80$request_uri",
+				StatusZone:                     "httproute_test_route1",
+				RouteNamespace:                 "test",
+				RouteName:                      "route1",
 				ProxySetHeaders:                httpBaseHeaders,
 				MirrorSplitClientsVariableName: "__ngf_internal_mirror_my_backend_test_route1_1_50_00",
 				Type:                           http.InternalLocationType,
@@ -1609,6 +1727,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /mirror-filter-100-percent",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				MirrorPaths:     []string{"/_ngf-internal-mirror-my-backend-test/route1-2"},
 				Type:            http.ExternalLocationType,
@@ -1617,6 +1738,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /_ngf-internal-mirror-my-backend-test/route1-2",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.InternalLocationType,
 				Includes:        externalIncludes,
@@ -1624,6 +1748,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /mirror-filter-0-percent",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				MirrorPaths:     []string{"/_ngf-internal-mirror-my-backend-test/route1-3"},
 				Type:            http.ExternalLocationType,
@@ -1632,6 +1759,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:                           "= /_ngf-internal-mirror-my-backend-test/route1-3",
 				ProxyPass:                      "http://test_foo_80$request_uri",
+				StatusZone:                     "httproute_test_route1",
+				RouteNamespace:                 "test",
+				RouteName:                      "route1",
 				ProxySetHeaders:                httpBaseHeaders,
 				MirrorSplitClientsVariableName: "__ngf_internal_mirror_my_backend_test_route1_3_0_00",
 				Type:                           http.InternalLocationType,
@@ -1640,6 +1770,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /mirror-filter-duplicate-targets",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				MirrorPaths:     []string{"/_ngf-internal-mirror-my-backend-test/route1-4"},
 				Type:            http.ExternalLocationType,
@@ -1648,6 +1781,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:                           "= /_ngf-internal-mirror-my-backend-test/route1-4",
 				ProxyPass:                      "http://test_foo_80$request_uri",
+				StatusZone:                     "httproute_test_route1",
+				RouteNamespace:                 "test",
+				RouteName:                      "route1",
 				ProxySetHeaders:                httpBaseHeaders,
 				MirrorSplitClientsVariableName: "__ngf_internal_mirror_my_backend_test_route1_4_50_00",
 				Type:                           http.InternalLocationType,
@@ -1657,6 +1793,9 @@ func TestCreateServers(t *testing.T) {
 				Path:            "= /grpc/mirror",
 				GRPC:            true,
 				ProxyPass:       "grpc://test_foo_80",
+				StatusZone:      "grpcroute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: grpcBaseHeaders,
 				MirrorPaths:     []string{"/_ngf-internal-mirror-my-grpc-backend-test/route1-0"},
 				Type:            http.ExternalLocationType,
@@ -1666,6 +1805,9 @@ func TestCreateServers(t *testing.T) {
 				Path:            "= /_ngf-internal-mirror-my-grpc-backend-test/route1-0",
 				GRPC:            true,
 				ProxyPass:       "grpc://test_foo_80",
+				StatusZone:      "grpcroute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				Rewrites:        []string{"^ $request_uri break"},
 				ProxySetHeaders: grpcBaseHeaders,
 				Type:            http.InternalLocationType,
@@ -1710,6 +1852,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /exact",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.ExternalLocationType,
 				Includes:        externalIncludes,
@@ -1723,13 +1868,19 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "/_ngf-internal-rule24-route0",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.InternalLocationType,
 				Includes:        internalIncludes,
 			},
 			{
-				Path:      "^~ /proxy-set-headers/",
-				ProxyPass: "http://test_foo_80$request_uri",
+				Path:           "^~ /proxy-set-headers/",
+				ProxyPass:      "http://test_foo_80$request_uri",
+				StatusZone:     "httproute_test_route1",
+				RouteNamespace: "test",
+				RouteName:      "route1",
 				ProxySetHeaders: append([]http.Header{
 					{
 						Name:  "my-header",
@@ -1750,8 +1901,11 @@ func TestCreateServers(t *testing.T) {
 				Includes: externalIncludes,
 			},
 			{
-				Path:      "= /proxy-set-headers",
-				ProxyPass: "http://test_foo_80$request_uri",
+				Path:           "= /proxy-set-headers",
+				ProxyPass:      "http://test_foo_80$request_uri",
+				StatusZone:     "httproute_test_route1",
+				RouteNamespace: "test",
+				RouteName:      "route1",
 				ProxySetHeaders: append([]http.Header{
 					{
 						Name:  "my-header",
@@ -1774,14 +1928,20 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /grpc/method",
 				ProxyPass:       "grpc://test_foo_80",
+				StatusZone:      "grpcroute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				GRPC:            true,
 				ProxySetHeaders: grpcBaseHeaders,
 				Type:            http.ExternalLocationType,
 				Includes:        externalIncludes,
 			},
 			{
-				Path:      "= /grpc-with-backend-tls-policy/method",
-				ProxyPass: "grpcs://test_btp_80",
+				Path:           "= /grpc-with-backend-tls-policy/method",
+				ProxyPass:      "grpcs://test_btp_80",
+				StatusZone:     "grpcroute_test_route1",
+				RouteNamespace: "test",
+				RouteName:      "route1",
 				ProxySSLVerify: &http.ProxySSLVerify{
 					Name:               "test-btp.example.com",
 					TrustedCertificate: "/etc/nginx/secrets/test-btp.crt",
@@ -1794,6 +1954,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /include-path-only-match",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.ExternalLocationType,
 				Includes:        externalIncludes,
@@ -1807,6 +1970,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "/_ngf-internal-rule29-route0",
 				ProxyPass:       "http://test_foo_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: httpBaseHeaders,
 				Type:            http.InternalLocationType,
 				Includes:        internalIncludes,
@@ -1814,6 +1980,9 @@ func TestCreateServers(t *testing.T) {
 			{
 				Path:            "= /keep-alive-enabled",
 				ProxyPass:       "http://test_keep_alive_80$request_uri",
+				StatusZone:      "httproute_test_route1",
+				RouteNamespace:  "test",
+				RouteName:       "route1",
 				ProxySetHeaders: createBaseProxySetHeaders("", httpUpgradeHeader, unsetHTTPConnectionHeader),
 				Type:            http.ExternalLocationType,
 				Includes:        externalIncludes,
@@ -1984,12 +2153,18 @@ func TestCreateServersConflicts(t *testing.T) {
 				{
 					Path:            "^~ /coffee/",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route",
+					RouteNamespace:  "test",
+					RouteName:       "route",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "= /coffee",
 					ProxyPass:       "http://test_bar_80$request_uri",
+					StatusZone:      "httproute_test_route",
+					RouteNamespace:  "test",
+					RouteName:       "route",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
@@ -2024,12 +2199,18 @@ func TestCreateServersConflicts(t *testing.T) {
 				{
 					Path:            "= /coffee",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route",
+					RouteNamespace:  "test",
+					RouteName:       "route",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "^~ /coffee/",
 					ProxyPass:       "http://test_bar_80$request_uri",
+					StatusZone:      "httproute_test_route",
+					RouteNamespace:  "test",
+					RouteName:       "route",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
@@ -2074,12 +2255,18 @@ func TestCreateServersConflicts(t *testing.T) {
 				{
 					Path:            "^~ /coffee/",
 					ProxyPass:       "http://test_bar_80$request_uri",
+					StatusZone:      "httproute_test_route",
+					RouteNamespace:  "test",
+					RouteName:       "route",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "= /coffee",
 					ProxyPass:       "http://test_baz_80$request_uri",
+					StatusZone:      "httproute_test_route",
+					RouteNamespace:  "test",
+					RouteName:       "route",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
@@ -2120,7 +2307,7 @@ func TestCreateServersConflicts(t *testing.T) {
 			result, _ := createServers(
 				dataplane.Configuration{HTTPServers: httpServers},
 				&policiesfakes.FakeGenerator{},
-				alwaysFalseKeepAliveChecker,
+				defaultKeepAliveChecker,
 			)
 			g.Expect(helpers.Diff(expectedServers, result)).To(BeEmpty())
 		})
@@ -2130,10 +2317,13 @@ func TestCreateServersConflicts(t *testing.T) {
 func TestCreateServers_Includes(t *testing.T) {
 	t.Parallel()
 
+	routePolicy := &policiesfakes.FakePolicy{}
+
 	pathRules := []dataplane.PathRule{
 		{
 			Path:     "/",
 			PathType: dataplane.PathTypeExact,
+			Policies: []policies.Policy{routePolicy},
 			MatchRules: []dataplane.MatchRule{
 				{
 					Filters: dataplane.HTTPFilters{
@@ -2199,6 +2389,12 @@ func TestCreateServers_Includes(t *testing.T) {
 			Content: []byte("server policy conf"),
 		},
 	})
+	fakeGenerator.GenerateForServerRoutesReturns(policies.GenerateResultFiles{
+		{
+			Name:    "route-policy.conf",
+			Content: []byte("route policy conf"),
+		},
+	})
 
 	expServers := []http.Server{
 		{
@@ -2226,6 +2422,10 @@ func TestCreateServers_Includes(t *testing.T) {
 					Name:    includesFolder + "/server-policy.conf",
 					Content: []byte("server policy conf"),
 				},
+				{
+					Name:    includesFolder + "/route-policy.conf",
+					Content: []byte("route policy conf"),
+				},
 				{
 					Name:    includesFolder + "/server-snippet.conf",
 					Content: []byte("server snippet contents"),
@@ -2259,6 +2459,10 @@ func TestCreateServers_Includes(t *testing.T) {
 					Name:    includesFolder + "/server-policy.conf",
 					Content: []byte("server policy conf"),
 				},
+				{
+					Name:    includesFolder + "/route-policy.conf",
+					Content: []byte("route policy conf"),
+				},
 				{
 					Name:    includesFolder + "/server-snippet.conf",
 					Content: []byte("server snippet contents"),
@@ -2271,10 +2475,14 @@ func TestCreateServers_Includes(t *testing.T) {
 
 	conf := dataplane.Configuration{HTTPServers: httpServers, SSLServers: sslServers}
 
-	actualServers, matchPairs := createServers(conf, fakeGenerator, alwaysFalseKeepAliveChecker)
+	actualServers, matchPairs := createServers(conf, fakeGenerator, defaultKeepAliveChecker)
 	g.Expect(matchPairs).To(BeEmpty())
 	g.Expect(actualServers).To(HaveLen(len(expServers)))
 
+	g.Expect(fakeGenerator.GenerateForServerRoutesCallCount()).To(Equal(2))
+	pols, _ := fakeGenerator.GenerateForServerRoutesArgsForCall(0)
+	g.Expect(pols).To(ConsistOf(routePolicy))
+
 	for i, expServer := range expServers {
 		g.Expect(actualServers[i].ServerName).To(Equal(expServer.ServerName))
 
@@ -2289,6 +2497,36 @@ func TestCreateServers_Includes(t *testing.T) {
 	}
 }
 
+func TestRoutePolicies(t *testing.T) {
+	t.Parallel()
+
+	policyA := &policiesfakes.FakePolicy{}
+	policyB := &policiesfakes.FakePolicy{}
+
+	server := dataplane.VirtualServer{
+		PathRules: []dataplane.PathRule{
+			{
+				Path:     "/a",
+				Policies: []policies.Policy{policyA},
+			},
+			{
+				Path: "/none",
+			},
+			{
+				Path:     "/b",
+				Policies: []policies.Policy{policyA, policyB},
+			},
+		},
+	}
+
+	g := NewWithT(t)
+
+	pols := routePolicies(server)
+	g.Expect(pols).To(HaveLen(2))
+	g.Expect(pols[0]).To(BeIdenticalTo(policyA))
+	g.Expect(pols[1]).To(BeIdenticalTo(policyB))
+}
+
 func TestCreateLocations_Includes(t *testing.T) {
 	t.Parallel()
 
@@ -2432,7 +2670,7 @@ func TestCreateLocations_Includes(t *testing.T) {
 		},
 	})
 
-	locations, matches, grpc := createLocations(&httpServer, "1", fakeGenerator, alwaysFalseKeepAliveChecker)
+	locations, matches, grpc := createLocations(&httpServer, "1", fakeGenerator, defaultKeepAliveChecker)
 
 	g := NewWithT(t)
 	g.Expect(grpc).To(BeFalse())
@@ -2593,6 +2831,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_foo_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2619,6 +2860,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_foo_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2644,6 +2888,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_primary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2657,6 +2904,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend1-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_secondary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2688,6 +2938,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_foo_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2718,6 +2971,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_primary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2731,6 +2987,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend1-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_secondary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2766,6 +3025,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_primary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2779,6 +3041,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend1-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_secondary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2815,6 +3080,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule0-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_foo_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2834,6 +3102,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule1-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_foo_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2848,6 +3119,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule2-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_primary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2861,6 +3135,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule2-route0-backend1-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_secondary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2885,6 +3162,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule3-route0-backend0-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_primary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2898,6 +3178,9 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 					Path:            "/_ngf-internal-proxy-pass-rule3-route0-backend1-inference",
 					Type:            http.InternalLocationType,
 					ProxyPass:       "http://$inference_backend_test_secondary_pool_80$request_uri",
+					StatusZone:      "httproute_testNS_routeName",
+					RouteNamespace:  "testNS",
+					RouteName:       "routeName",
 					ProxySetHeaders: proxySetHeaders,
 				},
 				{
@@ -2941,7 +3224,7 @@ func TestCreateLocations_InferenceBackends(t *testing.T) {
 				},
 				"1",
 				&policiesfakes.FakeGenerator{},
-				alwaysFalseKeepAliveChecker,
+				defaultKeepAliveChecker,
 			)
 
 			g.Expect(helpers.Diff(tc.expLocs, locs)).To(BeEmpty())
@@ -3033,12 +3316,18 @@ func TestCreateLocationsRootPath(t *testing.T) {
 				{
 					Path:            "= /path-1",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "= /path-2",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
@@ -3058,18 +3347,27 @@ func TestCreateLocationsRootPath(t *testing.T) {
 				{
 					Path:            "= /path-1",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "= /path-2",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "= /grpc",
 					ProxyPass:       "grpc://test_foo_80",
+					StatusZone:      "grpcroute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					GRPC:            true,
 					ProxySetHeaders: grpcBaseHeaders,
 					Type:            http.ExternalLocationType,
@@ -3089,18 +3387,27 @@ func TestCreateLocationsRootPath(t *testing.T) {
 				{
 					Path:            "= /path-1",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "= /path-2",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "= /",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
@@ -3132,7 +3439,7 @@ func TestCreateLocationsRootPath(t *testing.T) {
 				},
 				"1",
 				&policiesfakes.FakeGenerator{},
-				alwaysFalseKeepAliveChecker,
+				defaultKeepAliveChecker,
 			)
 			g.Expect(locs).To(Equal(test.expLocations))
 			g.Expect(httpMatchPair).To(BeEmpty())
@@ -3213,30 +3520,45 @@ func TestCreateLocationsPath(t *testing.T) {
 				{
 					Path:            "= /exact-path",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "^~ /prefix-path-with-trailing-slash/",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "^~ /prefix-path-without-trailing-slash/",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "= /prefix-path-without-trailing-slash",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
 				{
 					Path:            "~ ^/regular-expression-path/(.*)$",
 					ProxyPass:       "http://test_foo_80$request_uri",
+					StatusZone:      "httproute_test_route1",
+					RouteNamespace:  "test",
+					RouteName:       "route1",
 					ProxySetHeaders: httpBaseHeaders,
 					Type:            http.ExternalLocationType,
 				},
@@ -3262,7 +3584,7 @@ func TestCreateLocationsPath(t *testing.T) {
 				},
 				"1",
 				&policiesfakes.FakeGenerator{},
-				alwaysFalseKeepAliveChecker,
+				defaultKeepAliveChecker,
 			)
 			g.Expect(locs).To(Equal(test.expLocations))
 			g.Expect(httpMatchPair).To(BeEmpty())
@@ -4695,6 +5017,49 @@ func TestGetConnectionHeader(t *testing.T) {
 				},
 			},
 		},
+		{
+			msg:                 "upstream with keepAlive disabled",
+			expConnectionHeader: closeHTTPConnectionHeader,
+			upstreams: []http.Upstream{
+				{
+					Name: "upstream",
+					KeepAlive: http.UpstreamKeepAlive{
+						Disabled: true,
+					},
+				},
+			},
+			backends: []dataplane.Backend{
+				{
+					UpstreamName: "upstream",
+				},
+			},
+		},
+		{
+			msg:                 "keepAlive disabled takes precedence over keepAlive enabled",
+			expConnectionHeader: closeHTTPConnectionHeader,
+			upstreams: []http.Upstream{
+				{
+					Name: "upstream1",
+					KeepAlive: http.UpstreamKeepAlive{
+						Connections: 1,
+					},
+				},
+				{
+					Name: "upstream2",
+					KeepAlive: http.UpstreamKeepAlive{
+						Disabled: true,
+					},
+				},
+			},
+			backends: []dataplane.Backend{
+				{
+					UpstreamName: "upstream1",
+				},
+				{
+					UpstreamName: "upstream2",
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -4789,6 +5154,50 @@ func TestConvertBackendTLSFromGroup(t *testing.T) {
 				Name:               "my-hostname",
 			},
 		},
+		{
+			msg: "tls enabled, session reuse disabled with protocols and ciphers",
+			grp: []dataplane.Backend{
+				{
+					UpstreamName: "my-upstream",
+					Valid:        true,
+					Weight:       1,
+					VerifyTLS: &dataplane.VerifyTLS{
+						Hostname:     "my-hostname",
+						RootCAPath:   "/etc/ssl/certs/ca-certificates.crt",
+						SessionReuse: helpers.GetPointer(false),
+						Protocols:    []string{"TLSv1.2", "TLSv1.3"},
+						Ciphers:      "HIGH:!aNULL",
+					},
+				},
+			},
+			expected: &http.ProxySSLVerify{
+				TrustedCertificate: "/etc/ssl/certs/ca-certificates.crt",
+				Name:               "my-hostname",
+				SessionReuse:       "off",
+				Protocols:          "TLSv1.2 TLSv1.3",
+				Ciphers:            "HIGH:!aNULL",
+			},
+		},
+		{
+			msg: "tls enabled, session reuse enabled",
+			grp: []dataplane.Backend{
+				{
+					UpstreamName: "my-upstream",
+					Valid:        true,
+					Weight:       1,
+					VerifyTLS: &dataplane.VerifyTLS{
+						Hostname:     "my-hostname",
+						RootCAPath:   "/etc/ssl/certs/ca-certificates.crt",
+						SessionReuse: helpers.GetPointer(true),
+					},
+				},
+			},
+			expected: &http.ProxySSLVerify{
+				TrustedCertificate: "/etc/ssl/certs/ca-certificates.crt",
+				Name:               "my-hostname",
+				SessionReuse:       "on",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -4927,7 +5336,7 @@ func TestExecuteServers_DisableSNIHostValidation(t *testing.T) {
 			DisableSNIHostValidation: false,
 		},
 	}
-	results := gen.executeServers(confWithValidation, &policiesfakes.FakeGenerator{}, alwaysFalseKeepAliveChecker)
+	results := gen.executeServers(confWithValidation, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
 	serverConf := string(results[0].data)
 	g.Expect(serverConf).To(ContainSubstring("if ($ssl_server_name != $host)"),
 		"Expected SNI host validation block to be present when DisableSNIHostValidation is false")
@@ -4939,12 +5348,380 @@ func TestExecuteServers_DisableSNIHostValidation(t *testing.T) {
 			DisableSNIHostValidation: true,
 		},
 	}
-	results = gen.executeServers(confWithoutValidation, &policiesfakes.FakeGenerator{}, alwaysFalseKeepAliveChecker)
+	results = gen.executeServers(confWithoutValidation, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
 	serverConf = string(results[0].data)
 	g.Expect(serverConf).NotTo(ContainSubstring("if ($ssl_server_name != $host)"),
 		"Expected SNI host validation block to be absent when DisableSNIHostValidation is true")
 }
 
+func TestExecuteServers_ProxySSLOptions(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	verifyTLS := &dataplane.VerifyTLS{
+		Hostname:     "backend.example.com",
+		RootCAPath:   "/etc/ssl/certs/ca-certificates.crt",
+		SessionReuse: helpers.GetPointer(false),
+		Protocols:    []string{"TLSv1.2", "TLSv1.3"},
+		Ciphers:      "HIGH:!aNULL",
+	}
+
+	pathRule := func(path string, grpc bool) dataplane.PathRule {
+		return dataplane.PathRule{
+			Path:     path,
+			PathType: dataplane.PathTypeExact,
+			GRPC:     grpc,
+			MatchRules: []dataplane.MatchRule{
+				{
+					BackendGroup: dataplane.BackendGroup{
+						Source: types.NamespacedName{Namespace: "test", Name: "route"},
+						Backends: []dataplane.Backend{
+							{
+								UpstreamName: "test_backend_443",
+								Valid:        true,
+								Weight:       1,
+								VerifyTLS:    verifyTLS,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				Hostname: "example.com",
+				Port:     8080,
+				PathRules: []dataplane.PathRule{
+					pathRule("/https", false),
+					pathRule("/grpc.Service/Method", true),
+				},
+			},
+		},
+	}
+
+	gen := GeneratorImpl{}
+	results := gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	serverConf := string(results[0].data)
+
+	for _, prefix := range []string{"proxy", "grpc"} {
+		g.Expect(serverConf).To(ContainSubstring(prefix + "_ssl_session_reuse off;"))
+		g.Expect(serverConf).To(ContainSubstring(prefix + "_ssl_protocols TLSv1.2 TLSv1.3;"))
+		g.Expect(serverConf).To(ContainSubstring(prefix + "_ssl_ciphers HIGH:!aNULL;"))
+	}
+}
+
+func TestExecuteServers_KeepAliveDisabled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	pathRule := func(path, upstreamName string) dataplane.PathRule {
+		return dataplane.PathRule{
+			Path:     path,
+			PathType: dataplane.PathTypeExact,
+			MatchRules: []dataplane.MatchRule{
+				{
+					BackendGroup: dataplane.BackendGroup{
+						Source: types.NamespacedName{Namespace: "test", Name: "route"},
+						Backends: []dataplane.Backend{
+							{
+								UpstreamName: upstreamName,
+								Valid:        true,
+								Weight:       1,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				Hostname: "example.com",
+				Port:     8080,
+				PathRules: []dataplane.PathRule{
+					pathRule("/disabled", "test_disabled_80"),
+					pathRule("/default", "test_default_80"),
+				},
+			},
+		},
+	}
+
+	keepAliveCheck := newKeepAliveChecker([]http.Upstream{
+		{
+			Name:      "test_disabled_80",
+			KeepAlive: http.UpstreamKeepAlive{Disabled: true},
+		},
+		{
+			Name: "test_default_80",
+		},
+	})
+
+	gen := GeneratorImpl{}
+	results := gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, keepAliveCheck)
+	serverConf := string(results[0].data)
+
+	g.Expect(strings.Count(serverConf, "proxy_http_version 1.0;")).To(Equal(1))
+	g.Expect(serverConf).To(ContainSubstring(`proxy_set_header Connection "close";`))
+	g.Expect(serverConf).To(ContainSubstring("proxy_http_version 1.1;"))
+	g.Expect(serverConf).To(ContainSubstring(`proxy_set_header Connection "$connection_upgrade";`))
+}
+
+func TestExecuteServers_UpstreamHTTP2(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				Hostname: "example.com",
+				Port:     8080,
+				PathRules: []dataplane.PathRule{
+					{
+						Path:     "/",
+						PathType: dataplane.PathTypeExact,
+						MatchRules: []dataplane.MatchRule{
+							{
+								BackendGroup: dataplane.BackendGroup{
+									Source: types.NamespacedName{Namespace: "test", Name: "route"},
+									Backends: []dataplane.Backend{
+										{UpstreamName: "test_foo_80", Valid: true, Weight: 1},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		BaseHTTPConfig: dataplane.BaseHTTPConfig{UpstreamHTTP2: true},
+	}
+
+	gen := GeneratorImpl{}
+	results := gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	serverConf := string(results[0].data)
+
+	g.Expect(serverConf).To(ContainSubstring("proxy_http_version 2;"))
+	g.Expect(serverConf).ToNot(ContainSubstring("proxy_http_version 1.1;"))
+
+	conf.BaseHTTPConfig.UpstreamHTTP2 = false
+	results = gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	serverConf = string(results[0].data)
+
+	g.Expect(serverConf).ToNot(ContainSubstring("proxy_http_version 2;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_http_version 1.1;"))
+}
+
+func TestExecuteServers_GatewayTest(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	pathRule := func(path string) dataplane.PathRule {
+		return dataplane.PathRule{
+			Path:     path,
+			PathType: dataplane.PathTypeExact,
+			GRPC:     path == "/grpc",
+			MatchRules: []dataplane.MatchRule{
+				{
+					BackendGroup: dataplane.BackendGroup{
+						Source: types.NamespacedName{Namespace: "test", Name: "route"},
+						Backends: []dataplane.Backend{
+							{UpstreamName: "test_foo_80", Valid: true, Weight: 1},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				Hostname:  "example.com",
+				Port:      8080,
+				PathRules: []dataplane.PathRule{pathRule("/"), pathRule("/grpc")},
+			},
+		},
+	}
+
+	gen := GeneratorImpl{}
+	results := gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	g.Expect(string(results[0].data)).ToNot(ContainSubstring("X-NGF-Gateway-Test-Upstream"))
+
+	conf.BaseHTTPConfig.GatewayTestToken = "0123456789abcdef"
+	results = gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	serverConf := string(results[0].data)
+
+	// the upstreams of gRPC requests are not reported, and the header is only added to the responses
+	// to the requests of the GatewayTests, so that the location doesn't override the headers of the server.
+	g.Expect(strings.Count(
+		serverConf,
+		"if ($ngf_gateway_test) {\n            add_header X-NGF-Gateway-Test-Upstream $proxy_host always;\n        }",
+	)).To(Equal(1))
+	g.Expect(strings.Count(serverConf, "add_header X-NGF-Gateway-Test-Upstream")).To(Equal(1))
+}
+
+func TestExecuteServers_RejectHandshake(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	conf := dataplane.Configuration{
+		SSLServers: []dataplane.VirtualServer{
+			{
+				Hostname:        "~^",
+				SSL:             &dataplane.SSL{KeyPairID: "test-keypair"},
+				Port:            8443,
+				RejectHandshake: true,
+			},
+			{
+				Hostname: "example.com",
+				SSL:      &dataplane.SSL{KeyPairID: "test-keypair"},
+				Port:     8443,
+			},
+		},
+	}
+
+	gen := GeneratorImpl{}
+	results := gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	serverConf := string(results[0].data)
+
+	g.Expect(strings.Count(serverConf, "ssl_reject_handshake on;")).To(Equal(1))
+	g.Expect(serverConf).To(MatchRegexp(`server_name ~\^;`))
+	g.Expect(strings.Index(serverConf, "ssl_reject_handshake on;")).To(
+		BeNumerically("<", strings.Index(serverConf, "server_name example.com;")),
+	)
+}
+
+func TestExecuteServers_Timeouts(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				Hostname: "example.com",
+				Port:     8080,
+				PathRules: []dataplane.PathRule{
+					{
+						Path:     "/retry",
+						PathType: dataplane.PathTypeExact,
+						MatchRules: []dataplane.MatchRule{
+							{
+								BackendGroup: dataplane.BackendGroup{
+									Source: types.NamespacedName{Namespace: "test", Name: "route"},
+									Backends: []dataplane.Backend{
+										{
+											UpstreamName: "test_backend_80",
+											Valid:        true,
+											Weight:       1,
+										},
+									},
+								},
+								Timeouts: &dataplane.Timeouts{
+									Request:        "10s",
+									BackendRequest: "2s",
+									RetryCodes:     []int{502, 503},
+									Tries:          3,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	gen := GeneratorImpl{}
+	results := gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	serverConf := string(results[0].data)
+
+	g.Expect(serverConf).To(ContainSubstring("proxy_read_timeout 2s;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_send_timeout 2s;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_next_upstream error timeout http_502 http_503;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_next_upstream_tries 3;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_next_upstream_timeout 10s;"))
+}
+
+func TestCreateProxyTimeouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		timeouts *dataplane.Timeouts
+		expected *http.ProxyTimeouts
+		msg      string
+	}{
+		{
+			msg: "no timeouts",
+		},
+		{
+			msg: "request timeout is used for the tries without a backend request timeout",
+			timeouts: &dataplane.Timeouts{
+				Request: "10s",
+			},
+			expected: &http.ProxyTimeouts{
+				TryTimeout:          "10s",
+				NextUpstreamTimeout: "10s",
+			},
+		},
+		{
+			msg: "backend request timeout without a request timeout",
+			timeouts: &dataplane.Timeouts{
+				BackendRequest: "2s",
+			},
+			expected: &http.ProxyTimeouts{
+				TryTimeout: "2s",
+			},
+		},
+		{
+			msg: "retries",
+			timeouts: &dataplane.Timeouts{
+				Request:        "10s",
+				BackendRequest: "2s",
+				RetryCodes:     []int{429, 503},
+				Tries:          4,
+			},
+			expected: &http.ProxyTimeouts{
+				TryTimeout:          "2s",
+				NextUpstreamTimeout: "10s",
+				NextUpstream:        "error timeout http_429 http_503",
+				NextUpstreamTries:   4,
+			},
+		},
+		{
+			msg: "retries without codes",
+			timeouts: &dataplane.Timeouts{
+				Tries: 2,
+			},
+			expected: &http.ProxyTimeouts{
+				NextUpstream:      "error timeout",
+				NextUpstreamTries: 2,
+			},
+		},
+		{
+			msg: "a single try disables the retries",
+			timeouts: &dataplane.Timeouts{
+				Tries: 1,
+			},
+			expected: &http.ProxyTimeouts{
+				NextUpstream: "off",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(createProxyTimeouts(test.timeouts)).To(Equal(test.expected))
+		})
+	}
+}
+
 func TestCreateBaseProxySetHeadersWithExternalName(t *testing.T) {
 	t.Parallel()
 
@@ -4988,3 +5765,37 @@ func TestCreateBaseProxySetHeadersWithExternalName(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteServers_TokenReview(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{IsDefault: true, Port: 8080},
+			{Hostname: "example.com", Port: 8080},
+		},
+	}
+	gen := GeneratorImpl{}
+
+	results := gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	g.Expect(string(results[0].data)).ToNot(ContainSubstring(http.TokenReviewPath))
+
+	conf.TokenReview = &dataplane.TokenReview{Server: "ngf-nginx-gateway.nginx-gateway.svc:9444"}
+	results = gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	serverConf := string(results[0].data)
+	g.Expect(serverConf).To(ContainSubstring("location ^~ /_ngf-internal-token-review/ {\n        internal;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_pass_request_body off;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_ssl_verify on;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_ssl_certificate /var/run/secrets/ngf/tls.crt;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_ssl_trusted_certificate /var/run/secrets/ngf/ca.crt;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_ssl_name ngf-nginx-gateway.nginx-gateway.svc;"))
+	g.Expect(serverConf).To(ContainSubstring("proxy_pass https://ngf-nginx-gateway.nginx-gateway.svc:9444/;"))
+
+	// without the verifier, the requests that require a token are rejected
+	conf.TokenReview = &dataplane.TokenReview{}
+	results = gen.executeServers(conf, &policiesfakes.FakeGenerator{}, nil, defaultKeepAliveChecker)
+	serverConf = string(results[0].data)
+	g.Expect(serverConf).To(ContainSubstring("location ^~ /_ngf-internal-token-review/ {\n        internal;\n        return 500;"))
+	g.Expect(serverConf).ToNot(ContainSubstring("proxy_pass_request_body off;"))
+}
@@ -2,7 +2,6 @@ package config
 
 import (
 	"fmt"
-	"math"
 	"strings"
 	gotemplate "text/template"
 
@@ -15,9 +14,13 @@ import (
 
 var splitClientsTemplate = gotemplate.Must(gotemplate.New("split_clients").Parse(splitClientsTemplateText))
 
-func executeSplitClients(conf dataplane.Configuration) []executeResult {
-	splitClients := collectAllSplitClients(conf)
+func newExecuteSplitClientsFunc(splitClients []http.SplitClient) executeFunc {
+	return func(_ dataplane.Configuration) []executeResult {
+		return executeSplitClients(splitClients)
+	}
+}
 
+func executeSplitClients(splitClients []http.SplitClient) []executeResult {
 	result := executeResult{
 		dest: httpConfigFile,
 		data: helpers.MustExecuteTemplate(splitClientsTemplate, splitClients),
@@ -139,12 +142,13 @@ func createBackendGroupSplitClientDistributions(group dataplane.BackendGroup) []
 
 	backends := group.Backends
 
-	totalWeight := int32(0)
+	weights := make([]int64, 0, len(backends))
 	for _, b := range backends {
-		totalWeight += b.Weight
+		weights = append(weights, int64(b.Weight))
 	}
 
-	if totalWeight == 0 {
+	buckets := distributeBuckets(weights)
+	if buckets == nil {
 		return []http.SplitClientDistribution{
 			{
 				Percent: "100",
@@ -155,32 +159,13 @@ func createBackendGroupSplitClientDistributions(group dataplane.BackendGroup) []
 
 	distributions := make([]http.SplitClientDistribution, 0, len(backends))
 
-	// The percentage of all backends cannot exceed 100.
-	availablePercentage := float64(100)
-
-	// Iterate over all backends except the last one.
-	// The last backend will get the remaining percentage.
-	for i := range len(backends) - 1 {
-		b := backends[i]
-
-		percentage := percentOf(b.Weight, totalWeight)
-		availablePercentage -= percentage
-
+	for i, b := range backends {
 		distributions = append(distributions, http.SplitClientDistribution{
-			Percent: fmt.Sprintf("%.2f", percentage),
+			Percent: bucketsToPercent(buckets[i]),
 			Value:   getSplitClientValue(b, group.Source, group.RuleIdx, group.PathRuleIdx),
 		})
 	}
 
-	// The last backend gets the remaining percentage.
-	// This is done to guarantee that the sum of all percentages is 100.
-	lastBackend := backends[len(backends)-1]
-
-	distributions = append(distributions, http.SplitClientDistribution{
-		Percent: fmt.Sprintf("%.2f", availablePercentage),
-		Value:   getSplitClientValue(lastBackend, group.Source, group.RuleIdx, group.PathRuleIdx),
-	})
-
 	return distributions
 }
 
@@ -200,14 +185,75 @@ func getSplitClientValue(b dataplane.Backend, source types.NamespacedName, ruleI
 	return invalidBackendRef
 }
 
-// percentOf returns the percentage of a weight out of a totalWeight.
-// The percentage is rounded to 2 decimal places using the Floor method.
-// Floor is used here in order to guarantee that the sum of all percentages does not exceed 100.
-// Ex. percentOf(2, 3) = 66.66
-// Ex. percentOf(800, 2000) = 40.00.
-func percentOf(weight, totalWeight int32) float64 {
-	p := (float64(weight) * 100) / float64(totalWeight)
-	return math.Floor(p*100) / 100
+// splitClientsBuckets is the number of buckets that the requests are split into. NGINX supports the percentages
+// in split_clients with up to two decimal places, so the finest split is one bucket per 0.01% of the requests.
+const splitClientsBuckets = 10000
+
+// distributeBuckets distributes the splitClientsBuckets between the weights, in proportion to the weights.
+// It returns nil if the sum of the weights is 0.
+//
+// The buckets are distributed with the largest remainder method, so that the sum of the buckets is always
+// splitClientsBuckets, and each weight gets the closest number of buckets to its exact share.
+// On a tie, the later weight gets the remaining bucket.
+// A non-zero weight always gets at least one bucket, so a backend with a tiny weight, like a canary with 1:99999,
+// still receives traffic. The bucket is taken from the weight with the most buckets.
+// Ex. distributeBuckets([]int64{999, 1}) = [9990, 10]
+// Ex. distributeBuckets([]int64{1, 1, 1}) = [3333, 3333, 3334].
+func distributeBuckets(weights []int64) []int64 {
+	var totalWeight int64
+	for _, w := range weights {
+		totalWeight += w
+	}
+
+	if totalWeight == 0 {
+		return nil
+	}
+
+	buckets := make([]int64, len(weights))
+	remainders := make([]int64, len(weights))
+	distributed := int64(0)
+
+	for i, w := range weights {
+		buckets[i] = w * splitClientsBuckets / totalWeight
+		remainders[i] = w * splitClientsBuckets % totalWeight
+		distributed += buckets[i]
+	}
+
+	for ; distributed < splitClientsBuckets; distributed++ {
+		largest := 0
+		for i := range remainders {
+			if remainders[i] >= remainders[largest] {
+				largest = i
+			}
+		}
+
+		buckets[largest]++
+		remainders[largest] = -1
+	}
+
+	for i, w := range weights {
+		if w == 0 || buckets[i] > 0 {
+			continue
+		}
+
+		most := 0
+		for j := range buckets {
+			if buckets[j] > buckets[most] {
+				most = j
+			}
+		}
+
+		buckets[most]--
+		buckets[i]++
+	}
+
+	return buckets
+}
+
+// bucketsToPercent formats a number of buckets as a percentage with two decimal places.
+// Ex. bucketsToPercent(3334) = "33.34".
+func bucketsToPercent(buckets int64) string {
+	return fmt.Sprintf("%d.%02d", buckets/100, buckets%100)
 }
 
 func backendGroupNeedsSplit(group dataplane.BackendGroup) bool {
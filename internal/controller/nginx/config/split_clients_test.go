@@ -349,7 +349,7 @@ func TestExecuteSplitClients(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
 
-			splitResults := executeSplitClients(test.configuration)
+			splitResults := executeSplitClients(collectAllSplitClients(test.configuration))
 
 			g.Expect(splitResults).To(HaveLen(1))
 			g.Expect(splitResults[0].dest).To(Equal(httpConfigFile))
@@ -820,6 +820,65 @@ func TestCreateBackendGroupSplitClientDistributions(t *testing.T) {
 				},
 			},
 		},
+		{
+			msg: "two backends; fine-grained canary",
+			backends: []dataplane.Backend{
+				{
+					UpstreamName: "stable",
+					Valid:        true,
+					Weight:       999,
+				},
+				{
+					UpstreamName: "canary",
+					Valid:        true,
+					Weight:       1,
+				},
+			},
+			expDistributions: []http.SplitClientDistribution{
+				{
+					Percent: "99.90",
+					Value:   "stable",
+				},
+				{
+					Percent: "0.10",
+					Value:   "canary",
+				},
+			},
+		},
+		{
+			msg: "three backends; zero weight and tiny weight",
+			backends: []dataplane.Backend{
+				{
+					UpstreamName: "canary",
+					Valid:        true,
+					Weight:       1,
+				},
+				{
+					UpstreamName: "stable",
+					Valid:        true,
+					Weight:       100000,
+				},
+				{
+					UpstreamName: "disabled",
+					Valid:        true,
+					Weight:       0,
+				},
+			},
+			expDistributions: []http.SplitClientDistribution{
+				{
+					Percent: "0.01",
+					Value:   "canary",
+				},
+				{
+					Percent: "99.99",
+					Value:   "stable",
+				},
+				{
+					Percent: "0.00",
+					Value:   "disabled",
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -903,67 +962,72 @@ func TestGetSplitClientValue(t *testing.T) {
 	}
 }
 
-func TestPercentOf(t *testing.T) {
+func TestDistributeBuckets(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		msg         string
-		weight      int32
-		totalWeight int32
-		expPercent  float64
+		msg        string
+		weights    []int64
+		expBuckets []int64
 	}{
 		{
-			msg:         "50/100",
-			weight:      50,
-			totalWeight: 100,
-			expPercent:  50,
+			msg:        "total weight 0",
+			weights:    []int64{0, 0},
+			expBuckets: nil,
+		},
+		{
+			msg:        "50/100",
+			weights:    []int64{50, 50},
+			expBuckets: []int64{5000, 5000},
+		},
+		{
+			msg:        "2000/4000",
+			weights:    []int64{2000, 2000},
+			expBuckets: []int64{5000, 5000},
+		},
+		{
+			msg:        "single non-zero weight",
+			weights:    []int64{0, 5},
+			expBuckets: []int64{0, 10000},
 		},
 		{
-			msg:         "2000/4000",
-			weight:      2000,
-			totalWeight: 4000,
-			expPercent:  50,
+			msg:        "2/3",
+			weights:    []int64{2, 1},
+			expBuckets: []int64{6667, 3333},
 		},
 		{
-			msg:         "100/100",
-			weight:      100,
-			totalWeight: 100,
-			expPercent:  100,
+			msg:        "4/15",
+			weights:    []int64{4, 11},
+			expBuckets: []int64{2667, 7333},
 		},
 		{
-			msg:         "5/5",
-			weight:      5,
-			totalWeight: 5,
-			expPercent:  100,
+			msg:        "300/2400",
+			weights:    []int64{300, 2100},
+			expBuckets: []int64{1250, 8750},
 		},
 		{
-			msg:         "0/8000",
-			weight:      0,
-			totalWeight: 8000,
-			expPercent:  0,
+			msg:        "equal weights; the last weight gets the remaining bucket",
+			weights:    []int64{1, 1, 1},
+			expBuckets: []int64{3333, 3333, 3334},
 		},
 		{
-			msg:         "2/3",
-			weight:      2,
-			totalWeight: 3,
-			expPercent:  66.66,
+			msg:        "999:1",
+			weights:    []int64{999, 1},
+			expBuckets: []int64{9990, 10},
 		},
 		{
-			msg:         "4/15",
-			weight:      4,
-			totalWeight: 15,
-			expPercent:  26.66,
+			msg:        "9999:1",
+			weights:    []int64{9999, 1},
+			expBuckets: []int64{9999, 1},
 		},
 		{
-			msg:         "800/2000",
-			weight:      800,
-			totalWeight: 2000,
-			expPercent:  40,
+			msg:        "tiny weight gets one bucket",
+			weights:    []int64{99999, 1},
+			expBuckets: []int64{9999, 1},
 		},
 		{
-			msg:         "300/2400",
-			weight:      300,
-			totalWeight: 2400,
-			expPercent:  12.5,
+			msg:        "max weights don't overflow",
+			weights:    []int64{1000000, 1000000, 1},
+			expBuckets: []int64{4999, 5000, 1},
 		},
 	}
 
@@ -971,12 +1035,31 @@ func TestPercentOf(t *testing.T) {
 		t.Run(test.msg, func(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
-			percent := percentOf(test.weight, test.totalWeight)
-			g.Expect(percent).To(Equal(test.expPercent))
+			buckets := distributeBuckets(test.weights)
+			g.Expect(buckets).To(Equal(test.expBuckets))
+
+			if buckets != nil {
+				var sum int64
+				for _, b := range buckets {
+					sum += b
+				}
+				g.Expect(sum).To(BeEquivalentTo(splitClientsBuckets))
+			}
 		})
 	}
 }
 
+func TestBucketsToPercent(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(bucketsToPercent(0)).To(Equal("0.00"))
+	g.Expect(bucketsToPercent(1)).To(Equal("0.01"))
+	g.Expect(bucketsToPercent(10)).To(Equal("0.10"))
+	g.Expect(bucketsToPercent(3334)).To(Equal("33.34"))
+	g.Expect(bucketsToPercent(10000)).To(Equal("100.00"))
+}
+
 func TestBackendGroupNeedsSplit(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -2,14 +2,19 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	gotemplate "text/template"
 
+	pb "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/upstreamsettings"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/stream"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/types"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 )
 
@@ -37,8 +42,20 @@ const (
 	defaultLBMethod = "random two least_conn"
 )
 
-// keepAliveChecker takes an upstream name and returns if it has keep alive settings enabled.
-type keepAliveChecker func(upstreamName string) bool
+// keepAliveMode is the mode of the keep-alive connections to the servers of an upstream.
+type keepAliveMode int
+
+const (
+	// keepAliveDefault means that the upstream has no keep-alive settings.
+	keepAliveDefault keepAliveMode = iota
+	// keepAliveEnabled means that the upstream caches the keep-alive connections.
+	keepAliveEnabled
+	// keepAliveDisabled means that the connections to the upstream are closed after every request.
+	keepAliveDisabled
+)
+
+// keepAliveChecker takes an upstream name and returns the mode of its keep-alive connections.
+type keepAliveChecker func(upstreamName string) keepAliveMode
 
 func newKeepAliveChecker(upstreams []http.Upstream) keepAliveChecker {
 	upstreamMap := make(map[string]http.Upstream)
@@ -47,12 +64,19 @@ func newKeepAliveChecker(upstreams []http.Upstream) keepAliveChecker {
 		upstreamMap[upstream.Name] = upstream
 	}
 
-	return func(upstreamName string) bool {
-		if upstream, exists := upstreamMap[upstreamName]; exists {
-			return upstream.KeepAlive.Connections != 0
+	return func(upstreamName string) keepAliveMode {
+		upstream, exists := upstreamMap[upstreamName]
+
+		switch {
+		case !exists:
+			return keepAliveDefault
+		case upstream.KeepAlive.Disabled:
+			return keepAliveDisabled
+		case upstream.KeepAlive.Connections != 0:
+			return keepAliveEnabled
+		default:
+			return keepAliveDefault
 		}
-
-		return false
 	}
 }
 
@@ -82,6 +106,43 @@ func (g GeneratorImpl) executeStreamUpstreams(conf dataplane.Configuration) []ex
 	return []executeResult{result}
 }
 
+// generateStateFiles generates the files for the state files of the NGINX Plus upstreams.
+// NGINX Plus saves the servers of an upstream, which are updated with the API, to its state file,
+// and restores them from the file when it restarts. The files are unmanaged, so that the agent doesn't overwrite
+// them, but deletes the state file of an upstream once the upstream is removed.
+func generateStateFiles(httpUpstreams []http.Upstream, streamUpstreams []stream.Upstream) []agent.File {
+	stateFiles := make([]string, 0, len(httpUpstreams)+len(streamUpstreams))
+
+	for _, u := range httpUpstreams {
+		if u.StateFile != "" {
+			stateFiles = append(stateFiles, u.StateFile)
+		}
+	}
+
+	for _, u := range streamUpstreams {
+		if u.StateFile != "" {
+			stateFiles = append(stateFiles, u.StateFile)
+		}
+	}
+
+	// upstreams of the same Service port share the state file
+	slices.Sort(stateFiles)
+	stateFiles = slices.Compact(stateFiles)
+
+	files := make([]agent.File, 0, len(stateFiles))
+	for _, name := range stateFiles {
+		files = append(files, agent.File{
+			Meta: &pb.FileMeta{
+				Name:        name,
+				Permissions: file.RegularFileMode,
+			},
+			Unmanaged: true,
+		})
+	}
+
+	return files
+}
+
 func (g GeneratorImpl) createStreamUpstreams(upstreams []dataplane.Upstream) []stream.Upstream {
 	ups := make([]stream.Upstream, 0, len(upstreams))
 
@@ -5,10 +5,12 @@ import (
 	"strings"
 	"testing"
 
+	pb "github.com/nginx/agent/v3/api/grpc/mpi/v1"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/upstreamsettings"
@@ -16,6 +18,7 @@ import (
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/types"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 )
 
@@ -1232,6 +1235,51 @@ func TestCreateStreamUpstreamPlus(t *testing.T) {
 	g.Expect(result).To(Equal(expectedUpstream))
 }
 
+func TestGenerateStateFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	httpUpstreams := []http.Upstream{
+		{
+			Name:      "up1",
+			StateFile: stateDir + "/test_svc_80.conf",
+		},
+		{
+			Name:      "up1-session-persistence",
+			StateFile: stateDir + "/test_svc_80.conf",
+		},
+		{
+			Name: "resolve-servers",
+		},
+	}
+	streamUpstreams := []stream.Upstream{
+		{
+			Name:      "stream-up",
+			StateFile: stateDir + "/stream-up.conf",
+		},
+	}
+
+	expectedFiles := []agent.File{
+		{
+			Meta: &pb.FileMeta{
+				Name:        stateDir + "/stream-up.conf",
+				Permissions: file.RegularFileMode,
+			},
+			Unmanaged: true,
+		},
+		{
+			Meta: &pb.FileMeta{
+				Name:        stateDir + "/test_svc_80.conf",
+				Permissions: file.RegularFileMode,
+			},
+			Unmanaged: true,
+		},
+	}
+
+	g.Expect(generateStateFiles(httpUpstreams, streamUpstreams)).To(Equal(expectedFiles))
+	g.Expect(generateStateFiles(nil, nil)).To(BeEmpty())
+}
+
 func TestKeepAliveChecker(t *testing.T) {
 	t.Parallel()
 
@@ -1438,17 +1486,42 @@ func TestKeepAliveChecker(t *testing.T) {
 			keepAliveCheck := newKeepAliveChecker(test.upstreams)
 
 			for index, upstream := range test.upstreams {
-				g.Expect(keepAliveCheck(upstream.Name)).To(Equal(test.expKeepAliveEnabled[index]))
+				g.Expect(keepAliveCheck(upstream.Name) == keepAliveEnabled).To(Equal(test.expKeepAliveEnabled[index]))
 			}
 		})
 	}
 }
 
+func TestKeepAliveCheckerModes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	keepAliveCheck := newKeepAliveChecker([]http.Upstream{
+		{
+			Name: "default",
+		},
+		{
+			Name:      "enabled",
+			KeepAlive: http.UpstreamKeepAlive{Connections: 1},
+		},
+		{
+			Name:      "disabled",
+			KeepAlive: http.UpstreamKeepAlive{Disabled: true},
+		},
+	})
+
+	g.Expect(keepAliveCheck("default")).To(Equal(keepAliveDefault))
+	g.Expect(keepAliveCheck("enabled")).To(Equal(keepAliveEnabled))
+	g.Expect(keepAliveCheck("disabled")).To(Equal(keepAliveDisabled))
+	g.Expect(keepAliveCheck("unknown")).To(Equal(keepAliveDefault))
+}
+
 func TestExecuteUpstreams_LoadBalancingMethod(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		expectedSubStrings map[string]int
+		hashKey            *ngfAPI.HashKey
 		name               string
 		lbType             ngfAPI.LoadBalancingType
 		HashMethodKey      ngfAPI.HashMethodKey
@@ -1506,6 +1579,18 @@ func TestExecuteUpstreams_LoadBalancingMethod(t *testing.T) {
 				"hash $remote_addr consistent;": 2,
 			},
 		},
+		{
+			name:   "hash consistent load balancing method with header hash key",
+			lbType: ngfAPI.LoadBalancingTypeHashConsistent,
+			hashKey: &ngfAPI.HashKey{
+				Header: helpers.GetPointer("X-Tenant-ID"),
+			},
+			expectedSubStrings: map[string]int{
+				"upstream up1-usp-ipv4":              1,
+				"upstream up2-usp-ipv6":              1,
+				"hash $http_x_tenant_id consistent;": 2,
+			},
+		},
 		{
 			name:   "random load balancing method",
 			lbType: ngfAPI.LoadBalancingTypeRandom,
@@ -1603,6 +1688,7 @@ func TestExecuteUpstreams_LoadBalancingMethod(t *testing.T) {
 							Spec: ngfAPI.UpstreamSettingsPolicySpec{
 								LoadBalancingMethod: helpers.GetPointer(tt.lbType),
 								HashMethodKey:       helpers.GetPointer(tt.HashMethodKey),
+								HashKey:             tt.hashKey,
 							},
 						},
 					},
@@ -1624,6 +1710,7 @@ func TestExecuteUpstreams_LoadBalancingMethod(t *testing.T) {
 							Spec: ngfAPI.UpstreamSettingsPolicySpec{
 								LoadBalancingMethod: helpers.GetPointer(tt.lbType),
 								HashMethodKey:       helpers.GetPointer(tt.HashMethodKey),
+								HashKey:             tt.hashKey,
 							},
 						},
 					},
@@ -9,6 +9,8 @@ import (
 
 	"github.com/dlclark/regexp2"
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
 )
 
 const (
@@ -17,7 +19,8 @@ const (
 )
 
 var (
-	pathRegexp   = regexp2.MustCompile("^"+pathFmt+"$", 0)
+	// \z is used instead of $, because $ in regexp2 also matches before a trailing newline.
+	pathRegexp   = regexp2.MustCompile("^"+pathFmt+`\z`, 0)
 	pathExamples = []string{"/", "/path", "/path/subpath-123"}
 )
 
@@ -64,6 +67,75 @@ func validateEscapedStringNoVarExpansion(value string, examples []string) error
 	return nil
 }
 
+// validateHeaderValueWithVariables validates a header value that is surrounded by " in the NGINX config for
+// a directive that expands variables, like add_header. The value has the same rules as in validateEscapedString,
+// but a variable is only allowed if it is in the allowedVariables, which contains the variable names with the '$'
+// prefix. A variable can be written as $name or ${name}. A '$' can't be escaped, because NGINX doesn't support it.
+// If the value is invalid, the function returns an error that includes the specified examples of valid values.
+func validateHeaderValueWithVariables(value string, allowedVariables map[string]struct{}, examples []string) error {
+	if err := validateEscapedString(value, examples); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if value[i+1] == '$' {
+				return errors.New("'$' cannot be escaped")
+			}
+			// skip the escaped character
+			i++
+		case '$':
+			name, length := parseVariableName(value[i+1:])
+			if name == "" {
+				return fmt.Errorf("'$' at position %d must be followed by a variable name", i)
+			}
+
+			if valid, allowed := validateInSupportedValues("$"+name, allowedVariables); !valid {
+				if len(allowed) == 0 {
+					return fmt.Errorf("variable $%s is not allowed, no variables are supported", name)
+				}
+				return fmt.Errorf("variable $%s is not allowed, supported variables are: %s",
+					name, strings.Join(allowed, ", "))
+			}
+
+			i += length
+		}
+	}
+
+	return nil
+}
+
+// parseVariableName parses the name of the variable at the start of the value, which is the part of a variable
+// after the '$'. It returns the name and the length of the variable in the value. The name is empty if the value
+// doesn't start with a variable name.
+func parseVariableName(value string) (name string, length int) {
+	isNameChar := func(c byte) bool {
+		return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+	}
+
+	if strings.HasPrefix(value, "{") {
+		end := strings.IndexByte(value, '}')
+		if end == -1 {
+			return "", 0
+		}
+
+		for j := 1; j < end; j++ {
+			if !isNameChar(value[j]) {
+				return "", 0
+			}
+		}
+
+		return value[1:end], end + 1
+	}
+
+	for length < len(value) && isNameChar(value[length]) {
+		length++
+	}
+
+	return value[:length], length
+}
+
 const (
 	invalidHeadersErrMsg string = "unsupported header name configured, unsupported names are: "
 	maxHeaderLength      int    = 256
@@ -153,7 +225,8 @@ func validatePathInRegexMatch(path string) error {
 type HTTPDurationValidator struct{}
 
 func (d HTTPDurationValidator) ValidateDuration(duration string) (string, error) {
-	return d.validateDurationCanBeConvertedToNginxFormat(duration)
+	converted, err := d.validateDurationCanBeConvertedToNginxFormat(duration)
+	return converted, errcodes.Wrap(errcodes.InvalidDuration, err)
 }
 
 // validateDurationCanBeConvertedToNginxFormat parses a Gateway API duration and returns a single-unit,
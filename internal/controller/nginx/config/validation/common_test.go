@@ -3,6 +3,8 @@ package validation
 import (
 	"strings"
 	"testing"
+
+	. "github.com/onsi/gomega"
 )
 
 func TestValidateEscapedString(t *testing.T) {
@@ -46,6 +48,56 @@ func TestValidateEscapedStringNoVarExpansion(t *testing.T) {
 	)
 }
 
+func TestValidateHeaderValueWithVariables(t *testing.T) {
+	t.Parallel()
+	allowedVariables := map[string]struct{}{
+		"$request_id":  {},
+		"$remote_addr": {},
+	}
+	validator := func(value string) error {
+		return validateHeaderValueWithVariables(value, allowedVariables, []string{"example"})
+	}
+
+	testValidValuesForSimpleValidator(
+		t,
+		validator,
+		`test`,
+		`test test`,
+		`\"`,
+		`\\`,
+		`$request_id`,
+		`id=$request_id; addr=${remote_addr}`,
+		`${request_id}suffix`,
+		`\\$request_id`,
+	)
+	testInvalidValuesForSimpleValidator(
+		t,
+		validator,
+		`\`,
+		`test"test`,
+		`$host`,
+		`${host}`,
+		`$request_id$host`,
+		`$`,
+		`test $`,
+		`${}`,
+		`${request_id`,
+		`${request-id}`,
+		`\$request_id`,
+	)
+}
+
+func TestValidateHeaderValueWithVariablesNoneAllowed(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	err := validateHeaderValueWithVariables(`$request_id`, nil, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("no variables are supported")))
+
+	err = validateHeaderValueWithVariables(`$host`, map[string]struct{}{"$request_id": {}}, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("supported variables are: $request_id")))
+}
+
 func TestValidateValidHeaderName(t *testing.T) {
 	t.Parallel()
 	validator := validateHeaderName
@@ -93,6 +145,7 @@ func TestValidatePathForFilters(t *testing.T) {
 		`path`,
 		`$path`,
 		"/path$",
+		"/path\n",
 	)
 }
 
@@ -115,6 +168,7 @@ func TestValidatePathInMatch(t *testing.T) {
 		"/path{",
 		"/path}",
 		"/path;",
+		"/path\n",
 		"path",
 		"",
 	)
@@ -0,0 +1,186 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+// The fuzz targets check that the values accepted by the Validator can't break out of the NGINX configuration
+// context they are used in. Run them with, for example:
+//
+//	go test -fuzz=FuzzValidatePath ./internal/controller/nginx/config/validation/
+
+func FuzzValidatePath(f *testing.F) {
+	for _, seed := range []string{"", "/", "/path/subpath-123", "/path;", "/path\n", "/$path", "path"} {
+		f.Add(seed)
+	}
+
+	v := NewValidator()
+
+	f.Fuzz(func(t *testing.T, path string) {
+		if v.ValidatePath(path) != nil {
+			return
+		}
+
+		if path == "" {
+			return
+		}
+
+		if !strings.HasPrefix(path, "/") {
+			t.Errorf("accepted path %q that doesn't start with /", path)
+		}
+		if strings.ContainsAny(path, " \t\n\v\f\r{};$") {
+			t.Errorf("accepted path %q with a forbidden character", path)
+		}
+	})
+}
+
+func FuzzValidatePathInMatch(f *testing.F) {
+	for _, seed := range []string{"/", "/path/subpath-123", "/path{", "/path\r\n", "", "path"} {
+		f.Add(seed)
+	}
+
+	v := NewValidator()
+
+	f.Fuzz(func(t *testing.T, path string) {
+		if v.ValidatePathInMatch(path) != nil {
+			return
+		}
+
+		if !strings.HasPrefix(path, "/") {
+			t.Errorf("accepted path %q that doesn't start with /", path)
+		}
+		if strings.ContainsAny(path, " \t\n\v\f\r{};") {
+			t.Errorf("accepted path %q with a forbidden character", path)
+		}
+	})
+}
+
+func FuzzValidateHeaderName(f *testing.F) {
+	for _, seed := range []string{"X-Forwarded-For", "Host", "CONNECTION", "X Header", "X-Header\n", "X:Header"} {
+		f.Add(seed)
+	}
+
+	v := NewValidator()
+
+	f.Fuzz(func(t *testing.T, name string) {
+		if v.ValidateHeaderName(name) != nil {
+			return
+		}
+
+		if name == "" || len(name) > maxHeaderLength {
+			t.Errorf("accepted header name %q with invalid length", name)
+		}
+		if strings.IndexFunc(name, func(r rune) bool {
+			return r != '-' && (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+		}) != -1 {
+			t.Errorf("accepted header name %q with a forbidden character", name)
+		}
+		if _, unsupported := invalidHeaders[strings.ToLower(name)]; unsupported {
+			t.Errorf("accepted unsupported header name %q", name)
+		}
+	})
+}
+
+func FuzzValidateHeaderValue(f *testing.F) {
+	for _, seed := range []string{"my-header-value", `\"quoted\"`, `"`, `\`, `$host`, `\$host`, `value\\`} {
+		f.Add(seed)
+	}
+
+	v := NewValidator()
+
+	f.Fuzz(func(t *testing.T, value string) {
+		if v.ValidateHeaderValue(value) != nil {
+			return
+		}
+
+		checkEscapedStringNoVarExpansion(t, value)
+	})
+}
+
+func FuzzValidateHeaderValueWithVariables(f *testing.F) {
+	for _, seed := range []string{"$request_id", "${request_id}x", "$host", "${}", `\$request_id`, `"$request_id"`} {
+		f.Add(seed)
+	}
+
+	v := NewValidator()
+	allowedVariables := map[string]struct{}{"$request_id": {}}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		if v.ValidateHeaderValueWithVariables(value, allowedVariables) != nil {
+			return
+		}
+
+		// the allowed variable is the only one that can be in the value
+		withoutVariables := strings.NewReplacer("${request_id}", "", "$request_id", "").Replace(value)
+		checkEscapedStringNoVarExpansion(t, withoutVariables)
+	})
+}
+
+func FuzzValidateEscapedString(f *testing.F) {
+	for _, seed := range []string{"test test", `\\`, `test"test`, `$test`, `\$test`, `test\`} {
+		f.Add(seed)
+	}
+
+	v := NewValidator()
+
+	f.Fuzz(func(t *testing.T, value string) {
+		if v.ValidateEscapedString(value) != nil {
+			return
+		}
+
+		checkEscapedStringNoVarExpansion(t, value)
+	})
+}
+
+func FuzzValidateDuration(f *testing.F) {
+	for _, seed := range []string{"5ms", "10s", "1000h", "10", "10000s", "1s1ms", "10s;"} {
+		f.Add(seed)
+	}
+
+	v := NewValidator()
+
+	f.Fuzz(func(t *testing.T, duration string) {
+		if v.ValidateDuration(duration) != nil {
+			return
+		}
+
+		number := strings.TrimRight(duration, "msh")
+		if number == "" || len(number) > 4 {
+			t.Errorf("accepted duration %q with invalid number", duration)
+		}
+		if strings.IndexFunc(number, func(r rune) bool { return r < '0' || r > '9' }) != -1 {
+			t.Errorf("accepted duration %q with a forbidden character", duration)
+		}
+		if unit := duration[len(number):]; unit != "" && unit != "ms" && unit != "s" && unit != "m" && unit != "h" {
+			t.Errorf("accepted duration %q with invalid unit", duration)
+		}
+	})
+}
+
+// checkEscapedStringNoVarExpansion checks that a value surrounded by double quotes in the NGINX configuration
+// can't end the string early and doesn't contain variables.
+func checkEscapedStringNoVarExpansion(t *testing.T, value string) {
+	t.Helper()
+
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '"':
+			t.Errorf("accepted value %q with an unescaped double quote", value)
+			return
+		case '$':
+			t.Errorf("accepted value %q with a variable", value)
+			return
+		case '\\':
+			if i == len(value)-1 {
+				t.Errorf("accepted value %q that ends with an unescaped backslash", value)
+				return
+			}
+			if value[i+1] == '$' {
+				t.Errorf("accepted value %q with an escaped variable", value)
+				return
+			}
+			i++
+		}
+	}
+}
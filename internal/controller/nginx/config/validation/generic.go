@@ -1,10 +1,11 @@
 package validation
 
 import (
-	"errors"
 	"regexp"
 
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
 )
 
 // GenericValidator validates values for generic cases in the nginx conf.
@@ -13,7 +14,7 @@ type GenericValidator struct{}
 // ValidateEscapedStringNoVarExpansion ensures that no invalid characters are included in the string value that
 // could lead to unwanted nginx behavior.
 func (GenericValidator) ValidateEscapedStringNoVarExpansion(value string) error {
-	return validateEscapedStringNoVarExpansion(value, nil)
+	return errcodes.Wrap(errcodes.InvalidEscapedString, validateEscapedStringNoVarExpansion(value, nil))
 }
 
 const (
@@ -32,7 +33,10 @@ func (GenericValidator) ValidateServiceName(name string) error {
 			"svc_1",
 		}
 
-		return errors.New(k8svalidation.RegexError(alphaNumericStringErrMsg, alphaNumericStringFmt, examples...))
+		return errcodes.New(
+			errcodes.InvalidServiceName,
+			k8svalidation.RegexError(alphaNumericStringErrMsg, alphaNumericStringFmt, examples...),
+		)
 	}
 
 	return nil
@@ -55,7 +59,10 @@ func (GenericValidator) ValidateNginxDuration(duration string) error {
 			"1000h",
 		}
 
-		return errors.New(k8svalidation.RegexError(durationStringFmt, durationStringErrMsg, examples...))
+		return errcodes.New(
+			errcodes.InvalidNginxDuration,
+			k8svalidation.RegexError(durationStringFmt, durationStringErrMsg, examples...),
+		)
 	}
 
 	return nil
@@ -78,7 +85,10 @@ func (GenericValidator) ValidateNginxSize(size string) error {
 			"1g",
 		}
 
-		return errors.New(k8svalidation.RegexError(sizeStringFmt, sizeStringErrMsg, examples...))
+		return errcodes.New(
+			errcodes.InvalidNginxSize,
+			k8svalidation.RegexError(sizeStringFmt, sizeStringErrMsg, examples...),
+		)
 	}
 
 	return nil
@@ -101,15 +111,18 @@ func (GenericValidator) ValidateEndpoint(endpoint string) error {
 			"http://my-endpoint",
 		}
 
-		return errors.New(k8svalidation.RegexError(endpointStringFmt, endpointStringErrMsg, examples...))
+		return errcodes.New(
+			errcodes.InvalidEndpoint,
+			k8svalidation.RegexError(endpointStringFmt, endpointStringErrMsg, examples...),
+		)
 	}
 
 	return nil
 }
 
 const (
-	variableNameFmt    = `\$[a-z_]+`
-	variableNameErrMsg = "must start with '$' followed by lowercase letters and underscores only"
+	variableNameFmt    = `\$[A-Za-z0-9_]+`
+	variableNameErrMsg = "must start with '$' followed by letters, digits and underscores only"
 )
 
 var variableNameRegexp = regexp.MustCompile("^" + variableNameFmt + "$")
@@ -122,7 +135,10 @@ func (GenericValidator) ValidateNginxVariableName(name string) error {
 			"$remote_addr",
 		}
 
-		return errors.New(k8svalidation.RegexError(variableNameFmt, variableNameErrMsg, examples...))
+		return errcodes.New(
+			errcodes.InvalidNginxVariableName,
+			k8svalidation.RegexError(variableNameFmt, variableNameErrMsg, examples...),
+		)
 	}
 
 	return nil
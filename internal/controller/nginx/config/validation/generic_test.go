@@ -123,6 +123,8 @@ func TestValidateNginxVariableName(t *testing.T) {
 		`$upstream_bytes_sent`,
 		`$upstream_last_server_name`,
 		`$remote_addr`,
+		`$http_x_b3_traceid`,
+		`$cookie_SessionID`,
 	)
 
 	testInvalidValuesForSimpleValidator(
@@ -132,5 +134,7 @@ func TestValidateNginxVariableName(t *testing.T) {
 		`var-name`,
 		`var name`,
 		`var$name`,
+		`$var-name`,
+		`$`,
 	)
 }
@@ -1,5 +1,10 @@
 package validation
 
+import (
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
+)
+
 // HTTPRedirectValidator validates values for a redirect, which in NGINX is done with the return directive.
 // For example, return 302 "https://example.com:8080";
 type HTTPRedirectValidator struct{}
@@ -44,34 +49,37 @@ func (HTTPRedirectValidator) ValidateRedirectStatusCode(statusCode int) (valid b
 	return validateInSupportedValues(statusCode, supportedRedirectStatusCodes)
 }
 
-var hostnameExamples = []string{"host", "example.com"}
-
+// ValidateHostname validates a hostname of a redirect or a rewrite, which can't be a wildcard hostname.
 func (HTTPRedirectValidator) ValidateHostname(hostname string) error {
-	return validateEscapedStringNoVarExpansion(hostname, hostnameExamples)
+	_, err := validation.ValidateHostname(hostname, validation.HostnameOptions{})
+	return err
 }
 
 // ValidatePath validates a path used in filters.
 func (HTTPPathValidator) ValidatePath(path string) error {
-	return validatePath(path)
+	return errcodes.Wrap(errcodes.InvalidPath, validatePath(path))
 }
 
 // ValidatePathInMatch a path used in the location directive.
 func (HTTPPathValidator) ValidatePathInMatch(path string) error {
-	return validatePathInMatch(path)
+	return errcodes.Wrap(errcodes.InvalidPathInMatch, validatePathInMatch(path))
 }
 
 // ValidatePathInRegexMatch a path used in a regex location directive.
 func (HTTPPathValidator) ValidatePathInRegexMatch(path string) error {
-	return validatePathInRegexMatch(path)
+	return errcodes.Wrap(errcodes.InvalidRegexPathInMatch, validatePathInRegexMatch(path))
 }
 
 func (HTTPHeaderValidator) ValidateFilterHeaderName(name string) error {
-	return validateHeaderName(name)
+	return errcodes.Wrap(errcodes.InvalidHeaderName, validateHeaderName(name))
 }
 
 var requestHeaderValueExamples = []string{"my-header-value", "example/12345=="}
 
 func (HTTPHeaderValidator) ValidateFilterHeaderValue(value string) error {
 	// Variables in header values are supported by NGINX but not required by the Gateway API.
-	return validateEscapedStringNoVarExpansion(value, requestHeaderValueExamples)
+	return errcodes.Wrap(
+		errcodes.InvalidHeaderValue,
+		validateEscapedStringNoVarExpansion(value, requestHeaderValueExamples),
+	)
 }
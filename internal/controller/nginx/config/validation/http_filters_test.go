@@ -62,12 +62,16 @@ func TestValidateHostname(t *testing.T) {
 		t,
 		validator.ValidateHostname,
 		"example.com",
+		"bücher.example",
 	)
 
 	testInvalidValuesForSimpleValidator(
 		t,
 		validator.ValidateHostname,
 		"example.com$",
+		"*.example.com",
+		"example.com:8080",
+		"",
 	)
 }
 
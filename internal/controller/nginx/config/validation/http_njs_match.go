@@ -8,6 +8,7 @@ import (
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
 )
 
 // HTTPNJSMatchValidator validates values used for matching a request.
@@ -17,14 +18,14 @@ type HTTPNJSMatchValidator struct{}
 
 func (HTTPNJSMatchValidator) ValidateHeaderNameInMatch(name string) error {
 	if err := k8svalidation.IsHTTPHeaderName(name); err != nil {
-		return errors.New(err[0])
+		return errcodes.New(errcodes.InvalidHeaderNameInMatch, err[0])
 	}
 
-	return validateNJSHeaderPart(name)
+	return errcodes.Wrap(errcodes.InvalidHeaderNameInMatch, validateNJSHeaderPart(name))
 }
 
 func (HTTPNJSMatchValidator) ValidateHeaderValueInMatch(value string) error {
-	return validateNJSHeaderPart(value)
+	return errcodes.Wrap(errcodes.InvalidHeaderValueInMatch, validateNJSHeaderPart(value))
 }
 
 func validateNJSHeaderPart(value string) error {
@@ -37,11 +38,11 @@ func validateNJSHeaderPart(value string) error {
 }
 
 func (HTTPNJSMatchValidator) ValidateQueryParamNameInMatch(name string) error {
-	return validateCommonNJSMatchPart(name)
+	return errcodes.Wrap(errcodes.InvalidQueryParamNameInMatch, validateCommonNJSMatchPart(name))
 }
 
 func (HTTPNJSMatchValidator) ValidateQueryParamValueInMatch(value string) error {
-	return validateCommonNJSMatchPart(value)
+	return errcodes.Wrap(errcodes.InvalidQueryParamValueInMatch, validateCommonNJSMatchPart(value))
 }
 
 // validateCommonNJSMatchPart validates a string value used in NJS-based matching.
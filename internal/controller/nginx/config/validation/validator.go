@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
+)
+
+// Validator validates the values that propagate into the NGINX configuration.
+//
+// It is the stable entry point to the validation rules of this package. Every component that validates such values
+// outside of the graph building, like the NGF Policies and an admission webhook, must use it, so that they accept
+// exactly the same values as the configuration generation.
+type Validator interface {
+	// ValidatePath validates a path used in a directive like rewrite or return. An empty path is valid.
+	ValidatePath(path string) error
+	// ValidatePathInMatch validates a path used in the location directive.
+	ValidatePathInMatch(path string) error
+	// ValidateHeaderName validates the name of a header that is set, added or removed.
+	ValidateHeaderName(name string) error
+	// ValidateHeaderValue validates the value of a header that is set or added. Variables are not allowed.
+	ValidateHeaderValue(value string) error
+	// ValidateHeaderValueWithVariables validates the value of a header that is set or added, which can contain
+	// the variables from the allowedVariables. The variable names in the allowedVariables include the '$' prefix.
+	ValidateHeaderValueWithVariables(value string, allowedVariables map[string]struct{}) error
+	// ValidateDuration validates a duration in the NGINX format, like 10s.
+	ValidateDuration(duration string) error
+	// ValidateEscapedString validates a string that is surrounded by double quotes in the NGINX configuration.
+	// Variables are not allowed.
+	ValidateEscapedString(value string) error
+}
+
+// NewValidator creates a new Validator.
+func NewValidator() Validator {
+	return validator{}
+}
+
+type validator struct{}
+
+func (validator) ValidatePath(path string) error {
+	return HTTPPathValidator{}.ValidatePath(path)
+}
+
+func (validator) ValidatePathInMatch(path string) error {
+	return HTTPPathValidator{}.ValidatePathInMatch(path)
+}
+
+func (validator) ValidateHeaderName(name string) error {
+	return HTTPHeaderValidator{}.ValidateFilterHeaderName(name)
+}
+
+func (validator) ValidateHeaderValue(value string) error {
+	return HTTPHeaderValidator{}.ValidateFilterHeaderValue(value)
+}
+
+func (validator) ValidateHeaderValueWithVariables(value string, allowedVariables map[string]struct{}) error {
+	return errcodes.Wrap(
+		errcodes.InvalidHeaderValue,
+		validateHeaderValueWithVariables(value, allowedVariables, requestHeaderValueExamples),
+	)
+}
+
+func (validator) ValidateDuration(duration string) error {
+	return GenericValidator{}.ValidateNginxDuration(duration)
+}
+
+func (validator) ValidateEscapedString(value string) error {
+	return GenericValidator{}.ValidateEscapedStringNoVarExpansion(value)
+}
@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	t.Parallel()
+
+	v := NewValidator()
+
+	testValidValuesForSimpleValidator(t, v.ValidatePath, "", "/", "/path/subpath-123")
+	testInvalidValuesForSimpleValidator(t, v.ValidatePath, "path", "/path$", "/path\n")
+
+	testValidValuesForSimpleValidator(t, v.ValidatePathInMatch, "/", "/path/subpath-123")
+	testInvalidValuesForSimpleValidator(t, v.ValidatePathInMatch, "", "/path;", "/path\n")
+
+	testValidValuesForSimpleValidator(t, v.ValidateHeaderName, "X-Forwarded-For", strings.Repeat("a", 256))
+	testInvalidValuesForSimpleValidator(t, v.ValidateHeaderName, "Host", "X Header", strings.Repeat("a", 257))
+
+	testValidValuesForSimpleValidator(t, v.ValidateHeaderValue, "my-header-value", `\"quoted\"`)
+	testInvalidValuesForSimpleValidator(t, v.ValidateHeaderValue, "$host", `"`, `\`)
+
+	withVariables := func(value string) error {
+		return v.ValidateHeaderValueWithVariables(value, map[string]struct{}{"$request_id": {}})
+	}
+	testValidValuesForSimpleValidator(t, withVariables, "my-header-value", "$request_id", "${request_id}")
+	testInvalidValuesForSimpleValidator(t, withVariables, "$host", `"`, `\$request_id`)
+
+	testValidValuesForSimpleValidator(t, v.ValidateDuration, "5ms", "10s", "1000h")
+	testInvalidValuesForSimpleValidator(t, v.ValidateDuration, "", "10d", "10000s", "1s1ms")
+
+	testValidValuesForSimpleValidator(t, v.ValidateEscapedString, "test test", `\\`)
+	testInvalidValuesForSimpleValidator(t, v.ValidateEscapedString, "$test", `test"test`)
+}
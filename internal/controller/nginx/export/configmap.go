@@ -0,0 +1,151 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+const (
+	// PathsAnnotation is the annotation of the exported ConfigMap and Secret with the JSON object
+	// that maps the keys of the data to the paths of the files.
+	PathsAnnotation = "gateway.nginx.org/export-paths"
+	// PathsKey is the key of the data of the exported ConfigMap and Secret with the same JSON object as
+	// PathsAnnotation, so that the paths are available when the ConfigMap or Secret is mounted as a volume.
+	PathsKey = "export-paths.json"
+
+	exportNameSuffix = "nginx-config"
+)
+
+// ConfigMapExporter exports the NGINX configuration of a Gateway to the ConfigMap <gateway-name>-nginx-config
+// in the namespace of the Gateway. Files with secrets, such as TLS keys, are exported to the Secret with the same
+// name instead. Both objects are owned by the Gateway, so they are deleted together with the Gateway.
+type ConfigMapExporter struct {
+	k8sClient client.Client
+	plus      bool
+}
+
+// NewConfigMapExporter creates a new ConfigMapExporter.
+func NewConfigMapExporter(k8sClient client.Client, plus bool) *ConfigMapExporter {
+	return &ConfigMapExporter{
+		k8sClient: k8sClient,
+		plus:      plus,
+	}
+}
+
+// Export creates or updates the ConfigMap and the Secret with the configuration files of the Gateway.
+func (e *ConfigMapExporter) Export(ctx context.Context, gateway *gatewayv1.Gateway, files []agent.File) error {
+	data := make(map[string][]byte)
+	paths := make(map[string]string)
+	secretData := make(map[string][]byte)
+	secretPaths := make(map[string]string)
+
+	for _, f := range buildBundle(files, e.plus) {
+		key := keyForPath(f.Path)
+
+		if f.Type == file.TypeSecret {
+			secretData[key] = f.Content
+			secretPaths[key] = f.Path
+			continue
+		}
+
+		data[key] = f.Content
+		paths[key] = f.Path
+	}
+
+	pathsJSON, err := json.Marshal(paths)
+	if err != nil {
+		return fmt.Errorf("failed to marshal file paths: %w", err)
+	}
+
+	secretPathsJSON, err := json.Marshal(secretPaths)
+	if err != nil {
+		return fmt.Errorf("failed to marshal file paths: %w", err)
+	}
+
+	data[PathsKey] = pathsJSON
+	secretData[PathsKey] = secretPathsJSON
+
+	objectMeta := metav1.ObjectMeta{
+		Name:      ConfigMapName(gateway.GetName()),
+		Namespace: gateway.GetNamespace(),
+	}
+
+	// The Secret is exported first, so that the ConfigMap never references secrets that are not exported yet.
+	secret := &corev1.Secret{ObjectMeta: objectMeta}
+	if _, err := controllerutil.CreateOrUpdate(ctx, e.k8sClient, secret, func() error {
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = secretData
+
+		setExportMetadata(&secret.ObjectMeta, gateway, secretPathsJSON)
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to export configuration to Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: *objectMeta.DeepCopy()}
+	if _, err := controllerutil.CreateOrUpdate(ctx, e.k8sClient, cm, func() error {
+		cm.BinaryData = nil
+		cm.Data = make(map[string]string, len(data))
+		for key, content := range data {
+			cm.Data[key] = string(content)
+		}
+
+		setExportMetadata(&cm.ObjectMeta, gateway, pathsJSON)
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to export configuration to ConfigMap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+
+	return nil
+}
+
+// ConfigMapName returns the name of the ConfigMap and the Secret that the configuration of the Gateway
+// is exported to.
+func ConfigMapName(gatewayName string) string {
+	return controller.CreateNginxResourceName(gatewayName, exportNameSuffix)
+}
+
+func setExportMetadata(objectMeta *metav1.ObjectMeta, gateway *gatewayv1.Gateway, pathsJSON []byte) {
+	if objectMeta.Labels == nil {
+		objectMeta.Labels = make(map[string]string)
+	}
+	objectMeta.Labels[controller.GatewayLabel] = gateway.GetName()
+
+	if objectMeta.Annotations == nil {
+		objectMeta.Annotations = make(map[string]string)
+	}
+	objectMeta.Annotations[PathsAnnotation] = string(pathsJSON)
+
+	objectMeta.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       kinds.Gateway,
+			Name:       gateway.GetName(),
+			UID:        gateway.GetUID(),
+		},
+	}
+}
+
+// keyForPath returns the ConfigMap or Secret key for the file path. Keys can't contain slashes,
+// so the path relative to the NGINX directory is used with the slashes replaced by underscores.
+func keyForPath(path string) string {
+	key := strings.TrimPrefix(path, nginxDir+"/")
+	key = strings.TrimPrefix(key, "/")
+
+	return strings.ReplaceAll(key, "/", "_")
+}
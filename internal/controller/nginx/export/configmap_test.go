@@ -0,0 +1,95 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+func TestConfigMapExporter(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	existingCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway-nginx-config"},
+		Data:       map[string]string{"conf.d_stale.conf": "stale"},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingCM).Build()
+	exporter := NewConfigMapExporter(fakeClient, false)
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway", UID: "uid"},
+	}
+
+	g.Expect(exporter.Export(context.Background(), gateway, testFiles)).To(Succeed())
+
+	expOwnerRefs := []metav1.OwnerReference{
+		{
+			APIVersion: "gateway.networking.k8s.io/v1",
+			Kind:       kinds.Gateway,
+			Name:       "gateway",
+			UID:        "uid",
+		},
+	}
+
+	key := client.ObjectKey{Namespace: "test", Name: "gateway-nginx-config"}
+
+	var cm corev1.ConfigMap
+	g.Expect(fakeClient.Get(context.Background(), key, &cm)).To(Succeed())
+	g.Expect(cm.Data).To(HaveKeyWithValue("conf.d_http.conf", "http"))
+	g.Expect(cm.Data).To(HaveKey("nginx.conf"))
+	g.Expect(cm.Data).ToNot(HaveKey("conf.d_stale.conf"))
+	g.Expect(cm.Data).ToNot(HaveKey("secrets_test_secret.pem"))
+	g.Expect(cm.Labels).To(HaveKeyWithValue(controller.GatewayLabel, "gateway"))
+	g.Expect(cm.OwnerReferences).To(Equal(expOwnerRefs))
+
+	var paths map[string]string
+	g.Expect(json.Unmarshal([]byte(cm.Annotations[PathsAnnotation]), &paths)).To(Succeed())
+	g.Expect(paths).To(HaveKeyWithValue("conf.d_http.conf", "/etc/nginx/conf.d/http.conf"))
+	g.Expect(paths).To(HaveKeyWithValue("nginx.conf", "/etc/nginx/nginx.conf"))
+	g.Expect(cm.Data).To(HaveKeyWithValue(PathsKey, cm.Annotations[PathsAnnotation]))
+
+	var secret corev1.Secret
+	g.Expect(fakeClient.Get(context.Background(), key, &secret)).To(Succeed())
+	g.Expect(secret.Data).To(HaveLen(2))
+	g.Expect(secret.Data).To(HaveKeyWithValue("secrets_test_secret.pem", []byte("secret")))
+	g.Expect(secret.Data).To(HaveKeyWithValue(PathsKey, []byte(secret.Annotations[PathsAnnotation])))
+	g.Expect(secret.OwnerReferences).To(Equal(expOwnerRefs))
+
+	var secretPaths map[string]string
+	g.Expect(json.Unmarshal([]byte(secret.Annotations[PathsAnnotation]), &secretPaths)).To(Succeed())
+	g.Expect(secretPaths).To(Equal(map[string]string{
+		"secrets_test_secret.pem": "/etc/nginx/secrets/test_secret.pem",
+	}))
+}
+
+func TestConfigMapName(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(ConfigMapName("gateway")).To(Equal("gateway-nginx-config"))
+}
+
+func TestKeyForPath(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(keyForPath("/etc/nginx/nginx.conf")).To(Equal("nginx.conf"))
+	g.Expect(keyForPath("/etc/nginx/conf.d/http.conf")).To(Equal("conf.d_http.conf"))
+	g.Expect(keyForPath("/var/lib/file")).To(Equal("var_lib_file"))
+}
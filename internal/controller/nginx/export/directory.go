@@ -0,0 +1,80 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
+)
+
+const dirMode = 0o750
+
+// DirExporter exports the NGINX configuration of a Gateway to the directory <dir>/<namespace>/<name>.
+// The files are written under their absolute paths in that directory, so the directory can be copied
+// to the root of the file system of the NGINX instance.
+// The files are written to a temporary directory first, so the directory never contains
+// a partially written configuration.
+type DirExporter struct {
+	fileMgr file.OSFileManager
+	dir     string
+	plus    bool
+}
+
+// NewDirExporter creates a new DirExporter.
+func NewDirExporter(dir string, plus bool) *DirExporter {
+	return &DirExporter{
+		fileMgr: file.NewStdLibOSFileManager(),
+		dir:     dir,
+		plus:    plus,
+	}
+}
+
+// Export writes the configuration files of the Gateway to its directory.
+func (e *DirExporter) Export(_ context.Context, gateway *gatewayv1.Gateway, files []agent.File) error {
+	parentDir := filepath.Join(e.dir, gateway.GetNamespace())
+	if err := os.MkdirAll(parentDir, dirMode); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", parentDir, err)
+	}
+
+	tmpDir, err := os.MkdirTemp(parentDir, "."+gateway.GetName()+"-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	// no-op when the temporary directory has been renamed
+	defer os.RemoveAll(tmpDir)
+
+	for _, f := range buildBundle(files, e.plus) {
+		f.Path = filepath.Join(tmpDir, f.Path)
+
+		if err := os.MkdirAll(filepath.Dir(f.Path), dirMode); err != nil {
+			return fmt.Errorf("failed to create directory for file %q: %w", f.Path, err)
+		}
+
+		if err := file.Write(e.fileMgr, f); err != nil {
+			return err
+		}
+	}
+
+	gatewayDir := filepath.Join(parentDir, gateway.GetName())
+	oldDir := tmpDir + ".old"
+
+	if err := os.Rename(gatewayDir, oldDir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to move previous configuration %q: %w", gatewayDir, err)
+	}
+
+	if err := os.Rename(tmpDir, gatewayDir); err != nil {
+		return fmt.Errorf("failed to move configuration to %q: %w", gatewayDir, err)
+	}
+
+	if err := os.RemoveAll(oldDir); err != nil {
+		return fmt.Errorf("failed to remove previous configuration %q: %w", oldDir, err)
+	}
+
+	return nil
+}
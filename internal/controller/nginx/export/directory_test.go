@@ -0,0 +1,49 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/conf"
+)
+
+func TestDirExporter(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	exporter := NewDirExporter(dir, false)
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"},
+	}
+
+	gatewayDir := filepath.Join(dir, "test", "gateway")
+	stalePath := filepath.Join(gatewayDir, "etc/nginx/conf.d/stale.conf")
+
+	g.Expect(os.MkdirAll(filepath.Dir(stalePath), 0o750)).To(Succeed())
+	g.Expect(os.WriteFile(stalePath, []byte("stale"), 0o600)).To(Succeed())
+
+	g.Expect(exporter.Export(context.Background(), gateway, testFiles)).To(Succeed())
+
+	g.Expect(filepath.Join(gatewayDir, "etc/nginx/nginx.conf")).To(BeARegularFile())
+	g.Expect(os.ReadFile(filepath.Join(gatewayDir, "etc/nginx/nginx.conf"))).To(Equal(conf.NginxConf))
+	g.Expect(os.ReadFile(filepath.Join(gatewayDir, "etc/nginx/conf.d/http.conf"))).To(Equal([]byte("http")))
+	g.Expect(stalePath).ToNot(BeAnExistingFile())
+
+	secretInfo, err := os.Stat(filepath.Join(gatewayDir, "etc/nginx/secrets/test_secret.pem"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(secretInfo.Mode().Perm()).To(Equal(os.FileMode(0o640)))
+
+	// only the Gateway directory is left
+	entries, err := os.ReadDir(filepath.Join(dir, "test"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entries).To(HaveLen(1))
+	g.Expect(entries[0].Name()).To(Equal("gateway"))
+}
@@ -0,0 +1,10 @@
+/*
+Package export contains the exporters that write the complete NGINX configuration of a Gateway
+to a location outside of the NGINX Pods. The exported configuration can be used by an NGINX instance
+that is not managed by the control plane and doesn't run the NGINX agent, for example, an NGINX instance
+in a disaster recovery site or in a hybrid deployment outside of the cluster.
+
+The exported configuration includes the base nginx.conf of the NGINX image, so the NGINX instance
+only needs the NGINX modules that are installed in the NGINX image, such as njs.
+*/
+package export
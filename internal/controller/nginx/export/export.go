@@ -0,0 +1,69 @@
+package export
+
+import (
+	"context"
+	"path/filepath"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/conf"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
+)
+
+const nginxDir = "/etc/nginx"
+
+// Exporter exports the NGINX configuration of a Gateway.
+type Exporter interface {
+	// Export exports the configuration files of the Gateway. The files replace any previously exported
+	// files of the Gateway.
+	Export(ctx context.Context, gateway *gatewayv1.Gateway, files []agent.File) error
+}
+
+// buildBundle returns the complete set of configuration files, which consists of the generated files
+// and the static files of the NGINX image.
+func buildBundle(files []agent.File, plus bool) []file.File {
+	nginxConf := conf.NginxConf
+	if plus {
+		nginxConf = conf.NginxPlusConf
+	}
+
+	staticFiles := []file.File{
+		{
+			Path:    filepath.Join(nginxDir, "nginx.conf"),
+			Content: nginxConf,
+			Type:    file.TypeRegular,
+		},
+		{
+			Path:    filepath.Join(nginxDir, "grpc-error-locations.conf"),
+			Content: conf.GRPCErrorLocations,
+			Type:    file.TypeRegular,
+		},
+		{
+			Path:    filepath.Join(nginxDir, "grpc-error-pages.conf"),
+			Content: conf.GRPCErrorPages,
+			Type:    file.TypeRegular,
+		},
+	}
+
+	bundle := make([]file.File, 0, len(files)+len(staticFiles))
+	generated := make(map[string]struct{}, len(files))
+
+	for _, f := range files {
+		converted := file.Convert(f)
+		if converted.Path == "" {
+			continue
+		}
+
+		generated[converted.Path] = struct{}{}
+		bundle = append(bundle, converted)
+	}
+
+	for _, f := range staticFiles {
+		if _, exists := generated[f.Path]; !exists {
+			bundle = append(bundle, f)
+		}
+	}
+
+	return bundle
+}
@@ -0,0 +1,77 @@
+package export
+
+import (
+	"testing"
+
+	pb "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/conf"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/file"
+)
+
+var testFiles = []agent.File{
+	{
+		Meta:     &pb.FileMeta{Name: "/etc/nginx/conf.d/http.conf", Permissions: file.RegularFileMode},
+		Contents: []byte("http"),
+	},
+	{
+		Meta:     &pb.FileMeta{Name: "/etc/nginx/secrets/test_secret.pem", Permissions: file.SecretFileMode},
+		Contents: []byte("secret"),
+	},
+}
+
+func TestBuildBundle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		files             []agent.File
+		expectedNginxConf []byte
+		plus              bool
+	}{
+		{
+			name:              "oss",
+			files:             testFiles,
+			expectedNginxConf: conf.NginxConf,
+		},
+		{
+			name:              "plus",
+			files:             testFiles,
+			plus:              true,
+			expectedNginxConf: conf.NginxPlusConf,
+		},
+		{
+			name: "generated file overrides static file",
+			files: append([]agent.File{
+				{
+					Meta:     &pb.FileMeta{Name: "/etc/nginx/nginx.conf", Permissions: file.RegularFileMode},
+					Contents: []byte("generated"),
+				},
+			}, testFiles...),
+			expectedNginxConf: []byte("generated"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			bundle := buildBundle(test.files, test.plus)
+
+			contents := make(map[string][]byte, len(bundle))
+			for _, f := range bundle {
+				g.Expect(contents).ToNot(HaveKey(f.Path))
+				contents[f.Path] = f.Content
+			}
+
+			g.Expect(contents).To(HaveKeyWithValue("/etc/nginx/nginx.conf", test.expectedNginxConf))
+			g.Expect(contents).To(HaveKeyWithValue("/etc/nginx/conf.d/http.conf", []byte("http")))
+			g.Expect(contents).To(HaveKeyWithValue("/etc/nginx/secrets/test_secret.pem", []byte("secret")))
+			g.Expect(contents).To(HaveKeyWithValue("/etc/nginx/grpc-error-locations.conf", conf.GRPCErrorLocations))
+			g.Expect(contents).To(HaveKeyWithValue("/etc/nginx/grpc-error-pages.conf", conf.GRPCErrorPages))
+		})
+	}
+}
@@ -0,0 +1,23 @@
+const DELAY_VAR = 'ngf_tarpit_delay';
+const STATUS_VAR = 'ngf_tarpit_status';
+
+// delay responds to a request for a decoy path after the configured delay. Only the clients that scan for
+// vulnerable applications request the decoy paths, so the IP address of the client is logged with the
+// "tarpit:" prefix, for example, for fail2ban to block the client downstream.
+function delay(r) {
+	const delayMs = parseInt(r.variables[DELAY_VAR], 10);
+	const status = parseInt(r.variables[STATUS_VAR], 10);
+	if (isNaN(delayMs) || isNaN(status)) {
+		r.error(`Missing required variables: ${DELAY_VAR} and/or ${STATUS_VAR}`);
+		r.return(500);
+		return;
+	}
+
+	r.warn(`tarpit: client ${r.remoteAddress} requested the decoy path ${r.uri}`);
+
+	setTimeout(() => {
+		r.return(status);
+	}, delayMs);
+}
+
+export default { delay };
@@ -0,0 +1,47 @@
+import { default as tarpit } from '../src/tarpit.js';
+import { expect, describe, it, beforeEach, afterEach, vi } from 'vitest';
+
+function makeRequest({ uri = '/wp-admin', remoteAddress = '10.0.0.1', variables = {} } = {}) {
+	return {
+		uri,
+		remoteAddress,
+		variables,
+		error: vi.fn(),
+		warn: vi.fn(),
+		return: vi.fn(),
+	};
+}
+
+describe('delay', () => {
+	beforeEach(() => {
+		vi.useFakeTimers();
+	});
+	afterEach(() => {
+		vi.useRealTimers();
+	});
+
+	it('returns 500 if the variables are missing', () => {
+		const r = makeRequest({ variables: { ngf_tarpit_delay: '1000' } });
+		tarpit.delay(r);
+		expect(r.error).toHaveBeenCalledWith(expect.stringContaining('Missing required variables'));
+		expect(r.return).toHaveBeenCalledWith(500);
+		expect(r.warn).not.toHaveBeenCalled();
+	});
+
+	it('logs the client and responds after the delay', () => {
+		const r = makeRequest({
+			uri: '/wp-admin/install.php',
+			variables: { ngf_tarpit_delay: '30000', ngf_tarpit_status: '403' },
+		});
+		tarpit.delay(r);
+		expect(r.warn).toHaveBeenCalledWith(
+			'tarpit: client 10.0.0.1 requested the decoy path /wp-admin/install.php',
+		);
+
+		vi.advanceTimersByTime(29999);
+		expect(r.return).not.toHaveBeenCalled();
+
+		vi.advanceTimersByTime(1);
+		expect(r.return).toHaveBeenCalledWith(403);
+	});
+});
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+)
+
+// defaultNodeAddressTypes are the types of the Node addresses that are reported if the NginxProxy doesn't
+// set them.
+var defaultNodeAddressTypes = []ngfAPIv1alpha2.NodeAddressType{
+	ngfAPIv1alpha2.NodeAddressTypeExternalIP,
+	ngfAPIv1alpha2.NodeAddressTypeInternalIP,
+}
+
+// getNodeAddresses gets the IP addresses and the hostnames of the Nodes that the Gateway is reachable at.
+// The Gateway is reachable at the Nodes if its Service is of type NodePort or its NGINX Pods use the host network.
+// Otherwise, no addresses are returned.
+//
+// The Nodes are read with k8sReader, so that the Nodes of the cluster are not cached.
+func getNodeAddresses(
+	ctx context.Context,
+	k8sClient client.Client,
+	k8sReader client.Reader,
+	svc *v1.Service,
+	gateway *graph.Gateway,
+) (addresses, hostnames []string, err error) {
+	if svc.Spec.Type == v1.ServiceTypeLoadBalancer || len(svc.Spec.Selector) == 0 {
+		return nil, nil, nil
+	}
+
+	var pods v1.PodList
+	if err := k8sClient.List(
+		ctx,
+		&pods,
+		client.InNamespace(svc.Namespace),
+		client.MatchingLabels(svc.Spec.Selector),
+	); err != nil {
+		return nil, nil, fmt.Errorf("error listing the NGINX Pods: %w", err)
+	}
+
+	hostNetwork := slices.ContainsFunc(pods.Items, func(pod v1.Pod) bool {
+		return pod.Spec.HostNetwork
+	})
+
+	if svc.Spec.Type != v1.ServiceTypeNodePort && !hostNetwork {
+		return nil, nil, nil
+	}
+
+	addressTypes := defaultNodeAddressTypes
+	selection := ngfAPIv1alpha2.NodeSelectionPodNodes
+
+	if np := gateway.EffectiveNginxProxy; np != nil && np.Kubernetes != nil && np.Kubernetes.Service != nil &&
+		np.Kubernetes.Service.NodeAddresses != nil {
+		cfg := np.Kubernetes.Service.NodeAddresses
+		if len(cfg.Types) > 0 {
+			addressTypes = cfg.Types
+		}
+		if cfg.Selection != nil {
+			selection = *cfg.Selection
+		}
+	}
+
+	var nodes []v1.Node
+	switch selection {
+	case ngfAPIv1alpha2.NodeSelectionAllNodes:
+		var nodeList v1.NodeList
+		if err := k8sReader.List(ctx, &nodeList); err != nil {
+			return nil, nil, fmt.Errorf("error listing Nodes: %w", err)
+		}
+
+		for _, node := range nodeList.Items {
+			if isNodeReady(node) {
+				nodes = append(nodes, node)
+			}
+		}
+	default:
+		nodes, err = getPodNodes(ctx, k8sReader, pods.Items)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	slices.SortFunc(nodes, func(a, b v1.Node) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, node := range nodes {
+		addrType, value, found := preferredNodeAddress(node, addressTypes)
+		if !found {
+			continue
+		}
+
+		switch addrType {
+		case ngfAPIv1alpha2.NodeAddressTypeExternalIP, ngfAPIv1alpha2.NodeAddressTypeInternalIP:
+			if !slices.Contains(addresses, value) {
+				addresses = append(addresses, value)
+			}
+		default:
+			if !slices.Contains(hostnames, value) {
+				hostnames = append(hostnames, value)
+			}
+		}
+	}
+
+	return addresses, hostnames, nil
+}
+
+// getPodNodes gets the Nodes that run the ready Pods.
+func getPodNodes(ctx context.Context, k8sReader client.Reader, pods []v1.Pod) ([]v1.Node, error) {
+	var nodeNames []string
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || !isPodReady(pod) || slices.Contains(nodeNames, pod.Spec.NodeName) {
+			continue
+		}
+
+		nodeNames = append(nodeNames, pod.Spec.NodeName)
+	}
+
+	nodes := make([]v1.Node, 0, len(nodeNames))
+	for _, name := range nodeNames {
+		var node v1.Node
+		if err := k8sReader.Get(ctx, types.NamespacedName{Name: name}, &node); err != nil {
+			return nil, fmt.Errorf("error getting Node %s: %w", name, err)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// preferredNodeAddress returns the address of the Node of the first type in addressTypes that the Node has.
+func preferredNodeAddress(
+	node v1.Node,
+	addressTypes []ngfAPIv1alpha2.NodeAddressType,
+) (ngfAPIv1alpha2.NodeAddressType, string, bool) {
+	for _, addrType := range addressTypes {
+		for _, addr := range node.Status.Addresses {
+			if addr.Type == v1.NodeAddressType(addrType) && addr.Address != "" {
+				return addrType, addr.Address, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func isPodReady(pod v1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func isNodeReady(node v1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
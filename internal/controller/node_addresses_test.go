@@ -0,0 +1,219 @@
+package controller
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+func createNode(name string, ready bool, addresses ...v1.NodeAddress) *v1.Node {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Addresses:  addresses,
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func createNginxPod(name, nodeName string, hostNetwork, ready bool) *v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{"app": "gateway-nginx"},
+		},
+		Spec: v1.PodSpec{
+			NodeName:    nodeName,
+			HostNetwork: hostNetwork,
+		},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+		},
+	}
+}
+
+func TestGetNodeAddresses(t *testing.T) {
+	t.Parallel()
+
+	nodes := []client.Object{
+		createNode(
+			"node-1",
+			true,
+			v1.NodeAddress{Type: v1.NodeInternalIP, Address: "10.0.0.1"},
+			v1.NodeAddress{Type: v1.NodeExternalIP, Address: "203.0.113.1"},
+			v1.NodeAddress{Type: v1.NodeHostName, Address: "node-1.example.com"},
+		),
+		createNode(
+			"node-2",
+			true,
+			v1.NodeAddress{Type: v1.NodeInternalIP, Address: "10.0.0.2"},
+			v1.NodeAddress{Type: v1.NodeHostName, Address: "node-2.example.com"},
+		),
+		createNode(
+			"node-3",
+			true,
+			v1.NodeAddress{Type: v1.NodeInternalIP, Address: "10.0.0.3"},
+		),
+		createNode(
+			"node-4",
+			false,
+			v1.NodeAddress{Type: v1.NodeInternalIP, Address: "10.0.0.4"},
+		),
+	}
+
+	createService := func(svcType v1.ServiceType) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway-nginx", Namespace: "test"},
+			Spec: v1.ServiceSpec{
+				Type:      svcType,
+				ClusterIP: "12.13.14.15",
+				Selector:  map[string]string{"app": "gateway-nginx"},
+			},
+		}
+	}
+
+	createGateway := func(nodeAddresses *ngfAPIv1alpha2.NodeAddresses) *graph.Gateway {
+		gw := &graph.Gateway{
+			Source: &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "test"}},
+		}
+
+		if nodeAddresses != nil {
+			gw.EffectiveNginxProxy = &graph.EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Service: &ngfAPIv1alpha2.ServiceSpec{NodeAddresses: nodeAddresses},
+				},
+			}
+		}
+
+		return gw
+	}
+
+	tests := []struct {
+		svc          *v1.Service
+		gateway      *graph.Gateway
+		name         string
+		pods         []client.Object
+		expAddresses []string
+		expHostnames []string
+	}{
+		{
+			name:    "NodePort Service; addresses of the Nodes of the ready Pods",
+			svc:     createService(v1.ServiceTypeNodePort),
+			gateway: createGateway(nil),
+			pods: []client.Object{
+				createNginxPod("pod-2", "node-2", false, true),
+				createNginxPod("pod-1", "node-1", false, true),
+				createNginxPod("pod-3", "node-3", false, false),
+				createNginxPod("pod-4", "node-1", false, true),
+			},
+			expAddresses: []string{"203.0.113.1", "10.0.0.2"},
+		},
+		{
+			name: "NodePort Service; all ready Nodes",
+			svc:  createService(v1.ServiceTypeNodePort),
+			gateway: createGateway(&ngfAPIv1alpha2.NodeAddresses{
+				Selection: helpers.GetPointer(ngfAPIv1alpha2.NodeSelectionAllNodes),
+			}),
+			pods: []client.Object{
+				createNginxPod("pod-1", "node-1", false, true),
+			},
+			expAddresses: []string{"203.0.113.1", "10.0.0.2", "10.0.0.3"},
+		},
+		{
+			name: "host network; preferred hostnames",
+			svc:  createService(v1.ServiceTypeClusterIP),
+			gateway: createGateway(&ngfAPIv1alpha2.NodeAddresses{
+				Types: []ngfAPIv1alpha2.NodeAddressType{
+					ngfAPIv1alpha2.NodeAddressTypeHostname,
+					ngfAPIv1alpha2.NodeAddressTypeInternalIP,
+				},
+			}),
+			pods: []client.Object{
+				createNginxPod("pod-1", "node-1", true, true),
+				createNginxPod("pod-2", "node-2", true, true),
+				createNginxPod("pod-3", "node-3", true, true),
+			},
+			expAddresses: []string{"10.0.0.3"},
+			expHostnames: []string{"node-1.example.com", "node-2.example.com"},
+		},
+		{
+			name:    "ClusterIP Service",
+			svc:     createService(v1.ServiceTypeClusterIP),
+			gateway: createGateway(nil),
+			pods: []client.Object{
+				createNginxPod("pod-1", "node-1", false, true),
+			},
+		},
+		{
+			name:    "LoadBalancer Service",
+			svc:     createService(v1.ServiceTypeLoadBalancer),
+			gateway: createGateway(nil),
+			pods: []client.Object{
+				createNginxPod("pod-1", "node-1", true, true),
+			},
+		},
+		{
+			name:    "NodePort Service; no ready Pods",
+			svc:     createService(v1.ServiceTypeNodePort),
+			gateway: createGateway(nil),
+			pods: []client.Object{
+				createNginxPod("pod-1", "node-1", false, false),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().WithObjects(append(nodes, test.pods...)...).Build()
+
+			addresses, hostnames, err := getNodeAddresses(t.Context(), fakeClient, fakeClient, test.svc, test.gateway)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(addresses).To(Equal(test.expAddresses))
+			g.Expect(hostnames).To(Equal(test.expHostnames))
+		})
+	}
+}
+
+func TestGetNodeAddresses_MissingNode(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	fakeClient := fake.NewClientBuilder().WithObjects(createNginxPod("pod-1", "node-1", true, true)).Build()
+
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway-nginx", Namespace: "test"},
+		Spec: v1.ServiceSpec{
+			Type:     v1.ServiceTypeClusterIP,
+			Selector: map[string]string{"app": "gateway-nginx"},
+		},
+	}
+	gw := &graph.Gateway{
+		Source: &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "test"}},
+	}
+
+	_, _, err := getNodeAddresses(t.Context(), fakeClient, fakeClient, svc, gw)
+	g.Expect(err).To(MatchError(ContainSubstring("error getting Node node-1")))
+}
@@ -0,0 +1,18 @@
+/*
+Package outlier triggers diagnostic actions on the data plane of the Gateways of the HTTPRoutes and GRPCRoutes
+whose error rate crosses a threshold, to ease the diagnosis of intermittent issues.
+
+A Route opts into the hook with the gateway.nginx.org/outlier-max-error-rate annotation. The Hook periodically
+queries a metrics provider, such as Prometheus, for the error rate of the NGINX upstreams of the Route.
+When the error rate of an upstream exceeds the threshold, the Hook triggers the action of the Route on the Gateways
+that the Route is attached to, for a bounded duration:
+
+  - DebugLogging raises the NGINX error log level of the Gateways to debug.
+  - AnnotatePods annotates the NGINX Pods of the Gateways with the time until which the action is active,
+    so that a capture sidecar, which reads the annotations of its Pod through the downward API, can capture
+    the traffic in the meantime.
+
+Once the action expires, the Route is not triggered again for the same duration, so that a Route that
+keeps failing doesn't keep the data plane in the diagnostic mode.
+*/
+package outlier
@@ -0,0 +1,322 @@
+package outlier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+)
+
+// State is the state of the Action of a Route.
+type State string
+
+const (
+	// StateTriggered means that the error rate of the Route crossed the threshold and the Action is active.
+	StateTriggered State = "Triggered"
+	// StateExpired means that the Action is no longer active.
+	StateExpired State = "Expired"
+)
+
+// Change is a change of the State of the Action of a Route.
+type Change struct {
+	// Route is the Route.
+	Route client.Object
+	// Until is the time until which the Action is active. If the Action expired, it is the time until which
+	// the Action was meant to be active.
+	Until time.Time
+	// State is the new State.
+	State State
+	// Action is the Action.
+	Action Action
+	// Message describes the change.
+	Message string
+	// Gateways are the Gateways that the Action applies to.
+	Gateways []types.NamespacedName
+}
+
+// trigger is an active Action of a Route.
+type trigger struct {
+	// route is the Route.
+	route client.Object
+	// until is the time until which the Action is active.
+	until time.Time
+	// action is the Action.
+	action Action
+	// fingerprint is the fingerprint of the outlier annotations of the Route at the time of the trigger.
+	fingerprint string
+	// gateways are the Gateways that the Action applies to.
+	gateways []types.NamespacedName
+	// duration is the duration of the Action.
+	duration time.Duration
+}
+
+// Hook triggers the diagnostic Actions of the Routes whose error rate crosses the threshold.
+// An Action stays active for its duration, and then the Route is not triggered again for the same duration.
+type Hook struct {
+	metrics canary.MetricsProvider
+	// now returns the current time.
+	now func() time.Time
+	// triggers are the active Actions of the Routes.
+	triggers map[types.NamespacedName]trigger
+	// cooldowns are the times until which the Routes, whose Action expired, are not triggered again.
+	cooldowns map[types.NamespacedName]time.Time
+	logger    logr.Logger
+	lock      sync.RWMutex
+}
+
+// NewHook creates a new Hook.
+func NewHook(logger logr.Logger, metrics canary.MetricsProvider) *Hook {
+	return &Hook{
+		metrics:   metrics,
+		now:       time.Now,
+		triggers:  make(map[types.NamespacedName]trigger),
+		cooldowns: make(map[types.NamespacedName]time.Time),
+		logger:    logger,
+	}
+}
+
+// Evaluate evaluates the error rates of the Routes of the graph, and returns the changes of the States
+// of their Actions.
+func (h *Hook) Evaluate(ctx context.Context, gr *graph.Graph) []Change {
+	if gr == nil {
+		return nil
+	}
+
+	now := h.now()
+
+	var changes []Change
+	watched := make(map[types.NamespacedName]struct{})
+
+	for _, route := range gr.Routes {
+		if route.Source == nil || !route.Valid {
+			continue
+		}
+
+		nsName := client.ObjectKeyFromObject(route.Source)
+		annotations := route.Source.GetAnnotations()
+
+		spec, exists, err := ParseSpec(annotations)
+		if !exists {
+			continue
+		}
+
+		watched[nsName] = struct{}{}
+
+		if err != nil {
+			h.logger.Error(err, "Invalid outlier hook annotations", "route", nsName.String())
+			continue
+		}
+
+		if change, changed := h.evaluateRoute(ctx, route, spec, fingerprint(annotations), now); changed {
+			changes = append(changes, change)
+		}
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for nsName := range h.cooldowns {
+		if _, exists := watched[nsName]; !exists {
+			delete(h.cooldowns, nsName)
+		}
+	}
+
+	for nsName, t := range h.triggers {
+		if _, exists := watched[nsName]; exists {
+			continue
+		}
+
+		delete(h.triggers, nsName)
+		changes = append(changes, expiredChange(t, "Outlier hook is no longer configured; the action expired"))
+	}
+
+	slices.SortFunc(changes, func(c1, c2 Change) int {
+		return strings.Compare(
+			client.ObjectKeyFromObject(c1.Route).String(),
+			client.ObjectKeyFromObject(c2.Route).String(),
+		)
+	})
+
+	return changes
+}
+
+// DebugLogging returns whether the DebugLogging Action of a Route is active on the Gateway.
+func (h *Hook) DebugLogging(gateway types.NamespacedName) bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	for _, t := range h.triggers {
+		if t.action == ActionDebugLogging && slices.Contains(t.gateways, gateway) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *Hook) evaluateRoute(
+	ctx context.Context,
+	route *graph.L7Route,
+	spec Spec,
+	fp string,
+	now time.Time,
+) (Change, bool) {
+	nsName := client.ObjectKeyFromObject(route.Source)
+
+	h.lock.Lock()
+	t, active := h.triggers[nsName]
+	cooldown, coolingDown := h.cooldowns[nsName]
+
+	if active {
+		defer h.lock.Unlock()
+
+		switch {
+		case t.fingerprint != fp:
+			delete(h.triggers, nsName)
+			return expiredChange(t, "Outlier hook annotations changed; the action expired"), true
+		case !now.Before(t.until):
+			delete(h.triggers, nsName)
+			h.cooldowns[nsName] = now.Add(t.duration)
+			return expiredChange(t, fmt.Sprintf("Outlier hook action %s expired", t.action)), true
+		default:
+			return Change{}, false
+		}
+	}
+
+	if coolingDown && now.Before(cooldown) {
+		h.lock.Unlock()
+		return Change{}, false
+	}
+
+	delete(h.cooldowns, nsName)
+	h.lock.Unlock()
+
+	reason, err := h.evaluate(ctx, spec, routeUpstreams(route))
+	if err != nil {
+		h.logger.Error(err, "Failed to evaluate the outlier hook", "route", nsName.String())
+		return Change{}, false
+	}
+
+	if reason == "" {
+		return Change{}, false
+	}
+
+	gateways := routeGateways(route)
+	if len(gateways) == 0 {
+		return Change{}, false
+	}
+
+	t = trigger{
+		route:       route.Source,
+		until:       now.Add(spec.Duration),
+		action:      spec.Action,
+		fingerprint: fp,
+		gateways:    gateways,
+		duration:    spec.Duration,
+	}
+
+	h.lock.Lock()
+	h.triggers[nsName] = t
+	h.lock.Unlock()
+
+	message := fmt.Sprintf(
+		"Outlier hook triggered: %s; the action %s is active until %s",
+		reason,
+		spec.Action,
+		t.until.UTC().Format(time.RFC3339),
+	)
+	h.logger.Info(message, "route", nsName.String())
+
+	return Change{
+		Route:    route.Source,
+		Until:    t.until,
+		State:    StateTriggered,
+		Action:   spec.Action,
+		Message:  message,
+		Gateways: gateways,
+	}, true
+}
+
+// evaluate evaluates the upstreams of a Route against the maximum error rate of the Spec. It returns the reason
+// why the Route crossed the threshold, or an empty string if it didn't. Upstreams without data are skipped.
+func (h *Hook) evaluate(ctx context.Context, spec Spec, upstreams map[string]struct{}) (string, error) {
+	for _, upstream := range slices.Sorted(maps.Keys(upstreams)) {
+		rate, err := h.metrics.ErrorRate(ctx, upstream)
+		switch {
+		case errors.Is(err, canary.ErrNoData):
+			continue
+		case err != nil:
+			return "", fmt.Errorf("failed to get the error rate of upstream %s: %w", upstream, err)
+		case rate > spec.MaxErrorRate:
+			return fmt.Sprintf(
+				"error rate %.4f of upstream %s exceeds the maximum %.4f",
+				rate,
+				upstream,
+				spec.MaxErrorRate,
+			), nil
+		}
+	}
+
+	return "", nil
+}
+
+func expiredChange(t trigger, message string) Change {
+	return Change{
+		Route:    t.route,
+		Until:    t.until,
+		State:    StateExpired,
+		Action:   t.action,
+		Message:  message,
+		Gateways: t.gateways,
+	}
+}
+
+// routeUpstreams returns the names of the upstreams of the valid backends of the Route that receive traffic.
+func routeUpstreams(route *graph.L7Route) map[string]struct{} {
+	upstreams := make(map[string]struct{})
+
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			upstream := ref.ServicePortReference()
+			if upstream == "" || ref.IsMirrorBackend || ref.IsStaticBackend() || ref.Weight == 0 {
+				continue
+			}
+
+			upstreams[upstream] = struct{}{}
+		}
+	}
+
+	return upstreams
+}
+
+// routeGateways returns the sorted Gateways that the Route is attached to.
+func routeGateways(route *graph.L7Route) []types.NamespacedName {
+	var gateways []types.NamespacedName
+
+	for _, ref := range route.ParentRefs {
+		if ref.Gateway == nil || ref.Attachment == nil || !ref.Attachment.Attached {
+			continue
+		}
+
+		if !slices.Contains(gateways, ref.Gateway.NamespacedName) {
+			gateways = append(gateways, ref.Gateway.NamespacedName)
+		}
+	}
+
+	slices.SortFunc(gateways, func(gw1, gw2 types.NamespacedName) int {
+		return strings.Compare(gw1.String(), gw2.String())
+	})
+
+	return gateways
+}
@@ -0,0 +1,171 @@
+package outlier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary/canaryfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+)
+
+var gwNsName = types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+func createGraph(annotations map[string]string) *graph.Graph {
+	route := &graph.L7Route{
+		Source: &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route", Annotations: annotations},
+		},
+		RouteType: graph.RouteTypeHTTP,
+		Valid:     true,
+		ParentRefs: []graph.ParentRef{
+			{
+				Gateway:    &graph.ParentRefGateway{NamespacedName: gwNsName},
+				Attachment: &graph.ParentRefAttachmentStatus{Attached: true},
+			},
+			{
+				Gateway:    &graph.ParentRefGateway{NamespacedName: types.NamespacedName{Namespace: "test", Name: "other"}},
+				Attachment: &graph.ParentRefAttachmentStatus{Attached: false},
+			},
+		},
+		Spec: graph.L7RouteSpec{
+			Rules: []graph.RouteRule{
+				{
+					BackendRefs: []graph.BackendRef{
+						{
+							SvcNsName:   types.NamespacedName{Namespace: "test", Name: "backend"},
+							ServicePort: v1.ServicePort{Port: 80},
+							Weight:      1,
+							Valid:       true,
+						},
+						{
+							SvcNsName:   types.NamespacedName{Namespace: "test", Name: "drained"},
+							ServicePort: v1.ServicePort{Port: 80},
+							Weight:      0,
+							Valid:       true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return &graph.Graph{
+		Routes: map[graph.RouteKey]*graph.L7Route{
+			graph.CreateRouteKey(route.Source): route,
+		},
+	}
+}
+
+func TestHook(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	annotations := map[string]string{
+		MaxErrorRateAnnotation: "0.1",
+		DurationAnnotation:     "10m",
+	}
+
+	metrics := &canaryfakes.FakeMetricsProvider{}
+	hook := NewHook(logr.Discard(), metrics)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	hook.now = func() time.Time { return now }
+
+	// the error rate is below the threshold, or can't be queried
+	metrics.ErrorRateReturns(0.05, nil)
+	g.Expect(hook.Evaluate(context.Background(), createGraph(annotations))).To(BeEmpty())
+	metrics.ErrorRateReturns(0, canary.ErrNoData)
+	g.Expect(hook.Evaluate(context.Background(), createGraph(annotations))).To(BeEmpty())
+	metrics.ErrorRateReturns(0, errors.New("connection refused"))
+	g.Expect(hook.Evaluate(context.Background(), createGraph(annotations))).To(BeEmpty())
+	g.Expect(hook.DebugLogging(gwNsName)).To(BeFalse())
+
+	// only the upstreams that receive traffic are queried
+	_, upstream := metrics.ErrorRateArgsForCall(0)
+	g.Expect(upstream).To(Equal("test_backend_80"))
+	g.Expect(metrics.ErrorRateCallCount()).To(Equal(3))
+
+	// the error rate crosses the threshold, so the action is triggered on the attached Gateways
+	metrics.ErrorRateReturns(0.5, nil)
+	changes := hook.Evaluate(context.Background(), createGraph(annotations))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StateTriggered))
+	g.Expect(changes[0].Action).To(Equal(ActionDebugLogging))
+	g.Expect(changes[0].Until).To(Equal(now.Add(10 * time.Minute)))
+	g.Expect(changes[0].Gateways).To(Equal([]types.NamespacedName{gwNsName}))
+	g.Expect(changes[0].Message).To(Equal(
+		"Outlier hook triggered: error rate 0.5000 of upstream test_backend_80 exceeds the maximum 0.1000; " +
+			"the action DebugLogging is active until 2025-01-01T00:10:00Z",
+	))
+	g.Expect(hook.DebugLogging(gwNsName)).To(BeTrue())
+	g.Expect(hook.DebugLogging(types.NamespacedName{Namespace: "test", Name: "other"})).To(BeFalse())
+
+	// the action stays active for its duration, without querying the metrics
+	calls := metrics.ErrorRateCallCount()
+	now = now.Add(5 * time.Minute)
+	g.Expect(hook.Evaluate(context.Background(), createGraph(annotations))).To(BeEmpty())
+	g.Expect(metrics.ErrorRateCallCount()).To(Equal(calls))
+
+	// the action expires
+	now = now.Add(5 * time.Minute)
+	changes = hook.Evaluate(context.Background(), createGraph(annotations))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StateExpired))
+	g.Expect(changes[0].Gateways).To(Equal([]types.NamespacedName{gwNsName}))
+	g.Expect(hook.DebugLogging(gwNsName)).To(BeFalse())
+
+	// the Route is not triggered again during the cooldown
+	now = now.Add(9 * time.Minute)
+	g.Expect(hook.Evaluate(context.Background(), createGraph(annotations))).To(BeEmpty())
+	g.Expect(metrics.ErrorRateCallCount()).To(Equal(calls))
+
+	// after the cooldown, the Route is triggered again
+	now = now.Add(time.Minute)
+	changes = hook.Evaluate(context.Background(), createGraph(annotations))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StateTriggered))
+
+	// the annotations change, so the action expires early
+	annotations[ActionAnnotation] = string(ActionAnnotatePods)
+	changes = hook.Evaluate(context.Background(), createGraph(annotations))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StateExpired))
+	g.Expect(changes[0].Action).To(Equal(ActionDebugLogging))
+
+	// the new action is triggered without a cooldown
+	changes = hook.Evaluate(context.Background(), createGraph(annotations))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StateTriggered))
+	g.Expect(changes[0].Action).To(Equal(ActionAnnotatePods))
+	g.Expect(hook.DebugLogging(gwNsName)).To(BeFalse())
+
+	// the Route no longer has the outlier annotations, so the action expires
+	changes = hook.Evaluate(context.Background(), createGraph(nil))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(StateExpired))
+	g.Expect(changes[0].Message).To(ContainSubstring("no longer configured"))
+}
+
+func TestHook_InvalidAnnotations(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	metrics := &canaryfakes.FakeMetricsProvider{}
+	hook := NewHook(logr.Discard(), metrics)
+
+	gr := createGraph(map[string]string{MaxErrorRateAnnotation: "high"})
+
+	g.Expect(hook.Evaluate(context.Background(), gr)).To(BeEmpty())
+	g.Expect(hook.Evaluate(context.Background(), nil)).To(BeEmpty())
+	g.Expect(metrics.ErrorRateCallCount()).To(BeZero())
+}
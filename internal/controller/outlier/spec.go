@@ -0,0 +1,118 @@
+package outlier
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxErrorRateAnnotation is the annotation of a Route with the maximum ratio, between 0 and 1, of the responses
+	// of an upstream of the Route with a 5xx status code to all responses of the upstream. The Route is watched
+	// by the Hook only if it has this annotation.
+	MaxErrorRateAnnotation = "gateway.nginx.org/outlier-max-error-rate"
+	// ActionAnnotation is the annotation of a Route with the Action that is triggered when the error rate
+	// of the Route crosses the threshold. Defaults to DebugLogging.
+	ActionAnnotation = "gateway.nginx.org/outlier-action"
+	// DurationAnnotation is the annotation of a Route with how long the Action stays active, for example 10m.
+	// Defaults to DefaultDuration, and must not exceed MaxDuration.
+	DurationAnnotation = "gateway.nginx.org/outlier-action-duration"
+	// CaptureUntilAnnotation is the annotation of the NGINX Pods with the time, in the RFC 3339 format,
+	// until which the AnnotatePods action is active.
+	CaptureUntilAnnotation = "gateway.nginx.org/outlier-capture-until"
+)
+
+const (
+	// DefaultDuration is the duration of the Action if the Route doesn't specify it.
+	DefaultDuration = 5 * time.Minute
+	// MaxDuration is the maximum duration of the Action.
+	MaxDuration = time.Hour
+)
+
+// Action is the diagnostic action that is triggered when the error rate of a Route crosses the threshold.
+type Action string
+
+const (
+	// ActionDebugLogging raises the NGINX error log level of the Gateways of the Route to debug.
+	ActionDebugLogging Action = "DebugLogging"
+	// ActionAnnotatePods annotates the NGINX Pods of the Gateways of the Route with the CaptureUntilAnnotation.
+	ActionAnnotatePods Action = "AnnotatePods"
+)
+
+// Spec is the specification of the outlier hook of a Route.
+type Spec struct {
+	// Action is the action that is triggered when the error rate crosses the threshold.
+	Action Action
+	// MaxErrorRate is the maximum error rate of the upstreams of the Route.
+	MaxErrorRate float64
+	// Duration is how long the Action stays active.
+	Duration time.Duration
+}
+
+// ParseSpec parses the Spec of the outlier hook from the annotations of a Route. It returns false
+// if the Route doesn't have the MaxErrorRateAnnotation.
+func ParseSpec(annotations map[string]string) (Spec, bool, error) {
+	value, exists := annotations[MaxErrorRateAnnotation]
+	if !exists {
+		return Spec{}, false, nil
+	}
+
+	var errs []error
+
+	spec := Spec{
+		Action:   ActionDebugLogging,
+		Duration: DefaultDuration,
+	}
+
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		errs = append(errs, fmt.Errorf("%s must be a number between 0 and 1, got %q", MaxErrorRateAnnotation, value))
+	} else {
+		spec.MaxErrorRate = rate
+	}
+
+	if value, exists := annotations[ActionAnnotation]; exists {
+		switch action := Action(value); action {
+		case ActionDebugLogging, ActionAnnotatePods:
+			spec.Action = action
+		default:
+			errs = append(
+				errs,
+				fmt.Errorf("%s must be %s or %s, got %q", ActionAnnotation, ActionDebugLogging, ActionAnnotatePods, value),
+			)
+		}
+	}
+
+	if value, exists := annotations[DurationAnnotation]; exists {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 || duration > MaxDuration {
+			errs = append(
+				errs,
+				fmt.Errorf("%s must be a positive duration of at most %s, got %q", DurationAnnotation, MaxDuration, value),
+			)
+		} else {
+			spec.Duration = duration
+		}
+	}
+
+	if len(errs) > 0 {
+		return Spec{}, true, errors.Join(errs...)
+	}
+
+	return spec, true, nil
+}
+
+// fingerprint returns the values of the outlier annotations. An active Action expires early
+// when the fingerprint changes.
+func fingerprint(annotations map[string]string) string {
+	return strings.Join(
+		[]string{
+			annotations[MaxErrorRateAnnotation],
+			annotations[ActionAnnotation],
+			annotations[DurationAnnotation],
+		},
+		"\x00",
+	)
+}
@@ -0,0 +1,79 @@
+package outlier
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expErr      string
+		expSpec     Spec
+		expExists   bool
+	}{
+		{
+			name:        "no max error rate",
+			annotations: map[string]string{ActionAnnotation: "AnnotatePods"},
+		},
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				MaxErrorRateAnnotation: "0.05",
+				ActionAnnotation:       "AnnotatePods",
+				DurationAnnotation:     "10m",
+			},
+			expSpec: Spec{
+				Action:       ActionAnnotatePods,
+				MaxErrorRate: 0.05,
+				Duration:     10 * time.Minute,
+			},
+			expExists: true,
+		},
+		{
+			name:        "defaults",
+			annotations: map[string]string{MaxErrorRateAnnotation: "0.1"},
+			expSpec: Spec{
+				Action:       ActionDebugLogging,
+				MaxErrorRate: 0.1,
+				Duration:     DefaultDuration,
+			},
+			expExists: true,
+		},
+		{
+			name: "invalid annotations",
+			annotations: map[string]string{
+				MaxErrorRateAnnotation: "-0.1",
+				ActionAnnotation:       "Capture",
+				DurationAnnotation:     "2h",
+			},
+			expErr: "gateway.nginx.org/outlier-max-error-rate must be a number between 0 and 1, got \"-0.1\"\n" +
+				"gateway.nginx.org/outlier-action must be DebugLogging or AnnotatePods, got \"Capture\"\n" +
+				"gateway.nginx.org/outlier-action-duration must be a positive duration of at most 1h0m0s, got \"2h\"",
+			expExists: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			spec, exists, err := ParseSpec(test.annotations)
+			g.Expect(exists).To(Equal(test.expExists))
+
+			if test.expErr != "" {
+				g.Expect(err).To(MatchError(test.expErr))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(spec).To(Equal(test.expSpec))
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/outlier"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/runnables"
+)
+
+const (
+	// outlierHookJitterFactor spreads the evaluations of the outlier hook of the replicas of the control plane.
+	outlierHookJitterFactor = 0.1
+	// outlierDebugErrorLevel is the NGINX error log level of the Gateways with an active DebugLogging action.
+	outlierDebugErrorLevel = "debug"
+)
+
+// outlierHookEvent makes the event handler apply the changes of the actions of the outlier hook.
+type outlierHookEvent struct {
+	changes []outlier.Change
+}
+
+// newOutlierHookJob creates a job that periodically evaluates the outlier hook of the Routes of the latest graph,
+// and sends an outlierHookEvent to the event loop when the action of a Route is triggered or expires.
+// Every replica of the control plane evaluates the hook, so that they all generate the same configuration.
+func newOutlierHookJob(
+	logger logr.Logger,
+	hook *outlier.Hook,
+	getLatestGraph func() *graph.Graph,
+	eventCh chan<- interface{},
+	readyCh <-chan struct{},
+	period time.Duration,
+) *runnables.LeaderOrNonLeader {
+	worker := func(ctx context.Context) {
+		changes := hook.Evaluate(ctx, getLatestGraph())
+		if len(changes) == 0 {
+			return
+		}
+
+		select {
+		case eventCh <- &outlierHookEvent{changes: changes}:
+		case <-ctx.Done():
+		}
+	}
+
+	return &runnables.LeaderOrNonLeader{
+		Runnable: runnables.NewCronJob(
+			runnables.CronJobConfig{
+				Worker:       worker,
+				Logger:       logger,
+				Period:       period,
+				JitterFactor: outlierHookJitterFactor,
+				ReadyCh:      readyCh,
+			},
+		),
+	}
+}
+
+// outlierHookChanged returns whether an action of the outlier hook changed since the last call, and resets it.
+func (h *eventHandlerImpl) outlierHookChanged() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	changed := h.outlierChanged
+	h.outlierChanged = false
+
+	return changed
+}
+
+// outlierDebugLogging returns whether a DebugLogging action of the outlier hook is active on the Gateway.
+func (h *eventHandlerImpl) outlierDebugLogging(gateway types.NamespacedName) bool {
+	return h.cfg.outlierHook != nil && h.cfg.outlierHook.DebugLogging(gateway)
+}
+
+// applyOutlierHookChanges records the changes of the actions of the outlier hook as events of the Routes,
+// and annotates the nginx Pods of the AnnotatePods actions. Only the leader records the events and annotates
+// the Pods, so that they are not duplicated by every replica.
+func (h *eventHandlerImpl) applyOutlierHookChanges(
+	ctx context.Context,
+	logger logr.Logger,
+	changes []outlier.Change,
+) {
+	if !h.isLeader() {
+		return
+	}
+
+	gr := h.cfg.processor.GetLatestGraph()
+
+	for _, change := range changes {
+		eventType := v1.EventTypeWarning
+		if change.State == outlier.StateExpired {
+			eventType = v1.EventTypeNormal
+		}
+
+		h.cfg.eventRecorder.Event(change.Route, eventType, "OutlierHook"+string(change.State), change.Message)
+
+		if change.Action != outlier.ActionAnnotatePods || gr == nil {
+			continue
+		}
+
+		for _, gwNsName := range change.Gateways {
+			gw, exists := gr.Gateways[gwNsName]
+			if !exists {
+				continue
+			}
+
+			if err := h.annotateOutlierPods(ctx, gw.DeploymentName, change); err != nil {
+				logger.Error(err, "Failed to annotate the nginx Pods for the outlier hook", "gateway", gwNsName.String())
+			}
+		}
+	}
+}
+
+// annotateOutlierPods sets the outlier.CaptureUntilAnnotation of the nginx Pods of a Deployment to the time
+// until which the triggered action is active, or removes it once the action expires. If the Pods are annotated
+// by another action that is active for longer, the annotation is left unchanged.
+func (h *eventHandlerImpl) annotateOutlierPods(
+	ctx context.Context,
+	deploymentName types.NamespacedName,
+	change outlier.Change,
+) error {
+	var pods v1.PodList
+	if err := h.cfg.k8sClient.List(
+		ctx,
+		&pods,
+		client.InNamespace(deploymentName.Namespace),
+		client.MatchingLabels{controller.AppNameLabel: deploymentName.Name},
+	); err != nil {
+		return fmt.Errorf("error listing nginx Pods: %w", err)
+	}
+
+	until := change.Until.UTC().Format(time.RFC3339)
+
+	var errs []error
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		current, annotated := pod.Annotations[outlier.CaptureUntilAnnotation]
+		currentUntil, err := time.Parse(time.RFC3339, current)
+		activeForLonger := annotated && err == nil && currentUntil.After(change.Until)
+
+		if activeForLonger || (change.State == outlier.StateExpired && !annotated) {
+			continue
+		}
+
+		if change.State == outlier.StateTriggered && current == until {
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+
+		if change.State == outlier.StateExpired {
+			delete(pod.Annotations, outlier.CaptureUntilAnnotation)
+		} else {
+			if pod.Annotations == nil {
+				pod.Annotations = make(map[string]string)
+			}
+			pod.Annotations[outlier.CaptureUntilAnnotation] = until
+		}
+
+		if err := h.cfg.k8sClient.Patch(ctx, pod, patch); err != nil {
+			errs = append(errs, fmt.Errorf("error annotating nginx Pod %s: %w", pod.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/canary/canaryfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/outlier"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+)
+
+func TestOutlierHookJob(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	route := &graph.L7Route{
+		Source: &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "test",
+				Name:        "route",
+				Annotations: map[string]string{outlier.MaxErrorRateAnnotation: "0.1"},
+			},
+		},
+		RouteType: graph.RouteTypeHTTP,
+		Valid:     true,
+		ParentRefs: []graph.ParentRef{
+			{
+				Gateway:    &graph.ParentRefGateway{NamespacedName: types.NamespacedName{Namespace: "test", Name: "gw"}},
+				Attachment: &graph.ParentRefAttachmentStatus{Attached: true},
+			},
+		},
+		Spec: graph.L7RouteSpec{
+			Rules: []graph.RouteRule{
+				{
+					BackendRefs: []graph.BackendRef{
+						{
+							SvcNsName:   types.NamespacedName{Namespace: "test", Name: "backend"},
+							ServicePort: v1.ServicePort{Port: 80},
+							Weight:      1,
+							Valid:       true,
+						},
+					},
+				},
+			},
+		},
+	}
+	gr := &graph.Graph{
+		Routes: map[graph.RouteKey]*graph.L7Route{graph.CreateRouteKey(route.Source): route},
+	}
+
+	metrics := &canaryfakes.FakeMetricsProvider{}
+	metrics.ErrorRateReturns(0.5, nil)
+
+	eventCh := make(chan interface{})
+	readyCh := make(chan struct{})
+
+	job := newOutlierHookJob(
+		logr.Discard(),
+		outlier.NewHook(logr.Discard(), metrics),
+		func() *graph.Graph { return gr },
+		eventCh,
+		readyCh,
+		10*time.Millisecond,
+	)
+	g.Expect(job.NeedLeaderElection()).To(BeFalse())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- job.Start(ctx)
+	}()
+
+	// the evaluation doesn't start until the control plane is ready
+	g.Consistently(eventCh).ShouldNot(Receive())
+
+	close(readyCh)
+
+	var event interface{}
+	g.Eventually(eventCh).Should(Receive(&event))
+	g.Expect(event).To(BeAssignableToTypeOf(&outlierHookEvent{}))
+
+	changes := event.(*outlierHookEvent).changes
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].State).To(Equal(outlier.StateTriggered))
+
+	// the action stays active, so no more events are sent
+	g.Consistently(eventCh).ShouldNot(Receive())
+
+	cancel()
+	g.Eventually(errCh).Should(Receive(BeNil()))
+}
+
+func TestAnnotateOutlierPods(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	deploymentName := types.NamespacedName{Namespace: "test", Name: "gw-nginx"}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	createPod := func(name string, annotations map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   deploymentName.Namespace,
+				Name:        name,
+				Labels:      map[string]string{controller.AppNameLabel: deploymentName.Name},
+				Annotations: annotations,
+			},
+		}
+	}
+
+	later := now.Add(time.Hour).Format(time.RFC3339)
+
+	plain := createPod("plain", nil)
+	longer := createPod("longer", map[string]string{outlier.CaptureUntilAnnotation: later})
+	other := createPod("other", nil)
+	other.Labels[controller.AppNameLabel] = "other-nginx"
+
+	k8sClient := fake.NewFakeClient(plain, longer, other)
+	handler := &eventHandlerImpl{cfg: eventHandlerConfig{k8sClient: k8sClient}}
+
+	annotation := func(pod *v1.Pod) map[string]string {
+		g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+		return pod.Annotations
+	}
+
+	triggered := outlier.Change{State: outlier.StateTriggered, Until: now.Add(5 * time.Minute)}
+	g.Expect(handler.annotateOutlierPods(context.Background(), deploymentName, triggered)).To(Succeed())
+
+	g.Expect(annotation(plain)).To(HaveKeyWithValue(outlier.CaptureUntilAnnotation, "2025-01-01T00:05:00Z"))
+	// the Pod is annotated by an action that is active for longer
+	g.Expect(annotation(longer)).To(HaveKeyWithValue(outlier.CaptureUntilAnnotation, later))
+	// the Pod doesn't belong to the Deployment
+	g.Expect(annotation(other)).ToNot(HaveKey(outlier.CaptureUntilAnnotation))
+
+	expired := outlier.Change{State: outlier.StateExpired, Until: triggered.Until}
+	g.Expect(handler.annotateOutlierPods(context.Background(), deploymentName, expired)).To(Succeed())
+
+	g.Expect(annotation(plain)).ToNot(HaveKey(outlier.CaptureUntilAnnotation))
+	g.Expect(annotation(longer)).To(HaveKeyWithValue(outlier.CaptureUntilAnnotation, later))
+}
@@ -0,0 +1,77 @@
+package provisioner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/ipam"
+)
+
+// allocateServiceAddress sets the address allocated by the AddressAllocator, if configured, on the nginx Service
+// of the Gateway. The Service isn't provisioned without its address, so that it doesn't get an address
+// that is not managed by the AddressAllocator.
+func (p *NginxProvisioner) allocateServiceAddress(
+	ctx context.Context,
+	gateway *gatewayv1.Gateway,
+	objects []client.Object,
+) error {
+	if p.cfg.AddressAllocator == nil {
+		return nil
+	}
+
+	for _, obj := range objects {
+		svc, ok := obj.(*corev1.Service)
+		if !ok {
+			continue
+		}
+
+		allocation, err := p.cfg.AddressAllocator.Allocate(ctx, client.ObjectKeyFromObject(gateway))
+		if err != nil {
+			p.cfg.EventRecorder.Eventf(
+				gateway,
+				corev1.EventTypeWarning,
+				"AddressAllocationFailed",
+				"Failed to allocate the address of the nginx Service: %s",
+				err.Error(),
+			)
+			return fmt.Errorf("error allocating the address of the nginx Service: %w", err)
+		}
+
+		applyAddressAllocation(svc, allocation)
+	}
+
+	return nil
+}
+
+// applyAddressAllocation sets the allocated address and annotations on the Service. The address of a LoadBalancer
+// Service is requested from the load balancer, unless the NginxProxy sets it, and the address of other Services
+// is added to their external IPs.
+func applyAddressAllocation(svc *corev1.Service, allocation ipam.Allocation) {
+	if len(allocation.Annotations) > 0 {
+		if svc.Annotations == nil {
+			svc.Annotations = make(map[string]string, len(allocation.Annotations))
+		}
+		maps.Copy(svc.Annotations, allocation.Annotations)
+	}
+
+	if allocation.Address == "" {
+		return
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		if svc.Spec.LoadBalancerIP == "" {
+			svc.Spec.LoadBalancerIP = allocation.Address
+		}
+		return
+	}
+
+	if !slices.Contains(svc.Spec.ExternalIPs, allocation.Address) {
+		svc.Spec.ExternalIPs = append(svc.Spec.ExternalIPs, allocation.Address)
+	}
+}
@@ -0,0 +1,95 @@
+package provisioner
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/ipam"
+)
+
+func TestApplyAddressAllocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		svc        *corev1.Service
+		expected   *corev1.Service
+		name       string
+		allocation ipam.Allocation
+	}{
+		{
+			name: "LoadBalancer Service",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			},
+			allocation: ipam.Allocation{
+				Annotations: map[string]string{ipam.MetalLBAddressPoolAnnotation: "gateways"},
+				Address:     "10.0.0.10",
+			},
+			expected: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{ipam.MetalLBAddressPoolAnnotation: "gateways"},
+				},
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerIP: "10.0.0.10"},
+			},
+		},
+		{
+			name: "LoadBalancer Service with an IP set by the NginxProxy",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerIP: "10.0.0.1"},
+			},
+			allocation: ipam.Allocation{Address: "10.0.0.10"},
+			expected: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerIP: "10.0.0.1"},
+			},
+		},
+		{
+			name: "NodePort Service",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"key": "value"}},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort},
+			},
+			allocation: ipam.Allocation{
+				Annotations: map[string]string{"ipam.example.com/id": "1"},
+				Address:     "10.0.0.10",
+			},
+			expected: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"key": "value", "ipam.example.com/id": "1"},
+				},
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, ExternalIPs: []string{"10.0.0.10"}},
+			},
+		},
+		{
+			name: "ClusterIP Service with the address already set",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ExternalIPs: []string{"10.0.0.10"}},
+			},
+			allocation: ipam.Allocation{Address: "10.0.0.10"},
+			expected: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ExternalIPs: []string{"10.0.0.10"}},
+			},
+		},
+		{
+			name: "empty allocation",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			},
+			expected: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			applyAddressAllocation(test.svc, test.allocation)
+			g.Expect(test.svc).To(Equal(test.expected))
+		})
+	}
+}
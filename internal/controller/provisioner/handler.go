@@ -11,6 +11,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -63,7 +64,8 @@ func (h *eventHandler) HandleEventBatch(ctx context.Context, logger logr.Logger,
 			case *gatewayv1.Gateway:
 				h.store.updateGateway(obj)
 			case *appsv1.Deployment, *appsv1.DaemonSet, *corev1.ServiceAccount,
-				*corev1.ConfigMap, *rbacv1.Role, *rbacv1.RoleBinding, *autoscalingv2.HorizontalPodAutoscaler:
+				*corev1.ConfigMap, *rbacv1.Role, *rbacv1.RoleBinding, *autoscalingv2.HorizontalPodAutoscaler,
+				*networkingv1.NetworkPolicy:
 				objLabels := labels.Set(obj.GetLabels())
 				if h.labelSelector.Matches(objLabels) {
 					gatewayName := objLabels.Get(controller.GatewayLabel)
@@ -74,6 +76,17 @@ func (h *eventHandler) HandleEventBatch(ctx context.Context, logger logr.Logger,
 					if err := h.updateOrDeleteResources(ctx, logger, obj, gatewayNSName); err != nil {
 						logger.Error(err, "error handling resource update")
 					}
+
+					// The NGINX Pods may have moved to other Nodes, whose addresses are reported in the Gateway
+					// status for the NodePort Services and the host network.
+					_, isDeployment := obj.(*appsv1.Deployment)
+					_, isDaemonSet := obj.(*appsv1.DaemonSet)
+					if isDeployment || isDaemonSet {
+						h.provisioner.cfg.StatusQueue.Enqueue(&status.QueueObject{
+							Deployment: client.ObjectKeyFromObject(obj),
+							UpdateType: status.UpdateGateway,
+						})
+					}
 				}
 			case *corev1.Service:
 				objLabels := labels.Set(obj.GetLabels())
@@ -124,7 +137,8 @@ func (h *eventHandler) HandleEventBatch(ctx context.Context, logger logr.Logger,
 				}
 				h.store.deleteGateway(e.NamespacedName)
 			case *appsv1.Deployment, *appsv1.DaemonSet, *corev1.Service, *corev1.ServiceAccount,
-				*corev1.ConfigMap, *rbacv1.Role, *rbacv1.RoleBinding, *autoscalingv2.HorizontalPodAutoscaler:
+				*corev1.ConfigMap, *rbacv1.Role, *rbacv1.RoleBinding, *autoscalingv2.HorizontalPodAutoscaler,
+				*networkingv1.NetworkPolicy:
 				if err := h.reprovisionResources(ctx, e); err != nil {
 					logger.Error(err, "error re-provisioning nginx resources")
 				}
@@ -191,11 +205,7 @@ func (h *eventHandler) provisionResource(
 	if resources != nil && resources.Gateway != nil {
 		resourceName := controller.CreateNginxResourceName(gatewayNSName.Name, h.gcName)
 
-		objects, err := h.provisioner.buildNginxResourceObjects(
-			resourceName,
-			resources.Gateway.Source,
-			resources.Gateway.EffectiveNginxProxy,
-		)
+		objects, err := h.provisioner.buildNginxResourceObjectsForGateway(resourceName, resources.Gateway)
 		if err != nil {
 			logger.Error(err, "error building some nginx resources")
 		}
@@ -230,12 +240,7 @@ func (h *eventHandler) provisionResource(
 func (h *eventHandler) reprovisionResources(ctx context.Context, event *events.DeleteEvent) error {
 	if gateway := h.store.gatewayExistsForResource(event.Type, event.NamespacedName); gateway != nil && gateway.Valid {
 		resourceName := controller.CreateNginxResourceName(gateway.Source.GetName(), h.gcName)
-		if err := h.provisioner.reprovisionNginx(
-			ctx,
-			resourceName,
-			gateway.Source,
-			gateway.EffectiveNginxProxy,
-		); err != nil {
+		if err := h.provisioner.reprovisionNginx(ctx, resourceName, gateway); err != nil {
 			return err
 		}
 	}
@@ -128,6 +128,12 @@ func TestHandleEventBatch_Upsert(t *testing.T) {
 
 	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(deployment), &appsv1.Deployment{})).To(Succeed())
 
+	item := provisioner.cfg.StatusQueue.Dequeue(ctx)
+	g.Expect(item).ToNot(BeNil())
+	g.Expect(item.UpdateType).To(BeEquivalentTo(status.UpdateGateway))
+	g.Expect(item.Deployment).To(Equal(client.ObjectKeyFromObject(deployment)))
+	g.Expect(item.GatewayService).To(BeNil())
+
 	// Test handling Service
 	upsertEvent = &events.UpsertEvent{Resource: service}
 	batch = events.EventBatch{upsertEvent}
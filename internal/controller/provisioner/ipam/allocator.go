@@ -0,0 +1,64 @@
+package ipam
+
+import (
+	"context"
+	"maps"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+//go:generate go tool counterfeiter -generate
+
+// MetalLBAddressPoolAnnotation is the annotation of a Service that selects the MetalLB address pool
+// that its address is allocated from.
+const MetalLBAddressPoolAnnotation = "metallb.io/address-pool"
+
+// Allocation is the allocation of the address of the Service of a Gateway.
+type Allocation struct {
+	// Annotations are the annotations of the Service, for example, the ones that select the address pool
+	// of the load balancer.
+	Annotations map[string]string
+	// Address is the IP address of the Service. If empty, the address is assigned by the load balancer.
+	Address string
+}
+
+//counterfeiter:generate . AddressAllocator
+
+// AddressAllocator allocates the addresses of the Services of the Gateways.
+type AddressAllocator interface {
+	// Allocate allocates the address of the Service of the Gateway. It is called every time the Service
+	// is provisioned, so it must return the same Allocation for the same Gateway until the address is released.
+	Allocate(ctx context.Context, gateway types.NamespacedName) (Allocation, error)
+	// Release releases the address of the Service of the Gateway, once the Gateway is deleted.
+	Release(ctx context.Context, gateway types.NamespacedName) error
+}
+
+// MetalLBAllocator allocates the addresses of the Services from a MetalLB address pool.
+type MetalLBAllocator struct {
+	pool string
+}
+
+// NewMetalLBAllocator creates a new MetalLBAllocator for the MetalLB address pool.
+func NewMetalLBAllocator(pool string) *MetalLBAllocator {
+	return &MetalLBAllocator{pool: pool}
+}
+
+// Allocate selects the address pool of the Service. MetalLB assigns the address from the pool.
+func (a *MetalLBAllocator) Allocate(_ context.Context, _ types.NamespacedName) (Allocation, error) {
+	return Allocation{
+		Annotations: map[string]string{MetalLBAddressPoolAnnotation: a.pool},
+	}, nil
+}
+
+// Release does nothing, because MetalLB releases the address once the Service is deleted.
+func (a *MetalLBAllocator) Release(_ context.Context, _ types.NamespacedName) error {
+	return nil
+}
+
+// cloneAllocation returns a copy of the Allocation that doesn't share the annotations.
+func cloneAllocation(allocation Allocation) Allocation {
+	return Allocation{
+		Annotations: maps.Clone(allocation.Annotations),
+		Address:     allocation.Address,
+	}
+}
@@ -0,0 +1,25 @@
+package ipam
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestMetalLBAllocator(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	allocator := NewMetalLBAllocator("gateways")
+	gateway := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	allocation, err := allocator.Allocate(context.Background(), gateway)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(allocation).To(Equal(Allocation{
+		Annotations: map[string]string{MetalLBAddressPoolAnnotation: "gateways"},
+	}))
+
+	g.Expect(allocator.Release(context.Background(), gateway)).To(Succeed())
+}
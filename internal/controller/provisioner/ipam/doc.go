@@ -0,0 +1,8 @@
+/*
+Package ipam allocates the addresses of the NGINX Services of the Gateways.
+
+An AddressAllocator assigns an address, and the annotations that select the address, to the Service of a Gateway
+when the Service is provisioned, and releases the address when the Gateway is deleted. The built-in allocators
+select a MetalLB address pool, or request an address from an external IPAM service over HTTP.
+*/
+package ipam
@@ -0,0 +1,126 @@
+package ipam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	httpTimeout = 10 * time.Second
+	// maxResponseSize limits the size of the responses of the IPAM service.
+	maxResponseSize = 64 * 1024
+)
+
+// httpAllocation is the response of the IPAM service to an allocation request.
+type httpAllocation struct {
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Address     string            `json:"address"`
+}
+
+// HTTPAllocator allocates the addresses of the Services from an external IPAM service.
+//
+// The address of a Gateway is allocated with the request PUT <endpoint>/gateways/<namespace>/<name>,
+// which returns the JSON object {"address": "<ip>", "annotations": {...}}. The request must be idempotent:
+// the IPAM service returns the same address for the same Gateway until it is released with the request
+// DELETE <endpoint>/gateways/<namespace>/<name>.
+type HTTPAllocator struct {
+	client      *http.Client
+	allocations map[types.NamespacedName]Allocation
+	endpoint    string
+	lock        sync.Mutex
+}
+
+// NewHTTPAllocator creates a new HTTPAllocator for the endpoint of the IPAM service.
+func NewHTTPAllocator(endpoint string) *HTTPAllocator {
+	return &HTTPAllocator{
+		client:      &http.Client{Timeout: httpTimeout},
+		allocations: make(map[types.NamespacedName]Allocation),
+		endpoint:    endpoint,
+	}
+}
+
+// Allocate requests the address of the Gateway from the IPAM service. The allocation is cached until it is released.
+func (a *HTTPAllocator) Allocate(ctx context.Context, gateway types.NamespacedName) (Allocation, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if allocation, exists := a.allocations[gateway]; exists {
+		return cloneAllocation(allocation), nil
+	}
+
+	body, err := a.do(ctx, http.MethodPut, gateway)
+	if err != nil {
+		return Allocation{}, err
+	}
+
+	var resp httpAllocation
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Allocation{}, fmt.Errorf("failed to decode the allocation of Gateway %s: %w", gateway, err)
+	}
+
+	if net.ParseIP(resp.Address) == nil {
+		return Allocation{}, fmt.Errorf("IPAM service allocated an invalid address %q to Gateway %s", resp.Address, gateway)
+	}
+
+	allocation := Allocation{Annotations: resp.Annotations, Address: resp.Address}
+	a.allocations[gateway] = allocation
+
+	return cloneAllocation(allocation), nil
+}
+
+// Release releases the address of the Gateway in the IPAM service.
+func (a *HTTPAllocator) Release(ctx context.Context, gateway types.NamespacedName) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if _, err := a.do(ctx, http.MethodDelete, gateway); err != nil {
+		return err
+	}
+
+	delete(a.allocations, gateway)
+
+	return nil
+}
+
+func (a *HTTPAllocator) do(ctx context.Context, method string, gateway types.NamespacedName) ([]byte, error) {
+	reqURL, err := url.JoinPath(a.endpoint, "gateways", gateway.Namespace, gateway.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the IPAM request URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the IPAM request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("IPAM request %s %s failed: %w", method, reqURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the IPAM response: %w", err)
+	}
+
+	switch {
+	case method == http.MethodDelete && resp.StatusCode == http.StatusNotFound:
+		// the address is already released
+		return body, nil
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		return nil, fmt.Errorf("IPAM request %s %s returned status %d", method, reqURL, resp.StatusCode)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,103 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeIPAM is an IPAM service that allocates the addresses of the Gateways from a list.
+type fakeIPAM struct {
+	addresses map[string]string
+	lock      sync.Mutex
+}
+
+func (f *fakeIPAM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	address, exists := f.addresses[r.URL.Path]
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"address":%q,"annotations":{"ipam.example.com/id":"1"}}`, address)
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHTTPAllocator(t *testing.T) {
+	t.Parallel()
+
+	ipam := &fakeIPAM{
+		addresses: map[string]string{
+			"/ipam/gateways/test/gateway": "10.0.0.10",
+			"/ipam/gateways/test/invalid": "not-an-ip",
+		},
+	}
+	server := httptest.NewServer(ipam)
+	t.Cleanup(server.Close)
+
+	t.Run("allocate and release", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		allocator := NewHTTPAllocator(server.URL + "/ipam")
+		gateway := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+		expAllocation := Allocation{
+			Annotations: map[string]string{"ipam.example.com/id": "1"},
+			Address:     "10.0.0.10",
+		}
+
+		allocation, err := allocator.Allocate(context.Background(), gateway)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(allocation).To(Equal(expAllocation))
+
+		// the allocation is cached
+		allocation.Annotations["modified"] = "true"
+		allocation, err = allocator.Allocate(context.Background(), gateway)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(allocation).To(Equal(expAllocation))
+
+		g.Expect(allocator.Release(context.Background(), gateway)).To(Succeed())
+		g.Expect(allocator.allocations).To(BeEmpty())
+	})
+
+	t.Run("release of an unknown Gateway", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		allocator := NewHTTPAllocator(server.URL + "/ipam")
+		g.Expect(allocator.Release(context.Background(), types.NamespacedName{Namespace: "test", Name: "unknown"})).
+			To(Succeed())
+	})
+
+	t.Run("allocation errors", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		allocator := NewHTTPAllocator(server.URL + "/ipam")
+
+		_, err := allocator.Allocate(context.Background(), types.NamespacedName{Namespace: "test", Name: "unknown"})
+		g.Expect(err).To(MatchError(ContainSubstring("returned status 404")))
+
+		_, err = allocator.Allocate(context.Background(), types.NamespacedName{Namespace: "test", Name: "invalid"})
+		g.Expect(err).To(MatchError(ContainSubstring(`invalid address "not-an-ip"`)))
+
+		g.Expect(allocator.allocations).To(BeEmpty())
+	})
+}
@@ -0,0 +1,192 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package ipamfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/ipam"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type FakeAddressAllocator struct {
+	AllocateStub        func(context.Context, types.NamespacedName) (ipam.Allocation, error)
+	allocateMutex       sync.RWMutex
+	allocateArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}
+	allocateReturns struct {
+		result1 ipam.Allocation
+		result2 error
+	}
+	allocateReturnsOnCall map[int]struct {
+		result1 ipam.Allocation
+		result2 error
+	}
+	ReleaseStub        func(context.Context, types.NamespacedName) error
+	releaseMutex       sync.RWMutex
+	releaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}
+	releaseReturns struct {
+		result1 error
+	}
+	releaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAddressAllocator) Allocate(arg1 context.Context, arg2 types.NamespacedName) (ipam.Allocation, error) {
+	fake.allocateMutex.Lock()
+	ret, specificReturn := fake.allocateReturnsOnCall[len(fake.allocateArgsForCall)]
+	fake.allocateArgsForCall = append(fake.allocateArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}{arg1, arg2})
+	stub := fake.AllocateStub
+	fakeReturns := fake.allocateReturns
+	fake.recordInvocation("Allocate", []interface{}{arg1, arg2})
+	fake.allocateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAddressAllocator) AllocateCallCount() int {
+	fake.allocateMutex.RLock()
+	defer fake.allocateMutex.RUnlock()
+	return len(fake.allocateArgsForCall)
+}
+
+func (fake *FakeAddressAllocator) AllocateCalls(stub func(context.Context, types.NamespacedName) (ipam.Allocation, error)) {
+	fake.allocateMutex.Lock()
+	defer fake.allocateMutex.Unlock()
+	fake.AllocateStub = stub
+}
+
+func (fake *FakeAddressAllocator) AllocateArgsForCall(i int) (context.Context, types.NamespacedName) {
+	fake.allocateMutex.RLock()
+	defer fake.allocateMutex.RUnlock()
+	argsForCall := fake.allocateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAddressAllocator) AllocateReturns(result1 ipam.Allocation, result2 error) {
+	fake.allocateMutex.Lock()
+	defer fake.allocateMutex.Unlock()
+	fake.AllocateStub = nil
+	fake.allocateReturns = struct {
+		result1 ipam.Allocation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAddressAllocator) AllocateReturnsOnCall(i int, result1 ipam.Allocation, result2 error) {
+	fake.allocateMutex.Lock()
+	defer fake.allocateMutex.Unlock()
+	fake.AllocateStub = nil
+	if fake.allocateReturnsOnCall == nil {
+		fake.allocateReturnsOnCall = make(map[int]struct {
+			result1 ipam.Allocation
+			result2 error
+		})
+	}
+	fake.allocateReturnsOnCall[i] = struct {
+		result1 ipam.Allocation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAddressAllocator) Release(arg1 context.Context, arg2 types.NamespacedName) error {
+	fake.releaseMutex.Lock()
+	ret, specificReturn := fake.releaseReturnsOnCall[len(fake.releaseArgsForCall)]
+	fake.releaseArgsForCall = append(fake.releaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}{arg1, arg2})
+	stub := fake.ReleaseStub
+	fakeReturns := fake.releaseReturns
+	fake.recordInvocation("Release", []interface{}{arg1, arg2})
+	fake.releaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAddressAllocator) ReleaseCallCount() int {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return len(fake.releaseArgsForCall)
+}
+
+func (fake *FakeAddressAllocator) ReleaseCalls(stub func(context.Context, types.NamespacedName) error) {
+	fake.releaseMutex.Lock()
+	defer fake.releaseMutex.Unlock()
+	fake.ReleaseStub = stub
+}
+
+func (fake *FakeAddressAllocator) ReleaseArgsForCall(i int) (context.Context, types.NamespacedName) {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	argsForCall := fake.releaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAddressAllocator) ReleaseReturns(result1 error) {
+	fake.releaseMutex.Lock()
+	defer fake.releaseMutex.Unlock()
+	fake.ReleaseStub = nil
+	fake.releaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAddressAllocator) ReleaseReturnsOnCall(i int, result1 error) {
+	fake.releaseMutex.Lock()
+	defer fake.releaseMutex.Unlock()
+	fake.ReleaseStub = nil
+	if fake.releaseReturnsOnCall == nil {
+		fake.releaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAddressAllocator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAddressAllocator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ipam.AddressAllocator = new(FakeAddressAllocator)
@@ -0,0 +1,462 @@
+package provisioner
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+const (
+	// controlPlaneServicePort is the port of the control plane Service that nginx agent connects to.
+	controlPlaneServicePort = 443
+	dnsPort                 = 53
+	namespaceNameLabel      = "kubernetes.io/metadata.name"
+	// otelExporterPort is the default port of the OTLP/gRPC endpoint of the OpenTelemetry exporter.
+	otelExporterPort = 4317
+	// usageReportEndpoint is the default endpoint that NGINX Plus reports its usage to.
+	usageReportEndpoint = "product.connect.nginx.com"
+	// usageReportPort is the default port of the usage reporting endpoint.
+	usageReportPort = 443
+)
+
+// networkPolicyBackend is a Service port or a static endpoint of a Backend that the nginx Pods need to send
+// traffic to. For static endpoints, address is set instead of svcNsName.
+type networkPolicyBackend struct {
+	svcNsName types.NamespacedName
+	address   string
+	port      int32
+}
+
+func isNetworkPolicyEnabled(nProxyCfg *graph.EffectiveNginxProxy) bool {
+	return nProxyCfg != nil && nProxyCfg.Kubernetes != nil &&
+		nProxyCfg.Kubernetes.NetworkPolicy != nil && nProxyCfg.Kubernetes.NetworkPolicy.Enable
+}
+
+// buildNginxResourceObjectsForGateway builds the nginx resources for the Gateway, including the resources
+// that depend on the Routes attached to the Gateway, like the NetworkPolicy.
+func (p *NginxProvisioner) buildNginxResourceObjectsForGateway(
+	resourceName string,
+	gateway *graph.Gateway,
+) ([]client.Object, error) {
+	objects, err := p.buildNginxResourceObjects(resourceName, gateway.Source, gateway.EffectiveNginxProxy)
+
+	if !isNetworkPolicyEnabled(gateway.EffectiveNginxProxy) {
+		return objects, err
+	}
+
+	backends := slices.Concat(networkPolicyBackends(gateway), p.networkPolicyEndpoints(gateway.EffectiveNginxProxy))
+
+	networkPolicy, npErr := p.buildNginxNetworkPolicy(objects, backends)
+	if npErr != nil {
+		return objects, errors.Join(err, npErr)
+	}
+
+	return append(objects, networkPolicy), err
+}
+
+// networkPolicyBackends returns the sorted list of backend Service ports referenced by the valid Routes
+// attached to the Gateway, and the remote authentication endpoints of the policies of the Routes.
+func networkPolicyBackends(gateway *graph.Gateway) []networkPolicyBackend {
+	if gateway == nil {
+		return nil
+	}
+
+	backends := make(map[networkPolicyBackend]struct{})
+	addBackendRef := func(ref graph.BackendRef) {
+		if !ref.Valid || ref.SvcNsName.Name == "" {
+			return
+		}
+
+		if ref.IsStaticBackend() {
+			for _, endpoint := range ref.StaticEndpoints {
+				backends[networkPolicyBackend{address: endpoint.Address, port: endpoint.Port}] = struct{}{}
+			}
+			return
+		}
+
+		backends[networkPolicyBackend{svcNsName: ref.SvcNsName, port: ref.ServicePort.Port}] = struct{}{}
+
+		if epp := ref.EndpointPickerConfig.EndpointPickerRef; epp != nil && epp.Port != nil {
+			eppBackend := networkPolicyBackend{
+				svcNsName: types.NamespacedName{
+					Namespace: ref.EndpointPickerConfig.NsName,
+					Name:      string(epp.Name),
+				},
+				port: int32(epp.Port.Number),
+			}
+			backends[eppBackend] = struct{}{}
+		}
+	}
+
+	for _, listener := range gateway.Listeners {
+		for _, route := range listener.Routes {
+			if !route.Valid {
+				continue
+			}
+
+			for _, rule := range route.Spec.Rules {
+				for _, ref := range rule.BackendRefs {
+					addBackendRef(ref)
+				}
+			}
+
+			for _, pol := range route.Policies {
+				if endpoint, ok := remoteAuthenticationEndpoint(pol); ok {
+					backends[endpoint] = struct{}{}
+				}
+			}
+		}
+
+		for _, route := range listener.L4Routes {
+			if !route.Valid {
+				continue
+			}
+
+			addBackendRef(route.Spec.BackendRef)
+		}
+	}
+
+	result := slices.Collect(maps.Keys(backends))
+	slices.SortFunc(result, func(a, b networkPolicyBackend) int {
+		return cmp.Or(
+			cmp.Compare(a.svcNsName.Namespace, b.svcNsName.Namespace),
+			cmp.Compare(a.svcNsName.Name, b.svcNsName.Name),
+			cmp.Compare(a.address, b.address),
+			cmp.Compare(a.port, b.port),
+		)
+	})
+
+	return result
+}
+
+// remoteAuthenticationEndpoint returns the remote endpoint that authenticates the requests of a valid
+// ClientSettingsPolicy in the Remote mode.
+func remoteAuthenticationEndpoint(pol *graph.Policy) (networkPolicyBackend, bool) {
+	if !pol.Valid {
+		return networkPolicyBackend{}, false
+	}
+
+	csp, ok := pol.Source.(*ngfAPIv1alpha1.ClientSettingsPolicy)
+	if !ok || csp.Spec.Authentication == nil || csp.Spec.Authentication.Remote == nil {
+		return networkPolicyBackend{}, false
+	}
+
+	u, err := url.Parse(csp.Spec.Authentication.Remote.URL)
+	if err != nil || u.Hostname() == "" {
+		return networkPolicyBackend{}, false
+	}
+
+	port := int32(80)
+	if u.Scheme == "https" {
+		port = 443
+	}
+
+	if u.Port() != "" {
+		p, err := strconv.ParseInt(u.Port(), 10, 32)
+		if err != nil {
+			return networkPolicyBackend{}, false
+		}
+
+		port = int32(p)
+	}
+
+	return networkPolicyBackend{address: u.Hostname(), port: port}, true
+}
+
+// networkPolicyEndpoints returns the endpoints outside of the cluster that the nginx Pods send traffic to:
+// the OpenTelemetry exporter, the NGINX Plus usage reporting endpoint, and the NGINX One Console.
+func (p *NginxProvisioner) networkPolicyEndpoints(nProxyCfg *graph.EffectiveNginxProxy) []networkPolicyBackend {
+	var endpoints []networkPolicyBackend
+
+	if nProxyCfg != nil && nProxyCfg.Telemetry != nil && nProxyCfg.Telemetry.Exporter != nil &&
+		nProxyCfg.Telemetry.Exporter.Endpoint != nil {
+		if endpoint, ok := parseNetworkPolicyEndpoint(*nProxyCfg.Telemetry.Exporter.Endpoint, otelExporterPort); ok {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	if p.cfg.Plus && p.cfg.PlusUsageConfig != nil {
+		usageEndpoint := cmp.Or(p.cfg.PlusUsageConfig.Endpoint, usageReportEndpoint)
+		if endpoint, ok := parseNetworkPolicyEndpoint(usageEndpoint, usageReportPort); ok {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	if telemetryCfg := p.cfg.NginxOneConsoleTelemetryConfig; telemetryCfg.DataplaneKeySecretName != "" {
+		endpoints = append(endpoints, networkPolicyBackend{
+			address: telemetryCfg.EndpointHost,
+			port:    int32(telemetryCfg.EndpointPort), //nolint:gosec // validated port
+		})
+	}
+
+	return endpoints
+}
+
+// parseNetworkPolicyEndpoint parses the endpoint in the [scheme://]host[:port] format.
+// The port defaults to defaultPort.
+func parseNetworkPolicyEndpoint(endpoint string, defaultPort int32) (networkPolicyBackend, bool) {
+	if _, address, found := strings.Cut(endpoint, "://"); found {
+		endpoint = address
+	}
+
+	if endpoint == "" {
+		return networkPolicyBackend{}, false
+	}
+
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return networkPolicyBackend{address: endpoint, port: defaultPort}, true
+	}
+
+	port, err := strconv.ParseInt(portStr, 10, 32)
+	if err != nil || host == "" {
+		return networkPolicyBackend{}, false
+	}
+
+	return networkPolicyBackend{address: host, port: int32(port)}, true
+}
+
+// buildNginxNetworkPolicy builds the NetworkPolicy for the nginx Pods. Ingress is allowed to the ports
+// exposed by the nginx containers, and egress is allowed to DNS, every enabled listener of the control plane,
+// and the backends.
+func (p *NginxProvisioner) buildNginxNetworkPolicy(
+	objects []client.Object,
+	backends []networkPolicyBackend,
+) (*networkingv1.NetworkPolicy, error) {
+	var objectMeta metav1.ObjectMeta
+	var selector *metav1.LabelSelector
+	var podSpec corev1.PodSpec
+
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			objectMeta, selector, podSpec = o.ObjectMeta, o.Spec.Selector, o.Spec.Template.Spec
+		case *appsv1.DaemonSet:
+			objectMeta, selector, podSpec = o.ObjectMeta, o.Spec.Selector, o.Spec.Template.Spec
+		}
+	}
+
+	if selector == nil {
+		return nil, errors.New("cannot build NetworkPolicy: nginx Deployment or DaemonSet not found")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	egress := []networkingv1.NetworkPolicyEgressRule{buildDNSEgressRule()}
+
+	controlPlaneSvc := types.NamespacedName{
+		Namespace: p.cfg.GatewayPodConfig.Namespace,
+		Name:      p.cfg.GatewayPodConfig.ServiceName,
+	}
+
+	for _, port := range slices.Concat([]int32{controlPlaneServicePort}, p.cfg.ControlPlanePorts) {
+		controlPlane := networkPolicyBackend{svcNsName: controlPlaneSvc, port: port}
+
+		controlPlaneRule, err := p.buildEgressRuleForBackend(ctx, controlPlane)
+		if err != nil {
+			return nil, fmt.Errorf("cannot build NetworkPolicy egress rule for the control plane: %w", err)
+		}
+		// the egress to the control plane must be restricted to its Pods
+		if controlPlaneRule == nil || len(controlPlaneRule.To) == 0 {
+			return nil, fmt.Errorf(
+				"cannot build NetworkPolicy egress rule for the control plane: Service %s does not exist or has no selector",
+				controlPlaneSvc,
+			)
+		}
+
+		egress = append(egress, *controlPlaneRule)
+	}
+
+	var errs []error
+	for _, backend := range backends {
+		if backend.address != "" {
+			egress = append(egress, buildEgressRuleForStaticEndpoint(backend))
+			continue
+		}
+
+		rule, err := p.buildEgressRuleForBackend(ctx, backend)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if rule != nil {
+			egress = append(egress, *rule)
+		}
+	}
+
+	var ingressPorts []networkingv1.NetworkPolicyPort
+	for _, container := range podSpec.Containers {
+		for _, port := range container.Ports {
+			ingressPorts = append(ingressPorts, networkingv1.NetworkPolicyPort{
+				Protocol: helpers.GetPointer(cmp.Or(port.Protocol, corev1.ProtocolTCP)),
+				Port:     helpers.GetPointer(intstr.FromInt32(port.ContainerPort)),
+			})
+		}
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        objectMeta.Name,
+			Namespace:   objectMeta.Namespace,
+			Labels:      maps.Clone(objectMeta.Labels),
+			Annotations: maps.Clone(objectMeta.Annotations),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: *selector.DeepCopy(),
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{Ports: ingressPorts},
+			},
+			Egress: egress,
+		},
+	}
+
+	return networkPolicy, errors.Join(errs...)
+}
+
+// buildEgressRuleForBackend builds an egress rule that allows traffic to the Pods of the backend Service
+// on the target port of the Service. Returns nil if the Service doesn't exist.
+// The Pods of ExternalName Services and of Services without a selector can't be selected, so the traffic
+// is allowed to any destination on the port of the Service instead. The Gateway reports such Services
+// in its NetworkPolicyRestricted condition.
+func (p *NginxProvisioner) buildEgressRuleForBackend(
+	ctx context.Context,
+	backend networkPolicyBackend,
+) (*networkingv1.NetworkPolicyEgressRule, error) {
+	svc := &corev1.Service{}
+	if err := p.k8sClient.Get(ctx, backend.svcNsName, svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil //nolint:nilnil // a missing Service has no Pods to send traffic to
+		}
+		return nil, fmt.Errorf("error getting Service %s: %w", backend.svcNsName, err)
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		// nginx connects to the external name on the port of the Service
+		return buildEgressRuleToAnyAddress(corev1.ProtocolTCP, intstr.FromInt32(backend.port)), nil
+	}
+
+	targetPort := intstr.FromInt32(backend.port)
+	protocol := corev1.ProtocolTCP
+	for _, svcPort := range svc.Spec.Ports {
+		if svcPort.Port != backend.port {
+			continue
+		}
+
+		if svcPort.TargetPort.IntVal != 0 || svcPort.TargetPort.StrVal != "" {
+			targetPort = svcPort.TargetPort
+		}
+		protocol = cmp.Or(svcPort.Protocol, corev1.ProtocolTCP)
+		break
+	}
+
+	if len(svc.Spec.Selector) == 0 {
+		// the endpoints of Services without a selector are managed manually, and their named ports can't be resolved
+		if targetPort.Type == intstr.String {
+			targetPort = intstr.FromInt32(backend.port)
+		}
+
+		return buildEgressRuleToAnyAddress(protocol, targetPort), nil
+	}
+
+	return &networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{namespaceNameLabel: svc.GetNamespace()},
+				},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: maps.Clone(svc.Spec.Selector),
+				},
+			},
+		},
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Protocol: helpers.GetPointer(protocol),
+				Port:     helpers.GetPointer(targetPort),
+			},
+		},
+	}, nil
+}
+
+// buildEgressRuleToAnyAddress builds an egress rule that allows traffic to any destination on the port.
+func buildEgressRuleToAnyAddress(
+	protocol corev1.Protocol,
+	port intstr.IntOrString,
+) *networkingv1.NetworkPolicyEgressRule {
+	return &networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Protocol: helpers.GetPointer(protocol),
+				Port:     helpers.GetPointer(port),
+			},
+		},
+	}
+}
+
+// buildEgressRuleForStaticEndpoint builds an egress rule that allows traffic to a static endpoint of a Backend.
+// Since the IP addresses of a DNS name are not known in advance, traffic to DNS name endpoints is allowed
+// to any destination on the port of the endpoint.
+func buildEgressRuleForStaticEndpoint(backend networkPolicyBackend) networkingv1.NetworkPolicyEgressRule {
+	rule := networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+				Port:     helpers.GetPointer(intstr.FromInt32(backend.port)),
+			},
+		},
+	}
+
+	if ip := net.ParseIP(backend.address); ip != nil {
+		prefixLen := 32
+		if ip.To4() == nil {
+			prefixLen = 128
+		}
+
+		rule.To = []networkingv1.NetworkPolicyPeer{
+			{
+				IPBlock: &networkingv1.IPBlock{CIDR: fmt.Sprintf("%s/%d", ip.String(), prefixLen)},
+			},
+		}
+	}
+
+	return rule
+}
+
+func buildDNSEgressRule() networkingv1.NetworkPolicyEgressRule {
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Protocol: helpers.GetPointer(corev1.ProtocolUDP),
+				Port:     helpers.GetPointer(intstr.FromInt32(dnsPort)),
+			},
+			{
+				Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+				Port:     helpers.GetPointer(intstr.FromInt32(dnsPort)),
+			},
+		},
+	}
+}
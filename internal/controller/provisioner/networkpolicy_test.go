@@ -0,0 +1,598 @@
+package provisioner
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/config"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+func networkPolicyEnabledNginxProxy() *graph.EffectiveNginxProxy {
+	return &graph.EffectiveNginxProxy{
+		Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+			NetworkPolicy: &ngfAPIv1alpha2.NetworkPolicySpec{
+				Enable: true,
+			},
+		},
+	}
+}
+
+func listenerWithBackend(svcName string, port int32) *graph.Listener {
+	return &graph.Listener{
+		Routes: map[graph.RouteKey]*graph.L7Route{
+			{NamespacedName: types.NamespacedName{Namespace: "default", Name: "route"}}: {
+				Valid: true,
+				Spec: graph.L7RouteSpec{
+					Rules: []graph.RouteRule{
+						{
+							BackendRefs: []graph.BackendRef{
+								{
+									SvcNsName:   types.NamespacedName{Namespace: "default", Name: svcName},
+									ServicePort: corev1.ServicePort{Port: port},
+									Valid:       true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNetworkPolicyBackends(t *testing.T) {
+	t.Parallel()
+
+	backendRef := func(namespace, name string, port int32, valid bool) graph.BackendRef {
+		return graph.BackendRef{
+			SvcNsName:   types.NamespacedName{Namespace: namespace, Name: name},
+			ServicePort: corev1.ServicePort{Port: port},
+			Valid:       valid,
+		}
+	}
+
+	eppBackendRef := backendRef("default", "pool-shadow", 8000, true)
+	eppBackendRef.EndpointPickerConfig = graph.EndpointPickerConfig{
+		NsName: "default",
+		EndpointPickerRef: &inference.EndpointPickerRef{
+			Name: "epp",
+			Port: &inference.Port{Number: 9002},
+		},
+	}
+
+	remoteAuthPolicy := func(url string, valid bool) *graph.Policy {
+		return &graph.Policy{
+			Source: &ngfAPIv1alpha1.ClientSettingsPolicy{
+				Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+					Authentication: &ngfAPIv1alpha1.ClientAuthentication{
+						Mode:   ngfAPIv1alpha1.ClientAuthenticationModeRemote,
+						Remote: &ngfAPIv1alpha1.RemoteAuthentication{URL: url},
+					},
+				},
+			},
+			Valid: valid,
+		}
+	}
+
+	gateway := &graph.Gateway{
+		Listeners: []*graph.Listener{
+			{
+				Routes: map[graph.RouteKey]*graph.L7Route{
+					{NamespacedName: types.NamespacedName{Namespace: "default", Name: "valid"}}: {
+						Valid: true,
+						Spec: graph.L7RouteSpec{
+							Rules: []graph.RouteRule{
+								{
+									BackendRefs: []graph.BackendRef{
+										backendRef("test", "svc", 80, true),
+										backendRef("default", "svc", 8080, true),
+										backendRef("default", "invalid", 80, false),
+									},
+								},
+								{
+									BackendRefs: []graph.BackendRef{
+										backendRef("default", "svc", 8080, true),
+										eppBackendRef,
+									},
+								},
+							},
+						},
+					},
+					{NamespacedName: types.NamespacedName{Namespace: "default", Name: "invalid"}}: {
+						Valid: false,
+						Spec: graph.L7RouteSpec{
+							Rules: []graph.RouteRule{
+								{
+									BackendRefs: []graph.BackendRef{backendRef("default", "invalid-route", 80, true)},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Routes: map[graph.RouteKey]*graph.L7Route{
+					{NamespacedName: types.NamespacedName{Namespace: "default", Name: "static"}}: {
+						Valid: true,
+						Spec: graph.L7RouteSpec{
+							Rules: []graph.RouteRule{
+								{
+									BackendRefs: []graph.BackendRef{
+										{
+											SvcNsName: types.NamespacedName{Namespace: "default", Name: "static"},
+											StaticEndpoints: []ngfAPIv1alpha1.BackendEndpoint{
+												{Address: "10.0.0.1", Port: 8080},
+												{Address: "backend.example.com", Port: 443},
+											},
+											Valid: true,
+										},
+									},
+								},
+							},
+						},
+						Policies: []*graph.Policy{
+							remoteAuthPolicy("https://auth.example.com/verify", true),
+							remoteAuthPolicy("http://auth.internal:9000/verify", true),
+							remoteAuthPolicy("https://invalid.example.com/verify", false),
+						},
+					},
+				},
+				L4Routes: map[graph.L4RouteKey]*graph.L4Route{
+					{NamespacedName: types.NamespacedName{Namespace: "default", Name: "tls"}}: {
+						Valid: true,
+						Spec: graph.L4RouteSpec{
+							BackendRef: backendRef("default", "tls-svc", 443, true),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []networkPolicyBackend{
+		{address: "10.0.0.1", port: 8080},
+		{address: "auth.example.com", port: 443},
+		{address: "auth.internal", port: 9000},
+		{address: "backend.example.com", port: 443},
+		{svcNsName: types.NamespacedName{Namespace: "default", Name: "epp"}, port: 9002},
+		{svcNsName: types.NamespacedName{Namespace: "default", Name: "pool-shadow"}, port: 8000},
+		{svcNsName: types.NamespacedName{Namespace: "default", Name: "svc"}, port: 8080},
+		{svcNsName: types.NamespacedName{Namespace: "default", Name: "tls-svc"}, port: 443},
+		{svcNsName: types.NamespacedName{Namespace: "test", Name: "svc"}, port: 80},
+	}
+
+	g := NewWithT(t)
+	g.Expect(networkPolicyBackends(gateway)).To(Equal(expected))
+	g.Expect(networkPolicyBackends(nil)).To(BeEmpty())
+}
+
+func TestBuildNginxResourceObjectsForGateway(t *testing.T) {
+	t.Parallel()
+
+	controlPlaneSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "ngf-svc", Namespace: ngfNamespace},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app.kubernetes.io/name": "nginx-gateway"},
+			Ports: []corev1.ServicePort{
+				{Port: 443, TargetPort: intstr.FromInt32(8443)},
+				{Port: 9444, TargetPort: intstr.FromInt32(9444)},
+				{Port: 1514, TargetPort: intstr.FromInt32(1514), Protocol: corev1.ProtocolUDP},
+			},
+		},
+	}
+	backendSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "backend"},
+			Ports: []corev1.ServicePort{
+				{Port: 80, TargetPort: intstr.FromString("http")},
+			},
+		},
+	}
+	selectorlessSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "selectorless", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromInt32(8080)}},
+		},
+	}
+	externalNameSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Type:         corev1.ServiceTypeExternalName,
+			ExternalName: "example.com",
+			Ports:        []corev1.ServicePort{{Port: 443, TargetPort: intstr.FromInt32(8443)}},
+		},
+	}
+
+	newGateway := func(nProxyCfg *graph.EffectiveNginxProxy) *graph.Gateway {
+		return &graph.Gateway{
+			Source: &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+				Spec: gatewayv1.GatewaySpec{
+					Listeners: []gatewayv1.Listener{{Port: 80}},
+				},
+			},
+			EffectiveNginxProxy: nProxyCfg,
+			Listeners: []*graph.Listener{
+				listenerWithBackend("backend", 80),
+				listenerWithBackend("selectorless", 80),
+				listenerWithBackend("external", 443),
+				listenerWithBackend("missing", 80),
+			},
+			Valid: true,
+		}
+	}
+
+	findNetworkPolicy := func(
+		g *WithT,
+		gateway *graph.Gateway,
+		controlPlaneSvcName string,
+		controlPlanePorts []int32,
+		expErr bool,
+	) *networkingv1.NetworkPolicy {
+		agentTLSSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentTLSTestSecretName,
+				Namespace: ngfNamespace,
+			},
+			Data: map[string][]byte{"tls.crt": []byte("tls")},
+		}
+
+		provisioner := &NginxProvisioner{
+			cfg: Config{
+				GatewayPodConfig: &config.GatewayPodConfig{
+					Namespace:   ngfNamespace,
+					ServiceName: controlPlaneSvcName,
+					Version:     "1.0.0",
+					Image:       "ngf-image",
+				},
+				AgentTLSSecretName: agentTLSTestSecretName,
+				AgentLabels:        make(map[string]string),
+				ControlPlanePorts:  controlPlanePorts,
+			},
+			baseLabelSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "nginx",
+				},
+			},
+			k8sClient: fake.NewFakeClient(agentTLSSecret, controlPlaneSvc, backendSvc, selectorlessSvc, externalNameSvc),
+		}
+
+		objs, err := provisioner.buildNginxResourceObjectsForGateway("gw-nginx", gateway)
+		if expErr {
+			g.Expect(err).To(HaveOccurred())
+		} else {
+			g.Expect(err).ToNot(HaveOccurred())
+		}
+
+		for _, obj := range objs {
+			if np, ok := obj.(*networkingv1.NetworkPolicy); ok {
+				return np
+			}
+		}
+
+		return nil
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		g.Expect(findNetworkPolicy(g, newGateway(nil), controlPlaneSvc.GetName(), nil, false)).To(BeNil())
+	})
+
+	t.Run("control plane Service is missing", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		g.Expect(findNetworkPolicy(g, newGateway(networkPolicyEnabledNginxProxy()), "missing", nil, true)).To(BeNil())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		np := findNetworkPolicy(g, newGateway(networkPolicyEnabledNginxProxy()), controlPlaneSvc.GetName(), nil, false)
+		g.Expect(np).ToNot(BeNil())
+
+		g.Expect(np.GetName()).To(Equal("gw-nginx"))
+		g.Expect(np.GetNamespace()).To(Equal("default"))
+		g.Expect(np.GetLabels()).To(HaveKeyWithValue(controller.AppNameLabel, "gw-nginx"))
+		g.Expect(np.Spec.PodSelector.MatchLabels).To(HaveKeyWithValue(controller.AppNameLabel, "gw-nginx"))
+		g.Expect(np.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress))
+
+		g.Expect(np.Spec.Ingress).To(HaveLen(1))
+		g.Expect(np.Spec.Ingress[0].From).To(BeEmpty())
+		g.Expect(np.Spec.Ingress[0].Ports).To(ContainElement(networkingv1.NetworkPolicyPort{
+			Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+			Port:     helpers.GetPointer(intstr.FromInt32(80)),
+		}))
+
+		g.Expect(np.Spec.Egress).To(Equal([]networkingv1.NetworkPolicyEgressRule{
+			buildDNSEgressRule(),
+			{
+				To: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{namespaceNameLabel: ngfNamespace},
+						},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app.kubernetes.io/name": "nginx-gateway"},
+						},
+					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+						Port:     helpers.GetPointer(intstr.FromInt32(8443)),
+					},
+				},
+			},
+			{
+				To: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{namespaceNameLabel: "default"},
+						},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "backend"},
+						},
+					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+						Port:     helpers.GetPointer(intstr.FromString("http")),
+					},
+				},
+			},
+			// the Pods of the ExternalName Service and of the Service without a selector can't be selected
+			{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+						Port:     helpers.GetPointer(intstr.FromInt32(443)),
+					},
+				},
+			},
+			{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+						Port:     helpers.GetPointer(intstr.FromInt32(8080)),
+					},
+				},
+			},
+		}))
+	})
+
+	t.Run("enabled with control plane listeners", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		np := findNetworkPolicy(
+			g,
+			newGateway(networkPolicyEnabledNginxProxy()),
+			controlPlaneSvc.GetName(),
+			[]int32{9444, 1514},
+			false,
+		)
+		g.Expect(np).ToNot(BeNil())
+
+		controlPlanePeers := []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{namespaceNameLabel: ngfNamespace},
+				},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app.kubernetes.io/name": "nginx-gateway"},
+				},
+			},
+		}
+
+		g.Expect(np.Spec.Egress).To(ContainElements(
+			networkingv1.NetworkPolicyEgressRule{
+				To: controlPlanePeers,
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+						Port:     helpers.GetPointer(intstr.FromInt32(8443)),
+					},
+				},
+			},
+			networkingv1.NetworkPolicyEgressRule{
+				To: controlPlanePeers,
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+						Port:     helpers.GetPointer(intstr.FromInt32(9444)),
+					},
+				},
+			},
+			networkingv1.NetworkPolicyEgressRule{
+				To: controlPlanePeers,
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: helpers.GetPointer(corev1.ProtocolUDP),
+						Port:     helpers.GetPointer(intstr.FromInt32(1514)),
+					},
+				},
+			},
+		))
+	})
+}
+
+func TestBuildEgressRuleForStaticEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expected []networkingv1.NetworkPolicyPeer
+		backend  networkPolicyBackend
+	}{
+		{
+			name:    "IPv4 address",
+			backend: networkPolicyBackend{address: "10.0.0.1", port: 8080},
+			expected: []networkingv1.NetworkPolicyPeer{
+				{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}},
+			},
+		},
+		{
+			name:    "IPv6 address",
+			backend: networkPolicyBackend{address: "fd00::1", port: 8080},
+			expected: []networkingv1.NetworkPolicyPeer{
+				{IPBlock: &networkingv1.IPBlock{CIDR: "fd00::1/128"}},
+			},
+		},
+		{
+			name:     "DNS name",
+			backend:  networkPolicyBackend{address: "backend.example.com", port: 8080},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			rule := buildEgressRuleForStaticEndpoint(test.backend)
+			g.Expect(rule.To).To(Equal(test.expected))
+			g.Expect(rule.Ports).To(Equal([]networkingv1.NetworkPolicyPort{
+				{
+					Protocol: helpers.GetPointer(corev1.ProtocolTCP),
+					Port:     helpers.GetPointer(intstr.FromInt32(8080)),
+				},
+			}))
+		})
+	}
+}
+
+func TestNetworkPolicyEndpoints(t *testing.T) {
+	t.Parallel()
+
+	telemetryNginxProxy := &graph.EffectiveNginxProxy{
+		Telemetry: &ngfAPIv1alpha2.Telemetry{
+			Exporter: &ngfAPIv1alpha2.TelemetryExporter{
+				Endpoint: helpers.GetPointer("collector.monitoring.svc:4318"),
+			},
+		},
+	}
+
+	tests := []struct {
+		nProxyCfg *graph.EffectiveNginxProxy
+		name      string
+		expected  []networkPolicyBackend
+		cfg       Config
+	}{
+		{
+			name: "no endpoints",
+			cfg:  Config{PlusUsageConfig: &config.UsageReportConfig{}},
+		},
+		{
+			name:      "OpenTelemetry exporter",
+			nProxyCfg: telemetryNginxProxy,
+			expected: []networkPolicyBackend{
+				{address: "collector.monitoring.svc", port: 4318},
+			},
+		},
+		{
+			name: "default NGINX Plus usage reporting endpoint",
+			cfg:  Config{Plus: true, PlusUsageConfig: &config.UsageReportConfig{}},
+			expected: []networkPolicyBackend{
+				{address: "product.connect.nginx.com", port: 443},
+			},
+		},
+		{
+			name: "every endpoint",
+			cfg: Config{
+				Plus:            true,
+				PlusUsageConfig: &config.UsageReportConfig{Endpoint: "nim.example.com:8443"},
+				NginxOneConsoleTelemetryConfig: config.NginxOneConsoleTelemetryConfig{
+					DataplaneKeySecretName: "dataplane-key",
+					EndpointHost:           "agent.connect.nginx.com",
+					EndpointPort:           443,
+				},
+			},
+			nProxyCfg: telemetryNginxProxy,
+			expected: []networkPolicyBackend{
+				{address: "collector.monitoring.svc", port: 4318},
+				{address: "nim.example.com", port: 8443},
+				{address: "agent.connect.nginx.com", port: 443},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			provisioner := &NginxProvisioner{cfg: test.cfg}
+			g.Expect(provisioner.networkPolicyEndpoints(test.nProxyCfg)).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestParseNetworkPolicyEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		expected networkPolicyBackend
+		expOK    bool
+	}{
+		{
+			name:     "host and port",
+			endpoint: "collector.example.com:4318",
+			expected: networkPolicyBackend{address: "collector.example.com", port: 4318},
+			expOK:    true,
+		},
+		{
+			name:     "default port",
+			endpoint: "collector.example.com",
+			expected: networkPolicyBackend{address: "collector.example.com", port: 4317},
+			expOK:    true,
+		},
+		{
+			name:     "scheme",
+			endpoint: "http://10.0.0.1:4318",
+			expected: networkPolicyBackend{address: "10.0.0.1", port: 4318},
+			expOK:    true,
+		},
+		{
+			name:     "empty",
+			endpoint: "",
+		},
+		{
+			name:     "invalid port",
+			endpoint: "collector.example.com:otlp",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			endpoint, ok := parseNetworkPolicyEndpoint(test.endpoint, otelExporterPort)
+			g.Expect(ok).To(Equal(test.expOK))
+			g.Expect(endpoint).To(Equal(test.expected))
+		})
+	}
+}
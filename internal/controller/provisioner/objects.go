@@ -6,15 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	jsonpatch "gopkg.in/evanphx/json-patch.v4"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -24,6 +28,7 @@ import (
 
 	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/config"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/export"
 	nginxTypes "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/types"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
@@ -39,10 +44,27 @@ const (
 	defaultServiceType   = corev1.ServiceTypeLoadBalancer
 	defaultServicePolicy = corev1.ServiceExternalTrafficPolicyLocal
 
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	externalDNSTTLAnnotation      = "external-dns.alpha.kubernetes.io/ttl"
+
+	loadBalancerHealthCheckPortName = "lb-health-check"
+
+	awsHealthCheckProtocolAnnotation   = "service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol"
+	awsHealthCheckPathAnnotation       = "service.beta.kubernetes.io/aws-load-balancer-healthcheck-path"
+	awsHealthCheckPortAnnotation       = "service.beta.kubernetes.io/aws-load-balancer-healthcheck-port"
+	azureHealthProbeProtocolAnnotation = "service.beta.kubernetes.io/azure-load-balancer-health-probe-protocol"
+	azureHealthProbePathAnnotation     = "service.beta.kubernetes.io/azure-load-balancer-health-probe-request-path"
+	// azureHealthProbePortAnnotationFmt is the annotation of the health probe port of a port of the Service.
+	azureHealthProbePortAnnotationFmt = "service.beta.kubernetes.io/port_%d_health-probe_port"
+
 	defaultNginxImagePath      = "ghcr.io/nginx/nginx-gateway-fabric/nginx"
 	defaultNginxPlusImagePath  = "private-registry.nginx.com/nginx-gateway-fabric/nginx-plus"
 	defaultImagePullPolicy     = corev1.PullIfNotPresent
 	defaultInitialDelaySeconds = int32(3)
+
+	// preStopDrainShutdownSeconds is how long NGINX has to shut down gracefully after the pre-stop drain,
+	// if the termination grace period of the Pod is not set.
+	preStopDrainShutdownSeconds = int64(30)
 )
 
 var emptyDirVolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
@@ -96,14 +118,9 @@ func (p *NginxProvisioner) buildNginxResourceObjects(
 	labels := make(map[string]string)
 	annotations := make(map[string]string)
 
-	if len(gateway.GetName()) > controller.MaxServiceNameLen {
-		annotations[controller.GatewayLabel] = gateway.GetName()
-	} else {
-		selectorLabels[controller.GatewayLabel] = gateway.GetName()
-	}
-
-	maps.Copy(labels, selectorLabels)
-
+	// The infrastructure labels and annotations of the Gateway are propagated to every provisioned object,
+	// including the Pods. The labels and annotations that NGF relies on to select and track the objects
+	// take precedence over the infrastructure ones, so they can't be overridden.
 	if gateway.Spec.Infrastructure != nil {
 		for key, value := range gateway.Spec.Infrastructure.Labels {
 			labels[string(key)] = string(value)
@@ -114,6 +131,14 @@ func (p *NginxProvisioner) buildNginxResourceObjects(
 		}
 	}
 
+	if len(gateway.GetName()) > controller.MaxServiceNameLen {
+		annotations[controller.GatewayLabel] = gateway.GetName()
+	} else {
+		selectorLabels[controller.GatewayLabel] = gateway.GetName()
+	}
+
+	maps.Copy(labels, selectorLabels)
+
 	objectMeta := metav1.ObjectMeta{
 		Name:        resourceName,
 		Namespace:   gateway.GetNamespace(),
@@ -143,10 +168,7 @@ func (p *NginxProvisioner) buildNginxResourceObjects(
 		clientSSLSecretName != "",
 	)
 
-	serviceAccount := &corev1.ServiceAccount{
-		ObjectMeta:                   objectMeta,
-		AutomountServiceAccountToken: helpers.GetPointer(false),
-	}
+	serviceAccount := buildNginxServiceAccount(objectMeta, nProxyCfg)
 
 	var openshiftObjs []client.Object
 	if p.isOpenshift {
@@ -173,6 +195,9 @@ func (p *NginxProvisioner) buildNginxResourceObjects(
 		Annotations: maps.Clone(objectMeta.Annotations),
 	}
 
+	setExternalDNSAnnotations(serviceObjectMeta.Annotations, nProxyCfg, gateway.Spec.Listeners)
+	setLoadBalancerHealthCheckAnnotations(serviceObjectMeta.Annotations, nProxyCfg, ports)
+
 	service, err := buildNginxService(serviceObjectMeta, nProxyCfg, ports, selectorLabels, gateway.Spec.Addresses)
 	if err != nil {
 		errs = append(errs, err)
@@ -183,6 +208,7 @@ func (p *NginxProvisioner) buildNginxResourceObjects(
 		nProxyCfg,
 		ngxIncludesConfigMapName,
 		ngxAgentConfigMapName,
+		export.ConfigMapName(gateway.GetName()),
 		ports,
 		selectorLabels,
 		agentTLSSecretName,
@@ -495,6 +521,32 @@ func (p *NginxProvisioner) buildNginxConfigMaps(
 	return []client.Object{bootstrapCM, agentCM}
 }
 
+// buildNginxServiceAccount builds the dedicated ServiceAccount of a Gateway. The ServiceAccount is not bound to any
+// Kubernetes API permissions and its token is not mounted, so it only serves as an identity, for example, for
+// cloud credentials configured through the NginxProxy ServiceAccount annotations.
+func buildNginxServiceAccount(
+	objectMeta metav1.ObjectMeta,
+	nProxyCfg *graph.EffectiveNginxProxy,
+) *corev1.ServiceAccount {
+	annotations := maps.Clone(objectMeta.Annotations)
+	if nProxyCfg != nil && nProxyCfg.Kubernetes != nil && nProxyCfg.Kubernetes.ServiceAccount != nil {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		maps.Copy(annotations, nProxyCfg.Kubernetes.ServiceAccount.Annotations)
+	}
+
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        objectMeta.Name,
+			Namespace:   objectMeta.Namespace,
+			Labels:      objectMeta.Labels,
+			Annotations: annotations,
+		},
+		AutomountServiceAccountToken: helpers.GetPointer(false),
+	}
+}
+
 func (p *NginxProvisioner) buildOpenshiftObjects(objectMeta metav1.ObjectMeta) []client.Object {
 	role := &rbacv1.Role{
 		ObjectMeta: objectMeta,
@@ -570,6 +622,14 @@ func buildNginxService(
 		servicePorts = append(servicePorts, servicePort)
 	}
 
+	if _, port, enabled := graph.LoadBalancerHealthCheckForNginxProxy(nProxyCfg); enabled {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       loadBalancerHealthCheckPortName,
+			Port:       port,
+			TargetPort: intstr.FromInt32(port),
+		})
+	}
+
 	// need to sort ports so everytime buildNginxService is called it will generate the exact same
 	// array of ports. This is needed to satisfy deterministic results of the method.
 	sort.Slice(servicePorts, func(i, j int) bool {
@@ -634,11 +694,73 @@ func setSvcLoadBalancerSettings(svcCfg ngfAPIv1alpha2.ServiceSpec, svcSpec *core
 	}
 }
 
+// setExternalDNSAnnotations sets the external-dns annotations of the Service with the hostnames of the listeners,
+// if enabled, so that external-dns creates the DNS records of the hostnames pointing at the address of the Service.
+func setExternalDNSAnnotations(
+	annotations map[string]string,
+	nProxyCfg *graph.EffectiveNginxProxy,
+	listeners []gatewayv1.Listener,
+) {
+	if nProxyCfg == nil || nProxyCfg.Kubernetes == nil || nProxyCfg.Kubernetes.Service == nil {
+		return
+	}
+
+	externalDNS := nProxyCfg.Kubernetes.Service.ExternalDNS
+	if externalDNS == nil || !externalDNS.Enable {
+		return
+	}
+
+	hostnames := make([]string, 0, len(listeners))
+	for _, listener := range listeners {
+		if listener.Hostname != nil && *listener.Hostname != "" {
+			hostnames = append(hostnames, string(*listener.Hostname))
+		}
+	}
+
+	if len(hostnames) == 0 {
+		return
+	}
+
+	slices.Sort(hostnames)
+	annotations[externalDNSHostnameAnnotation] = strings.Join(slices.Compact(hostnames), ",")
+
+	if externalDNS.TTL != nil {
+		annotations[externalDNSTTLAnnotation] = strconv.Itoa(int(*externalDNS.TTL))
+	}
+}
+
+// setLoadBalancerHealthCheckAnnotations sets the annotations of the Service for the health checks of the cloud
+// load balancers, if enabled, so that the load balancers health check the dedicated health check endpoint
+// of NGINX instead of the ports of the listeners.
+func setLoadBalancerHealthCheckAnnotations(
+	annotations map[string]string,
+	nProxyCfg *graph.EffectiveNginxProxy,
+	ports map[int32]struct{},
+) {
+	path, port, enabled := graph.LoadBalancerHealthCheckForNginxProxy(nProxyCfg)
+	if !enabled {
+		return
+	}
+
+	healthCheckPort := strconv.Itoa(int(port))
+
+	annotations[awsHealthCheckProtocolAnnotation] = "HTTP"
+	annotations[awsHealthCheckPathAnnotation] = path
+	annotations[awsHealthCheckPortAnnotation] = healthCheckPort
+
+	annotations[azureHealthProbeProtocolAnnotation] = "http"
+	annotations[azureHealthProbePathAnnotation] = path
+	for listenerPort := range ports {
+		annotations[fmt.Sprintf(azureHealthProbePortAnnotationFmt, listenerPort)] = healthCheckPort
+	}
+}
+
 func (p *NginxProvisioner) buildNginxDeployment(
 	objectMeta metav1.ObjectMeta,
 	nProxyCfg *graph.EffectiveNginxProxy,
 	ngxIncludesConfigMapName string,
 	ngxAgentConfigMapName string,
+	exportConfigMapName string,
 	ports map[int32]struct{},
 	selectorLabels map[string]string,
 	agentTLSSecretName string,
@@ -653,6 +775,7 @@ func (p *NginxProvisioner) buildNginxDeployment(
 		nProxyCfg,
 		ngxIncludesConfigMapName,
 		ngxAgentConfigMapName,
+		exportConfigMapName,
 		ports,
 		agentTLSSecretName,
 		dockerSecretNames,
@@ -829,6 +952,7 @@ func (p *NginxProvisioner) buildNginxPodTemplateSpec(
 	nProxyCfg *graph.EffectiveNginxProxy,
 	ngxIncludesConfigMapName string,
 	ngxAgentConfigMapName string,
+	exportConfigMapName string,
 	ports map[int32]struct{},
 	agentTLSSecretName string,
 	dockerSecretNames map[string]string,
@@ -1071,6 +1195,14 @@ func (p *NginxProvisioner) buildNginxPodTemplateSpec(
 			container.Lifecycle = containerSpec.Lifecycle
 			container.VolumeMounts = append(container.VolumeMounts, containerSpec.VolumeMounts...)
 
+			if drain := containerSpec.PreStopDrain; drain != nil {
+				container.Lifecycle = preStopDrainLifecycle(container.Lifecycle, drain.DelaySeconds)
+				if spec.Spec.TerminationGracePeriodSeconds == nil {
+					grace := int64(drain.DelaySeconds) + preStopDrainShutdownSeconds
+					spec.Spec.TerminationGracePeriodSeconds = &grace
+				}
+			}
+
 			if containerSpec.Debug != nil && *containerSpec.Debug {
 				container.Command = append(container.Command, "/agent/entrypoint.sh")
 				container.Args = append(container.Args, "debug")
@@ -1088,6 +1220,10 @@ func (p *NginxProvisioner) buildNginxPodTemplateSpec(
 		}
 	}
 
+	if nProxyCfg != nil && nProxyCfg.TempFiles != nil && nProxyCfg.TempFiles.VolumeSizeLimit != nil {
+		limitTempFileVolume(&spec.Spec, *nProxyCfg.TempFiles.VolumeSizeLimit)
+	}
+
 	for name := range dockerSecretNames {
 		ref := corev1.LocalObjectReference{Name: name}
 		spec.Spec.ImagePullSecrets = append(spec.Spec.ImagePullSecrets, ref)
@@ -1214,9 +1350,93 @@ func (p *NginxProvisioner) buildNginxPodTemplateSpec(
 		})
 	}
 
+	if graph.AgentlessEnabledForNginxProxy(nProxyCfg) {
+		p.configureAgentless(&spec.Spec, exportConfigMapName, pullPolicy)
+	}
+
 	return spec
 }
 
+// configureAgentless configures the nginx Pod to run nginx without the agent. The configuration is delivered
+// through the ConfigMap and the Secret that the control plane exports the configuration to, and the config-reloader
+// sidecar copies the configuration to nginx and reloads nginx. The process namespace is shared so that
+// the sidecar can signal the nginx master process.
+func (p *NginxProvisioner) configureAgentless(
+	podSpec *corev1.PodSpec,
+	exportConfigMapName string,
+	pullPolicy corev1.PullPolicy,
+) {
+	podSpec.ShareProcessNamespace = helpers.GetPointer(true)
+
+	nginxBinary := "/usr/sbin/nginx"
+	if slices.Equal(podSpec.Containers[0].Args, []string{"debug"}) {
+		nginxBinary = "/usr/sbin/nginx-debug"
+	}
+
+	// bypass the entrypoint of the image, which starts the agent
+	podSpec.Containers[0].Command = []string{nginxBinary, "-g", "daemon off;"}
+	podSpec.Containers[0].Args = nil
+
+	podSpec.Volumes = append(
+		podSpec.Volumes,
+		corev1.Volume{
+			Name: "nginx-export-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: exportConfigMapName},
+					// the ConfigMap is created after the first configuration of the Gateway is exported
+					Optional: helpers.GetPointer(true),
+				},
+			},
+		},
+		corev1.Volume{
+			Name: "nginx-export-secrets",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: exportConfigMapName,
+					Optional:   helpers.GetPointer(true),
+				},
+			},
+		},
+	)
+
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{
+		Name:            "config-reloader",
+		Image:           p.cfg.GatewayPodConfig.Image,
+		ImagePullPolicy: pullPolicy,
+		Command: []string{
+			"/usr/bin/gateway",
+			"config-reloader",
+			"--config-dir", "/var/run/nginx-export/config",
+			"--secrets-dir", "/var/run/nginx-export/secrets",
+			"--pid-file", "/var/run/nginx/nginx.pid",
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{MountPath: "/var/run/nginx-export/config", Name: "nginx-export-config", ReadOnly: true},
+			{MountPath: "/var/run/nginx-export/secrets", Name: "nginx-export-secrets", ReadOnly: true},
+			{MountPath: "/etc/nginx/conf.d", Name: "nginx-conf"},
+			{MountPath: "/etc/nginx/stream-conf.d", Name: "nginx-stream-conf"},
+			{MountPath: "/etc/nginx/main-includes", Name: "nginx-main-includes"},
+			{MountPath: "/etc/nginx/events-includes", Name: "nginx-events-includes"},
+			{MountPath: "/etc/nginx/secrets", Name: "nginx-secrets"},
+			{MountPath: "/etc/nginx/includes", Name: "nginx-includes"},
+			{MountPath: "/var/run/nginx", Name: "nginx-run"},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: helpers.GetPointer(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			ReadOnlyRootFilesystem: helpers.GetPointer(true),
+			RunAsGroup:             helpers.GetPointer[int64](1001),
+			RunAsUser:              helpers.GetPointer[int64](101),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+	})
+}
+
 func (p *NginxProvisioner) buildImage(nProxyCfg *graph.EffectiveNginxProxy) (string, corev1.PullPolicy) {
 	return DetermineNginxImageName(nProxyCfg, p.cfg.Plus, p.cfg.GatewayPodConfig.Version)
 }
@@ -1298,6 +1518,7 @@ func (p *NginxProvisioner) buildNginxResourceObjectsForDeletion(deploymentNSName
 	// serviceaccount
 	// configmaps
 	// secrets
+	// networkpolicy (last, so the traffic of the nginx Pods stays restricted until they are removed)
 
 	objectMeta := metav1.ObjectMeta{
 		Name:      deploymentNSName.Name,
@@ -1421,6 +1642,11 @@ func (p *NginxProvisioner) buildNginxResourceObjectsForDeletion(deploymentNSName
 		objects = append(objects, dataplaneKeySecret)
 	}
 
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: objectMeta,
+	}
+	objects = append(objects, networkPolicy)
+
 	return objects
 }
 
@@ -1497,3 +1723,37 @@ func DetermineNginxImageName(
 
 	return fmt.Sprintf("%s:%s", image, tag), pullPolicy
 }
+
+// preStopDrainLifecycle returns the lifecycle of the NGINX container with a PreStop handler that drains the traffic
+// of the Pod: it creates the drain file, so that the readiness endpoint and the health check endpoint for the cloud
+// load balancer respond with 503, and waits for the delay before the kubelet stops NGINX.
+// limitTempFileVolume limits the size of the nginx-cache volume, which holds the temporary files of NGINX.
+func limitTempFileVolume(podSpec *corev1.PodSpec, sizeLimit resource.Quantity) {
+	for i, volume := range podSpec.Volumes {
+		if volume.Name == "nginx-cache" {
+			// the emptyDirVolumeSource is shared by the volumes, so it's replaced rather than modified
+			podSpec.Volumes[i].VolumeSource = corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &sizeLimit},
+			}
+		}
+	}
+}
+
+func preStopDrainLifecycle(lifecycle *corev1.Lifecycle, delaySeconds int32) *corev1.Lifecycle {
+	drainLifecycle := &corev1.Lifecycle{}
+	if lifecycle != nil {
+		drainLifecycle = lifecycle.DeepCopy()
+	}
+
+	drainLifecycle.PreStop = &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{
+			Command: []string{
+				"/bin/sh",
+				"-c",
+				fmt.Sprintf("touch %s && sleep %d", graph.DrainFilePath, delaySeconds),
+			},
+		},
+	}
+
+	return drainLifecycle
+}
@@ -8,6 +8,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -159,6 +160,7 @@ func TestBuildNginxResourceObjects(t *testing.T) {
 	validateLabelsAndAnnotations(cm)
 	g.Expect(cm.Data).To(HaveKey("nginx-agent.conf"))
 	g.Expect(cm.Data["nginx-agent.conf"]).To(ContainSubstring("command:"))
+	g.Expect(cm.Data["nginx-agent.conf"]).ToNot(ContainSubstring("/var/lib/nginx/state"))
 
 	svcAcctObj := objects[3]
 	svcAcct, ok := svcAcctObj.(*corev1.ServiceAccount)
@@ -234,6 +236,137 @@ func TestBuildNginxResourceObjects(t *testing.T) {
 	g.Expect(initContainer.ImagePullPolicy).To(Equal(defaultImagePullPolicy))
 }
 
+func TestBuildNginxResourceObjects_InfrastructureDoesNotOverrideSelectorLabels(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	agentTLSSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentTLSTestSecretName,
+			Namespace: ngfNamespace,
+		},
+		Data: map[string][]byte{"tls.crt": []byte("tls")},
+	}
+
+	provisioner := &NginxProvisioner{
+		cfg: Config{
+			GatewayPodConfig: &config.GatewayPodConfig{
+				Namespace: ngfNamespace,
+				Version:   "1.0.0",
+				Image:     "ngf-image",
+			},
+			AgentTLSSecretName: agentTLSTestSecretName,
+			AgentLabels:        make(map[string]string),
+		},
+		baseLabelSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "nginx",
+			},
+		},
+		k8sClient: fake.NewFakeClient(agentTLSSecret),
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "default",
+		},
+		Spec: gatewayv1.GatewaySpec{
+			Infrastructure: &gatewayv1.GatewayInfrastructure{
+				Labels: map[gatewayv1.LabelKey]gatewayv1.LabelValue{
+					"app":                                    "other",
+					"app.kubernetes.io/name":                 "other",
+					"gateway.networking.k8s.io/gateway-name": "other",
+					"label":                                  "value",
+				},
+			},
+		},
+	}
+
+	objects, err := provisioner.buildNginxResourceObjects("gw-nginx", gateway, &graph.EffectiveNginxProxy{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	expLabels := map[string]string{
+		"label":                                  "value",
+		"app":                                    "nginx",
+		"gateway.networking.k8s.io/gateway-name": "gw",
+		"app.kubernetes.io/name":                 "gw-nginx",
+	}
+
+	var deployment *appsv1.Deployment
+	for _, obj := range objects {
+		g.Expect(obj.GetLabels()).To(Equal(expLabels))
+
+		if dep, ok := obj.(*appsv1.Deployment); ok {
+			deployment = dep
+		}
+	}
+
+	g.Expect(deployment).ToNot(BeNil())
+	g.Expect(deployment.Spec.Template.Labels).To(Equal(expLabels))
+	for key, value := range deployment.Spec.Selector.MatchLabels {
+		g.Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(key, value))
+	}
+}
+
+func TestBuildNginxServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	objectMeta := metav1.ObjectMeta{
+		Name:        "gw-nginx",
+		Namespace:   "default",
+		Labels:      map[string]string{"app": "nginx"},
+		Annotations: map[string]string{"annotation": "infrastructure", "shared": "infrastructure"},
+	}
+
+	tests := []struct {
+		nProxyCfg      *graph.EffectiveNginxProxy
+		expAnnotations map[string]string
+		name           string
+	}{
+		{
+			name:           "no NginxProxy",
+			expAnnotations: map[string]string{"annotation": "infrastructure", "shared": "infrastructure"},
+		},
+		{
+			name: "NginxProxy annotations take precedence",
+			nProxyCfg: &graph.EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					ServiceAccount: &ngfAPIv1alpha2.ServiceAccountSpec{
+						Annotations: map[string]string{
+							"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/gateway",
+							"shared":                     "nginxproxy",
+						},
+					},
+				},
+			},
+			expAnnotations: map[string]string{
+				"annotation":                 "infrastructure",
+				"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/gateway",
+				"shared":                     "nginxproxy",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			sa := buildNginxServiceAccount(objectMeta, test.nProxyCfg)
+
+			g.Expect(sa.Name).To(Equal(objectMeta.Name))
+			g.Expect(sa.Namespace).To(Equal(objectMeta.Namespace))
+			g.Expect(sa.Labels).To(Equal(objectMeta.Labels))
+			g.Expect(sa.Annotations).To(Equal(test.expAnnotations))
+			g.Expect(sa.AutomountServiceAccountToken).To(Equal(helpers.GetPointer(false)))
+
+			// the shared object metadata must not be modified
+			g.Expect(objectMeta.Annotations).To(HaveLen(2))
+		})
+	}
+}
+
 func TestBuildNginxResourceObjects_NginxProxyConfig(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -683,6 +816,7 @@ func TestBuildNginxResourceObjects_Plus(t *testing.T) {
 	g.Expect(ok).To(BeTrue())
 	g.Expect(cm.Data).To(HaveKey("nginx-agent.conf"))
 	g.Expect(cm.Data["nginx-agent.conf"]).To(ContainSubstring("api-action"))
+	g.Expect(cm.Data["nginx-agent.conf"]).To(ContainSubstring("- /var/lib/nginx/state"))
 
 	depObj := objects[8]
 	dep, ok := depObj.(*appsv1.Deployment)
@@ -1092,7 +1226,7 @@ func TestBuildNginxResourceObjectsForDeletion(t *testing.T) {
 
 	objects := provisioner.buildNginxResourceObjectsForDeletion(deploymentNSName)
 
-	g.Expect(objects).To(HaveLen(8))
+	g.Expect(objects).To(HaveLen(9))
 
 	validateMeta := func(obj client.Object, name string) {
 		g.Expect(obj.GetName()).To(Equal(name))
@@ -1133,6 +1267,11 @@ func TestBuildNginxResourceObjectsForDeletion(t *testing.T) {
 	cm, ok = cmObj.(*corev1.ConfigMap)
 	g.Expect(ok).To(BeTrue())
 	validateMeta(cm, controller.CreateNginxResourceName(deploymentNSName.Name, nginxAgentConfigMapNameSuffix))
+
+	npObj := objects[8]
+	np, ok := npObj.(*networkingv1.NetworkPolicy)
+	g.Expect(ok).To(BeTrue())
+	validateMeta(np, deploymentNSName.Name)
 }
 
 func TestBuildNginxResourceObjectsForDeletion_Plus(t *testing.T) {
@@ -1159,7 +1298,7 @@ func TestBuildNginxResourceObjectsForDeletion_Plus(t *testing.T) {
 
 	objects := provisioner.buildNginxResourceObjectsForDeletion(deploymentNSName)
 
-	g.Expect(objects).To(HaveLen(12))
+	g.Expect(objects).To(HaveLen(13))
 
 	validateMeta := func(obj client.Object, name string) {
 		g.Expect(obj.GetName()).To(Equal(name))
@@ -1247,7 +1386,7 @@ func TestBuildNginxResourceObjectsForDeletion_OpenShift(t *testing.T) {
 
 	objects := provisioner.buildNginxResourceObjectsForDeletion(deploymentNSName)
 
-	g.Expect(objects).To(HaveLen(10))
+	g.Expect(objects).To(HaveLen(11))
 
 	validateMeta := func(obj client.Object, name string) {
 		g.Expect(obj.GetName()).To(Equal(name))
@@ -1294,7 +1433,7 @@ func TestBuildNginxResourceObjectsForDeletion_DataplaneKeySecret(t *testing.T) {
 
 	// Should include the dataplane key secret in the objects list
 	// Default: deployment, daemonset, service, hpa, serviceaccount, 2 configmaps, agentTLSSecret, dataplaneKeySecret
-	g.Expect(objects).To(HaveLen(9))
+	g.Expect(objects).To(HaveLen(10))
 
 	validateMeta := func(obj client.Object, name string) {
 		g.Expect(obj.GetName()).To(Equal(name))
@@ -1935,3 +2074,525 @@ func TestBuildNginxResourceObjects_InferenceExtension(t *testing.T) {
 	g.Expect(containers[1].Command).To(Equal(expectedCommands))
 	g.Expect(containers[1].Resources.Limits).To(HaveKeyWithValue(corev1.ResourceCPU, resource.MustParse("500m")))
 }
+
+func TestBuildNginxResourceObjects_ExternalDNS(t *testing.T) {
+	t.Parallel()
+
+	agentTLSSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentTLSTestSecretName,
+			Namespace: ngfNamespace,
+		},
+		Data: map[string][]byte{"tls.crt": []byte("tls")},
+	}
+
+	provisioner := &NginxProvisioner{
+		cfg: Config{
+			GatewayPodConfig: &config.GatewayPodConfig{
+				Namespace: ngfNamespace,
+			},
+			AgentTLSSecretName: agentTLSTestSecretName,
+			AgentLabels:        make(map[string]string),
+		},
+		k8sClient: fake.NewFakeClient(agentTLSSecret),
+		baseLabelSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": "nginx"},
+		},
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "default",
+		},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80, Hostname: helpers.GetPointer[gatewayv1.Hostname]("foo.example.com")},
+				{Name: "https", Port: 443, Hostname: helpers.GetPointer[gatewayv1.Hostname]("foo.example.com")},
+				{Name: "wildcard", Port: 80, Hostname: helpers.GetPointer[gatewayv1.Hostname]("*.bar.example.com")},
+				{Name: "any", Port: 8080},
+			},
+		},
+	}
+
+	tests := []struct {
+		externalDNS    *ngfAPIv1alpha2.ExternalDNS
+		expAnnotations map[string]string
+		name           string
+	}{
+		{
+			name:           "not configured",
+			expAnnotations: map[string]string{},
+		},
+		{
+			name:           "disabled",
+			externalDNS:    &ngfAPIv1alpha2.ExternalDNS{Enable: false, TTL: helpers.GetPointer[int32](60)},
+			expAnnotations: map[string]string{},
+		},
+		{
+			name:        "enabled",
+			externalDNS: &ngfAPIv1alpha2.ExternalDNS{Enable: true},
+			expAnnotations: map[string]string{
+				externalDNSHostnameAnnotation: "*.bar.example.com,foo.example.com",
+			},
+		},
+		{
+			name:        "enabled with TTL",
+			externalDNS: &ngfAPIv1alpha2.ExternalDNS{Enable: true, TTL: helpers.GetPointer[int32](60)},
+			expAnnotations: map[string]string{
+				externalDNSHostnameAnnotation: "*.bar.example.com,foo.example.com",
+				externalDNSTTLAnnotation:      "60",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			npCfg := &graph.EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Service: &ngfAPIv1alpha2.ServiceSpec{ExternalDNS: test.externalDNS},
+				},
+			}
+
+			objects, err := provisioner.buildNginxResourceObjects("gw-nginx", gateway, npCfg)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			var service *corev1.Service
+			var deployment *appsv1.Deployment
+			for _, obj := range objects {
+				switch o := obj.(type) {
+				case *corev1.Service:
+					service = o
+				case *appsv1.Deployment:
+					deployment = o
+				}
+			}
+
+			g.Expect(service).ToNot(BeNil())
+			g.Expect(service.Annotations).To(Equal(test.expAnnotations))
+
+			// the annotations are only set on the Service
+			g.Expect(deployment).ToNot(BeNil())
+			g.Expect(deployment.Annotations).ToNot(HaveKey(externalDNSHostnameAnnotation))
+		})
+	}
+}
+
+func TestBuildNginxResourceObjects_LoadBalancerHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	agentTLSSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentTLSTestSecretName,
+			Namespace: ngfNamespace,
+		},
+		Data: map[string][]byte{"tls.crt": []byte("tls")},
+	}
+
+	provisioner := &NginxProvisioner{
+		cfg: Config{
+			GatewayPodConfig: &config.GatewayPodConfig{
+				Namespace: ngfNamespace,
+			},
+			AgentTLSSecretName: agentTLSTestSecretName,
+			AgentLabels:        make(map[string]string),
+		},
+		k8sClient: fake.NewFakeClient(agentTLSSecret),
+		baseLabelSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": "nginx"},
+		},
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "default",
+		},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80},
+				{Name: "https", Port: 443},
+			},
+		},
+	}
+
+	tests := []struct {
+		healthCheck    *ngfAPIv1alpha2.LoadBalancerHealthCheck
+		expAnnotations map[string]string
+		name           string
+		expPorts       []corev1.ServicePort
+	}{
+		{
+			name:           "not configured",
+			expAnnotations: map[string]string{},
+			expPorts: []corev1.ServicePort{
+				{Name: "port-80", Port: 80, TargetPort: intstr.FromInt(80)},
+				{Name: "port-443", Port: 443, TargetPort: intstr.FromInt(443)},
+			},
+		},
+		{
+			name:           "disabled",
+			healthCheck:    &ngfAPIv1alpha2.LoadBalancerHealthCheck{Port: helpers.GetPointer[int32](9000)},
+			expAnnotations: map[string]string{},
+			expPorts: []corev1.ServicePort{
+				{Name: "port-80", Port: 80, TargetPort: intstr.FromInt(80)},
+				{Name: "port-443", Port: 443, TargetPort: intstr.FromInt(443)},
+			},
+		},
+		{
+			name:        "enabled",
+			healthCheck: &ngfAPIv1alpha2.LoadBalancerHealthCheck{Enable: true},
+			expAnnotations: map[string]string{
+				awsHealthCheckProtocolAnnotation:                        "HTTP",
+				awsHealthCheckPathAnnotation:                            "/healthz",
+				awsHealthCheckPortAnnotation:                            "8082",
+				azureHealthProbeProtocolAnnotation:                      "http",
+				azureHealthProbePathAnnotation:                          "/healthz",
+				"service.beta.kubernetes.io/port_80_health-probe_port":  "8082",
+				"service.beta.kubernetes.io/port_443_health-probe_port": "8082",
+			},
+			expPorts: []corev1.ServicePort{
+				{Name: "port-80", Port: 80, TargetPort: intstr.FromInt(80)},
+				{Name: "port-443", Port: 443, TargetPort: intstr.FromInt(443)},
+				{Name: loadBalancerHealthCheckPortName, Port: 8082, TargetPort: intstr.FromInt(8082)},
+			},
+		},
+		{
+			name: "enabled with path and port",
+			healthCheck: &ngfAPIv1alpha2.LoadBalancerHealthCheck{
+				Path:   helpers.GetPointer("/lb-health"),
+				Port:   helpers.GetPointer[int32](90),
+				Enable: true,
+			},
+			expAnnotations: map[string]string{
+				awsHealthCheckProtocolAnnotation:                        "HTTP",
+				awsHealthCheckPathAnnotation:                            "/lb-health",
+				awsHealthCheckPortAnnotation:                            "90",
+				azureHealthProbeProtocolAnnotation:                      "http",
+				azureHealthProbePathAnnotation:                          "/lb-health",
+				"service.beta.kubernetes.io/port_80_health-probe_port":  "90",
+				"service.beta.kubernetes.io/port_443_health-probe_port": "90",
+			},
+			expPorts: []corev1.ServicePort{
+				{Name: "port-80", Port: 80, TargetPort: intstr.FromInt(80)},
+				{Name: loadBalancerHealthCheckPortName, Port: 90, TargetPort: intstr.FromInt(90)},
+				{Name: "port-443", Port: 443, TargetPort: intstr.FromInt(443)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			npCfg := &graph.EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Service: &ngfAPIv1alpha2.ServiceSpec{LoadBalancerHealthCheck: test.healthCheck},
+				},
+			}
+
+			objects, err := provisioner.buildNginxResourceObjects("gw-nginx", gateway, npCfg)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			var service *corev1.Service
+			for _, obj := range objects {
+				if svc, ok := obj.(*corev1.Service); ok {
+					service = svc
+				}
+			}
+
+			g.Expect(service).ToNot(BeNil())
+			g.Expect(service.Annotations).To(Equal(test.expAnnotations))
+			g.Expect(service.Spec.Ports).To(Equal(test.expPorts))
+		})
+	}
+}
+
+func TestBuildNginxResourceObjects_PreStopDrain(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	agentTLSSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentTLSTestSecretName,
+			Namespace: ngfNamespace,
+		},
+		Data: map[string][]byte{"tls.crt": []byte("tls")},
+	}
+	fakeClient := fake.NewFakeClient(agentTLSSecret)
+
+	provisioner := &NginxProvisioner{
+		cfg: Config{
+			GatewayPodConfig: &config.GatewayPodConfig{
+				Namespace: ngfNamespace,
+			},
+			AgentTLSSecretName: agentTLSTestSecretName,
+			AgentLabels:        make(map[string]string),
+		},
+		k8sClient: fakeClient,
+		baseLabelSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "nginx",
+			},
+		},
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "default",
+		},
+	}
+
+	postStart := &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{Command: []string{"echo", "started"}},
+	}
+
+	nProxyCfg := &graph.EffectiveNginxProxy{
+		Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+			Deployment: &ngfAPIv1alpha2.DeploymentSpec{
+				Container: ngfAPIv1alpha2.ContainerSpec{
+					Lifecycle:    &corev1.Lifecycle{PostStart: postStart},
+					PreStopDrain: &ngfAPIv1alpha2.PreStopDrain{DelaySeconds: 15},
+				},
+			},
+		},
+	}
+
+	getTemplate := func() corev1.PodTemplateSpec {
+		objects, err := provisioner.buildNginxResourceObjects("gw-nginx", gateway, nProxyCfg)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		for _, obj := range objects {
+			if dep, ok := obj.(*appsv1.Deployment); ok {
+				return dep.Spec.Template
+			}
+		}
+
+		t.Fatal("Deployment not found")
+		return corev1.PodTemplateSpec{}
+	}
+
+	template := getTemplate()
+	g.Expect(*template.Spec.TerminationGracePeriodSeconds).To(Equal(int64(45)))
+
+	lifecycle := template.Spec.Containers[0].Lifecycle
+	g.Expect(lifecycle.PostStart).To(Equal(postStart))
+	g.Expect(lifecycle.PreStop.Exec.Command).To(Equal([]string{
+		"/bin/sh", "-c", "touch /var/run/nginx/drain && sleep 15",
+	}))
+
+	// the lifecycle of the NginxProxy is not modified
+	g.Expect(nProxyCfg.Kubernetes.Deployment.Container.Lifecycle.PreStop).To(BeNil())
+
+	// the termination grace period of the NginxProxy is kept
+	nProxyCfg.Kubernetes.Deployment.Pod.TerminationGracePeriodSeconds = helpers.GetPointer[int64](60)
+	template = getTemplate()
+	g.Expect(*template.Spec.TerminationGracePeriodSeconds).To(Equal(int64(60)))
+}
+
+func TestBuildNginxResourceObjects_TempFileVolumeSizeLimit(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	agentTLSSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentTLSTestSecretName,
+			Namespace: ngfNamespace,
+		},
+		Data: map[string][]byte{"tls.crt": []byte("tls")},
+	}
+	fakeClient := fake.NewFakeClient(agentTLSSecret)
+
+	provisioner := &NginxProvisioner{
+		cfg: Config{
+			GatewayPodConfig: &config.GatewayPodConfig{
+				Namespace: ngfNamespace,
+			},
+			AgentTLSSecretName: agentTLSTestSecretName,
+			AgentLabels:        make(map[string]string),
+		},
+		k8sClient: fakeClient,
+		baseLabelSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "nginx",
+			},
+		},
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "default",
+		},
+	}
+
+	nProxyCfg := &graph.EffectiveNginxProxy{
+		TempFiles: &ngfAPIv1alpha2.TempFiles{
+			VolumeSizeLimit: helpers.GetPointer(resource.MustParse("512Mi")),
+		},
+	}
+
+	objects, err := provisioner.buildNginxResourceObjects("gw-nginx", gateway, nProxyCfg)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var volumes []corev1.Volume
+	for _, obj := range objects {
+		if dep, ok := obj.(*appsv1.Deployment); ok {
+			volumes = dep.Spec.Template.Spec.Volumes
+		}
+	}
+
+	g.Expect(volumes).To(ContainElement(HaveField("Name", "nginx-cache")))
+	for _, volume := range volumes {
+		switch volume.Name {
+		case "nginx-cache":
+			g.Expect(volume.EmptyDir.SizeLimit.String()).To(Equal("512Mi"))
+		case "nginx-run":
+			// the other volumes are not limited
+			g.Expect(volume.EmptyDir.SizeLimit).To(BeNil())
+		}
+	}
+	g.Expect(emptyDirVolumeSource.EmptyDir.SizeLimit).To(BeNil())
+}
+
+func TestBuildNginxResourceObjects_Agentless(t *testing.T) {
+	t.Parallel()
+
+	agentTLSSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentTLSTestSecretName,
+			Namespace: ngfNamespace,
+		},
+		Data: map[string][]byte{"tls.crt": []byte("tls")},
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "default",
+		},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{{Port: 80}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		expNginxCommand  []string
+		expContainerNum  int
+		debug            bool
+		agentlessEnabled bool
+	}{
+		{
+			name:             "agentless disabled",
+			agentlessEnabled: false,
+			expContainerNum:  1,
+		},
+		{
+			name:             "agentless enabled",
+			agentlessEnabled: true,
+			expNginxCommand:  []string{"/usr/sbin/nginx", "-g", "daemon off;"},
+			expContainerNum:  2,
+		},
+		{
+			name:             "agentless enabled with debug",
+			agentlessEnabled: true,
+			debug:            true,
+			expNginxCommand:  []string{"/usr/sbin/nginx-debug", "-g", "daemon off;"},
+			expContainerNum:  2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			provisioner := &NginxProvisioner{
+				cfg: Config{
+					GatewayPodConfig: &config.GatewayPodConfig{
+						Namespace: ngfNamespace,
+						Image:     "ngf-image",
+					},
+					AgentTLSSecretName: agentTLSTestSecretName,
+					AgentLabels:        make(map[string]string),
+				},
+				k8sClient: fake.NewFakeClient(agentTLSSecret),
+				baseLabelSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "nginx"},
+				},
+			}
+
+			npCfg := &graph.EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Agentless: &ngfAPIv1alpha2.AgentlessSpec{Enable: test.agentlessEnabled},
+					Deployment: &ngfAPIv1alpha2.DeploymentSpec{
+						Container: ngfAPIv1alpha2.ContainerSpec{
+							Debug: helpers.GetPointer(test.debug),
+						},
+					},
+				},
+			}
+
+			objects, err := provisioner.buildNginxResourceObjects("gw-nginx", gateway, npCfg)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			var deployment *appsv1.Deployment
+			for _, obj := range objects {
+				if d, ok := obj.(*appsv1.Deployment); ok {
+					deployment = d
+					break
+				}
+			}
+			g.Expect(deployment).ToNot(BeNil())
+
+			podSpec := deployment.Spec.Template.Spec
+			g.Expect(podSpec.Containers).To(HaveLen(test.expContainerNum))
+
+			if !test.agentlessEnabled {
+				g.Expect(podSpec.ShareProcessNamespace).To(BeNil())
+				return
+			}
+
+			g.Expect(podSpec.ShareProcessNamespace).To(Equal(helpers.GetPointer(true)))
+			g.Expect(podSpec.Containers[0].Command).To(Equal(test.expNginxCommand))
+			g.Expect(podSpec.Containers[0].Args).To(BeEmpty())
+
+			reloader := podSpec.Containers[1]
+			g.Expect(reloader.Name).To(Equal("config-reloader"))
+			g.Expect(reloader.Image).To(Equal("ngf-image"))
+			g.Expect(reloader.Command[:2]).To(Equal([]string{"/usr/bin/gateway", "config-reloader"}))
+			g.Expect(reloader.VolumeMounts).To(ContainElements(
+				corev1.VolumeMount{MountPath: "/var/run/nginx-export/config", Name: "nginx-export-config", ReadOnly: true},
+				corev1.VolumeMount{MountPath: "/etc/nginx/conf.d", Name: "nginx-conf"},
+				corev1.VolumeMount{MountPath: "/var/run/nginx", Name: "nginx-run"},
+			))
+
+			g.Expect(podSpec.Volumes).To(ContainElements(
+				corev1.Volume{
+					Name: "nginx-export-config",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "gw-nginx-config"},
+							Optional:             helpers.GetPointer(true),
+						},
+					},
+				},
+				corev1.Volume{
+					Name: "nginx-export-secrets",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: "gw-nginx-config",
+							Optional:   helpers.GetPointer(true),
+						},
+					},
+				},
+			))
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/config"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/ipam"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/openshift"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
@@ -49,6 +51,7 @@ type Config struct {
 	DeploymentStore                agent.DeploymentStorer
 	EventRecorder                  record.EventRecorder
 	PlusUsageConfig                *config.UsageReportConfig
+	AddressAllocator               ipam.AddressAllocator
 	StatusQueue                    *status.Queue
 	GatewayPodConfig               *config.GatewayPodConfig
 	AgentLabels                    map[string]string
@@ -57,6 +60,7 @@ type Config struct {
 	GCName                         string
 	AgentTLSSecretName             string
 	NginxDockerSecretNames         []string
+	ControlPlanePorts              []int32
 	NginxOneConsoleTelemetryConfig config.NginxOneConsoleTelemetryConfig
 	Plus                           bool
 	InferenceExtension             bool
@@ -243,6 +247,10 @@ func (p *NginxProvisioner) provisionNginx(
 		"resource names", objNames,
 	)
 
+	if err := p.allocateServiceAddress(ctx, gateway, objects); err != nil {
+		return err
+	}
+
 	var agentConfigMapUpdated, deploymentCreated bool
 	var deploymentObj *appsv1.Deployment
 	var daemonSetObj *appsv1.DaemonSet
@@ -370,24 +378,27 @@ func (p *NginxProvisioner) provisionNginx(
 func (p *NginxProvisioner) reprovisionNginx(
 	ctx context.Context,
 	resourceName string,
-	gateway *gatewayv1.Gateway,
-	nProxyCfg *graph.EffectiveNginxProxy,
+	gateway *graph.Gateway,
 ) error {
 	if !p.isLeader() {
 		return nil
 	}
 
-	objects, err := p.buildNginxResourceObjects(resourceName, gateway, nProxyCfg)
+	objects, err := p.buildNginxResourceObjectsForGateway(resourceName, gateway)
 	if err != nil {
 		p.cfg.Logger.Error(err, "error provisioning some nginx resources")
 	}
 
 	p.cfg.Logger.Info(
 		"Re-creating nginx resources",
-		"namespace", gateway.GetNamespace(),
+		"namespace", gateway.Source.GetNamespace(),
 		"name", resourceName,
 	)
 
+	if err := p.allocateServiceAddress(ctx, gateway.Source, objects); err != nil {
+		return err
+	}
+
 	createCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -437,6 +448,17 @@ func (p *NginxProvisioner) deprovisionNginx(ctx context.Context, gatewayNSName t
 				return err
 			}
 		}
+
+		if p.cfg.AddressAllocator != nil {
+			if err := p.cfg.AddressAllocator.Release(deleteCtx, gatewayNSName); err != nil {
+				p.cfg.Logger.Error(
+					err,
+					"error releasing the address of the nginx Service",
+					"namespace", gatewayNSName.Namespace,
+					"name", gatewayNSName.Name,
+				)
+			}
+		}
 	}
 
 	p.store.deleteResourcesForGateway(gatewayNSName)
@@ -510,13 +532,13 @@ func (p *NginxProvisioner) RegisterGateway(
 	}
 
 	if gateway.Valid {
-		objects, err := p.buildNginxResourceObjects(resourceName, gateway.Source, gateway.EffectiveNginxProxy)
+		objects, err := p.buildNginxResourceObjectsForGateway(resourceName, gateway)
 		if err != nil {
 			p.cfg.Logger.Error(err, "error building some nginx resources")
 		}
 
 		// If NGINX deployment type switched between Deployment and DaemonSet, clean up the old one.
-		// If HPA was disabled, remove it.
+		// If HPA or NetworkPolicy was disabled, remove it.
 		nginxResources := p.store.getNginxResourcesForGateway(gatewayNSName)
 		if nginxResources != nil {
 			if needToDeleteDaemonSet(nginxResources) {
@@ -534,6 +556,15 @@ func (p *NginxProvisioner) RegisterGateway(
 					p.cfg.Logger.Error(err, "error deleting nginx resource")
 				}
 			}
+
+			if needToDeleteNetworkPolicy(nginxResources) {
+				if err := p.deleteObject(
+					ctx,
+					&networkingv1.NetworkPolicy{ObjectMeta: nginxResources.NetworkPolicy},
+				); err != nil {
+					p.cfg.Logger.Error(err, "error deleting nginx resource")
+				}
+			}
 		}
 
 		if err := p.provisionNginx(ctx, resourceName, gateway.Source, objects); err != nil {
@@ -590,3 +621,8 @@ func needToDeleteHPA(cfg *NginxResources) bool {
 
 	return false
 }
+
+func needToDeleteNetworkPolicy(cfg *NginxResources) bool {
+	return cfg.NetworkPolicy.Name != "" && cfg.Gateway != nil &&
+		!isNetworkPolicyEnabled(cfg.Gateway.EffectiveNginxProxy)
+}
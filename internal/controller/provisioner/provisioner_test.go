@@ -2,6 +2,7 @@ package provisioner
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -9,6 +10,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -23,6 +25,8 @@ import (
 	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/config"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent/agentfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/ipam"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/ipam/ipamfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/provisioner/openshift/openshiftfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
@@ -47,6 +51,7 @@ func createScheme() *runtime.Scheme {
 	utilruntime.Must(corev1.AddToScheme(scheme))
 	utilruntime.Must(appsv1.AddToScheme(scheme))
 	utilruntime.Must(autoscalingv2.AddToScheme(scheme))
+	utilruntime.Must(networkingv1.AddToScheme(scheme))
 
 	return scheme
 }
@@ -350,6 +355,85 @@ func TestRegisterGateway(t *testing.T) {
 	g.Expect(deploymentStore.RemoveCallCount()).To(Equal(1))
 }
 
+func TestRegisterGateway_AddressAllocator(t *testing.T) {
+	t.Parallel()
+
+	gwNsName := types.NamespacedName{Name: "gw", Namespace: "default"}
+	svcNsName := types.NamespacedName{Name: "gw-nginx", Namespace: "default"}
+
+	createGateway := func(valid bool) *graph.Gateway {
+		return &graph.Gateway{
+			Source: &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: gwNsName.Name, Namespace: gwNsName.Namespace},
+			},
+			Valid: valid,
+		}
+	}
+
+	secrets := func() []client.Object {
+		objects := make([]client.Object, 0, 5)
+		for _, name := range []string{
+			agentTLSTestSecretName,
+			jwtTestSecretName,
+			caTestSecretName,
+			clientTestSecretName,
+			dockerTestSecretName,
+		} {
+			objects = append(objects, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ngfNamespace}})
+		}
+		return objects
+	}
+
+	t.Run("allocates and releases the address", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		gateway := createGateway(true)
+		provisioner, fakeClient, _ := defaultNginxProvisioner(append(secrets(), gateway.Source)...)
+
+		allocator := &ipamfakes.FakeAddressAllocator{}
+		allocator.AllocateReturns(ipam.Allocation{
+			Annotations: map[string]string{ipam.MetalLBAddressPoolAnnotation: "gateways"},
+			Address:     "10.0.0.10",
+		}, nil)
+		provisioner.cfg.AddressAllocator = allocator
+
+		g.Expect(provisioner.RegisterGateway(t.Context(), gateway, "gw-nginx")).To(Succeed())
+
+		_, allocatedGateway := allocator.AllocateArgsForCall(0)
+		g.Expect(allocatedGateway).To(Equal(gwNsName))
+
+		var svc corev1.Service
+		g.Expect(fakeClient.Get(t.Context(), svcNsName, &svc)).To(Succeed())
+		g.Expect(svc.Spec.LoadBalancerIP).To(Equal("10.0.0.10"))
+		g.Expect(svc.Annotations).To(HaveKeyWithValue(ipam.MetalLBAddressPoolAnnotation, "gateways"))
+
+		g.Expect(provisioner.RegisterGateway(t.Context(), createGateway(false), "gw-nginx")).To(Succeed())
+
+		g.Expect(allocator.ReleaseCallCount()).To(Equal(1))
+		_, releasedGateway := allocator.ReleaseArgsForCall(0)
+		g.Expect(releasedGateway).To(Equal(gwNsName))
+	})
+
+	t.Run("doesn't provision the Service without its address", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		gateway := createGateway(true)
+		provisioner, fakeClient, _ := defaultNginxProvisioner(append(secrets(), gateway.Source)...)
+
+		allocator := &ipamfakes.FakeAddressAllocator{}
+		allocator.AllocateReturns(ipam.Allocation{}, errors.New("address pool exhausted"))
+		provisioner.cfg.AddressAllocator = allocator
+
+		err := provisioner.RegisterGateway(t.Context(), gateway, "gw-nginx")
+		g.Expect(err).To(MatchError(ContainSubstring("address pool exhausted")))
+
+		var svc corev1.Service
+		g.Expect(fakeClient.Get(t.Context(), svcNsName, &svc)).ToNot(Succeed())
+	})
+}
+
 func TestRegisterGateway_CleansUpOldDeploymentOrDaemonSet(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -472,6 +556,50 @@ func TestRegisterGateway_CleansUpOldHPA(t *testing.T) {
 	g.Expect(hpaErr).To(HaveOccurred())
 }
 
+func TestRegisterGateway_CleansUpOldNetworkPolicy(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	// Setup: Gateway previously had a NetworkPolicy, but it is now disabled
+	oldNetworkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw-nginx",
+			Namespace: "default",
+		},
+	}
+	gateway := &graph.Gateway{
+		Source: &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw",
+				Namespace: "default",
+			},
+		},
+		Valid: true,
+		EffectiveNginxProxy: &graph.EffectiveNginxProxy{
+			Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+				NetworkPolicy: &ngfAPIv1alpha2.NetworkPolicySpec{
+					Enable: false,
+				},
+			},
+		},
+	}
+
+	provisioner, fakeClient, _ := defaultNginxProvisioner(gateway.Source, oldNetworkPolicy)
+	provisioner.store.nginxResources[types.NamespacedName{Name: "gw", Namespace: "default"}] = &NginxResources{
+		NetworkPolicy: oldNetworkPolicy.ObjectMeta,
+	}
+
+	g.Expect(provisioner.RegisterGateway(t.Context(), gateway, "gw-nginx")).To(Succeed())
+
+	// NetworkPolicy should be deleted
+	npErr := fakeClient.Get(
+		t.Context(),
+		types.NamespacedName{Name: "gw-nginx", Namespace: "default"},
+		&networkingv1.NetworkPolicy{},
+	)
+	g.Expect(npErr).To(HaveOccurred())
+}
+
 func TestNonLeaderProvisioner(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -486,7 +614,7 @@ func TestNonLeaderProvisioner(t *testing.T) {
 	g.Expect(provisioner.provisionNginx(t.Context(), "gw-nginx", nil, nil)).To(Succeed())
 	expectResourcesToNotExist(t, g, fakeClient, nsName)
 
-	g.Expect(provisioner.reprovisionNginx(t.Context(), "gw-nginx", nil, nil)).To(Succeed())
+	g.Expect(provisioner.reprovisionNginx(t.Context(), "gw-nginx", nil)).To(Succeed())
 	expectResourcesToNotExist(t, g, fakeClient, nsName)
 
 	g.Expect(provisioner.deprovisionNginx(t.Context(), nsName)).To(Succeed())
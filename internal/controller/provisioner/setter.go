@@ -8,6 +8,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +36,8 @@ func objectSpecSetter(object client.Object) controllerutil.MutateFn {
 		return roleSpecSetter(obj, obj.Rules, obj.ObjectMeta)
 	case *rbacv1.RoleBinding:
 		return roleBindingSpecSetter(obj, obj.RoleRef, obj.Subjects, obj.ObjectMeta)
+	case *networkingv1.NetworkPolicy:
+		return networkPolicySpecSetter(obj, obj.Spec, obj.ObjectMeta)
 	}
 
 	return nil
@@ -60,12 +63,25 @@ func hpaSpecSetter(
 ) controllerutil.MutateFn {
 	return func() error {
 		hpa.Labels = objectMeta.Labels
-		hpa.Annotations = objectMeta.Annotations
+		hpa.Annotations = mergeAnnotations(hpa.Annotations, objectMeta.Annotations)
 		hpa.Spec = spec
 		return nil
 	}
 }
 
+func networkPolicySpecSetter(
+	networkPolicy *networkingv1.NetworkPolicy,
+	spec networkingv1.NetworkPolicySpec,
+	objectMeta metav1.ObjectMeta,
+) controllerutil.MutateFn {
+	return func() error {
+		networkPolicy.Labels = objectMeta.Labels
+		networkPolicy.Annotations = mergeAnnotations(networkPolicy.Annotations, objectMeta.Annotations)
+		networkPolicy.Spec = spec
+		return nil
+	}
+}
+
 func daemonSetSpecSetter(
 	daemonSet *appsv1.DaemonSet,
 	spec appsv1.DaemonSetSpec,
@@ -98,7 +114,7 @@ func serviceAccountSpecSetter(
 ) controllerutil.MutateFn {
 	return func() error {
 		serviceAccount.Labels = objectMeta.Labels
-		serviceAccount.Annotations = objectMeta.Annotations
+		serviceAccount.Annotations = mergeAnnotations(serviceAccount.Annotations, objectMeta.Annotations)
 		return nil
 	}
 }
@@ -109,16 +125,18 @@ func configMapSpecSetter(
 	objectMeta metav1.ObjectMeta,
 ) controllerutil.MutateFn {
 	return func() error {
+		annotations := mergeAnnotations(configMap.Annotations, objectMeta.Annotations)
+
 		// this check ensures we don't trigger an unnecessary update to the agent ConfigMap
 		// and trigger a Deployment restart
 		if maps.Equal(configMap.Labels, objectMeta.Labels) &&
-			maps.Equal(configMap.Annotations, objectMeta.Annotations) &&
+			maps.Equal(configMap.Annotations, annotations) &&
 			maps.Equal(configMap.Data, data) {
 			return nil
 		}
 
 		configMap.Labels = objectMeta.Labels
-		configMap.Annotations = objectMeta.Annotations
+		configMap.Annotations = annotations
 		configMap.Data = data
 		return nil
 	}
@@ -131,7 +149,7 @@ func secretSpecSetter(
 ) controllerutil.MutateFn {
 	return func() error {
 		secret.Labels = objectMeta.Labels
-		secret.Annotations = objectMeta.Annotations
+		secret.Annotations = mergeAnnotations(secret.Annotations, objectMeta.Annotations)
 		secret.Data = data
 		return nil
 	}
@@ -144,7 +162,7 @@ func roleSpecSetter(
 ) controllerutil.MutateFn {
 	return func() error {
 		role.Labels = objectMeta.Labels
-		role.Annotations = objectMeta.Annotations
+		role.Annotations = mergeAnnotations(role.Annotations, objectMeta.Annotations)
 		role.Rules = rules
 		return nil
 	}
@@ -158,13 +176,17 @@ func roleBindingSpecSetter(
 ) controllerutil.MutateFn {
 	return func() error {
 		roleBinding.Labels = objectMeta.Labels
-		roleBinding.Annotations = objectMeta.Annotations
+		roleBinding.Annotations = mergeAnnotations(roleBinding.Annotations, objectMeta.Annotations)
 		roleBinding.RoleRef = roleRef
 		roleBinding.Subjects = subjects
 		return nil
 	}
 }
 
+// mergeAnnotations merges the desired NGF-managed annotations into the existing annotations of an object.
+// Annotations added by other controllers or users are preserved, desired annotations take precedence on conflicts,
+// and annotations that NGF previously managed but are no longer desired (for example, because they were removed
+// from the Gateway infrastructure) are removed.
 func mergeAnnotations(existing, desired map[string]string) map[string]string {
 	const trackingKey = "gateway.nginx.org/internal-managed-annotation-keys"
 	desiredKeys := make(map[string]struct{}, len(desired))
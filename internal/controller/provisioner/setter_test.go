@@ -5,8 +5,11 @@ import (
 
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestServiceSpecSetter_PreservesExternalAnnotations(t *testing.T) {
@@ -349,3 +352,111 @@ func TestDeploymentAndDaemonSetSpecSetter(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectSpecSetter_MergesAnnotations(t *testing.T) {
+	t.Parallel()
+
+	existingAnnotations := func() map[string]string {
+		return map[string]string{
+			"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/external",
+			"removed":                    "value",
+			"gateway.nginx.org/internal-managed-annotation-keys": "removed",
+		}
+	}
+
+	desiredMeta := metav1.ObjectMeta{
+		Name:        "test",
+		Namespace:   "default",
+		Labels:      map[string]string{"app": "nginx-gateway"},
+		Annotations: map[string]string{"custom.annotation": "from-gateway-infrastructure"},
+	}
+
+	expAnnotations := map[string]string{
+		"eks.amazonaws.com/role-arn":                         "arn:aws:iam::123456789012:role/external",
+		"custom.annotation":                                  "from-gateway-infrastructure",
+		"gateway.nginx.org/internal-managed-annotation-keys": "custom.annotation",
+	}
+
+	tests := []struct {
+		existing client.Object
+		desired  client.Object
+		name     string
+	}{
+		{
+			name:     "ServiceAccount",
+			existing: &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Annotations: existingAnnotations()}},
+			desired:  &corev1.ServiceAccount{ObjectMeta: desiredMeta},
+		},
+		{
+			name:     "ConfigMap",
+			existing: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: existingAnnotations()}},
+			desired:  &corev1.ConfigMap{ObjectMeta: desiredMeta},
+		},
+		{
+			name:     "Secret",
+			existing: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: existingAnnotations()}},
+			desired:  &corev1.Secret{ObjectMeta: desiredMeta},
+		},
+		{
+			name: "HorizontalPodAutoscaler",
+			existing: &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Annotations: existingAnnotations()},
+			},
+			desired: &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: desiredMeta},
+		},
+		{
+			name:     "Role",
+			existing: &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Annotations: existingAnnotations()}},
+			desired:  &rbacv1.Role{ObjectMeta: desiredMeta},
+		},
+		{
+			name:     "RoleBinding",
+			existing: &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Annotations: existingAnnotations()}},
+			desired:  &rbacv1.RoleBinding{ObjectMeta: desiredMeta},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			// objectSpecSetter captures the desired state, and is called with the existing object,
+			// like controllerutil.CreateOrUpdate does after getting the object from the API.
+			setter := objectSpecSetter(tc.desired)
+			g.Expect(setter).ToNot(BeNil())
+
+			tc.desired.SetAnnotations(tc.existing.GetAnnotations())
+			g.Expect(setter()).To(Succeed())
+
+			g.Expect(tc.desired.GetAnnotations()).To(Equal(expAnnotations))
+			g.Expect(tc.desired.GetLabels()).To(Equal(desiredMeta.Labels))
+		})
+	}
+}
+
+func TestConfigMapSpecSetter_NoChange(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	objectMeta := metav1.ObjectMeta{
+		Labels:      map[string]string{"app": "nginx-gateway"},
+		Annotations: map[string]string{"custom.annotation": "value"},
+	}
+	data := map[string]string{"key": "value"}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app": "nginx-gateway"},
+			Annotations: map[string]string{
+				"custom.annotation": "value",
+				"gateway.nginx.org/internal-managed-annotation-keys": "custom.annotation",
+			},
+		},
+		Data: data,
+	}
+	expConfigMap := configMap.DeepCopy()
+
+	g.Expect(configMapSpecSetter(configMap, data, objectMeta)()).To(Succeed())
+	g.Expect(configMap).To(Equal(expConfigMap))
+}
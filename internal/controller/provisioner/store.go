@@ -2,12 +2,14 @@ package provisioner
 
 import (
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -22,6 +24,7 @@ type NginxResources struct {
 	Gateway             *graph.Gateway
 	Deployment          metav1.ObjectMeta
 	HPA                 metav1.ObjectMeta
+	NetworkPolicy       metav1.ObjectMeta
 	DaemonSet           metav1.ObjectMeta
 	Service             metav1.ObjectMeta
 	ServiceAccount      metav1.ObjectMeta
@@ -149,6 +152,14 @@ func (s *store) registerResourceInGatewayConfig(gatewayNSName types.NamespacedNa
 		} else {
 			cfg.HPA = obj.ObjectMeta
 		}
+	case *networkingv1.NetworkPolicy:
+		if cfg, ok := s.nginxResources[gatewayNSName]; !ok {
+			s.nginxResources[gatewayNSName] = &NginxResources{
+				NetworkPolicy: obj.ObjectMeta,
+			}
+		} else {
+			cfg.NetworkPolicy = obj.ObjectMeta
+		}
 	case *appsv1.DaemonSet:
 		if cfg, ok := s.nginxResources[gatewayNSName]; !ok {
 			s.nginxResources[gatewayNSName] = &NginxResources{
@@ -295,7 +306,16 @@ func gatewayChanged(original, updated *graph.Gateway) bool {
 		return true
 	}
 
-	return !reflect.DeepEqual(original.EffectiveNginxProxy, updated.EffectiveNginxProxy)
+	if !reflect.DeepEqual(original.EffectiveNginxProxy, updated.EffectiveNginxProxy) {
+		return true
+	}
+
+	// the NetworkPolicy needs to be updated when the backends referenced by the attached Routes change
+	if isNetworkPolicyEnabled(updated.EffectiveNginxProxy) {
+		return !slices.Equal(networkPolicyBackends(original), networkPolicyBackends(updated))
+	}
+
+	return false
 }
 
 func (s *store) getNginxResourcesForGateway(nsName types.NamespacedName) *NginxResources {
@@ -327,6 +347,10 @@ func (s *store) gatewayExistsForResource(object client.Object, nsName types.Name
 			if resourceMatches(resources.HPA, nsName) {
 				return resources.Gateway
 			}
+		case *networkingv1.NetworkPolicy:
+			if resourceMatches(resources.NetworkPolicy, nsName) {
+				return resources.Gateway
+			}
 		case *appsv1.DaemonSet:
 			if resourceMatches(resources.DaemonSet, nsName) {
 				return resources.Gateway
@@ -413,6 +437,10 @@ func (s *store) getResourceVersionForObject(gatewayNSName types.NamespacedName,
 		if resources.HPA.GetName() == obj.GetName() {
 			return resources.HPA.GetResourceVersion()
 		}
+	case *networkingv1.NetworkPolicy:
+		if resources.NetworkPolicy.GetName() == obj.GetName() {
+			return resources.NetworkPolicy.GetResourceVersion()
+		}
 	case *appsv1.DaemonSet:
 		if resources.DaemonSet.GetName() == obj.GetName() {
 			return resources.DaemonSet.GetResourceVersion()
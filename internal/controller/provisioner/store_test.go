@@ -8,6 +8,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -428,6 +429,28 @@ func TestGatewayChanged(t *testing.T) {
 			},
 			changed: true,
 		},
+		{
+			name: "backends change with NetworkPolicy enabled",
+			original: &graph.Gateway{
+				EffectiveNginxProxy: networkPolicyEnabledNginxProxy(),
+				Listeners:           []*graph.Listener{listenerWithBackend("backend1", 80)},
+			},
+			updated: &graph.Gateway{
+				EffectiveNginxProxy: networkPolicyEnabledNginxProxy(),
+				Listeners:           []*graph.Listener{listenerWithBackend("backend2", 80)},
+			},
+			changed: true,
+		},
+		{
+			name: "backends change with NetworkPolicy disabled",
+			original: &graph.Gateway{
+				Listeners: []*graph.Listener{listenerWithBackend("backend1", 80)},
+			},
+			updated: &graph.Gateway{
+				Listeners: []*graph.Listener{listenerWithBackend("backend2", 80)},
+			},
+			changed: false,
+		},
 		{
 			name: "no changes",
 			original: &graph.Gateway{Source: &gatewayv1.Gateway{
@@ -501,6 +524,10 @@ func TestGatewayExistsForResource(t *testing.T) {
 			Name:      "test-hpa",
 			Namespace: "default",
 		},
+		NetworkPolicy: metav1.ObjectMeta{
+			Name:      "test-networkpolicy",
+			Namespace: "default",
+		},
 		Role: metav1.ObjectMeta{
 			Name:      "test-role",
 			Namespace: "default",
@@ -600,6 +627,16 @@ func TestGatewayExistsForResource(t *testing.T) {
 			},
 			expected: gateway,
 		},
+		{
+			name: "NetworkPolicy exists",
+			object: &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-networkpolicy",
+					Namespace: "default",
+				},
+			},
+			expected: gateway,
+		},
 		{
 			name: "Role exists",
 			object: &rbacv1.Role{
@@ -806,6 +843,11 @@ func TestGetResourceVersionForObject(t *testing.T) {
 			Namespace:       "default",
 			ResourceVersion: "15",
 		},
+		NetworkPolicy: metav1.ObjectMeta{
+			Name:            "test-networkpolicy",
+			Namespace:       "default",
+			ResourceVersion: "16",
+		},
 	}
 
 	tests := []struct {
@@ -833,6 +875,16 @@ func TestGetResourceVersionForObject(t *testing.T) {
 			},
 			expectedResult: "2",
 		},
+		{
+			name: "NetworkPolicy resource version",
+			object: &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-networkpolicy",
+					Namespace: "default",
+				},
+			},
+			expectedResult: "16",
+		},
 		{
 			name: "Service resource version",
 			object: &corev1.Service{
@@ -48,6 +48,9 @@ allowed_directories:
 - /etc/nginx
 - /usr/share/nginx
 - /var/run/nginx
+{{- if eq true .Plus }}
+- /var/lib/nginx/state
+{{- end }}
 features:
 - configuration
 - certificates
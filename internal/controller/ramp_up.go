@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/rampup"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/runnables"
+)
+
+const (
+	// rampUpPeriod is the period of the ramp-up job. It is the minimum duration of a ramp-up step,
+	// so that every step is applied.
+	rampUpPeriod = rampup.MinStepDuration
+	// rampUpJitterFactor spreads the ramp-ups of the replicas of the control plane.
+	rampUpJitterFactor = 0.1
+	// rampUpProbeTimeout is the timeout of the health probes of the endpoints of the new backends.
+	rampUpProbeTimeout = 2 * time.Second
+)
+
+// rampUpEvent makes the event handler regenerate the configuration from the latest graph, because
+// the weights of the ramping backends of Routes changed.
+type rampUpEvent struct {
+	changes []rampup.Change
+}
+
+// newRampUpJob creates a job that periodically advances the ramp-up of the new backends of the Routes of
+// the latest graph, and sends a rampUpEvent to the event loop when their weights change.
+// Every replica of the control plane ramps up the backends, so that they all generate the same configuration.
+func newRampUpJob(
+	logger logr.Logger,
+	ramper *rampup.Ramper,
+	getLatestGraph func() *graph.Graph,
+	eventCh chan<- interface{},
+	readyCh <-chan struct{},
+	period time.Duration,
+) *runnables.LeaderOrNonLeader {
+	worker := func(ctx context.Context) {
+		changes, weightsChanged := ramper.Advance(ctx, getLatestGraph(), time.Now())
+		if !weightsChanged {
+			return
+		}
+
+		select {
+		case eventCh <- &rampUpEvent{changes: changes}:
+		case <-ctx.Done():
+		}
+	}
+
+	return &runnables.LeaderOrNonLeader{
+		Runnable: runnables.NewCronJob(
+			runnables.CronJobConfig{
+				Worker:       worker,
+				Logger:       logger,
+				Period:       period,
+				JitterFactor: rampUpJitterFactor,
+				ReadyCh:      readyCh,
+			},
+		),
+	}
+}
+
+// rampUpChanged returns whether the weights of the ramping backends changed since the last call, and resets it.
+func (h *eventHandlerImpl) rampUpChanged() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	changed := h.rampUpWeightsChanged
+	h.rampUpWeightsChanged = false
+
+	return changed
+}
+
+// recordRampUpChanges records the changes of the ramp-ups as events of the Routes.
+// Only the leader records the events, so that they are not duplicated by every replica.
+func (h *eventHandlerImpl) recordRampUpChanges(changes []rampup.Change) {
+	if !h.isLeader() {
+		return
+	}
+
+	for _, change := range changes {
+		h.cfg.eventRecorder.Event(change.Route, v1.EventTypeNormal, change.Reason, change.Message)
+	}
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/rampup"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/rampup/rampupfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver/resolverfakes"
+)
+
+func TestRampUpJob(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	createGraph := func(backends ...string) *graph.Graph {
+		refs := make([]graph.BackendRef, 0, len(backends))
+		for _, name := range backends {
+			refs = append(refs, graph.BackendRef{
+				SvcNsName:   types.NamespacedName{Namespace: "test", Name: name},
+				ServicePort: v1.ServicePort{Port: 80},
+				Weight:      10,
+				Valid:       true,
+			})
+		}
+
+		route := &graph.L7Route{
+			Source: &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "test",
+					Name:        "route",
+					Annotations: map[string]string{rampup.DurationAnnotation: "5m"},
+				},
+			},
+			RouteType: graph.RouteTypeHTTP,
+			Valid:     true,
+			Spec: graph.L7RouteSpec{
+				Rules: []graph.RouteRule{{BackendRefs: refs}},
+			},
+		}
+
+		return &graph.Graph{
+			Routes: map[graph.RouteKey]*graph.L7Route{graph.CreateRouteKey(route.Source): route},
+		}
+	}
+
+	fakeResolver := &resolverfakes.FakeServiceResolver{}
+	fakeResolver.ResolveReturns([]resolver.Endpoint{{Address: "10.0.0.1", Port: 8080}}, nil)
+
+	ramper := rampup.NewRamper(logr.Discard(), fakeResolver, &rampupfakes.FakeProber{})
+	ramper.Observe(createGraph("stable"))
+
+	gr := createGraph("stable", "new")
+	ramper.Observe(gr)
+
+	eventCh := make(chan interface{})
+	readyCh := make(chan struct{})
+
+	job := newRampUpJob(
+		logr.Discard(),
+		ramper,
+		func() *graph.Graph { return gr },
+		eventCh,
+		readyCh,
+		10*time.Millisecond,
+	)
+	g.Expect(job.NeedLeaderElection()).To(BeFalse())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- job.Start(ctx)
+	}()
+
+	// the ramp-up doesn't start until the control plane is ready
+	g.Consistently(eventCh).ShouldNot(Receive())
+
+	close(readyCh)
+
+	var event interface{}
+	g.Eventually(eventCh).Should(Receive(&event))
+	g.Expect(event).To(BeAssignableToTypeOf(&rampUpEvent{}))
+
+	changes := event.(*rampUpEvent).changes
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].Reason).To(Equal(rampup.ReasonStarted))
+
+	// the step of the ramp-up holds, so no more events are sent
+	g.Consistently(eventCh).ShouldNot(Receive())
+
+	cancel()
+	g.Eventually(errCh).Should(Receive(BeNil()))
+}
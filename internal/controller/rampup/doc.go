@@ -0,0 +1,11 @@
+/*
+Package rampup ramps up the weights of the backends that are added to the rules of HTTPRoutes and GRPCRoutes.
+
+A Route opts into the ramp-up with the gateway.nginx.org/ramp-up-duration annotation. A backend that is added
+to a rule, which already has a backend receiving traffic, starts at weight 0. Once the endpoints of its Service
+are ready, and, if the gateway.nginx.org/ramp-up-health-path annotation is set, every endpoint responds to
+the health probe, its weight is increased in steps until it reaches the weight of its backendRef at the end
+of the duration. The backends of a Route that exist when the Route is first observed, or when the control plane
+starts, are not ramped up.
+*/
+package rampup
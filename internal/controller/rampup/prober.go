@@ -0,0 +1,61 @@
+package rampup
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//go:generate go tool counterfeiter -generate
+
+//counterfeiter:generate . Prober
+
+// Prober probes the health of the endpoints of the backends.
+type Prober interface {
+	// Probe probes the path of the endpoint. It returns an error if the endpoint is not healthy.
+	Probe(ctx context.Context, address string, port int32, path string) error
+}
+
+// HTTPProber probes the endpoints with HTTP GET requests. An endpoint is healthy if it responds with a 2xx
+// or 3xx status code.
+type HTTPProber struct {
+	client *http.Client
+}
+
+// NewHTTPProber creates a new HTTPProber whose probes time out after the timeout.
+func NewHTTPProber(timeout time.Duration) *HTTPProber {
+	return &HTTPProber{
+		client: &http.Client{
+			Timeout: timeout,
+			// the redirects of the endpoints are not followed, because they are healthy responses
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Probe sends a GET request to the path of the endpoint.
+func (p *HTTPProber) Probe(ctx context.Context, address string, port int32, path string) error {
+	url := "http://" + net.JoinHostPort(address, strconv.Itoa(int(port))) + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("cannot create the health probe of %s: %w", url, err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("health probe of %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("health probe of %s failed with status code %d", url, resp.StatusCode)
+	}
+
+	return nil
+}
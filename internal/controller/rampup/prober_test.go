@@ -0,0 +1,70 @@
+package rampup
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHTTPProber(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/redirect":
+			http.Redirect(w, r, "/unhealthy", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+	port, err := strconv.Atoi(portStr)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name   string
+		path   string
+		expErr string
+	}{
+		{
+			name: "healthy",
+			path: "/healthz",
+		},
+		{
+			name: "redirect is not followed",
+			path: "/redirect",
+		},
+		{
+			name:   "unhealthy",
+			path:   "/unhealthy",
+			expErr: "failed with status code 503",
+		},
+	}
+
+	prober := NewHTTPProber(time.Second)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			err := prober.Probe(t.Context(), host, int32(port), test.path)
+			if test.expErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(test.expErr)))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
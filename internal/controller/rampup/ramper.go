@@ -0,0 +1,442 @@
+package rampup
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+)
+
+const (
+	// ReasonStarted is the reason of the Change when the ramp-up of a backend started.
+	ReasonStarted = "RampUpStarted"
+	// ReasonCompleted is the reason of the Change when the ramp-up of a backend completed.
+	ReasonCompleted = "RampUpCompleted"
+)
+
+// Change is a change of the ramp-up of a backend of a Route.
+type Change struct {
+	// Route is the Route.
+	Route client.Object
+	// Reason is the reason of the change.
+	Reason string
+	// Message describes the change.
+	Message string
+}
+
+// rampingBackend is a new backend of a Route that is ramped up.
+type rampingBackend struct {
+	// started is the time when the ramp-up started. It is zero while the backend is not ready.
+	started time.Time
+	// ref is the backendRef of the backend.
+	ref graph.BackendRef
+	// step is the current step of the ramp-up. It is 0 while the backend is not ready.
+	step int
+}
+
+// routeState is the state of the ramp-up of the backends of a Route.
+type routeState struct {
+	// route is the Route.
+	route client.Object
+	// established are the backends that receive the weight of their backendRef.
+	established map[dataplane.BackendWeightKey]struct{}
+	// ramping are the new backends that are ramped up.
+	ramping map[dataplane.BackendWeightKey]*rampingBackend
+	spec    Spec
+}
+
+// Ramper ramps up the weights of the backends that are added to the rules of the Routes.
+type Ramper struct {
+	resolver resolver.ServiceResolver
+	prober   Prober
+	routes   map[types.NamespacedName]*routeState
+	logger   logr.Logger
+	lock     sync.RWMutex
+}
+
+// NewRamper creates a new Ramper.
+func NewRamper(logger logr.Logger, serviceResolver resolver.ServiceResolver, prober Prober) *Ramper {
+	return &Ramper{
+		resolver: serviceResolver,
+		prober:   prober,
+		routes:   make(map[types.NamespacedName]*routeState),
+		logger:   logger,
+	}
+}
+
+// Observe records the backends of the Routes of the graph. The backends that are added to a rule, which has
+// an established backend, are held at weight 0 until their ramp-up starts. Observe is called before the
+// configuration is generated from the graph, so that the new backends don't receive traffic until they are ready.
+func (r *Ramper) Observe(gr *graph.Graph) {
+	if gr == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	observed := make(map[types.NamespacedName]struct{})
+
+	for _, route := range gr.Routes {
+		if route.Source == nil || !route.Valid {
+			continue
+		}
+
+		// the invalid specs are reported by Advance
+		spec, exists, err := ParseSpec(route.Source.GetAnnotations())
+		if !exists || err != nil {
+			continue
+		}
+
+		nsName := client.ObjectKeyFromObject(route.Source)
+		observed[nsName] = struct{}{}
+
+		backends := routeBackends(route)
+
+		state, exists := r.routes[nsName]
+		if !exists {
+			r.routes[nsName] = &routeState{
+				route:       route.Source,
+				spec:        spec,
+				established: keySet(backends),
+				ramping:     make(map[dataplane.BackendWeightKey]*rampingBackend),
+			}
+
+			continue
+		}
+
+		state.route = route.Source
+		state.spec = spec
+		state.update(backends)
+	}
+
+	for nsName := range r.routes {
+		if _, exists := observed[nsName]; !exists {
+			delete(r.routes, nsName)
+		}
+	}
+}
+
+// update updates the backends of the Route.
+func (s *routeState) update(backends map[dataplane.BackendWeightKey]graph.BackendRef) {
+	for key := range s.established {
+		if _, exists := backends[key]; !exists {
+			delete(s.established, key)
+		}
+	}
+
+	for key, backend := range s.ramping {
+		ref, exists := backends[key]
+		if !exists {
+			delete(s.ramping, key)
+			continue
+		}
+
+		backend.ref = ref
+	}
+
+	for _, key := range slices.SortedFunc(maps.Keys(backends), compareKeys) {
+		_, established := s.established[key]
+		_, ramping := s.ramping[key]
+
+		switch {
+		case established || ramping:
+		case s.hasEstablishedBackend(key.RuleIdx):
+			s.ramping[key] = &rampingBackend{ref: backends[key]}
+		default:
+			// the rule has no backend that receives traffic, so there is no traffic to shift to the new backend
+			s.established[key] = struct{}{}
+		}
+	}
+
+	// Once the established backends of a rule are removed, its ramping backends must receive the traffic.
+	for key := range s.ramping {
+		if !s.hasEstablishedBackend(key.RuleIdx) {
+			delete(s.ramping, key)
+			s.established[key] = struct{}{}
+		}
+	}
+}
+
+func (s *routeState) hasEstablishedBackend(ruleIdx int) bool {
+	for key := range s.established {
+		if key.RuleIdx == ruleIdx {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Advance starts the ramp-up of the new backends that are ready and advances the ramp-up of the backends
+// to the step of the time now. It returns the changes of the ramp-ups. The weights of the backends change
+// only when Advance is called, so the configuration must be regenerated when the returned changes are not
+// empty, or when the weights of a ramp-up step changed.
+func (r *Ramper) Advance(ctx context.Context, gr *graph.Graph, now time.Time) ([]Change, bool) {
+	if gr != nil {
+		r.logInvalidSpecs(gr)
+	}
+
+	pending := r.pendingBackends()
+
+	ready := make(map[types.NamespacedName]map[dataplane.BackendWeightKey]struct{}, len(pending))
+	for nsName, backends := range pending {
+		for key, p := range backends {
+			if err := r.checkReady(ctx, p.ref, p.spec); err != nil {
+				r.logger.V(1).Info(
+					"New backend is not ready for the ramp-up",
+					"route", nsName.String(),
+					"upstream", key.UpstreamName,
+					"reason", err.Error(),
+				)
+				continue
+			}
+
+			if ready[nsName] == nil {
+				ready[nsName] = make(map[dataplane.BackendWeightKey]struct{})
+			}
+			ready[nsName][key] = struct{}{}
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var changes []Change
+	weightsChanged := false
+
+	for _, nsName := range slices.SortedFunc(maps.Keys(r.routes), compareNsNames) {
+		state := r.routes[nsName]
+
+		for _, key := range slices.SortedFunc(maps.Keys(state.ramping), compareKeys) {
+			backend := state.ramping[key]
+
+			if backend.step == 0 {
+				if _, isReady := ready[nsName][key]; !isReady {
+					continue
+				}
+
+				backend.started = now
+				backend.step = 1
+				weightsChanged = true
+
+				changes = append(changes, Change{
+					Route:  state.route,
+					Reason: ReasonStarted,
+					Message: fmt.Sprintf(
+						"Ramp-up of backend %s of rule %d started; its weight is increased to %d in %d steps over %s",
+						backend.ref.SvcNsName.Name,
+						key.RuleIdx,
+						backend.ref.Weight,
+						state.spec.Steps,
+						state.spec.Duration,
+					),
+				})
+
+				continue
+			}
+
+			elapsed := now.Sub(backend.started)
+			if elapsed >= state.spec.Duration {
+				delete(state.ramping, key)
+				state.established[key] = struct{}{}
+				weightsChanged = true
+
+				changes = append(changes, Change{
+					Route:  state.route,
+					Reason: ReasonCompleted,
+					Message: fmt.Sprintf(
+						"Ramp-up of backend %s of rule %d completed; it receives its weight %d",
+						backend.ref.SvcNsName.Name,
+						key.RuleIdx,
+						backend.ref.Weight,
+					),
+				})
+
+				continue
+			}
+
+			step := min(int(elapsed/(state.spec.Duration/time.Duration(state.spec.Steps)))+1, state.spec.Steps)
+			if step != backend.step {
+				backend.step = step
+				weightsChanged = true
+			}
+		}
+	}
+
+	for _, change := range changes {
+		r.logger.Info(change.Message, "route", client.ObjectKeyFromObject(change.Route).String())
+	}
+
+	return changes, weightsChanged
+}
+
+// pendingBackend is a backend whose ramp-up didn't start.
+type pendingBackend struct {
+	ref  graph.BackendRef
+	spec Spec
+}
+
+// pendingBackends returns the backends whose ramp-up didn't start, so that their readiness is checked
+// without holding the lock.
+func (r *Ramper) pendingBackends() map[types.NamespacedName]map[dataplane.BackendWeightKey]pendingBackend {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	pending := make(map[types.NamespacedName]map[dataplane.BackendWeightKey]pendingBackend)
+
+	for nsName, state := range r.routes {
+		for key, backend := range state.ramping {
+			if backend.step != 0 {
+				continue
+			}
+
+			if pending[nsName] == nil {
+				pending[nsName] = make(map[dataplane.BackendWeightKey]pendingBackend)
+			}
+			pending[nsName][key] = pendingBackend{ref: backend.ref, spec: state.spec}
+		}
+	}
+
+	return pending
+}
+
+// checkReady returns an error if the endpoints of the backend are not ready, or don't respond to the health probe.
+func (r *Ramper) checkReady(ctx context.Context, ref graph.BackendRef, spec Spec) error {
+	// the static endpoints are not resolved from the EndpointSlices
+	if ref.IsStaticBackend() {
+		return nil
+	}
+
+	endpoints, err := r.resolver.Resolve(
+		ctx,
+		r.logger,
+		ref.SvcNsName,
+		ref.ServicePort,
+		[]discoveryV1.AddressType{discoveryV1.AddressTypeIPv4, discoveryV1.AddressTypeIPv6},
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(endpoints) == 0 {
+		return fmt.Errorf("no ready endpoints found for Service %s", ref.SvcNsName)
+	}
+
+	if spec.HealthPath == "" {
+		return nil
+	}
+
+	for _, endpoint := range endpoints {
+		if endpoint.Resolve {
+			continue
+		}
+
+		if err := r.prober.Probe(ctx, endpoint.Address, endpoint.Port, spec.HealthPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Ramper) logInvalidSpecs(gr *graph.Graph) {
+	for _, route := range gr.Routes {
+		if route.Source == nil || !route.Valid {
+			continue
+		}
+
+		if _, exists, err := ParseSpec(route.Source.GetAnnotations()); exists && err != nil {
+			r.logger.Error(
+				err,
+				"Invalid ramp-up annotations",
+				"route", client.ObjectKeyFromObject(route.Source).String(),
+			)
+		}
+	}
+}
+
+// WeightOverrides returns the weights of the ramping backends of the Routes, keyed by the NamespacedName
+// of the Route. The backends whose ramp-up didn't start have weight 0.
+func (r *Ramper) WeightOverrides() map[types.NamespacedName]dataplane.BackendWeights {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	overrides := make(map[types.NamespacedName]dataplane.BackendWeights)
+
+	for nsName, state := range r.routes {
+		if len(state.ramping) == 0 {
+			continue
+		}
+
+		weights := make(dataplane.BackendWeights, len(state.ramping))
+		for key, backend := range state.ramping {
+			weights[key] = stepWeight(backend.ref.Weight, backend.step, state.spec.Steps)
+		}
+
+		overrides[nsName] = weights
+	}
+
+	return overrides
+}
+
+// stepWeight returns the weight of a backend at the step of its ramp-up. A ramping backend receives
+// at least weight 1, so that it receives traffic from the first step.
+func stepWeight(weight int32, step, steps int) int32 {
+	if step == 0 {
+		return 0
+	}
+
+	return max(1, int32(int64(weight)*int64(step)/int64(steps)))
+}
+
+// routeBackends returns the valid backends of the Route that receive traffic.
+func routeBackends(route *graph.L7Route) map[dataplane.BackendWeightKey]graph.BackendRef {
+	backends := make(map[dataplane.BackendWeightKey]graph.BackendRef)
+
+	for idx, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			upstream := ref.ServicePortReference()
+			if upstream == "" || ref.IsMirrorBackend || ref.Weight == 0 {
+				continue
+			}
+
+			backends[dataplane.BackendWeightKey{UpstreamName: upstream, RuleIdx: idx}] = ref
+		}
+	}
+
+	return backends
+}
+
+func keySet(backends map[dataplane.BackendWeightKey]graph.BackendRef) map[dataplane.BackendWeightKey]struct{} {
+	keys := make(map[dataplane.BackendWeightKey]struct{}, len(backends))
+	for key := range backends {
+		keys[key] = struct{}{}
+	}
+
+	return keys
+}
+
+func compareKeys(a, b dataplane.BackendWeightKey) int {
+	if a.RuleIdx != b.RuleIdx {
+		return a.RuleIdx - b.RuleIdx
+	}
+
+	return strings.Compare(a.UpstreamName, b.UpstreamName)
+}
+
+func compareNsNames(a, b types.NamespacedName) int {
+	return strings.Compare(a.String(), b.String())
+}
@@ -0,0 +1,242 @@
+package rampup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver/resolverfakes"
+)
+
+// fakeProber is a Prober that returns a fixed error. The counterfeiter fake can't be used
+// in the tests of this package because it imports this package.
+type fakeProber struct {
+	err   error
+	paths []string
+}
+
+func (f *fakeProber) Probe(_ context.Context, _ string, _ int32, path string) error {
+	f.paths = append(f.paths, path)
+	return f.err
+}
+
+var routeNsName = types.NamespacedName{Namespace: "test", Name: "route"}
+
+func backendRef(name string, weight int32) graph.BackendRef {
+	return graph.BackendRef{
+		SvcNsName:   types.NamespacedName{Namespace: "test", Name: name},
+		ServicePort: v1.ServicePort{Port: 80},
+		Weight:      weight,
+		Valid:       true,
+	}
+}
+
+func createGraph(annotations map[string]string, rules ...[]graph.BackendRef) *graph.Graph {
+	routeRules := make([]graph.RouteRule, 0, len(rules))
+	for _, refs := range rules {
+		routeRules = append(routeRules, graph.RouteRule{BackendRefs: refs})
+	}
+
+	route := &graph.L7Route{
+		Source: &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   routeNsName.Namespace,
+				Name:        routeNsName.Name,
+				Annotations: annotations,
+			},
+		},
+		RouteType: graph.RouteTypeHTTP,
+		Valid:     true,
+		Spec:      graph.L7RouteSpec{Rules: routeRules},
+	}
+
+	return &graph.Graph{
+		Routes: map[graph.RouteKey]*graph.L7Route{
+			graph.CreateRouteKey(route.Source): route,
+		},
+	}
+}
+
+func TestRamper(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	annotations := map[string]string{
+		DurationAnnotation:   "4m",
+		StepsAnnotation:      "4",
+		HealthPathAnnotation: "/healthz",
+	}
+
+	fakeResolver := &resolverfakes.FakeServiceResolver{}
+	prober := &fakeProber{}
+	ramper := NewRamper(logr.Discard(), fakeResolver, prober)
+
+	// the backends of a Route that is observed for the first time are established
+	ramper.Observe(createGraph(annotations, []graph.BackendRef{backendRef("stable", 10)}))
+	g.Expect(ramper.WeightOverrides()).To(BeEmpty())
+
+	gr := createGraph(annotations, []graph.BackendRef{backendRef("stable", 10), backendRef("new", 10)})
+	ramper.Observe(gr)
+
+	newKey := dataplane.BackendWeightKey{UpstreamName: "test_new_80", RuleIdx: 0}
+	g.Expect(ramper.WeightOverrides()).To(Equal(map[types.NamespacedName]dataplane.BackendWeights{
+		routeNsName: {newKey: 0},
+	}))
+
+	start := time.Now()
+
+	// the new backend has no ready endpoints
+	changes, weightsChanged := ramper.Advance(t.Context(), gr, start)
+	g.Expect(changes).To(BeEmpty())
+	g.Expect(weightsChanged).To(BeFalse())
+
+	// the endpoints of the new backend don't respond to the health probe
+	fakeResolver.ResolveReturns([]resolver.Endpoint{{Address: "10.0.0.1", Port: 8080}}, nil)
+	prober.err = errors.New("unhealthy")
+
+	changes, weightsChanged = ramper.Advance(t.Context(), gr, start)
+	g.Expect(changes).To(BeEmpty())
+	g.Expect(weightsChanged).To(BeFalse())
+	g.Expect(prober.paths).To(ConsistOf("/healthz"))
+
+	prober.err = nil
+
+	changes, weightsChanged = ramper.Advance(t.Context(), gr, start)
+	g.Expect(weightsChanged).To(BeTrue())
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].Reason).To(Equal(ReasonStarted))
+	g.Expect(ramper.WeightOverrides()[routeNsName]).To(Equal(dataplane.BackendWeights{newKey: 2}))
+
+	// the step doesn't change within its duration
+	changes, weightsChanged = ramper.Advance(t.Context(), gr, start.Add(30*time.Second))
+	g.Expect(changes).To(BeEmpty())
+	g.Expect(weightsChanged).To(BeFalse())
+
+	changes, weightsChanged = ramper.Advance(t.Context(), gr, start.Add(2*time.Minute))
+	g.Expect(changes).To(BeEmpty())
+	g.Expect(weightsChanged).To(BeTrue())
+	g.Expect(ramper.WeightOverrides()[routeNsName]).To(Equal(dataplane.BackendWeights{newKey: 7}))
+
+	changes, weightsChanged = ramper.Advance(t.Context(), gr, start.Add(4*time.Minute))
+	g.Expect(weightsChanged).To(BeTrue())
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].Reason).To(Equal(ReasonCompleted))
+	g.Expect(ramper.WeightOverrides()).To(BeEmpty())
+
+	// once the Route loses the annotations, it's no longer tracked
+	ramper.Observe(createGraph(nil, []graph.BackendRef{backendRef("stable", 10)}))
+	ramper.Observe(createGraph(nil, []graph.BackendRef{backendRef("stable", 10), backendRef("other", 10)}))
+	g.Expect(ramper.WeightOverrides()).To(BeEmpty())
+}
+
+func TestRamper_Observe(t *testing.T) {
+	t.Parallel()
+
+	annotations := map[string]string{DurationAnnotation: "1m"}
+
+	tests := []struct {
+		expOverrides map[types.NamespacedName]dataplane.BackendWeights
+		name         string
+		next         [][]graph.BackendRef
+	}{
+		{
+			name: "new backend of a new rule is established",
+			next: [][]graph.BackendRef{
+				{backendRef("stable", 10)},
+				{backendRef("new", 10)},
+			},
+			expOverrides: map[types.NamespacedName]dataplane.BackendWeights{},
+		},
+		{
+			name: "new backend replaces the established backends",
+			next: [][]graph.BackendRef{
+				{backendRef("new", 10)},
+			},
+			expOverrides: map[types.NamespacedName]dataplane.BackendWeights{},
+		},
+		{
+			name: "new backends with weight 0 and invalid backends are ignored",
+			next: [][]graph.BackendRef{
+				{backendRef("stable", 10), backendRef("zero", 0), {Weight: 10}},
+			},
+			expOverrides: map[types.NamespacedName]dataplane.BackendWeights{},
+		},
+		{
+			name: "new backend is held at weight 0",
+			next: [][]graph.BackendRef{
+				{backendRef("stable", 10), backendRef("new", 10)},
+			},
+			expOverrides: map[types.NamespacedName]dataplane.BackendWeights{
+				routeNsName: {{UpstreamName: "test_new_80", RuleIdx: 0}: 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			ramper := NewRamper(logr.Discard(), &resolverfakes.FakeServiceResolver{}, &fakeProber{})
+
+			ramper.Observe(createGraph(annotations, []graph.BackendRef{backendRef("stable", 10)}))
+			ramper.Observe(createGraph(annotations, test.next...))
+
+			g.Expect(ramper.WeightOverrides()).To(Equal(test.expOverrides))
+		})
+	}
+}
+
+func TestRamper_PromotesRampingBackends(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	annotations := map[string]string{DurationAnnotation: "1m"}
+	ramper := NewRamper(logr.Discard(), &resolverfakes.FakeServiceResolver{}, &fakeProber{})
+
+	ramper.Observe(createGraph(annotations, []graph.BackendRef{backendRef("stable", 10)}))
+	ramper.Observe(createGraph(annotations, []graph.BackendRef{backendRef("stable", 10), backendRef("new", 10)}))
+	g.Expect(ramper.WeightOverrides()).To(HaveLen(1))
+
+	// once the established backend is removed, the ramping backend must receive the traffic
+	ramper.Observe(createGraph(annotations, []graph.BackendRef{backendRef("new", 10)}))
+	g.Expect(ramper.WeightOverrides()).To(BeEmpty())
+}
+
+func TestRamper_InvalidAnnotations(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	annotations := map[string]string{DurationAnnotation: "invalid"}
+	ramper := NewRamper(logr.Discard(), &resolverfakes.FakeServiceResolver{}, &fakeProber{})
+
+	ramper.Observe(createGraph(annotations, []graph.BackendRef{backendRef("stable", 10)}))
+	gr := createGraph(annotations, []graph.BackendRef{backendRef("stable", 10), backendRef("new", 10)})
+	ramper.Observe(gr)
+
+	changes, weightsChanged := ramper.Advance(t.Context(), gr, time.Now())
+	g.Expect(changes).To(BeEmpty())
+	g.Expect(weightsChanged).To(BeFalse())
+	g.Expect(ramper.WeightOverrides()).To(BeEmpty())
+}
+
+func TestStepWeight(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(stepWeight(10, 0, 4)).To(BeZero())
+	g.Expect(stepWeight(10, 1, 4)).To(Equal(int32(2)))
+	g.Expect(stepWeight(1, 1, 4)).To(Equal(int32(1)))
+	g.Expect(stepWeight(10, 4, 4)).To(Equal(int32(10)))
+}
@@ -0,0 +1,101 @@
+package rampup
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DurationAnnotation is the annotation of a Route with the duration of the ramp-up of its new backends,
+	// for example 5m. The backends of a Route are ramped up only if the Route has this annotation.
+	DurationAnnotation = "gateway.nginx.org/ramp-up-duration"
+	// StepsAnnotation is the annotation of a Route with the number of steps in which the weights of its new
+	// backends are increased. Defaults to DefaultSteps.
+	StepsAnnotation = "gateway.nginx.org/ramp-up-steps"
+	// HealthPathAnnotation is the annotation of a Route with the HTTP path that every endpoint of a new backend
+	// must respond to with a 2xx or 3xx status code before the ramp-up of the backend starts.
+	HealthPathAnnotation = "gateway.nginx.org/ramp-up-health-path"
+)
+
+const (
+	// DefaultSteps is the default number of steps of the ramp-up.
+	DefaultSteps = 5
+	// MaxSteps is the maximum number of steps of the ramp-up.
+	MaxSteps = 20
+	// MinStepDuration is the minimum duration of a step of the ramp-up, so that NGINX is not reconfigured
+	// too often.
+	MinStepDuration = 10 * time.Second
+	// MaxDuration is the maximum duration of the ramp-up.
+	MaxDuration = 24 * time.Hour
+)
+
+// Spec is the specification of the ramp-up of the new backends of a Route.
+type Spec struct {
+	// HealthPath is the path of the health probe of the endpoints. If empty, the endpoints are not probed.
+	HealthPath string
+	// Duration is the duration of the ramp-up.
+	Duration time.Duration
+	// Steps is the number of steps of the ramp-up.
+	Steps int
+}
+
+// ParseSpec parses the Spec of the ramp-up from the annotations of a Route. It returns false if the Route
+// doesn't have the DurationAnnotation.
+func ParseSpec(annotations map[string]string) (Spec, bool, error) {
+	value, exists := annotations[DurationAnnotation]
+	if !exists {
+		return Spec{}, false, nil
+	}
+
+	var errs []error
+
+	spec := Spec{Steps: DefaultSteps}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 || duration > MaxDuration {
+		errs = append(
+			errs,
+			fmt.Errorf("%s must be a positive duration of at most %s, got %q", DurationAnnotation, MaxDuration, value),
+		)
+	} else {
+		spec.Duration = duration
+	}
+
+	if value, exists := annotations[StepsAnnotation]; exists {
+		steps, err := strconv.Atoi(value)
+		if err != nil || steps < 1 || steps > MaxSteps {
+			errs = append(errs, fmt.Errorf("%s must be a number between 1 and %d, got %q", StepsAnnotation, MaxSteps, value))
+		} else {
+			spec.Steps = steps
+		}
+	}
+
+	if value, exists := annotations[HealthPathAnnotation]; exists {
+		if !strings.HasPrefix(value, "/") || strings.ContainsAny(value, " ?#") {
+			errs = append(errs, fmt.Errorf("%s must be an absolute path, got %q", HealthPathAnnotation, value))
+		} else {
+			spec.HealthPath = value
+		}
+	}
+
+	if len(errs) == 0 && spec.Duration/time.Duration(spec.Steps) < MinStepDuration {
+		errs = append(
+			errs,
+			fmt.Errorf(
+				"every step of the ramp-up must be at least %s; increase %s or decrease %s",
+				MinStepDuration,
+				DurationAnnotation,
+				StepsAnnotation,
+			),
+		)
+	}
+
+	if len(errs) > 0 {
+		return Spec{}, true, errors.Join(errs...)
+	}
+
+	return spec, true, nil
+}
@@ -0,0 +1,87 @@
+package rampup
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expErr      string
+		expSpec     Spec
+		expExists   bool
+	}{
+		{
+			name:        "no duration",
+			annotations: map[string]string{StepsAnnotation: "4"},
+		},
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				DurationAnnotation:   "4m",
+				StepsAnnotation:      "4",
+				HealthPathAnnotation: "/healthz",
+			},
+			expSpec: Spec{
+				HealthPath: "/healthz",
+				Duration:   4 * time.Minute,
+				Steps:      4,
+			},
+			expExists: true,
+		},
+		{
+			name:        "default steps",
+			annotations: map[string]string{DurationAnnotation: "5m"},
+			expSpec: Spec{
+				Duration: 5 * time.Minute,
+				Steps:    DefaultSteps,
+			},
+			expExists: true,
+		},
+		{
+			name: "steps too short",
+			annotations: map[string]string{
+				DurationAnnotation: "1m",
+				StepsAnnotation:    "10",
+			},
+			expErr: "every step of the ramp-up must be at least 10s; increase gateway.nginx.org/ramp-up-duration " +
+				"or decrease gateway.nginx.org/ramp-up-steps",
+			expExists: true,
+		},
+		{
+			name: "invalid annotations",
+			annotations: map[string]string{
+				DurationAnnotation:   "48h",
+				StepsAnnotation:      "0",
+				HealthPathAnnotation: "healthz",
+			},
+			expErr: "gateway.nginx.org/ramp-up-duration must be a positive duration of at most 24h0m0s, got \"48h\"\n" +
+				"gateway.nginx.org/ramp-up-steps must be a number between 1 and 20, got \"0\"\n" +
+				"gateway.nginx.org/ramp-up-health-path must be an absolute path, got \"healthz\"",
+			expExists: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			spec, exists, err := ParseSpec(test.annotations)
+			g.Expect(exists).To(Equal(test.expExists))
+			if test.expErr != "" {
+				g.Expect(err).To(MatchError(test.expErr))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(spec).To(Equal(test.expSpec))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/governor"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+)
+
+// reloadGovernorEvent makes the event handler regenerate the configuration when a deferred reload
+// of an nginx Deployment is due.
+type reloadGovernorEvent struct {
+	deployment types.NamespacedName
+}
+
+// newReloadGovernor creates a reload governor that sends a reloadGovernorEvent to the event loop when
+// a deferred reload is due. The Notify function of the Config is replaced.
+func newReloadGovernor(ctx context.Context, cfg governor.Config, eventCh chan<- interface{}) *governor.Governor {
+	cfg.Notify = func(deployment types.NamespacedName) {
+		go func() {
+			select {
+			case eventCh <- &reloadGovernorEvent{deployment: deployment}:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	return governor.New(cfg)
+}
+
+// admitReload returns whether the configuration of the Gateway is applied now, or its reload is deferred
+// by the reload governor.
+func (h *eventHandlerImpl) admitReload(logger logr.Logger, gw *graph.Gateway, conf dataplane.Configuration) bool {
+	if h.cfg.reloadGovernor == nil {
+		return true
+	}
+
+	decision := h.cfg.reloadGovernor.Admit(gw.DeploymentName, conf)
+	if !decision.Apply {
+		logger.V(1).Info(
+			"Deferring the reload of nginx to enforce the minimum interval between reloads",
+			"gateway", gw.Source.GetName(),
+			"change", decision.Change,
+		)
+	}
+
+	return decision.Apply
+}
+
+// updateGovernedNginxConf updates the nginx conf files of the Deployment, and records in the reload governor
+// whether the update reloaded nginx. The deployment FileLock MUST already be locked before calling this function.
+func (h *eventHandlerImpl) updateGovernedNginxConf(
+	gw *graph.Gateway,
+	deployment *agent.Deployment,
+	conf dataplane.Configuration,
+	volumeMounts []v1.VolumeMount,
+) []agent.File {
+	_, prevVersion := deployment.GetFileOverviews()
+	files := h.updateNginxConf(deployment, conf, volumeMounts)
+	_, version := deployment.GetFileOverviews()
+
+	if h.cfg.reloadGovernor != nil {
+		h.cfg.reloadGovernor.Applied(gw.DeploymentName, conf, version != prevVersion)
+	}
+
+	return files
+}
+
+// reloadDue returns whether a deferred reload became due since the last call, and resets it.
+func (h *eventHandlerImpl) reloadDue() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	due := h.deferredReloadDue
+	h.deferredReloadDue = false
+
+	return due
+}
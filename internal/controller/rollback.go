@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
+)
+
+// configRollbackAnnotation is the annotation of a Gateway that rolls the nginx configuration of the Gateway back
+// to the previous version, when set to "true". While the Gateway has the annotation, the rolled back configuration
+// is held and changes to the configuration are not sent to nginx. Removing the annotation releases the rollback,
+// and the latest configuration is sent to nginx.
+//
+// The previous configuration is kept by the control plane in memory, so it can't be rolled back to
+// after the control plane restarts. The rollback is not supported for Gateways that run nginx without the agent.
+const configRollbackAnnotation = "gateway.nginx.org/config-rollback"
+
+// rollbackRequested returns true if the operator requested the rollback of the nginx configuration of the Gateway.
+func rollbackRequested(gateway *gatewayv1.Gateway) bool {
+	return gateway.GetAnnotations()[configRollbackAnnotation] == "true"
+}
+
+// rollbackNginxConf rolls the nginx configuration of the Gateway back to the previous version,
+// or keeps holding the configuration if it is already rolled back, and enqueues the status update of the Gateway.
+func (h *eventHandlerImpl) rollbackNginxConf(
+	ctx context.Context,
+	logger logr.Logger,
+	gw *graph.Gateway,
+	deployment *agent.Deployment,
+	volumeMounts []v1.VolumeMount,
+) {
+	deployment.FileLock.Lock()
+	err := h.cfg.nginxUpdater.RollbackConfig(deployment, volumeMounts)
+	rolledBack := deployment.IsRolledBack()
+	deployment.FileLock.Unlock()
+
+	if err != nil {
+		err = fmt.Errorf("failed to roll back nginx configuration: %w", err)
+	} else {
+		logger.V(1).Info(
+			"Holding rolled back nginx configuration",
+			"namespace", gw.Source.GetNamespace(),
+			"name", gw.Source.GetName(),
+		)
+		err = errcodes.Wrap(errcodes.NginxConfigApplyFailed, deployment.GetLatestConfigError())
+
+		triggers := []string{fmt.Sprintf("rollback requested with the %s annotation", configRollbackAnnotation)}
+		h.recordConfigVersion(contextWithConfigTriggers(ctx, triggers), gw, deployment.GetFiles(), err)
+	}
+
+	obj := &status.QueueObject{
+		UpdateType:       status.UpdateAll,
+		Error:            err,
+		WithheldFeatures: deployment.GetWithheldFeatures(),
+		Deployment:       gw.DeploymentName,
+		RolledBack:       rolledBack,
+	}
+	h.cfg.statusQueue.Enqueue(obj)
+}
+
+// releaseRollback releases the rolled back nginx configuration of the Gateway, if any, so that the latest
+// configuration replaces it.
+// The deployment FileLock MUST already be locked before calling this function.
+func releaseRollback(logger logr.Logger, gw *graph.Gateway, deployment *agent.Deployment) {
+	if deployment.ReleaseRollback() {
+		logger.Info(
+			"Released rolled back nginx configuration",
+			"namespace", gw.Source.GetNamespace(),
+			"name", gw.Source.GetName(),
+		)
+	}
+}
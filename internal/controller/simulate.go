@@ -0,0 +1,501 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/agent"
+	ngxcfg "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config"
+	ngxvalidation "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/validation"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/status"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller/index"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+// SimulationConfig is the configuration of a simulation.
+type SimulationConfig struct {
+	// Logger is the logger.
+	Logger logr.Logger
+	// GatewayCtlrName is the name of the Gateway controller.
+	GatewayCtlrName string
+	// GatewayClassName is the name of the GatewayClass of NGF. If the manifests don't contain the GatewayClass,
+	// the simulation adds it.
+	GatewayClassName string
+	// Plus indicates if the configuration is generated for NGINX Plus.
+	Plus bool
+	// ExperimentalFeatures indicates if the experimental features of Gateway API are enabled.
+	ExperimentalFeatures bool
+	// FIPS indicates if FIPS mode is enabled.
+	FIPS bool
+}
+
+// SimulationResult is the result of a simulation.
+type SimulationResult struct {
+	// Configs are the NGINX configuration files of the Gateways of NGF.
+	Configs map[types.NamespacedName][]agent.File
+	// Gateways are the Gateways of NGF, by their NamespacedName.
+	Gateways map[types.NamespacedName]*gatewayv1.Gateway
+	// Statuses are the resources with the statuses that NGF would write, sorted by kind, namespace, and name.
+	Statuses []client.Object
+	// Skipped are the resources of the manifests that NGF doesn't process, in the form <kind> <namespace>/<name>.
+	Skipped []string
+}
+
+// Simulate builds the graph, the NGINX configuration, and the statuses of the resources in the manifests without
+// a cluster, the same way the control plane would do it.
+//
+// The simulation has the following limitations:
+//   - The NGINX configuration is not validated by NGINX.
+//   - The addresses of the Gateways are not known, so the statuses don't include them.
+//   - The Services reference their endpoints only if the manifests contain their EndpointSlices.
+func Simulate(ctx context.Context, cfg SimulationConfig, objects []client.Object) (SimulationResult, error) {
+	mustExtractGVK := kinds.NewMustExtractGKV(scheme)
+
+	supported := simulatedGVKs(mustExtractGVK, cfg.ExperimentalFeatures)
+
+	if !slices.ContainsFunc(objects, func(obj client.Object) bool {
+		gc, ok := obj.(*gatewayv1.GatewayClass)
+		return ok && gc.Name == cfg.GatewayClassName
+	}) {
+		objects = append(objects, &gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: cfg.GatewayClassName},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: gatewayv1.GatewayController(cfg.GatewayCtlrName),
+			},
+		})
+	}
+
+	genericValidator := ngxvalidation.GenericValidator{}
+	featureFlags := graph.FeatureFlags{
+		Plus:         cfg.Plus,
+		Experimental: cfg.ExperimentalFeatures,
+		FIPS:         cfg.FIPS,
+	}
+
+	processor := state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+		GatewayCtlrName:  cfg.GatewayCtlrName,
+		GatewayClassName: cfg.GatewayClassName,
+		Logger:           cfg.Logger.WithName("changeProcessor"),
+		Validators: validation.Validators{
+			HTTPFieldsValidator: ngxvalidation.HTTPValidator{},
+			GenericValidator:    genericValidator,
+			PolicyValidator:     createPolicyManager(mustExtractGVK, genericValidator, cfg.Plus),
+		},
+		EventRecorder:  &record.FakeRecorder{},
+		MustExtractGVK: mustExtractGVK,
+		FeatureFlags:   featureFlags,
+	})
+
+	var result SimulationResult
+	var handled []client.Object
+	var endpointSlices []client.Object
+
+	for _, obj := range objects {
+		gvk := mustExtractGVK(obj)
+		if !supported.Has(gvk) {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s %s", gvk.Kind, objectName(obj)))
+			continue
+		}
+
+		if slice, ok := obj.(*discoveryV1.EndpointSlice); ok {
+			endpointSlices = append(endpointSlices, defaultEndpointSlice(slice))
+		}
+
+		processor.CaptureUpsertChange(obj)
+		handled = append(handled, obj)
+	}
+
+	gr := processor.Process()
+	if gr == nil {
+		gr = &graph.Graph{}
+	}
+
+	endpointsClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(endpointSlices...).
+		WithIndex(&discoveryV1.EndpointSlice{}, index.KubernetesServiceNameIndexField, index.ServiceNameIndexFunc).
+		Build()
+	serviceResolver := resolver.NewServiceResolverImpl(endpointsClient)
+
+	generator := ngxcfg.NewGeneratorImpl(cfg.Plus, cfg.FIPS, nil, cfg.Logger.WithName("generator"))
+
+	result.Configs = make(map[types.NamespacedName][]agent.File, len(gr.Gateways))
+	result.Gateways = make(map[types.NamespacedName]*gatewayv1.Gateway, len(gr.Gateways))
+
+	transitionTime := metav1.Now()
+	reqs := status.PrepareGatewayClassRequests(gr.GatewayClass, gr.IgnoredGatewayClasses, transitionTime, nil)
+
+	for nsname, gw := range gr.Gateways {
+		result.Gateways[nsname] = gw.Source
+		reqs = append(reqs, status.PrepareGatewayRequests(gw, transitionTime, nil, gw.LatestReloadResult)...)
+
+		if !gw.Valid {
+			continue
+		}
+
+		conf := dataplane.BuildConfiguration(ctx, cfg.Logger, gr, gw, serviceResolver, cfg.Plus)
+		dataplane.RemoveUnreferencedUpstreams(&conf)
+		result.Configs[nsname] = generator.Generate(conf)
+	}
+
+	reqs = append(reqs, status.PrepareRouteRequests(gr.L4Routes, gr.Routes, transitionTime, cfg.GatewayCtlrName, nil)...)
+	reqs = append(reqs, status.PrepareBackendTLSPolicyRequests(
+		gr.BackendTLSPolicies,
+		transitionTime,
+		cfg.GatewayCtlrName,
+	)...)
+	reqs = append(reqs, status.PrepareNGFPolicyRequests(gr.NGFPolicies, transitionTime, cfg.GatewayCtlrName)...)
+	reqs = append(reqs, status.PrepareSnippetsFilterRequests(
+		gr.SnippetsFilters,
+		transitionTime,
+		cfg.GatewayCtlrName,
+	)...)
+
+	result.Statuses = applyStatusRequests(mustExtractGVK, handled, reqs)
+
+	return result, nil
+}
+
+// simulatedGVKs returns the GroupVersionKinds of the resources that the simulation processes.
+// They are the resources that the control plane watches, except for the ones that only exist in a cluster,
+// like the CustomResourceDefinitions.
+func simulatedGVKs(mustExtractGVK kinds.MustExtractGVK, experimental bool) sets.Set[schema.GroupVersionKind] {
+	objs := []client.Object{
+		&gatewayv1.GatewayClass{},
+		&gatewayv1.Gateway{},
+		&gatewayv1.HTTPRoute{},
+		&gatewayv1.GRPCRoute{},
+		&gatewayv1.BackendTLSPolicy{},
+		&gatewayv1beta1.ReferenceGrant{},
+		&apiv1.Namespace{},
+		&apiv1.Service{},
+		&apiv1.Secret{},
+		&apiv1.ConfigMap{},
+		&discoveryV1.EndpointSlice{},
+		&inference.InferencePool{},
+		&ngfAPIv1alpha1.ClientSettingsPolicy{},
+		&ngfAPIv1alpha1.UpstreamSettingsPolicy{},
+		&ngfAPIv1alpha1.SnippetsFilter{},
+		&ngfAPIv1alpha1.Backend{},
+		&ngfAPIv1alpha2.ObservabilityPolicy{},
+		&ngfAPIv1alpha2.NginxProxy{},
+	}
+
+	if experimental {
+		objs = append(objs, &gatewayv1alpha2.TLSRoute{})
+	}
+
+	gvks := sets.New[schema.GroupVersionKind]()
+	for _, obj := range objs {
+		gvks.Insert(mustExtractGVK(obj))
+	}
+
+	return gvks
+}
+
+// defaultEndpointSlice returns a copy of the EndpointSlice with the defaults that the API server sets, which
+// the resolver relies on.
+func defaultEndpointSlice(slice *discoveryV1.EndpointSlice) *discoveryV1.EndpointSlice {
+	defaulted := slice.DeepCopy()
+
+	for i := range defaulted.Ports {
+		if defaulted.Ports[i].Name == nil {
+			defaulted.Ports[i].Name = helpers.GetPointer("")
+		}
+	}
+
+	return defaulted
+}
+
+// applyStatusRequests sets the statuses of the requests on copies of the resources and returns the copies
+// sorted by kind, namespace, and name.
+func applyStatusRequests(
+	mustExtractGVK kinds.MustExtractGVK,
+	objects []client.Object,
+	reqs []status.UpdateRequest,
+) []client.Object {
+	type key struct {
+		gvk    schema.GroupVersionKind
+		nsname types.NamespacedName
+	}
+
+	byKey := make(map[key]client.Object, len(objects))
+	for _, obj := range objects {
+		byKey[key{gvk: mustExtractGVK(obj), nsname: client.ObjectKeyFromObject(obj)}] = obj
+	}
+
+	updated := make(map[key]client.Object, len(reqs))
+
+	for _, req := range reqs {
+		k := key{gvk: mustExtractGVK(req.ResourceType), nsname: req.NsName}
+
+		obj, ok := updated[k]
+		if !ok {
+			source, exists := byKey[k]
+			if !exists {
+				continue
+			}
+
+			obj, ok = source.DeepCopyObject().(client.Object)
+			if !ok {
+				continue
+			}
+			obj.GetObjectKind().SetGroupVersionKind(k.gvk)
+			updated[k] = obj
+		}
+
+		req.Setter(obj)
+	}
+
+	statuses := make([]client.Object, 0, len(updated))
+	for _, obj := range updated {
+		statuses = append(statuses, obj)
+	}
+
+	slices.SortFunc(statuses, func(a, b client.Object) int {
+		return strings.Compare(objectID(a), objectID(b))
+	})
+
+	return statuses
+}
+
+// objectID returns the ID of the resource in the form <kind> <namespace>/<name>, or <kind> <name> if the resource
+// is cluster-scoped.
+func objectID(obj client.Object) string {
+	return obj.GetObjectKind().GroupVersionKind().Kind + " " + objectName(obj)
+}
+
+func objectName(obj client.Object) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+
+	return client.ObjectKeyFromObject(obj).String()
+}
+
+// LoadManifests loads the resources from the YAML and JSON files in the directory and its subdirectories.
+// The resources without a namespace are put in the default namespace, unless they are cluster-scoped.
+// The resources of the kinds that NGF doesn't know are returned as unstructured objects.
+func LoadManifests(dir string) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var objects []client.Object
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		objs, err := loadManifestFile(decoder, path)
+		if err != nil {
+			return fmt.Errorf("error loading %s: %w", path, err)
+		}
+
+		objects = append(objects, objs...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func loadManifestFile(decoder runtime.Decoder, path string) ([]client.Object, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var objects []client.Object
+
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(f))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			return nil, err
+		}
+
+		// an empty document or a document with only comments
+		if typeMeta.Kind == "" && typeMeta.APIVersion == "" {
+			continue
+		}
+
+		obj, err := decodeManifest(decoder, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		if obj.GetNamespace() == "" && !isClusterScoped(obj) {
+			obj.SetNamespace(metav1.NamespaceDefault)
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+func decodeManifest(decoder runtime.Decoder, doc []byte) (client.Object, error) {
+	runtimeObj, gvk, err := decoder.Decode(doc, nil, nil)
+	if err != nil {
+		if !runtime.IsNotRegisteredError(err) {
+			return nil, err
+		}
+
+		unstructuredObj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &unstructuredObj.Object); err != nil {
+			return nil, err
+		}
+
+		return unstructuredObj, nil
+	}
+
+	obj, ok := runtimeObj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unsupported resource %s", gvk)
+	}
+
+	return obj, nil
+}
+
+func isClusterScoped(obj client.Object) bool {
+	switch obj.(type) {
+	case *gatewayv1.GatewayClass, *apiv1.Namespace:
+		return true
+	default:
+		return false
+	}
+}
+
+// Problems returns the problems that the statuses report, in the form
+// <kind> <namespace>/<name>: <condition type> <reason>: <message>.
+// A problem is a positive-polarity condition, like Accepted, ResolvedRefs, or Programmed, that is not true.
+func (r SimulationResult) Problems() ([]string, error) {
+	var problems []string
+
+	for _, obj := range r.Statuses {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("error converting %s: %w", objectID(obj), err)
+		}
+
+		for _, cond := range findConditions(content["status"]) {
+			if !positiveConditionTypes.Has(cond.Type) || cond.Status == metav1.ConditionTrue {
+				continue
+			}
+
+			problems = append(
+				problems,
+				fmt.Sprintf("%s: %s %s: %s", objectID(obj), cond.Type, cond.Reason, cond.Message),
+			)
+		}
+	}
+
+	slices.Sort(problems)
+
+	return problems, nil
+}
+
+var positiveConditionTypes = sets.New(
+	string(gatewayv1.GatewayConditionAccepted),
+	string(gatewayv1.GatewayConditionProgrammed),
+	string(gatewayv1.RouteConditionResolvedRefs),
+)
+
+// findConditions returns the conditions of the status and of its nested statuses, like the statuses of
+// the listeners of a Gateway or of the parents of a route.
+func findConditions(value any) []metav1.Condition {
+	var conditions []metav1.Condition
+
+	switch v := value.(type) {
+	case map[string]any:
+		for field, nested := range v {
+			if field != "conditions" {
+				conditions = append(conditions, findConditions(nested)...)
+				continue
+			}
+
+			items, ok := nested.([]any)
+			if !ok {
+				continue
+			}
+
+			for _, item := range items {
+				content, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+
+				var cond metav1.Condition
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &cond); err != nil {
+					continue
+				}
+
+				conditions = append(conditions, cond)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			conditions = append(conditions, findConditions(item)...)
+		}
+	}
+
+	return conditions
+}
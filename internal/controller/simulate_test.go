@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const simulationManifests = `# a document with only comments
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: gateway
+spec:
+  gatewayClassName: nginx
+  listeners:
+  - name: http
+    port: 80
+    protocol: HTTP
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: coffee
+spec:
+  parentRefs:
+  - name: gateway
+  hostnames:
+  - cafe.example.com
+  rules:
+  - matches:
+    - path:
+        type: PathPrefix
+        value: /
+    backendRefs:
+    - name: coffee
+      port: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: coffee
+spec:
+  ports:
+  - port: 80
+    targetPort: 8080
+---
+apiVersion: discovery.k8s.io/v1
+kind: EndpointSlice
+metadata:
+  name: coffee-abc
+  labels:
+    kubernetes.io/service-name: coffee
+addressType: IPv4
+endpoints:
+- addresses:
+  - 10.0.0.1
+  conditions:
+    ready: true
+ports:
+- port: 8080
+`
+
+const simulationUnknownManifest = `{
+  "apiVersion": "example.com/v1",
+  "kind": "Barista",
+  "metadata": {"name": "coffee", "namespace": "cafe"}
+}`
+
+func writeManifests(t *testing.T, files map[string]string) string {
+	t.Helper()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		g.Expect(os.MkdirAll(filepath.Dir(path), 0o750)).To(Succeed())
+		g.Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	return dir
+}
+
+func newSimulationConfig() SimulationConfig {
+	return SimulationConfig{
+		Logger:           logr.Discard(),
+		GatewayCtlrName:  "gateway.nginx.org/nginx-gateway-controller",
+		GatewayClassName: "nginx",
+	}
+}
+
+func TestLoadManifests(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := writeManifests(t, map[string]string{
+		"cafe.yaml":          simulationManifests,
+		"staff/barista.json": simulationUnknownManifest,
+		"README.md":          "not a manifest",
+	})
+
+	objects, err := LoadManifests(dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(objects).To(HaveLen(5))
+
+	// the files are walked in lexical order
+	gw, ok := objects[0].(*gatewayv1.Gateway)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(client.ObjectKeyFromObject(gw)).To(Equal(types.NamespacedName{Namespace: "default", Name: "gateway"}))
+
+	g.Expect(objects[1]).To(BeAssignableToTypeOf(&gatewayv1.HTTPRoute{}))
+	g.Expect(objects[2]).To(BeAssignableToTypeOf(&apiv1.Service{}))
+	g.Expect(objects[3]).To(BeAssignableToTypeOf(&discoveryV1.EndpointSlice{}))
+
+	// the kinds that NGF doesn't know are unstructured
+	barista, ok := objects[4].(*unstructured.Unstructured)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(barista.GetKind()).To(Equal("Barista"))
+	g.Expect(barista.GetNamespace()).To(Equal("cafe"))
+}
+
+func TestLoadManifestsInvalid(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := writeManifests(t, map[string]string{
+		"invalid.yaml": "apiVersion: gateway.networking.k8s.io/v1\nkind: Gateway\nspec: [",
+	})
+
+	_, err := LoadManifests(dir)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid.yaml")))
+}
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	objects, err := LoadManifests(writeManifests(t, map[string]string{
+		"cafe.yaml":    simulationManifests,
+		"barista.json": simulationUnknownManifest,
+	}))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	result, err := Simulate(t.Context(), newSimulationConfig(), objects)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(result.Skipped).To(ConsistOf("Barista cafe/coffee"))
+
+	gwNsName := types.NamespacedName{Namespace: "default", Name: "gateway"}
+	g.Expect(result.Gateways).To(HaveKey(gwNsName))
+	g.Expect(result.Configs).To(HaveKey(gwNsName))
+
+	var httpConf string
+	for _, f := range result.Configs[gwNsName] {
+		if f.Meta.GetName() == "/etc/nginx/conf.d/http.conf" {
+			httpConf = string(f.Contents)
+		}
+	}
+	g.Expect(httpConf).To(ContainSubstring("upstream default_coffee_80"))
+	g.Expect(httpConf).To(ContainSubstring("server 10.0.0.1:8080;"))
+	g.Expect(httpConf).To(ContainSubstring("server_name cafe.example.com;"))
+
+	ids := make([]string, 0, len(result.Statuses))
+	for _, obj := range result.Statuses {
+		ids = append(ids, objectID(obj))
+	}
+	g.Expect(ids).To(Equal([]string{
+		"Gateway default/gateway",
+		"GatewayClass nginx",
+		"HTTPRoute default/coffee",
+	}))
+
+	route, ok := result.Statuses[2].(*gatewayv1.HTTPRoute)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(route.Status.Parents).To(HaveLen(1))
+
+	// the statuses are set on copies of the resources
+	sourceRoute, ok := objects[2].(*gatewayv1.HTTPRoute)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(sourceRoute.Status.Parents).To(BeEmpty())
+
+	problems, err := result.Problems()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(problems).To(BeEmpty())
+}
+
+func TestSimulateProblems(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	manifests := strings.Replace(simulationManifests, "- name: coffee\n      port: 80", "- name: tea\n      port: 80", 1)
+
+	objects, err := LoadManifests(writeManifests(t, map[string]string{"cafe.yaml": manifests}))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	result, err := Simulate(t.Context(), newSimulationConfig(), objects)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	problems, err := result.Problems()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(problems).To(Equal([]string{
+		`HTTPRoute default/coffee: ResolvedRefs BackendNotFound: spec.rules[0].backendRefs[0].name: Not found: "tea"`,
+	}))
+}
+
+func TestSimulateNoGateways(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	result, err := Simulate(t.Context(), newSimulationConfig(), nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(result.Configs).To(BeEmpty())
+	g.Expect(result.Gateways).To(BeEmpty())
+	g.Expect(result.Skipped).To(BeEmpty())
+
+	// the GatewayClass is added, so its status is reported
+	g.Expect(result.Statuses).To(HaveLen(1))
+	g.Expect(objectID(result.Statuses[0])).To(Equal("GatewayClass nginx"))
+}
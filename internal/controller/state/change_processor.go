@@ -8,6 +8,7 @@ import (
 	discoveryV1 "k8s.io/api/discovery/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -64,6 +65,10 @@ type ChangeProcessorConfig struct {
 	GatewayCtlrName string
 	// GatewayClassName is the name of the GatewayClass resource.
 	GatewayClassName string
+	// ClusterDomain is the DNS domain of the cluster, which is used to build the hostnames of the Services.
+	ClusterDomain string
+	// CustomRouteKinds are the registered custom route kinds.
+	CustomRouteKinds graph.CustomRouteKinds
 	// FeaturesFlags holds the feature flags for building the Graph.
 	FeatureFlags graph.FeatureFlags
 }
@@ -102,6 +107,11 @@ func NewChangeProcessorImpl(cfg ChangeProcessorConfig) *ChangeProcessorImpl {
 		NGFPolicies:        make(map[graph.PolicyKey]policies.Policy),
 		SnippetsFilters:    make(map[types.NamespacedName]*ngfAPIv1alpha1.SnippetsFilter),
 		InferencePools:     make(map[types.NamespacedName]*inference.InferencePool),
+		Backends:           make(map[types.NamespacedName]*ngfAPIv1alpha1.Backend),
+		CustomRoutes:       make(map[graph.CustomRouteKey]*unstructured.Unstructured),
+		ClusterDefaultPolicies: make(
+			map[types.NamespacedName]*ngfAPIv1alpha2.ClusterDefaultPolicy,
+		),
 	}
 
 	processor := &ChangeProcessorImpl{
@@ -127,9 +137,21 @@ func NewChangeProcessorImpl(cfg ChangeProcessorConfig) *ChangeProcessorImpl {
 	// Use this object store for all NGF policies
 	commonPolicyObjectStore := newNGFPolicyObjectStore(clusterStore.NGFPolicies, cfg.MustExtractGVK)
 
+	// Use this object store for all custom routes
+	customRouteObjectStore := newCustomRouteObjectStore(clusterStore.CustomRoutes, cfg.MustExtractGVK)
+
+	customRouteObjectTypeCfgs := make([]changeTrackingUpdaterObjectTypeCfg, 0, len(cfg.CustomRouteKinds))
+	for _, routeKind := range cfg.CustomRouteKinds {
+		customRouteObjectTypeCfgs = append(customRouteObjectTypeCfgs, changeTrackingUpdaterObjectTypeCfg{
+			gvk:       routeKind.GroupVersionKind(),
+			store:     customRouteObjectStore,
+			predicate: nil,
+		})
+	}
+
 	trackingUpdater := newChangeTrackingUpdater(
 		cfg.MustExtractGVK,
-		[]changeTrackingUpdaterObjectTypeCfg{
+		append([]changeTrackingUpdaterObjectTypeCfg{
 			{
 				gvk:       cfg.MustExtractGVK(&v1.GatewayClass{}),
 				store:     newObjectStoreMapAdapter(clusterStore.GatewayClasses),
@@ -225,7 +247,19 @@ func NewChangeProcessorImpl(cfg ChangeProcessorConfig) *ChangeProcessorImpl {
 				store:     newObjectStoreMapAdapter(clusterStore.SnippetsFilters),
 				predicate: nil, // we always want to write status to SnippetsFilters so we don't filter them out
 			},
-		},
+			{
+				gvk:   cfg.MustExtractGVK(&ngfAPIv1alpha2.ClusterDefaultPolicy{}),
+				store: newObjectStoreMapAdapter(clusterStore.ClusterDefaultPolicies),
+				// we always want to write status to ClusterDefaultPolicies so we don't filter them out
+				predicate: nil,
+			},
+			{
+				gvk:   cfg.MustExtractGVK(&ngfAPIv1alpha1.Backend{}),
+				store: newObjectStoreMapAdapter(clusterStore.Backends),
+				// Routes can reference a Backend before it exists, so we don't filter out unreferenced Backends
+				predicate: nil,
+			},
+		}, customRouteObjectTypeCfgs...),
 	)
 
 	processor.getAndResetClusterStateChanged = trackingUpdater.getAndResetChangedStatus
@@ -277,8 +311,10 @@ func (c *ChangeProcessorImpl) Process() *graph.Graph {
 		c.cfg.GatewayClassName,
 		c.cfg.PlusSecrets,
 		c.cfg.Validators,
+		c.cfg.CustomRouteKinds,
 		c.cfg.Logger,
 		c.cfg.FeatureFlags,
+		c.cfg.ClusterDomain,
 	)
 
 	return c.latestGraph
@@ -9,6 +9,7 @@ import (
 	discoveryV1 "k8s.io/api/discovery/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -306,6 +307,29 @@ func createAlwaysValidValidators() validation.Validators {
 	}
 }
 
+var customRouteGVK = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "CustomRoute"}
+
+// testCustomRouteKind is a graph.CustomRouteKind whose routes attach to the test/gateway Gateway.
+type testCustomRouteKind struct{}
+
+func (testCustomRouteKind) GroupVersionKind() schema.GroupVersionKind {
+	return customRouteGVK
+}
+
+func (testCustomRouteKind) Translate(*unstructured.Unstructured) (v1.HTTPRouteSpec, error) {
+	return v1.HTTPRouteSpec{
+		CommonRouteSpec: v1.CommonRouteSpec{
+			ParentRefs: []v1.ParentReference{
+				{
+					Namespace: helpers.GetPointer[v1.Namespace]("test"),
+					Name:      "gateway",
+				},
+			},
+		},
+		Rules: []v1.HTTPRouteRule{{}},
+	}, nil
+}
+
 func createScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 
@@ -3016,11 +3040,13 @@ var _ = Describe("ChangeProcessor", func() {
 						Namespace: "test",
 					},
 					Spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-						TargetRefs: []v1.LocalPolicyTargetReference{
+						TargetRefs: []v1.LocalPolicyTargetReferenceWithSectionName{
 							{
-								Group: v1.GroupName,
-								Kind:  kinds.HTTPRoute,
-								Name:  "hr-1",
+								LocalPolicyTargetReference: v1.LocalPolicyTargetReference{
+									Group: v1.GroupName,
+									Kind:  kinds.HTTPRoute,
+									Name:  "hr-1",
+								},
 							},
 						},
 						Tracing: &ngfAPIv1alpha2.Tracing{
@@ -3094,13 +3120,21 @@ var _ = Describe("ChangeProcessor", func() {
 					Expect(graph).ToNot(BeNil())
 					Expect(graph.NGFPolicies).To(HaveKey(cspKey))
 					Expect(graph.NGFPolicies[cspKey].Source).To(Equal(csp))
-					Expect(graph.NGFPolicies).ToNot(HaveKey(obsKey))
+
+					// the policy is kept to report that its target doesn't exist
+					Expect(graph.NGFPolicies).To(HaveKey(obsKey))
+					Expect(graph.NGFPolicies[obsKey].TargetRefs).To(BeEmpty())
+					Expect(graph.NGFPolicies[obsKey].Ancestors).To(HaveLen(1))
+					Expect(graph.NGFPolicies[obsKey].Ancestors[0].Conditions).To(ConsistOf(
+						conditions.NewPolicyTargetNotFound("The TargetRef is not found"),
+					))
 
 					processor.CaptureUpsertChange(route)
 					graph = processor.Process()
 					Expect(graph).ToNot(BeNil())
 					Expect(graph.NGFPolicies).To(HaveKey(obsKey))
 					Expect(graph.NGFPolicies[obsKey].Source).To(Equal(obs))
+					Expect(graph.NGFPolicies[obsKey].TargetRefs).To(HaveLen(1))
 
 					processor.CaptureUpsertChange(svc)
 					graph = processor.Process()
@@ -3756,6 +3790,96 @@ var _ = Describe("ChangeProcessor", func() {
 			)
 		})
 	})
+	Describe("Custom route kinds", Ordered, func() {
+		var (
+			processor   state.ChangeProcessor
+			customRoute *unstructured.Unstructured
+			routeType   *unstructured.Unstructured
+			routeKey    graph.RouteKey
+		)
+
+		BeforeAll(func() {
+			routeKinds, err := graph.NewCustomRouteKinds(testCustomRouteKind{})
+			Expect(err).ToNot(HaveOccurred())
+
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:  controllerName,
+				GatewayClassName: gcName,
+				Logger:           logr.Discard(),
+				Validators:       createAlwaysValidValidators(),
+				MustExtractGVK:   kinds.NewMustExtractGKV(createScheme()),
+				CustomRouteKinds: routeKinds,
+			})
+
+			gc := &v1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: gcName,
+				},
+				Spec: v1.GatewayClassSpec{
+					ControllerName: controllerName,
+				},
+			}
+			gw := &v1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "gateway",
+				},
+				Spec: v1.GatewaySpec{
+					GatewayClassName: gcName,
+					Listeners: []v1.Listener{
+						{
+							Name:     "listener-80",
+							Port:     80,
+							Protocol: v1.HTTPProtocolType,
+							AllowedRoutes: &v1.AllowedRoutes{
+								Kinds: []v1.RouteGroupKind{
+									{
+										Group: helpers.GetPointer[v1.Group](v1.Group(customRouteGVK.Group)),
+										Kind:  v1.Kind(customRouteGVK.Kind),
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			customRoute = &unstructured.Unstructured{}
+			customRoute.SetGroupVersionKind(customRouteGVK)
+			customRoute.SetNamespace("test")
+			customRoute.SetName("route")
+
+			routeType = &unstructured.Unstructured{}
+			routeType.SetGroupVersionKind(customRouteGVK)
+
+			routeKey = graph.CreateRouteKey(customRoute)
+
+			processor.CaptureUpsertChange(gc)
+			processor.CaptureUpsertChange(gw)
+			Expect(processor.Process()).ToNot(BeNil())
+		})
+
+		It("attaches an upserted custom route to the listener", func() {
+			processor.CaptureUpsertChange(customRoute)
+
+			g := processor.Process()
+			Expect(g).ToNot(BeNil())
+			Expect(g.Routes).To(HaveKey(routeKey))
+
+			gw := g.Gateways[types.NamespacedName{Namespace: "test", Name: "gateway"}]
+			Expect(gw).ToNot(BeNil())
+			Expect(getListenerByName(gw, "listener-80").Routes).To(HaveKey(routeKey))
+		})
+
+		It("removes a deleted custom route", func() {
+			processor.CaptureDeleteChange(routeType, client.ObjectKeyFromObject(customRoute))
+
+			g := processor.Process()
+			Expect(g).ToNot(BeNil())
+			Expect(g.Routes).ToNot(HaveKey(routeKey))
+		})
+	})
+
 	Describe("Edge cases with panic", func() {
 		var processor state.ChangeProcessor
 
@@ -2,12 +2,14 @@ package conditions
 
 import (
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 )
 
 const (
@@ -69,6 +71,18 @@ const (
 	// RouteReasonInvalidInferencePool is used when a InferencePool backendRef referenced by a Route is invalid.
 	RouteReasonInvalidInferencePool v1.RouteConditionReason = "InvalidInferencePool"
 
+	// RouteConditionTrafficRoutingStepApplied indicates whether the traffic routing step of a Route, set by
+	// a progressive delivery controller, is applied to the data plane of the parent Gateway.
+	RouteConditionTrafficRoutingStepApplied v1.RouteConditionType = "TrafficRoutingStepApplied"
+
+	// RouteReasonStepApplied is used with the "TrafficRoutingStepApplied" condition when the traffic routing step
+	// is applied to the data plane.
+	RouteReasonStepApplied v1.RouteConditionReason = "StepApplied"
+
+	// RouteReasonStepPending is used with the "TrafficRoutingStepApplied" condition when the traffic routing step
+	// is not yet applied to the data plane.
+	RouteReasonStepPending v1.RouteConditionReason = "StepPending"
+
 	// GatewayReasonUnsupportedField is used with the "Accepted" condition when a Gateway contains fields
 	// that are not yet supported.
 	GatewayReasonUnsupportedField v1.GatewayConditionReason = "UnsupportedField"
@@ -115,6 +129,10 @@ const (
 	// when telemetry is not enabled in the NginxProxy resource.
 	PolicyMessageTelemetryNotEnabled = "Telemetry is not enabled in the NginxProxy resource"
 
+	// PolicyMessageDNSResolverNotSet is a message used with the PolicyReasonNginxProxyConfigNotSet reason
+	// when the DNS resolver is not set in the NginxProxy resource.
+	PolicyMessageDNSResolverNotSet = "The DNS resolver is not set in the NginxProxy resource"
+
 	// PolicyReasonTargetConflict is used with the "PolicyAccepted" condition when a Route that it targets
 	// has an overlapping hostname:port/path combination with another Route.
 	PolicyReasonTargetConflict v1.PolicyConditionReason = "TargetConflict"
@@ -154,6 +172,46 @@ const (
 	// secretRef resource is not permitted by any ReferenceGrant.
 	GatewayReasonSecretRefNotPermitted v1.GatewayConditionReason = "SecretRefNotPermitted"
 
+	// GatewayDataPlaneCompatible condition indicates whether the nginx data plane of the Gateway supports
+	// all features of the Gateway configuration.
+	GatewayDataPlaneCompatible v1.GatewayConditionType = "DataPlaneCompatible"
+
+	// GatewayReasonFeaturesWithheld is used with the "DataPlaneCompatible" condition when features were
+	// withheld from the nginx configuration, because at least one nginx Pod runs a version that doesn't
+	// support them.
+	GatewayReasonFeaturesWithheld v1.GatewayConditionReason = "FeaturesWithheld"
+
+	// GatewayConfigRolledBack condition indicates that the nginx configuration of the Gateway was rolled back
+	// to the previous version, and that the configuration is held until the rollback is released.
+	GatewayConfigRolledBack v1.GatewayConditionType = "ConfigRolledBack"
+
+	// GatewayReasonRollbackRequested is used with the "ConfigRolledBack" condition when the operator
+	// requested the rollback of the nginx configuration of the Gateway.
+	GatewayReasonRollbackRequested v1.GatewayConditionReason = "RollbackRequested"
+
+	// GatewayDataPlaneHealthy condition indicates whether the nginx data plane of the Gateway failed recently.
+	GatewayDataPlaneHealthy v1.GatewayConditionType = "DataPlaneHealthy"
+
+	// GatewayReasonWorkerCrashed is used with the "DataPlaneHealthy" condition when an nginx worker process
+	// crashed recently.
+	GatewayReasonWorkerCrashed v1.GatewayConditionReason = "WorkerCrashed"
+
+	// GatewayReasonOOMKilled is used with the "DataPlaneHealthy" condition when an nginx process or container
+	// was killed recently, because it ran out of memory.
+	GatewayReasonOOMKilled v1.GatewayConditionReason = "OOMKilled"
+
+	// GatewayReasonReloadFailed is used with the "DataPlaneHealthy" condition when nginx recently failed
+	// to apply its configuration.
+	GatewayReasonReloadFailed v1.GatewayConditionReason = "ReloadFailed"
+
+	// GatewayNetworkPolicyRestricted condition indicates whether the NetworkPolicy of the nginx Pods of the Gateway
+	// restricts the egress traffic to the Pods of every backend.
+	GatewayNetworkPolicyRestricted v1.GatewayConditionType = "NetworkPolicyRestricted"
+
+	// GatewayReasonEgressToAnyAddress is used with the "NetworkPolicyRestricted" condition when the NetworkPolicy
+	// allows the egress traffic to any address on the ports of some backends, because their Pods can't be selected.
+	GatewayReasonEgressToAnyAddress v1.GatewayConditionReason = "EgressToAnyAddress"
+
 	// PolicyReasonAncestorLimitReached is used with the "PolicyAccepted" condition when a policy
 	// cannot be applied because the ancestor status list has reached the maximum size of 16.
 	PolicyReasonAncestorLimitReached v1.PolicyConditionReason = "AncestorLimitReached"
@@ -539,6 +597,32 @@ func NewRouteUnsupportedConfiguration(msg string) Condition {
 	}
 }
 
+// NewRouteTrafficRoutingStepApplied returns a Condition that indicates that the traffic routing step of the Route
+// is applied to the data plane of the parent Gateway.
+func NewRouteTrafficRoutingStepApplied(step string) Condition {
+	return Condition{
+		Type:    string(RouteConditionTrafficRoutingStepApplied),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(RouteReasonStepApplied),
+		Message: fmt.Sprintf("Traffic routing step %q is applied to the data plane", step),
+	}
+}
+
+// NewRouteTrafficRoutingStepPending returns a Condition that indicates that the traffic routing step of the Route
+// is not yet applied to the data plane of the parent Gateway.
+func NewRouteTrafficRoutingStepPending(step string) Condition {
+	return Condition{
+		Type:   string(RouteConditionTrafficRoutingStepApplied),
+		Status: metav1.ConditionFalse,
+		Reason: string(RouteReasonStepPending),
+		Message: fmt.Sprintf(
+			"Traffic routing step %q is not yet applied to the data plane; the configuration may be pending, "+
+				"failed, or the weights of the Route may be held by the canary analysis",
+			step,
+		),
+	}
+}
+
 // NewRouteInvalidIPFamily returns a Condition that indicates that the Service associated with the Route
 // is not configured with the same IP family as the NGINX server.
 func NewRouteInvalidIPFamily(msg string) Condition {
@@ -969,6 +1053,50 @@ func NewNginxGatewayInvalid(msg string) Condition {
 	}
 }
 
+// NewGatewayTestPassing returns a Condition that indicates that the responses to the requests of all the assertions
+// of the GatewayTest match their expected responses.
+func NewGatewayTestPassing() Condition {
+	return Condition{
+		Type:    string(ngfAPI.GatewayTestConditionPassing),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(ngfAPI.GatewayTestReasonPassed),
+		Message: "All assertions passed",
+	}
+}
+
+// NewGatewayTestFailed returns a Condition that indicates that the response to the request of an assertion
+// of the GatewayTest doesn't match its expected response.
+func NewGatewayTestFailed(msg string) Condition {
+	return Condition{
+		Type:    string(ngfAPI.GatewayTestConditionPassing),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(ngfAPI.GatewayTestReasonFailed),
+		Message: msg,
+	}
+}
+
+// NewGatewayTestGatewayNotReady returns a Condition that indicates that the requests of the assertions
+// of the GatewayTest can't be sent to its Gateway.
+func NewGatewayTestGatewayNotReady(msg string) Condition {
+	return Condition{
+		Type:    string(ngfAPI.GatewayTestConditionPassing),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(ngfAPI.GatewayTestReasonGatewayNotReady),
+		Message: msg,
+	}
+}
+
+// NewGatewayTestGatewayNotAccepted returns a Condition that indicates that the Gateway of the GatewayTest
+// is not managed by this NGINX Gateway Fabric.
+func NewGatewayTestGatewayNotAccepted(msg string) Condition {
+	return Condition{
+		Type:    string(ngfAPI.GatewayTestConditionPassing),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(ngfAPI.GatewayTestReasonGatewayNotAccepted),
+		Message: msg,
+	}
+}
+
 // NewGatewayResolvedRefs returns a Condition that indicates that the parametersRef
 // on the Gateway is resolved.
 func NewGatewayResolvedRefs() Condition {
@@ -1025,6 +1153,60 @@ func NewGatewayAcceptedUnsupportedField(msg string) Condition {
 	}
 }
 
+// NewGatewayFeaturesWithheld returns a Condition that indicates that features were withheld from
+// the nginx configuration of the Gateway, because the data plane doesn't support them.
+func NewGatewayFeaturesWithheld(features []string) Condition {
+	return Condition{
+		Type:   string(GatewayDataPlaneCompatible),
+		Status: metav1.ConditionFalse,
+		Reason: string(GatewayReasonFeaturesWithheld),
+		Message: fmt.Sprintf(
+			"The following features are not supported by the nginx data plane and were withheld "+
+				"from the configuration until the data plane is upgraded: %s",
+			strings.Join(features, ", "),
+		),
+	}
+}
+
+// NewGatewayConfigRolledBack returns a Condition that indicates that the nginx configuration of the Gateway
+// was rolled back to the previous version and is held.
+func NewGatewayConfigRolledBack() Condition {
+	return Condition{
+		Type:   string(GatewayConfigRolledBack),
+		Status: metav1.ConditionTrue,
+		Reason: string(GatewayReasonRollbackRequested),
+		Message: "The nginx configuration was rolled back to the previous version. Changes to the configuration " +
+			"are held until the gateway.nginx.org/config-rollback annotation is removed from the Gateway",
+	}
+}
+
+// NewGatewayNetworkPolicyEgressToAnyAddress returns a Condition that indicates that the NetworkPolicy of
+// the nginx Pods of the Gateway allows the egress traffic to any address on the ports of the Services, because
+// the Services are ExternalName Services or Services without a selector.
+func NewGatewayNetworkPolicyEgressToAnyAddress(services []string) Condition {
+	return Condition{
+		Type:   string(GatewayNetworkPolicyRestricted),
+		Status: metav1.ConditionFalse,
+		Reason: string(GatewayReasonEgressToAnyAddress),
+		Message: fmt.Sprintf(
+			"The NetworkPolicy allows the egress traffic to any address on the ports of the following Services, "+
+				"because they are ExternalName Services or don't have a selector: %s",
+			strings.Join(services, ", "),
+		),
+	}
+}
+
+// NewGatewayDataPlaneUnhealthy returns a Condition that indicates that the nginx data plane of the Gateway
+// failed recently.
+func NewGatewayDataPlaneUnhealthy(reason v1.GatewayConditionReason, msg string) Condition {
+	return Condition{
+		Type:    string(GatewayDataPlaneHealthy),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(reason),
+		Message: msg,
+	}
+}
+
 // NewPolicyAccepted returns a Condition that indicates that the Policy is accepted.
 func NewPolicyAccepted() Condition {
 	return Condition{
@@ -1123,6 +1305,28 @@ func NewSnippetsFilterAccepted() Condition {
 	}
 }
 
+// NewClusterDefaultPolicyInvalid returns a Condition that indicates that the ClusterDefaultPolicy is not accepted
+// because it is syntactically or semantically invalid.
+func NewClusterDefaultPolicyInvalid(msg string) Condition {
+	return Condition{
+		Type:    string(ngfAPIv1alpha2.ClusterDefaultPolicyConditionTypeAccepted),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(ngfAPIv1alpha2.ClusterDefaultPolicyConditionReasonInvalid),
+		Message: msg,
+	}
+}
+
+// NewClusterDefaultPolicyAccepted returns a Condition that indicates that the ClusterDefaultPolicy is accepted
+// because it is valid.
+func NewClusterDefaultPolicyAccepted() Condition {
+	return Condition{
+		Type:    string(ngfAPIv1alpha2.ClusterDefaultPolicyConditionTypeAccepted),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(ngfAPIv1alpha2.ClusterDefaultPolicyConditionReasonAccepted),
+		Message: "The ClusterDefaultPolicy is accepted",
+	}
+}
+
 // NewObservabilityPolicyAffected returns a Condition that indicates that an ObservabilityPolicy
 // is applied to the resource.
 func NewObservabilityPolicyAffected() Condition {
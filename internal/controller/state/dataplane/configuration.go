@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"maps"
+	"net"
+	"reflect"
 	"slices"
 	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	discoveryV1 "k8s.io/api/discovery/v1"
@@ -59,6 +63,8 @@ func BuildConfiguration(
 	baseStreamConfig := buildBaseStreamConfig(gateway)
 
 	httpServers, sslServers := buildServers(gateway, g.ReferencedServices)
+	applyDefaultResponseHeaders(gateway.EffectiveNginxProxy, httpServers, sslServers)
+	applyStrictSNI(gateway.EffectiveNginxProxy, sslServers)
 	backendGroups := buildBackendGroups(append(httpServers, sslServers...))
 	upstreams := buildUpstreams(
 		ctx,
@@ -93,19 +99,118 @@ func BuildConfiguration(
 			buildRefCertificateBundles(g.ReferencedSecrets, g.ReferencedCaCertConfigMaps),
 			backendGroups,
 		),
-		Telemetry:         buildTelemetry(g, gateway),
-		BaseHTTPConfig:    baseHTTPConfig,
-		BaseStreamConfig:  baseStreamConfig,
-		Logging:           buildLogging(gateway),
-		NginxPlus:         nginxPlus,
-		MainSnippets:      buildSnippetsForContext(gatewaySnippetsFilters, ngfAPIv1alpha1.NginxContextMain),
-		AuxiliarySecrets:  buildAuxiliarySecrets(g.PlusSecrets),
-		WorkerConnections: buildWorkerConnections(gateway),
+		Telemetry:              buildTelemetry(g, gateway),
+		BaseHTTPConfig:         baseHTTPConfig,
+		BaseStreamConfig:       baseStreamConfig,
+		Logging:                buildLogging(gateway),
+		NginxPlus:              nginxPlus,
+		MainSnippets:           buildSnippetsForContext(gatewaySnippetsFilters, ngfAPIv1alpha1.NginxContextMain),
+		AuxiliarySecrets:       buildAuxiliarySecrets(g.PlusSecrets),
+		WorkerConnections:      buildWorkerConnections(gateway),
+		ConnectionCloseTimeout: buildConnectionCloseTimeout(gateway),
+		TokenReview:            buildTokenReview(httpServers, sslServers),
 	}
 
 	return config
 }
 
+// buildTokenReview returns the verifier of the bearer tokens if the ClientSettingsPolicy of any Route of the servers
+// requires the tokens in the TokenReview mode. The address of the verifier is set by the control plane, if it runs the verifier.
+func buildTokenReview(servers ...[]VirtualServer) *TokenReview {
+	for _, svrs := range servers {
+		for _, s := range svrs {
+			for _, rule := range s.PathRules {
+				for _, pol := range rule.Policies {
+					csp, ok := pol.(*ngfAPIv1alpha1.ClientSettingsPolicy)
+					if ok && csp.Spec.Authentication != nil &&
+						csp.Spec.Authentication.Mode == ngfAPIv1alpha1.ClientAuthenticationModeTokenReview {
+						return &TokenReview{}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyStrictSNI rejects the TLS handshakes of the SSL servers that match any hostname, if the StrictSNI feature
+// of the NginxProxy is enabled, so that only the server names of the servers with hostnames complete the handshake.
+func applyStrictSNI(np *graph.EffectiveNginxProxy, sslServers []VirtualServer) {
+	if np == nil || np.Features == nil || np.Features.StrictSNI == nil || !*np.Features.StrictSNI {
+		return
+	}
+
+	for i := range sslServers {
+		if !sslServers[i].IsDefault && sslServers[i].Hostname == wildcardHostname {
+			sslServers[i].RejectHandshake = true
+		}
+	}
+}
+
+// applyDefaultResponseHeaders sets the default response headers of the NginxProxy on the responses of the routing
+// rules of the servers. A rule overrides a default header when its ResponseHeaderModifier filter sets, adds,
+// or removes a header of the same name.
+func applyDefaultResponseHeaders(np *graph.EffectiveNginxProxy, servers ...[]VirtualServer) {
+	if np == nil || len(np.DefaultResponseHeaders) == 0 {
+		return
+	}
+
+	for _, svrs := range servers {
+		for i := range svrs {
+			for j := range svrs[i].PathRules {
+				matchRules := svrs[i].PathRules[j].MatchRules
+				for k := range matchRules {
+					filters := &matchRules[k].Filters
+					if filters.InvalidFilter != nil {
+						continue
+					}
+
+					filters.ResponseHeaderModifiers = mergeDefaultResponseHeaders(
+						filters.ResponseHeaderModifiers,
+						np.DefaultResponseHeaders,
+					)
+				}
+			}
+		}
+	}
+}
+
+func mergeDefaultResponseHeaders(
+	filter *HTTPHeaderFilter,
+	defaultHeaders []ngfAPIv1alpha2.HTTPHeader,
+) *HTTPHeaderFilter {
+	// the filter can be shared by the rules of several servers, so a copy is modified
+	merged := &HTTPHeaderFilter{}
+	overridden := make(map[string]struct{})
+
+	if filter != nil {
+		merged.Set = slices.Clone(filter.Set)
+		merged.Add = slices.Clone(filter.Add)
+		merged.Remove = slices.Clone(filter.Remove)
+
+		for _, h := range filter.Set {
+			overridden[strings.ToLower(h.Name)] = struct{}{}
+		}
+		for _, h := range filter.Add {
+			overridden[strings.ToLower(h.Name)] = struct{}{}
+		}
+		for _, name := range filter.Remove {
+			overridden[strings.ToLower(name)] = struct{}{}
+		}
+	}
+
+	for _, h := range defaultHeaders {
+		if _, ok := overridden[strings.ToLower(h.Name)]; ok {
+			continue
+		}
+
+		merged.Set = append(merged.Set, HTTPHeader{Name: h.Name, Value: h.Value})
+	}
+
+	return merged
+}
+
 // buildPassthroughServers builds TLSPassthroughServers from TLSRoutes attaches to listeners.
 func buildPassthroughServers(gateway *graph.Gateway) []Layer4VirtualServer {
 	passthroughServersMap := make(map[graph.L4RouteKey][]Layer4VirtualServer)
@@ -455,6 +560,9 @@ func convertBackendTLS(btp *graph.BackendTLSPolicy, gwNsName types.NamespacedNam
 		verify.RootCAPath = alpineSSLRootCAPath
 	}
 	verify.Hostname = string(btp.Source.Spec.Validation.Hostname)
+	verify.SessionReuse = btp.Options.SessionReuse
+	verify.Protocols = btp.Options.Protocols
+	verify.Ciphers = btp.Options.Ciphers
 	return verify
 }
 
@@ -490,16 +598,39 @@ func buildServers(
 	pols := buildPolicies(gateway, gateway.Policies)
 
 	for i := range httpServers {
-		httpServers[i].Policies = pols
+		httpServers[i].Policies = mergeServerPolicies(httpServers[i].Policies, pols)
 	}
 
 	for i := range sslServers {
-		sslServers[i].Policies = pols
+		sslServers[i].Policies = mergeServerPolicies(sslServers[i].Policies, pols)
 	}
 
 	return httpServers, sslServers
 }
 
+// mergeServerPolicies merges the policies that target the listener of a server with the policies that target
+// the Gateway. A policy that targets the listener takes precedence over a policy of the same type that targets
+// the Gateway, so that the server doesn't get conflicting directives of both policies.
+func mergeServerPolicies(listenerPolicies, gatewayPolicies []policies.Policy) []policies.Policy {
+	if len(listenerPolicies) == 0 {
+		return gatewayPolicies
+	}
+
+	merged := slices.Clone(listenerPolicies)
+
+	for _, gwPolicy := range gatewayPolicies {
+		overridden := slices.ContainsFunc(listenerPolicies, func(p policies.Policy) bool {
+			return reflect.TypeOf(p) == reflect.TypeOf(gwPolicy)
+		})
+
+		if !overridden {
+			merged = append(merged, gwPolicy)
+		}
+	}
+
+	return merged
+}
+
 // portPathRules keeps track of hostPathRules per port.
 type portPathRules map[v1.PortNumber]*hostPathRules
 
@@ -526,16 +657,19 @@ type pathAndType struct {
 type hostPathRules struct {
 	rulesPerHost     map[string]map[pathAndType]PathRule
 	listenersForHost map[string]*graph.Listener
-	httpsListeners   []*graph.Listener
-	port             int32
-	listenersExist   bool
+	// policiesForListener holds the policies that target a listener, by the name of the listener.
+	policiesForListener map[string][]policies.Policy
+	httpsListeners      []*graph.Listener
+	port                int32
+	listenersExist      bool
 }
 
 func newHostPathRules() *hostPathRules {
 	return &hostPathRules{
-		rulesPerHost:     make(map[string]map[pathAndType]PathRule),
-		listenersForHost: make(map[string]*graph.Listener),
-		httpsListeners:   make([]*graph.Listener, 0),
+		rulesPerHost:        make(map[string]map[pathAndType]PathRule),
+		listenersForHost:    make(map[string]*graph.Listener),
+		policiesForListener: make(map[string][]policies.Policy),
+		httpsListeners:      make([]*graph.Listener, 0),
 	}
 }
 
@@ -546,6 +680,7 @@ func (hpr *hostPathRules) upsertListener(
 ) {
 	hpr.listenersExist = true
 	hpr.port = l.Source.Port
+	hpr.policiesForListener[l.Name] = buildPolicies(gateway, l.Policies)
 
 	if l.Source.Protocol == v1.HTTPSProtocolType {
 		hpr.httpsListeners = append(hpr.httpsListeners, l)
@@ -573,9 +708,19 @@ func (hpr *hostPathRules) upsertRoute(
 
 	routeNsName := client.ObjectKeyFromObject(route.Source)
 
-	if GRPC {
+	switch {
+	case GRPC:
 		objectSrc = &helpers.MustCastObject[*v1.GRPCRoute](route.Source).ObjectMeta
-	} else {
+	case !route.CustomKind.Empty():
+		// custom routes are unstructured, so we only copy the metadata that the configuration relies on
+		objectSrc = &metav1.ObjectMeta{
+			Name:        route.Source.GetName(),
+			Namespace:   route.Source.GetNamespace(),
+			Generation:  route.Source.GetGeneration(),
+			Labels:      route.Source.GetLabels(),
+			Annotations: route.Source.GetAnnotations(),
+		}
+	default:
 		objectSrc = &helpers.MustCastObject[*v1.HTTPRoute](route.Source).ObjectMeta
 	}
 
@@ -609,7 +754,7 @@ func (hpr *hostPathRules) upsertRoute(
 		}
 
 		var filters HTTPFilters
-		if rule.Filters.Valid {
+		if rule.Filters.Valid && (rule.Timeouts == nil || rule.Timeouts.Valid) {
 			filters = createHTTPFilters(rule.Filters.Filters, idx, routeNsName)
 		} else {
 			filters = HTTPFilters{
@@ -652,6 +797,7 @@ func (hpr *hostPathRules) upsertRoute(
 					BackendGroup: backendGroup,
 					Filters:      filters,
 					Match:        convertMatch(m),
+					Timeouts:     convertTimeouts(rule.Timeouts),
 				})
 
 				hpr.rulesPerHost[h][key] = hostRule
@@ -675,6 +821,8 @@ func (hpr *hostPathRules) buildServers() []VirtualServer {
 			panic(fmt.Sprintf("no listener found for hostname: %s", h))
 		}
 
+		s.Policies = hpr.policiesForListener[l.Name]
+
 		if l.ResolvedSecret != nil {
 			s.SSL = &SSL{
 				KeyPairID: generateSSLKeyPairID(*l.ResolvedSecret),
@@ -713,6 +861,7 @@ func (hpr *hostPathRules) buildServers() []VirtualServer {
 			s := VirtualServer{
 				Hostname: hostname,
 				Port:     hpr.port,
+				Policies: hpr.policiesForListener[l.Name],
 			}
 
 			if l.ResolvedSecret != nil {
@@ -1040,7 +1189,12 @@ func buildTelemetry(g *graph.Graph, gateway *graph.Gateway) Telemetry {
 	// Find a generic way to include relevant policy info at the http context so we don't need policy-specific
 	// logic in this function
 	ratioMap := make(map[string]int32)
-	for _, pol := range g.NGFPolicies {
+	pols := slices.Collect(maps.Values(g.NGFPolicies))
+	for _, cdp := range g.ClusterDefaultPolicies {
+		pols = append(pols, cdp.Policies...)
+	}
+
+	for _, pol := range pols {
 		if obsPol, ok := pol.Source.(*ngfAPIv1alpha2.ObservabilityPolicy); ok {
 			if obsPol.Spec.Tracing != nil && obsPol.Spec.Tracing.Ratio != nil && *obsPol.Spec.Tracing.Ratio > 0 {
 				ratioName := CreateRatioVarName(*obsPol.Spec.Tracing.Ratio)
@@ -1107,6 +1261,10 @@ func buildBaseHTTPConfig(
 		baseConfig.DisableSNIHostValidation = true
 	}
 
+	if np.Features != nil && np.Features.UpstreamHTTP2 != nil && *np.Features.UpstreamHTTP2 {
+		baseConfig.UpstreamHTTP2 = true
+	}
+
 	if np.IPFamily != nil {
 		switch *np.IPFamily {
 		case ngfAPIv1alpha2.IPv4:
@@ -1120,6 +1278,23 @@ func buildBaseHTTPConfig(
 		baseConfig.NginxReadinessProbePort = port
 	}
 
+	if path, port, enabled := graph.LoadBalancerHealthCheckForNginxProxy(np); enabled {
+		baseConfig.LoadBalancerHealthCheck = &LoadBalancerHealthCheck{Path: path, Port: port}
+	}
+
+	if _, enabled := graph.PreStopDrainForNginxProxy(np); enabled {
+		baseConfig.DrainFile = graph.DrainFilePath
+	}
+
+	if header, tenants, enabled := graph.TenantAttributionForNginxProxy(np); enabled {
+		baseConfig.TenantAttribution = &TenantAttribution{
+			Header:  header,
+			Tenants: tenants,
+		}
+	}
+
+	baseConfig.TempFiles = buildTempFiles(np.TempFiles)
+
 	baseConfig.RewriteClientIPSettings = buildRewriteClientIPConfig(np.RewriteClientIP)
 
 	baseConfig.DNSResolver = buildDNSResolverConfig(np.DNSResolver)
@@ -1127,6 +1302,22 @@ func buildBaseHTTPConfig(
 	return baseConfig
 }
 
+func buildTempFiles(tempFiles *ngfAPIv1alpha2.TempFiles) *TempFiles {
+	if tempFiles == nil {
+		return nil
+	}
+
+	tf := &TempFiles{
+		ReportWrites: tempFiles.ReportWrites != nil && *tempFiles.ReportWrites,
+	}
+
+	if tempFiles.MaxResponseFileSize != nil {
+		tf.MaxResponseFileSize = string(*tempFiles.MaxResponseFileSize)
+	}
+
+	return tf
+}
+
 func getNginxReadinessProbePort(np *graph.EffectiveNginxProxy) int32 {
 	var port int32
 
@@ -1285,11 +1476,19 @@ func buildAccessLog(srcLogSettings *ngfAPIv1alpha2.NginxLogging) *AccessLog {
 			return &AccessLog{Disable: true}
 		}
 
+		separateClientAborts := srcLogSettings.AccessLog.SeparateClientAborts != nil &&
+			*srcLogSettings.AccessLog.SeparateClientAborts
+
 		if srcLogSettings.AccessLog.Format != nil && *srcLogSettings.AccessLog.Format != "" {
 			return &AccessLog{
-				Format: *srcLogSettings.AccessLog.Format,
+				Format:               *srcLogSettings.AccessLog.Format,
+				SeparateClientAborts: separateClientAborts,
 			}
 		}
+
+		if separateClientAborts {
+			return &AccessLog{SeparateClientAborts: true}
+		}
 	}
 
 	return nil
@@ -1308,6 +1507,18 @@ func buildWorkerConnections(gateway *graph.Gateway) int32 {
 	return DefaultWorkerConnections
 }
 
+func buildConnectionCloseTimeout(gateway *graph.Gateway) string {
+	if gateway == nil || gateway.EffectiveNginxProxy == nil {
+		return ""
+	}
+
+	if timeout := gateway.EffectiveNginxProxy.ConnectionCloseTimeout; timeout != nil {
+		return string(*timeout)
+	}
+
+	return ""
+}
+
 func buildAuxiliarySecrets(
 	secrets map[types.NamespacedName][]graph.PlusSecretFile,
 ) map[graph.SecretFileType][]byte {
@@ -1390,7 +1601,46 @@ func getExternalHostname(
 	return ""
 }
 
-// resolveUpstreamEndpoints handles service resolution for both regular and ExternalName services.
+// resolveStaticEndpoints converts the endpoints of a Backend to Endpoints. IP addresses of a family that is not
+// allowed are skipped, and DNS names are resolved by NGINX.
+func resolveStaticEndpoints(
+	endpoints []ngfAPIv1alpha1.BackendEndpoint,
+	allowedAddressType []discoveryV1.AddressType,
+) []resolver.Endpoint {
+	eps := make([]resolver.Endpoint, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		ip := net.ParseIP(endpoint.Address)
+		if ip == nil {
+			eps = append(eps, resolver.Endpoint{
+				Address: endpoint.Address,
+				Port:    endpoint.Port,
+				Resolve: true,
+			})
+			continue
+		}
+
+		addressType := discoveryV1.AddressTypeIPv4
+		if ip.To4() == nil {
+			addressType = discoveryV1.AddressTypeIPv6
+		}
+
+		if !slices.Contains(allowedAddressType, addressType) {
+			continue
+		}
+
+		eps = append(eps, resolver.Endpoint{
+			Address: endpoint.Address,
+			Port:    endpoint.Port,
+			IPv6:    addressType == discoveryV1.AddressTypeIPv6,
+		})
+	}
+
+	return eps
+}
+
+// resolveUpstreamEndpoints handles service resolution for regular and ExternalName services,
+// and Backends with static endpoints.
 func resolveUpstreamEndpoints(
 	ctx context.Context,
 	logger logr.Logger,
@@ -1399,6 +1649,10 @@ func resolveUpstreamEndpoints(
 	referencedServices map[types.NamespacedName]*graph.ReferencedService,
 	allowedAddressType []discoveryV1.AddressType,
 ) ([]resolver.Endpoint, error) {
+	if br.IsStaticBackend() {
+		return resolveStaticEndpoints(br.StaticEndpoints, allowedAddressType), nil
+	}
+
 	// Check if this is an ExternalName service
 	if externalName := getExternalHostname(br.SvcNsName, referencedServices); externalName != "" {
 		// For ExternalName services, create an endpoint directly with the external name
@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	apiv1 "k8s.io/api/core/v1"
 	discoveryV1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -2513,6 +2514,238 @@ func TestUpsertRoute_PathRuleHasInferenceBackend(t *testing.T) {
 	g.Expect(found).To(BeTrue(), "PathRule for '/infer' not found")
 }
 
+func TestUpsertRoute_Timeouts(t *testing.T) {
+	t.Parallel()
+
+	listenerName := "listener-80"
+	gwName := types.NamespacedName{Namespace: "test", Name: "gw"}
+
+	createRule := func(path string, timeouts *graph.RouteTimeouts) graph.RouteRule {
+		return graph.RouteRule{
+			ValidMatches: true,
+			Filters:      graph.RouteRuleFilters{Valid: true},
+			Timeouts:     timeouts,
+			Matches: []v1.HTTPRouteMatch{
+				{
+					Path: &v1.HTTPPathMatch{
+						Type:  helpers.GetPointer(v1.PathMatchPathPrefix),
+						Value: helpers.GetPointer(path),
+					},
+				},
+			},
+		}
+	}
+
+	route := &graph.L7Route{
+		RouteType: graph.RouteTypeHTTP,
+		Source: &v1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "hr",
+				Namespace: "test",
+			},
+		},
+		Spec: graph.L7RouteSpec{
+			Rules: []graph.RouteRule{
+				createRule("/valid", &graph.RouteTimeouts{
+					Request:        "10s",
+					BackendRequest: "2s",
+					RetryCodes:     []int{502, 503},
+					Tries:          3,
+					Valid:          true,
+				}),
+				createRule("/invalid", &graph.RouteTimeouts{
+					Request:        "1s",
+					BackendRequest: "2s",
+				}),
+				createRule("/none", nil),
+			},
+		},
+		ParentRefs: []graph.ParentRef{
+			{
+				Attachment: &graph.ParentRefAttachmentStatus{
+					AcceptedHostnames: map[string][]string{
+						graph.CreateGatewayListenerKey(gwName, listenerName): {"*"},
+					},
+				},
+			},
+		},
+		Valid: true,
+	}
+
+	listener := &graph.Listener{
+		Name:        listenerName,
+		GatewayName: gwName,
+		Valid:       true,
+		Routes: map[graph.RouteKey]*graph.L7Route{
+			graph.CreateRouteKey(route.Source): route,
+		},
+	}
+
+	gateway := &graph.Gateway{
+		Source: &v1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw",
+				Namespace: "test",
+			},
+		},
+		Listeners: []*graph.Listener{listener},
+	}
+
+	hpr := newHostPathRules()
+	hpr.upsertRoute(route, listener, gateway, nil)
+
+	matchRules := make(map[string]MatchRule)
+	for _, rules := range hpr.rulesPerHost {
+		for _, pr := range rules {
+			matchRules[pr.Path] = pr.MatchRules[0]
+		}
+	}
+
+	tests := []struct {
+		expTimeouts      *Timeouts
+		name             string
+		path             string
+		expInvalidFilter bool
+	}{
+		{
+			name: "valid timeouts",
+			path: "/valid",
+			expTimeouts: &Timeouts{
+				Request:        "10s",
+				BackendRequest: "2s",
+				RetryCodes:     []int{502, 503},
+				Tries:          3,
+			},
+		},
+		{
+			name:             "invalid timeouts respond with an error",
+			path:             "/invalid",
+			expInvalidFilter: true,
+		},
+		{
+			name: "no timeouts",
+			path: "/none",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(matchRules).To(HaveKey(test.path))
+
+			matchRule := matchRules[test.path]
+			g.Expect(matchRule.Timeouts).To(Equal(test.expTimeouts))
+			g.Expect(matchRule.Filters.InvalidFilter != nil).To(Equal(test.expInvalidFilter))
+		})
+	}
+}
+
+func TestBuildServers_ListenerPolicies(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gwName := types.NamespacedName{Namespace: "test", Name: "gw"}
+
+	newObsPolicy := func(name string) *graph.Policy {
+		return &graph.Policy{
+			Source: &ngfAPIv1alpha2.ObservabilityPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			},
+			Valid:              true,
+			InvalidForGateways: map[types.NamespacedName]struct{}{},
+		}
+	}
+
+	gwObsPolicy := newObsPolicy("gateway")
+	adminObsPolicy := newObsPolicy("admin")
+	gwCSPolicy := &graph.Policy{
+		Source: &ngfAPIv1alpha1.ClientSettingsPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "test"},
+		},
+		Valid:              true,
+		InvalidForGateways: map[types.NamespacedName]struct{}{},
+	}
+
+	createListener := func(name string, port v1.PortNumber, hostname string, pols ...*graph.Policy) *graph.Listener {
+		route := &graph.L7Route{
+			RouteType: graph.RouteTypeHTTP,
+			Source: &v1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			},
+			Spec: graph.L7RouteSpec{
+				Rules: []graph.RouteRule{
+					{
+						ValidMatches: true,
+						Filters:      graph.RouteRuleFilters{Valid: true},
+						Matches: []v1.HTTPRouteMatch{
+							{
+								Path: &v1.HTTPPathMatch{
+									Type:  helpers.GetPointer(v1.PathMatchPathPrefix),
+									Value: helpers.GetPointer("/"),
+								},
+							},
+						},
+					},
+				},
+			},
+			ParentRefs: []graph.ParentRef{
+				{
+					Attachment: &graph.ParentRefAttachmentStatus{
+						AcceptedHostnames: map[string][]string{
+							graph.CreateGatewayListenerKey(gwName, name): {hostname},
+						},
+					},
+				},
+			},
+			Valid: true,
+		}
+
+		return &graph.Listener{
+			Name:        name,
+			GatewayName: gwName,
+			Source: v1.Listener{
+				Name:     v1.SectionName(name),
+				Port:     port,
+				Protocol: v1.HTTPProtocolType,
+			},
+			Routes: map[graph.RouteKey]*graph.L7Route{
+				graph.CreateRouteKey(route.Source): route,
+			},
+			Policies: pols,
+			Valid:    true,
+		}
+	}
+
+	gateway := &graph.Gateway{
+		Source: &v1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: gwName.Name, Namespace: gwName.Namespace},
+		},
+		Listeners: []*graph.Listener{
+			createListener("admin", 8080, "admin.example.com", adminObsPolicy),
+			createListener("public", 80, "example.com"),
+		},
+		Policies: []*graph.Policy{gwObsPolicy, gwCSPolicy},
+	}
+
+	httpServers, sslServers := buildServers(gateway, nil)
+	g.Expect(sslServers).To(BeEmpty())
+
+	serverPolicies := make(map[string][]policies.Policy)
+	for _, server := range httpServers {
+		if !server.IsDefault {
+			serverPolicies[server.Hostname] = server.Policies
+		}
+	}
+
+	// The policy of the listener overrides the ObservabilityPolicy of the Gateway, but not the ClientSettingsPolicy.
+	g.Expect(serverPolicies).To(Equal(map[string][]policies.Policy{
+		"admin.example.com": {adminObsPolicy.Source, gwCSPolicy.Source},
+		"example.com":       {gwObsPolicy.Source, gwCSPolicy.Source},
+	}))
+}
+
 func TestNewBackendGroup_Mirror(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -3493,6 +3726,25 @@ func TestConvertBackendTLS(t *testing.T) {
 		Gateways: []types.NamespacedName{testGateway},
 	}
 
+	btpWithOptions := &graph.BackendTLSPolicy{
+		Source: btpWellKnownCerts.Source,
+		Options: graph.BackendTLSOptions{
+			SessionReuse: helpers.GetPointer(false),
+			Protocols:    []string{"TLSv1.2", "TLSv1.3"},
+			Ciphers:      "HIGH:!aNULL",
+		},
+		Valid:    true,
+		Gateways: []types.NamespacedName{testGateway},
+	}
+
+	expectedWithOptions := &VerifyTLS{
+		Hostname:     "example.com",
+		RootCAPath:   alpineSSLRootCAPath,
+		SessionReuse: helpers.GetPointer(false),
+		Protocols:    []string{"TLSv1.2", "TLSv1.3"},
+		Ciphers:      "HIGH:!aNULL",
+	}
+
 	expectedWithCertPath := &VerifyTLS{
 		CertBundleID: generateCertBundleID(
 			types.NamespacedName{Namespace: "test", Name: "ca-cert"},
@@ -3529,6 +3781,12 @@ func TestConvertBackendTLS(t *testing.T) {
 			expected: expectedWithWellKnownCerts,
 			msg:      "normal case no cert path",
 		},
+		{
+			btp:      btpWithOptions,
+			gwNsName: testGateway,
+			expected: expectedWithOptions,
+			msg:      "normal case with options",
+		},
 		{
 			btp:      btpCaCertRefs,
 			gwNsName: types.NamespacedName{Namespace: "test", Name: "unsupported-gateway"},
@@ -3987,6 +4245,57 @@ func TestGetAllowedAddressType(t *testing.T) {
 	}
 }
 
+func TestResolveStaticEndpoints(t *testing.T) {
+	t.Parallel()
+
+	endpoints := []ngfAPIv1alpha1.BackendEndpoint{
+		{Address: "10.0.0.1", Port: 8080},
+		{Address: "fd00::1", Port: 8080},
+		{Address: "backend.example.com", Port: 443},
+	}
+
+	tests := []struct {
+		name               string
+		allowedAddressType []discoveryV1.AddressType
+		expected           []resolver.Endpoint
+	}{
+		{
+			name:               "dual stack",
+			allowedAddressType: []discoveryV1.AddressType{discoveryV1.AddressTypeIPv4, discoveryV1.AddressTypeIPv6},
+			expected: []resolver.Endpoint{
+				{Address: "10.0.0.1", Port: 8080},
+				{Address: "fd00::1", Port: 8080, IPv6: true},
+				{Address: "backend.example.com", Port: 443, Resolve: true},
+			},
+		},
+		{
+			name:               "IPv4 only",
+			allowedAddressType: []discoveryV1.AddressType{discoveryV1.AddressTypeIPv4},
+			expected: []resolver.Endpoint{
+				{Address: "10.0.0.1", Port: 8080},
+				{Address: "backend.example.com", Port: 443, Resolve: true},
+			},
+		},
+		{
+			name:               "IPv6 only",
+			allowedAddressType: []discoveryV1.AddressType{discoveryV1.AddressTypeIPv6},
+			expected: []resolver.Endpoint{
+				{Address: "fd00::1", Port: 8080, IPv6: true},
+				{Address: "backend.example.com", Port: 443, Resolve: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(resolveStaticEndpoints(endpoints, test.allowedAddressType)).To(Equal(test.expected))
+		})
+	}
+}
+
 func TestCreateRatioVarName(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -4629,6 +4938,46 @@ func TestBuildLogging(t *testing.T) {
 				},
 			},
 		},
+		{
+			msg: "AccessLog configured to log client aborts separately",
+			gw: &graph.Gateway{
+				EffectiveNginxProxy: &graph.EffectiveNginxProxy{
+					Logging: &ngfAPIv1alpha2.NginxLogging{
+						ErrorLevel: helpers.GetPointer(ngfAPIv1alpha2.NginxLogLevelInfo),
+						AccessLog: &ngfAPIv1alpha2.NginxAccessLog{
+							Format:               helpers.GetPointer(logFormat),
+							SeparateClientAborts: helpers.GetPointer(true),
+						},
+					},
+				},
+			},
+			expLoggingSettings: Logging{
+				ErrorLevel: "info",
+				AccessLog: &AccessLog{
+					Format:               logFormat,
+					SeparateClientAborts: true,
+				},
+			},
+		},
+		{
+			msg: "AccessLog configured to log client aborts separately without Format",
+			gw: &graph.Gateway{
+				EffectiveNginxProxy: &graph.EffectiveNginxProxy{
+					Logging: &ngfAPIv1alpha2.NginxLogging{
+						ErrorLevel: helpers.GetPointer(ngfAPIv1alpha2.NginxLogLevelInfo),
+						AccessLog: &ngfAPIv1alpha2.NginxAccessLog{
+							SeparateClientAborts: helpers.GetPointer(true),
+						},
+					},
+				},
+			},
+			expLoggingSettings: Logging{
+				ErrorLevel: "info",
+				AccessLog: &AccessLog{
+					SeparateClientAborts: true,
+				},
+			},
+		},
 		{
 			msg: "Nothing configured if AccessLog Format is missing",
 			gw: &graph.Gateway{
@@ -5021,6 +5370,120 @@ func TestBuildWorkerConnections(t *testing.T) {
 	}
 }
 
+func TestBuildTokenReview(t *testing.T) {
+	t.Parallel()
+
+	authPolicy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			Authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: ngfAPIv1alpha1.ClientAuthenticationModeTokenReview,
+			},
+		},
+	}
+	otherPolicy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			KeepAlive: &ngfAPIv1alpha1.ClientKeepAlive{Requests: helpers.GetPointer[int32](10)},
+		},
+	}
+	remotePolicy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			Authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode:   ngfAPIv1alpha1.ClientAuthenticationModeRemote,
+				Remote: &ngfAPIv1alpha1.RemoteAuthentication{URL: "https://auth.example.com/verify"},
+			},
+		},
+	}
+
+	serversWith := func(pols ...policies.Policy) []VirtualServer {
+		return []VirtualServer{
+			{IsDefault: true},
+			{
+				Hostname:  "example.com",
+				PathRules: []PathRule{{Path: "/", Policies: pols}},
+			},
+		}
+	}
+
+	tests := []struct {
+		expTokenReview *TokenReview
+		msg            string
+		httpServers    []VirtualServer
+		sslServers     []VirtualServer
+	}{
+		{
+			msg: "no servers",
+		},
+		{
+			msg:         "no policy requires a token",
+			httpServers: serversWith(otherPolicy),
+			sslServers:  serversWith(),
+		},
+		{
+			msg:         "policy authenticates with a remote endpoint",
+			httpServers: serversWith(remotePolicy),
+		},
+		{
+			msg:            "policy of an HTTP server requires a token",
+			httpServers:    serversWith(otherPolicy, authPolicy),
+			expTokenReview: &TokenReview{},
+		},
+		{
+			msg:            "policy of an SSL server requires a token",
+			httpServers:    serversWith(otherPolicy),
+			sslServers:     serversWith(authPolicy),
+			expTokenReview: &TokenReview{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.msg, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(buildTokenReview(tc.httpServers, tc.sslServers)).To(Equal(tc.expTokenReview))
+		})
+	}
+}
+
+func TestBuildConnectionCloseTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		gw         *graph.Gateway
+		msg        string
+		expTimeout string
+	}{
+		{
+			msg: "NginxProxy is nil",
+			gw:  &graph.Gateway{},
+		},
+		{
+			msg: "NginxProxy doesn't specify the connection close timeout",
+			gw: &graph.Gateway{
+				EffectiveNginxProxy: &graph.EffectiveNginxProxy{},
+			},
+		},
+		{
+			msg: "NginxProxy specifies the connection close timeout",
+			gw: &graph.Gateway{
+				EffectiveNginxProxy: &graph.EffectiveNginxProxy{
+					ConnectionCloseTimeout: helpers.GetPointer[ngfAPIv1alpha1.Duration]("30s"),
+				},
+			},
+			expTimeout: "30s",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.msg, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(buildConnectionCloseTimeout(tc.gw)).To(Equal(tc.expTimeout))
+		})
+	}
+}
+
 func TestBuildBaseHTTPConfig_ReadinessProbe(t *testing.T) {
 	t.Parallel()
 	test := []struct {
@@ -5128,6 +5591,133 @@ func TestBuildBaseHTTPConfig_ReadinessProbe(t *testing.T) {
 	}
 }
 
+func TestBuildBaseHTTPConfig_LoadBalancerHealthCheck(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gateway := &graph.Gateway{
+		EffectiveNginxProxy: &graph.EffectiveNginxProxy{
+			Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+				Service: &ngfAPIv1alpha2.ServiceSpec{
+					LoadBalancerHealthCheck: &ngfAPIv1alpha2.LoadBalancerHealthCheck{
+						Port:   helpers.GetPointer(int32(9000)),
+						Enable: true,
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(buildBaseHTTPConfig(gateway, nil).LoadBalancerHealthCheck).To(Equal(&LoadBalancerHealthCheck{
+		Path: graph.DefaultLoadBalancerHealthCheckPath,
+		Port: 9000,
+	}))
+
+	gateway.EffectiveNginxProxy.Kubernetes.Service.LoadBalancerHealthCheck.Enable = false
+	g.Expect(buildBaseHTTPConfig(gateway, nil).LoadBalancerHealthCheck).To(BeNil())
+}
+
+func TestBuildBaseHTTPConfig_PreStopDrain(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gateway := &graph.Gateway{
+		EffectiveNginxProxy: &graph.EffectiveNginxProxy{
+			Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+				Deployment: &ngfAPIv1alpha2.DeploymentSpec{},
+			},
+		},
+	}
+
+	g.Expect(buildBaseHTTPConfig(gateway, nil).DrainFile).To(BeEmpty())
+
+	gateway.EffectiveNginxProxy.Kubernetes.Deployment.Container.PreStopDrain = &ngfAPIv1alpha2.PreStopDrain{
+		DelaySeconds: 15,
+	}
+	g.Expect(buildBaseHTTPConfig(gateway, nil).DrainFile).To(Equal(graph.DrainFilePath))
+}
+
+func TestBuildBaseHTTPConfig_TenantAttribution(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gateway := &graph.Gateway{
+		Source: &v1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "gateway",
+			},
+		},
+		EffectiveNginxProxy: &graph.EffectiveNginxProxy{
+			TenantAttribution: &ngfAPIv1alpha2.TenantAttribution{
+				Header:  helpers.GetPointer("X-Tenant"),
+				Tenants: []string{"team-a", "team-b"},
+				Enable:  true,
+			},
+		},
+	}
+
+	g.Expect(buildBaseHTTPConfig(gateway, nil).TenantAttribution).To(Equal(&TenantAttribution{
+		Header:  "X-Tenant",
+		Tenants: []string{"team-a", "team-b"},
+	}))
+
+	gateway.EffectiveNginxProxy.TenantAttribution.Enable = false
+	g.Expect(buildBaseHTTPConfig(gateway, nil).TenantAttribution).To(BeNil())
+}
+
+func TestBuildBaseHTTPConfig_TempFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gateway := &graph.Gateway{
+		Source: &v1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "gateway",
+			},
+		},
+		EffectiveNginxProxy: &graph.EffectiveNginxProxy{},
+	}
+	g.Expect(buildBaseHTTPConfig(gateway, nil).TempFiles).To(BeNil())
+
+	gateway.EffectiveNginxProxy.TempFiles = &ngfAPIv1alpha2.TempFiles{
+		MaxResponseFileSize: helpers.GetPointer[ngfAPIv1alpha1.Size]("0"),
+		ReportWrites:        helpers.GetPointer(true),
+	}
+	g.Expect(buildBaseHTTPConfig(gateway, nil).TempFiles).To(Equal(&TempFiles{
+		MaxResponseFileSize: "0",
+		ReportWrites:        true,
+	}))
+
+	gateway.EffectiveNginxProxy.TempFiles = &ngfAPIv1alpha2.TempFiles{
+		VolumeSizeLimit: helpers.GetPointer(resource.MustParse("1Gi")),
+	}
+	g.Expect(buildBaseHTTPConfig(gateway, nil).TempFiles).To(Equal(&TempFiles{}))
+}
+
+func TestBuildBaseHTTPConfig_UpstreamHTTP2(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gateway := &graph.Gateway{
+		Source: &v1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "gateway",
+			},
+		},
+		EffectiveNginxProxy: &graph.EffectiveNginxProxy{},
+	}
+	g.Expect(buildBaseHTTPConfig(gateway, nil).UpstreamHTTP2).To(BeFalse())
+
+	gateway.EffectiveNginxProxy.Features = &ngfAPIv1alpha2.Features{UpstreamHTTP2: helpers.GetPointer(true)}
+	g.Expect(buildBaseHTTPConfig(gateway, nil).UpstreamHTTP2).To(BeTrue())
+
+	gateway.EffectiveNginxProxy.Features.UpstreamHTTP2 = helpers.GetPointer(false)
+	g.Expect(buildBaseHTTPConfig(gateway, nil).UpstreamHTTP2).To(BeFalse())
+}
+
 func TestBuildDNSResolverConfig(t *testing.T) {
 	t.Parallel()
 
@@ -5502,6 +6092,138 @@ func TestBuildConfiguration_GatewaysAndListeners(t *testing.T) {
 	}
 }
 
+func TestApplyDefaultResponseHeaders(t *testing.T) {
+	t.Parallel()
+
+	np := &graph.EffectiveNginxProxy{
+		DefaultResponseHeaders: []ngfAPIv1alpha2.HTTPHeader{
+			{Name: "X-Frame-Options", Value: "DENY"},
+			{Name: "X-Content-Type-Options", Value: "nosniff"},
+			{Name: "X-Company", Value: "example"},
+		},
+	}
+
+	sharedFilter := &HTTPHeaderFilter{
+		Set:    []HTTPHeader{{Name: "x-frame-options", Value: "SAMEORIGIN"}},
+		Add:    []HTTPHeader{{Name: "X-Company", Value: "team"}},
+		Remove: []string{"X-Content-Type-Options"},
+	}
+
+	createServer := func(filters ...HTTPFilters) VirtualServer {
+		matchRules := make([]MatchRule, 0, len(filters))
+		for _, f := range filters {
+			matchRules = append(matchRules, MatchRule{Filters: f})
+		}
+
+		return VirtualServer{
+			Hostname:  "example.com",
+			PathRules: []PathRule{{Path: "/", MatchRules: matchRules}},
+		}
+	}
+
+	httpServers := []VirtualServer{
+		{IsDefault: true},
+		createServer(
+			HTTPFilters{},
+			HTTPFilters{ResponseHeaderModifiers: sharedFilter},
+			HTTPFilters{InvalidFilter: &InvalidHTTPFilter{}},
+		),
+	}
+	sslServers := []VirtualServer{
+		createServer(HTTPFilters{ResponseHeaderModifiers: sharedFilter}),
+	}
+
+	applyDefaultResponseHeaders(np, httpServers, sslServers)
+
+	g := NewWithT(t)
+
+	httpRules := httpServers[1].PathRules[0].MatchRules
+	g.Expect(httpRules[0].Filters.ResponseHeaderModifiers).To(Equal(&HTTPHeaderFilter{
+		Set: []HTTPHeader{
+			{Name: "X-Frame-Options", Value: "DENY"},
+			{Name: "X-Content-Type-Options", Value: "nosniff"},
+			{Name: "X-Company", Value: "example"},
+		},
+	}))
+
+	// the route sets, adds, and removes the default headers itself
+	overridden := &HTTPHeaderFilter{
+		Set:    []HTTPHeader{{Name: "x-frame-options", Value: "SAMEORIGIN"}},
+		Add:    []HTTPHeader{{Name: "X-Company", Value: "team"}},
+		Remove: []string{"X-Content-Type-Options"},
+	}
+	g.Expect(httpRules[1].Filters.ResponseHeaderModifiers).To(Equal(overridden))
+	g.Expect(sslServers[0].PathRules[0].MatchRules[0].Filters.ResponseHeaderModifiers).To(Equal(overridden))
+
+	g.Expect(httpRules[2].Filters.ResponseHeaderModifiers).To(BeNil())
+
+	// the filter of the route is not modified
+	g.Expect(sharedFilter).To(Equal(overridden))
+	g.Expect(httpRules[1].Filters.ResponseHeaderModifiers).ToNot(BeIdenticalTo(sharedFilter))
+
+	// no default headers
+	servers := []VirtualServer{createServer(HTTPFilters{})}
+	applyDefaultResponseHeaders(&graph.EffectiveNginxProxy{}, servers)
+	g.Expect(servers[0].PathRules[0].MatchRules[0].Filters.ResponseHeaderModifiers).To(BeNil())
+}
+
+func TestApplyStrictSNI(t *testing.T) {
+	t.Parallel()
+
+	createServers := func() []VirtualServer {
+		return []VirtualServer{
+			{IsDefault: true, Port: 443},
+			{Hostname: wildcardHostname, Port: 443},
+			{Hostname: "example.com", Port: 443},
+		}
+	}
+
+	tests := []struct {
+		np       *graph.EffectiveNginxProxy
+		name     string
+		expected []VirtualServer
+	}{
+		{
+			name:     "NginxProxy is nil",
+			expected: createServers(),
+		},
+		{
+			name:     "features are not set",
+			np:       &graph.EffectiveNginxProxy{},
+			expected: createServers(),
+		},
+		{
+			name: "strict SNI is disabled",
+			np: &graph.EffectiveNginxProxy{
+				Features: &ngfAPIv1alpha2.Features{StrictSNI: helpers.GetPointer(false)},
+			},
+			expected: createServers(),
+		},
+		{
+			name: "strict SNI is enabled",
+			np: &graph.EffectiveNginxProxy{
+				Features: &ngfAPIv1alpha2.Features{StrictSNI: helpers.GetPointer(true)},
+			},
+			expected: []VirtualServer{
+				{IsDefault: true, Port: 443},
+				{Hostname: wildcardHostname, Port: 443, RejectHandshake: true},
+				{Hostname: "example.com", Port: 443},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			servers := createServers()
+			applyStrictSNI(test.np, servers)
+			g.Expect(servers).To(Equal(test.expected))
+		})
+	}
+}
+
 func TestBuildConfiguration_NginxProxy(t *testing.T) {
 	t.Parallel()
 
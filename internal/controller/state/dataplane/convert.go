@@ -168,6 +168,21 @@ func convertPathModifier(path *v1.HTTPPathModifier) *HTTPPathModifier {
 	return nil
 }
 
+// convertTimeouts converts the timeouts of a route rule. Invalid timeouts are not converted, because the rule
+// of invalid timeouts responds with an error.
+func convertTimeouts(timeouts *graph.RouteTimeouts) *Timeouts {
+	if timeouts == nil || !timeouts.Valid {
+		return nil
+	}
+
+	return &Timeouts{
+		Request:        timeouts.Request,
+		BackendRequest: timeouts.BackendRequest,
+		RetryCodes:     timeouts.RetryCodes,
+		Tries:          timeouts.Tries,
+	}
+}
+
 func convertSnippetsFilter(filter *graph.SnippetsFilter) SnippetsFilter {
 	result := SnippetsFilter{}
 
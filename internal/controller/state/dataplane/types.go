@@ -59,6 +59,15 @@ type Configuration struct {
 	Telemetry Telemetry
 	// BaseHTTPConfig holds the configuration options at the http context.
 	BaseHTTPConfig BaseHTTPConfig
+	// APICatalog is the catalog of the APIs of the Routes of the Gateway, which NGINX serves on every server
+	// of the Gateway. If nil, the catalog is not served.
+	APICatalog *APICatalog
+	// TokenReview is the verifier of the bearer tokens of the requests to the Routes whose ClientSettingsPolicy
+	// requires the tokens. If nil, no Route of the Gateway requires the tokens.
+	TokenReview *TokenReview
+	// ConnectionCloseTimeout is the time after which NGINX closes the connections that are still open when its
+	// configuration changes. Empty if NGINX waits for the connections to be closed.
+	ConnectionCloseTimeout string
 	// WorkerConnections specifies the maximum number of simultaneous connections that can be opened by a worker process.
 	WorkerConnections int32
 }
@@ -96,6 +105,9 @@ type VirtualServer struct {
 	Port int32
 	// IsDefault indicates whether the server is the default server.
 	IsDefault bool
+	// RejectHandshake indicates whether the TLS handshakes for the server are rejected.
+	// Only applicable to SSL servers.
+	RejectHandshake bool
 }
 
 // Layer4VirtualServer is a virtual server for Layer 4 traffic.
@@ -311,12 +323,28 @@ type MatchRule struct {
 	Filters HTTPFilters
 	// Source is the ObjectMeta of the resource that includes the rule.
 	Source *metav1.ObjectMeta
+	// Timeouts holds the timeouts and the retries of the rule. Nil if the rule doesn't configure them.
+	Timeouts *Timeouts
 	// Match holds the match for the rule.
 	Match Match
 	// BackendGroup is the group of Backends that the rule routes to.
 	BackendGroup BackendGroup
 }
 
+// Timeouts holds the timeouts and the retries of a routing rule. The tries of a request fit within the timeout
+// of the whole request.
+type Timeouts struct {
+	// Request is the timeout of the whole request, including the retries. Empty if the request is not limited.
+	Request string
+	// BackendRequest is the timeout of a single try of the request to a backend. Empty if the tries are not limited.
+	BackendRequest string
+	// RetryCodes are the HTTP response status codes that the request is retried on.
+	RetryCodes []int
+	// Tries is the number of tries of the request, including the first one. Zero if the rule doesn't configure
+	// the retry.
+	Tries int
+}
+
 // Match represents a match for a routing rule which consist of matches against various HTTP request attributes.
 type Match struct {
 	// Method matches against the HTTP method.
@@ -383,9 +411,15 @@ type EndpointPickerConfig struct {
 
 // VerifyTLS holds the backend TLS verification configuration.
 type VerifyTLS struct {
+	// SessionReuse enables or disables the reuse of the TLS sessions. If nil, NGINX uses its default.
+	SessionReuse *bool
 	CertBundleID CertBundleID
 	Hostname     string
 	RootCAPath   string
+	// Ciphers are the enabled ciphers in the OpenSSL format. If empty, NGINX uses its default.
+	Ciphers string
+	// Protocols are the enabled TLS protocols. If empty, NGINX uses its default.
+	Protocols []string
 }
 
 // Telemetry represents global Otel configuration for the dataplane.
@@ -418,10 +452,25 @@ type SpanAttribute struct {
 type BaseHTTPConfig struct {
 	// DNSResolver defines the DNS resolver configuration for NGINX.
 	DNSResolver *DNSResolverConfig
+	// LoadBalancerHealthCheck is the health check endpoint for the cloud load balancer of the NGINX Service.
+	// If nil, the endpoint is disabled.
+	LoadBalancerHealthCheck *LoadBalancerHealthCheck
+	// TenantAttribution defines how the requests are attributed to tenants.
+	// If nil, the requests are not attributed to tenants.
+	TenantAttribution *TenantAttribution
+	// TempFiles configures the temporary files of NGINX. If nil, NGINX uses its defaults.
+	TempFiles *TempFiles
 	// IPFamily specifies the IP family for all servers.
 	IPFamily IPFamilyType
 	// GatewaySecretID is the ID of the secret that contains the gateway backend TLS certificate.
 	GatewaySecretID SSLKeyPairID
+	// GatewayTestToken is the token that the requests of the GatewayTests carry in the X-NGF-Gateway-Test header.
+	// NGINX reports the upstream of such requests in the X-NGF-Gateway-Test-Upstream response header.
+	// If empty, the upstreams are not reported.
+	GatewayTestToken string
+	// DrainFile is the file that drains the traffic of the NGINX Pod: while it exists, the readiness endpoint
+	// and the health check endpoint for the cloud load balancer respond with 503. If empty, the Pod is not drained.
+	DrainFile string
 	// Snippets contain the snippets that apply to the http context.
 	Snippets []Snippet
 	// RewriteIPSettings defines configuration for rewriting the client IP to the original client's IP.
@@ -432,6 +481,59 @@ type BaseHTTPConfig struct {
 	HTTP2 bool
 	// DisableSNIHostValidation specifies if the SNI host validation should be disabled.
 	DisableSNIHostValidation bool
+	// UpstreamHTTP2 specifies whether HTTP/2 is used for the connections to the backends of HTTPRoutes.
+	UpstreamHTTP2 bool
+}
+
+// APICatalog is the catalog of the APIs of the Routes of a Gateway.
+type APICatalog struct {
+	// Path is the path at which the catalog is served.
+	Path string
+	// Content is the JSON document of the catalog.
+	Content []byte
+}
+
+// TokenReview is the verifier of the control plane that reviews the bearer tokens of the requests with the
+// Kubernetes TokenReview API.
+type TokenReview struct {
+	// Server is the address of the verifier. If empty, the control plane doesn't run the verifier, and the requests
+	// that require a token are rejected.
+	Server string
+}
+
+// LoadBalancerHealthCheck is the health check endpoint for the cloud load balancer of the NGINX Service.
+type LoadBalancerHealthCheck struct {
+	// Path is the path of the endpoint.
+	Path string
+	// Port is the port of the endpoint.
+	Port int32
+}
+
+// TenantAttribution defines how the requests are attributed to tenants.
+type TenantAttribution struct {
+	// Header is the name of the request header that identifies the tenant of a request.
+	// If empty, the tenant of a request is the namespace of its route.
+	Header string
+	// Server is the address of the syslog server of the control plane, to which NGINX reports every request.
+	// If empty, NGINX doesn't report the requests.
+	Server string
+	// Tenants are the tenants that the Header can identify. The requests whose Header is not one of them
+	// are attributed to the namespace of their route.
+	Tenants []string
+}
+
+// TempFiles configures the temporary files to which NGINX writes the request bodies and the responses
+// of the backends.
+type TempFiles struct {
+	// MaxResponseFileSize is the maximum size of the temporary file of a response. If empty, NGINX uses its default.
+	MaxResponseFileSize string
+	// Server is the address of the syslog server of the control plane, to which NGINX reports the writes
+	// to the temporary files. If empty, NGINX doesn't report the writes.
+	Server string
+	// SyslogTag is the syslog tag that identifies the Gateway in the reports of the writes.
+	SyslogTag string
+	// ReportWrites specifies whether the writes to the temporary files are reported to the control plane.
+	ReportWrites bool
 }
 
 // BaseStreamConfig holds the configuration options at the stream context.
@@ -507,6 +609,11 @@ type Logging struct {
 	AccessLog *AccessLog
 	// ErrorLevel defines the error log level.
 	ErrorLevel string
+	// FailureServer is the address of the syslog server of the control plane, to which NGINX reports the exits
+	// of its worker processes on a signal. If empty, NGINX doesn't report the exits.
+	FailureServer string
+	// FailureSyslogTag is the syslog tag that identifies the nginx Deployment in the reports of the exits.
+	FailureSyslogTag string
 }
 
 // NginxPlus specifies NGINX Plus additional settings.
@@ -534,4 +641,7 @@ type AccessLog struct {
 	Format string
 	// Disable specifies whether the access log is disabled.
 	Disable bool
+	// SeparateClientAborts specifies whether the requests that the client closed before NGINX responded
+	// (status 499) are logged separately, in a distinct format.
+	SeparateClientAborts bool
 }
@@ -0,0 +1,39 @@
+package dataplane
+
+// RemoveUnreferencedUpstreams removes the upstreams to which the configuration doesn't proxy any requests.
+// For example, when all Routes that reference a Service give it no weight, or when the weights of a canary are
+// overridden to zero, the upstream of the Service is not referenced. Removing such upstreams reduces the size of
+// the configuration and the shared memory of the upstream zones, and keeps the NGINX Plus API requests for
+// the upstreams consistent with the configuration.
+//
+// It must be called after the weights of the Backends are final, because it relies on them. The upstreams
+// with the keep names are not removed.
+func RemoveUnreferencedUpstreams(conf *Configuration, keep ...string) {
+	if len(conf.Upstreams) == 0 {
+		return
+	}
+
+	referenced := make(map[string]struct{}, len(keep))
+	for _, name := range keep {
+		referenced[name] = struct{}{}
+	}
+
+	for _, group := range conf.BackendGroups {
+		for _, b := range group.Backends {
+			// The InferencePool Backends are referenced by the maps of their EndpointPickers regardless of
+			// their weight.
+			if b.EndpointPickerConfig != nil || (b.Valid && b.Weight > 0) {
+				referenced[b.UpstreamName] = struct{}{}
+			}
+		}
+	}
+
+	upstreams := make([]Upstream, 0, len(conf.Upstreams))
+	for _, u := range conf.Upstreams {
+		if _, exists := referenced[u.Name]; exists {
+			upstreams = append(upstreams, u)
+		}
+	}
+
+	conf.Upstreams = upstreams
+}
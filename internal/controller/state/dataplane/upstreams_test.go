@@ -0,0 +1,92 @@
+package dataplane
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRemoveUnreferencedUpstreams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		groups       []BackendGroup
+		upstreams    []Upstream
+		expUpstreams []Upstream
+		keep         []string
+	}{
+		{
+			name: "no upstreams",
+			groups: []BackendGroup{
+				{Backends: []Backend{{UpstreamName: "test_foo_80", Weight: 1, Valid: true}}},
+			},
+			upstreams:    nil,
+			expUpstreams: nil,
+		},
+		{
+			name: "unreferenced upstreams are removed",
+			groups: []BackendGroup{
+				{
+					Backends: []Backend{
+						{UpstreamName: "test_stable_80", Weight: 100, Valid: true},
+						{UpstreamName: "test_canary_80", Weight: 0, Valid: true},
+					},
+				},
+				{Backends: []Backend{{UpstreamName: "test_invalid_80", Weight: 1}}},
+				{
+					Backends: []Backend{
+						{
+							UpstreamName:         "test_pool_80",
+							EndpointPickerConfig: &EndpointPickerConfig{},
+						},
+					},
+				},
+			},
+			upstreams: []Upstream{
+				{Name: "test_canary_80"},
+				{Name: "test_invalid_80"},
+				{Name: "test_orphan_80"},
+				{Name: "test_pool_80"},
+				{Name: "test_stable_80"},
+			},
+			expUpstreams: []Upstream{
+				{Name: "test_pool_80"},
+				{Name: "test_stable_80"},
+			},
+		},
+		{
+			name: "upstream referenced by any group is kept",
+			groups: []BackendGroup{
+				{Backends: []Backend{{UpstreamName: "test_foo_80", Weight: 0, Valid: true}}},
+				{Backends: []Backend{{UpstreamName: "test_foo_80", Weight: 1, Valid: true}}},
+			},
+			upstreams:    []Upstream{{Name: "test_foo_80"}},
+			expUpstreams: []Upstream{{Name: "test_foo_80"}},
+		},
+		{
+			name: "unreferenced upstreams to keep are kept",
+			groups: []BackendGroup{
+				{Backends: []Backend{{UpstreamName: "test_foo_80", Weight: 1, Valid: true}}},
+			},
+			upstreams:    []Upstream{{Name: "hook_upstream"}, {Name: "test_bar_80"}, {Name: "test_foo_80"}},
+			keep:         []string{"hook_upstream"},
+			expUpstreams: []Upstream{{Name: "hook_upstream"}, {Name: "test_foo_80"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			conf := Configuration{
+				BackendGroups: test.groups,
+				Upstreams:     test.upstreams,
+			}
+
+			RemoveUnreferencedUpstreams(&conf, test.keep...)
+			g.Expect(conf.Upstreams).To(Equal(test.expUpstreams))
+		})
+	}
+}
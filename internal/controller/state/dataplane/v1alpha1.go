@@ -0,0 +1,488 @@
+package dataplane
+
+import (
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+	"github.com/nginx/nginx-gateway-fabric/v2/pkg/dataplane/v1alpha1"
+)
+
+// ToV1alpha1 converts the routing state of the Configuration into the public v1alpha1 model.
+// Everything that is not part of the model, like secrets and policies, is dropped.
+func ToV1alpha1(conf Configuration) v1alpha1.Configuration {
+	return v1alpha1.Configuration{
+		APIVersion:            v1alpha1.APIVersion,
+		HTTPServers:           convertSlice(conf.HTTPServers, virtualServerToV1alpha1),
+		SSLServers:            convertSlice(conf.SSLServers, virtualServerToV1alpha1),
+		TLSPassthroughServers: convertSlice(conf.TLSPassthroughServers, layer4VirtualServerToV1alpha1),
+		Upstreams:             convertSlice(conf.Upstreams, upstreamToV1alpha1),
+		StreamUpstreams:       convertSlice(conf.StreamUpstreams, upstreamToV1alpha1),
+		BackendGroups:         convertSlice(conf.BackendGroups, backendGroupToV1alpha1),
+	}
+}
+
+// FromV1alpha1 converts the public v1alpha1 model into a Configuration. Only the routing state is set;
+// the rest of the Configuration has zero values.
+func FromV1alpha1(conf v1alpha1.Configuration) Configuration {
+	return Configuration{
+		HTTPServers:           convertSlice(conf.HTTPServers, virtualServerFromV1alpha1),
+		SSLServers:            convertSlice(conf.SSLServers, virtualServerFromV1alpha1),
+		TLSPassthroughServers: convertSlice(conf.TLSPassthroughServers, layer4VirtualServerFromV1alpha1),
+		Upstreams:             convertSlice(conf.Upstreams, upstreamFromV1alpha1),
+		StreamUpstreams:       convertSlice(conf.StreamUpstreams, upstreamFromV1alpha1),
+		BackendGroups:         convertSlice(conf.BackendGroups, backendGroupFromV1alpha1),
+	}
+}
+
+// ApplyV1alpha1 replaces the routing state of the Configuration with the public v1alpha1 model, for example,
+// after an extension mutated the model. The state that is not part of the model, like policies, is kept for the
+// servers, path rules, match rules, backends, and upstreams that are still in the model.
+// It returns an error, and leaves the Configuration unchanged, if the model is of a different version or refers to
+// an SSL key pair or upstream that doesn't exist.
+func ApplyV1alpha1(conf *Configuration, model v1alpha1.Configuration) error {
+	if model.APIVersion != v1alpha1.APIVersion {
+		return fmt.Errorf("unsupported apiVersion %q, expected %q", model.APIVersion, v1alpha1.APIVersion)
+	}
+
+	if err := validateV1alpha1References(*conf, model); err != nil {
+		return err
+	}
+
+	applied := FromV1alpha1(model)
+
+	backends := make(map[string]Backend)
+	for _, group := range conf.BackendGroups {
+		for _, b := range group.Backends {
+			backends[b.UpstreamName] = b
+		}
+	}
+
+	restoreServers(applied.HTTPServers, conf.HTTPServers, backends)
+	restoreServers(applied.SSLServers, conf.SSLServers, backends)
+	restoreUpstreams(applied.Upstreams, conf.Upstreams)
+	restoreUpstreams(applied.StreamUpstreams, conf.StreamUpstreams)
+	for i := range applied.BackendGroups {
+		restoreBackends(applied.BackendGroups[i].Backends, backends)
+	}
+
+	conf.HTTPServers = applied.HTTPServers
+	conf.SSLServers = applied.SSLServers
+	conf.TLSPassthroughServers = applied.TLSPassthroughServers
+	conf.Upstreams = applied.Upstreams
+	conf.StreamUpstreams = applied.StreamUpstreams
+	conf.BackendGroups = applied.BackendGroups
+
+	return nil
+}
+
+func validateV1alpha1References(conf Configuration, model v1alpha1.Configuration) error {
+	for _, s := range model.SSLServers {
+		if _, exists := conf.SSLKeyPairs[SSLKeyPairID(s.SSLKeyPairID)]; s.SSLKeyPairID != "" && !exists {
+			return fmt.Errorf("server %s:%d refers to unknown SSL key pair %q", s.Hostname, s.Port, s.SSLKeyPairID)
+		}
+	}
+
+	upstreams := make(map[string]struct{}, len(model.Upstreams))
+	for _, u := range model.Upstreams {
+		upstreams[u.Name] = struct{}{}
+	}
+
+	validateGroup := func(group v1alpha1.BackendGroup) error {
+		for _, b := range group.Backends {
+			if _, exists := upstreams[b.UpstreamName]; b.Valid && !exists {
+				return fmt.Errorf(
+					"backend group of route %s/%s refers to unknown upstream %q",
+					group.Route.Namespace,
+					group.Route.Name,
+					b.UpstreamName,
+				)
+			}
+		}
+
+		return nil
+	}
+
+	for _, group := range model.BackendGroups {
+		if err := validateGroup(group); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range slices.Concat(model.HTTPServers, model.SSLServers) {
+		for _, r := range s.PathRules {
+			for _, mr := range r.MatchRules {
+				if err := validateGroup(mr.BackendGroup); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+type serverKey struct {
+	hostname string
+	port     int32
+}
+
+type matchRuleKey struct {
+	route   types.NamespacedName
+	ruleIdx int
+}
+
+// restoreServers restores the state that is not part of the v1alpha1 model from the original servers.
+func restoreServers(servers, original []VirtualServer, backends map[string]Backend) {
+	originalServers := make(map[serverKey]VirtualServer, len(original))
+	for _, s := range original {
+		originalServers[serverKey{hostname: s.Hostname, port: s.Port}] = s
+	}
+
+	for i := range servers {
+		s := &servers[i]
+
+		orig, exists := originalServers[serverKey{hostname: s.Hostname, port: s.Port}]
+		if exists {
+			s.Policies = orig.Policies
+		}
+
+		for j := range s.PathRules {
+			restorePathRule(&s.PathRules[j], orig.PathRules, backends)
+		}
+	}
+}
+
+func restorePathRule(rule *PathRule, original []PathRule, backends map[string]Backend) {
+	var orig PathRule
+	for _, r := range original {
+		if r.Path == rule.Path && r.PathType == rule.PathType {
+			orig = r
+			rule.Policies = r.Policies
+			rule.HasInferenceBackends = r.HasInferenceBackends
+			break
+		}
+	}
+
+	originalMatchRules := make(map[matchRuleKey]MatchRule, len(orig.MatchRules))
+	for _, mr := range orig.MatchRules {
+		key := matchRuleKey{route: mr.BackendGroup.Source, ruleIdx: mr.BackendGroup.RuleIdx}
+		if _, exists := originalMatchRules[key]; !exists {
+			originalMatchRules[key] = mr
+		}
+	}
+
+	for i := range rule.MatchRules {
+		mr := &rule.MatchRules[i]
+		restoreBackends(mr.BackendGroup.Backends, backends)
+
+		origMR, exists := originalMatchRules[matchRuleKey{route: mr.BackendGroup.Source, ruleIdx: mr.BackendGroup.RuleIdx}]
+		if !exists {
+			continue
+		}
+
+		if origMR.Source != nil {
+			mr.Source = origMR.Source
+		}
+
+		if mr.Filters.InvalidFilter == nil {
+			mr.Filters.RequestMirrors = origMR.Filters.RequestMirrors
+			mr.Filters.SnippetsFilters = origMR.Filters.SnippetsFilters
+		}
+	}
+}
+
+func restoreBackends(backends []Backend, original map[string]Backend) {
+	for i := range backends {
+		b := &backends[i]
+		if orig, exists := original[b.UpstreamName]; exists {
+			b.VerifyTLS = orig.VerifyTLS
+			b.EndpointPickerConfig = orig.EndpointPickerConfig
+		}
+	}
+}
+
+func restoreUpstreams(upstreams, original []Upstream) {
+	originalUpstreams := make(map[string]Upstream, len(original))
+	for _, u := range original {
+		originalUpstreams[u.Name] = u
+	}
+
+	for i := range upstreams {
+		u := &upstreams[i]
+		if orig, exists := originalUpstreams[u.Name]; exists {
+			u.SessionPersistence = orig.SessionPersistence
+			u.StateFileKey = orig.StateFileKey
+			u.Policies = orig.Policies
+		}
+	}
+}
+
+// convertSlice converts every element of the slice. A nil slice stays nil.
+func convertSlice[From, To any](from []From, convert func(From) To) []To {
+	if from == nil {
+		return nil
+	}
+
+	to := make([]To, 0, len(from))
+	for _, f := range from {
+		to = append(to, convert(f))
+	}
+
+	return to
+}
+
+func virtualServerToV1alpha1(s VirtualServer) v1alpha1.VirtualServer {
+	vs := v1alpha1.VirtualServer{
+		Hostname:  s.Hostname,
+		PathRules: convertSlice(s.PathRules, pathRuleToV1alpha1),
+		Port:      s.Port,
+		IsDefault: s.IsDefault,
+	}
+
+	if s.SSL != nil {
+		vs.SSLKeyPairID = string(s.SSL.KeyPairID)
+	}
+
+	return vs
+}
+
+func virtualServerFromV1alpha1(s v1alpha1.VirtualServer) VirtualServer {
+	vs := VirtualServer{
+		Hostname:  s.Hostname,
+		PathRules: convertSlice(s.PathRules, pathRuleFromV1alpha1),
+		Port:      s.Port,
+		IsDefault: s.IsDefault,
+	}
+
+	if s.SSLKeyPairID != "" {
+		vs.SSL = &SSL{KeyPairID: SSLKeyPairID(s.SSLKeyPairID)}
+	}
+
+	return vs
+}
+
+func layer4VirtualServerToV1alpha1(s Layer4VirtualServer) v1alpha1.Layer4VirtualServer {
+	return v1alpha1.Layer4VirtualServer(s)
+}
+
+func layer4VirtualServerFromV1alpha1(s v1alpha1.Layer4VirtualServer) Layer4VirtualServer {
+	return Layer4VirtualServer(s)
+}
+
+func pathRuleToV1alpha1(r PathRule) v1alpha1.PathRule {
+	return v1alpha1.PathRule{
+		Path:       r.Path,
+		PathType:   v1alpha1.PathType(r.PathType),
+		MatchRules: convertSlice(r.MatchRules, matchRuleToV1alpha1),
+		GRPC:       r.GRPC,
+	}
+}
+
+func pathRuleFromV1alpha1(r v1alpha1.PathRule) PathRule {
+	return PathRule{
+		Path:       r.Path,
+		PathType:   PathType(r.PathType),
+		MatchRules: convertSlice(r.MatchRules, matchRuleFromV1alpha1),
+		GRPC:       r.GRPC,
+	}
+}
+
+func matchRuleToV1alpha1(r MatchRule) v1alpha1.MatchRule {
+	mr := v1alpha1.MatchRule{
+		Match: v1alpha1.Match{
+			Method:      r.Match.Method,
+			Headers:     convertSlice(r.Match.Headers, headerMatchToV1alpha1),
+			QueryParams: convertSlice(r.Match.QueryParams, queryParamMatchToV1alpha1),
+		},
+		Filters:      filtersToV1alpha1(r.Filters),
+		BackendGroup: backendGroupToV1alpha1(r.BackendGroup),
+	}
+
+	if r.Source != nil {
+		mr.Route = v1alpha1.ObjectReference{Namespace: r.Source.Namespace, Name: r.Source.Name}
+	}
+
+	return mr
+}
+
+func matchRuleFromV1alpha1(r v1alpha1.MatchRule) MatchRule {
+	return MatchRule{
+		Source: &metav1.ObjectMeta{Namespace: r.Route.Namespace, Name: r.Route.Name},
+		Match: Match{
+			Method:      r.Match.Method,
+			Headers:     convertSlice(r.Match.Headers, headerMatchFromV1alpha1),
+			QueryParams: convertSlice(r.Match.QueryParams, queryParamMatchFromV1alpha1),
+		},
+		Filters:      filtersFromV1alpha1(r.Filters),
+		BackendGroup: backendGroupFromV1alpha1(r.BackendGroup),
+	}
+}
+
+func headerMatchToV1alpha1(m HTTPHeaderMatch) v1alpha1.HTTPHeaderMatch {
+	return v1alpha1.HTTPHeaderMatch{Name: m.Name, Value: m.Value, Type: v1alpha1.MatchType(m.Type)}
+}
+
+func headerMatchFromV1alpha1(m v1alpha1.HTTPHeaderMatch) HTTPHeaderMatch {
+	return HTTPHeaderMatch{Name: m.Name, Value: m.Value, Type: MatchType(m.Type)}
+}
+
+func queryParamMatchToV1alpha1(m HTTPQueryParamMatch) v1alpha1.HTTPQueryParamMatch {
+	return v1alpha1.HTTPQueryParamMatch{Name: m.Name, Value: m.Value, Type: v1alpha1.MatchType(m.Type)}
+}
+
+func queryParamMatchFromV1alpha1(m v1alpha1.HTTPQueryParamMatch) HTTPQueryParamMatch {
+	return HTTPQueryParamMatch{Name: m.Name, Value: m.Value, Type: MatchType(m.Type)}
+}
+
+func filtersToV1alpha1(f HTTPFilters) v1alpha1.HTTPFilters {
+	filters := v1alpha1.HTTPFilters{
+		RequestHeaderModifiers:  headerFilterToV1alpha1(f.RequestHeaderModifiers),
+		ResponseHeaderModifiers: headerFilterToV1alpha1(f.ResponseHeaderModifiers),
+		Invalid:                 f.InvalidFilter != nil,
+	}
+
+	if f.RequestRedirect != nil {
+		filters.RequestRedirect = &v1alpha1.HTTPRequestRedirectFilter{
+			Scheme:     f.RequestRedirect.Scheme,
+			Hostname:   f.RequestRedirect.Hostname,
+			Port:       f.RequestRedirect.Port,
+			StatusCode: f.RequestRedirect.StatusCode,
+			Path:       pathModifierToV1alpha1(f.RequestRedirect.Path),
+		}
+	}
+
+	if f.RequestURLRewrite != nil {
+		filters.RequestURLRewrite = &v1alpha1.HTTPURLRewriteFilter{
+			Hostname: f.RequestURLRewrite.Hostname,
+			Path:     pathModifierToV1alpha1(f.RequestURLRewrite.Path),
+		}
+	}
+
+	return filters
+}
+
+func filtersFromV1alpha1(f v1alpha1.HTTPFilters) HTTPFilters {
+	filters := HTTPFilters{
+		RequestHeaderModifiers:  headerFilterFromV1alpha1(f.RequestHeaderModifiers),
+		ResponseHeaderModifiers: headerFilterFromV1alpha1(f.ResponseHeaderModifiers),
+	}
+
+	if f.Invalid {
+		filters.InvalidFilter = &InvalidHTTPFilter{}
+	}
+
+	if f.RequestRedirect != nil {
+		filters.RequestRedirect = &HTTPRequestRedirectFilter{
+			Scheme:     f.RequestRedirect.Scheme,
+			Hostname:   f.RequestRedirect.Hostname,
+			Port:       f.RequestRedirect.Port,
+			StatusCode: f.RequestRedirect.StatusCode,
+			Path:       pathModifierFromV1alpha1(f.RequestRedirect.Path),
+		}
+	}
+
+	if f.RequestURLRewrite != nil {
+		filters.RequestURLRewrite = &HTTPURLRewriteFilter{
+			Hostname: f.RequestURLRewrite.Hostname,
+			Path:     pathModifierFromV1alpha1(f.RequestURLRewrite.Path),
+		}
+	}
+
+	return filters
+}
+
+func headerFilterToV1alpha1(f *HTTPHeaderFilter) *v1alpha1.HTTPHeaderFilter {
+	if f == nil {
+		return nil
+	}
+
+	return &v1alpha1.HTTPHeaderFilter{
+		Set:    convertSlice(f.Set, func(h HTTPHeader) v1alpha1.HTTPHeader { return v1alpha1.HTTPHeader(h) }),
+		Add:    convertSlice(f.Add, func(h HTTPHeader) v1alpha1.HTTPHeader { return v1alpha1.HTTPHeader(h) }),
+		Remove: f.Remove,
+	}
+}
+
+func headerFilterFromV1alpha1(f *v1alpha1.HTTPHeaderFilter) *HTTPHeaderFilter {
+	if f == nil {
+		return nil
+	}
+
+	return &HTTPHeaderFilter{
+		Set:    convertSlice(f.Set, func(h v1alpha1.HTTPHeader) HTTPHeader { return HTTPHeader(h) }),
+		Add:    convertSlice(f.Add, func(h v1alpha1.HTTPHeader) HTTPHeader { return HTTPHeader(h) }),
+		Remove: f.Remove,
+	}
+}
+
+func pathModifierToV1alpha1(m *HTTPPathModifier) *v1alpha1.HTTPPathModifier {
+	if m == nil {
+		return nil
+	}
+
+	return &v1alpha1.HTTPPathModifier{Replacement: m.Replacement, Type: v1alpha1.PathModifierType(m.Type)}
+}
+
+func pathModifierFromV1alpha1(m *v1alpha1.HTTPPathModifier) *HTTPPathModifier {
+	if m == nil {
+		return nil
+	}
+
+	return &HTTPPathModifier{Replacement: m.Replacement, Type: PathModifierType(m.Type)}
+}
+
+func backendGroupToV1alpha1(g BackendGroup) v1alpha1.BackendGroup {
+	return v1alpha1.BackendGroup{
+		Route:       v1alpha1.ObjectReference{Namespace: g.Source.Namespace, Name: g.Source.Name},
+		Backends:    convertSlice(g.Backends, backendToV1alpha1),
+		RuleIdx:     g.RuleIdx,
+		PathRuleIdx: g.PathRuleIdx,
+	}
+}
+
+func backendGroupFromV1alpha1(g v1alpha1.BackendGroup) BackendGroup {
+	return BackendGroup{
+		Source:      types.NamespacedName{Namespace: g.Route.Namespace, Name: g.Route.Name},
+		Backends:    convertSlice(g.Backends, backendFromV1alpha1),
+		RuleIdx:     g.RuleIdx,
+		PathRuleIdx: g.PathRuleIdx,
+	}
+}
+
+func backendToV1alpha1(b Backend) v1alpha1.Backend {
+	return v1alpha1.Backend{
+		UpstreamName:     b.UpstreamName,
+		ExternalHostname: b.ExternalHostname,
+		Weight:           b.Weight,
+		Valid:            b.Valid,
+	}
+}
+
+func backendFromV1alpha1(b v1alpha1.Backend) Backend {
+	return Backend{
+		UpstreamName:     b.UpstreamName,
+		ExternalHostname: b.ExternalHostname,
+		Weight:           b.Weight,
+		Valid:            b.Valid,
+	}
+}
+
+func upstreamToV1alpha1(u Upstream) v1alpha1.Upstream {
+	return v1alpha1.Upstream{
+		Name:      u.Name,
+		ErrorMsg:  u.ErrorMsg,
+		Endpoints: convertSlice(u.Endpoints, func(e resolver.Endpoint) v1alpha1.Endpoint { return v1alpha1.Endpoint(e) }),
+	}
+}
+
+func upstreamFromV1alpha1(u v1alpha1.Upstream) Upstream {
+	return Upstream{
+		Name:      u.Name,
+		ErrorMsg:  u.ErrorMsg,
+		Endpoints: convertSlice(u.Endpoints, func(e v1alpha1.Endpoint) resolver.Endpoint { return resolver.Endpoint(e) }),
+	}
+}
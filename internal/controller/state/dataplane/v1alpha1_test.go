@@ -0,0 +1,368 @@
+package dataplane
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies/policiesfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/pkg/dataplane/v1alpha1"
+)
+
+func TestV1alpha1Conversion(t *testing.T) {
+	t.Parallel()
+
+	group := BackendGroup{
+		Source: types.NamespacedName{Namespace: "test", Name: "hr"},
+		Backends: []Backend{
+			{UpstreamName: "test_foo_80", Weight: 1, Valid: true},
+			{UpstreamName: "test_bar_80", ExternalHostname: "bar.example.com", Weight: 0, Valid: false},
+		},
+		RuleIdx: 1,
+	}
+
+	conf := Configuration{
+		HTTPServers: []VirtualServer{
+			{IsDefault: true, Port: 80},
+			{
+				Hostname: "foo.example.com",
+				Port:     80,
+				PathRules: []PathRule{
+					{
+						Path:     "/",
+						PathType: PathTypePrefix,
+						MatchRules: []MatchRule{
+							{
+								Source: &metav1.ObjectMeta{Namespace: "test", Name: "hr"},
+								Match: Match{
+									Method:      helpers.GetPointer("GET"),
+									Headers:     []HTTPHeaderMatch{{Name: "version", Value: "v1", Type: MatchTypeExact}},
+									QueryParams: []HTTPQueryParamMatch{{Name: "q", Value: "^a", Type: MatchTypeRegularExpression}},
+								},
+								Filters: HTTPFilters{
+									RequestRedirect: &HTTPRequestRedirectFilter{
+										Scheme:     helpers.GetPointer("https"),
+										Port:       helpers.GetPointer[int32](443),
+										StatusCode: helpers.GetPointer(301),
+										Path:       &HTTPPathModifier{Type: ReplaceFullPath, Replacement: "/full"},
+									},
+									RequestURLRewrite: &HTTPURLRewriteFilter{
+										Hostname: helpers.GetPointer("bar.example.com"),
+										Path:     &HTTPPathModifier{Type: ReplacePrefixMatch, Replacement: "/prefix"},
+									},
+									RequestHeaderModifiers: &HTTPHeaderFilter{
+										Set:    []HTTPHeader{{Name: "X-Set", Value: "set"}},
+										Add:    []HTTPHeader{{Name: "X-Add", Value: "add"}},
+										Remove: []string{"X-Remove"},
+									},
+									ResponseHeaderModifiers: &HTTPHeaderFilter{Remove: []string{"Server"}},
+								},
+								BackendGroup: group,
+							},
+						},
+					},
+				},
+			},
+		},
+		SSLServers: []VirtualServer{
+			{
+				Hostname: "foo.example.com",
+				SSL:      &SSL{KeyPairID: "ssl_keypair_test_secret"},
+				Port:     443,
+				PathRules: []PathRule{
+					{
+						Path:     "/grpc",
+						PathType: PathTypeExact,
+						GRPC:     true,
+						MatchRules: []MatchRule{
+							{
+								Source:       &metav1.ObjectMeta{Namespace: "test", Name: "hr"},
+								Filters:      HTTPFilters{InvalidFilter: &InvalidHTTPFilter{}},
+								BackendGroup: group,
+							},
+						},
+					},
+				},
+			},
+		},
+		TLSPassthroughServers: []Layer4VirtualServer{
+			{Hostname: "tls.example.com", UpstreamName: "test_tls_443", Port: 443},
+		},
+		Upstreams: []Upstream{
+			{
+				Name: "test_foo_80",
+				Endpoints: []resolver.Endpoint{
+					{Address: "10.0.0.1", Port: 8080},
+					{Address: "fd00::1", Port: 8080, IPv6: true},
+				},
+			},
+			{
+				Name:      "test_bar_80",
+				ErrorMsg:  "no endpoints",
+				Endpoints: []resolver.Endpoint{{Address: "bar.example.com", Port: 80, Resolve: true}},
+			},
+		},
+		StreamUpstreams: []Upstream{
+			{Name: "test_tls_443", Endpoints: []resolver.Endpoint{{Address: "10.0.0.2", Port: 443}}},
+		},
+		BackendGroups: []BackendGroup{group},
+	}
+
+	g := NewWithT(t)
+
+	result := ToV1alpha1(conf)
+	g.Expect(result.APIVersion).To(Equal(v1alpha1.APIVersion))
+	g.Expect(result.SSLServers[0].SSLKeyPairID).To(Equal("ssl_keypair_test_secret"))
+	g.Expect(result.HTTPServers[1].PathRules[0].MatchRules[0].Route).To(Equal(
+		v1alpha1.ObjectReference{Namespace: "test", Name: "hr"},
+	))
+	g.Expect(result.SSLServers[0].PathRules[0].MatchRules[0].Filters.Invalid).To(BeTrue())
+
+	g.Expect(FromV1alpha1(result)).To(Equal(conf))
+}
+
+func TestToV1alpha1_DropsFieldsOutsideTheModel(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	conf := Configuration{
+		SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{
+			"ssl_keypair_test_secret": {Cert: []byte("cert"), Key: []byte("key")},
+		},
+		HTTPServers: []VirtualServer{
+			{
+				Hostname: "foo.example.com",
+				Policies: []policies.Policy{&policiesfakes.FakePolicy{}},
+				PathRules: []PathRule{
+					{
+						Path:                 "/",
+						PathType:             PathTypePrefix,
+						HasInferenceBackends: true,
+						MatchRules: []MatchRule{
+							{
+								Filters: HTTPFilters{
+									RequestMirrors:  []*HTTPRequestMirrorFilter{{Name: helpers.GetPointer("mirror")}},
+									SnippetsFilters: []SnippetsFilter{{LocationSnippet: &Snippet{Name: "snippet"}}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Upstreams: []Upstream{
+			{
+				Name:               "test_foo_80",
+				StateFileKey:       "test_foo_80",
+				SessionPersistence: SessionPersistenceConfig{Name: "session"},
+			},
+		},
+		WorkerConnections: 1024,
+	}
+
+	expected := v1alpha1.Configuration{
+		APIVersion: v1alpha1.APIVersion,
+		HTTPServers: []v1alpha1.VirtualServer{
+			{
+				Hostname: "foo.example.com",
+				PathRules: []v1alpha1.PathRule{
+					{
+						Path:       "/",
+						PathType:   v1alpha1.PathTypePrefix,
+						MatchRules: []v1alpha1.MatchRule{{}},
+					},
+				},
+			},
+		},
+		Upstreams: []v1alpha1.Upstream{{Name: "test_foo_80"}},
+	}
+
+	g.Expect(ToV1alpha1(conf)).To(Equal(expected))
+}
+
+func TestApplyV1alpha1(t *testing.T) {
+	t.Parallel()
+
+	policy := &policiesfakes.FakePolicy{}
+	verifyTLS := &VerifyTLS{Hostname: "foo.example.com", CertBundleID: "cert_bundle_test_ca"}
+	mirror := &HTTPRequestMirrorFilter{Name: helpers.GetPointer("mirror"), Namespace: helpers.GetPointer("test")}
+
+	group := BackendGroup{
+		Source:   types.NamespacedName{Namespace: "test", Name: "hr"},
+		Backends: []Backend{{UpstreamName: "test_foo_80", VerifyTLS: verifyTLS, Weight: 1, Valid: true}},
+	}
+
+	newConf := func() Configuration {
+		return Configuration{
+			SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{"ssl_keypair_test_secret": {}},
+			SSLServers: []VirtualServer{
+				{
+					Hostname: "foo.example.com",
+					SSL:      &SSL{KeyPairID: "ssl_keypair_test_secret"},
+					Policies: []policies.Policy{policy},
+					Port:     443,
+					PathRules: []PathRule{
+						{
+							Path:     "/",
+							PathType: PathTypePrefix,
+							Policies: []policies.Policy{policy},
+							MatchRules: []MatchRule{
+								{
+									Source: &metav1.ObjectMeta{
+										Namespace:   "test",
+										Name:        "hr",
+										Annotations: map[string]string{"key": "value"},
+									},
+									Filters: HTTPFilters{
+										RequestMirrors:  []*HTTPRequestMirrorFilter{mirror},
+										SnippetsFilters: []SnippetsFilter{{LocationSnippet: &Snippet{Name: "snippet"}}},
+									},
+									BackendGroup: group,
+								},
+							},
+						},
+					},
+				},
+			},
+			Upstreams: []Upstream{
+				{
+					Name:               "test_foo_80",
+					StateFileKey:       "test_foo_80",
+					SessionPersistence: SessionPersistenceConfig{Name: "session", SessionType: CookieBasedSessionPersistence},
+					Policies:           []policies.Policy{policy},
+					Endpoints:          []resolver.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+				},
+			},
+			BackendGroups:     []BackendGroup{group},
+			WorkerConnections: 1024,
+		}
+	}
+
+	tests := []struct {
+		mutate      func(*v1alpha1.Configuration)
+		expected    func() Configuration
+		name        string
+		expectedErr string
+	}{
+		{
+			name:     "unchanged model",
+			mutate:   func(*v1alpha1.Configuration) {},
+			expected: newConf,
+		},
+		{
+			name: "mutated model keeps the state outside of the model",
+			mutate: func(model *v1alpha1.Configuration) {
+				model.SSLServers[0].PathRules[0].MatchRules[0].Filters.ResponseHeaderModifiers = &v1alpha1.HTTPHeaderFilter{
+					Set: []v1alpha1.HTTPHeader{{Name: "X-Hook", Value: "true"}},
+				}
+				model.SSLServers[0].PathRules = append(model.SSLServers[0].PathRules, v1alpha1.PathRule{
+					Path:     "/new",
+					PathType: v1alpha1.PathTypeExact,
+					MatchRules: []v1alpha1.MatchRule{
+						{
+							Route: v1alpha1.ObjectReference{Namespace: "hook", Name: "route"},
+							BackendGroup: v1alpha1.BackendGroup{
+								Route:    v1alpha1.ObjectReference{Namespace: "hook", Name: "route"},
+								Backends: []v1alpha1.Backend{{UpstreamName: "test_foo_80", Weight: 1, Valid: true}},
+							},
+						},
+					},
+				})
+				model.Upstreams[0].Endpoints = append(model.Upstreams[0].Endpoints, v1alpha1.Endpoint{
+					Address: "10.0.0.2",
+					Port:    8080,
+				})
+			},
+			expected: func() Configuration {
+				conf := newConf()
+
+				conf.SSLServers[0].PathRules[0].MatchRules[0].Filters.ResponseHeaderModifiers = &HTTPHeaderFilter{
+					Set: []HTTPHeader{{Name: "X-Hook", Value: "true"}},
+				}
+				conf.SSLServers[0].PathRules = append(conf.SSLServers[0].PathRules, PathRule{
+					Path:     "/new",
+					PathType: PathTypeExact,
+					MatchRules: []MatchRule{
+						{
+							Source: &metav1.ObjectMeta{Namespace: "hook", Name: "route"},
+							BackendGroup: BackendGroup{
+								Source: types.NamespacedName{Namespace: "hook", Name: "route"},
+								Backends: []Backend{
+									{UpstreamName: "test_foo_80", VerifyTLS: verifyTLS, Weight: 1, Valid: true},
+								},
+							},
+						},
+					},
+				})
+				conf.Upstreams[0].Endpoints = append(conf.Upstreams[0].Endpoints, resolver.Endpoint{
+					Address: "10.0.0.2",
+					Port:    8080,
+				})
+
+				return conf
+			},
+		},
+		{
+			name: "removed server",
+			mutate: func(model *v1alpha1.Configuration) {
+				model.SSLServers = nil
+			},
+			expected: func() Configuration {
+				conf := newConf()
+				conf.SSLServers = nil
+
+				return conf
+			},
+		},
+		{
+			name: "unsupported apiVersion",
+			mutate: func(model *v1alpha1.Configuration) {
+				model.APIVersion = "v2"
+				model.SSLServers = nil
+			},
+			expected:    newConf,
+			expectedErr: `unsupported apiVersion "v2", expected "v1alpha1"`,
+		},
+		{
+			name: "unknown SSL key pair",
+			mutate: func(model *v1alpha1.Configuration) {
+				model.SSLServers[0].SSLKeyPairID = "ssl_keypair_test_unknown"
+			},
+			expected:    newConf,
+			expectedErr: `server foo.example.com:443 refers to unknown SSL key pair "ssl_keypair_test_unknown"`,
+		},
+		{
+			name: "unknown upstream",
+			mutate: func(model *v1alpha1.Configuration) {
+				model.SSLServers[0].PathRules[0].MatchRules[0].BackendGroup.Backends[0].UpstreamName = "unknown"
+			},
+			expected:    newConf,
+			expectedErr: `backend group of route test/hr refers to unknown upstream "unknown"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			conf := newConf()
+			model := ToV1alpha1(conf)
+			test.mutate(&model)
+
+			err := ApplyV1alpha1(&conf, model)
+			if test.expectedErr != "" {
+				g.Expect(err).To(MatchError(test.expectedErr))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			g.Expect(conf).To(Equal(test.expected()))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package dataplane
+
+import (
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// BackendWeightKey identifies a Backend of a rule of a Route.
+type BackendWeightKey struct {
+	// UpstreamName is the name of the upstream of the Backend.
+	UpstreamName string
+	// RuleIdx is the index of the rule in the Route.
+	RuleIdx int
+}
+
+// BackendWeights are the weights of the Backends of the rules of a Route.
+type BackendWeights map[BackendWeightKey]int32
+
+// OverrideBackendWeights overrides the weights of the Backends of the Routes with the given weights, keyed by
+// the NamespacedName of the Route. The Backends without an overriding weight keep their weight.
+func OverrideBackendWeights(conf *Configuration, overrides map[types.NamespacedName]BackendWeights) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	overrideServers := func(servers []VirtualServer) {
+		for i := range servers {
+			for j := range servers[i].PathRules {
+				matchRules := servers[i].PathRules[j].MatchRules
+				for k := range matchRules {
+					weights, exists := overrides[matchRules[k].BackendGroup.Source]
+					if !exists {
+						continue
+					}
+
+					matchRules[k].BackendGroup = overrideBackendGroupWeights(matchRules[k].BackendGroup, weights)
+				}
+			}
+		}
+	}
+
+	overrideServers(conf.HTTPServers)
+	overrideServers(conf.SSLServers)
+
+	conf.BackendGroups = buildBackendGroups(append(slices.Clone(conf.HTTPServers), conf.SSLServers...))
+}
+
+func overrideBackendGroupWeights(group BackendGroup, weights BackendWeights) BackendGroup {
+	backends := slices.Clone(group.Backends)
+	for i := range backends {
+		key := BackendWeightKey{UpstreamName: backends[i].UpstreamName, RuleIdx: group.RuleIdx}
+		if weight, exists := weights[key]; exists {
+			backends[i].Weight = weight
+		}
+	}
+
+	group.Backends = backends
+
+	return group
+}
+
+// MergeBackendWeights merges the weight overrides of the Routes. For the same Backend, the weight of a later
+// override takes precedence over the weight of an earlier one.
+func MergeBackendWeights(overrides ...map[types.NamespacedName]BackendWeights) map[types.NamespacedName]BackendWeights {
+	merged := make(map[types.NamespacedName]BackendWeights)
+
+	for _, override := range overrides {
+		for route, weights := range override {
+			if merged[route] == nil {
+				merged[route] = make(BackendWeights, len(weights))
+			}
+
+			maps.Copy(merged[route], weights)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,94 @@
+package dataplane
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestOverrideBackendWeights(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	route := types.NamespacedName{Namespace: "test", Name: "route"}
+	otherRoute := types.NamespacedName{Namespace: "test", Name: "other-route"}
+
+	createGroup := func(source types.NamespacedName, ruleIdx int) BackendGroup {
+		return BackendGroup{
+			Source:  source,
+			RuleIdx: ruleIdx,
+			Backends: []Backend{
+				{UpstreamName: "test_stable_80", Weight: 50, Valid: true},
+				{UpstreamName: "test_canary_80", Weight: 50, Valid: true},
+			},
+		}
+	}
+
+	createServer := func(groups ...BackendGroup) VirtualServer {
+		matchRules := make([]MatchRule, 0, len(groups))
+		for _, group := range groups {
+			matchRules = append(matchRules, MatchRule{BackendGroup: group})
+		}
+
+		return VirtualServer{PathRules: []PathRule{{MatchRules: matchRules}}}
+	}
+
+	conf := Configuration{
+		HTTPServers: []VirtualServer{createServer(createGroup(route, 0), createGroup(route, 1))},
+		SSLServers:  []VirtualServer{createServer(createGroup(route, 0), createGroup(otherRoute, 0))},
+	}
+	conf.BackendGroups = buildBackendGroups(append(conf.HTTPServers, conf.SSLServers...))
+	original := conf.BackendGroups
+
+	OverrideBackendWeights(&conf, map[types.NamespacedName]BackendWeights{
+		route: {
+			{UpstreamName: "test_stable_80", RuleIdx: 0}: 100,
+			{UpstreamName: "test_canary_80", RuleIdx: 0}: 0,
+		},
+	})
+
+	overridden := BackendGroup{
+		Source: route,
+		Backends: []Backend{
+			{UpstreamName: "test_stable_80", Weight: 100, Valid: true},
+			{UpstreamName: "test_canary_80", Weight: 0, Valid: true},
+		},
+	}
+
+	g.Expect(conf.HTTPServers[0].PathRules[0].MatchRules[0].BackendGroup).To(Equal(overridden))
+	g.Expect(conf.SSLServers[0].PathRules[0].MatchRules[0].BackendGroup).To(Equal(overridden))
+	g.Expect(conf.BackendGroups).To(ConsistOf(overridden, createGroup(route, 1), createGroup(otherRoute, 0)))
+
+	// the backends of the original groups are not modified
+	g.Expect(original).To(ConsistOf(createGroup(route, 0), createGroup(route, 1), createGroup(otherRoute, 0)))
+}
+
+func TestMergeBackendWeights(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	route := types.NamespacedName{Namespace: "test", Name: "route"}
+	otherRoute := types.NamespacedName{Namespace: "test", Name: "other-route"}
+
+	v1 := BackendWeightKey{UpstreamName: "test_v1_80", RuleIdx: 0}
+	v2 := BackendWeightKey{UpstreamName: "test_v2_80", RuleIdx: 0}
+
+	rampUp := map[types.NamespacedName]BackendWeights{
+		route:      {v2: 10},
+		otherRoute: {v2: 0},
+	}
+	canary := map[types.NamespacedName]BackendWeights{
+		route: {v1: 100, v2: 0},
+	}
+
+	g.Expect(MergeBackendWeights(rampUp, canary)).To(Equal(map[types.NamespacedName]BackendWeights{
+		route:      {v1: 100, v2: 0},
+		otherRoute: {v2: 0},
+	}))
+
+	// the merged overrides don't share the weights of the original overrides
+	g.Expect(rampUp[route]).To(Equal(BackendWeights{v2: 10}))
+
+	g.Expect(MergeBackendWeights()).To(BeEmpty())
+}
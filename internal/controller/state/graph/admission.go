@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
+)
+
+// AdmissionResult is the result of the validation of a resource at admission.
+type AdmissionResult struct {
+	// Errors are the errors that make the resource, or any of its rules, invalid.
+	Errors field.ErrorList
+	// Warnings are the errors of the unsupported fields, which are ignored.
+	Warnings field.ErrorList
+}
+
+// ValidateHTTPRouteForAdmission validates an HTTPRoute with the same rules as the Graph, but without the cluster
+// state, so that the invalid HTTPRoutes can be rejected at admission. The references to other resources, like
+// the filters and backends, are not resolved.
+func ValidateHTTPRouteForAdmission(
+	validator validation.HTTPFieldsValidator,
+	route *v1.HTTPRoute,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
+	featureFlags FeatureFlags,
+) AdmissionResult {
+	result := AdmissionResult{
+		Errors: getHostnamesErrors(route.Spec.Hostnames, field.NewPath("spec").Child("hostnames")),
+	}
+
+	nsName := types.NamespacedName{Namespace: route.Namespace, Name: route.Name}
+	for ruleIdx, rule := range route.Spec.Rules {
+		_, errs := processHTTPRouteRule(
+			rule,
+			ruleIdx,
+			validator,
+			resolveAnyExtRefFilter,
+			nil,
+			nsName,
+			unknownFilterPolicy,
+			featureFlags,
+		)
+		result.Errors = append(result.Errors, errs.invalid...)
+		result.Warnings = append(result.Warnings, errs.warn...)
+	}
+
+	return result
+}
+
+// ValidateGRPCRouteForAdmission validates a GRPCRoute with the same rules as the Graph, but without the cluster
+// state, so that the invalid GRPCRoutes can be rejected at admission. The references to other resources, like
+// the filters and backends, are not resolved.
+func ValidateGRPCRouteForAdmission(
+	validator validation.HTTPFieldsValidator,
+	route *v1.GRPCRoute,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
+	featureFlags FeatureFlags,
+) AdmissionResult {
+	result := AdmissionResult{
+		Errors: getHostnamesErrors(route.Spec.Hostnames, field.NewPath("spec").Child("hostnames")),
+	}
+
+	nsName := types.NamespacedName{Namespace: route.Namespace, Name: route.Name}
+	for ruleIdx, rule := range route.Spec.Rules {
+		_, errs := processGRPCRouteRule(
+			rule,
+			ruleIdx,
+			validator,
+			resolveAnyExtRefFilter,
+			nsName,
+			unknownFilterPolicy,
+			featureFlags,
+		)
+		result.Errors = append(result.Errors, errs.invalid...)
+		result.Warnings = append(result.Warnings, errs.warn...)
+	}
+
+	return result
+}
+
+// ValidateSnippetsFilterForAdmission validates a SnippetsFilter with the same rules as the Graph.
+func ValidateSnippetsFilterForAdmission(filter *ngfAPI.SnippetsFilter) error {
+	if cond := validateSnippetsFilter(filter); cond != nil {
+		return errors.New(cond.Message)
+	}
+
+	return nil
+}
+
+// resolveAnyExtRefFilter resolves every reference to a valid filter. It is used at admission, when the referenced
+// filters might not exist yet.
+func resolveAnyExtRefFilter(v1.LocalObjectReference) *ExtensionRefFilter {
+	return &ExtensionRefFilter{Valid: true}
+}
@@ -0,0 +1,231 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation/validationfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+func TestValidateHTTPRouteForAdmission(t *testing.T) {
+	t.Parallel()
+
+	createRoute := func(hostname v1.Hostname, rule v1.HTTPRouteRule) *v1.HTTPRoute {
+		return &v1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"},
+			Spec: v1.HTTPRouteSpec{
+				Hostnames: []v1.Hostname{hostname},
+				Rules:     []v1.HTTPRouteRule{rule},
+			},
+		}
+	}
+
+	snippetsFilterRef := v1.HTTPRouteFilter{
+		Type: v1.HTTPRouteFilterExtensionRef,
+		ExtensionRef: &v1.LocalObjectReference{
+			Group: ngfAPI.GroupName,
+			Kind:  kinds.SnippetsFilter,
+			Name:  "does-not-exist-yet",
+		},
+	}
+
+	unknownFilterRef := v1.HTTPRouteFilter{
+		Type: v1.HTTPRouteFilterExtensionRef,
+		ExtensionRef: &v1.LocalObjectReference{
+			Group: ngfAPI.GroupName,
+			Kind:  "UnknownFilter",
+			Name:  "filter",
+		},
+	}
+
+	tests := []struct {
+		route          *v1.HTTPRoute
+		name           string
+		policy         ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy
+		expErrFields   []string
+		expWarnFields  []string
+		invalidMatches bool
+	}{
+		{
+			name: "valid route with a reference to a SnippetsFilter that doesn't exist",
+			route: createRoute("foo.example.com", v1.HTTPRouteRule{
+				Filters: []v1.HTTPRouteFilter{snippetsFilterRef},
+			}),
+		},
+		{
+			name:         "invalid hostname",
+			route:        createRoute("foo.example.com:80", v1.HTTPRouteRule{}),
+			expErrFields: []string{"spec.hostnames[0]"},
+		},
+		{
+			name: "unsupported filter",
+			route: createRoute("foo.example.com", v1.HTTPRouteRule{
+				Filters: []v1.HTTPRouteFilter{{Type: v1.HTTPRouteFilterCORS}},
+			}),
+			expErrFields: []string{"spec.rules[0].filters[0].type"},
+		},
+		{
+			name: "unsupported extension filter kind",
+			route: createRoute("foo.example.com", v1.HTTPRouteRule{
+				Filters: []v1.HTTPRouteFilter{unknownFilterRef},
+			}),
+			policy:       ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+			expErrFields: []string{"spec.rules[0].filters[0].extensionRef"},
+		},
+		{
+			name: "unsupported extension filter kind with the Warn policy",
+			route: createRoute("foo.example.com", v1.HTTPRouteRule{
+				Filters: []v1.HTTPRouteFilter{unknownFilterRef},
+			}),
+			policy:        ngfAPIv1alpha2.UnknownExtensionRefFilterWarn,
+			expWarnFields: []string{"spec.rules[0].filters[0].extensionRef"},
+		},
+		{
+			name: "unsupported extension filter kind with the Ignore policy",
+			route: createRoute("foo.example.com", v1.HTTPRouteRule{
+				Filters: []v1.HTTPRouteFilter{unknownFilterRef},
+			}),
+			policy: ngfAPIv1alpha2.UnknownExtensionRefFilterIgnore,
+		},
+		{
+			name:           "invalid match",
+			invalidMatches: true,
+			route: createRoute("foo.example.com", v1.HTTPRouteRule{
+				Matches: []v1.HTTPRouteMatch{
+					{
+						Path: &v1.HTTPPathMatch{
+							Type:  helpers.GetPointer(v1.PathMatchPathPrefix),
+							Value: helpers.GetPointer("/invalid"),
+						},
+					},
+				},
+			}),
+			expErrFields: []string{"spec.rules[0].matches[0].path.value"},
+		},
+		{
+			name: "unsupported field",
+			route: createRoute("foo.example.com", v1.HTTPRouteRule{
+				Retry: &v1.HTTPRouteRetry{},
+			}),
+			expWarnFields: []string{"spec.rules[0].retry"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			validator := &validationfakes.FakeHTTPFieldsValidator{}
+			if test.invalidMatches {
+				validator.ValidatePathInMatchReturns(errors.New("invalid path value"))
+			}
+
+			result := ValidateHTTPRouteForAdmission(validator, test.route, test.policy, FeatureFlags{})
+
+			g.Expect(errorFields(result.Errors)).To(Equal(test.expErrFields))
+			g.Expect(errorFields(result.Warnings)).To(Equal(test.expWarnFields))
+		})
+	}
+}
+
+func TestValidateGRPCRouteForAdmission(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		route        *v1.GRPCRoute
+		name         string
+		expErrFields []string
+	}{
+		{
+			name: "valid route",
+			route: &v1.GRPCRoute{
+				Spec: v1.GRPCRouteSpec{
+					Hostnames: []v1.Hostname{"foo.example.com"},
+					Rules: []v1.GRPCRouteRule{
+						{
+							Filters: []v1.GRPCRouteFilter{
+								{
+									Type: v1.GRPCRouteFilterRequestHeaderModifier,
+									RequestHeaderModifier: &v1.HTTPHeaderFilter{
+										Remove: []string{"X-Remove"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid hostname and unsupported filter",
+			route: &v1.GRPCRoute{
+				Spec: v1.GRPCRouteSpec{
+					Hostnames: []v1.Hostname{"*"},
+					Rules: []v1.GRPCRouteRule{
+						{Filters: []v1.GRPCRouteFilter{{Type: "URLRewrite"}}},
+					},
+				},
+			},
+			expErrFields: []string{"spec.hostnames[0]", "spec.rules[0].filters[0].type"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			result := ValidateGRPCRouteForAdmission(
+				&validationfakes.FakeHTTPFieldsValidator{},
+				test.route,
+				ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+				FeatureFlags{},
+			)
+
+			g.Expect(errorFields(result.Errors)).To(Equal(test.expErrFields))
+			g.Expect(result.Warnings).To(BeEmpty())
+		})
+	}
+}
+
+func TestValidateSnippetsFilterForAdmission(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	valid := &ngfAPI.SnippetsFilter{
+		Spec: ngfAPI.SnippetsFilterSpec{
+			Snippets: []ngfAPI.Snippet{{Context: ngfAPI.NginxContextHTTP, Value: "log_format custom 'custom';"}},
+		},
+	}
+	g.Expect(ValidateSnippetsFilterForAdmission(valid)).To(Succeed())
+
+	invalid := &ngfAPI.SnippetsFilter{
+		Spec: ngfAPI.SnippetsFilterSpec{
+			Snippets: []ngfAPI.Snippet{{Context: ngfAPI.NginxContextHTTP}},
+		},
+	}
+	g.Expect(ValidateSnippetsFilterForAdmission(invalid)).To(MatchError(ContainSubstring("value cannot be empty")))
+}
+
+func errorFields(errs []*field.Error) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(errs))
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+
+	return fields
+}
@@ -3,6 +3,7 @@ package graph
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
 	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	sort "github.com/nginx/nginx-gateway-fabric/v2/internal/controller/ngfsort"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
@@ -47,15 +49,29 @@ type BackendRef struct {
 	Valid bool
 	// IsMirrorBackend indicates whether the BackendGroup is for a mirrored backend.
 	IsMirrorBackend bool
+	// StaticEndpoints are the endpoints of the Backend referenced by the backendRef. If set, SvcNsName
+	// is the NamespacedName of the Backend instead of a Service.
+	StaticEndpoints []ngfAPIv1alpha1.BackendEndpoint
 	// IsInferencePool indicates whether the BackendRef is for an InferencePool.
 	IsInferencePool bool
 }
 
 // BaseServicePortKey returns a base unique string key for the Service port of the BackendRef.
 func (b BackendRef) BaseServicePortKey() string {
+	if b.IsStaticBackend() {
+		// Service keys always end with the port, so the suffix ensures the key doesn't clash with a Service
+		// of the same name.
+		return fmt.Sprintf("%s_%s_backend", b.SvcNsName.Namespace, b.SvcNsName.Name)
+	}
+
 	return fmt.Sprintf("%s_%s_%d", b.SvcNsName.Namespace, b.SvcNsName.Name, b.ServicePort.Port)
 }
 
+// IsStaticBackend returns whether the BackendRef references a Backend with static endpoints.
+func (b BackendRef) IsStaticBackend() bool {
+	return len(b.StaticEndpoints) > 0
+}
+
 // ServicePortReference returns a unique string reference for the Service port of the BackendRef including
 // session persistence index if applicable.
 func (b BackendRef) ServicePortReference() string {
@@ -77,9 +93,10 @@ func addBackendRefsToRouteRules(
 	services map[types.NamespacedName]*v1.Service,
 	referencedInferencePools map[types.NamespacedName]*ReferencedInferencePool,
 	backendTLSPolicies map[types.NamespacedName]*BackendTLSPolicy,
+	backends map[types.NamespacedName]*ngfAPIv1alpha1.Backend,
 ) {
 	for _, r := range routes {
-		addBackendRefsToRules(r, refGrantResolver, services, referencedInferencePools, backendTLSPolicies)
+		addBackendRefsToRules(r, refGrantResolver, services, referencedInferencePools, backendTLSPolicies, backends)
 	}
 }
 
@@ -93,6 +110,7 @@ func addBackendRefsToRules(
 	services map[types.NamespacedName]*v1.Service,
 	referencedInferencePools map[types.NamespacedName]*ReferencedInferencePool,
 	backendTLSPolicies map[types.NamespacedName]*BackendTLSPolicy,
+	backends map[types.NamespacedName]*ngfAPIv1alpha1.Backend,
 ) {
 	if !route.Valid {
 		return
@@ -121,6 +139,14 @@ func addBackendRefsToRules(
 			}
 			routeNs := route.Source.GetNamespace()
 
+			if isStaticBackendRef(ref.BackendRef) {
+				backendRef, conds := createStaticBackendRef(ref, route, backends, refPath)
+
+				backendRefs = append(backendRefs, backendRef)
+				route.Conditions = append(route.Conditions, conds...)
+				continue
+			}
+
 			// if we have an InferencePool backend disguised as a Service, set the port value
 			if ref.IsInferencePool {
 				namespace := routeNs
@@ -336,7 +362,7 @@ func createBackendRef(
 // validateBackendTLSPolicyMatchingAllBackends validates that all backends in a rule reference the same
 // BackendTLSPolicy. We require that all backends in a group have the same backend TLS policy configuration.
 // The backend TLS policy configuration is considered matching if: 1. CACertRefs reference the same ConfigMap, or
-// 2. WellKnownCACerts are the same, and 3. Hostname is the same.
+// 2. WellKnownCACerts are the same, and 3. Hostname is the same, and 4. Options are the same.
 // FIXME (ciarams87): This is a temporary solution until we can support multiple backend TLS policies per group.
 // https://github.com/nginx/nginx-gateway-fabric/issues/1546
 func validateBackendTLSPolicyMatchingAllBackends(backendRefs []BackendRef) *conditions.Condition {
@@ -346,7 +372,8 @@ func validateBackendTLSPolicyMatchingAllBackends(backendRefs []BackendRef) *cond
 	checkPoliciesEqual := func(p1, p2 *gatewayv1.BackendTLSPolicy) bool {
 		return !slices.Equal(p1.Spec.Validation.CACertificateRefs, p2.Spec.Validation.CACertificateRefs) ||
 			p1.Spec.Validation.WellKnownCACertificates != p2.Spec.Validation.WellKnownCACertificates ||
-			p1.Spec.Validation.Hostname != p2.Spec.Validation.Hostname
+			p1.Spec.Validation.Hostname != p2.Spec.Validation.Hostname ||
+			!maps.Equal(p1.Spec.Options, p2.Spec.Options)
 	}
 
 	for _, backendRef := range backendRefs {
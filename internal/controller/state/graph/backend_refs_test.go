@@ -1270,7 +1270,7 @@ func TestAddBackendRefsToRules(t *testing.T) {
 				},
 			}
 
-			addBackendRefsToRules(test.route, resolver, services, referencedInferencePools, test.policies)
+			addBackendRefsToRules(test.route, resolver, services, referencedInferencePools, test.policies, nil)
 
 			var actual []BackendRef
 			if test.route.Spec.Rules != nil {
@@ -1938,6 +1938,20 @@ func TestValidateBackendTLSPolicyMatchingAllBackends(t *testing.T) {
 			BackendTLSPolicy: getBtp("btp2", "ca2"),
 		},
 	}
+	btpWithOptions := getBtp("btp2", "ca1")
+	btpWithOptions.Source.Spec.Options = map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+		BackendTLSOptionSSLSessionReuse: "off",
+	}
+	backendRefsWithNotMatchingOptions := []BackendRef{
+		{
+			SvcNsName:        types.NamespacedName{Namespace: "test", Name: "svc1"},
+			BackendTLSPolicy: getBtp("btp1", "ca1"),
+		},
+		{
+			SvcNsName:        types.NamespacedName{Namespace: "test", Name: "svc2"},
+			BackendTLSPolicy: btpWithOptions,
+		},
+	}
 	backendRefsOnePolicy := []BackendRef{
 		{
 			SvcNsName:        types.NamespacedName{Namespace: "test", Name: "svc1"},
@@ -1968,6 +1982,11 @@ func TestValidateBackendTLSPolicyMatchingAllBackends(t *testing.T) {
 			backendRefs:       backendRefsWithNotMatchingPolicies,
 			expectedCondition: helpers.GetPointer(conditions.NewRouteBackendRefUnsupportedValue(msg)),
 		},
+		{
+			name:              "not matching options",
+			backendRefs:       backendRefsWithNotMatchingOptions,
+			expectedCondition: helpers.GetPointer(conditions.NewRouteBackendRefUnsupportedValue(msg)),
+		},
 		{
 			name:              "only one policy",
 			backendRefs:       backendRefsOnePolicy,
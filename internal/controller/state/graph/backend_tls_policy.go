@@ -2,6 +2,7 @@ package graph
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -11,12 +12,45 @@ import (
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
 )
 
+const (
+	// BackendTLSOptionSSLSessionReuse is the BackendTLSPolicy option that enables ("on") or disables ("off")
+	// the reuse of the TLS sessions with the backends. NGINX reuses the sessions by default.
+	BackendTLSOptionSSLSessionReuse v1.AnnotationKey = "gateway.nginx.org/ssl-session-reuse"
+	// BackendTLSOptionSSLProtocols is the BackendTLSPolicy option with the space-separated list of the TLS protocols
+	// that NGINX enables for the connections to the backends, for example, "TLSv1.2 TLSv1.3".
+	BackendTLSOptionSSLProtocols v1.AnnotationKey = "gateway.nginx.org/ssl-protocols"
+	// BackendTLSOptionSSLCiphers is the BackendTLSPolicy option with the ciphers that NGINX enables for
+	// the connections to the backends, in the OpenSSL format, for example, "ECDHE-RSA-AES128-GCM-SHA256:HIGH".
+	BackendTLSOptionSSLCiphers v1.AnnotationKey = "gateway.nginx.org/ssl-ciphers"
+
+	// backendTLSOptionPrefix is the prefix of the BackendTLSPolicy options of NGINX Gateway Fabric.
+	// Options with other prefixes belong to other implementations and are ignored.
+	backendTLSOptionPrefix = "gateway.nginx.org/"
+)
+
+var (
+	supportedBackendTLSOptions = []string{
+		string(BackendTLSOptionSSLSessionReuse),
+		string(BackendTLSOptionSSLProtocols),
+		string(BackendTLSOptionSSLCiphers),
+	}
+
+	supportedBackendTLSProtocols = []string{"TLSv1", "TLSv1.1", "TLSv1.2", "TLSv1.3"}
+	// fipsBackendTLSProtocols are the TLS protocols approved for use in FIPS mode.
+	fipsBackendTLSProtocols = []string{"TLSv1.2", "TLSv1.3"}
+
+	backendTLSCiphersRegexp = regexp.MustCompile(`^[A-Za-z0-9!+@=:._-]+$`)
+)
+
 type BackendTLSPolicy struct {
 	// Source is the source resource.
 	Source *v1.BackendTLSPolicy
+	// Options holds the NGINX-specific TLS options of the policy.
+	Options BackendTLSOptions
 	// CaCertRef is the name of the ConfigMap that contains the CA certificate.
 	CaCertRef types.NamespacedName
 	// Gateways are the names of the Gateways for which this BackendTLSPolicy is effectively applied.
@@ -37,6 +71,7 @@ func processBackendTLSPolicies(
 	configMapResolver *configMapResolver,
 	secretResolver *secretResolver,
 	gateways map[types.NamespacedName]*Gateway,
+	fips bool,
 ) map[types.NamespacedName]*BackendTLSPolicy {
 	if len(backendTLSPolicies) == 0 || len(gateways) == 0 {
 		return nil
@@ -48,6 +83,12 @@ func processBackendTLSPolicies(
 
 		valid, ignored, conds := validateBackendTLSPolicy(backendTLSPolicy, configMapResolver, secretResolver)
 
+		options, optionsConds := processBackendTLSOptions(backendTLSPolicy, fips)
+		if len(optionsConds) > 0 {
+			valid = false
+			conds = append(conds, optionsConds...)
+		}
+
 		if valid && !ignored && backendTLSPolicy.Spec.Validation.CACertificateRefs != nil {
 			caCertRef = types.NamespacedName{
 				Namespace: backendTLSPolicy.Namespace, Name: string(backendTLSPolicy.Spec.Validation.CACertificateRefs[0].Name),
@@ -56,6 +97,7 @@ func processBackendTLSPolicies(
 
 		processedBackendTLSPolicies[nsname] = &BackendTLSPolicy{
 			Source:     backendTLSPolicy,
+			Options:    options,
 			Valid:      valid,
 			Conditions: conds,
 			CaCertRef:  caCertRef,
@@ -343,3 +385,102 @@ func addGatewaysForBackendTLSPolicies(
 		}
 	}
 }
+
+// BackendTLSOptions holds the NGINX-specific TLS options of a BackendTLSPolicy.
+type BackendTLSOptions struct {
+	// SessionReuse enables or disables the reuse of the TLS sessions. If nil, NGINX uses its default.
+	SessionReuse *bool
+	// Ciphers are the enabled ciphers in the OpenSSL format. If empty, NGINX uses its default.
+	Ciphers string
+	// Protocols are the enabled TLS protocols. If empty, NGINX uses its default.
+	Protocols []string
+}
+
+// processBackendTLSOptions processes the options of the BackendTLSPolicy with the NGINX Gateway Fabric prefix.
+// In FIPS mode, only the FIPS-approved protocols can be enabled, and the ciphers can't be changed.
+func processBackendTLSOptions(btp *v1.BackendTLSPolicy, fips bool) (BackendTLSOptions, []conditions.Condition) {
+	var options BackendTLSOptions
+	var conds []conditions.Condition
+
+	optionsPath := field.NewPath("spec.options")
+
+	for key, value := range btp.Spec.Options {
+		if !strings.HasPrefix(string(key), backendTLSOptionPrefix) {
+			continue
+		}
+
+		path := optionsPath.Key(string(key))
+
+		var err *field.Error
+		switch key {
+		case BackendTLSOptionSSLSessionReuse:
+			options.SessionReuse, err = processBackendTLSSessionReuse(path, string(value))
+		case BackendTLSOptionSSLProtocols:
+			options.Protocols, err = processBackendTLSProtocols(path, string(value), fips)
+		case BackendTLSOptionSSLCiphers:
+			options.Ciphers, err = processBackendTLSCiphers(path, string(value), fips)
+		default:
+			err = field.NotSupported(optionsPath, key, supportedBackendTLSOptions)
+		}
+
+		if err != nil {
+			conds = append(conds, conditions.NewPolicyInvalid(err.Error()))
+		}
+	}
+
+	// the options are a map, so the conditions are sorted to make them stable
+	slices.SortFunc(conds, func(a, b conditions.Condition) int {
+		return strings.Compare(a.Message, b.Message)
+	})
+
+	return options, conds
+}
+
+func processBackendTLSSessionReuse(path *field.Path, value string) (*bool, *field.Error) {
+	switch value {
+	case "on":
+		return helpers.GetPointer(true), nil
+	case "off":
+		return helpers.GetPointer(false), nil
+	default:
+		return nil, field.NotSupported(path, value, []string{"on", "off"})
+	}
+}
+
+func processBackendTLSProtocols(path *field.Path, value string, fips bool) ([]string, *field.Error) {
+	supported := supportedBackendTLSProtocols
+	if fips {
+		supported = fipsBackendTLSProtocols
+	}
+
+	protocols := strings.Fields(value)
+	if len(protocols) == 0 {
+		return nil, field.Required(path, "at least one protocol must be specified")
+	}
+
+	for _, protocol := range protocols {
+		if !slices.Contains(supported, protocol) {
+			return nil, field.NotSupported(path, protocol, supported)
+		}
+	}
+
+	slices.Sort(protocols)
+
+	return slices.Compact(protocols), nil
+}
+
+func processBackendTLSCiphers(path *field.Path, value string, fips bool) (string, *field.Error) {
+	if fips {
+		return "", field.Forbidden(path, "the ciphers can't be changed in FIPS mode")
+	}
+
+	if !backendTLSCiphersRegexp.MatchString(value) {
+		return "", field.Invalid(
+			path,
+			value,
+			"ciphers must be in the OpenSSL format, for example, 'ECDHE-RSA-AES128-GCM-SHA256:HIGH'",
+		)
+	}
+
+	return value, nil
+}
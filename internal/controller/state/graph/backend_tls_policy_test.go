@@ -78,7 +78,7 @@ func TestProcessBackendTLSPoliciesEmpty(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
 
-			processed := processBackendTLSPolicies(test.backendTLSPolicies, nil, nil, test.gateways)
+			processed := processBackendTLSPolicies(test.backendTLSPolicies, nil, nil, test.gateways, false)
 
 			g.Expect(processed).To(Equal(test.expected))
 		})
@@ -469,7 +469,7 @@ func TestValidateBackendTLSPolicy(t *testing.T) {
 	}
 
 	configMapResolver := newConfigMapResolver(configMaps)
-	secretMapResolver := newSecretResolver(secretMaps)
+	secretMapResolver := newSecretResolver(secretMaps, false)
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -534,6 +534,160 @@ func TestValidateBackendTLSPolicy(t *testing.T) {
 	}
 }
 
+func TestProcessBackendTLSOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		options     map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue
+		expCondMsgs []string
+		name        string
+		expOptions  BackendTLSOptions
+		fips        bool
+	}{
+		{
+			name: "no options",
+		},
+		{
+			name: "all options",
+			options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+				BackendTLSOptionSSLSessionReuse: "off",
+				BackendTLSOptionSSLProtocols:    "TLSv1.3  TLSv1.2 TLSv1.3",
+				BackendTLSOptionSSLCiphers:      "ECDHE-RSA-AES128-GCM-SHA256:HIGH:!aNULL",
+			},
+			expOptions: BackendTLSOptions{
+				SessionReuse: helpers.GetPointer(false),
+				Protocols:    []string{"TLSv1.2", "TLSv1.3"},
+				Ciphers:      "ECDHE-RSA-AES128-GCM-SHA256:HIGH:!aNULL",
+			},
+		},
+		{
+			name: "session reuse enabled",
+			options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+				BackendTLSOptionSSLSessionReuse: "on",
+			},
+			expOptions: BackendTLSOptions{
+				SessionReuse: helpers.GetPointer(true),
+			},
+		},
+		{
+			name: "options of other implementations are ignored",
+			options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+				"example.com/ssl-protocols": "SSLv3",
+				"min-tls-version":           "1.0",
+			},
+		},
+		{
+			name: "invalid options",
+			options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+				BackendTLSOptionSSLSessionReuse: "true",
+				BackendTLSOptionSSLProtocols:    "SSLv3 TLSv1.2",
+				BackendTLSOptionSSLCiphers:      "HIGH; return 200",
+				"gateway.nginx.org/unknown":     "value",
+			},
+			expCondMsgs: []string{
+				`spec.options: Unsupported value: "gateway.nginx.org/unknown"`,
+				`spec.options[gateway.nginx.org/ssl-ciphers]: Invalid value: "HIGH; return 200"`,
+				`spec.options[gateway.nginx.org/ssl-protocols]: Unsupported value: "SSLv3"`,
+				`spec.options[gateway.nginx.org/ssl-session-reuse]: Unsupported value: "true"`,
+			},
+		},
+		{
+			name: "empty protocols",
+			options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+				BackendTLSOptionSSLProtocols: " ",
+			},
+			expCondMsgs: []string{
+				"spec.options[gateway.nginx.org/ssl-protocols]: Required value",
+			},
+		},
+		{
+			name: "FIPS-approved protocols in FIPS mode",
+			options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+				BackendTLSOptionSSLProtocols: "TLSv1.3",
+			},
+			fips: true,
+			expOptions: BackendTLSOptions{
+				Protocols: []string{"TLSv1.3"},
+			},
+		},
+		{
+			name: "protocols and ciphers that are not FIPS-approved in FIPS mode",
+			options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+				BackendTLSOptionSSLProtocols: "TLSv1.1",
+				BackendTLSOptionSSLCiphers:   "HIGH",
+			},
+			fips: true,
+			expCondMsgs: []string{
+				"spec.options[gateway.nginx.org/ssl-ciphers]: Forbidden: the ciphers can't be changed in FIPS mode",
+				`spec.options[gateway.nginx.org/ssl-protocols]: Unsupported value: "TLSv1.1"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			btp := &gatewayv1.BackendTLSPolicy{
+				Spec: gatewayv1.BackendTLSPolicySpec{
+					Options: test.options,
+				},
+			}
+
+			options, conds := processBackendTLSOptions(btp, test.fips)
+
+			g.Expect(conds).To(HaveLen(len(test.expCondMsgs)))
+			for i, msg := range test.expCondMsgs {
+				g.Expect(conds[i].Type).To(Equal(string(gatewayv1.PolicyConditionAccepted)))
+				g.Expect(conds[i].Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(conds[i].Message).To(HavePrefix(msg))
+			}
+
+			if len(test.expCondMsgs) == 0 {
+				g.Expect(options).To(Equal(test.expOptions))
+			}
+		})
+	}
+}
+
+func TestProcessBackendTLSPoliciesInvalidOptions(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	nsName := types.NamespacedName{Namespace: "test", Name: "tls-policy"}
+	backendTLSPolicies := map[types.NamespacedName]*gatewayv1.BackendTLSPolicy{
+		nsName: {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nsName.Name,
+				Namespace: nsName.Namespace,
+			},
+			Spec: gatewayv1.BackendTLSPolicySpec{
+				Validation: gatewayv1.BackendTLSPolicyValidation{
+					WellKnownCACertificates: helpers.GetPointer(gatewayv1.WellKnownCACertificatesSystem),
+					Hostname:                "foo.test.com",
+				},
+				Options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+					BackendTLSOptionSSLSessionReuse: "maybe",
+				},
+			},
+		},
+	}
+
+	gateways := map[types.NamespacedName]*Gateway{
+		{Namespace: "test", Name: "gateway"}: {
+			Source: &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "test"}},
+		},
+	}
+
+	processed := processBackendTLSPolicies(backendTLSPolicies, nil, nil, gateways, false)
+
+	g.Expect(processed).To(HaveKey(nsName))
+	g.Expect(processed[nsName].Valid).To(BeFalse())
+	g.Expect(processed[nsName].Conditions).To(HaveLen(1))
+	g.Expect(processed[nsName].Conditions[0].Message).To(ContainSubstring(string(BackendTLSOptionSSLSessionReuse)))
+}
+
 func TestAddGatewaysForBackendTLSPolicies(t *testing.T) {
 	t.Parallel()
 
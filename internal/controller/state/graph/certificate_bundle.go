@@ -1,10 +1,14 @@
 package graph
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
+	"errors"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -51,6 +55,55 @@ func validateTLS(tlsCert, tlsPrivateKey []byte) error {
 	return nil
 }
 
+// fipsMinRSAKeySize is the minimum RSA key size in bits approved for use in FIPS mode.
+const fipsMinRSAKeySize = 2048
+
+// validateFIPSCertificate checks that the leaf certificate uses a FIPS-approved public key algorithm and
+// signature algorithm. It expects the certificate to have already been validated by validateTLS.
+func validateFIPSCertificate(tlsCert []byte) error {
+	block, _ := pem.Decode(tlsCert)
+	if block == nil {
+		return errors.New("tls secret is invalid: failed to decode certificate PEM block")
+	}
+
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("tls secret is invalid: %w", err)
+	}
+
+	switch pub := leaf.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if size := pub.N.BitLen(); size < fipsMinRSAKeySize {
+			return fmt.Errorf(
+				"tls secret is not FIPS compliant: RSA key size %d is less than %d bits",
+				size,
+				fipsMinRSAKeySize,
+			)
+		}
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+		default:
+			return fmt.Errorf("tls secret is not FIPS compliant: unsupported ECDSA curve %s", pub.Curve.Params().Name)
+		}
+	default:
+		return fmt.Errorf("tls secret is not FIPS compliant: unsupported public key algorithm %s", leaf.PublicKeyAlgorithm)
+	}
+
+	switch leaf.SignatureAlgorithm {
+	case x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA,
+		x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS,
+		x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512:
+	default:
+		return fmt.Errorf(
+			"tls secret is not FIPS compliant: unsupported signature algorithm %s",
+			leaf.SignatureAlgorithm,
+		)
+	}
+
+	return nil
+}
+
 // validateCA validates the ca.crt entry in the Certificate. If it is valid, the function returns nil.
 func validateCA(caData []byte) error {
 	data := make([]byte, base64.StdEncoding.DecodedLen(len(caData)))
@@ -1,8 +1,19 @@
 package graph
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 )
@@ -56,6 +67,94 @@ func TestValidateTLS(t *testing.T) {
 	}
 }
 
+func TestValidateFIPSCertificate(t *testing.T) {
+	t.Parallel()
+
+	rsa1024Key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p224Key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		expectedErr string
+		tlsCert     []byte
+	}{
+		{
+			name:    "RSA 2048 key with SHA256 signature",
+			tlsCert: cert,
+		},
+		{
+			name:    "ECDSA P-256 key",
+			tlsCert: createSelfSignedCert(t, p256Key),
+		},
+		{
+			name:        "RSA key is too small",
+			tlsCert:     createSelfSignedCert(t, rsa1024Key),
+			expectedErr: "tls secret is not FIPS compliant: RSA key size 1024 is less than 2048 bits",
+		},
+		{
+			name:        "ECDSA curve is not approved",
+			tlsCert:     createSelfSignedCert(t, p224Key),
+			expectedErr: "tls secret is not FIPS compliant: unsupported ECDSA curve P-224",
+		},
+		{
+			name:        "Ed25519 key is not approved",
+			tlsCert:     createSelfSignedCert(t, ed25519Key),
+			expectedErr: "tls secret is not FIPS compliant: unsupported public key algorithm Ed25519",
+		},
+		{
+			name:        "invalid cert",
+			tlsCert:     invalidCert,
+			expectedErr: "tls secret is invalid: x509: malformed certificate",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := NewWithT(t)
+			err := validateFIPSCertificate(test.tlsCert)
+			if test.expectedErr != "" {
+				g.Expect(err).To(MatchError(test.expectedErr))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func createSelfSignedCert(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cafe.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
 func TestValidateCA(t *testing.T) {
 	t.Parallel()
 	base64Data := make([]byte, base64.StdEncoding.EncodedLen(len(caBlock)))
@@ -0,0 +1,359 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/ngfsort"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+// ClusterDefaultPolicy represents a ngfAPIv1alpha2.ClusterDefaultPolicy.
+type ClusterDefaultPolicy struct {
+	// Source is the ClusterDefaultPolicy.
+	Source *ngfAPIv1alpha2.ClusterDefaultPolicy
+	// Selector selects the Namespaces of the ClusterDefaultPolicy.
+	Selector labels.Selector
+	// Policies are the policies that apply the defaults of the ClusterDefaultPolicy to the Routes and Services.
+	// They don't exist in the cluster, so they don't have a status.
+	Policies []*Policy
+	// Conditions define the conditions to be reported in the status of the ClusterDefaultPolicy.
+	Conditions []conditions.Condition
+	// Valid indicates whether the ClusterDefaultPolicy is valid.
+	Valid bool
+}
+
+// defaultPolicyNamePrefix prefixes the names of the policies that apply the defaults of a ClusterDefaultPolicy.
+// The underscore isn't allowed in the names of Kubernetes resources, so the names of such policies never collide
+// with the names of the policies in the Namespace.
+const defaultPolicyNamePrefix = kinds.ClusterDefaultPolicy + "_"
+
+func processClusterDefaultPolicies(
+	cdps map[types.NamespacedName]*ngfAPIv1alpha2.ClusterDefaultPolicy,
+	validator validation.PolicyValidator,
+) map[types.NamespacedName]*ClusterDefaultPolicy {
+	if len(cdps) == 0 {
+		return nil
+	}
+
+	processed := make(map[types.NamespacedName]*ClusterDefaultPolicy, len(cdps))
+
+	for nsname, cdp := range cdps {
+		processedCDP := &ClusterDefaultPolicy{
+			Source: cdp,
+			Valid:  true,
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&cdp.Spec.NamespaceSelector)
+		if err != nil {
+			processedCDP.Valid = false
+			processedCDP.Conditions = []conditions.Condition{
+				conditions.NewClusterDefaultPolicyInvalid(fmt.Sprintf("spec.namespaceSelector: %s", err)),
+			}
+		} else if cond := validateClusterDefaultPolicy(cdp, validator); cond != nil {
+			processedCDP.Valid = false
+			processedCDP.Conditions = []conditions.Condition{*cond}
+		}
+
+		processedCDP.Selector = selector
+		processed[nsname] = processedCDP
+	}
+
+	return processed
+}
+
+// validateClusterDefaultPolicy validates the defaults of the ClusterDefaultPolicy the same way as the policies
+// that apply them.
+func validateClusterDefaultPolicy(
+	cdp *ngfAPIv1alpha2.ClusterDefaultPolicy,
+	validator validation.PolicyValidator,
+) *conditions.Condition {
+	spec := cdp.Spec
+	if spec.ClientSettings == nil && spec.Observability == nil && spec.UpstreamSettings == nil {
+		cond := conditions.NewClusterDefaultPolicyInvalid(
+			"at least one of clientSettings, observability, or upstreamSettings must be set",
+		)
+		return &cond
+	}
+
+	routeRef := gatewayv1.LocalPolicyTargetReference{
+		Group: gatewayv1.GroupName,
+		Kind:  kinds.HTTPRoute,
+		Name:  gatewayv1.ObjectName(cdp.Name),
+	}
+	svcRef := gatewayv1.LocalPolicyTargetReference{
+		Kind: kinds.Service,
+		Name: gatewayv1.ObjectName(cdp.Name),
+	}
+
+	var pols []policies.Policy
+	if spec.ClientSettings != nil {
+		pols = append(pols, buildDefaultClientSettingsPolicy(cdp, "", routeRef))
+	}
+	if spec.Observability != nil {
+		pols = append(pols, buildDefaultObservabilityPolicy(cdp, "", routeRef))
+	}
+	if spec.UpstreamSettings != nil {
+		pols = append(pols, buildDefaultUpstreamSettingsPolicy(cdp, "", svcRef))
+	}
+
+	for _, pol := range pols {
+		if conds := validator.Validate(pol); len(conds) > 0 {
+			cond := conditions.NewClusterDefaultPolicyInvalid(conds[0].Message)
+			return &cond
+		}
+	}
+
+	return nil
+}
+
+// applyClusterDefaultPolicies attaches the defaults of the valid ClusterDefaultPolicies to the Routes and the Services
+// of the graph in the selected Namespaces, unless a policy of the same kind in the Namespace is attached to them.
+// If several ClusterDefaultPolicies that define the same defaults select a Namespace, the oldest one wins.
+// It modifies the graph in place, so it must be called after the policies are attached.
+func (g *Graph) applyClusterDefaultPolicies(
+	namespaces map[types.NamespacedName]*v1.Namespace,
+	validator validation.PolicyValidator,
+) {
+	cdps := make([]*ClusterDefaultPolicy, 0, len(g.ClusterDefaultPolicies))
+	for _, cdp := range g.ClusterDefaultPolicies {
+		if cdp.Valid {
+			cdps = append(cdps, cdp)
+		}
+	}
+
+	if len(cdps) == 0 {
+		return
+	}
+
+	slices.SortFunc(cdps, func(a, b *ClusterDefaultPolicy) int {
+		switch {
+		case ngfsort.LessClientObject(a.Source, b.Source):
+			return -1
+		case ngfsort.LessClientObject(b.Source, a.Source):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	// selecting returns the oldest ClusterDefaultPolicy that selects the Namespace and defines the defaults.
+	selecting := func(
+		namespace string,
+		defines func(spec ngfAPIv1alpha2.ClusterDefaultPolicySpec) bool,
+	) *ClusterDefaultPolicy {
+		ns, exists := namespaces[types.NamespacedName{Name: namespace}]
+		if !exists {
+			return nil
+		}
+
+		for _, cdp := range cdps {
+			if defines(cdp.Source.Spec) && cdp.Selector.Matches(labels.Set(ns.GetLabels())) {
+				return cdp
+			}
+		}
+
+		return nil
+	}
+
+	for _, route := range g.Routes {
+		if route.RouteType != RouteTypeHTTP && route.RouteType != RouteTypeGRPC {
+			continue
+		}
+
+		if !route.Valid || !route.Attachable || len(route.ParentRefs) == 0 {
+			continue
+		}
+
+		namespace := route.Source.GetNamespace()
+		ref := gatewayv1.LocalPolicyTargetReference{
+			Group: gatewayv1.GroupName,
+			Kind:  kinds.HTTPRoute,
+			Name:  gatewayv1.ObjectName(route.Source.GetName()),
+		}
+		if route.RouteType == RouteTypeGRPC {
+			ref.Kind = kinds.GRPCRoute
+		}
+
+		if !hasValidPolicy[*ngfAPIv1alpha1.ClientSettingsPolicy](route.Policies) {
+			hasClientSettings := func(spec ngfAPIv1alpha2.ClusterDefaultPolicySpec) bool {
+				return spec.ClientSettings != nil
+			}
+
+			if cdp := selecting(namespace, hasClientSettings); cdp != nil {
+				policy := newDefaultPolicy(buildDefaultClientSettingsPolicy(cdp.Source, namespace, ref), namespace, ref)
+				route.Policies = append(route.Policies, policy)
+				cdp.Policies = append(cdp.Policies, policy)
+			}
+		}
+
+		if !hasValidPolicy[*ngfAPIv1alpha2.ObservabilityPolicy](route.Policies) {
+			hasObservability := func(spec ngfAPIv1alpha2.ClusterDefaultPolicySpec) bool {
+				return spec.Observability != nil
+			}
+
+			if cdp := selecting(namespace, hasObservability); cdp != nil {
+				policy := newDefaultPolicy(buildDefaultObservabilityPolicy(cdp.Source, namespace, ref), namespace, ref)
+				if applyDefaultPolicyGlobalSettings(policy, route, validator) {
+					route.Policies = append(route.Policies, policy)
+					cdp.Policies = append(cdp.Policies, policy)
+				}
+			}
+		}
+	}
+
+	for svcNsName, svc := range g.ReferencedServices {
+		if hasValidPolicy[*ngfAPIv1alpha1.UpstreamSettingsPolicy](svc.Policies) {
+			continue
+		}
+
+		hasUpstreamSettings := func(spec ngfAPIv1alpha2.ClusterDefaultPolicySpec) bool {
+			return spec.UpstreamSettings != nil
+		}
+
+		if cdp := selecting(svcNsName.Namespace, hasUpstreamSettings); cdp != nil {
+			ref := gatewayv1.LocalPolicyTargetReference{
+				Kind: kinds.Service,
+				Name: gatewayv1.ObjectName(svcNsName.Name),
+			}
+
+			policy := newDefaultPolicy(
+				buildDefaultUpstreamSettingsPolicy(cdp.Source, svcNsName.Namespace, ref),
+				svcNsName.Namespace,
+				ref,
+			)
+			svc.Policies = append(svc.Policies, policy)
+			cdp.Policies = append(cdp.Policies, policy)
+		}
+	}
+}
+
+// applyDefaultPolicyGlobalSettings validates the default policy of the Route with the NginxProxy settings of
+// the Gateways of the Route, the same way as attachPolicyToRoute. It returns whether the policy is effective for
+// at least one Gateway.
+func applyDefaultPolicyGlobalSettings(policy *Policy, route *L7Route, validator validation.PolicyValidator) bool {
+	for _, parentRef := range route.ParentRefs {
+		if parentRef.Gateway == nil || parentRef.Gateway.EffectiveNginxProxy == nil {
+			continue
+		}
+
+		globalSettings := &policies.GlobalSettings{
+			TelemetryEnabled:   telemetryEnabledForNginxProxy(parentRef.Gateway.EffectiveNginxProxy),
+			DNSResolverEnabled: parentRef.Gateway.EffectiveNginxProxy.DNSResolver != nil,
+		}
+
+		if conds := validator.ValidateGlobalSettings(policy.Source, globalSettings); len(conds) > 0 {
+			policy.InvalidForGateways[parentRef.Gateway.NamespacedName] = struct{}{}
+		}
+	}
+
+	return len(policy.InvalidForGateways) < len(route.ParentRefs)
+}
+
+// hasValidPolicy returns whether a valid policy of type T is among the policies.
+func hasValidPolicy[T policies.Policy](pols []*Policy) bool {
+	for _, pol := range pols {
+		if _, ok := pol.Source.(T); ok && pol.Valid {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newDefaultPolicy(
+	source policies.Policy,
+	namespace string,
+	ref gatewayv1.LocalPolicyTargetReference,
+) *Policy {
+	return &Policy{
+		Source: source,
+		Valid:  true,
+		TargetRefs: []PolicyTargetRef{
+			{
+				Kind:   ref.Kind,
+				Group:  ref.Group,
+				Nsname: types.NamespacedName{Namespace: namespace, Name: string(ref.Name)},
+			},
+		},
+		InvalidForGateways: make(map[types.NamespacedName]struct{}),
+	}
+}
+
+func defaultPolicyObjectMeta(cdp *ngfAPIv1alpha2.ClusterDefaultPolicy, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              defaultPolicyNamePrefix + cdp.Name,
+		CreationTimestamp: cdp.CreationTimestamp,
+		Generation:        cdp.Generation,
+	}
+}
+
+func buildDefaultClientSettingsPolicy(
+	cdp *ngfAPIv1alpha2.ClusterDefaultPolicy,
+	namespace string,
+	ref gatewayv1.LocalPolicyTargetReference,
+) *ngfAPIv1alpha1.ClientSettingsPolicy {
+	return &ngfAPIv1alpha1.ClientSettingsPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ngfAPIv1alpha1.SchemeGroupVersion.String(),
+			Kind:       kinds.ClientSettingsPolicy,
+		},
+		ObjectMeta: defaultPolicyObjectMeta(cdp, namespace),
+		Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+			Body:      cdp.Spec.ClientSettings.Body,
+			KeepAlive: cdp.Spec.ClientSettings.KeepAlive,
+			TargetRef: ref,
+		},
+	}
+}
+
+func buildDefaultObservabilityPolicy(
+	cdp *ngfAPIv1alpha2.ClusterDefaultPolicy,
+	namespace string,
+	ref gatewayv1.LocalPolicyTargetReference,
+) *ngfAPIv1alpha2.ObservabilityPolicy {
+	return &ngfAPIv1alpha2.ObservabilityPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ngfAPIv1alpha2.SchemeGroupVersion.String(),
+			Kind:       kinds.ObservabilityPolicy,
+		},
+		ObjectMeta: defaultPolicyObjectMeta(cdp, namespace),
+		Spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
+			Tracing:    cdp.Spec.Observability.Tracing,
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{{LocalPolicyTargetReference: ref}},
+		},
+	}
+}
+
+func buildDefaultUpstreamSettingsPolicy(
+	cdp *ngfAPIv1alpha2.ClusterDefaultPolicy,
+	namespace string,
+	ref gatewayv1.LocalPolicyTargetReference,
+) *ngfAPIv1alpha1.UpstreamSettingsPolicy {
+	return &ngfAPIv1alpha1.UpstreamSettingsPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ngfAPIv1alpha1.SchemeGroupVersion.String(),
+			Kind:       kinds.UpstreamSettingsPolicy,
+		},
+		ObjectMeta: defaultPolicyObjectMeta(cdp, namespace),
+		Spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+			ZoneSize:            cdp.Spec.UpstreamSettings.ZoneSize,
+			KeepAlive:           cdp.Spec.UpstreamSettings.KeepAlive,
+			LoadBalancingMethod: cdp.Spec.UpstreamSettings.LoadBalancingMethod,
+			HashMethodKey:       cdp.Spec.UpstreamSettings.HashMethodKey,
+			TargetRefs:          []gatewayv1.LocalPolicyTargetReference{ref},
+		},
+	}
+}
@@ -0,0 +1,290 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation/validationfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+func createClusterDefaultPolicy(
+	name string,
+	created time.Time,
+	selector metav1.LabelSelector,
+) *ngfAPIv1alpha2.ClusterDefaultPolicy {
+	return &ngfAPIv1alpha2.ClusterDefaultPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: ngfAPIv1alpha2.ClusterDefaultPolicySpec{
+			NamespaceSelector: selector,
+			ClientSettings: &ngfAPIv1alpha2.DefaultClientSettings{
+				Body: &ngfAPIv1alpha1.ClientBody{
+					MaxSize: helpers.GetPointer[ngfAPIv1alpha1.Size]("10m"),
+				},
+			},
+		},
+	}
+}
+
+func TestProcessClusterDefaultPolicies(t *testing.T) {
+	t.Parallel()
+
+	valid := createClusterDefaultPolicy("valid", time.Now(), metav1.LabelSelector{})
+
+	invalidSelector := createClusterDefaultPolicy("invalid-selector", time.Now(), metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "team", Operator: "Unknown"},
+		},
+	})
+
+	noDefaults := createClusterDefaultPolicy("no-defaults", time.Now(), metav1.LabelSelector{})
+	noDefaults.Spec.ClientSettings = nil
+
+	invalidDefaults := createClusterDefaultPolicy("invalid-defaults", time.Now(), metav1.LabelSelector{})
+
+	validator := &validationfakes.FakePolicyValidator{}
+	validator.ValidateStub = func(policy policies.Policy) []conditions.Condition {
+		if policy.GetName() == defaultPolicyNamePrefix+invalidDefaults.Name {
+			return []conditions.Condition{conditions.NewPolicyInvalid("invalid body")}
+		}
+		return nil
+	}
+
+	processed := processClusterDefaultPolicies(
+		map[types.NamespacedName]*ngfAPIv1alpha2.ClusterDefaultPolicy{
+			{Name: valid.Name}:           valid,
+			{Name: invalidSelector.Name}: invalidSelector,
+			{Name: noDefaults.Name}:      noDefaults,
+			{Name: invalidDefaults.Name}: invalidDefaults,
+		},
+		validator,
+	)
+
+	g := NewWithT(t)
+	g.Expect(processed).To(HaveLen(4))
+
+	g.Expect(processed[types.NamespacedName{Name: valid.Name}].Valid).To(BeTrue())
+	g.Expect(processed[types.NamespacedName{Name: valid.Name}].Conditions).To(BeEmpty())
+
+	for _, name := range []string{invalidSelector.Name, noDefaults.Name} {
+		cdp := processed[types.NamespacedName{Name: name}]
+		g.Expect(cdp.Valid).To(BeFalse())
+		g.Expect(cdp.Conditions).To(HaveLen(1))
+		g.Expect(cdp.Conditions[0].Reason).To(
+			Equal(string(ngfAPIv1alpha2.ClusterDefaultPolicyConditionReasonInvalid)),
+		)
+	}
+
+	g.Expect(processed[types.NamespacedName{Name: invalidDefaults.Name}].Conditions).To(Equal(
+		[]conditions.Condition{conditions.NewClusterDefaultPolicyInvalid("invalid body")},
+	))
+
+	g.Expect(processClusterDefaultPolicies(nil, validator)).To(BeNil())
+}
+
+func TestApplyClusterDefaultPolicies(t *testing.T) {
+	t.Parallel()
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	createRoute := func(namespace, name string) *L7Route {
+		return &L7Route{
+			Source: &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			},
+			RouteType:  RouteTypeHTTP,
+			Valid:      true,
+			Attachable: true,
+			ParentRefs: []ParentRef{
+				{Gateway: &ParentRefGateway{NamespacedName: gwNsName}},
+			},
+		}
+	}
+
+	teamRoute := createRoute("team", "route")
+	overriddenRoute := createRoute("team", "overridden")
+	otherRoute := createRoute("other", "route")
+
+	nsPolicy := &Policy{
+		Source: &ngfAPIv1alpha1.ClientSettingsPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "own"},
+		},
+		Valid: true,
+	}
+	overriddenRoute.Policies = []*Policy{nsPolicy}
+
+	teamSvc := &ReferencedService{}
+	otherSvc := &ReferencedService{}
+
+	now := time.Now()
+	selector := metav1.LabelSelector{MatchLabels: map[string]string{"team": "true"}}
+
+	oldest := createClusterDefaultPolicy("oldest", now.Add(-time.Hour), selector)
+	oldest.Spec.UpstreamSettings = &ngfAPIv1alpha2.DefaultUpstreamSettings{
+		ZoneSize: helpers.GetPointer[ngfAPIv1alpha1.Size]("1m"),
+	}
+	newest := createClusterDefaultPolicy("newest", now, metav1.LabelSelector{})
+	invalid := createClusterDefaultPolicy("invalid", now.Add(-2*time.Hour), metav1.LabelSelector{})
+
+	cdps := processClusterDefaultPolicies(
+		map[types.NamespacedName]*ngfAPIv1alpha2.ClusterDefaultPolicy{
+			{Name: oldest.Name}:  oldest,
+			{Name: newest.Name}:  newest,
+			{Name: invalid.Name}: invalid,
+		},
+		&validationfakes.FakePolicyValidator{},
+	)
+	cdps[types.NamespacedName{Name: invalid.Name}].Valid = false
+
+	g := &Graph{
+		Routes: map[RouteKey]*L7Route{
+			CreateRouteKey(teamRoute.Source):       teamRoute,
+			CreateRouteKey(overriddenRoute.Source): overriddenRoute,
+			CreateRouteKey(otherRoute.Source):      otherRoute,
+		},
+		ReferencedServices: map[types.NamespacedName]*ReferencedService{
+			{Namespace: "team", Name: "svc"}:  teamSvc,
+			{Namespace: "other", Name: "svc"}: otherSvc,
+		},
+		ClusterDefaultPolicies: cdps,
+	}
+
+	namespaces := map[types.NamespacedName]*v1.Namespace{
+		{Name: "team"}: {
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Labels: map[string]string{"team": "true"}},
+		},
+		{Name: "other"}: {
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+		},
+	}
+
+	g.applyClusterDefaultPolicies(namespaces, &validationfakes.FakePolicyValidator{})
+
+	e := NewWithT(t)
+
+	// the oldest matching ClusterDefaultPolicy wins
+	e.Expect(teamRoute.Policies).To(HaveLen(1))
+	csp, ok := teamRoute.Policies[0].Source.(*ngfAPIv1alpha1.ClientSettingsPolicy)
+	e.Expect(ok).To(BeTrue())
+	e.Expect(csp.GetNamespace()).To(Equal("team"))
+	e.Expect(csp.GetName()).To(Equal(defaultPolicyNamePrefix + oldest.Name))
+	e.Expect(csp.Spec.TargetRef.Kind).To(Equal(gatewayv1.Kind(kinds.HTTPRoute)))
+	e.Expect(csp.Spec.TargetRef.Name).To(Equal(gatewayv1.ObjectName("route")))
+	e.Expect(teamRoute.Policies[0].Valid).To(BeTrue())
+
+	// the policy in the Namespace overrides the defaults
+	e.Expect(overriddenRoute.Policies).To(Equal([]*Policy{nsPolicy}))
+
+	// the oldest ClusterDefaultPolicy doesn't select the Namespace
+	e.Expect(otherRoute.Policies).To(HaveLen(1))
+	e.Expect(otherRoute.Policies[0].Source.GetName()).To(Equal(defaultPolicyNamePrefix + newest.Name))
+
+	// only the oldest ClusterDefaultPolicy defines the upstream settings
+	e.Expect(teamSvc.Policies).To(HaveLen(1))
+	usp, ok := teamSvc.Policies[0].Source.(*ngfAPIv1alpha1.UpstreamSettingsPolicy)
+	e.Expect(ok).To(BeTrue())
+	e.Expect(usp.Spec.ZoneSize).To(Equal(helpers.GetPointer[ngfAPIv1alpha1.Size]("1m")))
+	e.Expect(otherSvc.Policies).To(BeEmpty())
+
+	e.Expect(cdps[types.NamespacedName{Name: oldest.Name}].Policies).To(HaveLen(2))
+	e.Expect(cdps[types.NamespacedName{Name: newest.Name}].Policies).To(HaveLen(1))
+	e.Expect(cdps[types.NamespacedName{Name: invalid.Name}].Policies).To(BeEmpty())
+}
+
+func TestApplyClusterDefaultPoliciesObservability(t *testing.T) {
+	t.Parallel()
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	route := &L7Route{
+		Source: &gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "route"},
+		},
+		RouteType:  RouteTypeGRPC,
+		Valid:      true,
+		Attachable: true,
+		ParentRefs: []ParentRef{
+			{
+				Gateway: &ParentRefGateway{
+					NamespacedName:      gwNsName,
+					EffectiveNginxProxy: &EffectiveNginxProxy{},
+				},
+			},
+		},
+	}
+
+	cdp := createClusterDefaultPolicy("defaults", time.Now(), metav1.LabelSelector{})
+	cdp.Spec.ClientSettings = nil
+	cdp.Spec.Observability = &ngfAPIv1alpha2.DefaultObservability{
+		Tracing: &ngfAPIv1alpha2.Tracing{Strategy: ngfAPIv1alpha2.TraceStrategyRatio},
+	}
+
+	namespaces := map[types.NamespacedName]*v1.Namespace{
+		{Name: "team"}: {ObjectMeta: metav1.ObjectMeta{Name: "team"}},
+	}
+
+	tests := []struct {
+		name           string
+		globalSettings []conditions.Condition
+		expAttached    bool
+	}{
+		{
+			name:        "telemetry enabled",
+			expAttached: true,
+		},
+		{
+			name:           "telemetry disabled",
+			globalSettings: []conditions.Condition{conditions.NewPolicyNotAcceptedNginxProxyNotSet("disabled")},
+			expAttached:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			testRoute := *route
+			validator := &validationfakes.FakePolicyValidator{}
+			validator.ValidateGlobalSettingsReturns(test.globalSettings)
+
+			gr := &Graph{
+				Routes: map[RouteKey]*L7Route{CreateRouteKey(testRoute.Source): &testRoute},
+				ClusterDefaultPolicies: processClusterDefaultPolicies(
+					map[types.NamespacedName]*ngfAPIv1alpha2.ClusterDefaultPolicy{{Name: cdp.Name}: cdp},
+					validator,
+				),
+			}
+
+			gr.applyClusterDefaultPolicies(namespaces, validator)
+
+			if !test.expAttached {
+				g.Expect(testRoute.Policies).To(BeEmpty())
+				return
+			}
+
+			g.Expect(testRoute.Policies).To(HaveLen(1))
+			obs, ok := testRoute.Policies[0].Source.(*ngfAPIv1alpha2.ObservabilityPolicy)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(obs.GetTargetRefs()).To(ConsistOf(gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  kinds.GRPCRoute,
+				Name:  "route",
+			}))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
 )
 
@@ -113,6 +114,7 @@ func processRouteRuleFilters(
 	path *field.Path,
 	validator validation.HTTPFieldsValidator,
 	resolveExtRefFunc resolveExtRefFilter,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 ) (RouteRuleFilters, routeRuleErrors) {
 	errors := routeRuleErrors{}
 	valid := true
@@ -120,6 +122,18 @@ func processRouteRuleFilters(
 	for i, f := range filters {
 		filterPath := path.Index(i)
 
+		// The unknown filters are kept in the list, so that the indexes of the filters match the spec,
+		// but they are not resolved, so they don't affect the configuration.
+		if f.FilterType == FilterExtensionRef && f.ExtensionRef != nil && !isKnownExtensionRef(*f.ExtensionRef) {
+			switch unknownFilterPolicy {
+			case ngfAPIv1alpha2.UnknownExtensionRefFilterWarn:
+				errors.warn = append(errors.warn, validateExtensionRefFilter(f.ExtensionRef, filterPath)...)
+				continue
+			case ngfAPIv1alpha2.UnknownExtensionRefFilterIgnore:
+				continue
+			}
+		}
+
 		validateErrs := validateFilter(validator, f, filterPath)
 		if len(validateErrs) > 0 {
 			errors.invalid = append(errors.invalid, validateErrs...)
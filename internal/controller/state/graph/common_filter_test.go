@@ -9,6 +9,7 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation/validationfakes"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
@@ -792,3 +793,88 @@ func TestConvertHTTPFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessRouteRuleFiltersUnknownExtensionRef(t *testing.T) {
+	t.Parallel()
+
+	unknownRef := &gatewayv1.LocalObjectReference{
+		Group: "other.example.com",
+		Kind:  "OtherFilter",
+		Name:  "other",
+	}
+	snippetsFilterRef := &gatewayv1.LocalObjectReference{
+		Group: ngfAPI.GroupName,
+		Kind:  kinds.SnippetsFilter,
+		Name:  "sf",
+	}
+
+	createFilters := func() []Filter {
+		return []Filter{
+			{
+				RouteType:    RouteTypeHTTP,
+				FilterType:   FilterExtensionRef,
+				ExtensionRef: unknownRef,
+			},
+			{
+				RouteType:    RouteTypeHTTP,
+				FilterType:   FilterExtensionRef,
+				ExtensionRef: snippetsFilterRef,
+			},
+		}
+	}
+
+	resolved := &ExtensionRefFilter{SnippetsFilter: &SnippetsFilter{Valid: true}, Valid: true}
+	resolve := func(gatewayv1.LocalObjectReference) *ExtensionRefFilter {
+		return resolved
+	}
+
+	tests := []struct {
+		name       string
+		policy     ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy
+		expInvalid int
+		expWarn    int
+		expValid   bool
+	}{
+		{
+			name:       "Reject",
+			policy:     ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+			expInvalid: 2,
+			expValid:   false,
+		},
+		{
+			name:     "Warn",
+			policy:   ngfAPIv1alpha2.UnknownExtensionRefFilterWarn,
+			expWarn:  2,
+			expValid: true,
+		},
+		{
+			name:     "Ignore",
+			policy:   ngfAPIv1alpha2.UnknownExtensionRefFilterIgnore,
+			expValid: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			result, errs := processRouteRuleFilters(
+				createFilters(),
+				field.NewPath("spec").Child("rules").Index(0).Child("filters"),
+				&validationfakes.FakeHTTPFieldsValidator{},
+				resolve,
+				test.policy,
+			)
+
+			g.Expect(errs.invalid).To(HaveLen(test.expInvalid))
+			g.Expect(errs.warn).To(HaveLen(test.expWarn))
+			g.Expect(result.Valid).To(Equal(test.expValid))
+
+			// the unknown filter is kept, so that the indexes of the filters match the spec, but it isn't resolved
+			g.Expect(result.Filters).To(HaveLen(2))
+			g.Expect(result.Filters[0].ResolvedExtensionRef).To(BeNil())
+			g.Expect(result.Filters[1].ResolvedExtensionRef).To(Equal(resolved))
+		})
+	}
+}
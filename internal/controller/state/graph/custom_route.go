@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+// CustomRouteKind is an extension point for route kinds that are not part of the Gateway API, for example,
+// route types defined by CRDs of other projects in the ecosystem.
+//
+// A custom route is translated into the spec of an HTTPRoute, so that it goes through the same validation,
+// attachment, and configuration generation as a native HTTPRoute. Custom routes can attach only to HTTP and HTTPS
+// listeners, and only to the listeners that list their kind in allowedRoutes.kinds.
+//
+// The status of a custom route is written to status.parents, so the CRD must use the Gateway API RouteStatus
+// structure for its status.
+type CustomRouteKind interface {
+	// GroupVersionKind returns the GroupVersionKind of the routes. The version is used to watch the routes.
+	GroupVersionKind() schema.GroupVersionKind
+	// Translate translates the spec of the route into the spec of an HTTPRoute.
+	// If the route cannot be translated, Translate returns an error, which is reported in the status of the route.
+	Translate(route *unstructured.Unstructured) (v1.HTTPRouteSpec, error)
+}
+
+// CustomRouteStatus is an optional interface of a CustomRouteKind. A CustomRouteKind implements it if its routes
+// don't use the Gateway API RouteStatus structure for their status, for example, Ingresses.
+type CustomRouteStatus interface {
+	// HasRouteStatus returns false if the status of the routes must not be written.
+	HasRouteStatus() bool
+}
+
+// CustomRouteKinds holds the registered CustomRouteKinds, keyed by their GroupKind.
+type CustomRouteKinds map[schema.GroupKind]CustomRouteKind
+
+// NewCustomRouteKinds registers the CustomRouteKinds.
+// It returns an error if a kind is registered more than once or conflicts with a Gateway API route kind.
+func NewCustomRouteKinds(routeKinds ...CustomRouteKind) (CustomRouteKinds, error) {
+	registered := make(CustomRouteKinds, len(routeKinds))
+
+	for _, rk := range routeKinds {
+		gvk := rk.GroupVersionKind()
+		gk := gvk.GroupKind()
+
+		if gk.Kind == "" || gvk.Version == "" {
+			return nil, fmt.Errorf("custom route kind %q must have a version and kind", gvk.String())
+		}
+
+		if gk.Group == v1.GroupName {
+			return nil, fmt.Errorf("custom route kind %q cannot use the Gateway API group", gk.String())
+		}
+
+		switch gk.Kind {
+		case kinds.HTTPRoute, kinds.GRPCRoute, kinds.TLSRoute:
+			return nil, fmt.Errorf("custom route kind %q conflicts with a Gateway API route kind", gk.String())
+		}
+
+		if _, exists := registered[gk]; exists {
+			return nil, fmt.Errorf("custom route kind %q is registered more than once", gk.String())
+		}
+
+		registered[gk] = rk
+	}
+
+	return registered, nil
+}
+
+// supports returns true if the RouteGroupKind is a registered kind.
+func (c CustomRouteKinds) supports(kind v1.RouteGroupKind) bool {
+	if kind.Group == nil {
+		return false
+	}
+
+	_, exists := c[schema.GroupKind{Group: string(*kind.Group), Kind: string(kind.Kind)}]
+
+	return exists
+}
+
+// CustomRouteKey is the unique identifier for a custom route in the ClusterState.
+type CustomRouteKey struct {
+	// NsName is the NamespacedName of the route.
+	NsName types.NamespacedName
+	// GroupKind is the GroupKind of the route.
+	GroupKind schema.GroupKind
+}
+
+// buildCustomRoute builds an L7Route from a custom route. The route is built as an HTTPRoute, but
+// its Source and CustomKind refer to the custom route. It also returns the translated HTTPRoute.
+func buildCustomRoute(
+	validator validation.HTTPFieldsValidator,
+	route *unstructured.Unstructured,
+	routeKind CustomRouteKind,
+	gws map[types.NamespacedName]*Gateway,
+	snippetsFilters map[types.NamespacedName]*SnippetsFilter,
+	inferencePools map[types.NamespacedName]*inference.InferencePool,
+	waypointServices map[types.NamespacedName]*Gateway,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
+	featureFlags FeatureFlags,
+) (*L7Route, *v1.HTTPRoute) {
+	spec, translateErr := routeKind.Translate(route)
+
+	hr := &v1.HTTPRoute{Spec: spec}
+	hr.SetName(route.GetName())
+	hr.SetNamespace(route.GetNamespace())
+	hr.SetGeneration(route.GetGeneration())
+	hr.SetLabels(route.GetLabels())
+	hr.SetAnnotations(route.GetAnnotations())
+
+	if translateErr != nil {
+		// We can only report the error if the route references any of the Gateways, which we learn from its
+		// parentRefs. Translate is expected to return the parentRefs even if the rest of the spec is invalid.
+		hr.Spec.Rules = nil
+	}
+
+	r := buildHTTPRoute(
+		validator,
+		hr,
+		gws,
+		snippetsFilters,
+		inferencePools,
+		waypointServices,
+		unknownFilterPolicy,
+		featureFlags,
+	)
+	if r == nil {
+		return nil, nil
+	}
+
+	r.Source = route
+	r.CustomKind = route.GroupVersionKind().GroupKind()
+
+	if rs, ok := routeKind.(CustomRouteStatus); ok && !rs.HasRouteStatus() {
+		r.SkipStatus = true
+	}
+
+	if translateErr != nil {
+		r.Valid = false
+		r.Attachable = false
+		r.Spec.Rules = nil
+		msg := helpers.CapitalizeString(translateErr.Error())
+		r.Conditions = append(r.Conditions, conditions.NewRouteUnsupportedValue(msg))
+	}
+
+	return r, hr
+}
@@ -0,0 +1,450 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation/validationfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+var customRouteGVK = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "CustomRoute"}
+
+// testCustomRouteKind is a CustomRouteKind whose routes have the spec of an HTTPRoute.
+type testCustomRouteKind struct {
+	gvk schema.GroupVersionKind
+}
+
+func (k testCustomRouteKind) GroupVersionKind() schema.GroupVersionKind {
+	return k.gvk
+}
+
+func (k testCustomRouteKind) Translate(route *unstructured.Unstructured) (gatewayv1.HTTPRouteSpec, error) {
+	var spec gatewayv1.HTTPRouteSpec
+
+	obj, _, err := unstructured.NestedMap(route.Object, "spec")
+	if err != nil {
+		return spec, err
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &spec); err != nil {
+		return spec, err
+	}
+
+	if len(spec.Rules) == 0 {
+		return spec, errors.New("spec.rules must not be empty")
+	}
+
+	return spec, nil
+}
+
+// statuslessCustomRouteKind is a CustomRouteKind whose routes don't have the Gateway API route status.
+type statuslessCustomRouteKind struct {
+	testCustomRouteKind
+}
+
+func (k statuslessCustomRouteKind) HasRouteStatus() bool {
+	return false
+}
+
+func createCustomRoute(name string, hr *gatewayv1.HTTPRoute) *unstructured.Unstructured {
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&hr.Spec)
+	if err != nil {
+		panic(err)
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	route.SetGroupVersionKind(customRouteGVK)
+	route.SetNamespace(hr.Namespace)
+	route.SetName(name)
+
+	return route
+}
+
+func TestNewCustomRouteKinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		routeKinds []CustomRouteKind
+		expErr     bool
+	}{
+		{
+			name: "valid kinds",
+			routeKinds: []CustomRouteKind{
+				testCustomRouteKind{gvk: customRouteGVK},
+				testCustomRouteKind{gvk: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "OtherRoute"}},
+			},
+		},
+		{
+			name: "duplicate kinds",
+			routeKinds: []CustomRouteKind{
+				testCustomRouteKind{gvk: customRouteGVK},
+				testCustomRouteKind{gvk: schema.GroupVersionKind{Group: "example.com", Version: "v2", Kind: "CustomRoute"}},
+			},
+			expErr: true,
+		},
+		{
+			name: "Gateway API group",
+			routeKinds: []CustomRouteKind{
+				testCustomRouteKind{gvk: schema.GroupVersionKind{Group: gatewayv1.GroupName, Version: "v1", Kind: "Route"}},
+			},
+			expErr: true,
+		},
+		{
+			name: "Gateway API route kind",
+			routeKinds: []CustomRouteKind{
+				testCustomRouteKind{gvk: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: kinds.HTTPRoute}},
+			},
+			expErr: true,
+		},
+		{
+			name: "missing version",
+			routeKinds: []CustomRouteKind{
+				testCustomRouteKind{gvk: schema.GroupVersionKind{Group: "example.com", Kind: "CustomRoute"}},
+			},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			routeKinds, err := NewCustomRouteKinds(test.routeKinds...)
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(routeKinds).To(BeNil())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(routeKinds).To(HaveLen(len(test.routeKinds)))
+		})
+	}
+}
+
+func TestBuildRoutesForGateways_CustomRoutes(t *testing.T) {
+	t.Parallel()
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	gateways := map[types.NamespacedName]*Gateway{
+		gwNsName: {
+			Source: &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "gateway",
+				},
+			},
+			Valid: true,
+		},
+	}
+
+	hr := createHTTPRoute("hr", gwNsName.Name, "example.com", "/")
+	hr.Spec.Rules[0].BackendRefs[0].Filters = nil
+
+	validRoute := createCustomRoute("valid", hr)
+
+	invalidHR := hr.DeepCopy()
+	invalidHR.Spec.Rules = nil
+	invalidRoute := createCustomRoute("invalid", invalidHR)
+
+	unregisteredRoute := createCustomRoute("unregistered", hr)
+	unregisteredRoute.SetKind("UnregisteredRoute")
+
+	customRoutes := map[CustomRouteKey]*unstructured.Unstructured{}
+	for _, route := range []*unstructured.Unstructured{validRoute, invalidRoute, unregisteredRoute} {
+		key := CustomRouteKey{
+			NsName:    client.ObjectKeyFromObject(route),
+			GroupKind: route.GroupVersionKind().GroupKind(),
+		}
+		customRoutes[key] = route
+	}
+
+	routeKinds, err := NewCustomRouteKinds(testCustomRouteKind{gvk: customRouteGVK})
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	validator := &validationfakes.FakeHTTPFieldsValidator{}
+
+	expParentRefs := []ParentRef{
+		{
+			Idx:         0,
+			Gateway:     CreateParentRefGateway(gateways[gwNsName]),
+			SectionName: hr.Spec.ParentRefs[0].SectionName,
+		},
+	}
+
+	expected := map[RouteKey]*L7Route{
+		CreateRouteKey(validRoute): {
+			Source:     validRoute,
+			RouteType:  RouteTypeHTTP,
+			CustomKind: customRouteGVK.GroupKind(),
+			ParentRefs: expParentRefs,
+			Valid:      true,
+			Attachable: true,
+			Spec: L7RouteSpec{
+				Hostnames: hr.Spec.Hostnames,
+				Rules: []RouteRule{
+					{
+						ValidMatches: true,
+						Filters: RouteRuleFilters{
+							Valid:   true,
+							Filters: []Filter{},
+						},
+						Matches:          hr.Spec.Rules[0].Matches,
+						RouteBackendRefs: []RouteBackendRef{{BackendRef: hr.Spec.Rules[0].BackendRefs[0].BackendRef}},
+					},
+				},
+			},
+		},
+		CreateRouteKey(invalidRoute): {
+			Source:     invalidRoute,
+			RouteType:  RouteTypeHTTP,
+			CustomKind: customRouteGVK.GroupKind(),
+			ParentRefs: expParentRefs,
+			Spec: L7RouteSpec{
+				Hostnames: hr.Spec.Hostnames,
+			},
+			Conditions: []conditions.Condition{
+				conditions.NewRouteUnsupportedValue("Spec.rules must not be empty"),
+			},
+		},
+	}
+
+	g := NewWithT(t)
+
+	routes := buildRoutesForGateways(
+		validator,
+		nil,
+		nil,
+		customRoutes,
+		routeKinds,
+		gateways,
+		nil,
+		nil,
+		nil,
+		ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+		FeatureFlags{},
+	)
+
+	g.Expect(helpers.Diff(expected, routes)).To(BeEmpty())
+}
+
+func TestBuildCustomRoute_SkipStatus(t *testing.T) {
+	t.Parallel()
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	gateways := map[types.NamespacedName]*Gateway{
+		gwNsName: {
+			Source: &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "gateway",
+				},
+			},
+			Valid: true,
+		},
+	}
+
+	hr := createHTTPRoute("hr", gwNsName.Name, "example.com", "/")
+	hr.Spec.Rules[0].BackendRefs[0].Filters = nil
+	route := createCustomRoute("route", hr)
+
+	tests := []struct {
+		routeKind     CustomRouteKind
+		name          string
+		expSkipStatus bool
+	}{
+		{
+			name:      "kind with route status",
+			routeKind: testCustomRouteKind{gvk: customRouteGVK},
+		},
+		{
+			name:          "kind without route status",
+			routeKind:     statuslessCustomRouteKind{testCustomRouteKind{gvk: customRouteGVK}},
+			expSkipStatus: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			r, _ := buildCustomRoute(
+				&validationfakes.FakeHTTPFieldsValidator{},
+				route,
+				test.routeKind,
+				gateways,
+				nil,
+				nil,
+				nil,
+				ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+				FeatureFlags{},
+			)
+
+			g.Expect(r).ToNot(BeNil())
+			g.Expect(r.Valid).To(BeTrue())
+			g.Expect(r.SkipStatus).To(Equal(test.expSkipStatus))
+		})
+	}
+}
+
+func TestGetAndValidateListenerSupportedKinds_CustomRouteKinds(t *testing.T) {
+	t.Parallel()
+
+	routeKinds, err := NewCustomRouteKinds(testCustomRouteKind{gvk: customRouteGVK})
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	customKind := gatewayv1.RouteGroupKind{
+		Group: helpers.GetPointer[gatewayv1.Group]("example.com"),
+		Kind:  "CustomRoute",
+	}
+	httpRouteKind := gatewayv1.RouteGroupKind{
+		Group: helpers.GetPointer[gatewayv1.Group](gatewayv1.GroupName),
+		Kind:  kinds.HTTPRoute,
+	}
+
+	tests := []struct {
+		name          string
+		protocol      gatewayv1.ProtocolType
+		kinds         []gatewayv1.RouteGroupKind
+		expectedKinds []gatewayv1.RouteGroupKind
+		expectErr     bool
+	}{
+		{
+			name:          "custom kind on HTTP listener",
+			protocol:      gatewayv1.HTTPProtocolType,
+			kinds:         []gatewayv1.RouteGroupKind{customKind, httpRouteKind},
+			expectedKinds: []gatewayv1.RouteGroupKind{customKind, httpRouteKind},
+		},
+		{
+			name:          "custom kind on HTTPS listener",
+			protocol:      gatewayv1.HTTPSProtocolType,
+			kinds:         []gatewayv1.RouteGroupKind{customKind},
+			expectedKinds: []gatewayv1.RouteGroupKind{customKind},
+		},
+		{
+			name:          "custom kind on TLS listener",
+			protocol:      gatewayv1.TLSProtocolType,
+			kinds:         []gatewayv1.RouteGroupKind{customKind},
+			expectedKinds: []gatewayv1.RouteGroupKind{},
+			expectErr:     true,
+		},
+		{
+			name:     "unregistered custom kind",
+			protocol: gatewayv1.HTTPProtocolType,
+			kinds: []gatewayv1.RouteGroupKind{
+				{
+					Group: helpers.GetPointer[gatewayv1.Group]("example.com"),
+					Kind:  "UnregisteredRoute",
+				},
+			},
+			expectedKinds: []gatewayv1.RouteGroupKind{},
+			expectErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			listener := gatewayv1.Listener{
+				Protocol: test.protocol,
+				AllowedRoutes: &gatewayv1.AllowedRoutes{
+					Kinds: test.kinds,
+				},
+			}
+
+			conds, supportedKinds := getAndValidateListenerSupportedKinds(listener, routeKinds)
+			g.Expect(supportedKinds).To(Equal(test.expectedKinds))
+			if test.expectErr {
+				g.Expect(conds).ToNot(BeEmpty())
+			} else {
+				g.Expect(conds).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestIsL7RouteKindAllowedByListener(t *testing.T) {
+	t.Parallel()
+
+	listener := &Listener{
+		SupportedKinds: []gatewayv1.RouteGroupKind{
+			{Kind: kinds.HTTPRoute, Group: helpers.GetPointer[gatewayv1.Group](gatewayv1.GroupName)},
+			{Kind: "CustomRoute", Group: helpers.GetPointer[gatewayv1.Group]("example.com")},
+		},
+	}
+
+	tests := []struct {
+		route     *L7Route
+		name      string
+		expResult bool
+	}{
+		{
+			name:      "HTTPRoute is allowed",
+			route:     &L7Route{RouteType: RouteTypeHTTP},
+			expResult: true,
+		},
+		{
+			name:      "GRPCRoute is not allowed",
+			route:     &L7Route{RouteType: RouteTypeGRPC},
+			expResult: false,
+		},
+		{
+			name:      "custom route is allowed",
+			route:     &L7Route{RouteType: RouteTypeHTTP, CustomKind: customRouteGVK.GroupKind()},
+			expResult: true,
+		},
+		{
+			name: "custom route of another group is not allowed",
+			route: &L7Route{
+				RouteType:  RouteTypeHTTP,
+				CustomKind: schema.GroupKind{Group: "other.com", Kind: "CustomRoute"},
+			},
+			expResult: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(isL7RouteKindAllowedByListener(listener, test.route)).To(Equal(test.expResult))
+		})
+	}
+}
+
+func TestCreateRouteKey_CustomRoute(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(customRouteGVK)
+	route.SetNamespace("test")
+	route.SetName("route")
+
+	g.Expect(CreateRouteKey(route)).To(Equal(RouteKey{
+		NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"},
+		RouteType:      RouteTypeHTTP,
+		CustomKind:     customRouteGVK.GroupKind(),
+	}))
+}
@@ -22,6 +22,11 @@ type ExtensionRefFilter struct {
 // If it cannot be resolved, *ExtensionRefFilter will be nil.
 type resolveExtRefFilter func(ref v1.LocalObjectReference) *ExtensionRefFilter
 
+// isKnownExtensionRef returns true if the reference points to a filter kind that NGF supports.
+func isKnownExtensionRef(ref v1.LocalObjectReference) bool {
+	return ref.Group == ngfAPI.GroupName && ref.Kind == kinds.SnippetsFilter
+}
+
 func validateExtensionRefFilter(ref *v1.LocalObjectReference, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
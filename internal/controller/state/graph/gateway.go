@@ -19,6 +19,8 @@ import (
 type Gateway struct {
 	// LatestReloadResult is the result of the last nginx reload attempt.
 	LatestReloadResult NginxReloadResult
+	// DataPlaneFailures are the recent failures of the nginx data plane of the Gateway, the latest first.
+	DataPlaneFailures []DataPlaneFailure
 	// Source is the corresponding Gateway resource.
 	Source *v1.Gateway
 	// NginxProxy is the NginxProxy referenced by this Gateway.
@@ -69,6 +71,7 @@ func buildGateways(
 	gc *GatewayClass,
 	refGrantResolver *referenceGrantResolver,
 	nps map[types.NamespacedName]*NginxProxy,
+	customRouteKinds CustomRouteKinds,
 	experimentalFeatures bool,
 ) map[types.NamespacedName]*Gateway {
 	if len(gws) == 0 {
@@ -121,7 +124,7 @@ func buildGateways(
 		} else {
 			builtGateways[gwNsName] = &Gateway{
 				Source:              gw,
-				Listeners:           buildListeners(gw, secretResolver, refGrantResolver, protectedPorts),
+				Listeners:           buildListeners(gw, secretResolver, refGrantResolver, protectedPorts, customRouteKinds),
 				NginxProxy:          np,
 				EffectiveNginxProxy: effectiveNginxProxy,
 				Valid:               true,
@@ -37,6 +37,8 @@ type Listener struct {
 	ResolvedSecret *types.NamespacedName
 	// Conditions holds the conditions of the Listener.
 	Conditions []conditions.Condition
+	// Policies holds the policies that target the Listener with the sectionName of a Gateway targetRef.
+	Policies []*Policy
 	// SupportedKinds is the list of RouteGroupKinds allowed by the listener.
 	SupportedKinds []v1.RouteGroupKind
 	// Valid shows whether the Listener is valid.
@@ -52,10 +54,17 @@ func buildListeners(
 	secretResolver *secretResolver,
 	refGrantResolver *referenceGrantResolver,
 	protectedPorts ProtectedPorts,
+	customRouteKinds CustomRouteKinds,
 ) []*Listener {
 	listeners := make([]*Listener, 0, len(gw.Spec.Listeners))
 
-	listenerFactory := newListenerConfiguratorFactory(gw, secretResolver, refGrantResolver, protectedPorts)
+	listenerFactory := newListenerConfiguratorFactory(
+		gw,
+		secretResolver,
+		refGrantResolver,
+		protectedPorts,
+		customRouteKinds,
+	)
 
 	for _, gl := range gw.Spec.Listeners {
 		configurator := listenerFactory.getConfiguratorForListener(gl)
@@ -87,9 +96,11 @@ func newListenerConfiguratorFactory(
 	secretResolver *secretResolver,
 	refGrantResolver *referenceGrantResolver,
 	protectedPorts ProtectedPorts,
+	customRouteKinds CustomRouteKinds,
 ) *listenerConfiguratorFactory {
 	sharedPortConflictResolver := createPortConflictResolver()
 	sharedOverlappingTLSConfigResolver := createOverlappingTLSConfigResolver()
+	allowedRouteKindValidator := createListenerAllowedRouteKindValidator(customRouteKinds)
 
 	return &listenerConfiguratorFactory{
 		unsupportedProtocol: &listenerConfigurator{
@@ -105,8 +116,9 @@ func newListenerConfiguratorFactory(
 			},
 		},
 		http: &listenerConfigurator{
+			customRouteKinds: customRouteKinds,
 			validators: []listenerValidator{
-				validateListenerAllowedRouteKind,
+				allowedRouteKindValidator,
 				validateListenerLabelSelector,
 				validateListenerHostname,
 				createHTTPListenerValidator(protectedPorts),
@@ -116,8 +128,9 @@ func newListenerConfiguratorFactory(
 			},
 		},
 		https: &listenerConfigurator{
+			customRouteKinds: customRouteKinds,
 			validators: []listenerValidator{
-				validateListenerAllowedRouteKind,
+				allowedRouteKindValidator,
 				validateListenerLabelSelector,
 				validateListenerHostname,
 				createHTTPSListenerValidator(protectedPorts),
@@ -132,7 +145,7 @@ func newListenerConfiguratorFactory(
 		},
 		tls: &listenerConfigurator{
 			validators: []listenerValidator{
-				validateListenerAllowedRouteKind,
+				allowedRouteKindValidator,
 				validateListenerLabelSelector,
 				validateListenerHostname,
 				validateTLSFieldOnTLSListener,
@@ -170,6 +183,8 @@ type listenerConfigurator struct {
 	conflictResolvers []listenerConflictResolver
 	// externalReferenceResolvers can depend on validators - they will only be executed if all validators pass.
 	externalReferenceResolvers []listenerExternalReferenceResolver
+	// customRouteKinds are the custom route kinds that the listener supports in addition to the Gateway API kinds.
+	customRouteKinds CustomRouteKinds
 }
 
 func (c *listenerConfigurator) configure(listener v1.Listener, gwNSName types.NamespacedName) *Listener {
@@ -198,7 +213,7 @@ func (c *listenerConfigurator) configure(listener v1.Listener, gwNSName types.Na
 		}
 	}
 
-	supportedKinds := getListenerSupportedKinds(listener)
+	supportedKinds := getListenerSupportedKinds(listener, c.customRouteKinds)
 
 	l := &Listener{
 		Name:                      string(listener.Name),
@@ -252,7 +267,8 @@ func validateListenerHostname(listener v1.Listener) (conds []conditions.Conditio
 // getAndValidateListenerSupportedKinds validates the route kind and returns the supported kinds for the listener.
 // The supported kinds are determined based on the listener's allowedRoutes field.
 // If the listener does not specify allowedRoutes, listener determines allowed routes based on its protocol.
-func getAndValidateListenerSupportedKinds(listener v1.Listener) (
+// Custom route kinds are only supported if they are listed in allowedRoutes.kinds.
+func getAndValidateListenerSupportedKinds(listener v1.Listener, customRouteKinds CustomRouteKinds) (
 	[]conditions.Condition,
 	[]v1.RouteGroupKind,
 ) {
@@ -275,7 +291,9 @@ func getAndValidateListenerSupportedKinds(listener v1.Listener) (
 
 	validProtocolRouteKind := func(kind v1.RouteGroupKind) bool {
 		if kind.Group != nil && *kind.Group != v1.GroupName {
-			return false
+			// custom routes are translated to HTTPRoutes, so they share the protocols of HTTPRoutes
+			httpProtocol := listener.Protocol == v1.HTTPProtocolType || listener.Protocol == v1.HTTPSProtocolType
+			return httpProtocol && customRouteKinds.supports(kind)
 		}
 		for _, k := range validKinds {
 			if k.Kind == kind.Kind {
@@ -315,13 +333,15 @@ func getAndValidateListenerSupportedKinds(listener v1.Listener) (
 	return conds, validKinds
 }
 
-func validateListenerAllowedRouteKind(listener v1.Listener) (conds []conditions.Condition, attachable bool) {
-	conds, _ = getAndValidateListenerSupportedKinds(listener)
-	return conds, len(conds) == 0
+func createListenerAllowedRouteKindValidator(customRouteKinds CustomRouteKinds) listenerValidator {
+	return func(listener v1.Listener) (conds []conditions.Condition, attachable bool) {
+		conds, _ = getAndValidateListenerSupportedKinds(listener, customRouteKinds)
+		return conds, len(conds) == 0
+	}
 }
 
-func getListenerSupportedKinds(listener v1.Listener) []v1.RouteGroupKind {
-	_, sk := getAndValidateListenerSupportedKinds(listener)
+func getListenerSupportedKinds(listener v1.Listener, customRouteKinds CustomRouteKinds) []v1.RouteGroupKind {
+	_, sk := getAndValidateListenerSupportedKinds(listener, customRouteKinds)
 	return sk
 }
 
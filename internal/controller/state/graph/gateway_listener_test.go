@@ -450,7 +450,7 @@ func TestGetAndValidateListenerSupportedKinds(t *testing.T) {
 				}
 			}
 
-			conds, kinds := getAndValidateListenerSupportedKinds(listener)
+			conds, kinds := getAndValidateListenerSupportedKinds(listener, nil)
 			g.Expect(helpers.Diff(test.expected, kinds)).To(BeEmpty())
 			if test.expectErr {
 				g.Expect(conds).ToNot(BeEmpty())
@@ -919,11 +919,11 @@ func TestOverlappingTLSConfigCondition(t *testing.T) {
 			g := NewWithT(t)
 
 			// Create mock resolvers
-			secretResolver := newSecretResolver(nil)
+			secretResolver := newSecretResolver(nil, false)
 			refGrantResolver := newReferenceGrantResolver(nil)
 
 			// Build listeners
-			listeners := buildListeners(test.gateway, secretResolver, refGrantResolver, protectedPorts)
+			listeners := buildListeners(test.gateway, secretResolver, refGrantResolver, protectedPorts, nil)
 
 			if test.expectedCondition {
 				// Check that the expected listeners have the OverlappingTLSConfig condition
@@ -297,9 +297,9 @@ func TestBuildGateway(t *testing.T) {
 	)
 
 	const (
-		invalidHostnameMsg = `hostname: Invalid value: "$example.com": a lowercase RFC 1123 subdomain ` +
-			"must consist of lower case alphanumeric characters, '-' or '.', and must start and end " +
-			"with an alphanumeric character (e.g. 'example.com', regex used for validation is " +
+		invalidHostnameMsg = `hostname: Invalid value: "$example.com": [NGF1401 InvalidHostname] ` +
+			"a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', " +
+			"and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is " +
 			`'[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`
 
 		conflict80PortMsg = "Multiple listeners for the same port 80 specify incompatible protocols; " +
@@ -1653,7 +1653,9 @@ func TestBuildGateway(t *testing.T) {
 		map[types.NamespacedName]*apiv1.Secret{
 			client.ObjectKeyFromObject(secretSameNs):        secretSameNs,
 			client.ObjectKeyFromObject(secretDiffNamespace): secretDiffNamespace,
-		})
+		},
+		false,
+	)
 
 	nginxProxies := map[types.NamespacedName]*NginxProxy{
 		client.ObjectKeyFromObject(validGwNp): {Valid: true, Source: validGwNp},
@@ -1669,7 +1671,7 @@ func TestBuildGateway(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			g := NewWithT(t)
 			resolver := newReferenceGrantResolver(test.refGrants)
-			result := buildGateways(test.gateway, secretResolver, test.gatewayClass, resolver, nginxProxies, false)
+			result := buildGateways(test.gateway, secretResolver, test.gatewayClass, resolver, nginxProxies, nil, false)
 			g.Expect(helpers.Diff(test.expected, result)).To(BeEmpty())
 		})
 	}
@@ -2142,7 +2144,7 @@ func TestGateway_BackendTLSConfig(t *testing.T) {
 				},
 			},
 			experimental:   false,
-			secretResolver: newSecretResolver(secrets),
+			secretResolver: newSecretResolver(secrets, false),
 		},
 		{
 			name: "gateway with experimental disabled and tls.backend is specified",
@@ -2156,7 +2158,7 @@ func TestGateway_BackendTLSConfig(t *testing.T) {
 				false,
 			),
 			experimental:   false,
-			secretResolver: newSecretResolver(secrets),
+			secretResolver: newSecretResolver(secrets, false),
 		},
 		{
 			name: "gateway with experimental enabled, tls.backend is specified but secret reference is invalid",
@@ -2171,7 +2173,7 @@ func TestGateway_BackendTLSConfig(t *testing.T) {
 				true,
 			),
 			experimental:   true,
-			secretResolver: newSecretResolver(secrets),
+			secretResolver: newSecretResolver(secrets, false),
 		},
 		{
 			name: "gateway with experimental enabled, tls.backend is specified but secret is not permitted by reference grant",
@@ -2187,7 +2189,7 @@ func TestGateway_BackendTLSConfig(t *testing.T) {
 				true,
 			),
 			experimental:   true,
-			secretResolver: newSecretResolver(secrets),
+			secretResolver: newSecretResolver(secrets, false),
 		},
 		{
 			name: "gateway with experimental enabled, tls.backend is specified secret in" +
@@ -2224,7 +2226,7 @@ func TestGateway_BackendTLSConfig(t *testing.T) {
 				},
 			},
 			experimental:   true,
-			secretResolver: newSecretResolver(secrets),
+			secretResolver: newSecretResolver(secrets, false),
 		},
 	}
 
@@ -2236,7 +2238,7 @@ func TestGateway_BackendTLSConfig(t *testing.T) {
 				Valid: true,
 			}
 			resolver := newReferenceGrantResolver(test.refGrants)
-			gateways := buildGateways(test.gw, test.secretResolver, validGC, resolver, nil, test.experimental)
+			gateways := buildGateways(test.gw, test.secretResolver, validGC, resolver, nil, nil, test.experimental)
 			g.Expect(helpers.Diff(test.expected, gateways)).To(BeEmpty())
 		})
 	}
@@ -7,6 +7,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	discoveryV1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
@@ -41,6 +42,10 @@ type ClusterState struct {
 	NGFPolicies        map[PolicyKey]policies.Policy
 	SnippetsFilters    map[types.NamespacedName]*ngfAPIv1alpha1.SnippetsFilter
 	InferencePools     map[types.NamespacedName]*inference.InferencePool
+	Backends           map[types.NamespacedName]*ngfAPIv1alpha1.Backend
+	CustomRoutes       map[CustomRouteKey]*unstructured.Unstructured
+	// ClusterDefaultPolicies are cluster-scoped, so their Namespace is empty.
+	ClusterDefaultPolicies map[types.NamespacedName]*ngfAPIv1alpha2.ClusterDefaultPolicy
 }
 
 // Graph is a Graph-like representation of Gateway API resources.
@@ -66,6 +71,9 @@ type Graph struct {
 	ReferencedNamespaces map[types.NamespacedName]*v1.Namespace
 	// ReferencedServices includes the NamespacedNames of all the Services that are referenced by at least one Route.
 	ReferencedServices map[types.NamespacedName]*ReferencedService
+	// WaypointServices includes the Services that use one of the Gateways as their waypoint for mesh Routes.
+	// The value is the waypoint Gateway.
+	WaypointServices map[types.NamespacedName]*Gateway
 	// ReferencedInferencePools includes the NamespacedNames of all the InferencePools
 	// that are referenced by at least one Route.
 	ReferencedInferencePools map[types.NamespacedName]*ReferencedInferencePool
@@ -79,6 +87,8 @@ type Graph struct {
 	NGFPolicies map[PolicyKey]*Policy
 	// SnippetsFilters holds all the SnippetsFilters.
 	SnippetsFilters map[types.NamespacedName]*SnippetsFilter
+	// ClusterDefaultPolicies holds all the ClusterDefaultPolicies.
+	ClusterDefaultPolicies map[types.NamespacedName]*ClusterDefaultPolicy
 	// PlusSecrets holds the secrets related to NGINX Plus licensing.
 	PlusSecrets map[types.NamespacedName][]PlusSecretFile
 }
@@ -87,6 +97,35 @@ type Graph struct {
 type NginxReloadResult struct {
 	// Error is the error that occurred during the reload.
 	Error error
+	// WithheldFeatures are the features that were withheld from the NGINX configuration, because
+	// the data plane doesn't support them.
+	WithheldFeatures []string
+	// RolledBack is true if the NGINX configuration was rolled back to the previous version and is held.
+	RolledBack bool
+}
+
+// DataPlaneFailureKind is the kind of a failure of the NGINX data plane.
+type DataPlaneFailureKind string
+
+const (
+	// DataPlaneFailureWorkerCrash is the kind of the failures in which an NGINX worker process exited
+	// on a signal, for example, because of a segmentation fault.
+	DataPlaneFailureWorkerCrash DataPlaneFailureKind = "worker_crash"
+	// DataPlaneFailureOOMKill is the kind of the failures in which an NGINX process or container was killed,
+	// because it ran out of memory.
+	DataPlaneFailureOOMKill DataPlaneFailureKind = "oom_kill"
+	// DataPlaneFailureReloadFailure is the kind of the failures in which NGINX failed to apply its configuration.
+	DataPlaneFailureReloadFailure DataPlaneFailureKind = "reload_failure"
+)
+
+// DataPlaneFailure describes the recent failures of one kind of the NGINX data plane of a Gateway.
+type DataPlaneFailure struct {
+	// Kind is the kind of the failures.
+	Kind DataPlaneFailureKind
+	// Message describes the latest failure.
+	Message string
+	// Count is the number of the recent failures.
+	Count int
 }
 
 // ProtectedPorts are the ports that may not be configured by a listener with a descriptive name of each port.
@@ -98,6 +137,9 @@ type FeatureFlags struct {
 	Plus bool
 	// Experimental indicates whether experimental features are enabled.
 	Experimental bool
+	// FIPS indicates whether FIPS mode is enabled. In FIPS mode, referenced certificates must use
+	// FIPS-approved algorithms.
+	FIPS bool
 }
 
 // IsReferenced returns true if the Graph references the resource.
@@ -124,13 +166,20 @@ func (g *Graph) IsReferenced(resourceType ngftypes.ObjectType, nsname types.Name
 		//
 		// `exists` does not cover the case highlighted above by `existed` and vice versa so both are needed.
 
+		//
+		// The ClusterDefaultPolicies select Namespaces by their labels too, so any Namespace can change which
+		// defaults apply to its Routes and Services.
+
 		_, existed := g.ReferencedNamespaces[nsname]
 		exists := isNamespaceReferenced(obj, g.Gateways)
-		return existed || exists
-	// Service reference exists if at least one Route references it.
+		return existed || exists || len(g.ClusterDefaultPolicies) > 0
+	// Service reference exists if at least one Route references it, or if it uses one of the Gateways as its
+	// waypoint. Like for Namespaces, both the waypoint Services of the graph and the labels of the Service
+	// are checked to cover the cases when the waypoint label is removed or added.
 	case *v1.Service:
 		_, exists := g.ReferencedServices[nsname]
-		return exists
+		_, isWaypointService := g.WaypointServices[nsname]
+		return exists || isWaypointService || findWaypointForService(obj, g.Gateways) != nil
 	// InferencePool reference exists if at least one Route references it.
 	case *inference.InferencePool:
 		_, exists := g.ReferencedInferencePools[nsname]
@@ -215,8 +264,10 @@ func BuildGraph(
 	gcName string,
 	plusSecrets map[types.NamespacedName][]PlusSecretFile,
 	validators validation.Validators,
+	customRouteKinds CustomRouteKinds,
 	logger logr.Logger,
 	featureFlags FeatureFlags,
+	clusterDomain string,
 ) *Graph {
 	processedGwClasses, gcExists := processGatewayClasses(state.GatewayClasses, gcName, controllerName)
 	if gcExists && processedGwClasses.Winner == nil {
@@ -239,7 +290,7 @@ func BuildGraph(
 		featureFlags.Experimental,
 	)
 
-	secretResolver := newSecretResolver(state.Secrets)
+	secretResolver := newSecretResolver(state.Secrets, featureFlags.FIPS)
 	configMapResolver := newConfigMapResolver(state.ConfigMaps)
 
 	refGrantResolver := newReferenceGrantResolver(state.ReferenceGrants)
@@ -250,6 +301,7 @@ func BuildGraph(
 		gc,
 		refGrantResolver,
 		processedNginxProxies,
+		customRouteKinds,
 		featureFlags.Experimental,
 	)
 
@@ -258,17 +310,24 @@ func BuildGraph(
 		configMapResolver,
 		secretResolver,
 		gws,
+		featureFlags.FIPS,
 	)
 
 	processedSnippetsFilters := processSnippetsFilters(state.SnippetsFilters)
 
+	waypointServices := buildWaypointServices(state.Services, gws)
+
 	routes := buildRoutesForGateways(
 		validators.HTTPFieldsValidator,
 		state.HTTPRoutes,
 		state.GRPCRoutes,
+		state.CustomRoutes,
+		customRouteKinds,
 		gws,
 		processedSnippetsFilters,
 		state.InferencePools,
+		waypointServices,
+		UnknownExtensionRefFilterPolicyForGatewayClass(gc),
 		featureFlags,
 	)
 
@@ -287,12 +346,14 @@ func BuildGraph(
 		state.Services,
 		referencedInferencePools,
 		processedBackendTLSPolicies,
+		state.Backends,
 	)
-	bindRoutesToListeners(routes, l4routes, gws, state.Namespaces)
+	bindRoutesToListeners(routes, l4routes, gws, state.Namespaces, clusterDomain)
 
 	referencedNamespaces := buildReferencedNamespaces(state.Namespaces, gws)
 
 	referencedServices := buildReferencedServices(routes, l4routes, gws, state.Services)
+	addNetworkPolicyConditions(gws, state.Services)
 
 	addGatewaysForBackendTLSPolicies(processedBackendTLSPolicies, referencedServices, controllerName, gws, logger)
 
@@ -303,6 +364,8 @@ func BuildGraph(
 		routes,
 		referencedServices,
 		gws,
+		state,
+		controllerName,
 	)
 
 	// add status conditions to each targetRef based on the policies that affect them.
@@ -319,17 +382,22 @@ func BuildGraph(
 		ReferencedSecrets:          secretResolver.getResolvedSecrets(),
 		ReferencedNamespaces:       referencedNamespaces,
 		ReferencedServices:         referencedServices,
+		WaypointServices:           waypointServices,
 		ReferencedInferencePools:   referencedInferencePools,
 		ReferencedCaCertConfigMaps: configMapResolver.getResolvedConfigMaps(),
 		ReferencedNginxProxies:     processedNginxProxies,
 		BackendTLSPolicies:         processedBackendTLSPolicies,
 		NGFPolicies:                processedPolicies,
 		SnippetsFilters:            processedSnippetsFilters,
+		ClusterDefaultPolicies:     processClusterDefaultPolicies(state.ClusterDefaultPolicies, validators.PolicyValidator),
 		PlusSecrets:                plusSecrets,
 	}
 
 	g.attachPolicies(validators.PolicyValidator, controllerName, logger)
 
+	// the defaults apply only to the Routes and Services that have no policies of the same kind attached
+	g.applyClusterDefaultPolicies(state.Namespaces, validators.PolicyValidator)
+
 	return g
 }
 
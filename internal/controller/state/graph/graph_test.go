@@ -1539,11 +1539,13 @@ func TestBuildGraph(t *testing.T) {
 					GenericValidator:    &validationfakes.FakeGenericValidator{},
 					PolicyValidator:     fakePolicyValidator,
 				},
+				nil,
 				logr.Discard(),
 				FeatureFlags{
 					Experimental: test.experimentalEnabled,
 					Plus:         test.plus,
 				},
+				"cluster.local",
 			)
 
 			g.Expect(helpers.Diff(test.expected, result)).To(BeEmpty())
@@ -1613,6 +1615,26 @@ func TestIsReferenced(t *testing.T) {
 		},
 	}
 	emptyService := &v1.Service{}
+	waypointServiceInGraph := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNs,
+			Name:      "waypointServiceInGraph",
+		},
+	}
+	waypointServiceNotInGraph := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNs,
+			Name:      "waypointServiceNotInGraph",
+			Labels:    map[string]string{WaypointLabel: "waypoint"},
+		},
+	}
+	serviceWithUnknownWaypoint := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNs,
+			Name:      "serviceWithUnknownWaypoint",
+			Labels:    map[string]string{WaypointLabel: "unknown"},
+		},
+	}
 
 	inferenceInGraph := &inference.InferencePool{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1652,6 +1674,9 @@ func TestIsReferenced(t *testing.T) {
 			},
 			Valid: true,
 		},
+		{Namespace: testNs, Name: "waypoint"}: {
+			Valid: true,
+		},
 	}
 
 	nsNotInGraphButInGateway := &v1.Namespace{
@@ -1707,6 +1732,9 @@ func TestIsReferenced(t *testing.T) {
 		ReferencedServices: map[types.NamespacedName]*ReferencedService{
 			client.ObjectKeyFromObject(serviceInGraph): {},
 		},
+		WaypointServices: map[types.NamespacedName]*Gateway{
+			client.ObjectKeyFromObject(waypointServiceInGraph): gw[types.NamespacedName{Namespace: testNs, Name: "waypoint"}],
+		},
 		ReferencedInferencePools: map[types.NamespacedName]*ReferencedInferencePool{
 			client.ObjectKeyFromObject(inferenceInGraph): {},
 		},
@@ -1810,6 +1838,24 @@ func TestIsReferenced(t *testing.T) {
 			graph:    graph,
 			expected: false,
 		},
+		{
+			name:     "Service in graph's WaypointServices is referenced",
+			resource: waypointServiceInGraph,
+			graph:    graph,
+			expected: true,
+		},
+		{
+			name:     "Service not in graph's WaypointServices, but with a waypoint Gateway label, is referenced",
+			resource: waypointServiceNotInGraph,
+			graph:    graph,
+			expected: true,
+		},
+		{
+			name:     "Service with a waypoint label for an unknown Gateway is not referenced",
+			resource: serviceWithUnknownWaypoint,
+			graph:    graph,
+			expected: false,
+		},
 
 		// InferencePool tests
 		{
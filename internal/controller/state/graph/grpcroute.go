@@ -9,6 +9,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/mirror"
@@ -21,6 +22,7 @@ func buildGRPCRoute(
 	ghr *v1.GRPCRoute,
 	gws map[types.NamespacedName]*Gateway,
 	snippetsFilters map[types.NamespacedName]*SnippetsFilter,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 	featureFlags FeatureFlags,
 ) *L7Route {
 	r := &L7Route{
@@ -62,6 +64,7 @@ func buildGRPCRoute(
 		validator,
 		getSnippetsFilterResolverForNamespace(snippetsFilters, r.Source.GetNamespace()),
 		grpcRouteNsName,
+		unknownFilterPolicy,
 		featureFlags,
 	)
 
@@ -78,6 +81,7 @@ func buildGRPCMirrorRoutes(
 	route *v1.GRPCRoute,
 	gateways map[types.NamespacedName]*Gateway,
 	snippetsFilters map[types.NamespacedName]*SnippetsFilter,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 	featureFlags FeatureFlags,
 ) {
 	for idx, rule := range l7route.Spec.Rules {
@@ -115,6 +119,7 @@ func buildGRPCMirrorRoutes(
 					tmpMirrorRoute,
 					gateways,
 					snippetsFilters,
+					unknownFilterPolicy,
 					featureFlags,
 				)
 
@@ -170,6 +175,7 @@ func processGRPCRouteRule(
 	validator validation.HTTPFieldsValidator,
 	resolveExtRefFunc resolveExtRefFilter,
 	grpcRouteNsName types.NamespacedName,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 	featureFlags FeatureFlags,
 ) (RouteRule, routeRuleErrors) {
 	rulePath := field.NewPath("spec").Child("rules").Index(ruleIdx)
@@ -196,6 +202,7 @@ func processGRPCRouteRule(
 		rulePath.Child("filters"),
 		validator,
 		resolveExtRefFunc,
+		unknownFilterPolicy,
 	)
 
 	errors = errors.append(filterErrors)
@@ -268,6 +275,7 @@ func processGRPCRouteRules(
 	validator validation.HTTPFieldsValidator,
 	resolveExtRefFunc resolveExtRefFilter,
 	grpcRouteNsName types.NamespacedName,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 	featureFlags FeatureFlags,
 ) (rules []RouteRule, valid bool, conds []conditions.Condition) {
 	rules = make([]RouteRule, len(specRules))
@@ -284,6 +292,7 @@ func processGRPCRouteRules(
 			validator,
 			resolveExtRefFunc,
 			grpcRouteNsName,
+			unknownFilterPolicy,
 			featureFlags,
 		)
 
@@ -13,6 +13,7 @@ import (
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/mirror"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
@@ -286,9 +287,13 @@ func TestBuildGRPCRoutes(t *testing.T) {
 				createAllValidValidator(),
 				map[types.NamespacedName]*v1.HTTPRoute{},
 				grRoutes,
+				nil,
+				nil,
 				test.gateways,
 				snippetsFilters,
 				nil,
+				nil,
+				ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
 				FeatureFlags{
 					Plus:         true,
 					Experimental: true,
@@ -1098,7 +1103,7 @@ func TestBuildGRPCRoute(t *testing.T) {
 				},
 				Conditions: []conditions.Condition{
 					conditions.NewRouteUnsupportedValue(
-						`spec.hostnames[0]: Invalid value: "": cannot be empty string`,
+						`spec.hostnames[0]: Invalid value: "": [NGF1401 InvalidHostname] cannot be empty string`,
 					),
 				},
 			},
@@ -1317,6 +1322,7 @@ func TestBuildGRPCRoute(t *testing.T) {
 				test.gr,
 				gws,
 				snippetsFilters,
+				ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
 				FeatureFlags{
 					Plus:         test.plus,
 					Experimental: test.experimental,
@@ -1484,10 +1490,19 @@ func TestBuildGRPCRouteWithMirrorRoutes(t *testing.T) {
 		gr,
 		gateways,
 		snippetsFilters,
+		ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
 		featureFlags,
 	)
 	g.Expect(l7route).NotTo(BeNil())
-	buildGRPCMirrorRoutes(routes, l7route, gr, gateways, snippetsFilters, featureFlags)
+	buildGRPCMirrorRoutes(
+		routes,
+		l7route,
+		gr,
+		gateways,
+		snippetsFilters,
+		ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+		featureFlags,
+	)
 
 	obj, ok := expectedMirrorRoute.Source.(*v1.GRPCRoute)
 	g.Expect(ok).To(BeTrue())
@@ -1792,6 +1807,7 @@ func TestProcessGRPCRouteRules_UnsupportedFields(t *testing.T) {
 				validation.SkipValidator{},
 				nil,
 				grpcRouteNsName,
+				ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
 				FeatureFlags{
 					Plus:         test.plusEnabled,
 					Experimental: test.experimental,
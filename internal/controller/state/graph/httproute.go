@@ -10,6 +10,7 @@ import (
 	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/mirror"
@@ -31,6 +32,8 @@ func buildHTTPRoute(
 	gws map[types.NamespacedName]*Gateway,
 	snippetsFilters map[types.NamespacedName]*SnippetsFilter,
 	inferencePools map[types.NamespacedName]*inference.InferencePool,
+	waypointServices map[types.NamespacedName]*Gateway,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 	featureFlags FeatureFlags,
 ) *L7Route {
 	r := &L7Route{
@@ -44,6 +47,8 @@ func buildHTTPRoute(
 
 		return r
 	}
+	serviceRefs := buildServiceParentRefs(ghr.Spec.ParentRefs, ghr.Namespace, waypointServices)
+	sectionNameRefs = append(sectionNameRefs, serviceRefs...)
 	// route doesn't belong to any of the Gateways
 	if len(sectionNameRefs) == 0 {
 		return nil
@@ -74,6 +79,7 @@ func buildHTTPRoute(
 		getSnippetsFilterResolverForNamespace(snippetsFilters, r.Source.GetNamespace()),
 		inferencePools,
 		nsName,
+		unknownFilterPolicy,
 		featureFlags,
 	)
 
@@ -90,6 +96,8 @@ func buildHTTPMirrorRoutes(
 	route *v1.HTTPRoute,
 	gateways map[types.NamespacedName]*Gateway,
 	snippetsFilters map[types.NamespacedName]*SnippetsFilter,
+	waypointServices map[types.NamespacedName]*Gateway,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 	featureFlags FeatureFlags,
 ) {
 	for idx, rule := range l7route.Spec.Rules {
@@ -128,6 +136,8 @@ func buildHTTPMirrorRoutes(
 					gateways,
 					snippetsFilters,
 					nil,
+					waypointServices,
+					unknownFilterPolicy,
 					featureFlags,
 				)
 
@@ -184,6 +194,7 @@ func processHTTPRouteRule(
 	resolveExtRefFunc resolveExtRefFilter,
 	inferencePools map[types.NamespacedName]*inference.InferencePool,
 	routeNsName types.NamespacedName,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 	featureFlags FeatureFlags,
 ) (RouteRule, routeRuleErrors) {
 	rulePath := field.NewPath("spec").Child("rules").Index(ruleIdx)
@@ -211,6 +222,7 @@ func processHTTPRouteRule(
 		rulePath.Child("filters"),
 		validator,
 		resolveExtRefFunc,
+		unknownFilterPolicy,
 	)
 	errors = errors.append(filterErrors)
 
@@ -234,6 +246,14 @@ func processHTTPRouteRule(
 		}
 	}
 
+	retry := specRule.Retry
+	if !featureFlags.Experimental {
+		retry = nil
+	}
+
+	timeouts, timeoutsErrors := processRouteTimeouts(specRule.Timeouts, retry, rulePath, validator)
+	errors = errors.append(timeoutsErrors)
+
 	backendRefs, backendRefErrors := getBackendRefs(specRule, routeNsName.Namespace, inferencePools, rulePath, sp)
 	errors = errors.append(backendRefErrors)
 
@@ -258,6 +278,7 @@ func processHTTPRouteRule(
 		Matches:          specRule.Matches,
 		Filters:          routeFilters,
 		RouteBackendRefs: backendRefs,
+		Timeouts:         timeouts,
 	}, errors
 }
 
@@ -329,6 +350,7 @@ func processHTTPRouteRules(
 	resolveExtRefFunc resolveExtRefFilter,
 	inferencePools map[types.NamespacedName]*inference.InferencePool,
 	routeNsName types.NamespacedName,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 	featureFlags FeatureFlags,
 ) (rules []RouteRule, valid bool, conds []conditions.Condition) {
 	rules = make([]RouteRule, len(specRules))
@@ -346,10 +368,11 @@ func processHTTPRouteRules(
 			resolveExtRefFunc,
 			inferencePools,
 			routeNsName,
+			unknownFilterPolicy,
 			featureFlags,
 		)
 
-		if rr.ValidMatches && rr.Filters.Valid {
+		if rr.ValidMatches && rr.Filters.Valid && (rr.Timeouts == nil || rr.Timeouts.Valid) {
 			atLeastOneValid = true
 		}
 
@@ -664,13 +687,7 @@ func checkForUnsupportedHTTPFields(
 			"Name",
 		))
 	}
-	if rule.Timeouts != nil {
-		ruleErrors = append(ruleErrors, field.Forbidden(
-			rulePath.Child("timeouts"),
-			"Timeouts",
-		))
-	}
-	if rule.Retry != nil {
+	if !featureFlags.Experimental && rule.Retry != nil {
 		ruleErrors = append(ruleErrors, field.Forbidden(
 			rulePath.Child("retry"),
 			"Retry",
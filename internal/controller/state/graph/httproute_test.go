@@ -14,6 +14,7 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/mirror"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
@@ -330,9 +331,13 @@ func TestBuildHTTPRoutes(t *testing.T) {
 				createAllValidValidator(),
 				hrRoutes,
 				map[types.NamespacedName]*gatewayv1.GRPCRoute{},
+				nil,
+				nil,
 				test.gateways,
 				snippetsFilters,
 				nil,
+				nil,
+				ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
 				FeatureFlags{
 					Plus:         true,
 					Experimental: true,
@@ -665,7 +670,7 @@ func TestBuildHTTPRoute(t *testing.T) {
 				},
 				Conditions: []conditions.Condition{
 					conditions.NewRouteUnsupportedValue(
-						`Spec.hostnames[0]: Invalid value: "": cannot be empty string`,
+						`Spec.hostnames[0]: Invalid value: "": [NGF1401 InvalidHostname] cannot be empty string`,
 					),
 				},
 			},
@@ -1209,6 +1214,8 @@ func TestBuildHTTPRoute(t *testing.T) {
 				gws,
 				snippetsFilters,
 				inferencePools,
+				nil,
+				ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
 				FeatureFlags{
 					Plus:         test.plus,
 					Experimental: test.experimental,
@@ -1356,11 +1363,22 @@ func TestBuildHTTPRouteWithMirrorRoutes(t *testing.T) {
 		gateways,
 		snippetsFilters,
 		nil,
+		nil,
+		ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
 		featureFlags,
 	)
 	g.Expect(l7route).NotTo(BeNil())
 
-	buildHTTPMirrorRoutes(routes, l7route, hr, gateways, snippetsFilters, featureFlags)
+	buildHTTPMirrorRoutes(
+		routes,
+		l7route,
+		hr,
+		gateways,
+		snippetsFilters,
+		nil,
+		ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+		featureFlags,
+	)
 
 	obj, ok := expectedMirrorRoute.Source.(*gatewayv1.HTTPRoute)
 	g.Expect(ok).To(BeTrue())
@@ -1478,6 +1496,7 @@ func TestProcessHTTPRouteRule_InferencePoolWithMultipleBackendRefs(t *testing.T)
 				nil,
 				inferencePools,
 				routeNsName,
+				ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
 				FeatureFlags{
 					Plus:         false,
 					Experimental: false,
@@ -2111,16 +2130,13 @@ func TestUnsupportedFieldsErrors(t *testing.T) {
 		{
 			name: "Multiple unsupported fields",
 			specRule: gatewayv1.HTTPRouteRule{
-				Name: helpers.GetPointer[gatewayv1.SectionName]("unsupported-name"),
-				Timeouts: helpers.GetPointer(gatewayv1.HTTPRouteTimeouts{
-					Request: (*gatewayv1.Duration)(helpers.GetPointer("unsupported-timeouts")),
-				}),
+				Name:  helpers.GetPointer[gatewayv1.SectionName]("unsupported-name"),
 				Retry: helpers.GetPointer(gatewayv1.HTTPRouteRetry{Attempts: helpers.GetPointer(3)}),
 				SessionPersistence: helpers.GetPointer(gatewayv1.SessionPersistence{
 					Type: helpers.GetPointer(gatewayv1.SessionPersistenceType("unsupported-session-persistence")),
 				}),
 			},
-			expectedErrors: 5,
+			expectedErrors: 4,
 		},
 	}
 
@@ -2190,10 +2206,6 @@ func TestProcessHTTPRouteRules_UnsupportedFields(t *testing.T) {
 			specRules: []gatewayv1.HTTPRouteRule{
 				{
 					Name: helpers.GetPointer[gatewayv1.SectionName]("unsupported-name"),
-					Timeouts: helpers.GetPointer(gatewayv1.HTTPRouteTimeouts{
-						Request: (*gatewayv1.Duration)(helpers.GetPointer("unsupported-timeouts")),
-					}),
-					Retry: helpers.GetPointer(gatewayv1.HTTPRouteRetry{Attempts: helpers.GetPointer(3)}),
 					SessionPersistence: helpers.GetPointer(gatewayv1.SessionPersistence{
 						Type:        helpers.GetPointer(gatewayv1.CookieBasedSessionPersistence),
 						SessionName: helpers.GetPointer("session_id"),
@@ -2203,8 +2215,7 @@ func TestProcessHTTPRouteRules_UnsupportedFields(t *testing.T) {
 			expectedValid: true,
 			expectedConds: []conditions.Condition{
 				conditions.NewRouteAcceptedUnsupportedField(
-					fmt.Sprintf("[spec.rules[0].name: Forbidden: Name, spec.rules[0].timeouts: "+
-						"Forbidden: Timeouts, spec.rules[0].retry: Forbidden: Retry, "+
+					fmt.Sprintf("[spec.rules[0].name: Forbidden: Name, "+
 						"spec.rules[0].sessionPersistence: Forbidden: "+
 						"%s OSS users can use `ip_hash` load balancing method via the UpstreamSettingsPolicy for session affinity.]",
 						spErrMsg,
@@ -2212,7 +2223,44 @@ func TestProcessHTTPRouteRules_UnsupportedFields(t *testing.T) {
 			},
 			experimental:  true,
 			plusEnabled:   false,
-			expectedWarns: 4,
+			expectedWarns: 2,
+		},
+		{
+			name: "Retry unsupported with experimental disabled",
+			specRules: []gatewayv1.HTTPRouteRule{
+				{
+					Timeouts: helpers.GetPointer(gatewayv1.HTTPRouteTimeouts{
+						Request: helpers.GetPointer(gatewayv1.Duration("1s")),
+					}),
+					Retry: helpers.GetPointer(gatewayv1.HTTPRouteRetry{Attempts: helpers.GetPointer(3)}),
+				},
+			},
+			expectedValid: true,
+			expectedConds: []conditions.Condition{
+				conditions.NewRouteAcceptedUnsupportedField("spec.rules[0].retry: Forbidden: Retry"),
+			},
+			expectedWarns: 1,
+		},
+		{
+			name: "Retries exceeding the request timeout",
+			specRules: []gatewayv1.HTTPRouteRule{
+				{
+					Timeouts: helpers.GetPointer(gatewayv1.HTTPRouteTimeouts{
+						Request:        helpers.GetPointer(gatewayv1.Duration("1s")),
+						BackendRequest: helpers.GetPointer(gatewayv1.Duration("500ms")),
+					}),
+					Retry: helpers.GetPointer(gatewayv1.HTTPRouteRetry{Attempts: helpers.GetPointer(3)}),
+				},
+			},
+			expectedValid: false,
+			expectedConds: []conditions.Condition{
+				conditions.NewRouteUnsupportedValue(
+					`All rules are invalid: spec.rules[0].timeouts.request: Invalid value: "1s": ` +
+						"must be at least 2s to fit 4 tries of the backend request timeout 500ms; " +
+						"increase the request timeout, or decrease the backend request timeout or the retry attempts",
+				),
+			},
+			experimental: true,
 		},
 		{
 			name: "Session persistence unsupported with experimental disabled",
@@ -2262,6 +2310,7 @@ func TestProcessHTTPRouteRules_UnsupportedFields(t *testing.T) {
 				nil,
 				nil,
 				routeNsName,
+				ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
 				FeatureFlags{
 					Plus:         test.plusEnabled,
 					Experimental: test.experimental,
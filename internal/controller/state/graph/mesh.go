@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+// WaypointLabel is the label of a Service that binds the Service to a Gateway that acts as its waypoint for
+// east-west (mesh) traffic. The value of the label is the name of a Gateway in the namespace of the Service.
+// HTTPRoutes that have the Service as a parentRef (GAMMA) are attached to the listeners of the waypoint Gateway.
+//
+// Only producer Routes, which are in the namespace of their parent Service, are supported. A consumer Route
+// only applies to the requests of the clients in its namespace, but the waypoint can't tell the namespace
+// of a client from its request, so consumer Routes are not accepted.
+const WaypointLabel = "gateway.nginx.org/use-waypoint"
+
+// buildWaypointServices returns the Services that are bound to one of the Gateways as their waypoint.
+// The key is the NamespacedName of the Service, and the value is the waypoint Gateway.
+func buildWaypointServices(
+	services map[types.NamespacedName]*apiv1.Service,
+	gws map[types.NamespacedName]*Gateway,
+) map[types.NamespacedName]*Gateway {
+	var waypointServices map[types.NamespacedName]*Gateway
+	for nsName, svc := range services {
+		gw := findWaypointForService(svc, gws)
+		if gw == nil {
+			continue
+		}
+
+		if waypointServices == nil {
+			waypointServices = make(map[types.NamespacedName]*Gateway)
+		}
+		waypointServices[nsName] = gw
+	}
+
+	return waypointServices
+}
+
+func findWaypointForService(svc *apiv1.Service, gws map[types.NamespacedName]*Gateway) *Gateway {
+	name, ok := svc.GetLabels()[WaypointLabel]
+	if !ok || name == "" {
+		return nil
+	}
+
+	return gws[types.NamespacedName{Namespace: svc.GetNamespace(), Name: name}]
+}
+
+// isServiceParentRef returns whether the parentRef references a Service, which makes the Route a mesh Route.
+func isServiceParentRef(ref v1.ParentReference) bool {
+	return ref.Kind != nil && *ref.Kind == kinds.Service && ref.Group != nil && *ref.Group == ""
+}
+
+// buildServiceParentRefs builds the ParentRefs for the parentRefs of the Route that reference a Service
+// bound to one of our Gateways as its waypoint. Service parentRefs for Services without such a waypoint
+// are ignored, because they are handled by another mesh implementation.
+func buildServiceParentRefs(
+	parentRefs []v1.ParentReference,
+	routeNamespace string,
+	waypointServices map[types.NamespacedName]*Gateway,
+) []ParentRef {
+	var refs []ParentRef
+
+	for i, p := range parentRefs {
+		if !isServiceParentRef(p) {
+			continue
+		}
+
+		ns := routeNamespace
+		if p.Namespace != nil {
+			ns = string(*p.Namespace)
+		}
+
+		svcNsName := types.NamespacedName{Namespace: ns, Name: string(p.Name)}
+
+		gw, exists := waypointServices[svcNsName]
+		if !exists {
+			continue
+		}
+
+		refs = append(refs, ParentRef{
+			Idx:     i,
+			Gateway: CreateParentRefGateway(gw),
+			Service: &svcNsName,
+			Port:    p.Port,
+		})
+	}
+
+	return refs
+}
+
+// isConsumerRoute returns whether the mesh Route is a consumer Route, meaning that it is not in the namespace
+// of its parent Service. A consumer Route only applies to the requests of the clients in the namespace
+// of the Route, while a producer Route, which is in the namespace of the Service, applies to all requests.
+func isConsumerRoute(routeNamespace string, svcNsName types.NamespacedName) bool {
+	return routeNamespace != svcNsName.Namespace
+}
+
+// serviceHostnames returns the hostnames that clients use to send requests to the Service in the cluster
+// with the DNS domain. The hostnames of a mesh Route are ignored, and these hostnames are used instead.
+func serviceHostnames(svcNsName types.NamespacedName, clusterDomain string) []v1.Hostname {
+	return []v1.Hostname{
+		v1.Hostname(svcNsName.Name),
+		v1.Hostname(fmt.Sprintf("%s.%s", svcNsName.Name, svcNsName.Namespace)),
+		v1.Hostname(fmt.Sprintf("%s.%s.svc", svcNsName.Name, svcNsName.Namespace)),
+		v1.Hostname(fmt.Sprintf("%s.%s.svc.%s", svcNsName.Name, svcNsName.Namespace, clusterDomain)),
+	}
+}
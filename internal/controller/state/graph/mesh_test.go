@@ -0,0 +1,274 @@
+package graph
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+func TestBuildWaypointServices(t *testing.T) {
+	t.Parallel()
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "waypoint"}
+	gw := &Gateway{
+		Source: &v1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: gwNsName.Namespace, Name: gwNsName.Name},
+		},
+	}
+	gws := map[types.NamespacedName]*Gateway{gwNsName: gw}
+
+	createService := func(namespace, name string, labels map[string]string) *apiv1.Service {
+		return &apiv1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		}
+	}
+
+	services := map[types.NamespacedName]*apiv1.Service{
+		{Namespace: "test", Name: "waypoint-svc"}: createService(
+			"test",
+			"waypoint-svc",
+			map[string]string{WaypointLabel: "waypoint"},
+		),
+		{Namespace: "test", Name: "no-label"}: createService("test", "no-label", nil),
+		{Namespace: "test", Name: "empty-label"}: createService(
+			"test",
+			"empty-label",
+			map[string]string{WaypointLabel: ""},
+		),
+		{Namespace: "test", Name: "unknown-gw"}: createService(
+			"test",
+			"unknown-gw",
+			map[string]string{WaypointLabel: "unknown"},
+		),
+		{Namespace: "other", Name: "other-ns"}: createService(
+			"other",
+			"other-ns",
+			map[string]string{WaypointLabel: "waypoint"},
+		),
+	}
+
+	tests := []struct {
+		services map[types.NamespacedName]*apiv1.Service
+		gws      map[types.NamespacedName]*Gateway
+		expected map[types.NamespacedName]*Gateway
+		name     string
+	}{
+		{
+			name:     "waypoint Services",
+			services: services,
+			gws:      gws,
+			expected: map[types.NamespacedName]*Gateway{
+				{Namespace: "test", Name: "waypoint-svc"}: gw,
+			},
+		},
+		{
+			name:     "no Gateways",
+			services: services,
+			gws:      nil,
+			expected: nil,
+		},
+		{
+			name:     "no Services",
+			services: nil,
+			gws:      gws,
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(buildWaypointServices(test.services, test.gws)).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestBuildServiceParentRefs(t *testing.T) {
+	t.Parallel()
+
+	gw := &Gateway{
+		Source: &v1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "waypoint"},
+		},
+	}
+
+	svcNsName := types.NamespacedName{Namespace: "test", Name: "svc"}
+	otherNsSvcNsName := types.NamespacedName{Namespace: "other", Name: "svc"}
+
+	waypointServices := map[types.NamespacedName]*Gateway{
+		svcNsName:        gw,
+		otherNsSvcNsName: gw,
+	}
+
+	parentRefs := []v1.ParentReference{
+		{
+			Name: "waypoint",
+		},
+		{
+			Group: helpers.GetPointer[v1.Group](""),
+			Kind:  helpers.GetPointer[v1.Kind](kinds.Service),
+			Name:  "svc",
+			Port:  helpers.GetPointer[v1.PortNumber](80),
+		},
+		{
+			Group:     helpers.GetPointer[v1.Group](""),
+			Kind:      helpers.GetPointer[v1.Kind](kinds.Service),
+			Namespace: helpers.GetPointer[v1.Namespace]("other"),
+			Name:      "svc",
+		},
+		{
+			Group: helpers.GetPointer[v1.Group](""),
+			Kind:  helpers.GetPointer[v1.Kind](kinds.Service),
+			Name:  "no-waypoint",
+		},
+		{
+			Kind: helpers.GetPointer[v1.Kind](kinds.Service),
+			Name: "svc",
+		},
+	}
+
+	expected := []ParentRef{
+		{
+			Idx:     1,
+			Gateway: CreateParentRefGateway(gw),
+			Service: &svcNsName,
+			Port:    helpers.GetPointer[v1.PortNumber](80),
+		},
+		{
+			Idx:     2,
+			Gateway: CreateParentRefGateway(gw),
+			Service: &otherNsSvcNsName,
+		},
+	}
+
+	g := NewWithT(t)
+
+	g.Expect(buildServiceParentRefs(parentRefs, "test", waypointServices)).To(Equal(expected))
+	g.Expect(buildServiceParentRefs(parentRefs, "test", nil)).To(BeEmpty())
+}
+
+func TestServiceHostnames(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	expected := []v1.Hostname{
+		"svc",
+		"svc.test",
+		"svc.test.svc",
+		"svc.test.svc.example.local",
+	}
+
+	g.Expect(serviceHostnames(types.NamespacedName{Namespace: "test", Name: "svc"}, "example.local")).
+		To(Equal(expected))
+}
+
+func TestBindMeshRouteToListeners(t *testing.T) {
+	t.Parallel()
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "waypoint"}
+	svcNsName := types.NamespacedName{Namespace: "test", Name: "svc"}
+
+	createGateway := func() *Gateway {
+		return &Gateway{
+			Source: &v1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: gwNsName.Namespace, Name: gwNsName.Name},
+			},
+			Listeners: []*Listener{
+				{
+					Name:        "mesh",
+					GatewayName: gwNsName,
+					Source: v1.Listener{
+						Name:     "mesh",
+						Port:     80,
+						Protocol: v1.HTTPProtocolType,
+					},
+					Valid:      true,
+					Attachable: true,
+					Routes:     map[RouteKey]*L7Route{},
+					SupportedKinds: []v1.RouteGroupKind{
+						{Kind: v1.Kind(kinds.HTTPRoute), Group: helpers.GetPointer[v1.Group](v1.GroupName)},
+					},
+				},
+			},
+			Valid: true,
+		}
+	}
+
+	createRoute := func(namespace string) *L7Route {
+		return &L7Route{
+			RouteType: RouteTypeHTTP,
+			Source: &v1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "hr"},
+			},
+			Spec: L7RouteSpec{
+				Hostnames: []v1.Hostname{"ignored.example.com"},
+			},
+			Valid:      true,
+			Attachable: true,
+			ParentRefs: []ParentRef{
+				{
+					Idx:     0,
+					Gateway: &ParentRefGateway{NamespacedName: gwNsName},
+					Service: &svcNsName,
+					// the port of the Service doesn't need to match the port of the Listener
+					Port: helpers.GetPointer[v1.PortNumber](8080),
+				},
+			},
+		}
+	}
+
+	t.Run("producer route", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		gw := createGateway()
+		route := createRoute("test")
+
+		bindL7RouteToListeners(route, gw, nil, "example.local")
+
+		g.Expect(route.ParentRefs[0].Attachment).To(Equal(&ParentRefAttachmentStatus{
+			AcceptedHostnames: map[string][]string{
+				CreateGatewayListenerKey(gwNsName, "mesh"): {
+					"svc",
+					"svc.test",
+					"svc.test.svc",
+					"svc.test.svc.example.local",
+				},
+			},
+			ListenerPort: 80,
+			Attached:     true,
+		}))
+		g.Expect(gw.Listeners[0].Routes).To(HaveKey(CreateRouteKey(route.Source)))
+	})
+
+	t.Run("consumer route", func(t *testing.T) {
+		t.Parallel()
+		g := NewWithT(t)
+
+		gw := createGateway()
+		route := createRoute("consumer")
+
+		bindL7RouteToListeners(route, gw, nil, "cluster.local")
+
+		g.Expect(route.ParentRefs[0].Attachment).To(Equal(&ParentRefAttachmentStatus{
+			AcceptedHostnames: map[string][]string{},
+			FailedConditions: []conditions.Condition{
+				conditions.NewRouteUnsupportedConfiguration(
+					"Consumer routes are not supported: only producer routes, which are in the namespace " +
+						"of their parent Service test/svc, are supported",
+				),
+			},
+		}))
+		g.Expect(gw.Listeners[0].Routes).To(BeEmpty())
+	})
+}
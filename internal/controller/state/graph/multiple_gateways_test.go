@@ -408,10 +408,12 @@ func Test_MultipleGateways_WithNginxProxy(t *testing.T) {
 					GenericValidator:    &validationfakes.FakeGenericValidator{},
 					PolicyValidator:     fakePolicyValidator,
 				},
+				nil,
 				logr.Discard(),
 				FeatureFlags{
 					Experimental: experimentalFeaturesEnabled,
 				},
+				"cluster.local",
 			)
 
 			g.Expect(helpers.Diff(test.expGraph, result)).To(BeEmpty())
@@ -900,10 +902,12 @@ func Test_MultipleGateways_WithListeners(t *testing.T) {
 					GenericValidator:    &validationfakes.FakeGenericValidator{},
 					PolicyValidator:     fakePolicyValidator,
 				},
+				nil,
 				logr.Discard(),
 				FeatureFlags{
 					Experimental: experimentalFeaturesEnabled,
 				},
+				"cluster.local",
 			)
 
 			g.Expect(helpers.Diff(test.expGraph, result)).To(BeEmpty())
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"slices"
+	"strings"
 
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/types"
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -123,6 +125,102 @@ func MetricsEnabledForNginxProxy(np *EffectiveNginxProxy) (*int32, bool) {
 	return nil, true
 }
 
+const (
+	// DefaultLoadBalancerHealthCheckPath is the default path of the health check endpoint for the cloud
+	// load balancer of the NGINX Service.
+	DefaultLoadBalancerHealthCheckPath = "/healthz"
+	// DefaultLoadBalancerHealthCheckPort is the default port of the health check endpoint for the cloud
+	// load balancer of the NGINX Service.
+	DefaultLoadBalancerHealthCheckPort = int32(8082)
+)
+
+// LoadBalancerHealthCheckForNginxProxy returns the path and the port of the health check endpoint for the cloud
+// load balancer of the NGINX Service, and whether the endpoint is enabled. By default, the endpoint is disabled.
+func LoadBalancerHealthCheckForNginxProxy(np *EffectiveNginxProxy) (string, int32, bool) {
+	if np == nil || np.Kubernetes == nil || np.Kubernetes.Service == nil {
+		return "", 0, false
+	}
+
+	healthCheck := np.Kubernetes.Service.LoadBalancerHealthCheck
+	if healthCheck == nil || !healthCheck.Enable {
+		return "", 0, false
+	}
+
+	path := DefaultLoadBalancerHealthCheckPath
+	if healthCheck.Path != nil {
+		path = *healthCheck.Path
+	}
+
+	port := DefaultLoadBalancerHealthCheckPort
+	if healthCheck.Port != nil {
+		port = *healthCheck.Port
+	}
+
+	return path, port, true
+}
+
+// DrainFilePath is the file that the PreStop handler of the NGINX container creates to drain the traffic
+// of the Pod. While the file exists, the readiness endpoint and the health check endpoint for the cloud
+// load balancer respond with 503.
+const DrainFilePath = "/var/run/nginx/drain"
+
+// PreStopDrainForNginxProxy returns the delay of the pre-stop drain of the NGINX container, and whether
+// the drain is enabled. By default, the drain is disabled.
+func PreStopDrainForNginxProxy(np *EffectiveNginxProxy) (int32, bool) {
+	if np == nil || np.Kubernetes == nil {
+		return 0, false
+	}
+
+	var drain *ngfAPIv1alpha2.PreStopDrain
+	switch {
+	case np.Kubernetes.Deployment != nil:
+		drain = np.Kubernetes.Deployment.Container.PreStopDrain
+	case np.Kubernetes.DaemonSet != nil:
+		drain = np.Kubernetes.DaemonSet.Container.PreStopDrain
+	}
+
+	if drain == nil {
+		return 0, false
+	}
+
+	return drain.DelaySeconds, true
+}
+
+// AgentlessEnabledForNginxProxy returns whether the NGINX Pods run without the NGINX agent.
+// By default, the NGINX Pods run with the NGINX agent.
+func AgentlessEnabledForNginxProxy(np *EffectiveNginxProxy) bool {
+	return np != nil && np.Kubernetes != nil && np.Kubernetes.Agentless != nil && np.Kubernetes.Agentless.Enable
+}
+
+// TenantAttributionForNginxProxy returns the name of the request header that identifies the tenant of a request,
+// the tenants that the header can identify, and whether the requests are attributed to tenants. An empty header
+// name means that the tenant of a request is the namespace of its route. By default, the requests are not
+// attributed to tenants.
+func TenantAttributionForNginxProxy(np *EffectiveNginxProxy) (string, []string, bool) {
+	if np == nil || np.TenantAttribution == nil || !np.TenantAttribution.Enable {
+		return "", nil, false
+	}
+
+	if np.TenantAttribution.Header == nil {
+		return "", nil, true
+	}
+
+	return *np.TenantAttribution.Header, np.TenantAttribution.Tenants, true
+}
+
+// UnknownExtensionRefFilterPolicyForGatewayClass returns the policy for the ExtensionRef filters that NGF
+// doesn't recognize, which is set in the NginxProxy of the GatewayClass. By default, the rules with such filters
+// are rejected.
+func UnknownExtensionRefFilterPolicyForGatewayClass(
+	gc *GatewayClass,
+) ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy {
+	if gc == nil || !nginxProxyValid(gc.NginxProxy) || gc.NginxProxy.Source.Spec.UnknownExtensionRefFilters == nil {
+		return ngfAPIv1alpha2.UnknownExtensionRefFilterReject
+	}
+
+	return *gc.NginxProxy.Source.Spec.UnknownExtensionRefFilters
+}
+
 func processNginxProxies(
 	nps map[types.NamespacedName]*ngfAPIv1alpha2.NginxProxy,
 	validator validation.GenericValidator,
@@ -270,6 +368,13 @@ func validateNginxProxy(
 		}
 	}
 
+	if npCfg.Spec.ConnectionCloseTimeout != nil {
+		timeout := *npCfg.Spec.ConnectionCloseTimeout
+		if err := validator.ValidateNginxDuration(string(timeout)); err != nil {
+			allErrs = append(allErrs, field.Invalid(spec.Child("connectionCloseTimeout"), timeout, err.Error()))
+		}
+	}
+
 	allErrs = append(allErrs, validateLogging(npCfg)...)
 
 	allErrs = append(allErrs, validateDNSResolver(validator, npCfg)...)
@@ -278,6 +383,12 @@ func validateNginxProxy(
 
 	allErrs = append(allErrs, validateNginxPlus(npCfg)...)
 
+	allErrs = append(allErrs, validateServiceAccount(npCfg)...)
+
+	allErrs = append(allErrs, validatePreStopDrain(npCfg)...)
+
+	allErrs = append(allErrs, validateDefaultResponseHeaders(validator, npCfg)...)
+
 	return allErrs
 }
 
@@ -384,6 +495,32 @@ func validateDNSResolver(
 	return allErrs
 }
 
+func validateDefaultResponseHeaders(
+	validator validation.GenericValidator,
+	npCfg *ngfAPIv1alpha2.NginxProxy,
+) field.ErrorList {
+	var allErrs field.ErrorList
+	headersPath := field.NewPath("spec", "defaultResponseHeaders")
+
+	names := make(map[string]struct{}, len(npCfg.Spec.DefaultResponseHeaders))
+	for i, header := range npCfg.Spec.DefaultResponseHeaders {
+		headerPath := headersPath.Index(i)
+
+		// header names are case-insensitive, so the list map key doesn't catch all duplicates
+		name := strings.ToLower(header.Name)
+		if _, exists := names[name]; exists {
+			allErrs = append(allErrs, field.Duplicate(headerPath.Child("name"), header.Name))
+		}
+		names[name] = struct{}{}
+
+		if err := validator.ValidateEscapedStringNoVarExpansion(header.Value); err != nil {
+			allErrs = append(allErrs, field.Invalid(headerPath.Child("value"), header.Value, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
 func validateRewriteClientIP(npCfg *ngfAPIv1alpha2.NginxProxy) field.ErrorList {
 	var allErrs field.ErrorList
 	spec := field.NewPath("spec")
@@ -502,3 +639,58 @@ func validateNginxPlus(npCfg *ngfAPIv1alpha2.NginxProxy) field.ErrorList {
 
 	return allErrs
 }
+
+func validateServiceAccount(npCfg *ngfAPIv1alpha2.NginxProxy) field.ErrorList {
+	if npCfg.Spec.Kubernetes == nil || npCfg.Spec.Kubernetes.ServiceAccount == nil {
+		return nil
+	}
+
+	annotationsPath := field.NewPath("spec").Child("kubernetes", "serviceAccount", "annotations")
+
+	return apivalidation.ValidateAnnotations(npCfg.Spec.Kubernetes.ServiceAccount.Annotations, annotationsPath)
+}
+
+func validatePreStopDrain(npCfg *ngfAPIv1alpha2.NginxProxy) field.ErrorList {
+	k8s := npCfg.Spec.Kubernetes
+	if k8s == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	k8sPath := field.NewPath("spec").Child("kubernetes")
+
+	validate := func(path *field.Path, container ngfAPIv1alpha2.ContainerSpec, pod ngfAPIv1alpha2.PodSpec) {
+		drain := container.PreStopDrain
+		if drain == nil {
+			return
+		}
+
+		drainPath := path.Child("container", "preStopDrain")
+
+		if container.Lifecycle != nil && container.Lifecycle.PreStop != nil {
+			allErrs = append(allErrs, field.Forbidden(
+				drainPath,
+				"cannot be set together with a preStop handler in the lifecycle of the container",
+			))
+		}
+
+		grace := pod.TerminationGracePeriodSeconds
+		if grace != nil && *grace <= int64(drain.DelaySeconds) {
+			allErrs = append(allErrs, field.Invalid(
+				path.Child("pod", "terminationGracePeriodSeconds"),
+				*grace,
+				"must be greater than the delaySeconds of the preStopDrain of the container",
+			))
+		}
+	}
+
+	if k8s.Deployment != nil {
+		validate(k8sPath.Child("deployment"), k8s.Deployment.Container, k8s.Deployment.Pod)
+	}
+
+	if k8s.DaemonSet != nil {
+		validate(k8sPath.Child("daemonSet"), k8s.DaemonSet.Container, k8s.DaemonSet.Pod)
+	}
+
+	return allErrs
+}
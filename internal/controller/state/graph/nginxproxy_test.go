@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -460,6 +461,328 @@ func TestMetricsEnabledForNginxProxy(t *testing.T) {
 	}
 }
 
+func TestAgentlessEnabledForNginxProxy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ep      *EffectiveNginxProxy
+		name    string
+		enabled bool
+	}{
+		{
+			name:    "NginxProxy is nil",
+			enabled: false,
+		},
+		{
+			name:    "kubernetes struct is nil",
+			ep:      &EffectiveNginxProxy{},
+			enabled: false,
+		},
+		{
+			name: "agentless struct is nil",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{},
+			},
+			enabled: false,
+		},
+		{
+			name: "agentless is disabled",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Agentless: &ngfAPIv1alpha2.AgentlessSpec{Enable: false},
+				},
+			},
+			enabled: false,
+		},
+		{
+			name: "agentless is enabled",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Agentless: &ngfAPIv1alpha2.AgentlessSpec{Enable: true},
+				},
+			},
+			enabled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(AgentlessEnabledForNginxProxy(test.ep)).To(Equal(test.enabled))
+		})
+	}
+}
+
+func TestTenantAttributionForNginxProxy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ep      *EffectiveNginxProxy
+		name    string
+		header  string
+		tenants []string
+		enabled bool
+	}{
+		{
+			name:    "NginxProxy is nil",
+			enabled: false,
+		},
+		{
+			name:    "tenant attribution struct is nil",
+			ep:      &EffectiveNginxProxy{},
+			enabled: false,
+		},
+		{
+			name: "tenant attribution is disabled",
+			ep: &EffectiveNginxProxy{
+				TenantAttribution: &ngfAPIv1alpha2.TenantAttribution{
+					Enable: false,
+					Header: helpers.GetPointer("X-Tenant"),
+				},
+			},
+			enabled: false,
+		},
+		{
+			name: "tenant attribution is enabled without header",
+			ep: &EffectiveNginxProxy{
+				TenantAttribution: &ngfAPIv1alpha2.TenantAttribution{Enable: true},
+			},
+			enabled: true,
+		},
+		{
+			name: "tenant attribution is enabled with header",
+			ep: &EffectiveNginxProxy{
+				TenantAttribution: &ngfAPIv1alpha2.TenantAttribution{
+					Enable:  true,
+					Header:  helpers.GetPointer("X-Tenant"),
+					Tenants: []string{"team-a", "team-b"},
+				},
+			},
+			header:  "X-Tenant",
+			tenants: []string{"team-a", "team-b"},
+			enabled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			header, tenants, enabled := TenantAttributionForNginxProxy(test.ep)
+			g.Expect(header).To(Equal(test.header))
+			g.Expect(tenants).To(Equal(test.tenants))
+			g.Expect(enabled).To(Equal(test.enabled))
+		})
+	}
+}
+
+func TestUnknownExtensionRefFilterPolicyForGatewayClass(t *testing.T) {
+	t.Parallel()
+
+	createGatewayClass := func(policy *ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy, valid bool) *GatewayClass {
+		return &GatewayClass{
+			NginxProxy: &NginxProxy{
+				Source: &ngfAPIv1alpha2.NginxProxy{
+					Spec: ngfAPIv1alpha2.NginxProxySpec{UnknownExtensionRefFilters: policy},
+				},
+				Valid: valid,
+			},
+		}
+	}
+
+	tests := []struct {
+		gc        *GatewayClass
+		name      string
+		expPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy
+	}{
+		{
+			name:      "GatewayClass is nil",
+			expPolicy: ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+		},
+		{
+			name:      "GatewayClass doesn't reference an NginxProxy",
+			gc:        &GatewayClass{},
+			expPolicy: ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+		},
+		{
+			name:      "policy is not set",
+			gc:        createGatewayClass(nil, true),
+			expPolicy: ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+		},
+		{
+			name: "policy is set",
+			gc: createGatewayClass(
+				helpers.GetPointer(ngfAPIv1alpha2.UnknownExtensionRefFilterWarn),
+				true,
+			),
+			expPolicy: ngfAPIv1alpha2.UnknownExtensionRefFilterWarn,
+		},
+		{
+			name: "policy is set in an invalid NginxProxy",
+			gc: createGatewayClass(
+				helpers.GetPointer(ngfAPIv1alpha2.UnknownExtensionRefFilterIgnore),
+				false,
+			),
+			expPolicy: ngfAPIv1alpha2.UnknownExtensionRefFilterReject,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(UnknownExtensionRefFilterPolicyForGatewayClass(test.gc)).To(Equal(test.expPolicy))
+		})
+	}
+}
+
+func TestLoadBalancerHealthCheckForNginxProxy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ep      *EffectiveNginxProxy
+		name    string
+		path    string
+		port    int32
+		enabled bool
+	}{
+		{
+			name:    "NginxProxy is nil",
+			enabled: false,
+		},
+		{
+			name: "service struct is nil",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{},
+			},
+			enabled: false,
+		},
+		{
+			name: "health check is disabled",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Service: &ngfAPIv1alpha2.ServiceSpec{
+						LoadBalancerHealthCheck: &ngfAPIv1alpha2.LoadBalancerHealthCheck{
+							Path: helpers.GetPointer("/lb-health"),
+						},
+					},
+				},
+			},
+			enabled: false,
+		},
+		{
+			name: "health check is enabled with defaults",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Service: &ngfAPIv1alpha2.ServiceSpec{
+						LoadBalancerHealthCheck: &ngfAPIv1alpha2.LoadBalancerHealthCheck{Enable: true},
+					},
+				},
+			},
+			path:    DefaultLoadBalancerHealthCheckPath,
+			port:    DefaultLoadBalancerHealthCheckPort,
+			enabled: true,
+		},
+		{
+			name: "health check is enabled with path and port",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Service: &ngfAPIv1alpha2.ServiceSpec{
+						LoadBalancerHealthCheck: &ngfAPIv1alpha2.LoadBalancerHealthCheck{
+							Path:   helpers.GetPointer("/lb-health"),
+							Port:   helpers.GetPointer[int32](9000),
+							Enable: true,
+						},
+					},
+				},
+			},
+			path:    "/lb-health",
+			port:    9000,
+			enabled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			path, port, enabled := LoadBalancerHealthCheckForNginxProxy(test.ep)
+			g.Expect(path).To(Equal(test.path))
+			g.Expect(port).To(Equal(test.port))
+			g.Expect(enabled).To(Equal(test.enabled))
+		})
+	}
+}
+
+func TestPreStopDrainForNginxProxy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ep      *EffectiveNginxProxy
+		name    string
+		delay   int32
+		enabled bool
+	}{
+		{
+			name:    "NginxProxy is nil",
+			enabled: false,
+		},
+		{
+			name: "drain is not set",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Deployment: &ngfAPIv1alpha2.DeploymentSpec{},
+				},
+			},
+			enabled: false,
+		},
+		{
+			name: "drain is set on the Deployment",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Deployment: &ngfAPIv1alpha2.DeploymentSpec{
+						Container: ngfAPIv1alpha2.ContainerSpec{
+							PreStopDrain: &ngfAPIv1alpha2.PreStopDrain{DelaySeconds: 15},
+						},
+					},
+				},
+			},
+			delay:   15,
+			enabled: true,
+		},
+		{
+			name: "drain is set on the DaemonSet",
+			ep: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					DaemonSet: &ngfAPIv1alpha2.DaemonSetSpec{
+						Container: ngfAPIv1alpha2.ContainerSpec{
+							PreStopDrain: &ngfAPIv1alpha2.PreStopDrain{DelaySeconds: 30},
+						},
+					},
+				},
+			},
+			delay:   30,
+			enabled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			delay, enabled := PreStopDrainForNginxProxy(test.ep)
+			g.Expect(delay).To(Equal(test.delay))
+			g.Expect(enabled).To(Equal(test.enabled))
+		})
+	}
+}
+
 func TestProcessNginxProxies(t *testing.T) {
 	t.Parallel()
 
@@ -863,6 +1186,19 @@ func TestValidateNginxProxy(t *testing.T) {
 			expErrSubstring: "telemetry.exporter.interval",
 			expectErrCount:  1,
 		},
+		{
+			name:      "invalid connectionCloseTimeout",
+			validator: createInvalidValidator(),
+			np: &ngfAPIv1alpha2.NginxProxy{
+				Spec: ngfAPIv1alpha2.NginxProxySpec{
+					ConnectionCloseTimeout: helpers.GetPointer[ngfAPIv1alpha1.Duration](
+						"my-timeout",
+					), // any value is invalid by the validator
+				},
+			},
+			expErrSubstring: "spec.connectionCloseTimeout",
+			expectErrCount:  1,
+		},
 		{
 			name:      "invalid spanAttributes",
 			validator: createInvalidValidator(),
@@ -1583,6 +1919,216 @@ func TestValidateNginxPlus(t *testing.T) {
 	}
 }
 
+func TestValidateServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		np             *ngfAPIv1alpha2.NginxProxy
+		name           string
+		expectErrCount int
+	}{
+		{
+			name:           "no kubernetes spec",
+			np:             &ngfAPIv1alpha2.NginxProxy{},
+			expectErrCount: 0,
+		},
+		{
+			name: "valid annotations",
+			np: &ngfAPIv1alpha2.NginxProxy{
+				Spec: ngfAPIv1alpha2.NginxProxySpec{
+					Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+						ServiceAccount: &ngfAPIv1alpha2.ServiceAccountSpec{
+							Annotations: map[string]string{
+								"eks.amazonaws.com/role-arn":     "arn:aws:iam::123456789012:role/gateway",
+								"iam.gke.io/gcp-service-account": "gateway@project.iam.gserviceaccount.com",
+							},
+						},
+					},
+				},
+			},
+			expectErrCount: 0,
+		},
+		{
+			name: "invalid annotation key",
+			np: &ngfAPIv1alpha2.NginxProxy{
+				Spec: ngfAPIv1alpha2.NginxProxySpec{
+					Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+						ServiceAccount: &ngfAPIv1alpha2.ServiceAccountSpec{
+							Annotations: map[string]string{
+								"invalid key": "value",
+							},
+						},
+					},
+				},
+			},
+			expectErrCount: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			allErrs := validateServiceAccount(test.np)
+			g.Expect(allErrs).To(HaveLen(test.expectErrCount))
+			if len(allErrs) > 0 {
+				g.Expect(allErrs[0].Field).To(Equal("spec.kubernetes.serviceAccount.annotations"))
+			}
+		})
+	}
+}
+
+func TestValidatePreStopDrain(t *testing.T) {
+	t.Parallel()
+
+	createNginxProxy := func(container ngfAPIv1alpha2.ContainerSpec, grace *int64) *ngfAPIv1alpha2.NginxProxy {
+		return &ngfAPIv1alpha2.NginxProxy{
+			Spec: ngfAPIv1alpha2.NginxProxySpec{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					Deployment: &ngfAPIv1alpha2.DeploymentSpec{
+						Container: container,
+						Pod:       ngfAPIv1alpha2.PodSpec{TerminationGracePeriodSeconds: grace},
+					},
+				},
+			},
+		}
+	}
+
+	drain := &ngfAPIv1alpha2.PreStopDrain{DelaySeconds: 15}
+	preStop := &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"sleep", "15"}},
+		},
+	}
+
+	tests := []struct {
+		np             *ngfAPIv1alpha2.NginxProxy
+		name           string
+		expectedFields []string
+	}{
+		{
+			name: "no kubernetes spec",
+			np:   &ngfAPIv1alpha2.NginxProxy{},
+		},
+		{
+			name: "drain is not set",
+			np:   createNginxProxy(ngfAPIv1alpha2.ContainerSpec{Lifecycle: preStop}, helpers.GetPointer[int64](5)),
+		},
+		{
+			name: "valid drain",
+			np:   createNginxProxy(ngfAPIv1alpha2.ContainerSpec{PreStopDrain: drain}, helpers.GetPointer[int64](45)),
+		},
+		{
+			name: "drain with a preStop handler",
+			np: createNginxProxy(
+				ngfAPIv1alpha2.ContainerSpec{PreStopDrain: drain, Lifecycle: preStop},
+				nil,
+			),
+			expectedFields: []string{"spec.kubernetes.deployment.container.preStopDrain"},
+		},
+		{
+			name:           "termination grace period is not longer than the delay",
+			np:             createNginxProxy(ngfAPIv1alpha2.ContainerSpec{PreStopDrain: drain}, helpers.GetPointer[int64](15)),
+			expectedFields: []string{"spec.kubernetes.deployment.pod.terminationGracePeriodSeconds"},
+		},
+		{
+			name: "invalid drain on the DaemonSet",
+			np: &ngfAPIv1alpha2.NginxProxy{
+				Spec: ngfAPIv1alpha2.NginxProxySpec{
+					Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+						DaemonSet: &ngfAPIv1alpha2.DaemonSetSpec{
+							Container: ngfAPIv1alpha2.ContainerSpec{PreStopDrain: drain, Lifecycle: preStop},
+						},
+					},
+				},
+			},
+			expectedFields: []string{"spec.kubernetes.daemonSet.container.preStopDrain"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			allErrs := validatePreStopDrain(test.np)
+
+			fields := make([]string, 0, len(allErrs))
+			for _, err := range allErrs {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(test.expectedFields))
+		})
+	}
+}
+
+func TestValidateDefaultResponseHeaders(t *testing.T) {
+	t.Parallel()
+
+	invalidValueValidator := createValidValidator()
+	invalidValueValidator.ValidateEscapedStringNoVarExpansionReturns(errors.New("error"))
+
+	tests := []struct {
+		validator      *validationfakes.FakeGenericValidator
+		name           string
+		expectedField  string
+		headers        []ngfAPIv1alpha2.HTTPHeader
+		expectErrCount int
+	}{
+		{
+			name:           "no headers",
+			validator:      createValidValidator(),
+			expectErrCount: 0,
+		},
+		{
+			name:      "valid headers",
+			validator: createValidValidator(),
+			headers: []ngfAPIv1alpha2.HTTPHeader{
+				{Name: "X-Frame-Options", Value: "DENY"},
+				{Name: "Strict-Transport-Security", Value: "max-age=31536000"},
+			},
+			expectErrCount: 0,
+		},
+		{
+			name:      "duplicate header names",
+			validator: createValidValidator(),
+			headers: []ngfAPIv1alpha2.HTTPHeader{
+				{Name: "X-Frame-Options", Value: "DENY"},
+				{Name: "x-frame-options", Value: "SAMEORIGIN"},
+			},
+			expectedField:  "spec.defaultResponseHeaders[1].name",
+			expectErrCount: 1,
+		},
+		{
+			name:      "invalid header value",
+			validator: invalidValueValidator,
+			headers: []ngfAPIv1alpha2.HTTPHeader{
+				{Name: "X-Frame-Options", Value: "$deny"},
+			},
+			expectedField:  "spec.defaultResponseHeaders[0].value",
+			expectErrCount: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			np := &ngfAPIv1alpha2.NginxProxy{
+				Spec: ngfAPIv1alpha2.NginxProxySpec{DefaultResponseHeaders: test.headers},
+			}
+
+			allErrs := validateDefaultResponseHeaders(test.validator, np)
+			g.Expect(allErrs).To(HaveLen(test.expectErrCount))
+			if len(allErrs) > 0 {
+				g.Expect(allErrs[0].Field).To(Equal(test.expectedField))
+			}
+		})
+	}
+}
+
 func TestValidateNginxProxy_NilCase(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
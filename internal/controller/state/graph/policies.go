@@ -53,6 +53,9 @@ type PolicyTargetRef struct {
 	Group v1.Group
 	// Nsname is the NamespacedName of the object.
 	Nsname types.NamespacedName
+	// SectionName is the name of the section of the object, like a listener of a Gateway.
+	// It is empty if the Policy targets the whole object.
+	SectionName v1.SectionName
 }
 
 // PolicyKey is a unique identifier for an NGF Policy.
@@ -125,7 +128,7 @@ func (g *Graph) attachPolicies(validator validation.PolicyValidator, ctlrName st
 		for _, ref := range policy.TargetRefs {
 			switch ref.Kind {
 			case kinds.Gateway:
-				attachPolicyToGateway(policy, ref, g.Gateways, ctlrName, logger)
+				attachPolicyToGateway(policy, ref, g.Gateways, validator, ctlrName, logger)
 			case kinds.HTTPRoute, kinds.GRPCRoute:
 				route, exists := g.Routes[routeKeyForKind(ref.Kind, ref.Nsname)]
 				if !exists {
@@ -182,15 +185,6 @@ func attachPolicyToService(
 			continue
 		}
 
-		// Check if this is an existing gateway from policy status
-		_, isExistingGateway := existingNGFGatewayAncestors[gwNsName]
-
-		if isExistingGateway {
-			// Existing gateway from policy status - mark as attached but don't add to ancestors
-			attachedToAnyGateway = true
-			continue
-		}
-
 		if ngfPolicyAncestorsFull(policy, ctlrName) {
 			policyName := getPolicyName(policy.Source)
 			policyKind := getPolicyKind(policy.Source)
@@ -262,12 +256,13 @@ func attachPolicyToRoute(
 	// Track which gateways the policy is effective for
 	var effectiveGateways []types.NamespacedName
 
-	// as of now, ObservabilityPolicy is the only policy that needs this check, and it only attaches to Routes
+	// the ObservabilityPolicy and the ClientSettingsPolicy need this check
 	for _, parentRef := range route.ParentRefs {
 		if parentRef.Gateway != nil && parentRef.Gateway.EffectiveNginxProxy != nil {
 			gw := parentRef.Gateway
 			globalSettings := &policies.GlobalSettings{
-				TelemetryEnabled: telemetryEnabledForNginxProxy(gw.EffectiveNginxProxy),
+				TelemetryEnabled:   telemetryEnabledForNginxProxy(gw.EffectiveNginxProxy),
+				DNSResolverEnabled: gw.EffectiveNginxProxy.DNSResolver != nil,
 			}
 
 			if conds := validator.ValidateGlobalSettings(policy.Source, globalSettings); len(conds) > 0 {
@@ -292,10 +287,15 @@ func attachPolicyToGateway(
 	policy *Policy,
 	ref PolicyTargetRef,
 	gateways map[types.NamespacedName]*Gateway,
+	validator validation.PolicyValidator,
 	ctlrName string,
 	logger logr.Logger,
 ) {
 	ancestorRef := createParentReference(v1.GroupName, kinds.Gateway, ref.Nsname)
+	if ref.SectionName != "" {
+		ancestorRef.SectionName = &ref.SectionName
+	}
+
 	gw, exists := gateways[ref.Nsname]
 
 	if _, ok := policy.InvalidForGateways[ref.Nsname]; ok {
@@ -305,7 +305,7 @@ func attachPolicyToGateway(
 	if ancestorsContainsAncestorRef(policy.Ancestors, ancestorRef) {
 		// Ancestor already exists, but still attach policy to gateway if it's valid
 		if exists && gw != nil && gw.Valid && gw.Source != nil {
-			gw.Policies = append(gw.Policies, policy)
+			attachPolicyToGatewaySection(policy, gw, ref.SectionName)
 		}
 		return
 	}
@@ -346,20 +346,87 @@ func attachPolicyToGateway(
 		return
 	}
 
+	if ref.SectionName != "" {
+		// The Gateway is not marked as invalid for the Policy, because the Policy can target other listeners
+		// of the Gateway.
+		listener := findListener(gw, ref.SectionName)
+		if listener == nil {
+			ancestor.Conditions = []conditions.Condition{
+				conditions.NewPolicyTargetNotFound("The TargetRef listener is not found"),
+			}
+			policy.Ancestors = append(policy.Ancestors, ancestor)
+			return
+		}
+
+		if !listener.Valid {
+			ancestor.Conditions = []conditions.Condition{
+				conditions.NewPolicyTargetNotFound("The TargetRef listener is invalid"),
+			}
+			policy.Ancestors = append(policy.Ancestors, ancestor)
+			return
+		}
+	}
+
+	if gw.EffectiveNginxProxy != nil {
+		globalSettings := &policies.GlobalSettings{
+			TelemetryEnabled:   telemetryEnabledForNginxProxy(gw.EffectiveNginxProxy),
+			DNSResolverEnabled: gw.EffectiveNginxProxy.DNSResolver != nil,
+		}
+
+		if conds := validator.ValidateGlobalSettings(policy.Source, globalSettings); len(conds) > 0 {
+			policy.InvalidForGateways[ref.Nsname] = struct{}{}
+			ancestor.Conditions = append(ancestor.Conditions, conds...)
+			policy.Ancestors = append(policy.Ancestors, ancestor)
+			return
+		}
+	}
+
 	// Policy is effective for this gateway (not adding to InvalidForGateways)
 
 	policy.Ancestors = append(policy.Ancestors, ancestor)
-	gw.Policies = append(gw.Policies, policy)
+	attachPolicyToGatewaySection(policy, gw, ref.SectionName)
 }
 
+// attachPolicyToGatewaySection attaches the Policy to the listener of the Gateway with the sectionName,
+// or to the Gateway if the sectionName is empty.
+func attachPolicyToGatewaySection(policy *Policy, gw *Gateway, sectionName v1.SectionName) {
+	if sectionName == "" {
+		gw.Policies = append(gw.Policies, policy)
+		return
+	}
+
+	if listener := findListener(gw, sectionName); listener != nil && listener.Valid {
+		listener.Policies = append(listener.Policies, policy)
+	}
+}
+
+// findListener returns the listener of the Gateway with the name, or nil if the Gateway has no such listener.
+func findListener(gw *Gateway, name v1.SectionName) *Listener {
+	for _, l := range gw.Listeners {
+		if l.Name == string(name) {
+			return l
+		}
+	}
+
+	return nil
+}
+
+// processPolicies processes the NGF Policies that target the resources in the graph.
+// It also keeps the following Policies, so that their status is kept up to date:
+//   - Policies that target a Gateway or a Route that doesn't exist in the cluster. Such Policies get
+//     the TargetNotFound condition for the missing target.
+//   - Policies that have ancestor statuses written by NGF. If such a Policy no longer targets
+//     a resource in the graph, its stale ancestor statuses are pruned.
 func processPolicies(
 	pols map[PolicyKey]policies.Policy,
 	validator validation.PolicyValidator,
 	routes map[RouteKey]*L7Route,
 	services map[types.NamespacedName]*ReferencedService,
 	gws map[types.NamespacedName]*Gateway,
+	state ClusterState,
+	ctlrName string,
 ) map[PolicyKey]*Policy {
-	if len(pols) == 0 || len(gws) == 0 {
+	if len(pols) == 0 {
 		return nil
 	}
 
@@ -370,19 +437,28 @@ func processPolicies(
 
 		targetRefs := make([]PolicyTargetRef, 0, len(policy.GetTargetRefs()))
 		targetedRoutes := make(map[types.NamespacedName]*L7Route)
+		var missingTargets []v1.ParentReference
 
-		for _, ref := range policy.GetTargetRefs() {
+		for _, ref := range policies.TargetRefsWithSectionName(policy) {
 			refNsName := types.NamespacedName{Name: string(ref.Name), Namespace: policy.GetNamespace()}
 
 			switch refGroupKind(ref.Group, ref.Kind) {
 			case gatewayGroupKind:
 				if !gatewayExists(refNsName, gws) {
+					if _, exists := state.Gateways[refNsName]; !exists {
+						missingTarget := createParentReference(v1.GroupName, ref.Kind, refNsName)
+						missingTarget.SectionName = ref.SectionName
+						missingTargets = append(missingTargets, missingTarget)
+					}
 					continue
 				}
 			case hrGroupKind, grpcGroupKind:
 				if route, exists := routes[routeKeyForKind(ref.Kind, refNsName)]; exists {
 					targetedRoutes[client.ObjectKeyFromObject(route.Source)] = route
 				} else {
+					if !routeExistsInCluster(ref.Kind, refNsName, state) {
+						missingTargets = append(missingTargets, createParentReference(v1.GroupName, ref.Kind, refNsName))
+					}
 					continue
 				}
 			case serviceGroupKind:
@@ -393,15 +469,19 @@ func processPolicies(
 				continue
 			}
 
-			targetRefs = append(targetRefs,
-				PolicyTargetRef{
-					Kind:   ref.Kind,
-					Group:  ref.Group,
-					Nsname: refNsName,
-				})
+			targetRef := PolicyTargetRef{
+				Kind:   ref.Kind,
+				Group:  ref.Group,
+				Nsname: refNsName,
+			}
+			if ref.SectionName != nil {
+				targetRef.SectionName = *ref.SectionName
+			}
+
+			targetRefs = append(targetRefs, targetRef)
 		}
 
-		if len(targetRefs) == 0 {
+		if len(targetRefs) == 0 && len(missingTargets) == 0 && !hasNGFAncestors(policy, ctlrName) {
 			continue
 		}
 
@@ -410,14 +490,27 @@ func processPolicies(
 
 		conds = append(conds, validator.Validate(policy)...)
 
-		processedPolicies[key] = &Policy{
+		processedPolicy := &Policy{
 			Source:             policy,
 			Valid:              len(conds) == 0,
 			Conditions:         conds,
 			TargetRefs:         targetRefs,
-			Ancestors:          make([]PolicyAncestor, 0, len(targetRefs)),
+			Ancestors:          make([]PolicyAncestor, 0, len(targetRefs)+len(missingTargets)),
 			InvalidForGateways: make(map[types.NamespacedName]struct{}),
 		}
+
+		for _, ref := range missingTargets {
+			if ngfPolicyAncestorsFull(processedPolicy, ctlrName) {
+				break
+			}
+
+			processedPolicy.Ancestors = append(processedPolicy.Ancestors, PolicyAncestor{
+				Ancestor:   ref,
+				Conditions: []conditions.Condition{conditions.NewPolicyTargetNotFound("The TargetRef is not found")},
+			})
+		}
+
+		processedPolicies[key] = processedPolicy
 	}
 
 	markConflictedPolicies(processedPolicies, validator)
@@ -425,6 +518,32 @@ func processPolicies(
 	return processedPolicies
 }
 
+// routeExistsInCluster returns whether the HTTPRoute or GRPCRoute exists in the cluster, regardless of
+// whether it is attached to a Gateway in the graph.
+func routeExistsInCluster(kind v1.Kind, nsname types.NamespacedName, state ClusterState) bool {
+	switch kind {
+	case kinds.HTTPRoute:
+		_, exists := state.HTTPRoutes[nsname]
+		return exists
+	case kinds.GRPCRoute:
+		_, exists := state.GRPCRoutes[nsname]
+		return exists
+	default:
+		return false
+	}
+}
+
+// hasNGFAncestors returns whether the Policy has ancestor statuses written by NGF.
+func hasNGFAncestors(policy policies.Policy, ctlrName string) bool {
+	for _, ancestor := range policy.GetPolicyStatus().Ancestors {
+		if string(ancestor.ControllerName) == ctlrName {
+			return true
+		}
+	}
+
+	return false
+}
+
 func checkTargetRoutesForOverlap(
 	targetedRoutes map[types.NamespacedName]*L7Route,
 	graphRoutes map[RouteKey]*L7Route,
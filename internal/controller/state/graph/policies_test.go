@@ -644,7 +644,14 @@ func TestAttachPolicyToGateway(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
 
-			attachPolicyToGateway(test.policy, test.policy.TargetRefs[0], test.gws, "nginx-gateway", logr.Discard())
+			attachPolicyToGateway(
+				test.policy,
+				test.policy.TargetRefs[0],
+				test.gws,
+				&policiesfakes.FakeValidator{},
+				"nginx-gateway",
+				logr.Discard(),
+			)
 
 			if test.expAttached {
 				for _, gw := range test.gws {
@@ -661,6 +668,175 @@ func TestAttachPolicyToGateway(t *testing.T) {
 	}
 }
 
+func TestAttachPolicyToGatewayListener(t *testing.T) {
+	t.Parallel()
+	gatewayNsName := types.NamespacedName{Namespace: testNs, Name: "gateway"}
+
+	newGateway := func(np *EffectiveNginxProxy) *Gateway {
+		return &Gateway{
+			Source: &v1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gatewayNsName.Name,
+					Namespace: gatewayNsName.Namespace,
+				},
+			},
+			Listeners: []*Listener{
+				{Name: "admin", Valid: true},
+				{Name: "public", Valid: true},
+				{Name: "invalid", Valid: false},
+			},
+			EffectiveNginxProxy: np,
+			Valid:               true,
+		}
+	}
+
+	newPolicy := func(sectionName v1.SectionName) *Policy {
+		return &Policy{
+			Source: &policiesfakes.FakePolicy{},
+			TargetRefs: []PolicyTargetRef{
+				{
+					Nsname:      gatewayNsName,
+					Kind:        kinds.Gateway,
+					SectionName: sectionName,
+				},
+			},
+			InvalidForGateways: map[types.NamespacedName]struct{}{},
+		}
+	}
+
+	getListenerParentRef := func(sectionName v1.SectionName) v1.ParentReference {
+		ref := getGatewayParentRef(gatewayNsName)
+		ref.SectionName = &sectionName
+		return ref
+	}
+
+	validatorError := &policiesfakes.FakeValidator{
+		ValidateGlobalSettingsStub: func(_ policies.Policy, gs *policies.GlobalSettings) []conditions.Condition {
+			if !gs.TelemetryEnabled {
+				return []conditions.Condition{
+					conditions.NewPolicyNotAcceptedNginxProxyNotSet(conditions.PolicyMessageTelemetryNotEnabled),
+				}
+			}
+			return nil
+		},
+	}
+
+	tests := []struct {
+		policy              *Policy
+		gw                  *Gateway
+		validator           validation.PolicyValidator
+		name                string
+		expAncestors        []PolicyAncestor
+		expAttachedListener string
+		expInvalidForGw     bool
+	}{
+		{
+			name:      "attached to listener",
+			policy:    newPolicy("admin"),
+			gw:        newGateway(nil),
+			validator: &policiesfakes.FakeValidator{},
+			expAncestors: []PolicyAncestor{
+				{Ancestor: getListenerParentRef("admin")},
+			},
+			expAttachedListener: "admin",
+		},
+		{
+			name:      "not attached; listener is not found",
+			policy:    newPolicy("missing"),
+			gw:        newGateway(nil),
+			validator: &policiesfakes.FakeValidator{},
+			expAncestors: []PolicyAncestor{
+				{
+					Ancestor: getListenerParentRef("missing"),
+					Conditions: []conditions.Condition{
+						conditions.NewPolicyTargetNotFound("The TargetRef listener is not found"),
+					},
+				},
+			},
+		},
+		{
+			name:      "not attached; listener is invalid",
+			policy:    newPolicy("invalid"),
+			gw:        newGateway(nil),
+			validator: &policiesfakes.FakeValidator{},
+			expAncestors: []PolicyAncestor{
+				{
+					Ancestor: getListenerParentRef("invalid"),
+					Conditions: []conditions.Condition{
+						conditions.NewPolicyTargetNotFound("The TargetRef listener is invalid"),
+					},
+				},
+			},
+		},
+		{
+			name:      "not attached; telemetry is not enabled",
+			policy:    newPolicy("admin"),
+			gw:        newGateway(&EffectiveNginxProxy{}),
+			validator: validatorError,
+			expAncestors: []PolicyAncestor{
+				{
+					Ancestor: getListenerParentRef("admin"),
+					Conditions: []conditions.Condition{
+						conditions.NewPolicyNotAcceptedNginxProxyNotSet(conditions.PolicyMessageTelemetryNotEnabled),
+					},
+				},
+			},
+			expInvalidForGw: true,
+		},
+		{
+			name:   "attached to listener; telemetry is enabled",
+			policy: newPolicy("admin"),
+			gw: newGateway(&EffectiveNginxProxy{
+				Telemetry: &ngfAPIv1alpha2.Telemetry{
+					Exporter: &ngfAPIv1alpha2.TelemetryExporter{
+						Endpoint: helpers.GetPointer("test-endpoint"),
+					},
+				},
+			}),
+			validator: validatorError,
+			expAncestors: []PolicyAncestor{
+				{Ancestor: getListenerParentRef("admin")},
+			},
+			expAttachedListener: "admin",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			gws := map[types.NamespacedName]*Gateway{gatewayNsName: test.gw}
+
+			attachPolicyToGateway(
+				test.policy,
+				test.policy.TargetRefs[0],
+				gws,
+				test.validator,
+				"nginx-gateway",
+				logr.Discard(),
+			)
+
+			g.Expect(test.gw.Policies).To(BeEmpty())
+
+			for _, l := range test.gw.Listeners {
+				if l.Name == test.expAttachedListener {
+					g.Expect(l.Policies).To(HaveLen(1))
+				} else {
+					g.Expect(l.Policies).To(BeEmpty())
+				}
+			}
+
+			g.Expect(test.policy.Ancestors).To(BeEquivalentTo(test.expAncestors))
+			if test.expInvalidForGw {
+				g.Expect(test.policy.InvalidForGateways).To(HaveKey(gatewayNsName))
+			} else {
+				g.Expect(test.policy.InvalidForGateways).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestAttachPolicyToService(t *testing.T) {
 	t.Parallel()
 
@@ -762,10 +938,14 @@ func TestAttachPolicyToService(t *testing.T) {
 				},
 			},
 			expAttached: true,
-			// Only new gateway should be added to ancestors, existing one already exists in policy status
+			// The existing gateway is reported again, so that its ancestor status isn't pruned,
+			// and it is processed before the new gateway, so that it keeps its place if the ancestors are full.
 			expAncestors: []PolicyAncestor{
 				{
-					Ancestor: getGatewayParentRef(gw2Nsname), // Only new gateway gets added
+					Ancestor: getGatewayParentRef(gwNsname),
+				},
+				{
+					Ancestor: getGatewayParentRef(gw2Nsname),
 				},
 			},
 		},
@@ -895,9 +1075,12 @@ func TestProcessPolicies(t *testing.T) {
 	gatewayRef2 := createTestRef(kinds.Gateway, v1.GroupName, "gw2")
 	svcRef := createTestRef(kinds.Service, "core", "svc")
 
+	// This ref references an object that doesn't exist in the cluster.
+	// Policies that contain this ref should be processed to report that the target is not found.
+	hrDoesNotExistRef := createTestRef(kinds.HTTPRoute, v1.GroupName, "dne")
+
 	// These refs reference objects that do not belong to NGF.
 	// Policies that contain these refs should NOT be processed.
-	hrDoesNotExistRef := createTestRef(kinds.HTTPRoute, v1.GroupName, "dne")
 	hrWrongGroup := createTestRef(kinds.HTTPRoute, "WrongGroup", "hr")
 	gatewayWrongGroupRef := createTestRef(kinds.Gateway, "WrongGroup", "gw")
 	nonNGFGatewayRef := createTestRef(kinds.Gateway, v1.GroupName, "not-ours")
@@ -914,6 +1097,28 @@ func TestProcessPolicies(t *testing.T) {
 	pol9, pol9Key := createTestPolicyAndKey(policyGVK, "pol9", svcDoesNotExistRef)
 	pol10, pol10Key := createTestPolicyAndKey(policyGVK, "pol10", svcRef)
 
+	// This policy no longer targets an object that belongs to NGF, but it has an ancestor status written by NGF.
+	// It should be processed, so that the stale ancestor status is pruned.
+	pol11, pol11Key := createTestPolicyAndKey(policyGVK, "pol11", nonNGFGatewayRef)
+	pol11.(*policiesfakes.FakePolicy).GetPolicyStatusReturns(v1.PolicyStatus{
+		Ancestors: []v1.PolicyAncestorStatus{
+			{
+				AncestorRef: createParentReference(
+					v1.GroupName,
+					kinds.Gateway,
+					types.NamespacedName{Namespace: testNs, Name: "not-ours"},
+				),
+				ControllerName: "ctlr",
+			},
+		},
+	})
+
+	state := ClusterState{
+		Gateways: map[types.NamespacedName]*v1.Gateway{
+			{Namespace: testNs, Name: "not-ours"}: {},
+		},
+	}
+
 	pol1Conflict, pol1ConflictKey := createTestPolicyAndKey(policyGVK, "pol1-conflict", hrRef)
 
 	allValidValidator := &policiesfakes.FakeValidator{}
@@ -942,6 +1147,7 @@ func TestProcessPolicies(t *testing.T) {
 				pol8Key:  pol8,
 				pol9Key:  pol9,
 				pol10Key: pol10,
+				pol11Key: pol11,
 			},
 			expProcessedPolicies: map[PolicyKey]*Policy{
 				pol1Key: {
@@ -996,6 +1202,24 @@ func TestProcessPolicies(t *testing.T) {
 					InvalidForGateways: map[types.NamespacedName]struct{}{},
 					Valid:              true,
 				},
+				pol5Key: {
+					Source:     pol5,
+					TargetRefs: []PolicyTargetRef{},
+					Ancestors: []PolicyAncestor{
+						{
+							Ancestor: createParentReference(
+								v1.GroupName,
+								kinds.HTTPRoute,
+								types.NamespacedName{Namespace: testNs, Name: "dne"},
+							),
+							Conditions: []conditions.Condition{
+								conditions.NewPolicyTargetNotFound("The TargetRef is not found"),
+							},
+						},
+					},
+					InvalidForGateways: map[types.NamespacedName]struct{}{},
+					Valid:              true,
+				},
 				pol10Key: {
 					Source: pol10,
 					TargetRefs: []PolicyTargetRef{
@@ -1009,6 +1233,13 @@ func TestProcessPolicies(t *testing.T) {
 					InvalidForGateways: map[types.NamespacedName]struct{}{},
 					Valid:              true,
 				},
+				pol11Key: {
+					Source:             pol11,
+					TargetRefs:         []PolicyTargetRef{},
+					Ancestors:          []PolicyAncestor{},
+					InvalidForGateways: map[types.NamespacedName]struct{}{},
+					Valid:              true,
+				},
 			},
 		},
 		{
@@ -1152,7 +1383,7 @@ func TestProcessPolicies(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
 
-			processed := processPolicies(test.policies, test.validator, routes, services, gateways)
+			processed := processPolicies(test.policies, test.validator, routes, services, gateways, state, "ctlr")
 			g.Expect(processed).To(BeEquivalentTo(test.expProcessedPolicies))
 		})
 	}
@@ -1315,7 +1546,7 @@ func TestProcessPolicies_RouteOverlap(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
 
-			processed := processPolicies(test.policies, test.validator, test.routes, nil, gateways)
+			processed := processPolicies(test.policies, test.validator, test.routes, nil, gateways, ClusterState{}, "ctlr")
 			g.Expect(processed).To(HaveLen(len(test.policies)))
 
 			for _, pol := range processed {
@@ -2263,7 +2494,15 @@ func TestNGFPolicyAncestorLimitHandling(t *testing.T) {
 	g := NewWithT(t)
 
 	// Process policies which should trigger ancestor limit handling
-	processedPolicies := processPolicies(testPolicies, validator, routes, referencedServices, gateways)
+	processedPolicies := processPolicies(
+		testPolicies,
+		validator,
+		routes,
+		referencedServices,
+		gateways,
+		ClusterState{},
+		"nginx-gateway",
+	)
 
 	// Create a graph and attach policies to trigger ancestor limit handling
 	graph := &Graph{
@@ -74,6 +74,10 @@ func parentRefEqual(ref1, ref2 v1.ParentReference) bool {
 		return false
 	}
 
+	if !helpers.EqualPointers(ref1.SectionName, ref2.SectionName) {
+		return false
+	}
+
 	// we don't check the other fields in ParentRef because we don't set them
 
 	if ref1.Name != ref2.Name {
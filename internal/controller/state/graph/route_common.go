@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,6 +16,7 @@ import (
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	v1alpha "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/ngfsort"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
@@ -39,6 +42,8 @@ type ParentRef struct {
 	Port *v1.PortNumber
 	// Gateway is the metadata about the parent Gateway.
 	Gateway *ParentRefGateway
+	// Service is the parent Service of a mesh Route. If set, Gateway is the waypoint of the Service.
+	Service *types.NamespacedName
 	// Idx is the index of the corresponding ParentReference in the Route.
 	Idx int
 }
@@ -97,6 +102,8 @@ type RouteKey struct {
 	NamespacedName types.NamespacedName
 	// RouteType is the type of the Route.
 	RouteType RouteType
+	// CustomKind is the GroupKind of the Route if it is a custom route. It is empty for Gateway API routes.
+	CustomKind schema.GroupKind
 }
 
 type L4Route struct {
@@ -128,6 +135,9 @@ type L7Route struct {
 	Source client.Object
 	// RouteType is the type (http or grpc) of the Route.
 	RouteType RouteType
+	// CustomKind is the GroupKind of the Route if it is a custom route. It is empty for Gateway API routes.
+	// Custom routes have the http RouteType.
+	CustomKind schema.GroupKind
 	// Spec is the L7RouteSpec of the Route
 	Spec L7RouteSpec
 	// ParentRefs describe the references to the parents in a Route.
@@ -140,6 +150,8 @@ type L7Route struct {
 	Valid bool
 	// Attachable indicates if the Route is attachable to any Listener.
 	Attachable bool
+	// SkipStatus indicates that the status of the custom Route must not be written. See CustomRouteStatus.
+	SkipStatus bool
 }
 
 type L7RouteSpec struct {
@@ -156,6 +168,8 @@ type RouteRule struct {
 	RouteBackendRefs []RouteBackendRef
 	// BackendRefs is an internal representation of a backendRef in a Route.
 	BackendRefs []BackendRef
+	// Timeouts holds the timeouts and the retries of the rule. Nil if the rule doesn't configure them.
+	Timeouts *RouteTimeouts
 	// Filters define processing steps that must be completed during the request or response lifecycle.
 	Filters RouteRuleFilters
 	// ValidMatches indicates if the matches are valid and accepted by the Route.
@@ -206,17 +220,22 @@ func CreateRouteKey(obj client.Object) RouteKey {
 		Namespace: obj.GetNamespace(),
 	}
 	var routeType RouteType
-	switch obj.(type) {
+	var customKind schema.GroupKind
+	switch o := obj.(type) {
 	case *v1.HTTPRoute:
 		routeType = RouteTypeHTTP
 	case *v1.GRPCRoute:
 		routeType = RouteTypeGRPC
+	case *unstructured.Unstructured:
+		routeType = RouteTypeHTTP
+		customKind = o.GroupVersionKind().GroupKind()
 	default:
 		panic(fmt.Sprintf("Unknown type: %T", obj))
 	}
 	return RouteKey{
 		NamespacedName: nsName,
 		RouteType:      routeType,
+		CustomKind:     customKind,
 	}
 }
 
@@ -272,14 +291,19 @@ func buildL4RoutesForGateways(
 	return routes
 }
 
-// buildGRPCRoutesForGateways builds routes from HTTP/GRPCRoutes that reference any of the specified Gateways.
+// buildRoutesForGateways builds routes from HTTP/GRPCRoutes and custom routes that reference any of the specified
+// Gateways.
 func buildRoutesForGateways(
 	validator validation.HTTPFieldsValidator,
 	httpRoutes map[types.NamespacedName]*v1.HTTPRoute,
 	grpcRoutes map[types.NamespacedName]*v1.GRPCRoute,
+	customRoutes map[CustomRouteKey]*unstructured.Unstructured,
+	customRouteKinds CustomRouteKinds,
 	gateways map[types.NamespacedName]*Gateway,
 	snippetsFilters map[types.NamespacedName]*SnippetsFilter,
 	inferencePools map[types.NamespacedName]*inference.InferencePool,
+	waypointServices map[types.NamespacedName]*Gateway,
+	unknownFilterPolicy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy,
 	featureFlags FeatureFlags,
 ) map[RouteKey]*L7Route {
 	if len(gateways) == 0 {
@@ -289,7 +313,16 @@ func buildRoutesForGateways(
 	routes := make(map[RouteKey]*L7Route)
 
 	for _, route := range httpRoutes {
-		r := buildHTTPRoute(validator, route, gateways, snippetsFilters, inferencePools, featureFlags)
+		r := buildHTTPRoute(
+			validator,
+			route,
+			gateways,
+			snippetsFilters,
+			inferencePools,
+			waypointServices,
+			unknownFilterPolicy,
+			featureFlags,
+		)
 		if r == nil {
 			continue
 		}
@@ -297,11 +330,20 @@ func buildRoutesForGateways(
 		routes[CreateRouteKey(route)] = r
 
 		// if this route has a RequestMirror filter, build a duplicate route for the mirror
-		buildHTTPMirrorRoutes(routes, r, route, gateways, snippetsFilters, featureFlags)
+		buildHTTPMirrorRoutes(
+			routes,
+			r,
+			route,
+			gateways,
+			snippetsFilters,
+			waypointServices,
+			unknownFilterPolicy,
+			featureFlags,
+		)
 	}
 
 	for _, route := range grpcRoutes {
-		r := buildGRPCRoute(validator, route, gateways, snippetsFilters, featureFlags)
+		r := buildGRPCRoute(validator, route, gateways, snippetsFilters, unknownFilterPolicy, featureFlags)
 		if r == nil {
 			continue
 		}
@@ -309,7 +351,42 @@ func buildRoutesForGateways(
 		routes[CreateRouteKey(route)] = r
 
 		// if this route has a RequestMirror filter, build a duplicate route for the mirror
-		buildGRPCMirrorRoutes(routes, r, route, gateways, snippetsFilters, featureFlags)
+		buildGRPCMirrorRoutes(routes, r, route, gateways, snippetsFilters, unknownFilterPolicy, featureFlags)
+	}
+
+	for key, route := range customRoutes {
+		routeKind, registered := customRouteKinds[key.GroupKind]
+		if !registered {
+			continue
+		}
+
+		r, hr := buildCustomRoute(
+			validator,
+			route,
+			routeKind,
+			gateways,
+			snippetsFilters,
+			inferencePools,
+			waypointServices,
+			unknownFilterPolicy,
+			featureFlags,
+		)
+		if r == nil {
+			continue
+		}
+
+		routes[CreateRouteKey(route)] = r
+
+		buildHTTPMirrorRoutes(
+			routes,
+			r,
+			hr,
+			gateways,
+			snippetsFilters,
+			waypointServices,
+			unknownFilterPolicy,
+			featureFlags,
+		)
 	}
 
 	return routes
@@ -435,6 +512,7 @@ func bindRoutesToListeners(
 	l4Routes map[L4RouteKey]*L4Route,
 	gws map[types.NamespacedName]*Gateway,
 	namespaces map[types.NamespacedName]*apiv1.Namespace,
+	clusterDomain string,
 ) {
 	if len(gws) == 0 {
 		return
@@ -442,7 +520,7 @@ func bindRoutesToListeners(
 
 	for _, gw := range gws {
 		for _, r := range l7Routes {
-			bindL7RouteToListeners(r, gw, namespaces)
+			bindL7RouteToListeners(r, gw, namespaces, clusterDomain)
 		}
 
 		routes := make([]*L7Route, 0, len(l7Routes))
@@ -778,6 +856,7 @@ func bindL7RouteToListeners(
 	route *L7Route,
 	gw *Gateway,
 	namespaces map[types.NamespacedName]*apiv1.Namespace,
+	clusterDomain string,
 ) {
 	if !route.Attachable {
 		return
@@ -804,6 +883,23 @@ func bindL7RouteToListeners(
 			)
 		}
 
+		hostnames := route.Spec.Hostnames
+		if ref.Service != nil {
+			if isConsumerRoute(route.Source.GetNamespace(), *ref.Service) {
+				msg := fmt.Sprintf(
+					"Consumer routes are not supported: only producer routes, which are in the namespace "+
+						"of their parent Service %s, are supported",
+					ref.Service,
+				)
+				attachment.FailedConditions = append(
+					attachment.FailedConditions, conditions.NewRouteUnsupportedConfiguration(msg),
+				)
+			}
+
+			// the hostnames of a mesh Route are ignored; it matches requests sent to its parent Service
+			hostnames = serviceHostnames(*ref.Service, clusterDomain)
+		}
+
 		if len(attachment.FailedConditions) > 0 {
 			continue
 		}
@@ -822,6 +918,7 @@ func bindL7RouteToListeners(
 			ref.Attachment,
 			attachableListeners,
 			route,
+			hostnames,
 			gw,
 			namespaces,
 		)
@@ -858,6 +955,7 @@ func tryToAttachL7RouteToListeners(
 	refStatus *ParentRefAttachmentStatus,
 	attachableListeners []*Listener,
 	route *L7Route,
+	routeHostnames []v1.Hostname,
 	gw *Gateway,
 	namespaces map[types.NamespacedName]*apiv1.Namespace,
 ) (conditions.Condition, bool) {
@@ -872,11 +970,11 @@ func tryToAttachL7RouteToListeners(
 			return false, false
 		}
 
-		if !isRouteTypeAllowedByListener(l, convertRouteType(route.RouteType)) {
+		if !isL7RouteKindAllowedByListener(l, route) {
 			return false, false
 		}
 
-		hostnames := findAcceptedHostnames(l.Source.Hostname, route.Spec.Hostnames)
+		hostnames := findAcceptedHostnames(l.Source.Hostname, routeHostnames)
 		if len(hostnames) == 0 {
 			return true, false
 		}
@@ -936,8 +1034,9 @@ func findAttachableListeners(ref *ParentRef, listeners []*Listener) ([]*Listener
 		return nil, false
 	}
 
-	// Case 2: Only port is specified - find all attachable listeners matching that port
-	if ref.Port != nil {
+	// Case 2: Only port is specified - find all attachable listeners matching that port.
+	// For mesh Routes, the port is the port of the parent Service, so it doesn't select listeners.
+	if ref.Port != nil && ref.Service == nil {
 		var attachableListeners []*Listener
 		var foundListener bool
 		for _, l := range listeners {
@@ -1082,6 +1181,24 @@ func isRouteTypeAllowedByListener(listener *Listener, kind v1.Kind) bool {
 	return false
 }
 
+// isL7RouteKindAllowedByListener checks if the kind of the route is allowed by the listener.
+// Unlike Gateway API routes, custom routes must match both the group and the kind.
+func isL7RouteKindAllowedByListener(listener *Listener, route *L7Route) bool {
+	if route.CustomKind.Empty() {
+		return isRouteTypeAllowedByListener(listener, convertRouteType(route.RouteType))
+	}
+
+	for _, supportedKind := range listener.SupportedKinds {
+		if supportedKind.Group != nil &&
+			string(*supportedKind.Group) == route.CustomKind.Group &&
+			string(supportedKind.Kind) == route.CustomKind.Kind {
+			return true
+		}
+	}
+
+	return false
+}
+
 func convertRouteType(routeType RouteType) v1.Kind {
 	switch routeType {
 	case RouteTypeHTTP:
@@ -1108,6 +1225,10 @@ func getSectionName(s *v1.SectionName) string {
 }
 
 func validateHostnames(hostnames []v1.Hostname, path *field.Path) error {
+	return getHostnamesErrors(hostnames, path).ToAggregate()
+}
+
+func getHostnamesErrors(hostnames []v1.Hostname, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
 	for i := range hostnames {
@@ -1117,7 +1238,7 @@ func validateHostnames(hostnames []v1.Hostname, path *field.Path) error {
 		}
 	}
 
-	return allErrs.ToAggregate()
+	return allErrs
 }
 
 func validateHeaderMatch(
@@ -1401,6 +1401,7 @@ func TestBindRouteToListeners(t *testing.T) {
 				test.route,
 				test.gateway,
 				namespaces,
+				"cluster.local",
 			)
 
 			g.Expect(test.route.ParentRefs).To(Equal(test.expectedSectionNameRefs))
@@ -3727,7 +3728,7 @@ func TestBindRoutesToListeners(t *testing.T) {
 	g := NewWithT(t)
 
 	g.Expect(func() {
-		bindRoutesToListeners(nil, nil, nil, nil)
+		bindRoutesToListeners(nil, nil, nil, nil, "cluster.local")
 	}).ToNot(Panic())
 }
 
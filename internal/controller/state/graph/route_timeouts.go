@@ -0,0 +1,211 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
+)
+
+// defaultRetryAttempts is the number of retries when the retry of a route rule doesn't specify the attempts.
+const defaultRetryAttempts = 1
+
+// supportedRetryCodes are the HTTP response status codes that NGINX can retry a request on.
+var supportedRetryCodes = []int{403, 404, 429, 500, 502, 503, 504}
+
+// RouteTimeouts holds the timeouts and the retries of a route rule. The settings are computed together,
+// so that the tries of a request to the backends fit within the timeout of the whole request.
+type RouteTimeouts struct {
+	// Request is the timeout of the whole request, including the retries, in the NGINX format.
+	// Empty if the request is not limited.
+	Request string
+	// BackendRequest is the timeout of a single try of the request to a backend, in the NGINX format.
+	// Empty if the tries are not limited.
+	BackendRequest string
+	// RetryCodes are the HTTP response status codes that the request is retried on.
+	RetryCodes []int
+	// Tries is the number of tries of the request, including the first one. Zero if the rule doesn't configure
+	// the retry, so the NGINX defaults apply.
+	Tries int
+	// Valid indicates if the timeouts and the retries are valid.
+	Valid bool
+}
+
+// processRouteTimeouts processes the timeouts and the retry of a route rule. Impossible combinations, like
+// retries that can't fit within the request timeout, are reported as invalid, so that the rule doesn't silently
+// behave differently from what the user asked for.
+func processRouteTimeouts(
+	timeouts *v1.HTTPRouteTimeouts,
+	retry *v1.HTTPRouteRetry,
+	rulePath *field.Path,
+	validator validation.HTTPFieldsValidator,
+) (*RouteTimeouts, routeRuleErrors) {
+	if timeouts == nil && retry == nil {
+		return nil, routeRuleErrors{}
+	}
+
+	var (
+		errors        routeRuleErrors
+		routeTimeouts RouteTimeouts
+	)
+
+	var request, backendRequest time.Duration
+	if timeouts != nil {
+		timeoutsPath := rulePath.Child("timeouts")
+
+		var errs field.ErrorList
+		routeTimeouts.Request, request, errs = processTimeout(timeouts.Request, timeoutsPath.Child("request"), validator)
+		errors.invalid = append(errors.invalid, errs...)
+
+		routeTimeouts.BackendRequest, backendRequest, errs = processTimeout(
+			timeouts.BackendRequest,
+			timeoutsPath.Child("backendRequest"),
+			validator,
+		)
+		errors.invalid = append(errors.invalid, errs...)
+
+		if request > 0 && backendRequest > request {
+			errors.invalid = append(errors.invalid, field.Invalid(
+				timeoutsPath.Child("backendRequest"),
+				*timeouts.BackendRequest,
+				fmt.Sprintf("must not be longer than the request timeout %s", *timeouts.Request),
+			))
+		}
+	}
+
+	if retry != nil {
+		var retryErrors routeRuleErrors
+		routeTimeouts.RetryCodes, routeTimeouts.Tries, retryErrors = processRetry(retry, rulePath.Child("retry"))
+		errors = errors.append(retryErrors)
+
+		if routeTimeouts.Tries > 1 && request > 0 {
+			errors.invalid = append(errors.invalid, validateRetryBudget(
+				request,
+				backendRequest,
+				routeTimeouts.Tries,
+				rulePath,
+			)...)
+		}
+	}
+
+	routeTimeouts.Valid = len(errors.invalid) == 0
+
+	return &routeTimeouts, errors
+}
+
+// processTimeout validates a timeout and returns it in the NGINX format and as a duration.
+// A zero timeout disables the timeout, so it is returned empty.
+func processTimeout(
+	timeout *v1.Duration,
+	path *field.Path,
+	validator validation.HTTPFieldsValidator,
+) (string, time.Duration, field.ErrorList) {
+	if timeout == nil {
+		return "", 0, nil
+	}
+
+	duration, err := time.ParseDuration(string(*timeout))
+	if err != nil {
+		return "", 0, field.ErrorList{field.Invalid(path, *timeout, err.Error())}
+	}
+
+	if duration == 0 {
+		return "", 0, nil
+	}
+
+	nginxDuration, err := validator.ValidateDuration(string(*timeout))
+	if err != nil {
+		return "", 0, field.ErrorList{field.Invalid(path, *timeout, err.Error())}
+	}
+
+	return nginxDuration, duration, nil
+}
+
+// processRetry validates the retry of a route rule and returns the codes to retry on and the number of tries.
+func processRetry(
+	retry *v1.HTTPRouteRetry,
+	path *field.Path,
+) ([]int, int, routeRuleErrors) {
+	var errors routeRuleErrors
+
+	var codes []int
+	supported := make([]string, 0, len(supportedRetryCodes))
+	for _, code := range supportedRetryCodes {
+		supported = append(supported, strconv.Itoa(code))
+	}
+
+	for i, code := range retry.Codes {
+		if !slices.Contains(supportedRetryCodes, int(code)) {
+			errors.invalid = append(errors.invalid, field.NotSupported(path.Child("codes").Index(i), code, supported))
+			continue
+		}
+
+		if !slices.Contains(codes, int(code)) {
+			codes = append(codes, int(code))
+		}
+	}
+
+	if retry.Backoff != nil {
+		errors.warn = append(errors.warn, field.Forbidden(
+			path.Child("backoff"),
+			"Backoff is not supported, the request is retried without a delay",
+		))
+	}
+
+	attempts := defaultRetryAttempts
+	if retry.Attempts != nil {
+		attempts = *retry.Attempts
+	}
+
+	// the request is tried once, so there is nothing to retry on
+	if attempts == 0 {
+		return nil, 1, errors
+	}
+
+	slices.Sort(codes)
+
+	return codes, attempts + 1, errors
+}
+
+// validateRetryBudget checks that all tries of a request fit within the request timeout.
+func validateRetryBudget(
+	request time.Duration,
+	backendRequest time.Duration,
+	tries int,
+	rulePath *field.Path,
+) field.ErrorList {
+	timeoutsPath := rulePath.Child("timeouts")
+
+	if backendRequest == 0 {
+		return field.ErrorList{field.Required(
+			timeoutsPath.Child("backendRequest"),
+			fmt.Sprintf(
+				"must be set when the request is retried within the request timeout %s, "+
+					"otherwise the first try can use the whole timeout",
+				request,
+			),
+		)}
+	}
+
+	budget := backendRequest * time.Duration(tries)
+	if budget > request {
+		return field.ErrorList{field.Invalid(
+			timeoutsPath.Child("request"),
+			request.String(),
+			fmt.Sprintf(
+				"must be at least %s to fit %d tries of the backend request timeout %s; "+
+					"increase the request timeout, or decrease the backend request timeout or the retry attempts",
+				budget,
+				tries,
+				backendRequest,
+			),
+		)}
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation/validationfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+)
+
+func TestProcessRouteTimeouts(t *testing.T) {
+	t.Parallel()
+
+	duration := func(d string) *gatewayv1.Duration {
+		return helpers.GetPointer(gatewayv1.Duration(d))
+	}
+
+	tests := []struct {
+		timeouts      *gatewayv1.HTTPRouteTimeouts
+		retry         *gatewayv1.HTTPRouteRetry
+		expected      *RouteTimeouts
+		name          string
+		expectedWarns []string
+		expectedErrs  []string
+	}{
+		{
+			name: "no timeouts and no retry",
+		},
+		{
+			name: "timeouts without retry",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        duration("10s"),
+				BackendRequest: duration("2s"),
+			},
+			expected: &RouteTimeouts{
+				Request:        "10s",
+				BackendRequest: "2s",
+				Valid:          true,
+			},
+		},
+		{
+			name: "zero timeouts disable the timeouts",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        duration("0s"),
+				BackendRequest: duration("0s"),
+			},
+			expected: &RouteTimeouts{
+				Valid: true,
+			},
+		},
+		{
+			name: "retries within the request timeout",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        duration("10s"),
+				BackendRequest: duration("2s"),
+			},
+			retry: &gatewayv1.HTTPRouteRetry{
+				Codes:    []gatewayv1.HTTPRouteRetryStatusCode{503, 502, 503},
+				Attempts: helpers.GetPointer(4),
+			},
+			expected: &RouteTimeouts{
+				Request:        "10s",
+				BackendRequest: "2s",
+				RetryCodes:     []int{502, 503},
+				Tries:          5,
+				Valid:          true,
+			},
+		},
+		{
+			name: "retry without timeouts uses the default attempts",
+			retry: &gatewayv1.HTTPRouteRetry{
+				Codes: []gatewayv1.HTTPRouteRetryStatusCode{500},
+			},
+			expected: &RouteTimeouts{
+				RetryCodes: []int{500},
+				Tries:      2,
+				Valid:      true,
+			},
+		},
+		{
+			name: "retry with zero attempts",
+			retry: &gatewayv1.HTTPRouteRetry{
+				Codes:    []gatewayv1.HTTPRouteRetryStatusCode{500},
+				Attempts: helpers.GetPointer(0),
+			},
+			expected: &RouteTimeouts{
+				Tries: 1,
+				Valid: true,
+			},
+		},
+		{
+			name: "backoff is ignored",
+			retry: &gatewayv1.HTTPRouteRetry{
+				Attempts: helpers.GetPointer(1),
+				Backoff:  duration("100ms"),
+			},
+			expected: &RouteTimeouts{
+				Tries: 2,
+				Valid: true,
+			},
+			expectedWarns: []string{
+				"spec.rules[0].retry.backoff: Forbidden: Backoff is not supported, the request is retried without a delay",
+			},
+		},
+		{
+			name: "backend request timeout longer than the request timeout",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        duration("1s"),
+				BackendRequest: duration("2s"),
+			},
+			expected: &RouteTimeouts{
+				Request:        "1s",
+				BackendRequest: "2s",
+			},
+			expectedErrs: []string{
+				`spec.rules[0].timeouts.backendRequest: Invalid value: "2s": ` +
+					"must not be longer than the request timeout 1s",
+			},
+		},
+		{
+			name: "retries exceed the request timeout",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        duration("5s"),
+				BackendRequest: duration("2s"),
+			},
+			retry: &gatewayv1.HTTPRouteRetry{
+				Attempts: helpers.GetPointer(2),
+			},
+			expected: &RouteTimeouts{
+				Request:        "5s",
+				BackendRequest: "2s",
+				Tries:          3,
+			},
+			expectedErrs: []string{
+				`spec.rules[0].timeouts.request: Invalid value: "5s": must be at least 6s to fit 3 tries of the ` +
+					"backend request timeout 2s; increase the request timeout, or decrease the backend request " +
+					"timeout or the retry attempts",
+			},
+		},
+		{
+			name: "retries within the request timeout without a backend request timeout",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request: duration("5s"),
+			},
+			retry: &gatewayv1.HTTPRouteRetry{
+				Attempts: helpers.GetPointer(2),
+			},
+			expected: &RouteTimeouts{
+				Request: "5s",
+				Tries:   3,
+			},
+			expectedErrs: []string{
+				"spec.rules[0].timeouts.backendRequest: Required value: must be set when the request is retried " +
+					"within the request timeout 5s, otherwise the first try can use the whole timeout",
+			},
+		},
+		{
+			name: "unsupported retry code",
+			retry: &gatewayv1.HTTPRouteRetry{
+				Codes: []gatewayv1.HTTPRouteRetryStatusCode{502, 501},
+			},
+			expected: &RouteTimeouts{
+				RetryCodes: []int{502},
+				Tries:      2,
+			},
+			expectedErrs: []string{
+				`spec.rules[0].retry.codes[1]: Unsupported value: 501: supported values: ` +
+					`"403", "404", "429", "500", "502", "503", "504"`,
+			},
+		},
+		{
+			name: "invalid duration",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request: duration("ten seconds"),
+			},
+			expected: &RouteTimeouts{},
+			expectedErrs: []string{
+				`spec.rules[0].timeouts.request: Invalid value: "ten seconds": ` +
+					`time: invalid duration "ten seconds"`,
+			},
+		},
+	}
+
+	rulePath := field.NewPath("spec").Child("rules").Index(0)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			validator := &validationfakes.FakeHTTPFieldsValidator{}
+			validator.ValidateDurationStub = func(d string) (string, error) {
+				return d, nil
+			}
+
+			timeouts, errs := processRouteTimeouts(test.timeouts, test.retry, rulePath, validator)
+			g.Expect(timeouts).To(Equal(test.expected))
+
+			g.Expect(errorStrings(errs.warn)).To(Equal(test.expectedWarns))
+			g.Expect(errorStrings(errs.invalid)).To(Equal(test.expectedErrs))
+		})
+	}
+}
+
+func TestProcessRouteTimeoutsInvalidNginxDuration(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	validator := &validationfakes.FakeHTTPFieldsValidator{}
+	validator.ValidateDurationReturns("", errors.New("duration is too large"))
+
+	timeouts, errs := processRouteTimeouts(
+		&gatewayv1.HTTPRouteTimeouts{Request: helpers.GetPointer(gatewayv1.Duration("20000h"))},
+		nil,
+		field.NewPath("spec").Child("rules").Index(0),
+		validator,
+	)
+
+	g.Expect(timeouts.Valid).To(BeFalse())
+	g.Expect(errorStrings(errs.invalid)).To(Equal([]string{
+		`spec.rules[0].timeouts.request: Invalid value: "20000h": duration is too large`,
+	}))
+}
+
+func errorStrings(errs field.ErrorList) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(errs))
+	for _, err := range errs {
+		result = append(result, err.Error())
+	}
+
+	return result
+}
@@ -28,12 +28,15 @@ type secretEntry struct {
 type secretResolver struct {
 	clusterSecrets  map[types.NamespacedName]*apiv1.Secret
 	resolvedSecrets map[types.NamespacedName]*secretEntry
+	// fips enables the validation that the certificates use FIPS-approved algorithms.
+	fips bool
 }
 
-func newSecretResolver(secrets map[types.NamespacedName]*apiv1.Secret) *secretResolver {
+func newSecretResolver(secrets map[types.NamespacedName]*apiv1.Secret, fips bool) *secretResolver {
 	return &secretResolver{
 		clusterSecrets:  secrets,
 		resolvedSecrets: make(map[types.NamespacedName]*secretEntry),
+		fips:            fips,
 	}
 }
 
@@ -61,6 +64,9 @@ func (r *secretResolver) resolve(nsname types.NamespacedName) error {
 			TLSPrivateKey: secret.Data[apiv1.TLSPrivateKeyKey],
 		}
 		validationErr = validateTLS(cert.TLSCert, cert.TLSPrivateKey)
+		if validationErr == nil && r.fips {
+			validationErr = validateFIPSCertificate(cert.TLSCert)
+		}
 
 		// Not always guaranteed to have a ca certificate in the secret.
 		// Cert-Manager puts this at ca.crt and thus this is statically placed like so.
@@ -170,7 +170,9 @@ func TestSecretResolver(t *testing.T) {
 			client.ObjectKeyFromObject(invalidSecretCert):   invalidSecretCert,
 			client.ObjectKeyFromObject(invalidSecretKey):    invalidSecretKey,
 			client.ObjectKeyFromObject(invalidSecretCaCert): invalidSecretCaCert,
-		})
+		},
+		false,
+	)
 
 	tests := []struct {
 		name           string
@@ -1,9 +1,14 @@
 package graph
 
 import (
+	"maps"
+	"slices"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
 )
 
 // A ReferencedService represents a Kubernetes Service that is referenced by a Route and the Gateways it belongs to.
@@ -119,7 +124,8 @@ func addServicesFromL7RouteRules(
 ) {
 	for _, rule := range routeRules {
 		for _, ref := range rule.BackendRefs {
-			if ref.SvcNsName == (types.NamespacedName{}) {
+			// Backends with static endpoints are not Services
+			if ref.SvcNsName == (types.NamespacedName{}) || ref.IsStaticBackend() {
 				continue
 			}
 
@@ -160,3 +166,59 @@ func getServiceExternalNameInfo(
 
 	return true, svc.Spec.ExternalName
 }
+
+// addNetworkPolicyConditions adds a condition to the Gateways whose NetworkPolicy allows the egress traffic to any
+// address on the ports of some backends. The NetworkPolicy can't select the Pods of the ExternalName Services
+// and of the Services without a selector.
+func addNetworkPolicyConditions(
+	gws map[types.NamespacedName]*Gateway,
+	services map[types.NamespacedName]*v1.Service,
+) {
+	for _, gw := range gws {
+		if gw == nil || !isNetworkPolicyEnabled(gw.EffectiveNginxProxy) {
+			continue
+		}
+
+		unselectable := make(map[string]struct{})
+		addBackendRef := func(ref BackendRef) {
+			if !ref.Valid || ref.IsStaticBackend() {
+				return
+			}
+
+			svc, exists := services[ref.SvcNsName]
+			if exists && (svc.Spec.Type == v1.ServiceTypeExternalName || len(svc.Spec.Selector) == 0) {
+				unselectable[ref.SvcNsName.String()] = struct{}{}
+			}
+		}
+
+		for _, l := range gw.Listeners {
+			for _, route := range l.Routes {
+				if !route.Valid {
+					continue
+				}
+
+				for _, rule := range route.Spec.Rules {
+					for _, ref := range rule.BackendRefs {
+						addBackendRef(ref)
+					}
+				}
+			}
+
+			for _, route := range l.L4Routes {
+				if route.Valid {
+					addBackendRef(route.Spec.BackendRef)
+				}
+			}
+		}
+
+		if len(unselectable) > 0 {
+			names := slices.Sorted(maps.Keys(unselectable))
+			gw.Conditions = append(gw.Conditions, conditions.NewGatewayNetworkPolicyEgressToAnyAddress(names))
+		}
+	}
+}
+
+func isNetworkPolicyEnabled(nProxyCfg *EffectiveNginxProxy) bool {
+	return nProxyCfg != nil && nProxyCfg.Kubernetes != nil &&
+		nProxyCfg.Kubernetes.NetworkPolicy != nil && nProxyCfg.Kubernetes.NetworkPolicy.Enable
+}
@@ -8,6 +8,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
 )
 
 func TestBuildReferencedServices(t *testing.T) {
@@ -424,3 +427,81 @@ func TestBuildReferencedServices(t *testing.T) {
 		})
 	}
 }
+
+func TestAddNetworkPolicyConditions(t *testing.T) {
+	t.Parallel()
+
+	service := func(name string, spec corev1.ServiceSpec) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name},
+			Spec:       spec,
+		}
+	}
+
+	services := map[types.NamespacedName]*corev1.Service{
+		{Namespace: "test", Name: "selected"}: service("selected", corev1.ServiceSpec{
+			Selector: map[string]string{"app": "selected"},
+		}),
+		{Namespace: "test", Name: "selectorless"}: service("selectorless", corev1.ServiceSpec{}),
+		{Namespace: "test", Name: "external"}: service("external", corev1.ServiceSpec{
+			Type:         corev1.ServiceTypeExternalName,
+			ExternalName: "example.com",
+		}),
+		{Namespace: "test", Name: "invalid"}: service("invalid", corev1.ServiceSpec{}),
+	}
+
+	backendRef := func(name string, valid bool) BackendRef {
+		return BackendRef{SvcNsName: types.NamespacedName{Namespace: "test", Name: name}, Valid: valid}
+	}
+
+	newGateway := func(networkPolicy bool) *Gateway {
+		return &Gateway{
+			EffectiveNginxProxy: &EffectiveNginxProxy{
+				Kubernetes: &ngfAPIv1alpha2.KubernetesSpec{
+					NetworkPolicy: &ngfAPIv1alpha2.NetworkPolicySpec{Enable: networkPolicy},
+				},
+			},
+			Listeners: []*Listener{
+				{
+					Routes: map[RouteKey]*L7Route{
+						{NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"}}: {
+							Valid: true,
+							Spec: L7RouteSpec{
+								Rules: []RouteRule{
+									{
+										BackendRefs: []BackendRef{
+											backendRef("selected", true),
+											backendRef("selectorless", true),
+											backendRef("invalid", false),
+										},
+									},
+								},
+							},
+						},
+					},
+					L4Routes: map[L4RouteKey]*L4Route{
+						{NamespacedName: types.NamespacedName{Namespace: "test", Name: "tls-route"}}: {
+							Valid: true,
+							Spec:  L4RouteSpec{BackendRef: backendRef("external", true)},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	g := NewWithT(t)
+
+	enabled := newGateway(true)
+	disabled := newGateway(false)
+
+	addNetworkPolicyConditions(map[types.NamespacedName]*Gateway{
+		{Namespace: "test", Name: "enabled"}:  enabled,
+		{Namespace: "test", Name: "disabled"}: disabled,
+	}, services)
+
+	g.Expect(enabled.Conditions).To(Equal([]conditions.Condition{
+		conditions.NewGatewayNetworkPolicyEgressToAnyAddress([]string{"test/external", "test/selectorless"}),
+	}))
+	g.Expect(disabled.Conditions).To(BeEmpty())
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+// isStaticBackendRef returns whether the backendRef references a Backend with static endpoints.
+func isStaticBackendRef(ref gatewayv1.BackendRef) bool {
+	return ref.Group != nil && *ref.Group == ngfAPIv1alpha1.GroupName &&
+		ref.Kind != nil && *ref.Kind == kinds.Backend
+}
+
+// createStaticBackendRef creates a BackendRef for a backendRef that references a Backend. Unlike Services,
+// Backends can only be referenced by Routes in the same namespace.
+func createStaticBackendRef(
+	ref RouteBackendRef,
+	route *L7Route,
+	backends map[types.NamespacedName]*ngfAPIv1alpha1.Backend,
+	refPath *field.Path,
+) (BackendRef, []conditions.Condition) {
+	weight := int32(1)
+	if ref.Weight != nil {
+		weight = *ref.Weight
+	}
+
+	invalidBackendRef := BackendRef{
+		Weight:             weight,
+		IsMirrorBackend:    ref.MirrorBackendIdx != nil,
+		InvalidForGateways: make(map[types.NamespacedName]conditions.Condition),
+	}
+
+	if len(ref.Filters) > 0 {
+		valErr := field.TooMany(refPath.Child("filters"), len(ref.Filters), 0)
+		return invalidBackendRef, []conditions.Condition{conditions.NewRouteBackendRefUnsupportedValue(valErr.Error())}
+	}
+
+	if ref.Weight != nil {
+		if err := validateWeight(*ref.Weight); err != nil {
+			invalidBackendRef.Weight = 0
+			valErr := field.Invalid(refPath.Child("weight"), *ref.Weight, err.Error())
+			return invalidBackendRef, []conditions.Condition{conditions.NewRouteBackendRefUnsupportedValue(valErr.Error())}
+		}
+	}
+
+	routeNs := route.Source.GetNamespace()
+	if ref.Namespace != nil && string(*ref.Namespace) != routeNs {
+		valErr := field.Forbidden(
+			refPath.Child("namespace"),
+			"Backend must be in the same namespace as the Route",
+		)
+		return invalidBackendRef, []conditions.Condition{conditions.NewRouteBackendRefRefNotPermitted(valErr.Error())}
+	}
+
+	backendNsName := types.NamespacedName{Namespace: routeNs, Name: string(ref.Name)}
+
+	backend, exists := backends[backendNsName]
+	if !exists {
+		valErr := field.NotFound(refPath.Child("name"), ref.Name)
+		return invalidBackendRef, []conditions.Condition{conditions.NewRouteBackendRefRefBackendNotFound(valErr.Error())}
+	}
+
+	invalidForGateways := make(map[types.NamespacedName]conditions.Condition)
+	for _, endpoint := range backend.Spec.Endpoints {
+		if net.ParseIP(endpoint.Address) != nil {
+			continue
+		}
+
+		// DNS names are resolved by NGINX at runtime, which requires a DNS resolver
+		for _, parentRef := range route.ParentRefs {
+			if parentRef.Gateway.EffectiveNginxProxy == nil || parentRef.Gateway.EffectiveNginxProxy.DNSResolver == nil {
+				invalidForGateways[parentRef.Gateway.NamespacedName] = conditions.NewRouteBackendRefUnsupportedValue(
+					fmt.Sprintf(
+						"Backend %s with DNS name endpoints requires DNS resolver configuration in Gateway's NginxProxy",
+						backendNsName,
+					),
+				)
+			}
+		}
+
+		break
+	}
+
+	return BackendRef{
+		SvcNsName:          backendNsName,
+		StaticEndpoints:    backend.Spec.Endpoints,
+		Weight:             weight,
+		Valid:              true,
+		IsMirrorBackend:    ref.MirrorBackendIdx != nil,
+		InvalidForGateways: invalidForGateways,
+		SessionPersistence: ref.SessionPersistence,
+	}, nil
+}
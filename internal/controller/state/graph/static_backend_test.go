@@ -0,0 +1,246 @@
+package graph
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+func TestIsStaticBackendRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ref      gatewayv1.BackendRef
+		name     string
+		expected bool
+	}{
+		{
+			name: "Backend",
+			ref: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Group: helpers.GetPointer[gatewayv1.Group](ngfAPIv1alpha1.GroupName),
+					Kind:  helpers.GetPointer[gatewayv1.Kind](kinds.Backend),
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "Backend kind in core group",
+			ref: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Kind: helpers.GetPointer[gatewayv1.Kind](kinds.Backend),
+				},
+			},
+			expected: false,
+		},
+		{
+			name:     "Service",
+			ref:      gatewayv1.BackendRef{},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(isStaticBackendRef(test.ref)).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestCreateStaticBackendRef(t *testing.T) {
+	t.Parallel()
+
+	ipBackend := &ngfAPIv1alpha1.Backend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "ip"},
+		Spec: ngfAPIv1alpha1.BackendSpec{
+			Endpoints: []ngfAPIv1alpha1.BackendEndpoint{
+				{Address: "10.0.0.1", Port: 8080},
+				{Address: "10.0.0.2", Port: 8080},
+			},
+		},
+	}
+	dnsBackend := &ngfAPIv1alpha1.Backend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "dns"},
+		Spec: ngfAPIv1alpha1.BackendSpec{
+			Endpoints: []ngfAPIv1alpha1.BackendEndpoint{
+				{Address: "10.0.0.1", Port: 8080},
+				{Address: "backend.example.com", Port: 443},
+			},
+		},
+	}
+
+	backends := map[types.NamespacedName]*ngfAPIv1alpha1.Backend{
+		{Namespace: "test", Name: "ip"}:  ipBackend,
+		{Namespace: "test", Name: "dns"}: dnsBackend,
+	}
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	gwWithResolverNsName := types.NamespacedName{Namespace: "test", Name: "gateway-with-resolver"}
+
+	route := &L7Route{
+		Source: &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"},
+		},
+		ParentRefs: []ParentRef{
+			{
+				Gateway: &ParentRefGateway{NamespacedName: gwNsName},
+			},
+			{
+				Gateway: &ParentRefGateway{
+					NamespacedName: gwWithResolverNsName,
+					EffectiveNginxProxy: &EffectiveNginxProxy{
+						DNSResolver: &ngfAPIv1alpha2.DNSResolver{},
+					},
+				},
+			},
+		},
+	}
+
+	createRef := func(name string, modify func(*RouteBackendRef)) RouteBackendRef {
+		ref := RouteBackendRef{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Group: helpers.GetPointer[gatewayv1.Group](ngfAPIv1alpha1.GroupName),
+					Kind:  helpers.GetPointer[gatewayv1.Kind](kinds.Backend),
+					Name:  gatewayv1.ObjectName(name),
+				},
+			},
+		}
+		if modify != nil {
+			modify(&ref)
+		}
+
+		return ref
+	}
+
+	refPath := field.NewPath("test")
+
+	tests := []struct {
+		ref             RouteBackendRef
+		name            string
+		expectedConds   []conditions.Condition
+		expectedBackend BackendRef
+	}{
+		{
+			name: "Backend with IP endpoints",
+			ref: createRef("ip", func(ref *RouteBackendRef) {
+				ref.Weight = helpers.GetPointer[int32](5)
+			}),
+			expectedBackend: BackendRef{
+				SvcNsName:          types.NamespacedName{Namespace: "test", Name: "ip"},
+				StaticEndpoints:    ipBackend.Spec.Endpoints,
+				Weight:             5,
+				Valid:              true,
+				InvalidForGateways: map[types.NamespacedName]conditions.Condition{},
+			},
+		},
+		{
+			name: "Backend with DNS name endpoints is invalid for Gateways without a DNS resolver",
+			ref:  createRef("dns", nil),
+			expectedBackend: BackendRef{
+				SvcNsName:       types.NamespacedName{Namespace: "test", Name: "dns"},
+				StaticEndpoints: dnsBackend.Spec.Endpoints,
+				Weight:          1,
+				Valid:           true,
+				InvalidForGateways: map[types.NamespacedName]conditions.Condition{
+					gwNsName: conditions.NewRouteBackendRefUnsupportedValue(
+						"Backend test/dns with DNS name endpoints requires DNS resolver configuration in " +
+							"Gateway's NginxProxy",
+					),
+				},
+			},
+		},
+		{
+			name: "Backend does not exist",
+			ref:  createRef("missing", nil),
+			expectedBackend: BackendRef{
+				Weight:             1,
+				InvalidForGateways: map[types.NamespacedName]conditions.Condition{},
+			},
+			expectedConds: []conditions.Condition{
+				conditions.NewRouteBackendRefRefBackendNotFound(`test.name: Not found: "missing"`),
+			},
+		},
+		{
+			name: "Backend in a different namespace",
+			ref: createRef("ip", func(ref *RouteBackendRef) {
+				ref.Namespace = helpers.GetPointer[gatewayv1.Namespace]("other")
+			}),
+			expectedBackend: BackendRef{
+				Weight:             1,
+				InvalidForGateways: map[types.NamespacedName]conditions.Condition{},
+			},
+			expectedConds: []conditions.Condition{
+				conditions.NewRouteBackendRefRefNotPermitted(
+					"test.namespace: Forbidden: Backend must be in the same namespace as the Route",
+				),
+			},
+		},
+		{
+			name: "invalid weight",
+			ref: createRef("ip", func(ref *RouteBackendRef) {
+				ref.Weight = helpers.GetPointer[int32](-1)
+			}),
+			expectedBackend: BackendRef{
+				Weight:             0,
+				InvalidForGateways: map[types.NamespacedName]conditions.Condition{},
+			},
+			expectedConds: []conditions.Condition{
+				conditions.NewRouteBackendRefUnsupportedValue(
+					"test.weight: Invalid value: -1: must be in the range [0, 1000000]",
+				),
+			},
+		},
+		{
+			name: "filters are not supported",
+			ref: createRef("ip", func(ref *RouteBackendRef) {
+				ref.Filters = []any{struct{}{}}
+			}),
+			expectedBackend: BackendRef{
+				Weight:             1,
+				InvalidForGateways: map[types.NamespacedName]conditions.Condition{},
+			},
+			expectedConds: []conditions.Condition{
+				conditions.NewRouteBackendRefUnsupportedValue("test.filters: Too many: 1: must have at most 0 items"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			backendRef, conds := createStaticBackendRef(test.ref, route, backends, refPath)
+			g.Expect(helpers.Diff(test.expectedBackend, backendRef)).To(BeEmpty())
+			g.Expect(conds).To(Equal(test.expectedConds))
+		})
+	}
+}
+
+func TestStaticBackendServicePortReference(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	backendRef := BackendRef{
+		SvcNsName:       types.NamespacedName{Namespace: "test", Name: "backend"},
+		StaticEndpoints: []ngfAPIv1alpha1.BackendEndpoint{{Address: "10.0.0.1", Port: 80}},
+		Valid:           true,
+	}
+
+	g.Expect(backendRef.IsStaticBackend()).To(BeTrue())
+	g.Expect(backendRef.ServicePortReference()).To(Equal("test_backend_backend"))
+}
@@ -331,7 +331,7 @@ func TestBuildTLSRoute(t *testing.T) {
 				Source:     invalidHostnameGtr,
 				ParentRefs: []ParentRef{parentRefGraph},
 				Conditions: []conditions.Condition{conditions.NewRouteUnsupportedValue(
-					"Spec.hostnames[0]: Invalid value: \"hi....com\": a lowercase RFC 1" +
+					"Spec.hostnames[0]: Invalid value: \"hi....com\": [NGF1401 InvalidHostname] a lowercase RFC 1" +
 						"123 subdomain must consist of lower case alphanumeric characters" +
 						", '-' or '.', and must start and end with an alphanumeric charac" +
 						"ter (e.g. 'example.com', regex used for validation is '[a-z0-9](" +
@@ -1,31 +1,11 @@
 package graph
 
 import (
-	"errors"
-	"strings"
-
-	"k8s.io/apimachinery/pkg/util/validation"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
 )
 
+// validateHostname validates a hostname of a Gateway API resource, which can be a wildcard hostname.
 func validateHostname(hostname string) error {
-	if hostname == "" {
-		return errors.New("cannot be empty string")
-	}
-
-	if strings.HasPrefix(hostname, "*.") {
-		msgs := validation.IsWildcardDNS1123Subdomain(hostname)
-		if len(msgs) > 0 {
-			combined := strings.Join(msgs, ",")
-			return errors.New(combined)
-		}
-		return nil
-	}
-
-	msgs := validation.IsDNS1123Subdomain(hostname)
-	if len(msgs) > 0 {
-		combined := strings.Join(msgs, ",")
-		return errors.New(combined)
-	}
-
-	return nil
+	_, err := validation.ValidateHostname(hostname, validation.HostnameOptions{AllowWildcard: true})
+	return err
 }
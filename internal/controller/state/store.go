@@ -3,6 +3,7 @@ package state
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -76,6 +77,61 @@ func (p *ngfPolicyObjectStore) delete(objType ngftypes.ObjectType, nsname types.
 	delete(p.policies, key)
 }
 
+// customRouteObjectStore is a store of custom routes.
+// A single store should be used to store all kinds of custom routes.
+type customRouteObjectStore struct {
+	routes         map[graph.CustomRouteKey]*unstructured.Unstructured
+	extractGVKFunc kinds.MustExtractGVK
+}
+
+// newCustomRouteObjectStore returns a new customRouteObjectStore.
+func newCustomRouteObjectStore(
+	routes map[graph.CustomRouteKey]*unstructured.Unstructured,
+	gvkFunc kinds.MustExtractGVK,
+) *customRouteObjectStore {
+	return &customRouteObjectStore{
+		routes:         routes,
+		extractGVKFunc: gvkFunc,
+	}
+}
+
+func (c *customRouteObjectStore) get(objType ngftypes.ObjectType, nsname types.NamespacedName) client.Object {
+	key := graph.CustomRouteKey{
+		NsName:    nsname,
+		GroupKind: c.extractGVKFunc(objType).GroupKind(),
+	}
+
+	route, exist := c.routes[key]
+	if !exist {
+		return nil
+	}
+
+	return route
+}
+
+func (c *customRouteObjectStore) upsert(obj client.Object) {
+	route, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		panic(fmt.Sprintf("expected custom route, got %T", obj))
+	}
+
+	key := graph.CustomRouteKey{
+		NsName:    client.ObjectKeyFromObject(obj),
+		GroupKind: c.extractGVKFunc(obj).GroupKind(),
+	}
+
+	c.routes[key] = route
+}
+
+func (c *customRouteObjectStore) delete(objType ngftypes.ObjectType, nsname types.NamespacedName) {
+	key := graph.CustomRouteKey{
+		NsName:    nsname,
+		GroupKind: c.extractGVKFunc(objType).GroupKind(),
+	}
+
+	delete(c.routes, key)
+}
+
 // objectStoreMapAdapter wraps maps of types.NamespacedName to Kubernetes resources
 // (e.g. map[types.NamespacedName]*v1.Gateway) so that they can be used through objectStore interface.
 type objectStoreMapAdapter[T client.Object] struct {
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/idna"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
+)
+
+// HostnameOptions configures the forms of the hostnames that ValidateHostname accepts.
+type HostnameOptions struct {
+	// AllowWildcard allows a wildcard as the leftmost label, like *.example.com.
+	AllowWildcard bool
+	// AllowPort allows a port after the hostname, like example.com:8080. The port is stripped from the hostname.
+	AllowPort bool
+}
+
+const wildcardPrefix = "*."
+
+// ValidateHostname validates a hostname that propagates into the NGINX configuration, like in the server_name
+// directive or a redirect. It is shared by the listener, route and filter hostnames, so that they follow the
+// same rules:
+//   - the hostname consists of RFC 1123 labels, and is at most 253 characters long;
+//   - an internationalized hostname is converted to its punycode form, like xn--bcher-kva.example;
+//   - a wildcard is only allowed as the leftmost label, and a port only after the hostname, if the options allow them.
+//
+// It returns the normalized hostname: in the punycode form and without the port. The error has the
+// errcodes.InvalidHostname code.
+func ValidateHostname(hostname string, opts HostnameOptions) (string, error) {
+	normalized, err := validateHostname(hostname, opts)
+	if err != nil {
+		return "", errcodes.Wrap(errcodes.InvalidHostname, err)
+	}
+
+	return normalized, nil
+}
+
+func validateHostname(hostname string, opts HostnameOptions) (string, error) {
+	if hostname == "" {
+		return "", errors.New("cannot be empty string")
+	}
+
+	if opts.AllowPort {
+		var err error
+		if hostname, err = stripPort(hostname); err != nil {
+			return "", err
+		}
+	}
+
+	normalized, err := idna.Punycode.ToASCII(hostname)
+	if err != nil {
+		return "", fmt.Errorf("invalid internationalized hostname: %w", err)
+	}
+
+	var msgs []string
+	if strings.HasPrefix(normalized, wildcardPrefix) {
+		if !opts.AllowWildcard {
+			return "", errors.New("wildcard hostnames are not supported")
+		}
+		msgs = k8svalidation.IsWildcardDNS1123Subdomain(normalized)
+	} else {
+		msgs = k8svalidation.IsDNS1123Subdomain(normalized)
+	}
+
+	if len(msgs) > 0 {
+		return "", errors.New(strings.Join(msgs, ","))
+	}
+
+	return normalized, nil
+}
+
+// stripPort removes the port from a hostname like example.com:8080. A hostname without a port is returned as is.
+func stripPort(hostname string) (string, error) {
+	if !strings.Contains(hostname, ":") {
+		return hostname, nil
+	}
+
+	host, port, err := net.SplitHostPort(hostname)
+	if err != nil {
+		return "", fmt.Errorf("invalid port: %w", err)
+	}
+
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return "", fmt.Errorf("invalid port %q: must be a number between 1 and 65535", port)
+	}
+
+	return host, nil
+}
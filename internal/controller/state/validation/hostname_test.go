@@ -0,0 +1,156 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/errcodes"
+)
+
+func TestValidateHostname(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		hostname    string
+		expected    string
+		expectedErr string
+		opts        HostnameOptions
+	}{
+		{
+			name:     "valid hostname",
+			hostname: "example.com",
+			expected: "example.com",
+		},
+		{
+			name:     "valid single label",
+			hostname: "localhost",
+			expected: "localhost",
+		},
+		{
+			name:     "internationalized hostname is normalized to punycode",
+			hostname: "bücher.example",
+			expected: "xn--bcher-kva.example",
+		},
+		{
+			name:     "punycode hostname",
+			hostname: "xn--bcher-kva.example",
+			expected: "xn--bcher-kva.example",
+		},
+		{
+			name:     "wildcard hostname",
+			hostname: "*.example.com",
+			opts:     HostnameOptions{AllowWildcard: true},
+			expected: "*.example.com",
+		},
+		{
+			name:     "internationalized wildcard hostname",
+			hostname: "*.bücher.example",
+			opts:     HostnameOptions{AllowWildcard: true},
+			expected: "*.xn--bcher-kva.example",
+		},
+		{
+			name:     "port is stripped",
+			hostname: "example.com:8080",
+			opts:     HostnameOptions{AllowPort: true},
+			expected: "example.com",
+		},
+		{
+			name:     "hostname without port when port is allowed",
+			hostname: "example.com",
+			opts:     HostnameOptions{AllowPort: true},
+			expected: "example.com",
+		},
+		{
+			name:     "wildcard hostname with port",
+			hostname: "*.example.com:443",
+			opts:     HostnameOptions{AllowWildcard: true, AllowPort: true},
+			expected: "*.example.com",
+		},
+		{
+			name:     "max length",
+			hostname: strings.Repeat("a.", 126) + "a",
+			expected: strings.Repeat("a.", 126) + "a",
+		},
+		{
+			name:        "empty",
+			hostname:    "",
+			expectedErr: "cannot be empty string",
+		},
+		{
+			name:        "wildcard is not allowed",
+			hostname:    "*.example.com",
+			expectedErr: "wildcard hostnames are not supported",
+		},
+		{
+			name:        "wildcard is not the leftmost label",
+			hostname:    "foo.*.example.com",
+			opts:        HostnameOptions{AllowWildcard: true},
+			expectedErr: "a lowercase RFC 1123 subdomain",
+		},
+		{
+			name:        "port is not allowed",
+			hostname:    "example.com:8080",
+			expectedErr: "a lowercase RFC 1123 subdomain",
+		},
+		{
+			name:        "invalid port",
+			hostname:    "example.com:0",
+			opts:        HostnameOptions{AllowPort: true},
+			expectedErr: "invalid port",
+		},
+		{
+			name:        "non-numeric port",
+			hostname:    "example.com:http",
+			opts:        HostnameOptions{AllowPort: true},
+			expectedErr: "invalid port",
+		},
+		{
+			name:        "missing port",
+			hostname:    "example.com:",
+			opts:        HostnameOptions{AllowPort: true},
+			expectedErr: "invalid port",
+		},
+		{
+			name:        "too long",
+			hostname:    strings.Repeat("a.", 127),
+			expectedErr: "must be no more than 253 characters",
+		},
+		{
+			name:        "uppercase",
+			hostname:    "Example.com",
+			expectedErr: "a lowercase RFC 1123 subdomain",
+		},
+		{
+			name:        "invalid character",
+			hostname:    "example.com$",
+			expectedErr: "a lowercase RFC 1123 subdomain",
+		},
+		{
+			name:        "IP address with port is not a valid hostname",
+			hostname:    "[::1]:8080",
+			opts:        HostnameOptions{AllowPort: true},
+			expectedErr: "a lowercase RFC 1123 subdomain",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			normalized, err := ValidateHostname(test.hostname, test.opts)
+			if test.expectedErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(test.expectedErr)))
+				g.Expect(errcodes.Codes(err)).To(ConsistOf(errcodes.InvalidHostname))
+				g.Expect(normalized).To(BeEmpty())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(normalized).To(Equal(test.expected))
+		})
+	}
+}
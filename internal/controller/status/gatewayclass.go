@@ -1,6 +1,7 @@
 package status
 
 import (
+	"slices"
 	"sort"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -10,7 +11,8 @@ import (
 // supportedFeatures returns the list of features supported by NGINX Gateway Fabric.
 // The list must be sorted in ascending alphabetical order.
 // If experimental is true, experimental features like TLSRoute will be included.
-func supportedFeatures(experimental bool) []gatewayv1.SupportedFeature {
+// The inactive features, whose resources are not served by the cluster, are excluded.
+func supportedFeatures(experimental bool, inactive []features.FeatureName) []gatewayv1.SupportedFeature {
 	featureNames := []features.FeatureName{
 		// Core features
 		features.SupportGateway,
@@ -50,6 +52,10 @@ func supportedFeatures(experimental bool) []gatewayv1.SupportedFeature {
 		featureNames = append(featureNames, features.SupportTLSRoute)
 	}
 
+	featureNames = slices.DeleteFunc(featureNames, func(name features.FeatureName) bool {
+		return slices.Contains(inactive, name)
+	})
+
 	// Sort alphabetically by feature name
 	sort.Slice(featureNames, func(i, j int) bool {
 		return string(featureNames[i]) < string(featureNames[j])
@@ -49,6 +49,7 @@ func TestSupportedFeatures(t *testing.T) {
 		name               string
 		expectedFeatures   []gatewayv1.FeatureName
 		unexpectedFeatures []gatewayv1.FeatureName
+		inactive           []features.FeatureName
 		experimental       bool
 	}{
 		{
@@ -63,6 +64,18 @@ func TestSupportedFeatures(t *testing.T) {
 			expectedFeatures:   allFeatures,
 			unexpectedFeatures: []gatewayv1.FeatureName{},
 		},
+		{
+			name:         "inactive features are excluded",
+			experimental: true,
+			inactive:     []features.FeatureName{features.SupportBackendTLSPolicy, features.SupportTLSRoute},
+			expectedFeatures: slices.DeleteFunc(slices.Clone(standardFeatures), func(name gatewayv1.FeatureName) bool {
+				return name == gatewayv1.FeatureName(features.SupportBackendTLSPolicy)
+			}),
+			unexpectedFeatures: []gatewayv1.FeatureName{
+				gatewayv1.FeatureName(features.SupportBackendTLSPolicy),
+				gatewayv1.FeatureName(features.SupportTLSRoute),
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -70,7 +83,7 @@ func TestSupportedFeatures(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
 
-			features := supportedFeatures(tc.experimental)
+			features := supportedFeatures(tc.experimental, tc.inactive)
 
 			g.Expect(features).To(HaveLen(len(tc.expectedFeatures)))
 
@@ -1,20 +1,28 @@
 package status
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
+	"slices"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/pkg/features"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/gatewaytest"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/trafficrouting"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
 )
@@ -23,12 +31,14 @@ import (
 // This is needed to give the conformance tests an example valid ip unusable address.
 const unusableGatewayIPAddress = "198.51.100.0"
 
-// PrepareRouteRequests prepares status UpdateRequests for the given Routes.
+// PrepareRouteRequests prepares status UpdateRequests for the given Routes. The appliedSteps are the traffic
+// routing steps of the Routes that are applied to the data plane, keyed by the NamespacedName of the Gateway.
 func PrepareRouteRequests(
 	l4routes map[graph.L4RouteKey]*graph.L4Route,
 	routes map[graph.RouteKey]*graph.L7Route,
 	transitionTime metav1.Time,
 	gatewayCtlrName string,
+	appliedSteps map[types.NamespacedName]trafficrouting.Steps,
 ) []UpdateRequest {
 	reqs := make([]UpdateRequest, 0, len(routes))
 
@@ -39,6 +49,7 @@ func PrepareRouteRequests(
 			r.Conditions,
 			transitionTime,
 			r.Source.GetGeneration(),
+			nil,
 		)
 
 		status := v1alpha2.TLSRouteStatus{
@@ -55,14 +66,35 @@ func PrepareRouteRequests(
 	}
 
 	for routeKey, r := range routes {
+		if r.SkipStatus {
+			continue
+		}
+
 		routeStatus := prepareRouteStatus(
 			gatewayCtlrName,
 			r.ParentRefs,
 			r.Conditions,
 			transitionTime,
 			r.Source.GetGeneration(),
+			trafficRoutingConditions(routeKey.NamespacedName, r.Source.GetAnnotations(), appliedSteps),
 		)
 
+		if !r.CustomKind.Empty() {
+			// custom routes are watched as unstructured objects, so they are updated as such
+			resourceType := &unstructured.Unstructured{}
+			resourceType.SetGroupVersionKind(r.Source.GetObjectKind().GroupVersionKind())
+
+			req := UpdateRequest{
+				NsName:       routeKey.NamespacedName,
+				ResourceType: resourceType,
+				Setter:       newCustomRouteStatusSetter(routeStatus, gatewayCtlrName),
+			}
+
+			reqs = append(reqs, req)
+
+			continue
+		}
+
 		switch r.RouteType {
 		case graph.RouteTypeHTTP:
 			status := v1.HTTPRouteStatus{
@@ -98,6 +130,50 @@ func PrepareRouteRequests(
 	return reqs
 }
 
+// PrepareStaleRouteRequests prepares status UpdateRequests that remove the parent statuses of the Gateway controller
+// from the Routes that are no longer handled by any of its Gateways. For example, when a Gateway is deleted,
+// the Routes attached to it are no longer part of the graph, so their statuses would otherwise stay as they were.
+// The routes that are not HTTPRoutes, GRPCRoutes or TLSRoutes are ignored.
+func PrepareStaleRouteRequests(
+	routes []client.Object,
+	l4routes map[graph.L4RouteKey]*graph.L4Route,
+	l7routes map[graph.RouteKey]*graph.L7Route,
+	gatewayCtlrName string,
+) []UpdateRequest {
+	var reqs []UpdateRequest
+
+	for _, route := range routes {
+		var resourceType client.Object
+		var handled bool
+
+		switch route.(type) {
+		case *v1.HTTPRoute:
+			resourceType = &v1.HTTPRoute{}
+			_, handled = l7routes[graph.CreateRouteKey(route)]
+		case *v1.GRPCRoute:
+			resourceType = &v1.GRPCRoute{}
+			_, handled = l7routes[graph.CreateRouteKey(route)]
+		case *v1alpha2.TLSRoute:
+			resourceType = &v1alpha2.TLSRoute{}
+			_, handled = l4routes[graph.CreateRouteKeyL4(route)]
+		default:
+			continue
+		}
+
+		if handled || !hasControllerParentStatus(getRouteStatus(route), gatewayCtlrName) {
+			continue
+		}
+
+		reqs = append(reqs, UpdateRequest{
+			NsName:       client.ObjectKeyFromObject(route),
+			ResourceType: resourceType,
+			Setter:       newStaleRouteStatusSetter(gatewayCtlrName),
+		})
+	}
+
+	return reqs
+}
+
 // removeDuplicateIndexParentRefs removes duplicate ParentRefs by Idx, keeping the first occurrence.
 // If an Idx is duplicated, the SectionName for the stored ParentRef is nil.
 func removeDuplicateIndexParentRefs(parentRefs []graph.ParentRef) []graph.ParentRef {
@@ -133,12 +209,36 @@ func removeDuplicateIndexParentRefs(parentRefs []graph.ParentRef) []graph.Parent
 	return results
 }
 
+// trafficRoutingConditions returns a function that returns the TrafficRoutingStepApplied condition of the Route
+// for a parent Gateway, or nil if the Route has no traffic routing step.
+func trafficRoutingConditions(
+	route types.NamespacedName,
+	annotations map[string]string,
+	appliedSteps map[types.NamespacedName]trafficrouting.Steps,
+) func(types.NamespacedName) []conditions.Condition {
+	step := annotations[trafficrouting.StepAnnotation]
+	if step == "" {
+		return nil
+	}
+
+	return func(gateway types.NamespacedName) []conditions.Condition {
+		if applied, exists := appliedSteps[gateway][route]; exists && applied == step {
+			return []conditions.Condition{conditions.NewRouteTrafficRoutingStepApplied(step)}
+		}
+
+		return []conditions.Condition{conditions.NewRouteTrafficRoutingStepPending(step)}
+	}
+}
+
+// prepareRouteStatus prepares the status of a Route. The parentConds, if set, return the additional conditions
+// of the Route for a parent Gateway.
 func prepareRouteStatus(
 	gatewayCtlrName string,
 	parentRefs []graph.ParentRef,
 	conds []conditions.Condition,
 	transitionTime metav1.Time,
 	srcGeneration int64,
+	parentConds func(gateway types.NamespacedName) []conditions.Condition,
 ) v1.RouteStatus {
 	// If a route did not specify a sectionName in its parentRefs section, it will attempt to attach to all available
 	// listeners. In this case, parentRefs will be created and attached to the route for each attachable listener.
@@ -164,6 +264,9 @@ func prepareRouteStatus(
 		if failedAttachmentCondCount > 0 {
 			allConds = append(allConds, ref.Attachment.FailedConditions...)
 		}
+		if parentConds != nil && ref.Gateway != nil && ref.Service == nil {
+			allConds = append(allConds, parentConds(ref.Gateway.NamespacedName)...)
+		}
 
 		conds := conditions.DeduplicateConditions(allConds)
 		apiConds := conditions.ConvertConditions(conds, srcGeneration, transitionTime)
@@ -178,6 +281,17 @@ func prepareRouteStatus(
 			Conditions:     apiConds,
 		}
 
+		// The status of a mesh Route is reported for its parent Service rather than the waypoint Gateway.
+		if ref.Service != nil {
+			ps.ParentRef = v1.ParentReference{
+				Group:     helpers.GetPointer[v1.Group](""),
+				Kind:      helpers.GetPointer[v1.Kind](kinds.Service),
+				Namespace: helpers.GetPointer(v1.Namespace(ref.Service.Namespace)),
+				Name:      v1.ObjectName(ref.Service.Name),
+				Port:      ref.Port,
+			}
+		}
+
 		parents = append(parents, ps)
 	}
 
@@ -189,6 +303,7 @@ func PrepareGatewayClassRequests(
 	gc *graph.GatewayClass,
 	ignoredGwClasses map[types.NamespacedName]*v1.GatewayClass,
 	transitionTime metav1.Time,
+	inactiveFeatures []features.FeatureName,
 ) []UpdateRequest {
 	var reqs []UpdateRequest
 
@@ -211,7 +326,7 @@ func PrepareGatewayClassRequests(
 			ResourceType: &v1.GatewayClass{},
 			Setter: newGatewayClassStatusSetter(v1.GatewayClassStatus{
 				Conditions:        apiConds,
-				SupportedFeatures: supportedFeatures(gc.ExperimentalSupported),
+				SupportedFeatures: supportedFeatures(gc.ExperimentalSupported, inactiveFeatures),
 			}),
 		}
 
@@ -228,7 +343,7 @@ func PrepareGatewayClassRequests(
 					gwClass.Generation,
 					transitionTime,
 				),
-				SupportedFeatures: supportedFeatures(false),
+				SupportedFeatures: supportedFeatures(false, inactiveFeatures),
 			}),
 		}
 
@@ -326,6 +441,18 @@ func prepareGatewayRequest(
 		)
 	}
 
+	if len(nginxReloadRes.WithheldFeatures) > 0 {
+		gwConds = append(gwConds, conditions.NewGatewayFeaturesWithheld(nginxReloadRes.WithheldFeatures))
+	}
+
+	if nginxReloadRes.RolledBack {
+		gwConds = append(gwConds, conditions.NewGatewayConfigRolledBack())
+	}
+
+	if len(gateway.DataPlaneFailures) > 0 {
+		gwConds = append(gwConds, newDataPlaneUnhealthyCondition(gateway.DataPlaneFailures))
+	}
+
 	// Set the unprogrammed conditions here, because those do not make the gateway invalid.
 	// We set the unaccepted conditions elsewhere, because those do make the gateway invalid.
 	for _, address := range gateway.Source.Spec.Addresses {
@@ -365,11 +492,8 @@ func PrepareNGFPolicyRequests(
 	reqs := make([]UpdateRequest, 0, len(policies))
 
 	for key, pol := range policies {
-		ancestorStatuses := make([]v1.PolicyAncestorStatus, 0, len(pol.TargetRefs))
-
-		if len(pol.Ancestors) == 0 {
-			continue
-		}
+		// A Policy without ancestors still gets a request, so that the stale ancestor statuses are pruned.
+		ancestorStatuses := make([]v1.PolicyAncestorStatus, 0, len(pol.Ancestors))
 
 		for _, ancestor := range pol.Ancestors {
 			allConds := make([]conditions.Condition, 0, len(pol.Conditions)+len(ancestor.Conditions)+1)
@@ -486,6 +610,44 @@ func PrepareSnippetsFilterRequests(
 	return reqs
 }
 
+// PrepareClusterDefaultPolicyRequests prepares status UpdateRequests for the given ClusterDefaultPolicies.
+func PrepareClusterDefaultPolicyRequests(
+	clusterDefaultPolicies map[types.NamespacedName]*graph.ClusterDefaultPolicy,
+	transitionTime metav1.Time,
+	gatewayCtlrName string,
+) []UpdateRequest {
+	reqs := make([]UpdateRequest, 0, len(clusterDefaultPolicies))
+
+	for nsname, cdp := range clusterDefaultPolicies {
+		allConds := make([]conditions.Condition, 0, len(cdp.Conditions)+1)
+
+		// The order of conditions matters here.
+		// We add the default condition first, followed by the ClusterDefaultPolicy conditions.
+		// DeduplicateConditions will ensure the last condition wins.
+		allConds = append(allConds, conditions.NewClusterDefaultPolicyAccepted())
+		allConds = append(allConds, cdp.Conditions...)
+
+		conds := conditions.DeduplicateConditions(allConds)
+		apiConds := conditions.ConvertConditions(conds, cdp.Source.GetGeneration(), transitionTime)
+		status := ngfAPIv1alpha2.ClusterDefaultPolicyStatus{
+			Controllers: []ngfAPI.ControllerStatus{
+				{
+					Conditions:     apiConds,
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+				},
+			},
+		}
+
+		reqs = append(reqs, UpdateRequest{
+			NsName:       nsname,
+			ResourceType: cdp.Source,
+			Setter:       newClusterDefaultPolicyStatusSetter(status, gatewayCtlrName),
+		})
+	}
+
+	return reqs
+}
+
 // ControlPlaneUpdateResult describes the result of a control plane update.
 type ControlPlaneUpdateResult struct {
 	// Error is the error that occurred during the update.
@@ -522,12 +684,61 @@ func PrepareNginxGatewayStatus(
 	}
 }
 
+// PrepareGatewayTestStatus prepares a status UpdateRequest for the given GatewayTest from the results of
+// the verification of its assertions. If gatewayErr is not nil, the requests of the assertions couldn't be sent
+// to the Gateway.
+func PrepareGatewayTestStatus(
+	gatewayTest *ngfAPI.GatewayTest,
+	transitionTime metav1.Time,
+	results []ngfAPI.GatewayTestAssertionStatus,
+	gatewayErr error,
+) UpdateRequest {
+	var cond conditions.Condition
+	switch {
+	case errors.Is(gatewayErr, gatewaytest.ErrGatewayNotAccepted):
+		cond = conditions.NewGatewayTestGatewayNotAccepted(gatewayErr.Error())
+		results = nil
+	case gatewayErr != nil:
+		cond = conditions.NewGatewayTestGatewayNotReady(gatewayErr.Error())
+		results = nil
+	default:
+		var failed []string
+		for _, res := range results {
+			if !res.Passed {
+				failed = append(failed, res.Name)
+			}
+		}
+
+		if len(failed) > 0 {
+			cond = conditions.NewGatewayTestFailed(
+				fmt.Sprintf("%d of %d assertions failed: %s", len(failed), len(results), strings.Join(failed, ", ")),
+			)
+		} else {
+			cond = conditions.NewGatewayTestPassing()
+		}
+	}
+
+	return UpdateRequest{
+		NsName:       client.ObjectKeyFromObject(gatewayTest),
+		ResourceType: &ngfAPI.GatewayTest{},
+		Setter: newGatewayTestStatusSetter(ngfAPI.GatewayTestStatus{
+			Conditions: conditions.ConvertConditions(
+				[]conditions.Condition{cond},
+				gatewayTest.Generation,
+				transitionTime,
+			),
+			Assertions: results,
+		}),
+	}
+}
+
 // PrepareInferencePoolRequests prepares status UpdateRequests for the given InferencePools.
 func PrepareInferencePoolRequests(
 	referencedInferencePools map[types.NamespacedName]*graph.ReferencedInferencePool,
 	clusterInferencePoolList *inference.InferencePoolList,
 	referencedGateways map[types.NamespacedName]*graph.Gateway,
 	transitionTime metav1.Time,
+	gatewayCtlrName string,
 ) []UpdateRequest {
 	reqs := make([]UpdateRequest, 0, len(referencedInferencePools))
 
@@ -551,28 +762,22 @@ func PrepareInferencePoolRequests(
 			}
 
 			// If the pool is in the cluster, but not referenced, we need to check
-			// if any of its parents are an nginx Gateway, if so, we need to remove them.
+			// if any of its parents were written by NGF, if so, we need to remove them.
+			// The parents that belong to other controllers are kept by the setter.
 			if referencedInferencePools[nsname] == nil {
-				// represents parentRefs that are NOT nginx gateways
-				filteredParents := make([]inference.ParentStatus, 0, len(pool.Status.Parents))
-				for _, parent := range pool.Status.Parents {
-					// if the parent.ParentRef is not in the list of nginx gateways, keep it
-					// otherwise, we are removing it from the status
-					if !containsParentReference(nginxGatewayParentRefs, parent.ParentRef) {
-						filteredParents = append(filteredParents, parent)
-					}
-				}
-
-				// Create an update request to set the filtered parents
-				if len(filteredParents) != len(pool.Status.Parents) {
-					status := inference.InferencePoolStatus{
-						Parents: filteredParents,
-					}
+				hasNGFParent := slices.ContainsFunc(pool.Status.Parents, func(parent inference.ParentStatus) bool {
+					return isNGFInferencePoolParent(parent, gatewayCtlrName, nginxGatewayParentRefs)
+				})
 
+				if hasNGFParent {
 					req := UpdateRequest{
 						NsName:       nsname,
 						ResourceType: &inference.InferencePool{},
-						Setter:       newInferencePoolStatusSetter(status),
+						Setter: newInferencePoolStatusSetter(
+							inference.InferencePoolStatus{},
+							gatewayCtlrName,
+							nginxGatewayParentRefs,
+						),
 					}
 
 					reqs = append(reqs, req)
@@ -607,7 +812,8 @@ func PrepareInferencePoolRequests(
 					Group:     helpers.GetPointer(inference.Group(ref.GroupVersionKind().Group)),
 					Kind:      kinds.Gateway,
 				},
-				Conditions: apiConds,
+				ControllerName: inference.ControllerName(gatewayCtlrName),
+				Conditions:     apiConds,
 			})
 		}
 
@@ -618,7 +824,7 @@ func PrepareInferencePoolRequests(
 		req := UpdateRequest{
 			NsName:       nsname,
 			ResourceType: pool.Source,
-			Setter:       newInferencePoolStatusSetter(status),
+			Setter:       newInferencePoolStatusSetter(status, gatewayCtlrName, nginxGatewayParentRefs),
 		}
 
 		reqs = append(reqs, req)
@@ -640,3 +846,30 @@ func containsParentReference(parentRefs []inference.ParentReference, target infe
 
 	return false
 }
+
+// newDataPlaneUnhealthyCondition returns the DataPlaneHealthy condition of the recent failures of the nginx
+// data plane of a Gateway. The reason of the condition is the kind of the latest failure.
+func newDataPlaneUnhealthyCondition(failures []graph.DataPlaneFailure) conditions.Condition {
+	var reason v1.GatewayConditionReason
+	switch failures[0].Kind {
+	case graph.DataPlaneFailureWorkerCrash:
+		reason = conditions.GatewayReasonWorkerCrashed
+	case graph.DataPlaneFailureOOMKill:
+		reason = conditions.GatewayReasonOOMKilled
+	default:
+		reason = conditions.GatewayReasonReloadFailed
+	}
+
+	counts := make([]string, 0, len(failures))
+	for _, f := range failures {
+		counts = append(counts, fmt.Sprintf("%s (%d)", f.Kind, f.Count))
+	}
+
+	msg := fmt.Sprintf(
+		"The nginx data plane failed recently: %s. Latest failure: %s",
+		strings.Join(counts, ", "),
+		failures[0].Message,
+	)
+
+	return conditions.NewGatewayDataPlaneUnhealthy(reason, msg)
+}
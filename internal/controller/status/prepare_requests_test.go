@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -17,10 +18,14 @@ import (
 	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/pkg/features"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/gatewaytest"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/conditions"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/trafficrouting"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
 	ngftypes "github.com/nginx/nginx-gateway-fabric/v2/internal/framework/types"
@@ -33,6 +38,7 @@ func createK8sClientFor(resourceType ngftypes.ObjectType) client.Client {
 	utilruntime.Must(v1.Install(scheme))
 	utilruntime.Must(v1alpha2.Install(scheme))
 	utilruntime.Must(ngfAPI.AddToScheme(scheme))
+	utilruntime.Must(ngfAPIv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(inference.Install(scheme))
 
 	k8sClient := fake.NewClientBuilder().
@@ -425,13 +431,14 @@ func TestBuildHTTPRouteStatuses(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 	}
 
-	updater := NewUpdater(k8sClient, logr.Discard())
+	updater := NewUpdater(k8sClient, logr.Discard(), nil)
 
 	reqs := PrepareRouteRequests(
 		map[graph.L4RouteKey]*graph.L4Route{},
 		routes,
 		transitionTime,
 		gatewayCtlrName,
+		nil,
 	)
 
 	updater.Update(t.Context(), reqs...)
@@ -447,6 +454,304 @@ func TestBuildHTTPRouteStatuses(t *testing.T) {
 	}
 }
 
+func TestBuildCustomRouteStatuses(t *testing.T) {
+	t.Parallel()
+
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "CustomRoute"}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(gvk)
+	route.SetNamespace("test")
+	route.SetName("custom-route")
+	route.SetGeneration(3)
+
+	routes := map[graph.RouteKey]*graph.L7Route{
+		graph.CreateRouteKey(route): {
+			Valid:      true,
+			Source:     route,
+			ParentRefs: parentRefsValid,
+			RouteType:  graph.RouteTypeHTTP,
+			CustomKind: gvk.GroupKind(),
+		},
+	}
+
+	g := NewWithT(t)
+
+	reqs := PrepareRouteRequests(
+		map[graph.L4RouteKey]*graph.L4Route{},
+		routes,
+		transitionTime,
+		gatewayCtlrName,
+		nil,
+	)
+	g.Expect(reqs).To(HaveLen(1))
+
+	req := reqs[0]
+	g.Expect(req.NsName).To(Equal(types.NamespacedName{Namespace: "test", Name: "custom-route"}))
+	g.Expect(req.ResourceType.GetObjectKind().GroupVersionKind()).To(Equal(gvk))
+
+	obj := route.DeepCopy()
+	g.Expect(req.Setter(obj)).To(BeTrue())
+
+	status, found, err := unstructured.NestedMap(obj.Object, "status")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+
+	var routeStatus v1.RouteStatus
+	g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(status, &routeStatus)).To(Succeed())
+	g.Expect(routeStatus.Parents).To(HaveLen(len(routeStatusValid.Parents)))
+
+	expectedParents := make([]v1.RouteParentStatus, 0, len(routeStatusValid.Parents))
+	for _, p := range routeStatusValid.Parents {
+		expectedParents = append(expectedParents, v1.RouteParentStatus{
+			ParentRef:      p.ParentRef,
+			ControllerName: p.ControllerName,
+		})
+	}
+
+	parents := make([]v1.RouteParentStatus, 0, len(routeStatus.Parents))
+	for _, p := range routeStatus.Parents {
+		parents = append(parents, v1.RouteParentStatus{
+			ParentRef:      p.ParentRef,
+			ControllerName: p.ControllerName,
+		})
+	}
+
+	g.Expect(parents).To(ConsistOf(expectedParents))
+}
+
+func TestPrepareRouteRequestsSkipStatus(t *testing.T) {
+	t.Parallel()
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"})
+	route.SetNamespace("test")
+	route.SetName("ingress")
+
+	routes := map[graph.RouteKey]*graph.L7Route{
+		graph.CreateRouteKey(route): {
+			Valid:      true,
+			Source:     route,
+			ParentRefs: parentRefsValid,
+			RouteType:  graph.RouteTypeHTTP,
+			CustomKind: route.GroupVersionKind().GroupKind(),
+			SkipStatus: true,
+		},
+	}
+
+	g := NewWithT(t)
+
+	reqs := PrepareRouteRequests(
+		map[graph.L4RouteKey]*graph.L4Route{},
+		routes,
+		transitionTime,
+		gatewayCtlrName,
+		nil,
+	)
+	g.Expect(reqs).To(BeEmpty())
+}
+
+func TestPrepareStaleRouteRequests(t *testing.T) {
+	t.Parallel()
+
+	ourParent := v1.RouteParentStatus{
+		ParentRef:      v1.ParentReference{Name: "deleted-gateway"},
+		ControllerName: gatewayCtlrName,
+	}
+	otherParent := v1.RouteParentStatus{
+		ParentRef:      v1.ParentReference{Name: "other-gateway"},
+		ControllerName: "other-controller",
+	}
+
+	hrHandled := &v1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr-handled"},
+		Status: v1.HTTPRouteStatus{
+			RouteStatus: v1.RouteStatus{Parents: []v1.RouteParentStatus{ourParent}},
+		},
+	}
+	hrStale := &v1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr-stale"},
+		Status: v1.HTTPRouteStatus{
+			RouteStatus: v1.RouteStatus{Parents: []v1.RouteParentStatus{ourParent, otherParent}},
+		},
+	}
+	hrOtherController := &v1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr-other"},
+		Status: v1.HTTPRouteStatus{
+			RouteStatus: v1.RouteStatus{Parents: []v1.RouteParentStatus{otherParent}},
+		},
+	}
+	grStale := &v1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gr-stale"},
+		Status: v1.GRPCRouteStatus{
+			RouteStatus: v1.RouteStatus{Parents: []v1.RouteParentStatus{ourParent}},
+		},
+	}
+	trHandled := &v1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "tr-handled"},
+		Status: v1alpha2.TLSRouteStatus{
+			RouteStatus: v1.RouteStatus{Parents: []v1.RouteParentStatus{ourParent}},
+		},
+	}
+	trStale := &v1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "tr-stale"},
+		Status: v1alpha2.TLSRouteStatus{
+			RouteStatus: v1.RouteStatus{Parents: []v1.RouteParentStatus{ourParent}},
+		},
+	}
+
+	l7routes := map[graph.RouteKey]*graph.L7Route{
+		graph.CreateRouteKey(hrHandled): {Source: hrHandled},
+	}
+	l4routes := map[graph.L4RouteKey]*graph.L4Route{
+		graph.CreateRouteKeyL4(trHandled): {Source: trHandled},
+	}
+
+	g := NewWithT(t)
+
+	reqs := PrepareStaleRouteRequests(
+		[]client.Object{
+			hrHandled,
+			hrStale,
+			hrOtherController,
+			grStale,
+			trHandled,
+			trStale,
+			&v1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+		},
+		l4routes,
+		l7routes,
+		gatewayCtlrName,
+	)
+
+	nsNames := make([]types.NamespacedName, 0, len(reqs))
+	for _, req := range reqs {
+		nsNames = append(nsNames, req.NsName)
+	}
+	g.Expect(nsNames).To(ConsistOf(
+		client.ObjectKeyFromObject(hrStale),
+		client.ObjectKeyFromObject(grStale),
+		client.ObjectKeyFromObject(trStale),
+	))
+
+	k8sClient := createK8sClientFor(&v1.HTTPRoute{})
+	created := hrStale.DeepCopy()
+	g.Expect(k8sClient.Create(t.Context(), created)).To(Succeed())
+	created.Status = hrStale.Status
+	g.Expect(k8sClient.Status().Update(t.Context(), created)).To(Succeed())
+
+	updater := NewUpdater(k8sClient, logr.Discard(), nil)
+	updater.Update(t.Context(), reqs...)
+
+	var hr v1.HTTPRoute
+	g.Expect(k8sClient.Get(t.Context(), client.ObjectKeyFromObject(hrStale), &hr)).To(Succeed())
+	g.Expect(hr.Status.Parents).To(Equal([]v1.RouteParentStatus{otherParent}))
+}
+
+func TestPrepareRouteStatusForServiceParentRef(t *testing.T) {
+	t.Parallel()
+
+	svcNsName := types.NamespacedName{Namespace: "test", Name: "svc"}
+	parentRefs := []graph.ParentRef{
+		{
+			Idx:     0,
+			Gateway: &graph.ParentRefGateway{NamespacedName: gwNsName},
+			Service: &svcNsName,
+			Port:    helpers.GetPointer[v1.PortNumber](8080),
+			Attachment: &graph.ParentRefAttachmentStatus{
+				Attached: true,
+			},
+		},
+	}
+
+	expected := v1.RouteStatus{
+		Parents: []v1.RouteParentStatus{
+			{
+				ParentRef: v1.ParentReference{
+					Group:     helpers.GetPointer[v1.Group](""),
+					Kind:      helpers.GetPointer[v1.Kind](kinds.Service),
+					Namespace: helpers.GetPointer[v1.Namespace]("test"),
+					Name:      "svc",
+					Port:      helpers.GetPointer[v1.PortNumber](8080),
+				},
+				ControllerName: gatewayCtlrName,
+				Conditions: conditions.ConvertConditions(
+					conditions.NewDefaultRouteConditions(),
+					3,
+					transitionTime,
+				),
+			},
+		},
+	}
+
+	g := NewWithT(t)
+
+	status := prepareRouteStatus(gatewayCtlrName, parentRefs, nil, transitionTime, 3, nil)
+	g.Expect(helpers.Diff(expected, status)).To(BeEmpty())
+}
+
+func TestPrepareRouteStatusTrafficRoutingStep(t *testing.T) {
+	t.Parallel()
+
+	routeNsName := types.NamespacedName{Namespace: "test", Name: "route"}
+	otherGwNsName := types.NamespacedName{Namespace: "test", Name: "other-gateway"}
+
+	parentRefs := []graph.ParentRef{
+		{
+			Idx:        0,
+			Gateway:    &graph.ParentRefGateway{NamespacedName: gwNsName},
+			Attachment: &graph.ParentRefAttachmentStatus{Attached: true},
+		},
+		{
+			Idx:        1,
+			Gateway:    &graph.ParentRefGateway{NamespacedName: otherGwNsName},
+			Attachment: &graph.ParentRefAttachmentStatus{Attached: true},
+		},
+	}
+
+	appliedSteps := map[types.NamespacedName]trafficrouting.Steps{
+		gwNsName:      {routeNsName: "canary-weight-40"},
+		otherGwNsName: {routeNsName: "canary-weight-20"},
+	}
+	annotations := map[string]string{trafficrouting.StepAnnotation: "canary-weight-40"}
+
+	expectedConds := func(cond conditions.Condition) []metav1.Condition {
+		return conditions.ConvertConditions(
+			append(conditions.NewDefaultRouteConditions(), cond),
+			3,
+			transitionTime,
+		)
+	}
+
+	g := NewWithT(t)
+
+	status := prepareRouteStatus(
+		gatewayCtlrName,
+		parentRefs,
+		nil,
+		transitionTime,
+		3,
+		trafficRoutingConditions(routeNsName, annotations, appliedSteps),
+	)
+	g.Expect(status.Parents).To(HaveLen(2))
+
+	parentConds := make(map[v1.ObjectName][]metav1.Condition, len(status.Parents))
+	for _, parent := range status.Parents {
+		parentConds[parent.ParentRef.Name] = parent.Conditions
+	}
+
+	g.Expect(parentConds).To(Equal(map[v1.ObjectName][]metav1.Condition{
+		v1.ObjectName(gwNsName.Name): expectedConds(
+			conditions.NewRouteTrafficRoutingStepApplied("canary-weight-40"),
+		),
+		v1.ObjectName(otherGwNsName.Name): expectedConds(
+			conditions.NewRouteTrafficRoutingStepPending("canary-weight-40"),
+		),
+	}))
+
+	g.Expect(trafficRoutingConditions(routeNsName, nil, appliedSteps)).To(BeNil())
+}
+
 func TestBuildGRPCRouteStatuses(t *testing.T) {
 	t.Parallel()
 	grValid := &v1.GRPCRoute{
@@ -503,13 +808,14 @@ func TestBuildGRPCRouteStatuses(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 	}
 
-	updater := NewUpdater(k8sClient, logr.Discard())
+	updater := NewUpdater(k8sClient, logr.Discard(), nil)
 
 	reqs := PrepareRouteRequests(
 		map[graph.L4RouteKey]*graph.L4Route{},
 		routes,
 		transitionTime,
 		gatewayCtlrName,
+		nil,
 	)
 
 	updater.Update(t.Context(), reqs...)
@@ -579,13 +885,14 @@ func TestBuildTLSRouteStatuses(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 	}
 
-	updater := NewUpdater(k8sClient, logr.Discard())
+	updater := NewUpdater(k8sClient, logr.Discard(), nil)
 
 	reqs := PrepareRouteRequests(
 		routes,
 		map[graph.RouteKey]*graph.L7Route{},
 		transitionTime,
 		gatewayCtlrName,
+		nil,
 	)
 
 	updater.Update(t.Context(), reqs...)
@@ -610,6 +917,7 @@ func TestBuildGatewayClassStatuses(t *testing.T) {
 		ignoredClasses map[types.NamespacedName]*v1.GatewayClass
 		expected       map[types.NamespacedName]v1.GatewayClassStatus
 		name           string
+		inactive       []features.FeatureName
 	}{
 		{
 			name:     "nil gatewayclass and no ignored gatewayclasses",
@@ -643,7 +951,7 @@ func TestBuildGatewayClassStatuses(t *testing.T) {
 							Message:            conditions.GatewayClassMessageGatewayClassConflict,
 						},
 					},
-					SupportedFeatures: supportedFeatures(false),
+					SupportedFeatures: supportedFeatures(false, nil),
 				},
 				{Name: "ignored-2"}: {
 					Conditions: []metav1.Condition{
@@ -656,7 +964,7 @@ func TestBuildGatewayClassStatuses(t *testing.T) {
 							Message:            conditions.GatewayClassMessageGatewayClassConflict,
 						},
 					},
-					SupportedFeatures: supportedFeatures(false),
+					SupportedFeatures: supportedFeatures(false, nil),
 				},
 			},
 		},
@@ -690,7 +998,42 @@ func TestBuildGatewayClassStatuses(t *testing.T) {
 							Message:            "The Gateway API CRD versions are supported",
 						},
 					},
-					SupportedFeatures: supportedFeatures(false),
+					SupportedFeatures: supportedFeatures(false, nil),
+				},
+			},
+		},
+		{
+			name: "valid gatewayclass with inactive features",
+			gc: &graph.GatewayClass{
+				Source: &v1.GatewayClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "valid-gc",
+						Generation: 1,
+					},
+				},
+			},
+			inactive: []features.FeatureName{features.SupportBackendTLSPolicy},
+			expected: map[types.NamespacedName]v1.GatewayClassStatus{
+				{Name: "valid-gc"}: {
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1.GatewayClassConditionStatusAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 1,
+							LastTransitionTime: transitionTime,
+							Reason:             string(v1.GatewayClassReasonAccepted),
+							Message:            "The GatewayClass is accepted",
+						},
+						{
+							Type:               string(v1.GatewayClassReasonSupportedVersion),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 1,
+							LastTransitionTime: transitionTime,
+							Reason:             string(v1.GatewayClassReasonSupportedVersion),
+							Message:            "The Gateway API CRD versions are supported",
+						},
+					},
+					SupportedFeatures: supportedFeatures(false, []features.FeatureName{features.SupportBackendTLSPolicy}),
 				},
 			},
 		},
@@ -717,9 +1060,9 @@ func TestBuildGatewayClassStatuses(t *testing.T) {
 				expectedTotalReqs++
 			}
 
-			updater := NewUpdater(k8sClient, logr.Discard())
+			updater := NewUpdater(k8sClient, logr.Discard(), nil)
 
-			reqs := PrepareGatewayClassRequests(test.gc, test.ignoredClasses, transitionTime)
+			reqs := PrepareGatewayClassRequests(test.gc, test.ignoredClasses, transitionTime, test.inactive)
 
 			g.Expect(reqs).To(HaveLen(expectedTotalReqs))
 
@@ -1130,7 +1473,181 @@ func TestBuildGatewayStatuses(t *testing.T) {
 					},
 				},
 			},
-			nginxReloadRes: graph.NginxReloadResult{Error: errors.New("test error")},
+			nginxReloadRes: graph.NginxReloadResult{Error: errors.New("test error")},
+		},
+		{
+			name: "valid gateway; features withheld from the configuration",
+			gateway: &graph.Gateway{
+				Source: createGateway(),
+				Listeners: []*graph.Listener{
+					{
+						Name:   "listener-valid-1",
+						Valid:  true,
+						Routes: map[graph.RouteKey]*graph.L7Route{routeKey: {}},
+					},
+				},
+				Valid: true,
+			},
+			expected: map[types.NamespacedName]v1.GatewayStatus{
+				{Namespace: "test", Name: "gateway"}: {
+					Addresses: addr,
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1.GatewayConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(v1.GatewayReasonAccepted),
+							Message:            "The Gateway is accepted",
+						},
+						{
+							Type:               string(v1.GatewayConditionProgrammed),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(v1.GatewayReasonProgrammed),
+							Message:            "The Gateway is programmed",
+						},
+						{
+							Type:               string(conditions.GatewayDataPlaneCompatible),
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(conditions.GatewayReasonFeaturesWithheld),
+							Message: "The following features are not supported by the nginx data plane and " +
+								"were withheld from the configuration until the data plane is upgraded: UpstreamResolve",
+						},
+					},
+					Listeners: []v1.ListenerStatus{
+						{
+							Name:           "listener-valid-1",
+							AttachedRoutes: 1,
+							Conditions:     validListenerConditions,
+						},
+					},
+				},
+			},
+			nginxReloadRes: graph.NginxReloadResult{WithheldFeatures: []string{"UpstreamResolve"}},
+		},
+		{
+			name: "valid gateway; configuration rolled back",
+			gateway: &graph.Gateway{
+				Source: createGateway(),
+				Listeners: []*graph.Listener{
+					{
+						Name:   "listener-valid-1",
+						Valid:  true,
+						Routes: map[graph.RouteKey]*graph.L7Route{routeKey: {}},
+					},
+				},
+				Valid: true,
+			},
+			expected: map[types.NamespacedName]v1.GatewayStatus{
+				{Namespace: "test", Name: "gateway"}: {
+					Addresses: addr,
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1.GatewayConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(v1.GatewayReasonAccepted),
+							Message:            "The Gateway is accepted",
+						},
+						{
+							Type:               string(v1.GatewayConditionProgrammed),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(v1.GatewayReasonProgrammed),
+							Message:            "The Gateway is programmed",
+						},
+						{
+							Type:               string(conditions.GatewayConfigRolledBack),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(conditions.GatewayReasonRollbackRequested),
+							Message: "The nginx configuration was rolled back to the previous version. Changes to the " +
+								"configuration are held until the gateway.nginx.org/config-rollback annotation is " +
+								"removed from the Gateway",
+						},
+					},
+					Listeners: []v1.ListenerStatus{
+						{
+							Name:           "listener-valid-1",
+							AttachedRoutes: 1,
+							Conditions:     validListenerConditions,
+						},
+					},
+				},
+			},
+			nginxReloadRes: graph.NginxReloadResult{RolledBack: true},
+		},
+		{
+			name: "valid gateway; data plane failed recently",
+			gateway: &graph.Gateway{
+				Source: createGateway(),
+				Listeners: []*graph.Listener{
+					{
+						Name:   "listener-valid-1",
+						Valid:  true,
+						Routes: map[graph.RouteKey]*graph.L7Route{routeKey: {}},
+					},
+				},
+				Valid: true,
+				DataPlaneFailures: []graph.DataPlaneFailure{
+					{
+						Kind:    graph.DataPlaneFailureOOMKill,
+						Message: "worker process 21 exited on signal 9",
+						Count:   1,
+					},
+					{
+						Kind:    graph.DataPlaneFailureWorkerCrash,
+						Message: "worker process 20 exited on signal 11 (core dumped)",
+						Count:   2,
+					},
+				},
+			},
+			expected: map[types.NamespacedName]v1.GatewayStatus{
+				{Namespace: "test", Name: "gateway"}: {
+					Addresses: addr,
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1.GatewayConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(v1.GatewayReasonAccepted),
+							Message:            "The Gateway is accepted",
+						},
+						{
+							Type:               string(v1.GatewayConditionProgrammed),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(v1.GatewayReasonProgrammed),
+							Message:            "The Gateway is programmed",
+						},
+						{
+							Type:               string(conditions.GatewayDataPlaneHealthy),
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(conditions.GatewayReasonOOMKilled),
+							Message: "The nginx data plane failed recently: oom_kill (1), worker_crash (2). " +
+								"Latest failure: worker process 21 exited on signal 9",
+						},
+					},
+					Listeners: []v1.ListenerStatus{
+						{
+							Name:           "listener-valid-1",
+							AttachedRoutes: 1,
+							Conditions:     validListenerConditions,
+						},
+					},
+				},
+			},
 		},
 		{
 			name: "valid gateway with valid parametersRef; all valid listeners",
@@ -1361,7 +1878,7 @@ func TestBuildGatewayStatuses(t *testing.T) {
 				expectedTotalReqs++
 			}
 
-			updater := NewUpdater(k8sClient, logr.Discard())
+			updater := NewUpdater(k8sClient, logr.Discard(), nil)
 
 			reqs := PrepareGatewayRequests(
 				test.gateway,
@@ -1624,7 +2141,7 @@ func TestBuildBackendTLSPolicyStatuses(t *testing.T) {
 				g.Expect(err).ToNot(HaveOccurred())
 			}
 
-			updater := NewUpdater(k8sClient, logr.Discard())
+			updater := NewUpdater(k8sClient, logr.Discard(), nil)
 
 			reqs := PrepareBackendTLSPolicyRequests(test.backendTLSPolicies, transitionTime, gatewayCtlrName)
 
@@ -1718,7 +2235,7 @@ func TestBuildNginxGatewayStatus(t *testing.T) {
 				g.Expect(err).ToNot(HaveOccurred())
 			}
 
-			updater := NewUpdater(k8sClient, logr.Discard())
+			updater := NewUpdater(k8sClient, logr.Discard(), nil)
 
 			req := PrepareNginxGatewayStatus(test.nginxGateway, transitionTime, test.cpUpdateResult)
 
@@ -1738,6 +2255,123 @@ func TestBuildNginxGatewayStatus(t *testing.T) {
 	}
 }
 
+func TestPrepareGatewayTestStatus(t *testing.T) {
+	t.Parallel()
+
+	transitionTime := helpers.PrepareTimeForFakeClient(metav1.Now())
+
+	passed := ngfAPI.GatewayTestAssertionStatus{Name: "foo", Passed: true, Message: "status code 200"}
+	failed := ngfAPI.GatewayTestAssertionStatus{
+		Name:    "bar",
+		Message: "expected status code 200, got 404",
+	}
+
+	tests := []struct {
+		gatewayErr error
+		name       string
+		results    []ngfAPI.GatewayTestAssertionStatus
+		expected   ngfAPI.GatewayTestStatus
+	}{
+		{
+			name:    "all assertions passed",
+			results: []ngfAPI.GatewayTestAssertionStatus{passed},
+			expected: ngfAPI.GatewayTestStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(ngfAPI.GatewayTestConditionPassing),
+						Status:             metav1.ConditionTrue,
+						ObservedGeneration: 2,
+						LastTransitionTime: transitionTime,
+						Reason:             string(ngfAPI.GatewayTestReasonPassed),
+						Message:            "All assertions passed",
+					},
+				},
+				Assertions: []ngfAPI.GatewayTestAssertionStatus{passed},
+			},
+		},
+		{
+			name:    "an assertion failed",
+			results: []ngfAPI.GatewayTestAssertionStatus{passed, failed},
+			expected: ngfAPI.GatewayTestStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(ngfAPI.GatewayTestConditionPassing),
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 2,
+						LastTransitionTime: transitionTime,
+						Reason:             string(ngfAPI.GatewayTestReasonFailed),
+						Message:            "1 of 2 assertions failed: bar",
+					},
+				},
+				Assertions: []ngfAPI.GatewayTestAssertionStatus{passed, failed},
+			},
+		},
+		{
+			name:       "Gateway is not ready",
+			results:    []ngfAPI.GatewayTestAssertionStatus{passed},
+			gatewayErr: errors.New("gateway test/gateway not found"),
+			expected: ngfAPI.GatewayTestStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(ngfAPI.GatewayTestConditionPassing),
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 2,
+						LastTransitionTime: transitionTime,
+						Reason:             string(ngfAPI.GatewayTestReasonGatewayNotReady),
+						Message:            "gateway test/gateway not found",
+					},
+				},
+			},
+		},
+		{
+			name:       "Gateway is not accepted",
+			results:    []ngfAPI.GatewayTestAssertionStatus{passed},
+			gatewayErr: fmt.Errorf("%w: gateway test/gateway", gatewaytest.ErrGatewayNotAccepted),
+			expected: ngfAPI.GatewayTestStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(ngfAPI.GatewayTestConditionPassing),
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 2,
+						LastTransitionTime: transitionTime,
+						Reason:             string(ngfAPI.GatewayTestReasonGatewayNotAccepted),
+						Message:            "unsupported GatewayClass: gateway test/gateway",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			gatewayTest := &ngfAPI.GatewayTest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "gateway-test",
+					Namespace:  "test",
+					Generation: 2,
+				},
+			}
+
+			k8sClient := createK8sClientFor(&ngfAPI.GatewayTest{})
+			g.Expect(k8sClient.Create(t.Context(), gatewayTest)).To(Succeed())
+
+			updater := NewUpdater(k8sClient, logr.Discard(), nil)
+			updater.Update(
+				t.Context(),
+				PrepareGatewayTestStatus(gatewayTest, transitionTime, test.results, test.gatewayErr),
+			)
+
+			var gt ngfAPI.GatewayTest
+			err := k8sClient.Get(t.Context(), types.NamespacedName{Namespace: "test", Name: "gateway-test"}, &gt)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(helpers.Diff(test.expected, gt.Status)).To(BeEmpty())
+		})
+	}
+}
+
 func TestBuildNGFPolicyStatuses(t *testing.T) {
 	t.Parallel()
 	const gatewayCtlrName = "controller"
@@ -1996,7 +2630,10 @@ func TestBuildNGFPolicyStatuses(t *testing.T) {
 			policies: map[graph.PolicyKey]*graph.Policy{
 				nilAncestorPolicyKey: getPolicy(nilAncestorPolicyCfg),
 			},
-			expected: map[types.NamespacedName]v1.PolicyStatus{},
+			// the request prunes the stale ancestor statuses, so it is prepared even if there are no ancestors
+			expected: map[types.NamespacedName]v1.PolicyStatus{
+				nilAncestorPolicyKey.NsName: {},
+			},
 		},
 	}
 
@@ -2012,7 +2649,7 @@ func TestBuildNGFPolicyStatuses(t *testing.T) {
 				g.Expect(err).ToNot(HaveOccurred())
 			}
 
-			updater := NewUpdater(k8sClient, logr.Discard())
+			updater := NewUpdater(k8sClient, logr.Discard(), nil)
 
 			reqs := PrepareNGFPolicyRequests(test.policies, transitionTime, gatewayCtlrName)
 
@@ -2031,6 +2668,107 @@ func TestBuildNGFPolicyStatuses(t *testing.T) {
 	}
 }
 
+func TestPrepareNGFPolicyRequestsPrunesStaleAncestors(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	const gatewayCtlrName = "controller"
+
+	transitionTime := helpers.PrepareTimeForFakeClient(metav1.Now())
+
+	otherCtlrAncestor := v1.PolicyAncestorStatus{
+		AncestorRef:    v1.ParentReference{Name: "other-gateway"},
+		ControllerName: "other-controller",
+		Conditions: []metav1.Condition{
+			{
+				Type:   string(v1.PolicyConditionAccepted),
+				Status: metav1.ConditionTrue,
+				Reason: string(v1.PolicyReasonAccepted),
+			},
+		},
+	}
+
+	source := &ngfAPI.ClientSettingsPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "pol",
+			Namespace:  "test",
+			Generation: 3,
+		},
+		Status: v1.PolicyStatus{
+			Ancestors: []v1.PolicyAncestorStatus{
+				{
+					AncestorRef:    v1.ParentReference{Name: "deleted-gateway"},
+					ControllerName: gatewayCtlrName,
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1.PolicyConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							Reason:             string(v1.PolicyReasonAccepted),
+							ObservedGeneration: 2,
+						},
+					},
+				},
+				otherCtlrAncestor,
+			},
+		},
+	}
+
+	policyKey := graph.PolicyKey{
+		NsName: types.NamespacedName{Namespace: "test", Name: "pol"},
+		GVK:    schema.GroupVersionKind{Group: ngfAPI.GroupName, Kind: kinds.ClientSettingsPolicy},
+	}
+
+	pols := map[graph.PolicyKey]*graph.Policy{
+		policyKey: {
+			Source: source,
+			Ancestors: []graph.PolicyAncestor{
+				{
+					Ancestor: v1.ParentReference{Name: "missing-gateway"},
+					Conditions: []conditions.Condition{
+						conditions.NewPolicyTargetNotFound("The TargetRef is not found"),
+					},
+				},
+			},
+		},
+	}
+
+	reqs := PrepareNGFPolicyRequests(pols, transitionTime, gatewayCtlrName)
+	g.Expect(reqs).To(HaveLen(1))
+
+	g.Expect(reqs[0].Setter(source)).To(BeTrue())
+
+	// the ancestor status of the deleted Gateway is pruned, and the status of the other controller is kept
+	g.Expect(source.Status.Ancestors).To(Equal([]v1.PolicyAncestorStatus{
+		otherCtlrAncestor,
+		{
+			AncestorRef:    v1.ParentReference{Name: "missing-gateway"},
+			ControllerName: gatewayCtlrName,
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(v1.PolicyConditionAccepted),
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 3,
+					LastTransitionTime: transitionTime,
+					Reason:             string(v1.PolicyReasonTargetNotFound),
+					Message:            "The TargetRef is not found",
+				},
+			},
+		},
+	}))
+
+	// without ancestors, all the ancestor statuses of NGF are pruned
+	pols[policyKey].Ancestors = nil
+
+	reqs = PrepareNGFPolicyRequests(pols, transitionTime, gatewayCtlrName)
+	g.Expect(reqs).To(HaveLen(1))
+
+	g.Expect(reqs[0].Setter(source)).To(BeTrue())
+	g.Expect(source.Status.Ancestors).To(Equal([]v1.PolicyAncestorStatus{otherCtlrAncestor}))
+
+	// pruning is idempotent
+	g.Expect(reqs[0].Setter(source)).To(BeFalse())
+}
+
 func TestBuildSnippetsFilterStatuses(t *testing.T) {
 	transitionTime := helpers.PrepareTimeForFakeClient(metav1.Now())
 	const gatewayCtlrName = "controller"
@@ -2142,7 +2880,7 @@ func TestBuildSnippetsFilterStatuses(t *testing.T) {
 				g.Expect(err).ToNot(HaveOccurred())
 			}
 
-			updater := NewUpdater(k8sClient, logr.Discard())
+			updater := NewUpdater(k8sClient, logr.Discard(), nil)
 
 			reqs := PrepareSnippetsFilterRequests(test.snippetsFilters, transitionTime, gatewayCtlrName)
 
@@ -2161,6 +2899,103 @@ func TestBuildSnippetsFilterStatuses(t *testing.T) {
 	}
 }
 
+func TestBuildClusterDefaultPolicyStatuses(t *testing.T) {
+	t.Parallel()
+	transitionTime := helpers.PrepareTimeForFakeClient(metav1.Now())
+
+	validCDP := &graph.ClusterDefaultPolicy{
+		Source: &ngfAPIv1alpha2.ClusterDefaultPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "valid",
+				Generation: 1,
+			},
+		},
+		Valid: true,
+	}
+
+	invalidCDP := &graph.ClusterDefaultPolicy{
+		Source: &ngfAPIv1alpha2.ClusterDefaultPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "invalid",
+				Generation: 2,
+			},
+		},
+		Conditions: []conditions.Condition{conditions.NewClusterDefaultPolicyInvalid("Invalid defaults")},
+	}
+
+	cdps := map[types.NamespacedName]*graph.ClusterDefaultPolicy{
+		{Name: "valid"}:   validCDP,
+		{Name: "invalid"}: invalidCDP,
+	}
+
+	expected := map[types.NamespacedName]ngfAPIv1alpha2.ClusterDefaultPolicyStatus{
+		{Name: "valid"}: {
+			Controllers: []ngfAPI.ControllerStatus{
+				{
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(ngfAPIv1alpha2.ClusterDefaultPolicyConditionTypeAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 1,
+							LastTransitionTime: transitionTime,
+							Reason:             string(ngfAPIv1alpha2.ClusterDefaultPolicyConditionReasonAccepted),
+							Message:            "The ClusterDefaultPolicy is accepted",
+						},
+					},
+					ControllerName: gatewayCtlrName,
+				},
+			},
+		},
+		{Name: "invalid"}: {
+			Controllers: []ngfAPI.ControllerStatus{
+				{
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(ngfAPIv1alpha2.ClusterDefaultPolicyConditionTypeAccepted),
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: 2,
+							LastTransitionTime: transitionTime,
+							Reason:             string(ngfAPIv1alpha2.ClusterDefaultPolicyConditionReasonInvalid),
+							Message:            "Invalid defaults",
+						},
+					},
+					ControllerName: gatewayCtlrName,
+				},
+			},
+		},
+	}
+
+	g := NewWithT(t)
+
+	k8sClient := createK8sClientFor(&ngfAPIv1alpha2.ClusterDefaultPolicy{})
+
+	for _, cdp := range cdps {
+		g.Expect(k8sClient.Create(t.Context(), cdp.Source)).To(Succeed())
+	}
+
+	updater := NewUpdater(k8sClient, logr.Discard(), nil)
+
+	reqs := PrepareClusterDefaultPolicyRequests(cdps, transitionTime, gatewayCtlrName)
+	g.Expect(reqs).To(HaveLen(2))
+
+	updater.Update(t.Context(), reqs...)
+
+	for nsname, exp := range expected {
+		var cdp ngfAPIv1alpha2.ClusterDefaultPolicy
+
+		g.Expect(k8sClient.Get(t.Context(), nsname, &cdp)).To(Succeed())
+		g.Expect(helpers.Diff(exp, cdp.Status)).To(BeEmpty())
+	}
+
+	// the same status is not written again
+	for _, req := range reqs {
+		var cdp ngfAPIv1alpha2.ClusterDefaultPolicy
+
+		g.Expect(k8sClient.Get(t.Context(), req.NsName, &cdp)).To(Succeed())
+		g.Expect(req.Setter(&cdp)).To(BeFalse())
+	}
+}
+
 func TestBuildInferencePoolStatuses(t *testing.T) {
 	t.Parallel()
 	transitionTime := helpers.PrepareTimeForFakeClient(metav1.Now())
@@ -2250,6 +3085,31 @@ func TestBuildInferencePoolStatuses(t *testing.T) {
 		},
 	}
 
+	otherControllerParent := inference.ParentStatus{
+		Conditions: []metav1.Condition{
+			validAcceptedCondition,
+			validResolvedRefsCondition,
+		},
+		ParentRef: inference.ParentReference{
+			Namespace: inference.Namespace("test"),
+			Name:      "other-gateway",
+			Kind:      kinds.Gateway,
+			Group:     helpers.GetPointer(inference.Group(group)),
+		},
+		ControllerName: "other.example.com/gateway-controller",
+	}
+
+	inferencePoolWithOtherControllerStatus := &inference.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "inference-pool-with-other-controller-status",
+			Namespace:  "test",
+			Generation: 1,
+		},
+		Status: inference.InferencePoolStatus{
+			Parents: []inference.ParentStatus{otherControllerParent},
+		},
+	}
+
 	tests := []struct {
 		referencedInferencePool map[types.NamespacedName]*graph.ReferencedInferencePool
 		expectedPoolWithStatus  map[types.NamespacedName]inference.InferencePoolStatus
@@ -2302,6 +3162,7 @@ func TestBuildInferencePoolStatuses(t *testing.T) {
 								Kind:      kinds.Gateway,
 								Group:     helpers.GetPointer(inference.Group(group)),
 							},
+							ControllerName: gatewayCtlrName,
 						},
 						{
 							Conditions: []metav1.Condition{
@@ -2314,6 +3175,7 @@ func TestBuildInferencePoolStatuses(t *testing.T) {
 								Kind:      kinds.Gateway,
 								Group:     helpers.GetPointer(inference.Group(group)),
 							},
+							ControllerName: gatewayCtlrName,
 						},
 					},
 				},
@@ -2364,6 +3226,7 @@ func TestBuildInferencePoolStatuses(t *testing.T) {
 								Kind:      kinds.Gateway,
 								Group:     helpers.GetPointer(inference.Group(group)),
 							},
+							ControllerName: gatewayCtlrName,
 						},
 					},
 				},
@@ -2422,6 +3285,7 @@ func TestBuildInferencePoolStatuses(t *testing.T) {
 								Kind:      kinds.Gateway,
 								Group:     helpers.GetPointer(inference.Group(group)),
 							},
+							ControllerName: gatewayCtlrName,
 						},
 					},
 				},
@@ -2442,6 +3306,63 @@ func TestBuildInferencePoolStatuses(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "inference pool status of other controller is kept",
+			referencedInferencePool: map[types.NamespacedName]*graph.ReferencedInferencePool{
+				{Namespace: "test", Name: "inference-pool-with-other-controller-status"}: {
+					Source: inferencePoolWithOtherControllerStatus,
+					Gateways: []*v1.Gateway{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "gateway-1",
+								Namespace: "test",
+							},
+						},
+					},
+				},
+			},
+			clusterInferencePools: inference.InferencePoolList{
+				Items: []inference.InferencePool{
+					*inferencePoolWithOtherControllerStatus,
+				},
+			},
+			expectedReqs: 1,
+			expectedPoolWithStatus: map[types.NamespacedName]inference.InferencePoolStatus{
+				{Namespace: "test", Name: "inference-pool-with-other-controller-status"}: {
+					Parents: []inference.ParentStatus{
+						{
+							Conditions: []metav1.Condition{
+								validAcceptedCondition,
+								validResolvedRefsCondition,
+							},
+							ParentRef: inference.ParentReference{
+								Namespace: inference.Namespace("test"),
+								Name:      "gateway-1",
+								Kind:      kinds.Gateway,
+								Group:     helpers.GetPointer(inference.Group(group)),
+							},
+							ControllerName: gatewayCtlrName,
+						},
+						otherControllerParent,
+					},
+				},
+			},
+		},
+		{
+			name:                    "inference pool status of other controller is not removed if not referenced",
+			referencedInferencePool: map[types.NamespacedName]*graph.ReferencedInferencePool{},
+			clusterInferencePools: inference.InferencePoolList{
+				Items: []inference.InferencePool{
+					*inferencePoolWithOtherControllerStatus,
+				},
+			},
+			expectedReqs: 0,
+			expectedPoolWithStatus: map[types.NamespacedName]inference.InferencePoolStatus{
+				{Namespace: "test", Name: "inference-pool-with-other-controller-status"}: {
+					Parents: []inference.ParentStatus{otherControllerParent},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -2455,12 +3376,13 @@ func TestBuildInferencePoolStatuses(t *testing.T) {
 				g.Expect(err).ToNot(HaveOccurred())
 			}
 
-			updater := NewUpdater(k8sClient, logr.Discard())
+			updater := NewUpdater(k8sClient, logr.Discard(), nil)
 			reqs := PrepareInferencePoolRequests(
 				test.referencedInferencePool,
 				&test.clusterInferencePools,
 				referencedGateways,
 				transitionTime,
+				gatewayCtlrName,
 			)
 			g.Expect(reqs).To(HaveLen(test.expectedReqs))
 			updater.Update(t.Context(), reqs...)
@@ -25,7 +25,12 @@ type QueueObject struct {
 	GatewayService *corev1.Service
 	Error          error
 	Deployment     types.NamespacedName
-	UpdateType     UpdateType
+	// WithheldFeatures are the features that were withheld from the NGINX configuration, because
+	// the data plane doesn't support them.
+	WithheldFeatures []string
+	UpdateType       UpdateType
+	// RolledBack is true if the NGINX configuration was rolled back to the previous version and is held.
+	RolledBack bool
 }
 
 // Queue represents a queue with unlimited size.
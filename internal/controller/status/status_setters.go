@@ -3,12 +3,15 @@ package status
 import (
 	"slices"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/policies"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 )
@@ -26,6 +29,20 @@ func newNginxGatewayStatusSetter(status ngfAPI.NginxGatewayStatus) Setter {
 	}
 }
 
+func newGatewayTestStatusSetter(status ngfAPI.GatewayTestStatus) Setter {
+	return func(obj client.Object) (wasSet bool) {
+		gt := helpers.MustCastObject[*ngfAPI.GatewayTest](obj)
+
+		if ConditionsEqual(gt.Status.Conditions, status.Conditions) &&
+			slices.Equal(gt.Status.Assertions, status.Assertions) {
+			return false
+		}
+
+		gt.Status = status
+		return true
+	}
+}
+
 func newGatewayStatusSetter(status gatewayv1.GatewayStatus) Setter {
 	return func(obj client.Object) (wasSet bool) {
 		gw := helpers.MustCastObject[*gatewayv1.Gateway](obj)
@@ -105,6 +122,46 @@ func newHTTPRouteStatusSetter(status gatewayv1.HTTPRouteStatus, gatewayCtlrName
 	}
 }
 
+// newCustomRouteStatusSetter returns a Setter for custom routes. It expects the route to use the Gateway API
+// RouteStatus structure for its status.
+func newCustomRouteStatusSetter(status gatewayv1.RouteStatus, gatewayCtlrName string) Setter {
+	return func(object client.Object) (wasSet bool) {
+		route := helpers.MustCastObject[*unstructured.Unstructured](object)
+
+		var prevStatus gatewayv1.RouteStatus
+		if prev, found, err := unstructured.NestedMap(route.Object, "status"); err == nil && found {
+			// if the status doesn't have the expected structure, we overwrite it
+			_ = runtime.DefaultUnstructuredConverter.FromUnstructured(prev, &prevStatus)
+		}
+
+		// keep all the parent statuses that belong to other controllers
+		newParents := make([]gatewayv1.RouteParentStatus, 0, len(status.Parents))
+		newParents = append(newParents, status.Parents...)
+		for _, os := range prevStatus.Parents {
+			if string(os.ControllerName) != gatewayCtlrName {
+				newParents = append(newParents, os)
+			}
+		}
+
+		if routeStatusEqual(gatewayCtlrName, prevStatus.Parents, newParents) {
+			return false
+		}
+
+		fullStatus, err := runtime.DefaultUnstructuredConverter.ToUnstructured(
+			&gatewayv1.RouteStatus{Parents: newParents},
+		)
+		if err != nil {
+			return false
+		}
+
+		if err := unstructured.SetNestedField(route.Object, fullStatus["parents"], "status", "parents"); err != nil {
+			return false
+		}
+
+		return true
+	}
+}
+
 func newTLSRouteStatusSetter(status v1alpha2.TLSRouteStatus, gatewayCtlrName string) Setter {
 	return func(object client.Object) (wasSet bool) {
 		tr := helpers.MustCastObject[*v1alpha2.TLSRoute](object)
@@ -157,6 +214,47 @@ func newGRPCRouteStatusSetter(status gatewayv1.GRPCRouteStatus, gatewayCtlrName
 	}
 }
 
+// newStaleRouteStatusSetter returns a Setter that removes the parent statuses of the Gateway controller
+// from an HTTPRoute, GRPCRoute or TLSRoute, keeping the parent statuses that belong to other controllers.
+func newStaleRouteStatusSetter(gatewayCtlrName string) Setter {
+	return func(object client.Object) (wasSet bool) {
+		routeStatus := getRouteStatus(object)
+		if !hasControllerParentStatus(routeStatus, gatewayCtlrName) {
+			return false
+		}
+
+		routeStatus.Parents = slices.DeleteFunc(routeStatus.Parents, func(parent gatewayv1.RouteParentStatus) bool {
+			return string(parent.ControllerName) == gatewayCtlrName
+		})
+
+		return true
+	}
+}
+
+// getRouteStatus returns the status of an HTTPRoute, GRPCRoute or TLSRoute, or nil for other objects.
+func getRouteStatus(object client.Object) *gatewayv1.RouteStatus {
+	switch route := object.(type) {
+	case *gatewayv1.HTTPRoute:
+		return &route.Status.RouteStatus
+	case *gatewayv1.GRPCRoute:
+		return &route.Status.RouteStatus
+	case *v1alpha2.TLSRoute:
+		return &route.Status.RouteStatus
+	default:
+		return nil
+	}
+}
+
+func hasControllerParentStatus(routeStatus *gatewayv1.RouteStatus, gatewayCtlrName string) bool {
+	if routeStatus == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(routeStatus.Parents, func(parent gatewayv1.RouteParentStatus) bool {
+		return string(parent.ControllerName) == gatewayCtlrName
+	})
+}
+
 func routeStatusEqual(gatewayCtlrName string, prevParents, curParents []gatewayv1.RouteParentStatus) bool {
 	// Since other controllers may update HTTPRoute status we can't assume anything about the order of the statuses,
 	// and we have to ignore statuses written by other controllers when checking for equality.
@@ -388,6 +486,38 @@ func newSnippetsFilterStatusSetter(
 	}
 }
 
+func newClusterDefaultPolicyStatusSetter(
+	clusterDefaultPolicyStatus ngfAPIv1alpha2.ClusterDefaultPolicyStatus,
+	gatewayCtlrName string,
+) Setter {
+	return func(obj client.Object) (wasSet bool) {
+		cdp := helpers.MustCastObject[*ngfAPIv1alpha2.ClusterDefaultPolicy](obj)
+
+		controllerStatuses := make([]ngfAPI.ControllerStatus, 0, 1+len(cdp.Status.Controllers))
+
+		for _, status := range cdp.Status.Controllers {
+			if string(status.ControllerName) != gatewayCtlrName {
+				controllerStatuses = append(controllerStatuses, status)
+			}
+		}
+
+		controllerStatuses = append(controllerStatuses, clusterDefaultPolicyStatus.Controllers...)
+		clusterDefaultPolicyStatus.Controllers = controllerStatuses
+
+		// the status of a ClusterDefaultPolicy has the same structure as the status of a SnippetsFilter
+		if snippetsFilterStatusEqual(
+			gatewayCtlrName,
+			clusterDefaultPolicyStatus.Controllers,
+			cdp.Status.Controllers,
+		) {
+			return false
+		}
+
+		cdp.Status = clusterDefaultPolicyStatus
+		return true
+	}
+}
+
 func snippetsFilterStatusEqual(gatewayCtlrName string, currStatus, prevStatus []ngfAPI.ControllerStatus) bool {
 	// Since other controllers may update snippetsFilter status we can't assume anything about the order of the statuses,
 	// and we have to ignore statuses written by other controllers when checking for equality.
@@ -430,21 +560,48 @@ func snippetsStatusEqual(status1, status2 ngfAPI.ControllerStatus) bool {
 	return ConditionsEqual(status1.Conditions, status2.Conditions)
 }
 
-func newInferencePoolStatusSetter(status inference.InferencePoolStatus) Setter {
+// newInferencePoolStatusSetter returns a Setter for InferencePools. nginxGatewayParentRefs are the references to
+// the Gateways that belong to NGF; they are used to recognize the parent statuses that were written by NGF before it
+// populated the ControllerName field.
+func newInferencePoolStatusSetter(
+	status inference.InferencePoolStatus,
+	gatewayCtlrName string,
+	nginxGatewayParentRefs []inference.ParentReference,
+) Setter {
 	return func(obj client.Object) (wasSet bool) {
 		ip := helpers.MustCastObject[*inference.InferencePool](obj)
 
-		// we build all the parent statuses at once so we can directly
-		// compare the previous and current statuses
-		if inferencePoolStatusEqual(ip.Status.Parents, status.Parents) {
+		// keep all the parent statuses that belong to other controllers
+		newParents := make([]inference.ParentStatus, 0, len(status.Parents))
+		newParents = append(newParents, status.Parents...)
+		for _, ps := range ip.Status.Parents {
+			if !isNGFInferencePoolParent(ps, gatewayCtlrName, nginxGatewayParentRefs) {
+				newParents = append(newParents, ps)
+			}
+		}
+
+		if inferencePoolStatusEqual(ip.Status.Parents, newParents) {
 			return false
 		}
 
-		ip.Status = status
+		ip.Status = inference.InferencePoolStatus{Parents: newParents}
 		return true
 	}
 }
 
+// isNGFInferencePoolParent returns true if the parent status was written by NGF.
+func isNGFInferencePoolParent(
+	parent inference.ParentStatus,
+	gatewayCtlrName string,
+	nginxGatewayParentRefs []inference.ParentReference,
+) bool {
+	if parent.ControllerName != "" {
+		return string(parent.ControllerName) == gatewayCtlrName
+	}
+
+	return containsParentReference(nginxGatewayParentRefs, parent.ParentRef)
+}
+
 func inferencePoolStatusEqual(prevParents, curParents []inference.ParentStatus) bool {
 	// Compare the previous and current parent statuses, ignoring order
 	// Check if any previous parent status is missing in the current status
@@ -489,5 +646,9 @@ func parentStatusEqual(p1, p2 inference.ParentStatus) bool {
 		return false
 	}
 
+	if p1.ControllerName != p2.ControllerName {
+		return false
+	}
+
 	return ConditionsEqual(p1.Conditions, p2.Conditions)
 }
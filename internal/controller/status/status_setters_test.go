@@ -5,6 +5,9 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	inference "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -308,6 +311,108 @@ func TestNewHTTPRouteStatusSetter(t *testing.T) {
 	}
 }
 
+func TestNewCustomRouteStatusSetter(t *testing.T) {
+	t.Parallel()
+	const (
+		controllerName      = "controller"
+		otherControllerName = "different"
+	)
+
+	newParent := gatewayv1.RouteParentStatus{
+		ParentRef:      gatewayv1.ParentReference{Name: "gateway"},
+		ControllerName: gatewayv1.GatewayController(controllerName),
+		Conditions:     []metav1.Condition{{Type: "Accepted", Message: "new condition"}},
+	}
+	oldParent := gatewayv1.RouteParentStatus{
+		ParentRef:      gatewayv1.ParentReference{Name: "gateway"},
+		ControllerName: gatewayv1.GatewayController(controllerName),
+		Conditions:     []metav1.Condition{{Type: "Accepted", Message: "old condition"}},
+	}
+	otherParent := gatewayv1.RouteParentStatus{
+		ParentRef:      gatewayv1.ParentReference{Name: "other-gateway"},
+		ControllerName: gatewayv1.GatewayController(otherControllerName),
+		Conditions:     []metav1.Condition{{Type: "Accepted", Message: "some condition"}},
+	}
+
+	tests := []struct {
+		status            map[string]any
+		name              string
+		newStatus         gatewayv1.RouteStatus
+		expStatus         gatewayv1.RouteStatus
+		expStatusSet      bool
+		expStatusKeptKeys []string
+	}{
+		{
+			name:         "route has no status",
+			newStatus:    gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{newParent}},
+			expStatus:    gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{newParent}},
+			expStatusSet: true,
+		},
+		{
+			name:      "route has old status, keep other controller statuses and other status fields",
+			newStatus: gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{newParent}},
+			status: map[string]any{
+				"parents": []any{
+					mustToUnstructured(t, &otherParent),
+					mustToUnstructured(t, &oldParent),
+				},
+				"observedGeneration": int64(1),
+			},
+			expStatus:         gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{newParent, otherParent}},
+			expStatusSet:      true,
+			expStatusKeptKeys: []string{"observedGeneration"},
+		},
+		{
+			name:      "route has same status",
+			newStatus: gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{newParent}},
+			status: map[string]any{
+				"parents": []any{mustToUnstructured(t, &newParent)},
+			},
+			expStatus:    gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{newParent}},
+			expStatusSet: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			setter := newCustomRouteStatusSetter(test.newStatus, controllerName)
+			obj := &unstructured.Unstructured{Object: map[string]any{}}
+			if test.status != nil {
+				obj.Object["status"] = test.status
+			}
+
+			statusSet := setter(obj)
+			g.Expect(statusSet).To(Equal(test.expStatusSet))
+
+			status, found, err := unstructured.NestedMap(obj.Object, "status")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(found).To(BeTrue())
+
+			var routeStatus gatewayv1.RouteStatus
+			g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(status, &routeStatus)).To(Succeed())
+			g.Expect(routeStatus).To(Equal(test.expStatus))
+
+			for _, key := range test.expStatusKeptKeys {
+				g.Expect(status).To(HaveKey(key))
+			}
+		})
+	}
+}
+
+func mustToUnstructured(t *testing.T, obj any) map[string]any {
+	t.Helper()
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return u
+}
+
 func TestNewGRPCRouteStatusSetter(t *testing.T) {
 	t.Parallel()
 	const (
@@ -662,6 +767,98 @@ func TestNewTLSRouteStatusSetter(t *testing.T) {
 	}
 }
 
+func TestNewStaleRouteStatusSetter(t *testing.T) {
+	t.Parallel()
+
+	const controllerName = "controller"
+
+	ourParent := gatewayv1.RouteParentStatus{
+		ParentRef:      gatewayv1.ParentReference{Name: "gateway"},
+		ControllerName: controllerName,
+	}
+	otherParent := gatewayv1.RouteParentStatus{
+		ParentRef:      gatewayv1.ParentReference{Name: "gateway"},
+		ControllerName: "other-controller",
+	}
+
+	tests := []struct {
+		route        client.Object
+		expStatus    gatewayv1.RouteStatus
+		name         string
+		expStatusSet bool
+	}{
+		{
+			name: "HTTPRoute with parent statuses of both controllers",
+			route: &gatewayv1.HTTPRoute{
+				Status: gatewayv1.HTTPRouteStatus{
+					RouteStatus: gatewayv1.RouteStatus{
+						Parents: []gatewayv1.RouteParentStatus{ourParent, otherParent},
+					},
+				},
+			},
+			expStatusSet: true,
+			expStatus:    gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{otherParent}},
+		},
+		{
+			name: "GRPCRoute with parent status of the controller",
+			route: &gatewayv1.GRPCRoute{
+				Status: gatewayv1.GRPCRouteStatus{
+					RouteStatus: gatewayv1.RouteStatus{
+						Parents: []gatewayv1.RouteParentStatus{ourParent},
+					},
+				},
+			},
+			expStatusSet: true,
+			expStatus:    gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{}},
+		},
+		{
+			name: "TLSRoute with parent status of the controller",
+			route: &v1alpha2.TLSRoute{
+				Status: v1alpha2.TLSRouteStatus{
+					RouteStatus: gatewayv1.RouteStatus{
+						Parents: []gatewayv1.RouteParentStatus{ourParent},
+					},
+				},
+			},
+			expStatusSet: true,
+			expStatus:    gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{}},
+		},
+		{
+			name: "HTTPRoute with parent status of another controller only",
+			route: &gatewayv1.HTTPRoute{
+				Status: gatewayv1.HTTPRouteStatus{
+					RouteStatus: gatewayv1.RouteStatus{
+						Parents: []gatewayv1.RouteParentStatus{otherParent},
+					},
+				},
+			},
+			expStatusSet: false,
+			expStatus:    gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{otherParent}},
+		},
+		{
+			name:         "HTTPRoute without status",
+			route:        &gatewayv1.HTTPRoute{},
+			expStatusSet: false,
+			expStatus:    gatewayv1.RouteStatus{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			setter := newStaleRouteStatusSetter(controllerName)
+
+			g.Expect(setter(test.route)).To(Equal(test.expStatusSet))
+			g.Expect(*getRouteStatus(test.route)).To(Equal(test.expStatus))
+		})
+	}
+
+	g := NewWithT(t)
+	g.Expect(newStaleRouteStatusSetter(controllerName)(&gatewayv1.Gateway{})).To(BeFalse())
+}
+
 func TestNewGatewayClassStatusSetter(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -1754,6 +1951,23 @@ func TestNewSnippetsFilterStatusSetter(t *testing.T) {
 func TestInferencePoolStatusSetter(t *testing.T) {
 	t.Parallel()
 
+	const otherCtlrName = "other.example.com/gateway-controller"
+
+	nginxGatewayParentRefs := []inference.ParentReference{
+		{Name: "gateway1", Namespace: "test"},
+		{Name: "gateway2", Namespace: "test"},
+		{Name: "gateway3", Namespace: "test"},
+	}
+
+	otherParent := inference.ParentStatus{
+		Conditions: []metav1.Condition{{Message: "other-gateway is valid parent ref"}},
+		ParentRef: inference.ParentReference{
+			Name:      "other-gateway",
+			Namespace: "test",
+		},
+		ControllerName: otherCtlrName,
+	}
+
 	tests := []struct {
 		name                         string
 		status, newStatus, expStatus inference.InferencePoolStatus
@@ -2033,6 +2247,95 @@ func TestInferencePoolStatusSetter(t *testing.T) {
 			},
 			expStatusSet: false,
 		},
+		{
+			name: "InferencePool has parent statuses of other controllers",
+			status: inference.InferencePoolStatus{
+				Parents: []inference.ParentStatus{
+					{
+						Conditions: []metav1.Condition{{Message: "gateway1 is valid parent ref"}},
+						ParentRef: inference.ParentReference{
+							Name:      "gateway1",
+							Namespace: "test",
+						},
+					},
+					otherParent,
+				},
+			},
+			newStatus: inference.InferencePoolStatus{
+				Parents: []inference.ParentStatus{
+					{
+						Conditions: []metav1.Condition{{Message: "gateway1 is valid parent ref"}},
+						ParentRef: inference.ParentReference{
+							Name:      "gateway1",
+							Namespace: "test",
+						},
+						ControllerName: gatewayCtlrName,
+					},
+				},
+			},
+			expStatus: inference.InferencePoolStatus{
+				Parents: []inference.ParentStatus{
+					{
+						Conditions: []metav1.Condition{{Message: "gateway1 is valid parent ref"}},
+						ParentRef: inference.ParentReference{
+							Name:      "gateway1",
+							Namespace: "test",
+						},
+						ControllerName: gatewayCtlrName,
+					},
+					otherParent,
+				},
+			},
+			expStatusSet: true,
+		},
+		{
+			name: "InferencePool parent statuses of NGF are removed, other controllers are kept",
+			status: inference.InferencePoolStatus{
+				Parents: []inference.ParentStatus{
+					{
+						Conditions: []metav1.Condition{{Message: "deleted-gateway is valid parent ref"}},
+						ParentRef: inference.ParentReference{
+							Name:      "deleted-gateway",
+							Namespace: "test",
+						},
+						ControllerName: gatewayCtlrName,
+					},
+					otherParent,
+				},
+			},
+			newStatus: inference.InferencePoolStatus{},
+			expStatus: inference.InferencePoolStatus{
+				Parents: []inference.ParentStatus{otherParent},
+			},
+			expStatusSet: true,
+		},
+		{
+			name: "InferencePool parent status without controller name for a Gateway of other controller is kept",
+			status: inference.InferencePoolStatus{
+				Parents: []inference.ParentStatus{
+					{
+						Conditions: []metav1.Condition{{Message: "other-gateway is valid parent ref"}},
+						ParentRef: inference.ParentReference{
+							Name:      "other-gateway",
+							Namespace: "test",
+						},
+					},
+				},
+			},
+			newStatus: inference.InferencePoolStatus{},
+			expStatus: inference.InferencePoolStatus{
+				Parents: []inference.ParentStatus{
+					{
+						Conditions: []metav1.Condition{{Message: "other-gateway is valid parent ref"}},
+						ParentRef: inference.ParentReference{
+							Name:      "other-gateway",
+							Namespace: "test",
+						},
+					},
+				},
+			},
+			expStatusSet: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -2040,7 +2343,7 @@ func TestInferencePoolStatusSetter(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
 
-			setter := newInferencePoolStatusSetter(test.newStatus)
+			setter := newInferencePoolStatusSetter(test.newStatus, gatewayCtlrName, nginxGatewayParentRefs)
 			obj := &inference.InferencePool{Status: test.status}
 
 			statusSet := setter(obj)
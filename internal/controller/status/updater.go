@@ -12,7 +12,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/health"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/loadshed"
 	ngftypes "github.com/nginx/nginx-gateway-fabric/v2/internal/framework/types"
 )
 
@@ -40,9 +42,9 @@ type Setter func(client.Object) (wasSet bool)
 // Making Updater asynchronous will prevent it from adding variable delays to the event loop.
 // FIXME(pleshakov): https://github.com/nginx/nginx-gateway-fabric/issues/1014
 //
-// (2) It doesn't clear the statuses of a resources that are no longer handled by the Gateway. For example, if
-// an HTTPRoute resource no longer has the parentRef to the Gateway resources, the Gateway must update the status
-// of the resource to remove the status about the removed parentRef.
+// (2) It doesn't clear the statuses of a resources that are no longer handled by the Gateway on its own.
+// The statuses of the Routes are cleared by the requests from PrepareStaleRouteRequests, but the statuses of
+// the other resources, like policies, are not.
 // FIXME(pleshakov): https://github.com/nginx/nginx-gateway-fabric/issues/1015
 //
 // (3) If another controllers changes the status of the Gateway/HTTPRoute resource so that the information set by our
@@ -51,21 +53,28 @@ type Setter func(client.Object) (wasSet bool)
 // FIXME(pleshakov): https://github.com/nginx/nginx-gateway-fabric/issues/1813
 type Updater struct {
 	client client.Client
+	// health tracks the health of the status updater. The updater is unhealthy while the last status updates failed.
+	health *health.Tracker
 	logger logr.Logger
 }
 
 var ErrFailedAssert = errors.New("type assertion failed")
 
 // NewUpdater creates a new Updater.
-func NewUpdater(c client.Client, logger logr.Logger) *Updater {
+// The healthTracker can be nil if the health of the status updater is not tracked.
+func NewUpdater(c client.Client, logger logr.Logger, healthTracker *health.Tracker) *Updater {
 	return &Updater{
 		client: c,
+		health: healthTracker,
 		logger: logger,
 	}
 }
 
 // Update updates the status of the resources from the requests.
 func (u *Updater) Update(ctx context.Context, reqs ...UpdateRequest) {
+	var failed int
+	var lastErr error
+
 	for _, r := range reqs {
 		select {
 		case <-ctx.Done():
@@ -80,8 +89,21 @@ func (u *Updater) Update(ctx context.Context, reqs ...UpdateRequest) {
 			"kind", r.ResourceType.GetObjectKind().GroupVersionKind().Kind,
 		)
 
-		u.writeStatuses(ctx, r.NsName, r.ResourceType, r.Setter)
+		if err := u.writeStatuses(ctx, r.NsName, r.ResourceType, r.Setter); err != nil {
+			failed++
+			lastErr = err
+		}
+	}
+
+	if failed > 0 {
+		u.health.SetUnhealthy(
+			health.StatusUpdater,
+			fmt.Errorf("failed to update the status of %d resource(s): %w", failed, lastErr),
+		)
+		return
 	}
+
+	u.health.SetHealthy(health.StatusUpdater)
 }
 
 func (u *Updater) writeStatuses(
@@ -89,15 +111,16 @@ func (u *Updater) writeStatuses(
 	nsname types.NamespacedName,
 	resourceType ngftypes.ObjectType,
 	statusSetter Setter,
-) {
+) error {
 	copiedObject := resourceType.DeepCopyObject()
 	obj, ok := copiedObject.(client.Object)
 	if !ok {
 		panic(fmt.Errorf("object is not a client.Object: %w", ErrFailedAssert))
 	}
 
+	// Status updates don't affect the data plane, so they are deferred or shed when the API server is under pressure.
 	err := wait.ExponentialBackoffWithContext(
-		ctx,
+		loadshed.WithPriority(ctx, loadshed.PriorityLow),
 		wait.Backoff{
 			Duration: time.Millisecond * 200,
 			Factor:   2,
@@ -115,7 +138,11 @@ func (u *Updater) writeStatuses(
 			"namespace", nsname.Namespace,
 			"name", nsname.Name,
 			"kind", resourceType.GetObjectKind().GroupVersionKind().Kind)
+
+		return err
 	}
+
+	return nil
 }
 
 // NewRetryUpdateFunc returns a function which will be used in wait.ExponentialBackoffWithContext.
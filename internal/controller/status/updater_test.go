@@ -2,6 +2,7 @@ package status
 
 import (
 	"context"
+	"errors"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
@@ -11,8 +12,11 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/health"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/metrics/collectors"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
 )
@@ -116,7 +120,7 @@ var _ = Describe("Updater", func() {
 		)
 
 		BeforeAll(func() {
-			updater = NewUpdater(k8sClient, logr.Discard())
+			updater = NewUpdater(k8sClient, logr.Discard(), nil)
 
 			for _, name := range gcNames {
 				gc := createGC(name)
@@ -202,4 +206,49 @@ var _ = Describe("Updater", func() {
 			})
 		})
 	})
+
+	Describe("Health of the status updater", Ordered, func() {
+		var (
+			tracker    *health.Tracker
+			failClient client.Client
+		)
+
+		BeforeAll(func() {
+			tracker = health.NewTracker(collectors.NewComponentHealthNoopCollector(), health.StatusUpdater)
+
+			Expect(k8sClient.Create(context.Background(), createGC("health"))).Should(Succeed())
+
+			failClient = interceptor.NewClient(k8sClient.(client.WithWatch), interceptor.Funcs{
+				SubResourceUpdate: func(
+					context.Context,
+					client.Client,
+					string,
+					client.Object,
+					...client.SubResourceUpdateOption,
+				) error {
+					return errors.New("update failed")
+				},
+			})
+		})
+
+		It("should be healthy after the statuses are updated", func() {
+			NewUpdater(k8sClient, logr.Discard(), tracker).Update(
+				context.Background(),
+				prepareReq("health", "TestHealthy", updateNeeded),
+			)
+
+			Expect(tracker.Check(health.StatusUpdater)).To(Succeed())
+		})
+
+		It("should be unhealthy after an update of a status fails", func() {
+			NewUpdater(failClient, logr.Discard(), tracker).Update(
+				context.Background(),
+				prepareReq("health", "TestUnhealthy", updateNeeded),
+			)
+
+			Expect(tracker.Check(health.StatusUpdater)).To(
+				MatchError(ContainSubstring("failed to update the status of 1 resource(s)")),
+			)
+		})
+	})
 })
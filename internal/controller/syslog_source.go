@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"net/netip"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller/index"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/syslog"
+)
+
+// podSourceResolver resolves the NGINX Pod that sent a syslog message from the IP addresses of the nginx Pods
+// that the control plane provisioned.
+type podSourceResolver struct {
+	k8sClient client.Reader
+	// selector selects the nginx Pods that the control plane provisioned.
+	selector labels.Selector
+}
+
+// newPodSourceResolver creates a new podSourceResolver for the nginx Pods of the control plane instance
+// and the GatewayClass.
+func newPodSourceResolver(k8sClient client.Reader, instanceName, gatewayClassName string) *podSourceResolver {
+	return &podSourceResolver{
+		k8sClient: k8sClient,
+		selector: labels.SelectorFromSet(labels.Set{
+			controller.AppInstanceLabel:  instanceName,
+			controller.AppManagedByLabel: controller.CreateNginxResourceName(instanceName, gatewayClassName),
+		}),
+	}
+}
+
+// ResolveSource returns the nginx Pod with the IP address. The address is not resolved if the Pods with the address
+// belong to different Gateways, for example, the Pods on the host network of a Node.
+func (r *podSourceResolver) ResolveSource(ctx context.Context, addr netip.Addr) (syslog.Source, bool) {
+	var pods apiv1.PodList
+	if err := r.k8sClient.List(
+		ctx,
+		&pods,
+		client.MatchingFields{index.PodIPIndexField: addr.String()},
+		client.MatchingLabelsSelector{Selector: r.selector},
+	); err != nil {
+		return syslog.Source{}, false
+	}
+
+	var source syslog.Source
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != apiv1.PodRunning {
+			continue
+		}
+
+		// the name of the Gateway is in an annotation if it is too long for a label.
+		gatewayName := pod.Labels[controller.GatewayLabel]
+		if gatewayName == "" {
+			gatewayName = pod.Annotations[controller.GatewayLabel]
+		}
+
+		if gatewayName == "" || pod.Labels[controller.AppNameLabel] == "" {
+			continue
+		}
+
+		podSource := syslog.Source{
+			Gateway:    types.NamespacedName{Namespace: pod.Namespace, Name: gatewayName},
+			Deployment: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[controller.AppNameLabel]},
+		}
+
+		if source != (syslog.Source{}) && source != podSource {
+			return syslog.Source{}, false
+		}
+
+		source = podSource
+	}
+
+	return source, source != (syslog.Source{})
+}
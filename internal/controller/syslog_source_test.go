@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"net/netip"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller/index"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/syslog"
+)
+
+func TestPodSourceResolver(t *testing.T) {
+	t.Parallel()
+
+	nginxPod := func(name, ip string, modify func(pod *v1.Pod)) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      name,
+				Labels: map[string]string{
+					controller.AppInstanceLabel:  "ngf",
+					controller.AppManagedByLabel: "ngf-nginx",
+					controller.AppNameLabel:      "gateway-nginx",
+					controller.GatewayLabel:      "gateway",
+				},
+			},
+			Status: v1.PodStatus{Phase: v1.PodRunning, PodIP: ip},
+		}
+		if modify != nil {
+			modify(pod)
+		}
+
+		return pod
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithObjects(
+			nginxPod("gateway-nginx", "10.0.0.1", nil),
+			nginxPod("long-gateway-nginx", "10.0.0.2", func(pod *v1.Pod) {
+				delete(pod.Labels, controller.GatewayLabel)
+				pod.Labels[controller.AppNameLabel] = "long-gateway-nginx"
+				pod.Annotations = map[string]string{controller.GatewayLabel: "long-gateway"}
+			}),
+			nginxPod("pending-nginx", "10.0.0.3", func(pod *v1.Pod) {
+				pod.Status.Phase = v1.PodPending
+			}),
+			nginxPod("other-instance-nginx", "10.0.0.4", func(pod *v1.Pod) {
+				pod.Labels[controller.AppInstanceLabel] = "other"
+			}),
+			nginxPod("host-network-nginx", "10.0.1.1", nil),
+			nginxPod("host-network-other-nginx", "10.0.1.1", func(pod *v1.Pod) {
+				pod.Labels[controller.AppNameLabel] = "other-nginx"
+				pod.Labels[controller.GatewayLabel] = "other"
+			}),
+			&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "app"},
+				Status:     v1.PodStatus{Phase: v1.PodRunning, PodIP: "10.0.0.5"},
+			},
+		).
+		WithIndex(&v1.Pod{}, index.PodIPIndexField, index.PodIPIndexFunc).
+		Build()
+
+	resolver := newPodSourceResolver(k8sClient, "ngf", "nginx")
+
+	tests := []struct {
+		name      string
+		addr      string
+		expSource syslog.Source
+		expOK     bool
+	}{
+		{
+			name: "nginx pod",
+			addr: "10.0.0.1",
+			expSource: syslog.Source{
+				Gateway:    types.NamespacedName{Namespace: "test", Name: "gateway"},
+				Deployment: types.NamespacedName{Namespace: "test", Name: "gateway-nginx"},
+			},
+			expOK: true,
+		},
+		{
+			name: "nginx pod of a gateway with a long name",
+			addr: "10.0.0.2",
+			expSource: syslog.Source{
+				Gateway:    types.NamespacedName{Namespace: "test", Name: "long-gateway"},
+				Deployment: types.NamespacedName{Namespace: "test", Name: "long-gateway-nginx"},
+			},
+			expOK: true,
+		},
+		{
+			name: "nginx pod that isn't running",
+			addr: "10.0.0.3",
+		},
+		{
+			name: "nginx pod of another control plane instance",
+			addr: "10.0.0.4",
+		},
+		{
+			name: "pods of different gateways with the same address",
+			addr: "10.0.1.1",
+		},
+		{
+			name: "pod that isn't an nginx pod",
+			addr: "10.0.0.5",
+		},
+		{
+			name: "unknown address",
+			addr: "10.0.0.6",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			source, ok := resolver.ResolveSource(t.Context(), netip.MustParseAddr(test.addr))
+			g.Expect(ok).To(Equal(test.expOK))
+			g.Expect(source).To(Equal(test.expSource))
+		})
+	}
+}
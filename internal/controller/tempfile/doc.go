@@ -0,0 +1,12 @@
+/*
+Package tempfile counts the writes of NGINX to its temporary files, so that the operators see when the request bodies
+and the responses of the backends spill from the memory buffers of NGINX to the disk, which silently adds latency
+on small or slow ephemeral volumes.
+
+A Gateway opts into the reporting of the writes with the tempFiles.reportWrites field of its NginxProxy. NGINX logs
+every write to a temporary file as a warning in its error log, and sends the warnings to the syslog Receiver of the
+control plane, with a syslog tag that identifies the Gateway. The Handler passes every write to a MetricsCollector,
+which exposes the counters of every Gateway as Prometheus metrics. Every replica of the control plane counts
+the writes that it receives, so the counters of the replicas must be summed.
+*/
+package tempfile
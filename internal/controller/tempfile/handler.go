@@ -0,0 +1,121 @@
+package tempfile
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/go-logr/logr"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/syslog"
+)
+
+//go:generate go tool counterfeiter -generate
+
+// SyslogTagPrefix is the prefix of the syslog tags of the messages in which NGINX reports the writes
+// to its temporary files. The prefix is followed by the hash of the Gateway, because a syslog tag is limited
+// to 32 characters.
+const SyslogTagPrefix = "ngf_temp_"
+
+const (
+	// KindRequestBody is the kind of the writes of the request bodies to the temporary files.
+	KindRequestBody = "request_body"
+	// KindUpstreamResponse is the kind of the writes of the responses of the backends to the temporary files.
+	KindUpstreamResponse = "upstream_response"
+)
+
+var (
+	// requestBodyWarning is the warning that NGINX logs when it writes a request body to a temporary file.
+	requestBodyWarning = []byte("a client request body is buffered to a temporary file")
+	// upstreamResponseWarning is the warning that NGINX logs when it writes a response of a backend
+	// to a temporary file.
+	upstreamResponseWarning = []byte("an upstream response is buffered to a temporary file")
+)
+
+//counterfeiter:generate . MetricsCollector
+
+// MetricsCollector collects the metrics of the writes to the temporary files.
+type MetricsCollector interface {
+	// ObserveTempFileWrite records a write of the kind to a temporary file of the NGINX of the Gateway.
+	ObserveTempFileWrite(gateway, kind string)
+}
+
+// Handler handles the syslog messages in which NGINX reports the writes to its temporary files,
+// and passes them to the MetricsCollector.
+type Handler struct {
+	collector MetricsCollector
+	// gateways maps the syslog tags to the Gateways.
+	gateways *syslog.Tags[string]
+	logger   logr.Logger
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(logger logr.Logger, collector MetricsCollector) *Handler {
+	return &Handler{
+		collector: collector,
+		gateways:  syslog.NewTags[string](SyslogTagPrefix),
+		logger:    logger,
+	}
+}
+
+// Register registers the Gateway, so that the writes that its NGINX reports are attributed to it,
+// and returns the syslog tag with which its NGINX must report the writes.
+func (h *Handler) Register(gateway string) string {
+	return h.gateways.Register(gateway, gateway)
+}
+
+// SyslogTag returns the syslog tag with which the NGINX of the Gateway reports the writes to its temporary files.
+func SyslogTag(gateway string) string {
+	return syslog.HashedTag(SyslogTagPrefix, gateway)
+}
+
+// Handle passes the write to a temporary file that NGINX reports in the syslog message to the MetricsCollector.
+// The message is ignored if it doesn't come from a Pod of the Gateway of its tag.
+func (h *Handler) Handle(source syslog.Source, msg []byte) {
+	tag, kind, err := ParseMessage(msg)
+	if err != nil {
+		h.logger.V(1).Info("Ignoring the syslog message", "error", err.Error())
+		return
+	}
+
+	gateway, exists := h.gateways.Lookup(tag)
+	if !exists {
+		h.logger.V(1).Info("Ignoring the syslog message of an unknown Gateway", "tag", tag)
+		return
+	}
+
+	if gateway != source.Gateway.String() {
+		h.logger.V(1).Info(
+			"Ignoring the syslog message of another Gateway",
+			"gateway", gateway,
+			"source", source.Gateway.String(),
+		)
+		return
+	}
+
+	h.collector.ObserveTempFileWrite(gateway, kind)
+}
+
+// ParseMessage parses the syslog tag and the kind of a write to a temporary file from a syslog message
+// of the error log of NGINX, for example:
+//
+//	<164>Oct 17 10:00:00 ngf_temp_6c62272e07bb0142: 2026/10/17 10:00:00 [warn] 21#21: *3 a client request body
+//	is buffered to a temporary file /var/cache/nginx/client_temp/0000000001, client: 10.0.0.1, ...
+//
+// It returns an error if the message is not a warning about a write to a temporary file.
+func ParseMessage(msg []byte) (tag, kind string, err error) {
+	tag, payload, err := syslog.CutTag(msg, SyslogTagPrefix)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case bytes.Contains(payload, requestBodyWarning):
+		kind = KindRequestBody
+	case bytes.Contains(payload, upstreamResponseWarning):
+		kind = KindUpstreamResponse
+	default:
+		return "", "", errors.New("message is not a write to a temporary file")
+	}
+
+	return tag, kind, nil
+}
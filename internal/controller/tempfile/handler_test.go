@@ -0,0 +1,130 @@
+package tempfile
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/syslog"
+)
+
+// write is a write to a temporary file of the NGINX of a Gateway.
+type write struct {
+	gateway string
+	kind    string
+}
+
+// fakeCollector is a MetricsCollector that records the writes. The counterfeiter fake can't be used
+// in the tests of this package because it imports this package.
+type fakeCollector struct {
+	writes []write
+}
+
+func (f *fakeCollector) ObserveTempFileWrite(gateway, kind string) {
+	f.writes = append(f.writes, write{gateway: gateway, kind: kind})
+}
+
+func TestSyslogTag(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tag := SyslogTag("test/gateway")
+	g.Expect(tag).To(HavePrefix(SyslogTagPrefix))
+	g.Expect(tag).To(MatchRegexp(`^[a-z0-9_]+$`))
+	// NGINX limits the syslog tags to 32 characters
+	g.Expect(len(tag)).To(BeNumerically("<=", 32))
+
+	g.Expect(SyslogTag("test/gateway")).To(Equal(tag))
+	g.Expect(SyslogTag("test/other-gateway")).ToNot(Equal(tag))
+}
+
+func TestParseMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		msg     string
+		expTag  string
+		expKind string
+		expErr  bool
+	}{
+		{
+			name: "request body",
+			msg: "<164>Oct 17 10:00:00 ngf_temp_6c62272e07bb0142: 2026/10/17 10:00:00 [warn] 21#21: *3 " +
+				"a client request body is buffered to a temporary file /var/cache/nginx/client_temp/0000000001, " +
+				`client: 10.0.0.1, server: cafe.example.com, request: "POST /upload HTTP/1.1", host: "cafe.example.com"`,
+			expTag:  "ngf_temp_6c62272e07bb0142",
+			expKind: KindRequestBody,
+		},
+		{
+			name: "upstream response",
+			msg: "<164>Oct 17 10:00:00 ngf_temp_6c62272e07bb0142: 2026/10/17 10:00:00 [warn] 21#21: *5 " +
+				"an upstream response is buffered to a temporary file /var/cache/nginx/proxy_temp/1/00/0000000001 " +
+				`while reading upstream, client: 10.0.0.1, server: cafe.example.com` + "\n",
+			expTag:  "ngf_temp_6c62272e07bb0142",
+			expKind: KindUpstreamResponse,
+		},
+		{
+			name: "other warning",
+			msg: "<164>Oct 17 10:00:00 ngf_temp_6c62272e07bb0142: 2026/10/17 10:00:00 [warn] 21#21: " +
+				"upstream server temporarily disabled",
+			expErr: true,
+		},
+		{
+			name: "message without the tag",
+			msg: "<164>Oct 17 10:00:00 nginx: 2026/10/17 10:00:00 [warn] 21#21: *3 " +
+				"a client request body is buffered to a temporary file /var/cache/nginx/client_temp/0000000001",
+			expErr: true,
+		},
+		{
+			name:   "message without a payload",
+			msg:    "<164>Oct 17 10:00:00 ngf_temp_6c62272e07bb0142",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			tag, kind, err := ParseMessage([]byte(test.msg))
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(tag).To(Equal(test.expTag))
+			g.Expect(kind).To(Equal(test.expKind))
+		})
+	}
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	collector := &fakeCollector{}
+	handler := NewHandler(logr.Discard(), collector)
+
+	tag := handler.Register("test/gateway")
+	g.Expect(tag).To(Equal(SyslogTag("test/gateway")))
+
+	handler.Register("test/other")
+
+	source := syslog.Source{Gateway: types.NamespacedName{Namespace: "test", Name: "gateway"}}
+	otherSource := syslog.Source{Gateway: types.NamespacedName{Namespace: "test", Name: "other"}}
+
+	handler.Handle(source, []byte("<164>Oct 17 10:00:00 "+SyslogTag("test/unknown")+": 2026/10/17 10:00:00 [warn] "+
+		"21#21: *5 an upstream response is buffered to a temporary file /var/cache/nginx/proxy_temp/1/00/0000000001"))
+	handler.Handle(otherSource, []byte("<164>Oct 17 10:00:00 "+tag+": 2026/10/17 10:00:00 [warn] 21#21: *5 "+
+		"an upstream response is buffered to a temporary file /var/cache/nginx/proxy_temp/1/00/0000000001"))
+	handler.Handle(source, []byte("<164>Oct 17 10:00:00 "+tag+": 2026/10/17 10:00:00 [warn] 21#21: *5 "+
+		"an upstream response is buffered to a temporary file /var/cache/nginx/proxy_temp/1/00/0000000001"))
+
+	// the writes of the unknown Gateways and the writes reported by the Pods of other Gateways are ignored
+	g.Expect(collector.writes).To(Equal([]write{{gateway: "test/gateway", kind: KindUpstreamResponse}}))
+}
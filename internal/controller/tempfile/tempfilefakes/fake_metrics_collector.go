@@ -0,0 +1,76 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package tempfilefakes
+
+import (
+	"sync"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tempfile"
+)
+
+type FakeMetricsCollector struct {
+	ObserveTempFileWriteStub        func(string, string)
+	observeTempFileWriteMutex       sync.RWMutex
+	observeTempFileWriteArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMetricsCollector) ObserveTempFileWrite(arg1 string, arg2 string) {
+	fake.observeTempFileWriteMutex.Lock()
+	fake.observeTempFileWriteArgsForCall = append(fake.observeTempFileWriteArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ObserveTempFileWriteStub
+	fake.recordInvocation("ObserveTempFileWrite", []interface{}{arg1, arg2})
+	fake.observeTempFileWriteMutex.Unlock()
+	if stub != nil {
+		fake.ObserveTempFileWriteStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeMetricsCollector) ObserveTempFileWriteCallCount() int {
+	fake.observeTempFileWriteMutex.RLock()
+	defer fake.observeTempFileWriteMutex.RUnlock()
+	return len(fake.observeTempFileWriteArgsForCall)
+}
+
+func (fake *FakeMetricsCollector) ObserveTempFileWriteCalls(stub func(string, string)) {
+	fake.observeTempFileWriteMutex.Lock()
+	defer fake.observeTempFileWriteMutex.Unlock()
+	fake.ObserveTempFileWriteStub = stub
+}
+
+func (fake *FakeMetricsCollector) ObserveTempFileWriteArgsForCall(i int) (string, string) {
+	fake.observeTempFileWriteMutex.RLock()
+	defer fake.observeTempFileWriteMutex.RUnlock()
+	argsForCall := fake.observeTempFileWriteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMetricsCollector) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMetricsCollector) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ tempfile.MetricsCollector = new(FakeMetricsCollector)
@@ -0,0 +1,15 @@
+/*
+Package tenant counts the requests and bytes of the tenants of the Gateways, so that the platform teams can bill
+the internal tenants for the usage of the Gateways.
+
+A Gateway opts into the attribution of its requests to tenants with the tenantAttribution field of its NginxProxy.
+NGINX attributes every request to the tenant in the configured request header, if it is one of the configured
+tenants, or, otherwise, to the namespace of the route of the request, and reports the request with its route, status,
+size, and the size of its response to the syslog Receiver of the control plane. The Receiver accepts only
+the messages of the nginx Pods, and the Handler attributes every request to the Gateway of the Pod that reported it,
+so that other Pods can't report requests for any Gateway. The Handler passes every request
+to a MetricsCollector, which exposes the counters of every tenant as Prometheus metrics, and optionally
+to a UsageRecorder, which summarizes the usage of every Gateway. Every replica of the control plane counts
+the requests that it receives, so the counters of the replicas must be summed.
+*/
+package tenant
@@ -0,0 +1,119 @@
+package tenant
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/syslog"
+)
+
+//go:generate go tool counterfeiter -generate
+
+// SyslogTag is the tag of the syslog messages in which NGINX reports the requests of the tenants.
+const SyslogTag = "ngf_tenant"
+
+// UnattributedTenant is the tenant of the requests that are not attributed to any tenant, for example,
+// the requests that don't match any route.
+const UnattributedTenant = "unattributed"
+
+//counterfeiter:generate . MetricsCollector
+
+// MetricsCollector collects the metrics of the requests of the tenants.
+type MetricsCollector interface {
+	// ObserveRequest records a request of the tenant of the Gateway, with the size of the request
+	// and the size of its response in bytes.
+	ObserveRequest(gateway, tenant string, requestBytes, responseBytes int64)
+}
+
+//counterfeiter:generate . UsageRecorder
+
+// UsageRecorder records the requests of the Gateways for their usage summaries.
+type UsageRecorder interface {
+	// Record records the request.
+	Record(req Request)
+}
+
+// Request is a request that NGINX attributed to a tenant.
+type Request struct {
+	// Gateway is the namespace and name of the Gateway that handled the request. It is the Gateway of the NGINX Pod
+	// that reported the request, so that the clients that can send syslog messages can't report the requests
+	// of other Gateways.
+	Gateway string `json:"-"`
+	// Tenant is the tenant of the request.
+	Tenant string `json:"tenant"`
+	// Route is the namespace and name of the route that handled the request. Empty if no route handled the request.
+	Route string `json:"route"`
+	// Status is the status code of the response.
+	Status int `json:"status"`
+	// RequestLength is the size of the request in bytes, including the request line, headers, and body.
+	RequestLength int64 `json:"request_length"`
+	// BytesSent is the size of the response in bytes.
+	BytesSent int64 `json:"bytes_sent"`
+}
+
+// Handler handles the syslog messages in which NGINX reports the requests of the tenants,
+// and passes them to the MetricsCollector and the UsageRecorder.
+type Handler struct {
+	collector MetricsCollector
+	recorder  UsageRecorder
+	logger    logr.Logger
+}
+
+// NewHandler creates a new Handler. If the recorder is nil, the requests are not recorded
+// for the usage summaries.
+func NewHandler(logger logr.Logger, collector MetricsCollector, recorder UsageRecorder) *Handler {
+	return &Handler{
+		collector: collector,
+		recorder:  recorder,
+		logger:    logger,
+	}
+}
+
+// Handle passes the request of a tenant that NGINX reports in the syslog message to the MetricsCollector
+// and the UsageRecorder. The request is attributed to the Gateway of the NGINX Pod that sent the message.
+func (h *Handler) Handle(source syslog.Source, msg []byte) {
+	req, err := ParseMessage(msg)
+	if err != nil {
+		h.logger.V(1).Info("Ignoring the syslog message", "error", err.Error())
+		return
+	}
+
+	req.Gateway = source.Gateway.String()
+
+	h.collector.ObserveRequest(req.Gateway, req.Tenant, req.RequestLength, req.BytesSent)
+
+	if h.recorder != nil {
+		h.recorder.Record(req)
+	}
+}
+
+// ParseMessage parses the request of a tenant from a syslog message of NGINX, for example:
+//
+//	<190>Oct 17 10:00:00 ngf_tenant: {"tenant":"team-a","route":"team-a/route","status":200,...}
+//
+// The requests without a tenant are attributed to the UnattributedTenant.
+func ParseMessage(msg []byte) (Request, error) {
+	tag, payload, err := syslog.CutTag(msg, SyslogTag)
+	if err != nil {
+		return Request{}, err
+	}
+
+	if tag != SyslogTag {
+		return Request{}, errors.New("message doesn't have the " + SyslogTag + " tag")
+	}
+
+	var req Request
+	if err := json.Unmarshal(bytes.TrimSpace(payload), &req); err != nil {
+		return Request{}, fmt.Errorf("failed to unmarshal the request: %w", err)
+	}
+
+	if req.Tenant == "" {
+		req.Tenant = UnattributedTenant
+	}
+
+	return req, nil
+}
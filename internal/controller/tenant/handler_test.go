@@ -0,0 +1,149 @@
+package tenant
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/syslog"
+)
+
+var testSource = syslog.Source{Gateway: types.NamespacedName{Namespace: "test", Name: "gateway"}}
+
+// fakeCollector is a MetricsCollector and a UsageRecorder that records the requests. The counterfeiter fakes
+// can't be used in the tests of this package because they import this package.
+type fakeCollector struct {
+	requests []Request
+	recorded []Request
+}
+
+func (f *fakeCollector) ObserveRequest(gateway, tenant string, requestBytes, responseBytes int64) {
+	f.requests = append(f.requests, Request{
+		Gateway:       gateway,
+		Tenant:        tenant,
+		RequestLength: requestBytes,
+		BytesSent:     responseBytes,
+	})
+}
+
+func (f *fakeCollector) Record(req Request) {
+	f.recorded = append(f.recorded, req)
+}
+
+func TestParseMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		msg      string
+		expected Request
+		expErr   bool
+	}{
+		{
+			name: "request of a tenant",
+			msg: `<190>Oct 17 10:00:00 ngf_tenant: {"tenant":"team-a",` +
+				`"route":"team-a/route","status":200,"request_length":120,"bytes_sent":2048}`,
+			expected: Request{
+				Tenant:        "team-a",
+				Route:         "team-a/route",
+				Status:        200,
+				RequestLength: 120,
+				BytesSent:     2048,
+			},
+		},
+		{
+			name: "request without a tenant",
+			msg: `<190>Oct 17 10:00:00 ngf_tenant: {"tenant":"","route":"",` +
+				`"status":404,"request_length":80,"bytes_sent":153}` + "\n",
+			expected: Request{
+				Tenant:        UnattributedTenant,
+				Status:        404,
+				RequestLength: 80,
+				BytesSent:     153,
+			},
+		},
+		{
+			name:   "message without the tag",
+			msg:    `<190>Oct 17 10:00:00 nginx: {"tenant":"team-a"}`,
+			expErr: true,
+		},
+		{
+			name:   "invalid JSON",
+			msg:    `<190>Oct 17 10:00:00 ngf_tenant: {"tenant":`,
+			expErr: true,
+		},
+		{
+			name: "gateway in the request is ignored",
+			msg: `<190>Oct 17 10:00:00 ngf_tenant: {"gateway":"other/gateway","tenant":"team-a",` +
+				`"request_length":1,"bytes_sent":1}`,
+			expected: Request{
+				Tenant:        "team-a",
+				RequestLength: 1,
+				BytesSent:     1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			req, err := ParseMessage([]byte(test.msg))
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(req).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	collector := &fakeCollector{}
+	handler := NewHandler(logr.Discard(), collector, collector)
+
+	handler.Handle(testSource, []byte(`<190>Oct 17 10:00:00 nginx: {"tenant":"team-a"}`))
+	handler.Handle(testSource, []byte(`<190>Oct 17 10:00:00 ngf_tenant: {"gateway":"other/gateway","tenant":"team-a",`+
+		`"route":"team-a/route","status":503,"request_length":120,"bytes_sent":2048}`))
+
+	g.Expect(collector.requests).To(Equal([]Request{
+		{
+			Gateway:       "test/gateway",
+			Tenant:        "team-a",
+			RequestLength: 120,
+			BytesSent:     2048,
+		},
+	}))
+	g.Expect(collector.recorded).To(Equal([]Request{
+		{
+			Gateway:       "test/gateway",
+			Tenant:        "team-a",
+			Route:         "team-a/route",
+			Status:        503,
+			RequestLength: 120,
+			BytesSent:     2048,
+		},
+	}))
+}
+
+func TestHandler_HandleWithoutRecorder(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	collector := &fakeCollector{}
+	handler := NewHandler(logr.Discard(), collector, nil)
+
+	handler.Handle(testSource, []byte(`<190>Oct 17 10:00:00 ngf_tenant: {"tenant":"team-a",`+
+		`"route":"team-a/route","status":200,"request_length":120,"bytes_sent":2048}`))
+
+	g.Expect(collector.requests).To(HaveLen(1))
+	g.Expect(collector.recorded).To(BeEmpty())
+}
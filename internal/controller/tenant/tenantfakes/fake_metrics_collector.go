@@ -0,0 +1,80 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package tenantfakes
+
+import (
+	"sync"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tenant"
+)
+
+type FakeMetricsCollector struct {
+	ObserveRequestStub        func(string, string, int64, int64)
+	observeRequestMutex       sync.RWMutex
+	observeRequestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 int64
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMetricsCollector) ObserveRequest(arg1 string, arg2 string, arg3 int64, arg4 int64) {
+	fake.observeRequestMutex.Lock()
+	fake.observeRequestArgsForCall = append(fake.observeRequestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 int64
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ObserveRequestStub
+	fake.recordInvocation("ObserveRequest", []interface{}{arg1, arg2, arg3, arg4})
+	fake.observeRequestMutex.Unlock()
+	if stub != nil {
+		fake.ObserveRequestStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *FakeMetricsCollector) ObserveRequestCallCount() int {
+	fake.observeRequestMutex.RLock()
+	defer fake.observeRequestMutex.RUnlock()
+	return len(fake.observeRequestArgsForCall)
+}
+
+func (fake *FakeMetricsCollector) ObserveRequestCalls(stub func(string, string, int64, int64)) {
+	fake.observeRequestMutex.Lock()
+	defer fake.observeRequestMutex.Unlock()
+	fake.ObserveRequestStub = stub
+}
+
+func (fake *FakeMetricsCollector) ObserveRequestArgsForCall(i int) (string, string, int64, int64) {
+	fake.observeRequestMutex.RLock()
+	defer fake.observeRequestMutex.RUnlock()
+	argsForCall := fake.observeRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeMetricsCollector) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMetricsCollector) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ tenant.MetricsCollector = new(FakeMetricsCollector)
@@ -0,0 +1,74 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package tenantfakes
+
+import (
+	"sync"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tenant"
+)
+
+type FakeUsageRecorder struct {
+	RecordStub        func(tenant.Request)
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		arg1 tenant.Request
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUsageRecorder) Record(arg1 tenant.Request) {
+	fake.recordMutex.Lock()
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		arg1 tenant.Request
+	}{arg1})
+	stub := fake.RecordStub
+	fake.recordInvocation("Record", []interface{}{arg1})
+	fake.recordMutex.Unlock()
+	if stub != nil {
+		fake.RecordStub(arg1)
+	}
+}
+
+func (fake *FakeUsageRecorder) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeUsageRecorder) RecordCalls(stub func(tenant.Request)) {
+	fake.recordMutex.Lock()
+	defer fake.recordMutex.Unlock()
+	fake.RecordStub = stub
+}
+
+func (fake *FakeUsageRecorder) RecordArgsForCall(i int) tenant.Request {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	argsForCall := fake.recordArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUsageRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeUsageRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ tenant.UsageRecorder = new(FakeUsageRecorder)
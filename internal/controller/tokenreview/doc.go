@@ -0,0 +1,18 @@
+/*
+Package tokenreview authenticates the requests to the Routes whose ClientSettingsPolicy requires the clients
+to authenticate with the token of a Kubernetes ServiceAccount, so that the internal Routes don't need
+a separate authentication proxy.
+
+NGINX checks every such request with an auth_request subrequest to the Verifier of the control plane. The path
+of the subrequest identifies the ClientSettingsPolicy, and the subrequest carries the Authorization header
+of the request. The subrequest is sent over TLS: NGINX verifies the certificate of the Verifier and authenticates
+with the client certificate of the agent, which are both signed by the CA of the agent connections, so that
+the tokens are not sent in plaintext and only NGINX can check them. The Verifier reviews the bearer token
+with the Kubernetes TokenReview API, for the audiences of the policy, and checks that the token belongs
+to a ServiceAccount that the policy allows. It responds with 200 (OK) if the request is allowed, 401 (Unauthorized)
+if the request doesn't authenticate, and 403 (Forbidden) if the client isn't allowed. NGINX passes the 401 and 403
+responses to the client, and rejects the request with 500 (Internal Server Error) if the Verifier fails.
+
+The reviews are cached for a short time, because NGINX can check a request in several locations.
+*/
+package tokenreview
@@ -0,0 +1,291 @@
+package tokenreview
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	authv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+)
+
+const (
+	// cacheTTL is the time for which a review of a token is reused.
+	cacheTTL = 10 * time.Second
+	// maxCacheSize is the maximum number of the cached reviews. The expired reviews are removed when the cache
+	// is full, and the cache is cleared if none of the reviews expired.
+	maxCacheSize = 10000
+	// reviewTimeout is the timeout of a TokenReview. It is shorter than the proxy_read_timeout of the subrequest,
+	// so that NGINX receives the response of the Verifier.
+	reviewTimeout = 4 * time.Second
+	// serviceAccountUsernamePrefix is the prefix of the usernames of the ServiceAccounts,
+	// system:serviceaccount:<namespace>:<name>.
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+)
+
+// review is the result of a TokenReview.
+type review struct {
+	expires       time.Time
+	username      string
+	err           string
+	authenticated bool
+}
+
+// TLSFiles are the paths to the files of the TLS configuration of the Verifier.
+type TLSFiles struct {
+	// CACert is the path to the CA certificate that the client certificates of NGINX must be signed by.
+	CACert string
+	// Cert is the path to the certificate of the Verifier.
+	Cert string
+	// Key is the path to the private key of the Verifier.
+	Key string
+}
+
+// Verifier verifies the bearer tokens of the requests that NGINX sends to it in auth_request subrequests.
+// The path of a subrequest is /<namespace>/<name> of the ClientSettingsPolicy that requires the token.
+// The Verifier serves over TLS and requires the client certificate of NGINX, so that only NGINX can check
+// the tokens, and the tokens are not sent in plaintext.
+type Verifier struct {
+	k8sClient client.Client
+	cache     map[string]review
+	now       func() time.Time
+	logger    logr.Logger
+	tlsFiles  TLSFiles
+	address   string
+	lock      sync.Mutex
+}
+
+// NewVerifier creates a new Verifier that listens on the address. The client gets the ClientSettingsPolicies
+// and creates the TokenReviews.
+func NewVerifier(logger logr.Logger, address string, tlsFiles TLSFiles, k8sClient client.Client) *Verifier {
+	return &Verifier{
+		k8sClient: k8sClient,
+		cache:     make(map[string]review),
+		now:       time.Now,
+		logger:    logger,
+		tlsFiles:  tlsFiles,
+		address:   address,
+	}
+}
+
+// Start starts the Verifier. It blocks until the context is canceled.
+func (v *Verifier) Start(ctx context.Context) error {
+	tlsConfig, err := v.tlsConfig()
+	if err != nil {
+		return fmt.Errorf("failed to configure TLS: %w", err)
+	}
+
+	var lc net.ListenConfig
+	tcpListener, err := lc.Listen(ctx, "tcp", v.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", v.address, err)
+	}
+
+	listener := tls.NewListener(tcpListener, tlsConfig)
+
+	server := &http.Server{
+		Handler:           v.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			v.logger.Error(err, "failed to close the server")
+		}
+	}()
+
+	v.logger.Info("Verifying the bearer tokens of the requests", "address", listener.Addr().String())
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+
+	return nil
+}
+
+// tlsConfig returns the TLS configuration of the server, which requires the client certificates signed by the CA.
+// The certificate is loaded for every connection, so that the rotated certificate is used without a restart.
+func (v *Verifier) tlsConfig() (*tls.Config, error) {
+	caPEM, err := os.ReadFile(v.tlsFiles.CACert)
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("error parsing CA PEM")
+	}
+
+	return &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(v.tlsFiles.Cert, v.tlsFiles.Key)
+			return &cert, err
+		},
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  certPool,
+		MinVersion: tls.VersionTLS13,
+	}, nil
+}
+
+// Handler returns the handler of the subrequests of NGINX.
+func (v *Verifier) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		policy := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+
+		code, err := v.verify(r.Context(), policy, r.Header.Get("Authorization"))
+		if err != nil {
+			v.logger.V(1).Info("Rejecting the request", "policy", policy.String(), "code", code, "reason", err.Error())
+		}
+
+		if code == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+		}
+
+		w.WriteHeader(code)
+	})
+
+	return mux
+}
+
+// verify returns the status code of the response to the subrequest for the policy, and the reason
+// if the request is rejected.
+func (v *Verifier) verify(ctx context.Context, policy types.NamespacedName, authorization string) (int, error) {
+	var csp ngfAPI.ClientSettingsPolicy
+	if err := v.k8sClient.Get(ctx, policy, &csp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return http.StatusForbidden, fmt.Errorf("policy %s doesn't exist", policy)
+		}
+
+		return http.StatusInternalServerError, fmt.Errorf("failed to get the policy %s: %w", policy, err)
+	}
+
+	auth := csp.Spec.Authentication
+	if auth == nil || auth.Mode != ngfAPI.ClientAuthenticationModeTokenReview {
+		return http.StatusForbidden, fmt.Errorf("policy %s doesn't authenticate the clients with a token", policy)
+	}
+
+	token, ok := bearerToken(authorization)
+	if !ok {
+		return http.StatusUnauthorized, errors.New("request doesn't have a bearer token")
+	}
+
+	var audiences []string
+	var serviceAccounts []ngfAPI.ServiceAccountName
+	if auth.TokenReview != nil {
+		audiences = auth.TokenReview.Audiences
+		serviceAccounts = auth.TokenReview.ServiceAccounts
+	}
+
+	r, err := v.review(ctx, token, audiences)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if !r.authenticated {
+		return http.StatusUnauthorized, fmt.Errorf("token is not valid: %s", r.err)
+	}
+
+	serviceAccount, ok := strings.CutPrefix(r.username, serviceAccountUsernamePrefix)
+	if !ok {
+		return http.StatusForbidden, fmt.Errorf("user %s is not a ServiceAccount", r.username)
+	}
+
+	// the name of the ServiceAccount can't contain a colon, so the first colon separates the namespace.
+	serviceAccount = strings.Replace(serviceAccount, ":", "/", 1)
+	if len(serviceAccounts) > 0 && !slices.Contains(serviceAccounts, ngfAPI.ServiceAccountName(serviceAccount)) {
+		return http.StatusForbidden, fmt.Errorf("ServiceAccount %s is not allowed", serviceAccount)
+	}
+
+	return http.StatusOK, nil
+}
+
+// review reviews the token for the audiences with the TokenReview API, or returns the cached review.
+func (v *Verifier) review(ctx context.Context, token string, audiences []string) (review, error) {
+	sum := sha256.Sum256([]byte(token + "\n" + strings.Join(audiences, "\n")))
+	key := hex.EncodeToString(sum[:])
+
+	v.lock.Lock()
+	cached, exists := v.cache[key]
+	v.lock.Unlock()
+
+	if exists && v.now().Before(cached.expires) {
+		return cached, nil
+	}
+
+	tokenReview := &authv1.TokenReview{
+		Spec: authv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: audiences,
+		},
+	}
+
+	createCtx, cancel := context.WithTimeout(ctx, reviewTimeout)
+	defer cancel()
+
+	if err := v.k8sClient.Create(createCtx, tokenReview); err != nil {
+		return review{}, fmt.Errorf("error creating TokenReview: %w", err)
+	}
+
+	r := review{
+		expires:       v.now().Add(cacheTTL),
+		username:      tokenReview.Status.User.Username,
+		err:           tokenReview.Status.Error,
+		authenticated: tokenReview.Status.Authenticated,
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if len(v.cache) >= maxCacheSize {
+		v.removeExpired()
+	}
+
+	v.cache[key] = r
+
+	return r, nil
+}
+
+// removeExpired removes the expired reviews from the cache, or clears the cache if none of the reviews expired.
+// The caller must hold the lock.
+func (v *Verifier) removeExpired() {
+	now := v.now()
+	for key, r := range v.cache {
+		if !now.Before(r.expires) {
+			delete(v.cache, key)
+		}
+	}
+
+	if len(v.cache) >= maxCacheSize {
+		clear(v.cache)
+	}
+}
+
+// bearerToken returns the token of the Authorization header with the Bearer scheme.
+func bearerToken(authorization string) (string, bool) {
+	scheme, token, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
@@ -0,0 +1,500 @@
+package tokenreview
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+)
+
+// reviewer reviews the tokens in the TokenReviews that the fake client creates.
+type reviewer struct {
+	err       error
+	usernames map[string]string
+	audiences [][]string
+	calls     int
+}
+
+func (r *reviewer) create(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+	r.calls++
+
+	if r.err != nil {
+		return r.err
+	}
+
+	tr, ok := obj.(*authv1.TokenReview)
+	if !ok {
+		return errors.New("unexpected object")
+	}
+
+	r.audiences = append(r.audiences, tr.Spec.Audiences)
+
+	username, exists := r.usernames[tr.Spec.Token]
+	if !exists {
+		tr.Status.Error = "invalid token"
+		return nil
+	}
+
+	tr.Status.Authenticated = true
+	tr.Status.User.Username = username
+
+	return nil
+}
+
+func newTestVerifier(t *testing.T, r *reviewer, objs ...client.Object) *Verifier {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := ngfAPI.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{Create: r.create}).
+		Build()
+
+	return NewVerifier(logr.Discard(), ":0", TLSFiles{}, k8sClient)
+}
+
+func createPolicy(name string, auth *ngfAPI.ClientAuthentication) *ngfAPI.ClientSettingsPolicy {
+	return &ngfAPI.ClientSettingsPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      name,
+		},
+		Spec: ngfAPI.ClientSettingsPolicySpec{
+			Authentication: auth,
+		},
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	t.Parallel()
+
+	objs := []client.Object{
+		createPolicy("any", &ngfAPI.ClientAuthentication{
+			Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+		}),
+		createPolicy("restricted", &ngfAPI.ClientAuthentication{
+			Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+			TokenReview: &ngfAPI.TokenReviewAuthentication{
+				Audiences:       []string{"internal-api"},
+				ServiceAccounts: []ngfAPI.ServiceAccountName{"default/reporting"},
+			},
+		}),
+		createPolicy("no-authentication", nil),
+	}
+
+	usernames := map[string]string{
+		"reporting-token": "system:serviceaccount:default:reporting",
+		"other-token":     "system:serviceaccount:default:other",
+		"user-token":      "alice",
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		expCode       int
+	}{
+		{
+			name:          "valid token of any ServiceAccount",
+			path:          "/test/any",
+			authorization: "Bearer other-token",
+			expCode:       http.StatusOK,
+		},
+		{
+			name:          "valid token of an allowed ServiceAccount",
+			path:          "/test/restricted",
+			authorization: "Bearer reporting-token",
+			expCode:       http.StatusOK,
+		},
+		{
+			name:          "scheme is case-insensitive",
+			path:          "/test/any",
+			authorization: "bearer reporting-token",
+			expCode:       http.StatusOK,
+		},
+		{
+			name:          "valid token of a ServiceAccount that isn't allowed",
+			path:          "/test/restricted",
+			authorization: "Bearer other-token",
+			expCode:       http.StatusForbidden,
+		},
+		{
+			name:          "valid token of a user",
+			path:          "/test/any",
+			authorization: "Bearer user-token",
+			expCode:       http.StatusForbidden,
+		},
+		{
+			name:          "invalid token",
+			path:          "/test/any",
+			authorization: "Bearer invalid-token",
+			expCode:       http.StatusUnauthorized,
+		},
+		{
+			name:    "no token",
+			path:    "/test/any",
+			expCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "not a bearer token",
+			path:          "/test/any",
+			authorization: "Basic dXNlcjpwYXNz",
+			expCode:       http.StatusUnauthorized,
+		},
+		{
+			name:          "policy doesn't exist",
+			path:          "/test/missing",
+			authorization: "Bearer reporting-token",
+			expCode:       http.StatusForbidden,
+		},
+		{
+			name:          "policy doesn't authenticate the clients",
+			path:          "/test/no-authentication",
+			authorization: "Bearer reporting-token",
+			expCode:       http.StatusForbidden,
+		},
+		{
+			name:          "unknown path",
+			path:          "/test",
+			authorization: "Bearer reporting-token",
+			expCode:       http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			v := newTestVerifier(t, &reviewer{usernames: usernames}, objs...)
+
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			v.Handler().ServeHTTP(rec, req)
+
+			g.Expect(rec.Code).To(Equal(test.expCode))
+			if test.expCode == http.StatusUnauthorized {
+				g.Expect(rec.Header().Get("WWW-Authenticate")).To(Equal("Bearer"))
+			}
+		})
+	}
+}
+
+func TestVerifier_Audiences(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	r := &reviewer{usernames: map[string]string{"token": "system:serviceaccount:default:reporting"}}
+	v := newTestVerifier(t, r, createPolicy("restricted", &ngfAPI.ClientAuthentication{
+		Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+		TokenReview: &ngfAPI.TokenReviewAuthentication{
+			Audiences: []string{"internal-api", "reports"},
+		},
+	}))
+
+	code, err := v.verify(context.Background(), policyName("restricted"), "Bearer token")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(code).To(Equal(http.StatusOK))
+	g.Expect(r.audiences).To(Equal([][]string{{"internal-api", "reports"}}))
+}
+
+func TestVerifier_ReviewError(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	r := &reviewer{err: errors.New("API server is unavailable")}
+	v := newTestVerifier(t, r, createPolicy("any", &ngfAPI.ClientAuthentication{
+		Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+	}))
+
+	code, err := v.verify(context.Background(), policyName("any"), "Bearer token")
+	g.Expect(err).To(MatchError(ContainSubstring("API server is unavailable")))
+	g.Expect(code).To(Equal(http.StatusInternalServerError))
+
+	// the failed reviews are not cached
+	_, _ = v.verify(context.Background(), policyName("any"), "Bearer token")
+	g.Expect(r.calls).To(Equal(2))
+}
+
+func TestVerifier_Cache(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	r := &reviewer{usernames: map[string]string{"token": "system:serviceaccount:default:reporting"}}
+	v := newTestVerifier(t, r, createPolicy("any", &ngfAPI.ClientAuthentication{
+		Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+	}))
+
+	now := time.Now()
+	v.now = func() time.Time { return now }
+
+	for range 3 {
+		code, err := v.verify(context.Background(), policyName("any"), "Bearer token")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(code).To(Equal(http.StatusOK))
+	}
+	g.Expect(r.calls).To(Equal(1))
+
+	// an invalid token is also cached
+	code, _ := v.verify(context.Background(), policyName("any"), "Bearer invalid")
+	g.Expect(code).To(Equal(http.StatusUnauthorized))
+	_, _ = v.verify(context.Background(), policyName("any"), "Bearer invalid")
+	g.Expect(r.calls).To(Equal(2))
+
+	// the review expires
+	now = now.Add(cacheTTL)
+	_, _ = v.verify(context.Background(), policyName("any"), "Bearer token")
+	g.Expect(r.calls).To(Equal(3))
+}
+
+func TestVerifier_RemoveExpired(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	v := NewVerifier(logr.Discard(), ":0", TLSFiles{}, nil)
+
+	now := time.Now()
+	v.now = func() time.Time { return now }
+
+	v.cache["expired"] = review{expires: now}
+	v.cache["valid"] = review{expires: now.Add(time.Second)}
+
+	v.removeExpired()
+	g.Expect(v.cache).To(HaveKey("valid"))
+	g.Expect(v.cache).ToNot(HaveKey("expired"))
+}
+
+func TestVerifier_Start(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	address := getFreeTCPAddress(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(ngfAPI.AddToScheme(scheme)).To(Succeed())
+
+	r := &reviewer{usernames: map[string]string{"token": "system:serviceaccount:default:reporting"}}
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(createPolicy("any", &ngfAPI.ClientAuthentication{
+			Mode: ngfAPI.ClientAuthenticationModeTokenReview,
+		})).
+		WithInterceptorFuncs(interceptor.Funcs{Create: r.create}).
+		Build()
+
+	certs := generateTestCerts(t)
+	v := NewVerifier(logr.Discard(), address, certs.serverFiles, k8sClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- v.Start(ctx)
+	}()
+
+	newClient := func(certificates []tls.Certificate) *http.Client {
+		return &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					Certificates: certificates,
+					RootCAs:      certs.caPool,
+					MinVersion:   tls.VersionTLS13,
+				},
+			},
+		}
+	}
+
+	get := func(httpClient *http.Client) (int, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+address+"/test/any", nil)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Authorization", "Bearer token")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+
+		return resp.StatusCode, nil
+	}
+
+	// the requests sent before the verifier listens are refused, so they are sent until one is answered
+	g.Eventually(func() (int, error) {
+		return get(newClient([]tls.Certificate{certs.client}))
+	}).WithTimeout(5 * time.Second).WithPolling(50 * time.Millisecond).Should(Equal(http.StatusOK))
+
+	// the clients without a certificate signed by the CA are rejected
+	_, err := get(newClient(nil))
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = get(newClient([]tls.Certificate{certs.untrustedClient}))
+	g.Expect(err).To(HaveOccurred())
+
+	cancel()
+	g.Eventually(errCh).WithTimeout(5 * time.Second).Should(Receive(BeNil()))
+}
+
+func TestVerifier_StartError(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	v := NewVerifier(logr.Discard(), "invalid-address", generateTestCerts(t).serverFiles, nil)
+
+	g.Expect(v.Start(context.Background())).To(MatchError(ContainSubstring("failed to listen")))
+}
+
+func TestVerifier_StartTLSError(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	v := NewVerifier(logr.Discard(), ":0", TLSFiles{CACert: filepath.Join(t.TempDir(), "ca.crt")}, nil)
+
+	g.Expect(v.Start(context.Background())).To(MatchError(ContainSubstring("failed to configure TLS")))
+}
+
+// testCerts are the certificates of the tests of the TLS server.
+type testCerts struct {
+	caPool          *x509.CertPool
+	serverFiles     TLSFiles
+	client          tls.Certificate
+	untrustedClient tls.Certificate
+}
+
+// generateTestCerts generates a CA, the certificate of the server for 127.0.0.1 in files, and the certificates
+// of a client that is signed by the CA and of a client that isn't.
+func generateTestCerts(t *testing.T) testCerts {
+	t.Helper()
+	g := NewWithT(t)
+
+	newKey := func() *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		g.Expect(err).ToNot(HaveOccurred())
+		return key
+	}
+
+	newTemplate := func(serial int64) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "test"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		}
+	}
+
+	newCA := func(serial int64) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+		key := newKey()
+		template := newTemplate(serial)
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		cert, err := x509.ParseCertificate(der)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+
+	newCert := func(serial int64, ca *x509.Certificate, caKey *ecdsa.PrivateKey) ([]byte, []byte) {
+		key := newKey()
+		template := newTemplate(serial)
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	ca, caKey, caPEM := newCA(1)
+	untrustedCA, untrustedCAKey, _ := newCA(2)
+
+	serverCert, serverKey := newCert(3, ca, caKey)
+	clientCert, clientKey := newCert(4, ca, caKey)
+	untrustedCert, untrustedKey := newCert(5, untrustedCA, untrustedCAKey)
+
+	dir := t.TempDir()
+	files := TLSFiles{
+		CACert: filepath.Join(dir, "ca.crt"),
+		Cert:   filepath.Join(dir, "tls.crt"),
+		Key:    filepath.Join(dir, "tls.key"),
+	}
+	g.Expect(os.WriteFile(files.CACert, caPEM, 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(files.Cert, serverCert, 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(files.Key, serverKey, 0o600)).To(Succeed())
+
+	client, err := tls.X509KeyPair(clientCert, clientKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	untrustedClient, err := tls.X509KeyPair(untrustedCert, untrustedKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caPool := x509.NewCertPool()
+	caPool.AddCert(ca)
+
+	return testCerts{
+		caPool:          caPool,
+		serverFiles:     files,
+		client:          client,
+		untrustedClient: untrustedClient,
+	}
+}
+
+func getFreeTCPAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to get a free TCP port: %v", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func policyName(name string) types.NamespacedName {
+	return types.NamespacedName{Namespace: "test", Name: name}
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"maps"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/trafficrouting"
+)
+
+// setAppliedTrafficRoutingSteps records the traffic routing steps of the Routes of the Gateway, once its
+// configuration is applied to the data plane. The steps of the Routes, whose weights are held by the canary
+// analysis, are not applied, so the progressive delivery controllers don't proceed with the rollout.
+func (h *eventHandlerImpl) setAppliedTrafficRoutingSteps(
+	gr *graph.Graph,
+	gateway *graph.Gateway,
+	weightOverrides map[types.NamespacedName]dataplane.BackendWeights,
+) {
+	if gateway == nil || gateway.Source == nil {
+		return
+	}
+
+	gwNsName := client.ObjectKeyFromObject(gateway.Source)
+
+	steps := trafficrouting.RouteSteps(gr, gwNsName)
+	for route := range weightOverrides {
+		delete(steps, route)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.appliedTrafficRoutingSteps[gwNsName] = steps
+}
+
+// getAppliedTrafficRoutingSteps returns the traffic routing steps of the Routes that are applied to the data plane
+// of each Gateway.
+func (h *eventHandlerImpl) getAppliedTrafficRoutingSteps() map[types.NamespacedName]trafficrouting.Steps {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return maps.Clone(h.appliedTrafficRoutingSteps)
+}
@@ -0,0 +1,18 @@
+/*
+Package trafficrouting implements the contract between NGINX Gateway Fabric and the progressive delivery
+controllers, such as Flagger and Argo Rollouts, that drive the weighted rollouts of HTTPRoutes and GRPCRoutes.
+
+A progressive delivery controller changes the weights of the backendRefs of a Route, and adds or removes the rules
+that route the requests with specific headers to the canary, in a single update of the Route, so that both are
+applied to the data plane in the same reload of NGINX. In the same update, the controller sets the
+gateway.nginx.org/traffic-routing-step annotation to an identifier of the step of the rollout, for example,
+"canary-weight-20". The value is opaque to NGINX Gateway Fabric.
+
+For every parent Gateway of a Route with the annotation, NGINX Gateway Fabric reports the
+TrafficRoutingStepApplied condition in the status of the Route. The condition is True with the StepApplied reason
+once the configuration with the step is applied to the data plane of the Gateway, and False with the StepPending
+reason otherwise, for example, while the configuration is being applied, when it failed to apply, or when the
+weights of the Route are held by the canary analysis. The message of the condition includes the step, so that the
+controller verifies that its latest step, rather than a previous one, is applied before it proceeds.
+*/
+package trafficrouting
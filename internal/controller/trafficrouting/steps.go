@@ -0,0 +1,53 @@
+package trafficrouting
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+)
+
+// StepAnnotation is the annotation of a Route with the identifier of the step of the rollout, set by
+// a progressive delivery controller together with the weights and the header routes of the step.
+const StepAnnotation = "gateway.nginx.org/traffic-routing-step"
+
+// Steps are the traffic routing steps of Routes, keyed by the NamespacedName of the Route.
+type Steps map[types.NamespacedName]string
+
+// RouteSteps returns the traffic routing steps of the valid Routes that are attached to the Gateway.
+func RouteSteps(gr *graph.Graph, gateway types.NamespacedName) Steps {
+	if gr == nil {
+		return nil
+	}
+
+	steps := make(Steps)
+
+	for _, route := range gr.Routes {
+		if route.Source == nil || !route.Valid {
+			continue
+		}
+
+		step := route.Source.GetAnnotations()[StepAnnotation]
+		if step == "" || !attachedToGateway(route.ParentRefs, gateway) {
+			continue
+		}
+
+		steps[client.ObjectKeyFromObject(route.Source)] = step
+	}
+
+	return steps
+}
+
+func attachedToGateway(refs []graph.ParentRef, gateway types.NamespacedName) bool {
+	for _, ref := range refs {
+		if ref.Gateway == nil || ref.Gateway.NamespacedName != gateway {
+			continue
+		}
+
+		if ref.Attachment != nil && ref.Attachment.Attached {
+			return true
+		}
+	}
+
+	return false
+}
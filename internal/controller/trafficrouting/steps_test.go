@@ -0,0 +1,90 @@
+package trafficrouting
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+)
+
+func TestRouteSteps(t *testing.T) {
+	t.Parallel()
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	otherGwNsName := types.NamespacedName{Namespace: "test", Name: "other"}
+
+	createRoute := func(name, step string, valid, attached bool, gateway types.NamespacedName) *graph.L7Route {
+		annotations := map[string]string{}
+		if step != "" {
+			annotations[StepAnnotation] = step
+		}
+
+		return &graph.L7Route{
+			Source: &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name, Annotations: annotations},
+			},
+			RouteType: graph.RouteTypeHTTP,
+			Valid:     valid,
+			ParentRefs: []graph.ParentRef{
+				{
+					Gateway:    &graph.ParentRefGateway{NamespacedName: gateway},
+					Attachment: &graph.ParentRefAttachmentStatus{Attached: attached},
+				},
+			},
+		}
+	}
+
+	routes := []*graph.L7Route{
+		createRoute("canary", "canary-weight-20", true, true, gwNsName),
+		createRoute("no-step", "", true, true, gwNsName),
+		createRoute("invalid", "canary-weight-20", false, true, gwNsName),
+		createRoute("not-attached", "canary-weight-20", true, false, gwNsName),
+		createRoute("other-gateway", "canary-weight-40", true, true, otherGwNsName),
+	}
+
+	gr := &graph.Graph{Routes: make(map[graph.RouteKey]*graph.L7Route)}
+	for _, route := range routes {
+		gr.Routes[graph.CreateRouteKey(route.Source)] = route
+	}
+
+	tests := []struct {
+		gr       *graph.Graph
+		expSteps Steps
+		name     string
+		gateway  types.NamespacedName
+	}{
+		{
+			name:    "steps of the Routes attached to the Gateway",
+			gr:      gr,
+			gateway: gwNsName,
+			expSteps: Steps{
+				{Namespace: "test", Name: "canary"}: "canary-weight-20",
+			},
+		},
+		{
+			name:    "steps of the Routes attached to another Gateway",
+			gr:      gr,
+			gateway: otherGwNsName,
+			expSteps: Steps{
+				{Namespace: "test", Name: "other-gateway"}: "canary-weight-40",
+			},
+		},
+		{
+			name:    "nil graph",
+			gateway: gwNsName,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(RouteSteps(test.gr, test.gateway)).To(Equal(test.expSteps))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package upstreammap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+const (
+	// MappingKey is the key of the data of the ConfigMap with the Mapping in the JSON format.
+	MappingKey = "upstream-map.json"
+
+	configMapNameSuffix = "upstream-map"
+)
+
+// Publisher publishes the Mapping of a Gateway.
+type Publisher interface {
+	// Publish publishes the Mapping of the Gateway. The Mapping replaces any previously published Mapping
+	// of the Gateway.
+	Publish(ctx context.Context, gateway *gatewayv1.Gateway, mapping Mapping) error
+}
+
+// ConfigMapPublisher publishes the Mapping of a Gateway to the ConfigMap <gateway-name>-upstream-map
+// in the namespace of the Gateway. The ConfigMap is owned by the Gateway, so it is deleted together with the Gateway.
+type ConfigMapPublisher struct {
+	k8sClient client.Client
+}
+
+// NewConfigMapPublisher creates a new ConfigMapPublisher.
+func NewConfigMapPublisher(k8sClient client.Client) *ConfigMapPublisher {
+	return &ConfigMapPublisher{
+		k8sClient: k8sClient,
+	}
+}
+
+// Publish creates or updates the ConfigMap with the Mapping of the Gateway.
+func (p *ConfigMapPublisher) Publish(ctx context.Context, gateway *gatewayv1.Gateway, mapping Mapping) error {
+	mappingJSON, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal upstream mapping: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(gateway.GetName()),
+			Namespace: gateway.GetNamespace(),
+		},
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, p.k8sClient, cm, func() error {
+		cm.BinaryData = nil
+		cm.Data = map[string]string{
+			MappingKey: string(mappingJSON),
+		}
+
+		if cm.Labels == nil {
+			cm.Labels = make(map[string]string)
+		}
+		cm.Labels[controller.GatewayLabel] = gateway.GetName()
+
+		cm.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: gatewayv1.GroupVersion.String(),
+				Kind:       kinds.Gateway,
+				Name:       gateway.GetName(),
+				UID:        gateway.GetUID(),
+			},
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to publish upstream mapping to ConfigMap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+
+	return nil
+}
+
+// ConfigMapName returns the name of the ConfigMap that the Mapping of the Gateway is published to.
+func ConfigMapName(gatewayName string) string {
+	return controller.CreateNginxResourceName(gatewayName, configMapNameSuffix)
+}
@@ -0,0 +1,98 @@
+package upstreammap
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+func TestConfigMapPublisher(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	existingCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway-upstream-map"},
+		Data:       map[string]string{"stale": "stale"},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingCM).Build()
+	publisher := NewConfigMapPublisher(fakeClient)
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway", UID: "uid"},
+	}
+
+	mapping := Mapping{
+		Gateway: ObjectRef{Namespace: "test", Name: "gateway"},
+		Routes: []Route{
+			{
+				ObjectRef: ObjectRef{Kind: kinds.HTTPRoute, Namespace: "test", Name: "hr"},
+				Upstreams: []string{"test_foo_80"},
+			},
+		},
+		Upstreams: []Upstream{
+			{
+				Name:      "test_foo_80",
+				Zone:      "test_foo_80",
+				Protocol:  ProtocolHTTP,
+				Backend:   ObjectRef{Kind: kinds.Service, Namespace: "test", Name: "foo"},
+				Port:      80,
+				Endpoints: []string{"10.0.0.1:8080"},
+			},
+		},
+	}
+
+	g.Expect(publisher.Publish(context.Background(), gateway, mapping)).To(Succeed())
+
+	var cm corev1.ConfigMap
+	key := client.ObjectKey{Namespace: "test", Name: "gateway-upstream-map"}
+	g.Expect(fakeClient.Get(context.Background(), key, &cm)).To(Succeed())
+
+	g.Expect(cm.Data).To(HaveLen(1))
+	g.Expect(cm.Labels).To(HaveKeyWithValue(controller.GatewayLabel, "gateway"))
+	g.Expect(cm.OwnerReferences).To(Equal([]metav1.OwnerReference{
+		{
+			APIVersion: "gateway.networking.k8s.io/v1",
+			Kind:       kinds.Gateway,
+			Name:       "gateway",
+			UID:        "uid",
+		},
+	}))
+
+	var published Mapping
+	g.Expect(json.Unmarshal([]byte(cm.Data[MappingKey]), &published)).To(Succeed())
+	g.Expect(published).To(Equal(mapping))
+
+	g.Expect(cm.Data[MappingKey]).To(ContainSubstring(`"kind": "HTTPRoute"`))
+	g.Expect(cm.Data[MappingKey]).To(ContainSubstring(`"zone": "test_foo_80"`))
+}
+
+func TestConfigMapPublisherError(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	// the scheme doesn't have ConfigMaps, so the client fails
+	fakeClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	publisher := NewConfigMapPublisher(fakeClient)
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"},
+	}
+
+	err := publisher.Publish(context.Background(), gateway, Mapping{})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to publish upstream mapping to ConfigMap")))
+}
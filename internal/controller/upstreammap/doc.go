@@ -0,0 +1,8 @@
+/*
+Package upstreammap publishes a machine-readable mapping of the Routes of a Gateway to the NGINX upstreams
+and their shared memory zones, and of the upstreams to the Services or Backends and their endpoints.
+
+External tooling, such as dashboards and consumers of the NGINX Plus API, can use the mapping to correlate
+the NGINX upstream statistics with the Kubernetes objects they were generated from.
+*/
+package upstreammap
@@ -0,0 +1,231 @@
+package upstreammap
+
+import (
+	"cmp"
+	"net"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+// Protocol is the protocol of an NGINX upstream, which determines where the NGINX Plus API reports its statistics.
+type Protocol string
+
+const (
+	// ProtocolHTTP is the protocol of the upstreams in the http context.
+	// The NGINX Plus API reports them under /http/upstreams.
+	ProtocolHTTP Protocol = "http"
+	// ProtocolStream is the protocol of the upstreams in the stream context.
+	// The NGINX Plus API reports them under /stream/upstreams.
+	ProtocolStream Protocol = "stream"
+)
+
+// Mapping maps the Routes of a Gateway to the NGINX upstreams, and the upstreams to the Kubernetes objects
+// they were generated from.
+type Mapping struct {
+	// Gateway is the Gateway that the mapping belongs to.
+	Gateway ObjectRef `json:"gateway"`
+	// Routes are the Routes attached to the Gateway, sorted by kind, namespace and name.
+	Routes []Route `json:"routes"`
+	// Upstreams are the upstreams that the Routes reference, sorted by name.
+	Upstreams []Upstream `json:"upstreams"`
+}
+
+// ObjectRef references a Kubernetes object.
+type ObjectRef struct {
+	// Kind is the kind of the object. Empty for the Gateway.
+	Kind string `json:"kind,omitempty"`
+	// Namespace is the namespace of the object.
+	Namespace string `json:"namespace"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+}
+
+// Route is a Route attached to the Gateway.
+type Route struct {
+	ObjectRef
+	// Upstreams are the names of the upstreams that the Route references, sorted.
+	Upstreams []string `json:"upstreams"`
+}
+
+// Upstream is an NGINX upstream.
+type Upstream struct {
+	// Backend is the Service or the Backend that the upstream was generated from.
+	Backend ObjectRef `json:"backend"`
+	// Name is the name of the upstream.
+	Name string `json:"name"`
+	// Zone is the name of the shared memory zone of the upstream, under which NGINX Plus reports its statistics.
+	Zone string `json:"zone"`
+	// Protocol is the protocol of the upstream.
+	Protocol Protocol `json:"protocol"`
+	// Endpoints are the addresses of the endpoints of the upstream in the host:port format, sorted.
+	Endpoints []string `json:"endpoints"`
+	// Port is the port of the Service. Zero for a Backend.
+	Port int32 `json:"port,omitempty"`
+}
+
+// Build builds the Mapping of the Gateway from the graph and the dataplane configuration of the Gateway.
+// Only the Routes that are attached to the Gateway and the valid backend references are included.
+func Build(gr *graph.Graph, gw *graph.Gateway, conf dataplane.Configuration) Mapping {
+	gwNsName := client.ObjectKeyFromObject(gw.Source)
+
+	mapping := Mapping{
+		Gateway: ObjectRef{
+			Namespace: gwNsName.Namespace,
+			Name:      gwNsName.Name,
+		},
+		Routes:    []Route{},
+		Upstreams: []Upstream{},
+	}
+
+	endpoints := make(map[string][]string, len(conf.Upstreams)+len(conf.StreamUpstreams))
+	for _, u := range conf.Upstreams {
+		endpoints[u.Name] = formatEndpoints(u)
+	}
+	for _, u := range conf.StreamUpstreams {
+		endpoints[u.Name] = formatEndpoints(u)
+	}
+
+	upstreams := make(map[string]Upstream)
+
+	addUpstream := func(br graph.BackendRef, protocol Protocol) string {
+		name := br.ServicePortReference()
+		if name == "" {
+			return ""
+		}
+
+		if _, exists := upstreams[name]; exists {
+			return name
+		}
+
+		u := Upstream{
+			Name:      name,
+			Zone:      name,
+			Protocol:  protocol,
+			Endpoints: endpoints[name],
+			Backend: ObjectRef{
+				Kind:      kinds.Service,
+				Namespace: br.SvcNsName.Namespace,
+				Name:      br.SvcNsName.Name,
+			},
+		}
+		if u.Endpoints == nil {
+			u.Endpoints = []string{}
+		}
+
+		if br.IsStaticBackend() {
+			u.Backend.Kind = kinds.Backend
+		} else {
+			u.Port = br.ServicePort.Port
+		}
+
+		upstreams[name] = u
+
+		return name
+	}
+
+	for _, r := range gr.Routes {
+		if !attachedToGateway(r.ParentRefs, gwNsName) {
+			continue
+		}
+
+		route := newRoute(r.Source, kindForRouteType(r.RouteType))
+		for _, rule := range r.Spec.Rules {
+			for _, br := range rule.BackendRefs {
+				if name := addUpstream(br, ProtocolHTTP); name != "" {
+					route.Upstreams = append(route.Upstreams, name)
+				}
+			}
+		}
+
+		mapping.Routes = append(mapping.Routes, compactRoute(route))
+	}
+
+	for _, r := range gr.L4Routes {
+		if !attachedToGateway(r.ParentRefs, gwNsName) {
+			continue
+		}
+
+		route := newRoute(r.Source, kinds.TLSRoute)
+		if name := addUpstream(r.Spec.BackendRef, ProtocolStream); name != "" {
+			route.Upstreams = append(route.Upstreams, name)
+		}
+
+		mapping.Routes = append(mapping.Routes, compactRoute(route))
+	}
+
+	for _, u := range upstreams {
+		mapping.Upstreams = append(mapping.Upstreams, u)
+	}
+
+	slices.SortFunc(mapping.Routes, func(a, b Route) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	slices.SortFunc(mapping.Upstreams, func(a, b Upstream) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return mapping
+}
+
+func attachedToGateway(parentRefs []graph.ParentRef, gwNsName types.NamespacedName) bool {
+	for _, ref := range parentRefs {
+		if ref.Gateway != nil &&
+			ref.Gateway.NamespacedName == gwNsName &&
+			ref.Attachment != nil &&
+			ref.Attachment.Attached {
+			return true
+		}
+	}
+
+	return false
+}
+
+func kindForRouteType(routeType graph.RouteType) string {
+	if routeType == graph.RouteTypeGRPC {
+		return kinds.GRPCRoute
+	}
+
+	return kinds.HTTPRoute
+}
+
+func newRoute(source client.Object, kind string) Route {
+	return Route{
+		ObjectRef: ObjectRef{
+			Kind:      kind,
+			Namespace: source.GetNamespace(),
+			Name:      source.GetName(),
+		},
+		Upstreams: []string{},
+	}
+}
+
+// compactRoute sorts the upstreams of the Route and removes the duplicates, because the rules of a Route
+// can reference the same upstream.
+func compactRoute(route Route) Route {
+	slices.Sort(route.Upstreams)
+	route.Upstreams = slices.Compact(route.Upstreams)
+
+	return route
+}
+
+func formatEndpoints(u dataplane.Upstream) []string {
+	formatted := make([]string, 0, len(u.Endpoints))
+	for _, ep := range u.Endpoints {
+		formatted = append(formatted, net.JoinHostPort(ep.Address, strconv.Itoa(int(ep.Port))))
+	}
+
+	slices.Sort(formatted)
+
+	return formatted
+}
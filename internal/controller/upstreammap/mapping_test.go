@@ -0,0 +1,235 @@
+package upstreammap
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	ngfAPIv1alpha1 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/dataplane"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/resolver"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	otherGwNsName := types.NamespacedName{Namespace: "test", Name: "other-gateway"}
+
+	gw := &graph.Gateway{
+		Source: &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: gwNsName.Namespace, Name: gwNsName.Name},
+		},
+	}
+
+	attachedTo := func(nsName types.NamespacedName, attached bool) []graph.ParentRef {
+		return []graph.ParentRef{
+			{
+				Gateway:    &graph.ParentRefGateway{NamespacedName: nsName},
+				Attachment: &graph.ParentRefAttachmentStatus{Attached: attached},
+			},
+		}
+	}
+
+	svcBackendRef := func(name string, port int32) graph.BackendRef {
+		return graph.BackendRef{
+			SvcNsName:   types.NamespacedName{Namespace: "test", Name: name},
+			ServicePort: v1.ServicePort{Port: port},
+			Valid:       true,
+		}
+	}
+
+	staticBackendRef := graph.BackendRef{
+		SvcNsName: types.NamespacedName{Namespace: "test", Name: "static"},
+		StaticEndpoints: []ngfAPIv1alpha1.BackendEndpoint{
+			{Address: "10.0.0.10", Port: 8080},
+		},
+		Valid: true,
+	}
+
+	invalidBackendRef := svcBackendRef("invalid", 80)
+	invalidBackendRef.Valid = false
+
+	gr := &graph.Graph{
+		Routes: map[graph.RouteKey]*graph.L7Route{
+			{RouteType: graph.RouteTypeHTTP, NamespacedName: types.NamespacedName{Namespace: "test", Name: "hr"}}: {
+				Source: &gatewayv1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"},
+				},
+				RouteType:  graph.RouteTypeHTTP,
+				ParentRefs: attachedTo(gwNsName, true),
+				Spec: graph.L7RouteSpec{
+					Rules: []graph.RouteRule{
+						{
+							BackendRefs: []graph.BackendRef{svcBackendRef("foo", 80), svcBackendRef("bar", 8080)},
+						},
+						{
+							BackendRefs: []graph.BackendRef{svcBackendRef("foo", 80), invalidBackendRef, staticBackendRef},
+						},
+					},
+				},
+			},
+			{RouteType: graph.RouteTypeGRPC, NamespacedName: types.NamespacedName{Namespace: "test", Name: "gr"}}: {
+				Source: &gatewayv1.GRPCRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gr"},
+				},
+				RouteType:  graph.RouteTypeGRPC,
+				ParentRefs: attachedTo(gwNsName, true),
+				Spec: graph.L7RouteSpec{
+					Rules: []graph.RouteRule{
+						{BackendRefs: []graph.BackendRef{svcBackendRef("grpc", 9000)}},
+					},
+				},
+			},
+			{RouteType: graph.RouteTypeHTTP, NamespacedName: types.NamespacedName{Namespace: "test", Name: "detached"}}: {
+				Source: &gatewayv1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "detached"},
+				},
+				RouteType:  graph.RouteTypeHTTP,
+				ParentRefs: attachedTo(gwNsName, false),
+				Spec: graph.L7RouteSpec{
+					Rules: []graph.RouteRule{
+						{BackendRefs: []graph.BackendRef{svcBackendRef("detached", 80)}},
+					},
+				},
+			},
+			{RouteType: graph.RouteTypeHTTP, NamespacedName: types.NamespacedName{Namespace: "test", Name: "other"}}: {
+				Source: &gatewayv1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "other"},
+				},
+				RouteType:  graph.RouteTypeHTTP,
+				ParentRefs: attachedTo(otherGwNsName, true),
+				Spec: graph.L7RouteSpec{
+					Rules: []graph.RouteRule{
+						{BackendRefs: []graph.BackendRef{svcBackendRef("other", 80)}},
+					},
+				},
+			},
+		},
+		L4Routes: map[graph.L4RouteKey]*graph.L4Route{
+			{NamespacedName: types.NamespacedName{Namespace: "test", Name: "tls"}}: {
+				Source: &v1alpha2.TLSRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "tls"},
+				},
+				ParentRefs: attachedTo(gwNsName, true),
+				Spec: graph.L4RouteSpec{
+					BackendRef: svcBackendRef("tls", 443),
+				},
+			},
+		},
+	}
+
+	conf := dataplane.Configuration{
+		Upstreams: []dataplane.Upstream{
+			{
+				Name: "test_foo_80",
+				Endpoints: []resolver.Endpoint{
+					{Address: "10.0.0.2", Port: 8080},
+					{Address: "10.0.0.1", Port: 8080},
+				},
+			},
+			{
+				Name:      "test_bar_8080",
+				Endpoints: []resolver.Endpoint{{Address: "fd00::1", Port: 8080, IPv6: true}},
+			},
+			{
+				Name:      "test_static_backend",
+				Endpoints: []resolver.Endpoint{{Address: "10.0.0.10", Port: 8080}},
+			},
+		},
+		StreamUpstreams: []dataplane.Upstream{
+			{
+				Name:      "test_tls_443",
+				Endpoints: []resolver.Endpoint{{Address: "10.0.0.3", Port: 8443}},
+			},
+		},
+	}
+
+	expected := Mapping{
+		Gateway: ObjectRef{Namespace: "test", Name: "gateway"},
+		Routes: []Route{
+			{
+				ObjectRef: ObjectRef{Kind: kinds.GRPCRoute, Namespace: "test", Name: "gr"},
+				Upstreams: []string{"test_grpc_9000"},
+			},
+			{
+				ObjectRef: ObjectRef{Kind: kinds.HTTPRoute, Namespace: "test", Name: "hr"},
+				Upstreams: []string{"test_bar_8080", "test_foo_80", "test_static_backend"},
+			},
+			{
+				ObjectRef: ObjectRef{Kind: kinds.TLSRoute, Namespace: "test", Name: "tls"},
+				Upstreams: []string{"test_tls_443"},
+			},
+		},
+		Upstreams: []Upstream{
+			{
+				Name:      "test_bar_8080",
+				Zone:      "test_bar_8080",
+				Protocol:  ProtocolHTTP,
+				Backend:   ObjectRef{Kind: kinds.Service, Namespace: "test", Name: "bar"},
+				Port:      8080,
+				Endpoints: []string{"[fd00::1]:8080"},
+			},
+			{
+				Name:      "test_foo_80",
+				Zone:      "test_foo_80",
+				Protocol:  ProtocolHTTP,
+				Backend:   ObjectRef{Kind: kinds.Service, Namespace: "test", Name: "foo"},
+				Port:      80,
+				Endpoints: []string{"10.0.0.1:8080", "10.0.0.2:8080"},
+			},
+			{
+				Name:      "test_grpc_9000",
+				Zone:      "test_grpc_9000",
+				Protocol:  ProtocolHTTP,
+				Backend:   ObjectRef{Kind: kinds.Service, Namespace: "test", Name: "grpc"},
+				Port:      9000,
+				Endpoints: []string{},
+			},
+			{
+				Name:      "test_static_backend",
+				Zone:      "test_static_backend",
+				Protocol:  ProtocolHTTP,
+				Backend:   ObjectRef{Kind: kinds.Backend, Namespace: "test", Name: "static"},
+				Endpoints: []string{"10.0.0.10:8080"},
+			},
+			{
+				Name:      "test_tls_443",
+				Zone:      "test_tls_443",
+				Protocol:  ProtocolStream,
+				Backend:   ObjectRef{Kind: kinds.Service, Namespace: "test", Name: "tls"},
+				Port:      443,
+				Endpoints: []string{"10.0.0.3:8443"},
+			},
+		},
+	}
+
+	g.Expect(Build(gr, gw, conf)).To(Equal(expected))
+}
+
+func TestBuildEmpty(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	gw := &graph.Gateway{
+		Source: &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"},
+		},
+	}
+
+	expected := Mapping{
+		Gateway:   ObjectRef{Namespace: "test", Name: "gateway"},
+		Routes:    []Route{},
+		Upstreams: []Upstream{},
+	}
+
+	g.Expect(Build(&graph.Graph{}, gw, dataplane.Configuration{})).To(Equal(expected))
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/usagesummary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/runnables"
+)
+
+// newUsageSummaryJob creates a job that periodically summarizes the usage of the Gateways over the last period,
+// and publishes the summaries.
+// Every replica of the control plane summarizes the requests that it receives, so the job runs on every replica,
+// and the publisher merges the summaries of the replicas.
+func newUsageSummaryJob(
+	logger logr.Logger,
+	aggregator *usagesummary.Aggregator,
+	publisher usagesummary.Publisher,
+	readyCh <-chan struct{},
+	period time.Duration,
+) *runnables.LeaderOrNonLeader {
+	worker := func(ctx context.Context) {
+		for _, summary := range aggregator.Flush(time.Now()) {
+			if err := publisher.Publish(ctx, summary); err != nil {
+				logger.Error(err, "error publishing usage summary", "gateway", summary.Gateway)
+			}
+		}
+	}
+
+	return &runnables.LeaderOrNonLeader{
+		Runnable: runnables.NewCronJob(
+			runnables.CronJobConfig{
+				Worker:  worker,
+				Logger:  logger,
+				Period:  period,
+				ReadyCh: readyCh,
+			},
+		),
+	}
+}
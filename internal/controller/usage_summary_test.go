@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tenant"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/usagesummary"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/usagesummary/usagesummaryfakes"
+)
+
+func TestUsageSummaryJob(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	aggregator := usagesummary.NewAggregator(time.Now())
+	aggregator.Record(tenant.Request{Gateway: "test/gateway", Route: "test/route", Status: 200})
+	aggregator.Record(tenant.Request{Gateway: "test/other-gateway", Route: "test/route", Status: 500})
+
+	publisher := &usagesummaryfakes.FakePublisher{}
+	// a failure to publish the summary of a Gateway doesn't prevent publishing the summaries of the other Gateways
+	publisher.PublishReturnsOnCall(0, errors.New("publish error"))
+
+	readyCh := make(chan struct{})
+
+	job := newUsageSummaryJob(logr.Discard(), aggregator, publisher, readyCh, 10*time.Millisecond)
+	g.Expect(job.NeedLeaderElection()).To(BeFalse())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- job.Start(ctx)
+	}()
+
+	// the summaries are not published until the control plane is ready
+	g.Consistently(publisher.PublishCallCount).Should(BeZero())
+
+	close(readyCh)
+
+	g.Eventually(publisher.PublishCallCount).Should(Equal(2))
+
+	_, summary := publisher.PublishArgsForCall(0)
+	g.Expect(summary.Gateway).To(Equal("test/gateway"))
+	_, summary = publisher.PublishArgsForCall(1)
+	g.Expect(summary.Gateway).To(Equal("test/other-gateway"))
+
+	// the Gateways without requests in the window are not published
+	g.Consistently(publisher.PublishCallCount).Should(Equal(2))
+
+	cancel()
+	g.Eventually(errCh).Should(Receive(BeNil()))
+}
@@ -0,0 +1,216 @@
+package usagesummary
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tenant"
+)
+
+const (
+	// maxTopRoutes is the maximum number of the top routes in a Summary.
+	maxTopRoutes = 10
+	// maxRoutesPerGateway is the maximum number of the routes of a Gateway that are summarized separately.
+	// The requests of the other routes only count towards the totals of the Gateway.
+	maxRoutesPerGateway = 1000
+)
+
+// Summary is the usage of a Gateway over a window.
+type Summary struct {
+	// WindowStart is the start of the window.
+	WindowStart time.Time `json:"windowStart"`
+	// WindowEnd is the end of the window.
+	WindowEnd time.Time `json:"windowEnd"`
+	// Gateway is the namespace and name of the Gateway.
+	Gateway string `json:"gateway"`
+	// TopRoutes are the routes with the most requests, in descending order of their requests.
+	TopRoutes []RouteUsage `json:"topRoutes"`
+	// Usage is the usage of the Gateway.
+	Usage
+}
+
+// RouteUsage is the usage of a route over a window.
+type RouteUsage struct {
+	// Route is the namespace and name of the route.
+	Route string `json:"route"`
+	Usage
+}
+
+// Usage is the number of the requests and bytes over a window.
+type Usage struct {
+	// Requests is the number of the requests.
+	Requests int64 `json:"requests"`
+	// RequestBytes is the number of the bytes received in the requests.
+	RequestBytes int64 `json:"requestBytes"`
+	// ResponseBytes is the number of the bytes sent in the responses.
+	ResponseBytes int64 `json:"responseBytes"`
+	// ServerErrors is the number of the responses with a 5xx status code.
+	ServerErrors int64 `json:"serverErrors"`
+	// ErrorRate is the ratio of the ServerErrors to the Requests.
+	ErrorRate float64 `json:"errorRate"`
+}
+
+func (u *Usage) record(req tenant.Request) {
+	u.Requests++
+	u.RequestBytes += req.RequestLength
+	u.ResponseBytes += req.BytesSent
+	if req.Status >= 500 && req.Status < 600 {
+		u.ServerErrors++
+	}
+}
+
+func (u *Usage) add(other Usage) {
+	u.Requests += other.Requests
+	u.RequestBytes += other.RequestBytes
+	u.ResponseBytes += other.ResponseBytes
+	u.ServerErrors += other.ServerErrors
+}
+
+func (u *Usage) setErrorRate() {
+	if u.Requests > 0 {
+		u.ErrorRate = float64(u.ServerErrors) / float64(u.Requests)
+	}
+}
+
+type gatewayUsage struct {
+	routes map[string]*Usage
+	total  Usage
+}
+
+// Aggregator aggregates the requests of the Gateways into their usage summaries.
+// Implements the tenant.UsageRecorder interface.
+type Aggregator struct {
+	windowStart time.Time
+	gateways    map[string]*gatewayUsage
+	lock        sync.Mutex
+}
+
+// NewAggregator creates a new Aggregator with the window that starts at the time.
+func NewAggregator(windowStart time.Time) *Aggregator {
+	return &Aggregator{
+		windowStart: windowStart,
+		gateways:    make(map[string]*gatewayUsage),
+	}
+}
+
+// Record records the request in the usage of its Gateway.
+func (a *Aggregator) Record(req tenant.Request) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	gw, ok := a.gateways[req.Gateway]
+	if !ok {
+		gw = &gatewayUsage{routes: make(map[string]*Usage)}
+		a.gateways[req.Gateway] = gw
+	}
+
+	gw.total.record(req)
+
+	if req.Route == "" {
+		return
+	}
+
+	route, ok := gw.routes[req.Route]
+	if !ok {
+		if len(gw.routes) >= maxRoutesPerGateway {
+			return
+		}
+
+		route = &Usage{}
+		gw.routes[req.Route] = route
+	}
+
+	route.record(req)
+}
+
+// Flush returns the summaries of the Gateways with requests in the window that ends at the time,
+// sorted by the Gateway, and starts a new window.
+func (a *Aggregator) Flush(windowEnd time.Time) []Summary {
+	a.lock.Lock()
+	gateways := a.gateways
+	windowStart := a.windowStart
+	a.gateways = make(map[string]*gatewayUsage)
+	a.windowStart = windowEnd
+	a.lock.Unlock()
+
+	summaries := make([]Summary, 0, len(gateways))
+	for name, gw := range gateways {
+		summary := Summary{
+			WindowStart: windowStart,
+			WindowEnd:   windowEnd,
+			Gateway:     name,
+			Usage:       gw.total,
+			TopRoutes:   topRoutes(gw.routes),
+		}
+		summary.setErrorRate()
+
+		summaries = append(summaries, summary)
+	}
+
+	slices.SortFunc(summaries, func(a, b Summary) int {
+		return strings.Compare(a.Gateway, b.Gateway)
+	})
+
+	return summaries
+}
+
+// Merge returns the Summary of a Gateway that sums the summaries of the Gateway, for example the summaries
+// of the replicas of the control plane. The window of the Summary spans the windows of the summaries.
+// The top routes are merged from the top routes of the summaries, so a route only counts the requests
+// of the summaries in whose top routes it is.
+func Merge(summaries []Summary) Summary {
+	var merged Summary
+	routes := make(map[string]*Usage)
+
+	for i, summary := range summaries {
+		if i == 0 || summary.WindowStart.Before(merged.WindowStart) {
+			merged.WindowStart = summary.WindowStart
+		}
+		if summary.WindowEnd.After(merged.WindowEnd) {
+			merged.WindowEnd = summary.WindowEnd
+		}
+		merged.Gateway = summary.Gateway
+		merged.add(summary.Usage)
+
+		for _, route := range summary.TopRoutes {
+			usage, ok := routes[route.Route]
+			if !ok {
+				usage = &Usage{}
+				routes[route.Route] = usage
+			}
+
+			usage.add(route.Usage)
+		}
+	}
+
+	merged.TopRoutes = topRoutes(routes)
+	merged.setErrorRate()
+
+	return merged
+}
+
+func topRoutes(routes map[string]*Usage) []RouteUsage {
+	top := make([]RouteUsage, 0, len(routes))
+	for name, usage := range routes {
+		route := RouteUsage{Route: name, Usage: *usage}
+		route.setErrorRate()
+
+		top = append(top, route)
+	}
+
+	slices.SortFunc(top, func(a, b RouteUsage) int {
+		if c := cmp.Compare(b.Requests, a.Requests); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Route, b.Route)
+	})
+
+	if len(top) > maxTopRoutes {
+		top = top[:maxTopRoutes]
+	}
+
+	return top
+}
@@ -0,0 +1,173 @@
+package usagesummary
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/tenant"
+)
+
+func TestAggregator(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	start := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	a := NewAggregator(start)
+
+	a.Record(tenant.Request{
+		Gateway:       "test/gateway",
+		Route:         "test/coffee",
+		Status:        200,
+		RequestLength: 100,
+		BytesSent:     1000,
+	})
+	a.Record(tenant.Request{
+		Gateway:       "test/gateway",
+		Route:         "test/coffee",
+		Status:        502,
+		RequestLength: 100,
+		BytesSent:     200,
+	})
+	a.Record(tenant.Request{Gateway: "test/gateway", Route: "test/tea", Status: 200, RequestLength: 50, BytesSent: 500})
+	// requests that don't match any route only count towards the totals
+	a.Record(tenant.Request{Gateway: "test/gateway", Status: 404, RequestLength: 10, BytesSent: 20})
+	a.Record(tenant.Request{Gateway: "other/gateway", Route: "other/route", Status: 503, RequestLength: 1, BytesSent: 2})
+
+	g.Expect(a.Flush(end)).To(Equal([]Summary{
+		{
+			WindowStart: start,
+			WindowEnd:   end,
+			Gateway:     "other/gateway",
+			Usage: Usage{
+				Requests:      1,
+				RequestBytes:  1,
+				ResponseBytes: 2,
+				ServerErrors:  1,
+				ErrorRate:     1,
+			},
+			TopRoutes: []RouteUsage{
+				{
+					Route: "other/route",
+					Usage: Usage{
+						Requests:      1,
+						RequestBytes:  1,
+						ResponseBytes: 2,
+						ServerErrors:  1,
+						ErrorRate:     1,
+					},
+				},
+			},
+		},
+		{
+			WindowStart: start,
+			WindowEnd:   end,
+			Gateway:     "test/gateway",
+			Usage: Usage{
+				Requests:      4,
+				RequestBytes:  260,
+				ResponseBytes: 1720,
+				ServerErrors:  1,
+				ErrorRate:     0.25,
+			},
+			TopRoutes: []RouteUsage{
+				{
+					Route: "test/coffee",
+					Usage: Usage{
+						Requests:      2,
+						RequestBytes:  200,
+						ResponseBytes: 1200,
+						ServerErrors:  1,
+						ErrorRate:     0.5,
+					},
+				},
+				{
+					Route: "test/tea",
+					Usage: Usage{
+						Requests:      1,
+						RequestBytes:  50,
+						ResponseBytes: 500,
+					},
+				},
+			},
+		},
+	}))
+
+	// the next window starts at the end of the flushed window
+	a.Record(tenant.Request{Gateway: "test/gateway", Route: "test/tea", Status: 200})
+
+	summaries := a.Flush(end.Add(time.Hour))
+	g.Expect(summaries).To(HaveLen(1))
+	g.Expect(summaries[0].WindowStart).To(Equal(end))
+	g.Expect(summaries[0].Requests).To(Equal(int64(1)))
+
+	g.Expect(a.Flush(end.Add(2 * time.Hour))).To(BeEmpty())
+}
+
+func TestAggregator_TopRoutes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	a := NewAggregator(time.Now())
+
+	for i := range maxTopRoutes + 5 {
+		for range i + 1 {
+			a.Record(tenant.Request{Gateway: "test/gateway", Route: fmt.Sprintf("test/route%d", i), Status: 200})
+		}
+	}
+
+	summaries := a.Flush(time.Now())
+	g.Expect(summaries).To(HaveLen(1))
+
+	top := summaries[0].TopRoutes
+	g.Expect(top).To(HaveLen(maxTopRoutes))
+	g.Expect(top[0].Route).To(Equal(fmt.Sprintf("test/route%d", maxTopRoutes+4)))
+	g.Expect(top[maxTopRoutes-1].Route).To(Equal("test/route5"))
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	start := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+
+	merged := Merge([]Summary{
+		{
+			WindowStart: start.Add(time.Minute),
+			WindowEnd:   start.Add(time.Hour + time.Minute),
+			Gateway:     "test/gateway",
+			Usage:       Usage{Requests: 3, RequestBytes: 300, ResponseBytes: 3000, ServerErrors: 1},
+			TopRoutes: []RouteUsage{
+				{Route: "test/coffee", Usage: Usage{Requests: 2, RequestBytes: 200, ResponseBytes: 2000}},
+				{Route: "test/tea", Usage: Usage{Requests: 1, RequestBytes: 100, ResponseBytes: 1000, ServerErrors: 1}},
+			},
+		},
+		{
+			WindowStart: start,
+			WindowEnd:   start.Add(time.Hour),
+			Gateway:     "test/gateway",
+			Usage:       Usage{Requests: 1, RequestBytes: 100, ResponseBytes: 1000},
+			TopRoutes: []RouteUsage{
+				{Route: "test/tea", Usage: Usage{Requests: 1, RequestBytes: 100, ResponseBytes: 1000}},
+			},
+		},
+	})
+
+	g.Expect(merged).To(Equal(Summary{
+		WindowStart: start,
+		WindowEnd:   start.Add(time.Hour + time.Minute),
+		Gateway:     "test/gateway",
+		Usage:       Usage{Requests: 4, RequestBytes: 400, ResponseBytes: 4000, ServerErrors: 1, ErrorRate: 0.25},
+		TopRoutes: []RouteUsage{
+			{Route: "test/coffee", Usage: Usage{Requests: 2, RequestBytes: 200, ResponseBytes: 2000}},
+			{
+				Route: "test/tea",
+				Usage: Usage{Requests: 2, RequestBytes: 200, ResponseBytes: 2000, ServerErrors: 1, ErrorRate: 0.5},
+			},
+		},
+	}))
+}
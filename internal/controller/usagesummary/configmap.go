@@ -0,0 +1,161 @@
+package usagesummary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+const configMapNameSuffix = "usage-summary"
+
+// TotalKey is the key of the data of the ConfigMap with the Summary of all the replicas of the control plane
+// in the JSON format.
+const TotalKey = "total.json"
+
+//go:generate go tool counterfeiter -generate
+
+//counterfeiter:generate . Publisher
+
+// Publisher publishes the Summary of a Gateway.
+type Publisher interface {
+	// Publish publishes the Summary of the Gateway. The Summary replaces any previously published Summary
+	// of the Gateway by the same replica of the control plane.
+	Publish(ctx context.Context, summary Summary) error
+}
+
+// ConfigMapPublisher publishes the Summary of a Gateway to the ConfigMap <gateway-name>-usage-summary
+// in the namespace of the Gateway, under the key <replica>.json, so that the replicas of the control plane
+// don't overwrite the summaries of each other. Every replica receives a share of the requests of the Gateway,
+// so the publisher also merges its Summary with the summaries of the other replicas whose windows end
+// within half a window of the end of its window, and publishes the merged Summary under the TotalKey. The ConfigMap is owned by the Gateway,
+// so it is deleted together with the Gateway.
+type ConfigMapPublisher struct {
+	k8sClient client.Client
+	replica   string
+}
+
+// NewConfigMapPublisher creates a new ConfigMapPublisher for the replica of the control plane.
+func NewConfigMapPublisher(k8sClient client.Client, replica string) *ConfigMapPublisher {
+	return &ConfigMapPublisher{
+		k8sClient: k8sClient,
+		replica:   replica,
+	}
+}
+
+// Publish creates or updates the ConfigMap with the Summary of the Gateway and the merged Summary of all
+// the replicas. The summaries of the other replicas in the ConfigMap are kept.
+func (p *ConfigMapPublisher) Publish(ctx context.Context, summary Summary) error {
+	gwNsName, err := parseGateway(summary.Gateway)
+	if err != nil {
+		return err
+	}
+
+	var gw gatewayv1.Gateway
+	if err := p.k8sClient.Get(ctx, gwNsName, &gw); err != nil {
+		return fmt.Errorf("failed to get Gateway %s: %w", gwNsName, err)
+	}
+
+	summaryJSON, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage summary: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(gw.GetName()),
+			Namespace: gw.GetNamespace(),
+		},
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, p.k8sClient, cm, func() error {
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[SummaryKey(p.replica)] = string(summaryJSON)
+
+		totalJSON, err := json.MarshalIndent(Merge(p.concurrentSummaries(summary, cm.Data)), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal total usage summary: %w", err)
+		}
+		cm.Data[TotalKey] = string(totalJSON)
+
+		if cm.Labels == nil {
+			cm.Labels = make(map[string]string)
+		}
+		cm.Labels[controller.GatewayLabel] = gw.GetName()
+
+		cm.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: gatewayv1.GroupVersion.String(),
+				Kind:       kinds.Gateway,
+				Name:       gw.GetName(),
+				UID:        gw.GetUID(),
+			},
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to publish usage summary to ConfigMap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+
+	return nil
+}
+
+// concurrentSummaries returns the Summary and the summaries of the other replicas in the data of the ConfigMap
+// whose windows end within half a window of the end of the window of the Summary. The replicas summarize
+// windows of the same length, so that is the window of every other replica that is closest to the window
+// of the Summary. The summaries of the replicas that stopped, or that didn't receive any requests
+// of the Gateway in the window, are older, so they are left out.
+func (p *ConfigMapPublisher) concurrentSummaries(summary Summary, data map[string]string) []Summary {
+	summaries := []Summary{summary}
+	maxOffset := summary.WindowEnd.Sub(summary.WindowStart) / 2
+
+	for key, value := range data {
+		if key == TotalKey || key == SummaryKey(p.replica) {
+			continue
+		}
+
+		var other Summary
+		if err := json.Unmarshal([]byte(value), &other); err != nil {
+			continue
+		}
+
+		offset := other.WindowEnd.Sub(summary.WindowEnd)
+		if offset > -maxOffset && offset < maxOffset {
+			summaries = append(summaries, other)
+		}
+	}
+
+	return summaries
+}
+
+// ConfigMapName returns the name of the ConfigMap that the Summary of the Gateway is published to.
+func ConfigMapName(gatewayName string) string {
+	return controller.CreateNginxResourceName(gatewayName, configMapNameSuffix)
+}
+
+// SummaryKey returns the key of the data of the ConfigMap with the Summary of the replica in the JSON format.
+func SummaryKey(replica string) string {
+	return replica + ".json"
+}
+
+func parseGateway(gateway string) (types.NamespacedName, error) {
+	namespace, name, found := strings.Cut(gateway, "/")
+	if !found || namespace == "" || name == "" {
+		return types.NamespacedName{}, errors.New("invalid Gateway " + gateway + "; must be namespace/name")
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
@@ -0,0 +1,186 @@
+package usagesummary
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/controller"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+func TestConfigMapPublisher(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(gatewayv1.Install(scheme)).To(Succeed())
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway", UID: "uid"},
+	}
+	existingCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway-usage-summary"},
+		Data:       map[string]string{"other-replica.json": "{}"},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gateway, existingCM).Build()
+	publisher := NewConfigMapPublisher(fakeClient, "ngf-replica")
+
+	summary := Summary{
+		WindowStart: time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC),
+		WindowEnd:   time.Date(2026, 10, 17, 11, 0, 0, 0, time.UTC),
+		Gateway:     "test/gateway",
+		Usage:       Usage{Requests: 2, RequestBytes: 200, ResponseBytes: 1200, ServerErrors: 1, ErrorRate: 0.5},
+		TopRoutes: []RouteUsage{
+			{
+				Route: "test/coffee",
+				Usage: Usage{Requests: 2, RequestBytes: 200, ResponseBytes: 1200, ServerErrors: 1, ErrorRate: 0.5},
+			},
+		},
+	}
+
+	g.Expect(publisher.Publish(context.Background(), summary)).To(Succeed())
+
+	var cm corev1.ConfigMap
+	g.Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(existingCM), &cm)).To(Succeed())
+
+	g.Expect(cm.Data).To(HaveKey("other-replica.json"))
+	g.Expect(cm.Data).To(HaveKey(SummaryKey("ngf-replica")))
+	g.Expect(cm.Labels).To(HaveKeyWithValue(controller.GatewayLabel, "gateway"))
+	g.Expect(cm.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
+		APIVersion: gatewayv1.GroupVersion.String(),
+		Kind:       kinds.Gateway,
+		Name:       "gateway",
+		UID:        "uid",
+	}))
+
+	var published Summary
+	g.Expect(json.Unmarshal([]byte(cm.Data[SummaryKey("ngf-replica")]), &published)).To(Succeed())
+	g.Expect(published).To(Equal(summary))
+
+	// the summary of the other replica is empty, so the total is the summary of this replica
+	var total Summary
+	g.Expect(json.Unmarshal([]byte(cm.Data[TotalKey]), &total)).To(Succeed())
+	g.Expect(total).To(Equal(summary))
+}
+
+func TestConfigMapPublisher_MultipleReplicas(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(gatewayv1.Install(scheme)).To(Succeed())
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway", UID: "uid"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gateway).Build()
+
+	replicas := []*ConfigMapPublisher{
+		NewConfigMapPublisher(fakeClient, "ngf-replica-1"),
+		NewConfigMapPublisher(fakeClient, "ngf-replica-2"),
+		NewConfigMapPublisher(fakeClient, "ngf-replica-3"),
+	}
+
+	start := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+	summary := func(offset time.Duration, requests int64) Summary {
+		return Summary{
+			WindowStart: start.Add(offset),
+			WindowEnd:   start.Add(offset + time.Hour),
+			Gateway:     "test/gateway",
+			Usage:       Usage{Requests: requests},
+			TopRoutes:   []RouteUsage{{Route: "test/coffee", Usage: Usage{Requests: requests}}},
+		}
+	}
+
+	getTotal := func() Summary {
+		var cm corev1.ConfigMap
+		key := client.ObjectKey{Namespace: "test", Name: ConfigMapName("gateway")}
+		g.Expect(fakeClient.Get(context.Background(), key, &cm)).To(Succeed())
+
+		var total Summary
+		g.Expect(json.Unmarshal([]byte(cm.Data[TotalKey]), &total)).To(Succeed())
+
+		return total
+	}
+
+	// the third replica published the summary of the previous window, and then stopped
+	g.Expect(replicas[2].Publish(context.Background(), summary(-time.Hour, 100))).To(Succeed())
+
+	g.Expect(replicas[0].Publish(context.Background(), summary(0, 1))).To(Succeed())
+	g.Expect(getTotal()).To(Equal(summary(0, 1)))
+
+	// the windows of the replicas started at different times
+	g.Expect(replicas[1].Publish(context.Background(), summary(10*time.Minute, 2))).To(Succeed())
+
+	expected := Summary{
+		WindowStart: start,
+		WindowEnd:   start.Add(time.Hour + 10*time.Minute),
+		Gateway:     "test/gateway",
+		Usage:       Usage{Requests: 3},
+		TopRoutes:   []RouteUsage{{Route: "test/coffee", Usage: Usage{Requests: 3}}},
+	}
+	g.Expect(getTotal()).To(Equal(expected))
+
+	// the first replica published the summary of its next window, which replaces its previous summary.
+	// The summary of the second replica is closer to the previous window, so it is left out until the second
+	// replica publishes the summary of its next window.
+	g.Expect(replicas[0].Publish(context.Background(), summary(time.Hour, 4))).To(Succeed())
+	g.Expect(getTotal()).To(Equal(summary(time.Hour, 4)))
+
+	g.Expect(replicas[1].Publish(context.Background(), summary(time.Hour+10*time.Minute, 5))).To(Succeed())
+
+	expected.WindowStart = start.Add(time.Hour)
+	expected.WindowEnd = start.Add(2*time.Hour + 10*time.Minute)
+	expected.Requests = 9
+	expected.TopRoutes[0].Requests = 9
+	g.Expect(getTotal()).To(Equal(expected))
+}
+
+func TestConfigMapPublisher_Errors(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	NewWithT(t).Expect(gatewayv1.Install(scheme)).To(Succeed())
+
+	tests := []struct {
+		name        string
+		gateway     string
+		expectedErr string
+	}{
+		{
+			name:        "invalid gateway",
+			gateway:     "gateway",
+			expectedErr: "invalid Gateway gateway; must be namespace/name",
+		},
+		{
+			name:        "gateway doesn't exist",
+			gateway:     "test/gateway",
+			expectedErr: "failed to get Gateway test/gateway",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			publisher := NewConfigMapPublisher(fake.NewClientBuilder().WithScheme(scheme).Build(), "ngf-replica")
+
+			err := publisher.Publish(context.Background(), Summary{Gateway: test.gateway})
+			g.Expect(err).To(MatchError(ContainSubstring(test.expectedErr)))
+		})
+	}
+}
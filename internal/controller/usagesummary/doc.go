@@ -0,0 +1,12 @@
+/*
+Package usagesummary summarizes the usage of every Gateway over a window, so that platform teams can report
+the usage of the Gateways without a metrics stack.
+
+The Aggregator records the requests that NGINX reports to the control plane for the Gateways that attribute
+their requests to tenants. At the end of every window, the requests, the bytes, the rate of the server errors,
+and the top routes of every Gateway are summarized, and the ConfigMapPublisher publishes the summary
+to the ConfigMap <gateway-name>-usage-summary in the namespace of the Gateway. Every replica of the control plane
+summarizes the requests that it receives, and publishes its summary under its own key of the ConfigMap, together
+with the total summary of all the replicas.
+*/
+package usagesummary
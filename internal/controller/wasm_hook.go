@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/wasmhook"
+)
+
+// wasmHookEvent makes the event handler regenerate the configuration from the latest graph, because the WASM hook
+// completed for the routing state of a Gateway.
+type wasmHookEvent struct {
+	gateway types.NamespacedName
+}
+
+// newWASMHookRunner creates a Runner that runs the WASM hook in the background, and sends a wasmHookEvent to
+// the event loop when the hook completes for the routing state of a Gateway.
+func newWASMHookRunner(hook wasmhook.Mutator, eventCh chan<- interface{}) *wasmhook.Runner {
+	return wasmhook.NewRunner(hook, func(ctx context.Context, gateway types.NamespacedName) {
+		select {
+		case eventCh <- &wasmHookEvent{gateway: gateway}:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// wasmHookChanged returns whether the WASM hook completed for the routing state of a Gateway since the last call,
+// and resets it.
+func (h *eventHandlerImpl) wasmHookChanged() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	completed := h.wasmHookCompleted
+	h.wasmHookCompleted = false
+
+	return completed
+}
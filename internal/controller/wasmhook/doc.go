@@ -0,0 +1,16 @@
+/*
+Package wasmhook runs an experimental extension hook that lets operators mutate the routing state of every Gateway
+with a WebAssembly module, so that they can apply custom transformations without forking the control plane.
+
+The module is a WASI command. It reads the routing state of a Gateway, in the public v1alpha1 format of the
+pkg/dataplane/v1alpha1 package, as JSON from its standard input, and writes the mutated state as JSON to its
+standard output. A non-zero exit status fails the hook.
+
+The module runs in a WASI runtime that is embedded in the control plane. The module gets no access to
+the filesystem, network, or environment. The size of its linear memory is limited, the runtime stops it once
+its execution time exceeds the timeout, and its output is limited to MaxOutputSize bytes. If the hook fails,
+the routing state is not mutated.
+
+The Runner runs the hook in the background, so that the execution time of the module doesn't delay the event loop.
+*/
+package wasmhook
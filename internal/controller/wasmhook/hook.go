@@ -0,0 +1,165 @@
+package wasmhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/pkg/dataplane/v1alpha1"
+)
+
+// MaxOutputSize is the maximum size of the output of a module, in bytes.
+const MaxOutputSize = 16 * 1024 * 1024
+
+// maxErrorOutputSize is the maximum size of the error output of a module that is included in the error, in bytes.
+const maxErrorOutputSize = 4096
+
+// wasmPageSize is the size of a page of the linear memory of a WASM module, in bytes.
+const wasmPageSize = 64 * 1024
+
+// Config is the configuration of a Hook.
+type Config struct {
+	// ModulePath is the path to the WASM module.
+	ModulePath string
+	// Timeout is the maximum execution time of the module.
+	Timeout time.Duration
+	// MaxMemoryBytes is the maximum size of the linear memory of the module, in bytes.
+	MaxMemoryBytes int64
+}
+
+// Hook mutates the routing state of a Gateway with a WASM module.
+type Hook struct {
+	runtime wazero.Runtime
+	module  wazero.CompiledModule
+	cfg     Config
+}
+
+// New creates a new Hook. It compiles the module in the embedded WASM runtime.
+func New(ctx context.Context, cfg Config) (*Hook, error) {
+	wasm, err := os.ReadFile(cfg.ModulePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module %s: %w", cfg.ModulePath, err)
+	}
+
+	// the memory limit is rounded up to whole pages, because the memory of a module grows by pages.
+	// The runtime stops the module when its context is done, so that the timeout is enforced
+	// even if the module never returns to the runtime.
+	runtimeConfig := wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(min((cfg.MaxMemoryBytes+wasmPageSize-1)/wasmPageSize, 65536))).
+		WithCloseOnContextDone(true)
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to instantiate WASI: %w", err)
+	}
+
+	module, err := runtime.CompileModule(ctx, wasm)
+	if err != nil {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to compile module %s: %w", cfg.ModulePath, err)
+	}
+
+	return &Hook{
+		runtime: runtime,
+		module:  module,
+		cfg:     cfg,
+	}, nil
+}
+
+// Close releases the resources of the WASM runtime.
+func (h *Hook) Close(ctx context.Context) error {
+	return h.runtime.Close(ctx)
+}
+
+// Mutate runs the module with the Configuration and returns the Configuration that the module wrote.
+// It is safe to call Mutate concurrently.
+func (h *Hook) Mutate(ctx context.Context, conf v1alpha1.Configuration) (v1alpha1.Configuration, error) {
+	input, err := json.Marshal(conf)
+	if err != nil {
+		return v1alpha1.Configuration{}, fmt.Errorf("failed to marshal the configuration: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.Timeout)
+	defer cancel()
+
+	output := &limitedBuffer{limit: MaxOutputSize}
+	stderr := &limitedBuffer{limit: maxErrorOutputSize}
+
+	// the module gets no access to the filesystem, network, or environment, because none are configured.
+	// Every run gets an anonymous instance, so that the runs don't share state.
+	moduleConfig := wazero.NewModuleConfig().
+		WithName("").
+		WithStdin(bytes.NewReader(input)).
+		WithStdout(output).
+		WithStderr(stderr)
+
+	mod, err := h.runtime.InstantiateModule(ctx, h.module, moduleConfig)
+	if mod != nil {
+		_ = mod.Close(ctx)
+	}
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return v1alpha1.Configuration{}, fmt.Errorf("module %s timed out after %s", h.cfg.ModulePath, h.cfg.Timeout)
+		}
+
+		var exitErr *sys.ExitError
+		if errors.As(err, &exitErr) {
+			err = fmt.Errorf("exit status %d", exitErr.ExitCode())
+		}
+
+		if msg := bytes.TrimSpace(stderr.Bytes()); len(msg) > 0 {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return v1alpha1.Configuration{}, fmt.Errorf("module %s failed: %w", h.cfg.ModulePath, err)
+	}
+
+	if output.exceeded {
+		return v1alpha1.Configuration{}, fmt.Errorf(
+			"output of module %s exceeds the limit of %d bytes",
+			h.cfg.ModulePath,
+			MaxOutputSize,
+		)
+	}
+
+	var mutated v1alpha1.Configuration
+	if err := json.Unmarshal(output.Bytes(), &mutated); err != nil {
+		return v1alpha1.Configuration{}, fmt.Errorf("failed to unmarshal the output of module %s: %w", h.cfg.ModulePath, err)
+	}
+
+	return mutated, nil
+}
+
+// limitedBuffer is a buffer that drops everything written past its limit.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	limit    int
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.buf.Len(); len(p) > remaining {
+		b.exceeded = true
+		b.buf.Write(p[:max(remaining, 0)])
+
+		// report the whole write as successful so that the module isn't failed by the write
+		return len(p), nil
+	}
+
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
@@ -0,0 +1,157 @@
+package wasmhook
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/pkg/dataplane/v1alpha1"
+)
+
+// buildModule builds the WASI command in testdata/hook, which stands in for a WASM hook.
+func buildModule(t *testing.T) string {
+	t.Helper()
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is required to build the WASM module")
+	}
+
+	path := filepath.Join(t.TempDir(), "hook.wasm")
+
+	cmd := exec.Command(goBin, "build", "-o", path, "./testdata/hook")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to build the WASM module: %v: %s", err, output)
+	}
+
+	return path
+}
+
+func TestMutate(t *testing.T) {
+	t.Parallel()
+
+	modulePath := buildModule(t)
+
+	// the module is compiled once, because compiling it takes a while
+	hook, err := New(context.Background(), Config{
+		ModulePath:     modulePath,
+		Timeout:        10 * time.Second,
+		MaxMemoryBytes: 64 * 1024 * 1024,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = hook.Close(context.Background())
+	})
+
+	newConf := func(hostname string) v1alpha1.Configuration {
+		return v1alpha1.Configuration{
+			APIVersion:  v1alpha1.APIVersion,
+			HTTPServers: []v1alpha1.VirtualServer{{Hostname: hostname, Port: 80}},
+		}
+	}
+
+	tests := []struct {
+		expected    v1alpha1.Configuration
+		name        string
+		hostname    string
+		expectedErr string
+		timeout     time.Duration
+	}{
+		{
+			name:     "module echoes the configuration",
+			hostname: "foo.example.com",
+			expected: newConf("foo.example.com"),
+		},
+		{
+			name:     "module mutates the configuration",
+			hostname: "mutate.example.com",
+			expected: v1alpha1.Configuration{
+				APIVersion:  v1alpha1.APIVersion,
+				HTTPServers: []v1alpha1.VirtualServer{{Hostname: "bar.example.com", Port: 8080}},
+			},
+		},
+		{
+			name:        "module fails",
+			hostname:    "fail.example.com",
+			expectedErr: "module " + modulePath + " failed: exit status 1: trap: unreachable",
+		},
+		{
+			name:        "module times out",
+			hostname:    "loop.example.com",
+			timeout:     500 * time.Millisecond,
+			expectedErr: "module " + modulePath + " timed out after 500ms",
+		},
+		{
+			name:        "module exceeds the memory limit",
+			hostname:    "memory.example.com",
+			expectedErr: "module " + modulePath + " failed: exit status 2",
+		},
+		{
+			name:        "module writes invalid output",
+			hostname:    "invalid.example.com",
+			expectedErr: "failed to unmarshal the output of module " + modulePath,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			testHook := *hook
+			if test.timeout != 0 {
+				testHook.cfg.Timeout = test.timeout
+			}
+
+			mutated, err := testHook.Mutate(context.Background(), newConf(test.hostname))
+			if test.expectedErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(HavePrefix(test.expectedErr))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(mutated).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	_, err := New(context.Background(), Config{ModulePath: "/does/not/exist.wasm"})
+	g.Expect(err).To(MatchError(HavePrefix("failed to read module /does/not/exist.wasm")))
+
+	path := filepath.Join(t.TempDir(), "invalid.wasm")
+	g.Expect(os.WriteFile(path, []byte("not wasm"), 0o600)).To(Succeed())
+
+	_, err = New(context.Background(), Config{ModulePath: path, MaxMemoryBytes: 1024 * 1024})
+	g.Expect(err).To(MatchError(HavePrefix("failed to compile module " + path)))
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	buf := &limitedBuffer{limit: 4}
+
+	n, err := buf.Write([]byte("abc"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(n).To(Equal(3))
+	g.Expect(buf.exceeded).To(BeFalse())
+
+	n, err = buf.Write([]byte("def"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(n).To(Equal(3))
+	g.Expect(buf.exceeded).To(BeTrue())
+	g.Expect(string(buf.Bytes())).To(Equal("abcd"))
+}
@@ -0,0 +1,130 @@
+package wasmhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/pkg/dataplane/v1alpha1"
+)
+
+// Mutator mutates the routing state of a Gateway.
+type Mutator interface {
+	Mutate(ctx context.Context, conf v1alpha1.Configuration) (v1alpha1.Configuration, error)
+}
+
+// Result is the outcome of a Mutator for the routing state of a Gateway.
+type Result struct {
+	// Err is the error of the Mutator. If set, Configuration is empty.
+	Err error
+	// Configuration is the mutated routing state.
+	Configuration v1alpha1.Configuration
+}
+
+// Runner runs a Mutator on the routing state of Gateways in the background, so that the execution time of
+// the module doesn't delay the caller. The Result for the latest routing state of every Gateway is kept, so that
+// the Mutator only runs again when the routing state of the Gateway changes.
+type Runner struct {
+	mutator Mutator
+	notify  func(ctx context.Context, gateway types.NamespacedName)
+	runs    map[types.NamespacedName]*run
+	lock    sync.Mutex
+}
+
+// run is a run of the Mutator for a routing state of a Gateway.
+type run struct {
+	result *Result
+	cancel context.CancelFunc
+	digest [sha256.Size]byte
+}
+
+// NewRunner creates a new Runner. notify is called with the Gateway once the Result for its routing state
+// is available.
+func NewRunner(mutator Mutator, notify func(ctx context.Context, gateway types.NamespacedName)) *Runner {
+	return &Runner{
+		mutator: mutator,
+		notify:  notify,
+		runs:    make(map[types.NamespacedName]*run),
+	}
+}
+
+// Result returns the Result of the Mutator for the routing state of the Gateway, and whether it is available.
+// If it isn't, the Mutator is started in the background with the routing state, and the run for a previous routing
+// state of the Gateway is canceled.
+func (r *Runner) Result(
+	ctx context.Context,
+	gateway types.NamespacedName,
+	conf v1alpha1.Configuration,
+) (Result, bool) {
+	input, err := json.Marshal(conf)
+	if err != nil {
+		return Result{Err: fmt.Errorf("failed to marshal the configuration: %w", err)}, true
+	}
+
+	digest := sha256.Sum256(input)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if current, exists := r.runs[gateway]; exists {
+		if current.digest == digest {
+			if current.result == nil {
+				return Result{}, false
+			}
+
+			return *current.result, true
+		}
+
+		current.cancel()
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	current := &run{digest: digest, cancel: cancel}
+	r.runs[gateway] = current
+
+	go r.run(ctx, runCtx, gateway, current, conf)
+
+	return Result{}, false
+}
+
+// Retain drops the Results of the Gateways that keep doesn't retain, and cancels their runs.
+func (r *Runner) Retain(keep func(gateway types.NamespacedName) bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for gateway, current := range r.runs {
+		if !keep(gateway) {
+			current.cancel()
+			delete(r.runs, gateway)
+		}
+	}
+}
+
+func (r *Runner) run(
+	ctx context.Context,
+	runCtx context.Context,
+	gateway types.NamespacedName,
+	current *run,
+	conf v1alpha1.Configuration,
+) {
+	mutated, err := r.mutator.Mutate(runCtx, conf)
+
+	r.lock.Lock()
+
+	// the Result of a canceled run is discarded, because a run for a newer routing state replaced it.
+	if r.runs[gateway] != current {
+		r.lock.Unlock()
+		return
+	}
+
+	current.result = &Result{Configuration: mutated, Err: err}
+	current.cancel()
+
+	r.lock.Unlock()
+
+	r.notify(ctx, gateway)
+}
@@ -0,0 +1,126 @@
+package wasmhook
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/pkg/dataplane/v1alpha1"
+)
+
+// fakeMutator blocks every run until it is released, and records the contexts of the runs.
+type fakeMutator struct {
+	release chan struct{}
+	err     error
+	ctxs    []context.Context
+	lock    sync.Mutex
+}
+
+func (f *fakeMutator) Mutate(ctx context.Context, conf v1alpha1.Configuration) (v1alpha1.Configuration, error) {
+	f.lock.Lock()
+	f.ctxs = append(f.ctxs, ctx)
+	f.lock.Unlock()
+
+	select {
+	case <-f.release:
+	case <-ctx.Done():
+		return v1alpha1.Configuration{}, ctx.Err()
+	}
+
+	if f.err != nil {
+		return v1alpha1.Configuration{}, f.err
+	}
+
+	conf.HTTPServers = append(conf.HTTPServers, v1alpha1.VirtualServer{Hostname: "hook.example.com"})
+
+	return conf, nil
+}
+
+func (f *fakeMutator) runs() []context.Context {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.ctxs
+}
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	mutator := &fakeMutator{release: make(chan struct{})}
+	notified := make(chan types.NamespacedName, 10)
+	runner := NewRunner(mutator, func(_ context.Context, gateway types.NamespacedName) {
+		notified <- gateway
+	})
+
+	gateway := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	conf := v1alpha1.Configuration{APIVersion: v1alpha1.APIVersion}
+
+	// the first call starts the run in the background
+	_, available := runner.Result(context.Background(), gateway, conf)
+	g.Expect(available).To(BeFalse())
+	g.Eventually(mutator.runs).Should(HaveLen(1))
+
+	// the same routing state doesn't start another run
+	_, available = runner.Result(context.Background(), gateway, conf)
+	g.Expect(available).To(BeFalse())
+
+	// a new routing state cancels the previous run
+	changed := v1alpha1.Configuration{APIVersion: v1alpha1.APIVersion, Upstreams: []v1alpha1.Upstream{{Name: "up"}}}
+	_, available = runner.Result(context.Background(), gateway, changed)
+	g.Expect(available).To(BeFalse())
+	g.Eventually(mutator.runs).Should(HaveLen(2))
+	g.Eventually(mutator.runs()[0].Done()).Should(BeClosed())
+
+	// the Gateway is notified once the run completes
+	close(mutator.release)
+	g.Eventually(notified).Should(Receive(Equal(gateway)))
+	g.Consistently(notified, 100*time.Millisecond).ShouldNot(Receive())
+
+	result, available := runner.Result(context.Background(), gateway, changed)
+	g.Expect(available).To(BeTrue())
+	g.Expect(result.Err).ToNot(HaveOccurred())
+	g.Expect(result.Configuration.Upstreams).To(Equal(changed.Upstreams))
+	g.Expect(result.Configuration.HTTPServers).To(ConsistOf(v1alpha1.VirtualServer{Hostname: "hook.example.com"}))
+	g.Expect(mutator.runs()).To(HaveLen(2))
+
+	// the Results of the Gateways that are not retained are dropped
+	runner.Retain(func(types.NamespacedName) bool { return true })
+	_, available = runner.Result(context.Background(), gateway, changed)
+	g.Expect(available).To(BeTrue())
+
+	runner.Retain(func(types.NamespacedName) bool { return false })
+	_, available = runner.Result(context.Background(), gateway, changed)
+	g.Expect(available).To(BeFalse())
+	g.Eventually(notified).Should(Receive(Equal(gateway)))
+	g.Expect(mutator.runs()).To(HaveLen(3))
+}
+
+func TestRunner_Error(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	mutator := &fakeMutator{release: make(chan struct{}), err: errors.New("module trapped")}
+	close(mutator.release)
+
+	notified := make(chan types.NamespacedName, 1)
+	runner := NewRunner(mutator, func(_ context.Context, gateway types.NamespacedName) {
+		notified <- gateway
+	})
+
+	gateway := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	conf := v1alpha1.Configuration{APIVersion: v1alpha1.APIVersion}
+
+	_, available := runner.Result(context.Background(), gateway, conf)
+	g.Expect(available).To(BeFalse())
+	g.Eventually(notified).Should(Receive(Equal(gateway)))
+
+	result, available := runner.Result(context.Background(), gateway, conf)
+	g.Expect(available).To(BeTrue())
+	g.Expect(result.Err).To(MatchError("module trapped"))
+}
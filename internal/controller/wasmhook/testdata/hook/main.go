@@ -0,0 +1,46 @@
+// Command hook is a WASI command that stands in for a WASM hook in the tests. Its behavior is selected by
+// the hostname of the first HTTP server of the routing state.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+func main() {
+	var conf map[string]any
+	if err := json.NewDecoder(os.Stdin).Decode(&conf); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var hostname string
+	if servers, ok := conf["httpServers"].([]any); ok && len(servers) > 0 {
+		hostname, _ = servers[0].(map[string]any)["hostname"].(string)
+	}
+
+	switch hostname {
+	case "fail.example.com":
+		fmt.Fprintln(os.Stderr, "trap: unreachable")
+		os.Exit(1)
+	case "loop.example.com":
+		for {
+		}
+	case "memory.example.com":
+		buf := make([]byte, 256*1024*1024)
+		for i := range buf {
+			buf[i] = 1
+		}
+	case "invalid.example.com":
+		fmt.Println("not json")
+		return
+	case "mutate.example.com":
+		conf["httpServers"] = []any{map[string]any{"hostname": "bar.example.com", "port": 8080}}
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(conf); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
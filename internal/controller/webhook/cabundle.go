@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	admregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// caBundleRetryPeriod is the period between the attempts to inject the CA bundle.
+const caBundleRetryPeriod = 5 * time.Second
+
+// CABundleInjector injects the CA certificate of the webhook server into the ValidatingWebhookConfiguration, so that
+// the API server trusts the webhook server.
+type CABundleInjector struct {
+	k8sClient client.Client
+	k8sReader client.Reader
+	logger    logr.Logger
+	caPath    string
+	name      string
+}
+
+// NewCABundleInjector creates a new CABundleInjector, which injects the CA certificate in the caPath
+// into the ValidatingWebhookConfiguration with the name.
+func NewCABundleInjector(
+	k8sClient client.Client,
+	k8sReader client.Reader,
+	logger logr.Logger,
+	name string,
+	caPath string,
+) *CABundleInjector {
+	return &CABundleInjector{
+		k8sClient: k8sClient,
+		k8sReader: k8sReader,
+		logger:    logger,
+		caPath:    caPath,
+		name:      name,
+	}
+}
+
+// Start injects the CA bundle, retrying until it succeeds or the context is canceled.
+func (i *CABundleInjector) Start(ctx context.Context) error {
+	inject := func(ctx context.Context) (bool, error) {
+		if err := i.inject(ctx); err != nil {
+			i.logger.Error(err, "Failed to inject the CA bundle into the ValidatingWebhookConfiguration", "name", i.name)
+			return false, nil
+		}
+
+		return true, nil
+	}
+
+	if err := wait.PollUntilContextCancel(ctx, caBundleRetryPeriod, true /* immediate */, inject); err != nil &&
+		ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *CABundleInjector) inject(ctx context.Context) error {
+	ca, err := os.ReadFile(i.caPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the CA certificate: %w", err)
+	}
+
+	var config admregv1.ValidatingWebhookConfiguration
+	if err := i.k8sReader.Get(ctx, types.NamespacedName{Name: i.name}, &config); err != nil {
+		return fmt.Errorf("failed to get the ValidatingWebhookConfiguration: %w", err)
+	}
+
+	var changed bool
+	for idx := range config.Webhooks {
+		if !bytes.Equal(config.Webhooks[idx].ClientConfig.CABundle, ca) {
+			config.Webhooks[idx].ClientConfig.CABundle = ca
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := i.k8sClient.Update(ctx, &config); err != nil {
+		return fmt.Errorf("failed to update the ValidatingWebhookConfiguration: %w", err)
+	}
+
+	i.logger.Info("Injected the CA bundle into the ValidatingWebhookConfiguration", "name", i.name)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	admregv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCABundleInjectorInject(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	g.Expect(os.WriteFile(caPath, []byte("ca"), 0o600)).To(Succeed())
+
+	scheme := runtime.NewScheme()
+	g.Expect(admregv1.AddToScheme(scheme)).To(Succeed())
+
+	config := &admregv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "ngf-webhook"},
+		Webhooks: []admregv1.ValidatingWebhook{
+			{Name: "routes.gateway.nginx.org"},
+			{Name: "snippetsfilters.gateway.nginx.org"},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(config).Build()
+
+	injector := NewCABundleInjector(k8sClient, k8sClient, logr.Discard(), "ngf-webhook", caPath)
+	g.Expect(injector.inject(context.Background())).To(Succeed())
+
+	var updated admregv1.ValidatingWebhookConfiguration
+	g.Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: "ngf-webhook"}, &updated)).To(Succeed())
+	g.Expect(updated.Webhooks).To(HaveLen(2))
+	for _, wh := range updated.Webhooks {
+		g.Expect(wh.ClientConfig.CABundle).To(Equal([]byte("ca")))
+	}
+
+	// injecting the same CA bundle again doesn't update the ValidatingWebhookConfiguration
+	g.Expect(injector.inject(context.Background())).To(Succeed())
+
+	var unchanged admregv1.ValidatingWebhookConfiguration
+	g.Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: "ngf-webhook"}, &unchanged)).To(Succeed())
+	g.Expect(unchanged.ResourceVersion).To(Equal(updated.ResourceVersion))
+}
+
+func TestCABundleInjectorInject_Errors(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := admregv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caPath, []byte("ca"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		caPath string
+		expErr string
+	}{
+		{
+			name:   "CA certificate doesn't exist",
+			caPath: filepath.Join(t.TempDir(), "missing.crt"),
+			expErr: "failed to read the CA certificate",
+		},
+		{
+			name:   "ValidatingWebhookConfiguration doesn't exist",
+			caPath: caPath,
+			expErr: "failed to get the ValidatingWebhookConfiguration",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			injector := NewCABundleInjector(k8sClient, k8sClient, logr.Discard(), "ngf-webhook", test.caPath)
+
+			err := injector.inject(context.Background())
+			g.Expect(err).To(MatchError(ContainSubstring(test.expErr)))
+		})
+	}
+}
+
+func TestCABundleInjectorStart_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	k8sClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	injector := NewCABundleInjector(k8sClient, k8sClient, logr.Discard(), "ngf-webhook", "/does/not/exist")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g.Expect(injector.Start(ctx)).To(Succeed())
+}
@@ -0,0 +1,9 @@
+/*
+Package webhook implements the optional validating admission webhook for the Gateway API and NGF resources.
+
+The webhook rejects the resources that NGF would report as invalid, like the Routes with unsupported filters,
+before they are stored, so that the misconfigurations don't linger with the Accepted=False condition.
+It validates the Routes with the same rules as the Graph, and only the Routes that reference a Gateway of NGF,
+so that the Routes of the other Gateway API implementations in the cluster are not affected.
+*/
+package webhook
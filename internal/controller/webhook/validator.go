@@ -0,0 +1,252 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"slices"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/nginx/config/http"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+// Path is the path on the webhook server that the validating webhook is served on.
+const Path = "/validate"
+
+// mirrorRouteNamePrefix is the prefix of the names of the internal Routes of the mirrored requests.
+var mirrorRouteNamePrefix = strings.TrimPrefix(http.InternalMirrorRoutePathPrefix, "/")
+
+// graphGetter gets the latest Graph.
+type graphGetter interface {
+	GetLatestGraph() *graph.Graph
+}
+
+// Config is the configuration of the Validator.
+type Config struct {
+	// GraphGetter gets the latest Graph, which determines the Gateways of NGF and the Routes attached to them.
+	GraphGetter graphGetter
+	// Validator validates the values that propagate into the NGINX configuration.
+	Validator validation.HTTPFieldsValidator
+	// FeatureFlags are the enabled features.
+	FeatureFlags graph.FeatureFlags
+	// MaxRoutesPerGateway is the maximum number of the Routes attached to a Gateway. If 0, it is not limited.
+	MaxRoutesPerGateway int
+	// SnippetsFilters indicates whether the SnippetsFilters are enabled.
+	SnippetsFilters bool
+}
+
+// Validator validates the Gateway API and NGF resources at admission.
+type Validator struct {
+	decoder admission.Decoder
+	cfg     Config
+}
+
+// NewValidator creates a new Validator.
+func NewValidator(scheme *runtime.Scheme, cfg Config) *Validator {
+	return &Validator{
+		decoder: admission.NewDecoder(scheme),
+		cfg:     cfg,
+	}
+}
+
+// Handle validates the resource of the admission request.
+func (v *Validator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation == admissionv1.Delete {
+		return admission.Allowed("")
+	}
+
+	switch req.Kind.Kind {
+	case kinds.HTTPRoute:
+		route := &v1.HTTPRoute{}
+		if err := v.decoder.Decode(req, route); err != nil {
+			return admission.Errored(nethttp.StatusBadRequest, err)
+		}
+
+		var filters []v1.LocalObjectReference
+		for _, rule := range route.Spec.Rules {
+			for _, filter := range rule.Filters {
+				if filter.ExtensionRef != nil {
+					filters = append(filters, *filter.ExtensionRef)
+				}
+			}
+		}
+
+		return v.validateRoute(
+			graph.CreateRouteKey(route),
+			route.Spec.ParentRefs,
+			filters,
+			func(policy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy) graph.AdmissionResult {
+				return graph.ValidateHTTPRouteForAdmission(v.cfg.Validator, route, policy, v.cfg.FeatureFlags)
+			},
+		)
+	case kinds.GRPCRoute:
+		route := &v1.GRPCRoute{}
+		if err := v.decoder.Decode(req, route); err != nil {
+			return admission.Errored(nethttp.StatusBadRequest, err)
+		}
+
+		var filters []v1.LocalObjectReference
+		for _, rule := range route.Spec.Rules {
+			for _, filter := range rule.Filters {
+				if filter.ExtensionRef != nil {
+					filters = append(filters, *filter.ExtensionRef)
+				}
+			}
+		}
+
+		return v.validateRoute(
+			graph.CreateRouteKey(route),
+			route.Spec.ParentRefs,
+			filters,
+			func(policy ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy) graph.AdmissionResult {
+				return graph.ValidateGRPCRouteForAdmission(v.cfg.Validator, route, policy, v.cfg.FeatureFlags)
+			},
+		)
+	case kinds.SnippetsFilter:
+		if !v.cfg.SnippetsFilters {
+			return admission.Denied("SnippetsFilters are disabled")
+		}
+
+		filter := &ngfAPI.SnippetsFilter{}
+		if err := v.decoder.Decode(req, filter); err != nil {
+			return admission.Errored(nethttp.StatusBadRequest, err)
+		}
+
+		if err := graph.ValidateSnippetsFilterForAdmission(filter); err != nil {
+			return admission.Denied(err.Error())
+		}
+
+		return admission.Allowed("")
+	default:
+		return admission.Allowed("")
+	}
+}
+
+func (v *Validator) validateRoute(
+	key graph.RouteKey,
+	parentRefs []v1.ParentReference,
+	filters []v1.LocalObjectReference,
+	validate func(ngfAPIv1alpha2.UnknownExtensionRefFilterPolicy) graph.AdmissionResult,
+) admission.Response {
+	g := v.cfg.GraphGetter.GetLatestGraph()
+
+	gateways := getNGFGateways(g, key.NamespacedName.Namespace, parentRefs)
+	if len(gateways) == 0 {
+		// The Route is handled by another implementation, or NGF doesn't know its Gateways yet.
+		return admission.Allowed("")
+	}
+
+	result := validate(graph.UnknownExtensionRefFilterPolicyForGatewayClass(g.GatewayClass))
+
+	if !v.cfg.SnippetsFilters {
+		for _, ref := range filters {
+			if ref.Group == ngfAPI.GroupName && ref.Kind == kinds.SnippetsFilter {
+				result.Errors = append(result.Errors, field.Forbidden(
+					field.NewPath("spec").Child("rules"),
+					fmt.Sprintf("SnippetsFilter %q cannot be referenced, because SnippetsFilters are disabled", ref.Name),
+				))
+			}
+		}
+	}
+
+	if v.cfg.MaxRoutesPerGateway > 0 {
+		for _, gw := range gateways {
+			if countAttachedRoutes(g, gw, key) >= v.cfg.MaxRoutesPerGateway {
+				result.Errors = append(result.Errors, field.Forbidden(
+					field.NewPath("spec").Child("parentRefs"),
+					fmt.Sprintf("Gateway %s already has the maximum of %d Routes", gw, v.cfg.MaxRoutesPerGateway),
+				))
+			}
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return admission.Denied(result.Errors.ToAggregate().Error())
+	}
+
+	resp := admission.Allowed("")
+	if len(result.Warnings) > 0 {
+		warnings := make([]string, 0, len(result.Warnings))
+		for _, w := range result.Warnings {
+			warnings = append(warnings, "unsupported field is ignored: "+w.Error())
+		}
+
+		resp = resp.WithWarnings(warnings...)
+	}
+
+	return resp
+}
+
+// getNGFGateways returns the Gateways of NGF that the parentRefs reference.
+func getNGFGateways(g *graph.Graph, routeNamespace string, parentRefs []v1.ParentReference) []types.NamespacedName {
+	if g == nil {
+		return nil
+	}
+
+	var gateways []types.NamespacedName
+	for _, ref := range parentRefs {
+		if ref.Group != nil && *ref.Group != v1.GroupName {
+			continue
+		}
+
+		if ref.Kind != nil && *ref.Kind != kinds.Gateway {
+			continue
+		}
+
+		nsName := types.NamespacedName{Namespace: routeNamespace, Name: string(ref.Name)}
+		if ref.Namespace != nil {
+			nsName.Namespace = string(*ref.Namespace)
+		}
+
+		if _, exists := g.Gateways[nsName]; exists && !slices.Contains(gateways, nsName) {
+			gateways = append(gateways, nsName)
+		}
+	}
+
+	return gateways
+}
+
+// countAttachedRoutes counts the Routes attached to the Gateway, except for the Route with the key.
+func countAttachedRoutes(g *graph.Graph, gw types.NamespacedName, except graph.RouteKey) int {
+	var count int
+
+	isAttached := func(refs []graph.ParentRef) bool {
+		for _, ref := range refs {
+			if ref.Gateway != nil && ref.Gateway.NamespacedName == gw && ref.Attachment != nil && ref.Attachment.Attached {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for key, route := range g.Routes {
+		if key == except || strings.HasPrefix(key.NamespacedName.Name, mirrorRouteNamePrefix) {
+			continue
+		}
+
+		if isAttached(route.ParentRefs) {
+			count++
+		}
+	}
+
+	for _, route := range g.L4Routes {
+		if isAttached(route.ParentRefs) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,360 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	ngfAPI "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha1"
+	ngfAPIv1alpha2 "github.com/nginx/nginx-gateway-fabric/v2/apis/v1alpha2"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/graph"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/controller/state/validation/validationfakes"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/kinds"
+)
+
+type fakeGraphGetter struct {
+	g *graph.Graph
+}
+
+func (f fakeGraphGetter) GetLatestGraph() *graph.Graph {
+	return f.g
+}
+
+func createRequest(t *testing.T, kind string, operation admissionv1.Operation, obj client.Object) admission.Request {
+	t.Helper()
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Kind: kind},
+			Operation: operation,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func TestHandle(t *testing.T) {
+	t.Parallel()
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	createAttachedRoute := func(name string) *graph.L7Route {
+		return &graph.L7Route{
+			Source: &v1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name}},
+			ParentRefs: []graph.ParentRef{
+				{
+					Gateway:    &graph.ParentRefGateway{NamespacedName: gwNsName},
+					Attachment: &graph.ParentRefAttachmentStatus{Attached: true},
+				},
+			},
+		}
+	}
+
+	g := &graph.Graph{
+		Gateways: map[types.NamespacedName]*graph.Gateway{gwNsName: {}},
+		Routes: map[graph.RouteKey]*graph.L7Route{
+			{
+				NamespacedName: types.NamespacedName{Namespace: "test", Name: "existing"},
+				RouteType:      graph.RouteTypeHTTP,
+			}: createAttachedRoute("existing"),
+			{
+				NamespacedName: types.NamespacedName{Namespace: "test", Name: "_ngf-internal-mirror-existing-test/svc-0"},
+				RouteType:      graph.RouteTypeHTTP,
+			}: createAttachedRoute("_ngf-internal-mirror-existing-test/svc-0"),
+		},
+	}
+
+	warnGraph := &graph.Graph{
+		GatewayClass: &graph.GatewayClass{
+			NginxProxy: &graph.NginxProxy{
+				Source: &ngfAPIv1alpha2.NginxProxy{
+					Spec: ngfAPIv1alpha2.NginxProxySpec{
+						UnknownExtensionRefFilters: helpers.GetPointer(ngfAPIv1alpha2.UnknownExtensionRefFilterWarn),
+					},
+				},
+				Valid: true,
+			},
+		},
+		Gateways: g.Gateways,
+		Routes:   g.Routes,
+	}
+
+	createHTTPRoute := func(name, gateway string, filters ...v1.HTTPRouteFilter) *v1.HTTPRoute {
+		return &v1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name},
+			Spec: v1.HTTPRouteSpec{
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{{Name: v1.ObjectName(gateway)}},
+				},
+				Rules: []v1.HTTPRouteRule{{Filters: filters}},
+			},
+		}
+	}
+
+	unsupportedFilter := v1.HTTPRouteFilter{Type: v1.HTTPRouteFilterCORS}
+	snippetsFilterRef := v1.HTTPRouteFilter{
+		Type: v1.HTTPRouteFilterExtensionRef,
+		ExtensionRef: &v1.LocalObjectReference{
+			Group: ngfAPI.GroupName,
+			Kind:  kinds.SnippetsFilter,
+			Name:  "snippets",
+		},
+	}
+
+	unknownFilterRef := v1.HTTPRouteFilter{
+		Type: v1.HTTPRouteFilterExtensionRef,
+		ExtensionRef: &v1.LocalObjectReference{
+			Group: "other.example.com",
+			Kind:  "OtherFilter",
+			Name:  "other",
+		},
+	}
+
+	snippetsFilter := &ngfAPI.SnippetsFilter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "snippets"},
+		Spec: ngfAPI.SnippetsFilterSpec{
+			Snippets: []ngfAPI.Snippet{{Context: ngfAPI.NginxContextHTTP, Value: "log_format custom 'custom';"}},
+		},
+	}
+
+	tests := []struct {
+		graph           *graph.Graph
+		req             func(t *testing.T) admission.Request
+		name            string
+		expMessage      string
+		expWarnings     []string
+		maxRoutes       int
+		snippetsFilters bool
+		expAllowed      bool
+	}{
+		{
+			name:  "valid HTTPRoute",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Create, createHTTPRoute("hr", "gateway"))
+			},
+			expAllowed: true,
+		},
+		{
+			name:  "HTTPRoute with an unsupported filter",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := createHTTPRoute("hr", "gateway", unsupportedFilter)
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Update, route)
+			},
+			expMessage: `spec.rules[0].filters[0].type: Unsupported value: "CORS"`,
+		},
+		{
+			name:  "HTTPRoute with an unsupported field",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := createHTTPRoute("hr", "gateway")
+				route.Spec.Rules[0].Retry = &v1.HTTPRouteRetry{}
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Create, route)
+			},
+			expAllowed:  true,
+			expWarnings: []string{"unsupported field is ignored: spec.rules[0].retry: Forbidden: Retry"},
+		},
+		{
+			name:  "HTTPRoute with an unknown ExtensionRef filter",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := createHTTPRoute("hr", "gateway", unknownFilterRef)
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Create, route)
+			},
+			expMessage: `spec.rules[0].filters[0].extensionRef: Unsupported value: "other.example.com"`,
+		},
+		{
+			name:  "HTTPRoute with an unknown ExtensionRef filter when the GatewayClass warns about unknown filters",
+			graph: warnGraph,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := createHTTPRoute("hr", "gateway", unknownFilterRef)
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Create, route)
+			},
+			expAllowed: true,
+			expWarnings: []string{
+				`unsupported field is ignored: spec.rules[0].filters[0].extensionRef: Unsupported value: ` +
+					`"other.example.com": supported values: "gateway.nginx.org"`,
+				`unsupported field is ignored: spec.rules[0].filters[0].extensionRef: Unsupported value: ` +
+					`"OtherFilter": supported values: "SnippetsFilter"`,
+			},
+		},
+		{
+			name:  "HTTPRoute of another implementation",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := createHTTPRoute("hr", "other-gateway", unsupportedFilter)
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Create, route)
+			},
+			expAllowed: true,
+		},
+		{
+			name: "HTTPRoute before the graph is built",
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := createHTTPRoute("hr", "gateway", unsupportedFilter)
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Create, route)
+			},
+			expAllowed: true,
+		},
+		{
+			name:  "HTTPRoute referencing a SnippetsFilter when SnippetsFilters are disabled",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := createHTTPRoute("hr", "gateway", snippetsFilterRef)
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Create, route)
+			},
+			expMessage: `SnippetsFilter "snippets" cannot be referenced, because SnippetsFilters are disabled`,
+		},
+		{
+			name:  "HTTPRoute referencing a SnippetsFilter when SnippetsFilters are enabled",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := createHTTPRoute("hr", "gateway", snippetsFilterRef)
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Create, route)
+			},
+			snippetsFilters: true,
+			expAllowed:      true,
+		},
+		{
+			name:  "HTTPRoute exceeding the maximum Routes of the Gateway",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Create, createHTTPRoute("hr", "gateway"))
+			},
+			maxRoutes:  1,
+			expMessage: "Gateway test/gateway already has the maximum of 1 Routes",
+		},
+		{
+			name:  "update of an HTTPRoute that is counted in the maximum Routes of the Gateway",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := createHTTPRoute("existing", "gateway")
+				return createRequest(t, kinds.HTTPRoute, admissionv1.Update, route)
+			},
+			maxRoutes:  1,
+			expAllowed: true,
+		},
+		{
+			name:  "GRPCRoute with an unsupported filter",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				route := &v1.GRPCRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "grpc"},
+					Spec: v1.GRPCRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								{
+									Namespace: helpers.GetPointer[v1.Namespace]("test"),
+									Name:      "gateway",
+								},
+							},
+						},
+						Rules: []v1.GRPCRouteRule{{Filters: []v1.GRPCRouteFilter{{Type: "URLRewrite"}}}},
+					},
+				}
+				return createRequest(t, kinds.GRPCRoute, admissionv1.Create, route)
+			},
+			expMessage: `spec.rules[0].filters[0].type: Unsupported value: "URLRewrite"`,
+		},
+		{
+			name: "SnippetsFilter when SnippetsFilters are disabled",
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				return createRequest(t, kinds.SnippetsFilter, admissionv1.Create, snippetsFilter)
+			},
+			expMessage: "SnippetsFilters are disabled",
+		},
+		{
+			name: "valid SnippetsFilter",
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				return createRequest(t, kinds.SnippetsFilter, admissionv1.Create, snippetsFilter)
+			},
+			snippetsFilters: true,
+			expAllowed:      true,
+		},
+		{
+			name: "invalid SnippetsFilter",
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				filter := snippetsFilter.DeepCopy()
+				filter.Spec.Snippets = nil
+				return createRequest(t, kinds.SnippetsFilter, admissionv1.Create, filter)
+			},
+			snippetsFilters: true,
+			expMessage:      "spec.snippets: Required value: at least one snippet must be provided",
+		},
+		{
+			name:  "deleted HTTPRoute",
+			graph: g,
+			req: func(t *testing.T) admission.Request {
+				t.Helper()
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Kind:      metav1.GroupVersionKind{Kind: kinds.HTTPRoute},
+						Operation: admissionv1.Delete,
+					},
+				}
+			},
+			expAllowed: true,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := v1.Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := ngfAPI.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			validator := NewValidator(scheme, Config{
+				GraphGetter:         fakeGraphGetter{g: test.graph},
+				Validator:           &validationfakes.FakeHTTPFieldsValidator{},
+				MaxRoutesPerGateway: test.maxRoutes,
+				SnippetsFilters:     test.snippetsFilters,
+			})
+
+			resp := validator.Handle(context.Background(), test.req(t))
+
+			g.Expect(resp.Allowed).To(Equal(test.expAllowed))
+			g.Expect(resp.Warnings).To(Equal(test.expWarnings))
+			if test.expMessage != "" {
+				g.Expect(resp.Result.Message).To(ContainSubstring(test.expMessage))
+			}
+		})
+	}
+}
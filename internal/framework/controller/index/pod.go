@@ -7,6 +7,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// PodIPIndexField is the name of the field index of the Pods by their PodIP.
+const PodIPIndexField = "status.podIP"
+
 // PodIPIndexFunc is a client.IndexerFunc that parses a Pod object and returns the PodIP.
 // Used by the gRPC token validator for validating a connection from NGINX agent.
 func PodIPIndexFunc(obj client.Object) []string {
@@ -4,15 +4,18 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/events"
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
 	ngftypes "github.com/nginx/nginx-gateway-fabric/v2/internal/framework/types"
 )
 
@@ -61,6 +64,14 @@ func (r *Reconciler) mustCreateNewObject(objectType ngftypes.ObjectType) ngftype
 		return partialObj
 	}
 
+	if u, ok := objectType.(*unstructured.Unstructured); ok {
+		// unstructured objects need the GroupVersionKind to be fetched
+		unstructuredObj := &unstructured.Unstructured{}
+		unstructuredObj.SetGroupVersionKind(u.GroupVersionKind())
+
+		return unstructuredObj
+	}
+
 	// without Elem(), t will be a pointer to the type. For example, *v1.Gateway, not v1.Gateway
 	t := reflect.TypeOf(objectType).Elem()
 
@@ -78,11 +89,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	// The controller runtime has set the logger with the group, kind, namespace and name of the resource,
 	// and a few other key/value pairs. So we don't need to set them here.
 
+	start := time.Now()
 	logger.Info("Reconciling the resource")
 
 	if r.cfg.NamespacedNameFilter != nil {
 		if shouldProcess, msg := r.cfg.NamespacedNameFilter(req.NamespacedName); !shouldProcess {
-			logger.Info(msg)
+			logger.Info(msg, logging.ResultValues(time.Since(start), logging.OutcomeSkipped)...)
 			return reconcile.Result{}, nil
 		}
 	}
@@ -91,7 +103,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	if err := r.cfg.Getter.Get(ctx, req.NamespacedName, obj); err != nil {
 		if !apierrors.IsNotFound(err) {
-			logger.Error(err, "Failed to get the resource")
+			logger.Error(err, "Failed to get the resource", logging.ResultValues(time.Since(start), logging.OutcomeError)...)
 			return reconcile.Result{}, err
 		}
 		// The resource does not exist (was deleted).
@@ -116,12 +128,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	select {
 	case <-ctx.Done():
-		logger.Info("Did not process the resource because the context was canceled")
+		logger.Info(
+			"Did not process the resource because the context was canceled",
+			logging.ResultValues(time.Since(start), logging.OutcomeSkipped)...,
+		)
 		return reconcile.Result{}, nil
 	case r.cfg.EventCh <- e:
 	}
 
-	logger.Info(fmt.Sprintf("%s the resource", op))
+	logger.Info(fmt.Sprintf("%s the resource", op), logging.ResultValues(time.Since(start), logging.OutcomeSuccess)...)
 
 	return reconcile.Result{}, nil
 }
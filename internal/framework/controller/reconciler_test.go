@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -203,6 +204,45 @@ var _ = Describe("Reconciler", func() {
 		})
 	})
 
+	Describe("Unstructured objects", func() {
+		It("should get the object with the GroupVersionKind of the ObjectType", func() {
+			gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "CustomRoute"}
+
+			objectType := &unstructured.Unstructured{}
+			objectType.SetGroupVersionKind(gvk)
+
+			rec = controller.NewReconciler(controller.ReconcilerConfig{
+				Getter:     fakeGetter,
+				ObjectType: objectType,
+				EventCh:    eventCh,
+			})
+
+			fakeGetter.GetCalls(func(
+				_ context.Context,
+				nsname types.NamespacedName,
+				object client.Object,
+				_ ...client.GetOption,
+			) error {
+				Expect(object.GetObjectKind().GroupVersionKind()).To(Equal(gvk))
+				object.SetNamespace(nsname.Namespace)
+				object.SetName(nsname.Name)
+
+				return nil
+			})
+
+			resultCh := startReconciling(hr1NsName)
+
+			var event interface{}
+			Eventually(eventCh).Should(Receive(&event))
+			upsert, ok := event.(*events.UpsertEvent)
+			Expect(ok).To(BeTrue(), "event is not *events.UpsertEvent")
+			Expect(upsert.Resource.GetObjectKind().GroupVersionKind()).To(Equal(gvk))
+			Expect(client.ObjectKeyFromObject(upsert.Resource)).To(Equal(hr1NsName))
+
+			Eventually(resultCh).Should(Receive(Equal(result{err: nil, reconcileResult: reconcile.Result{}})))
+		})
+	})
+
 	Describe("Edge cases", func() {
 		BeforeEach(func() {
 			rec = controller.NewReconciler(controller.ReconcilerConfig{
@@ -0,0 +1,99 @@
+package errcodes
+
+// Path codes.
+var (
+	// InvalidPath is used when a path used in a filter, like a rewrite or a redirect, is invalid.
+	InvalidPath = Code{ID: "NGF1101", Name: "InvalidPath"}
+	// InvalidPathInMatch is used when a path used in a match is invalid.
+	InvalidPathInMatch = Code{ID: "NGF1102", Name: "InvalidPathInMatch"}
+	// InvalidRegexPathInMatch is used when a regular expression path used in a match is invalid.
+	InvalidRegexPathInMatch = Code{ID: "NGF1103", Name: "InvalidRegexPathInMatch"}
+)
+
+// Header codes.
+var (
+	// InvalidHeaderNameInMatch is used when the name of a header used in a match is invalid.
+	InvalidHeaderNameInMatch = Code{ID: "NGF1201", Name: "InvalidHeaderNameInMatch"}
+	// InvalidHeaderValueInMatch is used when the value of a header used in a match is invalid.
+	InvalidHeaderValueInMatch = Code{ID: "NGF1202", Name: "InvalidHeaderValueInMatch"}
+	// InvalidHeaderName is used when the name of a header that is set, added or removed is invalid.
+	InvalidHeaderName = Code{ID: "NGF1203", Name: "InvalidHeaderName"}
+	// InvalidHeaderValue is used when the value of a header that is set or added is invalid.
+	InvalidHeaderValue = Code{ID: "NGF1204", Name: "InvalidHeaderValue"}
+)
+
+// Query parameter codes.
+var (
+	// InvalidQueryParamNameInMatch is used when the name of a query parameter used in a match is invalid.
+	InvalidQueryParamNameInMatch = Code{ID: "NGF1301", Name: "InvalidQueryParamNameInMatch"}
+	// InvalidQueryParamValueInMatch is used when the value of a query parameter used in a match is invalid.
+	InvalidQueryParamValueInMatch = Code{ID: "NGF1302", Name: "InvalidQueryParamValueInMatch"}
+)
+
+// Hostname codes.
+var (
+	// InvalidHostname is used when a hostname of a listener, a route, or a filter is invalid.
+	InvalidHostname = Code{ID: "NGF1401", Name: "InvalidHostname"}
+)
+
+// Generic NGINX value codes.
+var (
+	// InvalidEscapedString is used when a string surrounded by double quotes in the NGINX configuration is invalid.
+	InvalidEscapedString = Code{ID: "NGF1501", Name: "InvalidEscapedString"}
+	// InvalidServiceName is used when a service name is invalid.
+	InvalidServiceName = Code{ID: "NGF1502", Name: "InvalidServiceName"}
+	// InvalidNginxDuration is used when a duration in the NGINX format is invalid.
+	InvalidNginxDuration = Code{ID: "NGF1503", Name: "InvalidNginxDuration"}
+	// InvalidNginxSize is used when a size in the NGINX format is invalid.
+	InvalidNginxSize = Code{ID: "NGF1504", Name: "InvalidNginxSize"}
+	// InvalidEndpoint is used when an endpoint is invalid.
+	InvalidEndpoint = Code{ID: "NGF1505", Name: "InvalidEndpoint"}
+	// InvalidNginxVariableName is used when an NGINX variable name is invalid.
+	InvalidNginxVariableName = Code{ID: "NGF1506", Name: "InvalidNginxVariableName"}
+	// InvalidDuration is used when a Gateway API duration can't be converted to the NGINX format.
+	InvalidDuration = Code{ID: "NGF1507", Name: "InvalidDuration"}
+)
+
+// NGINX configuration codes.
+var (
+	// NginxConfigApplyFailed is used when NGINX fails to apply the configuration, for example, to reload.
+	NginxConfigApplyFailed = Code{ID: "NGF2001", Name: "NginxConfigApplyFailed"}
+	// NginxUpstreamUpdateFailed is used when the upstream servers fail to be updated using the NGINX Plus API.
+	NginxUpstreamUpdateFailed = Code{ID: "NGF2002", Name: "NginxUpstreamUpdateFailed"}
+	// NginxConfigDeliveryFailed is used when the configuration fails to be delivered to a data plane
+	// that runs without an agent.
+	NginxConfigDeliveryFailed = Code{ID: "NGF2003", Name: "NginxConfigDeliveryFailed"}
+)
+
+// Control plane configuration codes.
+var (
+	// ControlPlaneUpdateFailed is used when the control plane fails to apply the NginxGateway configuration.
+	ControlPlaneUpdateFailed = Code{ID: "NGF2101", Name: "ControlPlaneUpdateFailed"}
+)
+
+// All returns all codes, ordered by their IDs.
+func All() []Code {
+	return []Code{
+		InvalidPath,
+		InvalidPathInMatch,
+		InvalidRegexPathInMatch,
+		InvalidHeaderNameInMatch,
+		InvalidHeaderValueInMatch,
+		InvalidHeaderName,
+		InvalidHeaderValue,
+		InvalidQueryParamNameInMatch,
+		InvalidQueryParamValueInMatch,
+		InvalidHostname,
+		InvalidEscapedString,
+		InvalidServiceName,
+		InvalidNginxDuration,
+		InvalidNginxSize,
+		InvalidEndpoint,
+		InvalidNginxVariableName,
+		InvalidDuration,
+		NginxConfigApplyFailed,
+		NginxUpstreamUpdateFailed,
+		NginxConfigDeliveryFailed,
+		ControlPlaneUpdateFailed,
+	}
+}
@@ -0,0 +1,21 @@
+/*
+Package errcodes defines the stable error codes of the control plane.
+
+Every validation and configuration generation failure that is surfaced to users, in resource conditions, Events,
+or logs, carries a code like NGF1203. The code and its name never change once released, so that runbooks and
+automation can match on the code instead of on the message, which can change between releases.
+
+A coded error is formatted as "[NGF1203 InvalidHeaderName] message", so that the code is preserved when the error is
+flattened into a string, like a condition message. The codes are grouped by their first two digits:
+
+	NGF11xx - paths
+	NGF12xx - headers
+	NGF13xx - query parameters
+	NGF14xx - hostnames
+	NGF15xx - generic NGINX values, like durations and sizes
+	NGF20xx - applying the NGINX configuration
+	NGF21xx - applying the control plane configuration
+
+New codes must be added to the end of their group; existing codes must never be renumbered or reused.
+*/
+package errcodes
@@ -0,0 +1,125 @@
+package errcodes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
+)
+
+// Code is a stable error code.
+type Code struct {
+	// ID is the unique identifier of the code, like NGF1203.
+	ID string
+	// Name is the human-readable name of the code, like InvalidHeaderName.
+	Name string
+}
+
+// String returns the ID and the name of the code, like "NGF1203 InvalidHeaderName".
+func (c Code) String() string {
+	return c.ID + " " + c.Name
+}
+
+// Error is an error with a Code.
+type Error struct {
+	Err  error
+	Code Code
+}
+
+// Error returns the message of the error prefixed with the code, like "[NGF1203 InvalidHeaderName] message".
+func (e *Error) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns a new error with the code and the message.
+func New(code Code, msg string) error {
+	return &Error{Code: code, Err: errors.New(msg)}
+}
+
+// Wrap returns the error with the code. If the error is nil, it returns nil. If the error already has the code,
+// it is returned as is, so that the code isn't repeated in the message.
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, c := range Codes(err) {
+		if c == code {
+			return err
+		}
+	}
+
+	return &Error{Code: code, Err: err}
+}
+
+// Codes returns the codes of the error and of all the errors it wraps, including the errors joined with
+// errors.Join, in depth-first order and without duplicates.
+func Codes(err error) []Code {
+	var codes []Code
+
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+
+		if coded, ok := err.(*Error); ok { //nolint:errorlint // the wrapped errors are walked below
+			found := false
+			for _, c := range codes {
+				if c == coded.Code {
+					found = true
+					break
+				}
+			}
+			if !found {
+				codes = append(codes, coded.Code)
+			}
+		}
+
+		switch wrapped := err.(type) { //nolint:errorlint // the wrapped errors are walked explicitly
+		case interface{ Unwrap() error }:
+			walk(wrapped.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, e := range wrapped.Unwrap() {
+				walk(e)
+			}
+		}
+	}
+
+	walk(err)
+
+	return codes
+}
+
+// IDs returns the comma-separated IDs of the codes of the error, like "NGF2001,NGF2002".
+// It returns an empty string if the error has no codes.
+func IDs(err error) string {
+	codes := Codes(err)
+
+	ids := make([]string, 0, len(codes))
+	for _, c := range codes {
+		ids = append(ids, c.ID)
+	}
+
+	return strings.Join(ids, ",")
+}
+
+// Layer is a logging.Layer that adds the codes of the logged errors under the logging.KeyErrorCode key,
+// so that the error logs can be queried by the code.
+type Layer struct {
+	logging.BaseLayer
+}
+
+// Error adds the codes of the error to the key-value pairs of an error log entry.
+func (Layer) Error(err error, msg string, keysAndValues []any) (error, string, []any) {
+	if ids := IDs(err); ids != "" {
+		keysAndValues = append(keysAndValues, logging.KeyErrorCode, ids)
+	}
+
+	return err, msg, keysAndValues
+}
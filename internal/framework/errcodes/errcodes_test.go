@@ -0,0 +1,145 @@
+package errcodes
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	err := New(InvalidHeaderName, "must be a valid HTTP header name")
+
+	g.Expect(err).To(MatchError("[NGF1203 InvalidHeaderName] must be a valid HTTP header name"))
+	g.Expect(Codes(err)).To(Equal([]Code{InvalidHeaderName}))
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(Wrap(InvalidPath, nil)).ToNot(HaveOccurred())
+
+	original := errors.New("invalid")
+	wrapped := Wrap(InvalidPath, original)
+	g.Expect(wrapped).To(MatchError("[NGF1101 InvalidPath] invalid"))
+	g.Expect(wrapped).To(MatchError(original))
+
+	g.Expect(Wrap(InvalidPath, wrapped)).To(BeIdenticalTo(wrapped))
+	g.Expect(Wrap(InvalidPathInMatch, wrapped)).To(
+		MatchError("[NGF1102 InvalidPathInMatch] [NGF1101 InvalidPath] invalid"),
+	)
+}
+
+func TestCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err      error
+		name     string
+		expected []Code
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:     "error without a code",
+			err:      errors.New("plain"),
+			expected: nil,
+		},
+		{
+			name:     "wrapped with fmt.Errorf",
+			err:      fmt.Errorf("failed: %w", New(InvalidHostname, "invalid")),
+			expected: []Code{InvalidHostname},
+		},
+		{
+			name: "joined errors",
+			err: errors.Join(
+				Wrap(NginxConfigApplyFailed, errors.New("reload failed")),
+				errors.New("plain"),
+				Wrap(NginxUpstreamUpdateFailed, errors.New("update failed")),
+				Wrap(NginxConfigApplyFailed, errors.New("reload failed again")),
+			),
+			expected: []Code{NginxConfigApplyFailed, NginxUpstreamUpdateFailed},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(Codes(test.err)).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestIDs(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(IDs(errors.New("plain"))).To(BeEmpty())
+
+	err := errors.Join(
+		New(NginxConfigApplyFailed, "reload failed"),
+		New(NginxUpstreamUpdateFailed, "update failed"),
+	)
+	g.Expect(IDs(err)).To(Equal("NGF2001,NGF2002"))
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	ids := make(map[string]struct{})
+	names := make(map[string]struct{})
+
+	codes := All()
+	for i, c := range codes {
+		g.Expect(c.ID).To(MatchRegexp(`^NGF\d{4}$`))
+		g.Expect(c.Name).To(MatchRegexp(`^[A-Z][A-Za-z]+$`))
+
+		g.Expect(ids).ToNot(HaveKey(c.ID))
+		g.Expect(names).ToNot(HaveKey(c.Name))
+		ids[c.ID] = struct{}{}
+		names[c.Name] = struct{}{}
+
+		if i > 0 {
+			g.Expect(c.ID > codes[i-1].ID).To(BeTrue(), "codes must be ordered by their IDs")
+		}
+	}
+}
+
+func TestLayer(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var logs []string
+	base := funcr.New(
+		func(prefix, args string) {
+			logs = append(logs, fmt.Sprintf("%s %s", prefix, args))
+		},
+		funcr.Options{},
+	)
+
+	logger := logging.WithLayers(base, Layer{}).WithName("test").WithValues("key", "value")
+
+	logger.Error(New(NginxConfigApplyFailed, "reload failed"), "failed to update")
+	logger.Error(errors.New("plain"), "failed")
+	logger.Info("info")
+
+	g.Expect(logs).To(HaveLen(3))
+	g.Expect(logs[0]).To(ContainSubstring(`"errorCode"="NGF2001"`))
+	g.Expect(logs[0]).To(ContainSubstring(`"key"="value"`))
+	g.Expect(logs[1]).ToNot(ContainSubstring("errorCode"))
+	g.Expect(logs[2]).ToNot(ContainSubstring("errorCode"))
+}
@@ -0,0 +1,201 @@
+package eventrecorder
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// aggregationKey identifies identical Events.
+type aggregationKey struct {
+	objectType string
+	namespace  string
+	name       string
+	uid        string
+	eventType  string
+	reason     string
+	message    string
+}
+
+// aggregationRecord tracks the Events that were suppressed since an Event was last emitted.
+type aggregationRecord struct {
+	lastEmitted time.Time
+	// object and annotations are of the last suppressed Event. They are used to emit the suppressed count
+	// when the Event doesn't recur before the interval ends.
+	object       runtime.Object
+	annotations  map[string]string
+	suppressed   int
+	flushPending bool
+}
+
+// NewAggregatingEventRecorder returns an EventRecorder that emits identical Events (same object, type, reason,
+// and message) at most once per interval. The first occurrence is emitted right away. Occurrences within the
+// interval are counted, and the count is added to the message of the next Event that is emitted for that key.
+// If the Event doesn't recur after the interval ends, the count is emitted when the interval ends.
+func NewAggregatingEventRecorder(recorder record.EventRecorder, interval time.Duration) record.EventRecorder {
+	return &aggregatingEventRecorder{
+		recorder: recorder,
+		interval: interval,
+		records:  make(map[aggregationKey]*aggregationRecord),
+		now:      time.Now,
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+	}
+}
+
+type aggregatingEventRecorder struct {
+	recorder  record.EventRecorder
+	records   map[aggregationKey]*aggregationRecord
+	now       func() time.Time
+	afterFunc func(time.Duration, func())
+	lastPrune time.Time
+	interval  time.Duration
+	lock      sync.Mutex
+}
+
+func (r *aggregatingEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if msg, emit := r.aggregate(object, nil, eventtype, reason, message); emit {
+		r.recorder.Event(object, eventtype, reason, msg)
+	}
+}
+
+func (r *aggregatingEventRecorder) Eventf(
+	object runtime.Object,
+	eventtype,
+	reason,
+	messageFmt string,
+	args ...any,
+) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *aggregatingEventRecorder) AnnotatedEventf(
+	object runtime.Object,
+	annotations map[string]string,
+	eventtype,
+	reason,
+	messageFmt string,
+	args ...any,
+) {
+	msg, emit := r.aggregate(object, annotations, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+	if emit {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", msg)
+	}
+}
+
+// aggregate records the occurrence of the Event and returns the message to emit and whether the Event
+// should be emitted.
+func (r *aggregatingEventRecorder) aggregate(
+	object runtime.Object,
+	annotations map[string]string,
+	eventtype,
+	reason,
+	message string,
+) (string, bool) {
+	key := aggregationKey{
+		objectType: fmt.Sprintf("%T", object),
+		eventType:  eventtype,
+		reason:     reason,
+		message:    message,
+	}
+
+	if accessor, err := meta.Accessor(object); err == nil {
+		key.namespace = accessor.GetNamespace()
+		key.name = accessor.GetName()
+		key.uid = string(accessor.GetUID())
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	r.prune(now)
+
+	rec, exists := r.records[key]
+	if !exists {
+		r.records[key] = &aggregationRecord{lastEmitted: now}
+		return message, true
+	}
+
+	if now.Sub(rec.lastEmitted) < r.interval {
+		rec.suppressed++
+		rec.object = object
+		rec.annotations = annotations
+
+		if !rec.flushPending {
+			rec.flushPending = true
+			r.afterFunc(rec.lastEmitted.Add(r.interval).Sub(now), func() { r.flush(key) })
+		}
+
+		return "", false
+	}
+
+	return r.emit(rec, message, now), true
+}
+
+// flush emits the suppressed count of the Event when the interval ends and the Event hasn't recurred.
+func (r *aggregatingEventRecorder) flush(key aggregationKey) {
+	r.lock.Lock()
+
+	now := r.now()
+
+	rec, exists := r.records[key]
+	// The count was already emitted if the Event recurred after the interval, and a new interval started.
+	if !exists || rec.suppressed == 0 || now.Sub(rec.lastEmitted) < r.interval {
+		r.lock.Unlock()
+		return
+	}
+
+	object, annotations := rec.object, rec.annotations
+	message := r.emit(rec, key.message, now)
+
+	r.lock.Unlock()
+
+	if annotations != nil {
+		r.recorder.AnnotatedEventf(object, annotations, key.eventType, key.reason, "%s", message)
+	} else {
+		r.recorder.Event(object, key.eventType, key.reason, message)
+	}
+}
+
+// emit resets the record and returns the message with the number of suppressed occurrences.
+func (r *aggregatingEventRecorder) emit(rec *aggregationRecord, message string, now time.Time) string {
+	if rec.suppressed > 0 {
+		message = fmt.Sprintf(
+			"%s (occurred %d more times in the last %s)",
+			message,
+			rec.suppressed,
+			now.Sub(rec.lastEmitted).Round(time.Second),
+		)
+	}
+
+	rec.lastEmitted = now
+	rec.suppressed = 0
+	rec.object = nil
+	rec.annotations = nil
+	rec.flushPending = false
+
+	return message
+}
+
+// prune removes the records of Events that haven't been emitted for two intervals, so that the records don't
+// grow unbounded. Such records have no suppressed count, because it is flushed when the interval ends.
+// The records are pruned at most once per interval.
+func (r *aggregatingEventRecorder) prune(now time.Time) {
+	if now.Sub(r.lastPrune) < r.interval {
+		return
+	}
+
+	for key, rec := range r.records {
+		if now.Sub(rec.lastEmitted) >= 2*r.interval {
+			delete(r.records, key)
+		}
+	}
+
+	r.lastPrune = now
+}
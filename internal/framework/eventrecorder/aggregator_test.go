@@ -0,0 +1,150 @@
+package eventrecorder
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestAggregatingEventRecorder(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	fakeRecorder := record.NewFakeRecorder(10)
+	recorder := NewAggregatingEventRecorder(fakeRecorder, time.Minute)
+
+	now := time.Now()
+	recorder.(*aggregatingEventRecorder).now = func() time.Time { return now }
+
+	var flushes []func()
+	recorder.(*aggregatingEventRecorder).afterFunc = func(_ time.Duration, f func()) { flushes = append(flushes, f) }
+
+	obj := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "secret"}}
+	otherObj := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "other"}}
+
+	// first occurrence is emitted
+	recorder.Eventf(obj, v1.EventTypeWarning, "Invalid", "bad key %s", "tls.key")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Warning Invalid bad key tls.key")))
+
+	// identical events within the interval are suppressed
+	for range 3 {
+		recorder.Event(obj, v1.EventTypeWarning, "Invalid", "bad key tls.key")
+	}
+	g.Expect(fakeRecorder.Events).ToNot(Receive())
+
+	// events for other objects, reasons, or messages are emitted
+	recorder.Event(otherObj, v1.EventTypeWarning, "Invalid", "bad key tls.key")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Warning Invalid bad key tls.key")))
+	recorder.Event(obj, v1.EventTypeWarning, "Invalid", "bad key tls.crt")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Warning Invalid bad key tls.crt")))
+	recorder.AnnotatedEventf(obj, map[string]string{"a": "b"}, v1.EventTypeNormal, "Valid", "count %d", 1)
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Normal Valid count 1 map[a:b]")))
+
+	// after the interval, the event is emitted with the number of suppressed occurrences
+	now = now.Add(90 * time.Second)
+	recorder.Event(obj, v1.EventTypeWarning, "Invalid", "bad key tls.key")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal(
+		"Warning Invalid bad key tls.key (occurred 3 more times in the last 1m30s)",
+	)))
+
+	// the flush is a no-op, because the suppressed count was already emitted
+	g.Expect(flushes).To(HaveLen(1))
+	flushes[0]()
+	g.Expect(fakeRecorder.Events).ToNot(Receive())
+
+	// nothing was suppressed in the last interval
+	now = now.Add(time.Minute)
+	recorder.AnnotatedEventf(obj, map[string]string{"a": "b"}, v1.EventTypeNormal, "Valid", "count %d", 1)
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Normal Valid count 1 map[a:b]")))
+}
+
+func TestAggregatingEventRecorder_Flush(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	fakeRecorder := record.NewFakeRecorder(10)
+	recorder := NewAggregatingEventRecorder(fakeRecorder, time.Minute).(*aggregatingEventRecorder)
+
+	now := time.Now()
+	recorder.now = func() time.Time { return now }
+
+	var delays []time.Duration
+	var flushes []func()
+	recorder.afterFunc = func(d time.Duration, f func()) {
+		delays = append(delays, d)
+		flushes = append(flushes, f)
+	}
+
+	obj := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "secret"}}
+	annotations := map[string]string{"a": "b"}
+
+	recorder.Event(obj, v1.EventTypeWarning, "Invalid", "bad key")
+	recorder.AnnotatedEventf(obj, annotations, v1.EventTypeNormal, "Valid", "good key")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Warning Invalid bad key")))
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Normal Valid good key map[a:b]")))
+
+	// the suppressed events are flushed once when the interval ends
+	now = now.Add(20 * time.Second)
+	for range 2 {
+		recorder.Event(obj, v1.EventTypeWarning, "Invalid", "bad key")
+	}
+	recorder.AnnotatedEventf(obj, annotations, v1.EventTypeNormal, "Valid", "good key")
+	g.Expect(fakeRecorder.Events).ToNot(Receive())
+	g.Expect(delays).To(Equal([]time.Duration{40 * time.Second, 40 * time.Second}))
+
+	now = now.Add(40 * time.Second)
+	for _, flush := range flushes {
+		flush()
+	}
+	g.Expect(fakeRecorder.Events).To(Receive(Equal(
+		"Warning Invalid bad key (occurred 2 more times in the last 1m0s)",
+	)))
+	g.Expect(fakeRecorder.Events).To(Receive(Equal(
+		"Normal Valid good key (occurred 1 more times in the last 1m0s) map[a:b]",
+	)))
+
+	// the flush starts a new interval
+	now = now.Add(30 * time.Second)
+	recorder.Event(obj, v1.EventTypeWarning, "Invalid", "bad key")
+	g.Expect(fakeRecorder.Events).ToNot(Receive())
+	g.Expect(delays).To(HaveLen(3))
+	g.Expect(delays[2]).To(Equal(30 * time.Second))
+
+	// nothing is emitted when there is nothing to flush
+	flushes[0]()
+	g.Expect(fakeRecorder.Events).ToNot(Receive())
+}
+
+func TestAggregatingEventRecorder_Prune(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	fakeRecorder := record.NewFakeRecorder(10)
+	recorder := NewAggregatingEventRecorder(fakeRecorder, time.Minute).(*aggregatingEventRecorder)
+
+	now := time.Now()
+	recorder.now = func() time.Time { return now }
+
+	obj := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "secret"}}
+
+	recorder.Event(obj, v1.EventTypeWarning, "Invalid", "first")
+	recorder.Event(obj, v1.EventTypeWarning, "Invalid", "second")
+	g.Expect(recorder.records).To(HaveLen(2))
+
+	now = now.Add(90 * time.Second)
+	recorder.Event(obj, v1.EventTypeWarning, "Invalid", "second")
+	g.Expect(recorder.records).To(HaveLen(2))
+
+	now = now.Add(90 * time.Second)
+	recorder.Event(obj, v1.EventTypeWarning, "Invalid", "third")
+
+	messages := make([]string, 0, len(recorder.records))
+	for key := range recorder.records {
+		messages = append(messages, key.message)
+	}
+	g.Expect(messages).To(ConsistOf("second", "third"))
+}
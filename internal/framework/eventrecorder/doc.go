@@ -0,0 +1,8 @@
+/*
+Package eventrecorder provides Kubernetes EventRecorders that control which Events the control plane emits.
+
+The aggregating EventRecorder deduplicates identical Events, such as the same warning about an invalid resource
+that is emitted on every reconcile, so that they don't flood the namespace with Events. The number of suppressed
+Events is reported when the aggregation interval ends.
+*/
+package eventrecorder
@@ -1,6 +1,9 @@
 package events
 
 import (
+	"context"
+	"time"
+
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -23,3 +26,19 @@ type DeleteEvent struct {
 	// NamespacedName is the namespace & name of the deleted resource.
 	NamespacedName types.NamespacedName
 }
+
+type batchReceivedTimeKey struct{}
+
+// ContextWithBatchReceivedTime returns a copy of the context that holds the time when the first event
+// of the batch was received.
+func ContextWithBatchReceivedTime(ctx context.Context, receivedTime time.Time) context.Context {
+	return context.WithValue(ctx, batchReceivedTimeKey{}, receivedTime)
+}
+
+// BatchReceivedTime returns the time when the first event of the batch that is being handled was received
+// by the EventLoop. It can be used to measure how long it takes for a resource change to take effect.
+// It returns false if the context doesn't belong to the handling of a batch.
+func BatchReceivedTime(ctx context.Context) (time.Time, bool) {
+	receivedTime, ok := ctx.Value(batchReceivedTimeKey{}).(time.Time)
+	return receivedTime, ok
+}
@@ -2,6 +2,7 @@ package events
 
 import (
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
@@ -27,10 +28,29 @@ func TestEventLoop_SwapBatches(t *testing.T) {
 
 	eventLoop.nextBatch = nextBatch
 
+	receivedTime := time.Now()
+	eventLoop.nextBatchReceivedTime = receivedTime
+
 	eventLoop.swapBatches()
 
 	g.Expect(eventLoop.currentBatch).To(HaveLen(len(nextBatch)))
 	g.Expect(eventLoop.currentBatch).To(Equal(nextBatch))
 	g.Expect(eventLoop.nextBatch).To(BeEmpty())
 	g.Expect(eventLoop.nextBatch).To(HaveCap(3))
+	g.Expect(eventLoop.currentBatchReceivedTime).To(Equal(receivedTime))
+}
+
+func TestBatchReceivedTime(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	_, ok := BatchReceivedTime(t.Context())
+	g.Expect(ok).To(BeFalse())
+
+	receivedTime := time.Now()
+	ctx := ContextWithBatchReceivedTime(t.Context(), receivedTime)
+
+	batchReceivedTime, ok := BatchReceivedTime(ctx)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(batchReceivedTime).To(Equal(receivedTime))
 }
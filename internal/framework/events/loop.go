@@ -3,8 +3,11 @@ package events
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
 )
 
 // EventLoop is the main event loop of the Gateway. It handles events coming through the event channel.
@@ -35,6 +38,10 @@ type EventLoop struct {
 	currentBatch EventBatch
 	nextBatch    EventBatch
 
+	// the times when the first event of the current and next batches were received
+	currentBatchReceivedTime time.Time
+	nextBatchReceivedTime    time.Time
+
 	// the ID of the current batch
 	currentBatchID int
 }
@@ -65,17 +72,17 @@ func (el *EventLoop) Start(ctx context.Context) error {
 	handlingDone := make(chan struct{})
 
 	handleBatch := func() {
-		go func(batch EventBatch) {
+		go func(batch EventBatch, receivedTime time.Time) {
 			el.currentBatchID++
-			batchLogger := el.logger.WithName("eventHandler").WithValues("batchID", el.currentBatchID)
+			batchLogger := el.logger.WithName("eventHandler").WithValues(logging.KeyBatchID, el.currentBatchID)
 
 			batchLogger.V(1).Info("Handling events from the batch", "total", len(batch))
 
-			el.handler.HandleEventBatch(ctx, batchLogger, batch)
+			el.handler.HandleEventBatch(ContextWithBatchReceivedTime(ctx, receivedTime), batchLogger, batch)
 
 			batchLogger.V(1).Info("Finished handling the batch")
 			handlingDone <- struct{}{}
-		}(el.currentBatch)
+		}(el.currentBatch, el.currentBatchReceivedTime)
 	}
 
 	swapAndHandleBatch := func() {
@@ -96,6 +103,7 @@ func (el *EventLoop) Start(ctx context.Context) error {
 	// not trigger any reconfiguration after receiving an upsert for an existing resource with the same Generation.
 
 	var err error
+	el.currentBatchReceivedTime = time.Now()
 	el.currentBatch, err = el.preparer.Prepare(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to prepare the first batch: %w", err)
@@ -117,6 +125,10 @@ func (el *EventLoop) Start(ctx context.Context) error {
 			}
 			return nil
 		case e := <-el.eventCh:
+			if len(el.nextBatch) == 0 {
+				el.nextBatchReceivedTime = time.Now()
+			}
+
 			// Add the event to the current batch.
 			el.nextBatch = append(el.nextBatch, e)
 
@@ -145,4 +157,5 @@ func (el *EventLoop) Start(ctx context.Context) error {
 func (el *EventLoop) swapBatches() {
 	el.currentBatch, el.nextBatch = el.nextBatch, el.currentBatch
 	el.nextBatch = el.nextBatch[:0]
+	el.currentBatchReceivedTime = el.nextBatchReceivedTime
 }
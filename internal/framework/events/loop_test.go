@@ -74,6 +74,19 @@ var _ = Describe("EventLoop", func() {
 			Expect(batch).Should(Equal(expectedBatch))
 		})
 
+		It("should pass the time when the batch was received", func() {
+			before := time.Now()
+			eventCh <- "event"
+
+			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(2))
+			ctx, _, _ := fakeHandler.HandleEventBatchArgsForCall(1)
+
+			receivedTime, ok := events.BatchReceivedTime(ctx)
+			Expect(ok).To(BeTrue())
+			Expect(receivedTime).To(BeTemporally(">=", before))
+			Expect(receivedTime).To(BeTemporally("<=", time.Now()))
+		})
+
 		It("should batch multiple events", func() {
 			firstHandleEventBatchCallInProgress := make(chan struct{})
 			sentSecondAndThirdEvents := make(chan struct{})
@@ -35,12 +35,20 @@ const (
 const (
 	// Service is the Service kind.
 	Service = "Service"
+	// Ingress is the Ingress kind.
+	Ingress = "Ingress"
 )
 
 // NGINX Gateway Fabric kinds.
 const (
+	// Backend is the Backend kind.
+	Backend = "Backend"
 	// ClientSettingsPolicy is the ClientSettingsPolicy kind.
 	ClientSettingsPolicy = "ClientSettingsPolicy"
+	// ClusterDefaultPolicy is the ClusterDefaultPolicy kind.
+	ClusterDefaultPolicy = "ClusterDefaultPolicy"
+	// GatewayTest is the GatewayTest kind.
+	GatewayTest = "GatewayTest"
 	// ObservabilityPolicy is the ObservabilityPolicy kind.
 	ObservabilityPolicy = "ObservabilityPolicy"
 	// NginxProxy is the NginxProxy kind.
@@ -0,0 +1,9 @@
+/*
+Package loadshed provides adaptive client-side rate limiting for the requests to the Kubernetes API server.
+
+When the API server throttles the control plane (responds with 429 Too Many Requests), or when the control plane
+falls behind on processing events, the low-priority requests, such as status updates, are deferred, so that
+the requests that affect the data plane keep flowing. Low-priority requests that would be deferred for too long
+are shed, and are expected to be retried by their callers.
+*/
+package loadshed
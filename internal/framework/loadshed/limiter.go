@@ -0,0 +1,303 @@
+package loadshed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// DefaultMinBackoff is the default backoff after the API server throttles a request for the first time.
+	DefaultMinBackoff = time.Second
+	// DefaultMaxBackoff is the default maximum backoff after the API server repeatedly throttles requests.
+	DefaultMaxBackoff = time.Minute
+	// DefaultMaxDefer is the default maximum time a low-priority request is deferred before it is shed instead.
+	DefaultMaxDefer = 15 * time.Second
+	// DefaultQueueDepthThreshold is the default number of pending events above which the control plane is
+	// considered to fall behind.
+	DefaultQueueDepthThreshold = 500
+	// DefaultQueuePressureWindow is the default time for which low-priority requests are deferred after
+	// the control plane fell behind on processing events.
+	DefaultQueuePressureWindow = 5 * time.Second
+)
+
+// ErrShed is returned for a low-priority request that is shed because of the pressure on the API server.
+var ErrShed = errors.New("request was shed because of the pressure on the Kubernetes API server")
+
+// Priority is the priority of a request to the API server.
+type Priority int
+
+const (
+	// PriorityHigh is the priority of the requests that affect the data plane. It is the default priority.
+	PriorityHigh Priority = iota
+	// PriorityLow is the priority of the requests that can be deferred or shed under pressure, like status updates.
+	PriorityLow
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of the context that sets the priority of the API server requests made with it.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+func priorityFromContext(ctx context.Context) Priority {
+	priority, ok := ctx.Value(priorityKey{}).(Priority)
+	if !ok {
+		return PriorityHigh
+	}
+
+	return priority
+}
+
+// MetricsCollector collects metrics about the deferred and shed requests.
+type MetricsCollector interface {
+	IncThrottledResponses()
+	ObserveDeferred(duration time.Duration)
+	IncShed()
+}
+
+// LimiterConfig is the configuration of the Limiter.
+type LimiterConfig struct {
+	// Base rate limits all requests. If nil, the requests are not rate limited unless there is pressure.
+	Base flowcontrol.RateLimiter
+	// MetricsCollector collects metrics about the deferred and shed requests.
+	MetricsCollector MetricsCollector
+	// MinBackoff is the backoff after the API server throttles a request for the first time.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum backoff after the API server repeatedly throttles requests.
+	MaxBackoff time.Duration
+	// MaxDefer is the maximum time a low-priority request is deferred before it is shed instead.
+	MaxDefer time.Duration
+	// QueuePressureWindow is the time for which low-priority requests are deferred after
+	// the control plane fell behind on processing events.
+	QueuePressureWindow time.Duration
+	// QueueDepthThreshold is the number of pending events above which the control plane is
+	// considered to fall behind.
+	QueueDepthThreshold int
+}
+
+// Limiter is a client-go RateLimiter that defers or sheds low-priority requests when the API server
+// throttles the control plane, or when the control plane falls behind on processing events.
+//
+// The backoff doubles every time the API server throttles a request, and halves with every successful
+// response after the backoff expired.
+type Limiter struct {
+	throttledUntil     time.Time
+	queuePressureUntil time.Time
+	cfg                LimiterConfig
+	// now is used to get the current time. Overridden in tests.
+	now     func() time.Time
+	backoff time.Duration
+	lock    sync.Mutex
+}
+
+// NewLimiter creates a new Limiter. The zero values of the config are replaced with the defaults.
+func NewLimiter(cfg LimiterConfig) *Limiter {
+	if cfg.MinBackoff == 0 {
+		cfg.MinBackoff = DefaultMinBackoff
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = DefaultMaxBackoff
+	}
+	if cfg.MaxDefer == 0 {
+		cfg.MaxDefer = DefaultMaxDefer
+	}
+	if cfg.QueuePressureWindow == 0 {
+		cfg.QueuePressureWindow = DefaultQueuePressureWindow
+	}
+	if cfg.QueueDepthThreshold == 0 {
+		cfg.QueueDepthThreshold = DefaultQueueDepthThreshold
+	}
+
+	return &Limiter{
+		cfg: cfg,
+		now: time.Now,
+	}
+}
+
+// SetBase sets the rate limiter that rate limits all requests. It must be called before the Limiter is used.
+func (l *Limiter) SetBase(base flowcontrol.RateLimiter) {
+	l.cfg.Base = base
+}
+
+// TryAccept implements the flowcontrol.RateLimiter interface.
+func (l *Limiter) TryAccept() bool {
+	if l.cfg.Base == nil {
+		return true
+	}
+
+	return l.cfg.Base.TryAccept()
+}
+
+// Accept implements the flowcontrol.RateLimiter interface.
+func (l *Limiter) Accept() {
+	if l.cfg.Base != nil {
+		l.cfg.Base.Accept()
+	}
+}
+
+// Stop implements the flowcontrol.RateLimiter interface.
+func (l *Limiter) Stop() {
+	if l.cfg.Base != nil {
+		l.cfg.Base.Stop()
+	}
+}
+
+// QPS implements the flowcontrol.RateLimiter interface.
+// It returns -1 if the requests are not rate limited.
+func (l *Limiter) QPS() float32 {
+	if l.cfg.Base == nil {
+		return -1
+	}
+
+	return l.cfg.Base.QPS()
+}
+
+// Wait implements the flowcontrol.RateLimiter interface. Low-priority requests are deferred while there is
+// pressure, or shed with ErrShed if they would be deferred for longer than the MaxDefer.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if priorityFromContext(ctx) == PriorityLow {
+		if err := l.deferRequest(ctx); err != nil {
+			return err
+		}
+	}
+
+	if l.cfg.Base == nil {
+		return nil
+	}
+
+	return l.cfg.Base.Wait(ctx)
+}
+
+func (l *Limiter) deferRequest(ctx context.Context) error {
+	delay := l.pressureDelay()
+	if delay <= 0 {
+		return nil
+	}
+
+	if delay > l.cfg.MaxDefer {
+		l.cfg.MetricsCollector.IncShed()
+		return fmt.Errorf("%w: the request would be deferred for %s", ErrShed, delay)
+	}
+
+	l.cfg.MetricsCollector.ObserveDeferred(delay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pressureDelay returns for how long the low-priority requests must be deferred.
+func (l *Limiter) pressureDelay() time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	until := l.throttledUntil
+	if l.queuePressureUntil.After(until) {
+		until = l.queuePressureUntil
+	}
+
+	return until.Sub(l.now())
+}
+
+// ObserveThrottled records that the API server throttled a request and asked to retry it after retryAfter.
+func (l *Limiter) ObserveThrottled(retryAfter time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.backoff == 0 {
+		l.backoff = l.cfg.MinBackoff
+	} else {
+		l.backoff = min(2*l.backoff, l.cfg.MaxBackoff)
+	}
+
+	until := l.now().Add(max(l.backoff, retryAfter))
+	if until.After(l.throttledUntil) {
+		l.throttledUntil = until
+	}
+
+	l.cfg.MetricsCollector.IncThrottledResponses()
+}
+
+// ObserveSuccess records that the API server successfully responded to a request.
+func (l *Limiter) ObserveSuccess() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.backoff == 0 || l.now().Before(l.throttledUntil) {
+		return
+	}
+
+	l.backoff /= 2
+	if l.backoff < l.cfg.MinBackoff {
+		l.backoff = 0
+	}
+}
+
+// ObserveQueueDepth records the number of events that the control plane is about to process.
+func (l *Limiter) ObserveQueueDepth(depth int) {
+	if depth < l.cfg.QueueDepthThreshold {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.queuePressureUntil = l.now().Add(l.cfg.QueuePressureWindow)
+}
+
+// WrapTransport wraps the transport of the API server client, so that the Limiter observes the responses
+// of the API server. It can be used as the WrapTransport of a rest.Config.
+func (l *Limiter) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &roundTripper{next: rt, limiter: l}
+}
+
+type roundTripper struct {
+	next    http.RoundTripper
+	limiter *Limiter
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		rt.limiter.ObserveThrottled(retryAfter(resp))
+	case resp.StatusCode < http.StatusBadRequest:
+		rt.limiter.ObserveSuccess()
+	}
+
+	return resp, nil
+}
+
+// WrappedRoundTripper returns the wrapped RoundTripper, so that client-go can unwrap it.
+func (rt *roundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.next
+}
+
+// retryAfter returns the delay from the Retry-After header of the response, which the API server
+// sets in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
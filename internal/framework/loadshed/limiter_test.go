@@ -0,0 +1,313 @@
+package loadshed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+type fakeMetricsCollector struct {
+	deferred  []time.Duration
+	throttled int
+	shed      int
+}
+
+func (c *fakeMetricsCollector) IncThrottledResponses() {
+	c.throttled++
+}
+
+func (c *fakeMetricsCollector) ObserveDeferred(duration time.Duration) {
+	c.deferred = append(c.deferred, duration)
+}
+
+func (c *fakeMetricsCollector) IncShed() {
+	c.shed++
+}
+
+type fakeRoundTripper struct {
+	resp *http.Response
+	err  error
+}
+
+func (rt *fakeRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return rt.resp, rt.err
+}
+
+func newTestLimiter(collector *fakeMetricsCollector, now *time.Time) *Limiter {
+	limiter := NewLimiter(LimiterConfig{
+		MetricsCollector:    collector,
+		MinBackoff:          10 * time.Millisecond,
+		MaxBackoff:          80 * time.Millisecond,
+		MaxDefer:            50 * time.Millisecond,
+		QueuePressureWindow: 20 * time.Millisecond,
+		QueueDepthThreshold: 10,
+	})
+	limiter.now = func() time.Time {
+		return *now
+	}
+
+	return limiter
+}
+
+func TestNewLimiterDefaults(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	limiter := NewLimiter(LimiterConfig{})
+
+	g.Expect(limiter.cfg.MinBackoff).To(Equal(DefaultMinBackoff))
+	g.Expect(limiter.cfg.MaxBackoff).To(Equal(DefaultMaxBackoff))
+	g.Expect(limiter.cfg.MaxDefer).To(Equal(DefaultMaxDefer))
+	g.Expect(limiter.cfg.QueuePressureWindow).To(Equal(DefaultQueuePressureWindow))
+	g.Expect(limiter.cfg.QueueDepthThreshold).To(Equal(DefaultQueueDepthThreshold))
+	g.Expect(limiter.QPS()).To(Equal(float32(-1)))
+	g.Expect(limiter.TryAccept()).To(BeTrue())
+}
+
+func TestLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		setup          func(*Limiter)
+		expErr         error
+		name           string
+		priority       Priority
+		expDeferred    int
+		expShed        int
+		expNotDeferred bool
+	}{
+		{
+			name:           "high priority without pressure",
+			setup:          func(*Limiter) {},
+			priority:       PriorityHigh,
+			expNotDeferred: true,
+		},
+		{
+			name:           "low priority without pressure",
+			setup:          func(*Limiter) {},
+			priority:       PriorityLow,
+			expNotDeferred: true,
+		},
+		{
+			name: "high priority is not deferred when throttled",
+			setup: func(l *Limiter) {
+				l.ObserveThrottled(0)
+			},
+			priority:       PriorityHigh,
+			expNotDeferred: true,
+		},
+		{
+			name: "low priority is deferred when throttled",
+			setup: func(l *Limiter) {
+				l.ObserveThrottled(0)
+			},
+			priority:    PriorityLow,
+			expDeferred: 1,
+		},
+		{
+			name: "low priority is deferred when the queue is deep",
+			setup: func(l *Limiter) {
+				l.ObserveQueueDepth(10)
+			},
+			priority:    PriorityLow,
+			expDeferred: 1,
+		},
+		{
+			name: "low priority is not deferred when the queue is shallow",
+			setup: func(l *Limiter) {
+				l.ObserveQueueDepth(9)
+			},
+			priority:       PriorityLow,
+			expNotDeferred: true,
+		},
+		{
+			name: "low priority is shed when the API server asks to retry much later",
+			setup: func(l *Limiter) {
+				l.ObserveThrottled(time.Minute)
+			},
+			priority: PriorityLow,
+			expErr:   ErrShed,
+			expShed:  1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			collector := &fakeMetricsCollector{}
+			now := time.Now()
+			limiter := newTestLimiter(collector, &now)
+
+			test.setup(limiter)
+
+			err := limiter.Wait(WithPriority(context.Background(), test.priority))
+			if test.expErr != nil {
+				g.Expect(err).To(MatchError(test.expErr))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			g.Expect(collector.deferred).To(HaveLen(test.expDeferred))
+			g.Expect(collector.shed).To(Equal(test.expShed))
+			if test.expNotDeferred {
+				g.Expect(collector.deferred).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestLimiterWaitContextCanceled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	collector := &fakeMetricsCollector{}
+	now := time.Now()
+	limiter := newTestLimiter(collector, &now)
+	limiter.ObserveThrottled(0)
+
+	ctx, cancel := context.WithCancel(WithPriority(context.Background(), PriorityLow))
+	cancel()
+
+	g.Expect(limiter.Wait(ctx)).To(MatchError(context.Canceled))
+}
+
+func TestLimiterBackoff(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	collector := &fakeMetricsCollector{}
+	now := time.Now()
+	limiter := newTestLimiter(collector, &now)
+
+	expectedBackoffs := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		80 * time.Millisecond,
+		80 * time.Millisecond,
+	}
+
+	for _, expected := range expectedBackoffs {
+		limiter.ObserveThrottled(0)
+		g.Expect(limiter.backoff).To(Equal(expected))
+		g.Expect(limiter.pressureDelay()).To(Equal(expected))
+	}
+
+	g.Expect(collector.throttled).To(Equal(len(expectedBackoffs)))
+
+	// the backoff doesn't decay while the requests are still throttled
+	limiter.ObserveSuccess()
+	g.Expect(limiter.backoff).To(Equal(80 * time.Millisecond))
+
+	now = now.Add(80 * time.Millisecond)
+	g.Expect(limiter.pressureDelay()).To(BeNumerically("<=", 0))
+
+	for _, expected := range []time.Duration{40 * time.Millisecond, 20 * time.Millisecond, 10 * time.Millisecond, 0} {
+		limiter.ObserveSuccess()
+		g.Expect(limiter.backoff).To(Equal(expected))
+	}
+
+	// Retry-After is respected if it is longer than the backoff
+	limiter.ObserveThrottled(30 * time.Millisecond)
+	g.Expect(limiter.backoff).To(Equal(10 * time.Millisecond))
+	g.Expect(limiter.pressureDelay()).To(Equal(30 * time.Millisecond))
+}
+
+func TestLimiterQueuePressure(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	now := time.Now()
+	limiter := newTestLimiter(&fakeMetricsCollector{}, &now)
+
+	limiter.ObserveQueueDepth(100)
+	g.Expect(limiter.pressureDelay()).To(Equal(20 * time.Millisecond))
+
+	now = now.Add(20 * time.Millisecond)
+	g.Expect(limiter.pressureDelay()).To(BeNumerically("<=", 0))
+}
+
+func TestWrapTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		resp         *http.Response
+		err          error
+		name         string
+		expDelay     time.Duration
+		expThrottled int
+	}{
+		{
+			name: "too many requests with Retry-After",
+			resp: &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"1"}},
+			},
+			expDelay:     time.Second,
+			expThrottled: 1,
+		},
+		{
+			name: "too many requests with invalid Retry-After",
+			resp: &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"invalid"}},
+			},
+			expDelay:     10 * time.Millisecond,
+			expThrottled: 1,
+		},
+		{
+			name: "server error",
+			resp: &http.Response{
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "success",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "transport error",
+			err:  errors.New("transport error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			collector := &fakeMetricsCollector{}
+			now := time.Now()
+			limiter := newTestLimiter(collector, &now)
+
+			next := &fakeRoundTripper{resp: test.resp, err: test.err}
+			rt := limiter.WrapTransport(next)
+
+			g.Expect(rt).To(BeAssignableToTypeOf(&roundTripper{}))
+			g.Expect(rt.(*roundTripper).WrappedRoundTripper()).To(Equal(next))
+
+			resp, err := rt.RoundTrip(&http.Request{})
+			g.Expect(resp).To(Equal(test.resp))
+			if test.err != nil {
+				g.Expect(err).To(MatchError(test.err))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			g.Expect(collector.throttled).To(Equal(test.expThrottled))
+			if test.expDelay > 0 {
+				g.Expect(limiter.pressureDelay()).To(Equal(test.expDelay))
+			} else {
+				g.Expect(limiter.pressureDelay()).To(BeNumerically("<=", 0))
+			}
+		})
+	}
+}
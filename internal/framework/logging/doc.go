@@ -0,0 +1,9 @@
+/*
+Package logging defines the structured log schema of the control plane and builds the logger of the control plane
+from layers, like the per-module log level control.
+
+The keys defined in this package are part of the log schema identified by SchemaVersion. Within a schema version,
+keys are never renamed or removed and their value types never change, so that users can rely on them in their log
+processing pipelines. See docs/developer/logging-guidelines.md for more details.
+*/
+package logging
@@ -0,0 +1,154 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// nameSeparator separates the names of a logger, matching the separator used by zapr.
+const nameSeparator = "."
+
+// supportedLevels are the log levels that can be set for a module.
+var supportedLevels = []string{"info", "debug", "error"}
+
+// ModuleLevels controls the log levels of the control plane modules.
+//
+// A module is identified by the name of its logger (set with logr.Logger.WithName). A module level applies to the
+// logger with that name and all of its descendants, unless a more specific module level is set. Loggers that don't
+// belong to a module with a level use the global level.
+//
+// ModuleLevels implements zapcore.LevelEnabler so that it can be used as the level of the zap logger. This allows
+// module levels to be more verbose than the global level.
+type ModuleLevels struct {
+	global  zap.AtomicLevel
+	modules map[string]zapcore.Level
+	lock    sync.RWMutex
+}
+
+// NewModuleLevels creates a new ModuleLevels that uses the global level for loggers without a module level.
+func NewModuleLevels(global zap.AtomicLevel) *ModuleLevels {
+	return &ModuleLevels{
+		global:  global,
+		modules: make(map[string]zapcore.Level),
+	}
+}
+
+// Enabled reports whether the level is enabled globally or for any module.
+func (m *ModuleLevels) Enabled(level zapcore.Level) bool {
+	if m.global.Enabled(level) {
+		return true
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, moduleLevel := range m.modules {
+		if moduleLevel.Enabled(level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetModuleLevels replaces all module levels with the provided levels, keyed by module name.
+// If any level is invalid, none of the levels are changed.
+func (m *ModuleLevels) SetModuleLevels(levels map[string]string) error {
+	parsed := make(map[string]zapcore.Level, len(levels))
+
+	var errs []error
+	for module, level := range levels {
+		parsedLevel, err := parseLevel(level)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid level for module %q: %w", module, err))
+			continue
+		}
+
+		parsed[module] = parsedLevel
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.modules = parsed
+
+	return nil
+}
+
+// enabled reports whether the level is enabled for the logger with the provided name.
+func (m *ModuleLevels) enabled(name string, level zapcore.Level) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for module := name; module != ""; {
+		if moduleLevel, ok := m.modules[module]; ok {
+			return moduleLevel.Enabled(level)
+		}
+
+		idx := strings.LastIndex(module, nameSeparator)
+		if idx < 0 {
+			break
+		}
+		module = module[:idx]
+	}
+
+	return m.global.Enabled(level)
+}
+
+// Layer returns the Layer that applies the module levels. The logger must be built with the ModuleLevels
+// as its level.
+func (m *ModuleLevels) Layer() Layer {
+	return moduleLayer{levels: m}
+}
+
+// moduleLayer is a Layer that filters the log entries based on the level of the module of the logger.
+type moduleLayer struct {
+	BaseLayer
+	levels *ModuleLevels
+}
+
+func (l moduleLayer) LevelEnabled(name string, level int) bool {
+	// logr verbosity levels map to negative zap levels: V(0) is info, V(1) is debug.
+	return l.levels.enabled(name, zapcore.Level(-level))
+}
+
+// ParseModuleLevels parses module levels in the format "module1=level1,module2=level2".
+func ParseModuleLevels(value string) (map[string]string, error) {
+	levels := make(map[string]string)
+	if value == "" {
+		return levels, nil
+	}
+
+	for entry := range strings.SplitSeq(value, ",") {
+		module, level, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || module == "" {
+			return nil, fmt.Errorf("invalid module level %q: must be in the format module=level", entry)
+		}
+
+		if _, err := parseLevel(level); err != nil {
+			return nil, fmt.Errorf("invalid level for module %q: %w", module, err)
+		}
+
+		levels[module] = level
+	}
+
+	return levels, nil
+}
+
+func parseLevel(level string) (zapcore.Level, error) {
+	if !slices.Contains(supportedLevels, level) {
+		return 0, fmt.Errorf("unsupported level %q, must be one of %s", level, strings.Join(supportedLevels, ", "))
+	}
+
+	return zapcore.ParseLevel(level)
+}
@@ -0,0 +1,123 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	ctlrZap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func TestModuleLevels(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	levels := NewModuleLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+
+	g.Expect(levels.Enabled(zapcore.DebugLevel)).To(BeFalse())
+	g.Expect(levels.Enabled(zapcore.InfoLevel)).To(BeTrue())
+
+	g.Expect(levels.SetModuleLevels(map[string]string{
+		"eventHandler":              "debug",
+		"eventHandler.subComponent": "error",
+	})).To(Succeed())
+
+	g.Expect(levels.Enabled(zapcore.DebugLevel)).To(BeTrue())
+
+	g.Expect(levels.enabled("eventHandler", zapcore.DebugLevel)).To(BeTrue())
+	g.Expect(levels.enabled("eventHandler.other", zapcore.DebugLevel)).To(BeTrue())
+	g.Expect(levels.enabled("eventHandler.subComponent", zapcore.InfoLevel)).To(BeFalse())
+	g.Expect(levels.enabled("eventHandler.subComponent.child", zapcore.ErrorLevel)).To(BeTrue())
+	g.Expect(levels.enabled("provisioner", zapcore.DebugLevel)).To(BeFalse())
+	g.Expect(levels.enabled("provisioner", zapcore.InfoLevel)).To(BeTrue())
+	g.Expect(levels.enabled("", zapcore.InfoLevel)).To(BeTrue())
+
+	g.Expect(levels.SetModuleLevels(map[string]string{"provisioner": "trace"})).To(MatchError(
+		ContainSubstring(`invalid level for module "provisioner"`),
+	))
+	// levels are unchanged after an error
+	g.Expect(levels.enabled("eventHandler", zapcore.DebugLevel)).To(BeTrue())
+
+	g.Expect(levels.SetModuleLevels(nil)).To(Succeed())
+	g.Expect(levels.enabled("eventHandler", zapcore.DebugLevel)).To(BeFalse())
+	g.Expect(levels.Enabled(zapcore.DebugLevel)).To(BeFalse())
+}
+
+func TestModuleLevelsLogger(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	levels := NewModuleLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+	g.Expect(levels.SetModuleLevels(map[string]string{"debugModule": "debug", "errorModule": "error"})).To(Succeed())
+
+	var buf bytes.Buffer
+	logger := WithLayers(ctlrZap.New(ctlrZap.Level(levels), ctlrZap.WriteTo(&buf)), levels.Layer())
+
+	logger.WithName("debugModule").V(1).Info("debug from debugModule")
+	logger.WithName("errorModule").Info("info from errorModule")
+	logger.WithName("other").V(1).Info("debug from other")
+	logger.WithName("other").WithValues("key", "value").Info("info from other")
+
+	g.Expect(buf.String()).To(ContainSubstring("debug from debugModule"))
+	g.Expect(buf.String()).ToNot(ContainSubstring("info from errorModule"))
+	g.Expect(buf.String()).ToNot(ContainSubstring("debug from other"))
+	g.Expect(buf.String()).To(ContainSubstring("info from other"))
+}
+
+func TestParseModuleLevels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expected map[string]string
+		name     string
+		value    string
+		expErr   string
+	}{
+		{
+			name:     "empty",
+			value:    "",
+			expected: map[string]string{},
+		},
+		{
+			name:  "valid",
+			value: "eventHandler=debug, provisioner=error",
+			expected: map[string]string{
+				"eventHandler": "debug",
+				"provisioner":  "error",
+			},
+		},
+		{
+			name:   "missing level",
+			value:  "eventHandler",
+			expErr: `invalid module level "eventHandler": must be in the format module=level`,
+		},
+		{
+			name:   "missing module",
+			value:  "=debug",
+			expErr: `invalid module level "=debug": must be in the format module=level`,
+		},
+		{
+			name:   "invalid level",
+			value:  "eventHandler=verbose",
+			expErr: `invalid level for module "eventHandler": unsupported level "verbose", must be one of info, debug, error`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			levels, err := ParseModuleLevels(test.value)
+			if test.expErr != "" {
+				g.Expect(err).To(MatchError(test.expErr))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(levels).To(Equal(test.expected))
+		})
+	}
+}
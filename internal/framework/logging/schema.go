@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SchemaVersion is the version of the log schema. It must be incremented when a key is renamed or removed,
+// or when the type of its value changes.
+const SchemaVersion = "v1"
+
+// The following keys are part of the log schema.
+// Note: the keys namespace, name, and reconcileID match the keys that controller-runtime adds to the logger of
+// a reconciler, so that the logs of the reconcilers and the rest of the control plane can be queried the same way.
+const (
+	// KeySchemaVersion is the key for the version of the log schema. It is added to every log message.
+	KeySchemaVersion = "logSchema"
+	// KeyKind is the key for the kind of the Kubernetes resource.
+	KeyKind = "kind"
+	// KeyNamespace is the key for the namespace of the Kubernetes resource.
+	KeyNamespace = "namespace"
+	// KeyName is the key for the name of the Kubernetes resource.
+	KeyName = "name"
+	// KeyReconcileID is the key for the unique ID of a reconciliation.
+	KeyReconcileID = "reconcileID"
+	// KeyBatchID is the key for the ID of the event batch that is being handled.
+	KeyBatchID = "batchID"
+	// KeyDuration is the key for the duration of an operation, formatted as a Go duration string.
+	KeyDuration = "duration"
+	// KeyOutcome is the key for the outcome of an operation. The value is one of the Outcome constants.
+	KeyOutcome = "outcome"
+	// KeyErrorCode is the key for the comma-separated stable codes of a logged error, like NGF2001.
+	KeyErrorCode = "errorCode"
+)
+
+// Outcome is the outcome of an operation.
+type Outcome string
+
+const (
+	// OutcomeSuccess means the operation succeeded.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeError means the operation failed.
+	OutcomeError Outcome = "error"
+	// OutcomeSkipped means the operation was not performed because it was not needed.
+	OutcomeSkipped Outcome = "skipped"
+)
+
+// ResourceValues returns the schema key/value pairs that identify a Kubernetes resource.
+// For cluster-scoped resources, the namespace is omitted.
+func ResourceValues(kind string, nsName types.NamespacedName) []any {
+	if nsName.Namespace == "" {
+		return []any{KeyKind, kind, KeyName, nsName.Name}
+	}
+
+	return []any{KeyKind, kind, KeyNamespace, nsName.Namespace, KeyName, nsName.Name}
+}
+
+// ResultValues returns the schema key/value pairs that describe the result of an operation.
+func ResultValues(duration time.Duration, outcome Outcome) []any {
+	return []any{KeyDuration, duration.String(), KeyOutcome, string(outcome)}
+}
@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestResourceValues(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(ResourceValues("Gateway", types.NamespacedName{Namespace: "test", Name: "gw"})).To(Equal(
+		[]any{"kind", "Gateway", "namespace", "test", "name", "gw"},
+	))
+
+	g.Expect(ResourceValues("GatewayClass", types.NamespacedName{Name: "nginx"})).To(Equal(
+		[]any{"kind", "GatewayClass", "name", "nginx"},
+	))
+}
+
+func TestResultValues(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(ResultValues(1500*time.Millisecond, OutcomeSuccess)).To(Equal(
+		[]any{"duration", "1.5s", "outcome", "success"},
+	))
+}
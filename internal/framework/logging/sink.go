@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// Layer is a layer of the logger built by WithLayers. A layer can filter the log entries by their level,
+// and transform their messages, errors, and key-value pairs before they are passed to the next layer.
+type Layer interface {
+	// LevelEnabled reports whether the verbosity level is enabled for the logger with the name. The name is
+	// the names of the logger joined by dots, like "eventHandler.provisioner".
+	LevelEnabled(name string, level int) bool
+	// Info returns the message and the key-value pairs of an info log entry.
+	Info(msg string, keysAndValues []any) (string, []any)
+	// Error returns the error, the message, and the key-value pairs of an error log entry.
+	Error(err error, msg string, keysAndValues []any) (error, string, []any)
+	// Values returns the key-value pairs that are added to the logger.
+	Values(keysAndValues []any) []any
+}
+
+// BaseLayer is a Layer that passes all the log entries unchanged. Layers embed it, so that they only implement
+// the methods that they need.
+type BaseLayer struct{}
+
+// LevelEnabled returns true.
+func (BaseLayer) LevelEnabled(string, int) bool {
+	return true
+}
+
+// Info returns the message and the key-value pairs unchanged.
+func (BaseLayer) Info(msg string, keysAndValues []any) (string, []any) {
+	return msg, keysAndValues
+}
+
+// Error returns the error, the message, and the key-value pairs unchanged.
+func (BaseLayer) Error(err error, msg string, keysAndValues []any) (error, string, []any) {
+	return err, msg, keysAndValues
+}
+
+// Values returns the key-value pairs unchanged.
+func (BaseLayer) Values(keysAndValues []any) []any {
+	return keysAndValues
+}
+
+// WithLayers returns a copy of the logger that passes every log entry through the layers, in order,
+// before the entry is written by the sink of the logger.
+func WithLayers(logger logr.Logger, layers ...Layer) logr.Logger {
+	sink := logger.GetSink()
+	if sink == nil || len(layers) == 0 {
+		return logger
+	}
+
+	// account for the extra frame added by the layered sink so that caller information is preserved.
+	if cd, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = cd.WithCallDepth(1)
+	}
+
+	return logger.WithSink(&layeredSink{sink: sink, layers: layers})
+}
+
+// layeredSink is a logr.LogSink that passes the log entries through its layers before passing them
+// to the wrapped sink.
+type layeredSink struct {
+	sink   logr.LogSink
+	name   string
+	layers []Layer
+}
+
+// Init is a no-op since the wrapped sink has already been initialized.
+func (s *layeredSink) Init(_ logr.RuntimeInfo) {}
+
+func (s *layeredSink) Enabled(level int) bool {
+	for _, layer := range s.layers {
+		if !layer.LevelEnabled(s.name, level) {
+			return false
+		}
+	}
+
+	return s.sink.Enabled(level)
+}
+
+func (s *layeredSink) Info(level int, msg string, keysAndValues ...any) {
+	for _, layer := range s.layers {
+		msg, keysAndValues = layer.Info(msg, keysAndValues)
+	}
+
+	s.sink.Info(level, msg, keysAndValues...)
+}
+
+func (s *layeredSink) Error(err error, msg string, keysAndValues ...any) {
+	for _, layer := range s.layers {
+		err, msg, keysAndValues = layer.Error(err, msg, keysAndValues)
+	}
+
+	s.sink.Error(err, msg, keysAndValues...)
+}
+
+func (s *layeredSink) WithValues(keysAndValues ...any) logr.LogSink {
+	for _, layer := range s.layers {
+		keysAndValues = layer.Values(keysAndValues)
+	}
+
+	return &layeredSink{sink: s.sink.WithValues(keysAndValues...), name: s.name, layers: s.layers}
+}
+
+func (s *layeredSink) WithName(name string) logr.LogSink {
+	fullName := name
+	if s.name != "" {
+		fullName = s.name + nameSeparator + name
+	}
+
+	return &layeredSink{sink: s.sink.WithName(name), name: fullName, layers: s.layers}
+}
+
+func (s *layeredSink) WithCallDepth(depth int) logr.LogSink {
+	if cd, ok := s.sink.(logr.CallDepthLogSink); ok {
+		return &layeredSink{sink: cd.WithCallDepth(depth), name: s.name, layers: s.layers}
+	}
+
+	return s
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+)
+
+// suffixLayer is a Layer that appends its suffix to the messages, the errors, and the values,
+// and disables the loggers with the name.
+type suffixLayer struct {
+	suffix   string
+	disabled string
+}
+
+func (l suffixLayer) LevelEnabled(name string, _ int) bool {
+	return name != l.disabled
+}
+
+func (l suffixLayer) Info(msg string, keysAndValues []any) (string, []any) {
+	return msg + l.suffix, keysAndValues
+}
+
+func (l suffixLayer) Error(err error, msg string, keysAndValues []any) (error, string, []any) {
+	return fmt.Errorf("%w%s", err, l.suffix), msg + l.suffix, keysAndValues
+}
+
+func (l suffixLayer) Values(keysAndValues []any) []any {
+	return append(keysAndValues, "layer"+l.suffix, l.suffix)
+}
+
+func TestWithLayers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var logs []string
+	base := funcr.New(
+		func(prefix, args string) {
+			logs = append(logs, fmt.Sprintf("%s %s", prefix, args))
+		},
+		funcr.Options{},
+	)
+
+	logger := WithLayers(
+		base,
+		suffixLayer{suffix: "-a", disabled: "parent.disabledA"},
+		suffixLayer{suffix: "-b", disabled: "parent.disabledB"},
+		BaseLayer{},
+	).WithName("parent")
+
+	logger.WithName("child").WithValues("key", "value").Info("info")
+	logger.WithName("child").Error(errors.New("error"), "failed")
+	logger.WithName("disabledA").Info("disabled")
+	logger.WithName("disabledB").Info("disabled")
+
+	g.Expect(logs).To(HaveLen(2))
+	g.Expect(logs[0]).To(ContainSubstring(`parent/child "level"=0 "msg"="info-a-b"`))
+	g.Expect(logs[0]).To(ContainSubstring(`"key"="value" "layer-a"="-a" "layer-b"="-b"`))
+	g.Expect(logs[1]).To(ContainSubstring(`"msg"="failed-a-b" "error"="error-a-b"`))
+}
+
+func TestWithLayers_NilSink(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	logger := WithLayers(logr.Discard(), BaseLayer{})
+	g.Expect(logger.GetSink()).To(BeNil())
+	logger.Info("no panic")
+}
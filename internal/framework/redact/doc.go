@@ -0,0 +1,8 @@
+/*
+Package redact provides helpers for removing sensitive data, such as private keys, JWTs, and htpasswd
+credentials, from content before it leaves the control plane in logs, events, or debug artifacts.
+
+Any subsystem that emits NGINX configuration, Secret data, or user-provided strings to a destination that
+may be shared (for example, a support bundle) must pass that content through this package first.
+*/
+package redact
@@ -0,0 +1,101 @@
+package redact
+
+import (
+	"strings"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
+)
+
+// sensitiveKeys are substrings of log keys whose values are always redacted, regardless of their contents.
+var sensitiveKeys = []string{"password", "token", "privatekey", "apikey"}
+
+// Layer is a logging.Layer that redacts sensitive data from all messages, errors, and values
+// before they are written.
+type Layer struct {
+	logging.BaseLayer
+}
+
+// Info redacts the message and the key-value pairs of an info log entry.
+func (Layer) Info(msg string, keysAndValues []any) (string, []any) {
+	return String(msg), redactKeysAndValues(keysAndValues)
+}
+
+// Error redacts the error, the message, and the key-value pairs of an error log entry.
+func (Layer) Error(err error, msg string, keysAndValues []any) (error, string, []any) {
+	return Error(err), String(msg), redactKeysAndValues(keysAndValues)
+}
+
+// Values redacts the key-value pairs that are added to the logger.
+func (Layer) Values(keysAndValues []any) []any {
+	return redactKeysAndValues(keysAndValues)
+}
+
+func redactKeysAndValues(keysAndValues []any) []any {
+	if len(keysAndValues) == 0 {
+		return keysAndValues
+	}
+
+	redacted := make([]any, len(keysAndValues))
+	for i, v := range keysAndValues {
+		if i%2 == 0 {
+			redacted[i] = v
+			continue
+		}
+
+		if key, ok := keysAndValues[i-1].(string); ok && isSensitiveKey(key) {
+			redacted[i] = Placeholder
+			continue
+		}
+
+		redacted[i] = value(v)
+	}
+
+	return redacted
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func value(v any) any {
+	switch val := v.(type) {
+	case string:
+		return String(val)
+	case []byte:
+		return Bytes(val)
+	case error:
+		return Error(val)
+	default:
+		return v
+	}
+}
+
+// Error returns an error with any sensitive data redacted from its message. If the message contains no
+// sensitive data, the original error is returned so that it can still be unwrapped.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	if redacted := String(msg); redacted != msg {
+		return &redactedError{msg: redacted}
+	}
+
+	return err
+}
+
+type redactedError struct {
+	msg string
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
@@ -0,0 +1,54 @@
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/logging"
+)
+
+func TestLayer(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var logs []string
+	base := funcr.New(
+		func(prefix, args string) {
+			logs = append(logs, fmt.Sprintf("%s %s", prefix, args))
+		},
+		funcr.Options{},
+	)
+
+	logger := logging.WithLayers(base, Layer{}).WithName("test").WithValues("license", testJWT)
+
+	logger.Info("loaded key "+testPrivateKey, "data", []byte(testPrivateKey), "password", "hunter2", "name", "nginx")
+	logger.Error(errors.New("bad token "+testJWT), "failed", "apiKey", 12345)
+
+	g.Expect(logs).To(HaveLen(2))
+	for _, log := range logs {
+		g.Expect(log).ToNot(ContainSubstring("PRIVATE KEY"))
+		g.Expect(log).ToNot(ContainSubstring(testJWT))
+		g.Expect(log).ToNot(ContainSubstring("hunter2"))
+		g.Expect(log).ToNot(ContainSubstring("12345"))
+		g.Expect(log).To(ContainSubstring(Placeholder))
+		g.Expect(log).To(ContainSubstring("test"))
+	}
+	g.Expect(logs[0]).To(ContainSubstring(`"name"="nginx"`))
+}
+
+func TestError(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(Error(nil)).ToNot(HaveOccurred())
+
+	original := errors.New("plain error")
+	g.Expect(Error(original)).To(BeIdenticalTo(original))
+
+	redacted := Error(fmt.Errorf("failed to parse: %s", testJWT))
+	g.Expect(redacted).To(MatchError("failed to parse: " + Placeholder))
+}
@@ -0,0 +1,37 @@
+package redact
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// NewEventRecorder returns an EventRecorder that redacts sensitive data from event messages before
+// recording them with the provided recorder.
+func NewEventRecorder(recorder record.EventRecorder) record.EventRecorder {
+	return &eventRecorder{recorder: recorder}
+}
+
+type eventRecorder struct {
+	recorder record.EventRecorder
+}
+
+func (r *eventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.recorder.Event(object, eventtype, reason, String(message))
+}
+
+func (r *eventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	r.recorder.Event(object, eventtype, reason, String(fmt.Sprintf(messageFmt, args...)))
+}
+
+func (r *eventRecorder) AnnotatedEventf(
+	object runtime.Object,
+	annotations map[string]string,
+	eventtype,
+	reason,
+	messageFmt string,
+	args ...any,
+) {
+	r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", String(fmt.Sprintf(messageFmt, args...)))
+}
@@ -0,0 +1,26 @@
+package redact
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestEventRecorder(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	fakeRecorder := record.NewFakeRecorder(3)
+	recorder := NewEventRecorder(fakeRecorder)
+
+	obj := &v1.Secret{}
+	recorder.Event(obj, v1.EventTypeWarning, "Invalid", "bad key "+testPrivateKey)
+	recorder.Eventf(obj, v1.EventTypeWarning, "Invalid", "bad token %s", testJWT)
+	recorder.AnnotatedEventf(obj, map[string]string{"a": "b"}, v1.EventTypeNormal, "Valid", "count %d", 1)
+
+	g.Expect(<-fakeRecorder.Events).To(Equal("Warning Invalid bad key " + Placeholder))
+	g.Expect(<-fakeRecorder.Events).To(Equal("Warning Invalid bad token " + Placeholder))
+	g.Expect(<-fakeRecorder.Events).To(Equal("Normal Valid count 1 map[a:b]"))
+}
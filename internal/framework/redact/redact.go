@@ -0,0 +1,60 @@
+package redact
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Placeholder replaces any sensitive data that has been redacted.
+const Placeholder = "[REDACTED]"
+
+var (
+	// privateKeyRegexp matches PEM-encoded private keys of any type (RSA, EC, PKCS#8, encrypted).
+	privateKeyRegexp = regexp.MustCompile(
+		`(?s)-----BEGIN [A-Z0-9 ]*PRIVATE KEY-----.*?-----END [A-Z0-9 ]*PRIVATE KEY-----`,
+	)
+	// jwtRegexp matches a JSON Web Token. The header of a JWT is base64url-encoded JSON, so it always starts with
+	// "eyJ" (the encoding of `{"`).
+	jwtRegexp = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	// htpasswdRegexp matches htpasswd entries that use one of the hashing schemes supported by NGINX.
+	htpasswdRegexp = regexp.MustCompile(
+		`(?m)^([^:#\s]+):(?:\$apr1\$|\$2[aby]\$|\$5\$|\$6\$|\{SHA\}|\{SSHA\}|\{PLAIN\})\S*$`,
+	)
+)
+
+// sensitiveFileSuffixes are file name suffixes of files whose entire contents are sensitive.
+var sensitiveFileSuffixes = []string{".key", ".jwt", "htpasswd"}
+
+// String redacts all private keys, JWTs, and htpasswd credentials found in s.
+func String(s string) string {
+	s = privateKeyRegexp.ReplaceAllLiteralString(s, Placeholder)
+	s = jwtRegexp.ReplaceAllLiteralString(s, Placeholder)
+	s = htpasswdRegexp.ReplaceAllString(s, "${1}:"+Placeholder)
+
+	return s
+}
+
+// Bytes redacts all private keys, JWTs, and htpasswd credentials found in b.
+// The input slice is not modified.
+func Bytes(b []byte) []byte {
+	if len(b) == 0 {
+		return b
+	}
+
+	return []byte(String(string(b)))
+}
+
+// File redacts the contents of the file with the provided name. Files that only hold sensitive data, such as
+// private keys, JWTs, and htpasswd files, are replaced entirely by the Placeholder. All other files have any
+// sensitive data within them redacted.
+func File(name string, contents []byte) []byte {
+	base := strings.ToLower(filepath.Base(name))
+	for _, suffix := range sensitiveFileSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return []byte(Placeholder)
+		}
+	}
+
+	return Bytes(contents)
+}
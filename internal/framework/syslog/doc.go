@@ -0,0 +1,11 @@
+/*
+Package syslog receives the syslog messages that NGINX sends over UDP to the control plane.
+
+The Receiver listens on a UDP address and passes every message to the Handler of the feature that configured NGINX
+to send the messages, for example the attribution of the requests to tenants, or the reporting of the writes
+to the temporary files. UDP doesn't authenticate the senders, so the Receiver passes only the messages of the NGINX
+Pods, which the SourceResolver resolves from the IP addresses of the senders, along with the Gateway of the Pod.
+A feature that receives the messages of several Gateways identifies them with syslog tags,
+which the Tags register and resolve.
+*/
+package syslog
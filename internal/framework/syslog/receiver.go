@@ -0,0 +1,115 @@
+package syslog
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxMessageSize is the maximum size of a syslog message over UDP.
+const maxMessageSize = 65535
+
+// Source is the NGINX Pod that sent a syslog message.
+type Source struct {
+	// Gateway is the namespace and name of the Gateway of the Pod.
+	Gateway types.NamespacedName
+	// Deployment is the namespace and name of the nginx Deployment or DaemonSet of the Pod.
+	Deployment types.NamespacedName
+}
+
+// SourceResolver resolves the NGINX Pod that sent a syslog message from the IP address of the sender.
+type SourceResolver interface {
+	// ResolveSource returns the NGINX Pod with the IP address, and whether the address belongs to an NGINX Pod.
+	ResolveSource(ctx context.Context, addr netip.Addr) (Source, bool)
+}
+
+// Handler handles the syslog messages that the Receiver receives.
+type Handler interface {
+	// Handle handles a syslog message of the NGINX Pod. The message is only valid until Handle returns.
+	Handle(source Source, msg []byte)
+}
+
+// HandlerFunc is a function that implements the Handler interface.
+type HandlerFunc func(source Source, msg []byte)
+
+// Handle calls f(source, msg).
+func (f HandlerFunc) Handle(source Source, msg []byte) {
+	f(source, msg)
+}
+
+// Receiver is a syslog server that receives the messages over UDP and passes them to its Handler.
+// UDP doesn't authenticate the senders, so the Receiver drops the messages that don't come from
+// the IP address of an NGINX Pod, which the SourceResolver resolves.
+type Receiver struct {
+	handler  Handler
+	resolver SourceResolver
+	logger   logr.Logger
+	address  string
+}
+
+// NewReceiver creates a new Receiver that listens on the UDP address.
+func NewReceiver(logger logr.Logger, address string, resolver SourceResolver, handler Handler) *Receiver {
+	return &Receiver{
+		handler:  handler,
+		resolver: resolver,
+		logger:   logger,
+		address:  address,
+	}
+}
+
+// Start starts the Receiver. It blocks until the context is canceled.
+func (r *Receiver) Start(ctx context.Context) error {
+	var lc net.ListenConfig
+	conn, err := lc.ListenPacket(ctx, "udp", r.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", r.address, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := conn.Close(); err != nil {
+			r.logger.Error(err, "failed to close the connection")
+		}
+	}()
+
+	r.logger.Info("Receiving the syslog messages", "address", conn.LocalAddr().String())
+
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read the syslog message: %w", err)
+		}
+
+		source, ok := r.resolveSource(ctx, addr)
+		if !ok {
+			r.logger.V(1).Info("Ignoring the syslog message of an unknown sender", "address", addr.String())
+			continue
+		}
+
+		r.handler.Handle(source, buf[:n])
+	}
+}
+
+// resolveSource resolves the NGINX Pod that sent a message from the address of the sender.
+func (r *Receiver) resolveSource(ctx context.Context, addr net.Addr) (Source, bool) {
+	udpAddr, ok := addr.(*net.UDPAddr)
+	if !ok {
+		return Source{}, false
+	}
+
+	ip, ok := netip.AddrFromSlice(udpAddr.IP)
+	if !ok {
+		return Source{}, false
+	}
+
+	// the IPv4 senders have IPv4-mapped IPv6 addresses if the Receiver listens on a dual-stack socket.
+	return r.resolver.ResolveSource(ctx, ip.Unmap())
+}
@@ -0,0 +1,134 @@
+package syslog
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type sourceResolverFunc func(addr netip.Addr) (Source, bool)
+
+func (f sourceResolverFunc) ResolveSource(_ context.Context, addr netip.Addr) (Source, bool) {
+	return f(addr)
+}
+
+func TestReceiver_Start(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	address := getFreeUDPAddress(t)
+	source := Source{Gateway: types.NamespacedName{Namespace: "default", Name: "gateway"}}
+
+	var lock sync.Mutex
+	var messages []string
+	var sources []Source
+	resolver := sourceResolverFunc(func(addr netip.Addr) (Source, bool) {
+		g.Expect(addr).To(Equal(netip.MustParseAddr("127.0.0.1")))
+		return source, true
+	})
+	receiver := NewReceiver(logr.Discard(), address, resolver, HandlerFunc(func(src Source, msg []byte) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		messages = append(messages, string(msg))
+		sources = append(sources, src)
+	}))
+	getMessages := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+
+		return messages
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- receiver.Start(ctx)
+	}()
+
+	conn, err := net.Dial("udp", address)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer conn.Close()
+
+	msg := "<164>Oct 17 10:00:00 ngf_test: message"
+
+	// the datagrams sent before the receiver listens are lost or refused, so they are sent until one is received
+	g.Eventually(func() []string {
+		_, _ = conn.Write([]byte(msg))
+		return getMessages()
+	}).WithTimeout(5 * time.Second).WithPolling(50 * time.Millisecond).ShouldNot(BeEmpty())
+
+	g.Expect(getMessages()).To(HaveEach(msg))
+
+	lock.Lock()
+	g.Expect(sources).To(HaveEach(source))
+	lock.Unlock()
+
+	cancel()
+	g.Eventually(errCh).WithTimeout(5 * time.Second).Should(Receive(BeNil()))
+}
+
+func TestReceiver_StartUnknownSender(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	address := getFreeUDPAddress(t)
+
+	resolved := make(chan netip.Addr, 100)
+	resolver := sourceResolverFunc(func(addr netip.Addr) (Source, bool) {
+		resolved <- addr
+		return Source{}, false
+	})
+
+	handled := make(chan struct{}, 1)
+	receiver := NewReceiver(logr.Discard(), address, resolver, HandlerFunc(func(Source, []byte) {
+		handled <- struct{}{}
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = receiver.Start(ctx)
+	}()
+
+	conn, err := net.Dial("udp", address)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer conn.Close()
+
+	// the datagrams sent before the receiver listens are lost or refused, so they are sent until one is resolved
+	g.Eventually(func() int {
+		_, _ = conn.Write([]byte("<164>Oct 17 10:00:00 ngf_test: message"))
+		return len(resolved)
+	}).WithTimeout(5 * time.Second).WithPolling(50 * time.Millisecond).ShouldNot(BeZero())
+
+	g.Consistently(handled).WithTimeout(200 * time.Millisecond).ShouldNot(Receive())
+}
+
+func TestReceiver_StartListenError(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	resolver := sourceResolverFunc(func(netip.Addr) (Source, bool) { return Source{}, true })
+	receiver := NewReceiver(logr.Discard(), "invalid-address", resolver, HandlerFunc(func(Source, []byte) {}))
+
+	g.Expect(receiver.Start(context.Background())).To(MatchError(ContainSubstring("failed to listen")))
+}
+
+func getFreeUDPAddress(t *testing.T) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to get a free UDP port: %v", err)
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().String()
+}
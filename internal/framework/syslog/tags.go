@@ -0,0 +1,72 @@
+package syslog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// HashedTag returns the syslog tag of the key, which is the prefix followed by the hash of the key, because
+// NGINX limits a syslog tag to 32 characters.
+func HashedTag(prefix, key string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+
+	return fmt.Sprintf("%s%016x", prefix, h.Sum64())
+}
+
+// CutTag finds the syslog tag that starts with the prefix in the syslog message, and returns the tag and the payload
+// of the message that follows the tag.
+func CutTag(msg []byte, prefix string) (tag string, payload []byte, err error) {
+	idx := bytes.Index(msg, []byte(prefix))
+	if idx < 0 {
+		return "", nil, errors.New("message doesn't have the " + prefix + " tag")
+	}
+
+	rawTag, payload, found := bytes.Cut(msg[idx:], []byte(": "))
+	if !found {
+		return "", nil, errors.New("message doesn't have a payload")
+	}
+
+	return string(rawTag), payload, nil
+}
+
+// Tags maps the hashed syslog tags with a prefix to the values that they identify, for example the Gateways
+// whose NGINX sends the messages.
+type Tags[T any] struct {
+	values map[string]T
+	prefix string
+	lock   sync.RWMutex
+}
+
+// NewTags creates new Tags whose syslog tags start with the prefix.
+func NewTags[T any](prefix string) *Tags[T] {
+	return &Tags[T]{
+		values: make(map[string]T),
+		prefix: prefix,
+	}
+}
+
+// Register registers the value under the syslog tag of the key, and returns the tag.
+func (t *Tags[T]) Register(key string, value T) string {
+	tag := HashedTag(t.prefix, key)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.values[tag] = value
+
+	return tag
+}
+
+// Lookup returns the value registered under the syslog tag.
+func (t *Tags[T]) Lookup(tag string) (T, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	value, exists := t.values[tag]
+
+	return value, exists
+}
@@ -0,0 +1,84 @@
+package syslog
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHashedTag(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tag := HashedTag("ngf_test_", "test/gateway")
+	g.Expect(tag).To(HavePrefix("ngf_test_"))
+	g.Expect(tag).To(MatchRegexp(`^[a-z0-9_]+$`))
+	// NGINX limits the syslog tags to 32 characters
+	g.Expect(len(tag)).To(BeNumerically("<=", 32))
+
+	g.Expect(HashedTag("ngf_test_", "test/gateway")).To(Equal(tag))
+	g.Expect(HashedTag("ngf_test_", "test/other-gateway")).ToNot(Equal(tag))
+}
+
+func TestCutTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		msg        string
+		expTag     string
+		expPayload string
+		expErr     bool
+	}{
+		{
+			name:       "tagged message",
+			msg:        "<164>Oct 17 10:00:00 ngf_test_6c62272e07bb0142: 2026/10/17 10:00:00 [warn] message",
+			expTag:     "ngf_test_6c62272e07bb0142",
+			expPayload: "2026/10/17 10:00:00 [warn] message",
+		},
+		{
+			name:   "no tag",
+			msg:    "<164>Oct 17 10:00:00 nginx: message",
+			expErr: true,
+		},
+		{
+			name:   "no payload",
+			msg:    "<164>Oct 17 10:00:00 ngf_test_6c62272e07bb0142",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			tag, payload, err := CutTag([]byte(test.msg), "ngf_test_")
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(tag).To(Equal(test.expTag))
+			g.Expect(string(payload)).To(Equal(test.expPayload))
+		})
+	}
+}
+
+func TestTags(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tags := NewTags[int]("ngf_test_")
+
+	tag := tags.Register("test/gateway", 1)
+	g.Expect(tag).To(Equal(HashedTag("ngf_test_", "test/gateway")))
+
+	value, exists := tags.Lookup(tag)
+	g.Expect(exists).To(BeTrue())
+	g.Expect(value).To(Equal(1))
+
+	_, exists = tags.Lookup(HashedTag("ngf_test_", "test/unknown"))
+	g.Expect(exists).To(BeFalse())
+}
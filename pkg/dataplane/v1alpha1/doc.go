@@ -0,0 +1,15 @@
+/*
+Package v1alpha1 is a versioned, public copy of the route and upstream model that NGINX Gateway Fabric builds from
+Gateway API resources before it generates NGINX configuration.
+
+The model that the control plane uses internally changes with every release. This package is meant for extension
+authors and tests that need to construct or inspect the routing state without depending on it. The control plane
+converts its internal model into this one, for example, to serve it on the /debug/route-model endpoint.
+
+Only the routing state is included: servers, path and match rules, filters, backend groups, and upstreams.
+Secrets, policies, snippets, and the NGINX-wide settings are not part of the model.
+
+Within v1alpha1, fields may be added, but existing fields are not removed or changed in a way that breaks existing
+users. Such changes require a new version of the package.
+*/
+package v1alpha1
@@ -0,0 +1,253 @@
+package v1alpha1
+
+// APIVersion is the version of the model in this package.
+const APIVersion = "v1alpha1"
+
+// PathType is the type of the path in a PathRule.
+type PathType string
+
+const (
+	// PathTypeExact indicates that the path is exact.
+	PathTypeExact PathType = "exact"
+	// PathTypePrefix indicates that the path is a prefix.
+	PathTypePrefix PathType = "prefix"
+	// PathTypeRegularExpression indicates that the path is a regular expression.
+	PathTypeRegularExpression PathType = "regularExpression"
+)
+
+// MatchType is the type of match for headers and query parameters.
+type MatchType string
+
+const (
+	// MatchTypeExact indicates that the match type is exact.
+	MatchTypeExact MatchType = "Exact"
+	// MatchTypeRegularExpression indicates that the match type is a regular expression.
+	MatchTypeRegularExpression MatchType = "RegularExpression"
+)
+
+// PathModifierType is the type of the path modifier in a redirect or rewrite filter.
+type PathModifierType string
+
+const (
+	// ReplaceFullPath indicates that the full path is replaced.
+	ReplaceFullPath PathModifierType = "ReplaceFullPath"
+	// ReplacePrefixMatch indicates that the prefix match is replaced.
+	ReplacePrefixMatch PathModifierType = "ReplacePrefixMatch"
+)
+
+// Configuration is the routing state of a Gateway.
+type Configuration struct {
+	// APIVersion is the version of the model. It is always APIVersion.
+	APIVersion string `json:"apiVersion"`
+	// HTTPServers are the servers of the HTTP listeners.
+	HTTPServers []VirtualServer `json:"httpServers"`
+	// SSLServers are the servers of the HTTPS listeners.
+	SSLServers []VirtualServer `json:"sslServers"`
+	// TLSPassthroughServers are the servers of the TLS listeners.
+	TLSPassthroughServers []Layer4VirtualServer `json:"tlsPassthroughServers"`
+	// Upstreams are the upstreams of the HTTP and HTTPS servers.
+	Upstreams []Upstream `json:"upstreams"`
+	// StreamUpstreams are the upstreams of the TLS passthrough servers.
+	StreamUpstreams []Upstream `json:"streamUpstreams"`
+	// BackendGroups are all the unique backend groups that the match rules route to.
+	BackendGroups []BackendGroup `json:"backendGroups"`
+}
+
+// VirtualServer is a server for a hostname and port.
+type VirtualServer struct {
+	// Hostname is the hostname of the server.
+	Hostname string `json:"hostname"`
+	// SSLKeyPairID is the ID of the certificate and key of the server. It is empty for HTTP servers.
+	SSLKeyPairID string `json:"sslKeyPairID,omitempty"`
+	// PathRules are the routing rules of the server.
+	PathRules []PathRule `json:"pathRules,omitempty"`
+	// Port is the port of the server.
+	Port int32 `json:"port"`
+	// IsDefault indicates whether the server is the default server of the port.
+	IsDefault bool `json:"isDefault,omitempty"`
+}
+
+// Layer4VirtualServer is a server for Layer 4 traffic.
+type Layer4VirtualServer struct {
+	// Hostname is the hostname of the server.
+	Hostname string `json:"hostname"`
+	// UpstreamName is the name of the upstream that the server routes to.
+	UpstreamName string `json:"upstreamName"`
+	// Port is the port of the server.
+	Port int32 `json:"port"`
+	// IsDefault indicates whether the server is the default server of the port.
+	IsDefault bool `json:"isDefault,omitempty"`
+}
+
+// PathRule holds the routing rules that share a path.
+type PathRule struct {
+	// Path is the path. For example, '/hello'.
+	Path string `json:"path"`
+	// PathType is the type of the path.
+	PathType PathType `json:"pathType"`
+	// MatchRules are the routing rules for the path.
+	MatchRules []MatchRule `json:"matchRules"`
+	// GRPC indicates whether the rules are gRPC rules.
+	GRPC bool `json:"grpc,omitempty"`
+}
+
+// MatchRule is a routing rule. It corresponds to a match of a rule of a route.
+type MatchRule struct {
+	// Route is the route that the rule belongs to.
+	Route ObjectReference `json:"route"`
+	// Match is the match of the rule.
+	Match Match `json:"match"`
+	// Filters are the filters of the rule.
+	Filters HTTPFilters `json:"filters"`
+	// BackendGroup is the group of backends that the rule routes to.
+	BackendGroup BackendGroup `json:"backendGroup"`
+}
+
+// ObjectReference refers to a namespaced Kubernetes resource.
+type ObjectReference struct {
+	// Namespace is the namespace of the resource.
+	Namespace string `json:"namespace"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+}
+
+// Match matches HTTP request attributes.
+type Match struct {
+	// Method matches the HTTP method.
+	Method *string `json:"method,omitempty"`
+	// Headers match the HTTP headers.
+	Headers []HTTPHeaderMatch `json:"headers,omitempty"`
+	// QueryParams match the query parameters.
+	QueryParams []HTTPQueryParamMatch `json:"queryParams,omitempty"`
+}
+
+// HTTPHeaderMatch matches an HTTP header.
+type HTTPHeaderMatch struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+	// Value is the value of the header.
+	Value string `json:"value"`
+	// Type is the type of the match.
+	Type MatchType `json:"type"`
+}
+
+// HTTPQueryParamMatch matches a query parameter.
+type HTTPQueryParamMatch struct {
+	// Name is the name of the query parameter.
+	Name string `json:"name"`
+	// Value is the value of the query parameter.
+	Value string `json:"value"`
+	// Type is the type of the match.
+	Type MatchType `json:"type"`
+}
+
+// HTTPFilters are the filters of a MatchRule.
+type HTTPFilters struct {
+	// RequestRedirect redirects the requests.
+	RequestRedirect *HTTPRequestRedirectFilter `json:"requestRedirect,omitempty"`
+	// RequestURLRewrite rewrites the requests.
+	RequestURLRewrite *HTTPURLRewriteFilter `json:"requestURLRewrite,omitempty"`
+	// RequestHeaderModifiers modify the request headers.
+	RequestHeaderModifiers *HTTPHeaderFilter `json:"requestHeaderModifiers,omitempty"`
+	// ResponseHeaderModifiers modify the response headers.
+	ResponseHeaderModifiers *HTTPHeaderFilter `json:"responseHeaderModifiers,omitempty"`
+	// Invalid indicates that the filters of the rule are invalid. The requests that match the rule get a 500 response,
+	// and all other filters are nil.
+	Invalid bool `json:"invalid,omitempty"`
+}
+
+// HTTPHeader is an HTTP header.
+type HTTPHeader struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+	// Value is the value of the header.
+	Value string `json:"value"`
+}
+
+// HTTPHeaderFilter modifies HTTP headers.
+type HTTPHeaderFilter struct {
+	// Set adds or replaces headers.
+	Set []HTTPHeader `json:"set,omitempty"`
+	// Add adds headers. It appends to any existing values of the header.
+	Add []HTTPHeader `json:"add,omitempty"`
+	// Remove removes headers.
+	Remove []string `json:"remove,omitempty"`
+}
+
+// HTTPRequestRedirectFilter redirects HTTP requests.
+type HTTPRequestRedirectFilter struct {
+	// Scheme is the scheme of the redirect.
+	Scheme *string `json:"scheme,omitempty"`
+	// Hostname is the hostname of the redirect.
+	Hostname *string `json:"hostname,omitempty"`
+	// Port is the port of the redirect.
+	Port *int32 `json:"port,omitempty"`
+	// StatusCode is the HTTP status code of the redirect.
+	StatusCode *int `json:"statusCode,omitempty"`
+	// Path is the path of the redirect.
+	Path *HTTPPathModifier `json:"path,omitempty"`
+}
+
+// HTTPURLRewriteFilter rewrites HTTP requests.
+type HTTPURLRewriteFilter struct {
+	// Hostname is the hostname of the rewrite.
+	Hostname *string `json:"hostname,omitempty"`
+	// Path is the path of the rewrite.
+	Path *HTTPPathModifier `json:"path,omitempty"`
+}
+
+// HTTPPathModifier modifies the path of a request.
+type HTTPPathModifier struct {
+	// Replacement is the value that replaces the full path or the prefix match.
+	Replacement string `json:"replacement"`
+	// Type is the type of the modifier.
+	Type PathModifierType `json:"type"`
+}
+
+// BackendGroup is the group of backends of a rule of a route.
+type BackendGroup struct {
+	// Route is the route that the group belongs to.
+	Route ObjectReference `json:"route"`
+	// Backends are the backends of the group.
+	Backends []Backend `json:"backends"`
+	// RuleIdx is the index of the rule in the route.
+	RuleIdx int `json:"ruleIdx"`
+	// PathRuleIdx is the index of the path rule in the server. Groups of the match rules with the same path
+	// have the same PathRuleIdx.
+	PathRuleIdx int `json:"pathRuleIdx"`
+}
+
+// Backend is a backend of a BackendGroup.
+type Backend struct {
+	// UpstreamName is the name of the upstream of the backend.
+	UpstreamName string `json:"upstreamName"`
+	// ExternalHostname is the hostname of an ExternalName Service. It is used as the Host header.
+	ExternalHostname string `json:"externalHostname,omitempty"`
+	// Weight is the weight of the backend. The possible values are 0-1,000,000.
+	// No traffic is forwarded to a backend with a weight of 0.
+	Weight int32 `json:"weight"`
+	// Valid indicates whether the backend is valid.
+	Valid bool `json:"valid"`
+}
+
+// Upstream is a pool of endpoints to be load balanced.
+type Upstream struct {
+	// Name is the name of the upstream. It is unique for each Service and port.
+	Name string `json:"name"`
+	// ErrorMsg is the reason why the upstream is invalid. It is empty for valid upstreams.
+	ErrorMsg string `json:"errorMsg,omitempty"`
+	// Endpoints are the endpoints of the upstream.
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// Endpoint is an endpoint of an Upstream.
+type Endpoint struct {
+	// Address is the IP address or the DNS name of the endpoint.
+	Address string `json:"address"`
+	// Port is the port of the endpoint.
+	Port int32 `json:"port"`
+	// IPv6 indicates whether the address is an IPv6 address.
+	IPv6 bool `json:"ipv6,omitempty"`
+	// Resolve indicates whether the address is a DNS name that needs to be resolved.
+	Resolve bool `json:"resolve,omitempty"`
+}
@@ -209,3 +209,246 @@ func TestClientSettingsPoliciesKeepAliveTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestClientSettingsPoliciesRequestLimits(t *testing.T) {
+	t.Parallel()
+	k8sClient := getKubernetesClient(t)
+
+	tests := []struct {
+		limits     *ngfAPIv1alpha1.ClientRequestLimits
+		name       string
+		wantErrors []string
+	}{
+		{
+			name: "Validate RequestLimits with maxConcurrentRequests and rate",
+			limits: &ngfAPIv1alpha1.ClientRequestLimits{
+				MaxConcurrentRequests: helpers.GetPointer[int32](10),
+				Rate:                  helpers.GetPointer[ngfAPIv1alpha1.Rate]("10r/s"),
+				Burst:                 helpers.GetPointer[int32](5),
+			},
+		},
+		{
+			name:       "Validate RequestLimits must set maxConcurrentRequests or rate",
+			wantErrors: []string{expectedRequestLimitsEmptyError},
+			limits: &ngfAPIv1alpha1.ClientRequestLimits{
+				PerClient: true,
+			},
+		},
+		{
+			name:       "Validate Burst cannot be set without Rate",
+			wantErrors: []string{expectedBurstWithoutRateError},
+			limits: &ngfAPIv1alpha1.ClientRequestLimits{
+				MaxConcurrentRequests: helpers.GetPointer[int32](10),
+				Burst:                 helpers.GetPointer[int32](5),
+			},
+		},
+		{
+			name: "Validate RejectResponse with retryAfter and body",
+			limits: &ngfAPIv1alpha1.ClientRequestLimits{
+				Rate: helpers.GetPointer[ngfAPIv1alpha1.Rate]("10r/s"),
+				RejectResponse: &ngfAPIv1alpha1.ClientRejectResponse{
+					RetryAfter:  helpers.GetPointer[ngfAPIv1alpha1.Duration]("30s"),
+					ContentType: helpers.GetPointer("application/json"),
+					Body:        helpers.GetPointer(`{"error":"too many requests"}`),
+				},
+			},
+		},
+		{
+			name:       "Validate RejectResponse must set retryAfter or body",
+			wantErrors: []string{expectedRejectResponseEmptyError, expectedContentTypeWithoutBody},
+			limits: &ngfAPIv1alpha1.ClientRequestLimits{
+				Rate: helpers.GetPointer[ngfAPIv1alpha1.Rate]("10r/s"),
+				RejectResponse: &ngfAPIv1alpha1.ClientRejectResponse{
+					ContentType: helpers.GetPointer("application/json"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientSettingsPolicy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+				ObjectMeta: controllerruntime.ObjectMeta{
+					Name:      uniqueResourceName(testResourceName),
+					Namespace: defaultNamespace,
+				},
+				Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+					TargetRef: gatewayv1.LocalPolicyTargetReference{
+						Kind:  httpRouteKind,
+						Group: gatewayGroup,
+						Name:  gatewayv1.ObjectName(uniqueResourceName(testTargetRefName)),
+					},
+					RequestLimits: tt.limits,
+				},
+			}
+			validateCrd(t, tt.wantErrors, clientSettingsPolicy, k8sClient)
+		})
+	}
+}
+
+func TestClientSettingsPoliciesAccess(t *testing.T) {
+	t.Parallel()
+	k8sClient := getKubernetesClient(t)
+
+	tests := []struct {
+		access     *ngfAPIv1alpha1.ClientAccess
+		name       string
+		wantErrors []string
+	}{
+		{
+			name: "Validate Access with windows and cidrs",
+			access: &ngfAPIv1alpha1.ClientAccess{
+				Windows: []ngfAPIv1alpha1.AccessWindow{
+					{
+						Days:  []ngfAPIv1alpha1.Weekday{ngfAPIv1alpha1.Monday},
+						Start: "09:00",
+						End:   "24:00",
+					},
+				},
+				CIDRs: []string{"10.0.0.0/8"},
+			},
+		},
+		{
+			name:       "Validate Access must set windows, cidrs, or header",
+			wantErrors: []string{expectedAccessEmptyError},
+			access: &ngfAPIv1alpha1.ClientAccess{
+				DenyCode: helpers.GetPointer[int32](404),
+			},
+		},
+		{
+			name:       "Validate Access window end must be after start",
+			wantErrors: []string{expectedAccessWindowEndError},
+			access: &ngfAPIv1alpha1.ClientAccess{
+				Windows: []ngfAPIv1alpha1.AccessWindow{
+					{
+						Start: "17:00",
+						End:   "09:00",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientSettingsPolicy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+				ObjectMeta: controllerruntime.ObjectMeta{
+					Name:      uniqueResourceName(testResourceName),
+					Namespace: defaultNamespace,
+				},
+				Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+					TargetRef: gatewayv1.LocalPolicyTargetReference{
+						Kind:  httpRouteKind,
+						Group: gatewayGroup,
+						Name:  gatewayv1.ObjectName(uniqueResourceName(testTargetRefName)),
+					},
+					Access: tt.access,
+				},
+			}
+			validateCrd(t, tt.wantErrors, clientSettingsPolicy, k8sClient)
+		})
+	}
+}
+
+func TestClientSettingsPoliciesAuthentication(t *testing.T) {
+	t.Parallel()
+	k8sClient := getKubernetesClient(t)
+
+	tests := []struct {
+		authentication *ngfAPIv1alpha1.ClientAuthentication
+		name           string
+		wantErrors     []string
+	}{
+		{
+			name: "Validate Authentication with a ServiceAccount",
+			authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: ngfAPIv1alpha1.ClientAuthenticationModeTokenReview,
+				TokenReview: &ngfAPIv1alpha1.TokenReviewAuthentication{
+					Audiences:       []string{"internal-api"},
+					ServiceAccounts: []ngfAPIv1alpha1.ServiceAccountName{"default/reporting"},
+				},
+			},
+		},
+		{
+			name:       "Validate Authentication ServiceAccount must include the namespace",
+			wantErrors: []string{"should match"},
+			authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: ngfAPIv1alpha1.ClientAuthenticationModeTokenReview,
+				TokenReview: &ngfAPIv1alpha1.TokenReviewAuthentication{
+					ServiceAccounts: []ngfAPIv1alpha1.ServiceAccountName{"reporting"},
+				},
+			},
+		},
+		{
+			name:       "Validate Authentication mode must be supported",
+			wantErrors: []string{"Unsupported value"},
+			authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: "Basic",
+			},
+		},
+		{
+			name: "Validate Authentication with a remote endpoint",
+			authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: ngfAPIv1alpha1.ClientAuthenticationModeRemote,
+				Remote: &ngfAPIv1alpha1.RemoteAuthentication{
+					URL:           "https://auth.example.com/verify",
+					Timeout:       helpers.GetPointer[ngfAPIv1alpha1.Duration]("2s"),
+					CacheDuration: helpers.GetPointer[ngfAPIv1alpha1.Duration]("1m"),
+				},
+			},
+		},
+		{
+			name:       "Validate Authentication remote mode requires remote",
+			wantErrors: []string{expectedRemoteWithoutRemoteMode},
+			authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: ngfAPIv1alpha1.ClientAuthenticationModeRemote,
+			},
+		},
+		{
+			name:       "Validate Authentication tokenReview requires the TokenReview mode",
+			wantErrors: []string{expectedTokenReviewWithoutMode},
+			authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: ngfAPIv1alpha1.ClientAuthenticationModeRemote,
+				TokenReview: &ngfAPIv1alpha1.TokenReviewAuthentication{
+					Audiences: []string{"internal-api"},
+				},
+				Remote: &ngfAPIv1alpha1.RemoteAuthentication{
+					URL: "https://auth.example.com/verify",
+				},
+			},
+		},
+		{
+			name:       "Validate Authentication remote URL can't contain quotes",
+			wantErrors: []string{"should match"},
+			authentication: &ngfAPIv1alpha1.ClientAuthentication{
+				Mode: ngfAPIv1alpha1.ClientAuthenticationModeRemote,
+				Remote: &ngfAPIv1alpha1.RemoteAuthentication{
+					URL: `https://auth.example.com/"; return 200; #`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientSettingsPolicy := &ngfAPIv1alpha1.ClientSettingsPolicy{
+				ObjectMeta: controllerruntime.ObjectMeta{
+					Name:      uniqueResourceName(testResourceName),
+					Namespace: defaultNamespace,
+				},
+				Spec: ngfAPIv1alpha1.ClientSettingsPolicySpec{
+					TargetRef: gatewayv1.LocalPolicyTargetReference{
+						Kind:  httpRouteKind,
+						Group: gatewayGroup,
+						Name:  gatewayv1.ObjectName(uniqueResourceName(testTargetRefName)),
+					},
+					Authentication: tt.authentication,
+				},
+			}
+			validateCrd(t, tt.wantErrors, clientSettingsPolicy, k8sClient)
+		})
+	}
+}
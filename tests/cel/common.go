@@ -38,6 +38,14 @@ const (
 	expectedTargetRefKindError       = `TargetRef Kind must be one of: Gateway, HTTPRoute, or GRPCRoute`
 	expectedTargetRefGroupError      = `TargetRef Group must be gateway.networking.k8s.io`
 	expectedHeaderWithoutServerError = `header can only be specified if server is specified`
+	expectedRequestLimitsEmptyError  = `maxConcurrentRequests or rate must be specified`
+	expectedBurstWithoutRateError    = `burst can only be specified if rate is specified`
+	expectedRejectResponseEmptyError = `retryAfter or body must be specified`
+	expectedContentTypeWithoutBody   = `contentType can only be specified if body is specified`
+	expectedRemoteWithoutRemoteMode  = `remote must be specified if and only if mode is Remote`
+	expectedTokenReviewWithoutMode   = `tokenReview can only be specified if mode is TokenReview`
+	expectedAccessEmptyError         = `windows, cidrs, or header must be specified`
+	expectedAccessWindowEndError     = `end must be after start`
 )
 
 // NginxProxy validation errors.
@@ -49,9 +57,10 @@ const (
 
 // ObservabilityPolicy validation errors.
 const (
-	expectedTargetRefMustBeHTTPRouteOrGrpcRouteError = `TargetRef Kind must be: HTTPRoute or GRPCRoute`
-	expectedTargetRefKindAndNameComboMustBeUnique    = `TargetRef Kind and Name combination must be unique`
-	expectedStrategyMustBeOfTypeRatio                = `ratio can only be specified if strategy is of type ratio`
+	expectedTargetRefMustBeGatewayHTTPRouteOrGrpcRouteError = `TargetRef Kind must be: Gateway, HTTPRoute, or GRPCRoute`
+	expectedTargetRefSectionNameMustBeForGateway            = `TargetRef SectionName is only supported for Gateway`
+	expectedTargetRefKindNameAndSectionNameMustBeUnique     = `TargetRef Kind, Name, and SectionName combination must be unique`
+	expectedStrategyMustBeOfTypeRatio                       = `ratio can only be specified if strategy is of type ratio`
 )
 
 // UpstreamSettingsPolicy validation errors.
@@ -59,8 +68,12 @@ const (
 	expectedTargetRefKindServiceError     = `TargetRefs Kind must be: Service`
 	expectedTargetRefGroupCoreError       = `TargetRefs Group must be core`
 	expectedTargetRefNameUniqueError      = `TargetRef Name must be unique`
-	expectedHashKeyLoadBalancingTypeError = `hashMethodKey is required when loadBalancingMethod ` +
+	expectedHashKeyLoadBalancingTypeError = `hashMethodKey or hashKey is required when loadBalancingMethod ` +
 		`is 'hash' or 'hash consistent'`
+	expectedHashKeyMutuallyExclusiveError = `hashMethodKey and hashKey cannot be set together`
+	expectedHashKeyOneOfError             = `exactly one of header or cookie must be set`
+	expectedKeepAliveDisabledError        = `connections, requests, time and timeout cannot be set when ` +
+		`keep-alive is disabled`
 )
 
 // SnippetsFilter validation errors.
@@ -23,10 +23,12 @@ func TestObservabilityPoliciesTargetRefKind(t *testing.T) {
 		{
 			name: "Validate TargetRef of kind HTTPRoute is allowed",
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
@@ -34,46 +36,53 @@ func TestObservabilityPoliciesTargetRefKind(t *testing.T) {
 		{
 			name: "Validate TargetRef of kind GRPCRoute is allowed",
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  grpcRouteKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
 		},
 		{
 			name:       "Validate Invalid TargetRef Kind is not allowed",
-			wantErrors: []string{expectedTargetRefMustBeHTTPRouteOrGrpcRouteError},
+			wantErrors: []string{expectedTargetRefMustBeGatewayHTTPRouteOrGrpcRouteError},
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  invalidKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  invalidKind,
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
 		},
 		{
 			name:       "Validate TCPRoute TargetRef Kind is not allowed",
-			wantErrors: []string{expectedTargetRefMustBeHTTPRouteOrGrpcRouteError},
+			wantErrors: []string{expectedTargetRefMustBeGatewayHTTPRouteOrGrpcRouteError},
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  tcpRouteKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  tcpRouteKind,
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
 		},
 		{
-			name:       "Validate TargetRef of kind Gateway is not allowed",
-			wantErrors: []string{expectedTargetRefMustBeHTTPRouteOrGrpcRouteError},
+			name: "Validate TargetRef of kind Gateway is allowed",
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  gatewayKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  gatewayKind,
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
@@ -81,14 +90,18 @@ func TestObservabilityPoliciesTargetRefKind(t *testing.T) {
 		{
 			name: "Validate ObservabilityPolicy is applied when one TargetRef is valid and another is invalid",
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  gatewayKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  tcpRouteKind,
+							Group: gatewayGroup,
+						},
 					},
 					{
-						Kind:  grpcRouteKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
@@ -128,10 +141,12 @@ func TestObservabilityPoliciesTargetRefGroup(t *testing.T) {
 		{
 			name: "Validate gateway.networking.k8s.io TargetRef Group is allowed",
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
@@ -140,10 +155,12 @@ func TestObservabilityPoliciesTargetRefGroup(t *testing.T) {
 			name:       "Validate invalid.networking.k8s.io TargetRef Group is not allowed",
 			wantErrors: []string{expectedTargetRefGroupError},
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Group: invalidGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Group: invalidGroup,
+						},
 					},
 				},
 			},
@@ -152,10 +169,12 @@ func TestObservabilityPoliciesTargetRefGroup(t *testing.T) {
 			name:       "Validate discovery.k8s.io/v1 TargetRef Group is not allowed",
 			wantErrors: []string{expectedTargetRefGroupError},
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Group: discoveryGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Group: discoveryGroup,
+						},
 					},
 				},
 			},
@@ -194,18 +213,22 @@ func TestObservabilityPoliciesTargetRefKindAndNameCombo(t *testing.T) {
 	}{
 		{
 			name:       "Validate resource is invalid when TargetRef Kind and Name combination is not unique",
-			wantErrors: []string{expectedTargetRefKindAndNameComboMustBeUnique},
+			wantErrors: []string{expectedTargetRefKindNameAndSectionNameMustBeUnique},
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Name:  gatewayv1.ObjectName(testTargetRefName),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
 					},
 					{
-						Kind:  httpRouteKind,
-						Name:  gatewayv1.ObjectName(testTargetRefName),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
@@ -213,16 +236,20 @@ func TestObservabilityPoliciesTargetRefKindAndNameCombo(t *testing.T) {
 		{
 			name: "Validate resource is valid when TargetRef Kind and Name combination is unique using different kinds",
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Name:  gatewayv1.ObjectName(testTargetRefName),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
 					},
 					{
-						Kind:  grpcRouteKind,
-						Name:  gatewayv1.ObjectName(testTargetRefName),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
@@ -230,67 +257,194 @@ func TestObservabilityPoliciesTargetRefKindAndNameCombo(t *testing.T) {
 		{
 			name: "Validate resource is valid when TargetRef Kind and Name combination is unique using different names",
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Name:  gatewayv1.ObjectName(uniqueResourceName(testTargetRefName)),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Name:  gatewayv1.ObjectName(uniqueResourceName(testTargetRefName)),
+							Group: gatewayGroup,
+						},
 					},
 					{
-						Kind:  grpcRouteKind,
-						Name:  gatewayv1.ObjectName(uniqueResourceName(testTargetRefName)),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Name:  gatewayv1.ObjectName(uniqueResourceName(testTargetRefName)),
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
 		},
 		{
 			name:       "Validate three TargetRefs with one duplicate name are not allowed",
-			wantErrors: []string{expectedTargetRefKindAndNameComboMustBeUnique},
+			wantErrors: []string{expectedTargetRefKindNameAndSectionNameMustBeUnique},
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Name:  gatewayv1.ObjectName(uniqueResourceName(testTargetRefName)),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Name:  gatewayv1.ObjectName(uniqueResourceName(testTargetRefName)),
+							Group: gatewayGroup,
+						},
 					},
 					{
-						Kind:  grpcRouteKind,
-						Name:  gatewayv1.ObjectName(testTargetRefName),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
 					},
 					{
-						Kind:  grpcRouteKind,
-						Name:  gatewayv1.ObjectName(testTargetRefName),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
 					},
 				},
 			},
 		},
 		{
 			name:       "Validate multiple duplicate TargetRefs are not allowed",
-			wantErrors: []string{expectedTargetRefKindAndNameComboMustBeUnique},
+			wantErrors: []string{expectedTargetRefKindNameAndSectionNameMustBeUnique},
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  grpcRouteKind,
-						Name:  gatewayv1.ObjectName(fmt.Sprintf("duplicate-group-1-%s", testTargetRefName)),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Name:  gatewayv1.ObjectName(fmt.Sprintf("duplicate-group-1-%s", testTargetRefName)),
+							Group: gatewayGroup,
+						},
 					},
 					{
-						Kind:  grpcRouteKind,
-						Name:  gatewayv1.ObjectName(fmt.Sprintf("duplicate-group-1-%s", testTargetRefName)),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Name:  gatewayv1.ObjectName(fmt.Sprintf("duplicate-group-1-%s", testTargetRefName)),
+							Group: gatewayGroup,
+						},
 					},
 					{
-						Kind:  grpcRouteKind,
-						Name:  gatewayv1.ObjectName(fmt.Sprintf("duplicate-group-2-%s", testTargetRefName)),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Name:  gatewayv1.ObjectName(fmt.Sprintf("duplicate-group-2-%s", testTargetRefName)),
+							Group: gatewayGroup,
+						},
 					},
 					{
-						Kind:  grpcRouteKind,
-						Name:  gatewayv1.ObjectName(fmt.Sprintf("duplicate-group-2-%s", testTargetRefName)),
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  grpcRouteKind,
+							Name:  gatewayv1.ObjectName(fmt.Sprintf("duplicate-group-2-%s", testTargetRefName)),
+							Group: gatewayGroup,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			spec := tt.spec
+
+			observabilityPolicy := &ngfAPIv1alpha2.ObservabilityPolicy{
+				ObjectMeta: controllerruntime.ObjectMeta{
+					Name:      uniqueResourceName(testResourceName),
+					Namespace: defaultNamespace,
+				},
+				Spec: spec,
+			}
+			validateCrd(t, tt.wantErrors, observabilityPolicy, k8sClient)
+		})
+	}
+}
+
+func TestObservabilityPoliciesTargetRefSectionName(t *testing.T) {
+	t.Parallel()
+	k8sClient := getKubernetesClient(t)
+
+	adminListener := gatewayv1.SectionName("admin")
+	publicListener := gatewayv1.SectionName("public")
+
+	tests := []struct {
+		spec       ngfAPIv1alpha2.ObservabilityPolicySpec
+		name       string
+		wantErrors []string
+	}{
+		{
+			name: "Validate SectionName of a Gateway TargetRef is allowed",
+			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  gatewayKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
+						SectionName: &adminListener,
+					},
+				},
+			},
+		},
+		{
+			name:       "Validate SectionName of an HTTPRoute TargetRef is not allowed",
+			wantErrors: []string{expectedTargetRefSectionNameMustBeForGateway},
+			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
+						SectionName: &adminListener,
+					},
+				},
+			},
+		},
+		{
+			name: "Validate TargetRefs to different listeners of the same Gateway are allowed",
+			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  gatewayKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
+						SectionName: &adminListener,
+					},
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  gatewayKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
+						SectionName: &publicListener,
+					},
+				},
+			},
+		},
+		{
+			name:       "Validate TargetRefs to the same listener of a Gateway are not allowed",
+			wantErrors: []string{expectedTargetRefKindNameAndSectionNameMustBeUnique},
+			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  gatewayKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
+						SectionName: &adminListener,
+					},
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  gatewayKind,
+							Name:  gatewayv1.ObjectName(testTargetRefName),
+							Group: gatewayGroup,
+						},
+						SectionName: &adminListener,
 					},
 				},
 			},
@@ -326,10 +480,12 @@ func TestObservabilityPoliciesTracing(t *testing.T) {
 		{
 			name: "Validate ObservabilityPolicy is applied when ratio is set and strategy is TraceStrategyRatio",
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Group: gatewayGroup,
+						},
 					},
 				},
 				Tracing: &ngfAPIv1alpha2.Tracing{
@@ -342,10 +498,12 @@ func TestObservabilityPoliciesTracing(t *testing.T) {
 			name:       "Validate ObservabilityPolicy is invalid when ratio is set and strategy is not TraceStrategyRatio",
 			wantErrors: []string{expectedStrategyMustBeOfTypeRatio},
 			spec: ngfAPIv1alpha2.ObservabilityPolicySpec{
-				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
 					{
-						Kind:  httpRouteKind,
-						Group: gatewayGroup,
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind:  httpRouteKind,
+							Group: gatewayGroup,
+						},
 					},
 				},
 				Tracing: &ngfAPIv1alpha2.Tracing{
@@ -435,6 +435,162 @@ func TestUpstreamSettingsPolicy_LoadBalancing(t *testing.T) {
 				HashMethodKey:       helpers.GetPointer(ngfAPIv1alpha1.HashMethodKey("$upstream_bytes_sent")),
 			},
 		},
+		{
+			name: "specify load balancing method as hash consistent and set the header hash key, no error expected",
+			spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+					{
+						Kind:  serviceKind,
+						Group: coreGroup,
+					},
+				},
+				LoadBalancingMethod: helpers.GetPointer(ngfAPIv1alpha1.LoadBalancingTypeHashConsistent),
+				HashKey: &ngfAPIv1alpha1.HashKey{
+					Header: helpers.GetPointer("X-Tenant-ID"),
+				},
+			},
+		},
+		{
+			name: "specify load balancing method as hash and set the cookie hash key, no error expected",
+			spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+					{
+						Kind:  serviceKind,
+						Group: coreGroup,
+					},
+				},
+				LoadBalancingMethod: helpers.GetPointer(ngfAPIv1alpha1.LoadBalancingTypeHash),
+				HashKey: &ngfAPIv1alpha1.HashKey{
+					Cookie: helpers.GetPointer("session_id"),
+				},
+			},
+		},
+		{
+			name: "set both hash method key and hash key, error expected",
+			spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+					{
+						Kind:  serviceKind,
+						Group: coreGroup,
+					},
+				},
+				LoadBalancingMethod: helpers.GetPointer(ngfAPIv1alpha1.LoadBalancingTypeHash),
+				HashMethodKey:       helpers.GetPointer(ngfAPIv1alpha1.HashMethodKey("$remote_addr")),
+				HashKey: &ngfAPIv1alpha1.HashKey{
+					Cookie: helpers.GetPointer("session_id"),
+				},
+			},
+			wantErrors: []string{expectedHashKeyMutuallyExclusiveError},
+		},
+		{
+			name: "set both header and cookie in hash key, error expected",
+			spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+					{
+						Kind:  serviceKind,
+						Group: coreGroup,
+					},
+				},
+				LoadBalancingMethod: helpers.GetPointer(ngfAPIv1alpha1.LoadBalancingTypeHash),
+				HashKey: &ngfAPIv1alpha1.HashKey{
+					Header: helpers.GetPointer("X-Tenant-ID"),
+					Cookie: helpers.GetPointer("session_id"),
+				},
+			},
+			wantErrors: []string{expectedHashKeyOneOfError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i := range tt.spec.TargetRefs {
+				tt.spec.TargetRefs[i].Name = gatewayv1.ObjectName(uniqueResourceName(testTargetRefName))
+			}
+
+			upstreamSettingsPolicy := &ngfAPIv1alpha1.UpstreamSettingsPolicy{
+				ObjectMeta: controllerruntime.ObjectMeta{
+					Name:      uniqueResourceName(testResourceName),
+					Namespace: defaultNamespace,
+				},
+				Spec: tt.spec,
+			}
+			validateCrd(t, tt.wantErrors, upstreamSettingsPolicy, k8sClient)
+		})
+	}
+}
+
+func TestUpstreamSettingsPolicy_KeepAliveDisable(t *testing.T) {
+	t.Parallel()
+	k8sClient := getKubernetesClient(t)
+
+	tests := []struct {
+		spec       ngfAPIv1alpha1.UpstreamSettingsPolicySpec
+		name       string
+		wantErrors []string
+	}{
+		{
+			name: "disable keep-alive, no error expected",
+			spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+					{
+						Kind:  serviceKind,
+						Group: coreGroup,
+					},
+				},
+				KeepAlive: &ngfAPIv1alpha1.UpstreamKeepAlive{
+					Disable: helpers.GetPointer(true),
+				},
+			},
+		},
+		{
+			name: "keep-alive not disabled with connections, no error expected",
+			spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+					{
+						Kind:  serviceKind,
+						Group: coreGroup,
+					},
+				},
+				KeepAlive: &ngfAPIv1alpha1.UpstreamKeepAlive{
+					Disable:     helpers.GetPointer(false),
+					Connections: helpers.GetPointer[int32](10),
+				},
+			},
+		},
+		{
+			name: "disable keep-alive and set connections, error expected",
+			spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+					{
+						Kind:  serviceKind,
+						Group: coreGroup,
+					},
+				},
+				KeepAlive: &ngfAPIv1alpha1.UpstreamKeepAlive{
+					Disable:     helpers.GetPointer(true),
+					Connections: helpers.GetPointer[int32](10),
+				},
+			},
+			wantErrors: []string{expectedKeepAliveDisabledError},
+		},
+		{
+			name: "disable keep-alive and set timeout, error expected",
+			spec: ngfAPIv1alpha1.UpstreamSettingsPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReference{
+					{
+						Kind:  serviceKind,
+						Group: coreGroup,
+					},
+				},
+				KeepAlive: &ngfAPIv1alpha1.UpstreamKeepAlive{
+					Disable: helpers.GetPointer(true),
+					Timeout: helpers.GetPointer[ngfAPIv1alpha1.Duration]("10s"),
+				},
+			},
+			wantErrors: []string{expectedKeepAliveDisabledError},
+		},
 	}
 
 	for _, tt := range tests {
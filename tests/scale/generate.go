@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// maxEndpoints is the number of addresses in 10.0.0.0/8, excluding 10.0.0.0.
+	maxEndpoints = 1<<24 - 1
+
+	backendPort   = 80
+	containerPort = 8080
+)
+
+// objects are the resources of a scenario, in the order in which they are created.
+type objects struct {
+	// backends are the Services of the routes and their EndpointSlices.
+	backends []client.Object
+	// gateways are the Gateways.
+	gateways []*gatewayv1.Gateway
+	// routes are the HTTPRoutes.
+	routes []*gatewayv1.HTTPRoute
+}
+
+// generateObjects generates the resources of the scenario in the namespace.
+//
+// The backend Services don't select any Pods. Instead, every Service has an EndpointSlice with fake addresses,
+// so that large numbers of endpoints can be generated without running the backend Pods. NGINX doesn't need to
+// reach the endpoints to be configured with them.
+func generateObjects(s Scenario, namespace, gatewayClass string) objects {
+	var objs objects
+	var endpoint int
+
+	for g := range s.Gateways {
+		gatewayName := fmt.Sprintf("gateway-%d", g)
+		objs.gateways = append(objs.gateways, generateGateway(namespace, gatewayName, gatewayClass))
+
+		for r := range s.RoutesPerGateway {
+			backendName := fmt.Sprintf("%s-backend-%d", gatewayName, r)
+
+			addresses := make([]string, 0, s.EndpointsPerRoute)
+			for range s.EndpointsPerRoute {
+				endpoint++
+				addresses = append(addresses, endpointAddress(endpoint))
+			}
+
+			objs.backends = append(
+				objs.backends,
+				generateService(namespace, backendName),
+				generateEndpointSlice(namespace, backendName, addresses),
+			)
+
+			objs.routes = append(objs.routes, generateRoute(
+				namespace,
+				fmt.Sprintf("%s-route-%d", gatewayName, r),
+				gatewayName,
+				fmt.Sprintf("%d.%s.example.com", r, gatewayName),
+				backendName,
+			))
+		}
+	}
+
+	return objs
+}
+
+// endpointAddress returns the n-th address of 10.0.0.0/8.
+func endpointAddress(n int) string {
+	return fmt.Sprintf("10.%d.%d.%d", (n>>16)&0xff, (n>>8)&0xff, n&0xff)
+}
+
+func generateGateway(namespace, name, gatewayClass string) *gatewayv1.Gateway {
+	hostname := gatewayv1.Hostname(fmt.Sprintf("*.%s.example.com", name))
+
+	return &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: gatewayv1.ObjectName(gatewayClass),
+			Listeners: []gatewayv1.Listener{
+				{
+					Name:     "http",
+					Hostname: &hostname,
+					Port:     80,
+					Protocol: gatewayv1.HTTPProtocolType,
+				},
+			},
+		},
+	}
+}
+
+func generateService(namespace, name string) *core.Service {
+	return &core.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{
+				{
+					Name:       "http",
+					Port:       backendPort,
+					TargetPort: intstr.FromInt32(containerPort),
+					Protocol:   core.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+func generateEndpointSlice(namespace, serviceName string, addresses []string) *discoveryv1.EndpointSlice {
+	ready := true
+	portName := "http"
+	port := int32(containerPort)
+	protocol := core.ProtocolTCP
+
+	endpoints := make([]discoveryv1.Endpoint, 0, len(addresses))
+	for _, address := range addresses {
+		endpoints = append(endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{address},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		})
+	}
+
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: serviceName,
+				discoveryv1.LabelManagedBy:   "ngf-scale-harness",
+			},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+		Ports: []discoveryv1.EndpointPort{
+			{
+				Name:     &portName,
+				Port:     &port,
+				Protocol: &protocol,
+			},
+		},
+	}
+}
+
+func generateRoute(namespace, name, gatewayName, hostname, backendName string) *gatewayv1.HTTPRoute {
+	pathType := gatewayv1.PathMatchPathPrefix
+	path := "/"
+	port := gatewayv1.PortNumber(backendPort)
+
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{
+					{Name: gatewayv1.ObjectName(gatewayName)},
+				},
+			},
+			Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(hostname)},
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{
+							Path: &gatewayv1.HTTPPathMatch{
+								Type:  &pathType,
+								Value: &path,
+							},
+						},
+					},
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{
+							BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Name: gatewayv1.ObjectName(backendName),
+									Port: &port,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
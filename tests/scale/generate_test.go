@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+func TestGenerateObjects(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	scenario := Scenario{
+		Name:              "test",
+		Gateways:          2,
+		RoutesPerGateway:  3,
+		EndpointsPerRoute: 4,
+	}
+
+	objs := generateObjects(scenario, "scale", "nginx")
+
+	g.Expect(objs.gateways).To(HaveLen(2))
+	g.Expect(objs.routes).To(HaveLen(6))
+	// a Service and an EndpointSlice per route
+	g.Expect(objs.backends).To(HaveLen(12))
+
+	for _, gw := range objs.gateways {
+		g.Expect(gw.Namespace).To(Equal("scale"))
+		g.Expect(string(gw.Spec.GatewayClassName)).To(Equal("nginx"))
+	}
+
+	route := objs.routes[3]
+	g.Expect(route.Name).To(Equal("gateway-1-route-0"))
+	g.Expect(string(route.Spec.ParentRefs[0].Name)).To(Equal("gateway-1"))
+	g.Expect(string(route.Spec.Hostnames[0])).To(Equal("0.gateway-1.example.com"))
+	g.Expect(string(route.Spec.Rules[0].BackendRefs[0].Name)).To(Equal("gateway-1-backend-0"))
+
+	addresses := make(map[string]struct{})
+	for _, obj := range objs.backends {
+		slice, ok := obj.(*discoveryv1.EndpointSlice)
+		if !ok {
+			continue
+		}
+
+		g.Expect(slice.Labels).To(HaveKeyWithValue(discoveryv1.LabelServiceName, slice.Name))
+		g.Expect(slice.Endpoints).To(HaveLen(4))
+
+		for _, ep := range slice.Endpoints {
+			addresses[ep.Addresses[0]] = struct{}{}
+		}
+	}
+
+	// every endpoint has a unique address
+	g.Expect(addresses).To(HaveLen(24))
+}
+
+func TestEndpointAddress(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(endpointAddress(1)).To(Equal("10.0.0.1"))
+	g.Expect(endpointAddress(256)).To(Equal("10.0.1.0"))
+	g.Expect(endpointAddress(maxEndpoints)).To(Equal("10.255.255.255"))
+}
+
+func TestScenarioValidate(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	for name, s := range scenarios {
+		g.Expect(s.validate()).To(Succeed(), name)
+		g.Expect(s.Name).To(Equal(name))
+	}
+
+	g.Expect(Scenario{}.validate()).To(MatchError(And(
+		ContainSubstring("name must be set"),
+		ContainSubstring("gateways must be at least 1"),
+		ContainSubstring("routes per Gateway must be at least 1"),
+		ContainSubstring("endpoints per route must be at least 1"),
+	)))
+
+	tooLarge := Scenario{Name: "large", Gateways: 100, RoutesPerGateway: 1000, EndpointsPerRoute: 1000}
+	g.Expect(tooLarge.validate()).To(MatchError(ContainSubstring("total number of endpoints")))
+
+	_, err := getScenario("huge")
+	g.Expect(err).To(MatchError(`unknown scenario "huge", must be one of: large, medium, small`))
+}
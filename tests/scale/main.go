@@ -0,0 +1,298 @@
+// Command scale is the scale test harness of NGINX Gateway Fabric.
+//
+// The run subcommand generates the Gateways, HTTPRoutes, and endpoints of a scenario in a cluster where NGF is
+// installed, measures how NGF handles them, and writes the results as a JSON profile.
+// The compare subcommand compares a profile with a baseline profile and fails if any measurement regressed
+// by more than the thresholds, so that it can gate the changes in CI.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const usage = `Usage: scale <command> [flags]
+
+Commands:
+  run      Run a scenario against the cluster and write its profile.
+  compare  Compare a profile with a baseline profile.
+
+Run "scale <command> -h" for the flags of a command.
+`
+
+// errRegressions is returned when the compared profile regressed from the baseline.
+var errRegressions = errors.New("the profile regressed from the baseline")
+
+func main() {
+	if err := execute(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func execute(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errors.New("command is required")
+	}
+
+	switch args[0] {
+	case "run":
+		return runCommand(args[1:], stdout, stderr)
+	case "compare":
+		return compareCommand(args[1:], stdout, stderr)
+	default:
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func runCommand(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var (
+		scenarioName = fs.String("scenario", "small", "Name of the published scenario: small, medium, or large")
+		gateways     = fs.Int("gateways", 0, "Number of Gateways. Overrides the scenario")
+		routes       = fs.Int("routes-per-gateway", 0, "Number of HTTPRoutes per Gateway. Overrides the scenario")
+		endpoints    = fs.Int("endpoints-per-route", 0, "Number of endpoints per HTTPRoute. Overrides the scenario")
+
+		namespace      = fs.String("namespace", "ngf-scale", "Namespace of the generated resources. Must not exist")
+		gatewayClass   = fs.String("gateway-class", "nginx", "GatewayClass of the generated Gateways")
+		controllerName = fs.String(
+			"gateway-ctlr-name",
+			"gateway.nginx.org/nginx-gateway-controller",
+			"Controller name of NGF",
+		)
+		ngfNamespace = fs.String("ngf-namespace", "nginx-gateway", "Namespace of NGF")
+		ngfSelector  = fs.String(
+			"ngf-selector",
+			"app.kubernetes.io/name=nginx-gateway-fabric",
+			"Label selector of the NGF Pod",
+		)
+		metricsPort = fs.Int("ngf-metrics-port", 9113, "Port of the metrics endpoint of NGF. It must serve HTTP")
+		ngfVersion  = fs.String("ngf-version", "", "Version of NGF under test, recorded in the profile")
+		plus        = fs.Bool("plus", false, "Record that NGF runs with NGINX Plus in the profile")
+
+		timeout       = fs.Duration("timeout", 10*time.Minute, "Time to wait for all resources to become ready")
+		pollInterval  = fs.Duration("poll-interval", 500*time.Millisecond, "Interval of polling the status")
+		output        = fs.String("output", "", "File to write the profile to. If empty, it is written to stdout")
+		keepResources = fs.Bool("keep-resources", false, "Keep the generated resources after the run")
+	)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	scenario, err := getScenario(*scenarioName)
+	if err != nil {
+		return err
+	}
+
+	if *gateways != 0 || *routes != 0 || *endpoints != 0 {
+		scenario.Name = "custom"
+	}
+	if *gateways != 0 {
+		scenario.Gateways = *gateways
+	}
+	if *routes != 0 {
+		scenario.RoutesPerGateway = *routes
+	}
+	if *endpoints != 0 {
+		scenario.EndpointsPerRoute = *endpoints
+	}
+
+	if err := scenario.validate(); err != nil {
+		return fmt.Errorf("invalid scenario: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("error getting Kubernetes config: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(core.AddToScheme(scheme))
+	utilruntime.Must(discoveryv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
+
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("error creating Kubernetes client: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("error creating Kubernetes clientset: %w", err)
+	}
+
+	ngfPodName, err := getNGFPodName(ctx, k8sClient, *ngfNamespace, *ngfSelector)
+	if err != nil {
+		return err
+	}
+
+	r := newRunner(runnerConfig{
+		client: k8sClient,
+		scraper: &podMetricsScraper{
+			clientset:        clientset,
+			namespace:        *ngfNamespace,
+			podName:          ngfPodName,
+			gatewayNamespace: *namespace,
+			port:             *metricsPort,
+		},
+		out:            stderr,
+		namespace:      *namespace,
+		gatewayClass:   *gatewayClass,
+		controllerName: *controllerName,
+		scenario:       scenario,
+		timeout:        *timeout,
+		pollInterval:   *pollInterval,
+		keepResources:  *keepResources,
+	})
+
+	profile, err := r.run(ctx)
+	if err != nil {
+		return err
+	}
+
+	profile.NGFVersion = *ngfVersion
+	profile.Plus = *plus
+
+	if *output == "" {
+		return writeProfile(stdout, profile)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("error creating profile file: %w", err)
+	}
+	defer f.Close()
+
+	return writeProfile(f, profile)
+}
+
+func compareCommand(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var (
+		baselineFile = fs.String("baseline", "", "File of the baseline profile")
+		currentFile  = fs.String("current", "", "File of the profile to compare with the baseline")
+
+		latency = fs.Float64(
+			"latency-threshold",
+			0.2,
+			"Tolerated relative increase of the latency percentiles",
+		)
+		latencySlack = fs.Duration(
+			"latency-slack",
+			time.Second,
+			"Tolerated absolute increase of the latency percentiles",
+		)
+		configApplies = fs.Float64(
+			"config-applies-threshold",
+			0.1,
+			"Tolerated relative increase of the NGINX configuration applies",
+		)
+		memory = fs.Float64("memory-threshold", 0.2, "Tolerated relative increase of the peak memory")
+	)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *baselineFile == "" || *currentFile == "" {
+		return errors.New("baseline and current must be set")
+	}
+
+	baseline, err := readProfileFile(*baselineFile)
+	if err != nil {
+		return err
+	}
+
+	current, err := readProfileFile(*currentFile)
+	if err != nil {
+		return err
+	}
+
+	regressions, err := compareProfiles(baseline, current, Thresholds{
+		Latency:       *latency,
+		LatencySlack:  *latencySlack,
+		ConfigApplies: *configApplies,
+		Memory:        *memory,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(regressions) == 0 {
+		fmt.Fprintln(stdout, "No regressions")
+		return nil
+	}
+
+	for _, r := range regressions {
+		fmt.Fprintln(stdout, r)
+	}
+
+	return errRegressions
+}
+
+func readProfileFile(name string) (Profile, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Profile{}, fmt.Errorf("error opening profile file: %w", err)
+	}
+	defer f.Close()
+
+	p, err := readProfile(f)
+	if err != nil {
+		return Profile{}, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return p, nil
+}
+
+func getNGFPodName(ctx context.Context, k8sClient client.Client, namespace, selector string) (string, error) {
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid NGF selector: %w", err)
+	}
+
+	var pods core.PodList
+	if err := k8sClient.List(
+		ctx,
+		&pods,
+		client.InNamespace(namespace),
+		client.MatchingLabelsSelector{Selector: labelSelector},
+	); err != nil {
+		return "", fmt.Errorf("error listing NGF Pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == core.PodRunning {
+			return pod.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no running NGF Pod in namespace %s matches %q", namespace, selector)
+}
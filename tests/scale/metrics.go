@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	configApplyLatencyMetric = "nginx_gateway_fabric_config_apply_latency_milliseconds"
+	eventBatchMetric         = "nginx_gateway_fabric_event_batch_processing_milliseconds"
+	residentMemoryMetric     = "process_resident_memory_bytes"
+)
+
+// metricsSample is a sample of the metrics of the NGF control plane.
+type metricsSample struct {
+	// configApplies is the number of NGINX configurations, including the reloads, that were applied for the Gateways
+	// of the namespace.
+	configApplies float64
+	// eventBatches is the number of processed event batches.
+	eventBatches float64
+	// residentMemoryBytes is the resident memory of the control plane.
+	residentMemoryBytes float64
+}
+
+// metricsScraper scrapes the metrics of the NGF control plane.
+type metricsScraper interface {
+	scrape(ctx context.Context) (metricsSample, error)
+}
+
+// podMetricsScraper scrapes the metrics endpoint of the NGF Pod through the API server proxy, so that the harness
+// doesn't need to port-forward to the Pod.
+type podMetricsScraper struct {
+	clientset kubernetes.Interface
+	namespace string
+	podName   string
+	// gatewayNamespace is the namespace of the Gateways whose configuration applies are counted.
+	gatewayNamespace string
+	port             int
+}
+
+func (s *podMetricsScraper) scrape(ctx context.Context) (metricsSample, error) {
+	body, err := s.clientset.CoreV1().
+		Pods(s.namespace).
+		ProxyGet("http", s.podName, strconv.Itoa(s.port), "metrics", nil).
+		DoRaw(ctx)
+	if err != nil {
+		return metricsSample{}, fmt.Errorf("error scraping metrics of Pod %s/%s: %w", s.namespace, s.podName, err)
+	}
+
+	return parseMetrics(bytes.NewReader(body), s.gatewayNamespace)
+}
+
+// parseMetrics parses the metrics in the Prometheus text format. Only the configuration applies of the Gateways
+// in gatewayNamespace are counted.
+func parseMetrics(r io.Reader, gatewayNamespace string) (metricsSample, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return metricsSample{}, fmt.Errorf("error parsing metrics: %w", err)
+	}
+
+	var sample metricsSample
+
+	if family, ok := families[configApplyLatencyMetric]; ok {
+		for _, m := range family.GetMetric() {
+			if strings.HasPrefix(getLabel(m, "gateway"), gatewayNamespace+"/") {
+				sample.configApplies += float64(m.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+
+	if family, ok := families[eventBatchMetric]; ok {
+		for _, m := range family.GetMetric() {
+			sample.eventBatches += float64(m.GetHistogram().GetSampleCount())
+		}
+	}
+
+	if family, ok := families[residentMemoryMetric]; ok {
+		for _, m := range family.GetMetric() {
+			sample.residentMemoryBytes = m.GetGauge().GetValue()
+		}
+	}
+
+	return sample, nil
+}
+
+func getLabel(m *dto.Metric, name string) string {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+
+	return ""
+}
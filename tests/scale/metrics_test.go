@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const metricsText = `# HELP nginx_gateway_fabric_config_apply_latency_milliseconds Duration of config apply
+# TYPE nginx_gateway_fabric_config_apply_latency_milliseconds histogram
+nginx_gateway_fabric_config_apply_latency_milliseconds_bucket{class="nginx",gateway="scale/gateway-0",le="+Inf"} 3
+nginx_gateway_fabric_config_apply_latency_milliseconds_sum{class="nginx",gateway="scale/gateway-0"} 1500
+nginx_gateway_fabric_config_apply_latency_milliseconds_count{class="nginx",gateway="scale/gateway-0"} 3
+nginx_gateway_fabric_config_apply_latency_milliseconds_bucket{class="nginx",gateway="scale/gateway-1",le="+Inf"} 2
+nginx_gateway_fabric_config_apply_latency_milliseconds_sum{class="nginx",gateway="scale/gateway-1"} 1000
+nginx_gateway_fabric_config_apply_latency_milliseconds_count{class="nginx",gateway="scale/gateway-1"} 2
+nginx_gateway_fabric_config_apply_latency_milliseconds_bucket{class="nginx",gateway="scale-other/gateway",le="+Inf"} 7
+nginx_gateway_fabric_config_apply_latency_milliseconds_sum{class="nginx",gateway="scale-other/gateway"} 700
+nginx_gateway_fabric_config_apply_latency_milliseconds_count{class="nginx",gateway="scale-other/gateway"} 7
+# HELP nginx_gateway_fabric_event_batch_processing_milliseconds Duration of event batch processing
+# TYPE nginx_gateway_fabric_event_batch_processing_milliseconds histogram
+nginx_gateway_fabric_event_batch_processing_milliseconds_bucket{class="nginx",le="+Inf"} 12
+nginx_gateway_fabric_event_batch_processing_milliseconds_sum{class="nginx"} 240
+nginx_gateway_fabric_event_batch_processing_milliseconds_count{class="nginx"} 12
+# HELP process_resident_memory_bytes Resident memory size in bytes.
+# TYPE process_resident_memory_bytes gauge
+process_resident_memory_bytes 1.048576e+08
+`
+
+func TestParseMetrics(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sample, err := parseMetrics(strings.NewReader(metricsText), "scale")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sample).To(Equal(metricsSample{
+		configApplies:       5,
+		eventBatches:        12,
+		residentMemoryBytes: 100 << 20,
+	}))
+}
+
+func TestParseMetricsEmpty(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sample, err := parseMetrics(strings.NewReader(""), "scale")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sample).To(Equal(metricsSample{}))
+}
+
+func TestParseMetricsInvalid(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	_, err := parseMetrics(strings.NewReader("invalid metric{"), "scale")
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"time"
+)
+
+// Profile is the machine-readable result of a scale run.
+type Profile struct {
+	// StartTime is the time when the run started.
+	StartTime time.Time `json:"startTime"`
+	// NGFVersion is the version of NGF under test, if known.
+	NGFVersion string `json:"ngfVersion,omitempty"`
+	// Scenario is the scenario of the run.
+	Scenario Scenario `json:"scenario"`
+	// Results are the measurements of the run.
+	Results Results `json:"results"`
+	// Plus indicates if NGF ran with NGINX Plus.
+	Plus bool `json:"plus"`
+}
+
+// Results are the measurements of a scale run.
+type Results struct {
+	// GatewayProgrammedLatency is the latency from creating a Gateway to the Gateway being Programmed.
+	GatewayProgrammedLatency LatencySummary `json:"gatewayProgrammedLatency"`
+	// RouteAcceptedLatency is the latency from creating an HTTPRoute to the HTTPRoute being Accepted by NGF.
+	RouteAcceptedLatency LatencySummary `json:"routeAcceptedLatency"`
+	// ConfigApplies is the number of NGINX configurations, including the reloads, that were applied for the
+	// Gateways of the run.
+	ConfigApplies int64 `json:"configApplies"`
+	// EventBatches is the number of event batches that the control plane processed during the run.
+	EventBatches int64 `json:"eventBatches"`
+	// PeakMemoryBytes is the peak resident memory of the control plane during the run.
+	PeakMemoryBytes int64 `json:"peakMemoryBytes"`
+}
+
+// LatencySummary summarizes the latencies of the resources of a run, in milliseconds.
+// The latencies are measured by polling the status, so they are accurate to the poll interval.
+type LatencySummary struct {
+	// Count is the number of the resources.
+	Count int `json:"count"`
+	// P50 is the median latency.
+	P50 int64 `json:"p50Ms"`
+	// P90 is the 90th percentile latency.
+	P90 int64 `json:"p90Ms"`
+	// P99 is the 99th percentile latency.
+	P99 int64 `json:"p99Ms"`
+	// Max is the maximum latency.
+	Max int64 `json:"maxMs"`
+}
+
+// summarizeLatencies summarizes the latencies using the nearest-rank percentiles.
+func summarizeLatencies(latencies []time.Duration) LatencySummary {
+	if len(latencies) == 0 {
+		return LatencySummary{}
+	}
+
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+
+	percentile := func(p float64) int64 {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		return sorted[max(rank-1, 0)].Milliseconds()
+	}
+
+	return LatencySummary{
+		Count: len(sorted),
+		P50:   percentile(50),
+		P90:   percentile(90),
+		P99:   percentile(99),
+		Max:   sorted[len(sorted)-1].Milliseconds(),
+	}
+}
+
+func writeProfile(w io.Writer, p Profile) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(p)
+}
+
+func readProfile(r io.Reader) (Profile, error) {
+	var p Profile
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&p); err != nil {
+		return Profile{}, fmt.Errorf("error decoding profile: %w", err)
+	}
+
+	return p, nil
+}
+
+// Thresholds are the tolerated increases of the measurements of a profile over the baseline.
+type Thresholds struct {
+	// Latency is the tolerated relative increase of the latency percentiles, for example, 0.2 for 20%.
+	Latency float64
+	// LatencySlack is the tolerated absolute increase of the latency percentiles. The increases below it are
+	// not regressions, because the latencies are accurate only to the poll interval.
+	LatencySlack time.Duration
+	// ConfigApplies is the tolerated relative increase of the configuration applies.
+	ConfigApplies float64
+	// Memory is the tolerated relative increase of the peak memory.
+	Memory float64
+}
+
+// Regression is a measurement of a profile that exceeds the baseline by more than the threshold.
+type Regression struct {
+	// Metric is the name of the measurement.
+	Metric string
+	// Baseline is the value of the baseline.
+	Baseline int64
+	// Current is the value of the profile.
+	Current int64
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("%s: baseline %d, current %d", r.Metric, r.Baseline, r.Current)
+}
+
+// compareProfiles returns the regressions of the current profile from the baseline.
+// The profiles must be of the same scenario.
+func compareProfiles(baseline, current Profile, thresholds Thresholds) ([]Regression, error) {
+	if baseline.Scenario != current.Scenario {
+		return nil, errors.New("profiles must be of the same scenario")
+	}
+
+	var regressions []Regression
+
+	check := func(metric string, baseline, current int64, tolerance float64, slack int64) {
+		limit := float64(baseline) * (1 + tolerance)
+		if float64(current) > limit && current-baseline > slack {
+			regressions = append(regressions, Regression{Metric: metric, Baseline: baseline, Current: current})
+		}
+	}
+
+	latencySlack := thresholds.LatencySlack.Milliseconds()
+
+	checkLatency := func(name string, baseline, current LatencySummary) {
+		check(name+".p50Ms", baseline.P50, current.P50, thresholds.Latency, latencySlack)
+		check(name+".p90Ms", baseline.P90, current.P90, thresholds.Latency, latencySlack)
+		check(name+".p99Ms", baseline.P99, current.P99, thresholds.Latency, latencySlack)
+	}
+
+	checkLatency(
+		"gatewayProgrammedLatency",
+		baseline.Results.GatewayProgrammedLatency,
+		current.Results.GatewayProgrammedLatency,
+	)
+	checkLatency(
+		"routeAcceptedLatency",
+		baseline.Results.RouteAcceptedLatency,
+		current.Results.RouteAcceptedLatency,
+	)
+
+	check(
+		"configApplies",
+		baseline.Results.ConfigApplies,
+		current.Results.ConfigApplies,
+		thresholds.ConfigApplies,
+		0,
+	)
+	check(
+		"peakMemoryBytes",
+		baseline.Results.PeakMemoryBytes,
+		current.Results.PeakMemoryBytes,
+		thresholds.Memory,
+		0,
+	)
+
+	return regressions, nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSummarizeLatencies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		latencies []time.Duration
+		expected  LatencySummary
+	}{
+		{
+			name:     "no latencies",
+			expected: LatencySummary{},
+		},
+		{
+			name:      "one latency",
+			latencies: []time.Duration{2 * time.Second},
+			expected:  LatencySummary{Count: 1, P50: 2000, P90: 2000, P99: 2000, Max: 2000},
+		},
+		{
+			name: "unsorted latencies",
+			latencies: []time.Duration{
+				10 * time.Millisecond,
+				1 * time.Millisecond,
+				9 * time.Millisecond,
+				2 * time.Millisecond,
+				8 * time.Millisecond,
+				3 * time.Millisecond,
+				7 * time.Millisecond,
+				4 * time.Millisecond,
+				6 * time.Millisecond,
+				5 * time.Millisecond,
+			},
+			expected: LatencySummary{Count: 10, P50: 5, P90: 9, P99: 10, Max: 10},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(summarizeLatencies(test.latencies)).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestWriteAndReadProfile(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	profile := Profile{
+		StartTime:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		NGFVersion: "edge",
+		Scenario:   scenarios["small"],
+		Results: Results{
+			GatewayProgrammedLatency: LatencySummary{Count: 1, P50: 1000, P90: 1000, P99: 1000, Max: 1000},
+			RouteAcceptedLatency:     LatencySummary{Count: 10, P50: 500, P90: 900, P99: 1000, Max: 1000},
+			ConfigApplies:            3,
+			EventBatches:             5,
+			PeakMemoryBytes:          100 << 20,
+		},
+	}
+
+	var buf bytes.Buffer
+	g.Expect(writeProfile(&buf, profile)).To(Succeed())
+	g.Expect(buf.String()).To(ContainSubstring(`"p99Ms": 1000`))
+
+	read, err := readProfile(&buf)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(read).To(Equal(profile))
+}
+
+func TestReadProfileUnknownField(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	_, err := readProfile(strings.NewReader(`{"unknown": 1}`))
+	g.Expect(err).To(MatchError(ContainSubstring("unknown field")))
+}
+
+func TestCompareProfiles(t *testing.T) {
+	t.Parallel()
+
+	baseline := Profile{
+		Scenario: scenarios["medium"],
+		Results: Results{
+			GatewayProgrammedLatency: LatencySummary{Count: 5, P50: 4000, P90: 5000, P99: 6000, Max: 6000},
+			RouteAcceptedLatency:     LatencySummary{Count: 500, P50: 1000, P90: 2000, P99: 3000, Max: 3000},
+			ConfigApplies:            100,
+			EventBatches:             50,
+			PeakMemoryBytes:          100 << 20,
+		},
+	}
+
+	thresholds := Thresholds{
+		Latency:       0.2,
+		LatencySlack:  time.Second,
+		ConfigApplies: 0.1,
+		Memory:        0.2,
+	}
+
+	tests := []struct {
+		modify         func(p *Profile)
+		name           string
+		expRegressions []Regression
+		expErr         bool
+	}{
+		{
+			name:   "same profile",
+			modify: func(_ *Profile) {},
+		},
+		{
+			name: "improvements are not regressions",
+			modify: func(p *Profile) {
+				p.Results.RouteAcceptedLatency.P99 = 1000
+				p.Results.ConfigApplies = 10
+				p.Results.PeakMemoryBytes = 50 << 20
+			},
+		},
+		{
+			name: "increases within the thresholds",
+			modify: func(p *Profile) {
+				p.Results.GatewayProgrammedLatency.P99 = 7200
+				p.Results.ConfigApplies = 110
+				p.Results.PeakMemoryBytes = 120 << 20
+			},
+		},
+		{
+			name: "latency increase within the slack",
+			modify: func(p *Profile) {
+				p.Results.RouteAcceptedLatency.P50 = 1900
+			},
+		},
+		{
+			name: "increases over the thresholds",
+			modify: func(p *Profile) {
+				p.Results.RouteAcceptedLatency.P90 = 3100
+				p.Results.ConfigApplies = 111
+				p.Results.PeakMemoryBytes = 121 << 20
+			},
+			expRegressions: []Regression{
+				{Metric: "routeAcceptedLatency.p90Ms", Baseline: 2000, Current: 3100},
+				{Metric: "configApplies", Baseline: 100, Current: 111},
+				{Metric: "peakMemoryBytes", Baseline: 100 << 20, Current: 121 << 20},
+			},
+		},
+		{
+			name: "different scenario",
+			modify: func(p *Profile) {
+				p.Scenario = scenarios["large"]
+			},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			current := baseline
+			test.modify(&current)
+
+			regressions, err := compareProfiles(baseline, current, thresholds)
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(regressions).To(Equal(test.expRegressions))
+		})
+	}
+}
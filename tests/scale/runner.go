@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// runnerConfig is the configuration of a scale run.
+type runnerConfig struct {
+	// client is the Kubernetes client.
+	client client.Client
+	// scraper scrapes the metrics of the control plane.
+	scraper metricsScraper
+	// out is where the progress of the run is written.
+	out io.Writer
+	// namespace is the namespace of the generated resources. It must not exist.
+	namespace string
+	// gatewayClass is the GatewayClass of the generated Gateways.
+	gatewayClass string
+	// controllerName is the controller name of NGF.
+	controllerName string
+	// scenario is the scenario of the run.
+	scenario Scenario
+	// timeout is the time to wait for all resources to become ready.
+	timeout time.Duration
+	// pollInterval is the interval of polling the status of the resources and the metrics.
+	pollInterval time.Duration
+	// keepResources indicates if the generated resources are kept after the run.
+	keepResources bool
+}
+
+// runner generates the resources of a scenario and measures how the control plane handles them.
+type runner struct {
+	cfg runnerConfig
+
+	// created holds the times when the resources were created.
+	created map[types.NamespacedName]time.Time
+	// gatewayLatencies and routeLatencies hold the latencies of the resources that became ready.
+	gatewayLatencies map[types.NamespacedName]time.Duration
+	routeLatencies   map[types.NamespacedName]time.Duration
+
+	peakMemoryBytes float64
+}
+
+func newRunner(cfg runnerConfig) *runner {
+	return &runner{
+		cfg:              cfg,
+		created:          make(map[types.NamespacedName]time.Time),
+		gatewayLatencies: make(map[types.NamespacedName]time.Duration),
+		routeLatencies:   make(map[types.NamespacedName]time.Duration),
+	}
+}
+
+func (r *runner) run(ctx context.Context) (Profile, error) {
+	profile := Profile{
+		StartTime: time.Now(),
+		Scenario:  r.cfg.scenario,
+	}
+
+	objs := generateObjects(r.cfg.scenario, r.cfg.namespace, r.cfg.gatewayClass)
+
+	ns := &core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: r.cfg.namespace}}
+	if err := r.cfg.client.Create(ctx, ns); err != nil {
+		return Profile{}, fmt.Errorf("error creating namespace %s: %w", r.cfg.namespace, err)
+	}
+
+	if !r.cfg.keepResources {
+		defer r.cleanup(ns)
+	}
+
+	before, err := r.cfg.scraper.scrape(ctx)
+	if err != nil {
+		return Profile{}, err
+	}
+	r.peakMemoryBytes = before.residentMemoryBytes
+
+	r.logf(
+		"Creating %d Gateways, %d HTTPRoutes, and %d endpoints in namespace %s",
+		len(objs.gateways),
+		len(objs.routes),
+		r.cfg.scenario.Gateways*r.cfg.scenario.RoutesPerGateway*r.cfg.scenario.EndpointsPerRoute,
+		r.cfg.namespace,
+	)
+
+	if err := r.create(ctx, objs); err != nil {
+		return Profile{}, err
+	}
+
+	if err := r.waitForReady(ctx, objs); err != nil {
+		return Profile{}, err
+	}
+
+	after, err := r.cfg.scraper.scrape(ctx)
+	if err != nil {
+		return Profile{}, err
+	}
+	r.peakMemoryBytes = max(r.peakMemoryBytes, after.residentMemoryBytes)
+
+	profile.Results = Results{
+		GatewayProgrammedLatency: summarizeLatencies(mapValues(r.gatewayLatencies)),
+		RouteAcceptedLatency:     summarizeLatencies(mapValues(r.routeLatencies)),
+		ConfigApplies:            int64(after.configApplies - before.configApplies),
+		EventBatches:             int64(after.eventBatches - before.eventBatches),
+		PeakMemoryBytes:          int64(r.peakMemoryBytes),
+	}
+
+	return profile, nil
+}
+
+// create creates the backends first, so that the routes are accepted with resolved references and the number
+// of configuration applies doesn't depend on the order of the events.
+func (r *runner) create(ctx context.Context, objs objects) error {
+	for _, obj := range objs.backends {
+		if err := r.cfg.client.Create(ctx, obj); err != nil {
+			return fmt.Errorf("error creating %T %s: %w", obj, obj.GetName(), err)
+		}
+	}
+
+	for _, gw := range objs.gateways {
+		if err := r.cfg.client.Create(ctx, gw); err != nil {
+			return fmt.Errorf("error creating Gateway %s: %w", gw.Name, err)
+		}
+		r.created[client.ObjectKeyFromObject(gw)] = time.Now()
+	}
+
+	for _, route := range objs.routes {
+		if err := r.cfg.client.Create(ctx, route); err != nil {
+			return fmt.Errorf("error creating HTTPRoute %s: %w", route.Name, err)
+		}
+		r.created[client.ObjectKeyFromObject(route)] = time.Now()
+	}
+
+	return nil
+}
+
+func (r *runner) waitForReady(ctx context.Context, objs objects) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.timeout)
+	defer cancel()
+
+	err := wait.PollUntilContextCancel(ctx, r.cfg.pollInterval, true, func(ctx context.Context) (bool, error) {
+		if err := r.poll(ctx); err != nil {
+			r.logf("Error polling status: %v", err)
+			return false, nil
+		}
+
+		return len(r.gatewayLatencies) == len(objs.gateways) && len(r.routeLatencies) == len(objs.routes), nil
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"%d/%d Gateways and %d/%d HTTPRoutes became ready within %s: %w",
+			len(r.gatewayLatencies),
+			len(objs.gateways),
+			len(r.routeLatencies),
+			len(objs.routes),
+			r.cfg.timeout,
+			err,
+		)
+	}
+
+	r.logf("All Gateways and HTTPRoutes are ready")
+
+	return nil
+}
+
+func (r *runner) poll(ctx context.Context) error {
+	now := time.Now()
+
+	sample, err := r.cfg.scraper.scrape(ctx)
+	if err != nil {
+		return err
+	}
+	r.peakMemoryBytes = max(r.peakMemoryBytes, sample.residentMemoryBytes)
+
+	var gateways gatewayv1.GatewayList
+	if err := r.cfg.client.List(ctx, &gateways, client.InNamespace(r.cfg.namespace)); err != nil {
+		return fmt.Errorf("error listing Gateways: %w", err)
+	}
+
+	for _, gw := range gateways.Items {
+		key := client.ObjectKeyFromObject(&gw)
+		if _, ok := r.gatewayLatencies[key]; ok {
+			continue
+		}
+
+		if isGatewayProgrammed(&gw) {
+			r.gatewayLatencies[key] = now.Sub(r.created[key])
+		}
+	}
+
+	var routes gatewayv1.HTTPRouteList
+	if err := r.cfg.client.List(ctx, &routes, client.InNamespace(r.cfg.namespace)); err != nil {
+		return fmt.Errorf("error listing HTTPRoutes: %w", err)
+	}
+
+	for _, route := range routes.Items {
+		key := client.ObjectKeyFromObject(&route)
+		if _, ok := r.routeLatencies[key]; ok {
+			continue
+		}
+
+		if isRouteAccepted(&route, r.cfg.controllerName) {
+			r.routeLatencies[key] = now.Sub(r.created[key])
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) cleanup(ns *core.Namespace) {
+	r.logf("Deleting namespace %s", ns.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.timeout)
+	defer cancel()
+
+	if err := r.cfg.client.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+		r.logf("Error deleting namespace %s: %v", ns.Name, err)
+	}
+}
+
+func (r *runner) logf(format string, args ...any) {
+	fmt.Fprintf(r.cfg.out, "%s "+format+"\n", append([]any{time.Now().Format(time.RFC3339)}, args...)...)
+}
+
+func isGatewayProgrammed(gw *gatewayv1.Gateway) bool {
+	cond := meta.FindStatusCondition(gw.Status.Conditions, string(gatewayv1.GatewayConditionProgrammed))
+
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == gw.Generation
+}
+
+func isRouteAccepted(route *gatewayv1.HTTPRoute, controllerName string) bool {
+	for _, parent := range route.Status.Parents {
+		if string(parent.ControllerName) != controllerName {
+			continue
+		}
+
+		cond := meta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionAccepted))
+		if cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == route.Generation {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mapValues(m map[types.NamespacedName]time.Duration) []time.Duration {
+	values := make([]time.Duration, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+
+	return values
+}
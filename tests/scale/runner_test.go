@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const testControllerName = "gateway.nginx.org/nginx-gateway-controller"
+
+// fakeScraper returns the samples in order and, before returning each sample, calls onScrape,
+// which simulates the control plane.
+type fakeScraper struct {
+	onScrape func(ctx context.Context) error
+	samples  []metricsSample
+	calls    int
+}
+
+func (s *fakeScraper) scrape(ctx context.Context) (metricsSample, error) {
+	if s.onScrape != nil {
+		if err := s.onScrape(ctx); err != nil {
+			return metricsSample{}, err
+		}
+	}
+
+	sample := s.samples[min(s.calls, len(s.samples)-1)]
+	s.calls++
+
+	return sample, nil
+}
+
+func createFakeClient(t *testing.T) client.Client {
+	t.Helper()
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(core.AddToScheme(scheme)).To(Succeed())
+	g.Expect(discoveryv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(gatewayv1.Install(scheme)).To(Succeed())
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&gatewayv1.Gateway{}, &gatewayv1.HTTPRoute{}).
+		Build()
+}
+
+// programAll sets the status of all Gateways and HTTPRoutes of the namespace, the way NGF would do it.
+func programAll(ctx context.Context, k8sClient client.Client, namespace string) error {
+	var gateways gatewayv1.GatewayList
+	if err := k8sClient.List(ctx, &gateways, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	for _, gw := range gateways.Items {
+		gw.Status.Conditions = []metav1.Condition{
+			{
+				Type:               string(gatewayv1.GatewayConditionProgrammed),
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: gw.Generation,
+				Reason:             string(gatewayv1.GatewayReasonProgrammed),
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		if err := k8sClient.Status().Update(ctx, &gw); err != nil {
+			return err
+		}
+	}
+
+	var routes gatewayv1.HTTPRouteList
+	if err := k8sClient.List(ctx, &routes, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	for _, route := range routes.Items {
+		route.Status.Parents = []gatewayv1.RouteParentStatus{
+			{
+				ParentRef:      route.Spec.ParentRefs[0],
+				ControllerName: testControllerName,
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(gatewayv1.RouteConditionAccepted),
+						Status:             metav1.ConditionTrue,
+						ObservedGeneration: route.Generation,
+						Reason:             string(gatewayv1.RouteReasonAccepted),
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+			},
+		}
+		if err := k8sClient.Status().Update(ctx, &route); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	k8sClient := createFakeClient(t)
+
+	scraper := &fakeScraper{
+		samples: []metricsSample{
+			{configApplies: 10, eventBatches: 20, residentMemoryBytes: 50 << 20},
+			{configApplies: 11, eventBatches: 21, residentMemoryBytes: 80 << 20},
+			{configApplies: 13, eventBatches: 24, residentMemoryBytes: 70 << 20},
+		},
+	}
+
+	// The first scrape happens before the resources are created.
+	scraper.onScrape = func(ctx context.Context) error {
+		if scraper.calls == 0 {
+			return nil
+		}
+		return programAll(ctx, k8sClient, "scale")
+	}
+
+	r := newRunner(runnerConfig{
+		client:         k8sClient,
+		scraper:        scraper,
+		out:            io.Discard,
+		namespace:      "scale",
+		gatewayClass:   "nginx",
+		controllerName: testControllerName,
+		scenario:       Scenario{Name: "test", Gateways: 2, RoutesPerGateway: 3, EndpointsPerRoute: 2},
+		timeout:        10 * time.Second,
+		pollInterval:   10 * time.Millisecond,
+	})
+
+	profile, err := r.run(t.Context())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(profile.Scenario.Name).To(Equal("test"))
+	g.Expect(profile.Results.GatewayProgrammedLatency.Count).To(Equal(2))
+	g.Expect(profile.Results.RouteAcceptedLatency.Count).To(Equal(6))
+	g.Expect(profile.Results.ConfigApplies).To(Equal(int64(3)))
+	g.Expect(profile.Results.EventBatches).To(Equal(int64(4)))
+	g.Expect(profile.Results.PeakMemoryBytes).To(Equal(int64(80 << 20)))
+
+	// the namespace is deleted after the run
+	var ns core.Namespace
+	err = k8sClient.Get(t.Context(), client.ObjectKey{Name: "scale"}, &ns)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRunTimeout(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	r := newRunner(runnerConfig{
+		client:         createFakeClient(t),
+		scraper:        &fakeScraper{samples: []metricsSample{{}}},
+		out:            io.Discard,
+		namespace:      "scale",
+		gatewayClass:   "nginx",
+		controllerName: testControllerName,
+		scenario:       Scenario{Name: "test", Gateways: 1, RoutesPerGateway: 1, EndpointsPerRoute: 1},
+		timeout:        50 * time.Millisecond,
+		pollInterval:   10 * time.Millisecond,
+	})
+
+	_, err := r.run(t.Context())
+	g.Expect(err).To(MatchError(ContainSubstring("0/1 Gateways and 0/1 HTTPRoutes became ready")))
+}
+
+func TestRunScrapeError(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	scrapeErr := errors.New("metrics unavailable")
+
+	r := newRunner(runnerConfig{
+		client: createFakeClient(t),
+		scraper: &fakeScraper{
+			onScrape: func(context.Context) error { return scrapeErr },
+		},
+		out:            io.Discard,
+		namespace:      "scale",
+		gatewayClass:   "nginx",
+		controllerName: testControllerName,
+		scenario:       Scenario{Name: "test", Gateways: 1, RoutesPerGateway: 1, EndpointsPerRoute: 1},
+		timeout:        time.Second,
+		pollInterval:   10 * time.Millisecond,
+	})
+
+	_, err := r.run(t.Context())
+	g.Expect(err).To(MatchError(scrapeErr))
+}
+
+func TestIsRouteAccepted(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Status: gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{
+					{
+						ControllerName: "example.com/other-controller",
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(gatewayv1.RouteConditionAccepted),
+								Status:             metav1.ConditionTrue,
+								ObservedGeneration: 2,
+							},
+						},
+					},
+					{
+						ControllerName: testControllerName,
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(gatewayv1.RouteConditionAccepted),
+								Status:             metav1.ConditionTrue,
+								ObservedGeneration: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// accepted by another controller and for an older generation by NGF
+	g.Expect(isRouteAccepted(route, testControllerName)).To(BeFalse())
+
+	route.Status.Parents[1].Conditions[0].ObservedGeneration = 2
+	g.Expect(isRouteAccepted(route, testControllerName)).To(BeTrue())
+}
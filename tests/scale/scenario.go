@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scenario describes the resources that the harness generates.
+type Scenario struct {
+	// Name is the name of the scenario.
+	Name string `json:"name"`
+	// Gateways is the number of Gateways. Every Gateway has one HTTP listener.
+	Gateways int `json:"gateways"`
+	// RoutesPerGateway is the number of HTTPRoutes attached to every Gateway.
+	RoutesPerGateway int `json:"routesPerGateway"`
+	// EndpointsPerRoute is the number of endpoints of the backend Service of every HTTPRoute.
+	EndpointsPerRoute int `json:"endpointsPerRoute"`
+}
+
+// scenarios are the published scalability scenarios. The profiles of the releases are recorded for these scenarios,
+// so changing a scenario invalidates the recorded profiles of it.
+var scenarios = map[string]Scenario{
+	"small": {
+		Name:              "small",
+		Gateways:          1,
+		RoutesPerGateway:  10,
+		EndpointsPerRoute: 2,
+	},
+	"medium": {
+		Name:              "medium",
+		Gateways:          5,
+		RoutesPerGateway:  100,
+		EndpointsPerRoute: 10,
+	},
+	"large": {
+		Name:              "large",
+		Gateways:          10,
+		RoutesPerGateway:  500,
+		EndpointsPerRoute: 20,
+	},
+}
+
+func getScenario(name string) (Scenario, error) {
+	s, ok := scenarios[name]
+	if !ok {
+		names := make([]string, 0, len(scenarios))
+		for n := range scenarios {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+
+		return Scenario{}, fmt.Errorf("unknown scenario %q, must be one of: %s", name, strings.Join(names, ", "))
+	}
+
+	return s, nil
+}
+
+func (s Scenario) validate() error {
+	var errs []error
+
+	if s.Name == "" {
+		errs = append(errs, errors.New("name must be set"))
+	}
+	if s.Gateways < 1 {
+		errs = append(errs, fmt.Errorf("gateways must be at least 1, got %d", s.Gateways))
+	}
+	if s.RoutesPerGateway < 1 {
+		errs = append(errs, fmt.Errorf("routes per Gateway must be at least 1, got %d", s.RoutesPerGateway))
+	}
+	if s.EndpointsPerRoute < 1 {
+		errs = append(errs, fmt.Errorf("endpoints per route must be at least 1, got %d", s.EndpointsPerRoute))
+	}
+
+	// The addresses of the endpoints are allocated from 10.0.0.0/8.
+	if total := s.Gateways * s.RoutesPerGateway * s.EndpointsPerRoute; total > maxEndpoints {
+		errs = append(errs, fmt.Errorf("total number of endpoints must be at most %d, got %d", maxEndpoints, total))
+	}
+
+	return errors.Join(errs...)
+}
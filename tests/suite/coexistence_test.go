@@ -0,0 +1,380 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nginx/nginx-gateway-fabric/v2/internal/framework/helpers"
+	"github.com/nginx/nginx-gateway-fabric/v2/tests/framework"
+)
+
+// The coexistence tests verify that NGF can run alongside other Gateway API implementations in the same cluster.
+// If the coexisting-gateway-class flag is set, the tests use the GatewayClass of the other implementation
+// (for example, Istio or Envoy Gateway). Otherwise, the tests create a GatewayClass of a controller that
+// doesn't exist and write the status of that controller themselves.
+var _ = Describe("Coexistence with other Gateway controllers", Ordered, Label("functional", "coexistence"), func() {
+	const (
+		simulatedGatewayClassName = "coexistence-simulated"
+		simulatedControllerName   = "example.com/coexisting-gateway-controller"
+	)
+
+	var (
+		files = []string{
+			"coexistence/cafe.yaml",
+			"coexistence/gateway.yaml",
+		}
+		routeFiles = []string{
+			"coexistence/shared-route.yaml",
+		}
+
+		namespace = "coexistence"
+
+		routeNsName          = types.NamespacedName{Name: "shared-route", Namespace: namespace}
+		nginxGatewayName     = "gateway"
+		coexistingGatewayKey = types.NamespacedName{Name: "coexisting-gateway", Namespace: namespace}
+
+		otherGatewayClass string
+		simulated         bool
+	)
+
+	BeforeAll(func() {
+		ns := &core.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		}
+
+		Expect(resourceManager.Apply([]client.Object{ns})).To(Succeed())
+		Expect(resourceManager.ApplyFromFiles(files, namespace)).To(Succeed())
+		Expect(resourceManager.WaitForAppsToBeReady(namespace)).To(Succeed())
+
+		otherGatewayClass = *coexistingGatewayClass
+		if otherGatewayClass == "" {
+			simulated = true
+			otherGatewayClass = simulatedGatewayClassName
+
+			gc := &v1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: simulatedGatewayClassName,
+				},
+				Spec: v1.GatewayClassSpec{
+					ControllerName: simulatedControllerName,
+				},
+			}
+			Expect(resourceManager.Apply([]client.Object{gc})).To(Succeed())
+		}
+
+		coexistingGateway := &v1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      coexistingGatewayKey.Name,
+				Namespace: namespace,
+			},
+			Spec: v1.GatewaySpec{
+				GatewayClassName: v1.ObjectName(otherGatewayClass),
+				Listeners: []v1.Listener{
+					{
+						Name:     "http",
+						Port:     80,
+						Protocol: v1.HTTPProtocolType,
+					},
+				},
+			},
+		}
+		Expect(resourceManager.Apply([]client.Object{coexistingGateway})).To(Succeed())
+
+		// The route is attached to both Gateways, so we can't wait for all of its parents to be ready.
+		Expect(resourceManager.ApplyFromFiles(routeFiles, namespace)).To(Succeed())
+
+		nginxPodNames, err := resourceManager.GetReadyNginxPodNames(
+			namespace,
+			timeoutConfig.GetStatusTimeout,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nginxPodNames).To(HaveLen(1))
+
+		setUpPortForward(nginxPodNames[0], namespace)
+	})
+
+	AfterAll(func() {
+		framework.AddNginxLogsAndEventsToReport(resourceManager, namespace)
+		cleanUpPortForward()
+
+		Expect(resourceManager.DeleteNamespace(namespace)).To(Succeed())
+
+		if simulated {
+			gc := &v1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: simulatedGatewayClassName,
+				},
+			}
+			Expect(resourceManager.DeleteResources([]client.Object{gc})).To(Succeed())
+		}
+	})
+
+	It("writes the route status only for the NGINX Gateway", func() {
+		Eventually(checkSharedRouteStatus).
+			WithArguments(routeNsName, nginxGatewayName, coexistingGatewayKey.Name).
+			WithTimeout(timeoutConfig.GetStatusTimeout).
+			WithPolling(500 * time.Millisecond).
+			Should(Succeed())
+	})
+
+	It("doesn't provision NGINX for the Gateway of the other controller", func() {
+		Consistently(func() error {
+			nginxPodNames, err := resourceManager.GetReadyNginxPodNames(
+				namespace,
+				timeoutConfig.GetStatusTimeout,
+			)
+			if err != nil {
+				return err
+			}
+
+			if len(nginxPodNames) != 1 {
+				return fmt.Errorf("expected 1 NGINX Pod, got %d", len(nginxPodNames))
+			}
+
+			return nil
+		}).
+			WithTimeout(5 * time.Second).
+			WithPolling(time.Second).
+			Should(Succeed())
+
+		if !simulated {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutConfig.GetTimeout)
+		defer cancel()
+
+		var gw v1.Gateway
+		Expect(resourceManager.Get(ctx, coexistingGatewayKey, &gw)).To(Succeed())
+		Expect(gw.Status.Listeners).To(BeEmpty())
+		Expect(gw.Status.Addresses).To(BeEmpty())
+	})
+
+	It("keeps the route status written by the other controller", func() {
+		if simulated {
+			Eventually(setSimulatedParentStatus).
+				WithArguments(routeNsName, coexistingGatewayKey.Name, simulatedControllerName).
+				WithTimeout(timeoutConfig.GetStatusTimeout).
+				WithPolling(500 * time.Millisecond).
+				Should(Succeed())
+		}
+
+		Eventually(checkOtherControllerParentStatus).
+			WithArguments(routeNsName, coexistingGatewayKey.Name).
+			WithTimeout(timeoutConfig.GetStatusTimeout).
+			WithPolling(500 * time.Millisecond).
+			Should(Succeed())
+
+		// Change the route, so that NGF writes its status again.
+		var generation int64
+		Eventually(func() error {
+			var err error
+			generation, err = addPathToSharedRoute(routeNsName, "/coffee-beans")
+			return err
+		}).
+			WithTimeout(timeoutConfig.GetStatusTimeout).
+			WithPolling(500 * time.Millisecond).
+			Should(Succeed())
+
+		Eventually(checkNGFParentObservedGeneration).
+			WithArguments(routeNsName, nginxGatewayName, generation).
+			WithTimeout(timeoutConfig.GetStatusTimeout).
+			WithPolling(500 * time.Millisecond).
+			Should(Succeed())
+
+		Expect(checkOtherControllerParentStatus(routeNsName, coexistingGatewayKey.Name)).To(Succeed())
+	})
+
+	It("routes traffic through the NGINX Gateway", func() {
+		port := 80
+		if portFwdPort != 0 {
+			port = portFwdPort
+		}
+		baseURL := fmt.Sprintf("http://cafe.example.com:%d%s", port, "/coffee")
+
+		Eventually(
+			func() error {
+				return expectRequestToSucceed(baseURL, address, "URI: /coffee")
+			}).
+			WithTimeout(timeoutConfig.RequestTimeout).
+			WithPolling(500 * time.Millisecond).
+			Should(Succeed())
+	})
+})
+
+func getSharedRoute(routeNsName types.NamespacedName) (v1.HTTPRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutConfig.GetTimeout)
+	defer cancel()
+
+	var route v1.HTTPRoute
+	err := resourceManager.Get(ctx, routeNsName, &route)
+
+	return route, err
+}
+
+func checkSharedRouteStatus(routeNsName types.NamespacedName, nginxGatewayName, otherGatewayName string) error {
+	route, err := getSharedRoute(routeNsName)
+	if err != nil {
+		return err
+	}
+
+	var ngfParents int
+	for _, parent := range route.Status.Parents {
+		if parent.ControllerName != ngfControllerName {
+			continue
+		}
+
+		ngfParents++
+
+		if string(parent.ParentRef.Name) == otherGatewayName {
+			return fmt.Errorf("NGF wrote the route status for Gateway %q of other controller", otherGatewayName)
+		}
+
+		if string(parent.ParentRef.Name) != nginxGatewayName {
+			return fmt.Errorf("unexpected NGF parent status for Gateway %q", parent.ParentRef.Name)
+		}
+
+		if !hasCondition(parent.Conditions, string(v1.RouteConditionAccepted), metav1.ConditionTrue) {
+			return fmt.Errorf("expected route to be accepted by Gateway %q", nginxGatewayName)
+		}
+	}
+
+	if ngfParents != 1 {
+		return fmt.Errorf("expected 1 NGF parent status, got %d", ngfParents)
+	}
+
+	return nil
+}
+
+// setSimulatedParentStatus writes the status of the simulated controller for the other Gateway,
+// the way the controller of that Gateway would do it.
+func setSimulatedParentStatus(routeNsName types.NamespacedName, otherGatewayName, controllerName string) error {
+	route, err := getSharedRoute(routeNsName)
+	if err != nil {
+		return err
+	}
+
+	for _, parent := range route.Status.Parents {
+		if string(parent.ControllerName) == controllerName {
+			return nil
+		}
+	}
+
+	route.Status.Parents = append(route.Status.Parents, v1.RouteParentStatus{
+		ParentRef: v1.ParentReference{
+			Name: v1.ObjectName(otherGatewayName),
+		},
+		ControllerName: v1.GatewayController(controllerName),
+		Conditions: []metav1.Condition{
+			{
+				Type:               string(v1.RouteConditionAccepted),
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: route.Generation,
+				LastTransitionTime: metav1.Now(),
+				Reason:             string(v1.RouteReasonAccepted),
+				Message:            "Accepted by the simulated controller",
+			},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutConfig.UpdateTimeout)
+	defer cancel()
+
+	return resourceManager.K8sClient.Status().Update(ctx, &route)
+}
+
+func checkOtherControllerParentStatus(routeNsName types.NamespacedName, otherGatewayName string) error {
+	route, err := getSharedRoute(routeNsName)
+	if err != nil {
+		return err
+	}
+
+	for _, parent := range route.Status.Parents {
+		if parent.ControllerName != ngfControllerName && string(parent.ParentRef.Name) == otherGatewayName {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("route doesn't have the status of the controller of Gateway %q", otherGatewayName)
+}
+
+func addPathToSharedRoute(routeNsName types.NamespacedName, path string) (int64, error) {
+	route, err := getSharedRoute(routeNsName)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(route.Spec.Rules) == 0 {
+		return 0, errors.New("route has no rules")
+	}
+
+	route.Spec.Rules[0].Matches = append(route.Spec.Rules[0].Matches, v1.HTTPRouteMatch{
+		Path: &v1.HTTPPathMatch{
+			Type:  helpers.GetPointer(v1.PathMatchPathPrefix),
+			Value: helpers.GetPointer(path),
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutConfig.UpdateTimeout)
+	defer cancel()
+
+	if err := resourceManager.Update(ctx, &route, nil); err != nil {
+		return 0, err
+	}
+
+	return route.Generation, nil
+}
+
+func checkNGFParentObservedGeneration(
+	routeNsName types.NamespacedName,
+	nginxGatewayName string,
+	generation int64,
+) error {
+	route, err := getSharedRoute(routeNsName)
+	if err != nil {
+		return err
+	}
+
+	for _, parent := range route.Status.Parents {
+		if parent.ControllerName != ngfControllerName || string(parent.ParentRef.Name) != nginxGatewayName {
+			continue
+		}
+
+		for _, cond := range parent.Conditions {
+			if cond.ObservedGeneration < generation {
+				return fmt.Errorf(
+					"expected observed generation %d, got %d for condition %s",
+					generation,
+					cond.ObservedGeneration,
+					cond.Type,
+				)
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("route doesn't have the NGF status for Gateway %q", nginxGatewayName)
+}
+
+func hasCondition(conds []metav1.Condition, condType string, status metav1.ConditionStatus) bool {
+	for _, cond := range conds {
+		if cond.Type == condType && cond.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
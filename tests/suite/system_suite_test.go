@@ -65,6 +65,12 @@ var (
 	plusUsageEndpoint        = flag.String("plus-usage-endpoint", "", "Endpoint for reporting NGINX Plus usage")
 	clusterName              = flag.String("cluster-name", "kind", "Cluster name")
 	gkeProject               = flag.String("gke-project", "", "GKE Project name")
+	coexistingGatewayClass   = flag.String(
+		"coexisting-gateway-class",
+		"",
+		"GatewayClass of another Gateway controller (for example, Istio or Envoy Gateway) running in the cluster. "+
+			"If not set, the coexistence tests simulate the other controller",
+	)
 )
 
 var (